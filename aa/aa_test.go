@@ -0,0 +1,228 @@
+package aa
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+)
+
+var (
+	entryPoint = EntryPointV07
+	account    = stygos.Address{0xac}
+	bundler    = stygos.Address{0xb7}
+)
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{SelValidateUserOp, "validateUserOp((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes),bytes32,uint256)"},
+		{selGetNonce, "getNonce(address,uint192)"},
+		{selBalanceOf, "balanceOf(address)"},
+		{selDepositTo, "depositTo(address)"},
+		{selWithdrawTo, "withdrawTo(address,uint256)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func testOp() *UserOperation {
+	return &UserOperation{
+		Sender:             account,
+		Nonce:              stygos.NewU256(7).Lsh(64).Add(stygos.NewU256(3)),
+		InitCode:           nil,
+		CallData:           []byte{0xde, 0xad, 0xbe, 0xef, 0x01},
+		AccountGasLimits:   PackGas(100_000, 200_000),
+		PreVerificationGas: stygos.NewU256(21_000),
+		GasFees:            PackGas(1, 10),
+		PaymasterAndData:   make([]byte, 40),
+		Signature:          bytes.Repeat([]byte{0x5a}, 65),
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	op := testOp()
+	hash := stygos.Word{0x4a}
+
+	data := EncodeValidateUserOp(op, hash, stygos.NewU256(99))
+	got, gotHash, missing, err := DecodeValidateUserOp(data[4:])
+	if err != nil {
+		t.Fatalf("DecodeValidateUserOp failed: %v", err)
+	}
+	if gotHash != hash || missing.Uint64() != 99 {
+		t.Errorf("DecodeValidateUserOp failed. Expected hash %x and 99 missing, got %x, %d", hash, gotHash, missing.Uint64())
+	}
+	if got.Sender != op.Sender || got.Nonce != op.Nonce || got.AccountGasLimits != op.AccountGasLimits ||
+		got.PreVerificationGas != op.PreVerificationGas || got.GasFees != op.GasFees ||
+		len(got.InitCode) != 0 || !bytes.Equal(got.CallData, op.CallData) ||
+		!bytes.Equal(got.PaymasterAndData, op.PaymasterAndData) || !bytes.Equal(got.Signature, op.Signature) {
+		t.Errorf("DecodeValidateUserOp failed. Expected %+v, got %+v", op, got)
+	}
+	if _, _, _, err := DecodeValidateUserOp(data[4 : len(data)-32]); err != ErrMalformedUserOp {
+		t.Errorf("DecodeValidateUserOp failed. Expected ErrMalformedUserOp for truncated data, got %v", err)
+	}
+
+	if op.VerificationGasLimit().Uint64() != 100_000 || op.CallGasLimit().Uint64() != 200_000 || op.MaxFeePerGas().Uint64() != 10 {
+		t.Errorf("gas fields failed. Expected 100000, 200000, 10, got %d, %d, %d",
+			op.VerificationGasLimit().Uint64(), op.CallGasLimit().Uint64(), op.MaxFeePerGas().Uint64())
+	}
+	if op.NonceKey().Uint64() != 7 || op.NonceSequence() != 3 {
+		t.Errorf("nonce fields failed. Expected key 7 and sequence 3, got %d, %d", op.NonceKey().Uint64(), op.NonceSequence())
+	}
+
+	// The signature is not part of the hash; the chain and EntryPoint are
+	h := op.Hash(entryPoint, 1)
+	op.Signature = nil
+	if op.Hash(entryPoint, 1) != h {
+		t.Error("Hash failed. Expected the signature not to affect the hash")
+	}
+	if op.Hash(entryPoint, 2) == h || op.Hash(bundler, 1) == h {
+		t.Error("Hash failed. Expected the chain and EntryPoint to affect the hash")
+	}
+}
+
+func TestUserOpHash(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	// A plain execute(0x22..22, 1 ether, "") with no initCode or paymaster,
+	// hashed with getUserOpHash's encoding on Sepolia outside this package
+	callData, _ := hex.DecodeString("b61d27f6" +
+		"0000000000000000000000002222222222222222222222222222222222222222" +
+		"0000000000000000000000000000000000000000000000000de0b6b3a7640000" +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"0000000000000000000000000000000000000000000000000000000000000000")
+	var sender stygos.Address
+	copy(sender[:], bytes.Repeat([]byte{0x11}, 20))
+	op := &UserOperation{
+		Sender:             sender,
+		CallData:           callData,
+		AccountGasLimits:   PackGas(100_000, 200_000),
+		PreVerificationGas: stygos.NewU256(50_000),
+		GasFees:            PackGas(1_000_000_000, 2_000_000_000),
+	}
+	want := "a0351969783ccec3deb8449474a4881341f9c854f4422a41d361d82ef3400b78"
+	if h := op.Hash(EntryPointV07, 11155111); hex.EncodeToString(h[:]) != want {
+		t.Errorf("Hash failed. Expected %s, got %x", want, h)
+	}
+}
+
+func TestValidationData(t *testing.T) {
+	v := PackValidationData(true, 2_000, 1_000)
+	authorizer, until, after := ParseValidationData(v)
+	if authorizer != (stygos.Address{19: 1}) || until != 2_000 || after != 1_000 {
+		t.Errorf("ParseValidationData failed. Expected (1, 2000, 1000), got (%x, %d, %d)", authorizer, until, after)
+	}
+	if !PackValidationData(false, 0, 0).IsZero() {
+		t.Error("PackValidationData failed. Expected zero for success without time range")
+	}
+}
+
+func TestValidateApprovals(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	ecdsa.InstallMockEcrecover(mock)
+	hash := stygos.Keccak256([]byte("userOp"))
+
+	var list []ecdsa.Approval
+	for _, k := range []int64{5, 6} {
+		sig, err := ecdsa.Sign(big.NewInt(k), hash)
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		list = append(list, ecdsa.Approval{Signer: ecdsa.AddressOf(big.NewInt(k)), Signature: sig})
+	}
+	if bytes.Compare(list[0].Signer[:], list[1].Signer[:]) > 0 {
+		list[0], list[1] = list[1], list[0]
+	}
+	signature := ecdsa.EncodeApprovals(list)
+
+	if !ValidateApprovals(hash, signature, 2, nil).IsZero() {
+		t.Error("ValidateApprovals failed. Expected success for 2 of 2")
+	}
+	for name, v := range map[string]stygos.U256{
+		"threshold": ValidateApprovals(hash, signature, 3, nil),
+		"truncated": ValidateApprovals(hash, signature[1:], 1, nil),
+		"other op":  ValidateApprovals(stygos.Word{1}, signature, 1, nil),
+		"empty":     ValidateApprovals(hash, nil, 0, nil),
+	} {
+		if authorizer, _, _ := ParseValidationData(v); authorizer != (stygos.Address{19: 1}) {
+			t.Errorf("ValidateApprovals %s failed. Expected SIG_VALIDATION_FAILED, got %x", name, authorizer)
+		}
+	}
+}
+
+func TestMockEntryPoint(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = bundler
+	mock.Time = 1_500
+	stygos.UseRuntime(mock)
+	ep := InstallMockEntryPoint(mock, entryPoint)
+	mock.SetBalance(account, big.NewInt(1e18))
+
+	// An account that accepts operations whose signature is "ok" and pays
+	// its prefund
+	var executed [][]byte
+	client := NewEntryPoint(entryPoint)
+	mock.Deploy(account, func(input []byte) ([]byte, error) {
+		if !client.IsCaller() {
+			return nil, stygos.ErrRevert
+		}
+		if len(input) < 4 || !bytes.Equal(input[:4], SelValidateUserOp[:]) {
+			executed = append(executed, input)
+			return nil, nil
+		}
+		op, _, missing, err := DecodeValidateUserOp(input[4:])
+		if err != nil {
+			return nil, err
+		}
+		client.PayPrefund(missing)
+		v := PackValidationData(string(op.Signature) != "ok", 2_000, 0)
+		return concat(v.Word()), nil
+	})
+
+	op := testOp()
+	op.Nonce = stygos.U256{}
+	op.Signature = []byte("ok")
+	if err := ep.HandleOp(op); err != nil {
+		t.Fatalf("HandleOp failed: %v", err)
+	}
+	if len(executed) != 1 || !bytes.Equal(executed[0], op.CallData) {
+		t.Errorf("HandleOp failed. Expected the calldata executed once, got %x", executed)
+	}
+	// (100000 + 200000 + 21000) * 10
+	if got := mock.BalanceOf(entryPoint); got.Int64() != 3_210_000 {
+		t.Errorf("HandleOp failed. Expected the prefund paid to the EntryPoint, got %v", got)
+	}
+	if err := ep.HandleOp(op); err != ErrInvalidNonce {
+		t.Errorf("HandleOp failed. Expected ErrInvalidNonce, got %v", err)
+	}
+
+	op.Nonce = stygos.NewU256(1)
+	op.Signature = []byte("bad")
+	if err := ep.HandleOp(op); err != ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected ErrSignatureFailed, got %v", err)
+	}
+	op.Signature = []byte("ok")
+	mock.Time = 2_001
+	if err := ep.HandleOp(op); err != ErrExpiredOrNotDue {
+		t.Errorf("HandleOp failed. Expected ErrExpiredOrNotDue, got %v", err)
+	}
+
+	if n, err := client.GetNonce(account, stygos.U256{}); err != nil || n.Uint64() != 1 {
+		t.Errorf("GetNonce failed. Expected 1, got %d, %v", n.Uint64(), err)
+	}
+	if d, err := client.BalanceOf(account); err != nil || !d.IsZero() {
+		t.Errorf("BalanceOf failed. Expected the deposit used up, got %d, %v", d.Uint64(), err)
+	}
+}
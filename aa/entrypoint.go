@@ -0,0 +1,77 @@
+package aa
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// EntryPoint selectors
+var (
+	selGetNonce   = stygos.Selector{0x35, 0x56, 0x7e, 0x1a} // getNonce(address,uint192)
+	selBalanceOf  = stygos.Selector{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	selDepositTo  = stygos.Selector{0xb7, 0x60, 0xfa, 0xf9} // depositTo(address)
+	selWithdrawTo = stygos.Selector{0x20, 0x5c, 0x28, 0x78} // withdrawTo(address,uint256)
+)
+
+// EntryPoint is a client for an EntryPoint contract.
+type EntryPoint struct {
+	addr stygos.Address
+}
+
+// NewEntryPoint returns a client for the EntryPoint at addr.
+func NewEntryPoint(addr stygos.Address) EntryPoint {
+	return EntryPoint{addr: addr}
+}
+
+// Address returns the EntryPoint address.
+func (ep EntryPoint) Address() stygos.Address {
+	return ep.addr
+}
+
+// IsCaller reports whether the EntryPoint is msg.sender.
+func (ep EntryPoint) IsCaller() bool {
+	return stygos.GetMsgSender() == ep.addr
+}
+
+// GetNonce returns the next nonce of sender for key; the sequence is in
+// the low 64 bits.
+func (ep EntryPoint) GetNonce(sender stygos.Address, key stygos.U256) (stygos.U256, error) {
+	return ep.staticU256(selGetNonce, stygos.PadAddress(sender), key.Word())
+}
+
+// BalanceOf returns the deposit of account.
+func (ep EntryPoint) BalanceOf(account stygos.Address) (stygos.U256, error) {
+	return ep.staticU256(selBalanceOf, stygos.PadAddress(account))
+}
+
+// DepositTo adds amount of the executing contract's ETH to the deposit of
+// account.
+func (ep EntryPoint) DepositTo(account stygos.Address, amount stygos.U256) error {
+	_, err := stygos.Call(ep.addr, amount.Word(), append(selDepositTo[:], concat(stygos.PadAddress(account))...))
+	return err
+}
+
+// WithdrawTo withdraws amount of the executing contract's deposit to to.
+func (ep EntryPoint) WithdrawTo(to stygos.Address, amount stygos.U256) error {
+	_, err := stygos.Call(ep.addr, stygos.Word{}, append(selWithdrawTo[:], concat(stygos.PadAddress(to), amount.Word())...))
+	return err
+}
+
+// PayPrefund sends the EntryPoint the funds validateUserOp was told are
+// missing. Like the reference accounts it ignores failure: the EntryPoint
+// rejects the operation if the prefund is not covered.
+func (ep EntryPoint) PayPrefund(missingAccountFunds stygos.U256) {
+	if !missingAccountFunds.IsZero() {
+		stygos.Call(ep.addr, missingAccountFunds.Word(), nil)
+	}
+}
+
+func (ep EntryPoint) staticU256(sel stygos.Selector, args ...stygos.Word) (stygos.U256, error) {
+	ret, err := stygos.StaticCall(ep.addr, append(sel[:], concat(args...)...))
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if len(ret) != 32 {
+		return stygos.U256{}, stygos.ErrInvalidInput
+	}
+	return stygos.U256FromWord(wordAt(ret, 0)), nil
+}
@@ -0,0 +1,157 @@
+//go:build !tinygo
+
+package aa
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// MockEntryPoint errors, named after the EntryPoint's AAxx reasons
+var (
+	ErrInvalidNonce       = errors.New("aa: AA25 invalid account nonce")
+	ErrValidationReverted = errors.New("aa: AA23 reverted")
+	ErrSignatureFailed    = errors.New("aa: AA24 signature error")
+	ErrExpiredOrNotDue    = errors.New("aa: AA22 expired or not due")
+	ErrPrefundNotPaid     = errors.New("aa: AA21 didn't pay prefund")
+	ErrExecutionReverted  = errors.New("aa: execution reverted")
+	ErrInsufficientFunds  = errors.New("aa: withdraw amount too large")
+)
+
+// MockEntryPoint is an in-memory EntryPoint deployed on a
+// stygos.MockRuntime. It validates and executes user operations one at a
+// time and charges the full prefund, without gas metering.
+type MockEntryPoint struct {
+	Addr     stygos.Address
+	Deposits map[stygos.Address]stygos.U256
+	Nonces   map[stygos.Address]map[stygos.U256]uint64 // sender -> key -> sequence
+
+	rt *stygos.MockRuntime
+}
+
+// InstallMockEntryPoint deploys a MockEntryPoint at addr on rt and returns
+// it.
+func InstallMockEntryPoint(rt *stygos.MockRuntime, addr stygos.Address) *MockEntryPoint {
+	m := &MockEntryPoint{
+		Addr:     addr,
+		Deposits: make(map[stygos.Address]stygos.U256),
+		Nonces:   make(map[stygos.Address]map[stygos.U256]uint64),
+		rt:       rt,
+	}
+
+	r := stygos.NewRouter()
+	r.HandleSelector(selGetNonce, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) != 64 {
+			return nil, stygos.ErrInvalidInput
+		}
+		key := stygos.U256FromWord(wordAt(args, 32))
+		return concat(m.nonce(stygos.AddressFromWord(wordAt(args, 0)), key).Word()), nil
+	})
+	r.HandleSelector(selBalanceOf, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) != 32 {
+			return nil, stygos.ErrInvalidInput
+		}
+		return concat(m.Deposits[stygos.AddressFromWord(wordAt(args, 0))].Word()), nil
+	})
+	r.HandleSelector(selDepositTo, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) != 32 {
+			return nil, stygos.ErrInvalidInput
+		}
+		m.deposit(stygos.AddressFromWord(wordAt(args, 0)))
+		return nil, nil
+	})
+	r.HandleSelector(selWithdrawTo, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) != 64 {
+			return nil, stygos.ErrInvalidInput
+		}
+		sender, amount := stygos.GetMsgSender(), stygos.U256FromWord(wordAt(args, 32))
+		if m.Deposits[sender].Lt(amount) {
+			return nil, ErrInsufficientFunds
+		}
+		m.Deposits[sender] = m.Deposits[sender].Sub(amount)
+		return nil, stygos.Transfer(stygos.AddressFromWord(wordAt(args, 0)), amount)
+	})
+	// Plain transfers deposit for the sender
+	r.Fallback(func(ctx *stygos.Ctx, data []byte) ([]byte, error) {
+		if len(data) != 0 {
+			return nil, stygos.ErrUnknownSelector
+		}
+		m.deposit(stygos.GetMsgSender())
+		return nil, nil
+	})
+	rt.Deploy(addr, r.Dispatch)
+	return m
+}
+
+// HandleOp validates op through the account's validateUserOp, consumes its
+// nonce, charges the prefund from the account's deposit and executes its
+// calldata on the account, all with the EntryPoint as msg.sender. Unlike
+// handleOps it reports execution failure as ErrExecutionReverted.
+//
+// When validation fails, the deposit and the ETH balances of the account
+// and the EntryPoint are restored as if the bundle reverted; the account's
+// storage is not.
+func (m *MockEntryPoint) HandleOp(op *UserOperation) (err error) {
+	rt := m.rt
+	outer, outerStorage := rt.Contract, rt.Storage
+	rt.Storage = rt.StorageOf(m.Addr)
+	rt.Contract = m.Addr
+	deposit := m.Deposits[op.Sender]
+	accountBalance, epBalance := rt.BalanceOf(op.Sender), rt.BalanceOf(m.Addr)
+	defer func() {
+		rt.Contract, rt.Storage = outer, outerStorage
+		if err != nil && err != ErrExecutionReverted {
+			m.Deposits[op.Sender] = deposit
+			rt.SetBalance(op.Sender, accountBalance)
+			rt.SetBalance(m.Addr, epBalance)
+		}
+	}()
+
+	key, seq := op.NonceKey(), op.NonceSequence()
+	if seq != stygos.Uint64FromWord(m.nonce(op.Sender, key).Word()) {
+		return ErrInvalidNonce
+	}
+
+	required := op.VerificationGasLimit().Add(op.CallGasLimit()).Add(op.PreVerificationGas).Mul(op.MaxFeePerGas())
+	missing := stygos.U256{}
+	if deposit := m.Deposits[op.Sender]; deposit.Lt(required) {
+		missing = required.Sub(deposit)
+	}
+
+	ret, err := stygos.Call(op.Sender, stygos.Word{}, EncodeValidateUserOp(op, op.Hash(m.Addr, rt.Chain), missing))
+	if err != nil || len(ret) != 32 {
+		return ErrValidationReverted
+	}
+	if m.Deposits[op.Sender].Lt(required) {
+		return ErrPrefundNotPaid
+	}
+	authorizer, validUntil, validAfter := ParseValidationData(stygos.U256FromWord(wordAt(ret, 0)))
+	if authorizer != (stygos.Address{}) {
+		return ErrSignatureFailed
+	}
+	if (validUntil != 0 && rt.Time > validUntil) || rt.Time < validAfter {
+		return ErrExpiredOrNotDue
+	}
+
+	m.Nonces[op.Sender][key] = seq + 1
+	m.Deposits[op.Sender] = m.Deposits[op.Sender].Sub(required)
+
+	if len(op.CallData) > 0 {
+		if _, err := stygos.Call(op.Sender, stygos.Word{}, op.CallData); err != nil {
+			return ErrExecutionReverted
+		}
+	}
+	return nil
+}
+
+func (m *MockEntryPoint) nonce(sender stygos.Address, key stygos.U256) stygos.U256 {
+	if m.Nonces[sender] == nil {
+		m.Nonces[sender] = make(map[stygos.U256]uint64)
+	}
+	return key.Lsh(64).Add(stygos.NewU256(m.Nonces[sender][key]))
+}
+
+func (m *MockEntryPoint) deposit(account stygos.Address) {
+	m.Deposits[account] = m.Deposits[account].Add(stygos.U256FromBig(stygos.GetMsgValue()))
+}
@@ -0,0 +1,253 @@
+// Package aa supports ERC-4337 account abstraction against EntryPoint
+// v0.7: the PackedUserOperation type with its ABI encoding and hash,
+// validation data packing, and a client for the EntryPoint's deposit and
+// nonce functions.
+//
+// A smart account routes validateUserOp to DecodeValidateUserOp, checks
+// the signature over the user operation hash, pays the missing prefund
+// with EntryPoint.PayPrefund and returns PackValidationData. In tests,
+// InstallMockEntryPoint deploys an EntryPoint that runs user operations
+// against accounts on a stygos.MockRuntime.
+package aa
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+)
+
+// EntryPointV07 is the canonical EntryPoint v0.7 deployment.
+var EntryPointV07 = stygos.Address{
+	0x00, 0x00, 0x00, 0x00, 0x71, 0x72, 0x7D, 0xe2, 0x2E, 0x5E,
+	0x9d, 0x8B, 0xAf, 0x0e, 0xdA, 0xc6, 0xf3, 0x7d, 0xa0, 0x32,
+}
+
+// SelValidateUserOp is the selector of the account's validateUserOp.
+var SelValidateUserOp = stygos.Selector{0x19, 0x82, 0x2f, 0x7c} // validateUserOp((address,uint256,bytes,bytes,bytes32,uint256,bytes32,bytes,bytes),bytes32,uint256)
+
+// ErrMalformedUserOp is returned for calldata that is not a valid
+// validateUserOp encoding.
+var ErrMalformedUserOp = errors.New("aa: malformed user operation")
+
+// UserOperation is an EntryPoint v0.7 PackedUserOperation.
+type UserOperation struct {
+	Sender             stygos.Address
+	Nonce              stygos.U256
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   stygos.Word // verificationGasLimit (high 128 bits) and callGasLimit (low)
+	PreVerificationGas stygos.U256
+	GasFees            stygos.Word // maxPriorityFeePerGas (high 128 bits) and maxFeePerGas (low)
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// PackGas packs two 128-bit values into a word, high first, as
+// AccountGasLimits and GasFees are packed.
+func PackGas(high, low uint64) stygos.Word {
+	var w stygos.Word
+	h, l := stygos.WordFromUint64(high), stygos.WordFromUint64(low)
+	copy(w[:16], h[16:])
+	copy(w[16:], l[16:])
+	return w
+}
+
+// VerificationGasLimit returns the gas limit of validateUserOp.
+func (op *UserOperation) VerificationGasLimit() stygos.U256 {
+	return stygos.U256FromWord(op.AccountGasLimits).Rsh(128)
+}
+
+// CallGasLimit returns the gas limit of the execution call.
+func (op *UserOperation) CallGasLimit() stygos.U256 {
+	return lowHalf(op.AccountGasLimits)
+}
+
+// MaxFeePerGas returns the maximum fee per gas the account pays.
+func (op *UserOperation) MaxFeePerGas() stygos.U256 {
+	return lowHalf(op.GasFees)
+}
+
+// NonceKey returns the 192-bit key of the nonce, and NonceSequence its
+// 64-bit sequence number within that key.
+func (op *UserOperation) NonceKey() stygos.U256 {
+	return op.Nonce.Rsh(64)
+}
+
+// NonceSequence returns the sequence number of the nonce within its key.
+func (op *UserOperation) NonceSequence() uint64 {
+	return stygos.Uint64FromWord(op.Nonce.Word())
+}
+
+// Hash returns the user operation hash the EntryPoint passes to
+// validateUserOp and accounts verify signatures over:
+// keccak256(abi.encode(keccak256(pack(op)), entryPoint, chainId)).
+func (op *UserOperation) Hash(entryPoint stygos.Address, chainID uint64) stygos.Word {
+	packed := concat(
+		stygos.PadAddress(op.Sender),
+		op.Nonce.Word(),
+		stygos.Keccak256(op.InitCode),
+		stygos.Keccak256(op.CallData),
+		op.AccountGasLimits,
+		op.PreVerificationGas.Word(),
+		op.GasFees,
+		stygos.Keccak256(op.PaymasterAndData),
+	)
+	return stygos.Keccak256(concat(
+		stygos.Keccak256(packed),
+		stygos.PadAddress(entryPoint),
+		stygos.WordFromUint64(chainID),
+	))
+}
+
+// PackValidationData returns the validateUserOp result: 1 in the low 160
+// bits if the signature failed, then validUntil (0 for no expiry) and
+// validAfter as 48-bit timestamps.
+func PackValidationData(sigFailed bool, validUntil, validAfter uint64) stygos.U256 {
+	v := stygos.NewU256(validUntil & (1<<48 - 1)).Lsh(160).
+		Add(stygos.NewU256(validAfter & (1<<48 - 1)).Lsh(208))
+	if sigFailed {
+		v = v.Add(stygos.NewU256(1))
+	}
+	return v
+}
+
+// ParseValidationData splits validation data into the authorizer (zero on
+// success, one on signature failure, otherwise an aggregator), validUntil
+// and validAfter.
+func ParseValidationData(v stygos.U256) (authorizer stygos.Address, validUntil, validAfter uint64) {
+	authorizer = stygos.AddressFromWord(v.Word())
+	validUntil = stygos.Uint64FromWord(v.Rsh(160).Word()) & (1<<48 - 1)
+	validAfter = stygos.Uint64FromWord(v.Rsh(208).Word()) & (1<<48 - 1)
+	return authorizer, validUntil, validAfter
+}
+
+// ValidateApprovals returns the validation data for a signature made of
+// approvals (see ecdsa.DecodeApprovals) by at least threshold distinct
+// signers that isSigner accepts, in ascending order, each over hash.
+func ValidateApprovals(hash stygos.Word, signature []byte, threshold uint64, isSigner func(stygos.Address) bool) stygos.U256 {
+	approvals, err := ecdsa.DecodeApprovals(signature)
+	if err == nil {
+		err = ecdsa.VerifyApprovals(hash, approvals, threshold, isSigner)
+	}
+	return PackValidationData(err != nil, 0, 0)
+}
+
+// EncodeValidateUserOp encodes the calldata of validateUserOp(op,
+// userOpHash, missingAccountFunds), selector included.
+func EncodeValidateUserOp(op *UserOperation, userOpHash stygos.Word, missingAccountFunds stygos.U256) []byte {
+	tails := [][]byte{op.InitCode, op.CallData, op.PaymasterAndData, op.Signature}
+	offsets := make([]stygos.Word, len(tails))
+	next := uint64(9 * 32)
+	for i, t := range tails {
+		offsets[i] = stygos.WordFromUint64(next)
+		next += 32 + padded(len(t))
+	}
+
+	out := append([]byte{}, SelValidateUserOp[:]...)
+	out = append(out, concat(stygos.WordFromUint64(3*32), userOpHash, missingAccountFunds.Word())...)
+	out = append(out, concat(
+		stygos.PadAddress(op.Sender),
+		op.Nonce.Word(),
+		offsets[0],
+		offsets[1],
+		op.AccountGasLimits,
+		op.PreVerificationGas.Word(),
+		op.GasFees,
+		offsets[2],
+		offsets[3],
+	)...)
+	for _, t := range tails {
+		out = appendBytes(out, t)
+	}
+	return out
+}
+
+// DecodeValidateUserOp decodes the arguments of validateUserOp, without
+// the selector.
+func DecodeValidateUserOp(args []byte) (op UserOperation, userOpHash stygos.Word, missingAccountFunds stygos.U256, err error) {
+	if len(args) < 3*32 {
+		return op, userOpHash, missingAccountFunds, ErrMalformedUserOp
+	}
+	start, ok := offsetAt(args, 0)
+	if !ok || uint64(len(args))-start < 9*32 {
+		return op, userOpHash, missingAccountFunds, ErrMalformedUserOp
+	}
+	userOpHash = wordAt(args, 32)
+	missingAccountFunds = stygos.U256FromWord(wordAt(args, 64))
+
+	tuple := args[start:]
+	op.Sender = stygos.AddressFromWord(wordAt(tuple, 0))
+	op.Nonce = stygos.U256FromWord(wordAt(tuple, 32))
+	op.AccountGasLimits = wordAt(tuple, 4*32)
+	op.PreVerificationGas = stygos.U256FromWord(wordAt(tuple, 5*32))
+	op.GasFees = wordAt(tuple, 6*32)
+	for _, f := range []struct {
+		head int
+		dst  *[]byte
+	}{
+		{2, &op.InitCode},
+		{3, &op.CallData},
+		{7, &op.PaymasterAndData},
+		{8, &op.Signature},
+	} {
+		if *f.dst, ok = bytesAt(tuple, f.head); !ok {
+			return op, userOpHash, missingAccountFunds, ErrMalformedUserOp
+		}
+	}
+	return op, userOpHash, missingAccountFunds, nil
+}
+
+func lowHalf(w stygos.Word) stygos.U256 {
+	var low stygos.Word
+	copy(low[16:], w[16:])
+	return stygos.U256FromWord(low)
+}
+
+func concat(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+func wordAt(b []byte, off int) stygos.Word {
+	var w stygos.Word
+	copy(w[:], b[off:off+32])
+	return w
+}
+
+// offsetAt reads head word i of b as an offset into b.
+func offsetAt(b []byte, i int) (uint64, bool) {
+	v := stygos.U256FromWord(wordAt(b, 32*i))
+	if !v.IsUint64() || v.Uint64() > uint64(len(b)) {
+		return 0, false
+	}
+	return v.Uint64(), true
+}
+
+// bytesAt decodes the dynamic bytes whose offset is head word i of b.
+func bytesAt(b []byte, i int) ([]byte, bool) {
+	off, ok := offsetAt(b, i)
+	if !ok || uint64(len(b))-off < 32 {
+		return nil, false
+	}
+	n := stygos.U256FromWord(wordAt(b, int(off)))
+	start := off + 32
+	if !n.IsUint64() || n.Uint64() > uint64(len(b))-start {
+		return nil, false
+	}
+	return b[start : start+n.Uint64()], true
+}
+
+func padded(n int) uint64 {
+	return uint64((n + 31) / 32 * 32)
+}
+
+func appendBytes(out, b []byte) []byte {
+	n := stygos.WordFromUint64(uint64(len(b)))
+	out = append(out, n[:]...)
+	out = append(out, b...)
+	return append(out, make([]byte, int(padded(len(b)))-len(b))...)
+}
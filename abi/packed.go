@@ -0,0 +1,145 @@
+// Package abi implements Solidity ABI encodings beyond the word-by-word
+// calldata handled by stygos.ReturnBuilder.
+package abi
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Packed encoding errors
+var (
+	ErrUnsupportedType = errors.New("abi: type cannot be packed")
+	ErrInvalidSize     = errors.New("abi: integer size must be 8 to 256 bits in steps of 8")
+	ErrValueOverflow   = errors.New("abi: value does not fit its size")
+)
+
+// Sized is an unsigned integer packed into Bits/8 bytes, Solidity's uintN
+// for N other than 8, 16, 32, 64 and 256, which have Go types of their
+// own. Build one with Uint.
+type Sized struct {
+	Value stygos.U256
+	Bits  int
+}
+
+// Uint returns v as a uintN of the given number of bits for EncodePacked.
+func Uint(bits int, v stygos.U256) Sized {
+	return Sized{Value: v, Bits: bits}
+}
+
+// EncodePacked returns the non-standard packed encoding of args, as
+// Solidity's abi.encodePacked: each value takes only as many bytes as its
+// type, without length prefixes or padding, except that array elements are
+// padded to 32 bytes.
+//
+// Arguments map to Solidity types as follows:
+//
+//	stygos.Address                         address, 20 bytes
+//	stygos.Word                            bytes32
+//	stygos.U256, non-negative *big.Int     uint256
+//	stygos.Selector                        bytes4
+//	bool                                   1 byte
+//	uint8 ... uint64, int8 ... int64       1 to 8 bytes, big-endian
+//	Sized                                  uintN, N/8 bytes
+//	[]byte, string                         bytes, bytesN, string: raw
+//	[]stygos.Address, []stygos.Word,       arrays: 32 bytes per element
+//	[]stygos.U256
+//
+// Packed encodings are ambiguous: abi.encodePacked("a", "bc") and
+// abi.encodePacked("ab", "c") are the same bytes. Hashing a packed
+// encoding of two or more dynamic values ([]byte, string or arrays) for a
+// signature or a storage key lets an attacker move bytes from one value
+// to the next and reuse the hash; use a fixed-size type for all but one
+// of them, or length-prefix each, or use the standard encoding instead.
+func EncodePacked(args ...any) ([]byte, error) {
+	var out []byte
+	for _, arg := range args {
+		var err error
+		if out, err = appendPacked(out, arg); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func appendPacked(out []byte, arg any) ([]byte, error) {
+	switch v := arg.(type) {
+	case stygos.Address:
+		return append(out, v[:]...), nil
+	case stygos.Word:
+		return append(out, v[:]...), nil
+	case stygos.U256:
+		w := v.Word()
+		return append(out, w[:]...), nil
+	case *big.Int:
+		if v.Sign() < 0 || v.BitLen() > 256 {
+			return nil, ErrValueOverflow
+		}
+		w := stygos.WordFromBigInt(v)
+		return append(out, w[:]...), nil
+	case stygos.Selector:
+		return append(out, v[:]...), nil
+	case bool:
+		if v {
+			return append(out, 1), nil
+		}
+		return append(out, 0), nil
+	case uint8:
+		return appendUint(out, uint64(v), 1), nil
+	case uint16:
+		return appendUint(out, uint64(v), 2), nil
+	case uint32:
+		return appendUint(out, uint64(v), 4), nil
+	case uint64:
+		return appendUint(out, v, 8), nil
+	case int8:
+		return appendUint(out, uint64(v), 1), nil
+	case int16:
+		return appendUint(out, uint64(v), 2), nil
+	case int32:
+		return appendUint(out, uint64(v), 4), nil
+	case int64:
+		return appendUint(out, uint64(v), 8), nil
+	case Sized:
+		if v.Bits < 8 || v.Bits > 256 || v.Bits%8 != 0 {
+			return nil, ErrInvalidSize
+		}
+		if v.Value.BitLen() > v.Bits {
+			return nil, ErrValueOverflow
+		}
+		w := v.Value.Word()
+		return append(out, w[32-v.Bits/8:]...), nil
+	case []byte:
+		return append(out, v...), nil
+	case string:
+		return append(out, v...), nil
+	case []stygos.Address:
+		for _, a := range v {
+			w := stygos.PadAddress(a)
+			out = append(out, w[:]...)
+		}
+		return out, nil
+	case []stygos.Word:
+		for _, w := range v {
+			out = append(out, w[:]...)
+		}
+		return out, nil
+	case []stygos.U256:
+		for _, u := range v {
+			w := u.Word()
+			out = append(out, w[:]...)
+		}
+		return out, nil
+	}
+	return nil, ErrUnsupportedType
+}
+
+// appendUint appends the low n bytes of v, big-endian.
+func appendUint(out []byte, v uint64, n int) []byte {
+	for i := n - 1; i >= 0; i-- {
+		out = append(out, byte(v>>(8*uint(i))))
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestEncodePacked(t *testing.T) {
+	addr := stygos.Address{19: 0xaa}
+	tests := []struct {
+		name string
+		args []any
+		want string
+	}{
+		{"address", []any{addr}, "00000000000000000000000000000000000000aa"},
+		{"uint256", []any{stygos.NewU256(1)}, "0000000000000000000000000000000000000000000000000000000000000001"},
+		{"big.Int", []any{big.NewInt(2)}, "0000000000000000000000000000000000000000000000000000000000000002"},
+		{"bool", []any{true, false}, "0100"},
+		{"uints", []any{uint8(1), uint16(2), uint32(3), uint64(4)}, "01" + "0002" + "00000003" + "0000000000000004"},
+		{"ints", []any{int8(-1), int16(-2), int32(1)}, "ff" + "fffe" + "00000001"},
+		{"uint96", []any{Uint(96, stygos.NewU256(5))}, "000000000000000000000005"},
+		{"string and bytes", []any{"ab", []byte{0xcd}}, "6162cd"},
+		{"selector", []any{stygos.SelectorOf("transfer(address,uint256)")}, "a9059cbb"},
+		{"address[]", []any{[]stygos.Address{addr}}, "00000000000000000000000000000000000000000000000000000000000000aa"},
+		{"mixed", []any{"\x19\x01", stygos.Word{31: 1}, addr}, "1901" + "0000000000000000000000000000000000000000000000000000000000000001" + "00000000000000000000000000000000000000aa"},
+	}
+	for _, tt := range tests {
+		got, err := EncodePacked(tt.args...)
+		if err != nil || hex.EncodeToString(got) != tt.want {
+			t.Errorf("EncodePacked(%s) failed. Expected %s, got %x, %v", tt.name, tt.want, got, err)
+		}
+	}
+
+	// The encoding is ambiguous between dynamic values
+	a, _ := EncodePacked("a", "bc")
+	b, _ := EncodePacked("ab", "c")
+	if !bytes.Equal(a, b) {
+		t.Error("EncodePacked failed. Expected (\"a\", \"bc\") and (\"ab\", \"c\") to collide")
+	}
+}
+
+func TestEncodePackedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  any
+		want error
+	}{
+		{"int", 1, ErrUnsupportedType},
+		{"negative big.Int", big.NewInt(-1), ErrValueOverflow},
+		{"uint12", Uint(12, stygos.NewU256(1)), ErrInvalidSize},
+		{"uint8 overflow", Uint(8, stygos.NewU256(256)), ErrValueOverflow},
+	}
+	for _, tt := range tests {
+		if _, err := EncodePacked(tt.arg); err != tt.want {
+			t.Errorf("EncodePacked(%s) failed. Expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Package access implements role-based access control compatible with
+// OpenZeppelin's AccessControl: accounts hold roles, each role is
+// administered by another role, and DefaultAdminRole administers itself
+// and every role without an admin of its own.
+//
+// Roles are bytes32 ids, conventionally Role("MINTER_ROLE"). A contract
+// grants the first admin with Grant when it is initialized, guards its
+// functions with CheckRole, and serves the AccessControl ABI with Mount.
+package access
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// InterfaceID is the ERC-165 id of IAccessControl.
+var InterfaceID = stygos.Selector{0x79, 0x65, 0xdb, 0x0b}
+
+// DefaultAdminRole is the admin of roles without their own admin.
+var DefaultAdminRole = stygos.Word{}
+
+// AccessControl ABI selectors
+var (
+	selHasRole      = stygos.Selector{0x91, 0xd1, 0x48, 0x54} // hasRole(bytes32,address)
+	selGetRoleAdmin = stygos.Selector{0x24, 0x8a, 0x9c, 0xa3} // getRoleAdmin(bytes32)
+	selGrantRole    = stygos.Selector{0x2f, 0x2f, 0xf1, 0x5d} // grantRole(bytes32,address)
+	selRevokeRole   = stygos.Selector{0xd5, 0x47, 0x74, 0x1f} // revokeRole(bytes32,address)
+	selRenounceRole = stygos.Selector{0x36, 0x56, 0x8a, 0xbe} // renounceRole(bytes32,address)
+)
+
+// Access errors
+var (
+	ErrMissingRole     = errors.New("access: account is missing role")
+	ErrBadConfirmation = errors.New("access: can only renounce roles for self")
+)
+
+// Role returns the id of the role called name, keccak256(name).
+func Role(name string) stygos.Word {
+	return stygos.Keccak256([]byte(name))
+}
+
+// AccessControl holds the roles of a contract.
+//
+// Storage layout relative to the base slot, for each role:
+//
+//	MapKey(base, role)                            admin role
+//	MapKey(Offset(MapKey(base, role), 1), acc)    1 while acc holds role
+type AccessControl struct {
+	base stygos.Word
+}
+
+// NewAccessControl returns the roles rooted at base.
+func NewAccessControl(base stygos.Word) *AccessControl {
+	return &AccessControl{base: base}
+}
+
+// HasRole reports whether account holds role.
+func (a *AccessControl) HasRole(role stygos.Word, account stygos.Address) bool {
+	return stygos.StorageLoad(a.memberSlot(role, account)) != (stygos.Word{})
+}
+
+// CheckRole returns ErrMissingRole unless account holds role.
+func (a *AccessControl) CheckRole(role stygos.Word, account stygos.Address) error {
+	if !a.HasRole(role, account) {
+		return ErrMissingRole
+	}
+	return nil
+}
+
+// GetRoleAdmin returns the role that grants and revokes role.
+func (a *AccessControl) GetRoleAdmin(role stygos.Word) stygos.Word {
+	return stygos.StorageLoad(a.roleSlot(role))
+}
+
+// GrantRole grants role to account. The caller must hold the admin role of
+// role.
+func (a *AccessControl) GrantRole(role stygos.Word, account stygos.Address) error {
+	if err := a.CheckRole(a.GetRoleAdmin(role), stygos.GetMsgSender()); err != nil {
+		return err
+	}
+	a.Grant(role, account)
+	return nil
+}
+
+// RevokeRole revokes role from account. The caller must hold the admin
+// role of role.
+func (a *AccessControl) RevokeRole(role stygos.Word, account stygos.Address) error {
+	if err := a.CheckRole(a.GetRoleAdmin(role), stygos.GetMsgSender()); err != nil {
+		return err
+	}
+	a.Revoke(role, account)
+	return nil
+}
+
+// RenounceRole gives up role for the caller, which must pass itself as
+// confirmation.
+func (a *AccessControl) RenounceRole(role stygos.Word, confirmation stygos.Address) error {
+	if confirmation != stygos.GetMsgSender() {
+		return ErrBadConfirmation
+	}
+	a.Revoke(role, confirmation)
+	return nil
+}
+
+// Grant grants role to account without checking the caller, for
+// initialization. It emits RoleGranted if account did not hold role.
+func (a *AccessControl) Grant(role stygos.Word, account stygos.Address) {
+	if a.HasRole(role, account) {
+		return
+	}
+	stygos.StorageStore(a.memberSlot(role, account), stygos.WordFromUint64(1))
+	emitRole("RoleGranted(bytes32,address,address)", role, account)
+}
+
+// Revoke revokes role from account without checking the caller. It emits
+// RoleRevoked if account held role.
+func (a *AccessControl) Revoke(role stygos.Word, account stygos.Address) {
+	if !a.HasRole(role, account) {
+		return
+	}
+	stygos.StorageStore(a.memberSlot(role, account), stygos.Word{})
+	emitRole("RoleRevoked(bytes32,address,address)", role, account)
+}
+
+// SetRoleAdmin makes admin the admin role of role and emits
+// RoleAdminChanged.
+func (a *AccessControl) SetRoleAdmin(role, admin stygos.Word) {
+	previous := a.GetRoleAdmin(role)
+	stygos.StorageStore(a.roleSlot(role), admin)
+	stygos.EmitEvent(nil, stygos.Keccak256([]byte("RoleAdminChanged(bytes32,bytes32,bytes32)")), role, previous, admin)
+}
+
+// Mount registers the AccessControl functions on router and declares the
+// interface in registry.
+func (a *AccessControl) Mount(router *stygos.Router, registry *erc165.Registry) {
+	router.HandleSelector(selHasRole, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		role, account, err := roleArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		var result stygos.Word
+		if a.HasRole(role, account) {
+			result[31] = 1
+		}
+		return result[:], nil
+	})
+	router.HandleSelector(selGetRoleAdmin, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) != 32 {
+			return nil, stygos.ErrInvalidInput
+		}
+		var role stygos.Word
+		copy(role[:], args)
+		admin := a.GetRoleAdmin(role)
+		return admin[:], nil
+	})
+	router.HandleSelector(selGrantRole, a.roleHandler(a.GrantRole))
+	router.HandleSelector(selRevokeRole, a.roleHandler(a.RevokeRole))
+	router.HandleSelector(selRenounceRole, a.roleHandler(a.RenounceRole))
+	registry.Register(InterfaceID)
+}
+
+func (a *AccessControl) roleHandler(fn func(role stygos.Word, account stygos.Address) error) stygos.Handler {
+	return func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		role, account, err := roleArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return nil, fn(role, account)
+	}
+}
+
+func (a *AccessControl) roleSlot(role stygos.Word) stygos.Word {
+	return storage.MapKey(a.base, role[:])
+}
+
+func (a *AccessControl) memberSlot(role stygos.Word, account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(a.roleSlot(role), 1), account[:])
+}
+
+// roleArgs decodes (bytes32 role, address account).
+func roleArgs(args []byte) (stygos.Word, stygos.Address, error) {
+	if len(args) != 64 {
+		return stygos.Word{}, stygos.Address{}, stygos.ErrInvalidInput
+	}
+	var role, account stygos.Word
+	copy(role[:], args[:32])
+	copy(account[:], args[32:])
+	return role, stygos.AddressFromWord(account), nil
+}
+
+func emitRole(signature string, role stygos.Word, account stygos.Address) {
+	stygos.EmitEvent(nil, stygos.Keccak256([]byte(signature)), role, stygos.PadAddress(account), stygos.PadAddress(stygos.GetMsgSender()))
+}
@@ -0,0 +1,123 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/erc165"
+)
+
+var (
+	admin  = stygos.Address{0xad}
+	alice  = stygos.Address{0xa1}
+	bob    = stygos.Address{0xb0}
+	writer = Role("WRITER_ROLE")
+)
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selHasRole, "hasRole(bytes32,address)"},
+		{selGetRoleAdmin, "getRoleAdmin(bytes32)"},
+		{selGrantRole, "grantRole(bytes32,address)"},
+		{selRevokeRole, "revokeRole(bytes32,address)"},
+		{selRenounceRole, "renounceRole(bytes32,address)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+	id := erc165.InterfaceIDOf("hasRole(bytes32,address)", "getRoleAdmin(bytes32)", "grantRole(bytes32,address)", "revokeRole(bytes32,address)", "renounceRole(bytes32,address)")
+	if id != InterfaceID {
+		t.Errorf("InterfaceID failed. Expected %x, got %x", id, InterfaceID)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	a := NewAccessControl(stygos.Word{0xac})
+	a.Grant(DefaultAdminRole, admin)
+
+	mock.Sender = alice
+	if err := a.GrantRole(writer, alice); err != ErrMissingRole {
+		t.Errorf("GrantRole failed. Expected ErrMissingRole, got %v", err)
+	}
+	mock.Sender = admin
+	if err := a.GrantRole(writer, alice); err != nil || !a.HasRole(writer, alice) {
+		t.Fatalf("GrantRole failed: %v", err)
+	}
+	a.GrantRole(writer, alice)
+	if len(mock.Logs) != 2 {
+		t.Errorf("GrantRole failed. Expected 2 RoleGranted events, got %d", len(mock.Logs))
+	}
+
+	// A role administered by writers
+	editor := Role("EDITOR_ROLE")
+	a.SetRoleAdmin(editor, writer)
+	if a.GetRoleAdmin(editor) != writer {
+		t.Errorf("SetRoleAdmin failed. Expected the writer role")
+	}
+	if err := a.GrantRole(editor, bob); err != ErrMissingRole {
+		t.Errorf("GrantRole failed. Expected the default admin to be refused, got %v", err)
+	}
+	mock.Sender = alice
+	if err := a.GrantRole(editor, bob); err != nil || a.CheckRole(editor, bob) != nil {
+		t.Errorf("GrantRole failed. Expected a writer to grant editor: %v", err)
+	}
+
+	if err := a.RenounceRole(writer, bob); err != ErrBadConfirmation {
+		t.Errorf("RenounceRole failed. Expected ErrBadConfirmation, got %v", err)
+	}
+	if err := a.RenounceRole(writer, alice); err != nil || a.HasRole(writer, alice) {
+		t.Errorf("RenounceRole failed: %v", err)
+	}
+	mock.Sender = admin
+	a.GrantRole(writer, alice)
+	if err := a.RevokeRole(writer, alice); err != nil || a.CheckRole(writer, alice) != ErrMissingRole {
+		t.Errorf("RevokeRole failed: %v", err)
+	}
+}
+
+func TestMount(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	a := NewAccessControl(stygos.Word{0xac})
+	a.Grant(DefaultAdminRole, admin)
+
+	router := stygos.NewRouter()
+	registry := erc165.NewRegistry()
+	a.Mount(router, registry)
+	if !registry.Supports(InterfaceID) {
+		t.Errorf("Mount failed to register the IAccessControl interface")
+	}
+
+	acc := stygos.PadAddress(alice)
+	call := func(sel stygos.Selector, args ...stygos.Word) ([]byte, error) {
+		data := sel[:]
+		for _, w := range args {
+			data = append(data, w[:]...)
+		}
+		return router.Dispatch(data)
+	}
+	mock.Sender = admin
+	if _, err := call(selGrantRole, writer, acc); err != nil {
+		t.Fatalf("grantRole failed: %v", err)
+	}
+	if out, err := call(selHasRole, writer, acc); err != nil || out[31] != 1 {
+		t.Errorf("hasRole failed. Expected true, got %x, %v", out, err)
+	}
+	if out, err := call(selGetRoleAdmin, writer); err != nil || string(out) != string(DefaultAdminRole[:]) {
+		t.Errorf("getRoleAdmin failed. Expected the default admin, got %x, %v", out, err)
+	}
+	mock.Sender = alice
+	if _, err := call(selRevokeRole, writer, acc); err != ErrMissingRole {
+		t.Errorf("revokeRole failed. Expected ErrMissingRole, got %v", err)
+	}
+	if _, err := call(selRenounceRole, writer, acc); err != nil || a.HasRole(writer, alice) {
+		t.Errorf("renounceRole failed: %v", err)
+	}
+}
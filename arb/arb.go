@@ -0,0 +1,91 @@
+// Package arb provides typed bindings to the Arbitrum precompiles, so
+// stygos contracts can send L2 to L1 messages and read ArbOS data such as L2
+// block numbers and gas prices.
+//
+// Each binding encodes the ABI call, performs it through stygos.Call or
+// stygos.StaticCall and decodes the result. In tests, InstallMock deploys
+// in-memory precompiles on a stygos.MockRuntime.
+package arb
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Precompile addresses
+var (
+	ArbSysAddress        = stygos.Address{19: 0x64}
+	ArbGasInfoAddress    = stygos.Address{19: 0x6c}
+	ArbWasmAddress       = stygos.Address{19: 0x71}
+	NodeInterfaceAddress = stygos.Address{19: 0xc8}
+)
+
+// ErrBadReturn is returned when a precompile returns less data than its ABI
+// declares.
+var ErrBadReturn = errors.New("arb: malformed return data")
+
+// encodeCall returns selector ++ args, each argument a 32-byte word.
+func encodeCall(sel stygos.Selector, args ...stygos.Word) []byte {
+	data := make([]byte, 4, 4+32*len(args))
+	copy(data, sel[:])
+	for _, arg := range args {
+		data = append(data, arg[:]...)
+	}
+	return data
+}
+
+// appendBytes appends the tail encoding of a dynamic bytes argument: its
+// length followed by the data right-padded to a multiple of 32 bytes.
+func appendBytes(data, b []byte) []byte {
+	length := stygos.WordFromUint64(uint64(len(b)))
+	data = append(data, length[:]...)
+	data = append(data, b...)
+	if pad := len(b) % 32; pad != 0 {
+		data = append(data, make([]byte, 32-pad)...)
+	}
+	return data
+}
+
+// words splits return data into n words.
+func words(ret []byte, n int) ([]stygos.Word, error) {
+	if len(ret) < 32*n {
+		return nil, ErrBadReturn
+	}
+	out := make([]stygos.Word, n)
+	for i := range out {
+		copy(out[i][:], ret[32*i:])
+	}
+	return out, nil
+}
+
+// staticWord performs a static call and returns the single word result.
+func staticWord(to stygos.Address, data []byte) (stygos.Word, error) {
+	ret, err := stygos.StaticCall(to, data)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	w, err := words(ret, 1)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	return w[0], nil
+}
+
+// staticUint64 performs a static call returning a uint that fits 64 bits.
+func staticUint64(to stygos.Address, data []byte) (uint64, error) {
+	w, err := staticWord(to, data)
+	return stygos.Uint64FromWord(w), err
+}
+
+// staticU256 performs a static call returning a uint256.
+func staticU256(to stygos.Address, data []byte) (stygos.U256, error) {
+	w, err := staticWord(to, data)
+	return stygos.U256FromWord(w), err
+}
+
+// staticBool performs a static call returning a bool.
+func staticBool(to stygos.Address, data []byte) (bool, error) {
+	w, err := staticWord(to, data)
+	return w[31] != 0, err
+}
@@ -0,0 +1,175 @@
+package arb
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selArbBlockNumber, "arbBlockNumber()"},
+		{selArbBlockHash, "arbBlockHash(uint256)"},
+		{selArbChainID, "arbChainID()"},
+		{selArbOSVersion, "arbOSVersion()"},
+		{selWithdrawEth, "withdrawEth(address)"},
+		{selSendTxToL1, "sendTxToL1(address,bytes)"},
+		{selIsTopLevelCall, "isTopLevelCall()"},
+		{selWasMyCallersAddressAliased, "wasMyCallersAddressAliased()"},
+		{selMyCallersAddressWithoutAliasing, "myCallersAddressWithoutAliasing()"},
+		{selGetPricesInWei, "getPricesInWei()"},
+		{selGetL1BaseFeeEstimate, "getL1BaseFeeEstimate()"},
+		{selGetMinimumGasPrice, "getMinimumGasPrice()"},
+		{selGetCurrentTxL1GasFees, "getCurrentTxL1GasFees()"},
+		{selGetGasBacklog, "getGasBacklog()"},
+		{selGasEstimateL1Component, "gasEstimateL1Component(address,bool,bytes)"},
+		{selNitroGenesisBlock, "nitroGenesisBlock()"},
+		{selL2BlockRangeForL1, "l2BlockRangeForL1(uint64)"},
+	}
+
+	for _, tt := range tests {
+		if want := stygos.SelectorOf(tt.signature); tt.sel != want {
+			t.Errorf("Selector of %s failed. Expected %x, got %x", tt.signature, want, tt.sel)
+		}
+	}
+}
+
+func TestArbSysQueries(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	arbos := InstallMock(mock)
+	arbos.BlockNumber = 1000
+	arbos.BlockHashes[999] = stygos.Word{0x99}
+	arbos.CallerAliased = true
+	arbos.CallerWithoutAliasing = stygos.Address{0x11}
+
+	if n, err := ArbBlockNumber(); err != nil || n != 1000 {
+		t.Errorf("ArbBlockNumber failed. Expected 1000, got %d, %v", n, err)
+	}
+	if h, err := ArbBlockHash(999); err != nil || h != (stygos.Word{0x99}) {
+		t.Errorf("ArbBlockHash failed. Got %x, %v", h, err)
+	}
+	if _, err := ArbBlockHash(1000); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("ArbBlockHash failed. Expected revert for current block, got %v", err)
+	}
+	if _, err := ArbBlockHash(700); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("ArbBlockHash failed. Expected revert for old block, got %v", err)
+	}
+	if id, err := ArbChainID(); err != nil || id != 412346 {
+		t.Errorf("ArbChainID failed. Expected 412346, got %d, %v", id, err)
+	}
+	if top, err := IsTopLevelCall(); err != nil || !top {
+		t.Errorf("IsTopLevelCall failed. Got %v, %v", top, err)
+	}
+	if aliased, err := WasMyCallersAddressAliased(); err != nil || !aliased {
+		t.Errorf("WasMyCallersAddressAliased failed. Got %v, %v", aliased, err)
+	}
+	if addr, err := MyCallersAddressWithoutAliasing(); err != nil || addr != (stygos.Address{0x11}) {
+		t.Errorf("MyCallersAddressWithoutAliasing failed. Got %x, %v", addr, err)
+	}
+}
+
+func TestL2ToL1Messages(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.SetBalance(mock.Contract, big.NewInt(1e18+5))
+	stygos.UseRuntime(mock)
+	arbos := InstallMock(mock)
+
+	dest := stygos.Address{0xd0}
+	id, err := WithdrawEth(dest, stygos.NewU256(1e18))
+	if err != nil || !id.IsZero() {
+		t.Fatalf("WithdrawEth failed. Expected id 0, got %v, %v", id, err)
+	}
+
+	calldata := bytes.Repeat([]byte{0xab}, 37)
+	id, err = SendTxToL1(dest, stygos.NewU256(5), calldata)
+	if err != nil || id.Uint64() != 1 {
+		t.Fatalf("SendTxToL1 failed. Expected id 1, got %v, %v", id, err)
+	}
+
+	if len(arbos.Messages) != 2 {
+		t.Fatalf("Messages failed. Expected 2, got %d", len(arbos.Messages))
+	}
+	withdraw := arbos.Messages[0]
+	if withdraw.Sender != mock.Contract || withdraw.Destination != dest || withdraw.Value != stygos.NewU256(1e18) || withdraw.Data != nil {
+		t.Errorf("WithdrawEth message failed. Got %+v", withdraw)
+	}
+	tx := arbos.Messages[1]
+	if tx.Value != stygos.NewU256(5) || !bytes.Equal(tx.Data, calldata) {
+		t.Errorf("SendTxToL1 message failed. Got %+v", tx)
+	}
+}
+
+func TestGasInfoAndNodeInterface(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	arbos := InstallMock(mock)
+	arbos.Prices = Prices{PerL2Tx: stygos.NewU256(1), PerArbGasTotal: stygos.NewU256(100000000)}
+	arbos.L1BaseFeeEstimate = stygos.NewU256(30e9)
+	arbos.GasBacklog = 12
+	arbos.L1Component = L1Component{GasEstimateForL1: 1500, BaseFee: stygos.NewU256(1e8), L1BaseFeeEstimate: stygos.NewU256(30e9)}
+	arbos.L2BlockRanges[18000000] = [2]uint64{150000000, 150000003}
+
+	if p, err := GetPricesInWei(); err != nil || p != arbos.Prices {
+		t.Errorf("GetPricesInWei failed. Got %+v, %v", p, err)
+	}
+	if fee, err := GetL1BaseFeeEstimate(); err != nil || fee != stygos.NewU256(30e9) {
+		t.Errorf("GetL1BaseFeeEstimate failed. Got %v, %v", fee, err)
+	}
+	if backlog, err := GetGasBacklog(); err != nil || backlog != 12 {
+		t.Errorf("GetGasBacklog failed. Got %d, %v", backlog, err)
+	}
+	if c, err := GasEstimateL1Component(stygos.Address{1}, false, []byte{1, 2, 3}); err != nil || c != arbos.L1Component {
+		t.Errorf("GasEstimateL1Component failed. Got %+v, %v", c, err)
+	}
+	if first, last, err := L2BlockRangeForL1(18000000); err != nil || first != 150000000 || last != 150000003 {
+		t.Errorf("L2BlockRangeForL1 failed. Got %d-%d, %v", first, last, err)
+	}
+}
+
+func TestBadReturn(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	// Without the mock, the precompile addresses hold no code and calls
+	// return no data
+	if _, err := ArbBlockNumber(); err != ErrBadReturn {
+		t.Errorf("ArbBlockNumber failed. Expected ErrBadReturn, got %v", err)
+	}
+}
+
+func TestBlockAccessors(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Block = 19000000
+	stygos.UseRuntime(mock)
+	arbos := InstallMock(mock)
+	arbos.Mine(300)
+
+	if n := stygos.GetL1BlockNumber(); n != 19000000 {
+		t.Errorf("GetL1BlockNumber failed. Expected 19000000, got %d", n)
+	}
+	if n, _ := ArbBlockNumber(); n != 301 {
+		t.Errorf("Mine failed. Expected L2 block 301, got %d", n)
+	}
+
+	if h := stygos.BlockHash(300); h != arbos.BlockHashes[300] || h == (stygos.Word{}) {
+		t.Errorf("BlockHash failed for the previous block. Got %x", h)
+	}
+	if h := stygos.BlockHash(45); h != arbos.BlockHashes[45] {
+		t.Errorf("BlockHash failed for the oldest available block. Got %x", h)
+	}
+	if h := stygos.BlockHash(44); h != (stygos.Word{}) {
+		t.Errorf("BlockHash failed. Expected zero beyond 256 blocks, got %x", h)
+	}
+	if h := stygos.BlockHash(301); h != (stygos.Word{}) {
+		t.Errorf("BlockHash failed. Expected zero for the current block, got %x", h)
+	}
+}
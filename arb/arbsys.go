@@ -0,0 +1,89 @@
+package arb
+
+import "github.com/rafaelescrich/stygos"
+
+// ArbSys selectors
+var (
+	selArbBlockNumber                  = stygos.Selector{0xa3, 0xb1, 0xb3, 0x1d} // arbBlockNumber()
+	selArbBlockHash                    = stygos.Selector{0x2b, 0x40, 0x7a, 0x82} // arbBlockHash(uint256)
+	selArbChainID                      = stygos.Selector{0xd1, 0x27, 0xf5, 0x4a} // arbChainID()
+	selArbOSVersion                    = stygos.Selector{0x05, 0x10, 0x38, 0xf2} // arbOSVersion()
+	selWithdrawEth                     = stygos.Selector{0x25, 0xe1, 0x60, 0x63} // withdrawEth(address)
+	selSendTxToL1                      = stygos.Selector{0x92, 0x8c, 0x16, 0x9a} // sendTxToL1(address,bytes)
+	selIsTopLevelCall                  = stygos.Selector{0x08, 0xbd, 0x62, 0x4c} // isTopLevelCall()
+	selWasMyCallersAddressAliased      = stygos.Selector{0x17, 0x5a, 0x26, 0x0b} // wasMyCallersAddressAliased()
+	selMyCallersAddressWithoutAliasing = stygos.Selector{0xd7, 0x45, 0x23, 0xb3} // myCallersAddressWithoutAliasing()
+)
+
+// ArbBlockNumber returns the current L2 block number. On Arbitrum the
+// block_number hostio (stygos.GetBlockNumber) reports the L1 block instead.
+func ArbBlockNumber() (uint64, error) {
+	return staticUint64(ArbSysAddress, encodeCall(selArbBlockNumber))
+}
+
+// ArbBlockHash returns the hash of one of the last 256 L2 blocks.
+func ArbBlockHash(number uint64) (stygos.Word, error) {
+	return staticWord(ArbSysAddress, encodeCall(selArbBlockHash, stygos.WordFromUint64(number)))
+}
+
+// ArbChainID returns the chain id of the rollup.
+func ArbChainID() (uint64, error) {
+	return staticUint64(ArbSysAddress, encodeCall(selArbChainID))
+}
+
+// ArbOSVersion returns the ArbOS version, offset by 55 as reported by the
+// precompile.
+func ArbOSVersion() (uint64, error) {
+	return staticUint64(ArbSysAddress, encodeCall(selArbOSVersion))
+}
+
+// WithdrawEth sends value wei to destination on L1 and returns the unique id
+// of the L2 to L1 message. The funds can be claimed on L1 once the message
+// is confirmed.
+func WithdrawEth(destination stygos.Address, value stygos.U256) (stygos.U256, error) {
+	data := encodeCall(selWithdrawEth, stygos.PadAddress(destination))
+	ret, err := stygos.Call(ArbSysAddress, value.Word(), data)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	w, err := words(ret, 1)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	return stygos.U256FromWord(w[0]), nil
+}
+
+// SendTxToL1 sends an L2 to L1 message that executes calldata on
+// destination, carrying value wei, and returns the message id.
+func SendTxToL1(destination stygos.Address, value stygos.U256, calldata []byte) (stygos.U256, error) {
+	data := encodeCall(selSendTxToL1, stygos.PadAddress(destination), stygos.WordFromUint64(0x40))
+	data = appendBytes(data, calldata)
+	ret, err := stygos.Call(ArbSysAddress, value.Word(), data)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	w, err := words(ret, 1)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	return stygos.U256FromWord(w[0]), nil
+}
+
+// IsTopLevelCall reports whether the caller of this contract was called
+// directly by an EOA.
+func IsTopLevelCall() (bool, error) {
+	return staticBool(ArbSysAddress, encodeCall(selIsTopLevelCall))
+}
+
+// WasMyCallersAddressAliased reports whether the caller's address was
+// aliased because the call originated from an L1 contract.
+func WasMyCallersAddressAliased() (bool, error) {
+	return staticBool(ArbSysAddress, encodeCall(selWasMyCallersAddressAliased))
+}
+
+// MyCallersAddressWithoutAliasing returns the caller's address with any L1
+// to L2 aliasing undone.
+func MyCallersAddressWithoutAliasing() (stygos.Address, error) {
+	w, err := staticWord(ArbSysAddress, encodeCall(selMyCallersAddressWithoutAliasing))
+	return stygos.AddressFromWord(w), err
+}
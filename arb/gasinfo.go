@@ -0,0 +1,65 @@
+package arb
+
+import "github.com/rafaelescrich/stygos"
+
+// ArbGasInfo selectors
+var (
+	selGetPricesInWei        = stygos.Selector{0x41, 0xb2, 0x47, 0xa8} // getPricesInWei()
+	selGetL1BaseFeeEstimate  = stygos.Selector{0xf5, 0xd6, 0xde, 0xd7} // getL1BaseFeeEstimate()
+	selGetMinimumGasPrice    = stygos.Selector{0xf9, 0x18, 0x37, 0x9a} // getMinimumGasPrice()
+	selGetCurrentTxL1GasFees = stygos.Selector{0xc6, 0xf7, 0xde, 0x0e} // getCurrentTxL1GasFees()
+	selGetGasBacklog         = stygos.Selector{0x1d, 0x5b, 0x5c, 0x20} // getGasBacklog()
+)
+
+// Prices are the current gas prices in wei, as returned by
+// ArbGasInfo.getPricesInWei.
+type Prices struct {
+	PerL2Tx              stygos.U256
+	PerL1CalldataByte    stygos.U256
+	PerStorageAllocation stygos.U256
+	PerArbGasBase        stygos.U256
+	PerArbGasCongestion  stygos.U256
+	PerArbGasTotal       stygos.U256
+}
+
+// GetPricesInWei returns the current gas prices in wei.
+func GetPricesInWei() (Prices, error) {
+	ret, err := stygos.StaticCall(ArbGasInfoAddress, encodeCall(selGetPricesInWei))
+	if err != nil {
+		return Prices{}, err
+	}
+	w, err := words(ret, 6)
+	if err != nil {
+		return Prices{}, err
+	}
+	return Prices{
+		PerL2Tx:              stygos.U256FromWord(w[0]),
+		PerL1CalldataByte:    stygos.U256FromWord(w[1]),
+		PerStorageAllocation: stygos.U256FromWord(w[2]),
+		PerArbGasBase:        stygos.U256FromWord(w[3]),
+		PerArbGasCongestion:  stygos.U256FromWord(w[4]),
+		PerArbGasTotal:       stygos.U256FromWord(w[5]),
+	}, nil
+}
+
+// GetL1BaseFeeEstimate returns ArbOS's estimate of the L1 base fee in wei.
+func GetL1BaseFeeEstimate() (stygos.U256, error) {
+	return staticU256(ArbGasInfoAddress, encodeCall(selGetL1BaseFeeEstimate))
+}
+
+// GetMinimumGasPrice returns the minimum L2 gas price in wei.
+func GetMinimumGasPrice() (stygos.U256, error) {
+	return staticU256(ArbGasInfoAddress, encodeCall(selGetMinimumGasPrice))
+}
+
+// GetCurrentTxL1GasFees returns the fee in wei paid by the current
+// transaction for its L1 calldata.
+func GetCurrentTxL1GasFees() (stygos.U256, error) {
+	return staticU256(ArbGasInfoAddress, encodeCall(selGetCurrentTxL1GasFees))
+}
+
+// GetGasBacklog returns the L2 gas backlog used to compute congestion
+// pricing.
+func GetGasBacklog() (uint64, error) {
+	return staticUint64(ArbGasInfoAddress, encodeCall(selGetGasBacklog))
+}
@@ -0,0 +1,294 @@
+//go:build !tinygo
+
+package arb
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// ErrInvalidBlockNumber is the revert of the mock ArbSys.arbBlockHash for
+// blocks outside the last 256.
+var ErrInvalidBlockNumber = errors.New("arb: invalid block number")
+
+// L1Message is an L2 to L1 message recorded by the mock ArbSys.
+type L1Message struct {
+	ID          uint64
+	Sender      stygos.Address
+	Destination stygos.Address
+	Value       stygos.U256
+	Data        []byte // nil for withdrawEth
+}
+
+// MockArbOS is the state served by the mock precompiles. Tests set the
+// fields they need and inspect Messages after the contract runs.
+type MockArbOS struct {
+	BlockNumber           uint64
+	BlockHashes           map[uint64]stygos.Word
+	ChainID               uint64
+	Version               uint64
+	TopLevelCall          bool
+	CallerAliased         bool
+	CallerWithoutAliasing stygos.Address
+
+	Prices             Prices
+	L1BaseFeeEstimate  stygos.U256
+	MinimumGasPrice    stygos.U256
+	CurrentTxL1GasFees stygos.U256
+	GasBacklog         uint64
+
+	L1Component       L1Component
+	NitroGenesisBlock uint64
+	L2BlockRanges     map[uint64][2]uint64 // L1 block -> first, last L2 block
+
+	Messages []L1Message
+
+	RetryableLifetime uint64
+	Retryables        map[stygos.Word]*MockRetryable
+}
+
+// MockRetryable is a retryable ticket known to the mock ArbRetryableTx.
+type MockRetryable struct {
+	Timeout     uint64
+	Beneficiary stygos.Address
+	Redeemed    bool
+	Cancelled   bool
+}
+
+// Mock ArbRetryableTx reverts
+var (
+	ErrNoTicket       = errors.New("arb: ticket not found")
+	ErrNotBeneficiary = errors.New("arb: caller is not the beneficiary")
+)
+
+// InstallMock deploys mock ArbSys, ArbGasInfo and NodeInterface contracts
+// on rt and returns their shared state.
+func InstallMock(rt *stygos.MockRuntime) *MockArbOS {
+	m := &MockArbOS{
+		BlockNumber:   1,
+		BlockHashes:   make(map[uint64]stygos.Word),
+		ChainID:       412346,  // Nitro devnode
+		Version:       55 + 32, // ArbOS 32
+		TopLevelCall:  true,
+		L2BlockRanges: make(map[uint64][2]uint64),
+
+		RetryableLifetime: 7 * 24 * 60 * 60,
+		Retryables:        make(map[stygos.Word]*MockRetryable),
+	}
+
+	sys := stygos.NewRouter()
+	sys.HandleSelector(selArbBlockNumber, m.uint64Handler(&m.BlockNumber))
+	sys.HandleSelector(selArbBlockHash, m.arbBlockHash)
+	sys.HandleSelector(selArbChainID, m.uint64Handler(&m.ChainID))
+	sys.HandleSelector(selArbOSVersion, m.uint64Handler(&m.Version))
+	sys.HandleSelector(selWithdrawEth, m.withdrawEth)
+	sys.HandleSelector(selSendTxToL1, m.sendTxToL1)
+	sys.HandleSelector(selIsTopLevelCall, m.boolHandler(&m.TopLevelCall))
+	sys.HandleSelector(selWasMyCallersAddressAliased, m.boolHandler(&m.CallerAliased))
+	sys.HandleSelector(selMyCallersAddressWithoutAliasing, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w := stygos.PadAddress(m.CallerWithoutAliasing)
+		return w[:], nil
+	})
+	rt.Deploy(ArbSysAddress, sys.Dispatch)
+
+	gas := stygos.NewRouter()
+	gas.HandleSelector(selGetPricesInWei, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		p := m.Prices
+		return encodeWords(p.PerL2Tx.Word(), p.PerL1CalldataByte.Word(), p.PerStorageAllocation.Word(),
+			p.PerArbGasBase.Word(), p.PerArbGasCongestion.Word(), p.PerArbGasTotal.Word()), nil
+	})
+	gas.HandleSelector(selGetL1BaseFeeEstimate, m.u256Handler(&m.L1BaseFeeEstimate))
+	gas.HandleSelector(selGetMinimumGasPrice, m.u256Handler(&m.MinimumGasPrice))
+	gas.HandleSelector(selGetCurrentTxL1GasFees, m.u256Handler(&m.CurrentTxL1GasFees))
+	gas.HandleSelector(selGetGasBacklog, m.uint64Handler(&m.GasBacklog))
+	rt.Deploy(ArbGasInfoAddress, gas.Dispatch)
+
+	node := stygos.NewRouter()
+	node.HandleSelector(selGasEstimateL1Component, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		c := m.L1Component
+		return encodeWords(stygos.WordFromUint64(c.GasEstimateForL1), c.BaseFee.Word(), c.L1BaseFeeEstimate.Word()), nil
+	})
+	node.HandleSelector(selNitroGenesisBlock, m.uint64Handler(&m.NitroGenesisBlock))
+	node.HandleSelector(selL2BlockRangeForL1, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := words(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := m.L2BlockRanges[stygos.Uint64FromWord(w[0])]
+		if !ok {
+			return nil, ErrInvalidBlockNumber
+		}
+		return encodeWords(stygos.WordFromUint64(r[0]), stygos.WordFromUint64(r[1])), nil
+	})
+	rt.Deploy(NodeInterfaceAddress, node.Dispatch)
+
+	retry := stygos.NewRouter()
+	retry.HandleSelector(selRedeem, m.ticketHandler(func(id stygos.Word, r *MockRetryable) ([]byte, error) {
+		r.Redeemed = true
+		h := stygos.Keccak256(append([]byte("redeem"), id[:]...))
+		return h[:], nil
+	}))
+	retry.HandleSelector(selKeepalive, m.ticketHandler(func(id stygos.Word, r *MockRetryable) ([]byte, error) {
+		r.Timeout += m.RetryableLifetime
+		w := stygos.WordFromUint64(r.Timeout)
+		return w[:], nil
+	}))
+	retry.HandleSelector(selCancel, m.ticketHandler(func(id stygos.Word, r *MockRetryable) ([]byte, error) {
+		if stygos.GetMsgSender() != r.Beneficiary {
+			return nil, ErrNotBeneficiary
+		}
+		r.Cancelled = true
+		return nil, nil
+	}))
+	retry.HandleSelector(selGetTimeout, m.ticketHandler(func(id stygos.Word, r *MockRetryable) ([]byte, error) {
+		w := stygos.WordFromUint64(r.Timeout)
+		return w[:], nil
+	}))
+	retry.HandleSelector(selGetBeneficiary, m.ticketHandler(func(id stygos.Word, r *MockRetryable) ([]byte, error) {
+		w := stygos.PadAddress(r.Beneficiary)
+		return w[:], nil
+	}))
+	retry.HandleSelector(selGetLifetime, m.uint64Handler(&m.RetryableLifetime))
+	rt.Deploy(ArbRetryableTxAddress, retry.Dispatch)
+
+	return m
+}
+
+// ticketHandler resolves the ticket id argument of an ArbRetryableTx call.
+// Redeemed and cancelled tickets no longer exist.
+func (m *MockArbOS) ticketHandler(h func(id stygos.Word, r *MockRetryable) ([]byte, error)) stygos.Handler {
+	return func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := words(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := m.Retryables[w[0]]
+		if !ok || r.Redeemed || r.Cancelled {
+			return nil, ErrNoTicket
+		}
+		return h(w[0], r)
+	}
+}
+
+// MockInbox is the delayed inbox of a child chain. It records the retryable
+// tickets submitted to it.
+type MockInbox struct {
+	Tickets []RetryableTicket
+	Senders []stygos.Address
+	Values  []stygos.U256
+}
+
+// InstallMockInbox deploys a MockInbox at addr on rt. Like the real inbox it
+// reverts with ErrDepositShort when the value sent does not cover the
+// ticket's deposit, and returns the message number.
+func InstallMockInbox(rt *stygos.MockRuntime, addr stygos.Address) *MockInbox {
+	inbox := &MockInbox{}
+	rt.Deploy(addr, func(input []byte) ([]byte, error) {
+		t, err := DecodeRetryableTicket(input)
+		if err != nil {
+			return nil, err
+		}
+		value := stygos.U256FromBig(stygos.GetMsgValue())
+		if value.Lt(t.Deposit()) {
+			return nil, ErrDepositShort
+		}
+		n := stygos.WordFromUint64(uint64(len(inbox.Tickets)))
+		inbox.Tickets = append(inbox.Tickets, t)
+		inbox.Senders = append(inbox.Senders, stygos.GetMsgSender())
+		inbox.Values = append(inbox.Values, value)
+		return n[:], nil
+	})
+	return inbox
+}
+
+// Mine advances the L2 block number by n, giving each mined block a
+// deterministic hash derived from its number.
+func (m *MockArbOS) Mine(n int) {
+	for i := 0; i < n; i++ {
+		number := stygos.WordFromUint64(m.BlockNumber)
+		m.BlockHashes[m.BlockNumber] = stygos.Keccak256(append([]byte("block"), number[:]...))
+		m.BlockNumber++
+	}
+}
+
+func (m *MockArbOS) arbBlockHash(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := words(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	n := stygos.Uint64FromWord(w[0])
+	if n >= m.BlockNumber || m.BlockNumber-n > 256 {
+		return nil, ErrInvalidBlockNumber
+	}
+	h := m.BlockHashes[n]
+	return h[:], nil
+}
+
+func (m *MockArbOS) withdrawEth(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := words(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return m.record(stygos.AddressFromWord(w[0]), nil), nil
+}
+
+func (m *MockArbOS) sendTxToL1(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := words(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	data, err := decodeBytes(args, w[1])
+	if err != nil {
+		return nil, err
+	}
+	return m.record(stygos.AddressFromWord(w[0]), data), nil
+}
+
+// record appends an L2 to L1 message sent by the calling contract and
+// returns its encoded id.
+func (m *MockArbOS) record(destination stygos.Address, data []byte) []byte {
+	id := uint64(len(m.Messages))
+	m.Messages = append(m.Messages, L1Message{
+		ID:          id,
+		Sender:      stygos.GetMsgSender(),
+		Destination: destination,
+		Value:       stygos.U256FromBig(stygos.GetMsgValue()),
+		Data:        data,
+	})
+	w := stygos.WordFromUint64(id)
+	return w[:]
+}
+
+func (m *MockArbOS) uint64Handler(v *uint64) stygos.Handler {
+	return func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w := stygos.WordFromUint64(*v)
+		return w[:], nil
+	}
+}
+
+func (m *MockArbOS) u256Handler(v *stygos.U256) stygos.Handler {
+	return func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w := v.Word()
+		return w[:], nil
+	}
+}
+
+func (m *MockArbOS) boolHandler(v *bool) stygos.Handler {
+	return func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		var w stygos.Word
+		if *v {
+			w[31] = 1
+		}
+		return w[:], nil
+	}
+}
+
+// encodeWords concatenates words into return data.
+func encodeWords(ws ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(ws))
+	for _, w := range ws {
+		out = append(out, w[:]...)
+	}
+	return out
+}
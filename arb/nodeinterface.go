@@ -0,0 +1,68 @@
+package arb
+
+import "github.com/rafaelescrich/stygos"
+
+// NodeInterface selectors
+var (
+	selGasEstimateL1Component = stygos.Selector{0x77, 0xd4, 0x88, 0xa2} // gasEstimateL1Component(address,bool,bytes)
+	selNitroGenesisBlock      = stygos.Selector{0x93, 0xa2, 0xfe, 0x21} // nitroGenesisBlock()
+	selL2BlockRangeForL1      = stygos.Selector{0x48, 0xe7, 0xf8, 0x11} // l2BlockRangeForL1(uint64)
+)
+
+// NodeInterface is a virtual contract served by Arbitrum nodes: it only
+// answers eth_call and eth_estimateGas and does not exist on chain. The
+// bindings below work in tests and in off-chain calls; on chain they return
+// whatever the empty account at NodeInterfaceAddress returns, which decodes
+// as ErrBadReturn.
+
+// L1Component is the L1 part of the gas estimate for a transaction.
+type L1Component struct {
+	GasEstimateForL1  uint64
+	BaseFee           stygos.U256
+	L1BaseFeeEstimate stygos.U256
+}
+
+// GasEstimateL1Component estimates the L1 gas a transaction to to with
+// calldata would use.
+func GasEstimateL1Component(to stygos.Address, contractCreation bool, calldata []byte) (L1Component, error) {
+	var create stygos.Word
+	if contractCreation {
+		create[31] = 1
+	}
+	data := encodeCall(selGasEstimateL1Component, stygos.PadAddress(to), create, stygos.WordFromUint64(0x60))
+	data = appendBytes(data, calldata)
+
+	ret, err := stygos.StaticCall(NodeInterfaceAddress, data)
+	if err != nil {
+		return L1Component{}, err
+	}
+	w, err := words(ret, 3)
+	if err != nil {
+		return L1Component{}, err
+	}
+	return L1Component{
+		GasEstimateForL1:  stygos.Uint64FromWord(w[0]),
+		BaseFee:           stygos.U256FromWord(w[1]),
+		L1BaseFeeEstimate: stygos.U256FromWord(w[2]),
+	}, nil
+}
+
+// NitroGenesisBlock returns the L2 block number at which the chain moved to
+// Nitro.
+func NitroGenesisBlock() (uint64, error) {
+	return staticUint64(NodeInterfaceAddress, encodeCall(selNitroGenesisBlock))
+}
+
+// L2BlockRangeForL1 returns the first and last L2 blocks produced while the
+// L1 block number was l1Block.
+func L2BlockRangeForL1(l1Block uint64) (first, last uint64, err error) {
+	ret, err := stygos.StaticCall(NodeInterfaceAddress, encodeCall(selL2BlockRangeForL1, stygos.WordFromUint64(l1Block)))
+	if err != nil {
+		return 0, 0, err
+	}
+	w, err := words(ret, 2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return stygos.Uint64FromWord(w[0]), stygos.Uint64FromWord(w[1]), nil
+}
@@ -0,0 +1,226 @@
+package arb
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// ArbRetryableTxAddress is the precompile managing retryable tickets on L2.
+var ArbRetryableTxAddress = stygos.Address{19: 0x6e}
+
+// Retryable errors
+var (
+	ErrBadTicket    = errors.New("arb: malformed retryable ticket calldata")
+	ErrNotL1Sender  = errors.New("arb: caller is not the aliased L1 contract")
+	ErrDepositShort = errors.New("arb: value does not cover the retryable deposit")
+)
+
+// Retryable selectors
+var (
+	selCreateRetryableTicket = stygos.Selector{0x67, 0x9b, 0x6d, 0xed} // createRetryableTicket(address,uint256,uint256,address,address,uint256,uint256,bytes)
+	selRedeem                = stygos.Selector{0xed, 0xa1, 0x12, 0x2c} // redeem(bytes32)
+	selGetTimeout            = stygos.Selector{0x9f, 0x10, 0x25, 0xc6} // getTimeout(bytes32)
+	selKeepalive             = stygos.Selector{0xf0, 0xb2, 0x1a, 0x41} // keepalive(bytes32)
+	selGetBeneficiary        = stygos.Selector{0xba, 0x20, 0xdd, 0xa4} // getBeneficiary(bytes32)
+	selCancel                = stygos.Selector{0xc4, 0xd2, 0x52, 0xf5} // cancel(bytes32)
+	selGetLifetime           = stygos.Selector{0x81, 0xe6, 0xe0, 0x83} // getLifetime()
+)
+
+// aliasOffset is added to the address of an L1 contract when it sends a
+// message to L2, so it cannot impersonate an L2 contract at the same
+// address.
+var aliasOffset = stygos.Address{
+	0x11, 0x11, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x11, 0x11,
+}
+
+// ApplyL1ToL2Alias returns the address an L1 contract appears as on L2.
+func ApplyL1ToL2Alias(l1 stygos.Address) stygos.Address {
+	var out stygos.Address
+	carry := 0
+	for i := 19; i >= 0; i-- {
+		sum := int(l1[i]) + int(aliasOffset[i]) + carry
+		out[i] = byte(sum)
+		carry = sum >> 8
+	}
+	return out
+}
+
+// UndoL1ToL2Alias returns the L1 address behind an aliased L2 sender.
+func UndoL1ToL2Alias(l2 stygos.Address) stygos.Address {
+	var out stygos.Address
+	borrow := 0
+	for i := 19; i >= 0; i-- {
+		diff := int(l2[i]) - int(aliasOffset[i]) - borrow
+		borrow = 0
+		if diff < 0 {
+			diff += 256
+			borrow = 1
+		}
+		out[i] = byte(diff)
+	}
+	return out
+}
+
+// RequireL1Sender checks that the current call is an L1 to L2 message (such
+// as an executed retryable ticket) sent by the contract l1 on the parent
+// chain.
+func RequireL1Sender(l1 stygos.Address) error {
+	if stygos.GetMsgSender() != ApplyL1ToL2Alias(l1) {
+		return ErrNotL1Sender
+	}
+	return nil
+}
+
+// RetryableTicket holds the parameters of Inbox.createRetryableTicket.
+type RetryableTicket struct {
+	To                     stygos.Address // destination on the child chain
+	L2CallValue            stygos.U256    // value sent with the call
+	MaxSubmissionCost      stygos.U256    // covers storing the ticket
+	ExcessFeeRefundAddress stygos.Address // receives unused submission and gas fees
+	CallValueRefundAddress stygos.Address // receives L2CallValue if the ticket is cancelled or expires
+	GasLimit               uint64         // gas for the auto-redeem, 0 to skip it
+	MaxFeePerGas           stygos.U256    // gas price bid for the auto-redeem
+	Data                   []byte         // calldata for To
+}
+
+// SubmissionFee returns the minimum MaxSubmissionCost for a ticket carrying
+// dataLength bytes at the given parent chain base fee, following
+// Inbox.calculateRetryableSubmissionFee: (1400 + 6*dataLength) * baseFee.
+func SubmissionFee(dataLength int, baseFee stygos.U256) stygos.U256 {
+	return stygos.NewU256(1400 + 6*uint64(dataLength)).Mul(baseFee)
+}
+
+// Deposit returns the value that must accompany the ticket:
+// L2CallValue + MaxSubmissionCost + GasLimit*MaxFeePerGas.
+func (t RetryableTicket) Deposit() stygos.U256 {
+	gas := stygos.NewU256(t.GasLimit).Mul(t.MaxFeePerGas)
+	return t.L2CallValue.Add(t.MaxSubmissionCost).Add(gas)
+}
+
+// Calldata returns the ABI encoded createRetryableTicket call.
+func (t RetryableTicket) Calldata() []byte {
+	data := encodeCall(selCreateRetryableTicket,
+		stygos.PadAddress(t.To),
+		t.L2CallValue.Word(),
+		t.MaxSubmissionCost.Word(),
+		stygos.PadAddress(t.ExcessFeeRefundAddress),
+		stygos.PadAddress(t.CallValueRefundAddress),
+		stygos.WordFromUint64(t.GasLimit),
+		t.MaxFeePerGas.Word(),
+		stygos.WordFromUint64(8*32),
+	)
+	return appendBytes(data, t.Data)
+}
+
+// DecodeRetryableTicket parses createRetryableTicket calldata, including the
+// selector.
+func DecodeRetryableTicket(calldata []byte) (RetryableTicket, error) {
+	var sel stygos.Selector
+	copy(sel[:], calldata)
+	if len(calldata) < 4 || sel != selCreateRetryableTicket {
+		return RetryableTicket{}, ErrBadTicket
+	}
+	args := calldata[4:]
+	w, err := words(args, 8)
+	if err != nil {
+		return RetryableTicket{}, ErrBadTicket
+	}
+	data, err := decodeBytes(args, w[7])
+	if err != nil {
+		return RetryableTicket{}, ErrBadTicket
+	}
+	return RetryableTicket{
+		To:                     stygos.AddressFromWord(w[0]),
+		L2CallValue:            stygos.U256FromWord(w[1]),
+		MaxSubmissionCost:      stygos.U256FromWord(w[2]),
+		ExcessFeeRefundAddress: stygos.AddressFromWord(w[3]),
+		CallValueRefundAddress: stygos.AddressFromWord(w[4]),
+		GasLimit:               stygos.Uint64FromWord(w[5]),
+		MaxFeePerGas:           stygos.U256FromWord(w[6]),
+		Data:                   data,
+	}, nil
+}
+
+// CreateRetryableTicket submits t to the delayed inbox of a child chain
+// (for example an Orbit chain settling on Arbitrum) with its deposit as
+// value, and returns the message number assigned by the inbox.
+func CreateRetryableTicket(inbox stygos.Address, t RetryableTicket) (stygos.U256, error) {
+	ret, err := stygos.Call(inbox, t.Deposit().Word(), t.Calldata())
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	w, err := words(ret, 1)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	return stygos.U256FromWord(w[0]), nil
+}
+
+// Redeem manually redeems a retryable ticket whose auto-redeem failed and
+// returns the hash of the redeem transaction.
+func Redeem(ticketID stygos.Word) (stygos.Word, error) {
+	ret, err := stygos.Call(ArbRetryableTxAddress, stygos.Word{}, encodeCall(selRedeem, ticketID))
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	w, err := words(ret, 1)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	return w[0], nil
+}
+
+// Keepalive extends the lifetime of a ticket by one lifetime period and
+// returns the new timeout.
+func Keepalive(ticketID stygos.Word) (uint64, error) {
+	ret, err := stygos.Call(ArbRetryableTxAddress, stygos.Word{}, encodeCall(selKeepalive, ticketID))
+	if err != nil {
+		return 0, err
+	}
+	w, err := words(ret, 1)
+	if err != nil {
+		return 0, err
+	}
+	return stygos.Uint64FromWord(w[0]), nil
+}
+
+// Cancel cancels a ticket, refunding its call value to the beneficiary.
+// Only the beneficiary may cancel.
+func Cancel(ticketID stygos.Word) error {
+	_, err := stygos.Call(ArbRetryableTxAddress, stygos.Word{}, encodeCall(selCancel, ticketID))
+	return err
+}
+
+// GetTimeout returns the timestamp at which a ticket expires.
+func GetTimeout(ticketID stygos.Word) (uint64, error) {
+	return staticUint64(ArbRetryableTxAddress, encodeCall(selGetTimeout, ticketID))
+}
+
+// GetBeneficiary returns the address that may cancel a ticket and receives
+// its call value when it is cancelled or expires.
+func GetBeneficiary(ticketID stygos.Word) (stygos.Address, error) {
+	w, err := staticWord(ArbRetryableTxAddress, encodeCall(selGetBeneficiary, ticketID))
+	return stygos.AddressFromWord(w), err
+}
+
+// GetLifetime returns the number of seconds a ticket lives before expiring.
+func GetLifetime() (uint64, error) {
+	return staticUint64(ArbRetryableTxAddress, encodeCall(selGetLifetime))
+}
+
+// decodeBytes decodes a dynamic bytes argument whose head holds offset.
+func decodeBytes(args []byte, offset stygos.Word) ([]byte, error) {
+	off := stygos.Uint64FromWord(offset)
+	if off > uint64(len(args)) || uint64(len(args))-off < 32 {
+		return nil, ErrBadReturn
+	}
+	var lengthWord stygos.Word
+	copy(lengthWord[:], args[off:])
+	length := stygos.Uint64FromWord(lengthWord)
+	if length > uint64(len(args))-off-32 {
+		return nil, ErrBadReturn
+	}
+	return append([]byte{}, args[off+32:off+32+length]...), nil
+}
@@ -0,0 +1,155 @@
+package arb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func addressFromHex(s string) stygos.Address {
+	var a stygos.Address
+	b, _ := hex.DecodeString(s)
+	copy(a[:], b)
+	return a
+}
+
+func TestAlias(t *testing.T) {
+	tests := []struct{ l1, l2 string }{
+		{"0000000000000000000000000000000000000000", "1111000000000000000000000000000000001111"},
+		{"ffffffffffffffffffffffffffffffffffffffff", "1111000000000000000000000000000000001110"},
+		{"00000000000000000000000000000000000000ff", "1111000000000000000000000000000000001210"},
+	}
+
+	for _, tt := range tests {
+		l1, l2 := addressFromHex(tt.l1), addressFromHex(tt.l2)
+		if got := ApplyL1ToL2Alias(l1); got != l2 {
+			t.Errorf("ApplyL1ToL2Alias(%s) failed. Expected %s, got %x", tt.l1, tt.l2, got)
+		}
+		if got := UndoL1ToL2Alias(l2); got != l1 {
+			t.Errorf("UndoL1ToL2Alias(%s) failed. Expected %s, got %x", tt.l2, tt.l1, got)
+		}
+	}
+}
+
+func TestRequireL1Sender(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	l1Gateway := stygos.Address{0x42}
+	mock.Sender = ApplyL1ToL2Alias(l1Gateway)
+	if err := RequireL1Sender(l1Gateway); err != nil {
+		t.Errorf("RequireL1Sender failed for aliased sender: %v", err)
+	}
+
+	// The unaliased address is an L2 account and must be rejected
+	mock.Sender = l1Gateway
+	if err := RequireL1Sender(l1Gateway); err != ErrNotL1Sender {
+		t.Errorf("RequireL1Sender failed. Expected ErrNotL1Sender, got %v", err)
+	}
+}
+
+func TestRetryableTicketCalldata(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	ticket := RetryableTicket{
+		To:                     stygos.Address{0x01},
+		L2CallValue:            stygos.NewU256(1000),
+		MaxSubmissionCost:      SubmissionFee(70, stygos.NewU256(30e9)),
+		ExcessFeeRefundAddress: stygos.Address{0x02},
+		CallValueRefundAddress: stygos.Address{0x03},
+		GasLimit:               100000,
+		MaxFeePerGas:           stygos.NewU256(1e8),
+		Data:                   bytes.Repeat([]byte{0xcd}, 70),
+	}
+
+	if fee := ticket.MaxSubmissionCost; fee != stygos.NewU256((1400+6*70)*30e9) {
+		t.Errorf("SubmissionFee failed. Got %v", fee.Big())
+	}
+	want := stygos.NewU256(1000 + (1400+6*70)*30e9 + 100000*1e8)
+	if got := ticket.Deposit(); got != want {
+		t.Errorf("Deposit failed. Expected %v, got %v", want.Big(), got.Big())
+	}
+
+	calldata := ticket.Calldata()
+	if len(calldata) != 4+8*32+32+96 {
+		t.Errorf("Calldata failed. Expected %d bytes, got %d", 4+8*32+32+96, len(calldata))
+	}
+	decoded, err := DecodeRetryableTicket(calldata)
+	if err != nil {
+		t.Fatalf("DecodeRetryableTicket failed: %v", err)
+	}
+	if decoded.To != ticket.To || decoded.GasLimit != ticket.GasLimit || decoded.MaxFeePerGas != ticket.MaxFeePerGas ||
+		decoded.Deposit() != ticket.Deposit() || !bytes.Equal(decoded.Data, ticket.Data) {
+		t.Errorf("DecodeRetryableTicket failed. Got %+v", decoded)
+	}
+
+	if _, err := DecodeRetryableTicket(calldata[:100]); err != ErrBadTicket {
+		t.Errorf("DecodeRetryableTicket failed. Expected ErrBadTicket, got %v", err)
+	}
+}
+
+func TestCreateRetryableTicket(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xbb}
+	mock.SetBalance(mock.Contract, big.NewInt(1e18))
+	stygos.UseRuntime(mock)
+	inboxAddr := stygos.Address{0x1b}
+	inbox := InstallMockInbox(mock, inboxAddr)
+
+	ticket := RetryableTicket{
+		To:                stygos.Address{0x01},
+		MaxSubmissionCost: SubmissionFee(4, stygos.NewU256(1e9)),
+		GasLimit:          50000,
+		MaxFeePerGas:      stygos.NewU256(1e8),
+		Data:              []byte{1, 2, 3, 4},
+	}
+	n, err := CreateRetryableTicket(inboxAddr, ticket)
+	if err != nil || !n.IsZero() {
+		t.Fatalf("CreateRetryableTicket failed. Got %v, %v", n, err)
+	}
+	if len(inbox.Tickets) != 1 || inbox.Senders[0] != mock.Contract || inbox.Values[0] != ticket.Deposit() {
+		t.Errorf("CreateRetryableTicket failed to reach the inbox: %+v", inbox)
+	}
+
+	// Sending less than the deposit reverts
+	short := ticket.Calldata()
+	if _, err := stygos.Call(inboxAddr, stygos.WordFromUint64(1), short); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Inbox failed. Expected revert on short deposit, got %v", err)
+	}
+}
+
+func TestArbRetryableTx(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xaa}
+	stygos.UseRuntime(mock)
+	arbos := InstallMock(mock)
+
+	id := stygos.Word{0x7}
+	arbos.Retryables[id] = &MockRetryable{Timeout: 1000, Beneficiary: mock.Contract}
+
+	if lifetime, err := GetLifetime(); err != nil || lifetime != 7*24*60*60 {
+		t.Errorf("GetLifetime failed. Got %d, %v", lifetime, err)
+	}
+	if timeout, err := Keepalive(id); err != nil || timeout != 1000+7*24*60*60 {
+		t.Errorf("Keepalive failed. Got %d, %v", timeout, err)
+	}
+	if b, err := GetBeneficiary(id); err != nil || b != mock.Contract {
+		t.Errorf("GetBeneficiary failed. Got %x, %v", b, err)
+	}
+	if _, err := Redeem(id); err != nil || !arbos.Retryables[id].Redeemed {
+		t.Errorf("Redeem failed: %v", err)
+	}
+	if _, err := GetTimeout(id); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("GetTimeout failed. Expected revert for redeemed ticket, got %v", err)
+	}
+
+	other := stygos.Word{0x8}
+	arbos.Retryables[other] = &MockRetryable{Beneficiary: stygos.Address{0x99}}
+	if err := Cancel(other); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Cancel failed. Expected revert for non-beneficiary, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package stygos
+
+import "errors"
+
+// ArbOS versions with distinct host feature sets
+const (
+	ArbOS20 = 20 // Stylus testnets: storage_store_bytes32 and memory_grow
+	ArbOS30 = 30 // Stylus launch: storage cache, transient storage
+	ArbOS40 = 40 // EIP-7702 delegated accounts
+)
+
+// ErrUnsupported is returned by host features the target ArbOS version
+// does not provide.
+var ErrUnsupported = errors.New("stygos: not supported by the target ArbOS version")
+
+// Capability is a host feature that depends on the ArbOS version.
+type Capability uint8
+
+const (
+	// CapStorageCache: storage writes are cached and flushed by the host
+	// rather than written by each store.
+	CapStorageCache Capability = iota
+
+	// CapTransientStorage: TransientLoad and TransientStore, the EIP-1153
+	// storage cleared after each transaction.
+	CapTransientStorage
+
+	// CapDelegatedAccounts: an EOA may carry EIP-7702 delegation code, so
+	// code at an address no longer proves it is a contract.
+	CapDelegatedAccounts
+
+	// CapP256Verify: the RIP-7212 secp256r1 verification precompile at
+	// 0x100.
+	CapP256Verify
+)
+
+// capabilitySince is the first ArbOS version providing each capability.
+var capabilitySince = [...]uint64{
+	CapStorageCache:      ArbOS30,
+	CapTransientStorage:  ArbOS30,
+	CapDelegatedAccounts: ArbOS40,
+	CapP256Verify:        ArbOS30,
+}
+
+// TargetArbOS returns the ArbOS version the contract runs against. A TinyGo
+// build targets ArbOS 30 unless built with the arbos20 or arbos40 tag,
+// which also select the matching hostio imports; on a MockRuntime it is the
+// runtime's ArbOS field, so tests can run a contract as each version would.
+func TargetArbOS() uint64 {
+	return hostArbOS()
+}
+
+// Supports reports whether the target ArbOS version provides c. Code that
+// uses optional features checks it to fall back, or lets the feature
+// return ErrUnsupported.
+func Supports(c Capability) bool {
+	return int(c) < len(capabilitySince) && TargetArbOS() >= capabilitySince[c]
+}
+
+// TransientLoad returns the transient storage slot key of this contract,
+// or ErrUnsupported before ArbOS 30.
+func TransientLoad(key Word) (Word, error) {
+	var value Word
+	if !Supports(CapTransientStorage) || TransientLoadBytes32 == nil {
+		return value, ErrUnsupported
+	}
+	TransientLoadBytes32(&key[0], &value[0])
+	return value, nil
+}
+
+// TransientStore writes the transient storage slot key of this contract,
+// which reads back until the transaction ends, or returns ErrUnsupported
+// before ArbOS 30. Reentrancy locks and per-transaction caches belong here
+// rather than in storage, where they would pay for persistent writes.
+func TransientStore(key, value Word) error {
+	if !Supports(CapTransientStorage) || TransientStoreBytes32 == nil {
+		return ErrUnsupported
+	}
+	TransientStoreBytes32(&key[0], &value[0])
+	return nil
+}
@@ -0,0 +1,6 @@
+//go:build arbos20
+
+package stygos
+
+// buildArbOS is the ArbOS version selected by the arbos20 build tag.
+const buildArbOS = ArbOS20
@@ -0,0 +1,6 @@
+//go:build arbos40 && !arbos20
+
+package stygos
+
+// buildArbOS is the ArbOS version selected by the arbos40 build tag.
+const buildArbOS = ArbOS40
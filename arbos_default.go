@@ -0,0 +1,6 @@
+//go:build !arbos20 && !arbos40
+
+package stygos
+
+// buildArbOS is the ArbOS version targeted when no arbos build tag is set.
+const buildArbOS = ArbOS30
@@ -0,0 +1,108 @@
+package stygos
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSupports(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	if TargetArbOS() != buildArbOS {
+		t.Errorf("TargetArbOS() = %d, want %d", TargetArbOS(), buildArbOS)
+	}
+	tests := []struct {
+		arbos     uint64
+		cache     bool
+		transient bool
+		delegated bool
+	}{
+		{ArbOS20, false, false, false},
+		{ArbOS30, true, true, false},
+		{32, true, true, false},
+		{ArbOS40, true, true, true},
+	}
+	for _, tt := range tests {
+		mock.ArbOS = tt.arbos
+		if got := Supports(CapStorageCache); got != tt.cache {
+			t.Errorf("ArbOS %d: Supports(CapStorageCache) = %v, want %v", tt.arbos, got, tt.cache)
+		}
+		if got := Supports(CapTransientStorage); got != tt.transient {
+			t.Errorf("ArbOS %d: Supports(CapTransientStorage) = %v, want %v", tt.arbos, got, tt.transient)
+		}
+		if got := Supports(CapDelegatedAccounts); got != tt.delegated {
+			t.Errorf("ArbOS %d: Supports(CapDelegatedAccounts) = %v, want %v", tt.arbos, got, tt.delegated)
+		}
+		if got := Supports(CapP256Verify); got != tt.transient {
+			t.Errorf("ArbOS %d: Supports(CapP256Verify) = %v, want %v", tt.arbos, got, tt.transient)
+		}
+	}
+	if Supports(Capability(200)) {
+		t.Errorf("Supports(unknown) = true, want false")
+	}
+}
+
+func TestTransientStorage(t *testing.T) {
+	mock := NewMockRuntime()
+	mock.ArbOS = ArbOS30
+	mock.Contract = Address{0xa1}
+	UseRuntime(mock)
+
+	key := WordFromUint64(1)
+	if err := TransientStore(key, WordFromUint64(7)); err != nil {
+		t.Fatalf("TransientStore failed: %v", err)
+	}
+	if got, err := TransientLoad(key); err != nil || Uint64FromWord(got) != 7 {
+		t.Errorf("TransientLoad = %d, %v, want 7", Uint64FromWord(got), err)
+	}
+	if got := StorageLoad(key); got != (Word{}) {
+		t.Errorf("StorageLoad = %x, want transient storage kept apart", got)
+	}
+
+	// Each contract sees its own, and a reverted call's writes are undone
+	callee := Address{0xb2}
+	var seen Word
+	mock.Deploy(callee, func(input []byte) ([]byte, error) {
+		seen, _ = TransientLoad(key)
+		if len(input) > 0 {
+			TransientStore(key, WordFromUint64(11))
+			return nil, errors.New("revert")
+		}
+		TransientStore(key, WordFromUint64(9))
+		return nil, nil
+	})
+	if _, err := Call(callee, Word{}, nil); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if seen != (Word{}) {
+		t.Errorf("callee TransientLoad = %x, want zero", seen)
+	}
+	if _, err := Call(callee, Word{}, []byte{1}); err == nil {
+		t.Fatal("Call = nil, want the revert")
+	}
+	if _, err := Call(callee, Word{}, nil); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if seen != WordFromUint64(9) {
+		t.Errorf("callee TransientLoad = %x, want 9 from before the reverted call", seen)
+	}
+	if got, _ := TransientLoad(key); Uint64FromWord(got) != 7 {
+		t.Errorf("caller TransientLoad = %d, want 7", Uint64FromWord(got))
+	}
+
+	// The transaction ends
+	mock.ResetGas()
+	if got, _ := TransientLoad(key); got != (Word{}) {
+		t.Errorf("TransientLoad after ResetGas = %x, want zero", got)
+	}
+
+	// Before ArbOS 30 the feature is reported missing
+	mock.ArbOS = ArbOS20
+	if _, err := TransientLoad(key); err != ErrUnsupported {
+		t.Errorf("TransientLoad at ArbOS 20 = %v, want ErrUnsupported", err)
+	}
+	if err := TransientStore(key, WordFromUint64(1)); err != ErrUnsupported {
+		t.Errorf("TransientStore at ArbOS 20 = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,32 @@
+package stygos
+
+// ArgWords splits the arguments of a handler taking n static ABI values
+// into their words, failing with ErrInvalidInput unless args holds exactly
+// n words. It is for handlers registered with Router.Handle; typed handlers
+// get their arguments decoded by HandleTyped.
+func ArgWords(args []byte, n int) ([]Word, error) {
+	if n < 0 || len(args) != 32*n {
+		return nil, ErrInvalidInput
+	}
+	w := make([]Word, n)
+	for i := range w {
+		copy(w[i][:], args[32*i:])
+	}
+	return w, nil
+}
+
+// ArgBytes returns the dynamic bytes argument whose offset is head word i
+// of args, failing with ErrInvalidInput if the offset or length points
+// outside args. The result aliases args.
+func ArgBytes(args []byte, i int) ([]byte, error) {
+	d := &argDecoder{args: args}
+	head, ok := d.word(uint64(32 * i))
+	if i < 0 || !ok {
+		return nil, ErrInvalidInput
+	}
+	b := d.dynamic(head)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return b, nil
+}
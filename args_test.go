@@ -0,0 +1,56 @@
+package stygos
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestArgWords(t *testing.T) {
+	args := append(appendWord(nil, WordFromUint64(7)), appendWord(nil, WordFromUint64(9))...)
+	w, err := ArgWords(args, 2)
+	if err != nil || len(w) != 2 || w[0] != WordFromUint64(7) || w[1] != WordFromUint64(9) {
+		t.Errorf("ArgWords failed. Expected words 7 and 9, got %x, %v", w, err)
+	}
+
+	// Short, long and partial arguments are rejected
+	for _, n := range []int{1, 3} {
+		if _, err := ArgWords(args, n); err != ErrInvalidInput {
+			t.Errorf("ArgWords failed. Expected ErrInvalidInput for %d words of 2, got %v", n, err)
+		}
+	}
+	if _, err := ArgWords(args[:63], 2); err != ErrInvalidInput {
+		t.Errorf("ArgWords failed. Expected ErrInvalidInput for 63 bytes, got %v", err)
+	}
+}
+
+func TestArgBytes(t *testing.T) {
+	// f(uint256 n, bytes data) with data = "hello"
+	args := appendWord(nil, WordFromUint64(1))
+	args = appendWord(args, WordFromUint64(64))
+	args = appendWord(args, WordFromUint64(5))
+	args = append(args, "hello"...)
+	args = append(args, make([]byte, 27)...)
+
+	b, err := ArgBytes(args, 1)
+	if err != nil || !bytes.Equal(b, []byte("hello")) {
+		t.Errorf("ArgBytes failed. Expected hello, got %q, %v", b, err)
+	}
+
+	tests := []struct {
+		name string
+		args []byte
+		i    int
+	}{
+		{"head past the arguments", args, 4},
+		{"negative head", args, -1},
+		{"empty arguments", nil, 0},
+		{"offset past the arguments", args[:64], 1},
+		{"length past the arguments", args[:100], 1},
+		{"offset over 64 bits", append(appendWord(nil, Word{0: 1}), args[32:]...), 0},
+	}
+	for _, tt := range tests {
+		if _, err := ArgBytes(tt.args, tt.i); err != ErrInvalidInput {
+			t.Errorf("ArgBytes failed for %s. Expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package blake2b computes BLAKE2b hashes with the EIP-152 compression
+// precompile, for commitments made by chains and protocols built on it,
+// such as Zcash (personalized hashes) and Filecoin (BLAKE2b-256).
+//
+// F exposes the precompile's compression function. The hashes built on
+// it fall back to a Go implementation of the same function when the
+// precompile answers with no data, as on a chain without it.
+package blake2b
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// PrecompileAddress is the EIP-152 BLAKE2 F precompile.
+var PrecompileAddress = stygos.Address{19: 0x09}
+
+// BLAKE2b errors
+var (
+	ErrSize        = errors.New("blake2b: invalid digest size")
+	ErrKeySize     = errors.New("blake2b: key longer than 64 bytes")
+	ErrUnavailable = errors.New("blake2b: precompile not available")
+)
+
+// Sizes
+const (
+	BlockSize = 128
+	MaxSize   = 64
+	Rounds    = 12 // rounds of BLAKE2b; F accepts any number
+)
+
+// iv is the BLAKE2b initialization vector, that of SHA-512.
+var iv = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+// F runs the compression function with the precompile: rounds rounds
+// mixing the message block m into the state h, with the byte offset t and
+// the final block flag.
+func F(rounds uint32, h [8]uint64, m [16]uint64, t [2]uint64, final bool) ([8]uint64, error) {
+	input := make([]byte, 213)
+	binary.BigEndian.PutUint32(input, rounds)
+	for i, v := range h {
+		binary.LittleEndian.PutUint64(input[4+8*i:], v)
+	}
+	for i, v := range m {
+		binary.LittleEndian.PutUint64(input[68+8*i:], v)
+	}
+	binary.LittleEndian.PutUint64(input[196:], t[0])
+	binary.LittleEndian.PutUint64(input[204:], t[1])
+	if final {
+		input[212] = 1
+	}
+
+	ret, err := stygos.StaticCall(PrecompileAddress, input)
+	if err != nil {
+		return h, err
+	}
+	if len(ret) != 64 {
+		return h, ErrUnavailable
+	}
+	var out [8]uint64
+	for i := range out {
+		out[i] = binary.LittleEndian.Uint64(ret[8*i:])
+	}
+	return out, nil
+}
+
+// Params configures a hash: its size in bytes (1 to 64), an optional key
+// of up to 64 bytes, and the salt and personalization strings.
+type Params struct {
+	Size     int
+	Key      []byte
+	Salt     [16]byte
+	Personal [16]byte
+}
+
+// Sum returns the BLAKE2b hash of data under p.
+func (p *Params) Sum(data []byte) ([]byte, error) {
+	if p.Size < 1 || p.Size > MaxSize {
+		return nil, ErrSize
+	}
+	if len(p.Key) > MaxSize {
+		return nil, ErrKeySize
+	}
+
+	// Parameter block: digest length, key length, fanout 1, depth 1, then
+	// the salt and personalization in words 4..7
+	h := iv
+	h[0] ^= uint64(p.Size) | uint64(len(p.Key))<<8 | 1<<16 | 1<<24
+	h[4] ^= binary.LittleEndian.Uint64(p.Salt[:8])
+	h[5] ^= binary.LittleEndian.Uint64(p.Salt[8:])
+	h[6] ^= binary.LittleEndian.Uint64(p.Personal[:8])
+	h[7] ^= binary.LittleEndian.Uint64(p.Personal[8:])
+
+	// A key is hashed as a first block of its own
+	if len(p.Key) > 0 {
+		block := make([]byte, BlockSize, BlockSize+len(data))
+		copy(block, p.Key)
+		data = append(block, data...)
+	}
+
+	compress := F
+	var t uint64
+	for {
+		var block [BlockSize]byte
+		n := copy(block[:], data)
+		data = data[n:]
+		t += uint64(n)
+		final := len(data) == 0
+
+		var m [16]uint64
+		for i := range m {
+			m[i] = binary.LittleEndian.Uint64(block[8*i:])
+		}
+		next, err := compress(Rounds, h, m, [2]uint64{t, 0}, final)
+		if err == ErrUnavailable {
+			compress = FPure
+			next, err = compress(Rounds, h, m, [2]uint64{t, 0}, final)
+		}
+		if err != nil {
+			return nil, err
+		}
+		h = next
+		if final {
+			break
+		}
+	}
+
+	out := make([]byte, MaxSize)
+	for i, v := range h {
+		binary.LittleEndian.PutUint64(out[8*i:], v)
+	}
+	return out[:p.Size], nil
+}
+
+// Sum256 returns the unkeyed 32-byte BLAKE2b hash of data, as used by
+// Filecoin.
+func Sum256(data []byte) (stygos.Word, error) {
+	var w stygos.Word
+	sum, err := (&Params{Size: 32}).Sum(data)
+	copy(w[:], sum)
+	return w, err
+}
+
+// Sum512 returns the unkeyed 64-byte BLAKE2b hash of data.
+func Sum512(data []byte) ([64]byte, error) {
+	var out [64]byte
+	sum, err := (&Params{Size: 64}).Sum(data)
+	copy(out[:], sum)
+	return out, err
+}
@@ -0,0 +1,94 @@
+package blake2b
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	xblake2b "golang.org/x/crypto/blake2b"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// EIP-152 test vector 5: the final compression of BLAKE2b-512("abc")
+func TestF(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	InstallMockPrecompile(mock)
+
+	h := iv
+	h[0] ^= 0x01010040
+	m := [16]uint64{0x636261}
+	want := mustHex("ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923")
+	for name, f := range map[string]func(uint32, [8]uint64, [16]uint64, [2]uint64, bool) ([8]uint64, error){"F": F, "FPure": FPure} {
+		out, err := f(12, h, m, [2]uint64{3, 0}, true)
+		got := make([]byte, 0, 64)
+		for _, v := range out {
+			got = append(got, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+		}
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s failed. Expected %x, got %x, %v", name, want, got, err)
+		}
+	}
+
+	// Input of the wrong length makes the precompile revert
+	if _, err := stygos.StaticCall(PrecompileAddress, make([]byte, 212)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("F failed. Expected short input to revert, got %v", err)
+	}
+}
+
+func TestSum(t *testing.T) {
+	for _, installed := range []bool{true, false} {
+		mock := stygos.NewMockRuntime()
+		stygos.UseRuntime(mock)
+		if installed {
+			InstallMockPrecompile(mock)
+		}
+
+		for _, n := range []int{0, 1, 127, 128, 129, 300} {
+			data := bytes.Repeat([]byte{0x5a}, n)
+			want256, want512 := xblake2b.Sum256(data), xblake2b.Sum512(data)
+			if got, err := Sum256(data); err != nil || got != stygos.Word(want256) {
+				t.Errorf("Sum256(%d bytes, precompile %v) failed. Expected %x, got %x, %v", n, installed, want256, got, err)
+			}
+			if got, err := Sum512(data); err != nil || got != want512 {
+				t.Errorf("Sum512(%d bytes, precompile %v) failed. Expected %x, got %x, %v", n, installed, want512, got, err)
+			}
+		}
+
+		key := []byte("secret")
+		x, _ := xblake2b.New(48, key)
+		x.Write([]byte("keyed"))
+		if got, err := (&Params{Size: 48, Key: key}).Sum([]byte("keyed")); err != nil || !bytes.Equal(got, x.Sum(nil)) {
+			t.Errorf("Sum keyed failed. Expected %x, got %x, %v", x.Sum(nil), got, err)
+		}
+	}
+
+	// Personalized hashes, checked against Python's hashlib.blake2b
+	p := &Params{Size: 32}
+	copy(p.Personal[:], "ZcashPoWParamsxx")
+	if got, _ := p.Sum([]byte("zcash")); hex.EncodeToString(got) != "408534f30011373cba8daec2a43eb81337a55d61ca1a80cb34d0ea40b7c03693" {
+		t.Errorf("Sum personalized failed, got %x", got)
+	}
+	p = &Params{Size: 20, Key: []byte("secret")}
+	copy(p.Salt[:], "0123456789abcdef")
+	copy(p.Personal[:], "ZcashPoWParamsxx")
+	if got, _ := p.Sum([]byte("abc")); hex.EncodeToString(got) != "b3ca6df9f48ae540b4fb75ccfe0736cf266c3b0c" {
+		t.Errorf("Sum salted failed, got %x", got)
+	}
+
+	if _, err := (&Params{Size: 65}).Sum(nil); err != ErrSize {
+		t.Errorf("Sum failed. Expected ErrSize, got %v", err)
+	}
+	if _, err := (&Params{Size: 32, Key: make([]byte, 65)}).Sum(nil); err != ErrKeySize {
+		t.Errorf("Sum failed. Expected ErrKeySize, got %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !tinygo
+
+package blake2b
+
+import (
+	"encoding/binary"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// InstallMockPrecompile deploys a Go implementation of the EIP-152
+// precompile on rt. Like the precompile it reverts for input that is not
+// 213 bytes or has a final flag other than 0 or 1.
+func InstallMockPrecompile(rt *stygos.MockRuntime) {
+	rt.Deploy(PrecompileAddress, func(input []byte) ([]byte, error) {
+		if len(input) != 213 || input[212] > 1 {
+			return nil, stygos.ErrInvalidInput
+		}
+		var h [8]uint64
+		var m [16]uint64
+		for i := range h {
+			h[i] = binary.LittleEndian.Uint64(input[4+8*i:])
+		}
+		for i := range m {
+			m[i] = binary.LittleEndian.Uint64(input[68+8*i:])
+		}
+		t := [2]uint64{binary.LittleEndian.Uint64(input[196:]), binary.LittleEndian.Uint64(input[204:])}
+		out, _ := FPure(binary.BigEndian.Uint32(input), h, m, t, input[212] == 1)
+		ret := make([]byte, 64)
+		for i, v := range out {
+			binary.LittleEndian.PutUint64(ret[8*i:], v)
+		}
+		return ret, nil
+	})
+}
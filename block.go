@@ -0,0 +1,66 @@
+package stygos
+
+// arbSysAddress is the ArbSys precompile, which serves L2 block hashes.
+var arbSysAddress = Address{19: 0x64}
+
+// selArbBlockHash is the selector of arbBlockHash(uint256).
+var selArbBlockHash = Selector{0x2b, 0x40, 0x7a, 0x82}
+
+// GetL1BlockNumber returns the L1 block number. On Arbitrum the block_number
+// hostio, like block.number in Solidity, reports an estimate of the latest
+// L1 block rather than the L2 block; use arb.ArbBlockNumber for the latter.
+func GetL1BlockNumber() uint64 {
+	return GetBlockNumber()
+}
+
+// GetBlockTimestamp returns the timestamp of the current block in seconds
+func GetBlockTimestamp() uint64 {
+	return BlockTimestamp()
+}
+
+// GetChainID returns the chain id, as the CHAINID opcode
+func GetChainID() uint64 {
+	return ChainID()
+}
+
+// BlockHash returns the hash of an L2 block through ArbSys.arbBlockHash. As
+// with the BLOCKHASH opcode, blocks other than the 256 most recent yield the
+// zero word; history.BlockHash reaches further back.
+func BlockHash(number uint64) Word {
+	var data [36]byte
+	copy(data[:4], selArbBlockHash[:])
+	n := WordFromUint64(number)
+	copy(data[4:], n[:])
+
+	ret, err := StaticCall(arbSysAddress, data[:])
+	var hash Word
+	if err != nil || len(ret) < 32 {
+		return hash
+	}
+	copy(hash[:], ret)
+	return hash
+}
+
+// GetBlobHash returns the versioned hash of the transaction's blob at
+// index, as the BLOBHASH opcode, or the zero word if there is none.
+// Arbitrum transactions carry no blobs and Stylus has no hostio for them,
+// so on chain it is always zero; a MockRuntime serves its BlobHashes, to
+// test contracts that also run on chains with blobs.
+func GetBlobHash(index uint64) Word {
+	var h Word
+	if BlobHash != nil {
+		BlobHash(index, &h[0])
+	}
+	return h
+}
+
+// GetBlobBaseFee returns the blob base fee in wei, as the BLOBBASEFEE
+// opcode, or ErrUnsupported on chains without blobs. Arbitrum is one of
+// them, as is a MockRuntime whose BlobBaseFee is nil.
+func GetBlobBaseFee() (U256, error) {
+	var fee Word
+	if BlobBaseFee == nil || !BlobBaseFee(&fee[0]) {
+		return U256{}, ErrUnsupported
+	}
+	return U256FromWord(fee), nil
+}
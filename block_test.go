@@ -0,0 +1,66 @@
+package stygos
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBlockHash(t *testing.T) {
+	mock := NewMockRuntime()
+	mock.Block = 42
+	mock.Time = 1700000000
+	UseRuntime(mock)
+
+	if SelectorOf("arbBlockHash(uint256)") != selArbBlockHash {
+		t.Errorf("arbBlockHash selector mismatch")
+	}
+	if n := GetL1BlockNumber(); n != 42 {
+		t.Errorf("GetL1BlockNumber failed. Expected 42, got %d", n)
+	}
+
+	if ts := GetBlockTimestamp(); ts != 1700000000 {
+		t.Errorf("GetBlockTimestamp failed. Expected 1700000000, got %d", ts)
+	}
+
+	mock.Chain = 42161
+	if id := GetChainID(); id != 42161 {
+		t.Errorf("GetChainID failed. Expected 42161, got %d", id)
+	}
+
+	// Without ArbSys the hash is unavailable
+	if h := BlockHash(1); h != (Word{}) {
+		t.Errorf("BlockHash failed. Expected zero, got %x", h)
+	}
+
+	mock.Deploy(arbSysAddress, func(input []byte) ([]byte, error) {
+		n := Uint64FromWord(wordAt(input[4:], 0))
+		h := WordFromUint64(n * 2)
+		return h[:], nil
+	})
+	if h := BlockHash(21); Uint64FromWord(h) != 42 {
+		t.Errorf("BlockHash failed. Expected 42, got %x", h)
+	}
+}
+
+func TestBlobs(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	if h := GetBlobHash(0); h != (Word{}) {
+		t.Errorf("GetBlobHash failed. Expected zero without blobs, got %x", h)
+	}
+	if _, err := GetBlobBaseFee(); err != ErrUnsupported {
+		t.Errorf("GetBlobBaseFee failed. Expected ErrUnsupported, got %v", err)
+	}
+
+	mock.BlobHashes = []Word{{0x01, 0xaa}, {0x01, 0xbb}}
+	mock.BlobBaseFee = big.NewInt(7)
+	if h := GetBlobHash(1); h != (Word{0x01, 0xbb}) {
+		t.Errorf("GetBlobHash failed. Expected 01bb.., got %x", h)
+	}
+	if h := GetBlobHash(2); h != (Word{}) {
+		t.Errorf("GetBlobHash failed. Expected zero past the last blob, got %x", h)
+	}
+	if fee, err := GetBlobBaseFee(); err != nil || fee != NewU256(7) {
+		t.Errorf("GetBlobBaseFee failed. Expected 7, got %v, %v", fee, err)
+	}
+}
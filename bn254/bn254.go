@@ -0,0 +1,162 @@
+// Package bn254 calls the alt_bn128 precompiles of EIP-196 and EIP-197,
+// point addition, scalar multiplication and the pairing check, and
+// verifies Groth16 and PLONK proofs with them.
+//
+// Points use the precompiles' encoding: big-endian 32-byte coordinates,
+// with the point at infinity as all zeros. A G2 coordinate is an element
+// a·i + b of F_p², encoded as a then b, imaginary part first; snarkjs
+// lists them the other way around.
+package bn254
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Precompile addresses
+var (
+	AddAddress     = stygos.Address{19: 0x06}
+	MulAddress     = stygos.Address{19: 0x07}
+	PairingAddress = stygos.Address{19: 0x08}
+)
+
+// BN254 errors
+var (
+	ErrInvalidPoint  = errors.New("bn254: invalid point")
+	ErrLength        = errors.New("bn254: mismatched pairing inputs")
+	ErrInputCount    = errors.New("bn254: wrong number of public inputs")
+	ErrNotInField    = errors.New("bn254: public input not below the group order")
+	ErrInvalidLength = errors.New("bn254: invalid encoding length")
+)
+
+var (
+	// P is the base field modulus.
+	P, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+
+	// R is the order of the groups, the scalar field of circuits over
+	// BN254.
+	R, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+)
+
+// G1 is a point of the curve y² = x³ + 3 over F_p.
+type G1 struct {
+	X, Y stygos.Word
+}
+
+// G2 is a point of the twist curve over F_p². Each coordinate is
+// (imaginary, real).
+type G2 struct {
+	X, Y [2]stygos.Word
+}
+
+// G1Generator is the generator (1, 2) of G1.
+var G1Generator = G1{X: stygos.WordFromUint64(1), Y: stygos.WordFromUint64(2)}
+
+// G2Generator is the generator of G2 used by EIP-197 and snarkjs.
+var G2Generator = G2{
+	X: [2]stygos.Word{
+		hexWord("198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2"),
+		hexWord("1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed"),
+	},
+	Y: [2]stygos.Word{
+		hexWord("090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b"),
+		hexWord("12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa"),
+	},
+}
+
+func hexWord(s string) stygos.Word {
+	w, err := stygos.WordFromHex(s)
+	if err != nil {
+		panic("bn254: invalid constant")
+	}
+	return w
+}
+
+// Bytes returns the 64-byte encoding x || y.
+func (p G1) Bytes() []byte {
+	return append(append(make([]byte, 0, 64), p.X[:]...), p.Y[:]...)
+}
+
+// Bytes returns the 128-byte encoding of p.
+func (p G2) Bytes() []byte {
+	out := make([]byte, 0, 128)
+	for _, w := range [...]stygos.Word{p.X[0], p.X[1], p.Y[0], p.Y[1]} {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+// G1FromBytes decodes a 64-byte G1 point. It is checked by the
+// precompiles when used.
+func G1FromBytes(b []byte) (G1, error) {
+	var p G1
+	if len(b) != 64 {
+		return p, ErrInvalidLength
+	}
+	copy(p.X[:], b[:32])
+	copy(p.Y[:], b[32:])
+	return p, nil
+}
+
+// G2FromBytes decodes a 128-byte G2 point.
+func G2FromBytes(b []byte) (G2, error) {
+	var p G2
+	if len(b) != 128 {
+		return p, ErrInvalidLength
+	}
+	copy(p.X[0][:], b[:32])
+	copy(p.X[1][:], b[32:64])
+	copy(p.Y[0][:], b[64:96])
+	copy(p.Y[1][:], b[96:])
+	return p, nil
+}
+
+// Neg returns -p.
+func Neg(p G1) G1 {
+	y := new(big.Int).SetBytes(p.Y[:])
+	if y.Sign() == 0 {
+		return p
+	}
+	y.Sub(P, y.Mod(y, P))
+	y.FillBytes(p.Y[:])
+	return p
+}
+
+// Add returns a + b.
+func Add(a, b G1) (G1, error) {
+	return call(AddAddress, append(a.Bytes(), b.Bytes()...))
+}
+
+// ScalarMul returns s·p.
+func ScalarMul(p G1, s stygos.Word) (G1, error) {
+	return call(MulAddress, append(p.Bytes(), s[:]...))
+}
+
+func call(precompile stygos.Address, input []byte) (G1, error) {
+	ret, err := stygos.StaticCall(precompile, input)
+	if err != nil || len(ret) != 64 {
+		return G1{}, ErrInvalidPoint
+	}
+	return G1FromBytes(ret)
+}
+
+// PairingCheck reports whether e(a[0], b[0]) · ... · e(a[n-1], b[n-1])
+// is one. It fails with ErrInvalidPoint if a point is not on its curve,
+// or a G2 point is not in the group.
+func PairingCheck(a []G1, b []G2) (bool, error) {
+	if len(a) != len(b) {
+		return false, ErrLength
+	}
+	input := make([]byte, 0, 192*len(a))
+	for i := range a {
+		input = append(input, a[i].Bytes()...)
+		input = append(input, b[i].Bytes()...)
+	}
+	ret, err := stygos.StaticCall(PairingAddress, input)
+	if err != nil || len(ret) != 32 {
+		return false, ErrInvalidPoint
+	}
+	return ret[31] == 1, nil
+}
@@ -0,0 +1,179 @@
+package bn254
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	InstallMockPrecompiles(mock)
+	return mock
+}
+
+func TestG1(t *testing.T) {
+	setup()
+
+	two, err := Add(G1Generator, G1Generator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2·(1, 2), from the EIP-196 test vectors
+	want := G1{
+		X: hexWord("030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd3"),
+		Y: hexWord("15ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4"),
+	}
+	if two != want {
+		t.Errorf("Add failed. Expected %x, got %x", want, two)
+	}
+	if got, _ := ScalarMul(G1Generator, stygos.WordFromUint64(2)); got != want {
+		t.Errorf("ScalarMul failed. Expected %x, got %x", want, got)
+	}
+	if got, _ := Add(two, Neg(two)); got != (G1{}) {
+		t.Errorf("Add of a point and its negation failed. Expected infinity, got %x", got)
+	}
+	if got := MulG1(G1Generator, R); got != (G1{}) {
+		t.Errorf("MulG1 by the group order failed. Expected infinity, got %x", got)
+	}
+
+	offCurve := G1Generator
+	offCurve.Y[31] = 3
+	if _, err := Add(G1Generator, offCurve); err != ErrInvalidPoint {
+		t.Errorf("Add off the curve failed. Expected %v, got %v", ErrInvalidPoint, err)
+	}
+}
+
+func TestPairingCheck(t *testing.T) {
+	setup()
+
+	// e(a·G1, b·G2) · e(-ab·G1, G2) = 1
+	a, b := big.NewInt(1234567), big.NewInt(7654321)
+	ab := new(big.Int).Mul(a, b)
+	p := MulG1(G1Generator, a)
+	q := MulG2(G2Generator, b)
+	negAB := Neg(MulG1(G1Generator, ab))
+
+	offTwist := G2Generator
+	offTwist.X[1][31] ^= 1
+
+	tests := []struct {
+		name string
+		g1   []G1
+		g2   []G2
+		want bool
+		err  error
+	}{
+		{"empty", nil, nil, true, nil},
+		{"bilinear", []G1{p, negAB}, []G2{q, G2Generator}, true, nil},
+		{"swapped", []G1{MulG1(G1Generator, b), negAB}, []G2{MulG2(G2Generator, a), G2Generator}, true, nil},
+		{"unbalanced", []G1{p, negAB}, []G2{q, q}, false, nil},
+		{"infinity", []G1{{}}, []G2{G2Generator}, true, nil},
+		{"off twist", []G1{p}, []G2{offTwist}, false, ErrInvalidPoint},
+		{"mismatched", []G1{p}, nil, false, ErrLength},
+	}
+	for _, tt := range tests {
+		got, err := PairingCheck(tt.g1, tt.g2)
+		if got != tt.want || err != tt.err {
+			t.Errorf("PairingCheck(%s) failed. Expected %v, %v, got %v, %v", tt.name, tt.want, tt.err, got, err)
+		}
+	}
+}
+
+func TestVerifyGroth16(t *testing.T) {
+	setup()
+
+	trapdoor := &Groth16Trapdoor{
+		Alpha: big.NewInt(11), Beta: big.NewInt(13), Gamma: big.NewInt(17), Delta: big.NewInt(19),
+		IC: []*big.Int{big.NewInt(23), big.NewInt(29), big.NewInt(31)},
+	}
+	vk := trapdoor.VerifyingKey()
+	inputs := []stygos.Word{stygos.WordFromUint64(42), stygos.WordFromUint64(7)}
+	proof := trapdoor.Prove(inputs, big.NewInt(101), big.NewInt(103))
+
+	if ok, err := VerifyGroth16(vk, &proof, inputs); !ok || err != nil {
+		t.Errorf("VerifyGroth16 failed. Expected true, got %v, %v", ok, err)
+	}
+
+	encoded := append(append(proof.A.Bytes(), proof.B.Bytes()...), proof.C.Bytes()...)
+	decoded, err := Groth16ProofFromBytes(encoded)
+	if err != nil || decoded != proof {
+		t.Errorf("Groth16ProofFromBytes failed. Expected %x, got %x, %v", proof, decoded, err)
+	}
+
+	wrong := []stygos.Word{stygos.WordFromUint64(42), stygos.WordFromUint64(8)}
+	if ok, err := VerifyGroth16(vk, &proof, wrong); ok || err != nil {
+		t.Errorf("VerifyGroth16 with other inputs failed. Expected false, got %v, %v", ok, err)
+	}
+
+	// x + R is x modulo R, and must not verify as x
+	var aliased stygos.Word
+	new(big.Int).Add(R, big.NewInt(42)).FillBytes(aliased[:])
+	if _, err := VerifyGroth16(vk, &proof, []stygos.Word{aliased, inputs[1]}); err != ErrNotInField {
+		t.Errorf("VerifyGroth16 with an input over R failed. Expected %v, got %v", ErrNotInField, err)
+	}
+	if _, err := VerifyGroth16(vk, &proof, inputs[:1]); err != ErrInputCount {
+		t.Errorf("VerifyGroth16 with one input failed. Expected %v, got %v", ErrInputCount, err)
+	}
+}
+
+func TestVerifyPlonk(t *testing.T) {
+	setup()
+
+	trapdoor := &PlonkTrapdoor{Tau: big.NewInt(1234567), Power: 3, NPublic: 2}
+	vk := trapdoor.VerifyingKey()
+	inputs := []stygos.Word{stygos.WordFromUint64(42), stygos.WordFromUint64(7)}
+	proof := trapdoor.Prove(inputs)
+
+	if ok, err := VerifyPlonk(vk, &proof, inputs); !ok || err != nil {
+		t.Errorf("VerifyPlonk failed. Expected true, got %v, %v", ok, err)
+	}
+
+	var encoded []byte
+	for _, p := range []G1{proof.A, proof.B, proof.C, proof.Z, proof.T1, proof.T2, proof.T3, proof.Wxi, proof.Wxiw} {
+		encoded = append(encoded, p.Bytes()...)
+	}
+	for _, e := range []stygos.Word{proof.EvalA, proof.EvalB, proof.EvalC, proof.EvalS1, proof.EvalS2, proof.EvalZw} {
+		encoded = append(encoded, e[:]...)
+	}
+	decoded, err := PlonkProofFromBytes(encoded)
+	if err != nil || decoded != proof {
+		t.Errorf("PlonkProofFromBytes failed. Expected %x, got %x, %v", proof, decoded, err)
+	}
+
+	wrong := []stygos.Word{stygos.WordFromUint64(42), stygos.WordFromUint64(8)}
+	if ok, err := VerifyPlonk(vk, &proof, wrong); ok || err != nil {
+		t.Errorf("VerifyPlonk with other inputs failed. Expected false, got %v, %v", ok, err)
+	}
+	other := trapdoor.Prove(wrong)
+	if ok, err := VerifyPlonk(vk, &other, wrong); !ok || err != nil {
+		t.Errorf("VerifyPlonk with a second proof failed. Expected true, got %v, %v", ok, err)
+	}
+
+	tampered := proof
+	tampered.EvalC = stygos.WordFromUint64(1)
+	if ok, err := VerifyPlonk(vk, &tampered, inputs); ok || err != nil {
+		t.Errorf("VerifyPlonk with another evaluation failed. Expected false, got %v, %v", ok, err)
+	}
+	tampered = proof
+	tampered.Z = proof.A
+	if ok, err := VerifyPlonk(vk, &tampered, inputs); ok || err != nil {
+		t.Errorf("VerifyPlonk with another commitment failed. Expected false, got %v, %v", ok, err)
+	}
+
+	var aliased stygos.Word
+	new(big.Int).Add(R, big.NewInt(42)).FillBytes(aliased[:])
+	if _, err := VerifyPlonk(vk, &proof, []stygos.Word{aliased, inputs[1]}); err != ErrNotInField {
+		t.Errorf("VerifyPlonk with an input over R failed. Expected %v, got %v", ErrNotInField, err)
+	}
+	tampered = proof
+	tampered.EvalA = aliased
+	if _, err := VerifyPlonk(vk, &tampered, inputs); err != ErrNotInField {
+		t.Errorf("VerifyPlonk with an evaluation over R failed. Expected %v, got %v", ErrNotInField, err)
+	}
+	if _, err := VerifyPlonk(vk, &proof, inputs[:1]); err != ErrInputCount {
+		t.Errorf("VerifyPlonk with one input failed. Expected %v, got %v", ErrInputCount, err)
+	}
+}
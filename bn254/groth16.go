@@ -0,0 +1,72 @@
+package bn254
+
+import (
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Groth16VerifyingKey is the verification key of a circuit. IC holds one
+// point per public input, after the constant term IC[0].
+type Groth16VerifyingKey struct {
+	Alpha G1
+	Beta  G2
+	Gamma G2
+	Delta G2
+	IC    []G1
+}
+
+// Groth16Proof is a Groth16 proof.
+type Groth16Proof struct {
+	A G1
+	B G2
+	C G1
+}
+
+// Groth16ProofLength is the encoded size of a proof: A, B and C in the
+// precompile encoding, as the uint256[2], uint256[2][2] and uint256[2]
+// arguments of a snarkjs Solidity verifier.
+const Groth16ProofLength = 64 + 128 + 64
+
+// Groth16ProofFromBytes decodes a proof encoded as A || B || C.
+func Groth16ProofFromBytes(b []byte) (Groth16Proof, error) {
+	var proof Groth16Proof
+	if len(b) != Groth16ProofLength {
+		return proof, ErrInvalidLength
+	}
+	proof.A, _ = G1FromBytes(b[:64])
+	proof.B, _ = G2FromBytes(b[64:192])
+	proof.C, _ = G1FromBytes(b[192:])
+	return proof, nil
+}
+
+// VerifyGroth16 reports whether proof is valid for the public inputs
+// under vk, checking
+//
+//	e(-A, B) · e(alpha, beta) · e(L, gamma) · e(C, delta) = 1
+//
+// where L = IC[0] + inputs[0]·IC[1] + ... It fails with ErrNotInField for
+// an input of at least R, which would otherwise alias a smaller one, and
+// with ErrInvalidPoint for proof points off their curves.
+func VerifyGroth16(vk *Groth16VerifyingKey, proof *Groth16Proof, inputs []stygos.Word) (bool, error) {
+	if len(inputs)+1 != len(vk.IC) {
+		return false, ErrInputCount
+	}
+	l := vk.IC[0]
+	for i, in := range inputs {
+		if new(big.Int).SetBytes(in[:]).Cmp(R) >= 0 {
+			return false, ErrNotInField
+		}
+		term, err := ScalarMul(vk.IC[i+1], in)
+		if err != nil {
+			return false, err
+		}
+		if l, err = Add(l, term); err != nil {
+			return false, err
+		}
+	}
+	return PairingCheck(
+		[]G1{Neg(proof.A), vk.Alpha, l, proof.C},
+		[]G2{proof.B, vk.Beta, vk.Gamma, vk.Delta},
+	)
+}
@@ -0,0 +1,391 @@
+//go:build !tinygo
+
+package bn254
+
+import (
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// InstallMockPrecompiles deploys Go implementations of the EIP-196 and
+// EIP-197 precompiles on rt. Like them, they pad short input with zeros
+// and fail on points off their curves and G2 points outside the group.
+// The pairing takes a few hundred milliseconds per pair.
+func InstallMockPrecompiles(rt *stygos.MockRuntime) {
+	rt.Deploy(AddAddress, func(input []byte) ([]byte, error) {
+		input = padded(input, 128)
+		a, okA := decodeG1(input[:64])
+		c, okC := decodeG1(input[64:])
+		if !okA || !okC {
+			return nil, ErrInvalidPoint
+		}
+		return encodeG1(g1Add(a, c)).Bytes(), nil
+	})
+	rt.Deploy(MulAddress, func(input []byte) ([]byte, error) {
+		input = padded(input, 96)
+		a, ok := decodeG1(input[:64])
+		if !ok {
+			return nil, ErrInvalidPoint
+		}
+		return encodeG1(g1Mul(a, new(big.Int).SetBytes(input[64:]))).Bytes(), nil
+	})
+	rt.Deploy(PairingAddress, func(input []byte) ([]byte, error) {
+		if len(input)%192 != 0 {
+			return nil, ErrLength
+		}
+		var a []g1Point
+		var b []g2Point
+		for len(input) >= 192 {
+			p, okP := decodeG1(input[:64])
+			q, okQ := decodeG2(input[64:192])
+			if !okP || !okQ {
+				return nil, ErrInvalidPoint
+			}
+			a, b = append(a, p), append(b, q)
+			input = input[192:]
+		}
+		var out stygos.Word
+		if pairingProduct(a, b) {
+			out[31] = 1
+		}
+		return out[:], nil
+	})
+}
+
+func padded(input []byte, n int) []byte {
+	out := make([]byte, n)
+	copy(out, input)
+	return out
+}
+
+func fieldElement(b []byte) (*big.Int, bool) {
+	v := new(big.Int).SetBytes(b)
+	return v, v.Cmp(P) < 0
+}
+
+func decodeG1(b []byte) (g1Point, bool) {
+	x, okX := fieldElement(b[:32])
+	y, okY := fieldElement(b[32:64])
+	a := g1Point{x, y}
+	return a, okX && okY && (a.isInfinity() || g1OnCurve(a))
+}
+
+func decodeG2(b []byte) (g2Point, bool) {
+	var c [4]*big.Int
+	for i := range c {
+		v, ok := fieldElement(b[32*i : 32*i+32])
+		if !ok {
+			return g2Point{}, false
+		}
+		c[i] = v
+	}
+	a := g2Point{fq2{c[1], c[0]}, fq2{c[3], c[2]}}
+	if a.isInfinity() {
+		return a, true
+	}
+	return a, g2OnCurve(a) && g2Mul(a, R).isInfinity()
+}
+
+func encodeG1(a g1Point) G1 {
+	var p G1
+	a.x.FillBytes(p.X[:])
+	a.y.FillBytes(p.Y[:])
+	return p
+}
+
+func encodeG2(a g2Point) G2 {
+	var p G2
+	a.x[1].FillBytes(p.X[0][:])
+	a.x[0].FillBytes(p.X[1][:])
+	a.y[1].FillBytes(p.Y[0][:])
+	a.y[0].FillBytes(p.Y[1][:])
+	return p
+}
+
+// MulG1 returns k·p off-chain. It panics if p is not a valid point.
+func MulG1(p G1, k *big.Int) G1 {
+	a, ok := decodeG1(p.Bytes())
+	if !ok {
+		panic("bn254: invalid G1 point")
+	}
+	return encodeG1(g1Mul(a, new(big.Int).Mod(k, R)))
+}
+
+// MulG2 returns k·p off-chain. It panics if p is not a valid point.
+func MulG2(p G2, k *big.Int) G2 {
+	a, ok := decodeG2(p.Bytes())
+	if !ok {
+		panic("bn254: invalid G2 point")
+	}
+	return encodeG2(g2Mul(a, new(big.Int).Mod(k, R)))
+}
+
+// Groth16Trapdoor holds the secrets of a toy trusted setup, the discrete
+// logarithms of a verifying key, so tests can prove any statement under
+// that key without a circuit.
+type Groth16Trapdoor struct {
+	Alpha, Beta, Gamma, Delta *big.Int
+	IC                        []*big.Int
+}
+
+// VerifyingKey returns the verifying key of the setup.
+func (t *Groth16Trapdoor) VerifyingKey() *Groth16VerifyingKey {
+	vk := &Groth16VerifyingKey{
+		Alpha: MulG1(G1Generator, t.Alpha),
+		Beta:  MulG2(G2Generator, t.Beta),
+		Gamma: MulG2(G2Generator, t.Gamma),
+		Delta: MulG2(G2Generator, t.Delta),
+	}
+	for _, ic := range t.IC {
+		vk.IC = append(vk.IC, MulG1(G1Generator, ic))
+	}
+	return vk
+}
+
+// Prove returns a proof for inputs, with A = a·G1 and B = b·G2 and C
+// chosen to satisfy the verification equation.
+func (t *Groth16Trapdoor) Prove(inputs []stygos.Word, a, b *big.Int) Groth16Proof {
+	// c·delta = a·b - alpha·beta - l·gamma
+	l := new(big.Int).Set(t.IC[0])
+	for i, in := range inputs {
+		l.Add(l, new(big.Int).Mul(new(big.Int).SetBytes(in[:]), t.IC[i+1]))
+	}
+	c := new(big.Int).Mul(a, b)
+	c.Sub(c, new(big.Int).Mul(t.Alpha, t.Beta))
+	c.Sub(c, l.Mul(l, t.Gamma))
+	c.Mul(c, new(big.Int).ModInverse(t.Delta, R))
+	return Groth16Proof{
+		A: MulG1(G1Generator, a),
+		B: MulG2(G2Generator, b),
+		C: MulG1(G1Generator, c),
+	}
+}
+
+// PlonkTrapdoor holds the secret Tau of a toy PLONK setup over a domain of
+// 2^Power points, for a fixed circuit with NPublic public inputs that
+// squares each input and checks a constant. Domains must have more than
+// 2·NPublic points. Tests can prove any inputs under its key, with proofs
+// built as an honest prover builds them.
+type PlonkTrapdoor struct {
+	Tau     *big.Int
+	Power   uint
+	NPublic int
+}
+
+// plonkCircuit is the selectors, wire values and copy constraints of a
+// circuit over a domain. sigma maps each wire, column·n + row, to the
+// next one in its cycle.
+type plonkCircuit struct {
+	n                  int
+	w                  *big.Int // generator of the domain
+	k                  [3]*big.Int
+	qm, ql, qr, qo, qc []*big.Int
+	wires              [3][]*big.Int
+	sigma              [3][]int
+	inputs             []*big.Int
+}
+
+// circuit lays out the trapdoor circuit for inputs: row i < NPublic
+// copies input i into a, row NPublic+i squares it into c, and the last
+// row checks b = 1.
+func (t *PlonkTrapdoor) circuit(inputs []*big.Int) *plonkCircuit {
+	n := 1 << t.Power
+	zeros := func() []*big.Int {
+		s := make([]*big.Int, n)
+		for i := range s {
+			s[i] = new(big.Int)
+		}
+		return s
+	}
+	c := &plonkCircuit{
+		n:      n,
+		w:      new(big.Int).Exp(big.NewInt(5), new(big.Int).Rsh(new(big.Int).Sub(R, big.NewInt(1)), t.Power), R),
+		k:      [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)},
+		qm:     zeros(),
+		ql:     zeros(),
+		qr:     zeros(),
+		qo:     zeros(),
+		qc:     zeros(),
+		wires:  [3][]*big.Int{zeros(), zeros(), zeros()},
+		inputs: inputs,
+	}
+	for col := range c.sigma {
+		c.sigma[col] = make([]int, n)
+		for row := range c.sigma[col] {
+			c.sigma[col][row] = col*n + row
+		}
+	}
+	for i, in := range inputs {
+		sq := t.NPublic + i
+		c.ql[i] = big.NewInt(1)
+		c.wires[0][i] = in
+		c.qm[sq], c.qo[sq] = big.NewInt(1), frNeg(big.NewInt(1))
+		c.wires[0][sq], c.wires[1][sq], c.wires[2][sq] = in, in, frMul(in, in)
+		// a of the input row, a and b of the square row are one wire
+		c.sigma[0][i], c.sigma[0][sq], c.sigma[1][sq] = sq, n+sq, i
+	}
+	c.qr[n-1], c.qc[n-1] = big.NewInt(1), frNeg(big.NewInt(1))
+	c.wires[1][n-1] = big.NewInt(1)
+	return c
+}
+
+// eval returns the polynomial taking vals on the domain at x.
+func (c *plonkCircuit) eval(vals []*big.Int, x *big.Int) *big.Int {
+	// L_i(x) = w^i·(x^n - 1) / (n·(x - w^i))
+	zh := frSub(new(big.Int).Exp(x, big.NewInt(int64(c.n)), R), big.NewInt(1))
+	sum, wi := new(big.Int), big.NewInt(1)
+	for _, v := range vals {
+		d := frSub(x, wi)
+		if d.Sign() == 0 {
+			return v
+		}
+		l := frMul(frMul(wi, zh), new(big.Int).ModInverse(frMul(big.NewInt(int64(c.n)), d), R))
+		sum = frAdd(sum, frMul(v, l))
+		wi = frMul(wi, c.w)
+	}
+	return sum
+}
+
+// id returns the label of wire p, k[col]·w^row.
+func (c *plonkCircuit) id(p int) *big.Int {
+	return frMul(c.k[p/c.n], new(big.Int).Exp(c.w, big.NewInt(int64(p%c.n)), R))
+}
+
+// VerifyingKey returns the verifying key of the setup.
+func (t *PlonkTrapdoor) VerifyingKey() *PlonkVerifyingKey {
+	zeros := make([]*big.Int, t.NPublic)
+	for i := range zeros {
+		zeros[i] = new(big.Int)
+	}
+	c := t.circuit(zeros)
+	commit := func(vals []*big.Int) G1 {
+		return MulG1(G1Generator, c.eval(vals, t.Tau))
+	}
+	var s [3][]*big.Int
+	for col := range s {
+		for _, p := range c.sigma[col] {
+			s[col] = append(s[col], c.id(p))
+		}
+	}
+	return &PlonkVerifyingKey{
+		NPublic: t.NPublic,
+		Power:   t.Power,
+		K1:      frWord(c.k[1]),
+		K2:      frWord(c.k[2]),
+		Qm:      commit(c.qm),
+		Ql:      commit(c.ql),
+		Qr:      commit(c.qr),
+		Qo:      commit(c.qo),
+		Qc:      commit(c.qc),
+		S1:      commit(s[0]),
+		S2:      commit(s[1]),
+		S3:      commit(s[2]),
+		X2:      MulG2(G2Generator, t.Tau),
+		W:       frWord(c.w),
+	}
+}
+
+// Prove returns a proof for inputs. It evaluates the polynomials of the
+// PLONK paper at Tau where a prover commits to them, so the proof passes
+// only if VerifyPlonk recombines them the same way.
+func (t *PlonkTrapdoor) Prove(inputs []stygos.Word) PlonkProof {
+	vk := t.VerifyingKey()
+	ins := make([]*big.Int, len(inputs))
+	for i, in := range inputs {
+		ins[i] = new(big.Int).Mod(scalar(in), R)
+	}
+	c := t.circuit(ins)
+	commit := func(x *big.Int) G1 {
+		return MulG1(G1Generator, x)
+	}
+	var s [3][]*big.Int
+	for col := range s {
+		for _, p := range c.sigma[col] {
+			s[col] = append(s[col], c.id(p))
+		}
+	}
+	var proof PlonkProof
+	proof.A, proof.B, proof.C = commit(c.eval(c.wires[0], t.Tau)), commit(c.eval(c.wires[1], t.Tau)), commit(c.eval(c.wires[2], t.Tau))
+	ch := plonkTranscript(vk, &proof, inputs)
+	beta, gamma := ch.beta, ch.gamma
+
+	// The permutation polynomial accumulates the ratios row by row
+	z := []*big.Int{big.NewInt(1)}
+	for row := 0; row < c.n-1; row++ {
+		num, den := big.NewInt(1), big.NewInt(1)
+		for col := range c.wires {
+			v := frAdd(c.wires[col][row], gamma)
+			num = frMul(num, frAdd(v, frMul(beta, c.id(col*c.n+row))))
+			den = frMul(den, frAdd(v, frMul(beta, s[col][row])))
+		}
+		z = append(z, frMul(z[row], frMul(num, new(big.Int).ModInverse(den, R))))
+	}
+	proof.Z = commit(c.eval(z, t.Tau))
+	alpha := plonkTranscript(vk, &proof, inputs).alpha
+
+	// t(x) = (gate + PI + alpha·permutation + alpha²·(z - 1)·L1) / (x^n - 1)
+	l1 := make([]*big.Int, c.n)
+	pi := make([]*big.Int, c.n)
+	for i := range l1 {
+		l1[i], pi[i] = new(big.Int), new(big.Int)
+	}
+	l1[0] = big.NewInt(1)
+	for i, in := range ins {
+		pi[i] = frNeg(in)
+	}
+	quotient := func(x *big.Int) *big.Int {
+		a, b, cc := c.eval(c.wires[0], x), c.eval(c.wires[1], x), c.eval(c.wires[2], x)
+		gate := frAdd(frAdd(frAdd(frAdd(frMul(frMul(a, b), c.eval(c.qm, x)), frMul(a, c.eval(c.ql, x))),
+			frMul(b, c.eval(c.qr, x))), frMul(cc, c.eval(c.qo, x))), c.eval(c.qc, x))
+		gate = frAdd(gate, c.eval(pi, x))
+		perm := c.eval(z, x)
+		for col, v := range []*big.Int{a, b, cc} {
+			perm = frMul(perm, frAdd(frAdd(v, frMul(frMul(beta, c.k[col]), x)), gamma))
+		}
+		shifted := c.eval(z, frMul(x, c.w))
+		for col, v := range []*big.Int{a, b, cc} {
+			shifted = frMul(shifted, frAdd(frAdd(v, frMul(beta, c.eval(s[col], x))), gamma))
+		}
+		sum := frAdd(gate, frMul(alpha, frSub(perm, shifted)))
+		sum = frAdd(sum, frMul(frMul(alpha, alpha), frMul(frSub(c.eval(z, x), big.NewInt(1)), c.eval(l1, x))))
+		zh := frSub(new(big.Int).Exp(x, big.NewInt(int64(c.n)), R), big.NewInt(1))
+		return frMul(sum, new(big.Int).ModInverse(zh, R))
+	}
+	tTau := quotient(t.Tau)
+	proof.T1 = commit(tTau)
+	xi := plonkTranscript(vk, &proof, inputs).xi
+
+	xiw := frMul(xi, c.w)
+	a, b, cc := c.eval(c.wires[0], xi), c.eval(c.wires[1], xi), c.eval(c.wires[2], xi)
+	s1, s2, zw := c.eval(s[0], xi), c.eval(s[1], xi), c.eval(z, xiw)
+	proof.EvalA, proof.EvalB, proof.EvalC = frWord(a), frWord(b), frWord(cc)
+	proof.EvalS1, proof.EvalS2, proof.EvalZw = frWord(s1), frWord(s2), frWord(zw)
+	v := plonkTranscript(vk, &proof, inputs).v
+
+	// The linearization polynomial r at x, with the wires fixed at xi
+	zhXi := frSub(new(big.Int).Exp(xi, big.NewInt(int64(c.n)), R), big.NewInt(1))
+	linear := func(x, tx *big.Int) *big.Int {
+		r := frAdd(frAdd(frAdd(frAdd(frMul(frMul(a, b), c.eval(c.qm, x)), frMul(a, c.eval(c.ql, x))),
+			frMul(b, c.eval(c.qr, x))), frMul(cc, c.eval(c.qo, x))), c.eval(c.qc, x))
+		zc := alpha
+		for col, ev := range []*big.Int{a, b, cc} {
+			zc = frMul(zc, frAdd(frAdd(ev, frMul(frMul(beta, c.k[col]), xi)), gamma))
+		}
+		zc = frAdd(zc, frMul(frMul(alpha, alpha), c.eval(l1, xi)))
+		r = frAdd(r, frMul(zc, c.eval(z, x)))
+		sc := frMul(frMul(frMul(alpha, beta), zw), frMul(frAdd(frAdd(a, frMul(beta, s1)), gamma), frAdd(frAdd(b, frMul(beta, s2)), gamma)))
+		r = frSub(r, frMul(sc, c.eval(s[2], x)))
+		return frSub(r, frMul(zhXi, tx))
+	}
+	num := frSub(linear(t.Tau, tTau), linear(xi, quotient(xi)))
+	for i, p := range []struct {
+		vals []*big.Int
+		at   *big.Int
+	}{{c.wires[0], a}, {c.wires[1], b}, {c.wires[2], cc}, {s[0], s1}, {s[1], s2}} {
+		num = frAdd(num, frMul(v[i+1], frSub(c.eval(p.vals, t.Tau), p.at)))
+	}
+	proof.Wxi = commit(frMul(num, new(big.Int).ModInverse(frSub(t.Tau, xi), R)))
+	proof.Wxiw = commit(frMul(frSub(c.eval(z, t.Tau), zw), new(big.Int).ModInverse(frSub(t.Tau, xiw), R)))
+	return proof
+}
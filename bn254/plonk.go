@@ -0,0 +1,245 @@
+package bn254
+
+import (
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// PlonkVerifyingKey is the verification key of a PLONK circuit as snarkjs
+// exports it: the selector and permutation commitments, the size 2^Power
+// of the evaluation domain and its generator W, the coset shifts K1 and
+// K2, and X2, the trusted setup secret times the G2 generator.
+type PlonkVerifyingKey struct {
+	NPublic            int
+	Power              uint
+	K1, K2             stygos.Word
+	Qm, Ql, Qr, Qo, Qc G1
+	S1, S2, S3         G1
+	X2                 G2
+	W                  stygos.Word
+}
+
+// PlonkProof is a PLONK proof: the commitments of the wire, permutation
+// and quotient polynomials and of the two opening proofs, and the
+// evaluations at the challenge point.
+type PlonkProof struct {
+	A, B, C, Z, T1, T2, T3, Wxi, Wxiw           G1
+	EvalA, EvalB, EvalC, EvalS1, EvalS2, EvalZw stygos.Word
+}
+
+// PlonkProofLength is the encoded size of a proof, the uint256[24]
+// argument of a snarkjs Solidity verifier: the nine commitments, then the
+// six evaluations.
+const PlonkProofLength = 24 * 32
+
+// PlonkProofFromBytes decodes a proof in the order of PlonkProof.
+func PlonkProofFromBytes(b []byte) (PlonkProof, error) {
+	var proof PlonkProof
+	if len(b) != PlonkProofLength {
+		return proof, ErrInvalidLength
+	}
+	for i, p := range []*G1{&proof.A, &proof.B, &proof.C, &proof.Z, &proof.T1, &proof.T2, &proof.T3, &proof.Wxi, &proof.Wxiw} {
+		*p, _ = G1FromBytes(b[64*i : 64*i+64])
+	}
+	for i, e := range []*stygos.Word{&proof.EvalA, &proof.EvalB, &proof.EvalC, &proof.EvalS1, &proof.EvalS2, &proof.EvalZw} {
+		copy(e[:], b[9*64+32*i:])
+	}
+	return proof, nil
+}
+
+// plonkChallenges are the Fiat-Shamir challenges of a proof.
+type plonkChallenges struct {
+	beta, gamma, alpha, xi, u *big.Int
+	v                         [6]*big.Int // v[1] to v[5]
+}
+
+// VerifyPlonk reports whether proof is valid for the public inputs under
+// vk, as the Solidity verifier of snarkjs 0.7 decides it: the challenges
+// come from a keccak256 transcript, and the openings of the linearized
+// polynomial at xi and of Z at xi·W are checked with one pairing check
+// of two pairs. It fails with ErrNotInField for an input or evaluation of
+// at least R, and with ErrInvalidPoint for proof points off the curve.
+func VerifyPlonk(vk *PlonkVerifyingKey, proof *PlonkProof, inputs []stygos.Word) (bool, error) {
+	if len(inputs) != vk.NPublic {
+		return false, ErrInputCount
+	}
+	for _, w := range append([]stygos.Word{proof.EvalA, proof.EvalB, proof.EvalC, proof.EvalS1, proof.EvalS2, proof.EvalZw}, inputs...) {
+		if new(big.Int).SetBytes(w[:]).Cmp(R) >= 0 {
+			return false, ErrNotInField
+		}
+	}
+	ch := plonkTranscript(vk, proof, inputs)
+	a, b, c := scalar(proof.EvalA), scalar(proof.EvalB), scalar(proof.EvalC)
+	s1, s2, zw := scalar(proof.EvalS1), scalar(proof.EvalS2), scalar(proof.EvalZw)
+
+	// xi^n, the vanishing polynomial at xi, and L1 to Ln of the inputs
+	xin := new(big.Int).Set(ch.xi)
+	for i := uint(0); i < vk.Power; i++ {
+		xin = frMul(xin, xin)
+	}
+	zh := frSub(xin, big.NewInt(1))
+	n := new(big.Int).Lsh(big.NewInt(1), vk.Power)
+	count := vk.NPublic
+	if count < 1 {
+		count = 1
+	}
+	l := make([]*big.Int, count)
+	w := big.NewInt(1)
+	for i := range l {
+		// L_i(xi) = w^i·(xi^n - 1) / (n·(xi - w^i))
+		den := frMul(n, frSub(ch.xi, w))
+		if den.Sign() == 0 {
+			return false, nil
+		}
+		l[i] = frMul(frMul(w, zh), new(big.Int).ModInverse(den, R))
+		w = frMul(w, scalar(vk.W))
+	}
+	pi := new(big.Int)
+	for i, in := range inputs {
+		pi = frSub(pi, frMul(l[i], scalar(in)))
+	}
+
+	// r0 is the constant part of the linearization polynomial
+	alpha2 := frMul(ch.alpha, ch.alpha)
+	perm := frMul(frAdd(frAdd(a, frMul(ch.beta, s1)), ch.gamma), frAdd(frAdd(b, frMul(ch.beta, s2)), ch.gamma))
+	r0 := frSub(pi, frMul(l[0], alpha2))
+	r0 = frSub(r0, frMul(frMul(frMul(perm, frAdd(c, ch.gamma)), zw), ch.alpha))
+
+	// D is the commitment of the rest of the linearization polynomial
+	betaxi := frMul(ch.beta, ch.xi)
+	zCoef := frMul(frMul(frMul(
+		frAdd(frAdd(a, betaxi), ch.gamma),
+		frAdd(frAdd(b, frMul(betaxi, scalar(vk.K1))), ch.gamma)),
+		frAdd(frAdd(c, frMul(betaxi, scalar(vk.K2))), ch.gamma)),
+		ch.alpha)
+	zCoef = frAdd(frAdd(zCoef, frMul(l[0], alpha2)), ch.u)
+	s3Coef := frMul(frMul(perm, frMul(ch.alpha, ch.beta)), zw)
+	t, err := msm([]G1{proof.T1, proof.T2, proof.T3}, []*big.Int{big.NewInt(1), xin, frMul(xin, xin)})
+	if err != nil {
+		return false, err
+	}
+	// F batches D with the commitments opened at xi
+	f, err := msm(
+		[]G1{vk.Qm, vk.Ql, vk.Qr, vk.Qo, vk.Qc, proof.Z, vk.S3, t, proof.A, proof.B, proof.C, vk.S1, vk.S2},
+		[]*big.Int{frMul(a, b), a, b, c, big.NewInt(1), zCoef, frNeg(s3Coef), frNeg(zh),
+			ch.v[1], ch.v[2], ch.v[3], ch.v[4], ch.v[5]},
+	)
+	if err != nil {
+		return false, err
+	}
+	// E commits to the values F and Z open to
+	e := frNeg(r0)
+	for i, eval := range []*big.Int{a, b, c, s1, s2} {
+		e = frAdd(e, frMul(eval, ch.v[i+1]))
+	}
+	e = frAdd(e, frMul(zw, ch.u))
+
+	// e(-(Wxi + u·Wxiw), X2) · e(xi·Wxi + u·xi·w·Wxiw + F - E, G2) = 1
+	a1, err := msm([]G1{proof.Wxi, proof.Wxiw}, []*big.Int{big.NewInt(1), ch.u})
+	if err != nil {
+		return false, err
+	}
+	b1, err := msm(
+		[]G1{proof.Wxi, proof.Wxiw, f, G1Generator},
+		[]*big.Int{ch.xi, frMul(frMul(ch.u, ch.xi), scalar(vk.W)), big.NewInt(1), frNeg(e)},
+	)
+	if err != nil {
+		return false, err
+	}
+	return PairingCheck([]G1{Neg(a1), b1}, []G2{vk.X2, G2Generator})
+}
+
+// plonkTranscript derives the challenges of proof the way snarkjs does,
+// hashing 32-byte words with keccak256 and reducing modulo R.
+func plonkTranscript(vk *PlonkVerifyingKey, proof *PlonkProof, inputs []stygos.Word) *plonkChallenges {
+	var ch plonkChallenges
+	var buf []byte
+	points := func(ps ...G1) {
+		for _, p := range ps {
+			buf = append(buf, p.Bytes()...)
+		}
+	}
+	words := func(ws ...stygos.Word) {
+		for _, w := range ws {
+			buf = append(buf, w[:]...)
+		}
+	}
+	challenge := func() *big.Int {
+		h := stygos.Keccak256(buf)
+		buf = buf[:0]
+		return new(big.Int).Mod(new(big.Int).SetBytes(h[:]), R)
+	}
+
+	points(vk.Qm, vk.Ql, vk.Qr, vk.Qo, vk.Qc, vk.S1, vk.S2, vk.S3)
+	words(inputs...)
+	points(proof.A, proof.B, proof.C)
+	ch.beta = challenge()
+	words(frWord(ch.beta))
+	ch.gamma = challenge()
+	words(frWord(ch.beta), frWord(ch.gamma))
+	points(proof.Z)
+	ch.alpha = challenge()
+	words(frWord(ch.alpha))
+	points(proof.T1, proof.T2, proof.T3)
+	ch.xi = challenge()
+	words(frWord(ch.xi), proof.EvalA, proof.EvalB, proof.EvalC, proof.EvalS1, proof.EvalS2, proof.EvalZw)
+	ch.v[1] = challenge()
+	for i := 2; i < len(ch.v); i++ {
+		ch.v[i] = frMul(ch.v[i-1], ch.v[1])
+	}
+	points(proof.Wxi, proof.Wxiw)
+	ch.u = challenge()
+	return &ch
+}
+
+// msm returns the sum of ks[i]·ps[i], skipping zero scalars.
+func msm(ps []G1, ks []*big.Int) (G1, error) {
+	var sum G1
+	for i, p := range ps {
+		if ks[i].Sign() == 0 {
+			continue
+		}
+		term := p
+		if ks[i].Cmp(big.NewInt(1)) != 0 {
+			var err error
+			if term, err = ScalarMul(p, frWord(ks[i])); err != nil {
+				return G1{}, err
+			}
+		}
+		var err error
+		if sum, err = Add(sum, term); err != nil {
+			return G1{}, err
+		}
+	}
+	return sum, nil
+}
+
+func scalar(w stygos.Word) *big.Int {
+	return new(big.Int).SetBytes(w[:])
+}
+
+func frWord(x *big.Int) stygos.Word {
+	var w stygos.Word
+	x.FillBytes(w[:])
+	return w
+}
+
+func frAdd(x, y *big.Int) *big.Int {
+	z := new(big.Int).Add(x, y)
+	return z.Mod(z, R)
+}
+
+func frSub(x, y *big.Int) *big.Int {
+	z := new(big.Int).Sub(x, y)
+	return z.Mod(z, R)
+}
+
+func frMul(x, y *big.Int) *big.Int {
+	z := new(big.Int).Mul(x, y)
+	return z.Mod(z, R)
+}
+
+func frNeg(x *big.Int) *big.Int {
+	return frSub(new(big.Int), x)
+}
@@ -0,0 +1,431 @@
+//go:build !tinygo
+
+package bn254
+
+import "math/big"
+
+// The reference arithmetic behind the mock precompiles: affine points
+// over F_p and F_p², and the optimal ate pairing computed as py_ecc does,
+// in F_p¹² = F_p[w]/(w¹² - 18w⁶ + 82). It favors being easy to check
+// over speed.
+
+func fpMod(x *big.Int) *big.Int {
+	return x.Mod(x, P)
+}
+
+// fq2 is a + b·i, stored as (a, b), with i² = -1.
+type fq2 [2]*big.Int
+
+func fq2From(a, b int64) fq2 {
+	return fq2{big.NewInt(a), big.NewInt(b)}
+}
+
+func (x fq2) isZero() bool {
+	return x[0].Sign() == 0 && x[1].Sign() == 0
+}
+
+func (x fq2) equal(y fq2) bool {
+	return x[0].Cmp(y[0]) == 0 && x[1].Cmp(y[1]) == 0
+}
+
+func (x fq2) add(y fq2) fq2 {
+	return fq2{fpMod(new(big.Int).Add(x[0], y[0])), fpMod(new(big.Int).Add(x[1], y[1]))}
+}
+
+func (x fq2) sub(y fq2) fq2 {
+	return fq2{fpMod(new(big.Int).Sub(x[0], y[0])), fpMod(new(big.Int).Sub(x[1], y[1]))}
+}
+
+func (x fq2) mul(y fq2) fq2 {
+	ac := new(big.Int).Mul(x[0], y[0])
+	bd := new(big.Int).Mul(x[1], y[1])
+	ad := new(big.Int).Mul(x[0], y[1])
+	bc := new(big.Int).Mul(x[1], y[0])
+	return fq2{fpMod(ac.Sub(ac, bd)), fpMod(ad.Add(ad, bc))}
+}
+
+func (x fq2) inv() fq2 {
+	norm := new(big.Int).Mul(x[0], x[0])
+	norm.Add(norm, new(big.Int).Mul(x[1], x[1]))
+	norm.ModInverse(fpMod(norm), P)
+	neg := new(big.Int).Neg(x[1])
+	return fq2{fpMod(new(big.Int).Mul(x[0], norm)), fpMod(neg.Mul(neg, norm))}
+}
+
+// twistB is the coefficient b of the twist y² = x³ + 3/(9+i).
+var twistB = fq2From(3, 0).mul(fq2From(9, 1).inv())
+
+// g1Point and g2Point are affine points, with the point at infinity as
+// (0, 0), which is on neither curve.
+type g1Point struct {
+	x, y *big.Int
+}
+
+type g2Point struct {
+	x, y fq2
+}
+
+func (a g1Point) isInfinity() bool {
+	return a.x.Sign() == 0 && a.y.Sign() == 0
+}
+
+func (a g2Point) isInfinity() bool {
+	return a.x.isZero() && a.y.isZero()
+}
+
+func g1OnCurve(a g1Point) bool {
+	yy := fpMod(new(big.Int).Mul(a.y, a.y))
+	rhs := new(big.Int).Mul(a.x, a.x)
+	rhs.Mul(rhs, a.x)
+	rhs.Add(rhs, big.NewInt(3))
+	return yy.Cmp(fpMod(rhs)) == 0
+}
+
+func g2OnCurve(a g2Point) bool {
+	return a.y.mul(a.y).equal(a.x.mul(a.x).mul(a.x).add(twistB))
+}
+
+func g1Add(a, c g1Point) g1Point {
+	if a.isInfinity() {
+		return c
+	}
+	if c.isInfinity() {
+		return a
+	}
+	var m *big.Int
+	if a.x.Cmp(c.x) == 0 {
+		if a.y.Cmp(c.y) != 0 || a.y.Sign() == 0 {
+			return g1Point{new(big.Int), new(big.Int)}
+		}
+		m = new(big.Int).Mul(a.x, a.x)
+		m.Mul(m, big.NewInt(3))
+		m.Mul(m, new(big.Int).ModInverse(new(big.Int).Lsh(a.y, 1), P))
+	} else {
+		m = new(big.Int).Sub(c.y, a.y)
+		m.Mul(m, new(big.Int).ModInverse(fpMod(new(big.Int).Sub(c.x, a.x)), P))
+	}
+	fpMod(m)
+	x := new(big.Int).Mul(m, m)
+	x.Sub(x, a.x)
+	fpMod(x.Sub(x, c.x))
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, m)
+	fpMod(y.Sub(y, a.y))
+	return g1Point{x, y}
+}
+
+func g1Mul(a g1Point, k *big.Int) g1Point {
+	r := g1Point{new(big.Int), new(big.Int)}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = g1Add(r, r)
+		if k.Bit(i) == 1 {
+			r = g1Add(r, a)
+		}
+	}
+	return r
+}
+
+func g2Add(a, c g2Point) g2Point {
+	if a.isInfinity() {
+		return c
+	}
+	if c.isInfinity() {
+		return a
+	}
+	var m fq2
+	if a.x.equal(c.x) {
+		if !a.y.equal(c.y) || a.y.isZero() {
+			return g2Point{fq2From(0, 0), fq2From(0, 0)}
+		}
+		m = a.x.mul(a.x).mul(fq2From(3, 0)).mul(a.y.add(a.y).inv())
+	} else {
+		m = c.y.sub(a.y).mul(c.x.sub(a.x).inv())
+	}
+	x := m.mul(m).sub(a.x).sub(c.x)
+	return g2Point{x, m.mul(a.x.sub(x)).sub(a.y)}
+}
+
+func g2Mul(a g2Point, k *big.Int) g2Point {
+	r := g2Point{fq2From(0, 0), fq2From(0, 0)}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = g2Add(r, r)
+		if k.Bit(i) == 1 {
+			r = g2Add(r, a)
+		}
+	}
+	return r
+}
+
+// fq12 holds the coefficients of w⁰..w¹¹.
+type fq12 [12]*big.Int
+
+func fq12Scalar(a *big.Int) fq12 {
+	var x fq12
+	for i := range x {
+		x[i] = new(big.Int)
+	}
+	x[0].Set(a)
+	return x
+}
+
+func (x fq12) isOne() bool {
+	for i, c := range x {
+		if (i == 0 && c.Cmp(big.NewInt(1)) != 0) || (i > 0 && c.Sign() != 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func (x fq12) add(y fq12) fq12 {
+	var z fq12
+	for i := range z {
+		z[i] = fpMod(new(big.Int).Add(x[i], y[i]))
+	}
+	return z
+}
+
+func (x fq12) sub(y fq12) fq12 {
+	var z fq12
+	for i := range z {
+		z[i] = fpMod(new(big.Int).Sub(x[i], y[i]))
+	}
+	return z
+}
+
+func (x fq12) scale(k *big.Int) fq12 {
+	var z fq12
+	for i := range z {
+		z[i] = fpMod(new(big.Int).Mul(x[i], k))
+	}
+	return z
+}
+
+func (x fq12) mul(y fq12) fq12 {
+	var t [23]*big.Int
+	for i := range t {
+		t[i] = new(big.Int)
+	}
+	tmp := new(big.Int)
+	for i := range x {
+		for j := range y {
+			t[i+j].Add(t[i+j], tmp.Mul(x[i], y[j]))
+		}
+	}
+	// w¹² = 18w⁶ - 82
+	for i := 22; i >= 12; i-- {
+		t[i-6].Add(t[i-6], tmp.Mul(t[i], big.NewInt(18)))
+		t[i-12].Sub(t[i-12], tmp.Mul(t[i], big.NewInt(82)))
+	}
+	var z fq12
+	for i := range z {
+		z[i] = fpMod(t[i])
+	}
+	return z
+}
+
+func (x fq12) pow(k *big.Int) fq12 {
+	r := fq12Scalar(big.NewInt(1))
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = r.mul(r)
+		if k.Bit(i) == 1 {
+			r = r.mul(x)
+		}
+	}
+	return r
+}
+
+// inv inverts x with the extended Euclidean algorithm on polynomials,
+// keeping lm·x ≡ low and hm·x ≡ high modulo the field polynomial.
+func (x fq12) inv() fq12 {
+	low := trim(append([]*big.Int(nil), x[:]...))
+	high := make([]*big.Int, 13)
+	for i := range high {
+		high[i] = new(big.Int)
+	}
+	high[0].SetInt64(82)
+	high[6].Sub(P, big.NewInt(18))
+	high[12].SetInt64(1)
+	lm, hm := []*big.Int{big.NewInt(1)}, []*big.Int(nil)
+	for len(low) > 1 {
+		q, r := polyDivMod(high, low)
+		lm, hm = polySub(hm, polyMul(q, lm)), lm
+		low, high = r, low
+	}
+	c := new(big.Int).ModInverse(low[0], P)
+	var z fq12
+	for i := range z {
+		z[i] = new(big.Int)
+		if i < len(lm) {
+			z[i] = fpMod(new(big.Int).Mul(lm[i], c))
+		}
+	}
+	return z
+}
+
+func trim(a []*big.Int) []*big.Int {
+	for len(a) > 0 && a[len(a)-1].Sign() == 0 {
+		a = a[:len(a)-1]
+	}
+	return a
+}
+
+func polyMul(a, b []*big.Int) []*big.Int {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	out := make([]*big.Int, len(a)+len(b)-1)
+	for i := range out {
+		out[i] = new(big.Int)
+	}
+	for i := range a {
+		for j := range b {
+			out[i+j].Add(out[i+j], new(big.Int).Mul(a[i], b[j]))
+		}
+	}
+	for _, c := range out {
+		fpMod(c)
+	}
+	return trim(out)
+}
+
+func polySub(a, b []*big.Int) []*big.Int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make([]*big.Int, n)
+	for i := range out {
+		out[i] = new(big.Int)
+		if i < len(a) {
+			out[i].Add(out[i], a[i])
+		}
+		if i < len(b) {
+			out[i].Sub(out[i], b[i])
+		}
+		fpMod(out[i])
+	}
+	return trim(out)
+}
+
+func polyDivMod(a, b []*big.Int) (q, r []*big.Int) {
+	r = append([]*big.Int(nil), a...)
+	for i := range r {
+		r[i] = new(big.Int).Set(r[i])
+	}
+	r = trim(r)
+	lead := new(big.Int).ModInverse(b[len(b)-1], P)
+	if len(r) >= len(b) {
+		q = make([]*big.Int, len(r)-len(b)+1)
+	}
+	for len(r) >= len(b) {
+		shift := len(r) - len(b)
+		c := fpMod(new(big.Int).Mul(r[len(r)-1], lead))
+		q[shift] = c
+		for i := range b {
+			r[shift+i] = fpMod(r[shift+i].Sub(r[shift+i], new(big.Int).Mul(c, b[i])))
+		}
+		r = trim(r)
+	}
+	for i := range q {
+		if q[i] == nil {
+			q[i] = new(big.Int)
+		}
+	}
+	return q, r
+}
+
+// fq12Point is an affine point over F_p¹², never at infinity in the
+// Miller loop below.
+type fq12Point struct {
+	x, y fq12
+}
+
+// twist maps a point of the twist into F_p¹²: a + b·i becomes
+// (a - 9b) + b·w⁶, and x and y are multiplied by w² and w³.
+func twist(a g2Point) fq12Point {
+	embed := func(c fq2, shift int) fq12 {
+		z := fq12Scalar(new(big.Int))
+		z[shift] = fpMod(new(big.Int).Sub(c[0], new(big.Int).Mul(c[1], big.NewInt(9))))
+		z[shift+6] = new(big.Int).Set(c[1])
+		return z
+	}
+	return fq12Point{embed(a.x, 2), embed(a.y, 3)}
+}
+
+func (a fq12Point) slope(c fq12Point) fq12 {
+	if equal12(a.x, c.x) {
+		num := a.x.mul(a.x).scale(big.NewInt(3))
+		return num.mul(a.y.add(a.y).inv())
+	}
+	return c.y.sub(a.y).mul(c.x.sub(a.x).inv())
+}
+
+func (a fq12Point) add(c fq12Point) fq12Point {
+	m := a.slope(c)
+	x := m.mul(m).sub(a.x).sub(c.x)
+	return fq12Point{x, m.mul(a.x.sub(x)).sub(a.y)}
+}
+
+func equal12(x, y fq12) bool {
+	for i := range x {
+		if x[i].Cmp(y[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// line evaluates at t the line through a and c, or the tangent at a.
+func line(a, c, t fq12Point) fq12 {
+	if !equal12(a.x, c.x) || equal12(a.y, c.y) {
+		return a.slope(c).mul(t.x.sub(a.x)).sub(t.y.sub(a.y))
+	}
+	return t.x.sub(a.x)
+}
+
+var (
+	ateLoopCount, _ = new(big.Int).SetString("29793968203157093288", 10)
+	finalExponent   = func() *big.Int {
+		e := new(big.Int).Exp(P, big.NewInt(12), nil)
+		e.Sub(e, big.NewInt(1))
+		return e.Div(e, R)
+	}()
+)
+
+// millerLoop returns the optimal ate Miller loop of q and p, before the
+// final exponentiation.
+func millerLoop(q g2Point, p g1Point) fq12 {
+	tq := twist(q)
+	tp := fq12Point{fq12Scalar(p.x), fq12Scalar(p.y)}
+	r := tq
+	f := fq12Scalar(big.NewInt(1))
+	// r starts at q, the top bit of the loop count
+	for i := ateLoopCount.BitLen() - 2; i >= 0; i-- {
+		f = f.mul(f).mul(line(r, r, tp))
+		r = r.add(r)
+		if ateLoopCount.Bit(i) == 1 {
+			f = f.mul(line(r, tq, tp))
+			r = r.add(tq)
+		}
+	}
+	// Two more lines, through the images of q under the Frobenius map
+	q1 := fq12Point{tq.x.pow(P), tq.y.pow(P)}
+	nq2 := fq12Point{q1.x.pow(P), q1.y.pow(P).scale(new(big.Int).Sub(P, big.NewInt(1)))}
+	f = f.mul(line(r, q1, tp))
+	r = r.add(q1)
+	return f.mul(line(r, nq2, tp))
+}
+
+// pairingProduct reports whether the product of e(a[i], b[i]) is one,
+// skipping pairs with a point at infinity.
+func pairingProduct(a []g1Point, b []g2Point) bool {
+	f := fq12Scalar(big.NewInt(1))
+	for i := range a {
+		if a[i].isInfinity() || b[i].isInfinity() {
+			continue
+		}
+		f = f.mul(millerLoop(b[i], a[i]))
+	}
+	return f.pow(finalExponent).isOne()
+}
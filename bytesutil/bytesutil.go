@@ -0,0 +1,108 @@
+// Package bytesutil provides byte slice helpers for contracts built with
+// TinyGo, where every allocation grows the wasm heap for the rest of the
+// call and reflection-based packages bloat the binary.
+//
+// Concat sizes its result once instead of growing it through a chain of
+// appends, the pad and Append functions write into a single buffer, and
+// the bounds-checked readers return ErrOutOfBounds instead of panicking on
+// short calldata.
+package bytesutil
+
+import "errors"
+
+// ErrOutOfBounds is returned when a read runs past the end of a slice.
+var ErrOutOfBounds = errors.New("bytesutil: out of bounds")
+
+// Concat returns the concatenation of parts in a single allocation.
+func Concat(parts ...[]byte) []byte {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	out := make([]byte, 0, n)
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+// PadLeft returns b right-aligned in n zero bytes, as an ABI number.
+// b is returned unchanged if it is at least n bytes long.
+func PadLeft(b []byte, n int) []byte {
+	if len(b) >= n {
+		return b
+	}
+	out := make([]byte, n)
+	copy(out[n-len(b):], b)
+	return out
+}
+
+// PadRight returns b left-aligned in n zero bytes, as ABI bytes. b is
+// returned unchanged if it is at least n bytes long.
+func PadRight(b []byte, n int) []byte {
+	if len(b) >= n {
+		return b
+	}
+	out := make([]byte, n)
+	copy(out, b)
+	return out
+}
+
+// PadRight32 pads b with zeros to a multiple of 32 bytes, the tail of
+// dynamic ABI data.
+func PadRight32(b []byte) []byte {
+	return PadRight(b, (len(b)+31)/32*32)
+}
+
+// Slice returns b[start:end], or ErrOutOfBounds if the range is not
+// within b.
+func Slice(b []byte, start, end int) ([]byte, error) {
+	if start < 0 || end < start || end > len(b) {
+		return nil, ErrOutOfBounds
+	}
+	return b[start:end], nil
+}
+
+// Uint16 reads the big-endian uint16 at b[off:].
+func Uint16(b []byte, off int) (uint16, error) {
+	p, err := Slice(b, off, off+2)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(p[0])<<8 | uint16(p[1]), nil
+}
+
+// Uint32 reads the big-endian uint32 at b[off:].
+func Uint32(b []byte, off int) (uint32, error) {
+	p, err := Slice(b, off, off+4)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(p[0])<<24 | uint32(p[1])<<16 | uint32(p[2])<<8 | uint32(p[3]), nil
+}
+
+// Uint64 reads the big-endian uint64 at b[off:].
+func Uint64(b []byte, off int) (uint64, error) {
+	p, err := Slice(b, off, off+8)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(p[0])<<56 | uint64(p[1])<<48 | uint64(p[2])<<40 | uint64(p[3])<<32 |
+		uint64(p[4])<<24 | uint64(p[5])<<16 | uint64(p[6])<<8 | uint64(p[7]), nil
+}
+
+// AppendUint16 appends v to dst in big-endian order.
+func AppendUint16(dst []byte, v uint16) []byte {
+	return append(dst, byte(v>>8), byte(v))
+}
+
+// AppendUint32 appends v to dst in big-endian order.
+func AppendUint32(dst []byte, v uint32) []byte {
+	return append(dst, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// AppendUint64 appends v to dst in big-endian order.
+func AppendUint64(dst []byte, v uint64) []byte {
+	return append(dst, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
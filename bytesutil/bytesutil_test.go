@@ -0,0 +1,73 @@
+package bytesutil
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	got := Concat([]byte{1, 2}, nil, []byte{3}, []byte{4, 5})
+	if !bytes.Equal(got, []byte{1, 2, 3, 4, 5}) || cap(got) != 5 {
+		t.Errorf("Concat failed. Expected 0102030405 with capacity 5, got %x with capacity %d", got, cap(got))
+	}
+	if got := Concat(); len(got) != 0 {
+		t.Errorf("Concat failed. Expected an empty slice, got %x", got)
+	}
+	a, w := make([]byte, 20), make([]byte, 32)
+	if n := testing.AllocsPerRun(10, func() { Concat(a, w, w) }); n != 1 {
+		t.Errorf("Concat failed. Expected a single allocation, got %v", n)
+	}
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want []byte
+	}{
+		{"PadLeft", PadLeft([]byte{1, 2}, 4), []byte{0, 0, 1, 2}},
+		{"PadLeft long", PadLeft([]byte{1, 2, 3}, 2), []byte{1, 2, 3}},
+		{"PadRight", PadRight([]byte{1, 2}, 4), []byte{1, 2, 0, 0}},
+		{"PadRight32", PadRight32([]byte{1}), append([]byte{1}, make([]byte, 31)...)},
+		{"PadRight32 aligned", PadRight32(make([]byte, 64)), make([]byte, 64)},
+		{"PadRight32 empty", PadRight32(nil), nil},
+	}
+	for _, tt := range tests {
+		if !bytes.Equal(tt.got, tt.want) {
+			t.Errorf("%s failed. Expected %x, got %x", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestSlice(t *testing.T) {
+	b := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got, err := Slice(b, 2, 5); err != nil || !bytes.Equal(got, []byte{2, 3, 4}) {
+		t.Errorf("Slice failed. Expected 020304, got %x, %v", got, err)
+	}
+	for _, r := range [][2]int{{-1, 2}, {5, 4}, {8, 11}} {
+		if _, err := Slice(b, r[0], r[1]); err != ErrOutOfBounds {
+			t.Errorf("Slice(%d, %d) failed. Expected ErrOutOfBounds, got %v", r[0], r[1], err)
+		}
+	}
+}
+
+func TestUints(t *testing.T) {
+	b := AppendUint16(nil, 0x0102)
+	b = AppendUint32(b, 0x03040506)
+	b = AppendUint64(b, 0x0708090a0b0c0d0e)
+	if !bytes.Equal(b, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}) {
+		t.Fatalf("Append failed. Expected 0102..0e, got %x", b)
+	}
+
+	if v, err := Uint16(b, 0); err != nil || v != 0x0102 {
+		t.Errorf("Uint16 failed. Expected 0x0102, got %#x, %v", v, err)
+	}
+	if v, err := Uint32(b, 2); err != nil || v != 0x03040506 {
+		t.Errorf("Uint32 failed. Expected 0x03040506, got %#x, %v", v, err)
+	}
+	if v, err := Uint64(b, 6); err != nil || v != 0x0708090a0b0c0d0e {
+		t.Errorf("Uint64 failed. Expected 0x0708090a0b0c0d0e, got %#x, %v", v, err)
+	}
+	if _, err := Uint64(b, 7); err != ErrOutOfBounds {
+		t.Errorf("Uint64 failed. Expected ErrOutOfBounds, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package stygos
+
+// AllGas forwards all remaining gas to a call, subject to the 63/64 rule.
+const AllGas = ^uint64(0)
+
+// GetMsgSender returns the address of the account that called this contract
+func GetMsgSender() Address {
+	var sender Address
+	MsgSender(&sender[0])
+	return sender
+}
+
+// GetContractAddress returns the address of the executing contract
+func GetContractAddress() Address {
+	var addr Address
+	ContractAddress(&addr[0])
+	return addr
+}
+
+// GetBalance returns the ETH balance of an account in wei
+func GetBalance(addr Address) U256 {
+	var balance Word
+	AccountBalance(&addr[0], &balance[0])
+	return U256FromWord(balance)
+}
+
+// GetCodeSize returns the size of the code of an account, zero for
+// accounts without code such as EOAs. A contract under construction also
+// has no code yet. From ArbOS 40 an EOA may carry EIP-7702 delegation code
+// (see CapDelegatedAccounts), so code no longer proves an account is a
+// contract.
+func GetCodeSize(addr Address) uint32 {
+	return AccountCodeSize(&addr[0])
+}
+
+// Transfer sends value wei to an account, running its code if it is a
+// contract.
+func Transfer(to Address, value U256) error {
+	_, err := Call(to, value.Word(), nil)
+	return err
+}
+
+// Call calls the contract at to with the given value and calldata,
+// forwarding all gas, and returns its return data.
+func Call(to Address, value Word, data []byte) ([]byte, error) {
+	return CallGas(to, value, data, AllGas)
+}
+
+// CallGas is Call with an explicit gas limit.
+func CallGas(to Address, value Word, data []byte, gas uint64) ([]byte, error) {
+	if len(data) > MaxCallDataSize {
+		return nil, ErrMemoryLimit
+	}
+	var retLen uint32
+	status := CallContract(&to[0], dataPtr(data), uint32(len(data)), &value[0], gas, &retLen)
+	return callResult(status, retLen)
+}
+
+// StaticCall calls the contract at to without allowing state changes and
+// returns its return data.
+func StaticCall(to Address, data []byte) ([]byte, error) {
+	if len(data) > MaxCallDataSize {
+		return nil, ErrMemoryLimit
+	}
+	var retLen uint32
+	status := StaticCallContract(&to[0], dataPtr(data), uint32(len(data)), AllGas, &retLen)
+	return callResult(status, retLen)
+}
+
+// callResult reads the return data of the last call and maps a non-zero
+// status to an *ErrCallFailed. The return data is also returned alongside
+// it.
+func callResult(status uint8, retLen uint32) ([]byte, error) {
+	if retLen > MaxCallDataSize {
+		return nil, ErrMemoryLimit
+	}
+	var ret []byte
+	if retLen > 0 {
+		ret = make([]byte, retLen)
+		ReadReturnData(&ret[0], 0, retLen)
+	}
+	if status != statusSuccess {
+		return ret, callError(status, ret)
+	}
+	return ret, nil
+}
+
+// dataPtr returns a pointer to the first byte of data, or nil if it is
+// empty.
+func dataPtr(data []byte) *byte {
+	if len(data) == 0 {
+		return nil
+	}
+	return &data[0]
+}
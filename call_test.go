@@ -0,0 +1,199 @@
+package stygos
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestCallSwitchesFrame(t *testing.T) {
+	mock := NewMockRuntime()
+	mock.Contract = Address{0xa1}
+	mock.SetBalance(mock.Contract, big.NewInt(5))
+	UseRuntime(mock)
+
+	callee := Address{0xb2}
+	var seenSender, seenSelf Address
+	var seenValue uint64
+	mock.Deploy(callee, func(input []byte) ([]byte, error) {
+		seenSender = GetMsgSender()
+		seenSelf = GetContractAddress()
+		seenValue = GetMsgValue().Uint64()
+		StorageStore(Word{1}, WordFromUint64(7))
+		return append([]byte("echo:"), input...), nil
+	})
+
+	StorageStore(Word{1}, WordFromUint64(1))
+	out, err := Call(callee, WordFromUint64(5), []byte("hi"))
+	if err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	if string(out) != "echo:hi" {
+		t.Errorf("Call failed. Expected echo:hi, got %q", out)
+	}
+	if seenSender != mock.Contract || seenSelf != callee || seenValue != 5 {
+		t.Errorf("Call frame failed. Got sender %x, self %x, value %d", seenSender, seenSelf, seenValue)
+	}
+
+	// Each contract has its own storage
+	if got := Uint64FromWord(StorageLoad(Word{1})); got != 1 {
+		t.Errorf("Caller storage failed. Expected 1, got %d", got)
+	}
+	if got := Uint64FromWord(mock.StorageOf(callee)[Word{1}]); got != 7 {
+		t.Errorf("Callee storage failed. Expected 7, got %d", got)
+	}
+	if GetContractAddress() != (Address{0xa1}) {
+		t.Errorf("Frame not restored after call")
+	}
+}
+
+func TestCallRevertRollsBack(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	callee := Address{0xc3}
+	mock.Deploy(callee, func(input []byte) ([]byte, error) {
+		StorageStore(Word{2}, WordFromUint64(9))
+		return []byte("nope"), errors.New("revert")
+	})
+
+	out, err := Call(callee, Word{}, nil)
+	if !errors.Is(err, ErrRevert) {
+		t.Errorf("Call failed. Expected ErrRevert, got %v", err)
+	}
+	if string(out) != "nope" {
+		t.Errorf("Revert data failed. Expected nope, got %q", out)
+	}
+	if len(mock.StorageOf(callee)) != 0 {
+		t.Errorf("Revert failed to roll back callee storage")
+	}
+
+	// Calls to addresses without code succeed with no data
+	out, err = Call(Address{0xee}, Word{}, []byte{1})
+	if err != nil || len(out) != 0 {
+		t.Errorf("Call to EOA failed: %x, %v", out, err)
+	}
+}
+
+func TestRevertRollsBackSubCalls(t *testing.T) {
+	mock := NewMockRuntime()
+	mock.Contract = Address{0xa1}
+	mock.SetBalance(mock.Contract, big.NewInt(10))
+	UseRuntime(mock)
+
+	outer, inner, payee := Address{0xd4}, Address{0xe5}, Address{0xf6}
+	mock.Deploy(inner, func(input []byte) ([]byte, error) {
+		StorageStore(Word{5}, WordFromUint64(6))
+		EmitEvent(nil, Word{0x0e})
+		return nil, Transfer(payee, NewU256(3))
+	})
+	mock.Deploy(outer, func(input []byte) ([]byte, error) {
+		if _, err := Call(inner, WordFromUint64(4), nil); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("revert")
+	})
+
+	if _, err := Call(outer, WordFromUint64(4), nil); !errors.Is(err, ErrRevert) {
+		t.Fatalf("Call failed. Expected ErrRevert, got %v", err)
+	}
+	if len(mock.StorageOf(inner)) != 0 {
+		t.Errorf("Revert failed to roll back the storage of a successful sub-call")
+	}
+	if got := mock.BalanceOf(payee).Int64(); got != 0 {
+		t.Errorf("Revert failed to roll back a transfer of a sub-call. Expected 0, got %d", got)
+	}
+	if got := mock.BalanceOf(inner).Int64(); got != 0 {
+		t.Errorf("Revert failed to roll back the value of a sub-call. Expected 0, got %d", got)
+	}
+	if got := mock.BalanceOf(mock.Contract).Int64(); got != 10 {
+		t.Errorf("Revert failed to refund the caller. Expected 10, got %d", got)
+	}
+	if len(mock.Logs) != 0 {
+		t.Errorf("Revert failed to drop the logs of a sub-call, got %d", len(mock.Logs))
+	}
+}
+
+func TestNestedCallKeepsStorage(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	outer, inner := Address{0xd4}, Address{0xe5}
+	mock.Deploy(inner, func(input []byte) ([]byte, error) { return nil, nil })
+	mock.Deploy(outer, func(input []byte) ([]byte, error) {
+		StorageStore(Word{3}, WordFromUint64(4))
+		return Call(inner, Word{}, nil)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := Call(outer, Word{}, nil); err != nil {
+			t.Fatalf("Call failed: %v", err)
+		}
+		if got := Uint64FromWord(mock.StorageOf(outer)[Word{3}]); got != 4 {
+			t.Errorf("Nested call failed. Expected outer storage 4, got %d", got)
+		}
+	}
+}
+
+func TestStaticCallRejectsWrites(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	reader := Address{0xd4}
+	writer := Address{0xd5}
+	mock.Deploy(reader, func(input []byte) ([]byte, error) {
+		v := StorageLoad(Word{3})
+		return v[:], nil
+	})
+	mock.Deploy(writer, func(input []byte) ([]byte, error) {
+		StorageStore(Word{3}, WordFromUint64(1))
+		return nil, nil
+	})
+	mock.StorageOf(reader)[Word{3}] = WordFromUint64(42)
+
+	out, err := StaticCall(reader, nil)
+	if err != nil || Uint64FromWord(wordAt(out, 0)) != 42 {
+		t.Errorf("StaticCall failed. Expected 42, got %x, %v", out, err)
+	}
+	if _, err := StaticCall(writer, nil); !errors.Is(err, ErrRevert) {
+		t.Errorf("StaticCall failed. Expected write to revert, got %v", err)
+	}
+}
+
+func TestMockEntrypoint(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	callee := Address{0xe5}
+	mock.Deploy(callee, MockEntrypoint(func() int32 {
+		data, _ := GetCallData()
+		if len(data) == 0 {
+			return 1
+		}
+		SetReturnData(bytes.ToUpper(data))
+		return 0
+	}))
+
+	out, err := Call(callee, Word{}, []byte("abc"))
+	if err != nil || string(out) != "ABC" {
+		t.Errorf("MockEntrypoint failed. Expected ABC, got %q, %v", out, err)
+	}
+	if _, err := Call(callee, Word{}, nil); !errors.Is(err, ErrRevert) {
+		t.Errorf("MockEntrypoint failed. Expected revert, got %v", err)
+	}
+}
+
+func TestGetCodeSize(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	contract := Address{0xc0}
+	mock.Deploy(contract, func([]byte) ([]byte, error) { return nil, nil })
+	if GetCodeSize(contract) == 0 {
+		t.Errorf("GetCodeSize failed. Expected code at a deployed contract")
+	}
+	if size := GetCodeSize(Address{0xe0}); size != 0 {
+		t.Errorf("GetCodeSize failed. Expected no code at an EOA, got %d", size)
+	}
+}
@@ -0,0 +1,223 @@
+// Package chrono converts between block numbers, block timestamps and Go
+// durations, and guards code on deadlines and windows.
+//
+// Timestamps are block timestamps in seconds, as returned by
+// stygos.GetBlockTimestamp; durations are time.Duration values truncated
+// to whole seconds, so a voting period can be written as 3*chrono.Day
+// rather than a bare 259200. The guards spell out which side of a bound
+// is inclusive: a window [start, end) is open from start up to, but not
+// including, end, and a deadline has passed from the deadline itself on.
+//
+//	end, err := chrono.Deadline(7 * chrono.Day)
+//	...
+//	if err := chrono.Before(end); err != nil {
+//		return err // voting has closed
+//	}
+package chrono
+
+import (
+	"errors"
+	"math/bits"
+	"time"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Time errors
+var (
+	ErrTooEarly        = errors.New("chrono: too early")
+	ErrTooLate         = errors.New("chrono: too late")
+	ErrInvalidDuration = errors.New("chrono: invalid duration")
+	ErrOverflow        = errors.New("chrono: timestamp overflow")
+)
+
+// Durations longer than the time package's, which stops at time.Hour.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// L1BlockTime is the Ethereum slot time. On Arbitrum the block number
+// hostio reports the L1 block, which advances at this rate.
+const L1BlockTime = 12 * time.Second
+
+// Now returns the current block timestamp.
+func Now() uint64 {
+	return stygos.GetBlockTimestamp()
+}
+
+// Seconds returns d in whole seconds, truncating any fraction.
+func Seconds(d time.Duration) (uint64, error) {
+	if d < 0 {
+		return 0, ErrInvalidDuration
+	}
+	return uint64(d / time.Second), nil
+}
+
+// Duration returns a number of seconds as a duration, saturating at the
+// largest time.Duration.
+func Duration(seconds uint64) time.Duration {
+	if seconds > uint64(1<<63-1)/uint64(time.Second) {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Add returns the timestamp d after ts.
+func Add(ts uint64, d time.Duration) (uint64, error) {
+	s, err := Seconds(d)
+	if err != nil {
+		return 0, err
+	}
+	t, carry := bits.Add64(ts, s, 0)
+	if carry != 0 {
+		return 0, ErrOverflow
+	}
+	return t, nil
+}
+
+// Deadline returns the timestamp d from now.
+func Deadline(d time.Duration) (uint64, error) {
+	return Add(Now(), d)
+}
+
+// Since returns the time elapsed since ts, or zero if ts is in the future.
+func Since(ts uint64) time.Duration {
+	if now := Now(); now > ts {
+		return Duration(now - ts)
+	}
+	return 0
+}
+
+// Until returns the time left until ts, or zero if ts has passed.
+func Until(ts uint64) time.Duration {
+	if now := Now(); ts > now {
+		return Duration(ts - now)
+	}
+	return 0
+}
+
+// After returns nil once deadline has been reached, and ErrTooEarly
+// before.
+func After(deadline uint64) error {
+	if Now() < deadline {
+		return ErrTooEarly
+	}
+	return nil
+}
+
+// Before returns nil until deadline is reached, and ErrTooLate from then
+// on.
+func Before(deadline uint64) error {
+	if Now() >= deadline {
+		return ErrTooLate
+	}
+	return nil
+}
+
+// Between returns nil while the current timestamp is in [start, end),
+// ErrTooEarly before it and ErrTooLate after.
+func Between(start, end uint64) error {
+	return between(Now(), start, end)
+}
+
+// AfterBlock is After for block numbers.
+func AfterBlock(block uint64) error {
+	if stygos.GetBlockNumber() < block {
+		return ErrTooEarly
+	}
+	return nil
+}
+
+// BeforeBlock is Before for block numbers.
+func BeforeBlock(block uint64) error {
+	if stygos.GetBlockNumber() >= block {
+		return ErrTooLate
+	}
+	return nil
+}
+
+// BetweenBlocks is Between for block numbers.
+func BetweenBlocks(start, end uint64) error {
+	return between(stygos.GetBlockNumber(), start, end)
+}
+
+func between(now, start, end uint64) error {
+	if now < start {
+		return ErrTooEarly
+	}
+	if now >= end {
+		return ErrTooLate
+	}
+	return nil
+}
+
+// Blocks returns the number of blocks of blockTime that span d, rounded
+// up so a period measured in blocks is never shorter than d.
+func Blocks(d, blockTime time.Duration) (uint64, error) {
+	if d < 0 || blockTime <= 0 {
+		return 0, ErrInvalidDuration
+	}
+	n := d / blockTime
+	if d%blockTime != 0 {
+		n++
+	}
+	return uint64(n), nil
+}
+
+// BlockDuration returns the time n blocks of blockTime take, saturating at
+// the largest time.Duration.
+func BlockDuration(n uint64, blockTime time.Duration) time.Duration {
+	if blockTime <= 0 {
+		return 0
+	}
+	if n > uint64(1<<63-1)/uint64(blockTime) {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(n) * blockTime
+}
+
+// EstimateBlock estimates the block number at timestamp ts from the
+// current block and timestamp, assuming blocks of blockTime. Timestamps
+// before the chain could have reached them estimate block zero.
+func EstimateBlock(ts uint64, blockTime time.Duration) (uint64, error) {
+	bt, err := Seconds(blockTime)
+	if err != nil || bt == 0 {
+		return 0, ErrInvalidDuration
+	}
+	block, now := stygos.GetBlockNumber(), Now()
+	if ts >= now {
+		b, carry := bits.Add64(block, (ts-now)/bt, 0)
+		if carry != 0 {
+			return 0, ErrOverflow
+		}
+		return b, nil
+	}
+	if back := (now - ts) / bt; back < block {
+		return block - back, nil
+	}
+	return 0, nil
+}
+
+// EstimateTimestamp estimates the timestamp of block from the current
+// block and timestamp, assuming blocks of blockTime.
+func EstimateTimestamp(block uint64, blockTime time.Duration) (uint64, error) {
+	bt, err := Seconds(blockTime)
+	if err != nil {
+		return 0, err
+	}
+	current, now := stygos.GetBlockNumber(), Now()
+	if block >= current {
+		hi, lo := bits.Mul64(block-current, bt)
+		t, carry := bits.Add64(now, lo, 0)
+		if hi != 0 || carry != 0 {
+			return 0, ErrOverflow
+		}
+		return t, nil
+	}
+	hi, back := bits.Mul64(current-block, bt)
+	if hi != 0 || back > now {
+		return 0, nil
+	}
+	return now - back, nil
+}
@@ -0,0 +1,122 @@
+package chrono
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func setup(t *testing.T) *stygos.MockRuntime {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Block = 1_000
+	mock.Time = 1_700_000_000
+	stygos.UseRuntime(mock)
+	return mock
+}
+
+func TestDurations(t *testing.T) {
+	setup(t)
+
+	if s, err := Seconds(Day + 1500*time.Millisecond); err != nil || s != 86_401 {
+		t.Errorf("Seconds failed. Expected 86401, got %d, %v", s, err)
+	}
+	if _, err := Seconds(-time.Second); err != ErrInvalidDuration {
+		t.Errorf("Seconds failed. Expected ErrInvalidDuration, got %v", err)
+	}
+	if d := Duration(3_600); d != time.Hour {
+		t.Errorf("Duration failed. Expected 1h, got %v", d)
+	}
+	if d := Duration(^uint64(0)); d != time.Duration(1<<63-1) {
+		t.Errorf("Duration failed. Expected saturation, got %v", d)
+	}
+
+	end, err := Deadline(Week)
+	if err != nil || end != 1_700_000_000+604_800 {
+		t.Errorf("Deadline failed. Expected %d, got %d, %v", 1_700_000_000+604_800, end, err)
+	}
+	if _, err := Add(^uint64(0), time.Second); err != ErrOverflow {
+		t.Errorf("Add failed. Expected ErrOverflow, got %v", err)
+	}
+	if d := Until(end); d != Week {
+		t.Errorf("Until failed. Expected 168h, got %v", d)
+	}
+	if d := Since(end); d != 0 {
+		t.Errorf("Since failed. Expected 0 for a future timestamp, got %v", d)
+	}
+	if d := Since(1_700_000_000 - 90); d != 90*time.Second {
+		t.Errorf("Since failed. Expected 1m30s, got %v", d)
+	}
+}
+
+func TestGuards(t *testing.T) {
+	mock := setup(t)
+	start, end := uint64(1_700_000_100), uint64(1_700_000_200)
+
+	tests := []struct {
+		now                  uint64
+		after, before, fence error
+	}{
+		{start - 1, ErrTooEarly, nil, ErrTooEarly},
+		{start, nil, nil, nil},
+		{end - 1, nil, nil, nil},
+		{end, nil, ErrTooLate, ErrTooLate},
+	}
+	for _, tt := range tests {
+		mock.Time = tt.now
+		if err := After(start); err != tt.after {
+			t.Errorf("After(%d) at %d failed. Expected %v, got %v", start, tt.now, tt.after, err)
+		}
+		if err := Before(end); err != tt.before {
+			t.Errorf("Before(%d) at %d failed. Expected %v, got %v", end, tt.now, tt.before, err)
+		}
+		if err := Between(start, end); err != tt.fence {
+			t.Errorf("Between(%d, %d) at %d failed. Expected %v, got %v", start, end, tt.now, tt.fence, err)
+		}
+	}
+
+	mock.Block = 10
+	if err := AfterBlock(11); err != ErrTooEarly {
+		t.Errorf("AfterBlock failed. Expected ErrTooEarly, got %v", err)
+	}
+	if err := BeforeBlock(10); err != ErrTooLate {
+		t.Errorf("BeforeBlock failed. Expected ErrTooLate, got %v", err)
+	}
+	if err := BetweenBlocks(5, 11); err != nil {
+		t.Errorf("BetweenBlocks failed. Expected nil, got %v", err)
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	setup(t)
+
+	if n, err := Blocks(time.Hour, L1BlockTime); err != nil || n != 300 {
+		t.Errorf("Blocks failed. Expected 300, got %d, %v", n, err)
+	}
+	if n, _ := Blocks(13*time.Second, L1BlockTime); n != 2 {
+		t.Errorf("Blocks failed. Expected 13s to round up to 2 blocks, got %d", n)
+	}
+	if _, err := Blocks(time.Minute, 0); err != ErrInvalidDuration {
+		t.Errorf("Blocks failed. Expected ErrInvalidDuration, got %v", err)
+	}
+	if d := BlockDuration(300, L1BlockTime); d != time.Hour {
+		t.Errorf("BlockDuration failed. Expected 1h, got %v", d)
+	}
+
+	if b, err := EstimateBlock(1_700_000_000+120, L1BlockTime); err != nil || b != 1_010 {
+		t.Errorf("EstimateBlock failed. Expected 1010, got %d, %v", b, err)
+	}
+	if b, _ := EstimateBlock(1_700_000_000-120, L1BlockTime); b != 990 {
+		t.Errorf("EstimateBlock failed. Expected 990, got %d", b)
+	}
+	if b, _ := EstimateBlock(0, L1BlockTime); b != 0 {
+		t.Errorf("EstimateBlock failed. Expected 0 before genesis, got %d", b)
+	}
+	if ts, err := EstimateTimestamp(1_010, L1BlockTime); err != nil || ts != 1_700_000_120 {
+		t.Errorf("EstimateTimestamp failed. Expected 1700000120, got %d, %v", ts, err)
+	}
+	if ts, _ := EstimateTimestamp(990, L1BlockTime); ts != 1_699_999_880 {
+		t.Errorf("EstimateTimestamp failed. Expected 1699999880, got %d", ts)
+	}
+}
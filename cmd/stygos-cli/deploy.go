@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/script"
+)
+
+// runDeploy implements `stygos-cli deploy [package]`. It builds and
+// compresses the contract as size does, or reads it from -wasm, then
+// deploys and activates it with the key in $PRIVATE_KEY. With -init the
+// initializer runs in the same transaction through the StylusDeployer, so
+// the deployment cannot be front-run between deploy and initialize.
+func runDeploy(args []string) error {
+	fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	rpc := fs.String("rpc", "http://localhost:8547", "JSON-RPC endpoint")
+	wasmFile := fs.String("wasm", "", "deploy this wasm, or brotli-compressed wasm, instead of building")
+	initHex := fs.String("init", "", "hex calldata of the initializer, run atomically with the deployment")
+	value := fs.String("value", "", "wei sent to the initializer")
+	saltHex := fs.String("salt", "", "CREATE2 salt for -init, 32 bytes hex (default keccak256 of -name)")
+	journal := fs.String("journal", "", "journal file, to resume an interrupted deployment")
+	name := fs.String("name", "deploy", "step name in the journal")
+	noOpt := fs.Bool("noopt", false, "skip wasm-opt even when installed")
+	imports := addImportFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	var opts script.InitOptions
+	var initData []byte
+	if *initHex != "" {
+		var err error
+		if initData, err = hex.DecodeString(strings.TrimPrefix(*initHex, "0x")); err != nil {
+			return fmt.Errorf("deploy: -init: %v", err)
+		}
+	}
+	if *value != "" {
+		v, ok := new(big.Int).SetString(*value, 10)
+		if !ok || v.Sign() < 0 {
+			return fmt.Errorf("deploy: -value: invalid amount %q", *value)
+		}
+		opts.Value = v
+	}
+	if *saltHex != "" {
+		var err error
+		if opts.Salt, err = stygos.WordFromHex(*saltHex); err != nil {
+			return fmt.Errorf("deploy: -salt: %v", err)
+		}
+	}
+	if initData == nil && (opts.Value != nil || *saltHex != "") {
+		return fmt.Errorf("deploy: -value and -salt need -init")
+	}
+
+	keyHex := os.Getenv("PRIVATE_KEY")
+	if keyHex == "" {
+		return fmt.Errorf("deploy: PRIVATE_KEY is not set")
+	}
+	key, err := script.ParseKey(keyHex)
+	if err != nil {
+		return err
+	}
+
+	code, err := deployable(*wasmFile, pkg, *noOpt, imports)
+	if err != nil {
+		return err
+	}
+
+	backend, err := script.Dial(*rpc, key)
+	if err != nil {
+		return err
+	}
+	s, err := script.New(backend, *journal)
+	if err != nil {
+		return err
+	}
+	s.Log = os.Stderr
+
+	var addr stygos.Address
+	if initData != nil {
+		addr, err = s.DeployAndInit(*name, code, initData, opts)
+	} else {
+		addr, err = s.Deploy(*name, code)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(addr.Hex())
+	return nil
+}
+
+// deployable returns the brotli-compressed program to deploy: the file
+// as is if it is already compressed, or the wasm file or the built package
+// stripped, optimized, checked against imports and compressed.
+func deployable(wasmFile, pkg string, noOpt bool, imports *importPolicy) ([]byte, error) {
+	var built []byte
+	var err error
+	if wasmFile != "" {
+		if built, err = os.ReadFile(wasmFile); err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(built, wasmMagic) {
+			return built, nil
+		}
+	} else if built, err = buildWasm(pkg); err != nil {
+		return nil, err
+	}
+
+	_, deploy, err := prepare(built, noOpt, imports)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := brotli(deploy)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) > stylusLimit {
+		return nil, fmt.Errorf("deploy: %d bytes compressed, over the %d byte limit", len(compressed), stylusLimit)
+	}
+	return compressed, nil
+}
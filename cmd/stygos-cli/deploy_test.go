@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeployable(t *testing.T) {
+	// Compressed programs are deployed as they are
+	path := filepath.Join(t.TempDir(), "counter.wasm.br")
+	if err := os.WriteFile(path, []byte("compressed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, err := deployable(path, "", true, &importPolicy{ArbOS: defaultArbOS})
+	if err != nil || string(code) != "compressed" {
+		t.Errorf("deployable failed. Expected the file unchanged, got %q, %v", code, err)
+	}
+}
+
+func TestDeployFlags(t *testing.T) {
+	t.Setenv("PRIVATE_KEY", "")
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-init", "0xzz"}, "-init"},
+		{[]string{"-value", "-1", "-init", "00"}, "-value"},
+		{[]string{"-salt", "01"}, "-salt"},
+		{[]string{"-value", "1"}, "need -init"},
+		{[]string{"-init", "00"}, "PRIVATE_KEY"},
+	}
+	for _, tt := range tests {
+		err := runDeploy(tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("runDeploy(%v) failed. Expected an error about %s, got %v", tt.args, tt.want, err)
+		}
+	}
+}
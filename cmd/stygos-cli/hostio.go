@@ -0,0 +1,218 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// hostModule is the module Stylus provides its hostios in.
+const hostModule = "vm_hooks"
+
+// debugModule holds the hostios of chains in debug mode, such as log_txt.
+// Other chains reject programs importing them.
+const debugModule = "console"
+
+// defaultArbOS is the ArbOS version imports are checked against unless
+// -arbos says otherwise.
+const defaultArbOS = 32
+
+// stylusArbOS is the ArbOS version Stylus went live in.
+const stylusArbOS = 30
+
+// testnetArbOS is the ArbOS version of the Stylus testnets, which the SDK
+// targets when built with the arbos20 tag.
+const testnetArbOS = 20
+
+// hostIOSince maps each hostio to the first ArbOS version providing it.
+var hostIOSince = map[string]uint64{
+	"account_balance":         20,
+	"account_code":            20,
+	"account_code_size":       20,
+	"account_codehash":        20,
+	"block_basefee":           20,
+	"block_coinbase":          20,
+	"block_gas_limit":         20,
+	"block_number":            20,
+	"block_timestamp":         20,
+	"call_contract":           20,
+	"chainid":                 20,
+	"contract_address":        20,
+	"create1":                 20,
+	"create2":                 20,
+	"delegate_call_contract":  20,
+	"emit_log":                20,
+	"evm_gas_left":            20,
+	"evm_ink_left":            20,
+	"exit_early":              20,
+	"msg_reentrant":           20,
+	"msg_sender":              20,
+	"msg_value":               20,
+	"native_keccak256":        20,
+	"pay_for_memory_grow":     30,
+	"read_args":               20,
+	"read_return_data":        20,
+	"return_data_size":        20,
+	"static_call_contract":    20,
+	"storage_cache_bytes32":   30,
+	"storage_flush_cache":     30,
+	"storage_load_bytes32":    20,
+	"transient_load_bytes32":  30,
+	"transient_store_bytes32": 30,
+	"tx_gas_price":            20,
+	"tx_ink_price":            20,
+	"tx_origin":               20,
+	"write_result":            20,
+}
+
+// hostIOTypes maps each hostio to its wasm function type in Stylus. A
+// program importing a hostio under another type fails activation even when
+// the name is right.
+var hostIOTypes = map[string]string{
+	"account_balance":         "(i32, i32)",
+	"account_code":            "(i32, i32, i32, i32) -> i32",
+	"account_code_size":       "(i32) -> i32",
+	"account_codehash":        "(i32, i32)",
+	"block_basefee":           "(i32)",
+	"block_coinbase":          "(i32)",
+	"block_gas_limit":         "() -> i64",
+	"block_number":            "() -> i64",
+	"block_timestamp":         "() -> i64",
+	"call_contract":           "(i32, i32, i32, i32, i64, i32) -> i32",
+	"chainid":                 "() -> i64",
+	"contract_address":        "(i32)",
+	"create1":                 "(i32, i32, i32, i32, i32)",
+	"create2":                 "(i32, i32, i32, i32, i32, i32)",
+	"delegate_call_contract":  "(i32, i32, i32, i64, i32) -> i32",
+	"emit_log":                "(i32, i32, i32)",
+	"evm_gas_left":            "() -> i64",
+	"evm_ink_left":            "() -> i64",
+	"exit_early":              "(i32)",
+	"memory_grow":             "(i32)",
+	"msg_reentrant":           "() -> i32",
+	"msg_sender":              "(i32)",
+	"msg_value":               "(i32)",
+	"native_keccak256":        "(i32, i32, i32)",
+	"pay_for_memory_grow":     "(i32)",
+	"read_args":               "(i32)",
+	"read_return_data":        "(i32, i32, i32) -> i32",
+	"return_data_size":        "() -> i32",
+	"static_call_contract":    "(i32, i32, i32, i64, i32) -> i32",
+	"storage_cache_bytes32":   "(i32, i32)",
+	"storage_flush_cache":     "(i32)",
+	"storage_load_bytes32":    "(i32, i32)",
+	"storage_store_bytes32":   "(i32, i32)",
+	"transient_load_bytes32":  "(i32, i32)",
+	"transient_store_bytes32": "(i32, i32)",
+	"tx_gas_price":            "(i32)",
+	"tx_ink_price":            "() -> i32",
+	"tx_origin":               "(i32)",
+	"write_result":            "(i32, i32)",
+}
+
+// retiredHostIOs are hostios of the Stylus testnets that ArbOS 30 no longer
+// provides, with what replaced them.
+var retiredHostIOs = map[string]string{
+	"memory_grow":           "pay_for_memory_grow",
+	"storage_store_bytes32": "storage_cache_bytes32 and storage_flush_cache",
+}
+
+// importPolicy is what a program may import to activate on a chain: the
+// hostios of its ArbOS version, the debug hostios on debug chains, and
+// anything allowed explicitly.
+type importPolicy struct {
+	ArbOS uint64
+	Debug bool
+	Allow string // comma-separated module.name imports
+}
+
+// addImportFlags registers the flags of an import policy on fs.
+func addImportFlags(fs *flag.FlagSet) *importPolicy {
+	p := new(importPolicy)
+	fs.Uint64Var(&p.ArbOS, "arbos", defaultArbOS, "ArbOS version of the target chain, whose hostios the program may import")
+	fs.BoolVar(&p.Debug, "debug-hostios", false, "allow the console hostios of debug chains")
+	fs.StringVar(&p.Allow, "allow-imports", "", "comma-separated module.name imports to accept besides the hostios")
+	return p
+}
+
+// check fails if m imports anything the policy does not provide, or a
+// hostio under another type than Stylus gives it, listing each offending
+// import with the reason.
+func (p *importPolicy) check(m *module) error {
+	if p.ArbOS < testnetArbOS {
+		return fmt.Errorf("ArbOS %d predates Stylus, which needs ArbOS %d", p.ArbOS, testnetArbOS)
+	}
+	allowed := make(map[string]bool)
+	for _, name := range strings.Split(p.Allow, ",") {
+		allowed[strings.TrimSpace(name)] = true
+	}
+
+	var problems []string
+	for _, imp := range m.Imports {
+		key := imp.Module + "." + imp.Name
+		since, known := hostIOSince[imp.Name]
+		switch {
+		case allowed[key]:
+		case imp.Kind != 0:
+			problems = append(problems, key+": imports a "+importKind(imp.Kind)+"; Stylus programs define their own")
+		case imp.Module == debugModule:
+			if !p.Debug {
+				problems = append(problems, key+": only debug chains provide it (-debug-hostios)")
+			}
+		case imp.Module != hostModule:
+			problems = append(problems, key+": not a Stylus hostio")
+		case retiredHostIOs[imp.Name] != "" && p.ArbOS >= stylusArbOS:
+			problems = append(problems, fmt.Sprintf("%s: a Stylus testnet hostio ArbOS %d replaced with %s", key, stylusArbOS, retiredHostIOs[imp.Name]))
+		case !known && retiredHostIOs[imp.Name] == "":
+			problems = append(problems, key+": not a Stylus hostio")
+		case since > p.ArbOS:
+			problems = append(problems, fmt.Sprintf("%s: needs ArbOS %d", key, since))
+		case imp.Type != hostIOTypes[imp.Name]:
+			problems = append(problems, fmt.Sprintf("%s: imported as %s, Stylus defines %s", key, imp.Type, hostIOTypes[imp.Name]))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("ArbOS %d cannot activate a program with these imports:\n\t%s", p.ArbOS, strings.Join(problems, "\n\t"))
+}
+
+// requiredExports are the exports Stylus calls into a program through.
+var requiredExports = []wasmExport{
+	{Name: "user_entrypoint", Kind: 0},
+	{Name: "memory", Kind: 2},
+}
+
+// checkExports fails if m lacks an export Stylus needs to activate and
+// call it. Without user_entrypoint, which the SDK exports once a contract
+// calls stygos.SetEntrypoint, every call would fail.
+func checkExports(m *module) error {
+	var problems []string
+	for _, want := range requiredExports {
+		found := false
+		for _, e := range m.Exports {
+			found = found || e == want
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: no %s export", want.Name, importKind(want.Kind)))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Stylus cannot call a program without these exports (register the entrypoint with stygos.SetEntrypoint):\n\t%s", strings.Join(problems, "\n\t"))
+}
+
+func importKind(kind byte) string {
+	switch kind {
+	case 1:
+		return "table"
+	case 2:
+		return "memory"
+	case 3:
+		return "global"
+	}
+	return "function"
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestImportPolicy(t *testing.T) {
+	hostio := func(name string) wasmImport {
+		return wasmImport{Module: hostModule, Name: name, Type: hostIOTypes[name]}
+	}
+	typed := func(name, typ string) wasmImport { return wasmImport{Module: hostModule, Name: name, Type: typ} }
+	tests := []struct {
+		policy  importPolicy
+		imports []wasmImport
+		want    []string // substrings of the error, none if accepted
+	}{
+		{importPolicy{ArbOS: 30}, []wasmImport{hostio("read_args"), hostio("storage_cache_bytes32")}, nil},
+		{importPolicy{ArbOS: 19}, []wasmImport{hostio("read_args")}, []string{"predates Stylus"}},
+		{importPolicy{ArbOS: 20}, []wasmImport{hostio("read_args"), hostio("storage_store_bytes32"), hostio("memory_grow")}, nil},
+		{importPolicy{ArbOS: 20}, []wasmImport{hostio("transient_load_bytes32")}, []string{"transient_load_bytes32: needs ArbOS 30"}},
+		{
+			importPolicy{ArbOS: defaultArbOS},
+			[]wasmImport{hostio("storage_store_bytes32"), hostio("memory_grow"), hostio("no_such_hostio")},
+			[]string{"storage_store_bytes32: a Stylus testnet hostio", "memory_grow: a Stylus testnet hostio ArbOS 30 replaced with pay_for_memory_grow", "vm_hooks.no_such_hostio: not a Stylus hostio"},
+		},
+		{
+			importPolicy{ArbOS: defaultArbOS},
+			[]wasmImport{{Module: "wasi_snapshot_preview1", Name: "fd_write"}, {Module: "env", Name: "memory", Kind: 2}},
+			[]string{"fd_write: not a Stylus hostio", "env.memory: imports a memory"},
+		},
+		{
+			// The names Stylus provides, under types it does not
+			importPolicy{ArbOS: defaultArbOS},
+			[]wasmImport{typed("read_args", "(i32) -> i32"), typed("block_number", "(i32)"), typed("emit_log", "(i32, i32, i32, i32, i32, i32, i32)")},
+			[]string{
+				"vm_hooks.read_args: imported as (i32) -> i32, Stylus defines (i32)",
+				"vm_hooks.block_number: imported as (i32), Stylus defines () -> i64",
+				"vm_hooks.emit_log: imported as (i32, i32, i32, i32, i32, i32, i32), Stylus defines (i32, i32, i32)",
+			},
+		},
+		{importPolicy{ArbOS: defaultArbOS}, []wasmImport{{Module: debugModule, Name: "log_txt"}}, []string{"-debug-hostios"}},
+		{importPolicy{ArbOS: defaultArbOS, Debug: true}, []wasmImport{{Module: debugModule, Name: "log_txt"}}, nil},
+		{importPolicy{ArbOS: defaultArbOS, Allow: "env.abort, wasi_snapshot_preview1.fd_write"}, []wasmImport{{Module: "wasi_snapshot_preview1", Name: "fd_write"}}, nil},
+	}
+	for _, tt := range tests {
+		err := tt.policy.check(&module{Imports: tt.imports})
+		if len(tt.want) == 0 {
+			if err != nil {
+				t.Errorf("check(%+v) failed. Expected the imports accepted, got %v", tt.policy, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("check(%+v) failed. Expected %v rejected", tt.policy, tt.imports)
+			continue
+		}
+		for _, w := range tt.want {
+			if !strings.Contains(err.Error(), w) {
+				t.Errorf("check(%+v) failed. Expected %q in %v", tt.policy, w, err)
+			}
+		}
+	}
+
+	// A hostio a later version introduces is refused before it
+	hostIOSince["future_hostio"] = defaultArbOS + 1
+	defer delete(hostIOSince, "future_hostio")
+	p := importPolicy{ArbOS: defaultArbOS}
+	if err := p.check(&module{Imports: []wasmImport{hostio("future_hostio")}}); err == nil || !strings.Contains(err.Error(), "needs ArbOS") {
+		t.Errorf("check failed. Expected a newer hostio refused, got %v", err)
+	}
+	p.ArbOS++
+	if err := p.check(&module{Imports: []wasmImport{hostio("future_hostio")}}); err != nil {
+		t.Errorf("check failed. Expected the hostio accepted from its version, got %v", err)
+	}
+}
+
+func TestSDKHostIOs(t *testing.T) {
+	// Every hostio the SDK imports activates on the version it is built for
+	common, err := sdkImports("../../host_import_tinygo.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(common) == 0 {
+		t.Fatal("no //go:wasmimport directives found")
+	}
+	for _, tt := range []struct {
+		file  string
+		arbos uint64
+	}{
+		{"../../host_import_tinygo_arbos30.go", defaultArbOS},
+		{"../../host_import_tinygo_arbos20.go", testnetArbOS},
+	} {
+		versioned, err := sdkImports(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		imports := append(append([]wasmImport(nil), common...), versioned...)
+		if err := (&importPolicy{ArbOS: tt.arbos}).check(&module{Imports: imports}); err != nil {
+			t.Errorf("%s imports hostios ArbOS %d lacks: %v", tt.file, tt.arbos, err)
+		}
+	}
+}
+
+// sdkImports reads the //go:wasmimport directives of a source file, with
+// the wasm type of the Go function each declares.
+func sdkImports(path string) ([]wasmImport, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var imports []wasmImport
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Doc == nil {
+			continue
+		}
+		for _, c := range fd.Doc.List {
+			if f := strings.Fields(c.Text); len(f) == 3 && f[0] == "//go:wasmimport" {
+				imports = append(imports, wasmImport{Module: f[1], Name: f[2], Type: wasmSignature(fd.Type)})
+			}
+		}
+	}
+	return imports, nil
+}
+
+// wasmSignature returns the wasm type TinyGo gives a function of type ft:
+// 64-bit integers are i64, and pointers and smaller integers i32.
+func wasmSignature(ft *ast.FuncType) string {
+	valTypes := func(fields *ast.FieldList) string {
+		if fields == nil {
+			return ""
+		}
+		var out []string
+		for _, field := range fields.List {
+			typ := "i32"
+			if id, ok := field.Type.(*ast.Ident); ok && (id.Name == "uint64" || id.Name == "int64") {
+				typ = "i64"
+			}
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				out = append(out, typ)
+			}
+		}
+		return strings.Join(out, ", ")
+	}
+	s := "(" + valTypes(ft.Params) + ")"
+	if results := valTypes(ft.Results); results != "" {
+		s += " -> " + results
+	}
+	return s
+}
+
+func TestCheckExports(t *testing.T) {
+	m, err := parseWasm(optModule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkExports(m); err != nil {
+		t.Errorf("checkExports failed. Expected user_entrypoint and memory found, got %v", err)
+	}
+
+	// A contract that never calls stygos.SetEntrypoint only exports its own
+	// entrypoint, which Stylus does not call
+	m = &module{Exports: []wasmExport{{Name: "entrypoint"}, {Name: "memory", Kind: 2}, {Name: "user_entrypoint", Kind: 3}}}
+	if err := checkExports(m); err == nil || !strings.Contains(err.Error(), "user_entrypoint: no function export") {
+		t.Errorf("checkExports failed. Expected user_entrypoint reported missing, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Command stygos-cli builds and inspects stygos contracts.
+//
+// Usage:
+//
+//	stygos-cli <command> [flags] [args]
+//
+// Commands:
+//
+//	size     build a contract and report its compressed size against the
+//	         Stylus limit, attributed to Go packages and functions
+//	deploy   build, deploy and activate a contract, optionally running its
+//	         initializer in the same transaction
+//	optimize strip a contract of what Stylus does not need, run wasm-opt
+//	         with Stylus-safe features and check its imports
+//	verify   rebuild a contract with the pinned toolchain and check it
+//	         against the code deployed on chain
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "size":
+		err = runSize(args)
+	case "deploy":
+		err = runDeploy(args)
+	case "optimize":
+		err = runOptimize(args)
+	case "verify":
+		err = runVerify(args)
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "stygos-cli: unknown command %q\n", cmd)
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stygos-cli: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: stygos-cli <command> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  size     report the compressed contract size and what it is made of")
+	fmt.Fprintln(os.Stderr, "  deploy   deploy and activate a contract, with -init to initialize it atomically")
+	fmt.Fprintln(os.Stderr, "  optimize strip and optimize a contract, then check it only imports hostios")
+	fmt.Fprintln(os.Stderr, "  verify   rebuild a contract and check it matches the deployed code")
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stylusOptFlags run wasm-opt for size with only the WebAssembly features
+// Stylus accepts, so the optimizer cannot introduce instructions that fail
+// activation.
+var stylusOptFlags = []string{
+	"-Oz",
+	"--mvp-features",
+	"--enable-bulk-memory",
+	"--enable-sign-ext",
+	"--enable-mutable-globals",
+	"--enable-nontrapping-float-to-int",
+}
+
+// defaultExports are the exports Stylus needs: the entrypoint, under the
+// name of the Stylus ABI or of the examples, and the memory.
+const defaultExports = "user_entrypoint,entrypoint,memory"
+
+// runOptimize implements `stygos-cli optimize [package]`. It builds the
+// package as size does, or reads -wasm, and shrinks the module for
+// deployment: custom sections such as the name section are dropped,
+// exports other than -keep are removed so wasm-opt can drop the code only
+// they reach, and the zero bytes of data segments, which memory already
+// holds, are trimmed. wasm-opt then runs with Stylus-safe features, and the
+// result must import nothing but the hostios of the target ArbOS version
+// and export user_entrypoint and memory.
+func runOptimize(args []string) error {
+	fs := flag.NewFlagSet("optimize", flag.ContinueOnError)
+	wasmFile := fs.String("wasm", "", "optimize this wasm file instead of building")
+	output := fs.String("o", "contract.wasm", "write the optimized wasm here")
+	keep := fs.String("keep", defaultExports, "comma-separated exports to keep")
+	noOpt := fs.Bool("noopt", false, "skip wasm-opt")
+	imports := addImportFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	var built []byte
+	var err error
+	if *wasmFile != "" {
+		built, err = os.ReadFile(*wasmFile)
+	} else {
+		built, err = buildWasm(pkg)
+	}
+	if err != nil {
+		return err
+	}
+	m, err := parseWasm(built)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[string]bool)
+	for _, name := range strings.Split(*keep, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			kept[name] = true
+		}
+	}
+	out, removed, err := m.shrink(kept)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("built       %7d bytes\n", len(built))
+	fmt.Printf("shrunk      %7d bytes", len(out))
+	if len(removed) > 0 {
+		fmt.Printf("  removed exports: %s", strings.Join(removed, ", "))
+	}
+	fmt.Println()
+
+	if !*noOpt {
+		if out, err = wasmOpt(out, stylusOptFlags...); err != nil {
+			return err
+		}
+		fmt.Printf("wasm-opt    %7d bytes\n", len(out))
+	}
+
+	final, err := parseWasm(out)
+	if err != nil {
+		return err
+	}
+	if err := imports.check(final); err != nil {
+		return fmt.Errorf("optimize: %v", err)
+	}
+	if err := checkExports(final); err != nil {
+		return fmt.Errorf("optimize: %v", err)
+	}
+	return os.WriteFile(*output, out, 0o644)
+}
+
+// shrink returns the module without custom sections, without the exports
+// not in keep, which it lists, and with the zero bytes of its active data
+// segments trimmed.
+func (m *module) shrink(keep map[string]bool) ([]byte, []string, error) {
+	// Segments are numbered by memory.init and data.drop when the module
+	// counts them, so none can be dropped then
+	canDrop := true
+	for _, s := range m.Sections {
+		if s.ID == sectionDataCount {
+			canDrop = false
+		}
+	}
+
+	var removed []string
+	out := append([]byte(nil), wasmMagic...)
+	for _, s := range m.Sections {
+		payload := s.Payload
+		var err error
+		switch s.ID {
+		case sectionCustom:
+			continue
+		case sectionExport:
+			var dropped []string
+			payload, dropped, err = filterExports(payload, keep)
+			removed = append(removed, dropped...)
+		case sectionData:
+			payload, err = trimData(payload, canDrop)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, s.ID)
+		out = appendUleb(out, uint64(len(payload)))
+		out = append(out, payload...)
+	}
+	return out, removed, nil
+}
+
+// filterExports rewrites an export section with only the exports in keep,
+// returning the names of the others.
+func filterExports(payload []byte, keep map[string]bool) ([]byte, []string, error) {
+	r := &reader{b: payload}
+	var kept [][]byte
+	var removed []string
+	for count := r.uint(); count > 0 && r.err == nil; count-- {
+		start := r.off
+		name := r.name()
+		r.byte() // kind
+		r.uint() // index
+		if keep[name] {
+			kept = append(kept, payload[start:r.off])
+		} else {
+			removed = append(removed, name)
+		}
+	}
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	out := appendUleb(nil, uint64(len(kept)))
+	for _, e := range kept {
+		out = append(out, e...)
+	}
+	return out, removed, nil
+}
+
+// trimData rewrites a data section with the leading and trailing zero
+// bytes of active segments at constant offsets trimmed, dropping segments
+// left empty if canDrop. Other segments are kept as they are.
+func trimData(payload []byte, canDrop bool) ([]byte, error) {
+	r := &reader{b: payload}
+	var segments [][]byte
+	for count := r.uint(); count > 0 && r.err == nil; count-- {
+		start := r.off
+		switch flags := r.uint(); flags {
+		case 0: // active in memory 0 at an offset expression
+			exprStart := r.off
+			skipExpr(r)
+			expr := payload[exprStart:r.off]
+			init := r.bytes(r.uint())
+			if r.err != nil {
+				return nil, r.err
+			}
+			e := &reader{b: expr}
+			op, offset := e.byte(), e.sint()
+			if op != 0x41 || e.byte() != 0x0b || e.err != nil || e.off != len(expr) { // not i32.const N
+				segments = append(segments, payload[start:r.off])
+				continue
+			}
+			lead := 0
+			for lead < len(init) && init[lead] == 0 {
+				lead++
+			}
+			trail := len(init)
+			for trail > lead && init[trail-1] == 0 {
+				trail--
+			}
+			if lead == trail && canDrop {
+				continue
+			}
+			if lead == trail {
+				lead, trail = 0, 0
+			}
+			seg := []byte{0, 0x41}
+			seg = appendSleb(seg, int64(offset)+int64(lead))
+			seg = append(seg, 0x0b)
+			seg = appendUleb(seg, uint64(trail-lead))
+			segments = append(segments, append(seg, init[lead:trail]...))
+		case 1: // passive
+			r.bytes(r.uint())
+			segments = append(segments, payload[start:r.off])
+		case 2: // active in an explicit memory
+			r.uint()
+			skipExpr(r)
+			r.bytes(r.uint())
+			segments = append(segments, payload[start:r.off])
+		default:
+			return nil, errMalformed
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	out := appendUleb(nil, uint64(len(segments)))
+	for _, s := range segments {
+		out = append(out, s...)
+	}
+	return out, nil
+}
+
+// skipExpr skips a constant expression up to its end opcode.
+func skipExpr(r *reader) {
+	for r.err == nil {
+		switch op := r.byte(); op {
+		case 0x0b: // end
+			return
+		case 0x41: // i32.const
+			r.sint()
+		case 0x42: // i64.const
+			for r.err == nil && r.byte()&0x80 != 0 {
+			}
+		case 0x23: // global.get
+			r.uint()
+		default:
+			r.err = errMalformed
+		}
+	}
+}
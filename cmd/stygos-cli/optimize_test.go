@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// optModule builds a module with exports, data segments and a custom
+// section, importing the given functions with type (i32).
+func optModule(imports ...wasmImport) []byte {
+	sec := func(id byte, payload ...byte) []byte {
+		return append(append([]byte{id}, appendUleb(nil, uint64(len(payload)))...), payload...)
+	}
+	name := func(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+
+	imps := []byte{byte(len(imports))}
+	for _, imp := range imports {
+		imps = append(imps, name(imp.Module)...)
+		imps = append(imps, name(imp.Name)...)
+		imps = append(imps, 0, 1)
+	}
+
+	var exports []byte
+	exports = append(exports, 3)
+	exports = append(exports, name("user_entrypoint")...)
+	exports = append(exports, 0, byte(len(imports)))
+	exports = append(exports, name("malloc")...)
+	exports = append(exports, 0, byte(len(imports)))
+	exports = append(exports, name("memory")...)
+	exports = append(exports, 2, 0)
+
+	// A segment with zeros around its bytes, an all-zero one and a passive one
+	var data []byte
+	data = append(data, 3)
+	data = append(data, 0, 0x41, 0x80, 0x08, 0x0b, 6, 0, 0, 'h', 'i', 0, 0) // at 1024
+	data = append(data, 0, 0x41, 0x10, 0x0b, 4, 0, 0, 0, 0)
+	data = append(data, 1, 2, 'p', 'q')
+
+	m := append([]byte(nil), wasmMagic...)
+	m = append(m, sec(1, 2, 0x60, 0, 0, 0x60, 1, 0x7f, 0)...)
+	m = append(m, sec(sectionImport, imps...)...)
+	m = append(m, sec(3, 1, 0)...)
+	m = append(m, sec(5, 1, 0, 1)...) // memory of one page
+	m = append(m, sec(sectionExport, exports...)...)
+	m = append(m, sec(sectionCode, 1, 2, 0, 0x0b)...)
+	m = append(m, sec(sectionData, data...)...)
+	m = append(m, sec(sectionCustom, append(name("producers"), 0)...)...)
+	return m
+}
+
+func TestShrink(t *testing.T) {
+	m, err := parseWasm(optModule(wasmImport{Module: hostModule, Name: "msg_sender"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, removed, err := m.shrink(map[string]bool{"user_entrypoint": true, "memory": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(removed, ",") != "malloc" {
+		t.Errorf("shrink failed. Expected malloc removed, got %v", removed)
+	}
+	shrunk, err := parseWasm(out)
+	if err != nil {
+		t.Fatalf("shrink failed. Expected a valid module, got %v", err)
+	}
+	for _, s := range shrunk.Sections {
+		switch s.ID {
+		case sectionCustom:
+			t.Errorf("shrink failed. Expected no custom sections, got %q", s.Name)
+		case sectionExport:
+			if bytes.Contains(s.Payload, []byte("malloc")) || !bytes.Contains(s.Payload, []byte("user_entrypoint")) {
+				t.Errorf("shrink failed. Expected only the kept exports, got %q", s.Payload)
+			}
+		case sectionData:
+			// The first segment moves to 1026 and keeps "hi", the zero one goes
+			want := []byte{2, 0, 0x41, 0x82, 0x08, 0x0b, 2, 'h', 'i', 1, 2, 'p', 'q'}
+			if !bytes.Equal(s.Payload, want) {
+				t.Errorf("shrink failed. Expected data %x, got %x", want, s.Payload)
+			}
+		}
+	}
+	if err := (&importPolicy{ArbOS: defaultArbOS}).check(shrunk); err != nil {
+		t.Errorf("check failed. Expected hostios accepted, got %v", err)
+	}
+}
+
+func TestAppendSleb(t *testing.T) {
+	for _, v := range []int64{0, 1, 63, 64, -1, -64, -65, 1024, 1 << 30, -(1 << 31)} {
+		r := &reader{b: appendSleb(nil, v)}
+		if got := r.sint(); int64(got) != v || r.err != nil || r.off != len(r.b) {
+			t.Errorf("sleb %d failed. Got %d, %v", v, got, r.err)
+		}
+	}
+}
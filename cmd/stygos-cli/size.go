@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// stylusLimit is the largest brotli-compressed wasm Stylus deploys, 24KB.
+const stylusLimit = 24 * 1024
+
+// runSize implements `stygos-cli size [package]`. It builds the package
+// with TinyGo as the Makefile does, strips the custom sections, runs
+// wasm-opt when installed and compresses with brotli, then reports the
+// compressed size against the limit and attributes the code to Go packages
+// and functions through the wasm name section.
+func runSize(args []string) error {
+	fs := flag.NewFlagSet("size", flag.ContinueOnError)
+	wasmFile := fs.String("wasm", "", "report on this wasm file instead of building")
+	output := fs.String("o", "", "also write the built wasm here")
+	limit := fs.Int("limit", stylusLimit, "compressed size limit in bytes")
+	top := fs.Int("top", 15, "number of largest functions to list")
+	noOpt := fs.Bool("noopt", false, "skip wasm-opt even when installed")
+	imports := addImportFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pkg := "."
+	if fs.NArg() > 0 {
+		pkg = fs.Arg(0)
+	}
+
+	var built []byte
+	var err error
+	if *wasmFile != "" {
+		built, err = os.ReadFile(*wasmFile)
+	} else {
+		built, err = buildWasm(pkg)
+	}
+	if err != nil {
+		return err
+	}
+	if *output != "" {
+		if err := os.WriteFile(*output, built, 0o644); err != nil {
+			return err
+		}
+	}
+
+	m, deploy, err := prepare(built, *noOpt, imports)
+	if err != nil {
+		return err
+	}
+	compressed, err := brotli(deploy)
+	if err != nil {
+		return err
+	}
+
+	report(os.Stdout, m, len(deploy), len(compressed), *limit, *top)
+	if len(compressed) > *limit {
+		return fmt.Errorf("size: %d bytes compressed, over the %d byte limit", len(compressed), *limit)
+	}
+	return nil
+}
+
+// prepare parses a built module and returns it with the code to deploy:
+// the module stripped of custom sections and run through wasm-opt when
+// installed, unless noOpt is set. The code must import only what imports
+// allows and export the entrypoint, so a program the target chain cannot
+// activate or call fails the build.
+func prepare(built []byte, noOpt bool, imports *importPolicy) (*module, []byte, error) {
+	m, err := parseWasm(built)
+	if err != nil {
+		return nil, nil, err
+	}
+	deploy := m.strip()
+	if _, err := exec.LookPath("wasm-opt"); err == nil && !noOpt {
+		if deploy, err = wasmOpt(deploy); err != nil {
+			return nil, nil, err
+		}
+	}
+	final, err := parseWasm(deploy)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := imports.check(final); err != nil {
+		return nil, nil, err
+	}
+	if err := checkExports(final); err != nil {
+		return nil, nil, err
+	}
+	return m, deploy, nil
+}
+
+// buildFlags are the flags of the Makefile's build target.
+var buildFlags = []string{"-target=wasi", "-opt=z", "-panic=trap"}
+
+// buildWasm compiles pkg with buildFlags. The name section is kept for
+// attribution; strip drops it later.
+func buildWasm(pkg string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "stygos-size")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "contract.wasm")
+	args := append(append([]string{"build"}, buildFlags...), "-o", out, pkg)
+	if err := run(nil, nil, "tinygo", args...); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(out)
+}
+
+// wasmOpt runs wasm-opt over a module, with -Oz unless flags are given.
+func wasmOpt(wasm []byte, flags ...string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "stygos-size")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in, out := filepath.Join(dir, "in.wasm"), filepath.Join(dir, "out.wasm")
+	if err := os.WriteFile(in, wasm, 0o644); err != nil {
+		return nil, err
+	}
+	if len(flags) == 0 {
+		flags = []string{"-Oz"}
+	}
+	args := append(append([]string(nil), flags...), in, "-o", out)
+	if err := run(nil, nil, "wasm-opt", args...); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(out)
+}
+
+// brotli compresses data at the highest quality, as cargo stylus
+// compresses for deployment.
+func brotli(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	if err := run(data, &out, "brotli", "-c", "-q", "11"); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// run runs a tool with the given stdin and stdout, either of which may be
+// nil.
+func run(stdin []byte, stdout io.Writer, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if _, lookErr := exec.LookPath(name); lookErr != nil {
+			return fmt.Errorf("%s not found in PATH", name)
+		}
+		return fmt.Errorf("%s: %v\n%s", name, err, stderr.Bytes())
+	}
+	return nil
+}
+
+// report prints the sizes and the attribution of the code section.
+func report(w io.Writer, m *module, deployed, compressed, limit, top int) {
+	fmt.Fprintf(w, "compressed  %7d bytes  %5.1f%% of the %d byte limit\n", compressed, percent(compressed, limit), limit)
+	fmt.Fprintf(w, "deployed    %7d bytes uncompressed\n", deployed)
+
+	var code, data, other int
+	for _, s := range m.Sections {
+		switch s.ID {
+		case sectionCode:
+			code += s.Size
+		case sectionData:
+			data += s.Size
+		case sectionCustom:
+		default:
+			other += s.Size
+		}
+	}
+	fmt.Fprintf(w, "  code %d, data %d, other %d, before wasm-opt\n", code, data, other)
+
+	byPackage := map[string]int{}
+	for _, f := range m.Functions {
+		byPackage[packageOf(f.Name)] += f.Size
+	}
+	pkgs := make([]string, 0, len(byPackage))
+	for p := range byPackage {
+		pkgs = append(pkgs, p)
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		if byPackage[pkgs[i]] != byPackage[pkgs[j]] {
+			return byPackage[pkgs[i]] > byPackage[pkgs[j]]
+		}
+		return pkgs[i] < pkgs[j]
+	})
+
+	fmt.Fprintln(w, "\ncode by package:")
+	for _, p := range pkgs {
+		fmt.Fprintf(w, "%9d %5.1f%%  %s\n", byPackage[p], percent(byPackage[p], code), p)
+	}
+
+	funcs := append([]function(nil), m.Functions...)
+	sort.SliceStable(funcs, func(i, j int) bool { return funcs[i].Size > funcs[j].Size })
+	if top < len(funcs) {
+		funcs = funcs[:top]
+	}
+	fmt.Fprintln(w, "\nlargest functions:")
+	for _, f := range funcs {
+		fmt.Fprintf(w, "%9d %5.1f%%  %s\n", f.Size, percent(f.Size, code), f.Name)
+	}
+}
+
+// packageOf returns the Go package of a TinyGo symbol such as
+// "github.com/a/b.F", "(*github.com/a/b.T).M" or "runtime.alloc", or
+// "(other)" for symbols from C and the linker.
+func packageOf(symbol string) string {
+	s := strings.TrimLeft(symbol, "(*")
+	if i := strings.IndexAny(s, "[{"); i >= 0 {
+		s = s[:i]
+	}
+	slash := strings.LastIndexByte(s, '/')
+	dot := strings.IndexByte(s[slash+1:], '.')
+	if dot <= 0 {
+		return "(other)"
+	}
+	return s[:slash+1+dot]
+}
+
+func percent(n, of int) float64 {
+	if of == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(of)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// testModule builds a module importing one function and defining two,
+// with a name section naming the defined ones.
+func testModule() []byte {
+	sec := func(id byte, payload ...byte) []byte {
+		return append([]byte{id, byte(len(payload))}, payload...)
+	}
+	name := func(s string) []byte { return append([]byte{byte(len(s))}, s...) }
+
+	var imports []byte
+	imports = append(imports, 1)
+	imports = append(imports, name("vm_hooks")...)
+	imports = append(imports, name("msg_sender")...)
+	imports = append(imports, 0, 0)
+
+	// Bodies of 3 and 6 bytes: no locals, then instructions and end
+	code := []byte{2, 3, 0, 0x01, 0x0b, 6, 0, 0x01, 0x01, 0x01, 0x01, 0x0b}
+
+	var names []byte
+	names = append(names, 2)
+	names = append(names, 1)
+	names = append(names, name("runtime.alloc")...)
+	names = append(names, 2)
+	names = append(names, name("(*github.com/rafaelescrich/stygos.Router).Dispatch")...)
+	custom := append(name("name"), append([]byte{1, byte(len(names))}, names...)...)
+
+	m := append([]byte(nil), wasmMagic...)
+	m = append(m, sec(1, 1, 0x60, 0, 0)...) // type: func() -> ()
+	m = append(m, sec(sectionImport, imports...)...)
+	m = append(m, sec(3, 2, 0, 0)...) // function: two of type 0
+	m = append(m, sec(sectionCode, code...)...)
+	m = append(m, sec(sectionData, 0)...)
+	m = append(m, sec(sectionCustom, custom...)...)
+	return m
+}
+
+func TestParseWasm(t *testing.T) {
+	m, err := parseWasm(testModule())
+	if err != nil {
+		t.Fatalf("parseWasm failed: %v", err)
+	}
+	want := []function{
+		{Index: 1, Name: "runtime.alloc", Size: 3},
+		{Index: 2, Name: "(*github.com/rafaelescrich/stygos.Router).Dispatch", Size: 6},
+	}
+	if len(m.Functions) != 2 || m.Functions[0] != want[0] || m.Functions[1] != want[1] {
+		t.Errorf("parseWasm failed. Expected %+v, got %+v", want, m.Functions)
+	}
+
+	stripped := m.strip()
+	if bytes.Contains(stripped, []byte("runtime.alloc")) {
+		t.Error("strip failed. Expected the name section removed")
+	}
+	s, err := parseWasm(stripped)
+	if err != nil || len(s.Sections) != len(m.Sections)-1 || s.Functions[1].Name != "func[2]" {
+		t.Errorf("strip failed. Expected a valid module without names, got %v", err)
+	}
+
+	if _, err := parseWasm(testModule()[:20]); err == nil {
+		t.Error("parseWasm succeeded on a truncated module, want error")
+	}
+	if _, err := parseWasm([]byte("\x7fELF")); err == nil {
+		t.Error("parseWasm succeeded on a non-wasm file, want error")
+	}
+}
+
+func TestPackageOf(t *testing.T) {
+	tests := map[string]string{
+		"runtime.alloc": "runtime",
+		"github.com/rafaelescrich/stygos.Keccak256":                                               "github.com/rafaelescrich/stygos",
+		"(*github.com/rafaelescrich/stygos.Router).Dispatch":                                      "github.com/rafaelescrich/stygos",
+		"github.com/rafaelescrich/stygos/storage.NewSet[github.com/rafaelescrich/stygos.Address]": "github.com/rafaelescrich/stygos/storage",
+		"main.entrypoint$1": "main",
+		"malloc":            "(other)",
+		"func[12]":          "(other)",
+	}
+	for symbol, want := range tests {
+		if got := packageOf(symbol); got != want {
+			t.Errorf("packageOf(%q) = %q, want %q", symbol, got, want)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	m, err := parseWasm(testModule())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	report(&out, m, 40, 12288, stylusLimit, 1)
+	for _, want := range []string{
+		"compressed    12288 bytes   50.0% of the 24576 byte limit",
+		"        6  ",
+		"github.com/rafaelescrich/stygos\n",
+		"largest functions:\n        6",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report lacks %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Count(out.String(), "runtime.alloc") != 0 {
+		t.Errorf("report lists more than the top function:\n%s", out.String())
+	}
+}
+
+func TestBrotli(t *testing.T) {
+	if _, err := exec.LookPath("brotli"); err != nil {
+		t.Skip("brotli not installed")
+	}
+	out, err := brotli(bytes.Repeat([]byte("stygos"), 1000))
+	if err != nil || len(out) == 0 || len(out) > 100 {
+		t.Errorf("brotli failed. Expected a small output, got %d bytes, %v", len(out), err)
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/script"
+)
+
+// The toolchain of reproducible builds, as the Dockerfile installs it. A
+// different TinyGo or wasm-opt generally produces different code.
+const (
+	pinnedTinyGo  = "0.30.0"
+	pinnedWasmOpt = "116"
+)
+
+// attestation is what verify prints: the contract checked, the source and
+// toolchain it was rebuilt from, and whether the two match.
+type attestation struct {
+	Address    string   `json:"address"`
+	ChainID    uint64   `json:"chainId"`
+	Package    string   `json:"package"`
+	SourceHash string   `json:"sourceHash"` // keccak256 over the package's Go files
+	TinyGo     string   `json:"tinygo"`
+	WasmOpt    string   `json:"wasmOpt,omitempty"` // empty with -noopt
+	BuildFlags []string `json:"buildFlags"`
+	CodeHash   string   `json:"codeHash"`  // keccak256 of the deployed code
+	WasmHash   string   `json:"wasmHash"`  // keccak256 of the deployed wasm
+	BuiltHash  string   `json:"builtHash"` // keccak256 of the rebuilt wasm
+	Verified   bool     `json:"verified"`
+}
+
+// runVerify implements `stygos-cli verify address [package]`. It fetches
+// the program deployed at address, rebuilds the package with the pinned
+// TinyGo and wasm-opt as size does, and compares the rebuilt wasm with the
+// deployed one after decompressing it, so the brotli version does not
+// matter. It prints the attestation as JSON and fails if the code differs.
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	rpc := fs.String("rpc", "http://localhost:8547", "JSON-RPC endpoint")
+	tinygo := fs.String("tinygo", pinnedTinyGo, "required TinyGo version, empty for any")
+	wasmOptVersion := fs.String("wasm-opt", pinnedWasmOpt, "required wasm-opt version, empty for any")
+	noOpt := fs.Bool("noopt", false, "the deployed code was not run through wasm-opt")
+	output := fs.String("o", "", "also write the attestation here")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return fmt.Errorf("verify: usage: stygos-cli verify [flags] address [package]")
+	}
+	addr, err := stygos.AddressFromHex(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("verify: %v", err)
+	}
+	pkg := "."
+	if fs.NArg() > 1 {
+		pkg = fs.Arg(1)
+	}
+
+	// Fetch first: a wrong address or endpoint fails before the build
+	backend, err := script.Dial(*rpc, nil)
+	if err != nil {
+		return err
+	}
+	code, err := backend.Code(addr)
+	if err != nil {
+		return err
+	}
+	deployed, err := deployedWasm(code)
+	if err != nil {
+		return fmt.Errorf("verify: %s: %v", addr.Hex(), err)
+	}
+
+	att := attestation{
+		Address:    addr.Hex(),
+		Package:    pkg,
+		BuildFlags: buildFlags,
+		CodeHash:   stygos.Keccak256(code).Hex(),
+		WasmHash:   stygos.Keccak256(deployed).Hex(),
+	}
+	att.ChainID, _ = backend.ChainID()
+	if att.TinyGo, err = checkVersion("tinygo", *tinygo, "version"); err != nil {
+		return err
+	}
+	if !*noOpt {
+		if att.WasmOpt, err = checkVersion("wasm-opt", *wasmOptVersion, "--version"); err != nil {
+			return err
+		}
+	}
+	if att.SourceHash, err = sourceHash(pkg); err != nil {
+		return err
+	}
+
+	built, err := buildWasm(pkg)
+	if err != nil {
+		return err
+	}
+	m, err := parseWasm(built)
+	if err != nil {
+		return err
+	}
+	rebuilt := m.strip()
+	if !*noOpt {
+		if rebuilt, err = wasmOpt(rebuilt); err != nil {
+			return err
+		}
+	}
+	att.BuiltHash = stygos.Keccak256(rebuilt).Hex()
+	att.Verified = bytes.Equal(rebuilt, deployed)
+
+	out, err := json.MarshalIndent(att, "", "  ")
+	if err != nil {
+		return err
+	}
+	out = append(out, '\n')
+	os.Stdout.Write(out)
+	if *output != "" {
+		if err := os.WriteFile(*output, out, 0o644); err != nil {
+			return err
+		}
+	}
+	if !att.Verified {
+		return fmt.Errorf("verify: the code at %s does not match %s", addr.Hex(), pkg)
+	}
+	return nil
+}
+
+// deployedWasm returns the wasm of deployed Stylus code, decompressed.
+func deployedWasm(code []byte) ([]byte, error) {
+	if len(code) == 0 {
+		return nil, fmt.Errorf("no code deployed")
+	}
+	program, ok := script.DeployedProgram(code)
+	if !ok {
+		if len(code) >= 4 && bytes.HasPrefix(code, []byte{0xef, 0xf0, 0x00}) {
+			return nil, fmt.Errorf("compressed with brotli dictionary %d, which verify does not support", code[3])
+		}
+		return nil, fmt.Errorf("not a Stylus program")
+	}
+	var wasm bytes.Buffer
+	if err := run(program, &wasm, "brotli", "-d", "-c"); err != nil {
+		return nil, err
+	}
+	return wasm.Bytes(), nil
+}
+
+// checkVersion returns the version of the installed tool, which must be
+// want unless want is empty.
+func checkVersion(tool, want, arg string) (string, error) {
+	var out bytes.Buffer
+	if err := run(nil, &out, tool, arg); err != nil {
+		return "", err
+	}
+	got := toolVersion(out.String())
+	if got == "" {
+		return "", fmt.Errorf("verify: cannot read the %s version from %q", tool, strings.TrimSpace(out.String()))
+	}
+	if want != "" && got != want {
+		return "", fmt.Errorf("verify: %s %s installed, the build is pinned to %s (-%s to change it)", tool, got, want, tool)
+	}
+	return got, nil
+}
+
+// toolVersion returns the word after "version" in the output of
+// `tinygo version` or `wasm-opt --version`.
+func toolVersion(out string) string {
+	fields := strings.Fields(out)
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] == "version" {
+			return strings.TrimPrefix(fields[i+1], "v")
+		}
+	}
+	return ""
+}
+
+// sourceHash returns the keccak256 of the non-test Go files of the package
+// directory, each as its name, a zero byte, its length and its contents,
+// in name order.
+func sourceHash(dir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(paths)
+	var buf bytes.Buffer
+	n := 0
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&buf, "%s\x00%d\x00", filepath.Base(path), len(src))
+		buf.Write(src)
+		n++
+	}
+	if n == 0 {
+		return "", fmt.Errorf("verify: no Go files in %s", dir)
+	}
+	return stygos.Keccak256(buf.Bytes()).Hex(), nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestToolVersion(t *testing.T) {
+	tests := []struct {
+		out, want string
+	}{
+		{"tinygo version 0.30.0 linux/amd64 (using go version go1.21.0 and LLVM version 16.0.1)\n", "0.30.0"},
+		{"wasm-opt version 116 (version_116)\n", "116"},
+		{"no version here", "here"},
+		{"unknown", ""},
+	}
+	for _, tt := range tests {
+		if got := toolVersion(tt.out); got != tt.want {
+			t.Errorf("toolVersion(%q) failed. Expected %q, got %q", tt.out, tt.want, got)
+		}
+	}
+}
+
+func TestSourceHash(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.go", "package main\n")
+	h1, err := sourceHash(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Tests are not part of the build
+	write("main_test.go", "package main\n")
+	if h2, _ := sourceHash(dir); h2 != h1 {
+		t.Errorf("sourceHash failed. Expected tests ignored, got %s and %s", h1, h2)
+	}
+	write("main.go", "package main // changed\n")
+	if h3, _ := sourceHash(dir); h3 == h1 {
+		t.Errorf("sourceHash failed. Expected a change to the sources to change the hash")
+	}
+	if _, err := sourceHash(t.TempDir()); err == nil {
+		t.Errorf("sourceHash failed. Expected an error without Go files")
+	}
+}
+
+func TestVerifyCode(t *testing.T) {
+	code := "0x6080"
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var call struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		json.NewDecoder(req.Body).Decode(&call)
+		result := "0x66eee"
+		if call.Method == "eth_getCode" {
+			result = code
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "result": result})
+	}))
+	defer node.Close()
+
+	tests := []struct {
+		code string
+		args []string
+		want string
+	}{
+		{"0x6080", nil, "usage"},
+		{"0x6080", []string{"0x12"}, "verify"},
+		{"0x", []string{"-rpc", node.URL, "0x0000000000000000000000000000000000000011"}, "no code deployed"},
+		{"0x6080", []string{"-rpc", node.URL, "0x0000000000000000000000000000000000000011"}, "not a Stylus program"},
+		{"0xeff00001aa", []string{"-rpc", node.URL, "0x0000000000000000000000000000000000000011"}, "dictionary 1"},
+	}
+	for _, tt := range tests {
+		code = tt.code
+		err := runVerify(tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("runVerify(%v) on code %s failed. Expected an error about %s, got %v", tt.args, tt.code, tt.want, err)
+		}
+	}
+}
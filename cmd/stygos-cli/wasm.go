@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Wasm section ids used by the size report and optimize.
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionExport    = 7
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+var errMalformed = errors.New("malformed wasm module")
+
+// section is a section of a wasm module.
+type section struct {
+	ID      byte
+	Name    string // for custom sections
+	Payload []byte
+	Size    int // including the id and size header
+}
+
+// function is a defined function of a wasm module.
+type function struct {
+	Index int
+	Name  string
+	Size  int // body size in bytes
+}
+
+// wasmImport is an entry of the import section.
+type wasmImport struct {
+	Module string
+	Name   string
+	Kind   byte   // 0 for functions
+	Type   string // of functions, as funcType formats it
+}
+
+// wasmExport is an entry of the export section.
+type wasmExport struct {
+	Name string
+	Kind byte // 0 for functions, 2 for memories
+}
+
+// module is the part of a parsed wasm binary the size report uses.
+type module struct {
+	Sections  []section
+	Functions []function
+	Imports   []wasmImport
+	Exports   []wasmExport
+}
+
+// parseWasm splits a wasm binary into its sections and measures each
+// defined function, named from the name section when present.
+func parseWasm(b []byte) (*module, error) {
+	if !bytes.HasPrefix(b, wasmMagic) {
+		return nil, fmt.Errorf("not a wasm module")
+	}
+	m := new(module)
+	imported := 0
+	names := map[int]string{}
+	var types []string
+
+	r := &reader{b: b, off: len(wasmMagic)}
+	for r.off < len(b) {
+		start := r.off
+		id := r.byte()
+		n := r.uint()
+		payload := r.bytes(n)
+		if r.err != nil {
+			return nil, r.err
+		}
+		s := section{ID: id, Payload: payload, Size: r.off - start}
+
+		p := &reader{b: payload}
+		switch id {
+		case sectionCustom:
+			s.Name = p.name()
+			if s.Name == "name" {
+				parseNames(p, names)
+			}
+		case sectionType:
+			for count := p.uint(); count > 0 && p.err == nil; count-- {
+				types = append(types, funcType(p))
+			}
+		case sectionImport:
+			for count := p.uint(); count > 0 && p.err == nil; count-- {
+				imp := wasmImport{Module: p.name(), Name: p.name()}
+				imp.Kind = p.byte()
+				switch imp.Kind {
+				case 0: // function
+					if idx := p.uint(); idx < len(types) {
+						imp.Type = types[idx]
+					} else {
+						p.err = errMalformed
+					}
+					imported++
+				case 1: // table: reftype, limits
+					p.byte()
+					p.limits()
+				case 2: // memory
+					p.limits()
+				case 3: // global: valtype, mutability
+					p.byte()
+					p.byte()
+				default:
+					p.err = errMalformed
+				}
+				m.Imports = append(m.Imports, imp)
+			}
+		case sectionExport:
+			for count := p.uint(); count > 0 && p.err == nil; count-- {
+				m.Exports = append(m.Exports, wasmExport{Name: p.name(), Kind: p.byte()})
+				p.uint() // index
+			}
+		case sectionCode:
+			for i, count := 0, p.uint(); i < count && p.err == nil; i++ {
+				size := p.uint()
+				p.bytes(size)
+				m.Functions = append(m.Functions, function{Index: imported + i, Size: size})
+			}
+		}
+		if p.err != nil {
+			return nil, p.err
+		}
+		m.Sections = append(m.Sections, s)
+	}
+
+	for i := range m.Functions {
+		if name, ok := names[m.Functions[i].Index]; ok {
+			m.Functions[i].Name = name
+		} else {
+			m.Functions[i].Name = fmt.Sprintf("func[%d]", m.Functions[i].Index)
+		}
+	}
+	return m, nil
+}
+
+// funcType reads a function type and formats it as its parameter and
+// result types, such as "(i32, i64) -> i32".
+func funcType(p *reader) string {
+	if p.byte() != 0x60 {
+		p.err = errMalformed
+		return ""
+	}
+	valTypes := func() []string {
+		var out []string
+		for count := p.uint(); count > 0 && p.err == nil; count-- {
+			switch t := p.byte(); t {
+			case 0x7f:
+				out = append(out, "i32")
+			case 0x7e:
+				out = append(out, "i64")
+			case 0x7d:
+				out = append(out, "f32")
+			case 0x7c:
+				out = append(out, "f64")
+			default:
+				out = append(out, fmt.Sprintf("0x%02x", t))
+			}
+		}
+		return out
+	}
+	s := "(" + strings.Join(valTypes(), ", ") + ")"
+	if results := valTypes(); len(results) > 0 {
+		s += " -> " + strings.Join(results, ", ")
+	}
+	return s
+}
+
+// parseNames reads the function names subsection of a name section.
+func parseNames(p *reader, names map[int]string) {
+	for p.off < len(p.b) && p.err == nil {
+		id := p.byte()
+		sub := &reader{b: p.bytes(p.uint())}
+		if id != 1 {
+			continue
+		}
+		for count := sub.uint(); count > 0 && sub.err == nil; count-- {
+			idx := sub.uint()
+			names[idx] = sub.name()
+		}
+	}
+}
+
+// strip returns the module without its custom sections: names, DWARF and
+// producers, none of which is deployed.
+func (m *module) strip() []byte {
+	out := append([]byte(nil), wasmMagic...)
+	for _, s := range m.Sections {
+		if s.ID == sectionCustom {
+			continue
+		}
+		out = append(out, s.ID)
+		out = appendUleb(out, uint64(len(s.Payload)))
+		out = append(out, s.Payload...)
+	}
+	return out
+}
+
+func appendUleb(out []byte, v uint64) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func appendSleb(out []byte, v int64) []byte {
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+// reader decodes the LEB128 and vector encodings of wasm, recording the
+// first error.
+type reader struct {
+	b   []byte
+	off int
+	err error
+}
+
+func (r *reader) byte() byte {
+	if r.err != nil || r.off >= len(r.b) {
+		r.err = errMalformed
+		return 0
+	}
+	c := r.b[r.off]
+	r.off++
+	return c
+}
+
+func (r *reader) uint() int {
+	var v uint64
+	for shift := uint(0); shift < 35; shift += 7 {
+		c := r.byte()
+		v |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			return int(v)
+		}
+	}
+	r.err = errMalformed
+	return 0
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil || n < 0 || n > len(r.b)-r.off {
+		r.err = errMalformed
+		return nil
+	}
+	b := r.b[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+// sint reads a signed LEB128 of up to 32 bits, as i32.const encodes.
+func (r *reader) sint() int32 {
+	var v int64
+	for shift := uint(0); shift < 35; shift += 7 {
+		c := r.byte()
+		v |= int64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			if shift+7 < 64 && c&0x40 != 0 {
+				v |= -1 << (shift + 7)
+			}
+			return int32(v)
+		}
+	}
+	r.err = errMalformed
+	return 0
+}
+
+func (r *reader) name() string {
+	return string(r.bytes(r.uint()))
+}
+
+func (r *reader) limits() {
+	if r.byte()&1 != 0 {
+		r.uint()
+	}
+	r.uint()
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// runCheck implements `stygos-gen check`, a pre-deployment linter. It
+// takes the methods a contract routes, as dispatch-style signature=handler
+// arguments or bare signatures, and optionally its JSON ABI, and reports
+// selector collisions, ABI types the stygos encoder does not handle, and,
+// given the ABI of the deployed version, changes that break its callers.
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	abiFile := fs.String("abi", "", "JSON ABI of the contract")
+	prevFile := fs.String("prev", "", "JSON ABI of the previous version, to check compatibility against")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *prevFile != "" && *abiFile == "" {
+		return fmt.Errorf("check: -prev needs -abi")
+	}
+
+	var entries, prev []abiEntry
+	if *abiFile != "" {
+		var err error
+		if entries, err = readABI(*abiFile); err != nil {
+			return err
+		}
+	}
+	if *prevFile != "" {
+		var err error
+		if prev, err = readABI(*prevFile); err != nil {
+			return err
+		}
+	}
+
+	problems := checkABI(fs.Args(), entries, prev)
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("check: %d problem(s) found", len(problems))
+	}
+	return nil
+}
+
+func readABI(path string) ([]abiEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []abiEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("check: parsing %s: %v", path, err)
+	}
+	return entries, nil
+}
+
+// checkABI returns the problems found in the routed signatures and the ABI
+// entries, and the breaking changes from prev to entries.
+func checkABI(signatures []string, entries, prev []abiEntry) []string {
+	var problems []string
+
+	// Routed methods and ABI functions share one selector space
+	sigs := make([]string, 0, len(signatures)+len(entries))
+	for _, s := range signatures {
+		if i := strings.LastIndex(s, "="); i > 0 {
+			s = s[:i]
+		}
+		sigs = append(sigs, s)
+	}
+	for _, e := range entries {
+		if isFunction(e) {
+			sigs = append(sigs, signatureOf(e))
+		}
+	}
+	problems = append(problems, checkSelectors(sigs)...)
+
+	for _, e := range entries {
+		if !isFunction(e) && e.Type != "event" {
+			continue
+		}
+		kind := "function"
+		if e.Type == "event" {
+			kind = "event"
+		}
+		params := append(append([]abiParam(nil), e.Inputs...), e.Outputs...)
+		for i, p := range params {
+			if _, err := goType(p.Type); err != nil {
+				name := p.Name
+				if name == "" {
+					name = fmt.Sprintf("#%d", i)
+				}
+				problems = append(problems, fmt.Sprintf("%s %s: parameter %s has type %s, which the stygos encoder does not support", kind, signatureOf(e), name, p.Type))
+			}
+		}
+	}
+
+	if prev != nil {
+		problems = append(problems, checkCompat(prev, entries)...)
+	}
+	return problems
+}
+
+// checkSelectors reports signatures listed twice and distinct signatures
+// whose selectors collide.
+func checkSelectors(sigs []string) []string {
+	var problems []string
+	bySelector := map[[4]byte]string{}
+	for _, sig := range sigs {
+		sig = strings.ReplaceAll(sig, " ", "")
+		sel := selectorOf(sig)
+		first, ok := bySelector[sel]
+		switch {
+		case !ok:
+			bySelector[sel] = sig
+		case first == sig:
+			problems = append(problems, fmt.Sprintf("method %s is declared twice", sig))
+		default:
+			problems = append(problems, fmt.Sprintf("selector 0x%x is shared by %s and %s", sel, first, sig))
+		}
+	}
+	return problems
+}
+
+// checkCompat reports what the change from prev to cur breaks for callers
+// of the previous version: removed functions and events, changed return
+// types, functions that stop being view or payable, and events whose
+// indexed arguments move.
+func checkCompat(prev, cur []abiEntry) []string {
+	functions, events := map[string]abiEntry{}, map[string]abiEntry{}
+	for _, e := range cur {
+		switch {
+		case isFunction(e):
+			functions[signatureOf(e)] = e
+		case e.Type == "event":
+			events[signatureOf(e)] = e
+		}
+	}
+
+	var problems []string
+	for _, old := range prev {
+		sig := signatureOf(old)
+		switch {
+		case isFunction(old):
+			e, ok := functions[sig]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("function %s was removed", sig))
+				continue
+			}
+			if a, b := typeList(old.Outputs), typeList(e.Outputs); a != b {
+				problems = append(problems, fmt.Sprintf("function %s returns (%s), was (%s)", sig, b, a))
+			}
+			if isView(old) && !isView(e) {
+				problems = append(problems, fmt.Sprintf("function %s is no longer view", sig))
+			}
+			if old.StateMutability == "payable" && e.StateMutability != "payable" {
+				problems = append(problems, fmt.Sprintf("function %s is no longer payable", sig))
+			}
+		case old.Type == "event":
+			e, ok := events[sig]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("event %s was removed", sig))
+				continue
+			}
+			if indexedList(old) != indexedList(e) || old.Anonymous != e.Anonymous {
+				problems = append(problems, fmt.Sprintf("event %s changed its topics", sig))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func isFunction(e abiEntry) bool {
+	return e.Type == "function" || e.Type == ""
+}
+
+func isView(e abiEntry) bool {
+	return e.StateMutability == "view" || e.StateMutability == "pure" || e.Constant
+}
+
+func typeList(params []abiParam) string {
+	types := make([]string, len(params))
+	for i, p := range params {
+		types[i] = p.Type
+	}
+	return strings.Join(types, ",")
+}
+
+func indexedList(e abiEntry) string {
+	var b strings.Builder
+	for _, p := range e.Inputs {
+		if p.Indexed {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCheckSelectors(t *testing.T) {
+	problems := checkABI([]string{
+		"burn(uint256)=handleBurn",
+		"collate_propagate_storage(bytes16)=handleCollate",
+		"transfer(address,uint256)",
+		"transfer(address, uint256)=handleTransfer",
+	}, nil, nil)
+	if len(problems) != 2 {
+		t.Fatalf("checkABI failed. Expected 2 problems, got %q", problems)
+	}
+	if !strings.Contains(problems[0], "selector 0x42966c68 is shared by burn(uint256) and collate_propagate_storage(bytes16)") {
+		t.Errorf("checkABI failed. Expected the burn collision, got %q", problems[0])
+	}
+	if !strings.Contains(problems[1], "transfer(address,uint256) is declared twice") {
+		t.Errorf("checkABI failed. Expected the duplicate transfer, got %q", problems[1])
+	}
+}
+
+func TestCheckTypes(t *testing.T) {
+	var entries []abiEntry
+	if err := json.Unmarshal([]byte(`[
+		{"type": "function", "name": "swap", "inputs": [{"name": "route", "type": "tuple"}], "outputs": [{"name": "", "type": "fixed128x18"}]},
+		{"type": "event", "name": "Swap", "inputs": [{"name": "amount", "type": "uint256"}]}
+	]`), &entries); err != nil {
+		t.Fatal(err)
+	}
+	problems := checkABI(nil, entries, nil)
+	if len(problems) != 2 || !strings.Contains(problems[0], "parameter route has type tuple") || !strings.Contains(problems[1], "parameter #1 has type fixed128x18") {
+		t.Errorf("checkABI failed. Expected the tuple and fixed parameters, got %q", problems)
+	}
+}
+
+func TestCheckCompat(t *testing.T) {
+	var prev, cur []abiEntry
+	if err := json.Unmarshal([]byte(testABI), &prev); err != nil {
+		t.Fatal(err)
+	}
+	if problems := checkABI(nil, prev, prev); len(problems) != 0 {
+		t.Errorf("checkABI failed. Expected an unchanged ABI to pass, got %q", problems)
+	}
+
+	if err := json.Unmarshal([]byte(`[
+		{"type": "function", "name": "balanceOf", "stateMutability": "nonpayable",
+		 "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint128"}]},
+		{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+		 "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+		{"type": "function", "name": "roots", "stateMutability": "pure",
+		 "inputs": [{"name": "", "type": "uint64"}], "outputs": [{"name": "", "type": "bytes32[2]"}, {"name": "", "type": "string"}]},
+		{"type": "function", "name": "mint", "stateMutability": "nonpayable", "inputs": [], "outputs": []},
+		{"type": "event", "name": "Transfer", "anonymous": false,
+		 "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": false}, {"name": "value", "type": "uint256", "indexed": false}]}
+	]`), &cur); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"event Memo(string,uint8) was removed",
+		"event Transfer(address,address,uint256) changed its topics",
+		"function balanceOf(address) is no longer view",
+		"function balanceOf(address) returns (uint128), was (uint256)",
+		"function transfer(address,uint256,bytes) was removed",
+	}
+	if got := checkCompat(prev, cur); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("checkCompat failed. Expected\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// abiEntry is an element of a contract's JSON ABI.
+type abiEntry struct {
+	Type            string     `json:"type"`
+	Name            string     `json:"name"`
+	Inputs          []abiParam `json:"inputs"`
+	Outputs         []abiParam `json:"outputs"`
+	StateMutability string     `json:"stateMutability"`
+	Constant        bool       `json:"constant"`
+	Anonymous       bool       `json:"anonymous"`
+}
+
+// abiParam is a function or event parameter of a JSON ABI.
+type abiParam struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed"`
+}
+
+// runClient implements `stygos-gen client`, which reads a contract's JSON
+// ABI and emits a Go client for backends built on go-ethereum: read-only
+// functions become calls, the others transactions, and each event gets a
+// struct, a filter and a parser.
+func runClient(args []string) error {
+	fs := flag.NewFlagSet("client", flag.ContinueOnError)
+	abiFile := fs.String("abi", "", "JSON ABI of the contract")
+	typeName := fs.String("type", "", "name of the generated client type")
+	output := fs.String("o", "", "output file (default <type>_client.go, lowercased)")
+	dir := fs.String("dir", ".", "package directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *abiFile == "" || *typeName == "" {
+		return fmt.Errorf("client: -abi and -type are required")
+	}
+	if !token.IsIdentifier(*typeName) || !token.IsExported(*typeName) {
+		return fmt.Errorf("client: %q is not an exported Go identifier", *typeName)
+	}
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_client.go"
+	}
+
+	abiJSON, err := os.ReadFile(*abiFile)
+	if err != nil {
+		return err
+	}
+	pkg, err := packageName(*dir)
+	if err != nil {
+		return err
+	}
+	src, err := generateClient(pkg, *typeName, abiJSON)
+	if err != nil {
+		return err
+	}
+	return writeSource(*output, src)
+}
+
+// generateClient renders the client file for package pkg.
+func generateClient(pkg, typeName string, abiJSON []byte) ([]byte, error) {
+	var entries []abiEntry
+	if err := json.Unmarshal(abiJSON, &entries); err != nil {
+		return nil, fmt.Errorf("client: parsing ABI: %v", err)
+	}
+	compact := new(bytes.Buffer)
+	if err := json.Compact(compact, abiJSON); err != nil {
+		return nil, fmt.Errorf("client: parsing ABI: %v", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "client")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString(`import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Reference imports that go unused for some ABIs.
+var (
+	_ = context.Background
+	_ = big.NewInt
+	_ = ethereum.FilterQuery{}
+	_ = common.Address{}
+	_ = types.Log{}
+)
+
+`)
+
+	fmt.Fprintf(&buf, "// %sABI is the JSON ABI of %s.\n", typeName, typeName)
+	fmt.Fprintf(&buf, "const %sABI = %s\n\n", typeName, strconv.Quote(compact.String()))
+
+	fmt.Fprintf(&buf, `// %[1]s is a client for a deployed %[1]s contract. Calls and
+// transactions go through a bind.ContractBackend such as *ethclient.Client.
+type %[1]s struct {
+	Address  common.Address
+	abi      abi.ABI
+	backend  bind.ContractBackend
+	contract *bind.BoundContract
+}
+
+// New%[1]s returns a client for the %[1]s at address.
+func New%[1]s(address common.Address, backend bind.ContractBackend) (*%[1]s, error) {
+	parsed, err := abi.JSON(strings.NewReader(%[1]sABI))
+	if err != nil {
+		return nil, err
+	}
+	return &%[1]s{
+		Address:  address,
+		abi:      parsed,
+		backend:  backend,
+		contract: bind.NewBoundContract(address, parsed, backend, backend, backend),
+	}, nil
+}
+`, typeName)
+
+	// go-ethereum names overloads name, name0, name1, ... in ABI order
+	methods, events := map[string]int{}, map[string]int{}
+	for _, e := range entries {
+		var err error
+		switch e.Type {
+		case "function", "":
+			err = writeMethod(&buf, typeName, overloadName(methods, e.Name), e)
+		case "event":
+			err = writeEvent(&buf, typeName, overloadName(events, e.Name), e)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// overloadName returns the name go-ethereum's abi package gives the next
+// entry called name.
+func overloadName(seen map[string]int, name string) string {
+	n, ok := seen[name]
+	seen[name] = n + 1
+	if !ok {
+		return name
+	}
+	return name + strconv.Itoa(n-1)
+}
+
+func writeMethod(buf *bytes.Buffer, typeName, abiName string, e abiEntry) error {
+	params, names, err := goParams(e.Name, e.Inputs)
+	if err != nil {
+		return err
+	}
+	goName := toCamelCase(abiName)
+	callArgs := ""
+	if len(names) > 0 {
+		callArgs = ", " + strings.Join(names, ", ")
+	}
+
+	if !isView(e) {
+		fmt.Fprintf(buf, "\n// %s sends a %s transaction.\n", goName, signatureOf(e))
+		fmt.Fprintf(buf, "func (c *%s) %s(opts *bind.TransactOpts%s) (*types.Transaction, error) {\n", typeName, goName, prefixed(params))
+		fmt.Fprintf(buf, "return c.contract.Transact(opts, %q%s)\n}\n", abiName, callArgs)
+		return nil
+	}
+
+	outTypes := make([]string, len(e.Outputs))
+	zeros := make([]string, len(e.Outputs)+1)
+	for i, p := range e.Outputs {
+		t, err := goType(p.Type)
+		if err != nil {
+			return fmt.Errorf("client: %s: %v", e.Name, err)
+		}
+		outTypes[i] = t
+		zeros[i] = "*new(" + t + ")"
+	}
+	zeros[len(e.Outputs)] = "err"
+
+	fmt.Fprintf(buf, "\n// %s calls %s.\n", goName, signatureOf(e))
+	fmt.Fprintf(buf, "func (c *%s) %s(opts *bind.CallOpts%s) (%s) {\n", typeName, goName, prefixed(params), strings.Join(append(outTypes, "error"), ", "))
+	buf.WriteString("var out []interface{}\n")
+	fmt.Fprintf(buf, "if err := c.contract.Call(opts, &out, %q%s); err != nil {\n", abiName, callArgs)
+	fmt.Fprintf(buf, "return %s\n}\n", strings.Join(zeros, ", "))
+	results := make([]string, len(e.Outputs)+1)
+	for i, t := range outTypes {
+		results[i] = fmt.Sprintf("*abi.ConvertType(out[%d], new(%s)).(*%s)", i, t, t)
+	}
+	results[len(e.Outputs)] = "nil"
+	fmt.Fprintf(buf, "return %s\n}\n", strings.Join(results, ", "))
+	return nil
+}
+
+func writeEvent(buf *bytes.Buffer, typeName, abiName string, e abiEntry) error {
+	structName := typeName + toCamelCase(abiName)
+	fmt.Fprintf(buf, "\n// %s is a %s event.\n", structName, signatureOf(e))
+	fmt.Fprintf(buf, "type %s struct {\n", structName)
+	for i, p := range e.Inputs {
+		t, err := goType(p.Type)
+		if err != nil {
+			return fmt.Errorf("client: event %s: %v", e.Name, err)
+		}
+		if p.Indexed && isDynamic(p.Type) {
+			// Only the hash of an indexed dynamic value is in the log
+			t = "common.Hash"
+		}
+		fmt.Fprintf(buf, "%s %s\n", eventField(p, i), t)
+	}
+	buf.WriteString("Raw types.Log\n}\n")
+
+	fmt.Fprintf(buf, "\n// Parse%s decodes a %s log.\n", toCamelCase(abiName), e.Name)
+	fmt.Fprintf(buf, "func (c *%s) Parse%s(log types.Log) (*%s, error) {\n", typeName, toCamelCase(abiName), structName)
+	fmt.Fprintf(buf, "ev := new(%s)\n", structName)
+	fmt.Fprintf(buf, "if err := c.contract.UnpackLog(ev, %q, log); err != nil {\nreturn nil, err\n}\n", abiName)
+	buf.WriteString("ev.Raw = log\nreturn ev, nil\n}\n")
+
+	if e.Anonymous {
+		// Without a topic0 the logs of an anonymous event cannot be told
+		// apart from others; callers parse logs they select themselves
+		return nil
+	}
+
+	var params, rules []string
+	for i, p := range e.Inputs {
+		if !p.Indexed {
+			continue
+		}
+		t, _ := goType(p.Type)
+		if isDynamic(p.Type) {
+			t = "common.Hash"
+		}
+		name := argName(p.Name, i) + "Rule"
+		params = append(params, fmt.Sprintf("%s []%s", name, t))
+		rules = append(rules, name)
+	}
+	fmt.Fprintf(buf, "\n// Filter%s returns the %s logs in a block range, keeping only those\n", toCamelCase(abiName), e.Name)
+	buf.WriteString("// whose indexed arguments match one of the given values; a nil slice\n// matches any value.\n")
+	fmt.Fprintf(buf, "func (c *%s) Filter%s(opts *bind.FilterOpts%s) ([]*%s, error) {\n", typeName, toCamelCase(abiName), prefixed(params), structName)
+	fmt.Fprintf(buf, "query := [][]interface{}{{c.abi.Events[%q].ID}}\n", abiName)
+	for _, r := range rules {
+		buf.WriteString("{\nvar rule []interface{}\n")
+		fmt.Fprintf(buf, "for _, v := range %s {\nrule = append(rule, v)\n}\n", r)
+		buf.WriteString("query = append(query, rule)\n}\n")
+	}
+	buf.WriteString(`topics, err := abi.MakeTopics(query...)
+if err != nil {
+	return nil, err
+}
+if opts == nil {
+	opts = new(bind.FilterOpts)
+}
+ctx := opts.Context
+if ctx == nil {
+	ctx = context.Background()
+}
+filter := ethereum.FilterQuery{
+	FromBlock: new(big.Int).SetUint64(opts.Start),
+	Addresses: []common.Address{c.Address},
+	Topics:    topics,
+}
+if opts.End != nil {
+	filter.ToBlock = new(big.Int).SetUint64(*opts.End)
+}
+logs, err := c.backend.FilterLogs(ctx, filter)
+if err != nil {
+	return nil, err
+}
+`)
+	fmt.Fprintf(buf, "events := make([]*%s, 0, len(logs))\n", structName)
+	fmt.Fprintf(buf, "for _, log := range logs {\nev, err := c.Parse%s(log)\n", toCamelCase(abiName))
+	buf.WriteString("if err != nil {\nreturn nil, err\n}\nevents = append(events, ev)\n}\nreturn events, nil\n}\n")
+	return nil
+}
+
+// goParams returns the Go parameter list and argument names for inputs.
+func goParams(fn string, inputs []abiParam) ([]string, []string, error) {
+	params := make([]string, len(inputs))
+	names := make([]string, len(inputs))
+	for i, p := range inputs {
+		t, err := goType(p.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("client: %s: %v", fn, err)
+		}
+		names[i] = argName(p.Name, i)
+		params[i] = names[i] + " " + t
+	}
+	return params, names, nil
+}
+
+// goType maps an ABI type to the Go type go-ethereum decodes it to.
+func goType(t string) (string, error) {
+	if i := strings.LastIndexByte(t, '['); i >= 0 && strings.HasSuffix(t, "]") {
+		elem, err := goType(t[:i])
+		if err != nil {
+			return "", err
+		}
+		return t[i:] + elem, nil
+	}
+	switch t {
+	case "address":
+		return "common.Address", nil
+	case "bool", "string":
+		return t, nil
+	case "bytes":
+		return "[]byte", nil
+	}
+	for _, prefix := range []string{"uint", "int", "bytes"} {
+		if !strings.HasPrefix(t, prefix) {
+			continue
+		}
+		n, err := strconv.Atoi(t[len(prefix):])
+		if err != nil {
+			break
+		}
+		switch {
+		case prefix == "bytes" && n >= 1 && n <= 32:
+			return fmt.Sprintf("[%d]byte", n), nil
+		case prefix != "bytes" && (n == 8 || n == 16 || n == 32 || n == 64):
+			return prefix + strconv.Itoa(n), nil
+		case prefix != "bytes" && n%8 == 0 && n > 0 && n <= 256:
+			return "*big.Int", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported ABI type %q", t)
+}
+
+// isDynamic reports whether an indexed argument of type t is stored as a
+// hash.
+func isDynamic(t string) bool {
+	return t == "string" || t == "bytes" || strings.HasSuffix(t, "]")
+}
+
+// signatureOf returns the canonical signature of e.
+func signatureOf(e abiEntry) string {
+	types := make([]string, len(e.Inputs))
+	for i, p := range e.Inputs {
+		types[i] = p.Type
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// eventField returns the struct field go-ethereum unpacks input i into.
+func eventField(p abiParam, i int) string {
+	if p.Name == "" {
+		return "Arg" + strconv.Itoa(i)
+	}
+	return toCamelCase(p.Name)
+}
+
+// argName returns a Go parameter name for an ABI argument.
+func argName(name string, i int) string {
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return "arg" + strconv.Itoa(i)
+	}
+	name = strings.ToLower(name[:1]) + toCamelCase(name)[1:]
+	// Keywords and the generated code's own identifiers get a suffix
+	switch name {
+	case "c", "opts", "out", "err":
+		name += "_"
+	default:
+		if token.Lookup(name).IsKeyword() {
+			name += "_"
+		}
+	}
+	return name
+}
+
+// toCamelCase converts an ABI name the way go-ethereum's abi.ToCamelCase
+// does: each underscore-separated part capitalized.
+func toCamelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// prefixed joins params for appending after a leading parameter.
+func prefixed(params []string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(params, ", ")
+}
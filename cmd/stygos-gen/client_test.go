@@ -0,0 +1,82 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const testABI = `[
+	{"type": "function", "name": "balanceOf", "stateMutability": "view",
+	 "inputs": [{"name": "owner", "type": "address"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}], "outputs": [{"name": "", "type": "bool"}]},
+	{"type": "function", "name": "transfer", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}, {"name": "data", "type": "bytes"}], "outputs": []},
+	{"type": "function", "name": "roots", "stateMutability": "pure",
+	 "inputs": [{"name": "", "type": "uint64"}], "outputs": [{"name": "", "type": "bytes32[2]"}, {"name": "", "type": "string"}]},
+	{"type": "event", "name": "Transfer", "anonymous": false,
+	 "inputs": [{"name": "from", "type": "address", "indexed": true}, {"name": "to", "type": "address", "indexed": true}, {"name": "value", "type": "uint256", "indexed": false}]},
+	{"type": "event", "name": "Memo", "anonymous": false,
+	 "inputs": [{"name": "note", "type": "string", "indexed": true}, {"name": "", "type": "uint8", "indexed": false}]}
+]`
+
+func TestGenerateClient(t *testing.T) {
+	src, err := generateClient("token", "Token", []byte(testABI))
+	if err != nil {
+		t.Fatalf("generateClient failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "token_client.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"func NewToken(address common.Address, backend bind.ContractBackend) (*Token, error) {",
+		"func (c *Token) BalanceOf(opts *bind.CallOpts, owner common.Address) (*big.Int, error) {",
+		`c.contract.Call(opts, &out, "balanceOf", owner)`,
+		"func (c *Token) Transfer(opts *bind.TransactOpts, to common.Address, amount *big.Int) (*types.Transaction, error) {",
+		"func (c *Token) Transfer0(opts *bind.TransactOpts, to common.Address, amount *big.Int, data []byte) (*types.Transaction, error) {",
+		`c.contract.Transact(opts, "transfer0", to, amount, data)`,
+		"func (c *Token) Roots(opts *bind.CallOpts, arg0 uint64) ([2][32]byte, string, error) {",
+		"type TokenTransfer struct {",
+		"func (c *Token) FilterTransfer(opts *bind.FilterOpts, fromRule []common.Address, toRule []common.Address) ([]*TokenTransfer, error) {",
+		"func (c *Token) ParseMemo(log types.Log) (*TokenMemo, error) {",
+		"func (c *Token) FilterMemo(opts *bind.FilterOpts, noteRule []common.Hash) ([]*TokenMemo, error) {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+	// Indexed dynamic values are hashes; unnamed event inputs are ArgN
+	if !strings.Contains(string(src), "Note common.Hash") || !strings.Contains(string(src), "Arg1 uint8") {
+		t.Errorf("generated event struct is wrong:\n%s", src)
+	}
+
+	if _, err := generateClient("token", "Token", []byte(`[{"type":"function","name":"f","inputs":[{"name":"t","type":"tuple"}]}]`)); err == nil {
+		t.Error("generateClient succeeded on a tuple, want error")
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := map[string]string{
+		"address":      "common.Address",
+		"uint8":        "uint8",
+		"int64":        "int64",
+		"uint96":       "*big.Int",
+		"int256":       "*big.Int",
+		"bytes4":       "[4]byte",
+		"bytes":        "[]byte",
+		"uint256[]":    "[]*big.Int",
+		"address[2][]": "[][2]common.Address",
+	}
+	for abiType, want := range tests {
+		if got, err := goType(abiType); err != nil || got != want {
+			t.Errorf("goType(%q) = %q, %v, want %q", abiType, got, err, want)
+		}
+	}
+	for _, bad := range []string{"uint7", "bytes33", "tuple", "fixed128x18"} {
+		if _, err := goType(bad); err == nil {
+			t.Errorf("goType(%q) succeeded, want error", bad)
+		}
+	}
+}
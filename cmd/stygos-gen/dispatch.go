@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// route is a single `signature=handler` argument of the dispatch mode.
+type route struct {
+	Signature string
+	Handler   string
+	Selector  stygos.Selector
+}
+
+// runDispatch implements `stygos-gen dispatch`. Every argument has the form
+// signature=handler, e.g. "transfer(address,uint256)=handleTransfer", and the
+// output declares a router backed by a precomputed jump table.
+func runDispatch(args []string) error {
+	fs := flag.NewFlagSet("dispatch", flag.ContinueOnError)
+	output := fs.String("o", "dispatch_gen.go", "output file")
+	dir := fs.String("dir", ".", "package directory")
+	name := fs.String("var", "router", "name of the generated router variable")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("dispatch: no routes given, want signature=handler arguments")
+	}
+	if !token.IsIdentifier(*name) {
+		return fmt.Errorf("dispatch: %q is not a valid Go identifier", *name)
+	}
+
+	routes, err := parseRoutes(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	pkg, err := packageName(*dir)
+	if err != nil {
+		return err
+	}
+
+	src, err := generateDispatch(pkg, *name, routes)
+	if err != nil {
+		return err
+	}
+	return writeSource(*output, src)
+}
+
+// parseRoutes parses and validates signature=handler arguments.
+func parseRoutes(args []string) ([]route, error) {
+	routes := make([]route, 0, len(args))
+	for _, arg := range args {
+		i := strings.LastIndex(arg, "=")
+		if i <= 0 || i == len(arg)-1 {
+			return nil, fmt.Errorf("dispatch: malformed argument %q, want signature=handler", arg)
+		}
+		signature, handler := arg[:i], arg[i+1:]
+		if !token.IsIdentifier(handler) {
+			return nil, fmt.Errorf("dispatch: %q is not a valid Go identifier", handler)
+		}
+		if !strings.HasSuffix(signature, ")") || !strings.Contains(signature, "(") {
+			return nil, fmt.Errorf("dispatch: %q is not a function signature", signature)
+		}
+		routes = append(routes, route{
+			Signature: signature,
+			Handler:   handler,
+			Selector:  selectorOf(signature),
+		})
+	}
+	return routes, nil
+}
+
+// selectorOf returns the first four bytes of keccak256(signature).
+func selectorOf(signature string) stygos.Selector {
+	var sel stygos.Selector
+	hash := keccak256([]byte(signature))
+	copy(sel[:], hash[:4])
+	return sel
+}
+
+// generateDispatch renders the dispatch file for package pkg.
+func generateDispatch(pkg, name string, routes []route) ([]byte, error) {
+	selectors := make([]stygos.Selector, len(routes))
+	for i, r := range routes {
+		selectors[i] = r.Selector
+	}
+	table, err := stygos.BuildJumpTable(selectors)
+	if err != nil {
+		return nil, fmt.Errorf("dispatch: %v", err)
+	}
+
+	tableName := name + "Table"
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "dispatch")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"github.com/rafaelescrich/stygos\"\n\n")
+
+	fmt.Fprintf(&buf, "// %s is a perfect hash over the routed selectors:\n//\n", tableName)
+	for _, r := range routes {
+		fmt.Fprintf(&buf, "//\t0x%x %s -> %s\n", r.Selector, r.Signature, r.Handler)
+	}
+	fmt.Fprintf(&buf, "var %s = stygos.JumpTable{\n", tableName)
+	fmt.Fprintf(&buf, "Seed: %d,\n", table.Seed)
+	fmt.Fprintf(&buf, "Shift: %d,\n", table.Shift)
+	buf.WriteString("Slots: []uint8{")
+	for i, slot := range table.Slots {
+		if i%16 == 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%d, ", slot)
+	}
+	buf.WriteString("\n},\n")
+	buf.WriteString("Selectors: []stygos.Selector{\n")
+	for _, r := range routes {
+		fmt.Fprintf(&buf, "{0x%02x, 0x%02x, 0x%02x, 0x%02x}, // %s\n",
+			r.Selector[0], r.Selector[1], r.Selector[2], r.Selector[3], r.Signature)
+	}
+	buf.WriteString("},\n}\n\n")
+
+	fmt.Fprintf(&buf, "// %s dispatches calldata through %s.\n", name, tableName)
+	fmt.Fprintf(&buf, "var %s = stygos.NewTableRouter(&%s, []stygos.Handler{\n", name, tableName)
+	for _, r := range routes {
+		fmt.Fprintf(&buf, "%s,\n", r.Handler)
+	}
+	buf.WriteString("})\n")
+	return buf.Bytes(), nil
+}
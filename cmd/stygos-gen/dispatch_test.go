@@ -0,0 +1,51 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestParseRoutes(t *testing.T) {
+	routes, err := parseRoutes([]string{"transfer(address,uint256)=handleTransfer"})
+	if err != nil {
+		t.Fatalf("parseRoutes failed: %v", err)
+	}
+	if routes[0].Signature != "transfer(address,uint256)" || routes[0].Handler != "handleTransfer" {
+		t.Errorf("unexpected route %+v", routes[0])
+	}
+	if routes[0].Selector != [4]byte{0xa9, 0x05, 0x9c, 0xbb} {
+		t.Errorf("selector = %x, want a9059cbb", routes[0].Selector)
+	}
+
+	for _, bad := range []string{"transfer(address)", "transfer=handle", "transfer(address)=1handle"} {
+		if _, err := parseRoutes([]string{bad}); err == nil {
+			t.Errorf("parseRoutes(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestGenerateDispatch(t *testing.T) {
+	routes, err := parseRoutes([]string{
+		"name()=handleName",
+		"balanceOf(address)=handleBalanceOf",
+		"transfer(address,uint256)=handleTransfer",
+	})
+	if err != nil {
+		t.Fatalf("parseRoutes failed: %v", err)
+	}
+
+	src, err := generateDispatch("main", "router", routes)
+	if err != nil {
+		t.Fatalf("generateDispatch failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "dispatch_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{"var routerTable = stygos.JumpTable{", "stygos.NewTableRouter(&routerTable", "handleTransfer,"} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxIndexed is the number of indexed arguments an event may have besides
+// the topic of its signature.
+const maxIndexed = 3
+
+// eventKind classifies the field types supported by the events mode.
+type eventKind int
+
+const (
+	eventAddress eventKind = iota
+	eventWord
+	eventU256
+	eventBool
+	eventUint   // uint8..uint64
+	eventInt    // int8..int64
+	eventFixed  // [N]byte
+	eventBytes  // []byte
+	eventString // string
+)
+
+// eventArg is an argument of an event struct.
+type eventArg struct {
+	Field   string // struct field name
+	Param   string // Go parameter name of the emitter
+	Name    string // ABI argument name
+	GoType  string // as written in the struct
+	ABIType string
+	Kind    eventKind
+	Indexed bool
+}
+
+// eventDecl is an event struct.
+type eventDecl struct {
+	Name   string
+	Fields []eventArg
+}
+
+// Signature returns the canonical signature hashed into topic0.
+func (e *eventDecl) Signature() string {
+	types := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		types[i] = f.ABIType
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// Declaration returns the Solidity declaration of the event.
+func (e *eventDecl) Declaration() string {
+	params := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		params[i] = f.ABIType
+		if f.Indexed {
+			params[i] += " indexed"
+		}
+		params[i] += " " + f.Name
+	}
+	return "event " + e.Name + "(" + strings.Join(params, ", ") + ")"
+}
+
+// runEvents implements `stygos-gen events`, which emits an Emit<Event>
+// function and an Emit method for each event struct, computing the topics
+// and the ABI encoded data, and with -abi records the events in a JSON ABI.
+func runEvents(args []string) error {
+	fs := flag.NewFlagSet("events", flag.ContinueOnError)
+	typeNames := fs.String("type", "", "comma-separated list of event struct types")
+	output := fs.String("o", "events_gen.go", "output file")
+	dir := fs.String("dir", ".", "package directory")
+	abiFile := fs.String("abi", "", "JSON ABI file to add the events to, created if missing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *typeNames == "" {
+		return fmt.Errorf("events: -type is required")
+	}
+
+	pkg, structs, err := parseStructs(*dir)
+	if err != nil {
+		return err
+	}
+	var events []*eventDecl
+	for _, name := range strings.Split(*typeNames, ",") {
+		st, ok := structs[name]
+		if !ok {
+			return fmt.Errorf("events: struct type %s not found in %s", name, *dir)
+		}
+		e, err := eventLayout(name, st)
+		if err != nil {
+			return err
+		}
+		events = append(events, e)
+	}
+
+	if err := writeSource(*output, generateEvents(pkg, events)); err != nil {
+		return err
+	}
+	if *abiFile == "" {
+		return nil
+	}
+	prev, err := os.ReadFile(*abiFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	merged, err := mergeEventABI(prev, events)
+	if err != nil {
+		return fmt.Errorf("events: %s: %v", *abiFile, err)
+	}
+	return os.WriteFile(*abiFile, merged, 0o644)
+}
+
+// eventLayout reads the arguments of an event struct. Fields tagged
+// `stygos:"indexed"` go in topics, at most three; fields tagged
+// `stygos:"-"` are skipped.
+func eventLayout(name string, st *ast.StructType) (*eventDecl, error) {
+	e := &eventDecl{Name: name}
+	indexed := 0
+	for _, f := range st.Fields.List {
+		var opts []string
+		if f.Tag != nil {
+			tag, _ := strconv.Unquote(f.Tag.Value)
+			opts = strings.Split(reflect.StructTag(tag).Get("stygos"), ",")
+		}
+		if len(opts) > 0 && opts[0] == "-" {
+			continue
+		}
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("events: %s: embedded fields are not supported", name)
+		}
+		typ := exprString(f.Type)
+		kind, abiType, ok := eventType(f.Type)
+		if !ok {
+			return nil, fmt.Errorf("events: %s: field type %s has no ABI type (tag it `stygos:\"-\"` to skip)", name, typ)
+		}
+		isIndexed := false
+		for _, o := range opts {
+			switch o {
+			case "indexed":
+				isIndexed = true
+			case "":
+			default:
+				return nil, fmt.Errorf("events: %s: unknown option %q", name, o)
+			}
+		}
+		for _, ident := range f.Names {
+			if isIndexed {
+				indexed++
+			}
+			abiName := strings.ToLower(ident.Name[:1]) + ident.Name[1:]
+			e.Fields = append(e.Fields, eventArg{
+				Field:   ident.Name,
+				Param:   goParam(abiName),
+				Name:    abiName,
+				GoType:  typ,
+				ABIType: abiType,
+				Kind:    kind,
+				Indexed: isIndexed,
+			})
+		}
+	}
+	if indexed > maxIndexed {
+		return nil, fmt.Errorf("events: %s has %d indexed fields, at most %d fit in topics", name, indexed, maxIndexed)
+	}
+	return e, nil
+}
+
+// eventType returns the kind and ABI type of a supported field type.
+func eventType(expr ast.Expr) (eventKind, string, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "bool":
+			return eventBool, "bool", true
+		case "string":
+			return eventString, "string", true
+		case "uint8", "byte", "uint16", "uint32", "uint64":
+			if t.Name == "byte" {
+				return eventUint, "uint8", true
+			}
+			return eventUint, t.Name, true
+		case "int8", "int16", "int32", "int64":
+			return eventInt, t.Name, true
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "stygos" {
+			switch t.Sel.Name {
+			case "Address":
+				return eventAddress, "address", true
+			case "Word":
+				return eventWord, "bytes32", true
+			case "U256":
+				return eventU256, "uint256", true
+			}
+		}
+	case *ast.ArrayType:
+		elem, ok := t.Elt.(*ast.Ident)
+		if !ok || (elem.Name != "byte" && elem.Name != "uint8") {
+			break
+		}
+		if t.Len == nil {
+			return eventBytes, "bytes", true
+		}
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			n, err := strconv.Atoi(lit.Value)
+			if err == nil && n > 0 && n <= 32 {
+				return eventFixed, "bytes" + lit.Value, true
+			}
+		}
+	}
+	return 0, "", false
+}
+
+// emitterLocals are the names the generated emitters declare themselves.
+var emitterLocals = map[string]bool{
+	"stygos": true, "w": true, "data": true, "tail": true, "i": true,
+	"t1": true, "t2": true, "t3": true,
+}
+
+// goParam returns name usable as a parameter of the generated emitter.
+func goParam(name string) string {
+	if token.IsKeyword(name) || emitterLocals[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// generateEvents renders the events file for package pkg.
+func generateEvents(pkg string, events []*eventDecl) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "events")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"github.com/rafaelescrich/stygos\"\n")
+	for _, e := range events {
+		writeEmitter(&buf, e)
+	}
+	return buf.Bytes()
+}
+
+// writeEmitter renders the topic, emitter and method of one event.
+func writeEmitter(buf *bytes.Buffer, e *eventDecl) {
+	topic := e.Name + "Topic"
+	fmt.Fprintf(buf, "\n// %s is keccak256(\"%s\").\n", topic, e.Signature())
+	fmt.Fprintf(buf, "var %s = %s\n\n", topic, wordLiteral(keccak256([]byte(e.Signature()))))
+
+	params := make([]string, len(e.Fields))
+	fields := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		params[i] = f.Param + " " + f.GoType
+		fields[i] = "e." + f.Field
+	}
+
+	// The body is rendered first to know whether it needs the scratch word
+	var body bytes.Buffer
+	usesW := false
+	topicArgs := []string{topic}
+	var dynamic []eventArg
+	heads := 0
+	for _, f := range e.Fields {
+		switch {
+		case f.Indexed:
+			t := fmt.Sprintf("t%d", len(topicArgs))
+			if f.Kind == eventBytes || f.Kind == eventString {
+				fmt.Fprintf(&body, "%s := stygos.Keccak256([]byte(%s))\n", t, f.Param)
+			} else if expr, ok := wordExpr(f); ok {
+				fmt.Fprintf(&body, "%s := %s\n", t, expr)
+			} else {
+				writeWord(&body, f)
+				fmt.Fprintf(&body, "%s := w\n", t)
+				usesW = true
+			}
+			topicArgs = append(topicArgs, t)
+		case f.Kind == eventBytes || f.Kind == eventString:
+			dynamic = append(dynamic, f)
+			heads += 32
+		default:
+			heads += 32
+		}
+	}
+
+	data := "nil"
+	if heads > 0 {
+		data = "data"
+		usesW = true
+		size := strconv.Itoa(heads)
+		for _, f := range dynamic {
+			size += fmt.Sprintf(" + 32 + (len(%s)+31)/32*32", f.Param)
+		}
+		fmt.Fprintf(&body, "data := make([]byte, 0, %s)\n", size)
+		if len(dynamic) > 0 {
+			fmt.Fprintf(&body, "tail := %d\n", heads)
+		}
+		for _, f := range e.Fields {
+			switch {
+			case f.Indexed:
+				continue
+			case f.Kind == eventBytes || f.Kind == eventString:
+				body.WriteString("w = stygos.WordFromUint64(uint64(tail))\n")
+				fmt.Fprintf(&body, "tail += 32 + (len(%s)+31)/32*32\n", f.Param)
+			default:
+				writeWord(&body, f)
+			}
+			body.WriteString("data = append(data, w[:]...)\n")
+		}
+		for _, f := range dynamic {
+			fmt.Fprintf(&body, "w = stygos.WordFromUint64(uint64(len(%s)))\n", f.Param)
+			body.WriteString("data = append(data, w[:]...)\n")
+			fmt.Fprintf(&body, "data = append(data, %s...)\n", f.Param)
+			fmt.Fprintf(&body, "data = append(data, make([]byte, (len(%s)+31)/32*32-len(%s))...)\n", f.Param, f.Param)
+		}
+	}
+
+	fmt.Fprintf(buf, "// Emit%s emits\n//\n//\t%s\n", e.Name, e.Declaration())
+	fmt.Fprintf(buf, "func Emit%s(%s) error {\n", e.Name, strings.Join(params, ", "))
+	if usesW {
+		buf.WriteString("var w stygos.Word\n")
+	}
+	buf.Write(body.Bytes())
+	fmt.Fprintf(buf, "return stygos.EmitEvent(%s, %s)\n}\n\n", data, strings.Join(topicArgs, ", "))
+
+	fmt.Fprintf(buf, "// Emit emits the %s event e.\n", e.Name)
+	fmt.Fprintf(buf, "func (e *%s) Emit() error {\n", e.Name)
+	fmt.Fprintf(buf, "return Emit%s(%s)\n}\n", e.Name, strings.Join(fields, ", "))
+}
+
+// wordExpr returns an expression for the ABI word of a static field, if
+// one expression does.
+func wordExpr(f eventArg) (string, bool) {
+	switch f.Kind {
+	case eventAddress:
+		return "stygos.PadAddress(" + f.Param + ")", true
+	case eventWord:
+		return f.Param, true
+	case eventU256:
+		return f.Param + ".Word()", true
+	case eventUint:
+		return "stygos.WordFromUint64(uint64(" + f.Param + "))", true
+	}
+	return "", false
+}
+
+// writeWord renders statements setting w to the ABI word of a static field.
+func writeWord(buf *bytes.Buffer, f eventArg) {
+	if expr, ok := wordExpr(f); ok {
+		fmt.Fprintf(buf, "w = %s\n", expr)
+		return
+	}
+	switch f.Kind {
+	case eventBool:
+		fmt.Fprintf(buf, "w = stygos.Word{}\nif %s {\nw[31] = 1\n}\n", f.Param)
+	case eventInt:
+		// Converting to uint64 sign-extends; the upper bytes follow
+		fmt.Fprintf(buf, "w = stygos.WordFromUint64(uint64(%s))\n", f.Param)
+		fmt.Fprintf(buf, "if %s < 0 {\nfor i := 0; i < 24; i++ {\nw[i] = 0xff\n}\n}\n", f.Param)
+	case eventFixed:
+		fmt.Fprintf(buf, "w = stygos.Word{}\ncopy(w[:], %s[:])\n", f.Param)
+	}
+}
+
+// mergeEventABI adds the events to the JSON ABI prev, in place of the
+// events of the same name, and keeps every other entry as it is.
+func mergeEventABI(prev []byte, events []*eventDecl) ([]byte, error) {
+	var entries []json.RawMessage
+	if len(bytes.TrimSpace(prev)) > 0 {
+		if err := json.Unmarshal(prev, &entries); err != nil {
+			return nil, err
+		}
+	}
+	generated := make(map[string]*eventDecl, len(events))
+	for _, e := range events {
+		generated[e.Name] = e
+	}
+	placed := make(map[string]bool)
+	var merged []json.RawMessage
+	for _, raw := range entries {
+		var entry abiEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, err
+		}
+		e := generated[entry.Name]
+		switch {
+		case entry.Type != "event" || e == nil:
+			merged = append(merged, raw)
+		case !placed[e.Name]:
+			merged = append(merged, eventABI(e))
+			placed[e.Name] = true
+		}
+	}
+	for _, e := range events {
+		if !placed[e.Name] {
+			merged = append(merged, eventABI(e))
+		}
+	}
+	out, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// eventABI returns the JSON ABI entry of e.
+func eventABI(e *eventDecl) json.RawMessage {
+	entry := struct {
+		Type      string     `json:"type"`
+		Name      string     `json:"name"`
+		Inputs    []abiParam `json:"inputs"`
+		Anonymous bool       `json:"anonymous"`
+	}{Type: "event", Name: e.Name, Inputs: []abiParam{}}
+	for _, f := range e.Fields {
+		entry.Inputs = append(entry.Inputs, abiParam{Name: f.Name, Type: f.ABIType, Indexed: f.Indexed})
+	}
+	out, _ := json.Marshal(entry)
+	return out
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const eventsSource = `package main
+
+import "github.com/rafaelescrich/stygos"
+
+type Transfer struct {
+	From   stygos.Address ` + "`stygos:\"indexed\"`" + `
+	To     stygos.Address ` + "`stygos:\"indexed\"`" + `
+	Amount stygos.U256
+}
+
+type Noted struct {
+	Key   string ` + "`stygos:\"indexed\"`" + `
+	Delta int32
+	Ok    bool
+	Tag   [4]byte
+	Memo  []byte
+	Type  string
+	Cache []int ` + "`stygos:\"-\"`" + `
+}
+
+type Crowded struct {
+	A, B, C, D uint64 ` + "`stygos:\"indexed\"`" + `
+}
+
+type Bad struct {
+	Owners []stygos.Address
+}
+`
+
+func parseEventsSource(t *testing.T, name string) *ast.StructType {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "src.go", eventsSource, 0)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	return f.Scope.Lookup(name).Decl.(*ast.TypeSpec).Type.(*ast.StructType)
+}
+
+func TestEventLayout(t *testing.T) {
+	e, err := eventLayout("Transfer", parseEventsSource(t, "Transfer"))
+	if err != nil {
+		t.Fatalf("eventLayout failed: %v", err)
+	}
+	if got := e.Signature(); got != "Transfer(address,address,uint256)" {
+		t.Errorf("Signature failed. Expected Transfer(address,address,uint256), got %s", got)
+	}
+	want := "event Transfer(address indexed from, address indexed to, uint256 amount)"
+	if got := e.Declaration(); got != want {
+		t.Errorf("Declaration failed. Expected %s, got %s", want, got)
+	}
+
+	e, err = eventLayout("Noted", parseEventsSource(t, "Noted"))
+	if err != nil {
+		t.Fatalf("eventLayout failed: %v", err)
+	}
+	if got := e.Signature(); got != "Noted(string,int32,bool,bytes4,bytes,string)" {
+		t.Errorf("Signature failed. Expected the skipped field left out, got %s", got)
+	}
+	if e.Fields[5].Param != "type_" || e.Fields[5].Name != "type" {
+		t.Errorf("keyword field failed. Expected param type_ named type, got %+v", e.Fields[5])
+	}
+
+	if _, err := eventLayout("Crowded", parseEventsSource(t, "Crowded")); err == nil || !strings.Contains(err.Error(), "4 indexed") {
+		t.Errorf("eventLayout(Crowded) failed. Expected too many indexed fields, got %v", err)
+	}
+	if _, err := eventLayout("Bad", parseEventsSource(t, "Bad")); err == nil {
+		t.Error("eventLayout(Bad) failed. Expected an unsupported type error")
+	}
+}
+
+func TestGenerateEvents(t *testing.T) {
+	var events []*eventDecl
+	for _, name := range []string{"Transfer", "Noted"} {
+		e, err := eventLayout(name, parseEventsSource(t, name))
+		if err != nil {
+			t.Fatalf("eventLayout failed: %v", err)
+		}
+		events = append(events, e)
+	}
+	src, err := format.Source(generateEvents("main", events))
+	if err != nil {
+		t.Fatalf("generated source does not format: %v", err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"var TransferTopic = stygos.Word{\n\t0xdd, 0xf2, 0x52, 0xad,",
+		"func EmitTransfer(from stygos.Address, to stygos.Address, amount stygos.U256) error {",
+		"return stygos.EmitEvent(data, TransferTopic, t1, t2)",
+		"func (e *Transfer) Emit() error {\n\treturn EmitTransfer(e.From, e.To, e.Amount)",
+		// Indexed strings are hashed into their topic
+		"t1 := stygos.Keccak256([]byte(key))",
+		// Five heads, then the tails of the two dynamic arguments
+		"data := make([]byte, 0, 160+32+(len(memo)+31)/32*32+32+(len(type_)+31)/32*32)",
+		"tail := 160",
+		"if delta < 0 {",
+		"copy(w[:], tag[:])",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("generated source failed. Expected %q in:\n%s", want, out)
+		}
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "events_gen.go", src, 0); err != nil {
+		t.Errorf("generated source does not parse: %v", err)
+	}
+}
+
+func TestMergeEventABI(t *testing.T) {
+	e, err := eventLayout("Transfer", parseEventsSource(t, "Transfer"))
+	if err != nil {
+		t.Fatalf("eventLayout failed: %v", err)
+	}
+	prev := `[
+  {"type": "function", "name": "transfer", "inputs": [], "outputs": [], "stateMutability": "nonpayable"},
+  {"type": "event", "name": "Transfer", "inputs": [], "anonymous": false},
+  {"type": "event", "name": "Approval", "inputs": [], "anonymous": false}
+]`
+	merged, err := mergeEventABI([]byte(prev), []*eventDecl{e})
+	if err != nil {
+		t.Fatalf("mergeEventABI failed: %v", err)
+	}
+	var entries []abiEntry
+	if err := json.Unmarshal(merged, &entries); err != nil {
+		t.Fatalf("merged ABI does not parse: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Name != "transfer" || entries[1].Name != "Transfer" || entries[2].Name != "Approval" {
+		t.Fatalf("mergeEventABI failed. Expected the event replaced in place, got %+v", entries)
+	}
+	if in := entries[1].Inputs; len(in) != 3 || in[0].Name != "from" || !in[0].Indexed || in[2].Type != "uint256" || in[2].Indexed {
+		t.Errorf("mergeEventABI failed. Expected the generated inputs, got %+v", in)
+	}
+
+	// A missing file starts a new ABI
+	merged, err = mergeEventABI(nil, []*eventDecl{e})
+	if err != nil || !strings.Contains(string(merged), `"name": "Transfer"`) {
+		t.Errorf("mergeEventABI(nil) failed. Expected a new ABI, got %s, %v", merged, err)
+	}
+}
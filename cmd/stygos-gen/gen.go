@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// packageName returns the name of the Go package being generated for. It
+// prefers $GOPACKAGE, which go generate sets, and falls back to parsing the
+// package clause of the first Go file in dir.
+func packageName(dir string) (string, error) {
+	if pkg := os.Getenv("GOPACKAGE"); pkg != "" {
+		return pkg, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+		return f.Name.Name, nil
+	}
+	return "", errors.New("no Go files in " + dir)
+}
+
+// writeSource formats src and writes it to path.
+func writeSource(path string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("formatting %s: %v", path, err)
+	}
+	return os.WriteFile(path, formatted, 0o644)
+}
+
+// keccak256 hashes data with the legacy Keccak-256 used by the EVM.
+func keccak256(data []byte) [32]byte {
+	var out [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(out[:0])
+	return out
+}
+
+// wordLiteral renders a 32-byte value as a stygos.Word composite literal.
+func wordLiteral(w [32]byte) string {
+	var buf bytes.Buffer
+	buf.WriteString("stygos.Word{\n")
+	for i, b := range w {
+		if i%16 == 0 {
+			buf.WriteString("\t")
+		}
+		fmt.Fprintf(&buf, "0x%02x,", b)
+		if i%16 == 15 {
+			buf.WriteString("\n")
+		} else {
+			buf.WriteString(" ")
+		}
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
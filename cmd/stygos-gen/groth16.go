@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rafaelescrich/stygos/bn254"
+)
+
+// snarkjsKey is a verification_key.json exported by snarkjs, with the
+// fields of Groth16 and of PLONK keys. Points are projective, as decimal
+// strings; G2 coordinates list the real part of each F_p² element first.
+type snarkjsKey struct {
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+	NPublic  int        `json:"nPublic"`
+	Alpha    []string   `json:"vk_alpha_1"`
+	Beta     [][]string `json:"vk_beta_2"`
+	Gamma    [][]string `json:"vk_gamma_2"`
+	Delta    [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+
+	Power uint       `json:"power"`
+	K1    string     `json:"k1"`
+	K2    string     `json:"k2"`
+	Qm    []string   `json:"Qm"`
+	Ql    []string   `json:"Ql"`
+	Qr    []string   `json:"Qr"`
+	Qo    []string   `json:"Qo"`
+	Qc    []string   `json:"Qc"`
+	S1    []string   `json:"S1"`
+	S2    []string   `json:"S2"`
+	S3    []string   `json:"S3"`
+	X2    [][]string `json:"X_2"`
+	W     string     `json:"w"`
+}
+
+// runGroth16 implements `stygos-gen groth16`, which reads a snarkjs
+// verification key and emits the key as a bn254.Groth16VerifyingKey and a
+// handler for verifyProof, the function of the Solidity verifier snarkjs
+// exports, so existing provers and callers work unchanged. With -contract
+// it also emits the router and entrypoint of a standalone verifier.
+func runGroth16(args []string) error {
+	fs := flag.NewFlagSet("groth16", flag.ContinueOnError)
+	vkFile := fs.String("vk", "verification_key.json", "snarkjs verification key")
+	output := fs.String("o", "verifier_gen.go", "output file")
+	dir := fs.String("dir", ".", "package directory")
+	contract := fs.Bool("contract", false, "also emit the router, main and entrypoint of a verifier contract")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	vkJSON, err := os.ReadFile(*vkFile)
+	if err != nil {
+		return err
+	}
+	pkg, err := packageName(*dir)
+	if err != nil {
+		return err
+	}
+	if *contract && pkg != "main" {
+		return fmt.Errorf("groth16: -contract needs package main, not %s", pkg)
+	}
+	src, err := generateGroth16(pkg, filepath.Base(*vkFile), vkJSON, *contract)
+	if err != nil {
+		return err
+	}
+	return writeSource(*output, src)
+}
+
+// generateGroth16 renders the verifier file for package pkg from the key
+// read from source.
+func generateGroth16(pkg, source string, vkJSON []byte, contract bool) ([]byte, error) {
+	var vk snarkjsKey
+	if err := json.Unmarshal(vkJSON, &vk); err != nil {
+		return nil, fmt.Errorf("groth16: parsing %s: %v", source, err)
+	}
+	switch {
+	case vk.Protocol == "plonk":
+		return nil, fmt.Errorf("groth16: %s is a plonk key; use stygos-gen plonk", source)
+	case vk.Protocol == "fflonk":
+		return nil, fmt.Errorf("groth16: %s is an fflonk key; only groth16 and plonk keys are supported", source)
+	case vk.Protocol != "groth16":
+		return nil, fmt.Errorf("groth16: %s has protocol %q, want groth16", source, vk.Protocol)
+	case vk.Curve != "bn128" && vk.Curve != "bn254":
+		return nil, fmt.Errorf("groth16: %s is over %s; only bn128 keys are supported", source, vk.Curve)
+	case len(vk.IC) != vk.NPublic+1:
+		return nil, fmt.Errorf("groth16: %s has %d IC points for %d public inputs", source, len(vk.IC), vk.NPublic)
+	}
+
+	var key bytes.Buffer
+	g1 := func(name string, p []string) error {
+		lit, err := g1Literal(p)
+		if err != nil {
+			return fmt.Errorf("groth16: %s: %v", name, err)
+		}
+		key.WriteString(lit)
+		return nil
+	}
+	g2 := func(name string, p [][]string) error {
+		lit, err := g2Literal(p)
+		if err != nil {
+			return fmt.Errorf("groth16: %s: %v", name, err)
+		}
+		key.WriteString(lit)
+		return nil
+	}
+	key.WriteString("Alpha: ")
+	if err := g1("vk_alpha_1", vk.Alpha); err != nil {
+		return nil, err
+	}
+	for _, f := range []struct {
+		field, name string
+		p           [][]string
+	}{{"Beta", "vk_beta_2", vk.Beta}, {"Gamma", "vk_gamma_2", vk.Gamma}, {"Delta", "vk_delta_2", vk.Delta}} {
+		fmt.Fprintf(&key, ",\n%s: ", f.field)
+		if err := g2(f.name, f.p); err != nil {
+			return nil, err
+		}
+	}
+	key.WriteString(",\nIC: []bn254.G1{\n")
+	for i, p := range vk.IC {
+		if err := g1(fmt.Sprintf("IC[%d]", i), p); err != nil {
+			return nil, err
+		}
+		key.WriteString(",\n")
+	}
+	key.WriteString("},\n")
+
+	types := []string{"uint256[2]", "uint256[2][2]", "uint256[2]", fmt.Sprintf("uint256[%d]", vk.NPublic)}
+	signature := "verifyProof(" + strings.Join(types, ",") + ")"
+	sel := selectorOf(signature)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "groth16")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import (\n\"github.com/rafaelescrich/stygos\"\n\"github.com/rafaelescrich/stygos/bn254\"\n)\n\n")
+
+	fmt.Fprintf(&buf, "// verifyingKey is the Groth16 verifying key read from %s.\n", source)
+	fmt.Fprintf(&buf, "var verifyingKey = &bn254.Groth16VerifyingKey{\n%s}\n\n", key.String())
+
+	fmt.Fprintf(&buf, "// selVerifyProof is %s.\n", signature)
+	fmt.Fprintf(&buf, "var selVerifyProof = stygos.Selector{0x%02x, 0x%02x, 0x%02x, 0x%02x}\n\n", sel[0], sel[1], sel[2], sel[3])
+
+	fmt.Fprintf(&buf, "// publicInputs is the number of public inputs of verifyProof.\nconst publicInputs = %d\n\n", vk.NPublic)
+
+	buf.WriteString(`// handleVerifyProof returns whether the proof in args is valid for the
+// public inputs after it, as an ABI encoded bool. Like a snarkjs verifier
+// it returns false for inputs outside the scalar field and for points off
+// their curves.
+func handleVerifyProof(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != bn254.Groth16ProofLength+32*publicInputs {
+		return nil, stygos.ErrInvalidInput
+	}
+	proof, _ := bn254.Groth16ProofFromBytes(args[:bn254.Groth16ProofLength])
+	inputs := make([]stygos.Word, publicInputs)
+	for i := range inputs {
+		copy(inputs[i][:], args[bn254.Groth16ProofLength+32*i:])
+	}
+	var out stygos.Word
+	if ok, err := bn254.VerifyGroth16(verifyingKey, &proof, inputs); ok && err == nil {
+		out[31] = 1
+	}
+	return out[:], nil
+}
+`)
+
+	if contract {
+		buf.WriteString(contractSource)
+	}
+	return buf.Bytes(), nil
+}
+
+// contractSource is the router, main and entrypoint of a standalone
+// verifier contract serving handleVerifyProof.
+const contractSource = `
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selVerifyProof, handleVerifyProof)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+`
+
+// g1Literal returns a snarkjs G1 point as a bn254.G1 literal.
+func g1Literal(p []string) (string, error) {
+	x, y, err := snarkjsG1(p)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("bn254.G1{\nX: %s,\nY: %s,\n}", wordLiteral(x), wordLiteral(y)), nil
+}
+
+// g2Literal returns a snarkjs G2 point as a bn254.G2 literal.
+func g2Literal(p [][]string) (string, error) {
+	c, err := snarkjsG2(p)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("bn254.G2{\nX: [2]stygos.Word{\n%s,\n%s,\n},\nY: [2]stygos.Word{\n%s,\n%s,\n},\n}",
+		wordLiteral(c[0]), wordLiteral(c[1]), wordLiteral(c[2]), wordLiteral(c[3])), nil
+}
+
+// snarkjsG1 converts a projective snarkjs G1 point to the precompile
+// encoding.
+func snarkjsG1(p []string) (x, y [32]byte, err error) {
+	if len(p) != 3 {
+		return x, y, fmt.Errorf("want 3 coordinates, got %d", len(p))
+	}
+	c, err := fieldElements(p)
+	if err != nil {
+		return x, y, err
+	}
+	switch {
+	case c[2].Sign() == 0:
+		return x, y, nil // infinity
+	case c[2].Cmp(big.NewInt(1)) != 0:
+		return x, y, fmt.Errorf("point is not affine")
+	}
+	c[0].FillBytes(x[:])
+	c[1].FillBytes(y[:])
+	return x, y, nil
+}
+
+// snarkjsG2 converts a projective snarkjs G2 point to the precompile
+// encoding, swapping each coordinate's parts to put the imaginary first.
+func snarkjsG2(p [][]string) (out [4][32]byte, err error) {
+	if len(p) != 3 || len(p[0]) != 2 || len(p[1]) != 2 || len(p[2]) != 2 {
+		return out, fmt.Errorf("want 3 coordinates of 2 elements")
+	}
+	c, err := fieldElements([]string{p[0][0], p[0][1], p[1][0], p[1][1], p[2][0], p[2][1]})
+	if err != nil {
+		return out, err
+	}
+	switch {
+	case c[4].Sign() == 0 && c[5].Sign() == 0:
+		return out, nil // infinity
+	case c[4].Cmp(big.NewInt(1)) != 0 || c[5].Sign() != 0:
+		return out, fmt.Errorf("point is not affine")
+	}
+	c[1].FillBytes(out[0][:])
+	c[0].FillBytes(out[1][:])
+	c[3].FillBytes(out[2][:])
+	c[2].FillBytes(out[3][:])
+	return out, nil
+}
+
+// fieldElements parses decimal elements of the BN254 base field.
+func fieldElements(s []string) ([]*big.Int, error) {
+	out := make([]*big.Int, len(s))
+	for i, v := range s {
+		n, ok := new(big.Int).SetString(v, 10)
+		if !ok || n.Sign() < 0 || n.Cmp(bn254.P) >= 0 {
+			return nil, fmt.Errorf("%q is not a field element", v)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
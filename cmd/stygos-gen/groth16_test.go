@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+// Generator points in snarkjs form; G2 lists the real part first.
+const (
+	testG1 = `["1", "2", "1"]`
+	testG2 = `[["10857046999023057135944570762232829481370756359578518086990519993285655852781",
+		"11559732032986387107991004021392285783925812861821192530917403151452391805634"],
+		["8495653923123431417604973247489272438418190587263600148770280649306958101930",
+		"4082367875863433681332203403145435568316851327593401208105741076214120093531"],
+		["1", "0"]]`
+)
+
+func testKey(protocol string, nPublic int, ic ...string) []byte {
+	return []byte(fmt.Sprintf(`{"protocol": %q, "curve": "bn128", "nPublic": %d,
+		"vk_alpha_1": %s, "vk_beta_2": %s, "vk_gamma_2": %s, "vk_delta_2": %s, "IC": [%s]}`,
+		protocol, nPublic, testG1, testG2, testG2, testG2, strings.Join(ic, ",")))
+}
+
+func TestGenerateGroth16(t *testing.T) {
+	src, err := generateGroth16("main", "verification_key.json", testKey("groth16", 1, testG1, testG1), true)
+	if err != nil {
+		t.Fatalf("generateGroth16 failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "verifier_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"// selVerifyProof is verifyProof(uint256[2],uint256[2][2],uint256[2],uint256[1]).",
+		"const publicInputs = 1",
+		"func handleVerifyProof(ctx *stygos.Ctx, args []byte) ([]byte, error) {",
+		"r.HandleSelector(selVerifyProof, handleVerifyProof)",
+		"func entrypoint() int32 {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+
+	// G2 coordinates are swapped to put the imaginary part first
+	imag, re := strings.Index(string(src), "0x19, 0x8e, 0x93, 0x93,"), strings.Index(string(src), "0x18, 0x00, 0xde, 0xef,")
+	if imag < 0 || re < imag {
+		t.Errorf("generated G2 coordinates are not in precompile order:\n%s", src)
+	}
+
+	src, err = generateGroth16("verifier", "verification_key.json", testKey("groth16", 0, testG1), false)
+	if err != nil {
+		t.Fatalf("generateGroth16 failed: %v", err)
+	}
+	if strings.Contains(string(src), "func entrypoint") {
+		t.Errorf("generated source has an entrypoint without -contract:\n%s", src)
+	}
+
+	for name, bad := range map[string][]byte{
+		"plonk":       testKey("plonk", 1, testG1, testG1),
+		"ic count":    testKey("groth16", 2, testG1, testG1),
+		"projective":  testKey("groth16", 0, `["1", "2", "3"]`),
+		"over field":  testKey("groth16", 0, `["21888242871839275222246405745257275088696311157297823662689037894645226208583", "2", "1"]`),
+		"not decimal": testKey("groth16", 0, `["0x1", "2", "1"]`),
+	} {
+		if _, err := generateGroth16("main", "verification_key.json", bad, false); err == nil {
+			t.Errorf("generateGroth16(%s) succeeded, want error", name)
+		}
+	}
+}
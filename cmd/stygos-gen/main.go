@@ -0,0 +1,91 @@
+// Command stygos-gen generates Go source for stygos contracts.
+//
+// It is meant to be invoked through go:generate directives placed in the
+// contract package, for example:
+//
+//	//go:generate stygos-gen slots -o slots_gen.go counterKey=counter
+//
+// Usage:
+//
+//	stygos-gen <mode> [flags] [args]
+//
+// Modes:
+//
+//	slots    emit precomputed keccak256 storage slot literals
+//	dispatch emit a selector router backed by a precomputed jump table
+//	pack     emit methods packing struct fields into storage words
+//	events   emit emitters for event structs and add them to a JSON ABI
+//	client   emit a go-ethereum client from a contract's JSON ABI
+//	ts       emit a TypeScript ABI module with a viem or ethers wrapper
+//	check    lint selectors and ABI types, and diff against a previous ABI
+//	vet      check calldata bounds and storage word padding
+//	groth16  emit a Groth16 verifier from a snarkjs verification key
+//	plonk    emit a PLONK verifier from a snarkjs verification key
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// header is written at the top of every generated file.
+const header = "// Code generated by stygos-gen %s. DO NOT EDIT.\n\n"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch mode, args := os.Args[1], os.Args[2:]; mode {
+	case "slots":
+		err = runSlots(args)
+	case "dispatch":
+		err = runDispatch(args)
+	case "pack":
+		err = runPack(args)
+	case "events":
+		err = runEvents(args)
+	case "client":
+		err = runClient(args)
+	case "ts":
+		err = runTS(args)
+	case "check":
+		err = runCheck(args)
+	case "vet":
+		err = runVet(args)
+	case "groth16":
+		err = runGroth16(args)
+	case "plonk":
+		err = runPlonk(args)
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "stygos-gen: unknown mode %q\n", mode)
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stygos-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: stygos-gen <mode> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "modes:")
+	fmt.Fprintln(os.Stderr, "  slots    emit precomputed keccak256 storage slot literals")
+	fmt.Fprintln(os.Stderr, "  dispatch emit a selector router backed by a precomputed jump table")
+	fmt.Fprintln(os.Stderr, "  pack     emit methods packing struct fields into storage words")
+	fmt.Fprintln(os.Stderr, "  events   emit emitters for event structs and add them to a JSON ABI")
+	fmt.Fprintln(os.Stderr, "  client   emit a go-ethereum client from a contract's JSON ABI")
+	fmt.Fprintln(os.Stderr, "  ts       emit a TypeScript ABI module with a viem or ethers wrapper")
+	fmt.Fprintln(os.Stderr, "  check    lint selectors and ABI types, and diff against a previous ABI")
+	fmt.Fprintln(os.Stderr, "  vet      check calldata bounds and storage word padding")
+	fmt.Fprintln(os.Stderr, "  groth16  emit a Groth16 verifier from a snarkjs verification key")
+	fmt.Fprintln(os.Stderr, "  plonk    emit a PLONK verifier from a snarkjs verification key")
+}
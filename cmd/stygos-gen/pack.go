@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// packKind classifies the field types supported by the pack mode.
+type packKind int
+
+const (
+	packBool  packKind = iota
+	packUint           // uint8..uint64 and their signed counterparts
+	packBytes          // stygos.Address, stygos.Selector and [N]byte
+	packWord           // stygos.Word
+	packU256           // stygos.U256
+)
+
+// packField is a struct field placed in the packed layout.
+type packField struct {
+	Name   string
+	Type   string // Go type as written in the struct
+	Kind   packKind
+	Size   int // bits for bools, bytes otherwise
+	Word   int // index of the storage word
+	Offset int // bit offset from the least significant end of the word
+}
+
+// runPack implements `stygos-gen pack`, which emits methods packing the
+// fields of a struct into as few storage words as possible.
+func runPack(args []string) error {
+	fs := flag.NewFlagSet("pack", flag.ContinueOnError)
+	typeNames := fs.String("type", "", "comma-separated list of struct types to pack")
+	output := fs.String("o", "", "output file (default <type>_pack_gen.go)")
+	dir := fs.String("dir", ".", "package directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *typeNames == "" {
+		return fmt.Errorf("pack: -type is required")
+	}
+	names := strings.Split(*typeNames, ",")
+
+	pkg, structs, err := parseStructs(*dir)
+	if err != nil {
+		return err
+	}
+
+	var layouts [][]packField
+	for _, name := range names {
+		st, ok := structs[name]
+		if !ok {
+			return fmt.Errorf("pack: struct type %s not found in %s", name, *dir)
+		}
+		fields, err := packLayout(name, st)
+		if err != nil {
+			return err
+		}
+		layouts = append(layouts, fields)
+	}
+
+	path := *output
+	if path == "" {
+		path = strings.ToLower(names[0]) + "_pack_gen.go"
+	}
+	return writeSource(path, generatePack(pkg, names, layouts))
+}
+
+// parseStructs returns the package name and the struct types declared in the
+// non-test Go files of dir.
+func parseStructs(dir string) (string, map[string]*ast.StructType, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	pkg := ""
+	structs := make(map[string]*ast.StructType)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return "", nil, err
+		}
+		pkg = f.Name.Name
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if st, ok := ts.Type.(*ast.StructType); ok {
+					structs[ts.Name.Name] = st
+				}
+			}
+		}
+	}
+	if pkg == "" {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, structs, nil
+}
+
+// packLayout assigns every field of st a word and bit offset. Fields are
+// placed in declaration order starting at the least significant end of a
+// word, like Solidity, except that bools take a single bit. A field that
+// does not fit in the remaining space of a word starts a new one. Fields
+// tagged `stygos:"-"` are skipped.
+func packLayout(name string, st *ast.StructType) ([]packField, error) {
+	var fields []packField
+	word, offset := 0, 0
+	for _, f := range st.Fields.List {
+		if f.Tag != nil {
+			tag, _ := strconv.Unquote(f.Tag.Value)
+			if reflect.StructTag(tag).Get("stygos") == "-" {
+				continue
+			}
+		}
+		typ := exprString(f.Type)
+		kind, size, ok := packType(f.Type)
+		if !ok {
+			return nil, fmt.Errorf("pack: %s: field type %s cannot be packed (tag it `stygos:\"-\"` to skip)", name, typ)
+		}
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("pack: %s: embedded fields are not supported", name)
+		}
+
+		bits := size
+		if kind != packBool {
+			bits = size * 8
+			offset = (offset + 7) &^ 7
+		}
+		for _, ident := range f.Names {
+			if offset+bits > 256 {
+				word++
+				offset = 0
+			}
+			fields = append(fields, packField{
+				Name:   ident.Name,
+				Type:   typ,
+				Kind:   kind,
+				Size:   size,
+				Word:   word,
+				Offset: offset,
+			})
+			offset += bits
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("pack: %s has no packable fields", name)
+	}
+	return fields, nil
+}
+
+// packType returns the kind and size of a supported field type.
+func packType(expr ast.Expr) (packKind, int, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "bool":
+			return packBool, 1, true
+		case "uint8", "int8", "byte":
+			return packUint, 1, true
+		case "uint16", "int16":
+			return packUint, 2, true
+		case "uint32", "int32":
+			return packUint, 4, true
+		case "uint64", "int64":
+			return packUint, 8, true
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "stygos" {
+			switch t.Sel.Name {
+			case "Address":
+				return packBytes, 20, true
+			case "Selector":
+				return packBytes, 4, true
+			case "Word":
+				return packWord, 32, true
+			case "U256":
+				return packU256, 32, true
+			}
+		}
+	case *ast.ArrayType:
+		elem, ok := t.Elt.(*ast.Ident)
+		lit, isLit := t.Len.(*ast.BasicLit)
+		if ok && isLit && (elem.Name == "byte" || elem.Name == "uint8") {
+			n, err := strconv.Atoi(lit.Value)
+			if err == nil && n > 0 && n <= 32 {
+				return packBytes, n, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// exprString renders a type expression as written in the source.
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + exprString(t.Elt)
+		}
+		return "[" + exprString(t.Len) + "]" + exprString(t.Elt)
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
+// generatePack renders the pack file for package pkg.
+func generatePack(pkg string, names []string, layouts [][]packField) []byte {
+	usesBinary := false
+	for _, fields := range layouts {
+		for _, f := range fields {
+			if f.Kind == packUint && f.Size > 1 {
+				usesBinary = true
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "pack")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import (\n")
+	if usesBinary {
+		buf.WriteString("\"encoding/binary\"\n\n")
+	}
+	buf.WriteString("\"github.com/rafaelescrich/stygos\"\n")
+	buf.WriteString("\"github.com/rafaelescrich/stygos/storage\"\n")
+	buf.WriteString(")\n")
+
+	for i, name := range names {
+		writePackType(&buf, name, layouts[i])
+	}
+	return buf.Bytes()
+}
+
+// writePackType renders the constant and methods of a single type.
+func writePackType(buf *bytes.Buffer, name string, fields []packField) {
+	words := fields[len(fields)-1].Word + 1
+	wordsConst := name + "PackedWords"
+
+	fmt.Fprintf(buf, "\n// %s is the number of storage words used by a packed %s.\n", wordsConst, name)
+	fmt.Fprintf(buf, "const %s = %d\n\n", wordsConst, words)
+
+	fmt.Fprintf(buf, "// MarshalWords packs v into storage words:\n//\n")
+	for _, f := range fields {
+		if f.Kind == packBool {
+			fmt.Fprintf(buf, "//\tword %d bit %d: %s\n", f.Word, f.Offset, f.Name)
+		} else {
+			fmt.Fprintf(buf, "//\tword %d bytes [%d:%d]: %s\n", f.Word, 32-f.Offset/8-f.Size, 32-f.Offset/8, f.Name)
+		}
+	}
+	fmt.Fprintf(buf, "func (v *%s) MarshalWords() [%s]stygos.Word {\n", name, wordsConst)
+	fmt.Fprintf(buf, "var w [%s]stygos.Word\n", wordsConst)
+	for _, f := range fields {
+		writeMarshalField(buf, f)
+	}
+	buf.WriteString("return w\n}\n\n")
+
+	fmt.Fprintf(buf, "// UnmarshalWords unpacks v from storage words produced by MarshalWords.\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalWords(w [%s]stygos.Word) {\n", name, wordsConst)
+	for _, f := range fields {
+		writeUnmarshalField(buf, f)
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "// Store writes v to the %s consecutive slots starting at base.\n", wordsConst)
+	fmt.Fprintf(buf, "func (v *%s) Store(base stygos.Word) {\n", name)
+	buf.WriteString("w := v.MarshalWords()\n")
+	buf.WriteString("for i := range w {\n")
+	buf.WriteString("stygos.StorageStore(storage.Offset(base, uint64(i)), w[i])\n")
+	buf.WriteString("}\n}\n\n")
+
+	fmt.Fprintf(buf, "// Load reads v from the %s consecutive slots starting at base.\n", wordsConst)
+	fmt.Fprintf(buf, "func (v *%s) Load(base stygos.Word) {\n", name)
+	fmt.Fprintf(buf, "var w [%s]stygos.Word\n", wordsConst)
+	buf.WriteString("for i := range w {\n")
+	buf.WriteString("w[i] = stygos.StorageLoad(storage.Offset(base, uint64(i)))\n")
+	buf.WriteString("}\n")
+	buf.WriteString("v.UnmarshalWords(w)\n}\n")
+}
+
+// byteRange returns the byte slice expression bounds of a non-bool field.
+func byteRange(f packField) (int, int) {
+	end := 32 - f.Offset/8
+	return end - f.Size, end
+}
+
+func writeMarshalField(buf *bytes.Buffer, f packField) {
+	switch f.Kind {
+	case packBool:
+		fmt.Fprintf(buf, "if v.%s {\nw[%d][%d] |= 1 << %d\n}\n", f.Name, f.Word, 31-f.Offset/8, f.Offset%8)
+	case packUint:
+		start, end := byteRange(f)
+		value := "v." + f.Name
+		if f.Size == 1 {
+			if f.Type != "byte" && f.Type != "uint8" {
+				value = "byte(" + value + ")"
+			}
+			fmt.Fprintf(buf, "w[%d][%d] = %s\n", f.Word, start, value)
+		} else {
+			if f.Type != fmt.Sprintf("uint%d", f.Size*8) {
+				value = fmt.Sprintf("uint%d(%s)", f.Size*8, value)
+			}
+			fmt.Fprintf(buf, "binary.BigEndian.PutUint%d(w[%d][%d:%d], %s)\n", f.Size*8, f.Word, start, end, value)
+		}
+	case packBytes:
+		start, end := byteRange(f)
+		fmt.Fprintf(buf, "copy(w[%d][%d:%d], v.%s[:])\n", f.Word, start, end, f.Name)
+	case packWord:
+		fmt.Fprintf(buf, "w[%d] = v.%s\n", f.Word, f.Name)
+	case packU256:
+		fmt.Fprintf(buf, "w[%d] = v.%s.Word()\n", f.Word, f.Name)
+	}
+}
+
+func writeUnmarshalField(buf *bytes.Buffer, f packField) {
+	switch f.Kind {
+	case packBool:
+		fmt.Fprintf(buf, "v.%s = w[%d][%d]&(1<<%d) != 0\n", f.Name, f.Word, 31-f.Offset/8, f.Offset%8)
+	case packUint:
+		start, end := byteRange(f)
+		value := fmt.Sprintf("w[%d][%d]", f.Word, start)
+		native := "byte"
+		if f.Size > 1 {
+			value = fmt.Sprintf("binary.BigEndian.Uint%d(w[%d][%d:%d])", f.Size*8, f.Word, start, end)
+			native = fmt.Sprintf("uint%d", f.Size*8)
+		}
+		if f.Type != native && !(native == "byte" && f.Type == "uint8") {
+			value = f.Type + "(" + value + ")"
+		}
+		fmt.Fprintf(buf, "v.%s = %s\n", f.Name, value)
+	case packBytes:
+		start, end := byteRange(f)
+		fmt.Fprintf(buf, "copy(v.%s[:], w[%d][%d:%d])\n", f.Name, f.Word, start, end)
+	case packWord:
+		fmt.Fprintf(buf, "v.%s = w[%d]\n", f.Name, f.Word)
+	case packU256:
+		fmt.Fprintf(buf, "v.%s = stygos.U256FromWord(w[%d])\n", f.Name, f.Word)
+	}
+}
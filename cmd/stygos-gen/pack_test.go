@@ -0,0 +1,95 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const packSource = `package main
+
+import "github.com/rafaelescrich/stygos"
+
+type Position struct {
+	Owner    stygos.Address
+	Active   bool
+	Frozen   bool
+	Nonce    uint32
+	Amount   stygos.U256
+	Tag      [4]byte
+	Memo     []byte ` + "`stygos:\"-\"`" + `
+}
+
+type Bad struct {
+	Name string
+}
+`
+
+func parsePackSource(t *testing.T, name string) *ast.StructType {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "src.go", packSource, 0)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	obj := f.Scope.Lookup(name)
+	return obj.Decl.(*ast.TypeSpec).Type.(*ast.StructType)
+}
+
+func TestPackLayout(t *testing.T) {
+	fields, err := packLayout("Position", parsePackSource(t, "Position"))
+	if err != nil {
+		t.Fatalf("packLayout failed: %v", err)
+	}
+
+	want := []struct {
+		name   string
+		word   int
+		offset int
+	}{
+		{"Owner", 0, 0},
+		{"Active", 0, 160}, // bools share a byte, one bit each
+		{"Frozen", 0, 161},
+		{"Nonce", 0, 168}, // byte aligned after the bools
+		{"Amount", 1, 0},  // a full word starts a new slot
+		{"Tag", 2, 0},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d (Memo must be skipped)", len(fields), len(want))
+	}
+	for i, w := range want {
+		f := fields[i]
+		if f.Name != w.name || f.Word != w.word || f.Offset != w.offset {
+			t.Errorf("field %d = %s word %d offset %d, want %s word %d offset %d",
+				i, f.Name, f.Word, f.Offset, w.name, w.word, w.offset)
+		}
+	}
+
+	if _, err := packLayout("Bad", parsePackSource(t, "Bad")); err == nil {
+		t.Errorf("packLayout accepted a string field")
+	}
+}
+
+func TestGeneratePack(t *testing.T) {
+	fields, err := packLayout("Position", parsePackSource(t, "Position"))
+	if err != nil {
+		t.Fatalf("packLayout failed: %v", err)
+	}
+
+	src := generatePack("main", []string{"Position"}, [][]packField{fields})
+	if _, err := parser.ParseFile(token.NewFileSet(), "position_pack_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"const PositionPackedWords = 3",
+		"w[0][11] |= 1 << 0", // Active
+		"w[0][11] |= 1 << 1", // Frozen
+		"binary.BigEndian.PutUint32(w[0][7:11], v.Nonce)",
+		"v.Amount = stygos.U256FromWord(w[1])",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+}
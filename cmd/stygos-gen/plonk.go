@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/rafaelescrich/stygos/bn254"
+)
+
+// runPlonk implements `stygos-gen plonk`, the PLONK counterpart of
+// `stygos-gen groth16`: it emits the key of a snarkjs PLONK circuit as a
+// bn254.PlonkVerifyingKey and a handler for the verifyProof function of
+// the Solidity verifier snarkjs exports for it.
+func runPlonk(args []string) error {
+	fs := flag.NewFlagSet("plonk", flag.ContinueOnError)
+	vkFile := fs.String("vk", "verification_key.json", "snarkjs verification key")
+	output := fs.String("o", "verifier_gen.go", "output file")
+	dir := fs.String("dir", ".", "package directory")
+	contract := fs.Bool("contract", false, "also emit the router, main and entrypoint of a verifier contract")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	vkJSON, err := os.ReadFile(*vkFile)
+	if err != nil {
+		return err
+	}
+	pkg, err := packageName(*dir)
+	if err != nil {
+		return err
+	}
+	if *contract && pkg != "main" {
+		return fmt.Errorf("plonk: -contract needs package main, not %s", pkg)
+	}
+	src, err := generatePlonk(pkg, filepath.Base(*vkFile), vkJSON, *contract)
+	if err != nil {
+		return err
+	}
+	return writeSource(*output, src)
+}
+
+// generatePlonk renders the verifier file for package pkg from the PLONK
+// key read from source.
+func generatePlonk(pkg, source string, vkJSON []byte, contract bool) ([]byte, error) {
+	var vk snarkjsKey
+	if err := json.Unmarshal(vkJSON, &vk); err != nil {
+		return nil, fmt.Errorf("plonk: parsing %s: %v", source, err)
+	}
+	switch {
+	case vk.Protocol != "plonk":
+		return nil, fmt.Errorf("plonk: %s has protocol %q, want plonk", source, vk.Protocol)
+	case vk.Curve != "bn128" && vk.Curve != "bn254":
+		return nil, fmt.Errorf("plonk: %s is over %s; only bn128 keys are supported", source, vk.Curve)
+	case vk.Power < 1 || vk.Power > 28:
+		return nil, fmt.Errorf("plonk: %s has a domain of 2^%d points", source, vk.Power)
+	case vk.NPublic < 0 || vk.NPublic >= 1<<vk.Power:
+		return nil, fmt.Errorf("plonk: %s has %d public inputs for 2^%d rows", source, vk.NPublic, vk.Power)
+	}
+
+	var key bytes.Buffer
+	fmt.Fprintf(&key, "NPublic: %d,\nPower: %d,\n", vk.NPublic, vk.Power)
+	for _, f := range []struct{ field, name, v string }{{"K1", "k1", vk.K1}, {"K2", "k2", vk.K2}, {"W", "w", vk.W}} {
+		n, ok := new(big.Int).SetString(f.v, 10)
+		if !ok || n.Sign() < 0 || n.Cmp(bn254.R) >= 0 {
+			return nil, fmt.Errorf("plonk: %s: %q is not a scalar", f.name, f.v)
+		}
+		var w [32]byte
+		n.FillBytes(w[:])
+		fmt.Fprintf(&key, "%s: %s,\n", f.field, wordLiteral(w))
+	}
+	for _, f := range []struct {
+		name string
+		p    []string
+	}{{"Qm", vk.Qm}, {"Ql", vk.Ql}, {"Qr", vk.Qr}, {"Qo", vk.Qo}, {"Qc", vk.Qc}, {"S1", vk.S1}, {"S2", vk.S2}, {"S3", vk.S3}} {
+		lit, err := g1Literal(f.p)
+		if err != nil {
+			return nil, fmt.Errorf("plonk: %s: %v", f.name, err)
+		}
+		fmt.Fprintf(&key, "%s: %s,\n", f.name, lit)
+	}
+	lit, err := g2Literal(vk.X2)
+	if err != nil {
+		return nil, fmt.Errorf("plonk: X_2: %v", err)
+	}
+	fmt.Fprintf(&key, "X2: %s,\n", lit)
+
+	signature := fmt.Sprintf("verifyProof(uint256[24],uint256[%d])", vk.NPublic)
+	sel := selectorOf(signature)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "plonk")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import (\n\"github.com/rafaelescrich/stygos\"\n\"github.com/rafaelescrich/stygos/bn254\"\n)\n\n")
+
+	fmt.Fprintf(&buf, "// verifyingKey is the PLONK verifying key read from %s.\n", source)
+	fmt.Fprintf(&buf, "var verifyingKey = &bn254.PlonkVerifyingKey{\n%s}\n\n", key.String())
+
+	fmt.Fprintf(&buf, "// selVerifyProof is %s.\n", signature)
+	fmt.Fprintf(&buf, "var selVerifyProof = stygos.Selector{0x%02x, 0x%02x, 0x%02x, 0x%02x}\n\n", sel[0], sel[1], sel[2], sel[3])
+
+	fmt.Fprintf(&buf, "// publicInputs is the number of public inputs of verifyProof.\nconst publicInputs = %d\n\n", vk.NPublic)
+
+	buf.WriteString(`// handleVerifyProof returns whether the proof in args is valid for the
+// public inputs after it, as an ABI encoded bool. Like a snarkjs verifier
+// it returns false for evaluations and inputs outside the scalar field and
+// for points off the curve.
+func handleVerifyProof(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != bn254.PlonkProofLength+32*publicInputs {
+		return nil, stygos.ErrInvalidInput
+	}
+	proof, _ := bn254.PlonkProofFromBytes(args[:bn254.PlonkProofLength])
+	inputs := make([]stygos.Word, publicInputs)
+	for i := range inputs {
+		copy(inputs[i][:], args[bn254.PlonkProofLength+32*i:])
+	}
+	var out stygos.Word
+	if ok, err := bn254.VerifyPlonk(verifyingKey, &proof, inputs); ok && err == nil {
+		out[31] = 1
+	}
+	return out[:], nil
+}
+`)
+
+	if contract {
+		buf.WriteString(contractSource)
+	}
+	return buf.Bytes(), nil
+}
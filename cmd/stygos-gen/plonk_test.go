@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func testPlonkKey(protocol string, nPublic int, power uint, w string, qm string) []byte {
+	return []byte(fmt.Sprintf(`{"protocol": %q, "curve": "bn128", "nPublic": %d, "power": %d,
+		"k1": "2", "k2": "3", "Qm": %s, "Ql": %[5]s, "Qr": %[5]s, "Qo": %[5]s, "Qc": %[5]s,
+		"S1": %[5]s, "S2": %[5]s, "S3": %[5]s, "X_2": %[6]s, "w": %[7]q}`,
+		protocol, nPublic, power, qm, testG1, testG2, w))
+}
+
+func TestGeneratePlonk(t *testing.T) {
+	src, err := generatePlonk("main", "verification_key.json", testPlonkKey("plonk", 2, 3, "19540430494807482326159819597004422086093766032135589407132600596362845576832", testG1), true)
+	if err != nil {
+		t.Fatalf("generatePlonk failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "verifier_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		"var verifyingKey = &bn254.PlonkVerifyingKey{",
+		"// selVerifyProof is verifyProof(uint256[24],uint256[2]).",
+		"const publicInputs = 2",
+		"bn254.VerifyPlonk(verifyingKey, &proof, inputs)",
+		"r.HandleSelector(selVerifyProof, handleVerifyProof)",
+		"func entrypoint() int32 {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source lacks %q:\n%s", want, src)
+		}
+	}
+
+	src, err = generatePlonk("verifier", "verification_key.json", testPlonkKey("plonk", 0, 1, "1", testG1), false)
+	if err != nil {
+		t.Fatalf("generatePlonk failed: %v", err)
+	}
+	if strings.Contains(string(src), "func entrypoint") {
+		t.Errorf("generated source has an entrypoint without -contract:\n%s", src)
+	}
+
+	for name, bad := range map[string][]byte{
+		"groth16":    testKey("groth16", 1, testG1, testG1),
+		"power":      testPlonkKey("plonk", 1, 0, "1", testG1),
+		"inputs":     testPlonkKey("plonk", 4, 2, "1", testG1),
+		"w over R":   testPlonkKey("plonk", 1, 2, "21888242871839275222246405745257275088548364400416034343698204186575808495617", testG1),
+		"projective": testPlonkKey("plonk", 1, 2, "1", `["1", "2", "3"]`),
+	} {
+		if _, err := generatePlonk("main", "verification_key.json", bad, false); err == nil {
+			t.Errorf("generatePlonk(%s) succeeded, want error", name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// slotDecl is a single `ident=preimage` argument of the slots mode.
+type slotDecl struct {
+	Ident    string
+	Preimage string
+}
+
+// runSlots implements `stygos-gen slots`. Every argument has the form
+// ident=preimage and becomes a package-level variable holding
+// keccak256(preimage), the same key storage.ConstSlot(preimage) derives at
+// runtime.
+func runSlots(args []string) error {
+	fs := flag.NewFlagSet("slots", flag.ContinueOnError)
+	output := fs.String("o", "slots_gen.go", "output file")
+	dir := fs.String("dir", ".", "package directory")
+	layout := fs.String("layout", "", "also declare this identifier holding the storage.LayoutHash of the slots")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("slots: no slots given, want ident=preimage arguments")
+	}
+
+	decls, err := parseSlotDecls(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	pkg, err := packageName(*dir)
+	if err != nil {
+		return err
+	}
+
+	if *layout != "" && !token.IsIdentifier(*layout) {
+		return fmt.Errorf("slots: %q is not a valid Go identifier", *layout)
+	}
+
+	return writeSource(*output, generateSlots(pkg, decls, *layout))
+}
+
+// parseSlotDecls parses and validates ident=preimage arguments.
+func parseSlotDecls(args []string) ([]slotDecl, error) {
+	seen := make(map[string]bool)
+	decls := make([]slotDecl, 0, len(args))
+	for _, arg := range args {
+		ident, preimage, ok := strings.Cut(arg, "=")
+		if !ok || preimage == "" {
+			return nil, fmt.Errorf("slots: malformed argument %q, want ident=preimage", arg)
+		}
+		if !token.IsIdentifier(ident) {
+			return nil, fmt.Errorf("slots: %q is not a valid Go identifier", ident)
+		}
+		if seen[ident] {
+			return nil, fmt.Errorf("slots: duplicate identifier %q", ident)
+		}
+		seen[ident] = true
+		decls = append(decls, slotDecl{Ident: ident, Preimage: preimage})
+	}
+	sort.Slice(decls, func(i, j int) bool { return decls[i].Ident < decls[j].Ident })
+	return decls, nil
+}
+
+// generateSlots renders the slots file for package pkg. A non-empty layout
+// adds a variable of that name holding the layout hash of the slots.
+func generateSlots(pkg string, decls []slotDecl, layout string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, header, "slots")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("import \"github.com/rafaelescrich/stygos\"\n\n")
+	buf.WriteString("// Precomputed storage slots, see storage.ConstSlot.\n")
+	buf.WriteString("var (\n")
+	for _, d := range decls {
+		fmt.Fprintf(&buf, "\t// %s is keccak256(%q).\n", d.Ident, d.Preimage)
+		fmt.Fprintf(&buf, "\t%s = %s\n", d.Ident, wordLiteral(keccak256([]byte(d.Preimage))))
+	}
+	if layout != "" {
+		fmt.Fprintf(&buf, "\t// %s is the storage.LayoutHash of the slots above.\n", layout)
+		fmt.Fprintf(&buf, "\t%s = %s\n", layout, wordLiteral(layoutHash(decls)))
+	}
+	buf.WriteString(")\n")
+	return buf.Bytes()
+}
+
+// layoutHash mirrors storage.LayoutHash: keccak256 of the distinct slot
+// keys, sorted and concatenated.
+func layoutHash(decls []slotDecl) [32]byte {
+	keys := make([][32]byte, len(decls))
+	for i, d := range decls {
+		keys[i] = keccak256([]byte(d.Preimage))
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+	var buf []byte
+	for i, k := range keys {
+		if i > 0 && k == keys[i-1] {
+			continue
+		}
+		buf = append(buf, k[:]...)
+	}
+	return keccak256(buf)
+}
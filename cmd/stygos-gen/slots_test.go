@@ -0,0 +1,61 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+func TestParseSlotDecls(t *testing.T) {
+	decls, err := parseSlotDecls([]string{"totalSupplyKey=totalSupply", "balancePrefix=balance"})
+	if err != nil {
+		t.Fatalf("parseSlotDecls failed: %v", err)
+	}
+	if len(decls) != 2 || decls[0].Ident != "balancePrefix" || decls[1].Preimage != "totalSupply" {
+		t.Errorf("unexpected decls %+v", decls)
+	}
+
+	for _, bad := range [][]string{
+		{"noequals"},
+		{"key="},
+		{"1key=x"},
+		{"key=a", "key=b"},
+	} {
+		if _, err := parseSlotDecls(bad); err == nil {
+			t.Errorf("parseSlotDecls(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestGenerateSlots(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	src := generateSlots("main", []slotDecl{{Ident: "counterKey", Preimage: "counter"}}, "")
+	if _, err := parser.ParseFile(token.NewFileSet(), "slots_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+
+	want := storage.ConstSlot("counter")
+	if lit := wordLiteral(want); !strings.Contains(string(src), lit) {
+		t.Errorf("generated source lacks literal for keccak256(counter):\n%s", src)
+	}
+}
+
+func TestGenerateSlotsLayout(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	decls := []slotDecl{{Ident: "ownerKey", Preimage: "owner"}, {Ident: "balancesKey", Preimage: "balances"}}
+	src := generateSlots("main", decls, "layoutHash")
+	if _, err := parser.ParseFile(token.NewFileSet(), "slots_gen.go", src, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, src)
+	}
+
+	want := storage.LayoutHash(storage.ConstSlot("balances"), storage.ConstSlot("owner"))
+	if !strings.Contains(string(src), "layoutHash = "+wordLiteral(want)) {
+		t.Errorf("generated source lacks the storage.LayoutHash of the slots:\n%s", src)
+	}
+}
@@ -0,0 +1,106 @@
+package calldata
+
+import (
+	"encoding/binary"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+var balanceKey = stygos.Word{1}
+
+func entrypoint() int32 {
+	callData, err := stygos.GetCallData()
+	if err != nil || len(callData) == 0 {
+		return 1
+	}
+	args := callData[1:]
+	switch callData[0] {
+	case 0:
+		if len(args) != 40 {
+			return 1
+		}
+		var to stygos.Address
+		copy(to[:], args[:20])
+		_ = binary.BigEndian.Uint64(args[32:40])
+	case 1:
+		// Checked for an address, read as a word
+		if len(args) < 20 {
+			return 1
+		}
+		var w stygos.Word
+		copy(w[:], args[:32]) // want "calldata args needs 32 bytes but only 20 are checked"
+	case 2:
+		_ = args[4] // want "calldata args needs 5 bytes but only 0 are checked"
+	}
+	return 0
+}
+
+func handleSet(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 20 {
+		return nil, stygos.ErrInvalidInput
+	}
+	var value stygos.Word
+	copy(value[:], args[:20]) // want "value is stored left-aligned"
+	stygos.StorageStore(balanceKey, value)
+
+	var padded stygos.Word
+	copy(padded[12:], args[:20])
+	stygos.StorageStore(balanceKey, padded)
+	return nil, nil
+}
+
+func handleWord(args []byte) ([]byte, error) {
+	if len(args) >= 32 {
+		var w stygos.Word
+		copy(w[:], args[:32])
+		stygos.StorageStore(balanceKey, w)
+	}
+	return args[:32], nil // want "calldata args needs 32 bytes but only 0 are checked"
+}
+
+func handleArray(args []byte) ([]byte, error) {
+	n := int(args[0]) // want "calldata args needs 1 bytes but only 0 are checked"
+	if len(args) < 1+32*n {
+		return nil, stygos.ErrInvalidInput
+	}
+	var sum uint64
+	for i := 0; i < n; i++ {
+		sum += binary.BigEndian.Uint64(args[1+32*i+24 : 1+32*i+32])
+	}
+	for i := range args {
+		_ = args[i]
+	}
+	return nil, nil
+}
+
+func handleUnchecked(args []byte) ([]byte, error) {
+	i := int(stygos.GetBlockNumber())
+	return args[i:], nil // want "calldata args is sliced without a length check"
+}
+
+func handleChunks(args []byte) ([]byte, error) {
+	var sum uint64
+	for len(args) > 0 {
+		var chunk [8]byte
+		n := copy(chunk[:], args)
+		args = args[n:]
+		sum += binary.BigEndian.Uint64(chunk[:])
+	}
+	return nil, nil
+}
+
+func handleConverted(args []byte) ([]byte, error) {
+	n := uint64(stygos.GetBlockNumber())
+	if uint64(len(args)) < n {
+		return nil, stygos.ErrInvalidInput
+	}
+	return args[:n], nil
+}
+
+// allowedLeftAligned is skipped: the test allows it with -calldata.allow.
+func allowedLeftAligned(args []byte) ([]byte, error) {
+	var w stygos.Word
+	copy(w[:], args)
+	stygos.StorageStore(balanceKey, w)
+	return nil, nil
+}
@@ -0,0 +1,137 @@
+package reentrancy
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	balances = storage.NewMapping(storage.ConstSlot("balances"), storage.Addresses, storage.Word(storage.U256s))
+	totalKey = storage.ConstSlot("total")
+	tok      = token.NewERC20(stygos.Address{0x70})
+)
+
+func setBalance(a stygos.Address, v stygos.U256) {
+	_ = balances.Set(a, v)
+}
+
+// withdrawUnsafe pays before clearing the balance.
+func withdrawUnsafe(args []byte) ([]byte, error) {
+	sender := stygos.GetMsgSender()
+	balance, _ := balances.Get(sender)
+	if err := stygos.Transfer(sender, balance); err != nil {
+		return nil, err
+	}
+	setBalance(sender, stygos.U256{}) // want "reentrancy.setBalance writes storage after the call to stygos.Transfer"
+	return nil, nil
+}
+
+// withdraw clears the balance first.
+func withdraw(args []byte) ([]byte, error) {
+	sender := stygos.GetMsgSender()
+	balance, _ := balances.Get(sender)
+	setBalance(sender, stygos.U256{})
+	return nil, stygos.Transfer(sender, balance)
+}
+
+// payout goes through a helper and the token client.
+func payout(to stygos.Address, amount stygos.U256) error {
+	return token.SafeTransfer(tok, to, amount)
+}
+
+func claim(args []byte) ([]byte, error) {
+	sender := stygos.GetMsgSender()
+	if err := payout(sender, stygos.NewU256(1)); err != nil {
+		return nil, err
+	}
+	stygos.StorageStore(totalKey, stygos.Word{}) // want "stygos.StorageStore writes storage after the call to reentrancy.payout"
+	return nil, nil
+}
+
+// distribute writes before calling out, but in a loop.
+func distribute(args []byte) ([]byte, error) {
+	for i := 0; i < 3; i++ {
+		to := stygos.Address{byte(i)}
+		balances.Set(to, stygos.U256{}) // want "storage.Mapping.Set writes storage after the call to token.ERC20.Transfer"
+		if err := tok.Transfer(to, stygos.NewU256(1)); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// The argument is computed by the call, then stored.
+func stored(args []byte) ([]byte, error) {
+	stygos.StorageStore(totalKey, word(stygos.Call(stygos.Address{0x01}, stygos.Word{}, nil))) // want "stygos.StorageStore writes storage after the call to stygos.Call"
+	return nil, nil
+}
+
+func word(out []byte, err error) stygos.Word {
+	var w stygos.Word
+	copy(w[:], out)
+	return w
+}
+
+// refundOnFailure restores the balance only when the transfer failed.
+func refundOnFailure(args []byte) ([]byte, error) {
+	sender := stygos.GetMsgSender()
+	balance, _ := balances.Get(sender)
+	setBalance(sender, stygos.U256{})
+	if err := stygos.Transfer(sender, balance); err != nil {
+		setBalance(sender, balance)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// allowedRefund is skipped: the test allows it with -reentrancy.allow.
+func allowedRefund(args []byte) ([]byte, error) {
+	stygos.Transfer(stygos.GetMsgSender(), stygos.NewU256(1))
+	stygos.StorageStore(totalKey, stygos.Word{})
+	return nil, nil
+}
+
+// dispatch runs one case per call, so the cases do not follow each other.
+func dispatch(args []byte) ([]byte, error) {
+	switch len(args) {
+	case 0:
+		return withdraw(args)
+	case 1:
+		stygos.StorageStore(totalKey, stygos.Word{})
+	}
+	return nil, nil
+}
+
+// offsets calls a method of its parameter's type, which writes nothing,
+// although other methods of the name do.
+func offsets(base stygos.Word) ([]byte, error) {
+	if err := payout(stygos.Address{}, stygos.NewU256(1)); err != nil {
+		return nil, err
+	}
+	slot := storage.Offset(base, 1)
+	return slot[:], nil
+}
+
+// escrow pays in one branch and writes in the other, and only the branch
+// that pays without returning reaches the writes after the if.
+func escrow(args []byte) ([]byte, error) {
+	sender := stygos.GetMsgSender()
+	if len(args) == 0 {
+		return nil, payout(sender, stygos.NewU256(1))
+	} else {
+		setBalance(sender, stygos.U256{})
+	}
+	if len(args) == 1 {
+		if err := payout(sender, stygos.NewU256(2)); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	setBalance(sender, stygos.NewU256(1))
+	if len(args) == 2 {
+		payout(sender, stygos.NewU256(3))
+	}
+	setBalance(sender, stygos.NewU256(2)) // want "reentrancy.setBalance writes storage after the call to reentrancy.payout"
+	return nil, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateTS(t *testing.T) {
+	src, err := generateTS("Token", "viem", []byte(testABI))
+	if err != nil {
+		t.Fatalf("generateTS failed: %v", err)
+	}
+	for _, want := range []string{
+		`import { getContract, type Address, type PublicClient, type WalletClient } from "viem";`,
+		"export const tokenAbi = [\n",
+		`"name": "balanceOf",`,
+		"] as const;",
+		"export function getToken(address: Address, client: { public: PublicClient; wallet?: WalletClient }) {",
+		"return getContract({ address, abi: tokenAbi, client });",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated viem module lacks %q:\n%s", want, src)
+		}
+	}
+
+	src, err = generateTS("Token", "ethers", []byte(testABI))
+	if err != nil {
+		t.Fatalf("generateTS failed: %v", err)
+	}
+	for _, want := range []string{
+		"export interface Token {",
+		"  balanceOf(owner: string): Promise<bigint>;",
+		`  "transfer(address,uint256)"(to: string, amount: BigNumberish, overrides?: Overrides): Promise<ContractTransactionResponse>;`,
+		`  "transfer(address,uint256,bytes)"(to: string, amount: BigNumberish, data: BytesLike, overrides?: Overrides): Promise<ContractTransactionResponse>;`,
+		"  roots(arg0: BigNumberish): Promise<Result>;",
+		"export function connectToken(address: string, runner: ContractRunner): Token {",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated ethers module lacks %q:\n%s", want, src)
+		}
+	}
+
+	src, err = generateTS("Token", "none", []byte(testABI))
+	if err != nil || strings.Contains(string(src), "import") {
+		t.Errorf("generateTS failed. Expected a bare ABI module, got %v:\n%s", err, src)
+	}
+	if _, err := generateTS("Token", "web3", []byte(testABI)); err == nil {
+		t.Error("generateTS succeeded with an unknown library, want error")
+	}
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// analyzer is a vet check. It mirrors the shape of a go/analysis Analyzer,
+// which this module does not depend on, so checks can move to one later.
+type analyzer struct {
+	Name  string
+	Doc   string
+	Flags flag.FlagSet // registered as -<Name>.<flag>
+	Run   func(*pass)
+}
+
+// pass is the package an analyzer runs on.
+type pass struct {
+	Fset      *token.FileSet
+	Files     []*ast.File
+	Pkg       *types.Package
+	TypesInfo *types.Info
+	Dir       string
+	Effects   *effectsCache
+
+	diagnostics *[]diagnostic
+	check       string
+}
+
+// vetEnv is shared by the packages of one vet run.
+type vetEnv struct {
+	fset    *token.FileSet
+	imp     types.Importer
+	effects *effectsCache
+}
+
+func newVetEnv() *vetEnv {
+	fset := token.NewFileSet()
+	// One importer type-checks each dependency once for all packages
+	return &vetEnv{fset: fset, imp: importer.ForCompiler(fset, "source", nil), effects: newEffectsCache()}
+}
+
+// diagnostic is a problem reported by an analyzer.
+type diagnostic struct {
+	Pos     token.Position
+	Check   string
+	Message string
+}
+
+func (d diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Pos, d.Message, d.Check)
+}
+
+// Reportf reports a problem at pos.
+func (p *pass) Reportf(pos token.Pos, format string, args ...any) {
+	*p.diagnostics = append(*p.diagnostics, diagnostic{
+		Pos:     p.Fset.Position(pos),
+		Check:   p.check,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// analyzers are the checks of `stygos-gen vet`, by name.
+var analyzers = []*analyzer{calldataAnalyzer, reentrancyAnalyzer}
+
+// runVet implements `stygos-gen vet`. It type-checks each package
+// directory given, ./... style patterns included, runs the selected checks
+// and prints what they find. It fails if anything was found.
+func runVet(args []string) error {
+	fs := flag.NewFlagSet("vet", flag.ContinueOnError)
+	checks := fs.String("checks", "", "comma-separated checks to run (default all)")
+	for _, a := range analyzers {
+		prefix := a.Name + "."
+		a.Flags.VisitAll(func(f *flag.Flag) {
+			fs.Var(f.Value, prefix+f.Name, f.Usage)
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	selected := analyzers
+	if *checks != "" {
+		selected = nil
+		for _, name := range strings.Split(*checks, ",") {
+			a := findAnalyzer(strings.TrimSpace(name))
+			if a == nil {
+				return fmt.Errorf("vet: unknown check %q", name)
+			}
+			selected = append(selected, a)
+		}
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	dirs, err := expandDirs(patterns)
+	if err != nil {
+		return err
+	}
+
+	env := newVetEnv()
+	var diags []diagnostic
+	for _, dir := range dirs {
+		found, err := env.vetDir(dir, selected)
+		if err != nil {
+			return err
+		}
+		diags = append(diags, found...)
+	}
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if len(diags) > 0 {
+		return fmt.Errorf("vet: %d problem(s) found", len(diags))
+	}
+	return nil
+}
+
+func findAnalyzer(name string) *analyzer {
+	for _, a := range analyzers {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// expandDirs resolves dir and dir/... patterns to the directories holding
+// Go files, skipping testdata and hidden directories.
+func expandDirs(patterns []string) ([]string, error) {
+	var dirs []string
+	for _, p := range patterns {
+		root := strings.TrimSuffix(p, "/...")
+		if root == p {
+			dirs = append(dirs, p)
+			continue
+		}
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return err
+			}
+			if name := d.Name(); path != root && (name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if matches, _ := filepath.Glob(filepath.Join(path, "*.go")); len(matches) > 0 {
+				dirs = append(dirs, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// vetDir runs the analyzers on the package in dir, as built for the host.
+// Type errors, such as imports that do not resolve, are tolerated: the
+// checks fall back to what the syntax tells them.
+func (env *vetEnv) vetDir(dir string, selected []*analyzer) ([]diagnostic, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		if _, ok := err.(*build.NoGoError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(env.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	conf := types.Config{
+		Importer: env.imp,
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(importPath(dir), env.fset, files, info)
+
+	var diags []diagnostic
+	for _, a := range selected {
+		a.Run(&pass{
+			Fset:        env.fset,
+			Files:       files,
+			Pkg:         pkg,
+			TypesInfo:   info,
+			Dir:         dir,
+			Effects:     env.effects,
+			diagnostics: &diags,
+			check:       a.Name,
+		})
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Pos, diags[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return diags, nil
+}
+
+// importPath returns the import path of the package in dir, from the
+// go.mod of its module. go/build gives every directory the path ".", under
+// which the packages of one run would share their effects.
+func importPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			mod := modulePath(data)
+			if mod == "" {
+				return abs
+			}
+			rel, _ := filepath.Rel(root, abs)
+			if rel == "." {
+				return mod
+			}
+			return mod + "/" + filepath.ToSlash(rel)
+		}
+		if filepath.Dir(root) == root {
+			return abs
+		}
+	}
+}
+
+// modulePath returns the module path declared in a go.mod file.
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if f := strings.Fields(line); len(f) >= 2 && f[0] == "module" {
+			return strings.Trim(f[1], `"`)
+		}
+	}
+	return ""
+}
+
+// constInt returns the value of e if it is an integer constant.
+func (p *pass) constInt(e ast.Expr) (int64, bool) {
+	if tv, ok := p.TypesInfo.Types[e]; ok && tv.Value != nil {
+		if v, ok := constant.Int64Val(constant.ToInt(tv.Value)); ok {
+			return v, true
+		}
+		return 0, false
+	}
+	if lit, ok := unparen(e).(*ast.BasicLit); ok && lit.Kind == token.INT {
+		v := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+		if n, ok := constant.Int64Val(v); ok {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// object returns the variable an identifier refers to, or nil.
+func (p *pass) object(e ast.Expr) types.Object {
+	id, ok := unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if obj := p.TypesInfo.Uses[id]; obj != nil {
+		return obj
+	}
+	return p.TypesInfo.Defs[id]
+}
+
+// calleeName returns the name of the function call invokes, without its
+// package or receiver.
+func calleeName(call *ast.CallExpr) string {
+	switch fn := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	}
+	return ""
+}
+
+// terminates reports whether a block always leaves the enclosing
+// statements, by returning, panicking, or jumping.
+func terminates(block *ast.BlockStmt) bool {
+	if block == nil || len(block.List) == 0 {
+		return false
+	}
+	switch s := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		return ok && calleeName(call) == "panic"
+	case *ast.BlockStmt:
+		return terminates(s)
+	}
+	return false
+}
+
+// unparen strips the parentheses around e.
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
@@ -0,0 +1,641 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// calldataAnalyzer finds calldata read past the length the contract
+// checked, and words filled from shorter slices that are then written to
+// storage.
+var calldataAnalyzer = &analyzer{
+	Name: "calldata",
+	Doc: `check calldata bounds and storage word padding
+
+Calldata is the slice returned by stygos.GetCallData, the argument of
+router handlers and mock contracts, shaped func(*stygos.Ctx, []byte)
+([]byte, error) and func([]byte) ([]byte, error), and any slice taken
+from them. Every constant index or slice bound must be covered by a length
+check earlier in the function, such as
+
+	if len(args) < 64 {
+		return nil, stygos.ErrInvalidInput
+	}
+
+and a variable index needs some check of the length at all. A word that
+copy(w[:], s) fills from a slice not known to hold 32 bytes is
+left-aligned, so storing it with StorageStore saves a different value than
+a padded one: use stygos.PadAddress or copy into the tail of the word.
+
+-calldata.allow takes a comma-separated list of functions to skip, as
+name or Type.Method, such as one storing byte strings left-aligned on
+purpose.`,
+	Run: runCalldata,
+}
+
+// calldataAllow lists the functions the calldata check skips, set with
+// -calldata.allow.
+var calldataAllow = &stringList{}
+
+func init() {
+	calldataAnalyzer.Flags.Var(calldataAllow, "allow", "functions to skip, comma-separated")
+}
+
+// lenFact is what a function knows about the length of a calldata slice.
+type lenFact struct {
+	min     int64 // bytes known to be present
+	exact   int64 // the exact length, or -1
+	checked bool  // compared against a length that is not constant
+}
+
+type lenFacts map[types.Object]lenFact
+
+func (f lenFacts) clone() lenFacts {
+	c := make(lenFacts, len(f))
+	for k, v := range f {
+		c[k] = v
+	}
+	return c
+}
+
+// calldataWalker checks one function body.
+type calldataWalker struct {
+	pass *pass
+	// words filled from slices that may be short, by variable
+	unpadded map[types.Object]*ast.CallExpr
+	// counts returned by copy, by variable, and the slices copied from
+	copied map[types.Object]types.Object
+}
+
+func runCalldata(p *pass) {
+	for _, f := range p.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var typ *ast.FuncType
+			var body *ast.BlockStmt
+			switch fn := n.(type) {
+			case *ast.FuncDecl:
+				if calldataAllow.has(funcKey(fn)) {
+					return false
+				}
+				typ, body = fn.Type, fn.Body
+			case *ast.FuncLit:
+				typ, body = fn.Type, fn.Body
+			default:
+				return true
+			}
+			if body == nil {
+				return false
+			}
+			facts := make(lenFacts)
+			if param := handlerParam(p, typ); param != nil {
+				facts[param] = lenFact{exact: -1}
+			}
+			w := &calldataWalker{
+				pass:     p,
+				unpadded: make(map[types.Object]*ast.CallExpr),
+				copied:   make(map[types.Object]types.Object),
+			}
+			w.stmts(body.List, facts)
+			// Function literals are checked on their own
+			return true
+		})
+	}
+}
+
+// handlerParam returns the calldata parameter of a func([]byte) ([]byte,
+// error), the shape of mock contracts, or of a func(*stygos.Ctx, []byte)
+// ([]byte, error), the shape of router handlers.
+func handlerParam(p *pass, typ *ast.FuncType) types.Object {
+	if typ.Params == nil || typ.Results == nil || len(typ.Results.List) != 2 {
+		return nil
+	}
+	params := typ.Params.List
+	if len(params) == 2 && len(params[0].Names) <= 1 && isCtx(params[0].Type) {
+		params = params[1:]
+	}
+	if len(params) != 1 || len(params[0].Names) != 1 {
+		return nil
+	}
+	if !isByteSlice(params[0].Type) || !isByteSlice(typ.Results.List[0].Type) {
+		return nil
+	}
+	if id, ok := typ.Results.List[1].Type.(*ast.Ident); !ok || id.Name != "error" {
+		return nil
+	}
+	return p.TypesInfo.Defs[params[0].Names[0]]
+}
+
+// isCtx reports whether e is *stygos.Ctx, or *Ctx within stygos.
+func isCtx(e ast.Expr) bool {
+	star, ok := e.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	switch x := star.X.(type) {
+	case *ast.Ident:
+		return x.Name == "Ctx"
+	case *ast.SelectorExpr:
+		return x.Sel.Name == "Ctx"
+	}
+	return false
+}
+
+func isByteSlice(e ast.Expr) bool {
+	at, ok := e.(*ast.ArrayType)
+	if !ok || at.Len != nil {
+		return false
+	}
+	id, ok := at.Elt.(*ast.Ident)
+	return ok && (id.Name == "byte" || id.Name == "uint8")
+}
+
+// stmts checks a statement list in order, updating facts as length checks
+// and assignments are met.
+func (w *calldataWalker) stmts(list []ast.Stmt, facts lenFacts) {
+	for _, s := range list {
+		w.stmt(s, facts)
+	}
+}
+
+func (w *calldataWalker) stmt(s ast.Stmt, facts lenFacts) {
+	switch s := s.(type) {
+	case *ast.BlockStmt:
+		w.stmts(s.List, facts)
+	case *ast.LabeledStmt:
+		w.stmt(s.Stmt, facts)
+	case *ast.AssignStmt:
+		for _, e := range s.Rhs {
+			w.expr(e, facts)
+		}
+		for _, e := range s.Lhs {
+			w.expr(e, facts)
+		}
+		w.assign(s.Lhs, s.Rhs, facts)
+	case *ast.DeclStmt:
+		gd, ok := s.Decl.(*ast.GenDecl)
+		if !ok {
+			return
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for _, e := range vs.Values {
+				w.expr(e, facts)
+			}
+			lhs := make([]ast.Expr, len(vs.Names))
+			for i, n := range vs.Names {
+				lhs[i] = n
+			}
+			if len(vs.Values) > 0 {
+				w.assign(lhs, vs.Values, facts)
+			}
+		}
+	case *ast.IfStmt:
+		if s.Init != nil {
+			w.stmt(s.Init, facts)
+		}
+		w.expr(s.Cond, facts)
+		then := facts.clone()
+		w.apply(s.Cond, true, then)
+		w.stmts(s.Body.List, then)
+		otherwise := facts.clone()
+		w.apply(s.Cond, false, otherwise)
+		if s.Else != nil {
+			w.stmt(s.Else, otherwise)
+		}
+		switch {
+		case terminates(s.Body) && (s.Else == nil || !elseTerminates(s.Else)):
+			replace(facts, otherwise)
+		case s.Else != nil && elseTerminates(s.Else) && !terminates(s.Body):
+			replace(facts, then)
+		}
+	case *ast.SwitchStmt:
+		if s.Init != nil {
+			w.stmt(s.Init, facts)
+		}
+		if s.Tag != nil {
+			w.expr(s.Tag, facts)
+		}
+		for _, c := range s.Body.List {
+			cc := c.(*ast.CaseClause)
+			branch := facts.clone()
+			for _, e := range cc.List {
+				w.expr(e, branch)
+				if s.Tag == nil && len(cc.List) == 1 {
+					w.apply(e, true, branch)
+				}
+			}
+			w.stmts(cc.Body, branch)
+		}
+	case *ast.TypeSwitchStmt:
+		if s.Init != nil {
+			w.stmt(s.Init, facts)
+		}
+		for _, c := range s.Body.List {
+			w.stmts(c.(*ast.CaseClause).Body, facts.clone())
+		}
+	case *ast.ForStmt:
+		if s.Init != nil {
+			w.stmt(s.Init, facts)
+		}
+		body := facts.clone()
+		if s.Cond != nil {
+			w.expr(s.Cond, facts)
+			w.apply(s.Cond, true, body)
+		}
+		w.stmts(s.Body.List, body)
+		if s.Post != nil {
+			w.stmt(s.Post, body)
+		}
+	case *ast.RangeStmt:
+		w.expr(s.X, facts)
+		body := facts.clone()
+		// Ranging over calldata bounds the index by its length
+		if obj := w.pass.object(s.X); obj != nil {
+			if f, ok := body[obj]; ok {
+				f.checked = true
+				body[obj] = f
+			}
+		}
+		w.stmts(s.Body.List, body)
+	case *ast.ExprStmt:
+		w.expr(s.X, facts)
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			w.call(call, facts)
+		}
+	case *ast.ReturnStmt:
+		for _, e := range s.Results {
+			w.expr(e, facts)
+		}
+	case *ast.DeferStmt:
+		w.expr(s.Call, facts)
+	case *ast.GoStmt:
+		w.expr(s.Call, facts)
+	case *ast.IncDecStmt:
+		w.expr(s.X, facts)
+	case *ast.SendStmt:
+		w.expr(s.Chan, facts)
+		w.expr(s.Value, facts)
+	}
+}
+
+func elseTerminates(s ast.Stmt) bool {
+	switch s := s.(type) {
+	case *ast.BlockStmt:
+		return terminates(s)
+	case *ast.IfStmt:
+		return terminates(s.Body) && s.Else != nil && elseTerminates(s.Else)
+	}
+	return false
+}
+
+// replace makes dst hold the facts of src.
+func replace(dst, src lenFacts) {
+	for k := range dst {
+		delete(dst, k)
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+}
+
+// assign tracks calldata through assignments: GetCallData results, copies
+// of calldata slices and subslices of them. Other assignments to a tracked
+// variable stop its tracking.
+func (w *calldataWalker) assign(lhs, rhs []ast.Expr, facts lenFacts) {
+	if len(rhs) == 1 && len(lhs) >= 1 {
+		if call, ok := unparen(rhs[0]).(*ast.CallExpr); ok && calleeName(call) == "GetCallData" {
+			if obj := w.pass.object(lhs[0]); obj != nil {
+				facts[obj] = lenFact{exact: -1}
+			}
+			return
+		}
+	}
+	for _, e := range lhs {
+		if obj := w.pass.object(e); obj != nil {
+			delete(w.copied, obj)
+		}
+	}
+	if len(rhs) == 1 && len(lhs) == 1 {
+		if call, ok := unparen(rhs[0]).(*ast.CallExpr); ok && calleeName(call) == "copy" && len(call.Args) == 2 {
+			if obj, src := w.pass.object(lhs[0]), w.pass.object(call.Args[1]); obj != nil && src != nil {
+				w.copied[obj] = src
+			}
+		}
+	}
+	if len(lhs) != len(rhs) {
+		for _, e := range lhs {
+			if obj := w.pass.object(e); obj != nil {
+				delete(facts, obj)
+			}
+		}
+		return
+	}
+	for i, e := range lhs {
+		obj := w.pass.object(e)
+		if obj == nil {
+			continue
+		}
+		if f, ok := w.derive(rhs[i], facts); ok {
+			facts[obj] = f
+		} else {
+			delete(facts, obj)
+		}
+	}
+}
+
+// derive returns the length facts of an expression built from calldata:
+// a tracked variable, or a subslice of one with constant bounds.
+func (w *calldataWalker) derive(e ast.Expr, facts lenFacts) (lenFact, bool) {
+	e = unparen(e)
+	if obj := w.pass.object(e); obj != nil {
+		f, ok := facts[obj]
+		return f, ok
+	}
+	se, ok := e.(*ast.SliceExpr)
+	if !ok {
+		return lenFact{}, false
+	}
+	base, ok := w.derive(se.X, facts)
+	if !ok {
+		return lenFact{}, false
+	}
+	lo := int64(0)
+	if se.Low != nil {
+		if lo, ok = w.pass.constInt(se.Low); !ok {
+			return lenFact{exact: -1, checked: base.checked}, true
+		}
+	}
+	if se.High != nil {
+		hi, ok := w.pass.constInt(se.High)
+		if !ok {
+			return lenFact{exact: -1, checked: base.checked}, true
+		}
+		return lenFact{min: hi - lo, exact: hi - lo, checked: base.checked}, true
+	}
+	f := lenFact{min: base.min - lo, exact: -1, checked: base.checked}
+	if f.min < 0 {
+		f.min = 0
+	}
+	if base.exact >= 0 {
+		f.exact = base.exact - lo
+	}
+	return f, true
+}
+
+// expr checks the calldata accesses in e. The right operand of && sees
+// the facts of the left one, as the code only reaches it when they hold.
+func (w *calldataWalker) expr(e ast.Expr, facts lenFacts) {
+	if e == nil {
+		return
+	}
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BinaryExpr:
+			if n.Op != token.LAND && n.Op != token.LOR {
+				return true
+			}
+			w.expr(n.X, facts)
+			right := facts.clone()
+			w.apply(n.X, n.Op == token.LAND, right)
+			w.expr(n.Y, right)
+			return false
+		case *ast.IndexExpr:
+			obj := w.pass.object(n.X)
+			f, ok := facts[obj]
+			if obj == nil || !ok {
+				return true
+			}
+			if i, ok := w.pass.constInt(n.Index); ok {
+				w.need(n, obj, i+1, f)
+			} else if !f.checked && f.min == 0 {
+				w.pass.Reportf(n.Pos(), "calldata %s is indexed without a length check", obj.Name())
+			}
+		case *ast.SliceExpr:
+			obj := w.pass.object(n.X)
+			f, ok := facts[obj]
+			if obj == nil || !ok {
+				return true
+			}
+			need, constant := int64(0), true
+			for _, b := range []ast.Expr{n.Low, n.High, n.Max} {
+				if b == nil {
+					continue
+				}
+				v, ok := w.pass.constInt(b)
+				if !ok {
+					// What copy took from a slice is within it
+					if src, ok := w.copied[w.pass.object(b)]; !ok || src != obj {
+						constant = false
+					}
+					continue
+				}
+				if v > need {
+					need = v
+				}
+			}
+			if !constant && !f.checked && f.min == 0 {
+				w.pass.Reportf(n.Pos(), "calldata %s is sliced without a length check", obj.Name())
+				return true
+			}
+			w.need(n, obj, need, f)
+		}
+		return true
+	})
+}
+
+// need reports an access to n bytes of calldata that the checks do not
+// cover. Checks against lengths that are not constant are trusted.
+func (w *calldataWalker) need(at ast.Node, obj types.Object, n int64, f lenFact) {
+	if n <= f.min || f.exact >= n || f.checked {
+		return
+	}
+	w.pass.Reportf(at.Pos(), "calldata %s needs %d bytes but only %d are checked", obj.Name(), n, f.min)
+}
+
+// apply records the facts that hold when cond evaluates to truth.
+func (w *calldataWalker) apply(cond ast.Expr, truth bool, facts lenFacts) {
+	switch c := unparen(cond).(type) {
+	case *ast.UnaryExpr:
+		if c.Op == token.NOT {
+			w.apply(c.X, !truth, facts)
+		}
+	case *ast.BinaryExpr:
+		switch {
+		case c.Op == token.LAND && truth, c.Op == token.LOR && !truth:
+			w.apply(c.X, truth, facts)
+			w.apply(c.Y, truth, facts)
+			return
+		case c.Op == token.LAND, c.Op == token.LOR:
+			return
+		}
+		op, x, y := c.Op, c.X, c.Y
+		obj := w.lenOf(x)
+		if obj == nil {
+			if obj = w.lenOf(y); obj == nil {
+				return
+			}
+			op, y = mirror(op), x
+		}
+		f, ok := facts[obj]
+		if !ok {
+			return
+		}
+		if !truth {
+			op = negate(op)
+		}
+		n, constant := w.pass.constInt(y)
+		if !constant {
+			f.checked = true
+			facts[obj] = f
+			return
+		}
+		switch op {
+		case token.GEQ:
+			f.min = maxInt64(f.min, n)
+		case token.GTR:
+			f.min = maxInt64(f.min, n+1)
+		case token.EQL:
+			f.min, f.exact = maxInt64(f.min, n), n
+		case token.NEQ:
+			if n == 0 {
+				f.min = maxInt64(f.min, 1)
+			}
+		}
+		facts[obj] = f
+	}
+}
+
+// lenOf returns the tracked variable e takes the length of, as len(x) or a
+// conversion of it such as uint64(len(x)).
+func (w *calldataWalker) lenOf(e ast.Expr) types.Object {
+	call, ok := unparen(e).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	if tv, ok := w.pass.TypesInfo.Types[call.Fun]; ok && tv.IsType() {
+		return w.lenOf(call.Args[0])
+	}
+	if id, ok := call.Fun.(*ast.Ident); !ok || id.Name != "len" {
+		return nil
+	}
+	return w.pass.object(call.Args[0])
+}
+
+// call checks copies into words and the storage writes of those words.
+func (w *calldataWalker) call(call *ast.CallExpr, facts lenFacts) {
+	switch name := calleeName(call); {
+	case name == "copy" && len(call.Args) == 2:
+		dst, ok := unparen(call.Args[0]).(*ast.SliceExpr)
+		if !ok || dst.Low != nil || dst.High != nil {
+			return
+		}
+		obj := w.pass.object(dst.X)
+		if obj == nil || !isWord(obj.Type()) {
+			return
+		}
+		if w.length(call.Args[1], facts) == 32 {
+			delete(w.unpadded, obj)
+		} else {
+			w.unpadded[obj] = call
+		}
+	case name == "StorageStore" && len(call.Args) == 2:
+		for _, arg := range call.Args {
+			obj := w.pass.object(arg)
+			if c, ok := w.unpadded[obj]; ok && obj != nil {
+				w.pass.Reportf(c.Pos(), "%s is stored left-aligned: the slice copied into it is not known to hold 32 bytes", obj.Name())
+				delete(w.unpadded, obj)
+			}
+		}
+	}
+}
+
+// length returns the length of a byte slice expression if it is known, or
+// -1.
+func (w *calldataWalker) length(e ast.Expr, facts lenFacts) int64 {
+	if f, ok := w.derive(e, facts); ok {
+		return f.exact
+	}
+	// Slices of arrays, such as word[:] or hash[:]
+	se, ok := unparen(e).(*ast.SliceExpr)
+	if !ok {
+		return -1
+	}
+	t := w.pass.TypesInfo.TypeOf(se.X)
+	if t == nil {
+		return -1
+	}
+	arr, ok := t.Underlying().(*types.Array)
+	if !ok {
+		return -1
+	}
+	lo, hi := int64(0), arr.Len()
+	if se.Low != nil {
+		if lo, ok = w.pass.constInt(se.Low); !ok {
+			return -1
+		}
+	}
+	if se.High != nil {
+		if hi, ok = w.pass.constInt(se.High); !ok {
+			return -1
+		}
+	}
+	return hi - lo
+}
+
+// isWord reports whether t is a 32-byte array, such as stygos.Word.
+func isWord(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	arr, ok := t.Underlying().(*types.Array)
+	if !ok || arr.Len() != 32 {
+		return false
+	}
+	b, ok := arr.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Byte
+}
+
+func mirror(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GTR
+	case token.GTR:
+		return token.LSS
+	case token.LEQ:
+		return token.GEQ
+	case token.GEQ:
+		return token.LEQ
+	}
+	return op
+}
+
+func negate(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GEQ
+	case token.GEQ:
+		return token.LSS
+	case token.GTR:
+		return token.LEQ
+	case token.LEQ:
+		return token.GTR
+	case token.EQL:
+		return token.NEQ
+	case token.NEQ:
+		return token.EQL
+	}
+	return op
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+)
+
+// stygosPath is the import path of the stygos host API.
+const stygosPath = "github.com/rafaelescrich/stygos"
+
+// effect is something a function may do to the chain.
+type effect uint8
+
+const (
+	effectCall  effect = 1 << iota // call another contract, which may call back
+	effectWrite                    // write storage
+)
+
+// hostEffects are the effects of the stygos host API functions.
+var hostEffects = map[string]effect{
+	"Call":         effectCall,
+	"CallGas":      effectCall,
+	"Transfer":     effectCall,
+	"StorageStore": effectWrite,
+}
+
+// pkgEffects are the effects of the functions of one package, by name for
+// functions and by Type.Name for methods.
+type pkgEffects struct {
+	name   string
+	funcs  map[string]effect
+	ifaces map[string]bool // interface types, whose methods are not known
+}
+
+// effectsCache summarizes the effects of the functions of packages,
+// following calls from package to package through their sources. The
+// summary is syntactic: calls through interfaces and function values are
+// not followed, and a method call matches every method of that name in the
+// package.
+type effectsCache struct {
+	pkgs map[string]*pkgEffects
+}
+
+func newEffectsCache() *effectsCache {
+	return &effectsCache{pkgs: make(map[string]*pkgEffects)}
+}
+
+// of returns the effects of the package with the given import path,
+// resolved from srcDir.
+func (c *effectsCache) of(path, srcDir string) *pkgEffects {
+	if e, ok := c.pkgs[path]; ok {
+		return e
+	}
+	e := &pkgEffects{funcs: make(map[string]effect)}
+	c.pkgs[path] = e // breaks import cycles
+	if path == stygosPath {
+		e.name = "stygos"
+		for name, eff := range hostEffects {
+			e.funcs[name] = eff
+		}
+		return e
+	}
+
+	bp, err := build.Import(path, srcDir, 0)
+	if err != nil || bp.Goroot {
+		return e
+	}
+	c.load(e, bp)
+	return e
+}
+
+// ofDir returns the effects of the package in dir, known by path.
+func (c *effectsCache) ofDir(path, dir string) *pkgEffects {
+	if e, ok := c.pkgs[path]; ok {
+		return e
+	}
+	e := &pkgEffects{funcs: make(map[string]effect)}
+	c.pkgs[path] = e
+	if bp, err := build.ImportDir(dir, 0); err == nil {
+		c.load(e, bp)
+	}
+	return e
+}
+
+// load summarizes the package bp into e.
+func (c *effectsCache) load(e *pkgEffects, bp *build.Package) {
+	e.name = bp.Name
+
+	// A call in a function body, resolved to its package when qualified
+	// and to the receiver's type when that is declared in the signature
+	type ref struct {
+		pkg     string // import path, empty for the package itself
+		typ     string // receiver type, when known
+		name    string
+		method  bool
+		imports map[string]string // of the file, for methods
+	}
+	refs := make(map[string][]ref)
+	methods := make(map[string][]string) // method name -> Type.Name keys
+	fset := token.NewFileSet()
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			continue
+		}
+		imports := fileImports(f, bp.Dir)
+		for _, d := range f.Decls {
+			if gd, ok := d.(*ast.GenDecl); ok {
+				for _, spec := range gd.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						if _, ok := ts.Type.(*ast.InterfaceType); ok {
+							if e.ifaces == nil {
+								e.ifaces = make(map[string]bool)
+							}
+							e.ifaces[ts.Name.Name] = true
+						}
+					}
+				}
+			}
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			key := funcKey(fd)
+			typed := signatureTypes(fd, imports)
+			if fd.Recv != nil {
+				methods[fd.Name.Name] = append(methods[fd.Name.Name], key)
+			}
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				switch fn := unparen(call.Fun).(type) {
+				case *ast.Ident:
+					refs[key] = append(refs[key], ref{name: fn.Name})
+				case *ast.SelectorExpr:
+					if x, ok := fn.X.(*ast.Ident); ok && x.Obj == nil {
+						if path, ok := imports[x.Name]; ok {
+							refs[key] = append(refs[key], ref{pkg: path, name: fn.Sel.Name})
+							return true
+						}
+					}
+					r := ref{name: fn.Sel.Name, method: true, imports: imports}
+					if x, ok := fn.X.(*ast.Ident); ok && x.Obj != nil {
+						if _, param := x.Obj.Decl.(*ast.Field); param {
+							if t, ok := typed[x.Name]; ok {
+								r.pkg, r.typ = t.pkg, t.name
+							}
+						}
+					}
+					refs[key] = append(refs[key], r)
+				}
+				return true
+			})
+		}
+	}
+
+	// Propagate to a fixed point
+	for changed := true; changed; {
+		changed = false
+		for key, rs := range refs {
+			eff := e.funcs[key]
+			for _, r := range rs {
+				switch {
+				case r.typ != "" && r.pkg == "" && !e.ifaces[r.typ]:
+					eff |= e.funcs[r.typ+"."+r.name]
+				case r.typ != "" && r.pkg != "" && !c.of(r.pkg, bp.Dir).ifaces[r.typ]:
+					eff |= c.of(r.pkg, bp.Dir).funcs[r.typ+"."+r.name]
+				case r.pkg != "" && !r.method:
+					eff |= c.of(r.pkg, bp.Dir).funcs[r.name]
+				case r.method:
+					for _, m := range methods[r.name] {
+						eff |= e.funcs[m]
+					}
+					// The value may come from any package the file imports
+					for _, path := range r.imports {
+						for k, v := range c.of(path, bp.Dir).funcs {
+							if k != r.name && methodName(k) == r.name {
+								eff |= v
+							}
+						}
+					}
+				default:
+					eff |= e.funcs[r.name]
+				}
+			}
+			if eff != e.funcs[key] {
+				e.funcs[key] = eff
+				changed = true
+			}
+		}
+	}
+}
+
+// fileImports maps the names a file refers to its imports by to their
+// paths.
+func fileImports(f *ast.File, dir string) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		var name string
+		switch {
+		case spec.Name != nil:
+			name = spec.Name.Name
+		case path == stygosPath:
+			name = "stygos"
+		default:
+			name = filepath.Base(path)
+			if bp, err := build.Import(path, dir, 0); err == nil {
+				name = bp.Name
+			}
+		}
+		if name != "" && name != "_" && name != "." {
+			imports[name] = path
+		}
+	}
+	return imports
+}
+
+// typeRef names a type, qualified by its import path unless local.
+type typeRef struct {
+	pkg  string
+	name string
+}
+
+// signatureTypes maps the receiver and parameters of fd to their named
+// types, so calls of their methods resolve to that type alone rather than
+// to every method of the name.
+func signatureTypes(fd *ast.FuncDecl, imports map[string]string) map[string]typeRef {
+	typed := make(map[string]typeRef)
+	var fields []*ast.Field
+	if fd.Recv != nil {
+		fields = append(fields, fd.Recv.List...)
+	}
+	fields = append(fields, fd.Type.Params.List...)
+	for _, field := range fields {
+		t := field.Type
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		var ref typeRef
+		switch x := t.(type) {
+		case *ast.Ident:
+			ref.name = x.Name
+		case *ast.SelectorExpr:
+			pkg, ok := x.X.(*ast.Ident)
+			if !ok || imports[pkg.Name] == "" {
+				continue
+			}
+			ref = typeRef{pkg: imports[pkg.Name], name: x.Sel.Name}
+		default:
+			continue
+		}
+		for _, name := range field.Names {
+			typed[name.Name] = ref
+		}
+	}
+	return typed
+}
+
+// funcKey returns Name for functions and Type.Name for methods.
+func funcKey(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+	t := fd.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+			continue
+		case *ast.IndexExpr:
+			t = x.X
+			continue
+		case *ast.IndexListExpr:
+			t = x.X
+			continue
+		case *ast.Ident:
+			return x.Name + "." + fd.Name.Name
+		}
+		return fd.Name.Name
+	}
+}
+
+// methodName returns the method part of a Type.Name key.
+func methodName(key string) string {
+	for i := len(key) - 1; i >= 0; i-- {
+		if key[i] == '.' {
+			return key[i+1:]
+		}
+	}
+	return key
+}
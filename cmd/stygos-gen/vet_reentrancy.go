@@ -0,0 +1,313 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// reentrancyAllow lists the functions and callees the reentrancy check
+// skips, set with -reentrancy.allow.
+var reentrancyAllow = &stringList{}
+
+// reentrancyAnalyzer finds storage writes that follow a call to another
+// contract in the same function.
+var reentrancyAnalyzer = &analyzer{
+	Name: "reentrancy",
+	Doc: `check that state is written before calling other contracts
+
+A contract called with stygos.Call, CallGas or Transfer, directly or
+through a function that does, can call back in before the caller returns.
+If the caller writes storage only after the call, the reentrant call sees
+the old state, as in the classic withdraw bug. Following
+checks-effects-interactions, write first:
+
+	setBalance(sender, stygos.U256{})
+	if err := stygos.Transfer(sender, balance); err != nil {
+		return nil, err
+	}
+
+Writes in a loop that calls out are reported too, as the next iteration
+runs after the call. Effects are followed through the packages the
+contract imports; calls through interfaces and function values are not.
+
+-reentrancy.allow takes a comma-separated list of functions to skip, as
+name or Type.Method, and of callees to trust, as pkg.Func or
+pkg.Type.Method, for example token.ERC20.Transfer for a known token.`,
+	Run: runReentrancy,
+}
+
+func init() {
+	reentrancyAnalyzer.Flags.Var(reentrancyAllow, "allow", "functions to skip and callees to trust, comma-separated")
+}
+
+// stringList is a comma-separated flag value.
+type stringList struct {
+	items []string
+}
+
+func (l *stringList) String() string {
+	return strings.Join(l.items, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			l.items = append(l.items, item)
+		}
+	}
+	return nil
+}
+
+func (l *stringList) has(s string) bool {
+	for _, item := range l.items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func runReentrancy(p *pass) {
+	if p.Pkg == nil || p.Pkg.Path() == stygosPath {
+		return
+	}
+	self := p.Effects.ofDir(p.Pkg.Path(), p.Dir)
+	for _, f := range p.Files {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			if reentrancyAllow.has(funcKey(fd)) {
+				continue
+			}
+			checkReentrancy(p, self, fd.Body)
+			// Function literals are checked on their own
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				if lit, ok := n.(*ast.FuncLit); ok {
+					checkReentrancy(p, self, lit.Body)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// checkReentrancy reports the writes that follow an external call in body,
+// not descending into function literals. Writes in the branch handling the
+// failure of a call, such as restoring state after a failed Transfer, are
+// not reported: the callee reverted, and so did anything it called back.
+func checkReentrancy(p *pass, self *pkgEffects, body *ast.BlockStmt) {
+	var called *ast.CallExpr // the first external call so far
+	var calledName string
+	failed := make(map[types.Object]bool) // errors returned by external calls
+
+	var visit func(n ast.Node)
+	visit = func(n ast.Node) {
+		var stack []ast.Node
+		ast.Inspect(n, func(n ast.Node) bool {
+			if n == nil {
+				// Calls take effect after their arguments
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				call, ok := top.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				eff, name := callEffect(p, self, call)
+				if eff&effectWrite != 0 && called != nil {
+					line := p.Fset.Position(called.Pos()).Line
+					p.Reportf(call.Pos(), "%s writes storage after the call to %s on line %d; write state before calling out", name, calledName, line)
+				}
+				if eff&effectCall != 0 && called == nil {
+					called, calledName = call, name
+				}
+				return true
+			}
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.AssignStmt:
+				if len(n.Rhs) == 1 {
+					if call, ok := unparen(n.Rhs[0]).(*ast.CallExpr); ok {
+						if eff, _ := callEffect(p, self, call); eff&effectCall != 0 {
+							for _, lhs := range n.Lhs {
+								if obj := p.object(lhs); obj != nil && obj.Type() != nil && obj.Type().String() == "error" {
+									failed[obj] = true
+								}
+							}
+						}
+					}
+				}
+			case *ast.IfStmt:
+				if n.Init != nil {
+					visit(n.Init)
+				}
+				visit(n.Cond)
+				// Each branch starts from the calls before the if, and one
+				// that returns does not reach the statements after it
+				before, beforeName := called, calledName
+				after, afterName := before, beforeName
+				if !failureCheck(p, n.Cond, failed) {
+					visit(n.Body)
+					if after == nil && !terminates(n.Body) {
+						after, afterName = called, calledName
+					}
+				}
+				if n.Else != nil {
+					called, calledName = before, beforeName
+					visit(n.Else)
+					if after == nil && !elseTerminates(n.Else) {
+						after, afterName = called, calledName
+					}
+				}
+				called, calledName = after, afterName
+				return false
+			case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				// Only one case runs, so each starts from the calls before
+				// the switch
+				var clauses []ast.Stmt
+				switch n := n.(type) {
+				case *ast.SwitchStmt:
+					if n.Init != nil {
+						visit(n.Init)
+					}
+					if n.Tag != nil {
+						visit(n.Tag)
+					}
+					clauses = n.Body.List
+				case *ast.TypeSwitchStmt:
+					if n.Init != nil {
+						visit(n.Init)
+					}
+					visit(n.Assign)
+					clauses = n.Body.List
+				case *ast.SelectStmt:
+					clauses = n.Body.List
+				}
+				before, beforeName := called, calledName
+				after, afterName := before, beforeName
+				for _, clause := range clauses {
+					called, calledName = before, beforeName
+					visit(clause)
+					if after == nil && !clauseTerminates(clause) {
+						after, afterName = called, calledName
+					}
+				}
+				called, calledName = after, afterName
+				return false
+			case *ast.ForStmt, *ast.RangeStmt:
+				// Calls in a loop precede the writes of later iterations
+				if called == nil {
+					if call, name := firstCall(p, self, n); call != nil {
+						called, calledName = call, name
+					}
+				}
+			}
+			stack = append(stack, n)
+			return true
+		})
+	}
+	visit(body)
+}
+
+// clauseTerminates reports whether a switch or select clause always
+// leaves the switch.
+func clauseTerminates(s ast.Stmt) bool {
+	switch s := s.(type) {
+	case *ast.CaseClause:
+		return terminates(&ast.BlockStmt{List: s.Body})
+	case *ast.CommClause:
+		return terminates(&ast.BlockStmt{List: s.Body})
+	}
+	return false
+}
+
+// failureCheck reports whether cond is err != nil for an error returned by
+// an external call.
+func failureCheck(p *pass, cond ast.Expr, failed map[types.Object]bool) bool {
+	be, ok := unparen(cond).(*ast.BinaryExpr)
+	if !ok || be.Op != token.NEQ {
+		return false
+	}
+	if id, ok := be.Y.(*ast.Ident); !ok || id.Name != "nil" {
+		return false
+	}
+	obj := p.object(be.X)
+	return obj != nil && failed[obj]
+}
+
+// firstCall returns the first external call in n.
+func firstCall(p *pass, self *pkgEffects, n ast.Node) (*ast.CallExpr, string) {
+	var first *ast.CallExpr
+	var firstName string
+	ast.Inspect(n, func(n ast.Node) bool {
+		if first != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if eff, name := callEffect(p, self, n); eff&effectCall != 0 {
+				first, firstName = n, name
+				return false
+			}
+		}
+		return true
+	})
+	return first, firstName
+}
+
+// callEffect returns the effects of call and the name of its callee, as
+// pkg.Func or pkg.Type.Method. Allowed callees have no call effect.
+func callEffect(p *pass, self *pkgEffects, call *ast.CallExpr) (effect, string) {
+	var id *ast.Ident
+	switch fn := unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fn
+	case *ast.SelectorExpr:
+		id = fn.Sel
+	default:
+		return 0, ""
+	}
+
+	fn, ok := p.TypesInfo.Uses[id].(*types.Func)
+	if !ok {
+		// Without type information, only local functions are known
+		if _, ok := call.Fun.(*ast.Ident); ok && p.TypesInfo.Uses[id] == nil {
+			return self.funcs[id.Name], self.name + "." + id.Name
+		}
+		return 0, ""
+	}
+	if fn.Pkg() == nil {
+		return 0, ""
+	}
+	key := fn.Name()
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		recv := sig.Recv().Type()
+		if ptr, ok := recv.(*types.Pointer); ok {
+			recv = ptr.Elem()
+		}
+		named, ok := recv.(*types.Named)
+		if !ok {
+			return 0, "" // interface method
+		}
+		key = named.Obj().Name() + "." + key
+	}
+
+	var pe *pkgEffects
+	if fn.Pkg() == p.Pkg {
+		pe = self
+	} else {
+		pe = p.Effects.of(fn.Pkg().Path(), p.Dir)
+	}
+	name := fn.Pkg().Name() + "." + key
+	eff := pe.funcs[key]
+	if reentrancyAllow.has(name) {
+		eff &^= effectCall
+	}
+	return eff, name
+}
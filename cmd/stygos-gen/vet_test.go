@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// wantRE matches the `// want "regexp"` comments of vet testdata, the
+// convention of go/analysis tests.
+var wantRE = regexp.MustCompile(`// want "([^"]+)"`)
+
+// testVet runs the named check on testdata/vet/<check> and compares its
+// diagnostics with the want comments there.
+func testVet(t *testing.T, check string) {
+	t.Helper()
+	dir := filepath.Join("testdata", "vet", check)
+	diags, err := newVetEnv().vetDir(dir, []*analyzer{findAnalyzer(check)})
+	if err != nil {
+		t.Fatalf("vetDir failed: %v", err)
+	}
+
+	wants := make(map[string]*regexp.Regexp) // by file:line
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range strings.Split(string(src), "\n") {
+			if m := wantRE.FindStringSubmatch(line); m != nil {
+				wants[file+":"+strconv.Itoa(i+1)] = regexp.MustCompile(m[1])
+			}
+		}
+	}
+
+	for _, d := range diags {
+		key := d.Pos.Filename + ":" + strconv.Itoa(d.Pos.Line)
+		re, ok := wants[key]
+		if !ok {
+			t.Errorf("unexpected diagnostic %s", d)
+			continue
+		}
+		if !re.MatchString(d.Message) {
+			t.Errorf("%s: diagnostic %q does not match %q", key, d.Message, re)
+		}
+		delete(wants, key)
+	}
+	for key, re := range wants {
+		t.Errorf("%s: no diagnostic matching %q", key, re)
+	}
+}
+
+func TestVetCalldata(t *testing.T) {
+	defer func(items []string) { calldataAllow.items = items }(calldataAllow.items)
+	calldataAllow.Set("allowedLeftAligned")
+	testVet(t, "calldata")
+}
+
+func TestVetReentrancy(t *testing.T) {
+	defer func(items []string) { reentrancyAllow.items = items }(reentrancyAllow.items)
+	reentrancyAllow.Set("allowedRefund")
+	testVet(t, "reentrancy")
+}
+
+func TestVetImportPath(t *testing.T) {
+	dir := filepath.Join("testdata", "vet", "reentrancy")
+	if got, want := importPath(dir), "github.com/rafaelescrich/stygos/cmd/stygos-gen/testdata/vet/reentrancy"; got != want {
+		t.Errorf("importPath(%s) = %s, want %s", dir, got, want)
+	}
+
+	// Packages vetted in one run keep their own effects
+	env := newVetEnv()
+	if _, err := env.vetDir(filepath.Join("testdata", "vet", "calldata"), []*analyzer{reentrancyAnalyzer}); err != nil {
+		t.Fatalf("vetDir failed: %v", err)
+	}
+	diags, err := env.vetDir(dir, []*analyzer{reentrancyAnalyzer})
+	if err != nil {
+		t.Fatalf("vetDir failed: %v", err)
+	}
+	var found bool
+	for _, d := range diags {
+		found = found || strings.Contains(d.Message, "reentrancy.setBalance")
+	}
+	if !found {
+		t.Errorf("vetDir after another package missed the write through setBalance, got %v", diags)
+	}
+}
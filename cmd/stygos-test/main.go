@@ -0,0 +1,49 @@
+// Command stygos-test measures how well the tests of a stygos contract
+// guard it.
+//
+// Usage:
+//
+//	stygos-test <mode> [flags] [args]
+//
+// Modes:
+//
+//	mutate   apply systematic mutations to the contract package, re-run its
+//	         tests and report the mutants they fail to kill
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch mode, args := os.Args[1], os.Args[2:]; mode {
+	case "mutate":
+		err = runMutate(args)
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "stygos-test: unknown mode %q\n", mode)
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stygos-test: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: stygos-test <mode> [flags] [args]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "modes:")
+	fmt.Fprintln(os.Stderr, "  mutate   report mutations of the contract its tests do not catch")
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// mutant is a single change to one source file of the package.
+type mutant struct {
+	File   string // path of the mutated file
+	Pos    token.Position
+	Kind   string // "negate", "boundary" or "drop-emit"
+	Desc   string // human-readable change, such as "< -> >="
+	Offset int    // byte range replaced
+	End    int
+	Repl   string
+}
+
+// mutantResult is the outcome of running the tests against a mutant.
+type mutantResult int
+
+const (
+	killed   mutantResult = iota // the tests failed
+	survived                     // the tests passed
+	invalid                      // the mutant did not compile
+)
+
+// negated and boundary map comparison operators to their mutations:
+// negation flips the outcome, boundary shifts it by one.
+var (
+	negated = map[token.Token]token.Token{
+		token.EQL: token.NEQ, token.NEQ: token.EQL,
+		token.LSS: token.GEQ, token.GEQ: token.LSS,
+		token.GTR: token.LEQ, token.LEQ: token.GTR,
+	}
+	boundary = map[token.Token]token.Token{
+		token.LSS: token.LEQ, token.LEQ: token.LSS,
+		token.GTR: token.GEQ, token.GEQ: token.GTR,
+	}
+)
+
+// runMutate implements `stygos-test mutate`. It parses the non-test files
+// of a package, derives mutants from them, and runs `go test` once per
+// mutant with the mutated file swapped in through -overlay, so the sources
+// on disk are never modified. Mutants the tests still pass on are
+// reported; they point at behavior no test checks.
+//
+// The mutations are:
+//
+//	negate     replace a comparison with its negation (< becomes >=)
+//	boundary   move a comparison bound by one (< becomes <=)
+//	drop-emit  remove a statement calling EmitEvent or an Emit* function
+func runMutate(args []string) error {
+	fs := flag.NewFlagSet("mutate", flag.ContinueOnError)
+	dir := fs.String("dir", ".", "package directory")
+	run := fs.String("run", "", "only run tests matching this regexp")
+	kinds := fs.String("kinds", "negate,boundary,drop-emit", "comma-separated mutations to apply")
+	list := fs.Bool("list", false, "list the mutants without running the tests")
+	timeout := fs.Duration("timeout", 2*time.Minute, "timeout of each test run")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	enabled := make(map[string]bool)
+	for _, k := range strings.Split(*kinds, ",") {
+		switch k = strings.TrimSpace(k); k {
+		case "negate", "boundary", "drop-emit":
+			enabled[k] = true
+		default:
+			return fmt.Errorf("mutate: unknown mutation %q", k)
+		}
+	}
+
+	mutants, err := findMutants(*dir, enabled)
+	if err != nil {
+		return err
+	}
+	if *list {
+		for _, m := range mutants {
+			fmt.Printf("%s: %s %s\n", m.Pos, m.Kind, m.Desc)
+		}
+		return nil
+	}
+	if len(mutants) == 0 {
+		return fmt.Errorf("mutate: no mutants found in %s", *dir)
+	}
+
+	tmp, err := os.MkdirTemp("", "stygos-mutate")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if res, out := runTests(*dir, "", *run, *timeout); res != survived {
+		return fmt.Errorf("mutate: tests fail before mutation:\n%s", out)
+	}
+
+	var counts [3]int
+	for i, m := range mutants {
+		overlay, err := writeOverlay(tmp, i, m)
+		if err != nil {
+			return err
+		}
+		res, _ := runTests(*dir, overlay, *run, *timeout)
+		counts[res]++
+		if res == survived {
+			fmt.Printf("%s: %s %s survived\n", m.Pos, m.Kind, m.Desc)
+		}
+	}
+
+	valid := counts[killed] + counts[survived]
+	score := 100.0
+	if valid > 0 {
+		score = 100 * float64(counts[killed]) / float64(valid)
+	}
+	fmt.Printf("%d mutants: %d killed, %d survived, %d invalid (score %.1f%%)\n",
+		len(mutants), counts[killed], counts[survived], counts[invalid], score)
+	if counts[survived] > 0 {
+		return fmt.Errorf("mutate: %d mutant(s) survived", counts[survived])
+	}
+	return nil
+}
+
+// findMutants returns the mutants of the non-test Go files in dir, ordered
+// by position.
+func findMutants(dir string, enabled map[string]bool) ([]mutant, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var mutants []mutant
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || strings.HasSuffix(path, "_gen.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		ms, err := fileMutants(path, src, enabled)
+		if err != nil {
+			return nil, err
+		}
+		mutants = append(mutants, ms...)
+	}
+	sort.SliceStable(mutants, func(i, j int) bool {
+		if mutants[i].File != mutants[j].File {
+			return mutants[i].File < mutants[j].File
+		}
+		return mutants[i].Offset < mutants[j].Offset
+	})
+	return mutants, nil
+}
+
+// fileMutants returns the mutants of one source file.
+func fileMutants(path string, src []byte, enabled map[string]bool) ([]mutant, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	tf := fset.File(file.Pos())
+
+	var mutants []mutant
+	add := func(kind string, from, to token.Pos, repl, desc string) {
+		mutants = append(mutants, mutant{
+			File:   path,
+			Pos:    fset.Position(from),
+			Kind:   kind,
+			Desc:   desc,
+			Offset: tf.Offset(from),
+			End:    tf.Offset(to),
+			Repl:   repl,
+		})
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			end := n.OpPos + token.Pos(len(n.Op.String()))
+			if to, ok := negated[n.Op]; ok && enabled["negate"] {
+				add("negate", n.OpPos, end, to.String(), n.Op.String()+" -> "+to.String())
+			}
+			if to, ok := boundary[n.Op]; ok && enabled["boundary"] {
+				add("boundary", n.OpPos, end, to.String(), n.Op.String()+" -> "+to.String())
+			}
+		case *ast.ExprStmt:
+			if name := emitName(n.X); name != "" && enabled["drop-emit"] {
+				add("drop-emit", n.Pos(), n.End(), "", "removed "+name+" call")
+			}
+		}
+		return true
+	})
+	return mutants, nil
+}
+
+// emitName returns the name of the function x calls if it emits an event:
+// EmitEvent, or any function or method whose name starts with Emit or
+// emit.
+func emitName(x ast.Expr) string {
+	call, ok := x.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	var name string
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		name = fn.Name
+	case *ast.SelectorExpr:
+		name = fn.Sel.Name
+	}
+	if strings.HasPrefix(name, "Emit") || strings.HasPrefix(name, "emit") {
+		return name
+	}
+	return ""
+}
+
+// writeOverlay writes the mutated file and an overlay file pointing go
+// test at it, returning the path of the overlay file.
+func writeOverlay(tmp string, i int, m mutant) (string, error) {
+	src, err := os.ReadFile(m.File)
+	if err != nil {
+		return "", err
+	}
+	mutated := make([]byte, 0, len(src)+len(m.Repl))
+	mutated = append(mutated, src[:m.Offset]...)
+	mutated = append(mutated, m.Repl...)
+	mutated = append(mutated, src[m.End:]...)
+
+	abs, err := filepath.Abs(m.File)
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(tmp, fmt.Sprintf("mutant%d.go", i))
+	if err := os.WriteFile(file, mutated, 0o644); err != nil {
+		return "", err
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": {abs: file}})
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(tmp, fmt.Sprintf("overlay%d.json", i))
+	return path, os.WriteFile(path, overlay, 0o644)
+}
+
+// runTests runs the tests of dir, through overlay if not empty, and
+// classifies the outcome. A run is invalid if the package does not build.
+func runTests(dir, overlay, run string, timeout time.Duration) (mutantResult, []byte) {
+	args := []string{"test", "-count=1", "-timeout", timeout.String()}
+	if overlay != "" {
+		args = append(args, "-overlay", overlay)
+	}
+	if run != "" {
+		args = append(args, "-run", run)
+	}
+	cmd := exec.Command("go", append(args, ".")...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	switch err := cmd.Run(); {
+	case err == nil:
+		return survived, out.Bytes()
+	case bytes.Contains(out.Bytes(), []byte("[build failed]")), bytes.Contains(out.Bytes(), []byte("[setup failed]")):
+		return invalid, out.Bytes()
+	default:
+		return killed, out.Bytes()
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const mutateSrc = `package vault
+
+func Withdraw(balance, amount uint64) (uint64, bool) {
+	if amount > balance {
+		return balance, false
+	}
+	emitWithdrawal(amount)
+	return balance - amount, true
+}
+
+var events int
+
+func emitWithdrawal(amount uint64) { events++ }
+`
+
+func TestFileMutants(t *testing.T) {
+	all := map[string]bool{"negate": true, "boundary": true, "drop-emit": true}
+	mutants, err := fileMutants("vault.go", []byte(mutateSrc), all)
+	if err != nil {
+		t.Fatalf("fileMutants failed: %v", err)
+	}
+	var got []string
+	for _, m := range mutants {
+		got = append(got, m.Kind+" "+m.Desc)
+		if m.Kind != "drop-emit" && mutateSrc[m.Offset:m.End] != ">" {
+			t.Errorf("%s replaces %q, want the operator", m.Desc, mutateSrc[m.Offset:m.End])
+		}
+	}
+	want := "negate > -> <=,boundary > -> >=,drop-emit removed emitWithdrawal call"
+	if strings.Join(got, ",") != want {
+		t.Errorf("mutants = %q, want %q", got, want)
+	}
+
+	mutants, _ = fileMutants("vault.go", []byte(mutateSrc), map[string]bool{"boundary": true})
+	if len(mutants) != 1 {
+		t.Errorf("boundary only: %d mutants, want 1", len(mutants))
+	}
+}
+
+func TestRunMutants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test per mutant")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not in PATH")
+	}
+
+	// The test checks the refusal but neither the boundary nor the event
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module vault\n\ngo 1.18\n",
+		"vault.go": mutateSrc,
+		"vault_test.go": `package vault
+
+import "testing"
+
+func TestWithdraw(t *testing.T) {
+	if _, ok := Withdraw(1, 2); ok {
+		t.Error("overdraft allowed")
+	}
+	if b, ok := Withdraw(5, 2); !ok || b != 3 {
+		t.Error("withdrawal refused")
+	}
+}
+`,
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mutants, err := findMutants(dir, map[string]bool{"negate": true, "boundary": true, "drop-emit": true})
+	if err != nil {
+		t.Fatalf("findMutants failed: %v", err)
+	}
+	tmp := t.TempDir()
+	var survivors []string
+	for i, m := range mutants {
+		overlay, err := writeOverlay(tmp, i, m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, out := runTests(dir, overlay, "", time.Minute)
+		switch res {
+		case survived:
+			survivors = append(survivors, m.Kind)
+		case invalid:
+			t.Errorf("%s mutant did not build:\n%s", m.Kind, out)
+		}
+	}
+	if strings.Join(survivors, ",") != "boundary,drop-emit" {
+		t.Errorf("survivors = %q, want the boundary and the dropped emit", survivors)
+	}
+}
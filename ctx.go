@@ -0,0 +1,58 @@
+package stygos
+
+import (
+	"encoding/hex"
+	"math/big"
+)
+
+// Ctx is the call a router handler serves: who made it, with how much
+// value, to which function and in which block. The router reads it from
+// the host once per call, so handlers and middleware take what they need
+// from it rather than from host functions, and tests can build one and
+// call a handler through DispatchCtx without a runtime.
+type Ctx struct {
+	Sender    Address  // msg.sender
+	Contract  Address  // the executing contract
+	Value     *big.Int // msg.value in wei
+	Block     uint64   // block number
+	Timestamp uint64   // block timestamp
+	Selector  Selector // selector of the call, zero if shorter than one
+}
+
+// NewCtx reads the context of the current call from the host.
+func NewCtx() *Ctx {
+	return &Ctx{
+		Sender:    GetMsgSender(),
+		Contract:  GetContractAddress(),
+		Value:     GetMsgValue(),
+		Block:     GetBlockNumber(),
+		Timestamp: GetBlockTimestamp(),
+	}
+}
+
+// Payable reports whether the call carries value.
+func (c *Ctx) Payable() bool {
+	return c.Value != nil && c.Value.Sign() != 0
+}
+
+// Revert returns an error that reverts the call with data as the revert
+// data, such as an ABI encoded custom error, when a handler returns it.
+func (c *Ctx) Revert(data []byte) error {
+	return &RevertError{Data: data}
+}
+
+// RevertError reverts a call with data. Router.Entrypoint writes the data
+// as the revert data; other errors revert without any, except for failed
+// calls, whose revert data is passed on.
+type RevertError struct {
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	return "reverted with 0x" + hex.EncodeToString(e.Data)
+}
+
+// Is reports that a RevertError is an ErrRevert.
+func (e *RevertError) Is(target error) bool {
+	return target == ErrRevert
+}
@@ -0,0 +1,87 @@
+package stygos
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestNewCtx(t *testing.T) {
+	mock := NewMockRuntime()
+	mock.Sender = Address{0xa1}
+	mock.Contract = Address{0xc0}
+	mock.Value = big.NewInt(7)
+	mock.Block = 42
+	mock.Time = 1700000000
+	UseRuntime(mock)
+
+	ctx := NewCtx()
+	if ctx.Sender != mock.Sender || ctx.Contract != mock.Contract {
+		t.Errorf("NewCtx() sender %x, contract %x, want %x, %x", ctx.Sender, ctx.Contract, mock.Sender, mock.Contract)
+	}
+	if ctx.Value.Int64() != 7 || !ctx.Payable() {
+		t.Errorf("NewCtx() value = %v, want 7", ctx.Value)
+	}
+	if ctx.Block != 42 || ctx.Timestamp != 1700000000 {
+		t.Errorf("NewCtx() block %d at %d, want 42 at 1700000000", ctx.Block, ctx.Timestamp)
+	}
+}
+
+func TestDispatchCtx(t *testing.T) {
+	owner := Address{0x0e}
+	router := NewRouter()
+	router.Handle("owned()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		if ctx.Sender != owner {
+			return nil, ctx.Revert([]byte("not owner"))
+		}
+		return ctx.Selector[:], nil
+	})
+
+	// Handlers run on the given context alone
+	sel := SelectorOf("owned()")
+	out, err := router.DispatchCtx(&Ctx{Sender: owner}, sel[:])
+	if err != nil || !bytes.Equal(out, sel[:]) {
+		t.Errorf("DispatchCtx(owner) = %x, %v, want %x", out, err, sel)
+	}
+	_, err = router.DispatchCtx(&Ctx{Sender: Address{1}}, sel[:])
+	if revert, ok := err.(*RevertError); !ok || string(revert.Data) != "not owner" {
+		t.Errorf("DispatchCtx(stranger) = %v, want a revert with data", err)
+	}
+
+	// The entrypoint returns the revert data
+	mock := NewMockRuntime()
+	mock.Sender = Address{1}
+	mock.Args = sel[:]
+	UseRuntime(mock)
+	if status := router.Entrypoint(); status != 1 {
+		t.Errorf("Entrypoint() = %d, want 1", status)
+	}
+	if string(mock.Result) != "not owner" {
+		t.Errorf("revert data = %q, want not owner", mock.Result)
+	}
+}
+
+func TestMulticallCtx(t *testing.T) {
+	UseRuntime(NewMockRuntime())
+
+	router := NewRouter()
+	router.Handle("selector()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		return ctx.Selector[:], nil
+	})
+	router.HandleMulticall()
+
+	// Each call of a batch sees its own selector, and the batch its own
+	sel := SelectorOf("selector()")
+	ctx := &Ctx{}
+	out, err := router.DispatchCtx(ctx, EncodeMulticall(sel[:], sel[:]))
+	if err != nil {
+		t.Fatalf("multicall failed: %v", err)
+	}
+	results, err := DecodeMulticallResult(out)
+	if err != nil || len(results) != 2 || !bytes.Equal(results[1], sel[:]) {
+		t.Errorf("multicall results = %x, %v, want the selector twice", results, err)
+	}
+	if ctx.Selector != selMulticall {
+		t.Errorf("batch selector = %x, want %x", ctx.Selector, selMulticall)
+	}
+}
@@ -0,0 +1,371 @@
+// Package amm implements a constant-product (x*y=k) liquidity pool for a
+// pair of ERC-20 tokens, in the style of Uniswap V2.
+//
+// Liquidity providers deposit both tokens in proportion to the reserves
+// and receive pool shares; traders swap one token for the other, paying a
+// fee that stays in the pool. Every reserve update advances cumulative
+// price accumulators, from which contracts derive manipulation-resistant
+// time-weighted average prices (TWAP).
+//
+// Tokens are pulled from the caller with transferFrom, so callers approve
+// the pool contract first. Reserves are capped at 2^112-1, which keeps
+// every intermediate product within 256 bits. Liquidity changes and swaps
+// record their effect before any token moves and hold a lock, as in
+// Uniswap V2, so a token that calls back into the pool during a transfer
+// cannot act on a half-finished operation.
+package amm
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/math/fixed"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Pool errors
+var (
+	ErrInitialized           = errors.New("amm: pool already initialized")
+	ErrNotInitialized        = errors.New("amm: pool not initialized")
+	ErrIdenticalTokens       = errors.New("amm: identical tokens")
+	ErrInvalidFee            = errors.New("amm: fee must be below 100%")
+	ErrInvalidToken          = errors.New("amm: token not in pool")
+	ErrInsufficientLiquidity = errors.New("amm: insufficient liquidity")
+	ErrInsufficientAmount    = errors.New("amm: amount below minimum")
+	ErrInsufficientShares    = errors.New("amm: insufficient shares")
+	ErrZeroAmount            = errors.New("amm: zero amount")
+	ErrOverflow              = errors.New("amm: reserve overflow")
+	ErrLocked                = errors.New("amm: reentrant call")
+	ErrZeroElapsed           = errors.New("amm: price readings taken at the same time")
+)
+
+// MinimumLiquidity shares are locked forever on the first deposit, so the
+// share price can never be inflated from a near-empty pool.
+const MinimumLiquidity = 1000
+
+// FeeDenominator is the denominator of the swap fee.
+const FeeDenominator = 10000
+
+// maxReserve is the largest reserve, 2^112-1.
+var maxReserve = stygos.NewU256(1).Lsh(112).Sub(stygos.NewU256(1))
+
+// State is the pool state, packed into storage by state_pack_gen.go.
+//
+//go:generate stygos-gen pack -type State -o state_pack_gen.go
+type State struct {
+	Token0        stygos.Address
+	FeeBps        uint16
+	TimestampLast uint64
+	Token1        stygos.Address
+	Reserve0      stygos.U256
+	Reserve1      stygos.U256
+
+	// Price0Cumulative sums reserve1/reserve0 as UQ112x112 over every
+	// second since creation, Price1Cumulative the inverse. Both wrap.
+	Price0Cumulative stygos.U256
+	Price1Cumulative stygos.U256
+	TotalShares      stygos.U256
+}
+
+// Pool is a constant-product pool.
+//
+// Storage layout relative to the base slot:
+//
+//	base ...                                     State (StatePackedWords slots)
+//	MapKey(Offset(base, StatePackedWords), acc)  shares of acc
+//	Offset(base, StatePackedWords+1)             lock, in transient storage from ArbOS 30
+type Pool struct {
+	base stygos.Word
+}
+
+// NewPool returns the pool rooted at base.
+func NewPool(base stygos.Word) *Pool {
+	return &Pool{base: base}
+}
+
+// Initialize sets the pool tokens and the swap fee in basis points.
+func (p *Pool) Initialize(token0, token1 stygos.Address, feeBps uint16) error {
+	s := p.load()
+	if s.Token0 != (stygos.Address{}) {
+		return ErrInitialized
+	}
+	if token0 == token1 {
+		return ErrIdenticalTokens
+	}
+	if feeBps >= FeeDenominator {
+		return ErrInvalidFee
+	}
+	s.Token0, s.Token1, s.FeeBps = token0, token1, feeBps
+	s.Store(p.base)
+	return nil
+}
+
+// State returns the pool state.
+func (p *Pool) State() State {
+	return p.load()
+}
+
+// SharesOf returns the pool shares held by account.
+func (p *Pool) SharesOf(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(p.sharesSlot(account)))
+}
+
+// AddLiquidity deposits up to the desired amounts of both tokens at the
+// current reserve ratio and mints shares to the caller. The first deposit
+// sets the ratio. It fails if the ratio would take less than a minimum.
+func (p *Pool) AddLiquidity(amount0Desired, amount1Desired, amount0Min, amount1Min stygos.U256) (amount0, amount1, shares stygos.U256, err error) {
+	unlock, err := p.lock()
+	if err != nil {
+		return amount0, amount1, shares, err
+	}
+	defer unlock()
+
+	s := p.load()
+	if s.Token0 == (stygos.Address{}) {
+		return amount0, amount1, shares, ErrNotInitialized
+	}
+
+	if amount0Desired.Gt(maxReserve) || amount1Desired.Gt(maxReserve) {
+		return amount0, amount1, shares, ErrOverflow
+	}
+
+	amount0, amount1 = amount0Desired, amount1Desired
+	if !s.Reserve0.IsZero() || !s.Reserve1.IsZero() {
+		optimal1 := Quote(amount0Desired, s.Reserve0, s.Reserve1)
+		if !optimal1.Gt(amount1Desired) {
+			amount1 = optimal1
+		} else {
+			amount0 = Quote(amount1Desired, s.Reserve1, s.Reserve0)
+		}
+	}
+	if amount0.Lt(amount0Min) || amount1.Lt(amount1Min) {
+		return amount0, amount1, shares, ErrInsufficientAmount
+	}
+	if amount0.Gt(maxReserve.Sub(s.Reserve0)) || amount1.Gt(maxReserve.Sub(s.Reserve1)) {
+		return amount0, amount1, shares, ErrOverflow
+	}
+
+	first := s.TotalShares.IsZero()
+	if first {
+		shares = fixed.Sqrt(amount0.Mul(amount1))
+		if !shares.Gt(stygos.NewU256(MinimumLiquidity)) {
+			return amount0, amount1, stygos.U256{}, ErrInsufficientLiquidity
+		}
+		shares = shares.Sub(stygos.NewU256(MinimumLiquidity))
+	} else {
+		shares = amount0.Mul(s.TotalShares).Div(s.Reserve0)
+		if other := amount1.Mul(s.TotalShares).Div(s.Reserve1); other.Lt(shares) {
+			shares = other
+		}
+		if shares.IsZero() {
+			return amount0, amount1, shares, ErrInsufficientLiquidity
+		}
+	}
+
+	sender, self := stygos.GetMsgSender(), stygos.GetContractAddress()
+	if first {
+		p.mint(&s, stygos.Address{}, stygos.NewU256(MinimumLiquidity))
+	}
+	p.mint(&s, sender, shares)
+	s.update(s.Reserve0.Add(amount0), s.Reserve1.Add(amount1))
+	s.Store(p.base)
+
+	if err := token.SafeTransferFrom(token.NewERC20(s.Token0), sender, self, amount0); err != nil {
+		return amount0, amount1, stygos.U256{}, err
+	}
+	if err := token.SafeTransferFrom(token.NewERC20(s.Token1), sender, self, amount1); err != nil {
+		return amount0, amount1, stygos.U256{}, err
+	}
+	return amount0, amount1, shares, nil
+}
+
+// RemoveLiquidity burns shares of the caller and sends it the matching
+// part of both reserves.
+func (p *Pool) RemoveLiquidity(shares, amount0Min, amount1Min stygos.U256) (amount0, amount1 stygos.U256, err error) {
+	unlock, err := p.lock()
+	if err != nil {
+		return amount0, amount1, err
+	}
+	defer unlock()
+
+	s := p.load()
+	sender := stygos.GetMsgSender()
+	slot := p.sharesSlot(sender)
+	held := stygos.U256FromWord(stygos.StorageLoad(slot))
+	if shares.IsZero() {
+		return amount0, amount1, ErrZeroAmount
+	}
+	if held.Lt(shares) {
+		return amount0, amount1, ErrInsufficientShares
+	}
+
+	amount0 = shares.Mul(s.Reserve0).Div(s.TotalShares)
+	amount1 = shares.Mul(s.Reserve1).Div(s.TotalShares)
+	if amount0.Lt(amount0Min) || amount1.Lt(amount1Min) {
+		return amount0, amount1, ErrInsufficientAmount
+	}
+
+	stygos.StorageStore(slot, held.Sub(shares).Word())
+	s.TotalShares = s.TotalShares.Sub(shares)
+	s.update(s.Reserve0.Sub(amount0), s.Reserve1.Sub(amount1))
+	s.Store(p.base)
+
+	if err := token.SafeTransfer(token.NewERC20(s.Token0), sender, amount0); err != nil {
+		return amount0, amount1, err
+	}
+	if err := token.SafeTransfer(token.NewERC20(s.Token1), sender, amount1); err != nil {
+		return amount0, amount1, err
+	}
+	return amount0, amount1, nil
+}
+
+// Swap sells amountIn of tokenIn from the caller for the other token and
+// returns the amount bought, which must be at least amountOutMin.
+func (p *Pool) Swap(tokenIn stygos.Address, amountIn, amountOutMin stygos.U256) (stygos.U256, error) {
+	unlock, err := p.lock()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	defer unlock()
+
+	s := p.load()
+	if s.Token0 == (stygos.Address{}) || (tokenIn != s.Token0 && tokenIn != s.Token1) {
+		return stygos.U256{}, ErrInvalidToken
+	}
+	if amountIn.IsZero() {
+		return stygos.U256{}, ErrZeroAmount
+	}
+
+	zeroForOne := tokenIn == s.Token0
+	reserveIn, reserveOut, tokenOut := s.Reserve0, s.Reserve1, s.Token1
+	if !zeroForOne {
+		reserveIn, reserveOut, tokenOut = s.Reserve1, s.Reserve0, s.Token0
+	}
+	if reserveIn.IsZero() || reserveOut.IsZero() {
+		return stygos.U256{}, ErrInsufficientLiquidity
+	}
+	if amountIn.Gt(maxReserve.Sub(reserveIn)) {
+		return stygos.U256{}, ErrOverflow
+	}
+	amountOut := GetAmountOut(amountIn, reserveIn, reserveOut, s.FeeBps)
+	if amountOut.IsZero() {
+		return stygos.U256{}, ErrInsufficientLiquidity
+	}
+	if amountOut.Lt(amountOutMin) {
+		return amountOut, ErrInsufficientAmount
+	}
+
+	if zeroForOne {
+		s.update(reserveIn.Add(amountIn), reserveOut.Sub(amountOut))
+	} else {
+		s.update(reserveOut.Sub(amountOut), reserveIn.Add(amountIn))
+	}
+	s.Store(p.base)
+
+	sender := stygos.GetMsgSender()
+	if err := token.SafeTransferFrom(token.NewERC20(tokenIn), sender, stygos.GetContractAddress(), amountIn); err != nil {
+		return stygos.U256{}, err
+	}
+	if err := token.SafeTransfer(token.NewERC20(tokenOut), sender, amountOut); err != nil {
+		return stygos.U256{}, err
+	}
+	return amountOut, nil
+}
+
+// CurrentCumulativePrices returns the price accumulators advanced to the
+// current block timestamp, and that timestamp.
+func (p *Pool) CurrentCumulativePrices() (price0, price1 stygos.U256, timestamp uint64) {
+	s := p.load()
+	s.accumulate(stygos.GetBlockTimestamp())
+	return s.Price0Cumulative, s.Price1Cumulative, s.TimestampLast
+}
+
+// update advances the price accumulators to now and stores new reserves.
+func (s *State) update(reserve0, reserve1 stygos.U256) {
+	s.accumulate(stygos.GetBlockTimestamp())
+	s.Reserve0, s.Reserve1 = reserve0, reserve1
+}
+
+// accumulate adds the prices at the current reserves for the time elapsed
+// since TimestampLast.
+func (s *State) accumulate(now uint64) {
+	if now <= s.TimestampLast {
+		return
+	}
+	if !s.Reserve0.IsZero() && !s.Reserve1.IsZero() {
+		elapsed := stygos.NewU256(now - s.TimestampLast)
+		s.Price0Cumulative = s.Price0Cumulative.Add(s.Reserve1.Lsh(112).Div(s.Reserve0).Mul(elapsed))
+		s.Price1Cumulative = s.Price1Cumulative.Add(s.Reserve0.Lsh(112).Div(s.Reserve1).Mul(elapsed))
+	}
+	s.TimestampLast = now
+}
+
+// lock fails with ErrLocked if the pool is already in use in this call
+// chain, and marks it in use until unlock is called. The flag lives in
+// transient storage where the chain has it, and in storage before.
+func (p *Pool) lock() (unlock func(), err error) {
+	key := storage.Offset(p.base, StatePackedWords+1)
+	load, store := stygos.TransientLoad, stygos.TransientStore
+	if !stygos.Supports(stygos.CapTransientStorage) {
+		load = func(key stygos.Word) (stygos.Word, error) { return stygos.StorageLoad(key), nil }
+		store = func(key, value stygos.Word) error { stygos.StorageStore(key, value); return nil }
+	}
+	held, err := load(key)
+	if err != nil {
+		return nil, err
+	}
+	if !held.IsZero() {
+		return nil, ErrLocked
+	}
+	if err := store(key, stygos.WordFromUint64(1)); err != nil {
+		return nil, err
+	}
+	return func() { store(key, stygos.Word{}) }, nil
+}
+
+func (p *Pool) mint(s *State, to stygos.Address, shares stygos.U256) {
+	slot := p.sharesSlot(to)
+	held := stygos.U256FromWord(stygos.StorageLoad(slot))
+	stygos.StorageStore(slot, held.Add(shares).Word())
+	s.TotalShares = s.TotalShares.Add(shares)
+}
+
+func (p *Pool) load() State {
+	var s State
+	s.Load(p.base)
+	return s
+}
+
+func (p *Pool) sharesSlot(account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(p.base, StatePackedWords), account[:])
+}
+
+// --- Pure math ---
+
+// Quote returns the amount of token B worth amountA at reserves
+// reserveA:reserveB, without fee.
+func Quote(amountA, reserveA, reserveB stygos.U256) stygos.U256 {
+	return amountA.Mul(reserveB).Div(reserveA)
+}
+
+// GetAmountOut returns the output of selling amountIn against the given
+// reserves with a fee in basis points, keeping reserveIn*reserveOut
+// constant after the fee.
+func GetAmountOut(amountIn, reserveIn, reserveOut stygos.U256, feeBps uint16) stygos.U256 {
+	amountInWithFee := amountIn.Mul(stygos.NewU256(FeeDenominator - uint64(feeBps)))
+	numerator := amountInWithFee.Mul(reserveOut)
+	denominator := reserveIn.Mul(stygos.NewU256(FeeDenominator)).Add(amountInWithFee)
+	return numerator.Div(denominator)
+}
+
+// AveragePrice returns the TWAP as UQ112x112 between two readings of a
+// cumulative price taken elapsed seconds apart. Wrapping of the
+// accumulator is accounted for. Readings from the same block have no
+// average, and fail with ErrZeroElapsed.
+func AveragePrice(cumulativeStart, cumulativeEnd stygos.U256, elapsed uint64) (stygos.U256, error) {
+	if elapsed == 0 {
+		return stygos.U256{}, ErrZeroElapsed
+	}
+	return cumulativeEnd.Sub(cumulativeStart).Div(stygos.NewU256(elapsed)), nil
+}
@@ -0,0 +1,246 @@
+package amm
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	poolAddr = stygos.Address{0x90}
+	token0   = stygos.Address{0x70}
+	token1   = stygos.Address{0x71}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+)
+
+// setup deploys both tokens and funds alice and bob, who approve the pool.
+func setup() (*stygos.MockRuntime, *token.MockERC20, *token.MockERC20) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = poolAddr
+	mock.Time = 1000
+	stygos.UseRuntime(mock)
+
+	t0 := token.InstallMockERC20(mock, token0)
+	t1 := token.InstallMockERC20(mock, token1)
+	max := stygos.U256{}.Not()
+	for _, acc := range []stygos.Address{alice, bob} {
+		t0.Mint(acc, stygos.NewU256(1e18))
+		t1.Mint(acc, stygos.NewU256(1e18))
+		t0.Approve(acc, poolAddr, max)
+		t1.Approve(acc, poolAddr, max)
+	}
+	return mock, t0, t1
+}
+
+func TestMath(t *testing.T) {
+	// 0.3% fee: 1000 in against 1e6:1e6 reserves
+	if got := GetAmountOut(stygos.NewU256(1000), stygos.NewU256(1e6), stygos.NewU256(1e6), 30); got.Uint64() != 996 {
+		t.Errorf("GetAmountOut failed. Expected 996, got %d", got.Uint64())
+	}
+	if got := Quote(stygos.NewU256(10), stygos.NewU256(100), stygos.NewU256(250)); got.Uint64() != 25 {
+		t.Errorf("Quote failed. Expected 25, got %d", got.Uint64())
+	}
+}
+
+func TestLiquidity(t *testing.T) {
+	mock, t0, t1 := setup()
+	pool := NewPool(stygos.Word{0xaa})
+	if err := pool.Initialize(token0, token0, 30); err != ErrIdenticalTokens {
+		t.Errorf("Initialize failed. Expected ErrIdenticalTokens, got %v", err)
+	}
+	if err := pool.Initialize(token0, token1, 30); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := pool.Initialize(token0, token1, 30); err != ErrInitialized {
+		t.Errorf("Initialize failed. Expected ErrInitialized, got %v", err)
+	}
+
+	// First deposit sets the 1:4 ratio and locks the minimum liquidity
+	mock.Sender = alice
+	a0, a1, shares, err := pool.AddLiquidity(stygos.NewU256(1e6), stygos.NewU256(4e6), stygos.U256{}, stygos.U256{})
+	if err != nil {
+		t.Fatalf("AddLiquidity failed: %v", err)
+	}
+	if a0.Uint64() != 1e6 || a1.Uint64() != 4e6 || shares.Uint64() != 2e6-MinimumLiquidity {
+		t.Errorf("AddLiquidity failed. Got %d %d %d", a0.Uint64(), a1.Uint64(), shares.Uint64())
+	}
+
+	// Later deposits follow the ratio, taking only what it allows
+	mock.Sender = bob
+	a0, a1, shares, err = pool.AddLiquidity(stygos.NewU256(1e6), stygos.NewU256(1e6), stygos.U256{}, stygos.U256{})
+	if err != nil {
+		t.Fatalf("AddLiquidity failed: %v", err)
+	}
+	if a0.Uint64() != 250000 || a1.Uint64() != 1e6 || shares.Uint64() != 5e5 {
+		t.Errorf("AddLiquidity failed. Expected 250000 1000000 500000, got %d %d %d", a0.Uint64(), a1.Uint64(), shares.Uint64())
+	}
+	if _, _, _, err := pool.AddLiquidity(stygos.NewU256(1e6), stygos.NewU256(1e6), stygos.NewU256(1e6), stygos.U256{}); err != ErrInsufficientAmount {
+		t.Errorf("AddLiquidity failed. Expected ErrInsufficientAmount, got %v", err)
+	}
+
+	s := pool.State()
+	if s.Reserve0.Uint64() != 1250000 || s.Reserve1.Uint64() != 5e6 || s.TotalShares.Uint64() != 25e5 {
+		t.Errorf("State failed. Got reserves %d %d shares %d", s.Reserve0.Uint64(), s.Reserve1.Uint64(), s.TotalShares.Uint64())
+	}
+	if got := t0.Balances[poolAddr]; got.Uint64() != 1250000 {
+		t.Errorf("Token balance failed. Expected 1250000, got %d", got.Uint64())
+	}
+
+	if _, _, err := pool.RemoveLiquidity(stygos.NewU256(5e5+1), stygos.U256{}, stygos.U256{}); err != ErrInsufficientShares {
+		t.Errorf("RemoveLiquidity failed. Expected ErrInsufficientShares, got %v", err)
+	}
+	r0, r1, err := pool.RemoveLiquidity(stygos.NewU256(5e5), stygos.U256{}, stygos.U256{})
+	if err != nil || r0.Uint64() != 250000 || r1.Uint64() != 1e6 {
+		t.Errorf("RemoveLiquidity failed. Expected 250000 1000000, got %d %d, %v", r0.Uint64(), r1.Uint64(), err)
+	}
+	if got := t1.Balances[bob]; got.Uint64() != 1e18 {
+		t.Errorf("RemoveLiquidity failed. Expected bob's tokens back, got %d", got.Uint64())
+	}
+	if got := pool.SharesOf(stygos.Address{}); got.Uint64() != MinimumLiquidity {
+		t.Errorf("SharesOf failed. Expected %d locked, got %d", MinimumLiquidity, got.Uint64())
+	}
+}
+
+func TestSwap(t *testing.T) {
+	mock, t0, t1 := setup()
+	pool := NewPool(stygos.Word{0xab})
+	pool.Initialize(token0, token1, 30)
+
+	mock.Sender = alice
+	pool.AddLiquidity(stygos.NewU256(1e6), stygos.NewU256(1e6), stygos.U256{}, stygos.U256{})
+
+	mock.Sender = bob
+	if _, err := pool.Swap(stygos.Address{0x01}, stygos.NewU256(1000), stygos.U256{}); err != ErrInvalidToken {
+		t.Errorf("Swap failed. Expected ErrInvalidToken, got %v", err)
+	}
+	if _, err := pool.Swap(token0, stygos.NewU256(1000), stygos.NewU256(997)); err != ErrInsufficientAmount {
+		t.Errorf("Swap failed. Expected ErrInsufficientAmount, got %v", err)
+	}
+	out, err := pool.Swap(token0, stygos.NewU256(1000), stygos.NewU256(996))
+	if err != nil || out.Uint64() != 996 {
+		t.Fatalf("Swap failed. Expected 996, got %d, %v", out.Uint64(), err)
+	}
+	if got := t1.Balances[bob]; got.Uint64() != 1e18+996 {
+		t.Errorf("Swap failed. Expected bob to receive 996, got %d", got.Uint64()-1e18)
+	}
+
+	// The invariant grows by the fee
+	s := pool.State()
+	k := s.Reserve0.Mul(s.Reserve1)
+	if !k.Gt(stygos.NewU256(1e12)) {
+		t.Errorf("Swap failed. Expected k above 1e12, got %v", k.Big())
+	}
+
+	// And back again
+	out, err = pool.Swap(token1, stygos.NewU256(996), stygos.U256{})
+	if err != nil || out.Uint64() != 994 {
+		t.Errorf("Swap failed. Expected 994, got %d, %v", out.Uint64(), err)
+	}
+	if got := t0.Balances[poolAddr]; got != pool.State().Reserve0 {
+		t.Errorf("Reserves failed. Expected reserve0 to match balance %d, got %d", got.Uint64(), pool.State().Reserve0.Uint64())
+	}
+
+	// Beyond the 112-bit reserve cap
+	if _, err := pool.Swap(token0, stygos.NewU256(1).Lsh(112), stygos.U256{}); err != ErrOverflow {
+		t.Errorf("Swap failed. Expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestTWAP(t *testing.T) {
+	mock, _, _ := setup()
+	pool := NewPool(stygos.Word{0xac})
+	pool.Initialize(token0, token1, 0)
+
+	// 1 token0 = 2 token1 for 100 seconds, then 1 = 8 for 300 seconds
+	mock.Sender = alice
+	pool.AddLiquidity(stygos.NewU256(1e6), stygos.NewU256(2e6), stygos.U256{}, stygos.U256{})
+	start0, start1, ts := pool.CurrentCumulativePrices()
+	if ts != 1000 {
+		t.Errorf("CurrentCumulativePrices failed. Expected timestamp 1000, got %d", ts)
+	}
+
+	mock.Time += 100
+	mock.Sender = bob
+	pool.Swap(token1, stygos.NewU256(2e6), stygos.U256{}) // reserves become 5e5:4e6
+
+	mock.Time += 300
+	end0, end1, ts := pool.CurrentCumulativePrices()
+	if ts != 1400 {
+		t.Errorf("CurrentCumulativePrices failed. Expected timestamp 1400, got %d", ts)
+	}
+
+	// (2*100 + 8*300) / 400 = 6.5, and (0.5*100 + 0.125*300) / 400 = 0.21875
+	q112 := stygos.NewU256(1).Lsh(112)
+	avg0, err := AveragePrice(start0, end0, 400)
+	if want := stygos.NewU256(13).Mul(q112).Div(stygos.NewU256(2)); err != nil || avg0 != want {
+		t.Errorf("AveragePrice failed. Expected %v, got %v, %v", want.Big(), avg0.Big(), err)
+	}
+	avg1, err := AveragePrice(start1, end1, 400)
+	if want := stygos.NewU256(7).Mul(q112).Div(stygos.NewU256(32)); err != nil || avg1 != want {
+		t.Errorf("AveragePrice failed. Expected %v, got %v, %v", want.Big(), avg1.Big(), err)
+	}
+
+	// Two readings in the same block span no time
+	if _, err := AveragePrice(end0, end0, 0); err != ErrZeroElapsed {
+		t.Errorf("AveragePrice failed. Expected ErrZeroElapsed, got %v", err)
+	}
+
+	// Reading does not store anything
+	if s := pool.State(); s.TimestampLast != 1100 {
+		t.Errorf("State failed. Expected last update at 1100, got %d", s.TimestampLast)
+	}
+}
+
+func TestReentrancyLock(t *testing.T) {
+	for _, arbos := range []uint64{stygos.ArbOS20, stygos.ArbOS30} {
+		mock, t0, _ := setup()
+		mock.ArbOS = arbos
+		pool := NewPool(stygos.Word{0xcc})
+		if err := pool.Initialize(token0, token1, 30); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+		mock.Sender = alice
+		if _, _, _, err := pool.AddLiquidity(stygos.NewU256(1e6), stygos.NewU256(1e6), stygos.U256{}, stygos.U256{}); err != nil {
+			t.Fatalf("AddLiquidity failed: %v", err)
+		}
+
+		// bob swaps token0 in a transaction, and token0 calls back into the
+		// pool, which swaps token1, while pulling the input
+		var inner error
+		mock.Deploy(poolAddr, func(input []byte) ([]byte, error) {
+			if len(input) == 0 {
+				_, inner = pool.Swap(token1, stygos.NewU256(1000), stygos.U256{})
+				return nil, inner
+			}
+			_, err := pool.Swap(token0, stygos.NewU256(1000), stygos.U256{})
+			return nil, err
+		})
+		erc20 := mock.Contracts[token0]
+		mock.Deploy(token0, func(input []byte) ([]byte, error) {
+			if _, err := stygos.Call(poolAddr, stygos.Word{}, nil); err != nil {
+				return nil, err
+			}
+			return erc20(input)
+		})
+		mock.Contract = bob
+		if _, err := stygos.Call(poolAddr, stygos.Word{}, []byte{1}); err == nil {
+			t.Errorf("ArbOS %d: Swap failed. Expected the reentrant swap to fail the outer one", arbos)
+		}
+		mock.Contract = poolAddr
+		if inner != ErrLocked {
+			t.Errorf("ArbOS %d: Swap failed. Expected ErrLocked on reentry, got %v", arbos, inner)
+		}
+
+		// The lock is released after the reverted swap
+		mock.Deploy(token0, erc20)
+		mock.Sender = bob
+		if _, err := pool.Swap(token0, stygos.NewU256(1000), stygos.U256{}); err != nil {
+			t.Errorf("ArbOS %d: Swap failed after the lock was released: %v", arbos, err)
+		}
+		if s := pool.State(); s.Reserve0 != t0.Balances[poolAddr] {
+			t.Errorf("ArbOS %d: State failed. Expected reserve0 to match the balance %v, got %v", arbos, t0.Balances[poolAddr].Big(), s.Reserve0.Big())
+		}
+	}
+}
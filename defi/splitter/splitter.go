@@ -0,0 +1,205 @@
+// Package splitter splits payments among payees in proportion to fixed
+// shares. ETH sent to the contract and ERC-20 tokens transferred to it
+// accumulate there; each payee's due part is paid out on request (pull
+// payments), so one failing payee never blocks the others. Released ETH is
+// credited to a payment.PullPayment, from which payees withdraw it.
+package splitter
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/payment"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Splitter errors
+var (
+	ErrInitialized    = errors.New("splitter: already initialized")
+	ErrNoPayees       = errors.New("splitter: no payees")
+	ErrLengthMismatch = errors.New("splitter: payees and shares length mismatch")
+	ErrZeroAddress    = errors.New("splitter: payee is the zero address")
+	ErrZeroShares     = errors.New("splitter: shares are zero")
+	ErrDuplicatePayee = errors.New("splitter: duplicate payee")
+	ErrNoShares       = errors.New("splitter: account has no shares")
+	ErrNothingDue     = errors.New("splitter: account is not due payment")
+)
+
+// Splitter is a payment splitter.
+//
+// Storage layout relative to the base slot:
+//
+//	base                                          total shares
+//	Offset(base, 1)                               total ETH released
+//	Offset(base, 2)                               payees (WordArray)
+//	MapKey(Offset(base, 3), payee)                shares
+//	MapKey(Offset(base, 4), payee)                ETH released
+//	MapKey(Offset(base, 5), token)                total token released
+//	MapKey(MapKey(Offset(base, 6), token), payee) token released
+//	Offset(base, 7)                               payment.PullPayment of released ETH
+type Splitter struct {
+	*payment.PullPayment
+	base stygos.Word
+}
+
+// NewSplitter returns the splitter rooted at base.
+func NewSplitter(base stygos.Word) *Splitter {
+	return &Splitter{PullPayment: payment.NewPullPayment(storage.Offset(base, 7)), base: base}
+}
+
+// Initialize sets the payees and their shares, once.
+func (s *Splitter) Initialize(payees []stygos.Address, shares []stygos.U256) error {
+	if !s.TotalShares().IsZero() {
+		return ErrInitialized
+	}
+	if len(payees) == 0 {
+		return ErrNoPayees
+	}
+	if len(payees) != len(shares) {
+		return ErrLengthMismatch
+	}
+	total := stygos.U256{}
+	for i, payee := range payees {
+		if payee == (stygos.Address{}) {
+			return ErrZeroAddress
+		}
+		if shares[i].IsZero() {
+			return ErrZeroShares
+		}
+		if !s.Shares(payee).IsZero() {
+			return ErrDuplicatePayee
+		}
+		stygos.StorageStore(s.sharesSlot(payee), shares[i].Word())
+		s.payees().Push(stygos.PadAddress(payee))
+		total = total.Add(shares[i])
+	}
+	stygos.StorageStore(s.base, total.Word())
+	return nil
+}
+
+// TotalShares returns the sum of all shares.
+func (s *Splitter) TotalShares() stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(s.base))
+}
+
+// Shares returns the shares of account.
+func (s *Splitter) Shares(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(s.sharesSlot(account)))
+}
+
+// PayeeCount returns the number of payees.
+func (s *Splitter) PayeeCount() uint64 {
+	return s.payees().Len()
+}
+
+// Payee returns payee i in initialization order.
+func (s *Splitter) Payee(i uint64) stygos.Address {
+	return stygos.AddressFromWord(s.payees().Get(i))
+}
+
+// TotalReleased returns the ETH paid out so far.
+func (s *Splitter) TotalReleased() stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(storage.Offset(s.base, 1)))
+}
+
+// Released returns the ETH paid out to account.
+func (s *Splitter) Released(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(s.releasedSlot(account)))
+}
+
+// TotalReleasedToken returns the tokens of tokenAddr paid out so far.
+func (s *Splitter) TotalReleasedToken(tokenAddr stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(s.tokenTotalSlot(tokenAddr)))
+}
+
+// ReleasedToken returns the tokens of tokenAddr paid out to account.
+func (s *Splitter) ReleasedToken(tokenAddr, account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(s.tokenReleasedSlot(tokenAddr, account)))
+}
+
+// Releasable returns the ETH account can be paid now. Released ETH not yet
+// withdrawn is not counted as received again.
+func (s *Splitter) Releasable(account stygos.Address) stygos.U256 {
+	balance := stygos.GetBalance(stygos.GetContractAddress()).Sub(s.TotalOwed())
+	received := balance.Add(s.TotalReleased())
+	return s.pending(account, received, s.Released(account))
+}
+
+// ReleasableToken returns the tokens of tokenAddr account can be paid now.
+func (s *Splitter) ReleasableToken(tokenAddr, account stygos.Address) stygos.U256 {
+	balance, err := token.NewERC20(tokenAddr).BalanceOf(stygos.GetContractAddress())
+	if err != nil {
+		return stygos.U256{}
+	}
+	received := balance.Add(s.TotalReleasedToken(tokenAddr))
+	return s.pending(account, received, s.ReleasedToken(tokenAddr, account))
+}
+
+// Release credits account its due ETH and returns the amount; the account
+// collects it with WithdrawPayments. Anyone may call it since the payment
+// only goes to account.
+func (s *Splitter) Release(account stygos.Address) (stygos.U256, error) {
+	if s.Shares(account).IsZero() {
+		return stygos.U256{}, ErrNoShares
+	}
+	amount := s.Releasable(account)
+	if amount.IsZero() {
+		return amount, ErrNothingDue
+	}
+	stygos.StorageStore(s.releasedSlot(account), s.Released(account).Add(amount).Word())
+	stygos.StorageStore(storage.Offset(s.base, 1), s.TotalReleased().Add(amount).Word())
+	s.Credit(account, amount)
+	return amount, nil
+}
+
+// ReleaseToken pays account its due tokens of tokenAddr and returns the
+// amount.
+func (s *Splitter) ReleaseToken(tokenAddr, account stygos.Address) (stygos.U256, error) {
+	if s.Shares(account).IsZero() {
+		return stygos.U256{}, ErrNoShares
+	}
+	amount := s.ReleasableToken(tokenAddr, account)
+	if amount.IsZero() {
+		return amount, ErrNothingDue
+	}
+	slot := s.tokenReleasedSlot(tokenAddr, account)
+	stygos.StorageStore(slot, s.ReleasedToken(tokenAddr, account).Add(amount).Word())
+	stygos.StorageStore(s.tokenTotalSlot(tokenAddr), s.TotalReleasedToken(tokenAddr).Add(amount).Word())
+	return amount, token.SafeTransfer(token.NewERC20(tokenAddr), account, amount)
+}
+
+// pending returns account's part of received minus what it was paid.
+// received*shares must fit in 256 bits, which holds for any realistic
+// share count.
+func (s *Splitter) pending(account stygos.Address, received, released stygos.U256) stygos.U256 {
+	total := s.TotalShares()
+	if total.IsZero() {
+		return stygos.U256{}
+	}
+	due := received.Mul(s.Shares(account)).Div(total)
+	if due.Lt(released) {
+		return stygos.U256{}
+	}
+	return due.Sub(released)
+}
+
+func (s *Splitter) payees() storage.WordArray {
+	return storage.NewWordArray(storage.Offset(s.base, 2))
+}
+
+func (s *Splitter) sharesSlot(account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(s.base, 3), account[:])
+}
+
+func (s *Splitter) releasedSlot(account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(s.base, 4), account[:])
+}
+
+func (s *Splitter) tokenTotalSlot(tokenAddr stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(s.base, 5), tokenAddr[:])
+}
+
+func (s *Splitter) tokenReleasedSlot(tokenAddr, account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.MapKey(storage.Offset(s.base, 6), tokenAddr[:]), account[:])
+}
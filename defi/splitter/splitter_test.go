@@ -0,0 +1,143 @@
+package splitter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	contract = stygos.Address{0xc0}
+	tokenAdr = stygos.Address{0x70}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+	carol    = stygos.Address{0xca}
+)
+
+func setup(t *testing.T) (*stygos.MockRuntime, *Splitter) {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	stygos.UseRuntime(mock)
+
+	s := NewSplitter(stygos.Word{0x5b})
+	err := s.Initialize(
+		[]stygos.Address{alice, bob, carol},
+		[]stygos.U256{stygos.NewU256(50), stygos.NewU256(30), stygos.NewU256(20)})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return mock, s
+}
+
+func TestInitialize(t *testing.T) {
+	_, s := setup(t)
+	if got := s.TotalShares(); got.Uint64() != 100 {
+		t.Errorf("TotalShares failed. Expected 100, got %d", got.Uint64())
+	}
+	if s.PayeeCount() != 3 || s.Payee(1) != bob {
+		t.Errorf("Payee failed. Expected 3 payees with bob second, got %d, %x", s.PayeeCount(), s.Payee(1))
+	}
+	if err := s.Initialize([]stygos.Address{alice}, []stygos.U256{stygos.NewU256(1)}); err != ErrInitialized {
+		t.Errorf("Initialize failed. Expected ErrInitialized, got %v", err)
+	}
+
+	tests := []struct {
+		payees []stygos.Address
+		shares []stygos.U256
+		err    error
+	}{
+		{nil, nil, ErrNoPayees},
+		{[]stygos.Address{alice}, nil, ErrLengthMismatch},
+		{[]stygos.Address{{}}, []stygos.U256{stygos.NewU256(1)}, ErrZeroAddress},
+		{[]stygos.Address{alice}, []stygos.U256{{}}, ErrZeroShares},
+		{[]stygos.Address{alice, alice}, []stygos.U256{stygos.NewU256(1), stygos.NewU256(1)}, ErrDuplicatePayee},
+	}
+	for i, tt := range tests {
+		if err := NewSplitter(stygos.Word{0x60, byte(i)}).Initialize(tt.payees, tt.shares); err != tt.err {
+			t.Errorf("Initialize %d failed. Expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+func TestReleaseETH(t *testing.T) {
+	mock, s := setup(t)
+	mock.SetBalance(contract, big.NewInt(1000))
+
+	if amount, err := s.Release(alice); err != nil || amount.Uint64() != 500 {
+		t.Fatalf("Release failed. Expected 500, got %d, %v", amount.Uint64(), err)
+	}
+	if _, err := s.Release(alice); err != ErrNothingDue {
+		t.Errorf("Release failed. Expected ErrNothingDue, got %v", err)
+	}
+	if _, err := s.Release(stygos.Address{0xee}); err != ErrNoShares {
+		t.Errorf("Release failed. Expected ErrNoShares, got %v", err)
+	}
+
+	// More ETH arrives after alice was paid
+	mock.SetBalance(contract, new(big.Int).Add(mock.BalanceOf(contract), big.NewInt(1000)))
+	if got := s.Releasable(alice); got.Uint64() != 500 {
+		t.Errorf("Releasable failed. Expected 500, got %d", got.Uint64())
+	}
+	if got := s.Releasable(bob); got.Uint64() != 600 {
+		t.Errorf("Releasable failed. Expected 600, got %d", got.Uint64())
+	}
+	for _, acc := range []stygos.Address{alice, bob, carol} {
+		if _, err := s.Release(acc); err != nil {
+			t.Fatalf("Release failed: %v", err)
+		}
+	}
+	want := map[stygos.Address]uint64{alice: 1000, bob: 600, carol: 400}
+	for acc, amount := range want {
+		if got := s.Payments(acc); got.Uint64() != amount {
+			t.Errorf("Release failed. Expected %x to be owed %d, got %d", acc, amount, got.Uint64())
+		}
+	}
+
+	// Withdrawals do not change what is due
+	for acc, amount := range want {
+		if _, err := s.WithdrawPayments(acc); err != nil {
+			t.Fatalf("WithdrawPayments failed: %v", err)
+		}
+		if got := mock.BalanceOf(acc); got.Uint64() != amount {
+			t.Errorf("WithdrawPayments failed. Expected %x to hold %d, got %v", acc, amount, got)
+		}
+	}
+	mock.SetBalance(contract, big.NewInt(100))
+	if got := s.Releasable(carol); got.Uint64() != 20 {
+		t.Errorf("Releasable failed. Expected 20, got %d", got.Uint64())
+	}
+	if got := s.TotalReleased(); got.Uint64() != 2000 {
+		t.Errorf("TotalReleased failed. Expected 2000, got %d", got.Uint64())
+	}
+}
+
+func TestReleaseToken(t *testing.T) {
+	mock, s := setup(t)
+	tok := token.InstallMockERC20(mock, tokenAdr)
+	tok.Mint(contract, stygos.NewU256(10_000))
+
+	if amount, err := s.ReleaseToken(tokenAdr, carol); err != nil || amount.Uint64() != 2000 {
+		t.Fatalf("ReleaseToken failed. Expected 2000, got %d, %v", amount.Uint64(), err)
+	}
+	tok.Mint(contract, stygos.NewU256(5_000))
+	if amount, err := s.ReleaseToken(tokenAdr, carol); err != nil || amount.Uint64() != 1000 {
+		t.Errorf("ReleaseToken failed. Expected 1000, got %d, %v", amount.Uint64(), err)
+	}
+	if got := s.ReleasableToken(tokenAdr, alice); got.Uint64() != 7500 {
+		t.Errorf("ReleasableToken failed. Expected 7500, got %d", got.Uint64())
+	}
+	if got := tok.Balances[carol]; got.Uint64() != 3000 {
+		t.Errorf("ReleaseToken failed. Expected carol to hold 3000, got %d", got.Uint64())
+	}
+	if got := s.ReleasedToken(tokenAdr, carol); got.Uint64() != 3000 {
+		t.Errorf("ReleasedToken failed. Expected 3000, got %d", got.Uint64())
+	}
+
+	// ETH accounting is independent of tokens
+	if _, err := s.Release(carol); err != ErrNothingDue {
+		t.Errorf("Release failed. Expected ErrNothingDue, got %v", err)
+	}
+}
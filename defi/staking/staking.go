@@ -0,0 +1,325 @@
+// Package staking distributes a reward token to stakers of another token
+// pro rata to their stake and the time staked, using the
+// reward-per-token-stored pattern of Synthetix's StakingRewards.
+//
+// A reward period is started with NotifyRewardAmount, which spreads the
+// reward evenly over the rewards duration. The pool tracks the reward
+// accrued per staked token since its creation; each account remembers the
+// value it was last settled at, so every operation is O(1) regardless of
+// the number of stakers.
+package staking
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Staking errors
+var (
+	ErrInitialized         = errors.New("staking: already initialized")
+	ErrNotInitialized      = errors.New("staking: not initialized")
+	ErrInvalidDuration     = errors.New("staking: duration must be positive")
+	ErrZeroAmount          = errors.New("staking: zero amount")
+	ErrInsufficientBalance = errors.New("staking: withdraw amount exceeds stake")
+	ErrRewardTooHigh       = errors.New("staking: reward exceeds balance")
+	ErrPeriodActive        = errors.New("staking: reward period not finished")
+)
+
+// precision scales the reward per token, 1e18.
+var precision = stygos.NewU256(1e18)
+
+// State is the pool state, packed into storage by state_pack_gen.go.
+//
+//go:generate stygos-gen pack -type State -o state_pack_gen.go
+type State struct {
+	StakingToken    stygos.Address
+	PeriodFinish    uint64 // unix seconds
+	RewardsToken    stygos.Address
+	LastUpdateTime  uint64 // unix seconds
+	RewardsDuration uint64 // seconds
+
+	RewardRate           stygos.U256 // reward tokens per second
+	RewardPerTokenStored stygos.U256 // scaled by 1e18
+	TotalStaked          stygos.U256
+}
+
+// Pool is a staking rewards pool.
+//
+// Storage layout relative to the base slot:
+//
+//	base ...                                       State (StatePackedWords slots)
+//	MapKey(Offset(base, StatePackedWords), acc)    stake of acc
+//	MapKey(Offset(base, StatePackedWords), acc)+1  reward per token paid to acc
+//	MapKey(Offset(base, StatePackedWords), acc)+2  rewards owed to acc
+type Pool struct {
+	base stygos.Word
+}
+
+// NewPool returns the staking pool rooted at base.
+func NewPool(base stygos.Word) *Pool {
+	return &Pool{base: base}
+}
+
+// Initialize sets the staked and reward tokens and the length of reward
+// periods in seconds.
+func (p *Pool) Initialize(stakingToken, rewardsToken stygos.Address, duration uint64) error {
+	s := p.load()
+	if s.StakingToken != (stygos.Address{}) {
+		return ErrInitialized
+	}
+	if duration == 0 {
+		return ErrInvalidDuration
+	}
+	s.StakingToken, s.RewardsToken, s.RewardsDuration = stakingToken, rewardsToken, duration
+	s.Store(p.base)
+	return nil
+}
+
+// State returns the pool state.
+func (p *Pool) State() State {
+	return p.load()
+}
+
+// BalanceOf returns the stake of account.
+func (p *Pool) BalanceOf(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(p.accountSlot(account)))
+}
+
+// LastTimeRewardApplicable returns the current time, or the end of the
+// reward period once it has passed.
+func (p *Pool) LastTimeRewardApplicable() uint64 {
+	s := p.load()
+	return s.lastTimeRewardApplicable()
+}
+
+// RewardPerToken returns the reward accrued per staked token since the
+// pool was created, scaled by 1e18.
+func (p *Pool) RewardPerToken() stygos.U256 {
+	s := p.load()
+	return s.rewardPerToken()
+}
+
+// Earned returns the rewards account can claim.
+func (p *Pool) Earned(account stygos.Address) stygos.U256 {
+	s := p.load()
+	return p.earned(&s, account)
+}
+
+// Stake deposits amount of the staking token from the caller, which must
+// have approved the pool contract. The stake is recorded before the tokens
+// are pulled, so a token that calls back sees it.
+func (p *Pool) Stake(amount stygos.U256) error {
+	if amount.IsZero() {
+		return ErrZeroAmount
+	}
+	s, err := p.loadInitialized()
+	if err != nil {
+		return err
+	}
+	sender := stygos.GetMsgSender()
+	p.updateReward(&s, sender)
+
+	stygos.StorageStore(p.accountSlot(sender), p.BalanceOf(sender).Add(amount).Word())
+	s.TotalStaked = s.TotalStaked.Add(amount)
+	s.Store(p.base)
+	return token.SafeTransferFrom(token.NewERC20(s.StakingToken), sender, stygos.GetContractAddress(), amount)
+}
+
+// Withdraw returns amount of the caller's stake.
+func (p *Pool) Withdraw(amount stygos.U256) error {
+	if amount.IsZero() {
+		return ErrZeroAmount
+	}
+	s, err := p.loadInitialized()
+	if err != nil {
+		return err
+	}
+	sender := stygos.GetMsgSender()
+	balance := p.BalanceOf(sender)
+	if balance.Lt(amount) {
+		return ErrInsufficientBalance
+	}
+	p.updateReward(&s, sender)
+
+	stygos.StorageStore(p.accountSlot(sender), balance.Sub(amount).Word())
+	s.TotalStaked = s.TotalStaked.Sub(amount)
+	s.Store(p.base)
+	return token.SafeTransfer(token.NewERC20(s.StakingToken), sender, amount)
+}
+
+// GetReward sends the caller its earned rewards and returns the amount.
+func (p *Pool) GetReward() (stygos.U256, error) {
+	s, err := p.loadInitialized()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	sender := stygos.GetMsgSender()
+	p.updateReward(&s, sender)
+	s.Store(p.base)
+
+	reward := p.takeReward(sender)
+	if reward.IsZero() {
+		return reward, nil
+	}
+	return reward, token.SafeTransfer(token.NewERC20(s.RewardsToken), sender, reward)
+}
+
+// Exit withdraws the caller's whole stake and claims its rewards. Both are
+// recorded before either token is sent.
+func (p *Pool) Exit() (stygos.U256, error) {
+	s, err := p.loadInitialized()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	sender := stygos.GetMsgSender()
+	p.updateReward(&s, sender)
+
+	balance := p.BalanceOf(sender)
+	if !balance.IsZero() {
+		stygos.StorageStore(p.accountSlot(sender), stygos.Word{})
+		s.TotalStaked = s.TotalStaked.Sub(balance)
+	}
+	s.Store(p.base)
+	reward := p.takeReward(sender)
+
+	if !balance.IsZero() {
+		if err := token.SafeTransfer(token.NewERC20(s.StakingToken), sender, balance); err != nil {
+			return stygos.U256{}, err
+		}
+	}
+	if reward.IsZero() {
+		return reward, nil
+	}
+	return reward, token.SafeTransfer(token.NewERC20(s.RewardsToken), sender, reward)
+}
+
+// NotifyRewardAmount starts a reward period distributing reward over the
+// rewards duration, adding what is left of a running period. The reward
+// tokens must already be held by the pool contract. The library performs
+// no access control: contracts restrict this to the reward distributor.
+func (p *Pool) NotifyRewardAmount(reward stygos.U256) error {
+	s, err := p.loadInitialized()
+	if err != nil {
+		return err
+	}
+	p.updateReward(&s, stygos.Address{})
+
+	now := stygos.GetBlockTimestamp()
+	duration := stygos.NewU256(s.RewardsDuration)
+	if now >= s.PeriodFinish {
+		s.RewardRate = reward.Div(duration)
+	} else {
+		leftover := stygos.NewU256(s.PeriodFinish - now).Mul(s.RewardRate)
+		s.RewardRate = reward.Add(leftover).Div(duration)
+	}
+
+	// The rate must be payable from the balance, excluding stakes when
+	// both tokens are the same
+	balance, err := token.NewERC20(s.RewardsToken).BalanceOf(stygos.GetContractAddress())
+	if err != nil {
+		return err
+	}
+	if s.RewardsToken == s.StakingToken {
+		if balance.Lt(s.TotalStaked) {
+			return ErrRewardTooHigh
+		}
+		balance = balance.Sub(s.TotalStaked)
+	}
+	if s.RewardRate.Gt(balance.Div(duration)) {
+		return ErrRewardTooHigh
+	}
+
+	s.LastUpdateTime = now
+	s.PeriodFinish = now + s.RewardsDuration
+	s.Store(p.base)
+	return nil
+}
+
+// SetRewardsDuration changes the length of future reward periods. It fails
+// while a period is running.
+func (p *Pool) SetRewardsDuration(duration uint64) error {
+	s, err := p.loadInitialized()
+	if err != nil {
+		return err
+	}
+	if stygos.GetBlockTimestamp() < s.PeriodFinish {
+		return ErrPeriodActive
+	}
+	if duration == 0 {
+		return ErrInvalidDuration
+	}
+	s.RewardsDuration = duration
+	s.Store(p.base)
+	return nil
+}
+
+// updateReward checkpoints the reward per token and, unless account is the
+// zero address, settles the account's earnings. The caller stores s.
+func (p *Pool) updateReward(s *State, account stygos.Address) {
+	s.RewardPerTokenStored = s.rewardPerToken()
+	s.LastUpdateTime = s.lastTimeRewardApplicable()
+	if account == (stygos.Address{}) {
+		return
+	}
+	slot := p.accountSlot(account)
+	stygos.StorageStore(storage.Offset(slot, 2), p.earned(s, account).Word())
+	stygos.StorageStore(storage.Offset(slot, 1), s.RewardPerTokenStored.Word())
+}
+
+// takeReward clears the settled rewards of account and returns them.
+func (p *Pool) takeReward(account stygos.Address) stygos.U256 {
+	slot := storage.Offset(p.accountSlot(account), 2)
+	reward := stygos.U256FromWord(stygos.StorageLoad(slot))
+	if !reward.IsZero() {
+		stygos.StorageStore(slot, stygos.Word{})
+	}
+	return reward
+}
+
+func (p *Pool) earned(s *State, account stygos.Address) stygos.U256 {
+	slot := p.accountSlot(account)
+	balance := stygos.U256FromWord(stygos.StorageLoad(slot))
+	paid := stygos.U256FromWord(stygos.StorageLoad(storage.Offset(slot, 1)))
+	rewards := stygos.U256FromWord(stygos.StorageLoad(storage.Offset(slot, 2)))
+	return balance.Mul(s.rewardPerToken().Sub(paid)).Div(precision).Add(rewards)
+}
+
+func (s *State) lastTimeRewardApplicable() uint64 {
+	if now := stygos.GetBlockTimestamp(); now < s.PeriodFinish {
+		return now
+	}
+	return s.PeriodFinish
+}
+
+func (s *State) rewardPerToken() stygos.U256 {
+	if s.TotalStaked.IsZero() {
+		return s.RewardPerTokenStored
+	}
+	last := s.lastTimeRewardApplicable()
+	if last <= s.LastUpdateTime {
+		return s.RewardPerTokenStored
+	}
+	elapsed := stygos.NewU256(last - s.LastUpdateTime)
+	return s.RewardPerTokenStored.Add(elapsed.Mul(s.RewardRate).Mul(precision).Div(s.TotalStaked))
+}
+
+func (p *Pool) load() State {
+	var s State
+	s.Load(p.base)
+	return s
+}
+
+func (p *Pool) loadInitialized() (State, error) {
+	s := p.load()
+	if s.StakingToken == (stygos.Address{}) {
+		return s, ErrNotInitialized
+	}
+	return s, nil
+}
+
+func (p *Pool) accountSlot(account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(p.base, StatePackedWords), account[:])
+}
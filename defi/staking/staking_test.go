@@ -0,0 +1,203 @@
+package staking
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	poolAddr     = stygos.Address{0x5a}
+	stakingToken = stygos.Address{0x57}
+	rewardsToken = stygos.Address{0x7e}
+	alice        = stygos.Address{0xa1}
+	bob          = stygos.Address{0xb0}
+)
+
+const day = 24 * 3600
+
+// setup deploys both tokens, funds alice and bob with staking tokens and
+// returns an initialized pool with 7-day reward periods.
+func setup(t *testing.T) (*stygos.MockRuntime, *Pool, *token.MockERC20, *token.MockERC20) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = poolAddr
+	mock.Time = 1_000_000
+	stygos.UseRuntime(mock)
+
+	stake := token.InstallMockERC20(mock, stakingToken)
+	rewards := token.InstallMockERC20(mock, rewardsToken)
+	for _, acc := range []stygos.Address{alice, bob} {
+		stake.Mint(acc, tokens(1000))
+		stake.Approve(acc, poolAddr, stygos.U256{}.Not())
+	}
+
+	pool := NewPool(stygos.Word{0x57, 0xa4})
+	if err := pool.Initialize(stakingToken, rewardsToken, 7*day); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return mock, pool, stake, rewards
+}
+
+// tokens returns n whole tokens of 18 decimals.
+func tokens(n uint64) stygos.U256 {
+	return stygos.NewU256(n).Mul(stygos.NewU256(1e18))
+}
+
+// approx reports whether got is within 1e6 wei of want, allowing for
+// rounding in the per-token accumulator.
+func approx(got, want stygos.U256) bool {
+	diff := got.Sub(want)
+	if got.Lt(want) {
+		diff = want.Sub(got)
+	}
+	return diff.Lt(stygos.NewU256(1e6))
+}
+
+func TestRewardsAccrue(t *testing.T) {
+	mock, pool, _, rewards := setup(t)
+
+	// 7000 reward tokens over 7 days: 1000 per day
+	rewards.Mint(poolAddr, tokens(7000))
+	if err := pool.NotifyRewardAmount(tokens(7001)); err != ErrRewardTooHigh {
+		t.Errorf("NotifyRewardAmount failed. Expected ErrRewardTooHigh, got %v", err)
+	}
+	if err := pool.NotifyRewardAmount(tokens(7000)); err != nil {
+		t.Fatalf("NotifyRewardAmount failed: %v", err)
+	}
+	start := mock.Time
+
+	// Alice stakes alone for a day
+	mock.Sender = alice
+	if err := pool.Stake(tokens(100)); err != nil {
+		t.Fatalf("Stake failed: %v", err)
+	}
+	mock.Time = start + day
+	if earned := pool.Earned(alice); !approx(earned, tokens(1000)) {
+		t.Errorf("Earned failed. Expected 1000e18 after one day, got %v", earned.Big())
+	}
+
+	// Bob joins with three times her stake for the next two days
+	mock.Sender = bob
+	pool.Stake(tokens(300))
+	mock.Time = start + 3*day
+	if earned := pool.Earned(alice); !approx(earned, tokens(1500)) {
+		t.Errorf("Earned failed. Expected alice at 1500e18, got %v", earned.Big())
+	}
+	if earned := pool.Earned(bob); !approx(earned, tokens(1500)) {
+		t.Errorf("Earned failed. Expected bob at 1500e18, got %v", earned.Big())
+	}
+
+	// Claiming resets earnings
+	mock.Sender = alice
+	reward, err := pool.GetReward()
+	if err != nil || !approx(reward, tokens(1500)) {
+		t.Fatalf("GetReward failed. Expected 1500e18, got %v, %v", reward.Big(), err)
+	}
+	if got := rewards.Balances[alice]; got != reward {
+		t.Errorf("GetReward failed. Expected alice to hold %v, got %v", reward.Big(), got.Big())
+	}
+	if earned := pool.Earned(alice); !earned.IsZero() {
+		t.Errorf("Earned failed. Expected 0 after claiming, got %v", earned.Big())
+	}
+
+	// Nothing accrues after the period finishes
+	mock.Time = start + 30*day
+	if last := pool.LastTimeRewardApplicable(); last != start+7*day {
+		t.Errorf("LastTimeRewardApplicable failed. Expected %d, got %d", start+7*day, last)
+	}
+	if earned := pool.Earned(alice); !approx(earned, tokens(1000)) {
+		t.Errorf("Earned failed. Expected alice at 1000e18 for days 3-7, got %v", earned.Big())
+	}
+	if earned := pool.Earned(bob); !approx(earned, tokens(4500)) {
+		t.Errorf("Earned failed. Expected bob at 4500e18, got %v", earned.Big())
+	}
+
+	mock.Sender = bob
+	if _, err := pool.Exit(); err != nil {
+		t.Fatalf("Exit failed: %v", err)
+	}
+	if got := pool.BalanceOf(bob); !got.IsZero() {
+		t.Errorf("Exit failed. Expected no stake left, got %v", got.Big())
+	}
+	if s := pool.State(); s.TotalStaked != tokens(100) {
+		t.Errorf("Exit failed. Expected 100e18 staked, got %v", s.TotalStaked.Big())
+	}
+}
+
+func TestStakeAndWithdraw(t *testing.T) {
+	mock, pool, stake, _ := setup(t)
+
+	mock.Sender = alice
+	if err := pool.Stake(stygos.U256{}); err != ErrZeroAmount {
+		t.Errorf("Stake failed. Expected ErrZeroAmount, got %v", err)
+	}
+	if err := pool.Stake(tokens(2000)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Stake failed. Expected ErrRevert beyond the token balance, got %v", err)
+	}
+
+	// The stake is recorded before the pull, and the contract's revert
+	// undoes it, which a direct call does not; start over
+	mock, pool, stake, _ = setup(t)
+	mock.Sender = alice
+	pool.Stake(tokens(400))
+	if err := pool.Withdraw(tokens(401)); err != ErrInsufficientBalance {
+		t.Errorf("Withdraw failed. Expected ErrInsufficientBalance, got %v", err)
+	}
+	if err := pool.Withdraw(tokens(150)); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+	if got := stake.Balances[alice]; got != tokens(750) {
+		t.Errorf("Withdraw failed. Expected alice to hold 750e18, got %v", got.Big())
+	}
+	if got := pool.BalanceOf(alice); got != tokens(250) {
+		t.Errorf("BalanceOf failed. Expected 250e18, got %v", got.Big())
+	}
+}
+
+func TestRewardPeriods(t *testing.T) {
+	mock, pool, stake, rewards := setup(t)
+	rewards.Mint(poolAddr, tokens(14000))
+
+	mock.Sender = alice
+	pool.Stake(tokens(1))
+	pool.NotifyRewardAmount(tokens(7000))
+	start := mock.Time
+
+	if err := pool.SetRewardsDuration(day); err != ErrPeriodActive {
+		t.Errorf("SetRewardsDuration failed. Expected ErrPeriodActive, got %v", err)
+	}
+
+	// Topping up halfway rolls the remaining 3500 into the new period
+	mock.Time = start + 7*day/2
+	if err := pool.NotifyRewardAmount(tokens(3500)); err != nil {
+		t.Fatalf("NotifyRewardAmount failed: %v", err)
+	}
+	if rate := pool.State().RewardRate; rate != tokens(7000).Div(stygos.NewU256(7*day)) {
+		t.Errorf("NotifyRewardAmount failed. Expected rate 1000e18/day, got %v", rate.Big())
+	}
+	if finish := pool.State().PeriodFinish; finish != mock.Time+7*day {
+		t.Errorf("NotifyRewardAmount failed. Expected new finish %d, got %d", mock.Time+7*day, finish)
+	}
+
+	mock.Time += 7 * day
+	if err := pool.SetRewardsDuration(day); err != nil {
+		t.Errorf("SetRewardsDuration failed: %v", err)
+	}
+	if earned := pool.Earned(alice); !approx(earned, tokens(10500)) {
+		t.Errorf("Earned failed. Expected 10500e18, got %v", earned.Big())
+	}
+
+	// With one token for both, stakes are not rewards, even when a token
+	// that loses value in transfers leaves less than was staked
+	same := NewPool(stygos.Word{0x5a, 0x3e})
+	if err := same.Initialize(stakingToken, stakingToken, 7*day); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	same.Stake(tokens(100))
+	stake.Balances[poolAddr] = tokens(50)
+	if err := same.NotifyRewardAmount(tokens(7)); err != ErrRewardTooHigh {
+		t.Errorf("NotifyRewardAmount failed. Expected ErrRewardTooHigh below the stakes, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package vesting
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Stream errors
+var (
+	ErrNoStream       = errors.New("vesting: no stream")
+	ErrNotRecipient   = errors.New("vesting: caller is not the recipient")
+	ErrNotParty       = errors.New("vesting: caller is not the sender or recipient")
+	ErrExceedsBalance = errors.New("vesting: amount exceeds streamed balance")
+)
+
+// Stream is a payment stream, packed into storage by vesting_pack_gen.go.
+// The deposit flows to the recipient evenly from StartTime to StopTime.
+type Stream struct {
+	Sender    stygos.Address
+	StartTime uint64 // unix seconds
+	Recipient stygos.Address
+	StopTime  uint64 // unix seconds
+	Deposit   stygos.U256
+	Withdrawn stygos.U256
+}
+
+// StreamedAt returns the part of the deposit streamed by time t.
+func (s *Stream) StreamedAt(t uint64) stygos.U256 {
+	switch {
+	case t <= s.StartTime:
+		return stygos.U256{}
+	case t >= s.StopTime:
+		return s.Deposit
+	}
+	return prorate(s.Deposit, t-s.StartTime, s.StopTime-s.StartTime)
+}
+
+// Streams holds payment streams of one token.
+//
+// Storage layout relative to the base slot:
+//
+//	base                          stream count
+//	MapKey(Offset(base, 1), id)   Stream (StreamPackedWords slots)
+type Streams struct {
+	base  stygos.Word
+	token token.ERC20
+}
+
+// NewStreams returns the streams of token rooted at base.
+func NewStreams(base stygos.Word, tokenAddr stygos.Address) *Streams {
+	return &Streams{base: base, token: token.NewERC20(tokenAddr)}
+}
+
+// Get returns the stream with the given id.
+func (ss *Streams) Get(id uint64) (Stream, error) {
+	var s Stream
+	s.Load(ss.slot(id))
+	if s.Sender == (stygos.Address{}) {
+		return s, ErrNoStream
+	}
+	return s, nil
+}
+
+// Create streams deposit tokens, pulled from the caller after the stream is
+// recorded, to recipient between start and stop. Stream ids start at 1.
+func (ss *Streams) Create(recipient stygos.Address, deposit stygos.U256, start, stop uint64) (uint64, error) {
+	if deposit.IsZero() {
+		return 0, ErrZeroAmount
+	}
+	if stop <= start || recipient == (stygos.Address{}) {
+		return 0, ErrInvalidSchedule
+	}
+	sender := stygos.GetMsgSender()
+	id := stygos.Uint64FromWord(stygos.StorageLoad(ss.base)) + 1
+	stygos.StorageStore(ss.base, stygos.WordFromUint64(id))
+	s := Stream{
+		Sender:    sender,
+		StartTime: start,
+		Recipient: recipient,
+		StopTime:  stop,
+		Deposit:   deposit,
+	}
+	s.Store(ss.slot(id))
+	if err := token.SafeTransferFrom(ss.token, sender, stygos.GetContractAddress(), deposit); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Balance returns what the recipient can withdraw now.
+func (ss *Streams) Balance(id uint64) (stygos.U256, error) {
+	s, err := ss.Get(id)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	return s.StreamedAt(stygos.GetBlockTimestamp()).Sub(s.Withdrawn), nil
+}
+
+// Withdraw sends amount of the streamed balance to the recipient, who must
+// be the caller.
+func (ss *Streams) Withdraw(id uint64, amount stygos.U256) error {
+	s, err := ss.Get(id)
+	if err != nil {
+		return err
+	}
+	if stygos.GetMsgSender() != s.Recipient {
+		return ErrNotRecipient
+	}
+	if amount.IsZero() {
+		return ErrZeroAmount
+	}
+	if s.StreamedAt(stygos.GetBlockTimestamp()).Sub(s.Withdrawn).Lt(amount) {
+		return ErrExceedsBalance
+	}
+	s.Withdrawn = s.Withdrawn.Add(amount)
+	s.Store(ss.slot(id))
+	return token.SafeTransfer(ss.token, s.Recipient, amount)
+}
+
+// Cancel ends a stream, paying the recipient what has streamed and
+// refunding the rest to the sender. Either party can cancel.
+func (ss *Streams) Cancel(id uint64) error {
+	s, err := ss.Get(id)
+	if err != nil {
+		return err
+	}
+	if caller := stygos.GetMsgSender(); caller != s.Sender && caller != s.Recipient {
+		return ErrNotParty
+	}
+	streamed := s.StreamedAt(stygos.GetBlockTimestamp())
+	owed := streamed.Sub(s.Withdrawn)
+	refund := s.Deposit.Sub(streamed)
+	(&Stream{}).Store(ss.slot(id))
+
+	if !owed.IsZero() {
+		if err := token.SafeTransfer(ss.token, s.Recipient, owed); err != nil {
+			return err
+		}
+	}
+	if !refund.IsZero() {
+		return token.SafeTransfer(ss.token, s.Sender, refund)
+	}
+	return nil
+}
+
+func (ss *Streams) slot(id uint64) stygos.Word {
+	key := stygos.WordFromUint64(id)
+	return storage.MapKey(storage.Offset(ss.base, 1), key[:])
+}
@@ -0,0 +1,165 @@
+// Package vesting releases ERC-20 tokens over time: Vesting holds grants
+// with a cliff and a linear schedule per beneficiary, optionally revocable
+// by the grantor, and Streams pays a deposit to a recipient second by
+// second.
+//
+// Both pull the tokens from the caller when a grant or stream is created,
+// so the caller approves the contract first. Neither performs access
+// control beyond the parties of a stream; contracts restrict who may
+// create or revoke grants.
+package vesting
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Vesting errors
+var (
+	ErrInvalidSchedule = errors.New("vesting: invalid schedule")
+	ErrZeroAmount      = errors.New("vesting: zero amount")
+	ErrGrantExists     = errors.New("vesting: beneficiary already has a grant")
+	ErrNoGrant         = errors.New("vesting: no grant")
+	ErrNotRevocable    = errors.New("vesting: grant is not revocable")
+	ErrRevoked         = errors.New("vesting: grant already revoked")
+	ErrNothingVested   = errors.New("vesting: nothing to release")
+)
+
+// Grant is a vesting grant, packed into storage by vesting_pack_gen.go.
+//
+// Nothing vests before Start+Cliff; from then on Amount vests linearly from
+// Start to Start+Duration, so the cliff releases its share at once.
+//
+//go:generate stygos-gen pack -type Grant,Stream -o vesting_pack_gen.go
+type Grant struct {
+	Start     uint64 // unix seconds
+	Cliff     uint64 // seconds after Start
+	Duration  uint64 // seconds
+	Revocable bool
+	Revoked   bool
+	Amount    stygos.U256 // reduced to the vested amount on revocation
+	Released  stygos.U256
+}
+
+// VestedAt returns the amount vested at time t.
+func (g *Grant) VestedAt(t uint64) stygos.U256 {
+	switch {
+	case g.Revoked || t >= g.Start+g.Duration:
+		return g.Amount
+	case t < g.Start+g.Cliff:
+		return stygos.U256{}
+	}
+	return prorate(g.Amount, t-g.Start, g.Duration)
+}
+
+// Vesting holds token grants, at most one per beneficiary.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, beneficiary)   Grant (GrantPackedWords slots)
+type Vesting struct {
+	base  stygos.Word
+	token token.ERC20
+}
+
+// NewVesting returns the grants of token rooted at base.
+func NewVesting(base stygos.Word, tokenAddr stygos.Address) *Vesting {
+	return &Vesting{base: base, token: token.NewERC20(tokenAddr)}
+}
+
+// Grant returns the grant of beneficiary.
+func (v *Vesting) Grant(beneficiary stygos.Address) (Grant, error) {
+	var g Grant
+	g.Load(v.slot(beneficiary))
+	if g.Duration == 0 {
+		return g, ErrNoGrant
+	}
+	return g, nil
+}
+
+// Create grants amount tokens, pulled from the caller once the grant is
+// recorded, vesting to beneficiary from start over duration seconds after
+// a cliff.
+func (v *Vesting) Create(beneficiary stygos.Address, amount stygos.U256, start, cliff, duration uint64, revocable bool) error {
+	if amount.IsZero() {
+		return ErrZeroAmount
+	}
+	if duration == 0 || cliff > duration || start+duration < start {
+		return ErrInvalidSchedule
+	}
+	if _, err := v.Grant(beneficiary); err != ErrNoGrant {
+		return ErrGrantExists
+	}
+	g := Grant{
+		Start:     start,
+		Cliff:     cliff,
+		Duration:  duration,
+		Revocable: revocable,
+		Amount:    amount,
+	}
+	g.Store(v.slot(beneficiary))
+	return token.SafeTransferFrom(v.token, stygos.GetMsgSender(), stygos.GetContractAddress(), amount)
+}
+
+// Releasable returns the vested tokens beneficiary has not released yet.
+func (v *Vesting) Releasable(beneficiary stygos.Address) stygos.U256 {
+	g, err := v.Grant(beneficiary)
+	if err != nil {
+		return stygos.U256{}
+	}
+	return g.VestedAt(stygos.GetBlockTimestamp()).Sub(g.Released)
+}
+
+// Release sends the caller its releasable tokens and returns the amount.
+func (v *Vesting) Release() (stygos.U256, error) {
+	sender := stygos.GetMsgSender()
+	g, err := v.Grant(sender)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	amount := g.VestedAt(stygos.GetBlockTimestamp()).Sub(g.Released)
+	if amount.IsZero() {
+		return amount, ErrNothingVested
+	}
+	g.Released = g.Released.Add(amount)
+	g.Store(v.slot(sender))
+	return amount, token.SafeTransfer(v.token, sender, amount)
+}
+
+// Revoke ends a revocable grant: what has vested stays releasable by the
+// beneficiary and the rest is sent to refundTo. It returns the refund.
+func (v *Vesting) Revoke(beneficiary, refundTo stygos.Address) (stygos.U256, error) {
+	g, err := v.Grant(beneficiary)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if !g.Revocable {
+		return stygos.U256{}, ErrNotRevocable
+	}
+	if g.Revoked {
+		return stygos.U256{}, ErrRevoked
+	}
+	vested := g.VestedAt(stygos.GetBlockTimestamp())
+	refund := g.Amount.Sub(vested)
+	g.Amount = vested
+	g.Revoked = true
+	g.Store(v.slot(beneficiary))
+	if refund.IsZero() {
+		return refund, nil
+	}
+	return refund, token.SafeTransfer(v.token, refundTo, refund)
+}
+
+func (v *Vesting) slot(beneficiary stygos.Address) stygos.Word {
+	return storage.MapKey(v.base, beneficiary[:])
+}
+
+// prorate returns amount*elapsed/duration for elapsed <= duration, without
+// overflowing for any amount.
+func prorate(amount stygos.U256, elapsed, duration uint64) stygos.U256 {
+	e, d := stygos.NewU256(elapsed), stygos.NewU256(duration)
+	return amount.Div(d).Mul(e).Add(amount.Mod(d).Mul(e).Div(d))
+}
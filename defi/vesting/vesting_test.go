@@ -0,0 +1,178 @@
+package vesting
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	contract = stygos.Address{0xc0}
+	tokenAdr = stygos.Address{0x70}
+	grantor  = stygos.Address{0x60}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+)
+
+const month = 30 * 24 * 3600
+
+func setup() (*stygos.MockRuntime, *token.MockERC20) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	mock.Time = 1_700_000_000
+	stygos.UseRuntime(mock)
+
+	tok := token.InstallMockERC20(mock, tokenAdr)
+	tok.Mint(grantor, stygos.NewU256(1e12))
+	tok.Approve(grantor, contract, stygos.U256{}.Not())
+	return mock, tok
+}
+
+func TestVestingSchedule(t *testing.T) {
+	mock, tok := setup()
+	v := NewVesting(stygos.Word{0x7e}, tokenAdr)
+	start := mock.Time
+
+	// 48 months with a 12 month cliff
+	mock.Sender = grantor
+	if err := v.Create(alice, stygos.NewU256(4800), start, 12*month, 48*month, false); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := v.Create(alice, stygos.NewU256(1), start, 0, 1, false); err != ErrGrantExists {
+		t.Errorf("Create failed. Expected ErrGrantExists, got %v", err)
+	}
+	if err := v.Create(bob, stygos.NewU256(1), start, 2, 1, false); err != ErrInvalidSchedule {
+		t.Errorf("Create failed. Expected ErrInvalidSchedule for a cliff after the end, got %v", err)
+	}
+
+	tests := []struct {
+		elapsed uint64
+		vested  uint64
+	}{
+		{0, 0},
+		{12*month - 1, 0},
+		{12 * month, 1200},
+		{30 * month, 3000},
+		{48 * month, 4800},
+		{60 * month, 4800},
+	}
+	g, _ := v.Grant(alice)
+	for _, tt := range tests {
+		if got := g.VestedAt(start + tt.elapsed); got.Uint64() != tt.vested {
+			t.Errorf("VestedAt(%d) failed. Expected %d, got %d", tt.elapsed, tt.vested, got.Uint64())
+		}
+	}
+
+	mock.Sender = alice
+	if _, err := v.Release(); err != ErrNothingVested {
+		t.Errorf("Release failed. Expected ErrNothingVested before the cliff, got %v", err)
+	}
+	mock.Time = start + 24*month
+	if amount, err := v.Release(); err != nil || amount.Uint64() != 2400 {
+		t.Errorf("Release failed. Expected 2400, got %d, %v", amount.Uint64(), err)
+	}
+	mock.Time = start + 36*month
+	if got := v.Releasable(alice); got.Uint64() != 1200 {
+		t.Errorf("Releasable failed. Expected 1200, got %d", got.Uint64())
+	}
+	v.Release()
+	if got := tok.Balances[alice]; got.Uint64() != 3600 {
+		t.Errorf("Release failed. Expected alice to hold 3600, got %d", got.Uint64())
+	}
+
+	mock.Sender = grantor
+	if _, err := v.Revoke(alice, grantor); err != ErrNotRevocable {
+		t.Errorf("Revoke failed. Expected ErrNotRevocable, got %v", err)
+	}
+}
+
+func TestVestingRevoke(t *testing.T) {
+	mock, tok := setup()
+	v := NewVesting(stygos.Word{0x7f}, tokenAdr)
+	start := mock.Time
+
+	mock.Sender = grantor
+	v.Create(bob, stygos.NewU256(1000), start, 0, 10*month, true)
+
+	mock.Time = start + 3*month
+	refund, err := v.Revoke(bob, grantor)
+	if err != nil || refund.Uint64() != 700 {
+		t.Fatalf("Revoke failed. Expected refund 700, got %d, %v", refund.Uint64(), err)
+	}
+	if _, err := v.Revoke(bob, grantor); err != ErrRevoked {
+		t.Errorf("Revoke failed. Expected ErrRevoked, got %v", err)
+	}
+
+	// Vested tokens stay with the beneficiary and nothing more vests
+	mock.Time = start + 10*month
+	mock.Sender = bob
+	if amount, err := v.Release(); err != nil || amount.Uint64() != 300 {
+		t.Errorf("Release failed. Expected 300, got %d, %v", amount.Uint64(), err)
+	}
+	if got := tok.Balances[contract]; !got.IsZero() {
+		t.Errorf("Revoke failed. Expected no tokens left in the contract, got %d", got.Uint64())
+	}
+}
+
+func TestStreams(t *testing.T) {
+	mock, tok := setup()
+	ss := NewStreams(stygos.Word{0x57}, tokenAdr)
+	start := mock.Time + 100
+
+	mock.Sender = grantor
+	if _, err := ss.Create(alice, stygos.NewU256(1000), start, start); err != ErrInvalidSchedule {
+		t.Errorf("Create failed. Expected ErrInvalidSchedule, got %v", err)
+	}
+	id, err := ss.Create(alice, stygos.NewU256(1000), start, start+3)
+	if err != nil || id != 1 {
+		t.Fatalf("Create failed. Expected id 1, got %d, %v", id, err)
+	}
+
+	tests := []struct {
+		at      uint64
+		balance uint64
+	}{
+		{start - 50, 0},
+		{start + 1, 333},
+		{start + 2, 666},
+		{start + 3, 1000},
+		{start + 100, 1000},
+	}
+	for _, tt := range tests {
+		mock.Time = tt.at
+		if got, _ := ss.Balance(id); got.Uint64() != tt.balance {
+			t.Errorf("Balance at %+d failed. Expected %d, got %d", int64(tt.at-start), tt.balance, got.Uint64())
+		}
+	}
+
+	mock.Time = start + 2
+	if err := ss.Withdraw(id, stygos.NewU256(100)); err != ErrNotRecipient {
+		t.Errorf("Withdraw failed. Expected ErrNotRecipient, got %v", err)
+	}
+	mock.Sender = alice
+	if err := ss.Withdraw(id, stygos.NewU256(667)); err != ErrExceedsBalance {
+		t.Errorf("Withdraw failed. Expected ErrExceedsBalance, got %v", err)
+	}
+	if err := ss.Withdraw(id, stygos.NewU256(500)); err != nil {
+		t.Fatalf("Withdraw failed: %v", err)
+	}
+
+	mock.Sender = bob
+	if err := ss.Cancel(id); err != ErrNotParty {
+		t.Errorf("Cancel failed. Expected ErrNotParty, got %v", err)
+	}
+	mock.Sender = grantor
+	if err := ss.Cancel(id); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if got := tok.Balances[alice]; got.Uint64() != 666 {
+		t.Errorf("Cancel failed. Expected alice to hold 666, got %d", got.Uint64())
+	}
+	if got := tok.Balances[grantor]; got.Uint64() != 1e12-666 {
+		t.Errorf("Cancel failed. Expected the unstreamed 334 refunded, got %d", got.Uint64())
+	}
+	if _, err := ss.Get(id); err != ErrNoStream {
+		t.Errorf("Get failed. Expected ErrNoStream after Cancel, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+//go:build !tinygo
+
+// Package devnode runs end-to-end tests of stygos contracts on a nitro dev
+// node: it starts the node in a docker container or attaches to a running
+// one, builds and deploys contracts, and funds test accounts, handing out
+// script.RPCBackend clients to drive them.
+//
+// End-to-end tests are opt-in through the STYGOS_DEVNODE environment
+// variable, and skipped when it is unset:
+//
+//	STYGOS_DEVNODE=docker go test ./examples/counter   # start a container
+//	STYGOS_DEVNODE=http://localhost:8547 go test ./... # attach, as in CI
+//
+// A test starts the node, deploys and calls:
+//
+//	func TestCounterE2E(t *testing.T) {
+//		node := devnode.Start(t)
+//		counter := node.Deploy(t, devnode.Build(t, "."))
+//		user := node.NewAccount(t, devnode.Ether)
+//		...
+//	}
+package devnode
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/schnorr"
+	"github.com/rafaelescrich/stygos/script"
+)
+
+// Dev node defaults
+const (
+	// EnvVar selects the node: "docker", or the URL of a running node.
+	EnvVar = "STYGOS_DEVNODE"
+
+	// Image is the nitro image started in docker mode.
+	Image = "offchainlabs/nitro-node:v3.2.1-d81324d"
+
+	// ChainID is the chain id of nitro dev nodes.
+	ChainID = 412346
+
+	// DevKey is the private key of the account nitro funds in dev mode.
+	DevKey = "0xb6b15c8cb491557369f3c7d2c287b053eb229daa9c22138887752191c9520659"
+
+	// StartTimeout bounds the wait for a container to answer RPC calls.
+	StartTimeout = 2 * time.Minute
+)
+
+// Ether is 10^18 wei.
+var Ether = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// Node is a running dev node.
+type Node struct {
+	URL string
+	Dev *script.RPCBackend // client of the funded dev account
+}
+
+// Start returns the node selected by STYGOS_DEVNODE, skipping the test if
+// it is unset. In docker mode it starts a container, removed when the test
+// ends; the node is shared by the test's subtests.
+func Start(t testing.TB) *Node {
+	t.Helper()
+	mode := os.Getenv(EnvVar)
+	switch {
+	case mode == "":
+		t.Skipf("set %s=docker or %s=<rpc url> to run end-to-end tests", EnvVar, EnvVar)
+	case mode == "docker":
+		return startDocker(t)
+	}
+	return attach(t, mode, 0)
+}
+
+func startDocker(t testing.TB) *Node {
+	t.Helper()
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Fatalf("devnode: %s=docker but docker is not in PATH", EnvVar)
+	}
+	id := strings.TrimSpace(command(t, "docker", "run", "-d", "--rm", "-p", "127.0.0.1::8547",
+		Image, "--dev", "--http.addr", "0.0.0.0", "--http.api", "net,web3,eth,debug"))
+	t.Cleanup(func() {
+		exec.Command("docker", "rm", "-f", id).Run()
+	})
+
+	// docker port prints the host address bound to the container port
+	port := strings.TrimSpace(command(t, "docker", "port", id, "8547/tcp"))
+	if i := strings.IndexByte(port, '\n'); i >= 0 {
+		port = port[:i]
+	}
+	return attach(t, "http://"+port, StartTimeout)
+}
+
+// attach dials the node at url, retrying for up to wait while it starts.
+func attach(t testing.TB, url string, wait time.Duration) *Node {
+	t.Helper()
+	key, err := script.ParseKey(DevKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		dev, err := script.Dial(url, key)
+		if err == nil {
+			return &Node{URL: url, Dev: dev}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("devnode: no node at %s: %v", url, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// Client returns a client sending from the account of key.
+func (n *Node) Client(t testing.TB, key *big.Int) *script.RPCBackend {
+	t.Helper()
+	b, err := script.Dial(n.URL, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Fund sends wei from the dev account to addr.
+func (n *Node) Fund(t testing.TB, addr stygos.Address, wei *big.Int) {
+	t.Helper()
+	s := &script.Script{Backend: n.Dev}
+	if _, err := s.Send("fund", script.Tx{To: &addr, Value: wei}); err != nil {
+		t.Fatalf("devnode: funding %s: %v", addr.Hex(), err)
+	}
+}
+
+// NewAccount generates an account, funds it with wei and returns its
+// client.
+func (n *Node) NewAccount(t testing.TB, wei *big.Int) *script.RPCBackend {
+	t.Helper()
+	key, err := rand.Int(rand.Reader, new(big.Int).Sub(schnorr.N, big.NewInt(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	key.Add(key, big.NewInt(1))
+	b := n.Client(t, key)
+	n.Fund(t, b.Sender(), wei)
+	return b
+}
+
+// Deploy deploys and activates a brotli-compressed program from the dev
+// account and returns its address.
+func (n *Node) Deploy(t testing.TB, compressed []byte) stygos.Address {
+	t.Helper()
+	s := &script.Script{Backend: n.Dev}
+	addr, err := s.Deploy("deploy", compressed)
+	if err != nil {
+		t.Fatalf("devnode: deploying: %v", err)
+	}
+	return addr
+}
+
+// Build compiles the contract package pkg with TinyGo as the Makefile
+// does, runs wasm-opt when installed and compresses the result with
+// brotli. The test is skipped if TinyGo or brotli is missing.
+func Build(t testing.TB, pkg string) []byte {
+	t.Helper()
+	for _, tool := range []string{"tinygo", "brotli"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("devnode: %s is not in PATH", tool)
+		}
+	}
+	dir := t.TempDir()
+	wasm := filepath.Join(dir, "contract.wasm")
+	command(t, "tinygo", "build", "-target=wasi", "-opt=z", "-panic=trap", "-no-debug", "-o", wasm, pkg)
+	if _, err := exec.LookPath("wasm-opt"); err == nil {
+		opt := filepath.Join(dir, "contract.opt.wasm")
+		command(t, "wasm-opt", "-Oz", wasm, "-o", opt)
+		wasm = opt
+	}
+	return []byte(command(t, "brotli", "-c", "-q", "11", wasm))
+}
+
+// command runs a tool and returns its output, failing the test on error.
+func command(t testing.TB, name string, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("devnode: %s: %v\n%s", name, err, stderr.Bytes())
+	}
+	return stdout.String()
+}
@@ -0,0 +1,103 @@
+package devnode
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/script"
+)
+
+func TestDevKey(t *testing.T) {
+	key, err := script.ParseKey(DevKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := stygos.AddressFromHex("0x3f1eae7d46d88f08fc2f8ed27fcb2ab183eb2d0e")
+	if got := ecdsa.AddressOf(key); got != want {
+		t.Errorf("DevKey failed. Expected %s, got %s", want.Hex(), got.Hex())
+	}
+}
+
+func TestStartSkips(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	var skipped bool
+	t.Run("e2e", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		Start(t)
+	})
+	if !skipped {
+		t.Error("Start failed. Expected the test to be skipped without STYGOS_DEVNODE")
+	}
+}
+
+// fakeNode answers the calls of Start and Fund, recording the raw
+// transactions it is sent.
+func fakeNode(t *testing.T, sent *[]string) *httptest.Server {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var call struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(req.Body).Decode(&call)
+		var result any
+		switch call.Method {
+		case "eth_chainId":
+			result = "0x64aba"
+		case "eth_getTransactionCount", "eth_maxPriorityFeePerGas":
+			result = "0x0"
+		case "eth_getBlockByNumber":
+			result = map[string]string{"baseFeePerGas": "0x5f5e100"}
+		case "eth_estimateGas":
+			result = "0x5208"
+		case "eth_sendRawTransaction":
+			var raw string
+			json.Unmarshal(call.Params[0], &raw)
+			*sent = append(*sent, raw)
+			result = "0x"
+		case "eth_getTransactionReceipt":
+			result = map[string]any{"status": "0x1", "blockNumber": "0x1", "gasUsed": "0x5208"}
+		default:
+			t.Errorf("unexpected call %s", call.Method)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "result": result})
+	}))
+	t.Cleanup(node.Close)
+	return node
+}
+
+func TestAttach(t *testing.T) {
+	var sent []string
+	node := fakeNode(t, &sent)
+	t.Setenv(EnvVar, node.URL)
+
+	n := Start(t)
+	if n.URL != node.URL || n.Dev.Sender() != ecdsa.AddressOf(mustKey(t)) {
+		t.Fatalf("Start failed. Expected the dev account at %s, got %s at %s", node.URL, n.Dev.Sender().Hex(), n.URL)
+	}
+	if id, _ := n.Dev.ChainID(); id != ChainID {
+		t.Errorf("Start failed. Expected chain %d, got %d", ChainID, id)
+	}
+
+	user := n.NewAccount(t, Ether)
+	if user.Sender() == n.Dev.Sender() || len(sent) != 1 {
+		t.Errorf("NewAccount failed. Expected a new account funded in 1 transaction, got %s after %d", user.Sender().Hex(), len(sent))
+	}
+	n.Fund(t, stygos.Address{19: 1}, big.NewInt(1))
+	if len(sent) != 2 {
+		t.Errorf("Fund failed. Expected 2 transactions, got %d", len(sent))
+	}
+}
+
+func mustKey(t *testing.T) *big.Int {
+	key, err := script.ParseKey(DevKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
@@ -0,0 +1,303 @@
+// Package dispute runs optimistic claims: a proposer asserts a value with
+// a bond, anyone may challenge it with an equal bond during a challenge
+// window, and a claim nobody challenges is accepted once the window
+// closes. Oracles use it for answers and bridges for message roots.
+//
+// A challenged claim is settled by a Resolver, the pluggable part of the
+// game: an Arbitrator's ruling, a fraud or validity proof checked
+// on-chain, or any other ResolverFunc. The winner takes both bonds,
+// credited to the game's payment.PullPayment for withdrawal, and the
+// game's callbacks let the application act on the result, such as
+// finalizing an answer, in the same transaction.
+package dispute
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/payment"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Dispute errors
+var (
+	ErrUnknownClaim   = errors.New("dispute: unknown claim")
+	ErrClaimExists    = errors.New("dispute: claim id already used")
+	ErrBondMismatch   = errors.New("dispute: msg.value does not match the bond")
+	ErrWindowClosed   = errors.New("dispute: challenge window closed")
+	ErrWindowOpen     = errors.New("dispute: challenge window still open")
+	ErrNotPending     = errors.New("dispute: claim is not pending")
+	ErrUndecided      = errors.New("dispute: challenge not decided yet")
+	ErrInvalidOutcome = errors.New("dispute: invalid outcome")
+	ErrNotArbiter     = errors.New("dispute: caller is not the arbiter")
+	ErrRuled          = errors.New("dispute: claim already ruled on")
+)
+
+// Status is the state of a claim.
+type Status uint8
+
+// Claim statuses
+const (
+	None       Status = iota
+	Proposed          // in its challenge window, or past it and unresolved
+	Challenged        // waiting for the resolver
+	Accepted          // unchallenged, or the proposer won
+	Rejected          // the challenger won
+)
+
+// Outcome is a resolver's decision on a challenged claim.
+type Outcome uint8
+
+// Outcomes
+const (
+	Undecided Outcome = iota
+	ProposerWins
+	ChallengerWins
+)
+
+// Event signatures
+var (
+	ClaimProposedTopic   = stygos.Keccak256([]byte("ClaimProposed(bytes32,address,bytes32,uint64)"))
+	ClaimChallengedTopic = stygos.Keccak256([]byte("ClaimChallenged(bytes32,address)"))
+	ClaimResolvedTopic   = stygos.Keccak256([]byte("ClaimResolved(bytes32,uint8)"))
+)
+
+// Claim is an optimistic claim, packed into storage by claim_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Claim -o claim_pack_gen.go
+type Claim struct {
+	Proposer     stygos.Address
+	Deadline     uint64 // end of the challenge window, unix seconds
+	Challenger   stygos.Address
+	ChallengedAt uint64
+	Status       uint8 // Status
+	Bond         stygos.U256
+	Data         stygos.Word // the claimed value
+}
+
+// Resolver decides challenged claims. It returns Undecided while the
+// challenge is not settled, for example until an arbiter rules, and is
+// never called for unchallenged claims.
+type Resolver interface {
+	Resolve(id stygos.Word, c *Claim) (Outcome, error)
+}
+
+// ResolverFunc adapts a function to a Resolver.
+type ResolverFunc func(id stygos.Word, c *Claim) (Outcome, error)
+
+// Resolve returns f(id, c).
+func (f ResolverFunc) Resolve(id stygos.Word, c *Claim) (Outcome, error) {
+	return f(id, c)
+}
+
+// Callback is called with a claim once it is settled, after the bonds are
+// credited. An error reverts the resolution.
+type Callback func(id stygos.Word, c *Claim) error
+
+// Config configures a game.
+type Config struct {
+	Bond       stygos.U256 // in wei, posted by the proposer and the challenger
+	Window     uint64      // challenge window in seconds
+	Resolver   Resolver
+	OnAccepted Callback // optional
+	OnRejected Callback // optional
+}
+
+// Game holds claims keyed by id. Ids are chosen by the application, such
+// as a question or message hash, and each is used once.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, id)   Claim (ClaimPackedWords slots)
+//	Offset(base, 1)    payment.PullPayment of bonds
+type Game struct {
+	*payment.PullPayment
+	base stygos.Word
+	cfg  Config
+}
+
+// NewGame returns the game rooted at base. It panics without a resolver.
+func NewGame(base stygos.Word, cfg Config) *Game {
+	if cfg.Resolver == nil {
+		panic("dispute: nil resolver")
+	}
+	return &Game{PullPayment: payment.NewPullPayment(storage.Offset(base, 1)), base: base, cfg: cfg}
+}
+
+// Get returns the claim with the given id.
+func (g *Game) Get(id stygos.Word) (Claim, error) {
+	var c Claim
+	c.Load(g.slot(id))
+	if Status(c.Status) == None {
+		return c, ErrUnknownClaim
+	}
+	return c, nil
+}
+
+// Propose claims data under id for the caller, who sends the bond as
+// msg.value, and opens the challenge window.
+func (g *Game) Propose(id, data stygos.Word) error {
+	if _, err := g.Get(id); err != ErrUnknownClaim {
+		return ErrClaimExists
+	}
+	if stygos.U256FromBig(stygos.GetMsgValue()) != g.cfg.Bond {
+		return ErrBondMismatch
+	}
+	c := Claim{
+		Proposer: stygos.GetMsgSender(),
+		Deadline: stygos.GetBlockTimestamp() + g.cfg.Window,
+		Status:   uint8(Proposed),
+		Bond:     g.cfg.Bond,
+		Data:     data,
+	}
+	c.Store(g.slot(id))
+	deadline := stygos.WordFromUint64(c.Deadline)
+	stygos.EmitEvent(append(data[:], deadline[:]...), ClaimProposedTopic, id, stygos.PadAddress(c.Proposer))
+	return nil
+}
+
+// Challenge disputes a claim in its window for the caller, who sends the
+// bond as msg.value.
+func (g *Game) Challenge(id stygos.Word) error {
+	c, err := g.Get(id)
+	if err != nil {
+		return err
+	}
+	if Status(c.Status) != Proposed {
+		return ErrNotPending
+	}
+	if stygos.GetBlockTimestamp() >= c.Deadline {
+		return ErrWindowClosed
+	}
+	if stygos.U256FromBig(stygos.GetMsgValue()) != c.Bond {
+		return ErrBondMismatch
+	}
+	c.Challenger = stygos.GetMsgSender()
+	c.ChallengedAt = stygos.GetBlockTimestamp()
+	c.Status = uint8(Challenged)
+	c.Store(g.slot(id))
+	stygos.EmitEvent(nil, ClaimChallengedTopic, id, stygos.PadAddress(c.Challenger))
+	return nil
+}
+
+// Resolve settles a claim and returns its final status. Anyone may call
+// it. An unchallenged claim is accepted once its window closes and its
+// bond returned; a challenged one is settled when the resolver decides,
+// and the winner receives both bonds. Bonds are credited for
+// WithdrawPayments, so a winner that cannot receive ETH does not block
+// the resolution. It fails with ErrUndecided while the resolver has not
+// decided.
+func (g *Game) Resolve(id stygos.Word) (Status, error) {
+	c, err := g.Get(id)
+	if err != nil {
+		return None, err
+	}
+
+	var winner stygos.Address
+	var payout stygos.U256
+	switch Status(c.Status) {
+	case Proposed:
+		if stygos.GetBlockTimestamp() < c.Deadline {
+			return Proposed, ErrWindowOpen
+		}
+		c.Status = uint8(Accepted)
+		winner, payout = c.Proposer, c.Bond
+	case Challenged:
+		outcome, err := g.cfg.Resolver.Resolve(id, &c)
+		if err != nil {
+			return Challenged, err
+		}
+		switch outcome {
+		case Undecided:
+			return Challenged, ErrUndecided
+		case ProposerWins:
+			c.Status = uint8(Accepted)
+			winner = c.Proposer
+		case ChallengerWins:
+			c.Status = uint8(Rejected)
+			winner = c.Challenger
+		default:
+			return Challenged, ErrInvalidOutcome
+		}
+		payout = c.Bond.Add(c.Bond)
+	default:
+		return Status(c.Status), ErrNotPending
+	}
+
+	c.Store(g.slot(id))
+	stygos.EmitEvent(nil, ClaimResolvedTopic, id, stygos.WordFromUint64(uint64(c.Status)))
+	g.Credit(winner, payout)
+	callback := g.cfg.OnAccepted
+	if Status(c.Status) == Rejected {
+		callback = g.cfg.OnRejected
+	}
+	if callback != nil {
+		if err := callback(id, &c); err != nil {
+			return Status(c.Status), err
+		}
+	}
+	return Status(c.Status), nil
+}
+
+func (g *Game) slot(id stygos.Word) stygos.Word {
+	return storage.MapKey(g.base, id[:])
+}
+
+// Arbitrator is a Resolver whose arbiter, such as a council multisig or
+// an escalation contract, rules on challenged claims.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, id)   Outcome
+type Arbitrator struct {
+	base    stygos.Word
+	arbiter stygos.Address
+}
+
+// NewArbitrator returns the rulings of arbiter rooted at base.
+func NewArbitrator(base stygos.Word, arbiter stygos.Address) *Arbitrator {
+	return &Arbitrator{base: base, arbiter: arbiter}
+}
+
+// Rule records the arbiter's outcome for claim id, once. The arbiter must
+// be the caller. Rulings may come before the challenge, so the arbiter
+// can rule as soon as a claim is known to be wrong.
+func (a *Arbitrator) Rule(id stygos.Word, outcome Outcome) error {
+	if stygos.GetMsgSender() != a.arbiter {
+		return ErrNotArbiter
+	}
+	if outcome != ProposerWins && outcome != ChallengerWins {
+		return ErrInvalidOutcome
+	}
+	if a.Ruling(id) != Undecided {
+		return ErrRuled
+	}
+	stygos.StorageStore(storage.MapKey(a.base, id[:]), stygos.WordFromUint64(uint64(outcome)))
+	return nil
+}
+
+// Ruling returns the arbiter's outcome for claim id.
+func (a *Arbitrator) Ruling(id stygos.Word) Outcome {
+	return Outcome(stygos.Uint64FromWord(stygos.StorageLoad(storage.MapKey(a.base, id[:]))))
+}
+
+// Resolve implements Resolver with the recorded ruling.
+func (a *Arbitrator) Resolve(id stygos.Word, c *Claim) (Outcome, error) {
+	return a.Ruling(id), nil
+}
+
+// DefaultAfter returns a Resolver that asks r, and decides outcome when r
+// is still undecided delay seconds after the challenge, so an absent
+// arbiter cannot lock the bonds forever.
+func DefaultAfter(r Resolver, delay uint64, outcome Outcome) Resolver {
+	return ResolverFunc(func(id stygos.Word, c *Claim) (Outcome, error) {
+		decided, err := r.Resolve(id, c)
+		if err != nil || decided != Undecided {
+			return decided, err
+		}
+		if stygos.GetBlockTimestamp() >= c.ChallengedAt+delay {
+			return outcome, nil
+		}
+		return Undecided, nil
+	})
+}
@@ -0,0 +1,228 @@
+package dispute
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/payment"
+)
+
+var (
+	contract = stygos.Address{0xd1}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+	arbiter  = stygos.Address{0xab}
+	id       = stygos.Word{0x1d}
+	answer   = stygos.WordFromUint64(42)
+)
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+	return mock
+}
+
+// post calls f as from with the bond, which the contract then holds.
+func post(mock *stygos.MockRuntime, from stygos.Address, bond int64, f func() error) error {
+	mock.Sender = from
+	mock.Value = big.NewInt(bond)
+	defer func() { mock.Value = big.NewInt(0) }()
+	if err := f(); err != nil {
+		return err
+	}
+	mock.SetBalance(contract, new(big.Int).Add(mock.BalanceOf(contract), big.NewInt(bond)))
+	return nil
+}
+
+func TestUnchallenged(t *testing.T) {
+	mock := setup()
+	var accepted []stygos.Word
+	g := NewGame(stygos.Word{0xd0}, Config{
+		Bond:     stygos.NewU256(100),
+		Window:   3_600,
+		Resolver: ResolverFunc(func(stygos.Word, *Claim) (Outcome, error) { return ChallengerWins, nil }),
+		OnAccepted: func(id stygos.Word, c *Claim) error {
+			accepted = append(accepted, c.Data)
+			return nil
+		},
+	})
+
+	if err := post(mock, alice, 99, func() error { return g.Propose(id, answer) }); err != ErrBondMismatch {
+		t.Errorf("Propose failed. Expected ErrBondMismatch, got %v", err)
+	}
+	if err := post(mock, alice, 100, func() error { return g.Propose(id, answer) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if err := post(mock, bob, 100, func() error { return g.Propose(id, answer) }); err != ErrClaimExists {
+		t.Errorf("Propose failed. Expected ErrClaimExists, got %v", err)
+	}
+	c, err := g.Get(id)
+	if err != nil || c.Proposer != alice || c.Deadline != 4_600 || Status(c.Status) != Proposed || c.Data != answer {
+		t.Errorf("Get failed. Expected a claim by alice until 4600, got %+v, %v", c, err)
+	}
+	if len(mock.Logs) != 1 || !strings.Contains(string(mock.Logs[0]), hex.EncodeToString(ClaimProposedTopic[:])) {
+		t.Errorf("Propose failed. Expected a ClaimProposed event, got %v", mock.Logs)
+	}
+
+	if _, err := g.Resolve(id); err != ErrWindowOpen {
+		t.Errorf("Resolve failed. Expected ErrWindowOpen, got %v", err)
+	}
+	mock.Time = 4_600
+	if err := post(mock, bob, 100, func() error { return g.Challenge(id) }); err != ErrWindowClosed {
+		t.Errorf("Challenge failed. Expected ErrWindowClosed, got %v", err)
+	}
+	if status, err := g.Resolve(id); status != Accepted || err != nil {
+		t.Fatalf("Resolve failed. Expected Accepted, got %v, %v", status, err)
+	}
+	if got := g.Payments(alice); got != stygos.NewU256(100) {
+		t.Errorf("Resolve failed. Expected alice's bond credited, got %s", got)
+	}
+	if _, err := g.WithdrawPayments(alice); err != nil {
+		t.Fatalf("WithdrawPayments failed: %v", err)
+	}
+	if got := mock.BalanceOf(alice).Int64(); got != 100 {
+		t.Errorf("WithdrawPayments failed. Expected alice's bond back, got %d", got)
+	}
+	if len(accepted) != 1 || accepted[0] != answer {
+		t.Errorf("OnAccepted failed. Expected one call with the answer, got %v", accepted)
+	}
+	if _, err := g.Resolve(id); err != ErrNotPending {
+		t.Errorf("Resolve failed. Expected ErrNotPending, got %v", err)
+	}
+	if _, err := g.Get(stygos.Word{0x02}); err != ErrUnknownClaim {
+		t.Errorf("Get failed. Expected ErrUnknownClaim, got %v", err)
+	}
+}
+
+func TestArbitrator(t *testing.T) {
+	mock := setup()
+	arb := NewArbitrator(stygos.Word{0xa0}, arbiter)
+	var rejected int
+	g := NewGame(stygos.Word{0xd0}, Config{
+		Bond:       stygos.NewU256(100),
+		Window:     3_600,
+		Resolver:   arb,
+		OnRejected: func(stygos.Word, *Claim) error { rejected++; return nil },
+	})
+
+	// The proposer is right
+	if err := post(mock, alice, 100, func() error { return g.Propose(id, answer) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if err := post(mock, bob, 50, func() error { return g.Challenge(id) }); err != ErrBondMismatch {
+		t.Errorf("Challenge failed. Expected ErrBondMismatch, got %v", err)
+	}
+	mock.Time = 2_000
+	if err := post(mock, bob, 100, func() error { return g.Challenge(id) }); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if err := post(mock, bob, 100, func() error { return g.Challenge(id) }); err != ErrNotPending {
+		t.Errorf("Challenge failed. Expected ErrNotPending, got %v", err)
+	}
+	if _, err := g.Resolve(id); err != ErrUndecided {
+		t.Errorf("Resolve failed. Expected ErrUndecided, got %v", err)
+	}
+	mock.Sender = bob
+	if err := arb.Rule(id, ChallengerWins); err != ErrNotArbiter {
+		t.Errorf("Rule failed. Expected ErrNotArbiter, got %v", err)
+	}
+	mock.Sender = arbiter
+	if err := arb.Rule(id, Undecided); err != ErrInvalidOutcome {
+		t.Errorf("Rule failed. Expected ErrInvalidOutcome, got %v", err)
+	}
+	if err := arb.Rule(id, ProposerWins); err != nil {
+		t.Fatalf("Rule failed: %v", err)
+	}
+	if err := arb.Rule(id, ChallengerWins); err != ErrRuled {
+		t.Errorf("Rule failed. Expected ErrRuled, got %v", err)
+	}
+	if status, err := g.Resolve(id); status != Accepted || err != nil {
+		t.Fatalf("Resolve failed. Expected Accepted, got %v, %v", status, err)
+	}
+	if got := g.Payments(alice); got != stygos.NewU256(200) {
+		t.Errorf("Resolve failed. Expected alice to take both bonds, got %s", got)
+	}
+
+	// The challenger is right
+	other := stygos.Word{0x2d}
+	if err := post(mock, alice, 100, func() error { return g.Propose(other, answer) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if err := post(mock, bob, 100, func() error { return g.Challenge(other) }); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	mock.Sender = arbiter
+	if err := arb.Rule(other, ChallengerWins); err != nil {
+		t.Fatalf("Rule failed: %v", err)
+	}
+	if status, err := g.Resolve(other); status != Rejected || err != nil {
+		t.Fatalf("Resolve failed. Expected Rejected, got %v, %v", status, err)
+	}
+	if got := g.Payments(bob); got != stygos.NewU256(200) || rejected != 1 {
+		t.Errorf("Resolve failed. Expected bob to take both bonds and one OnRejected call, got %s and %d", got, rejected)
+	}
+	if len(mock.Logs) != 8 || !strings.Contains(string(mock.Logs[6]), hex.EncodeToString(ClaimResolvedTopic[:])) ||
+		!strings.Contains(string(mock.Logs[7]), hex.EncodeToString(payment.DepositedTopic[:])) {
+		t.Errorf("Resolve failed. Expected 8 events ending with ClaimResolved and Deposited, got %d", len(mock.Logs))
+	}
+}
+
+func TestDefaultAfter(t *testing.T) {
+	mock := setup()
+	arb := NewArbitrator(stygos.Word{0xa0}, arbiter)
+	g := NewGame(stygos.Word{0xd0}, Config{
+		Window:   3_600,
+		Resolver: DefaultAfter(arb, 86_400, ChallengerWins),
+	})
+
+	// Zero bonds are allowed
+	if err := post(mock, alice, 0, func() error { return g.Propose(id, answer) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if err := post(mock, bob, 0, func() error { return g.Challenge(id) }); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	mock.Time = 1_000 + 86_399
+	if _, err := g.Resolve(id); err != ErrUndecided {
+		t.Errorf("Resolve failed. Expected ErrUndecided before the delay, got %v", err)
+	}
+	mock.Time = 1_000 + 86_400
+	if status, err := g.Resolve(id); status != Rejected || err != nil {
+		t.Errorf("Resolve failed. Expected Rejected by default, got %v, %v", status, err)
+	}
+}
+
+func TestCallbackError(t *testing.T) {
+	mock := setup()
+	errFinalize := errors.New("finalize failed")
+	g := NewGame(stygos.Word{0xd0}, Config{
+		Window:     10,
+		Resolver:   ResolverFunc(func(stygos.Word, *Claim) (Outcome, error) { return Outcome(7), nil }),
+		OnAccepted: func(stygos.Word, *Claim) error { return errFinalize },
+	})
+
+	if err := post(mock, alice, 0, func() error { return g.Propose(id, answer) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if err := post(mock, bob, 0, func() error { return g.Challenge(id) }); err != nil {
+		t.Fatalf("Challenge failed: %v", err)
+	}
+	if _, err := g.Resolve(id); err != ErrInvalidOutcome {
+		t.Errorf("Resolve failed. Expected ErrInvalidOutcome, got %v", err)
+	}
+
+	other := stygos.Word{0x2d}
+	if err := post(mock, alice, 0, func() error { return g.Propose(other, answer) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	mock.Time = 1_010
+	if _, err := g.Resolve(other); err != errFinalize {
+		t.Errorf("Resolve failed. Expected the callback's error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Package ecdsa recovers the signers of secp256k1 ECDSA signatures through
+// the ecrecover precompile, as Solidity's ecrecover, with the malleability
+// checks of OpenZeppelin's ECDSA library.
+package ecdsa
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/strconvx"
+)
+
+// PrecompileAddress is the ecrecover precompile.
+var PrecompileAddress = stygos.Address{19: 0x01}
+
+// ECDSA errors
+var (
+	ErrInvalidSignature       = errors.New("ecdsa: invalid signature")
+	ErrInvalidSignatureLength = errors.New("ecdsa: invalid signature length")
+	ErrInvalidS               = errors.New("ecdsa: invalid signature s value")
+	ErrInvalidV               = errors.New("ecdsa: invalid signature v value")
+)
+
+// halfN is the secp256k1 order divided by two. Signatures with s above it
+// are rejected so that each message has one valid signature per key.
+var halfN = stygos.Word{
+	0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+	0x5D, 0x57, 0x6E, 0x73, 0x57, 0xA4, 0x50, 0x1D, 0xDF, 0xE9, 0x2F, 0x46, 0x68, 0x1B, 0x20, 0xA0,
+}
+
+// Signature is an Ethereum ECDSA signature. V is 27 or 28.
+type Signature struct {
+	V uint8
+	R stygos.Word
+	S stygos.Word
+}
+
+// SignatureFromBytes decodes the 65-byte r || s || v encoding. V may be
+// given as 0/1 or 27/28.
+func SignatureFromBytes(sig []byte) (Signature, error) {
+	if len(sig) != 65 {
+		return Signature{}, ErrInvalidSignatureLength
+	}
+	var s Signature
+	copy(s.R[:], sig[:32])
+	copy(s.S[:], sig[32:64])
+	s.V = sig[64]
+	if s.V < 27 {
+		s.V += 27
+	}
+	return s, nil
+}
+
+// Bytes returns the 65-byte r || s || v encoding.
+func (s Signature) Bytes() []byte {
+	out := make([]byte, 65)
+	copy(out, s.R[:])
+	copy(out[32:], s.S[:])
+	out[64] = s.V
+	return out
+}
+
+// Recover returns the address that signed hash. It rejects high s values
+// and v other than 27 or 28, and never returns the zero address.
+func Recover(hash stygos.Word, sig Signature) (stygos.Address, error) {
+	if stygos.U256FromWord(halfN).Lt(stygos.U256FromWord(sig.S)) {
+		return stygos.Address{}, ErrInvalidS
+	}
+	if sig.V != 27 && sig.V != 28 {
+		return stygos.Address{}, ErrInvalidV
+	}
+
+	input := make([]byte, 128)
+	copy(input, hash[:])
+	input[63] = sig.V
+	copy(input[64:], sig.R[:])
+	copy(input[96:], sig.S[:])
+	ret, err := stygos.StaticCall(PrecompileAddress, input)
+	if err != nil || len(ret) != 32 {
+		return stygos.Address{}, ErrInvalidSignature
+	}
+	var w stygos.Word
+	copy(w[:], ret)
+	signer := stygos.AddressFromWord(w)
+	if signer == (stygos.Address{}) {
+		return signer, ErrInvalidSignature
+	}
+	return signer, nil
+}
+
+// EthSignedMessageHash returns the EIP-191 hash personal_sign signs:
+// keccak256("\x19Ethereum Signed Message:\n" || len(msg) || msg).
+func EthSignedMessageHash(msg []byte) stygos.Word {
+	data := []byte("\x19Ethereum Signed Message:\n" + strconvx.Itoa(len(msg)))
+	return stygos.Keccak256(append(data, msg...))
+}
@@ -0,0 +1,151 @@
+package ecdsa
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	InstallMockEcrecover(mock)
+	return mock
+}
+
+func TestAddressOf(t *testing.T) {
+	want, _ := hex.DecodeString("7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+	if got := AddressOf(big.NewInt(1)); hex.EncodeToString(got[:]) != hex.EncodeToString(want) {
+		t.Errorf("AddressOf failed. Expected %x, got %x", want, got)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	setup()
+	key := big.NewInt(0xdeadbeef)
+	hash := EthSignedMessageHash([]byte("hello"))
+
+	sig, err := Sign(key, hash)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	signer, err := Recover(hash, sig)
+	if err != nil || signer != AddressOf(key) {
+		t.Fatalf("Recover failed. Expected %x, got %x, %v", AddressOf(key), signer, err)
+	}
+
+	decoded, err := SignatureFromBytes(sig.Bytes())
+	if err != nil || decoded != sig {
+		t.Errorf("SignatureFromBytes failed. Expected %+v, got %+v, %v", sig, decoded, err)
+	}
+	raw := sig.Bytes()
+	raw[64] -= 27
+	if decoded, _ := SignatureFromBytes(raw); decoded.V != sig.V {
+		t.Errorf("SignatureFromBytes failed. Expected v %d, got %d", sig.V, decoded.V)
+	}
+	if _, err := SignatureFromBytes(raw[:64]); err != ErrInvalidSignatureLength {
+		t.Errorf("SignatureFromBytes failed. Expected ErrInvalidSignatureLength, got %v", err)
+	}
+
+	// Another hash recovers another address
+	if other, _ := Recover(stygos.Word{1}, sig); other == signer {
+		t.Error("Recover failed. Expected a different signer for a different hash")
+	}
+
+	// The malleable twin (n - s, flipped v) is rejected
+	s := new(big.Int).SetBytes(sig.S[:])
+	n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	twin := sig
+	new(big.Int).Sub(n, s).FillBytes(twin.S[:])
+	twin.V = 27 + 28 - sig.V
+	if _, err := Recover(hash, twin); err != ErrInvalidS {
+		t.Errorf("Recover failed. Expected ErrInvalidS, got %v", err)
+	}
+	if addr, _ := RecoverAddress(hash, twin); addr != signer {
+		t.Errorf("RecoverAddress failed. Expected the twin to recover %x, got %x", signer, addr)
+	}
+
+	bad := sig
+	bad.V = 29
+	if _, err := Recover(hash, bad); err != ErrInvalidV {
+		t.Errorf("Recover failed. Expected ErrInvalidV, got %v", err)
+	}
+	bad = sig
+	bad.R = stygos.Word{}
+	if _, err := Recover(hash, bad); err != ErrInvalidSignature {
+		t.Errorf("Recover failed. Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestEthSignedMessageHash(t *testing.T) {
+	setup()
+	// keccak256("\x19Ethereum Signed Message:\n5hello")
+	want := "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750"
+	if got := EthSignedMessageHash([]byte("hello")); hex.EncodeToString(got[:]) != want {
+		t.Errorf("EthSignedMessageHash failed. Expected %s, got %x", want, got)
+	}
+}
+
+// approvals signs hash with each key, ordered by signer
+func approvals(t *testing.T, hash stygos.Word, keys ...int64) []Approval {
+	var out []Approval
+	for _, k := range keys {
+		sig, err := Sign(big.NewInt(k), hash)
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		out = append(out, Approval{Signer: AddressOf(big.NewInt(k)), Signature: sig})
+	}
+	sort.Slice(out, func(i, j int) bool { return bytes.Compare(out[i].Signer[:], out[j].Signer[:]) < 0 })
+	return out
+}
+
+func TestVerifyApprovals(t *testing.T) {
+	setup()
+	hash := stygos.Keccak256([]byte("proposal"))
+	list := approvals(t, hash, 1, 2, 3)
+
+	decoded, err := DecodeApprovals(EncodeApprovals(list))
+	if err != nil || len(decoded) != 3 || decoded[2] != list[2] {
+		t.Fatalf("DecodeApprovals failed. Expected %+v, got %+v, %v", list, decoded, err)
+	}
+	if _, err := DecodeApprovals(make([]byte, ApprovalLength+1)); err != ErrInvalidSignatureLength {
+		t.Errorf("DecodeApprovals failed. Expected ErrInvalidSignatureLength, got %v", err)
+	}
+
+	if err := VerifyApprovals(hash, list, 3, nil); err != nil {
+		t.Errorf("VerifyApprovals failed: %v", err)
+	}
+	if err := VerifyApprovals(hash, list, 4, nil); err != ErrBelowThreshold {
+		t.Errorf("VerifyApprovals failed. Expected ErrBelowThreshold, got %v", err)
+	}
+	if err := VerifyApprovals(hash, nil, 0, nil); err != ErrBelowThreshold {
+		t.Errorf("VerifyApprovals failed. Expected ErrBelowThreshold for no approvals, got %v", err)
+	}
+
+	duplicate := []Approval{list[0], list[0]}
+	swapped := []Approval{list[1], list[0]}
+	for name, l := range map[string][]Approval{"duplicate": duplicate, "swapped": swapped} {
+		if err := VerifyApprovals(hash, l, 1, nil); err != ErrUnsortedSigners {
+			t.Errorf("VerifyApprovals %s failed. Expected ErrUnsortedSigners, got %v", name, err)
+		}
+	}
+	signers := []stygos.Address{list[0].Signer, list[1].Signer}
+	if CheckSorted(signers) != nil || CheckSorted([]stygos.Address{signers[1], signers[0]}) != ErrUnsortedSigners {
+		t.Error("CheckSorted failed. Expected only ascending signers to pass")
+	}
+
+	only := func(a stygos.Address) bool { return a != list[1].Signer }
+	if err := VerifyApprovals(hash, list, 1, only); err != ErrUnknownSigner {
+		t.Errorf("VerifyApprovals failed. Expected ErrUnknownSigner, got %v", err)
+	}
+	forged := append([]Approval(nil), list...)
+	forged[1].Signature = forged[0].Signature
+	if err := VerifyApprovals(hash, forged, 1, nil); err != ErrSignerMismatch {
+		t.Errorf("VerifyApprovals failed. Expected ErrSignerMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+//go:build !tinygo
+
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/schnorr"
+	"golang.org/x/crypto/sha3"
+)
+
+// InstallMockEcrecover deploys a Go implementation of the ecrecover
+// precompile on rt. Like the precompile it returns no data for signatures
+// it cannot recover.
+func InstallMockEcrecover(rt *stygos.MockRuntime) {
+	rt.Deploy(PrecompileAddress, func(input []byte) ([]byte, error) {
+		in := make([]byte, 128)
+		copy(in, input)
+		var hash stygos.Word
+		copy(hash[:], in[:32])
+		for _, b := range in[32:63] {
+			if b != 0 {
+				return nil, nil
+			}
+		}
+		var sig Signature
+		sig.V = in[63]
+		copy(sig.R[:], in[64:96])
+		copy(sig.S[:], in[96:128])
+
+		signer, err := RecoverAddress(hash, sig)
+		if err != nil {
+			return nil, nil
+		}
+		w := stygos.PadAddress(signer)
+		return w[:], nil
+	})
+}
+
+// RecoverAddress recovers the signer of hash in Go, without the
+// precompile and without the high-s check.
+func RecoverAddress(hash stygos.Word, sig Signature) (stygos.Address, error) {
+	if sig.V != 27 && sig.V != 28 {
+		return stygos.Address{}, ErrInvalidV
+	}
+	r := new(big.Int).SetBytes(sig.R[:])
+	s := new(big.Int).SetBytes(sig.S[:])
+	if r.Sign() == 0 || r.Cmp(schnorr.N) >= 0 || s.Sign() == 0 || s.Cmp(schnorr.N) >= 0 {
+		return stygos.Address{}, ErrInvalidSignature
+	}
+
+	R, err := schnorr.LiftX(r)
+	if err != nil {
+		return stygos.Address{}, ErrInvalidSignature
+	}
+	if sig.V == 28 {
+		R = schnorr.Neg(R)
+	}
+
+	// Q = r⁻¹(s·R - e·G)
+	e := new(big.Int).SetBytes(hash[:])
+	e.Mod(e, schnorr.N)
+	rInv := new(big.Int).ModInverse(r, schnorr.N)
+	sR := schnorr.Mul(R, s)
+	eG := schnorr.Mul(schnorr.G(), e)
+	Q := schnorr.Mul(schnorr.Add(sR, schnorr.Neg(eG)), rInv)
+	if Q.IsInfinity() {
+		return stygos.Address{}, ErrInvalidSignature
+	}
+	return pubkeyAddress(Q), nil
+}
+
+// AddressOf returns the address of the secret key d.
+func AddressOf(d *big.Int) stygos.Address {
+	return pubkeyAddress(schnorr.Mul(schnorr.G(), d))
+}
+
+// Sign signs hash with the secret key d, returning a low-s signature. The
+// nonce is derived deterministically from d and hash. For tests and
+// tooling; it is not constant time.
+func Sign(d *big.Int, hash stygos.Word) (Signature, error) {
+	if d.Sign() <= 0 || d.Cmp(schnorr.N) >= 0 {
+		return Signature{}, schnorr.ErrScalarOutOfRange
+	}
+	key := make([]byte, 32)
+	d.FillBytes(key)
+	e := new(big.Int).SetBytes(hash[:])
+	e.Mod(e, schnorr.N)
+	halfOrder := new(big.Int).Rsh(schnorr.N, 1)
+
+	for ctr := byte(0); ; ctr++ {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(hash[:])
+		mac.Write([]byte{ctr})
+		k := new(big.Int).SetBytes(mac.Sum(nil))
+		k.Mod(k, schnorr.N)
+		if k.Sign() == 0 {
+			continue
+		}
+		R := schnorr.Mul(schnorr.G(), k)
+		if R.X.Cmp(schnorr.N) >= 0 {
+			continue
+		}
+
+		// s = k⁻¹(e + r·d)
+		s := new(big.Int).Mul(R.X, d)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, schnorr.N))
+		s.Mod(s, schnorr.N)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		v := uint8(27 + R.Y.Bit(0))
+		if s.Cmp(halfOrder) > 0 {
+			s.Sub(schnorr.N, s)
+			v = 27 + 28 - v
+		}
+		var sig Signature
+		sig.V = v
+		R.X.FillBytes(sig.R[:])
+		s.FillBytes(sig.S[:])
+		return sig, nil
+	}
+}
+
+func pubkeyAddress(p schnorr.Point) stygos.Address {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(p.Bytes())
+	var addr stygos.Address
+	copy(addr[:], h.Sum(nil)[12:])
+	return addr
+}
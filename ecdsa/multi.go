@@ -0,0 +1,96 @@
+package ecdsa
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Multi-signature errors
+var (
+	ErrUnsortedSigners = errors.New("ecdsa: signers not in ascending order")
+	ErrSignerMismatch  = errors.New("ecdsa: signature does not match signer")
+	ErrUnknownSigner   = errors.New("ecdsa: unknown signer")
+	ErrBelowThreshold  = errors.New("ecdsa: not enough signatures")
+)
+
+// ApprovalLength is the encoded size of an Approval: the 20-byte signer
+// followed by its 65-byte signature.
+const ApprovalLength = 20 + 65
+
+// Approval is a signature by a claimed signer. Naming the signer lets
+// uniqueness be checked on the list before any signature is recovered.
+type Approval struct {
+	Signer    stygos.Address
+	Signature Signature
+}
+
+// DecodeApprovals decodes a concatenation of signer || r || s || v
+// entries.
+func DecodeApprovals(data []byte) ([]Approval, error) {
+	if len(data)%ApprovalLength != 0 {
+		return nil, ErrInvalidSignatureLength
+	}
+	out := make([]Approval, len(data)/ApprovalLength)
+	for i := range out {
+		entry := data[i*ApprovalLength : (i+1)*ApprovalLength]
+		copy(out[i].Signer[:], entry[:20])
+		sig, err := SignatureFromBytes(entry[20:])
+		if err != nil {
+			return nil, err
+		}
+		out[i].Signature = sig
+	}
+	return out, nil
+}
+
+// EncodeApprovals is the inverse of DecodeApprovals.
+func EncodeApprovals(approvals []Approval) []byte {
+	out := make([]byte, 0, len(approvals)*ApprovalLength)
+	for _, a := range approvals {
+		out = append(out, a.Signer[:]...)
+		out = append(out, a.Signature.Bytes()...)
+	}
+	return out
+}
+
+// CheckSorted requires signers to be in strictly ascending order, which
+// rules out duplicates without a set.
+func CheckSorted(signers []stygos.Address) error {
+	for i := 1; i < len(signers); i++ {
+		if bytes.Compare(signers[i-1][:], signers[i][:]) >= 0 {
+			return ErrUnsortedSigners
+		}
+	}
+	return nil
+}
+
+// VerifyApprovals checks that each approval is a signature over hash by
+// its signer, that the signers are distinct and in ascending order, that
+// isSigner accepts each of them, and that there are at least threshold
+// of them, and at least one. A nil isSigner accepts any signer. The
+// cheap checks all run before the first recovery.
+func VerifyApprovals(hash stygos.Word, approvals []Approval, threshold uint64, isSigner func(stygos.Address) bool) error {
+	if len(approvals) == 0 || uint64(len(approvals)) < threshold {
+		return ErrBelowThreshold
+	}
+	for i, a := range approvals {
+		if i > 0 && bytes.Compare(approvals[i-1].Signer[:], a.Signer[:]) >= 0 {
+			return ErrUnsortedSigners
+		}
+		if isSigner != nil && !isSigner(a.Signer) {
+			return ErrUnknownSigner
+		}
+	}
+	for _, a := range approvals {
+		signer, err := Recover(hash, a.Signature)
+		if err != nil {
+			return err
+		}
+		if signer != a.Signer {
+			return ErrSignerMismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package eip712 hashes typed structured data as specified by EIP-712, for
+// verifying signatures made with eth_signTypedData.
+//
+// Contracts compute a struct hash with HashStruct from the type hash and
+// the encoded fields, then the digest to verify with Digest:
+//
+//	domain := eip712.NewDomain("MyApp", "1")
+//	digest := eip712.Digest(domain.Separator(), eip712.HashStruct(typeHash, fields...))
+package eip712
+
+import "github.com/rafaelescrich/stygos"
+
+// DomainType is the EIP-712 domain type this package hashes.
+const DomainType = "EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"
+
+// Domain is an EIP-712 domain separating signatures between applications,
+// versions, chains and contracts.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract stygos.Address
+}
+
+// NewDomain returns the domain of the executing contract on the current
+// chain.
+func NewDomain(name, version string) Domain {
+	return Domain{
+		Name:              name,
+		Version:           version,
+		ChainID:           stygos.GetChainID(),
+		VerifyingContract: stygos.GetContractAddress(),
+	}
+}
+
+// Separator returns the domain separator, the struct hash of the domain.
+func (d Domain) Separator() stygos.Word {
+	return HashStruct(TypeHash(DomainType),
+		HashString(d.Name),
+		HashString(d.Version),
+		stygos.WordFromUint64(d.ChainID),
+		stygos.PadAddress(d.VerifyingContract))
+}
+
+// TypeHash returns keccak256 of an encoded type such as
+// "Mail(address from,address to,string contents)".
+func TypeHash(encodedType string) stygos.Word {
+	return stygos.Keccak256([]byte(encodedType))
+}
+
+// HashStruct returns keccak256(typeHash || fields...). Atomic fields are
+// encoded as ABI words; strings, bytes, arrays and nested structs by their
+// hashes (HashString, HashBytes, HashArray, HashStruct).
+func HashStruct(typeHash stygos.Word, fields ...stygos.Word) stygos.Word {
+	data := make([]byte, 0, 32*(1+len(fields)))
+	data = append(data, typeHash[:]...)
+	for _, f := range fields {
+		data = append(data, f[:]...)
+	}
+	return stygos.Keccak256(data)
+}
+
+// HashString encodes a string field.
+func HashString(s string) stygos.Word {
+	return stygos.Keccak256([]byte(s))
+}
+
+// HashBytes encodes a bytes field.
+func HashBytes(b []byte) stygos.Word {
+	return stygos.Keccak256(b)
+}
+
+// HashArray encodes an array field from its encoded elements.
+func HashArray(elems ...stygos.Word) stygos.Word {
+	data := make([]byte, 0, 32*len(elems))
+	for _, e := range elems {
+		data = append(data, e[:]...)
+	}
+	return stygos.Keccak256(data)
+}
+
+// Digest returns keccak256("\x19\x01" || domainSeparator || structHash), the
+// hash that is signed.
+func Digest(domainSeparator, structHash stygos.Word) stygos.Word {
+	data := make([]byte, 2, 66)
+	data[0], data[1] = 0x19, 0x01
+	data = append(data, domainSeparator[:]...)
+	data = append(data, structHash[:]...)
+	return stygos.Keccak256(data)
+}
@@ -0,0 +1,142 @@
+package eip712
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func addr(s string) stygos.Address {
+	var a stygos.Address
+	hex.Decode(a[:], []byte(s))
+	return a
+}
+
+// The Mail example of the EIP-712 specification
+func TestMailExample(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	domain := Domain{
+		Name:              "Ether Mail",
+		Version:           "1",
+		ChainID:           1,
+		VerifyingContract: addr("cccccccccccccccccccccccccccccccccccccccc"),
+	}
+	personType := TypeHash("Person(string name,address wallet)")
+	mailType := TypeHash("Mail(Person from,Person to,string contents)Person(string name,address wallet)")
+	mail := HashStruct(mailType,
+		HashStruct(personType, HashString("Cow"), stygos.PadAddress(addr("cd2a3d9f938e13cd947ec05abc7fe734df8dd826"))),
+		HashStruct(personType, HashString("Bob"), stygos.PadAddress(addr("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"))),
+		HashString("Hello, Bob!"))
+	emptyVersion := domain
+	emptyVersion.Version = ""
+
+	tests := []struct {
+		name string
+		got  stygos.Word
+		want string
+	}{
+		{"Separator", domain.Separator(), "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"},
+		{"HashStruct", mail, "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"},
+		{"Digest", Digest(domain.Separator(), mail), "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"},
+		// Empty strings and bytes hash to keccak256(""), not to zero
+		{"HashBytes", HashBytes(nil), "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"Separator without a version", emptyVersion.Separator(), "7ad05cea6873853e71a88bf72261254fa19b842100cbbc324078ffe04fa0bba9"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.got[:]); got != tt.want {
+			t.Errorf("%s failed. Expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestNewDomain(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.Chain = 42161
+	stygos.UseRuntime(mock)
+
+	d := NewDomain("App", "2")
+	if d.ChainID != 42161 || d.VerifyingContract != mock.Contract {
+		t.Errorf("NewDomain failed. Expected chain 42161 and the contract address, got %d, %x", d.ChainID, d.VerifyingContract)
+	}
+}
+
+func TestReplayGuard(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.Chain = 42161
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+	signer := stygos.Address{0x51}
+	domain := NewDomain("App", "1")
+	stamp := func(nonce, expiry uint64) Stamp { return domain.Stamp(signer, stygos.NewU256(nonce), expiry) }
+
+	g := NewReplayGuard(stygos.Word{0x0a})
+	other := stamp(0, 2_000)
+	other.ChainID = 1
+	if err := g.Consume(other); err != ErrWrongChain {
+		t.Errorf("Consume failed. Expected ErrWrongChain, got %v", err)
+	}
+	other = stamp(0, 2_000)
+	other.Contract = stygos.Address{0xc1}
+	if err := g.Consume(other); err != ErrWrongContract {
+		t.Errorf("Consume failed. Expected ErrWrongContract, got %v", err)
+	}
+	if err := g.Consume(stamp(0, 1_000)); err != ErrExpired {
+		t.Errorf("Consume failed. Expected ErrExpired at the expiry, got %v", err)
+	}
+	if err := g.Consume(stamp(1, 2_000)); err != ErrInvalidNonce {
+		t.Errorf("Consume failed. Expected ErrInvalidNonce out of order, got %v", err)
+	}
+	if err := g.Consume(stamp(0, 2_000)); err != nil {
+		t.Fatalf("Consume failed: %v", err)
+	}
+	if err := g.Consume(stamp(0, 2_000)); err != ErrInvalidNonce {
+		t.Errorf("Consume failed. Expected ErrInvalidNonce on replay, got %v", err)
+	}
+	g.Invalidate(signer, stygos.NewU256(4))
+	if g.NextNonce(signer) != stygos.NewU256(5) || !g.Used(signer, stygos.NewU256(3)) {
+		t.Errorf("Invalidate failed. Expected the next nonce to be 5, got %s", g.NextNonce(signer))
+	}
+	g.Invalidate(signer, stygos.NewU256(2))
+	if g.NextNonce(signer) != stygos.NewU256(5) {
+		t.Errorf("Invalidate failed. Expected a lower nonce to be ignored, got %s", g.NextNonce(signer))
+	}
+
+	// The next nonce never wraps back to 0, which would reopen nonces 0 to 4
+	last := stygos.U256{}.Sub(stygos.NewU256(1))
+	if err := g.Invalidate(signer, last); err != ErrNonceOverflow || g.NextNonce(signer) != stygos.NewU256(5) {
+		t.Errorf("Invalidate failed. Expected ErrNonceOverflow and the next nonce to stay 5, got %v, %s", err, g.NextNonce(signer))
+	}
+	if err := g.Invalidate(signer, last.Sub(stygos.NewU256(1))); err != nil || g.NextNonce(signer) != last {
+		t.Fatalf("Invalidate failed. Expected the next nonce to be 2^256-1, got %v, %s", err, g.NextNonce(signer))
+	}
+	for name, fn := range map[string]func(Stamp) error{"Check": g.Check, "Consume": g.Consume} {
+		if err := fn(domain.Stamp(signer, last, 2_000)); err != ErrNonceOverflow {
+			t.Errorf("%s failed. Expected ErrNonceOverflow for nonce 2^256-1, got %v", name, err)
+		}
+	}
+	if g.NextNonce(signer) != last || !g.Used(signer, stygos.NewU256(0)) {
+		t.Errorf("Consume failed. Expected the next nonce to stay 2^256-1, got %s", g.NextNonce(signer))
+	}
+
+	u := NewUnorderedReplayGuard(stygos.Word{0x0b})
+	if err := u.Consume(stamp(7, 2_000)); err != nil {
+		t.Fatalf("Consume failed: %v", err)
+	}
+	if err := u.Check(stamp(3, 2_000)); err != nil {
+		t.Errorf("Check failed. Expected a lower unused nonce to pass, got %v", err)
+	}
+	if err := u.Consume(stamp(7, 2_000)); err != ErrInvalidNonce {
+		t.Errorf("Consume failed. Expected ErrInvalidNonce on replay, got %v", err)
+	}
+	u.Invalidate(signer, stygos.NewU256(3))
+	if !u.Used(signer, stygos.NewU256(3)) || u.Used(signer, stygos.NewU256(4)) {
+		t.Errorf("Invalidate failed. Expected only nonce 3 to be used")
+	}
+	if err := u.Consume(domain.Stamp(signer, stygos.NewU256(1).Lsh(64), 2_000)); err != ErrInvalidNonce {
+		t.Errorf("Consume failed. Expected ErrInvalidNonce for a nonce over 64 bits, got %v", err)
+	}
+}
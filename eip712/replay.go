@@ -0,0 +1,156 @@
+package eip712
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Replay errors
+var (
+	ErrWrongChain    = errors.New("eip712: signed for another chain")
+	ErrWrongContract = errors.New("eip712: signed for another contract")
+	ErrExpired       = errors.New("eip712: signature expired")
+	ErrInvalidNonce  = errors.New("eip712: nonce already used or out of order")
+	ErrNonceOverflow = errors.New("eip712: nonce 2^256-1 cannot be used")
+)
+
+// Stamp holds the fields of a signed message that bind it to one use: the
+// chain and contract it is for, its signer's nonce and its expiry. They
+// must all be covered by the signature, directly or through the domain
+// separator.
+type Stamp struct {
+	ChainID  uint64
+	Contract stygos.Address
+	Signer   stygos.Address
+	Nonce    stygos.U256
+	Expiry   uint64 // unix seconds, exclusive; math.MaxUint64 never expires
+}
+
+// Stamp returns the stamp of a message signed under d. Checking it catches
+// a domain cached before a chain fork or copied from another deployment.
+func (d Domain) Stamp(signer stygos.Address, nonce stygos.U256, expiry uint64) Stamp {
+	return Stamp{ChainID: d.ChainID, Contract: d.VerifyingContract, Signer: signer, Nonce: nonce, Expiry: expiry}
+}
+
+// ReplayGuard checks and consumes stamps of signed messages, so a message
+// is only accepted on its chain and contract, before its expiry, and once.
+// Sequential nonces must be used in order, as in ERC-2612 permits;
+// unordered nonces may be used in any order, each once, as in Permit2.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, signer)   next nonce (sequential), or a storage.Bitmap
+//	                       of used nonces (unordered)
+type ReplayGuard struct {
+	base      stygos.Word
+	unordered bool
+}
+
+// NewReplayGuard returns the guard rooted at base with sequential nonces.
+func NewReplayGuard(base stygos.Word) *ReplayGuard {
+	return &ReplayGuard{base: base}
+}
+
+// NewUnorderedReplayGuard returns the guard rooted at base with unordered
+// nonces, which must fit in 64 bits.
+func NewUnorderedReplayGuard(base stygos.Word) *ReplayGuard {
+	return &ReplayGuard{base: base, unordered: true}
+}
+
+// Check verifies s without consuming its nonce.
+func (g *ReplayGuard) Check(s Stamp) error {
+	if s.ChainID != stygos.GetChainID() {
+		return ErrWrongChain
+	}
+	if s.Contract != stygos.GetContractAddress() {
+		return ErrWrongContract
+	}
+	if stygos.GetBlockTimestamp() >= s.Expiry {
+		return ErrExpired
+	}
+	if g.unordered {
+		if !s.Nonce.IsUint64() || g.bitmap(s.Signer).Get(s.Nonce.Uint64()) {
+			return ErrInvalidNonce
+		}
+	} else if g.NextNonce(s.Signer) != s.Nonce {
+		return ErrInvalidNonce
+	} else if _, err := after(s.Nonce); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Consume verifies s and uses its nonce. Contracts call it once the
+// signature is verified and before acting on the message.
+func (g *ReplayGuard) Consume(s Stamp) error {
+	if err := g.Check(s); err != nil {
+		return err
+	}
+	if g.unordered {
+		g.bitmap(s.Signer).Set(s.Nonce.Uint64())
+		return nil
+	}
+	next, err := after(s.Nonce)
+	if err != nil {
+		return err
+	}
+	stygos.StorageStore(g.slot(s.Signer), next.Word())
+	return nil
+}
+
+// NextNonce returns the nonce of signer's next message with sequential
+// nonces.
+func (g *ReplayGuard) NextNonce(signer stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(g.slot(signer)))
+}
+
+// Used reports whether signer's nonce can no longer be used.
+func (g *ReplayGuard) Used(signer stygos.Address, nonce stygos.U256) bool {
+	if g.unordered {
+		return !nonce.IsUint64() || g.bitmap(signer).Get(nonce.Uint64())
+	}
+	return nonce.Lt(g.NextNonce(signer))
+}
+
+// Invalidate cancels signer's messages with nonce: with sequential nonces
+// it skips to nonce+1, cancelling every lower nonce too, and with
+// unordered ones it marks nonce used. Contracts let signers call it for
+// themselves to revoke signatures they handed out. Sequential nonces
+// cannot skip past 2^256-1, which would wrap the next nonce to 0 and
+// reopen every consumed one, so that nonce fails with ErrNonceOverflow.
+func (g *ReplayGuard) Invalidate(signer stygos.Address, nonce stygos.U256) error {
+	if g.unordered {
+		if nonce.IsUint64() {
+			g.bitmap(signer).Set(nonce.Uint64())
+		}
+		return nil
+	}
+	if g.Used(signer, nonce) {
+		return nil
+	}
+	next, err := after(nonce)
+	if err != nil {
+		return err
+	}
+	stygos.StorageStore(g.slot(signer), next.Word())
+	return nil
+}
+
+// after returns the sequential nonce following nonce.
+func after(nonce stygos.U256) (stygos.U256, error) {
+	next, ok := nonce.CheckedAdd(stygos.NewU256(1))
+	if !ok {
+		return stygos.U256{}, ErrNonceOverflow
+	}
+	return next, nil
+}
+
+func (g *ReplayGuard) slot(signer stygos.Address) stygos.Word {
+	return storage.MapKey(g.base, signer[:])
+}
+
+func (g *ReplayGuard) bitmap(signer stygos.Address) *storage.Bitmap {
+	return storage.NewBitmap(g.slot(signer))
+}
@@ -0,0 +1,106 @@
+// Package base64 implements standard (RFC 4648) base64 with padding.
+//
+// It is a small, table-driven replacement for encoding/base64 meant for
+// building data URIs on chain: no reflection or interfaces, and the
+// encoder appends to a caller supplied buffer so a URI can be assembled in
+// one allocation.
+package base64
+
+import "errors"
+
+// ErrInvalidInput is returned when decoding malformed base64.
+var ErrInvalidInput = errors.New("base64: invalid input")
+
+const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// decodeMap maps alphabet characters to their value and others to 0xff.
+var decodeMap = func() [256]byte {
+	var m [256]byte
+	for i := range m {
+		m[i] = 0xff
+	}
+	for i := 0; i < len(alphabet); i++ {
+		m[alphabet[i]] = byte(i)
+	}
+	return m
+}()
+
+// EncodedLen returns the length of the encoding of n bytes.
+func EncodedLen(n int) int {
+	return (n + 2) / 3 * 4
+}
+
+// DecodedLen returns the maximum length of the data encoded in n bytes.
+func DecodedLen(n int) int {
+	return n / 4 * 3
+}
+
+// AppendEncode appends the encoding of src to dst.
+func AppendEncode(dst, src []byte) []byte {
+	for len(src) >= 3 {
+		v := uint(src[0])<<16 | uint(src[1])<<8 | uint(src[2])
+		dst = append(dst, alphabet[v>>18&0x3f], alphabet[v>>12&0x3f], alphabet[v>>6&0x3f], alphabet[v&0x3f])
+		src = src[3:]
+	}
+
+	switch len(src) {
+	case 1:
+		v := uint(src[0]) << 16
+		dst = append(dst, alphabet[v>>18&0x3f], alphabet[v>>12&0x3f], '=', '=')
+	case 2:
+		v := uint(src[0])<<16 | uint(src[1])<<8
+		dst = append(dst, alphabet[v>>18&0x3f], alphabet[v>>12&0x3f], alphabet[v>>6&0x3f], '=')
+	}
+	return dst
+}
+
+// Encode returns the encoding of src.
+func Encode(src []byte) []byte {
+	return AppendEncode(make([]byte, 0, EncodedLen(len(src))), src)
+}
+
+// EncodeToString returns the encoding of src as a string.
+func EncodeToString(src []byte) string {
+	return string(Encode(src))
+}
+
+// Decode decodes padded base64. Whitespace is not accepted.
+func Decode(src []byte) ([]byte, error) {
+	if len(src)%4 != 0 {
+		return nil, ErrInvalidInput
+	}
+	dst := make([]byte, 0, DecodedLen(len(src)))
+	for i := 0; i < len(src); i += 4 {
+		a, b, c, d := decodeMap[src[i]], decodeMap[src[i+1]], src[i+2], src[i+3]
+		if a == 0xff || b == 0xff {
+			return nil, ErrInvalidInput
+		}
+		last := i+4 == len(src)
+
+		switch {
+		case c == '=' && d == '=' && last:
+			if b&0x0f != 0 {
+				return nil, ErrInvalidInput
+			}
+			dst = append(dst, a<<2|b>>4)
+		case d == '=' && last:
+			cv := decodeMap[c]
+			if cv == 0xff || cv&0x03 != 0 {
+				return nil, ErrInvalidInput
+			}
+			dst = append(dst, a<<2|b>>4, b<<4|cv>>2)
+		default:
+			cv, dv := decodeMap[c], decodeMap[d]
+			if cv == 0xff || dv == 0xff {
+				return nil, ErrInvalidInput
+			}
+			dst = append(dst, a<<2|b>>4, b<<4|cv>>2, cv<<6|dv)
+		}
+	}
+	return dst, nil
+}
+
+// DecodeString decodes the padded base64 string s.
+func DecodeString(s string) ([]byte, error) {
+	return Decode([]byte(s))
+}
@@ -0,0 +1,60 @@
+package base64
+
+import (
+	"bytes"
+	stdbase64 "encoding/base64"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	// RFC 4648 test vectors
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"f", "Zg=="},
+		{"fo", "Zm8="},
+		{"foo", "Zm9v"},
+		{"foob", "Zm9vYg=="},
+		{"fooba", "Zm9vYmE="},
+		{"foobar", "Zm9vYmFy"},
+	}
+
+	for _, tt := range tests {
+		if got := EncodeToString([]byte(tt.in)); got != tt.want {
+			t.Errorf("EncodeToString(%q) failed. Expected %q, got %q", tt.in, tt.want, got)
+		}
+		got, err := DecodeString(tt.want)
+		if err != nil || string(got) != tt.in {
+			t.Errorf("DecodeString(%q) failed. Expected %q, got %q, %v", tt.want, tt.in, got, err)
+		}
+	}
+}
+
+func TestMatchesStdlib(t *testing.T) {
+	data := make([]byte, 300)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	for n := 0; n <= len(data); n += 13 {
+		want := stdbase64.StdEncoding.EncodeToString(data[:n])
+		got := Encode(data[:n])
+		if string(got) != want {
+			t.Errorf("Encode of %d bytes differs from encoding/base64", n)
+		}
+		if len(got) != EncodedLen(n) {
+			t.Errorf("EncodedLen(%d) failed. Expected %d, got %d", n, len(got), EncodedLen(n))
+		}
+		back, err := Decode(got)
+		if err != nil || !bytes.Equal(back, data[:n]) {
+			t.Errorf("Decode of %d bytes failed: %v", n, err)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, in := range []string{"Zg=", "Z===", "Zh==", "Zm9=", "Zm9v!A==", "Zg==Zg==", "Zm=v"} {
+		if _, err := DecodeString(in); err != ErrInvalidInput {
+			t.Errorf("DecodeString(%q) failed. Expected ErrInvalidInput, got %v", in, err)
+		}
+	}
+}
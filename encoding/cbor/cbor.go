@@ -0,0 +1,507 @@
+// Package cbor encodes and decodes compact structured messages in the
+// deterministic CBOR of RFC 8949 (section 4.2), for payloads exchanged with
+// off-chain agents and other chains where every byte of calldata counts.
+//
+// Messages are written with the Append functions, which append one item
+// to a caller supplied buffer, and read back with a Decoder, one item at
+// a time and without reflection:
+//
+//	msg := cbor.AppendMapHeader(nil, 2)
+//	msg = cbor.AppendUint(msg, 1) // key 1: recipient
+//	msg = cbor.AppendBytes(msg, to[:])
+//	msg = cbor.AppendUint(msg, 2) // key 2: amount
+//	msg = cbor.AppendU256(msg, amount)
+//
+// Deterministic encoding makes each value's encoding unique, so messages
+// can be hashed and signed. The Append functions always write the shortest
+// form; the writer must give arrays and maps their exact length and write
+// map keys in ascending order of their encoded bytes. The Decoder rejects
+// any other form with ErrNotCanonical, and Validate checks a whole message,
+// map key order included. Indefinite lengths and floating-point numbers
+// are not supported.
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// MaxDepth is the deepest nesting of arrays, maps and tags that Skip and
+// Validate accept.
+const MaxDepth = 32
+
+// CBOR errors
+var (
+	ErrTruncated    = errors.New("cbor: unexpected end of data")
+	ErrType         = errors.New("cbor: unexpected item type")
+	ErrNotCanonical = errors.New("cbor: not in deterministic encoding")
+	ErrUnsupported  = errors.New("cbor: unsupported item")
+	ErrOverflow     = errors.New("cbor: value out of range")
+	ErrInvalidUTF8  = errors.New("cbor: text string is not valid UTF-8")
+	ErrDepth        = errors.New("cbor: nesting too deep")
+	ErrTrailingData = errors.New("cbor: trailing data after item")
+)
+
+// Major types
+const (
+	majorUint   = 0
+	majorNeg    = 1
+	majorBytes  = 2
+	majorText   = 3
+	majorArray  = 4
+	majorMap    = 5
+	majorTag    = 6
+	majorSimple = 7
+)
+
+// Simple values
+const (
+	simpleFalse = 20
+	simpleTrue  = 21
+	simpleNull  = 22
+)
+
+// TagBignum marks a byte string holding an unsigned integer too large for
+// a plain integer item.
+const TagBignum = 2
+
+// Kind is the type of a CBOR item.
+type Kind byte
+
+// Item kinds
+const (
+	KindUint     Kind = iota // unsigned integer
+	KindNegative             // negative integer
+	KindBytes                // byte string
+	KindString               // text string
+	KindArray
+	KindMap
+	KindTag
+	KindBool
+	KindNull
+)
+
+// appendHead appends the initial byte of an item of the given major type
+// and its argument v, in the shortest form.
+func appendHead(dst []byte, major byte, v uint64) []byte {
+	m := major << 5
+	switch {
+	case v < 24:
+		return append(dst, m|byte(v))
+	case v <= 0xff:
+		return append(dst, m|24, byte(v))
+	case v <= 0xffff:
+		return append(dst, m|25, byte(v>>8), byte(v))
+	case v <= 0xffffffff:
+		return append(dst, m|26, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	}
+	return append(dst, m|27, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// AppendUint appends v as an unsigned integer.
+func AppendUint(dst []byte, v uint64) []byte {
+	return appendHead(dst, majorUint, v)
+}
+
+// AppendInt appends v as an unsigned or negative integer.
+func AppendInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		return appendHead(dst, majorNeg, uint64(^v))
+	}
+	return appendHead(dst, majorUint, uint64(v))
+}
+
+// AppendU256 appends v as an unsigned integer, or as a bignum when it
+// does not fit in 64 bits.
+func AppendU256(dst []byte, v stygos.U256) []byte {
+	if v.IsUint64() {
+		return appendHead(dst, majorUint, v.Uint64())
+	}
+	w := v.Word()
+	skip := (256 - v.BitLen()) / 8
+	dst = appendHead(dst, majorTag, TagBignum)
+	return AppendBytes(dst, w[skip:])
+}
+
+// AppendBytes appends b as a byte string.
+func AppendBytes(dst, b []byte) []byte {
+	dst = appendHead(dst, majorBytes, uint64(len(b)))
+	return append(dst, b...)
+}
+
+// AppendString appends s as a text string. s must be valid UTF-8.
+func AppendString(dst []byte, s string) []byte {
+	dst = appendHead(dst, majorText, uint64(len(s)))
+	return append(dst, s...)
+}
+
+// AppendArrayHeader starts an array of n items, which follow it.
+func AppendArrayHeader(dst []byte, n int) []byte {
+	return appendHead(dst, majorArray, uint64(n))
+}
+
+// AppendMapHeader starts a map of n pairs, which follow it as key, value,
+// key, value, ... in ascending order of the encoded keys.
+func AppendMapHeader(dst []byte, n int) []byte {
+	return appendHead(dst, majorMap, uint64(n))
+}
+
+// AppendTag appends a tag, which applies to the item that follows it.
+func AppendTag(dst []byte, tag uint64) []byte {
+	return appendHead(dst, majorTag, tag)
+}
+
+// AppendBool appends true or false.
+func AppendBool(dst []byte, v bool) []byte {
+	if v {
+		return append(dst, majorSimple<<5|simpleTrue)
+	}
+	return append(dst, majorSimple<<5|simpleFalse)
+}
+
+// AppendNull appends null.
+func AppendNull(dst []byte) []byte {
+	return append(dst, majorSimple<<5|simpleNull)
+}
+
+// Decoder reads items from a message in order. A method that fails
+// leaves the decoder where it was, so the caller may try another type.
+type Decoder struct {
+	data []byte
+	off  int
+}
+
+// NewDecoder returns a decoder reading data from the start.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Remaining returns the number of bytes left to read.
+func (d *Decoder) Remaining() int {
+	return len(d.data) - d.off
+}
+
+// head reads the initial byte and argument of the next item without
+// consuming them, and returns the offset of its content.
+func (d *Decoder) head() (major byte, v uint64, next int, err error) {
+	if d.off >= len(d.data) {
+		return 0, 0, 0, ErrTruncated
+	}
+	b := d.data[d.off]
+	major, info := b>>5, b&0x1f
+	if major == majorSimple {
+		if info < simpleFalse || info > simpleNull {
+			return 0, 0, 0, ErrUnsupported
+		}
+		return major, uint64(info), d.off + 1, nil
+	}
+	if info < 24 {
+		return major, uint64(info), d.off + 1, nil
+	}
+	if info == 31 {
+		return 0, 0, 0, ErrNotCanonical // indefinite length
+	}
+	if info > 27 {
+		return 0, 0, 0, ErrUnsupported // reserved
+	}
+	n := 1 << (info - 24)
+	if len(d.data)-d.off-1 < n {
+		return 0, 0, 0, ErrTruncated
+	}
+	for _, c := range d.data[d.off+1 : d.off+1+n] {
+		v = v<<8 | uint64(c)
+	}
+	// The shortest form is the only deterministic one
+	if v < 24 || (n > 1 && v>>(4*n) == 0) {
+		return 0, 0, 0, ErrNotCanonical
+	}
+	return major, v, d.off + 1 + n, nil
+}
+
+// Peek returns the kind of the next item without consuming it.
+func (d *Decoder) Peek() (Kind, error) {
+	major, v, _, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case major != majorSimple:
+		return Kind(major), nil
+	case v == simpleNull:
+		return KindNull, nil
+	}
+	return KindBool, nil
+}
+
+// expect reads the head of an item of the given major type.
+func (d *Decoder) expect(major byte) (uint64, int, error) {
+	m, v, next, err := d.head()
+	if err != nil {
+		return 0, 0, err
+	}
+	if m != major {
+		return 0, 0, ErrType
+	}
+	return v, next, nil
+}
+
+// Uint reads an unsigned integer.
+func (d *Decoder) Uint() (uint64, error) {
+	v, next, err := d.expect(majorUint)
+	if err != nil {
+		return 0, err
+	}
+	d.off = next
+	return v, nil
+}
+
+// Int reads an unsigned or negative integer into an int64.
+func (d *Decoder) Int() (int64, error) {
+	major, v, next, err := d.head()
+	if err != nil {
+		return 0, err
+	}
+	if major != majorUint && major != majorNeg {
+		return 0, ErrType
+	}
+	if v > 1<<63-1 {
+		return 0, ErrOverflow
+	}
+	d.off = next
+	if major == majorNeg {
+		return -1 - int64(v), nil
+	}
+	return int64(v), nil
+}
+
+// U256 reads an unsigned integer or a bignum of at most 256 bits.
+func (d *Decoder) U256() (stygos.U256, error) {
+	major, v, next, err := d.head()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if major == majorUint {
+		d.off = next
+		return stygos.NewU256(v), nil
+	}
+	if major != majorTag || v != TagBignum {
+		return stygos.U256{}, ErrType
+	}
+	start := d.off
+	d.off = next
+	b, err := d.Bytes()
+	if err == nil {
+		err = checkBignum(b)
+	}
+	if err == nil && len(b) > 32 {
+		err = ErrOverflow
+	}
+	if err != nil {
+		d.off = start
+		return stygos.U256{}, err
+	}
+	var w stygos.Word
+	copy(w[32-len(b):], b)
+	return stygos.U256FromWord(w), nil
+}
+
+// checkBignum checks the content of a bignum is in its deterministic
+// form: no leading zeros, and too large for a plain integer item.
+func checkBignum(b []byte) error {
+	if len(b) <= 8 || b[0] == 0 {
+		return ErrNotCanonical
+	}
+	return nil
+}
+
+// content reads the head of a string of the given major type and returns
+// its content.
+func (d *Decoder) content(major byte) ([]byte, int, error) {
+	v, next, err := d.expect(major)
+	if err != nil {
+		return nil, 0, err
+	}
+	if v > uint64(len(d.data)-next) {
+		return nil, 0, ErrTruncated
+	}
+	end := next + int(v)
+	return d.data[next:end], end, nil
+}
+
+// Bytes reads a byte string. The result aliases the message.
+func (d *Decoder) Bytes() ([]byte, error) {
+	b, end, err := d.content(majorBytes)
+	if err != nil {
+		return nil, err
+	}
+	d.off = end
+	return b, nil
+}
+
+// String reads a text string.
+func (d *Decoder) String() (string, error) {
+	b, end, err := d.content(majorText)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", ErrInvalidUTF8
+	}
+	d.off = end
+	return string(b), nil
+}
+
+// ArrayHeader reads the start of an array and returns its number of
+// items.
+func (d *Decoder) ArrayHeader() (int, error) {
+	return d.count(majorArray, 1)
+}
+
+// MapHeader reads the start of a map and returns its number of pairs.
+func (d *Decoder) MapHeader() (int, error) {
+	return d.count(majorMap, 2)
+}
+
+// count reads the head of a container whose entries take at least size
+// bytes each.
+func (d *Decoder) count(major byte, size uint64) (int, error) {
+	v, next, err := d.expect(major)
+	if err != nil {
+		return 0, err
+	}
+	if v > uint64(len(d.data)-next)/size {
+		return 0, ErrTruncated
+	}
+	d.off = next
+	return int(v), nil
+}
+
+// Tag reads a tag. The tagged item follows.
+func (d *Decoder) Tag() (uint64, error) {
+	v, next, err := d.expect(majorTag)
+	if err != nil {
+		return 0, err
+	}
+	d.off = next
+	return v, nil
+}
+
+// Bool reads true or false.
+func (d *Decoder) Bool() (bool, error) {
+	v, next, err := d.expect(majorSimple)
+	if err != nil {
+		return false, err
+	}
+	if v == simpleNull {
+		return false, ErrType
+	}
+	d.off = next
+	return v == simpleTrue, nil
+}
+
+// Null reads null.
+func (d *Decoder) Null() error {
+	v, next, err := d.expect(majorSimple)
+	if err != nil {
+		return err
+	}
+	if v != simpleNull {
+		return ErrType
+	}
+	d.off = next
+	return nil
+}
+
+// Skip reads the next item, with any items it contains, and checks it is
+// deterministically encoded.
+func (d *Decoder) Skip() error {
+	start := d.off
+	if err := d.skip(0); err != nil {
+		d.off = start
+		return err
+	}
+	return nil
+}
+
+func (d *Decoder) skip(depth int) error {
+	major, v, next, err := d.head()
+	if err != nil {
+		return err
+	}
+	switch major {
+	case majorBytes:
+		_, err = d.Bytes()
+		return err
+	case majorText:
+		_, err = d.String()
+		return err
+	case majorArray, majorMap, majorTag:
+		if depth == MaxDepth {
+			return ErrDepth
+		}
+	default:
+		d.off = next
+		return nil
+	}
+
+	switch major {
+	case majorArray:
+		n, err := d.ArrayHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err := d.skip(depth + 1); err != nil {
+				return err
+			}
+		}
+	case majorMap:
+		n, err := d.MapHeader()
+		if err != nil {
+			return err
+		}
+		var prev []byte
+		for i := 0; i < n; i++ {
+			start := d.off
+			if err := d.skip(depth + 1); err != nil {
+				return err
+			}
+			key := d.data[start:d.off]
+			if prev != nil && bytes.Compare(prev, key) >= 0 {
+				return ErrNotCanonical // out of order or duplicate
+			}
+			prev = key
+			if err := d.skip(depth + 1); err != nil {
+				return err
+			}
+		}
+	case majorTag:
+		d.off = next
+		if v == TagBignum {
+			b, err := d.Bytes()
+			if err != nil {
+				return err
+			}
+			return checkBignum(b)
+		}
+		return d.skip(depth + 1)
+	}
+	return nil
+}
+
+// Validate reports whether data holds exactly one item in deterministic
+// encoding.
+func Validate(data []byte) error {
+	d := NewDecoder(data)
+	if err := d.Skip(); err != nil {
+		return err
+	}
+	if d.Remaining() != 0 {
+		return ErrTrailingData
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Examples from RFC 8949 appendix A
+func TestAppend(t *testing.T) {
+	wide := stygos.NewU256(1).Lsh(64)
+	tests := []struct {
+		got  []byte
+		want string
+	}{
+		{AppendUint(nil, 0), "00"},
+		{AppendUint(nil, 23), "17"},
+		{AppendUint(nil, 24), "1818"},
+		{AppendUint(nil, 1000), "1903e8"},
+		{AppendUint(nil, 1000000), "1a000f4240"},
+		{AppendUint(nil, math.MaxUint64), "1bffffffffffffffff"},
+		{AppendInt(nil, -1), "20"},
+		{AppendInt(nil, -1000), "3903e7"},
+		{AppendInt(nil, math.MinInt64), "3b7fffffffffffffff"},
+		{AppendU256(nil, stygos.NewU256(10)), "0a"},
+		{AppendU256(nil, wide), "c249010000000000000000"},
+		{AppendBytes(nil, []byte{1, 2, 3, 4}), "4401020304"},
+		{AppendString(nil, "IETF"), "6449455446"},
+		{AppendString(nil, "ü"), "62c3bc"},
+		{AppendBool(AppendBool(AppendNull(nil), false), true), "f6f4f5"},
+		{AppendUint(AppendUint(AppendArrayHeader(nil, 2), 1), 2), "820102"},
+		{AppendTag(nil, 1), "c1"},
+	}
+	for _, tt := range tests {
+		if hex.EncodeToString(tt.got) != tt.want {
+			t.Errorf("Append failed. Expected %s, got %x", tt.want, tt.got)
+		}
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	// {1: h'0102', 2: [-5, "hi", true, null], "a": 2^255}
+	msg := AppendMapHeader(nil, 3)
+	msg = AppendUint(msg, 1)
+	msg = AppendBytes(msg, []byte{1, 2})
+	msg = AppendUint(msg, 2)
+	msg = AppendArrayHeader(msg, 4)
+	msg = AppendInt(msg, -5)
+	msg = AppendString(msg, "hi")
+	msg = AppendBool(msg, true)
+	msg = AppendNull(msg)
+	msg = AppendString(msg, "a")
+	top := stygos.NewU256(1).Lsh(255)
+	msg = AppendU256(msg, top)
+	if err := Validate(msg); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+
+	d := NewDecoder(msg)
+	if n, err := d.MapHeader(); err != nil || n != 3 {
+		t.Fatalf("MapHeader failed. Expected 3, got %d, %v", n, err)
+	}
+	if k, err := d.Uint(); err != nil || k != 1 {
+		t.Errorf("Uint failed. Expected 1, got %d, %v", k, err)
+	}
+	if b, err := d.Bytes(); err != nil || !bytes.Equal(b, []byte{1, 2}) {
+		t.Errorf("Bytes failed. Expected 0102, got %x, %v", b, err)
+	}
+	if _, err := d.String(); err != ErrType {
+		t.Errorf("String failed. Expected ErrType on an integer, got %v", err)
+	}
+	d.Uint()
+	if kind, _ := d.Peek(); kind != KindArray {
+		t.Errorf("Peek failed. Expected KindArray, got %d", kind)
+	}
+	d.ArrayHeader()
+	if v, err := d.Int(); err != nil || v != -5 {
+		t.Errorf("Int failed. Expected -5, got %d, %v", v, err)
+	}
+	if s, err := d.String(); err != nil || s != "hi" {
+		t.Errorf("String failed. Expected hi, got %q, %v", s, err)
+	}
+	if v, err := d.Bool(); err != nil || !v {
+		t.Errorf("Bool failed. Expected true, got %v, %v", v, err)
+	}
+	if kind, _ := d.Peek(); kind != KindNull {
+		t.Errorf("Peek failed. Expected KindNull, got %d", kind)
+	}
+	if err := d.Null(); err != nil {
+		t.Errorf("Null failed: %v", err)
+	}
+	if err := d.Skip(); err != nil {
+		t.Errorf("Skip failed: %v", err)
+	}
+	if v, err := d.U256(); err != nil || v != top {
+		t.Errorf("U256 failed. Expected 2^255, got %v, %v", v, err)
+	}
+	if d.Remaining() != 0 {
+		t.Errorf("Remaining failed. Expected 0, got %d", d.Remaining())
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"long uint", "1817", ErrNotCanonical},
+		{"long uint16", "1900ff", ErrNotCanonical},
+		{"long uint64", "1b00000000ffffffff", ErrNotCanonical},
+		{"indefinite array", "9f01ff", ErrNotCanonical},
+		{"unsorted keys", "a2020001 00", ErrNotCanonical},
+		{"duplicate keys", "a201000100", ErrNotCanonical},
+		{"longer key first", "a2186400 0100", ErrNotCanonical},
+		{"small bignum", "c248" + "0100000000000000", ErrNotCanonical},
+		{"padded bignum", "c24a" + "00010000000000000000", ErrNotCanonical},
+		{"float", "f93c00", ErrUnsupported},
+		{"undefined", "f7", ErrUnsupported},
+		{"reserved", "1c", ErrUnsupported},
+		{"truncated", "1903", ErrTruncated},
+		{"truncated string", "6449455446"[:8], ErrTruncated},
+		{"huge array", "9b7fffffffffffffff", ErrTruncated},
+		{"invalid utf-8", "62c328", ErrInvalidUTF8},
+		{"trailing", "0101", ErrTrailingData},
+		{"sorted keys", "a3 0100 2000 6161 00", nil},
+	}
+	for _, tt := range tests {
+		data := mustHex(stripSpaces(tt.data))
+		if err := Validate(data); err != tt.want {
+			t.Errorf("Validate(%s) failed. Expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	deep := bytes.Repeat([]byte{0x81}, MaxDepth+1)
+	if err := Validate(append(deep, 0)); err != ErrDepth {
+		t.Errorf("Validate failed. Expected ErrDepth, got %v", err)
+	}
+
+	// A failed read leaves the decoder in place
+	d := NewDecoder(mustHex("3b8000000000000000"))
+	if _, err := d.Int(); err != ErrOverflow || d.Remaining() != 9 {
+		t.Errorf("Int failed. Expected ErrOverflow without reading, got %v with %d left", err, d.Remaining())
+	}
+}
+
+func stripSpaces(s string) string {
+	return string(bytes.ReplaceAll([]byte(s), []byte(" "), nil))
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(uint64(0), int64(-1), []byte{}, "", []byte{1})
+	f.Add(uint64(math.MaxUint64), int64(math.MinInt64), []byte{0xff}, "héllo", bytes.Repeat([]byte{0xff}, 32))
+	f.Fuzz(func(t *testing.T, u uint64, i int64, b []byte, s string, wide []byte) {
+		if len(wide) > 32 {
+			wide = wide[:32]
+		}
+		var w stygos.Word
+		copy(w[32-len(wide):], wide)
+		v := stygos.U256FromWord(w)
+
+		msg := AppendArrayHeader(nil, 4)
+		msg = AppendUint(msg, u)
+		msg = AppendInt(msg, i)
+		msg = AppendBytes(msg, b)
+		msg = AppendU256(msg, v)
+		if err := Validate(msg); err != nil {
+			t.Fatalf("Validate failed: %v", err)
+		}
+
+		d := NewDecoder(msg)
+		if n, err := d.ArrayHeader(); err != nil || n != 4 {
+			t.Fatalf("ArrayHeader failed. Expected 4, got %d, %v", n, err)
+		}
+		if got, err := d.Uint(); err != nil || got != u {
+			t.Errorf("Uint failed. Expected %d, got %d, %v", u, got, err)
+		}
+		if got, err := d.Int(); err != nil || got != i {
+			t.Errorf("Int failed. Expected %d, got %d, %v", i, got, err)
+		}
+		if got, err := d.Bytes(); err != nil || !bytes.Equal(got, b) {
+			t.Errorf("Bytes failed. Expected %x, got %x, %v", b, got, err)
+		}
+		if got, err := d.U256(); err != nil || got != v {
+			t.Errorf("U256 failed. Expected %v, got %v, %v", v, got, err)
+		}
+
+		got, err := NewDecoder(AppendString(nil, s)).String()
+		if utf8.ValidString(s) && (err != nil || got != s) {
+			t.Errorf("String failed. Expected %q, got %q, %v", s, got, err)
+		}
+		if !utf8.ValidString(s) && err != ErrInvalidUTF8 {
+			t.Errorf("String failed. Expected ErrInvalidUTF8, got %v", err)
+		}
+	})
+}
+
+// FuzzValidate checks that whatever Validate accepts reads back through
+// the Decoder and re-encodes to the same bytes, the uniqueness
+// deterministic encoding promises.
+func FuzzValidate(f *testing.F) {
+	for _, seed := range []string{"00", "a201020304", "c249010000000000000000", "8301820203f6", "6449455446", "3903e7"} {
+		f.Add(mustHex(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if Validate(data) != nil {
+			return
+		}
+		d := NewDecoder(data)
+		out, err := transcode(d, nil)
+		if err != nil {
+			t.Fatalf("transcode(%x) failed: %v", data, err)
+		}
+		if !bytes.Equal(out, data) {
+			t.Fatalf("transcode failed. Expected %x, got %x", data, out)
+		}
+	})
+}
+
+// transcode reads one item from d and appends it again with the Append
+// functions.
+func transcode(d *Decoder, dst []byte) ([]byte, error) {
+	kind, err := d.Peek()
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case KindUint:
+		v, err := d.Uint()
+		return AppendUint(dst, v), err
+	case KindNegative:
+		major, v, next, err := d.head()
+		if err != nil || major != majorNeg {
+			return nil, ErrType
+		}
+		d.off = next
+		return appendHead(dst, majorNeg, v), nil
+	case KindBytes:
+		b, err := d.Bytes()
+		return AppendBytes(dst, b), err
+	case KindString:
+		s, err := d.String()
+		return AppendString(dst, s), err
+	case KindArray, KindMap:
+		var n int
+		if kind == KindArray {
+			n, err = d.ArrayHeader()
+			dst = AppendArrayHeader(dst, n)
+		} else {
+			n, err = d.MapHeader()
+			dst = AppendMapHeader(dst, n)
+			n *= 2
+		}
+		for i := 0; err == nil && i < n; i++ {
+			dst, err = transcode(d, dst)
+		}
+		return dst, err
+	case KindTag:
+		tag, err := d.Tag()
+		if err != nil {
+			return nil, err
+		}
+		return transcode(d, AppendTag(dst, tag))
+	case KindBool:
+		v, err := d.Bool()
+		return AppendBool(dst, v), err
+	}
+	return AppendNull(dst), d.Null()
+}
@@ -0,0 +1,318 @@
+// Package json writes JSON documents on chain, such as tokenURI metadata
+// and attestations that are signed or hashed and checked off chain.
+//
+// encoding/json relies on reflection, which TinyGo builds cannot afford.
+// An Encoder instead appends values to a caller supplied buffer as they
+// are written, with no maps, floats or intermediate strings:
+//
+//	e := json.NewEncoder(make([]byte, 0, 256))
+//	e.BeginObject()
+//	e.Key("owner").Address(owner)
+//	e.Key("amount").U256(amount)
+//	e.Key("tags").BeginArray().String("vip").String("early").EndArray()
+//	e.EndObject()
+//	doc, err := e.Finish()
+//
+// The output is deterministic: it depends only on the sequence of calls.
+// Members are written in the order given, without whitespace, and strings
+// are escaped as RFC 8785 (JSON canonicalization) does, so writing the
+// keys of each object in sorted order yields canonical JSON.
+package json
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/strconvx"
+)
+
+// MaxDepth is the deepest nesting of objects and arrays an Encoder
+// accepts.
+const MaxDepth = 64
+
+// JSON errors
+var (
+	ErrSyntax     = errors.New("json: value or key out of place")
+	ErrDepth      = errors.New("json: nesting too deep")
+	ErrIncomplete = errors.New("json: document is incomplete")
+)
+
+// Encoder appends a single JSON value, usually an object, to a buffer.
+//
+// It checks that calls form a valid document: each object member is a Key
+// followed by one value, and containers are closed by their own End call.
+// Errors are sticky: after the first misplaced call the encoder stops
+// appending and Err, Finish report ErrSyntax or ErrDepth.
+type Encoder struct {
+	buf   []byte
+	depth int
+	// One bit per open container, the innermost at bit depth-1: whether it
+	// is an object, and whether it has no member yet.
+	object, empty uint64
+	key           bool // a key awaits its value
+	done          bool // the top-level value has started
+	err           error
+}
+
+// NewEncoder returns an encoder appending to dst. Giving dst the capacity
+// of the expected document avoids growing it.
+func NewEncoder(dst []byte) *Encoder {
+	return &Encoder{buf: dst}
+}
+
+// value prepares the buffer for a value and reports whether one may be
+// written.
+func (e *Encoder) value() bool {
+	if e.err != nil {
+		return false
+	}
+	if e.depth == 0 {
+		if e.done {
+			e.err = ErrSyntax
+			return false
+		}
+		e.done = true
+		return true
+	}
+	bit := uint64(1) << (e.depth - 1)
+	if e.object&bit != 0 {
+		if !e.key {
+			e.err = ErrSyntax
+			return false
+		}
+		e.key = false
+		return true
+	}
+	if e.empty&bit == 0 {
+		e.buf = append(e.buf, ',')
+	}
+	e.empty &^= bit
+	return true
+}
+
+// begin opens a container.
+func (e *Encoder) begin(object bool, open byte) *Encoder {
+	if !e.value() {
+		return e
+	}
+	if e.depth == MaxDepth {
+		e.err = ErrDepth
+		return e
+	}
+	bit := uint64(1) << e.depth
+	if object {
+		e.object |= bit
+	} else {
+		e.object &^= bit
+	}
+	e.empty |= bit
+	e.depth++
+	e.buf = append(e.buf, open)
+	return e
+}
+
+// end closes the innermost container if it is of the given kind.
+func (e *Encoder) end(object bool, close byte) *Encoder {
+	if e.err != nil {
+		return e
+	}
+	if e.depth == 0 || e.key || (e.object&(1<<(e.depth-1)) != 0) != object {
+		e.err = ErrSyntax
+		return e
+	}
+	e.depth--
+	e.buf = append(e.buf, close)
+	return e
+}
+
+// BeginObject starts an object. Its members are written as a Key followed
+// by a value.
+func (e *Encoder) BeginObject() *Encoder {
+	return e.begin(true, '{')
+}
+
+// EndObject ends the innermost object.
+func (e *Encoder) EndObject() *Encoder {
+	return e.end(true, '}')
+}
+
+// BeginArray starts an array.
+func (e *Encoder) BeginArray() *Encoder {
+	return e.begin(false, '[')
+}
+
+// EndArray ends the innermost array.
+func (e *Encoder) EndArray() *Encoder {
+	return e.end(false, ']')
+}
+
+// Key writes the name of the next member of the innermost object.
+func (e *Encoder) Key(k string) *Encoder {
+	if e.err != nil {
+		return e
+	}
+	if e.depth == 0 || e.object&(1<<(e.depth-1)) == 0 || e.key {
+		e.err = ErrSyntax
+		return e
+	}
+	bit := uint64(1) << (e.depth - 1)
+	if e.empty&bit == 0 {
+		e.buf = append(e.buf, ',')
+	}
+	e.empty &^= bit
+	e.key = true
+	e.buf = AppendString(e.buf, k)
+	e.buf = append(e.buf, ':')
+	return e
+}
+
+// String writes s as a string.
+func (e *Encoder) String(s string) *Encoder {
+	if e.value() {
+		e.buf = AppendString(e.buf, s)
+	}
+	return e
+}
+
+// Uint writes v as a number.
+func (e *Encoder) Uint(v uint64) *Encoder {
+	if e.value() {
+		e.buf = strconvx.AppendUint(e.buf, v)
+	}
+	return e
+}
+
+// Int writes v as a number.
+func (e *Encoder) Int(v int64) *Encoder {
+	if e.value() {
+		e.buf = strconvx.AppendInt(e.buf, v)
+	}
+	return e
+}
+
+// U256 writes v as a number. Readers that parse numbers as doubles, such
+// as JavaScript's JSON.parse, round values above 2^53; use DecimalString
+// for amounts they must read exactly.
+func (e *Encoder) U256(v stygos.U256) *Encoder {
+	if e.value() {
+		e.buf = v.Append(e.buf)
+	}
+	return e
+}
+
+// DecimalString writes v as a string of decimal digits.
+func (e *Encoder) DecimalString(v stygos.U256) *Encoder {
+	if e.value() {
+		e.buf = append(e.buf, '"')
+		e.buf = v.Append(e.buf)
+		e.buf = append(e.buf, '"')
+	}
+	return e
+}
+
+// Bool writes true or false.
+func (e *Encoder) Bool(v bool) *Encoder {
+	if e.value() {
+		if v {
+			e.buf = append(e.buf, "true"...)
+		} else {
+			e.buf = append(e.buf, "false"...)
+		}
+	}
+	return e
+}
+
+// Null writes null.
+func (e *Encoder) Null() *Encoder {
+	if e.value() {
+		e.buf = append(e.buf, "null"...)
+	}
+	return e
+}
+
+// Hex writes p as a string of lowercase hex digits, 0x-prefixed.
+func (e *Encoder) Hex(p []byte) *Encoder {
+	if e.value() {
+		e.buf = append(e.buf, '"', '0', 'x')
+		e.buf = strconvx.AppendHex(e.buf, p)
+		e.buf = append(e.buf, '"')
+	}
+	return e
+}
+
+// Address writes a as a hex string. The digits are lowercase rather than
+// EIP-55 checksummed, which would cost a hash per address.
+func (e *Encoder) Address(a stygos.Address) *Encoder {
+	return e.Hex(a[:])
+}
+
+// Word writes w as a hex string of 64 digits.
+func (e *Encoder) Word(w stygos.Word) *Encoder {
+	return e.Hex(w[:])
+}
+
+// Err returns the first error encountered while writing.
+func (e *Encoder) Err() error {
+	return e.err
+}
+
+// Bytes returns the buffer, including the bytes of dst given to
+// NewEncoder. It may hold an incomplete document; see Finish.
+func (e *Encoder) Bytes() []byte {
+	return e.buf
+}
+
+// Finish returns the buffer once the document is complete: a value was
+// written and every container closed.
+func (e *Encoder) Finish() ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	if !e.done || e.depth != 0 {
+		return nil, ErrIncomplete
+	}
+	return e.buf, nil
+}
+
+// AppendString appends s to dst as a quoted JSON string, escaped as
+// RFC 8785 does: quotes, backslashes and control characters only, the
+// latter in their short form where JSON has one. Invalid UTF-8 is written
+// as U+FFFD.
+func AppendString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				dst = append(dst, "�"...)
+			} else {
+				dst = append(dst, s[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+		switch {
+		case c == '"' || c == '\\':
+			dst = append(dst, '\\', c)
+		case c == '\b':
+			dst = append(dst, '\\', 'b')
+		case c == '\f':
+			dst = append(dst, '\\', 'f')
+		case c == '\n':
+			dst = append(dst, '\\', 'n')
+		case c == '\r':
+			dst = append(dst, '\\', 'r')
+		case c == '\t':
+			dst = append(dst, '\\', 't')
+		case c < 0x20:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+		default:
+			dst = append(dst, c)
+		}
+		i++
+	}
+	return append(dst, '"')
+}
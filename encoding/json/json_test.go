@@ -0,0 +1,110 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"math"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestEncoder(t *testing.T) {
+	e := NewEncoder(nil)
+	e.BeginObject()
+	e.Key("name").String("Stygian #1")
+	e.Key("owner").Address(stygos.Address{0: 0xab, 19: 0x01})
+	e.Key("supply").U256(stygos.NewU256(0).Not())
+	e.Key("price").DecimalString(stygos.NewU256(1500))
+	e.Key("min").Int(math.MinInt64)
+	e.Key("max").Uint(math.MaxUint64)
+	e.Key("tags").BeginArray().String("a").Bool(true).Null().BeginObject().EndObject().BeginArray().EndArray().EndArray()
+	e.Key("data").Hex([]byte{0xde, 0xad})
+	e.EndObject()
+	doc, err := e.Finish()
+	if err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+
+	want := `{"name":"Stygian #1","owner":"0xab00000000000000000000000000000000000001",` +
+		`"supply":115792089237316195423570985008687907853269984665640564039457584007913129639935,` +
+		`"price":"1500","min":-9223372036854775808,"max":18446744073709551615,` +
+		`"tags":["a",true,null,{},[]],"data":"0xdead"}`
+	if string(doc) != want {
+		t.Errorf("Encoder failed. Expected %s, got %s", want, doc)
+	}
+	if !stdjson.Valid(doc) {
+		t.Errorf("Encoder failed. Expected valid JSON, got %s", doc)
+	}
+}
+
+func TestEncoderAppends(t *testing.T) {
+	dst := make([]byte, 0, 64)
+	dst = append(dst, "prefix:"...)
+	doc, err := NewEncoder(dst).BeginArray().Uint(1).Uint(2).EndArray().Finish()
+	if err != nil || string(doc) != "prefix:[1,2]" {
+		t.Errorf("Encoder failed. Expected prefix:[1,2], got %s, %v", doc, err)
+	}
+	if &doc[0] != &dst[:1][0] {
+		t.Error("Encoder failed. Expected to append in place within capacity")
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(e *Encoder)
+		want  error
+	}{
+		{"empty", func(e *Encoder) {}, ErrIncomplete},
+		{"unclosed", func(e *Encoder) { e.BeginObject() }, ErrIncomplete},
+		{"two values", func(e *Encoder) { e.Uint(1).Uint(2) }, ErrSyntax},
+		{"value without key", func(e *Encoder) { e.BeginObject().String("x").EndObject() }, ErrSyntax},
+		{"key without value", func(e *Encoder) { e.BeginObject().Key("x").EndObject() }, ErrSyntax},
+		{"two keys", func(e *Encoder) { e.BeginObject().Key("x").Key("y") }, ErrSyntax},
+		{"key in array", func(e *Encoder) { e.BeginArray().Key("x") }, ErrSyntax},
+		{"key at top", func(e *Encoder) { e.Key("x") }, ErrSyntax},
+		{"mismatched end", func(e *Encoder) { e.BeginArray().EndObject() }, ErrSyntax},
+		{"end at top", func(e *Encoder) { e.EndArray() }, ErrSyntax},
+		{"too deep", func(e *Encoder) {
+			for i := 0; i <= MaxDepth; i++ {
+				e.BeginArray()
+			}
+		}, ErrDepth},
+	}
+	for _, tt := range tests {
+		e := NewEncoder(nil)
+		tt.write(e)
+		if _, err := e.Finish(); err != tt.want {
+			t.Errorf("%s failed. Expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	// Errors are sticky
+	e := NewEncoder(nil)
+	e.BeginArray().Key("x").Uint(1).EndArray()
+	if string(e.Bytes()) != "[" || e.Err() != ErrSyntax {
+		t.Errorf("Encoder failed. Expected to stop at the error, got %s, %v", e.Bytes(), e.Err())
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", `"plain"`},
+		{`"q" \ /`, `"\"q\" \\ /"`},
+		{"\b\f\n\r\t\x00\x1f", `"\b\f\n\r\t\u0000\u001f"`},
+		{"<&> é €", `"<&> é €"`},
+		{"bad\xff\xfe", `"bad��"`},
+	}
+	for _, tt := range tests {
+		got := AppendString(nil, tt.in)
+		if string(got) != tt.want {
+			t.Errorf("AppendString(%q) failed. Expected %s, got %s", tt.in, tt.want, got)
+		}
+		var decoded string
+		if err := stdjson.Unmarshal(got, &decoded); err != nil {
+			t.Errorf("AppendString(%q) failed. Expected valid JSON, got %v", tt.in, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package erc165 implements ERC-165 interface detection: a contract
+// registers the interface ids it implements and answers
+// supportsInterface(bytes4) for them.
+package erc165
+
+import "github.com/rafaelescrich/stygos"
+
+// InterfaceID is the ERC-165 interface id, the selector of
+// supportsInterface(bytes4).
+var InterfaceID = stygos.Selector{0x01, 0xff, 0xc9, 0xa7}
+
+// invalidID must never be reported as supported.
+var invalidID = stygos.Selector{0xff, 0xff, 0xff, 0xff}
+
+// InterfaceIDOf returns the id of the interface made of the given function
+// signatures: the xor of their selectors.
+func InterfaceIDOf(signatures ...string) stygos.Selector {
+	var id stygos.Selector
+	for _, sig := range signatures {
+		sel := stygos.SelectorOf(sig)
+		for i := range id {
+			id[i] ^= sel[i]
+		}
+	}
+	return id
+}
+
+// Registry is the set of interfaces a contract supports. Interface support
+// is fixed by the code, so the registry lives in memory rather than
+// storage and is filled when the contract starts.
+type Registry struct {
+	ids []stygos.Selector
+}
+
+// NewRegistry returns a registry supporting ERC-165 itself.
+func NewRegistry() *Registry {
+	return &Registry{ids: []stygos.Selector{InterfaceID}}
+}
+
+// Register marks the interface id as supported.
+func (r *Registry) Register(id stygos.Selector) {
+	if id == invalidID || r.Supports(id) {
+		return
+	}
+	r.ids = append(r.ids, id)
+}
+
+// Supports reports whether the interface id is supported.
+func (r *Registry) Supports(id stygos.Selector) bool {
+	for _, known := range r.ids {
+		if known == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Mount registers the supportsInterface(bytes4) handler on router.
+func (r *Registry) Mount(router *stygos.Router) {
+	router.HandleSelector(InterfaceID, r.handleSupportsInterface)
+}
+
+func (r *Registry) handleSupportsInterface(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	var id stygos.Selector
+	copy(id[:], args[:4])
+	var result stygos.Word
+	if r.Supports(id) {
+		result[31] = 1
+	}
+	return result[:], nil
+}
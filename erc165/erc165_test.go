@@ -0,0 +1,60 @@
+package erc165
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestInterfaceIDOf(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	if id := InterfaceIDOf("supportsInterface(bytes4)"); id != InterfaceID {
+		t.Errorf("InterfaceIDOf failed. Expected %x, got %x", InterfaceID, id)
+	}
+
+	erc721 := InterfaceIDOf(
+		"balanceOf(address)",
+		"ownerOf(uint256)",
+		"safeTransferFrom(address,address,uint256,bytes)",
+		"safeTransferFrom(address,address,uint256)",
+		"transferFrom(address,address,uint256)",
+		"approve(address,uint256)",
+		"setApprovalForAll(address,bool)",
+		"getApproved(uint256)",
+		"isApprovedForAll(address,address)",
+	)
+	if want := (stygos.Selector{0x80, 0xac, 0x58, 0xcd}); erc721 != want {
+		t.Errorf("InterfaceIDOf failed for ERC-721. Expected %x, got %x", want, erc721)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	reg := NewRegistry()
+	other := stygos.Selector{0x80, 0xac, 0x58, 0xcd}
+	reg.Register(other)
+	reg.Register(invalidID)
+
+	router := stygos.NewRouter()
+	reg.Mount(router)
+
+	tests := []struct {
+		id   stygos.Selector
+		want byte
+	}{
+		{InterfaceID, 1},
+		{other, 1},
+		{invalidID, 0},
+		{stygos.Selector{1, 2, 3, 4}, 0},
+	}
+	for _, tt := range tests {
+		call := append(InterfaceID[:], make([]byte, 32)...)
+		copy(call[4:], tt.id[:])
+		out, err := router.Dispatch(call)
+		if err != nil || len(out) != 32 || out[31] != tt.want {
+			t.Errorf("supportsInterface(%x) failed. Expected %d, got %x, %v", tt.id, tt.want, out, err)
+		}
+	}
+}
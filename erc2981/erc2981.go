@@ -0,0 +1,136 @@
+// Package erc2981 implements the ERC-2981 NFT royalty standard: a default
+// royalty for the whole collection, optional per-token overrides, and the
+// royaltyInfo(uint256,uint256) query used by marketplaces.
+package erc2981
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// InterfaceID is the ERC-2981 interface id, the selector of
+// royaltyInfo(uint256,uint256).
+var InterfaceID = stygos.Selector{0x2a, 0x55, 0x20, 0x5a}
+
+// FeeDenominator is the denominator of royalty fees: fees are in basis
+// points.
+const FeeDenominator = 10000
+
+// Royalty errors
+var (
+	ErrInvalidFee      = errors.New("erc2981: fee exceeds sale price")
+	ErrInvalidReceiver = errors.New("erc2981: zero receiver")
+)
+
+// Royalties holds the royalty configuration of a collection.
+//
+// A royalty is packed in one word like OpenZeppelin's RoyaltyInfo: the fee
+// in basis points in the high 12 bytes and the receiver in the low 20.
+//
+// Storage layout relative to the base slot:
+//
+//	base                     default royalty
+//	MapKey(base+1, tokenID)  per-token royalty
+type Royalties struct {
+	base   stygos.Word
+	tokens stygos.Word
+}
+
+// NewRoyalties returns the royalties rooted at base. base and the following
+// slot must not be used by anything else.
+func NewRoyalties(base stygos.Word) *Royalties {
+	return &Royalties{base: base, tokens: storage.Offset(base, 1)}
+}
+
+// SetDefault sets the royalty for tokens without their own.
+func (r *Royalties) SetDefault(receiver stygos.Address, feeBps uint16) error {
+	w, err := pack(receiver, feeBps)
+	if err != nil {
+		return err
+	}
+	stygos.StorageStore(r.base, w)
+	return nil
+}
+
+// DeleteDefault removes the default royalty.
+func (r *Royalties) DeleteDefault() {
+	stygos.StorageStore(r.base, stygos.Word{})
+}
+
+// SetToken sets the royalty of a single token, overriding the default.
+func (r *Royalties) SetToken(tokenID stygos.U256, receiver stygos.Address, feeBps uint16) error {
+	w, err := pack(receiver, feeBps)
+	if err != nil {
+		return err
+	}
+	stygos.StorageStore(r.tokenSlot(tokenID), w)
+	return nil
+}
+
+// ResetToken removes a token's royalty so the default applies again.
+func (r *Royalties) ResetToken(tokenID stygos.U256) {
+	stygos.StorageStore(r.tokenSlot(tokenID), stygos.Word{})
+}
+
+// Info returns the receiver and amount of the royalty owed on a sale of
+// tokenID for salePrice.
+func (r *Royalties) Info(tokenID, salePrice stygos.U256) (stygos.Address, stygos.U256) {
+	w := stygos.StorageLoad(r.tokenSlot(tokenID))
+	if w == (stygos.Word{}) {
+		w = stygos.StorageLoad(r.base)
+	}
+	receiver := stygos.AddressFromWord(w)
+	fee := stygos.NewU256(uint64(w[10])<<8 | uint64(w[11]))
+
+	// salePrice * fee / FeeDenominator without overflowing: fee is at most
+	// FeeDenominator, so neither product can exceed 2^256.
+	denom := stygos.NewU256(FeeDenominator)
+	amount := salePrice.Div(denom).Mul(fee).Add(salePrice.Mod(denom).Mul(fee).Div(denom))
+	return receiver, amount
+}
+
+// Mount registers royaltyInfo(uint256,uint256) on router and declares the
+// interface in registry.
+func (r *Royalties) Mount(router *stygos.Router, registry *erc165.Registry) {
+	router.HandleSelector(InterfaceID, r.handleRoyaltyInfo)
+	registry.Register(InterfaceID)
+}
+
+func (r *Royalties) handleRoyaltyInfo(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 64 {
+		return nil, stygos.ErrInvalidInput
+	}
+	var tokenID, salePrice stygos.Word
+	copy(tokenID[:], args[:32])
+	copy(salePrice[:], args[32:64])
+
+	receiver, amount := r.Info(stygos.U256FromWord(tokenID), stygos.U256FromWord(salePrice))
+	out := make([]byte, 64)
+	recv := stygos.PadAddress(receiver)
+	amt := amount.Word()
+	copy(out[:32], recv[:])
+	copy(out[32:], amt[:])
+	return out, nil
+}
+
+func (r *Royalties) tokenSlot(tokenID stygos.U256) stygos.Word {
+	id := tokenID.Word()
+	return storage.MapKey(r.tokens, id[:])
+}
+
+// pack encodes a royalty as fee (uint96) ++ receiver.
+func pack(receiver stygos.Address, feeBps uint16) (stygos.Word, error) {
+	if feeBps > FeeDenominator {
+		return stygos.Word{}, ErrInvalidFee
+	}
+	if receiver == (stygos.Address{}) {
+		return stygos.Word{}, ErrInvalidReceiver
+	}
+	w := stygos.PadAddress(receiver)
+	w[10] = byte(feeBps >> 8)
+	w[11] = byte(feeBps)
+	return w, nil
+}
@@ -0,0 +1,110 @@
+package erc2981
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/erc165"
+)
+
+func TestRoyaltyInfo(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	if sel := stygos.SelectorOf("royaltyInfo(uint256,uint256)"); sel != InterfaceID {
+		t.Errorf("InterfaceID failed. Expected %x, got %x", sel, InterfaceID)
+	}
+
+	r := NewRoyalties(stygos.Word{0x29, 0x81})
+	artist := stygos.Address{0xa1}
+	collab := stygos.Address{0xc0}
+
+	// No royalty configured
+	if recv, amount := r.Info(stygos.NewU256(1), stygos.NewU256(1000)); recv != (stygos.Address{}) || !amount.IsZero() {
+		t.Errorf("Info failed. Expected no royalty, got %x %v", recv, amount)
+	}
+
+	if err := r.SetDefault(artist, 250); err != nil {
+		t.Fatalf("SetDefault failed: %v", err)
+	}
+	if err := r.SetToken(stygos.NewU256(7), collab, 1000); err != nil {
+		t.Fatalf("SetToken failed: %v", err)
+	}
+
+	tests := []struct {
+		token    uint64
+		price    uint64
+		receiver stygos.Address
+		amount   uint64
+	}{
+		{1, 10000, artist, 250},
+		{1, 1e18, artist, 25e15},
+		{1, 39, artist, 0}, // rounds down
+		{7, 10000, collab, 1000},
+	}
+	for _, tt := range tests {
+		recv, amount := r.Info(stygos.NewU256(tt.token), stygos.NewU256(tt.price))
+		if recv != tt.receiver || amount.Uint64() != tt.amount {
+			t.Errorf("Info(%d, %d) failed. Expected %x %d, got %x %v", tt.token, tt.price, tt.receiver, tt.amount, recv, amount.Big())
+		}
+	}
+
+	// The full range of sale prices does not overflow
+	max := stygos.U256{}.Not()
+	_, amount := r.Info(stygos.NewU256(7), max)
+	if want := max.Div(stygos.NewU256(10)); amount != want {
+		t.Errorf("Info failed for maximum price. Expected %v, got %v", want.Big(), amount.Big())
+	}
+
+	r.ResetToken(stygos.NewU256(7))
+	if recv, _ := r.Info(stygos.NewU256(7), stygos.NewU256(100)); recv != artist {
+		t.Errorf("ResetToken failed. Expected default receiver, got %x", recv)
+	}
+	r.DeleteDefault()
+	if recv, _ := r.Info(stygos.NewU256(7), stygos.NewU256(100)); recv != (stygos.Address{}) {
+		t.Errorf("DeleteDefault failed. Got receiver %x", recv)
+	}
+}
+
+func TestRoyaltyValidation(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	r := NewRoyalties(stygos.Word{0x29, 0x82})
+	if err := r.SetDefault(stygos.Address{1}, 10001); err != ErrInvalidFee {
+		t.Errorf("SetDefault failed. Expected ErrInvalidFee, got %v", err)
+	}
+	if err := r.SetToken(stygos.NewU256(1), stygos.Address{}, 100); err != ErrInvalidReceiver {
+		t.Errorf("SetToken failed. Expected ErrInvalidReceiver, got %v", err)
+	}
+}
+
+func TestMount(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	r := NewRoyalties(stygos.Word{0x29, 0x83})
+	r.SetDefault(stygos.Address{0xa1}, 500)
+
+	router := stygos.NewRouter()
+	registry := erc165.NewRegistry()
+	registry.Mount(router)
+	r.Mount(router, registry)
+
+	if !registry.Supports(InterfaceID) {
+		t.Errorf("Mount failed to register the ERC-2981 interface")
+	}
+
+	call := append([]byte{}, InterfaceID[:]...)
+	token := stygos.WordFromUint64(3)
+	price := stygos.WordFromUint64(2000)
+	call = append(call, token[:]...)
+	call = append(call, price[:]...)
+	out, err := router.Dispatch(call)
+	if err != nil || len(out) != 64 {
+		t.Fatalf("royaltyInfo failed: %x, %v", out, err)
+	}
+	var recv, amount stygos.Word
+	copy(recv[:], out[:32])
+	copy(amount[:], out[32:])
+	if stygos.AddressFromWord(recv) != (stygos.Address{0xa1}) || stygos.Uint64FromWord(amount) != 100 {
+		t.Errorf("royaltyInfo failed. Got %x", out)
+	}
+}
@@ -0,0 +1,113 @@
+// Package erc5192 implements ERC-5192 minimal soulbound NFTs: ERC-721
+// tokens flagged as locked cannot be transferred, which suits credentials
+// and memberships bound to one account.
+//
+// The collection calls CheckTransfer from its transfer functions, emits
+// Locked through Lock when it mints a soulbound token, and serves
+// locked(uint256) with Mount.
+package erc5192
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// InterfaceID is the ERC-5192 interface id, the selector of
+// locked(uint256).
+var InterfaceID = stygos.Selector{0xb4, 0x5a, 0x3c, 0x0e}
+
+// Lock errors
+var (
+	ErrLocked       = errors.New("erc5192: token is locked")
+	ErrUnknownToken = errors.New("erc5192: token does not exist")
+)
+
+// Locks holds the lock flags of a collection's tokens.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, tokenID)  1 while the token is locked
+type Locks struct {
+	base stygos.Word
+}
+
+// NewLocks returns the locks rooted at base.
+func NewLocks(base stygos.Word) *Locks {
+	return &Locks{base: base}
+}
+
+// Locked reports whether tokenID is locked.
+func (l *Locks) Locked(tokenID stygos.U256) bool {
+	return stygos.StorageLoad(l.slot(tokenID)) != (stygos.Word{})
+}
+
+// Lock locks tokenID and emits Locked(uint256). Locking a locked token
+// does nothing.
+func (l *Locks) Lock(tokenID stygos.U256) {
+	if l.Locked(tokenID) {
+		return
+	}
+	stygos.StorageStore(l.slot(tokenID), stygos.WordFromUint64(1))
+	emitLock("Locked(uint256)", tokenID)
+}
+
+// Unlock unlocks tokenID and emits Unlocked(uint256). Unlocking an
+// unlocked token does nothing.
+func (l *Locks) Unlock(tokenID stygos.U256) {
+	if !l.Locked(tokenID) {
+		return
+	}
+	stygos.StorageStore(l.slot(tokenID), stygos.Word{})
+	emitLock("Unlocked(uint256)", tokenID)
+}
+
+// CheckTransfer is the transfer hook: it fails with ErrLocked when tokenID
+// is locked and moves between two accounts. Mints, from the zero address,
+// and burns, to it, are allowed so the issuer can still create and revoke
+// credentials; a burn clears the lock.
+func (l *Locks) CheckTransfer(from, to stygos.Address, tokenID stygos.U256) error {
+	if !l.Locked(tokenID) || from == (stygos.Address{}) {
+		return nil
+	}
+	if to != (stygos.Address{}) {
+		return ErrLocked
+	}
+	stygos.StorageStore(l.slot(tokenID), stygos.Word{})
+	return nil
+}
+
+// Mount registers locked(uint256) on router and declares the interface in
+// registry. ERC-5192 requires the query to revert for tokens that do not
+// exist, which exists reports.
+func (l *Locks) Mount(router *stygos.Router, registry *erc165.Registry, exists func(tokenID stygos.U256) bool) {
+	router.HandleSelector(InterfaceID, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) < 32 {
+			return nil, stygos.ErrInvalidInput
+		}
+		var w stygos.Word
+		copy(w[:], args[:32])
+		tokenID := stygos.U256FromWord(w)
+		if !exists(tokenID) {
+			return nil, ErrUnknownToken
+		}
+		var result stygos.Word
+		if l.Locked(tokenID) {
+			result[31] = 1
+		}
+		return result[:], nil
+	})
+	registry.Register(InterfaceID)
+}
+
+func (l *Locks) slot(tokenID stygos.U256) stygos.Word {
+	id := tokenID.Word()
+	return storage.MapKey(l.base, id[:])
+}
+
+func emitLock(signature string, tokenID stygos.U256) {
+	id := tokenID.Word()
+	stygos.EmitEvent(id[:], stygos.Keccak256([]byte(signature)))
+}
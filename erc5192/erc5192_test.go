@@ -0,0 +1,106 @@
+package erc5192
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/eventlog"
+)
+
+var (
+	alice = stygos.Address{0xa1}
+	bob   = stygos.Address{0xb0}
+)
+
+func TestLocks(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	if sel := stygos.SelectorOf("locked(uint256)"); sel != InterfaceID {
+		t.Errorf("InterfaceID failed. Expected %x, got %x", sel, InterfaceID)
+	}
+
+	l := NewLocks(stygos.Word{0x51, 0x92})
+	id := stygos.NewU256(7)
+	if err := l.CheckTransfer(alice, bob, id); err != nil {
+		t.Errorf("CheckTransfer failed. Expected an unlocked token to move, got %v", err)
+	}
+
+	l.Lock(id)
+	l.Lock(id)
+	if !l.Locked(id) || l.Locked(stygos.NewU256(8)) {
+		t.Errorf("Lock failed. Expected only token 7 locked")
+	}
+	tests := []struct {
+		name     string
+		from, to stygos.Address
+		want     error
+	}{
+		{"transfer", alice, bob, ErrLocked},
+		{"mint", stygos.Address{}, alice, nil},
+		{"burn", alice, stygos.Address{}, nil},
+	}
+	for _, tt := range tests {
+		if err := l.CheckTransfer(tt.from, tt.to, id); err != tt.want {
+			t.Errorf("CheckTransfer %s failed. Expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+	if l.Locked(id) {
+		t.Errorf("CheckTransfer failed. Expected a burn to clear the lock")
+	}
+
+	l.Lock(id)
+	l.Unlock(id)
+	l.Unlock(id)
+	if l.Locked(id) {
+		t.Errorf("Unlock failed. Expected token 7 unlocked")
+	}
+
+	// One event per change of state, with the token id as data
+	logs, err := eventlog.MockLogs(mock)
+	if err != nil {
+		t.Fatalf("MockLogs failed: %v", err)
+	}
+	idWord := id.Word()
+	want := []string{"Locked(uint256)", "Locked(uint256)", "Unlocked(uint256)"}
+	if len(logs) != len(want) {
+		t.Fatalf("Lock failed. Expected %d events, got %d", len(want), len(logs))
+	}
+	for i, sig := range want {
+		if logs[i].Topics[0] != stygos.Keccak256([]byte(sig)) || string(logs[i].Data) != string(idWord[:]) {
+			t.Errorf("Event %d failed. Expected %s(7), got %x", i, sig, logs[i].Data)
+		}
+	}
+}
+
+func TestMount(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	l := NewLocks(stygos.Word{0x51, 0x93})
+	l.Lock(stygos.NewU256(1))
+
+	router := stygos.NewRouter()
+	registry := erc165.NewRegistry()
+	registry.Mount(router)
+	l.Mount(router, registry, func(tokenID stygos.U256) bool { return tokenID.Lt(stygos.NewU256(3)) })
+
+	if !registry.Supports(InterfaceID) {
+		t.Errorf("Mount failed to register the ERC-5192 interface")
+	}
+	for _, tt := range []struct {
+		token  uint64
+		locked byte
+		err    error
+	}{
+		{1, 1, nil},
+		{2, 0, nil},
+		{3, 0, ErrUnknownToken},
+	} {
+		id := stygos.WordFromUint64(tt.token)
+		out, err := router.Dispatch(append(append([]byte{}, InterfaceID[:]...), id[:]...))
+		if err != tt.err || (err == nil && out[31] != tt.locked) {
+			t.Errorf("locked(%d) failed. Expected %d, %v, got %x, %v", tt.token, tt.locked, tt.err, out, err)
+		}
+	}
+}
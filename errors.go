@@ -0,0 +1,81 @@
+package stygos
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// Calls to other contracts fail with an *ErrCallFailed holding the callee's
+// return data, which wraps the reason: ErrRevert or ErrOutOfInk. Test for
+// the reason with errors.Is and read the revert data with errors.As:
+//
+//	_, err := stygos.Call(token, stygos.Word{}, data)
+//	var failed *stygos.ErrCallFailed
+//	if errors.As(err, &failed) && errors.Is(err, stygos.ErrRevert) {
+//		// failed.Ret is the revert data, such as an ABI encoded error
+//	}
+var (
+	// ErrRevert is the reason of a call whose callee reverted, including a
+	// value transfer the caller cannot cover. A handler's RevertError also
+	// matches it.
+	ErrRevert = errors.New("call reverted")
+
+	// ErrOutOfInk is the reason of a call whose callee ran out of ink or
+	// gas before it returned.
+	ErrOutOfInk = errors.New("out of ink")
+)
+
+// ErrCallReverted is the earlier name of ErrRevert.
+//
+// Deprecated: use errors.Is(err, ErrRevert).
+var ErrCallReverted = ErrRevert
+
+// Call statuses returned by the call hostios. Any other non-zero status is
+// treated as a revert.
+const (
+	statusSuccess  = 0
+	statusRevert   = 1
+	statusOutOfInk = 2
+)
+
+// ErrCallFailed is the error of a failed call. Ret is the callee's return
+// data, the revert data when Err is ErrRevert.
+type ErrCallFailed struct {
+	Ret []byte
+	Err error // ErrRevert or ErrOutOfInk
+}
+
+func (e *ErrCallFailed) Error() string {
+	if len(e.Ret) == 0 {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + " with 0x" + hex.EncodeToString(e.Ret)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *ErrCallFailed) Unwrap() error {
+	return e.Err
+}
+
+// callError returns the error of a call that ended with a non-zero status.
+func callError(status uint8, ret []byte) error {
+	if status == statusOutOfInk {
+		return &ErrCallFailed{Ret: ret, Err: ErrOutOfInk}
+	}
+	return &ErrCallFailed{Ret: ret, Err: ErrRevert}
+}
+
+// revertData returns the data a handler error reverts with: a RevertError's
+// data, or the return data of a failed call the handler passed on, as
+// Solidity bubbles up the errors of the calls it makes.
+func revertData(err error) []byte {
+	var revert *RevertError
+	if errors.As(err, &revert) {
+		return revert.Data
+	}
+	var failed *ErrCallFailed
+	if errors.As(err, &failed) && errors.Is(failed.Err, ErrRevert) {
+		return failed.Ret
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package stygos
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCallErrors(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	reverter, exhausted := Address{0xe1}, Address{0xe2}
+	mock.Deploy(reverter, func(input []byte) ([]byte, error) {
+		return []byte("nope"), errors.New("nope")
+	})
+	mock.Deploy(exhausted, func(input []byte) ([]byte, error) {
+		return nil, ErrOutOfInk
+	})
+
+	_, err := Call(reverter, Word{}, nil)
+	var failed *ErrCallFailed
+	if !errors.As(err, &failed) || string(failed.Ret) != "nope" {
+		t.Fatalf("Call(reverter) = %v, want an *ErrCallFailed with the revert data", err)
+	}
+	if !errors.Is(err, ErrRevert) || errors.Is(err, ErrOutOfInk) {
+		t.Errorf("Call(reverter) = %v, want ErrRevert", err)
+	}
+	if got, want := err.Error(), "call reverted with 0x6e6f7065"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	_, err = StaticCall(exhausted, nil)
+	if !errors.Is(err, ErrOutOfInk) || errors.Is(err, ErrRevert) {
+		t.Errorf("StaticCall(exhausted) = %v, want ErrOutOfInk", err)
+	}
+
+	if !errors.Is((&Ctx{}).Revert(nil), ErrRevert) {
+		t.Error("RevertError does not match ErrRevert")
+	}
+}
+
+func TestEntrypointBubblesRevert(t *testing.T) {
+	mock := NewMockRuntime()
+	reverter := Address{0xe1}
+	mock.Deploy(reverter, func(input []byte) ([]byte, error) {
+		return []byte("inner"), ErrRevert
+	})
+	router := NewRouter()
+	router.Handle("forward()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		return Call(reverter, Word{}, nil)
+	})
+	router.Handle("fail()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		return nil, ErrInvalidInput
+	})
+
+	// A failed call's revert data is passed on, other errors have none
+	tests := []struct {
+		signature string
+		want      string
+	}{
+		{"forward()", "inner"},
+		{"fail()", ""},
+	}
+	for _, tt := range tests {
+		sel := SelectorOf(tt.signature)
+		mock.Args = sel[:]
+		mock.Result = nil
+		UseRuntime(mock)
+		if status := router.Entrypoint(); status != 1 {
+			t.Errorf("%s: Entrypoint() = %d, want 1", tt.signature, status)
+		}
+		if string(mock.Result) != tt.want {
+			t.Errorf("%s: revert data = %q, want %q", tt.signature, mock.Result, tt.want)
+		}
+	}
+}
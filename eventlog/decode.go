@@ -0,0 +1,281 @@
+package eventlog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Log is an emitted log, as read from the mock runtime or a chain client.
+// It is the type MockRuntime.SubscribeLogs delivers, so a Decoder reads
+// subscribed logs as they are emitted. Logs parsed from MockRuntime.Logs
+// have a zero Address, which that format does not record.
+type Log = stygos.Log
+
+// Arg is a decoded event argument. Value is a stygos.Address for address,
+// bool, *big.Int for uintN and intN, []byte for bytesN and bytes, string,
+// and []any for arrays; indexed parameters of dynamic or array type are
+// the stygos.Word hash held in the topic.
+type Arg struct {
+	Param
+	Value any
+}
+
+// Decoded is a log decoded against its event.
+type Decoded struct {
+	Event Event
+	Args  []Arg
+}
+
+// Arg returns the value of the argument called name, or nil.
+func (d Decoded) Arg(name string) any {
+	for _, a := range d.Args {
+		if a.Name == name {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+// String formats d as "Name(arg: value, ...)", with unnamed arguments by
+// position.
+func (d Decoded) String() string {
+	var sb strings.Builder
+	sb.WriteString(d.Event.Name)
+	sb.WriteByte('(')
+	for i, a := range d.Args {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if a.Name != "" {
+			sb.WriteString(a.Name)
+		} else {
+			sb.WriteString(strconv.Itoa(i))
+		}
+		sb.WriteString(": ")
+		sb.WriteString(formatValue(a.Value))
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// Decode decodes l as an e log.
+func (e Event) Decode(l Log) (Decoded, error) {
+	topics := l.Topics
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.Topic0() {
+			return Decoded{}, ErrTopicMismatch
+		}
+		topics = topics[1:]
+	}
+
+	d := Decoded{Event: e, Args: make([]Arg, len(e.Inputs))}
+	head := 0
+	for i, p := range e.Inputs {
+		d.Args[i].Param = p
+		if p.Indexed {
+			if len(topics) == 0 {
+				return Decoded{}, ErrTopicMismatch
+			}
+			if _, ok := wordSize(p.Type); ok {
+				d.Args[i].Value = wordValue(p.Type, topics[0])
+			} else {
+				d.Args[i].Value = topics[0]
+			}
+			topics = topics[1:]
+			continue
+		}
+		v, size, err := decodeData(p.Type, l.Data, head)
+		if err != nil {
+			return Decoded{}, err
+		}
+		d.Args[i].Value = v
+		head += size
+	}
+	if len(topics) != 0 {
+		return Decoded{}, ErrTopicMismatch
+	}
+	return d, nil
+}
+
+// decodeData decodes a t from the head at data[off:] and returns the
+// number of head bytes it took.
+func decodeData(t string, data []byte, off int) (any, int, error) {
+	if _, ok := wordSize(t); ok {
+		w, err := wordAt(data, off)
+		if err != nil {
+			return nil, 0, err
+		}
+		return wordValue(t, w), 32, nil
+	}
+
+	elem, k, isArray := splitArray(t)
+	if isArray && k > 0 {
+		// T[k] of a word type is k words inline
+		vals := make([]any, k)
+		for j := range vals {
+			w, err := wordAt(data, off+32*j)
+			if err != nil {
+				return nil, 0, err
+			}
+			vals[j] = wordValue(elem, w)
+		}
+		return vals, 32 * k, nil
+	}
+
+	// Dynamic: the head holds the offset of a length-prefixed tail
+	ptr, err := intAt(data, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	n, err := intAt(data, ptr)
+	if err != nil {
+		return nil, 0, err
+	}
+	start := ptr + 32
+	if isArray {
+		if n > (len(data)-start)/32 {
+			return nil, 0, ErrMalformedData
+		}
+		vals := make([]any, n)
+		for j := range vals {
+			w, _ := wordAt(data, start+32*j)
+			vals[j] = wordValue(elem, w)
+		}
+		return vals, 32, nil
+	}
+	if n > len(data)-start {
+		return nil, 0, ErrMalformedData
+	}
+	b := append([]byte(nil), data[start:start+n]...)
+	if t == "string" {
+		return string(b), 32, nil
+	}
+	return b, 32, nil
+}
+
+// wordValue converts a word to the Go value of word type t.
+func wordValue(t string, w stygos.Word) any {
+	n, _ := wordSize(t)
+	switch {
+	case t == "address":
+		return stygos.AddressFromWord(w)
+	case t == "bool":
+		return !w.IsZero()
+	case strings.HasPrefix(t, "uint"):
+		return new(big.Int).SetBytes(w[:])
+	case strings.HasPrefix(t, "int"):
+		v := new(big.Int).SetBytes(w[:])
+		if w[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return v
+	default:
+		return append([]byte(nil), w[:n]...)
+	}
+}
+
+// splitArray splits T[k] or T[] into T and k, with k zero for T[].
+func splitArray(t string) (elem string, k int, ok bool) {
+	i := strings.IndexByte(t, '[')
+	if i < 0 {
+		return t, 0, false
+	}
+	k, _ = strconv.Atoi(t[i+1 : len(t)-1])
+	return t[:i], k, true
+}
+
+func wordAt(data []byte, off int) (stygos.Word, error) {
+	var w stygos.Word
+	if off < 0 || off > len(data)-32 {
+		return w, ErrMalformedData
+	}
+	copy(w[:], data[off:])
+	return w, nil
+}
+
+// intAt reads an offset or length word, which must fit in an int.
+func intAt(data []byte, off int) (int, error) {
+	w, err := wordAt(data, off)
+	if err != nil {
+		return 0, err
+	}
+	u := stygos.U256FromWord(w)
+	if !u.IsUint64() || u.Uint64() > uint64(len(data)) {
+		return 0, ErrMalformedData
+	}
+	return int(u.Uint64()), nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case stygos.Address:
+		return v.Hex()
+	case stygos.Word:
+		return v.Hex()
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = formatValue(e)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+// Decoder decodes logs of a set of events, matched by topic0.
+type Decoder struct {
+	events map[stygos.Word]Event
+}
+
+// NewDecoder returns a decoder for events. Anonymous events cannot be
+// matched and are ignored.
+func NewDecoder(events ...Event) *Decoder {
+	d := &Decoder{events: make(map[stygos.Word]Event, len(events))}
+	for _, e := range events {
+		if !e.Anonymous {
+			d.events[e.Topic0()] = e
+		}
+	}
+	return d
+}
+
+// Decode decodes l against the event its topic0 names.
+func (d *Decoder) Decode(l Log) (Decoded, error) {
+	if len(l.Topics) == 0 {
+		return Decoded{}, ErrUnknownEvent
+	}
+	e, ok := d.events[l.Topics[0]]
+	if !ok {
+		return Decoded{}, ErrUnknownEvent
+	}
+	return e.Decode(l)
+}
+
+// Format decodes and formats l, falling back to its raw topics and data
+// for unknown or malformed logs.
+func (d *Decoder) Format(l Log) string {
+	if dec, err := d.Decode(l); err == nil {
+		return dec.String()
+	}
+	var sb strings.Builder
+	sb.WriteString("Log(topics: [")
+	for i, t := range l.Topics {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(t.Hex())
+	}
+	sb.WriteString("], data: 0x")
+	sb.WriteString(hex.EncodeToString(l.Data))
+	sb.WriteByte(')')
+	return sb.String()
+}
@@ -0,0 +1,199 @@
+// Package eventlog computes event topics and decodes and prints the logs
+// stygos contracts emit, for scripts, tests and backends rather than
+// contracts: it uses fmt and math/big freely and is not meant for wasm.
+//
+// Events are described by their Solidity declaration, parameter names and
+// indexed markers included:
+//
+//	transfer := eventlog.MustParseEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+//	transfer.Topic0() // keccak256("Transfer(address,address,uint256)")
+//
+// Logs come from a chain client, filled into a Log, or from the mock runtime
+// through MockLogs. A Decoder matches them to events by topic0:
+//
+//	d := eventlog.NewDecoder(transfer, approval)
+//	for _, l := range logs {
+//		fmt.Println(d.Format(l)) // Transfer(from: 0x…, to: 0x…, value: 100)
+//	}
+//
+// Supported parameter types are address, bool, uintN, intN, bytesN, bytes,
+// string, and one-dimensional arrays T[] and T[k] of the fixed-size ones.
+// Indexed parameters of dynamic or array type are stored by hash in the
+// topic and decode to that hash.
+package eventlog
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Event log errors
+var (
+	ErrInvalidSignature = errors.New("eventlog: invalid event signature")
+	ErrUnknownEvent     = errors.New("eventlog: unknown event")
+	ErrTopicMismatch    = errors.New("eventlog: topics do not match the event")
+	ErrMalformedData    = errors.New("eventlog: malformed log data")
+)
+
+// Param is an event parameter.
+type Param struct {
+	Name    string
+	Type    string // canonical, e.g. uint256 rather than uint
+	Indexed bool
+}
+
+// Event is an event declaration.
+type Event struct {
+	Name      string
+	Inputs    []Param
+	Anonymous bool // no topic0; set by the caller, it is not in the signature
+}
+
+// ParseEvent parses a Solidity event declaration such as
+// "Transfer(address indexed from, address indexed to, uint256 value)". A
+// leading "event " and parameter names are optional.
+func ParseEvent(decl string) (Event, error) {
+	decl = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(decl), ";"))
+	decl = strings.TrimPrefix(decl, "event ")
+	open := strings.IndexByte(decl, '(')
+	if open <= 0 || !strings.HasSuffix(decl, ")") {
+		return Event{}, ErrInvalidSignature
+	}
+	e := Event{Name: strings.TrimSpace(decl[:open])}
+	if !isIdent(e.Name) {
+		return Event{}, ErrInvalidSignature
+	}
+
+	params := strings.TrimSpace(decl[open+1 : len(decl)-1])
+	if params == "" {
+		return e, nil
+	}
+	indexed := 0
+	for _, p := range strings.Split(params, ",") {
+		fields := strings.Fields(p)
+		if len(fields) == 0 || len(fields) > 3 {
+			return Event{}, ErrInvalidSignature
+		}
+		typ, err := canonicalType(fields[0])
+		if err != nil {
+			return Event{}, err
+		}
+		param := Param{Type: typ}
+		rest := fields[1:]
+		if len(rest) > 0 && rest[0] == "indexed" {
+			param.Indexed = true
+			indexed++
+			rest = rest[1:]
+		}
+		switch {
+		case len(rest) == 1 && isIdent(rest[0]):
+			param.Name = rest[0]
+		case len(rest) != 0:
+			return Event{}, ErrInvalidSignature
+		}
+		e.Inputs = append(e.Inputs, param)
+	}
+	if indexed > 3 {
+		return Event{}, ErrInvalidSignature
+	}
+	return e, nil
+}
+
+// MustParseEvent is ParseEvent for declarations known to be valid; it
+// panics on error.
+func MustParseEvent(decl string) Event {
+	e, err := ParseEvent(decl)
+	if err != nil {
+		panic(err.Error() + ": " + decl)
+	}
+	return e
+}
+
+// Signature returns the canonical signature, e.g.
+// "Transfer(address,address,uint256)".
+func (e Event) Signature() string {
+	types := make([]string, len(e.Inputs))
+	for i, p := range e.Inputs {
+		types[i] = p.Type
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// Topic0 returns keccak256 of the canonical signature, the first topic of
+// a non-anonymous event's logs.
+func (e Event) Topic0() stygos.Word {
+	return stygos.Keccak256([]byte(e.Signature()))
+}
+
+// Topic0 parses decl and returns its topic0.
+func Topic0(decl string) (stygos.Word, error) {
+	e, err := ParseEvent(decl)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	return e.Topic0(), nil
+}
+
+// canonicalType validates a type and expands the uint and int aliases.
+func canonicalType(t string) (string, error) {
+	elem, suffix := t, ""
+	if i := strings.IndexByte(t, '['); i >= 0 {
+		elem, suffix = t[:i], t[i:]
+		if !strings.HasSuffix(suffix, "]") || strings.Count(suffix, "[") != 1 {
+			return "", ErrInvalidSignature
+		}
+		if n := suffix[1 : len(suffix)-1]; n != "" {
+			if k, err := strconv.Atoi(n); err != nil || k <= 0 {
+				return "", ErrInvalidSignature
+			}
+		}
+	}
+	switch elem {
+	case "uint", "int":
+		elem += "256"
+	}
+	if _, ok := wordSize(elem); !ok {
+		if suffix != "" || (elem != "bytes" && elem != "string") {
+			return "", ErrInvalidSignature
+		}
+	}
+	return elem + suffix, nil
+}
+
+// wordSize reports whether t is a type encoded in a single word, and for
+// uintN, intN and bytesN its N.
+func wordSize(t string) (int, bool) {
+	switch t {
+	case "address", "bool":
+		return 0, true
+	}
+	for _, prefix := range []string{"uint", "int", "bytes"} {
+		if !strings.HasPrefix(t, prefix) || len(t) == len(prefix) {
+			continue
+		}
+		n, err := strconv.Atoi(t[len(prefix):])
+		if err != nil {
+			return 0, false
+		}
+		if prefix == "bytes" {
+			return n, n >= 1 && n <= 32
+		}
+		return n, n >= 8 && n <= 256 && n%8 == 0
+	}
+	return 0, false
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if c != '_' && c != '$' && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && (i == 0 || !(c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package eventlog
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		decl, sig string
+	}{
+		{"Transfer(address indexed from, address indexed to, uint256 value)", "Transfer(address,address,uint256)"},
+		{"event Approval(address indexed, address indexed, uint value);", "Approval(address,address,uint256)"},
+		{"Batch(uint[] ids, bytes32[2] roots, string memo, bytes data)", "Batch(uint256[],bytes32[2],string,bytes)"},
+		{"Ping()", "Ping()"},
+	}
+	for _, tt := range tests {
+		e, err := ParseEvent(tt.decl)
+		if err != nil || e.Signature() != tt.sig {
+			t.Errorf("ParseEvent(%q) failed. Expected %s, got %s, %v", tt.decl, tt.sig, e.Signature(), err)
+		}
+	}
+
+	for _, decl := range []string{
+		"Transfer",
+		"(uint256)",
+		"T(uint7)",
+		"T(bytes33)",
+		"T(string[])",
+		"T(uint256[][])",
+		"T(tuple)",
+		"T(uint256 indexed a b)",
+		"T(uint8 indexed a, uint8 indexed b, uint8 indexed c, uint8 indexed d)",
+	} {
+		if _, err := ParseEvent(decl); err != ErrInvalidSignature {
+			t.Errorf("ParseEvent(%q) failed. Expected ErrInvalidSignature, got %v", decl, err)
+		}
+	}
+
+	// The ERC-20 Transfer topic
+	topic, _ := Topic0("Transfer(address indexed from, address indexed to, uint256 value)")
+	if topic.Hex() != "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Topic0 failed. Expected the ERC-20 Transfer topic, got %s", topic.Hex())
+	}
+}
+
+func TestDecodeMockLogs(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	transfer := MustParseEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+	batch := MustParseEvent("Batch(string indexed tag, int8 delta, uint64[] ids, bytes3 code, string memo)")
+
+	from, to := stygos.Address{19: 1}, stygos.Address{19: 2}
+	value := stygos.NewU256(100).Word()
+	stygos.EmitEvent(value[:], transfer.Topic0(), stygos.PadAddress(from), stygos.PadAddress(to))
+
+	// delta, ids offset, code, memo offset | ids | memo
+	words := []stygos.Word{
+		stygos.Word{}.Sub(stygos.WordFromUint64(2)),
+		stygos.WordFromUint64(4 * 32),
+		{0xab, 0xcd, 0xef},
+		stygos.WordFromUint64(7 * 32),
+		stygos.WordFromUint64(2), stygos.WordFromUint64(7), stygos.WordFromUint64(9),
+		stygos.WordFromUint64(2), {'h', 'i'},
+	}
+	var data []byte
+	for _, w := range words {
+		data = append(data, w[:]...)
+	}
+	tag := stygos.Keccak256([]byte("tag"))
+	stygos.EmitEvent(data, batch.Topic0(), tag)
+	stygos.EmitEvent(nil, stygos.Keccak256([]byte("Other()")))
+
+	logs, err := MockLogs(mock)
+	if err != nil || len(logs) != 3 {
+		t.Fatalf("MockLogs failed. Expected 3 logs, got %d, %v", len(logs), err)
+	}
+
+	d := NewDecoder(transfer, batch)
+	got, err := d.Decode(logs[0])
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if got.Arg("from") != from || got.Arg("to") != to || got.Arg("value").(*big.Int).Int64() != 100 {
+		t.Errorf("Decode failed. Expected from, to and 100, got %v", got)
+	}
+	want := "Transfer(from: " + from.Hex() + ", to: " + to.Hex() + ", value: 100)"
+	if s := d.Format(logs[0]); s != want {
+		t.Errorf("Format failed. Expected %s, got %s", want, s)
+	}
+
+	got, err = d.Decode(logs[1])
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if got.Arg("tag") != tag || got.Arg("delta").(*big.Int).Int64() != -2 || got.Arg("memo") != "hi" ||
+		!bytes.Equal(got.Arg("code").([]byte), []byte{0xab, 0xcd, 0xef}) {
+		t.Errorf("Decode failed. Expected the batch arguments, got %v", got)
+	}
+	want = `Batch(tag: ` + tag.Hex() + `, delta: -2, ids: [7, 9], code: 0xabcdef, memo: "hi")`
+	if s := got.String(); s != want {
+		t.Errorf("String failed. Expected %s, got %s", want, s)
+	}
+
+	if _, err := d.Decode(logs[2]); err != ErrUnknownEvent {
+		t.Errorf("Decode failed. Expected ErrUnknownEvent, got %v", err)
+	}
+	if s := d.Format(logs[2]); s != "Log(topics: ["+logs[2].Topics[0].Hex()+"], data: 0x)" {
+		t.Errorf("Format failed. Expected the raw log, got %s", s)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	e := MustParseEvent("Note(address indexed who, bytes data)")
+	who := stygos.PadAddress(stygos.Address{19: 1})
+
+	if _, err := e.Decode(Log{Topics: []stygos.Word{e.Topic0()}}); err != ErrTopicMismatch {
+		t.Errorf("Decode failed. Expected ErrTopicMismatch for a missing topic, got %v", err)
+	}
+	if _, err := e.Decode(Log{Topics: []stygos.Word{who, who}}); err != ErrTopicMismatch {
+		t.Errorf("Decode failed. Expected ErrTopicMismatch for another event, got %v", err)
+	}
+
+	// The length runs past the end of the data
+	offset, length := stygos.WordFromUint64(32), stygos.WordFromUint64(33)
+	data := append(append(offset[:], length[:]...), make([]byte, 32)...)
+	if _, err := e.Decode(Log{Topics: []stygos.Word{e.Topic0(), who}, Data: data}); err != ErrMalformedData {
+		t.Errorf("Decode failed. Expected ErrMalformedData, got %v", err)
+	}
+
+	// Anonymous events have no topic0
+	e.Anonymous = true
+	length = stygos.WordFromUint64(1)
+	data = append(append(offset[:], length[:]...), make([]byte, 32)...)
+	if got, err := e.Decode(Log{Topics: []stygos.Word{who}, Data: data}); err != nil || len(got.Arg("data").([]byte)) != 1 {
+		t.Errorf("Decode failed. Expected an anonymous log, got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,50 @@
+//go:build !tinygo
+
+package eventlog
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// MockLogs returns the logs recorded by a mock runtime, in order.
+func MockLogs(mock *stygos.MockRuntime) ([]Log, error) {
+	logs := make([]Log, 0, len(mock.Logs))
+	for _, entry := range mock.Logs {
+		l, err := ParseMockLog(entry)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+	return logs, nil
+}
+
+// ParseMockLog parses one entry of MockRuntime.Logs.
+func ParseMockLog(entry []byte) (Log, error) {
+	var l Log
+	for _, line := range strings.Split(string(entry), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Topic "):
+			i := strings.Index(line, ": ")
+			if i < 0 {
+				return Log{}, ErrMalformedData
+			}
+			w, err := stygos.WordFromHex(line[i+2:])
+			if err != nil {
+				return Log{}, ErrMalformedData
+			}
+			l.Topics = append(l.Topics, w)
+		case strings.HasPrefix(line, "Data: "):
+			data, err := hex.DecodeString(strings.TrimPrefix(line, "Data: "))
+			if err != nil {
+				return Log{}, ErrMalformedData
+			}
+			l.Data = data
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,242 @@
+// Command account is an ERC-4337 smart account for EntryPoint v0.7.
+//
+// The account is owned by an ECDSA key (an Ethereum address), a BIP-340
+// Schnorr key, or both. validateUserOp accepts a 65-byte ECDSA signature
+// over the EIP-191 hash of the user operation hash, or a 64-byte Schnorr
+// signature over the user operation hash itself, and pays the EntryPoint
+// whatever prefund is missing. Nonces are kept by the EntryPoint.
+//
+// The owner can also grant session keys: ECDSA keys allowed to sign
+// operations that call one target, optionally one function, with a value
+// cap and a time window (see Session), and guardians who can replace a
+// lost owner key (see guardians).
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/aa"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/schnorr"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go ownerKey=owner schnorrKeyKey=schnorrKey sessionKeysKey=sessionKeys sessionsKey=sessions recoveryKey=recovery
+
+// ABI selectors
+var (
+	selInitialize        = stygos.Selector{0xbe, 0x13, 0xf4, 0x7c} // initialize(address,bytes32)
+	selExecute           = stygos.Selector{0xb6, 0x1d, 0x27, 0xf6} // execute(address,uint256,bytes)
+	selGetNonce          = stygos.Selector{0xd0, 0x87, 0xd2, 0x88} // getNonce()
+	selEntryPoint        = stygos.Selector{0xb0, 0xd6, 0x91, 0xfe} // entryPoint()
+	selOwner             = stygos.Selector{0x8d, 0xa5, 0xcb, 0x5b} // owner()
+	selAddDeposit        = stygos.Selector{0x4a, 0x58, 0xdb, 0x19} // addDeposit()
+	selGetDeposit        = stygos.Selector{0xc3, 0x99, 0xec, 0x88} // getDeposit()
+	selWithdrawDepositTo = stygos.Selector{0x4d, 0x44, 0x56, 0x0d} // withdrawDepositTo(address,uint256)
+	selAddSession        = stygos.Selector{0x7b, 0x9b, 0xba, 0x42} // addSession(address,address,bytes4,uint256,uint48,uint48)
+	selRevokeSession     = stygos.Selector{0x1f, 0xa5, 0xd6, 0xa4} // revokeSession(address)
+	selGetSession        = stygos.Selector{0x8c, 0x8e, 0x13, 0xb9} // getSession(address)
+	selGetSessionKeys    = stygos.Selector{0x71, 0x74, 0x93, 0xc7} // getSessionKeys()
+	selProposeRecovery   = stygos.Selector{0x7e, 0xe7, 0x60, 0x82} // proposeRecovery(address)
+	selApproveRecovery   = stygos.Selector{0xfc, 0xae, 0x8d, 0x38} // approveRecovery()
+	selCancelRecovery    = stygos.Selector{0x0b, 0xa2, 0x34, 0xd6} // cancelRecovery()
+	selExecuteRecovery   = stygos.Selector{0x20, 0xc5, 0xa3, 0xe1} // executeRecovery()
+	selAddGuardian       = stygos.Selector{0xa5, 0x26, 0xd8, 0x3b} // addGuardian(address)
+	selRemoveGuardian    = stygos.Selector{0x71, 0x40, 0x41, 0x56} // removeGuardian(address)
+	selSetRecoveryConfig = stygos.Selector{0x79, 0x30, 0xd3, 0x66} // setRecoveryConfig(uint256,uint256)
+	selGetRecovery       = stygos.Selector{0x70, 0x6f, 0x76, 0x58} // getRecovery()
+	selGetGuardians      = stygos.Selector{0x06, 0x65, 0xf0, 0x4b} // getGuardians()
+)
+
+// Account errors
+var (
+	ErrInitialized   = errors.New("account: already initialized")
+	ErrNoOwner       = errors.New("account: no owner key")
+	ErrNotEntryPoint = errors.New("account: caller is not the EntryPoint")
+	ErrUnauthorized  = errors.New("account: caller is not the EntryPoint or owner")
+	ErrNotOwner      = errors.New("account: caller is not the owner or the account")
+)
+
+var (
+	entryPoint = aa.NewEntryPoint(aa.EntryPointV07)
+	router     = newRouter()
+)
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selInitialize, handleInitialize)
+	r.HandleSelector(aa.SelValidateUserOp, handleValidateUserOp)
+	r.HandleSelector(selExecute, handleExecute)
+	r.HandleSelector(selGetNonce, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		nonce, err := entryPoint.GetNonce(stygos.GetContractAddress(), stygos.U256{})
+		return encode(nonce.Word()), err
+	})
+	r.HandleSelector(selEntryPoint, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.PadAddress(entryPoint.Address())), nil
+	})
+	r.HandleSelector(selOwner, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.StorageLoad(ownerKey)), nil
+	})
+	r.HandleSelector(selAddDeposit, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return nil, entryPoint.DepositTo(stygos.GetContractAddress(), stygos.U256FromBig(stygos.GetMsgValue()))
+	})
+	r.HandleSelector(selGetDeposit, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		deposit, err := entryPoint.BalanceOf(stygos.GetContractAddress())
+		return encode(deposit.Word()), err
+	})
+	r.HandleSelector(selWithdrawDepositTo, handleWithdrawDepositTo)
+	r.HandleSelector(selAddSession, handleAddSession)
+	r.HandleSelector(selRevokeSession, handleRevokeSession)
+	r.HandleSelector(selGetSession, handleGetSession)
+	r.HandleSelector(selGetSessionKeys, handleGetSessionKeys)
+	r.HandleSelector(selProposeRecovery, handleProposeRecovery)
+	r.HandleSelector(selApproveRecovery, handleApproveRecovery)
+	r.HandleSelector(selCancelRecovery, handleCancelRecovery)
+	r.HandleSelector(selExecuteRecovery, handleExecuteRecovery)
+	r.HandleSelector(selAddGuardian, handleAddGuardian)
+	r.HandleSelector(selRemoveGuardian, handleRemoveGuardian)
+	r.HandleSelector(selSetRecoveryConfig, handleSetRecoveryConfig)
+	r.HandleSelector(selGetRecovery, handleGetRecovery)
+	r.HandleSelector(selGetGuardians, handleGetGuardians)
+	// Accept plain ETH transfers
+	r.Fallback(func(ctx *stygos.Ctx, data []byte) ([]byte, error) {
+		if len(data) != 0 {
+			return nil, stygos.ErrUnknownSelector
+		}
+		return nil, nil
+	})
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleInitialize sets the owner address and Schnorr key, once. Deploy
+// and initialize the account in one transaction.
+func handleInitialize(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if stygos.StorageLoad(ownerKey) != (stygos.Word{}) || stygos.StorageLoad(schnorrKeyKey) != (stygos.Word{}) {
+		return nil, ErrInitialized
+	}
+	if w[0] == (stygos.Word{}) && w[1] == (stygos.Word{}) {
+		return nil, ErrNoOwner
+	}
+	stygos.StorageStore(ownerKey, stygos.PadAddress(stygos.AddressFromWord(w[0])))
+	stygos.StorageStore(schnorrKeyKey, w[1])
+	return nil, nil
+}
+
+// handleValidateUserOp returns the validation data of the operation (see
+// validate) after paying the missing prefund.
+func handleValidateUserOp(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if !entryPoint.IsCaller() {
+		return nil, ErrNotEntryPoint
+	}
+	op, userOpHash, missing, err := aa.DecodeValidateUserOp(args)
+	if err != nil {
+		return nil, err
+	}
+	validationData := validate(userOpHash, &op)
+	entryPoint.PayPrefund(missing)
+	return encode(validationData.Word()), nil
+}
+
+// handleExecute calls dest with value and data and returns its result.
+func handleExecute(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if !entryPoint.IsCaller() && !isOwner(stygos.GetMsgSender()) {
+		return nil, ErrUnauthorized
+	}
+	dest, value, data, err := decodeExecute(args)
+	if err != nil {
+		return nil, err
+	}
+	return stygos.Call(dest, value, data)
+}
+
+// decodeExecute decodes the arguments of execute(address,uint256,bytes).
+func decodeExecute(args []byte) (dest stygos.Address, value stygos.Word, data []byte, err error) {
+	if len(args) < 3*32 {
+		return dest, value, nil, stygos.ErrInvalidInput
+	}
+	w, _ := stygos.ArgWords(args[:3*32], 3)
+	data, err = stygos.ArgBytes(args, 2)
+	return stygos.AddressFromWord(w[0]), w[1], data, err
+}
+
+// handleWithdrawDepositTo withdraws from the EntryPoint deposit.
+func handleWithdrawDepositTo(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	return nil, entryPoint.WithdrawTo(stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1]))
+}
+
+// validate returns the validation data for op: success for a signature
+// by the owner address or Schnorr key, the session's time range for a
+// session key acting within its scope, and SIG_VALIDATION_FAILED
+// otherwise.
+func validate(hash stygos.Word, op *aa.UserOperation) stygos.U256 {
+	switch len(op.Signature) {
+	case 65:
+		s, err := ecdsa.SignatureFromBytes(op.Signature)
+		if err != nil {
+			break
+		}
+		signer, err := ecdsa.Recover(ecdsa.EthSignedMessageHash(hash[:]), s)
+		if err != nil {
+			break
+		}
+		if isOwner(signer) {
+			return aa.PackValidationData(false, 0, 0)
+		}
+		return validateSession(signer, op.CallData)
+	case 64:
+		key := stygos.StorageLoad(schnorrKeyKey)
+		if key != (stygos.Word{}) && schnorr.Verify(hash[:], op.Signature, key[:]) {
+			return aa.PackValidationData(false, 0, 0)
+		}
+	}
+	return aa.PackValidationData(true, 0, 0)
+}
+
+// onlyOwnerOrSelf allows the owner, and the account itself when an
+// operation calls it through execute.
+func onlyOwnerOrSelf() error {
+	sender := stygos.GetMsgSender()
+	if !isOwner(sender) && sender != stygos.GetContractAddress() {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+func isOwner(addr stygos.Address) bool {
+	owner := stygos.AddressFromWord(stygos.StorageLoad(ownerKey))
+	return owner != (stygos.Address{}) && addr == owner
+}
+
+// encode concatenates words into ABI return data.
+func encode(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/aa"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/schnorr"
+)
+
+var (
+	account    = stygos.Address{0xac}
+	bundler    = stygos.Address{0xb1}
+	recipient  = stygos.Address{0x7e}
+	ownerSK    = big.NewInt(0xa11ce)
+	schnorrSK  = big.NewInt(0x5c4)
+	strangerSK = big.NewInt(0xbad)
+	sessionSK  = big.NewInt(0x5e55)
+	dapp       = stygos.Address{0xda}
+	selPing    = stygos.SelectorOf("ping()")
+)
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selInitialize, "initialize(address,bytes32)"},
+		{selExecute, "execute(address,uint256,bytes)"},
+		{selGetNonce, "getNonce()"},
+		{selEntryPoint, "entryPoint()"},
+		{selOwner, "owner()"},
+		{selAddDeposit, "addDeposit()"},
+		{selGetDeposit, "getDeposit()"},
+		{selWithdrawDepositTo, "withdrawDepositTo(address,uint256)"},
+		{selAddSession, "addSession(address,address,bytes4,uint256,uint48,uint48)"},
+		{selRevokeSession, "revokeSession(address)"},
+		{selGetSession, "getSession(address)"},
+		{selGetSessionKeys, "getSessionKeys()"},
+		{selProposeRecovery, "proposeRecovery(address)"},
+		{selApproveRecovery, "approveRecovery()"},
+		{selCancelRecovery, "cancelRecovery()"},
+		{selExecuteRecovery, "executeRecovery()"},
+		{selAddGuardian, "addGuardian(address)"},
+		{selRemoveGuardian, "removeGuardian(address)"},
+		{selSetRecoveryConfig, "setRecoveryConfig(uint256,uint256)"},
+		{selGetRecovery, "getRecovery()"},
+		{selGetGuardians, "getGuardians()"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func call(sel stygos.Selector, args ...[]byte) []byte {
+	out := append([]byte{}, sel[:]...)
+	for _, a := range args {
+		out = append(out, a...)
+	}
+	return out
+}
+
+func word(w stygos.Word) []byte { return w[:] }
+
+func toWord(b []byte) (w stygos.Word) {
+	copy(w[:], b)
+	return w
+}
+
+// executeCall encodes execute(dest, value, data).
+func executeCall(dest stygos.Address, value uint64, data []byte) []byte {
+	n := stygos.WordFromUint64(uint64(len(data)))
+	tail := append(n[:], data...)
+	tail = append(tail, make([]byte, (32-len(data)%32)%32)...)
+	return call(selExecute,
+		word(stygos.PadAddress(dest)),
+		word(stygos.WordFromUint64(value)),
+		word(stygos.WordFromUint64(3*32)),
+		tail)
+}
+
+func setup(t *testing.T) (*stygos.MockRuntime, *aa.MockEntryPoint) {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = bundler
+	stygos.UseRuntime(mock)
+	ecdsa.InstallMockEcrecover(mock)
+	ep := aa.InstallMockEntryPoint(mock, aa.EntryPointV07)
+	mock.Deploy(account, router.Dispatch)
+	mock.SetBalance(account, big.NewInt(1e18))
+
+	pub, err := schnorr.PublicKey(schnorrSK)
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	var key stygos.Word
+	copy(key[:], pub)
+	owner := stygos.PadAddress(ecdsa.AddressOf(ownerSK))
+	if _, err := stygos.Call(account, stygos.Word{}, call(selInitialize, word(owner), word(key))); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return mock, ep
+}
+
+func newOp(nonce uint64, callData []byte) *aa.UserOperation {
+	return &aa.UserOperation{
+		Sender:             account,
+		Nonce:              stygos.NewU256(nonce),
+		CallData:           callData,
+		AccountGasLimits:   aa.PackGas(100_000, 200_000),
+		PreVerificationGas: stygos.NewU256(21_000),
+		GasFees:            aa.PackGas(1, 10),
+	}
+}
+
+func signECDSA(t *testing.T, mock *stygos.MockRuntime, op *aa.UserOperation, d *big.Int) {
+	t.Helper()
+	hash := op.Hash(aa.EntryPointV07, mock.Chain)
+	sig, err := ecdsa.Sign(d, ecdsa.EthSignedMessageHash(hash[:]))
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	op.Signature = sig.Bytes()
+}
+
+func signSchnorr(t *testing.T, mock *stygos.MockRuntime, op *aa.UserOperation, d *big.Int) {
+	t.Helper()
+	hash := op.Hash(aa.EntryPointV07, mock.Chain)
+	sig, err := schnorr.Sign(d, hash[:], make([]byte, 32))
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	op.Signature = sig
+}
+
+func TestInitialize(t *testing.T) {
+	_, _ = setup(t)
+	ret, err := stygos.Call(account, stygos.Word{}, call(selOwner))
+	if err != nil || stygos.AddressFromWord(toWord(ret)) != ecdsa.AddressOf(ownerSK) {
+		t.Errorf("owner failed. Expected the ECDSA owner, got %x, %v", ret, err)
+	}
+	if _, err := stygos.Call(account, stygos.Word{}, call(selInitialize, word(stygos.Word{1}), word(stygos.Word{}))); err == nil {
+		t.Error("initialize failed. Expected a second call to revert")
+	}
+	ret, err = stygos.Call(account, stygos.Word{}, call(selEntryPoint))
+	if err != nil || stygos.AddressFromWord(toWord(ret)) != aa.EntryPointV07 {
+		t.Errorf("entryPoint failed. Expected %x, got %x, %v", aa.EntryPointV07, ret, err)
+	}
+}
+
+func TestUserOperations(t *testing.T) {
+	mock, ep := setup(t)
+
+	// ECDSA-signed transfer
+	op := newOp(0, executeCall(recipient, 1_000, nil))
+	signECDSA(t, mock, op, ownerSK)
+	if err := ep.HandleOp(op); err != nil {
+		t.Fatalf("HandleOp failed: %v", err)
+	}
+	if got := mock.BalanceOf(recipient); got.Int64() != 1_000 {
+		t.Errorf("HandleOp failed. Expected recipient to hold 1000, got %v", got)
+	}
+	// (100000 + 200000 + 21000) * 10
+	if got := mock.BalanceOf(aa.EntryPointV07); got.Int64() != 3_210_000 {
+		t.Errorf("HandleOp failed. Expected the prefund paid, got %v", got)
+	}
+	if err := ep.HandleOp(op); err != aa.ErrInvalidNonce {
+		t.Errorf("HandleOp failed. Expected ErrInvalidNonce on replay, got %v", err)
+	}
+
+	// Schnorr-signed transfer
+	op = newOp(1, executeCall(recipient, 500, nil))
+	signSchnorr(t, mock, op, schnorrSK)
+	if err := ep.HandleOp(op); err != nil {
+		t.Fatalf("HandleOp failed: %v", err)
+	}
+	if got := mock.BalanceOf(recipient); got.Int64() != 1_500 {
+		t.Errorf("HandleOp failed. Expected recipient to hold 1500, got %v", got)
+	}
+
+	// Wrong signers are rejected without reverting validation
+	op = newOp(2, executeCall(recipient, 500, nil))
+	signECDSA(t, mock, op, strangerSK)
+	if err := ep.HandleOp(op); err != aa.ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected ErrSignatureFailed, got %v", err)
+	}
+	signSchnorr(t, mock, op, strangerSK)
+	if err := ep.HandleOp(op); err != aa.ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected ErrSignatureFailed, got %v", err)
+	}
+	op.Signature = []byte{1, 2, 3}
+	if err := ep.HandleOp(op); err != aa.ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected ErrSignatureFailed, got %v", err)
+	}
+
+	ret, err := stygos.Call(account, stygos.Word{}, call(selGetNonce))
+	if err != nil || stygos.Uint64FromWord(toWord(ret)) != 2 {
+		t.Errorf("getNonce failed. Expected 2, got %x, %v", ret, err)
+	}
+}
+
+func TestAccess(t *testing.T) {
+	mock, _ := setup(t)
+
+	// Only the EntryPoint may validate
+	op := newOp(0, nil)
+	signECDSA(t, mock, op, ownerSK)
+	data := aa.EncodeValidateUserOp(op, op.Hash(aa.EntryPointV07, mock.Chain), stygos.U256{})
+	if _, err := stygos.Call(account, stygos.Word{}, data); err == nil {
+		t.Error("validateUserOp failed. Expected a revert for a non-EntryPoint caller")
+	}
+
+	// Only the EntryPoint or owner may execute
+	if _, err := stygos.Call(account, stygos.Word{}, executeCall(recipient, 1, nil)); err == nil {
+		t.Error("execute failed. Expected a revert for a stranger")
+	}
+	mock.Contract = ecdsa.AddressOf(ownerSK)
+	if _, err := stygos.Call(account, stygos.Word{}, executeCall(recipient, 1, nil)); err != nil {
+		t.Errorf("execute failed. Expected the owner to call, got %v", err)
+	}
+}
+
+func TestDeposit(t *testing.T) {
+	mock, ep := setup(t)
+	owner := ecdsa.AddressOf(ownerSK)
+	mock.Contract = owner
+	mock.SetBalance(owner, big.NewInt(1e9))
+
+	if _, err := stygos.Call(account, stygos.WordFromUint64(1e6), call(selAddDeposit)); err != nil {
+		t.Fatalf("addDeposit failed: %v", err)
+	}
+	ret, err := stygos.Call(account, stygos.Word{}, call(selGetDeposit))
+	if err != nil || stygos.Uint64FromWord(toWord(ret)) != 1e6 {
+		t.Errorf("getDeposit failed. Expected 1000000, got %x, %v", ret, err)
+	}
+
+	// A deposit covering the prefund means nothing more is paid
+	op := newOp(0, nil)
+	op.GasFees = aa.PackGas(1, 1)
+	signECDSA(t, mock, op, ownerSK)
+	if err := ep.HandleOp(op); err != nil {
+		t.Fatalf("HandleOp failed: %v", err)
+	}
+	if got := ep.Deposits[account]; got.Uint64() != 1e6-321_000 {
+		t.Errorf("HandleOp failed. Expected the prefund taken from the deposit, got %d", got.Uint64())
+	}
+
+	withdraw := call(selWithdrawDepositTo, word(stygos.PadAddress(recipient)), word(stygos.WordFromUint64(1_000)))
+	if _, err := stygos.Call(account, stygos.Word{}, withdraw); err != nil {
+		t.Fatalf("withdrawDepositTo failed: %v", err)
+	}
+	if got := mock.BalanceOf(recipient); got.Int64() != 1_000 {
+		t.Errorf("withdrawDepositTo failed. Expected recipient to hold 1000, got %v", got)
+	}
+	mock.Contract = bundler
+	if _, err := stygos.Call(account, stygos.Word{}, withdraw); err == nil {
+		t.Error("withdrawDepositTo failed. Expected a revert for a stranger")
+	}
+}
+
+func addSession(key, target stygos.Address, sel stygos.Selector, limit, after, until uint64) []byte {
+	var selWord stygos.Word
+	copy(selWord[:], sel[:])
+	return call(selAddSession,
+		word(stygos.PadAddress(key)),
+		word(stygos.PadAddress(target)),
+		word(selWord),
+		word(stygos.WordFromUint64(limit)),
+		word(stygos.WordFromUint64(after)),
+		word(stygos.WordFromUint64(until)))
+}
+
+func TestSessionKeys(t *testing.T) {
+	mock, ep := setup(t)
+	var pings int
+	mock.Deploy(dapp, func(input []byte) ([]byte, error) {
+		pings++
+		return nil, nil
+	})
+	session := ecdsa.AddressOf(sessionSK)
+
+	// The owner grants a session through a user operation calling the
+	// account itself
+	op := newOp(0, executeCall(account, 0, addSession(session, dapp, selPing, 100, 1_000, 5_000)))
+	signECDSA(t, mock, op, ownerSK)
+	if err := ep.HandleOp(op); err != nil {
+		t.Fatalf("HandleOp failed: %v", err)
+	}
+	ret, err := stygos.Call(account, stygos.Word{}, call(selGetSession, word(stygos.PadAddress(session))))
+	if err != nil || len(ret) != 5*32 || stygos.AddressFromWord(toWord(ret)) != dapp ||
+		stygos.Uint64FromWord(toWord(ret[4*32:])) != 5_000 {
+		t.Errorf("getSession failed. Got %x, %v", ret, err)
+	}
+	ret, err = stygos.Call(account, stygos.Word{}, call(selGetSessionKeys))
+	if err != nil || len(ret) != 3*32 || stygos.AddressFromWord(toWord(ret[64:])) != session {
+		t.Errorf("getSessionKeys failed. Got %x, %v", ret, err)
+	}
+
+	mock.Time = 2_000
+	tests := []struct {
+		name     string
+		callData []byte
+		err      error
+	}{
+		{"in scope", executeCall(dapp, 100, selPing[:]), nil},
+		{"value over limit", executeCall(dapp, 101, selPing[:]), aa.ErrSignatureFailed},
+		{"wrong selector", executeCall(dapp, 0, []byte{1, 2, 3, 4}), aa.ErrSignatureFailed},
+		{"wrong target", executeCall(recipient, 0, selPing[:]), aa.ErrSignatureFailed},
+		{"not execute", call(selWithdrawDepositTo, word(stygos.PadAddress(recipient)), word(stygos.Word{})), aa.ErrSignatureFailed},
+		{"escalation", executeCall(account, 0, addSession(session, recipient, stygos.Selector{}, 1e18, 0, 0)), aa.ErrSignatureFailed},
+	}
+	nonce := uint64(1)
+	for _, tt := range tests {
+		op := newOp(nonce, tt.callData)
+		signECDSA(t, mock, op, sessionSK)
+		if err := ep.HandleOp(op); err != tt.err {
+			t.Errorf("HandleOp %s failed. Expected %v, got %v", tt.name, tt.err, err)
+		}
+		if tt.err == nil {
+			nonce++
+		}
+	}
+	if pings != 1 || mock.BalanceOf(dapp).Int64() != 100 {
+		t.Errorf("Session failed. Expected one ping with 100 wei, got %d, %v", pings, mock.BalanceOf(dapp))
+	}
+
+	// Outside the time window
+	op = newOp(nonce, executeCall(dapp, 0, selPing[:]))
+	signECDSA(t, mock, op, sessionSK)
+	mock.Time = 5_001
+	if err := ep.HandleOp(op); err != aa.ErrExpiredOrNotDue {
+		t.Errorf("HandleOp failed. Expected ErrExpiredOrNotDue, got %v", err)
+	}
+	mock.Time = 999
+	if err := ep.HandleOp(op); err != aa.ErrExpiredOrNotDue {
+		t.Errorf("HandleOp failed. Expected ErrExpiredOrNotDue, got %v", err)
+	}
+
+	// Revoked keys fail
+	mock.Time = 2_000
+	mock.Contract = ecdsa.AddressOf(ownerSK)
+	if _, err := stygos.Call(account, stygos.Word{}, call(selRevokeSession, word(stygos.PadAddress(session)))); err != nil {
+		t.Fatalf("revokeSession failed: %v", err)
+	}
+	if err := ep.HandleOp(op); err != aa.ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected ErrSignatureFailed after revocation, got %v", err)
+	}
+	if _, err := stygos.Call(account, stygos.Word{}, call(selRevokeSession, word(stygos.PadAddress(session)))); err == nil {
+		t.Error("revokeSession failed. Expected a revert for an unknown key")
+	}
+}
+
+func TestAddSessionChecks(t *testing.T) {
+	mock, _ := setup(t)
+	session := ecdsa.AddressOf(sessionSK)
+
+	if _, err := stygos.Call(account, stygos.Word{}, addSession(session, dapp, selPing, 1, 0, 0)); err == nil {
+		t.Error("addSession failed. Expected a revert for a stranger")
+	}
+	mock.Contract = ecdsa.AddressOf(ownerSK)
+	tests := []struct {
+		name string
+		data []byte
+		ok   bool
+	}{
+		{"no expiry", addSession(session, dapp, selPing, 1, 0, 0), true},
+		{"zero key", addSession(stygos.Address{}, dapp, selPing, 1, 0, 0), false},
+		{"owner key", addSession(mock.Contract, dapp, selPing, 1, 0, 0), false},
+		{"zero target", addSession(session, stygos.Address{}, selPing, 1, 0, 0), false},
+		{"account target", addSession(session, account, selPing, 1, 0, 0), false},
+		{"empty window", addSession(session, dapp, selPing, 1, 10, 10), false},
+		{"timestamp overflow", addSession(session, dapp, selPing, 1, 0, 1<<48), false},
+	}
+	for _, tt := range tests {
+		if _, err := stygos.Call(account, stygos.Word{}, tt.data); (err == nil) != tt.ok {
+			t.Errorf("addSession %s failed. Expected ok %v, got %v", tt.name, tt.ok, err)
+		}
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	mock, ep := setup(t)
+	owner := ecdsa.AddressOf(ownerSK)
+	newOwnerSK := big.NewInt(0x2e3)
+	g1, g2, g3 := stygos.Address{0x91}, stygos.Address{0x92}, stygos.Address{0x93}
+
+	mock.Contract = owner
+	for _, g := range []stygos.Address{g1, g2, g3} {
+		if _, err := stygos.Call(account, stygos.Word{}, call(selAddGuardian, word(stygos.PadAddress(g)))); err != nil {
+			t.Fatalf("addGuardian failed: %v", err)
+		}
+	}
+	if _, err := stygos.Call(account, stygos.Word{}, call(selSetRecoveryConfig, word(stygos.WordFromUint64(2)), word(stygos.WordFromUint64(100)))); err != nil {
+		t.Fatalf("setRecoveryConfig failed: %v", err)
+	}
+	ret, err := stygos.Call(account, stygos.Word{}, call(selGetGuardians))
+	if err != nil || len(ret) != 5*32 {
+		t.Errorf("getGuardians failed. Expected three guardians, got %x, %v", ret, err)
+	}
+
+	propose := call(selProposeRecovery, word(stygos.PadAddress(ecdsa.AddressOf(newOwnerSK))))
+	mock.Contract = g1
+	if _, err := stygos.Call(account, stygos.Word{}, call(selAddGuardian, word(stygos.PadAddress(g1)))); err == nil {
+		t.Error("addGuardian failed. Expected a revert for a guardian")
+	}
+
+	// The owner can cancel during the delay
+	if _, err := stygos.Call(account, stygos.Word{}, propose); err != nil {
+		t.Fatalf("proposeRecovery failed: %v", err)
+	}
+	mock.Contract = g2
+	stygos.Call(account, stygos.Word{}, call(selApproveRecovery))
+	if _, err := stygos.Call(account, stygos.Word{}, call(selCancelRecovery)); err == nil {
+		t.Error("cancelRecovery failed. Expected a revert for a guardian")
+	}
+	mock.Contract = owner
+	if _, err := stygos.Call(account, stygos.Word{}, call(selCancelRecovery)); err != nil {
+		t.Fatalf("cancelRecovery failed: %v", err)
+	}
+
+	// A lost key: the guardians recover the account
+	mock.Time = 1_000
+	mock.Contract = g2
+	stygos.Call(account, stygos.Word{}, propose)
+	mock.Contract = g3
+	stygos.Call(account, stygos.Word{}, call(selApproveRecovery))
+	ret, err = stygos.Call(account, stygos.Word{}, call(selGetRecovery))
+	if err != nil || stygos.Uint64FromWord(toWord(ret[32:])) != 1_100 || stygos.Uint64FromWord(toWord(ret[64:])) != 2 {
+		t.Errorf("getRecovery failed. Expected ready at 1100 with 2 approvals, got %x, %v", ret, err)
+	}
+	if _, err := stygos.Call(account, stygos.Word{}, call(selExecuteRecovery)); err == nil {
+		t.Error("executeRecovery failed. Expected a revert during the delay")
+	}
+	mock.Time = 1_100
+	if _, err := stygos.Call(account, stygos.Word{}, call(selExecuteRecovery)); err != nil {
+		t.Fatalf("executeRecovery failed: %v", err)
+	}
+	ret, _ = stygos.Call(account, stygos.Word{}, call(selOwner))
+	if stygos.AddressFromWord(toWord(ret)) != ecdsa.AddressOf(newOwnerSK) {
+		t.Errorf("executeRecovery failed. Expected the new owner, got %x", ret)
+	}
+
+	// Only the new owner's signatures validate
+	mock.Contract = bundler
+	op := newOp(0, executeCall(recipient, 1, nil))
+	signECDSA(t, mock, op, ownerSK)
+	if err := ep.HandleOp(op); err != aa.ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected the old owner rejected, got %v", err)
+	}
+	signSchnorr(t, mock, op, schnorrSK)
+	if err := ep.HandleOp(op); err != aa.ErrSignatureFailed {
+		t.Errorf("HandleOp failed. Expected the Schnorr key cleared, got %v", err)
+	}
+	signECDSA(t, mock, op, newOwnerSK)
+	if err := ep.HandleOp(op); err != nil {
+		t.Errorf("HandleOp failed: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/recovery"
+)
+
+// Guardians can recover the account when its owner key is lost: a
+// guardian proposes a new owner address, the others approve, and after
+// the delay anyone executes the recovery, which replaces the owner and
+// clears the Schnorr key. The owner configures guardians and can cancel a
+// recovery during the delay.
+var guardians = recovery.NewRecovery(recoveryKey)
+
+// handleProposeRecovery starts a recovery to a new owner address.
+func handleProposeRecovery(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return nil, guardians.Propose(stygos.AddressFromWord(w[0]))
+}
+
+func handleApproveRecovery(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	return nil, guardians.Approve()
+}
+
+func handleCancelRecovery(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	return nil, guardians.Cancel()
+}
+
+// handleExecuteRecovery installs the recovered owner.
+func handleExecuteRecovery(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	owner, err := guardians.Execute()
+	if err != nil {
+		return nil, err
+	}
+	stygos.StorageStore(ownerKey, stygos.PadAddress(owner))
+	stygos.StorageStore(schnorrKeyKey, stygos.Word{})
+	return nil, nil
+}
+
+func handleAddGuardian(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return nil, guardians.AddGuardian(stygos.AddressFromWord(w[0]))
+}
+
+func handleRemoveGuardian(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return nil, guardians.RemoveGuardian(stygos.AddressFromWord(w[0]))
+}
+
+// handleSetRecoveryConfig sets the threshold and delay:
+// setRecoveryConfig(uint256 threshold, uint256 delay).
+func handleSetRecoveryConfig(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if err := guardians.SetThreshold(stygos.Uint64FromWord(w[0])); err != nil {
+		return nil, err
+	}
+	guardians.SetDelay(stygos.Uint64FromWord(w[1]))
+	return nil, nil
+}
+
+// handleGetRecovery returns (newOwner, readyAt, approvals) of the pending
+// recovery, all zero if there is none.
+func handleGetRecovery(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	req, _ := guardians.Pending()
+	return encode(
+		stygos.PadAddress(req.NewOwner),
+		stygos.WordFromUint64(req.ReadyAt),
+		stygos.WordFromUint64(guardians.Approvals()),
+	), nil
+}
+
+// handleGetGuardians returns the guardians as address[].
+func handleGetGuardians(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	list := guardians.Guardians()
+	words := []stygos.Word{stygos.WordFromUint64(32), stygos.WordFromUint64(uint64(len(list)))}
+	for _, g := range list {
+		words = append(words, stygos.PadAddress(g))
+	}
+	return encode(words...), nil
+}
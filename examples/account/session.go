@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/aa"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Session key errors
+var (
+	ErrInvalidSession = errors.New("account: invalid session")
+	ErrUnknownSession = errors.New("account: unknown session key")
+)
+
+// maxTimestamp is the largest time validation data can carry (uint48).
+const maxTimestamp = 1<<48 - 1
+
+// Session is the scope of a session key: an ECDSA key the owner lets sign
+// user operations that call Target through execute, with at most
+// ValueLimit wei per call, between ValidAfter and ValidUntil (0 for no
+// expiry). A non-zero Selector restricts the calldata to that function.
+// The EntryPoint enforces the time range from the validation data.
+//
+//go:generate stygos-gen pack -type Session -o session_pack_gen.go
+type Session struct {
+	Target     stygos.Address
+	Selector   stygos.Selector
+	ValidAfter uint64
+	ValidUntil uint64
+	ValueLimit stygos.U256
+}
+
+var sessionKeys = storage.NewAddressSet(sessionKeysKey)
+
+// sessionSlot returns the base slot of the Session of key.
+func sessionSlot(key stygos.Address) stygos.Word {
+	return storage.MapKey(sessionsKey, key[:])
+}
+
+// handleAddSession grants or replaces the session of a key:
+// addSession(address key, address target, bytes4 selector,
+// uint256 valueLimit, uint48 validAfter, uint48 validUntil).
+func handleAddSession(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(args, 6)
+	if err != nil {
+		return nil, err
+	}
+	key := stygos.AddressFromWord(w[0])
+	s := Session{
+		Target:     stygos.AddressFromWord(w[1]),
+		ValueLimit: stygos.U256FromWord(w[3]),
+		ValidAfter: stygos.Uint64FromWord(w[4]),
+		ValidUntil: stygos.Uint64FromWord(w[5]),
+	}
+	copy(s.Selector[:], w[2][:4])
+
+	switch {
+	case key == (stygos.Address{}) || isOwner(key):
+		return nil, ErrInvalidSession
+	case s.Target == (stygos.Address{}) || s.Target == stygos.GetContractAddress():
+		return nil, ErrInvalidSession
+	case s.ValidAfter > maxTimestamp || s.ValidUntil > maxTimestamp:
+		return nil, ErrInvalidSession
+	case s.ValidUntil != 0 && s.ValidUntil <= s.ValidAfter:
+		return nil, ErrInvalidSession
+	}
+	sessionKeys.Add(key)
+	s.Store(sessionSlot(key))
+	return nil, nil
+}
+
+// handleRevokeSession removes the session of a key.
+func handleRevokeSession(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if err := onlyOwnerOrSelf(); err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	key := stygos.AddressFromWord(w[0])
+	if !sessionKeys.Remove(key) {
+		return nil, ErrUnknownSession
+	}
+	var empty Session
+	empty.Store(sessionSlot(key))
+	return nil, nil
+}
+
+// handleGetSession returns (target, selector, valueLimit, validAfter,
+// validUntil) for a key, all zero if it has no session.
+func handleGetSession(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	var s Session
+	s.Load(sessionSlot(stygos.AddressFromWord(w[0])))
+	var sel stygos.Word
+	copy(sel[:], s.Selector[:])
+	return encode(
+		stygos.PadAddress(s.Target),
+		sel,
+		s.ValueLimit.Word(),
+		stygos.WordFromUint64(s.ValidAfter),
+		stygos.WordFromUint64(s.ValidUntil),
+	), nil
+}
+
+// handleGetSessionKeys returns the keys with a session as address[].
+func handleGetSessionKeys(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	n := sessionKeys.Length()
+	words := []stygos.Word{stygos.WordFromUint64(32), stygos.WordFromUint64(n)}
+	for i := uint64(0); i < n; i++ {
+		words = append(words, stygos.PadAddress(sessionKeys.At(i)))
+	}
+	return encode(words...), nil
+}
+
+// validateSession returns the validation data for a user operation signed
+// by a session key: its time range if callData is an execute call within
+// the session's scope, SIG_VALIDATION_FAILED otherwise.
+func validateSession(key stygos.Address, callData []byte) stygos.U256 {
+	failed := aa.PackValidationData(true, 0, 0)
+	if !sessionKeys.Contains(key) {
+		return failed
+	}
+	var s Session
+	s.Load(sessionSlot(key))
+
+	if !hasSelector(callData, selExecute) {
+		return failed
+	}
+	dest, value, data, err := decodeExecute(callData[4:])
+	if err != nil || dest != s.Target || s.ValueLimit.Lt(stygos.U256FromWord(value)) {
+		return failed
+	}
+	if s.Selector != (stygos.Selector{}) && !hasSelector(data, s.Selector) {
+		return failed
+	}
+	return aa.PackValidationData(false, s.ValidUntil, s.ValidAfter)
+}
+
+// hasSelector reports whether calldata b calls sel.
+func hasSelector(b []byte, sel stygos.Selector) bool {
+	return len(b) >= 4 && bytes.Equal(b[:4], sel[:])
+}
@@ -0,0 +1,269 @@
+// Command airdrop distributes an ERC-20 to many recipients, pushed in
+// batches or claimed against a Merkle root.
+//
+// Batches list their recipients as bytes32[] entries packing the recipient
+// in the high 20 bytes and a uint96 amount in the low 12, one word per
+// recipient instead of the two of parallel address[] and uint256[] arrays,
+// which halves the calldata that dominates the cost of large batches.
+// batchMint mints each entry through the token's mint(address,uint256) and
+// is reserved to the owner, which initialized the contract; batchTransfer
+// sends any token from the caller, who approves the distributor for the
+// total first.
+//
+// For claims the owner funds the contract and commits to the list of
+// (index, account, amount) entries with the root of a merkle.Tree over
+// merkle.Leaf(abi.encode(index, account, amount)). Anyone may then claim an
+// entry for its account with the proof, once; a bitmap records the claimed
+// indices.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/merkle"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go ownerKey=owner tokenKey=token rootKey=merkleRoot claimedKey=claimed
+
+// ABI selectors
+var (
+	selInitialize    = stygos.Selector{0xbe, 0x13, 0xf4, 0x7c} // initialize(address,bytes32)
+	selBatchMint     = stygos.Selector{0x90, 0x90, 0x58, 0x0b} // batchMint(bytes32[])
+	selBatchTransfer = stygos.Selector{0x22, 0x1c, 0x8f, 0x80} // batchTransfer(address,bytes32[])
+	selClaim         = stygos.Selector{0x2e, 0x7b, 0xa6, 0xef} // claim(uint256,address,uint256,bytes32[])
+	selIsClaimed     = stygos.Selector{0x9e, 0x34, 0x07, 0x0f} // isClaimed(uint256)
+	selMerkleRoot    = stygos.Selector{0x2e, 0xb4, 0xa7, 0xab} // merkleRoot()
+	selToken         = stygos.Selector{0xfc, 0x0c, 0x54, 0x6a} // token()
+	selOwner         = stygos.Selector{0x8d, 0xa5, 0xcb, 0x5b} // owner()
+)
+
+// Event topics
+var (
+	distributedTopic = stygos.Keccak256([]byte("Distributed(address,uint256,uint256)"))
+	claimedTopic     = stygos.Keccak256([]byte("Claimed(uint256,address,uint256)"))
+)
+
+// Airdrop errors
+var (
+	ErrInitialized    = errors.New("airdrop: already initialized")
+	ErrNotInitialized = errors.New("airdrop: not initialized")
+	ErrNotOwner       = errors.New("airdrop: caller is not the owner")
+	ErrEmptyBatch     = errors.New("airdrop: empty batch")
+	ErrAlreadyClaimed = errors.New("airdrop: already claimed")
+	ErrInvalidProof   = errors.New("airdrop: invalid proof")
+)
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selInitialize, handleInitialize)
+	r.HandleSelector(selBatchMint, handleBatchMint)
+	r.HandleSelector(selBatchTransfer, handleBatchTransfer)
+	r.HandleSelector(selClaim, handleClaim)
+	r.HandleSelector(selIsClaimed, handleIsClaimed)
+	r.HandleSelector(selMerkleRoot, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return word(stygos.StorageLoad(rootKey)), nil
+	})
+	r.HandleSelector(selToken, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return word(stygos.StorageLoad(tokenKey)), nil
+	})
+	r.HandleSelector(selOwner, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return word(stygos.StorageLoad(ownerKey)), nil
+	})
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleInitialize sets the token and the Merkle root of the claims, once,
+// and makes the caller the owner.
+func handleInitialize(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if stygos.StorageLoad(ownerKey) != (stygos.Word{}) {
+		return nil, ErrInitialized
+	}
+	stygos.StorageStore(ownerKey, stygos.PadAddress(stygos.GetMsgSender()))
+	stygos.StorageStore(tokenKey, w[0])
+	stygos.StorageStore(rootKey, w[1])
+	return nil, nil
+}
+
+// handleBatchMint mints every entry of the batch and returns the total
+// minted. Only the owner may mint.
+func handleBatchMint(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	owner := stygos.AddressFromWord(stygos.StorageLoad(ownerKey))
+	if owner == (stygos.Address{}) {
+		return nil, ErrNotInitialized
+	}
+	if stygos.GetMsgSender() != owner {
+		return nil, ErrNotOwner
+	}
+	entries, err := wordsArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	t := token.NewERC20(stygos.AddressFromWord(stygos.StorageLoad(tokenKey)))
+	return distribute(t, entries, t.Mint)
+}
+
+// handleBatchTransfer sends every entry of the batch in the token from the
+// caller and returns the total sent.
+func handleBatchTransfer(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 64 {
+		return nil, stygos.ErrInvalidInput
+	}
+	entries, err := wordsArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	t := token.NewERC20(stygos.AddressFromWord(wordAt(args, 0)))
+	from := stygos.GetMsgSender()
+	return distribute(t, entries, func(to stygos.Address, amount stygos.U256) error {
+		return token.SafeTransferFrom(t, from, to, amount)
+	})
+}
+
+// distribute sends the packed entries with send, emits Distributed and
+// returns the total.
+func distribute(t token.ERC20, entries []stygos.Word, send func(to stygos.Address, amount stygos.U256) error) ([]byte, error) {
+	if len(entries) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	var total stygos.U256
+	for _, e := range entries {
+		to, amount := unpack(e)
+		if err := send(to, amount); err != nil {
+			return nil, err
+		}
+		// uint96 amounts cannot overflow in fewer than 2^160 entries
+		total = total.Add(amount)
+	}
+	count := stygos.WordFromUint64(uint64(len(entries)))
+	stygos.EmitEvent(encode(count, total.Word()), distributedTopic, stygos.PadAddress(t.Address()))
+	return word(total.Word()), nil
+}
+
+// handleClaim sends the amount of a Merkle entry to its account.
+func handleClaim(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 4*32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	index, amount := wordAt(args, 0), wordAt(args, 2)
+	to := stygos.AddressFromWord(wordAt(args, 1))
+	account := stygos.PadAddress(to)
+	proof, err := wordsArg(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	t := stygos.AddressFromWord(stygos.StorageLoad(tokenKey))
+	if t == (stygos.Address{}) {
+		return nil, ErrNotInitialized
+	}
+
+	slot, bit := claimedBit(stygos.U256FromWord(index))
+	bitmap := stygos.U256FromWord(stygos.StorageLoad(slot))
+	if !bitmap.And(bit).IsZero() {
+		return nil, ErrAlreadyClaimed
+	}
+	leaf := merkle.Leaf(encode(index, account, amount))
+	if !merkle.Verify(proof, stygos.StorageLoad(rootKey), leaf) {
+		return nil, ErrInvalidProof
+	}
+	stygos.StorageStore(slot, bitmap.Or(bit).Word())
+
+	if err := token.SafeTransfer(token.NewERC20(t), to, stygos.U256FromWord(amount)); err != nil {
+		return nil, err
+	}
+	stygos.EmitEvent(encode(index, account, amount), claimedTopic)
+	return nil, nil
+}
+
+// handleIsClaimed reports whether a Merkle entry has been claimed.
+func handleIsClaimed(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	slot, bit := claimedBit(stygos.U256FromWord(w[0]))
+	var claimed stygos.Word
+	if !stygos.U256FromWord(stygos.StorageLoad(slot)).And(bit).IsZero() {
+		claimed[31] = 1
+	}
+	return word(claimed), nil
+}
+
+// claimedBit returns the bitmap word holding index and its bit in it.
+func claimedBit(index stygos.U256) (stygos.Word, stygos.U256) {
+	bucket := index.Rsh(8).Word()
+	return storage.MapKey(claimedKey, bucket[:]), stygos.NewU256(1).Lsh(uint(index.Uint64() & 0xff))
+}
+
+// unpack splits a batch entry into its recipient, the high 20 bytes, and
+// its uint96 amount, the low 12.
+func unpack(e stygos.Word) (stygos.Address, stygos.U256) {
+	var to stygos.Address
+	copy(to[:], e[:20])
+	var amount stygos.Word
+	copy(amount[20:], e[20:])
+	return to, stygos.U256FromWord(amount)
+}
+
+// wordsArg decodes the bytes32[] whose offset is head word i.
+func wordsArg(args []byte, i int) ([]stygos.Word, error) {
+	if len(args) < 32*(i+1) {
+		return nil, stygos.ErrInvalidInput
+	}
+	offset := stygos.U256FromWord(wordAt(args, i))
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(args))-32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	start := offset.Uint64() + 32
+	length := stygos.U256FromWord(wordAt(args[start-32:], 0))
+	if !length.IsUint64() || length.Uint64() > (uint64(len(args))-start)/32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	out := make([]stygos.Word, length.Uint64())
+	for j := range out {
+		out[j] = wordAt(args[start:], j)
+	}
+	return out, nil
+}
+
+// wordAt returns head word i of args.
+func wordAt(args []byte, i int) stygos.Word {
+	var w stygos.Word
+	copy(w[:], args[32*i:])
+	return w
+}
+
+// encode concatenates words into ABI data.
+func encode(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+func word(w stygos.Word) []byte {
+	return w[:]
+}
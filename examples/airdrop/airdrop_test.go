@@ -0,0 +1,212 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/merkle"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	distributor = stygos.Address{0xd1}
+	tokenAddr   = stygos.Address{0x70}
+	owner       = stygos.Address{0x0e}
+	alice       = stygos.Address{0xa1}
+	bob         = stygos.Address{0xb0}
+)
+
+// claim is an entry of the Merkle airdrop.
+type claim struct {
+	account stygos.Address
+	amount  uint64
+}
+
+var claims = []claim{{alice, 100}, {bob, 250}, {owner, 5}}
+
+type env struct {
+	mock *stygos.MockRuntime
+	tok  *token.MockERC20
+	tree *merkle.Tree
+}
+
+// setup deploys the distributor as minter of the token and initializes it
+// with the root of claims.
+func setup(t *testing.T) *env {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	mock.Deploy(distributor, stygos.MockEntrypoint(entrypoint))
+	e := &env{mock: mock, tok: token.InstallMockERC20(mock, tokenAddr)}
+	e.tok.Minter = distributor
+
+	leaves := make([]stygos.Word, len(claims))
+	for i, c := range claims {
+		leaves[i] = merkle.Leaf(encode(stygos.WordFromUint64(uint64(i)), stygos.PadAddress(c.account), stygos.WordFromUint64(c.amount)))
+	}
+	tree, err := merkle.NewTree(leaves)
+	if err != nil {
+		t.Fatalf("NewTree failed: %v", err)
+	}
+	e.tree = tree
+	if _, err := e.send(owner, selInitialize, stygos.PadAddress(tokenAddr), tree.Root()); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return e
+}
+
+// send calls the distributor from an account with head words followed by
+// tail words.
+func (e *env) send(from stygos.Address, sel stygos.Selector, args ...stygos.Word) ([]byte, error) {
+	e.mock.Contract = from
+	return stygos.Call(distributor, stygos.Word{}, append(sel[:], encode(args...)...))
+}
+
+// entries returns n packed batch entries to distinct recipients, recipient
+// i receiving i+1.
+func entries(n int) []stygos.Word {
+	out := make([]stygos.Word, n)
+	for i := range out {
+		to := stygos.Address{0xee, byte(i >> 8), byte(i)}
+		amount := stygos.WordFromUint64(uint64(i + 1))
+		copy(out[i][:20], to[:])
+		copy(out[i][20:], amount[20:])
+	}
+	return out
+}
+
+// array returns the ABI tail of a bytes32[] at the given offset.
+func array(offset int, words []stygos.Word) []stygos.Word {
+	return append([]stygos.Word{stygos.WordFromUint64(uint64(offset)), stygos.WordFromUint64(uint64(len(words)))}, words...)
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selInitialize, "initialize(address,bytes32)"},
+		{selBatchMint, "batchMint(bytes32[])"},
+		{selBatchTransfer, "batchTransfer(address,bytes32[])"},
+		{selClaim, "claim(uint256,address,uint256,bytes32[])"},
+		{selIsClaimed, "isClaimed(uint256)"},
+		{selMerkleRoot, "merkleRoot()"},
+		{selToken, "token()"},
+		{selOwner, "owner()"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestBatchMint(t *testing.T) {
+	e := setup(t)
+	if _, err := e.send(owner, selInitialize, stygos.PadAddress(tokenAddr), stygos.Word{}); err == nil {
+		t.Errorf("initialize failed. Expected a second call to revert")
+	}
+	batch := entries(3)
+	if _, err := e.send(alice, selBatchMint, array(32, batch)...); err == nil {
+		t.Errorf("batchMint failed. Expected a revert for a caller other than the owner")
+	}
+	if _, err := e.send(owner, selBatchMint, array(32, nil)...); err == nil {
+		t.Errorf("batchMint failed. Expected an empty batch to revert")
+	}
+	out, err := e.send(owner, selBatchMint, array(32, batch)...)
+	if err != nil || len(out) != 32 || out[31] != 6 {
+		t.Fatalf("batchMint failed. Expected a total of 6, got %x, %v", out, err)
+	}
+	for i, entry := range batch {
+		var to stygos.Address
+		copy(to[:], entry[:20])
+		if got := e.tok.Balances[to].Uint64(); got != uint64(i+1) {
+			t.Errorf("batchMint failed. Expected %d for recipient %d, got %d", i+1, i, got)
+		}
+	}
+	if len(e.mock.Logs) != 1 {
+		t.Errorf("batchMint failed. Expected one Distributed event, got %d", len(e.mock.Logs))
+	}
+}
+
+func TestBatchTransfer(t *testing.T) {
+	e := setup(t)
+	e.tok.Mint(alice, stygos.NewU256(1000))
+	e.tok.Approve(alice, distributor, stygos.NewU256(5))
+
+	batch := entries(3)
+	args := append([]stygos.Word{stygos.PadAddress(tokenAddr)}, array(64, batch)...)
+	if _, err := e.send(alice, selBatchTransfer, args...); err == nil {
+		t.Errorf("batchTransfer failed. Expected a revert beyond the allowance")
+	}
+
+	// The mock token keeps the moves of the reverted batch
+	before := e.tok.Balances[alice].Uint64()
+	third := e.tok.Balances[stygos.Address{0xee, 0, 2}].Uint64()
+	e.tok.Approve(alice, distributor, stygos.NewU256(6))
+	if _, err := e.send(alice, selBatchTransfer, args...); err != nil {
+		t.Fatalf("batchTransfer failed: %v", err)
+	}
+	if e.tok.Balances[alice].Uint64() != before-6 || e.tok.Balances[stygos.Address{0xee, 0, 2}].Uint64() != third+3 {
+		t.Errorf("batchTransfer failed. Expected 6 sent from alice, got %d left of %d", e.tok.Balances[alice].Uint64(), before)
+	}
+}
+
+func TestClaim(t *testing.T) {
+	e := setup(t)
+	e.tok.Mint(distributor, stygos.NewU256(355))
+
+	claimArgs := func(i int, amount uint64) []stygos.Word {
+		proof, err := e.tree.Proof(i)
+		if err != nil {
+			t.Fatalf("Proof failed: %v", err)
+		}
+		head := []stygos.Word{stygos.WordFromUint64(uint64(i)), stygos.PadAddress(claims[i].account), stygos.WordFromUint64(amount)}
+		return append(head, array(128, proof)...)
+	}
+
+	if _, err := e.send(alice, selClaim, claimArgs(1, 251)...); err == nil {
+		t.Errorf("claim failed. Expected a wrong amount to revert")
+	}
+	// Anyone may claim for the account
+	if _, err := e.send(alice, selClaim, claimArgs(1, 250)...); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	if e.tok.Balances[bob].Uint64() != 250 {
+		t.Errorf("claim failed. Expected 250 for bob, got %d", e.tok.Balances[bob].Uint64())
+	}
+	if _, err := e.send(bob, selClaim, claimArgs(1, 250)...); err == nil {
+		t.Errorf("claim failed. Expected a second claim to revert")
+	}
+	for i, want := range []byte{0, 1, 0} {
+		out, err := e.send(alice, selIsClaimed, stygos.WordFromUint64(uint64(i)))
+		if err != nil || out[31] != want {
+			t.Errorf("isClaimed(%d) failed. Expected %d, got %x, %v", i, want, out, err)
+		}
+	}
+}
+
+// TestBatchGas meters batches with the mock: each extra recipient costs
+// the same, and packing halves the calldata of parallel arrays.
+func TestBatchGas(t *testing.T) {
+	e := setup(t)
+	gas := func(n int) uint64 {
+		e.mock.ResetGas()
+		args := array(32, entries(n))
+		if _, err := e.send(owner, selBatchMint, args...); err != nil {
+			t.Fatalf("batchMint failed: %v", err)
+		}
+		return e.mock.GasUsed + stygos.CalldataGas(append(selBatchMint[:], encode(args...)...))
+	}
+	one, two, hundred := gas(1), gas(2), gas(100)
+	perRecipient := two - one
+	if hundred != one+99*perRecipient {
+		t.Errorf("GasUsed failed. Expected %d for 100 recipients at %d each, got %d", one+99*perRecipient, perRecipient, hundred)
+	}
+	t.Logf("batchMint: %d gas for one recipient, %d per extra recipient", one, perRecipient)
+
+	packed := len(encode(array(32, entries(100))...))
+	parallel := 32 * (2 + 2*(1+100)) // two offsets, two length-prefixed arrays
+	if 2*packed > parallel+64 {
+		t.Errorf("Packing failed. Expected about half of %d calldata bytes, got %d", parallel, packed)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/defi/amm"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go poolKey=pool
+
+// ABI selectors
+var (
+	selInitialize              = stygos.Selector{0x17, 0x94, 0xbb, 0x3c} // initialize(address,address,uint256)
+	selAddLiquidity            = stygos.Selector{0xae, 0xbf, 0x3e, 0x41} // addLiquidity(uint256,uint256,uint256,uint256)
+	selRemoveLiquidity         = stygos.Selector{0x85, 0x76, 0x20, 0xe1} // removeLiquidity(uint256,uint256,uint256)
+	selSwap                    = stygos.Selector{0x9f, 0x1d, 0x0f, 0x59} // swap(address,uint256,uint256)
+	selGetReserves             = stygos.Selector{0x09, 0x02, 0xf1, 0xac} // getReserves()
+	selCurrentCumulativePrices = stygos.Selector{0x1d, 0xf8, 0xc7, 0x17} // currentCumulativePrices()
+	selToken0                  = stygos.Selector{0x0d, 0xfe, 0x16, 0x81} // token0()
+	selToken1                  = stygos.Selector{0xd2, 0x12, 0x20, 0xa7} // token1()
+	selBalanceOf               = stygos.Selector{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	selTotalSupply             = stygos.Selector{0x18, 0x16, 0x0d, 0xdd} // totalSupply()
+)
+
+var (
+	pool   = amm.NewPool(poolKey)
+	router = newRouter()
+)
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selInitialize, handleInitialize)
+	r.HandleSelector(selAddLiquidity, handleAddLiquidity)
+	r.HandleSelector(selRemoveLiquidity, handleRemoveLiquidity)
+	r.HandleSelector(selSwap, handleSwap)
+	r.HandleSelector(selGetReserves, handleGetReserves)
+	r.HandleSelector(selCurrentCumulativePrices, handleCurrentCumulativePrices)
+	r.HandleSelector(selToken0, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.PadAddress(pool.State().Token0)), nil
+	})
+	r.HandleSelector(selToken1, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.PadAddress(pool.State().Token1)), nil
+	})
+	r.HandleSelector(selBalanceOf, handleBalanceOf)
+	r.HandleSelector(selTotalSupply, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(pool.State().TotalShares.Word()), nil
+	})
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleInitialize sets the pair and the fee in basis points, once.
+func handleInitialize(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	fee := stygos.U256FromWord(w[2])
+	if !fee.IsUint64() || fee.Uint64() >= amm.FeeDenominator {
+		return nil, amm.ErrInvalidFee
+	}
+	return nil, pool.Initialize(stygos.AddressFromWord(w[0]), stygos.AddressFromWord(w[1]), uint16(fee.Uint64()))
+}
+
+// handleAddLiquidity returns (amount0, amount1, shares).
+func handleAddLiquidity(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 4)
+	if err != nil {
+		return nil, err
+	}
+	amount0, amount1, shares, err := pool.AddLiquidity(
+		stygos.U256FromWord(w[0]), stygos.U256FromWord(w[1]),
+		stygos.U256FromWord(w[2]), stygos.U256FromWord(w[3]))
+	if err != nil {
+		return nil, err
+	}
+	return encode(amount0.Word(), amount1.Word(), shares.Word()), nil
+}
+
+// handleRemoveLiquidity returns (amount0, amount1).
+func handleRemoveLiquidity(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	amount0, amount1, err := pool.RemoveLiquidity(
+		stygos.U256FromWord(w[0]), stygos.U256FromWord(w[1]), stygos.U256FromWord(w[2]))
+	if err != nil {
+		return nil, err
+	}
+	return encode(amount0.Word(), amount1.Word()), nil
+}
+
+// handleSwap returns the amount bought.
+func handleSwap(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	out, err := pool.Swap(stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1]), stygos.U256FromWord(w[2]))
+	if err != nil {
+		return nil, err
+	}
+	return encode(out.Word()), nil
+}
+
+// handleGetReserves returns (reserve0, reserve1, blockTimestampLast).
+func handleGetReserves(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	s := pool.State()
+	return encode(s.Reserve0.Word(), s.Reserve1.Word(), stygos.WordFromUint64(s.TimestampLast)), nil
+}
+
+// handleCurrentCumulativePrices returns (price0Cumulative,
+// price1Cumulative, blockTimestamp) as of the current block.
+func handleCurrentCumulativePrices(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	price0, price1, timestamp := pool.CurrentCumulativePrices()
+	return encode(price0.Word(), price1.Word(), stygos.WordFromUint64(timestamp)), nil
+}
+
+// handleBalanceOf returns the pool shares of an account.
+func handleBalanceOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return encode(pool.SharesOf(stygos.AddressFromWord(w[0])).Word()), nil
+}
+
+// encode concatenates words into ABI return data.
+func encode(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+func call(sel stygos.Selector, args ...stygos.Word) []byte {
+	return append(sel[:], encode(args...)...)
+}
+
+func u(v uint64) stygos.Word {
+	return stygos.WordFromUint64(v)
+}
+
+func wordAt(b []byte) stygos.Word {
+	var w stygos.Word
+	copy(w[:], b)
+	return w
+}
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selInitialize, "initialize(address,address,uint256)"},
+		{selAddLiquidity, "addLiquidity(uint256,uint256,uint256,uint256)"},
+		{selRemoveLiquidity, "removeLiquidity(uint256,uint256,uint256)"},
+		{selSwap, "swap(address,uint256,uint256)"},
+		{selGetReserves, "getReserves()"},
+		{selCurrentCumulativePrices, "currentCumulativePrices()"},
+		{selToken0, "token0()"},
+		{selToken1, "token1()"},
+		{selBalanceOf, "balanceOf(address)"},
+		{selTotalSupply, "totalSupply()"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestAMM(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0x90}
+	mock.Time = 5000
+	stygos.UseRuntime(mock)
+
+	tokenA, tokenB := stygos.Address{0x0a}, stygos.Address{0x0b}
+	a := token.InstallMockERC20(mock, tokenA)
+	b := token.InstallMockERC20(mock, tokenB)
+	lp, trader := stygos.Address{0x1f}, stygos.Address{0x7a}
+	max := stygos.U256{}.Not()
+	for _, acc := range []stygos.Address{lp, trader} {
+		a.Mint(acc, stygos.NewU256(1e18))
+		b.Mint(acc, stygos.NewU256(1e18))
+		a.Approve(acc, mock.Contract, max)
+		b.Approve(acc, mock.Contract, max)
+	}
+
+	mock.Args = call(selInitialize, stygos.PadAddress(tokenA), stygos.PadAddress(tokenB), u(30))
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("initialize failed with status %d", status)
+	}
+	mock.Args = call(selInitialize, stygos.PadAddress(tokenA), stygos.PadAddress(tokenB), u(30))
+	if status := entrypoint(); status != 1 {
+		t.Errorf("initialize failed. Expected a second call to revert")
+	}
+
+	mock.Sender = lp
+	mock.Args = call(selAddLiquidity, u(4e9), u(1e9), u(0), u(0))
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("addLiquidity failed with status %d", status)
+	}
+	if shares := stygos.Uint64FromWord(wordAt(mock.Result[64:96])); shares != 2e9-1000 {
+		t.Errorf("addLiquidity failed. Expected %d shares, got %d", uint64(2e9-1000), shares)
+	}
+
+	// Sell 1e6 of token B for A at a 4:1 price
+	mock.Sender = trader
+	mock.Time += 60
+	mock.Args = call(selSwap, stygos.PadAddress(tokenB), u(1e6), u(3.9e6))
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("swap failed with status %d", status)
+	}
+	out := stygos.Uint64FromWord(wordAt(mock.Result))
+	if out < 3.9e6 || out >= 4e6 {
+		t.Errorf("swap failed. Expected just under 4e6, got %d", out)
+	}
+	if got := a.Balances[trader].Uint64(); got != 1e18+out {
+		t.Errorf("swap failed. Expected trader to receive %d, got %d", out, got-1e18)
+	}
+
+	mock.Args = call(selGetReserves)
+	entrypoint()
+	r0 := stygos.Uint64FromWord(wordAt(mock.Result[:32]))
+	r1 := stygos.Uint64FromWord(wordAt(mock.Result[32:64]))
+	ts := stygos.Uint64FromWord(wordAt(mock.Result[64:]))
+	if r0 != 4e9-out || r1 != 1e9+1e6 || ts != 5060 {
+		t.Errorf("getReserves failed. Got %d %d %d", r0, r1, ts)
+	}
+
+	mock.Args = call(selCurrentCumulativePrices)
+	entrypoint()
+	if price1 := stygos.U256FromWord(wordAt(mock.Result[32:64])); price1 != stygos.NewU256(4*60).Lsh(112) {
+		t.Errorf("currentCumulativePrices failed. Expected 4*60 as UQ112x112, got %v", price1.Big())
+	}
+
+	mock.Sender = lp
+	mock.Args = call(selBalanceOf, stygos.PadAddress(lp))
+	entrypoint()
+	shares := wordAt(mock.Result)
+	mock.Args = call(selRemoveLiquidity, shares, u(0), u(0))
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("removeLiquidity failed with status %d", status)
+	}
+	mock.Args = call(selTotalSupply)
+	entrypoint()
+	if supply := stygos.Uint64FromWord(wordAt(mock.Result)); supply != 1000 {
+		t.Errorf("totalSupply failed. Expected only the locked 1000 shares, got %d", supply)
+	}
+}
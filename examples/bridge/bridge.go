@@ -0,0 +1,250 @@
+// Command bridge is a lock-and-mint token bridge between two chains,
+// built on the xchain endpoint. The same contract runs on both sides: on
+// the home chain it is initialized with an ERC-20 token and escrows it,
+// and on the other chain it is initialized without one and is itself the
+// wrapped token, minted one for one against the escrowed tokens.
+//
+// bridge(to, amount) locks or burns the sender's tokens and sends a
+// message to the peer contract, with msg.value paying the bridge fee. The
+// peer mints or unlocks the tokens for to when the message is delivered.
+// Tokens are moved with the token package's safe wrappers, so tokens that
+// return nothing are supported, and the escrow credits the amount it
+// actually received, so fee-on-transfer tokens cannot be minted from thin
+// air. The escrow is locked during the transfer, so a token that calls
+// back cannot have one deposit credited twice.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+	"github.com/rafaelescrich/stygos/xchain"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go bridgeKey=bridge tokenKey=token peerChainKey=peerChain endpointKey=endpoint balancesKey=balances supplyKey=supply lockKey=lock
+
+// ABI selectors
+var (
+	selInitialize  = stygos.Selector{0x4e, 0x04, 0x27, 0x5f} // initialize(address,address,uint64,address)
+	selBridge      = stygos.Selector{0xc3, 0xde, 0x45, 0x3d} // bridge(address,uint256)
+	selToken       = stygos.Selector{0xfc, 0x0c, 0x54, 0x6a} // token()
+	selBalanceOf   = stygos.Selector{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	selTotalSupply = stygos.Selector{0x18, 0x16, 0x0d, 0xdd} // totalSupply()
+	selTransfer    = stygos.Selector{0xa9, 0x05, 0x9c, 0xbb} // transfer(address,uint256)
+)
+
+// Bridge errors
+var (
+	ErrInitialized         = errors.New("bridge: already initialized")
+	ErrNotInitialized      = errors.New("bridge: not initialized")
+	ErrZeroAmount          = errors.New("bridge: zero amount")
+	ErrInsufficientBalance = errors.New("bridge: amount exceeds balance")
+	ErrNotWrapped          = errors.New("bridge: not the wrapped token")
+	ErrLocked              = errors.New("bridge: reentrant call")
+)
+
+// transferTopic is the ERC-20 Transfer event of the wrapped token.
+var transferTopic = stygos.Keccak256([]byte("Transfer(address,address,uint256)"))
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selInitialize, handleInitialize)
+	r.HandleSelector(selBridge, handleBridge)
+	r.HandleSelector(xchain.ReceiveSelector, handleReceive)
+	r.HandleSelector(selToken, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.StorageLoad(tokenKey)), nil
+	})
+	r.HandleSelector(selBalanceOf, handleBalanceOf)
+	r.HandleSelector(selTotalSupply, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.StorageLoad(supplyKey)), nil
+	})
+	r.HandleSelector(selTransfer, handleTransfer)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleInitialize sets the xchain bridge contract, the escrowed token (zero
+// for the wrapped side) and the peer contract on the other chain. It is
+// called once, in the deployment transaction.
+func handleInitialize(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 4)
+	if err != nil {
+		return nil, err
+	}
+	if !stygos.StorageLoad(bridgeKey).IsZero() {
+		return nil, ErrInitialized
+	}
+	if w[0].IsZero() || w[3].IsZero() {
+		return nil, stygos.ErrInvalidInput
+	}
+	stygos.StorageStore(bridgeKey, w[0])
+	stygos.StorageStore(tokenKey, w[1])
+	stygos.StorageStore(peerChainKey, w[2])
+	endpoint().SetPeer(stygos.Uint64FromWord(w[2]), stygos.AddressFromWord(w[3]))
+	return nil, nil
+}
+
+// handleBridge locks or burns amount tokens of the sender and sends them
+// to an account on the peer chain, paying the bridge msg.value.
+func handleBridge(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if stygos.StorageLoad(bridgeKey).IsZero() {
+		return nil, ErrNotInitialized
+	}
+	sender, amount := stygos.GetMsgSender(), stygos.U256FromWord(w[1])
+	if t, ok := escrowed(); ok {
+		// Credit what arrived, which is less for fee-on-transfer tokens. A
+		// token bridging again while it runs would be counted twice, so the
+		// escrow is locked until the balance is read back
+		if !stygos.StorageLoad(lockKey).IsZero() {
+			return nil, ErrLocked
+		}
+		stygos.StorageStore(lockKey, stygos.WordFromUint64(1))
+		self := stygos.GetContractAddress()
+		before, err := t.BalanceOf(self)
+		if err != nil {
+			return nil, err
+		}
+		if err := token.SafeTransferFrom(t, sender, self, amount); err != nil {
+			return nil, err
+		}
+		after, err := t.BalanceOf(self)
+		if err != nil {
+			return nil, err
+		}
+		stygos.StorageStore(lockKey, stygos.Word{})
+		amount = after.Sub(before)
+	} else if err := burn(sender, amount); err != nil {
+		return nil, err
+	}
+	if amount.IsZero() {
+		return nil, ErrZeroAmount
+	}
+
+	chain := stygos.Uint64FromWord(stygos.StorageLoad(peerChainKey))
+	msg, err := endpoint().Send(chain, encode(w[0], amount.Word()), stygos.U256FromBig(stygos.GetMsgValue()))
+	if err != nil {
+		return nil, err
+	}
+	return encode(stygos.WordFromUint64(msg.Nonce)), nil
+}
+
+// handleReceive unlocks or mints the tokens of a message from the peer.
+func handleReceive(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	msg, err := endpoint().Receive(args)
+	if err != nil {
+		return nil, err
+	}
+	w, err := stygos.ArgWords(msg.Payload, 2)
+	if err != nil {
+		return nil, err
+	}
+	to, amount := stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1])
+	if t, ok := escrowed(); ok {
+		return nil, token.SafeTransfer(t, to, amount)
+	}
+	mint(to, amount)
+	return nil, nil
+}
+
+// handleBalanceOf returns the wrapped tokens held by an account.
+func handleBalanceOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return encode(balanceOf(stygos.AddressFromWord(w[0])).Word()), nil
+}
+
+// handleTransfer moves wrapped tokens from the sender.
+func handleTransfer(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := escrowed(); ok {
+		return nil, ErrNotWrapped
+	}
+	src, dst, amount := stygos.GetMsgSender(), stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1])
+	balance := balanceOf(src)
+	if balance.Lt(amount) {
+		return nil, ErrInsufficientBalance
+	}
+	setBalance(src, balance.Sub(amount))
+	setBalance(dst, balanceOf(dst).Add(amount))
+	emitTransfer(src, dst, amount)
+	return encode(stygos.WordFromUint64(1)), nil
+}
+
+// endpoint returns the xchain endpoint using the configured bridge.
+func endpoint() *xchain.Endpoint {
+	bridge := stygos.AddressFromWord(stygos.StorageLoad(bridgeKey))
+	return xchain.NewEndpoint(endpointKey, xchain.Outbox(bridge), xchain.Inbox(bridge))
+}
+
+// escrowed returns the escrowed token, or false on the wrapped side.
+func escrowed() (token.ERC20, bool) {
+	t := stygos.StorageLoad(tokenKey)
+	return token.NewERC20(stygos.AddressFromWord(t)), !t.IsZero()
+}
+
+// mint creates wrapped tokens. The supply is bounded by the escrowed
+// tokens, so it cannot overflow.
+func mint(to stygos.Address, amount stygos.U256) {
+	setBalance(to, balanceOf(to).Add(amount))
+	stygos.StorageStore(supplyKey, stygos.U256FromWord(stygos.StorageLoad(supplyKey)).Add(amount).Word())
+	emitTransfer(stygos.Address{}, to, amount)
+}
+
+// burn destroys wrapped tokens of from.
+func burn(from stygos.Address, amount stygos.U256) error {
+	balance := balanceOf(from)
+	if balance.Lt(amount) {
+		return ErrInsufficientBalance
+	}
+	setBalance(from, balance.Sub(amount))
+	stygos.StorageStore(supplyKey, stygos.U256FromWord(stygos.StorageLoad(supplyKey)).Sub(amount).Word())
+	emitTransfer(from, stygos.Address{}, amount)
+	return nil
+}
+
+func emitTransfer(from, to stygos.Address, amount stygos.U256) {
+	stygos.EmitEvent(encode(amount.Word()), transferTopic, stygos.PadAddress(from), stygos.PadAddress(to))
+}
+
+func balanceOf(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(storage.MapKey(balancesKey, account[:])))
+}
+
+func setBalance(account stygos.Address, amount stygos.U256) {
+	stygos.StorageStore(storage.MapKey(balancesKey, account[:]), amount.Word())
+}
+
+// encode concatenates words into ABI data.
+func encode(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+	"github.com/rafaelescrich/stygos/xchain"
+)
+
+const (
+	homeChain    = 1
+	wrappedChain = 42161
+)
+
+var (
+	bridge  = stygos.Address{0xb7} // xchain bridge on both chains
+	usdc    = stygos.Address{0x0c}
+	escrow  = stygos.Address{0xe5} // on the home chain
+	wrapped = stygos.Address{0x3e} // on the wrapped chain
+	alice   = stygos.Address{0xa1}
+	bob     = stygos.Address{0xb0}
+	carol   = stygos.Address{0xca}
+)
+
+func u(v uint64) stygos.U256 {
+	return stygos.NewU256(v)
+}
+
+// env is the two chains connected by the mock bridge, which is the
+// relayer.
+type env struct {
+	bridge  *xchain.MockBridge
+	home    *stygos.MockRuntime
+	remote  *stygos.MockRuntime
+	usdc    *token.MockERC20
+	wrapped token.ERC20
+}
+
+// setup deploys the escrow of usdc on the home chain and its wrapped token
+// on the other chain, and gives alice 1000 usdc approved for the escrow.
+func setup(t *testing.T) *env {
+	t.Helper()
+	e := &env{bridge: xchain.NewMockBridge(bridge), wrapped: token.NewERC20(wrapped)}
+	e.home = e.chain(homeChain, escrow)
+	e.remote = e.chain(wrappedChain, wrapped)
+	e.usdc = token.InstallMockERC20(e.home, usdc)
+	e.usdc.Mint(alice, u(1000))
+	e.usdc.Approve(alice, escrow, u(1000))
+
+	as(e.home, alice)
+	if err := call(escrow, 0, selInitialize, stygos.PadAddress(bridge), stygos.PadAddress(usdc),
+		stygos.WordFromUint64(wrappedChain), stygos.PadAddress(wrapped)); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	as(e.remote, alice)
+	if err := call(wrapped, 0, selInitialize, stygos.PadAddress(bridge), stygos.Word{},
+		stygos.WordFromUint64(homeChain), stygos.PadAddress(escrow)); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return e
+}
+
+func (e *env) chain(id uint64, addr stygos.Address) *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Chain = id
+	mock.Deploy(addr, stygos.MockEntrypoint(entrypoint))
+	e.bridge.AddChain(mock)
+	return mock
+}
+
+// as selects the chain and makes from the caller.
+func as(mock *stygos.MockRuntime, from stygos.Address) {
+	stygos.UseRuntime(mock)
+	mock.Contract = from
+}
+
+func call(to stygos.Address, value int64, sel stygos.Selector, args ...stygos.Word) error {
+	data := append([]byte(nil), sel[:]...)
+	for _, a := range args {
+		data = append(data, a[:]...)
+	}
+	_, err := stygos.Call(to, stygos.WordFromUint64(uint64(value)), data)
+	return err
+}
+
+// holdings returns the tokens of account on the chain of mock.
+func holdings(t *testing.T, mock *stygos.MockRuntime, tok token.ERC20, account stygos.Address) uint64 {
+	t.Helper()
+	stygos.UseRuntime(mock)
+	b, err := tok.BalanceOf(account)
+	if err != nil {
+		t.Fatalf("balanceOf failed: %v", err)
+	}
+	return b.Uint64()
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selInitialize, "initialize(address,address,uint64,address)"},
+		{selBridge, "bridge(address,uint256)"},
+		{selToken, "token()"},
+		{selBalanceOf, "balanceOf(address)"},
+		{selTotalSupply, "totalSupply()"},
+		{selTransfer, "transfer(address,uint256)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	e := setup(t)
+
+	// Lock on the home chain, paying the bridge a fee
+	as(e.home, alice)
+	e.home.SetBalance(alice, big.NewInt(10))
+	if err := call(escrow, 3, selBridge, stygos.PadAddress(bob), u(300).Word()); err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+	if got := e.usdc.Balances[escrow]; got != u(300) {
+		t.Errorf("bridge failed. Expected 300 usdc in escrow, got %s", got)
+	}
+	if got := e.home.BalanceOf(bridge).Int64(); got != 3 {
+		t.Errorf("bridge failed. Expected the bridge to get the fee, got %d", got)
+	}
+	if len(e.bridge.Pending) != 1 {
+		t.Fatalf("bridge failed. Expected one pending message, got %d", len(e.bridge.Pending))
+	}
+
+	// Mint on the other chain
+	if err := e.bridge.Relay(); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if got := holdings(t, e.remote, e.wrapped, bob); got != 300 {
+		t.Errorf("Relay failed. Expected bob to get 300 wrapped, got %d", got)
+	}
+
+	// Wrapped tokens move like any ERC-20
+	as(e.remote, bob)
+	if err := e.wrapped.Transfer(carol, u(100)); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	// Burn and unlock on the home chain
+	as(e.remote, carol)
+	if err := call(wrapped, 0, selBridge, stygos.PadAddress(carol), u(100).Word()); err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+	if supply, err := e.wrapped.TotalSupply(); err != nil || supply != u(200) {
+		t.Errorf("bridge failed. Expected a supply of 200, got %s, %v", supply, err)
+	}
+	if err := e.bridge.Relay(); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if got := e.usdc.Balances[carol]; got != u(100) {
+		t.Errorf("Relay failed. Expected carol to get 100 usdc, got %s", got)
+	}
+	if got := e.usdc.Balances[escrow]; got != u(200) {
+		t.Errorf("Relay failed. Expected 200 usdc left in escrow, got %s", got)
+	}
+}
+
+func TestRejects(t *testing.T) {
+	e := setup(t)
+
+	as(e.home, alice)
+	if err := call(escrow, 0, selInitialize, stygos.PadAddress(bridge), stygos.Word{},
+		stygos.WordFromUint64(wrappedChain), stygos.PadAddress(alice)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("initialize failed. Expected a revert when initialized, got %v", err)
+	}
+	if err := call(escrow, 0, selBridge, stygos.PadAddress(bob), stygos.Word{}); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("bridge failed. Expected a revert for a zero amount, got %v", err)
+	}
+	if err := call(escrow, 0, selBridge, stygos.PadAddress(bob), u(1001).Word()); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("bridge failed. Expected a revert beyond the allowance, got %v", err)
+	}
+	if err := call(escrow, 0, selTransfer, stygos.PadAddress(bob), u(1).Word()); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("transfer failed. Expected a revert on the escrow, got %v", err)
+	}
+	as(e.remote, bob)
+	if err := call(wrapped, 0, selBridge, stygos.PadAddress(bob), u(1).Word()); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("bridge failed. Expected a revert without wrapped tokens, got %v", err)
+	}
+
+	// Only the escrow's messages, delivered once, mint
+	as(e.home, alice)
+	if err := call(escrow, 0, selBridge, stygos.PadAddress(bob), u(50).Word()); err != nil {
+		t.Fatalf("bridge failed: %v", err)
+	}
+	sent := e.bridge.Pending[0]
+	if err := e.bridge.Relay(); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if err := e.bridge.Deliver(sent); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Deliver failed. Expected a replay to revert, got %v", err)
+	}
+	forged := sent
+	forged.Sender = alice
+	if err := e.bridge.Deliver(forged); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Deliver failed. Expected a message from another sender to revert, got %v", err)
+	}
+	if got := holdings(t, e.remote, e.wrapped, bob); got != 50 {
+		t.Errorf("Deliver failed. Expected bob to hold 50 wrapped, got %d", got)
+	}
+
+	// Direct calls to the receive entrypoint are not vouched for by the bridge
+	as(e.remote, alice)
+	if _, err := stygos.Call(wrapped, stygos.Word{}, xchain.Delivery(&sent)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("receiveMessage failed. Expected a revert from another caller, got %v", err)
+	}
+}
+
+func TestReentrantToken(t *testing.T) {
+	e := setup(t)
+
+	// usdc bridges its own tokens while pulling alice's, which would credit
+	// them to both deposits
+	e.usdc.Mint(usdc, u(100))
+	e.usdc.Approve(usdc, escrow, u(100))
+	erc20 := e.home.Contracts[usdc]
+	transferFrom := stygos.SelectorOf("transferFrom(address,address,uint256)")
+	var reentered bool
+	var inner error
+	e.home.Deploy(usdc, func(input []byte) ([]byte, error) {
+		if bytes.HasPrefix(input, transferFrom[:]) && stygos.GetMsgSender() == escrow && !reentered {
+			reentered = true
+			if inner = call(escrow, 0, selBridge, stygos.PadAddress(bob), u(100).Word()); inner != nil {
+				return nil, inner
+			}
+		}
+		return erc20(input)
+	})
+
+	as(e.home, alice)
+	if err := call(escrow, 0, selBridge, stygos.PadAddress(bob), u(300).Word()); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("bridge failed. Expected a reentrant deposit to revert, got %v", err)
+	}
+	if !errors.Is(inner, stygos.ErrRevert) {
+		t.Errorf("bridge failed. Expected the reentrant call to revert, got %v", inner)
+	}
+	if got := e.usdc.Balances[escrow]; !got.IsZero() {
+		t.Errorf("bridge failed. Expected nothing in escrow, got %s", got)
+	}
+	if len(e.bridge.Pending) != 0 {
+		t.Errorf("bridge failed. Expected no pending message, got %d", len(e.bridge.Pending))
+	}
+
+	// The lock is released once the deposit reverted
+	if err := call(escrow, 0, selBridge, stygos.PadAddress(bob), u(300).Word()); err != nil {
+		t.Errorf("bridge failed after the reentrant deposit: %v", err)
+	}
+}
@@ -6,10 +6,8 @@ import (
 	"github.com/rafaelescrich/stygos"
 )
 
-// Storage keys
-var (
-	counterKey = stygos.Keccak256([]byte("counter"))
-)
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go counterKey=counter
 
 // Commands
 const (
@@ -24,6 +22,11 @@ func main() {
 	// This function is required by Go but not used directly by Stylus
 }
 
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
 //export entrypoint
 func entrypoint() int32 {
 	// Get the call data
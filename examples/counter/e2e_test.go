@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/devnode"
+	"github.com/rafaelescrich/stygos/script"
+)
+
+// TestCounterE2E runs the counter on a nitro dev node. It is skipped
+// unless STYGOS_DEVNODE is set.
+func TestCounterE2E(t *testing.T) {
+	node := devnode.Start(t)
+	counter := node.Deploy(t, devnode.Build(t, "."))
+	user := node.NewAccount(t, devnode.Ether)
+	s := &script.Script{Backend: user}
+
+	get := func() uint32 {
+		ret, err := s.Call(counter, []byte{CMD_GET})
+		if err != nil || len(ret) != 4 {
+			t.Fatalf("CMD_GET failed: %x, %v", ret, err)
+		}
+		return binary.BigEndian.Uint32(ret)
+	}
+	if v := get(); v != 0 {
+		t.Fatalf("Initial value failed. Expected 0, got %d", v)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.Send("increment", script.Tx{To: &counter, Data: []byte{CMD_INCREMENT}}); err != nil {
+			t.Fatalf("CMD_INCREMENT failed: %v", err)
+		}
+	}
+	if v := get(); v != 2 {
+		t.Errorf("Increment failed. Expected 2, got %d", v)
+	}
+
+	slot, err := user.StorageAt(counter, counterKey)
+	if err != nil || slot != stygos.WordFromUint64(2) {
+		t.Errorf("Storage failed. Expected 2 in the counter slot, got %s, %v", slot.Hex(), err)
+	}
+}
@@ -5,17 +5,11 @@ import (
 	"errors"
 
 	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/bytesutil"
 )
 
-// Storage keys
-var (
-	nameKey         = stygos.Keccak256([]byte("name"))
-	symbolKey       = stygos.Keccak256([]byte("symbol"))
-	decimalsKey     = stygos.Keccak256([]byte("decimals"))
-	totalSupplyKey  = stygos.Keccak256([]byte("totalSupply"))
-	balancePrefix   = stygos.Keccak256([]byte("balance"))
-	allowancePrefix = stygos.Keccak256([]byte("allowance"))
-)
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go nameKey=name symbolKey=symbol decimalsKey=decimals totalSupplyKey=totalSupply balancePrefix=balance allowancePrefix=allowance
 
 // Commands
 const (
@@ -28,8 +22,30 @@ const (
 	CMD_ALLOWANCE     = 6
 	CMD_APPROVE       = 7
 	CMD_TRANSFER_FROM = 8
+
+	// Allowance changes relative to the current value. Unlike CMD_APPROVE
+	// they cannot be front-run into letting the spender use both the old
+	// and the new allowance.
+	CMD_INCREASE_ALLOWANCE = 9
+	CMD_DECREASE_ALLOWANCE = 10
+)
+
+// Errors of the token operations
+var (
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+	ErrAllowanceBelowZero    = errors.New("decreased allowance below zero")
+	ErrOverflow              = errors.New("overflow")
 )
 
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
 //export entrypoint
 func entrypoint() int32 {
 	callData, err := stygos.GetCallData()
@@ -112,6 +128,21 @@ func entrypoint() int32 {
 		if err != nil {
 			return 1
 		}
+	case CMD_INCREASE_ALLOWANCE, CMD_DECREASE_ALLOWANCE:
+		if len(args) != 40 {
+			return 1
+		}
+		var spender stygos.Address
+		copy(spender[:], args[:20])
+		amount := binary.BigEndian.Uint64(args[20:])
+		if command == CMD_INCREASE_ALLOWANCE {
+			err = increaseAllowance(spender, amount)
+		} else {
+			err = decreaseAllowance(spender, amount)
+		}
+		if err != nil {
+			return 1
+		}
 	default:
 		return 1
 	}
@@ -149,7 +180,18 @@ func transfer(to stygos.Address, amount uint64) error {
 	caller := stygos.AddressFromWord(stygos.StorageLoad(stygos.Keccak256([]byte("caller"))))
 	balance := getBalance(caller)
 	if balance < amount {
-		return errors.New("insufficient balance")
+		return ErrInsufficientBalance
+	}
+
+	// Check the recipient balance before any write; a transfer to self
+	// credits the already debited balance
+	recipientBalance := getBalance(to)
+	if to == caller {
+		recipientBalance = balance - amount
+	}
+	recipientBalance, ok := stygos.SafeAddU64(recipientBalance, amount)
+	if !ok {
+		return ErrOverflow
 	}
 
 	// Update sender balance
@@ -159,41 +201,72 @@ func transfer(to stygos.Address, amount uint64) error {
 
 	// Update recipient balance
 	recipientKey := stygos.Keccak256(append(balancePrefix[:], to[:]...))
-	recipientBalance := getBalance(to)
-	recipientValue := stygos.WordFromUint64(recipientBalance + amount)
+	recipientValue := stygos.WordFromUint64(recipientBalance)
 	stygos.StorageStore(recipientKey, recipientValue)
 
 	return nil
 }
 
 func getAllowance(owner, spender stygos.Address) uint64 {
-	key := stygos.Keccak256(append(append(allowancePrefix[:], owner[:]...), spender[:]...))
+	key := stygos.Keccak256(bytesutil.Concat(allowancePrefix[:], owner[:], spender[:]))
 	value := stygos.StorageLoad(key)
 	return stygos.Uint64FromWord(value)
 }
 
 func approve(spender stygos.Address, amount uint64) error {
 	caller := stygos.AddressFromWord(stygos.StorageLoad(stygos.Keccak256([]byte("caller"))))
-	key := stygos.Keccak256(append(append(allowancePrefix[:], caller[:]...), spender[:]...))
+	key := stygos.Keccak256(bytesutil.Concat(allowancePrefix[:], caller[:], spender[:]))
 	value := stygos.WordFromUint64(amount)
 	stygos.StorageStore(key, value)
 	return nil
 }
 
+// increaseAllowance raises the caller's allowance for spender by amount.
+func increaseAllowance(spender stygos.Address, amount uint64) error {
+	caller := stygos.AddressFromWord(stygos.StorageLoad(stygos.Keccak256([]byte("caller"))))
+	allowance, ok := stygos.SafeAddU64(getAllowance(caller, spender), amount)
+	if !ok {
+		return ErrOverflow
+	}
+	return approve(spender, allowance)
+}
+
+// decreaseAllowance lowers the caller's allowance for spender by amount.
+// It fails rather than clamp to zero when the spender has already used
+// more, so the owner learns the allowance was spent.
+func decreaseAllowance(spender stygos.Address, amount uint64) error {
+	caller := stygos.AddressFromWord(stygos.StorageLoad(stygos.Keccak256([]byte("caller"))))
+	allowance := getAllowance(caller, spender)
+	if allowance < amount {
+		return ErrAllowanceBelowZero
+	}
+	return approve(spender, allowance-amount)
+}
+
 func transferFrom(from, to stygos.Address, amount uint64) error {
 	caller := stygos.AddressFromWord(stygos.StorageLoad(stygos.Keccak256([]byte("caller"))))
 	allowance := getAllowance(from, caller)
 	if allowance < amount {
-		return errors.New("insufficient allowance")
+		return ErrInsufficientAllowance
 	}
 
 	fromBalance := getBalance(from)
 	if fromBalance < amount {
-		return errors.New("insufficient balance")
+		return ErrInsufficientBalance
+	}
+
+	// Check the recipient balance before any write, as in transfer
+	toBalance := getBalance(to)
+	if to == from {
+		toBalance = fromBalance - amount
+	}
+	toBalance, ok := stygos.SafeAddU64(toBalance, amount)
+	if !ok {
+		return ErrOverflow
 	}
 
 	// Update allowance
-	allowanceKey := stygos.Keccak256(append(append(allowancePrefix[:], from[:]...), caller[:]...))
+	allowanceKey := stygos.Keccak256(bytesutil.Concat(allowancePrefix[:], from[:], caller[:]))
 	allowanceValue := stygos.WordFromUint64(allowance - amount)
 	stygos.StorageStore(allowanceKey, allowanceValue)
 
@@ -204,8 +277,7 @@ func transferFrom(from, to stygos.Address, amount uint64) error {
 
 	// Update to balance
 	toKey := stygos.Keccak256(append(balancePrefix[:], to[:]...))
-	toBalance := getBalance(to)
-	toValue := stygos.WordFromUint64(toBalance + amount)
+	toValue := stygos.WordFromUint64(toBalance)
 	stygos.StorageStore(toKey, toValue)
 
 	return nil
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/rafaelescrich/stygos"
@@ -97,3 +98,89 @@ func TestERC20(t *testing.T) {
 		t.Errorf("Expected allowance 500, got %d", allowance)
 	}
 }
+
+func TestTransferOverflow(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	var owner, recipient stygos.Address
+	copy(owner[:], []byte("owner12345678901234"))
+	copy(recipient[:], []byte("recipient123456789"))
+	balancePrefix := stygos.Keccak256([]byte("balance"))
+	stygos.StorageStore(stygos.Keccak256(append(balancePrefix[:], owner[:]...)), stygos.WordFromUint64(10))
+	stygos.StorageStore(stygos.Keccak256(append(balancePrefix[:], recipient[:]...)), stygos.WordFromUint64(^uint64(0)))
+	stygos.StorageStore(stygos.Keccak256([]byte("caller")), stygos.PadAddress(owner))
+
+	// The recipient balance used to wrap around to 9
+	if err := transfer(recipient, 10); err == nil {
+		t.Errorf("Expected transfer to a full balance to fail, got balance %d", getBalance(recipient))
+	}
+
+	// A failed transfer writes nothing
+	if got := getBalance(owner); got != 10 {
+		t.Errorf("Expected the owner to keep 10 after the failed transfer, got %d", got)
+	}
+	allowancePrefix := stygos.Keccak256([]byte("allowance"))
+	allowanceKey := stygos.Keccak256(append(append(allowancePrefix[:], owner[:]...), owner[:]...))
+	stygos.StorageStore(allowanceKey, stygos.WordFromUint64(10))
+	if err := transferFrom(owner, recipient, 10); err == nil {
+		t.Errorf("Expected transferFrom to a full balance to fail, got balance %d", getBalance(recipient))
+	}
+	if got := getBalance(owner); got != 10 {
+		t.Errorf("Expected the owner to keep 10 after the failed transferFrom, got %d", got)
+	}
+	if got := getAllowance(owner, owner); got != 10 {
+		t.Errorf("Expected the allowance to stay 10 after the failed transferFrom, got %d", got)
+	}
+
+	// Transfers to self keep the balance
+	if err := transfer(owner, 4); err != nil {
+		t.Fatalf("transfer to self failed: %v", err)
+	}
+	if err := transferFrom(owner, owner, 4); err != nil {
+		t.Fatalf("transferFrom to self failed: %v", err)
+	}
+	if got := getBalance(owner); got != 10 {
+		t.Errorf("Expected transfers to self to keep 10, got %d", got)
+	}
+}
+
+func TestAllowanceChanges(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	var owner, spender stygos.Address
+	copy(owner[:], []byte("owner12345678901234"))
+	copy(spender[:], []byte("spender12345678901"))
+	stygos.StorageStore(stygos.Keccak256([]byte("caller")), stygos.PadAddress(owner))
+
+	command := func(cmd byte, amount uint64) int32 {
+		// The amount field is 20 bytes wide, as in CMD_APPROVE
+		var amt [20]byte
+		binary.BigEndian.PutUint64(amt[:], amount)
+		mock.Args = append(append([]byte{cmd}, spender[:]...), amt[:]...)
+		return entrypoint()
+	}
+
+	tests := []struct {
+		cmd    byte
+		amount uint64
+		status int32
+		want   uint64
+	}{
+		{CMD_INCREASE_ALLOWANCE, 300, 0, 300},
+		{CMD_INCREASE_ALLOWANCE, 200, 0, 500},
+		{CMD_DECREASE_ALLOWANCE, 100, 0, 400},
+		{CMD_DECREASE_ALLOWANCE, 401, 1, 400},
+		{CMD_INCREASE_ALLOWANCE, ^uint64(0), 1, 400},
+		{CMD_DECREASE_ALLOWANCE, 400, 0, 0},
+	}
+	for _, tt := range tests {
+		if status := command(tt.cmd, tt.amount); status != tt.status {
+			t.Errorf("Command %d with %d failed. Expected status %d, got %d", tt.cmd, tt.amount, tt.status, status)
+		}
+		if got := getAllowance(owner, spender); got != tt.want {
+			t.Errorf("Command %d with %d failed. Expected allowance %d, got %d", tt.cmd, tt.amount, tt.want, got)
+		}
+	}
+}
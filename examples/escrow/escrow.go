@@ -0,0 +1,254 @@
+// Command escrow is an ETH escrow settled by a Schnorr adaptor signature.
+//
+// The payer locks ETH for a payee together with a pre-signature, under the
+// payer's key signerX, of the release message for an adaptor point T. Only
+// someone who knows t with T = t·G can complete it into a valid signature,
+// so presenting one releases the funds to the payee and reveals t on-chain:
+// t = s - s' is extracted and stored where the payer, or anyone, can read
+// it. This is the on-chain half of an atomic swap whose other half is
+// unlocked by t. After the timeout the payer can take the funds back.
+package main
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/bytesutil"
+	"github.com/rafaelescrich/stygos/schnorr"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go escrowsKey=escrows
+
+// ABI selectors
+var (
+	selLock      = stygos.Selector{0x65, 0x61, 0xb1, 0xb5} // lock(bytes32,address,bytes32,bytes32,bytes32,bytes32,bytes32,uint256)
+	selRelease   = stygos.Selector{0xac, 0x8f, 0x75, 0xc1} // release(bytes32,bytes32,bytes32)
+	selRefund    = stygos.Selector{0x72, 0x49, 0xfb, 0xb6} // refund(bytes32)
+	selSecretOf  = stygos.Selector{0x49, 0x36, 0x8a, 0x22} // secretOf(bytes32)
+	selMessageOf = stygos.Selector{0xa4, 0x48, 0x8c, 0x1b} // messageOf(bytes32,address,uint256)
+)
+
+// Escrow errors
+var (
+	ErrEscrowExists       = errors.New("escrow: id already used")
+	ErrUnknownEscrow      = errors.New("escrow: unknown escrow")
+	ErrSettled            = errors.New("escrow: already settled")
+	ErrZeroValue          = errors.New("escrow: no value locked")
+	ErrInvalidPreSig      = errors.New("escrow: invalid adaptor pre-signature")
+	ErrInvalidSignature   = errors.New("escrow: invalid settlement signature")
+	ErrNotPayer           = errors.New("escrow: caller is not the payer")
+	ErrTimeoutNotReached  = errors.New("escrow: timeout not reached")
+	ErrTimeoutPassed      = errors.New("escrow: timeout passed")
+	ErrInvalidTimeout     = errors.New("escrow: timeout must be in the future")
+	ErrSecretNotAvailable = errors.New("escrow: secret not revealed")
+)
+
+// Escrow is a locked payment, packed into storage by escrow_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Escrow -o escrow_pack_gen.go
+type Escrow struct {
+	Payer    stygos.Address
+	Timeout  uint64 // unix seconds
+	Payee    stygos.Address
+	Settled  bool
+	Released bool
+	Amount   stygos.U256
+	SignerX  stygos.Word // x-only public key of the pre-signature
+	AdaptorX stygos.Word
+	AdaptorY stygos.Word
+	PreSigR  stygos.Word
+	PreSigS  stygos.Word
+	Secret   stygos.Word // t, set on release
+}
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selLock, handleLock)
+	r.HandleSelector(selRelease, handleRelease)
+	r.HandleSelector(selRefund, handleRefund)
+	r.HandleSelector(selSecretOf, handleSecretOf)
+	r.HandleSelector(selMessageOf, handleMessageOf)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleLock locks msg.value for the payee. The arguments are the escrow
+// id, the payee, the signer key, the adaptor point and the pre-signature
+// (R', s') of messageOf(id, payee, msg.value), and the timeout.
+func handleLock(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 8)
+	if err != nil {
+		return nil, err
+	}
+	id := w[0]
+	if _, err := load(id); err != ErrUnknownEscrow {
+		return nil, ErrEscrowExists
+	}
+	amount := stygos.U256FromBig(stygos.GetMsgValue())
+	if amount.IsZero() {
+		return nil, ErrZeroValue
+	}
+	timeout := stygos.U256FromWord(w[7])
+	if !timeout.IsUint64() || timeout.Uint64() <= stygos.GetBlockTimestamp() {
+		return nil, ErrInvalidTimeout
+	}
+
+	e := Escrow{
+		Payer:    stygos.GetMsgSender(),
+		Timeout:  timeout.Uint64(),
+		Payee:    stygos.AddressFromWord(w[1]),
+		Amount:   amount,
+		SignerX:  w[2],
+		AdaptorX: w[3],
+		AdaptorY: w[4],
+		PreSigR:  w[5],
+		PreSigS:  w[6],
+	}
+	T := schnorr.Point{X: new(big.Int).SetBytes(e.AdaptorX[:]), Y: new(big.Int).SetBytes(e.AdaptorY[:])}
+	msg := messageOf(id, e.Payee, amount)
+	if !schnorr.VerifyAdaptor(msg[:], preSig(&e), e.SignerX[:], T) {
+		return nil, ErrInvalidPreSig
+	}
+	e.Store(slot(id))
+	return nil, nil
+}
+
+// handleRelease pays the payee given the completed signature (R', s) and
+// records the adaptor secret it reveals. Anyone may submit it.
+func handleRelease(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	id := w[0]
+	e, err := load(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Settled {
+		return nil, ErrSettled
+	}
+	if stygos.GetBlockTimestamp() >= e.Timeout {
+		return nil, ErrTimeoutPassed
+	}
+
+	sig := make([]byte, 64)
+	copy(sig, w[1][:])
+	copy(sig[32:], w[2][:])
+	msg := messageOf(id, e.Payee, e.Amount)
+	if !schnorr.Verify(msg[:], sig, e.SignerX[:]) {
+		return nil, ErrInvalidSignature
+	}
+	// A valid signature sharing R' with the verified pre-signature has
+	// s·G - s'·G = R' - R = T, so s - s' is the secret.
+	t, err := schnorr.Extract(sig, preSig(&e))
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	t.FillBytes(e.Secret[:])
+	e.Settled = true
+	e.Released = true
+	e.Store(slot(id))
+
+	stygos.EmitEvent(e.Secret[:], stygos.Keccak256([]byte("Released(bytes32,bytes32)")), id)
+	return nil, stygos.Transfer(e.Payee, e.Amount)
+}
+
+// handleRefund returns the funds to the payer after the timeout.
+func handleRefund(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	id := w[0]
+	e, err := load(id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Settled {
+		return nil, ErrSettled
+	}
+	if stygos.GetMsgSender() != e.Payer {
+		return nil, ErrNotPayer
+	}
+	if stygos.GetBlockTimestamp() < e.Timeout {
+		return nil, ErrTimeoutNotReached
+	}
+	e.Settled = true
+	e.Store(slot(id))
+
+	stygos.EmitEvent(nil, stygos.Keccak256([]byte("Refunded(bytes32)")), id)
+	return nil, stygos.Transfer(e.Payer, e.Amount)
+}
+
+// handleSecretOf returns the adaptor secret revealed by a release.
+func handleSecretOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	e, err := load(w[0])
+	if err != nil {
+		return nil, err
+	}
+	if !e.Released {
+		return nil, ErrSecretNotAvailable
+	}
+	return e.Secret[:], nil
+}
+
+// handleMessageOf returns the message the payer pre-signs for an escrow.
+func handleMessageOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	msg := messageOf(w[0], stygos.AddressFromWord(w[1]), stygos.U256FromWord(w[2]))
+	return msg[:], nil
+}
+
+// messageOf binds a signature to this contract, the escrow id, the payee
+// and the amount: keccak256(contract ++ id ++ payee ++ amount).
+func messageOf(id stygos.Word, payee stygos.Address, amount stygos.U256) stygos.Word {
+	contract := stygos.GetContractAddress()
+	amt := amount.Word()
+	return stygos.Keccak256(bytesutil.Concat(contract[:], id[:], payee[:], amt[:]))
+}
+
+func preSig(e *Escrow) []byte {
+	sig := make([]byte, 64)
+	copy(sig, e.PreSigR[:])
+	copy(sig[32:], e.PreSigS[:])
+	return sig
+}
+
+func load(id stygos.Word) (Escrow, error) {
+	var e Escrow
+	e.Load(slot(id))
+	if e.Payer == (stygos.Address{}) {
+		return e, ErrUnknownEscrow
+	}
+	return e, nil
+}
+
+func slot(id stygos.Word) stygos.Word {
+	return storage.MapKey(escrowsKey, id[:])
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/schnorr"
+)
+
+var (
+	contract = stygos.Address{0xe5}
+	payer    = stygos.Address{0xa1}
+	payee    = stygos.Address{0xb0}
+	other    = stygos.Address{0x07}
+
+	signerKey = big.NewInt(0xa11ce)
+	secret    = big.NewInt(0x5ec2e7)
+)
+
+func call(sel stygos.Selector, args ...stygos.Word) []byte {
+	out := append([]byte{}, sel[:]...)
+	for _, w := range args {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+func word(b []byte) stygos.Word {
+	var w stygos.Word
+	copy(w[32-len(b):], b)
+	return w
+}
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+	return mock
+}
+
+// lock sends wei from the payer with a pre-signature for the adaptor point
+// of secret, returning the pre-signature.
+func lock(t *testing.T, mock *stygos.MockRuntime, id stygos.Word, wei uint64) []byte {
+	t.Helper()
+	T := schnorr.Mul(schnorr.G(), secret)
+	pub, _ := schnorr.PublicKey(signerKey)
+	msg := messageOf(id, payee, stygos.NewU256(wei))
+	pre, err := schnorr.AdaptorSign(signerKey, msg[:], make([]byte, 32), T)
+	if err != nil {
+		t.Fatalf("AdaptorSign failed: %v", err)
+	}
+
+	mock.Sender = payer
+	mock.Value = new(big.Int).SetUint64(wei)
+	mock.SetBalance(contract, new(big.Int).Add(mock.BalanceOf(contract), mock.Value))
+	_, err = router.Dispatch(call(selLock, id, stygos.PadAddress(payee), word(pub),
+		word(T.X.Bytes()), word(T.Y.Bytes()), word(pre[:32]), word(pre[32:]), stygos.WordFromUint64(2_000)))
+	if err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	mock.Value = big.NewInt(0)
+	return pre
+}
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selLock, "lock(bytes32,address,bytes32,bytes32,bytes32,bytes32,bytes32,uint256)"},
+		{selRelease, "release(bytes32,bytes32,bytes32)"},
+		{selRefund, "refund(bytes32)"},
+		{selSecretOf, "secretOf(bytes32)"},
+		{selMessageOf, "messageOf(bytes32,address,uint256)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestRelease(t *testing.T) {
+	mock := setup()
+	id := stygos.Word{0x01}
+	pre := lock(t, mock, id, 500)
+
+	if _, err := router.Dispatch(call(selLock, id)); err != stygos.ErrInvalidInput {
+		t.Errorf("lock failed. Expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := router.Dispatch(call(selSecretOf, id)); err != ErrSecretNotAvailable {
+		t.Errorf("secretOf failed. Expected ErrSecretNotAvailable, got %v", err)
+	}
+
+	// The pre-signature alone does not release the funds
+	mock.Sender = other
+	if _, err := router.Dispatch(call(selRelease, id, word(pre[:32]), word(pre[32:]))); err != ErrInvalidSignature {
+		t.Errorf("release failed. Expected ErrInvalidSignature for the pre-signature, got %v", err)
+	}
+
+	sig, _ := schnorr.Adapt(pre, secret)
+	if _, err := router.Dispatch(call(selRelease, id, word(sig[:32]), word(sig[32:]))); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+	if got := mock.BalanceOf(payee); got.Uint64() != 500 {
+		t.Errorf("release failed. Expected the payee to receive 500, got %v", got)
+	}
+	ret, err := router.Dispatch(call(selSecretOf, id))
+	if err != nil || new(big.Int).SetBytes(ret).Cmp(secret) != 0 {
+		t.Errorf("secretOf failed. Expected %x, got %x, %v", secret, ret, err)
+	}
+	if len(mock.Logs) != 1 {
+		t.Errorf("release failed. Expected 1 log, got %d", len(mock.Logs))
+	}
+
+	if _, err := router.Dispatch(call(selRelease, id, word(sig[:32]), word(sig[32:]))); err != ErrSettled {
+		t.Errorf("release failed. Expected ErrSettled, got %v", err)
+	}
+	mock.Sender = payer
+	mock.Time = 3_000
+	if _, err := router.Dispatch(call(selRefund, id)); err != ErrSettled {
+		t.Errorf("refund failed. Expected ErrSettled, got %v", err)
+	}
+}
+
+func TestRefund(t *testing.T) {
+	mock := setup()
+	id := stygos.Word{0x02}
+	pre := lock(t, mock, id, 700)
+
+	mock.Sender = payer
+	if _, err := router.Dispatch(call(selRefund, id)); err != ErrTimeoutNotReached {
+		t.Errorf("refund failed. Expected ErrTimeoutNotReached, got %v", err)
+	}
+	mock.Time = 2_000
+	sig, _ := schnorr.Adapt(pre, secret)
+	if _, err := router.Dispatch(call(selRelease, id, word(sig[:32]), word(sig[32:]))); err != ErrTimeoutPassed {
+		t.Errorf("release failed. Expected ErrTimeoutPassed, got %v", err)
+	}
+	mock.Sender = other
+	if _, err := router.Dispatch(call(selRefund, id)); err != ErrNotPayer {
+		t.Errorf("refund failed. Expected ErrNotPayer, got %v", err)
+	}
+	mock.Sender = payer
+	if _, err := router.Dispatch(call(selRefund, id)); err != nil {
+		t.Fatalf("refund failed: %v", err)
+	}
+	if got := mock.BalanceOf(payer); got.Uint64() != 700 {
+		t.Errorf("refund failed. Expected the payer to get 700 back, got %v", got)
+	}
+}
+
+func TestLockRejectsBadPreSig(t *testing.T) {
+	mock := setup()
+	id := stygos.Word{0x03}
+	T := schnorr.Mul(schnorr.G(), secret)
+	pub, _ := schnorr.PublicKey(signerKey)
+
+	// Signed for a different amount than the one locked
+	msg := messageOf(id, payee, stygos.NewU256(1))
+	pre, _ := schnorr.AdaptorSign(signerKey, msg[:], make([]byte, 32), T)
+
+	mock.Sender = payer
+	mock.Value = big.NewInt(900)
+	_, err := router.Dispatch(call(selLock, id, stygos.PadAddress(payee), word(pub),
+		word(T.X.Bytes()), word(T.Y.Bytes()), word(pre[:32]), word(pre[32:]), stygos.WordFromUint64(2_000)))
+	if err != ErrInvalidPreSig {
+		t.Errorf("lock failed. Expected ErrInvalidPreSig, got %v", err)
+	}
+
+	mock.Value = big.NewInt(0)
+	_, err = router.Dispatch(call(selLock, id, stygos.PadAddress(payee), word(pub),
+		word(T.X.Bytes()), word(T.Y.Bytes()), word(pre[:32]), word(pre[32:]), stygos.WordFromUint64(2_000)))
+	if err != ErrZeroValue {
+		t.Errorf("lock failed. Expected ErrZeroValue, got %v", err)
+	}
+
+	lock(t, mock, id, 1)
+	mock.Value = big.NewInt(1)
+	_, err = router.Dispatch(call(selLock, id, stygos.PadAddress(payee), word(pub),
+		word(T.X.Bytes()), word(T.Y.Bytes()), word(pre[:32]), word(pre[32:]), stygos.WordFromUint64(2_000)))
+	if err != ErrEscrowExists {
+		t.Errorf("lock failed. Expected ErrEscrowExists, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Command forwarder is a minimal ERC-2771 trusted forwarder. Relayers
+// submit requests signed by users with eth_signTypedData; the forwarder
+// checks the signature and nonce and calls the target with the signer's
+// address appended, which recipients read through metatx.Context.
+package main
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/bytesutil"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/metatx"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go noncesKey=nonces
+
+// ABI selectors
+var (
+	selGetNonce = stygos.Selector{0x2d, 0x03, 0x35, 0xab} // getNonce(address)
+	selVerify   = stygos.Selector{0x50, 0x7d, 0x8a, 0x27} // verify(address,address,uint256,uint256,uint256,bytes,bytes)
+	selExecute  = stygos.Selector{0x29, 0xa7, 0xb6, 0x9b} // execute(address,address,uint256,uint256,uint256,bytes,bytes)
+)
+
+var (
+	forwarder = metatx.NewForwarder(noncesKey, "MinimalForwarder", "0.0.1")
+	router    = newRouter()
+)
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selGetNonce, handleGetNonce)
+	r.HandleSelector(selVerify, handleVerify)
+	r.HandleSelector(selExecute, handleExecute)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleGetNonce returns the next nonce of a signer.
+func handleGetNonce(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	var w stygos.Word
+	copy(w[:], args)
+	nonce := forwarder.Nonce(stygos.AddressFromWord(w)).Word()
+	return nonce[:], nil
+}
+
+// handleVerify returns whether a request is signed and has the current
+// nonce.
+func handleVerify(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	req, sig, err := decodeRequest(args)
+	if err != nil {
+		return nil, err
+	}
+	return stygos.NewReturnBuilder(32).AppendBool(forwarder.Verify(req, sig)).Bytes(), nil
+}
+
+// handleExecute relays a request and returns (bool success, bytes
+// returndata). A reverted target call reverts the forwarder.
+func handleExecute(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	req, sig, err := decodeRequest(args)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := forwarder.Execute(req, sig)
+	if err != nil {
+		return nil, err
+	}
+	success, offset, length := stygos.WordFromUint64(1), stygos.WordFromUint64(64), stygos.WordFromUint64(uint64(len(ret)))
+	return bytesutil.Concat(success[:], offset[:], length[:], bytesutil.PadRight32(ret)), nil
+}
+
+// decodeRequest decodes (from, to, value, gas, nonce, data, signature).
+func decodeRequest(args []byte) (*metatx.ForwardRequest, ecdsa.Signature, error) {
+	if len(args) < 7*32 {
+		return nil, ecdsa.Signature{}, stygos.ErrInvalidInput
+	}
+	w := make([]stygos.Word, 5)
+	for i := range w {
+		copy(w[i][:], args[32*i:])
+	}
+	gas := stygos.U256FromWord(w[3])
+	if !gas.IsUint64() {
+		return nil, ecdsa.Signature{}, stygos.ErrInvalidInput
+	}
+	data, err := stygos.ArgBytes(args, 5)
+	if err != nil {
+		return nil, ecdsa.Signature{}, err
+	}
+	rawSig, err := stygos.ArgBytes(args, 6)
+	if err != nil {
+		return nil, ecdsa.Signature{}, err
+	}
+	sig, err := ecdsa.SignatureFromBytes(rawSig)
+	if err != nil {
+		return nil, ecdsa.Signature{}, err
+	}
+	req := &metatx.ForwardRequest{
+		From:  stygos.AddressFromWord(w[0]),
+		To:    stygos.AddressFromWord(w[1]),
+		Value: stygos.U256FromWord(w[2]),
+		Gas:   gas.Uint64(),
+		Nonce: stygos.U256FromWord(w[4]),
+		Data:  data,
+	}
+	return req, sig, nil
+}
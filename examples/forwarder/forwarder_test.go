@@ -0,0 +1,119 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/metatx"
+)
+
+var (
+	contract  = stygos.Address{0xf0}
+	recipient = stygos.Address{0x7e}
+	relayer   = stygos.Address{0x12}
+	userKey   = big.NewInt(0xb0b)
+)
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selGetNonce, "getNonce(address)"},
+		{selVerify, "verify(address,address,uint256,uint256,uint256,bytes,bytes)"},
+		{selExecute, "execute(address,address,uint256,uint256,uint256,bytes,bytes)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+// encodeBytes ABI-encodes a bytes tail: length then padded data.
+func encodeBytes(b []byte) []byte {
+	n := stygos.WordFromUint64(uint64(len(b)))
+	out := append(n[:], b...)
+	return append(out, make([]byte, (32-len(b)%32)%32)...)
+}
+
+func encodeRequest(sel stygos.Selector, req *metatx.ForwardRequest, sig []byte) []byte {
+	data, rawSig := encodeBytes(req.Data), encodeBytes(sig)
+	head := []stygos.Word{
+		stygos.PadAddress(req.From),
+		stygos.PadAddress(req.To),
+		req.Value.Word(),
+		stygos.WordFromUint64(req.Gas),
+		req.Nonce.Word(),
+		stygos.WordFromUint64(7 * 32),
+		stygos.WordFromUint64(uint64(7*32 + len(data))),
+	}
+	out := append([]byte{}, sel[:]...)
+	for _, w := range head {
+		out = append(out, w[:]...)
+	}
+	out = append(out, data...)
+	return append(out, rawSig...)
+}
+
+func TestForwarder(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	stygos.UseRuntime(mock)
+	ecdsa.InstallMockEcrecover(mock)
+
+	var seen stygos.Address
+	ctx := metatx.NewContext(contract)
+	mock.Deploy(recipient, func(input []byte) ([]byte, error) {
+		seen = ctx.MsgSender()
+		return []byte("done"), nil
+	})
+
+	user := ecdsa.AddressOf(userKey)
+	req := &metatx.ForwardRequest{From: user, To: recipient, Gas: 100_000, Data: []byte{0xca, 0xfe}}
+	sig, _ := ecdsa.Sign(userKey, forwarder.Digest(req))
+
+	ret, err := router.Dispatch(encodeRequest(selVerify, req, sig.Bytes()))
+	if err != nil || ret[31] != 1 {
+		t.Fatalf("verify failed. Expected true, got %x, %v", ret, err)
+	}
+
+	mock.Sender = relayer
+	ret, err = router.Dispatch(encodeRequest(selExecute, req, sig.Bytes()))
+	if err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if seen != user {
+		t.Errorf("execute failed. Expected the recipient to see %x, got %x", user, seen)
+	}
+	if ret[31] != 1 || ret[95] != 4 || string(ret[96:100]) != "done" || len(ret) != 128 {
+		t.Errorf("execute failed. Expected (true, \"done\"), got %x", ret)
+	}
+
+	addr := stygos.PadAddress(user)
+	nonce, _ := router.Dispatch(append(selGetNonce[:], addr[:]...))
+	if stygos.Uint64FromWord(wordOf(nonce)) != 1 {
+		t.Errorf("getNonce failed. Expected 1, got %x", nonce)
+	}
+	if _, err := router.Dispatch(encodeRequest(selExecute, req, sig.Bytes())); err != metatx.ErrInvalidNonce {
+		t.Errorf("execute failed. Expected ErrInvalidNonce, got %v", err)
+	}
+	if _, err := router.Dispatch(encodeRequest(selExecute, req, sig.Bytes()[:64])); err != ecdsa.ErrInvalidSignatureLength {
+		t.Errorf("execute failed. Expected ErrInvalidSignatureLength, got %v", err)
+	}
+	bad := encodeRequest(selExecute, req, sig.Bytes())
+	bad[4+6*32+31] = 0xff
+	if _, err := router.Dispatch(bad); err != stygos.ErrInvalidInput {
+		t.Errorf("execute failed. Expected ErrInvalidInput for a bad offset, got %v", err)
+	}
+}
+
+func wordOf(b []byte) stygos.Word {
+	var w stygos.Word
+	copy(w[:], b)
+	return w
+}
@@ -0,0 +1,650 @@
+// Command lending is an isolated lending market: lenders supply a loan
+// token and earn interest, and borrowers borrow it against a collateral
+// token priced by a Chainlink-style feed.
+//
+// Interest accrues continuously, e^(rate·t), at a rate that follows the
+// utilization of the supplied tokens with a kink: it rises slowly up to 80%
+// utilization and steeply above, to pull utilization back down. Supply and
+// debt are kept as shares of growing totals, so accrual is O(1) per
+// transaction regardless of the number of positions. A borrower may borrow
+// up to 80% of the value of their collateral; beyond that anyone can repay
+// up to half the debt and seize collateral worth the repayment plus a 5%
+// bonus. Bad debt left by a collateral crash is not socialized.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/math/fixed"
+	"github.com/rafaelescrich/stygos/oracle"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go marketKey=market positionsKey=positions
+
+// ABI selectors
+var (
+	selInitialize         = stygos.Selector{0xc0, 0xc5, 0x3b, 0x8b} // initialize(address,address,address)
+	selSupply             = stygos.Selector{0x35, 0x40, 0x30, 0x23} // supply(uint256)
+	selWithdraw           = stygos.Selector{0x2e, 0x1a, 0x7d, 0x4d} // withdraw(uint256)
+	selSupplyCollateral   = stygos.Selector{0x36, 0x7f, 0xeb, 0xea} // supplyCollateral(uint256)
+	selWithdrawCollateral = stygos.Selector{0x61, 0x12, 0xfe, 0x2e} // withdrawCollateral(uint256)
+	selBorrow             = stygos.Selector{0xc5, 0xeb, 0xea, 0xec} // borrow(uint256)
+	selRepay              = stygos.Selector{0x37, 0x1f, 0xd8, 0xe6} // repay(uint256)
+	selLiquidate          = stygos.Selector{0xbc, 0xba, 0xf4, 0x87} // liquidate(address,uint256)
+	selSupplyBalanceOf    = stygos.Selector{0x93, 0x88, 0x9f, 0x06} // supplyBalanceOf(address)
+	selDebtOf             = stygos.Selector{0xd2, 0x83, 0xe7, 0x5f} // debtOf(address)
+	selCollateralOf       = stygos.Selector{0x1a, 0xef, 0xb1, 0x07} // collateralOf(address)
+	selUtilization        = stygos.Selector{0xea, 0x21, 0xcd, 0x92} // utilization()
+	selBorrowRate         = stygos.Selector{0xc9, 0x14, 0xb4, 0x37} // borrowRate()
+	selSupplyRate         = stygos.Selector{0xad, 0x29, 0x61, 0xa3} // supplyRate()
+	selHealthFactor       = stygos.Selector{0x6a, 0xd9, 0xf9, 0xdf} // healthFactor(address)
+	selTotalSupplyAssets  = stygos.Selector{0x94, 0x87, 0x7f, 0xc4} // totalSupplyAssets()
+	selTotalBorrowAssets  = stygos.Selector{0x1a, 0xad, 0x44, 0xc2} // totalBorrowAssets()
+)
+
+// Lending errors
+var (
+	ErrInitialized       = errors.New("lending: already initialized")
+	ErrNotInitialized    = errors.New("lending: not initialized")
+	ErrZeroAmount        = errors.New("lending: zero amount")
+	ErrInsufficientFunds = errors.New("lending: amount exceeds balance")
+	ErrNoLiquidity       = errors.New("lending: not enough liquidity")
+	ErrUnhealthy         = errors.New("lending: position would be undercollateralized")
+	ErrHealthy           = errors.New("lending: position is healthy")
+	ErrRepayTooLarge     = errors.New("lending: repayment exceeds the close factor")
+)
+
+// Market parameters, as WADs
+var (
+	baseRate    = wad(2, 100)  // borrow rate at 0% utilization, per year
+	slope1      = wad(8, 100)  // added up to the kink
+	slope2      = wad(1, 1)    // added from the kink to 100% utilization
+	kink        = wad(80, 100) // optimal utilization
+	lltv        = wad(80, 100) // liquidation loan-to-value
+	bonus       = wad(5, 100)  // liquidation incentive
+	closeFactor = wad(50, 100) // share of a debt one liquidation may repay
+)
+
+const (
+	secondsPerYear = 365 * 24 * 3600
+	// maxPriceAge is the oldest feed answer the market accepts, in seconds.
+	maxPriceAge = 3600
+)
+
+// Virtual shares and assets offset the share price, as in Morpho Blue, so
+// the first depositor cannot inflate it to steal later deposits by
+// rounding.
+var (
+	virtualShares = stygos.NewU256(1e6)
+	virtualAssets = stygos.NewU256(1)
+)
+
+// Market is the market state, packed into storage by market_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Market -o market_pack_gen.go
+type Market struct {
+	LoanToken       stygos.Address
+	LastUpdate      uint64 // unix seconds
+	CollateralToken stygos.Address
+	FeedDecimals    uint8
+	Feed            stygos.Address
+
+	TotalSupplyAssets stygos.U256
+	TotalSupplyShares stygos.U256
+	TotalBorrowAssets stygos.U256
+	TotalBorrowShares stygos.U256
+}
+
+// Position slots relative to MapKey(positionsKey, account)
+const (
+	supplySharesOffset = iota
+	borrowSharesOffset
+	collateralOffset
+)
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selInitialize, handleInitialize)
+	r.HandleSelector(selSupply, handleSupply)
+	r.HandleSelector(selWithdraw, handleWithdraw)
+	r.HandleSelector(selSupplyCollateral, handleSupplyCollateral)
+	r.HandleSelector(selWithdrawCollateral, handleWithdrawCollateral)
+	r.HandleSelector(selBorrow, handleBorrow)
+	r.HandleSelector(selRepay, handleRepay)
+	r.HandleSelector(selLiquidate, handleLiquidate)
+	r.HandleSelector(selSupplyBalanceOf, handleSupplyBalanceOf)
+	r.HandleSelector(selDebtOf, handleDebtOf)
+	r.HandleSelector(selCollateralOf, handleCollateralOf)
+	r.HandleSelector(selUtilization, func(*stygos.Ctx, []byte) ([]byte, error) {
+		m := accrued()
+		return word(utilization(&m).Word()), nil
+	})
+	r.HandleSelector(selBorrowRate, func(*stygos.Ctx, []byte) ([]byte, error) {
+		m := accrued()
+		return word(borrowRate(&m).Word()), nil
+	})
+	r.HandleSelector(selSupplyRate, func(*stygos.Ctx, []byte) ([]byte, error) {
+		m := accrued()
+		rate, err := fixed.MulWadDown(borrowRate(&m), utilization(&m))
+		return word(rate.Word()), err
+	})
+	r.HandleSelector(selHealthFactor, handleHealthFactor)
+	r.HandleSelector(selTotalSupplyAssets, func(*stygos.Ctx, []byte) ([]byte, error) {
+		m := accrued()
+		return word(m.TotalSupplyAssets.Word()), nil
+	})
+	r.HandleSelector(selTotalBorrowAssets, func(*stygos.Ctx, []byte) ([]byte, error) {
+		m := accrued()
+		return word(m.TotalBorrowAssets.Word()), nil
+	})
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleInitialize sets the loan token, the collateral token and the feed
+// pricing the collateral in loan tokens, once.
+func handleInitialize(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	var m Market
+	m.Load(marketKey)
+	if m.LoanToken != (stygos.Address{}) {
+		return nil, ErrInitialized
+	}
+	feed := oracle.NewFeed(stygos.AddressFromWord(w[2]))
+	decimals, err := feed.Decimals()
+	if err != nil {
+		return nil, err
+	}
+	m.LoanToken = stygos.AddressFromWord(w[0])
+	m.CollateralToken = stygos.AddressFromWord(w[1])
+	m.Feed = feed.Address()
+	m.FeedDecimals = decimals
+	m.LastUpdate = stygos.GetBlockTimestamp()
+	m.Store(marketKey)
+	return nil, nil
+}
+
+// handleSupply lends assets from the caller and returns the shares minted.
+func handleSupply(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	assets, err := amountArg(args)
+	if err != nil {
+		return nil, err
+	}
+	m, err := accrue()
+	if err != nil {
+		return nil, err
+	}
+	sender := stygos.GetMsgSender()
+	shares, err := toSharesDown(assets, m.TotalSupplyAssets, m.TotalSupplyShares)
+	if err != nil {
+		return nil, err
+	}
+	m.TotalSupplyAssets = m.TotalSupplyAssets.Add(assets)
+	m.TotalSupplyShares = m.TotalSupplyShares.Add(shares)
+	m.Store(marketKey)
+	addPosition(sender, supplySharesOffset, shares)
+	return word(shares.Word()), token.SafeTransferFrom(token.NewERC20(m.LoanToken), sender, stygos.GetContractAddress(), assets)
+}
+
+// handleWithdraw returns supplied assets and interest to the caller.
+func handleWithdraw(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	assets, err := amountArg(args)
+	if err != nil {
+		return nil, err
+	}
+	m, err := accrue()
+	if err != nil {
+		return nil, err
+	}
+	sender := stygos.GetMsgSender()
+	shares, err := toSharesUp(assets, m.TotalSupplyAssets, m.TotalSupplyShares)
+	if err != nil {
+		return nil, err
+	}
+	if err := subPosition(sender, supplySharesOffset, shares); err != nil {
+		return nil, err
+	}
+	m.TotalSupplyAssets = m.TotalSupplyAssets.Sub(assets)
+	m.TotalSupplyShares = m.TotalSupplyShares.Sub(shares)
+	if m.TotalSupplyAssets.Lt(m.TotalBorrowAssets) {
+		return nil, ErrNoLiquidity
+	}
+	m.Store(marketKey)
+	return nil, token.SafeTransfer(token.NewERC20(m.LoanToken), sender, assets)
+}
+
+// handleSupplyCollateral deposits collateral from the caller.
+func handleSupplyCollateral(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	amount, err := amountArg(args)
+	if err != nil {
+		return nil, err
+	}
+	m, err := load()
+	if err != nil {
+		return nil, err
+	}
+	sender := stygos.GetMsgSender()
+	addPosition(sender, collateralOffset, amount)
+	return nil, token.SafeTransferFrom(token.NewERC20(m.CollateralToken), sender, stygos.GetContractAddress(), amount)
+}
+
+// handleWithdrawCollateral returns collateral to the caller if their debt
+// stays covered.
+func handleWithdrawCollateral(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	amount, err := amountArg(args)
+	if err != nil {
+		return nil, err
+	}
+	m, err := accrue()
+	if err != nil {
+		return nil, err
+	}
+	m.Store(marketKey)
+	sender := stygos.GetMsgSender()
+	if err := subPosition(sender, collateralOffset, amount); err != nil {
+		return nil, err
+	}
+	if err := checkHealthy(&m, sender); err != nil {
+		return nil, err
+	}
+	return nil, token.SafeTransfer(token.NewERC20(m.CollateralToken), sender, amount)
+}
+
+// handleBorrow lends assets to the caller against their collateral and
+// returns the debt shares minted.
+func handleBorrow(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	assets, err := amountArg(args)
+	if err != nil {
+		return nil, err
+	}
+	m, err := accrue()
+	if err != nil {
+		return nil, err
+	}
+	sender := stygos.GetMsgSender()
+	shares, err := toSharesUp(assets, m.TotalBorrowAssets, m.TotalBorrowShares)
+	if err != nil {
+		return nil, err
+	}
+	m.TotalBorrowAssets = m.TotalBorrowAssets.Add(assets)
+	m.TotalBorrowShares = m.TotalBorrowShares.Add(shares)
+	if m.TotalSupplyAssets.Lt(m.TotalBorrowAssets) {
+		return nil, ErrNoLiquidity
+	}
+	m.Store(marketKey)
+	addPosition(sender, borrowSharesOffset, shares)
+	if err := checkHealthy(&m, sender); err != nil {
+		return nil, err
+	}
+	return word(shares.Word()), token.SafeTransfer(token.NewERC20(m.LoanToken), sender, assets)
+}
+
+// handleRepay repays the caller's debt, all of it when assets exceeds it,
+// and returns the amount repaid.
+func handleRepay(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	assets, err := amountArg(args)
+	if err != nil {
+		return nil, err
+	}
+	m, err := accrue()
+	if err != nil {
+		return nil, err
+	}
+	sender := stygos.GetMsgSender()
+	repaid, err := repay(&m, sender, assets)
+	if err != nil {
+		return nil, err
+	}
+	m.Store(marketKey)
+	return word(repaid.Word()), token.SafeTransferFrom(token.NewERC20(m.LoanToken), sender, stygos.GetContractAddress(), repaid)
+}
+
+// handleLiquidate repays part of the debt of an undercollateralized
+// borrower for the caller and pays them in the borrower's collateral, with
+// the liquidation bonus. It returns the collateral seized.
+func handleLiquidate(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	borrower, assets := stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1])
+	if assets.IsZero() {
+		return nil, ErrZeroAmount
+	}
+	m, err := accrue()
+	if err != nil {
+		return nil, err
+	}
+	debt, maxDebt, price, err := position(&m, borrower)
+	if err != nil {
+		return nil, err
+	}
+	if !maxDebt.Lt(debt) {
+		return nil, ErrHealthy
+	}
+	limit, err := fixed.MulWadUp(debt, closeFactor)
+	if err != nil {
+		return nil, err
+	}
+	if limit.Lt(assets) {
+		return nil, ErrRepayTooLarge
+	}
+	repaid, err := repay(&m, borrower, assets)
+	if err != nil {
+		return nil, err
+	}
+	m.Store(marketKey)
+
+	// seized = repaid * (1 + bonus) / price, rounded down
+	value, err := fixed.MulWadDown(repaid, fixed.WAD.Add(bonus))
+	if err != nil {
+		return nil, err
+	}
+	seized, err := fixed.MulDivDown(value, pow10(m.FeedDecimals), price)
+	if err != nil {
+		return nil, err
+	}
+	if collateral := loadPosition(borrower, collateralOffset); collateral.Lt(seized) {
+		seized = collateral
+	}
+	if err := subPosition(borrower, collateralOffset, seized); err != nil {
+		return nil, err
+	}
+
+	liquidator := stygos.GetMsgSender()
+	if err := token.SafeTransferFrom(token.NewERC20(m.LoanToken), liquidator, stygos.GetContractAddress(), repaid); err != nil {
+		return nil, err
+	}
+	return word(seized.Word()), token.SafeTransfer(token.NewERC20(m.CollateralToken), liquidator, seized)
+}
+
+// handleSupplyBalanceOf returns the assets a lender can withdraw, interest
+// included.
+func handleSupplyBalanceOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	m := accrued()
+	shares := loadPosition(stygos.AddressFromWord(w[0]), supplySharesOffset)
+	assets, err := toAssetsDown(shares, m.TotalSupplyAssets, m.TotalSupplyShares)
+	return word(assets.Word()), err
+}
+
+// handleDebtOf returns the debt of a borrower, interest included.
+func handleDebtOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	m := accrued()
+	shares := loadPosition(stygos.AddressFromWord(w[0]), borrowSharesOffset)
+	assets, err := toAssetsUp(shares, m.TotalBorrowAssets, m.TotalBorrowShares)
+	return word(assets.Word()), err
+}
+
+// handleCollateralOf returns the collateral of a borrower.
+func handleCollateralOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return word(loadPosition(stygos.AddressFromWord(w[0]), collateralOffset).Word()), nil
+}
+
+// handleHealthFactor returns the largest debt the collateral of a borrower
+// allows divided by their debt, as a WAD: below 1 the borrower can be
+// liquidated. It is 2^256-1 without debt.
+func handleHealthFactor(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	m := accrued()
+	debt, maxDebt, _, err := position(&m, stygos.AddressFromWord(w[0]))
+	if err != nil {
+		return nil, err
+	}
+	if debt.IsZero() {
+		return word(stygos.U256{}.Not().Word()), nil
+	}
+	hf, err := fixed.DivWadDown(maxDebt, debt)
+	return word(hf.Word()), err
+}
+
+// --- Interest ---
+
+// borrowRate returns the yearly borrow rate as a WAD.
+func borrowRate(m *Market) stygos.U256 {
+	u := utilization(m)
+	if !kink.Lt(u) {
+		r, _ := fixed.MulDivDown(slope1, u, kink)
+		return baseRate.Add(r)
+	}
+	r, _ := fixed.MulDivDown(slope2, u.Sub(kink), fixed.WAD.Sub(kink))
+	return baseRate.Add(slope1).Add(r)
+}
+
+// utilization returns the share of the supplied assets borrowed as a WAD.
+func utilization(m *Market) stygos.U256 {
+	if m.TotalSupplyAssets.IsZero() {
+		return stygos.U256{}
+	}
+	u, _ := fixed.DivWadDown(m.TotalBorrowAssets, m.TotalSupplyAssets)
+	return u
+}
+
+// accrueInterest adds the interest since the last update to the debt and
+// to the supply.
+func accrueInterest(m *Market) error {
+	now := stygos.GetBlockTimestamp()
+	if now <= m.LastUpdate {
+		return nil
+	}
+	elapsed := now - m.LastUpdate
+	m.LastUpdate = now
+	if m.TotalBorrowAssets.IsZero() {
+		return nil
+	}
+	exponent := borrowRate(m).Mul(stygos.NewU256(elapsed)).Div(stygos.NewU256(secondsPerYear))
+	growth, err := fixed.ExpWad(exponent, false)
+	if err != nil {
+		return err
+	}
+	interest, err := fixed.MulWadDown(m.TotalBorrowAssets, growth.Sub(fixed.WAD))
+	if err != nil {
+		return err
+	}
+	m.TotalBorrowAssets = m.TotalBorrowAssets.Add(interest)
+	m.TotalSupplyAssets = m.TotalSupplyAssets.Add(interest)
+	return nil
+}
+
+// load returns the market state.
+func load() (Market, error) {
+	var m Market
+	m.Load(marketKey)
+	if m.LoanToken == (stygos.Address{}) {
+		return m, ErrNotInitialized
+	}
+	return m, nil
+}
+
+// accrue returns the market state with interest accrued, for the caller to
+// store.
+func accrue() (Market, error) {
+	m, err := load()
+	if err != nil {
+		return m, err
+	}
+	return m, accrueInterest(&m)
+}
+
+// accrued returns the market state with interest accrued for views. It
+// returns the stored state if accrual fails.
+func accrued() Market {
+	m, _ := load()
+	if accrueInterest(&m) != nil {
+		m.Load(marketKey)
+	}
+	return m
+}
+
+// --- Positions ---
+
+// position returns the debt of a borrower, the largest debt their
+// collateral allows, and the collateral price.
+func position(m *Market, borrower stygos.Address) (debt, maxDebt, price stygos.U256, err error) {
+	debt, err = toAssetsUp(loadPosition(borrower, borrowSharesOffset), m.TotalBorrowAssets, m.TotalBorrowShares)
+	if err != nil {
+		return
+	}
+	price, err = oracle.NewFeed(m.Feed).LatestPrice(maxPriceAge)
+	if err != nil {
+		return
+	}
+	value, err := fixed.MulDivDown(loadPosition(borrower, collateralOffset), price, pow10(m.FeedDecimals))
+	if err != nil {
+		return
+	}
+	maxDebt, err = fixed.MulWadDown(value, lltv)
+	return
+}
+
+// checkHealthy fails if the debt of borrower exceeds what their collateral
+// allows.
+func checkHealthy(m *Market, borrower stygos.Address) error {
+	if loadPosition(borrower, borrowSharesOffset).IsZero() {
+		return nil
+	}
+	debt, maxDebt, _, err := position(m, borrower)
+	if err != nil {
+		return err
+	}
+	if maxDebt.Lt(debt) {
+		return ErrUnhealthy
+	}
+	return nil
+}
+
+// repay burns the debt shares of borrower worth assets, or all of them if
+// assets exceeds the debt, and returns the assets repaid.
+func repay(m *Market, borrower stygos.Address, assets stygos.U256) (stygos.U256, error) {
+	owned := loadPosition(borrower, borrowSharesOffset)
+	debt, err := toAssetsUp(owned, m.TotalBorrowAssets, m.TotalBorrowShares)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	shares := owned
+	if assets.Lt(debt) {
+		if shares, err = toSharesDown(assets, m.TotalBorrowAssets, m.TotalBorrowShares); err != nil {
+			return stygos.U256{}, err
+		}
+	} else {
+		assets = debt
+	}
+	if err := subPosition(borrower, borrowSharesOffset, shares); err != nil {
+		return stygos.U256{}, err
+	}
+	m.TotalBorrowShares = m.TotalBorrowShares.Sub(shares)
+	// Rounding may leave the total a unit below the sum of the debts
+	if m.TotalBorrowAssets.Lt(assets) {
+		m.TotalBorrowAssets = stygos.U256{}
+	} else {
+		m.TotalBorrowAssets = m.TotalBorrowAssets.Sub(assets)
+	}
+	return assets, nil
+}
+
+func positionSlot(account stygos.Address, offset uint64) stygos.Word {
+	return storage.Offset(storage.MapKey(positionsKey, account[:]), offset)
+}
+
+func loadPosition(account stygos.Address, offset uint64) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(positionSlot(account, offset)))
+}
+
+func addPosition(account stygos.Address, offset uint64, amount stygos.U256) {
+	stygos.StorageStore(positionSlot(account, offset), loadPosition(account, offset).Add(amount).Word())
+}
+
+func subPosition(account stygos.Address, offset uint64, amount stygos.U256) error {
+	v := loadPosition(account, offset)
+	if v.Lt(amount) {
+		return ErrInsufficientFunds
+	}
+	stygos.StorageStore(positionSlot(account, offset), v.Sub(amount).Word())
+	return nil
+}
+
+// --- Shares ---
+
+func toSharesDown(assets, totalAssets, totalShares stygos.U256) (stygos.U256, error) {
+	return fixed.MulDivDown(assets, totalShares.Add(virtualShares), totalAssets.Add(virtualAssets))
+}
+
+func toSharesUp(assets, totalAssets, totalShares stygos.U256) (stygos.U256, error) {
+	return fixed.MulDivUp(assets, totalShares.Add(virtualShares), totalAssets.Add(virtualAssets))
+}
+
+func toAssetsDown(shares, totalAssets, totalShares stygos.U256) (stygos.U256, error) {
+	return fixed.MulDivDown(shares, totalAssets.Add(virtualAssets), totalShares.Add(virtualShares))
+}
+
+func toAssetsUp(shares, totalAssets, totalShares stygos.U256) (stygos.U256, error) {
+	return fixed.MulDivUp(shares, totalAssets.Add(virtualAssets), totalShares.Add(virtualShares))
+}
+
+// --- ABI ---
+
+// amountArg decodes a single non-zero uint256.
+func amountArg(args []byte) (stygos.U256, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	amount := stygos.U256FromWord(w[0])
+	if amount.IsZero() {
+		return amount, ErrZeroAmount
+	}
+	return amount, nil
+}
+
+func word(w stygos.Word) []byte {
+	return w[:]
+}
+
+// wad returns n/d as a WAD.
+func wad(n, d uint64) stygos.U256 {
+	return stygos.NewU256(n).Mul(fixed.WAD).Div(stygos.NewU256(d))
+}
+
+// pow10 returns 10^n.
+func pow10(n uint8) stygos.U256 {
+	p := stygos.NewU256(1)
+	for i := uint8(0); i < n; i++ {
+		p = p.Mul(stygos.NewU256(10))
+	}
+	return p
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/oracle"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	pool       = stygos.Address{0x90}
+	loan       = stygos.Address{0x0a}
+	collateral = stygos.Address{0x0c}
+	feedAddr   = stygos.Address{0xfe}
+
+	lender     = stygos.Address{0x1e}
+	borrower   = stygos.Address{0xb0}
+	liquidator = stygos.Address{0x11}
+)
+
+type env struct {
+	mock       *stygos.MockRuntime
+	loan, coll *token.MockERC20
+	feed       *oracle.MockFeed
+}
+
+// units returns n whole tokens of 18 decimals.
+func units(n uint64) stygos.U256 {
+	return stygos.NewU256(n).Mul(stygos.NewU256(1e18))
+}
+
+// setup deploys an initialized market pricing the collateral at 2 loan
+// tokens, and funds the accounts.
+func setup(t *testing.T) *env {
+	mock := stygos.NewMockRuntime()
+	mock.Time = 1_000_000
+	stygos.UseRuntime(mock)
+	mock.Deploy(pool, stygos.MockEntrypoint(entrypoint))
+	e := &env{
+		mock: mock,
+		loan: token.InstallMockERC20(mock, loan),
+		coll: token.InstallMockERC20(mock, collateral),
+		feed: oracle.InstallMockFeed(mock, feedAddr, 8, "COL / LOAN"),
+	}
+	e.feed.SetPrice(2e8, mock.Time)
+	max := stygos.U256{}.Not()
+	for _, acc := range []stygos.Address{lender, borrower, liquidator} {
+		e.loan.Mint(acc, units(10000))
+		e.coll.Mint(acc, units(10000))
+		e.loan.Approve(acc, pool, max)
+		e.coll.Approve(acc, pool, max)
+	}
+	if _, err := e.send(lender, selInitialize, stygos.PadAddress(loan), stygos.PadAddress(collateral), stygos.PadAddress(feedAddr)); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return e
+}
+
+// send calls the market from an account.
+func (e *env) send(from stygos.Address, sel stygos.Selector, args ...stygos.Word) (stygos.U256, error) {
+	e.mock.Contract = from
+	data := sel[:]
+	for _, w := range args {
+		data = append(data, w[:]...)
+	}
+	ret, err := stygos.Call(pool, stygos.Word{}, data)
+	var w stygos.Word
+	copy(w[:], ret)
+	return stygos.U256FromWord(w), err
+}
+
+func (e *env) view(sel stygos.Selector, args ...stygos.Word) stygos.U256 {
+	v, err := e.send(stygos.Address{}, sel, args...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// near reports whether got is within tolerance/1e6 of want.
+func near(got, want stygos.U256, tolerance uint64) bool {
+	diff := got.Sub(want)
+	if got.Lt(want) {
+		diff = want.Sub(got)
+	}
+	return !want.Mul(stygos.NewU256(tolerance)).Div(stygos.NewU256(1e6)).Lt(diff)
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selInitialize, "initialize(address,address,address)"},
+		{selSupply, "supply(uint256)"},
+		{selWithdraw, "withdraw(uint256)"},
+		{selSupplyCollateral, "supplyCollateral(uint256)"},
+		{selWithdrawCollateral, "withdrawCollateral(uint256)"},
+		{selBorrow, "borrow(uint256)"},
+		{selRepay, "repay(uint256)"},
+		{selLiquidate, "liquidate(address,uint256)"},
+		{selSupplyBalanceOf, "supplyBalanceOf(address)"},
+		{selDebtOf, "debtOf(address)"},
+		{selCollateralOf, "collateralOf(address)"},
+		{selUtilization, "utilization()"},
+		{selBorrowRate, "borrowRate()"},
+		{selSupplyRate, "supplyRate()"},
+		{selHealthFactor, "healthFactor(address)"},
+		{selTotalSupplyAssets, "totalSupplyAssets()"},
+		{selTotalBorrowAssets, "totalBorrowAssets()"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestRates(t *testing.T) {
+	for _, tt := range []struct {
+		borrowed, rate uint64 // per cent
+	}{
+		{0, 2}, {40, 6}, {80, 10}, {90, 60}, {100, 110},
+	} {
+		m := Market{TotalSupplyAssets: units(100), TotalBorrowAssets: units(tt.borrowed)}
+		if got := borrowRate(&m); got != wad(tt.rate, 100) {
+			t.Errorf("borrowRate at %d%% failed. Expected %d%%, got %v", tt.borrowed, tt.rate, got.Big())
+		}
+	}
+}
+
+func TestBorrowAndAccrue(t *testing.T) {
+	e := setup(t)
+	if _, err := e.send(lender, selSupply, units(1000).Word()); err != nil {
+		t.Fatalf("supply failed: %v", err)
+	}
+	if _, err := e.send(borrower, selBorrow, units(1).Word()); err == nil {
+		t.Errorf("borrow failed. Expected a revert without collateral")
+	}
+	if _, err := e.send(borrower, selSupplyCollateral, units(500).Word()); err != nil {
+		t.Fatalf("supplyCollateral failed: %v", err)
+	}
+	// 500 collateral at 2 is worth 1000, which allows 800
+	if _, err := e.send(borrower, selBorrow, units(801).Word()); err == nil {
+		t.Errorf("borrow failed. Expected a revert above 80%% of the collateral")
+	}
+	if _, err := e.send(borrower, selBorrow, units(800).Word()); err != nil {
+		t.Fatalf("borrow failed: %v", err)
+	}
+	if got := e.view(selUtilization); got != wad(80, 100) {
+		t.Errorf("utilization failed. Expected 80%%, got %v", got.Big())
+	}
+	if got := e.view(selSupplyRate); got != wad(8, 100) {
+		t.Errorf("supplyRate failed. Expected 8%%, got %v", got.Big())
+	}
+	if _, err := e.send(lender, selWithdraw, units(201).Word()); err == nil {
+		t.Errorf("withdraw failed. Expected a revert above the liquidity")
+	}
+	if _, err := e.send(borrower, selWithdrawCollateral, units(1).Word()); err == nil {
+		t.Errorf("withdrawCollateral failed. Expected a revert leaving the debt uncovered")
+	}
+
+	// A year at 10%: the debt grows by e^0.1
+	e.mock.Time += secondsPerYear
+	e.feed.SetPrice(2e8, e.mock.Time)
+	debt := e.view(selDebtOf, stygos.PadAddress(borrower))
+	interest := stygos.NewU256(84136732).Mul(stygos.NewU256(1e12)) // 800 * (e^0.1 - 1)
+	if !near(debt, units(800).Add(interest), 10) {
+		t.Errorf("debtOf failed. Expected about 884.14, got %v", debt.Big())
+	}
+	if got := e.view(selSupplyBalanceOf, stygos.PadAddress(lender)); !near(got, units(1000).Add(interest), 10) {
+		t.Errorf("supplyBalanceOf failed. Expected about 1084.14, got %v", got.Big())
+	}
+	if hf := e.view(selHealthFactor, stygos.PadAddress(borrower)); !hf.Lt(wad(1, 1)) {
+		t.Errorf("healthFactor failed. Expected the position to be liquidatable after a year, got %v", hf.Big())
+	}
+
+	// Repaying more than the debt repays it all
+	before := e.loan.Balances[borrower]
+	repaid, err := e.send(borrower, selRepay, units(1000).Word())
+	if err != nil || !near(repaid, debt, 1) || before.Sub(e.loan.Balances[borrower]) != repaid {
+		t.Fatalf("repay failed. Expected to repay the debt of %v, got %v, %v", debt.Big(), repaid.Big(), err)
+	}
+	if got := e.view(selDebtOf, stygos.PadAddress(borrower)); !got.IsZero() {
+		t.Errorf("repay failed. Expected no debt left, got %v", got.Big())
+	}
+	if _, err := e.send(borrower, selWithdrawCollateral, units(500).Word()); err != nil {
+		t.Errorf("withdrawCollateral failed: %v", err)
+	}
+}
+
+func TestLiquidate(t *testing.T) {
+	e := setup(t)
+	e.send(lender, selSupply, units(1000).Word())
+	e.send(borrower, selSupplyCollateral, units(500).Word())
+	if _, err := e.send(borrower, selBorrow, units(700).Word()); err != nil {
+		t.Fatalf("borrow failed: %v", err)
+	}
+	if _, err := e.send(liquidator, selLiquidate, stygos.PadAddress(borrower), units(100).Word()); err == nil {
+		t.Errorf("liquidate failed. Expected a healthy position to revert")
+	}
+
+	// At 1.6 the collateral allows 640 against 700 of debt
+	e.feed.SetPrice(16e7, e.mock.Time)
+	if _, err := e.send(liquidator, selLiquidate, stygos.PadAddress(borrower), units(351).Word()); err == nil {
+		t.Errorf("liquidate failed. Expected a revert above half the debt")
+	}
+	seized, err := e.send(liquidator, selLiquidate, stygos.PadAddress(borrower), units(200).Word())
+	if err != nil {
+		t.Fatalf("liquidate failed: %v", err)
+	}
+	// 200 * 1.05 / 1.6
+	if want := stygos.NewU256(13125).Mul(stygos.NewU256(1e16)); seized != want {
+		t.Errorf("liquidate failed. Expected to seize 131.25, got %v", seized.Big())
+	}
+	if e.coll.Balances[liquidator] != units(10000).Add(seized) || e.loan.Balances[liquidator] != units(9800) {
+		t.Errorf("liquidate failed. Expected the liquidator to swap 200 for the collateral, got %v and %v", e.coll.Balances[liquidator].Big(), e.loan.Balances[liquidator].Big())
+	}
+	if got := e.view(selDebtOf, stygos.PadAddress(borrower)); !near(got, units(500), 1) {
+		t.Errorf("liquidate failed. Expected 500 of debt left, got %v", got.Big())
+	}
+
+	// A stale price stops liquidations and borrows
+	e.mock.Time += maxPriceAge + 1
+	if _, err := e.send(liquidator, selLiquidate, stygos.PadAddress(borrower), units(1).Word()); err == nil {
+		t.Errorf("liquidate failed. Expected a stale price to revert")
+	}
+}
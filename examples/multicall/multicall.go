@@ -0,0 +1,171 @@
+// Command multicall is a Multicall3-compatible batching contract. Clients
+// read many view functions in one eth_call, and accounts send several
+// calls, with value, in one transaction.
+//
+// It implements aggregate, tryAggregate, aggregate3 and aggregate3Value
+// with the Multicall3 ABI, so the multicall package and existing tooling
+// such as viem and ethers work against it, and the getBlockNumber,
+// getCurrentBlockTimestamp, getChainId and getEthBalance helpers. The
+// functions reading block hashes, the coinbase and the base fee are not
+// provided. As in Multicall3 the batched calls come from this contract, not
+// from the caller: use stygos.MulticallSelf in a contract for calls that
+// must keep msg.sender.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/multicall"
+)
+
+// ABI selectors
+var (
+	selAggregate                = stygos.Selector{0x25, 0x2d, 0xba, 0x42} // aggregate((address,bytes)[])
+	selTryAggregate             = stygos.Selector{0xbc, 0xe3, 0x8b, 0xd7} // tryAggregate(bool,(address,bytes)[])
+	selAggregate3               = stygos.Selector{0x82, 0xad, 0x56, 0xcb} // aggregate3((address,bool,bytes)[])
+	selAggregate3Value          = stygos.Selector{0x17, 0x4d, 0xea, 0x71} // aggregate3Value((address,bool,uint256,bytes)[])
+	selGetBlockNumber           = stygos.Selector{0x42, 0xcb, 0xb1, 0x5c} // getBlockNumber()
+	selGetCurrentBlockTimestamp = stygos.Selector{0x0f, 0x28, 0xc9, 0x7d} // getCurrentBlockTimestamp()
+	selGetChainID               = stygos.Selector{0x34, 0x08, 0xe4, 0x70} // getChainId()
+	selGetEthBalance            = stygos.Selector{0x4d, 0x23, 0x01, 0xcc} // getEthBalance(address)
+)
+
+// Multicall errors
+var (
+	ErrValueMismatch = errors.New("multicall: value mismatch")
+	ErrBadArgs       = errors.New("multicall: malformed arguments")
+)
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selAggregate, handleAggregate)
+	r.HandleSelector(selTryAggregate, handleTryAggregate)
+	r.HandleSelector(selAggregate3, handleAggregate3)
+	r.HandleSelector(selAggregate3Value, handleAggregate3Value)
+	r.HandleSelector(selGetBlockNumber, func(ctx *stygos.Ctx, _ []byte) ([]byte, error) {
+		return word(stygos.WordFromUint64(ctx.Block)), nil
+	})
+	r.HandleSelector(selGetCurrentBlockTimestamp, func(ctx *stygos.Ctx, _ []byte) ([]byte, error) {
+		return word(stygos.WordFromUint64(ctx.Timestamp)), nil
+	})
+	r.HandleSelector(selGetChainID, func(*stygos.Ctx, []byte) ([]byte, error) {
+		return word(stygos.WordFromUint64(stygos.GetChainID())), nil
+	})
+	r.HandleSelector(selGetEthBalance, handleGetEthBalance)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleAggregate runs every call, reverting if any fails, and returns the
+// block number and the return data.
+func handleAggregate(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	calls, err := multicall.DecodeAggregate(args)
+	if err != nil {
+		return nil, err
+	}
+	data := make([][]byte, len(calls))
+	for i, c := range calls {
+		ret, err := stygos.Call(c.Target, stygos.Word{}, c.Data)
+		if err != nil {
+			return nil, multicall.ErrCallFailed
+		}
+		data[i] = ret
+	}
+	return multicall.EncodeAggregateResult(stygos.GetBlockNumber(), data), nil
+}
+
+// handleTryAggregate runs every call, reverting on a failure only when
+// requireSuccess is set.
+func handleTryAggregate(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	requireSuccess, calls, err := multicall.DecodeTryAggregate(args)
+	if err != nil {
+		return nil, err
+	}
+	for i := range calls {
+		calls[i].AllowFailure = !requireSuccess
+	}
+	results, _, err := run(calls)
+	if err != nil {
+		return nil, err
+	}
+	return multicall.EncodeResults(results), nil
+}
+
+// handleAggregate3 runs every call, reverting on the failure of a call
+// that does not allow it.
+func handleAggregate3(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	calls, err := multicall.DecodeAggregate3(args)
+	if err != nil {
+		return nil, err
+	}
+	results, _, err := run(calls)
+	if err != nil {
+		return nil, err
+	}
+	return multicall.EncodeResults(results), nil
+}
+
+// handleAggregate3Value is aggregate3 with a value per call. The values
+// must add up to msg.value.
+func handleAggregate3Value(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	calls, err := multicall.DecodeAggregate3Value(args)
+	if err != nil {
+		return nil, err
+	}
+	results, total, err := run(calls)
+	if err != nil {
+		return nil, err
+	}
+	if total != stygos.U256FromBig(stygos.GetMsgValue()) {
+		return nil, ErrValueMismatch
+	}
+	return multicall.EncodeResults(results), nil
+}
+
+// run makes the calls, with their values, and returns their results and
+// the total value sent.
+func run(calls []multicall.Call) ([]multicall.Result, stygos.U256, error) {
+	results := make([]multicall.Result, len(calls))
+	var total stygos.U256
+	for i, c := range calls {
+		var ok bool
+		if total, ok = total.CheckedAdd(c.Value); !ok {
+			return nil, total, ErrValueMismatch
+		}
+		ret, err := stygos.Call(c.Target, c.Value.Word(), c.Data)
+		if err != nil && !c.AllowFailure {
+			return nil, total, multicall.ErrCallFailed
+		}
+		results[i] = multicall.Result{Success: err == nil, ReturnData: ret}
+	}
+	return results, total, nil
+}
+
+// handleGetEthBalance returns the balance of an account in wei.
+func handleGetEthBalance(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 32 {
+		return nil, ErrBadArgs
+	}
+	var w stygos.Word
+	copy(w[:], args)
+	return word(stygos.GetBalance(stygos.AddressFromWord(w)).Word()), nil
+}
+
+func word(w stygos.Word) []byte {
+	return w[:]
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/multicall"
+)
+
+var (
+	contract = stygos.Address{0x3c}
+	target   = stygos.Address{0x7a}
+	caller   = stygos.Address{0xca}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+
+	selGet  = stygos.SelectorOf("get()")
+	selInc  = stygos.SelectorOf("inc()")
+	selFail = stygos.SelectorOf("fail()")
+)
+
+var errFail = errors.New("target: failed")
+
+// setup deploys the multicall contract and a counter target.
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	mock.Sender = alice
+	stygos.UseRuntime(mock)
+
+	key := stygos.Word{0x01}
+	r := stygos.NewRouter()
+	r.HandleSelector(selGet, func(*stygos.Ctx, []byte) ([]byte, error) {
+		return word(stygos.StorageLoad(key)), nil
+	})
+	r.HandleSelector(selInc, func(*stygos.Ctx, []byte) ([]byte, error) {
+		n := stygos.Uint64FromWord(stygos.StorageLoad(key))
+		stygos.StorageStore(key, stygos.WordFromUint64(n+1))
+		return nil, nil
+	})
+	r.HandleSelector(selFail, func(*stygos.Ctx, []byte) ([]byte, error) {
+		return []byte("nope"), errFail
+	})
+	mock.Deploy(target, r.Dispatch)
+	mock.Deploy(contract, stygos.MockEntrypoint(entrypoint))
+	return mock
+}
+
+func TestAggregate(t *testing.T) {
+	mock := setup()
+	mock.Block = 77
+
+	calls := []multicall.Call{{Target: target, Data: selInc[:]}, {Target: target, Data: selInc[:]}, {Target: target, Data: selGet[:]}}
+	ret, err := router.Dispatch(multicall.EncodeAggregate(calls))
+	if err != nil {
+		t.Fatalf("aggregate failed: %v", err)
+	}
+	block, data, err := multicall.DecodeAggregateResult(ret)
+	if err != nil || block != 77 || len(data) != 3 || uintOf(data[2]) != 2 {
+		t.Errorf("aggregate failed. Expected block 77 and a count of 2, got %d, %x, %v", block, data, err)
+	}
+
+	calls = append(calls, multicall.Call{Target: target, Data: selFail[:]})
+	if _, err := router.Dispatch(multicall.EncodeAggregate(calls)); err != multicall.ErrCallFailed {
+		t.Errorf("aggregate failed. Expected ErrCallFailed, got %v", err)
+	}
+}
+
+func TestTryAggregate(t *testing.T) {
+	setup()
+	calls := []multicall.Call{{Target: target, Data: selFail[:]}, {Target: target, Data: selInc[:]}}
+	ret, err := router.Dispatch(multicall.EncodeTryAggregate(false, calls))
+	if err != nil {
+		t.Fatalf("tryAggregate failed: %v", err)
+	}
+	results, err := multicall.DecodeResults(ret)
+	if err != nil || len(results) != 2 || results[0].Success || string(results[0].ReturnData) != "nope" || !results[1].Success {
+		t.Errorf("tryAggregate failed. Expected a failure with its revert data then a success, got %+v, %v", results, err)
+	}
+	if _, err := router.Dispatch(multicall.EncodeTryAggregate(true, calls)); err != multicall.ErrCallFailed {
+		t.Errorf("tryAggregate failed. Expected ErrCallFailed when requiring success, got %v", err)
+	}
+}
+
+func TestAggregate3Client(t *testing.T) {
+	mock := setup()
+	mock.Contract = caller
+
+	// A view batch through the client, as a contract or eth_call makes it
+	m := multicall.New(contract, stygos.StaticCall)
+	results, err := m.Aggregate3([]multicall.Call{
+		{Target: target, Data: selGet[:]},
+		{Target: target, Data: selFail[:], AllowFailure: true},
+	})
+	if err != nil || len(results) != 2 || !results[0].Success || results[1].Success {
+		t.Fatalf("Aggregate3 failed. Expected a success and an allowed failure, got %+v, %v", results, err)
+	}
+	if _, err := m.Aggregate3([]multicall.Call{{Target: target, Data: selFail[:]}}); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Aggregate3 failed. Expected the batch to revert, got %v", err)
+	}
+
+	// Writes in a view batch revert
+	if _, err := m.Aggregate3([]multicall.Call{{Target: target, Data: selInc[:]}}); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Aggregate3 failed. Expected a write to revert in a static call, got %v", err)
+	}
+
+	block, data, err := m.Aggregate([]multicall.Call{{Target: target, Data: selGet[:]}})
+	if err != nil || block != mock.Block || len(data) != 1 {
+		t.Errorf("Aggregate failed. Expected one result, got %d, %x, %v", block, data, err)
+	}
+}
+
+func TestAggregate3Value(t *testing.T) {
+	mock := setup()
+	mock.Value = big.NewInt(100)
+	mock.SetBalance(contract, big.NewInt(100))
+
+	calls := []multicall.Call{
+		{Target: alice, Value: stygos.NewU256(60)},
+		{Target: bob, Value: stygos.NewU256(40)},
+	}
+	if _, err := router.Dispatch(multicall.EncodeAggregate3Value(calls)); err != nil {
+		t.Fatalf("aggregate3Value failed: %v", err)
+	}
+	if mock.BalanceOf(alice).Int64() != 60 || mock.BalanceOf(bob).Int64() != 40 {
+		t.Errorf("aggregate3Value failed. Expected 60 and 40 wei sent, got %s and %s", mock.BalanceOf(alice), mock.BalanceOf(bob))
+	}
+
+	mock.SetBalance(contract, big.NewInt(100))
+	calls[1].Value = stygos.NewU256(30)
+	if _, err := router.Dispatch(multicall.EncodeAggregate3Value(calls)); err != ErrValueMismatch {
+		t.Errorf("aggregate3Value failed. Expected ErrValueMismatch, got %v", err)
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	mock := setup()
+	mock.SetBalance(bob, big.NewInt(12345))
+
+	addr := stygos.PadAddress(bob)
+	ret, err := router.Dispatch(append(selGetEthBalance[:], addr[:]...))
+	if err != nil || uintOf(ret) != 12345 {
+		t.Errorf("getEthBalance failed. Expected 12345, got %x, %v", ret, err)
+	}
+	ret, err = router.Dispatch(selGetChainID[:])
+	if err != nil || uintOf(ret) != mock.Chain {
+		t.Errorf("getChainId failed. Expected %d, got %x, %v", mock.Chain, ret, err)
+	}
+	if _, err := router.Dispatch(multicall.EncodeAggregate3(nil)[:40]); err != multicall.ErrBadCalls {
+		t.Errorf("aggregate3 failed. Expected ErrBadCalls for truncated calldata, got %v", err)
+	}
+}
+
+func uintOf(b []byte) uint64 {
+	var w stygos.Word
+	copy(w[:], b)
+	return stygos.Uint64FromWord(w)
+}
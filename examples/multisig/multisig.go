@@ -1,54 +1,112 @@
 package main
 
 import (
-	"encoding/binary"
 	"errors"
-	"math/big"
 
 	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/eip712"
+	"github.com/rafaelescrich/stygos/schnorr"
+	"github.com/rafaelescrich/stygos/storage"
 )
 
-// Multisig contract implementation using Schnorr signatures
-// This demonstrates how to use the Schnorr library for practical applications
+// Multisig wallet approved by ECDSA and Schnorr signatures.
+//
+// Owners are Ethereum addresses (approving with ecrecover signatures) and
+// BIP-340 x-only keys (approving with Schnorr signatures). An owner submits
+// a proposal to call an address with value and data; owners sign its
+// EIP-712 hash, which commits to the chain id, the wallet address and the
+// proposal nonce, and anyone may relay their approvals. Once the threshold
+// is met anyone may execute it. Owners and the threshold can only change
+// through proposals the wallet calls on itself.
+//
+// All arguments are 32-byte words following the command byte.
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go ownersKey=owners schnorrOwnersKey=schnorrOwners thresholdKey=threshold nonceKey=nonce proposalPrefix=proposal approvalPrefix=approval
 
-// Storage keys
-var (
-	ownersKey      = stygos.Keccak256([]byte("owners"))
-	thresholdKey   = stygos.Keccak256([]byte("threshold"))
-	nonceKey       = stygos.Keccak256([]byte("nonce"))
-	proposalPrefix = stygos.Keccak256([]byte("proposal"))
-	approvalPrefix = stygos.Keccak256([]byte("approval"))
+// Commands
+const (
+	CMD_INITIALIZE           = 0  // threshold, ecdsa owner count, owners..., schnorr keys...
+	CMD_SUBMIT_PROPOSAL      = 1  // to, value, data...; returns the nonce
+	CMD_APPROVE_PROPOSAL     = 2  // nonce, 65-byte ECDSA sig or x-only key || 64-byte Schnorr sig
+	CMD_EXECUTE_PROPOSAL     = 3  // nonce; returns the call's return data
+	CMD_GET_PROPOSAL         = 4  // nonce; returns to, value, executed, approvals, data...
+	CMD_GET_OWNERS           = 5  // returns the ECDSA owners
+	CMD_GET_THRESHOLD        = 6  // returns the threshold
+	CMD_ADD_OWNER            = 7  // owner (wallet only)
+	CMD_REMOVE_OWNER         = 8  // owner (wallet only)
+	CMD_ADD_SCHNORR_OWNER    = 9  // x-only key (wallet only)
+	CMD_REMOVE_SCHNORR_OWNER = 10 // x-only key (wallet only)
+	CMD_CHANGE_THRESHOLD     = 11 // threshold (wallet only)
+	CMD_GET_SCHNORR_OWNERS   = 12 // returns the Schnorr owner keys
+	CMD_GET_PROPOSAL_HASH    = 13 // nonce; returns the EIP-712 digest to sign
+	CMD_APPROVE_BATCH        = 14 // nonce, (owner || 65-byte ECDSA sig)... in ascending owner order
 )
 
-// Commands
+// maxOwners bounds the owners of both kinds, and so the cost of counting
+// approvals.
+const maxOwners = 10
+
+// ProposalType is the EIP-712 type owners sign. The nonce is the
+// proposal's sequence number in this wallet.
+const ProposalType = "Proposal(address to,uint256 value,bytes data,uint256 nonce)"
+
+// Domain name and version for proposal hashes
 const (
-	CMD_INITIALIZE       = 0
-	CMD_SUBMIT_PROPOSAL  = 1
-	CMD_APPROVE_PROPOSAL = 2
-	CMD_EXECUTE_PROPOSAL = 3
-	CMD_GET_PROPOSAL     = 4
-	CMD_GET_OWNERS       = 5
-	CMD_GET_THRESHOLD    = 6
+	domainName    = "Multisig"
+	domainVersion = "1"
 )
 
 // Errors
 var (
+	ErrInvalidInput          = errors.New("invalid input")
+	ErrInitialized           = errors.New("already initialized")
 	ErrNotOwner              = errors.New("not owner")
+	ErrNotWallet             = errors.New("caller is not the wallet")
+	ErrInvalidOwner          = errors.New("invalid owner")
 	ErrInvalidThreshold      = errors.New("invalid threshold")
+	ErrInvalidSignature      = errors.New("invalid signature")
 	ErrProposalNotFound      = errors.New("proposal not found")
 	ErrAlreadyApproved       = errors.New("already approved")
 	ErrInsufficientApprovals = errors.New("insufficient approvals")
 	ErrProposalExecuted      = errors.New("proposal already executed")
 )
 
-// Proposal structure (simplified for storage)
+// Proposal structure, packed into storage by proposal_pack_gen.go. The call
+// data is stored separately since it has no fixed size.
+//
+//go:generate stygos-gen pack -type Proposal -o proposal_pack_gen.go
 type Proposal struct {
 	To       stygos.Address
-	Value    *stygos.Word
-	Data     []byte
+	Value    stygos.Word
+	Data     []byte `stygos:"-"`
 	Executed bool
 }
 
+// Hash returns the EIP-712 struct hash of the proposal with the given
+// nonce.
+func (p *Proposal) Hash(nonce uint64) stygos.Word {
+	return eip712.HashStruct(eip712.TypeHash(ProposalType),
+		stygos.PadAddress(p.To),
+		p.Value,
+		eip712.HashBytes(p.Data),
+		stygos.WordFromUint64(nonce))
+}
+
+var (
+	owners        = storage.NewAddressSet(ownersKey)
+	schnorrOwners = storage.NewSet(schnorrOwnersKey, storage.Words)
+)
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
 //export entrypoint
 func entrypoint() int32 {
 	callData, err := stygos.GetCallData()
@@ -59,275 +117,376 @@ func entrypoint() int32 {
 	command := callData[0]
 	args := callData[1:]
 
+	var handler func([]byte) error
 	switch command {
 	case CMD_INITIALIZE:
-		return handleInitialize(args)
+		handler = handleInitialize
 	case CMD_SUBMIT_PROPOSAL:
-		return handleSubmitProposal(args)
+		handler = handleSubmitProposal
 	case CMD_APPROVE_PROPOSAL:
-		return handleApproveProposal(args)
+		handler = handleApproveProposal
 	case CMD_EXECUTE_PROPOSAL:
-		return handleExecuteProposal(args)
+		handler = handleExecuteProposal
 	case CMD_GET_PROPOSAL:
-		return handleGetProposal(args)
+		handler = handleGetProposal
 	case CMD_GET_OWNERS:
-		return handleGetOwners(args)
+		handler = handleGetOwners
 	case CMD_GET_THRESHOLD:
-		return handleGetThreshold(args)
+		handler = handleGetThreshold
+	case CMD_ADD_OWNER:
+		handler = handleAddOwner
+	case CMD_REMOVE_OWNER:
+		handler = handleRemoveOwner
+	case CMD_ADD_SCHNORR_OWNER:
+		handler = handleAddSchnorrOwner
+	case CMD_REMOVE_SCHNORR_OWNER:
+		handler = handleRemoveSchnorrOwner
+	case CMD_CHANGE_THRESHOLD:
+		handler = handleChangeThreshold
+	case CMD_GET_SCHNORR_OWNERS:
+		handler = handleGetSchnorrOwners
+	case CMD_GET_PROPOSAL_HASH:
+		handler = handleGetProposalHash
+	case CMD_APPROVE_BATCH:
+		handler = handleApproveBatch
 	default:
 		return 1 // Unknown command
 	}
-}
-
-// handleInitialize initializes the multisig with owners and threshold
-func handleInitialize(args []byte) int32 {
-	if len(args) < 1 {
-		return 1
-	}
-
-	threshold := uint8(args[0])
-	if threshold == 0 || threshold > 10 { // Reasonable limit
+	if handler(args) != nil {
 		return 1
 	}
-
-	// Parse owners (each owner is 32 bytes: 20-byte address + 12 bytes padding)
-	ownersCount := (len(args) - 1) / 32
-	if ownersCount == 0 || ownersCount > 10 { // Reasonable limit
-		return 1
-	}
-
-	// Store threshold
-	thresholdWord := stygos.WordFromUint64(uint64(threshold))
-	stygos.StorageStore(thresholdKey, thresholdWord)
-
-	// Store owners
-	ownersData := make([]byte, ownersCount*32)
-	copy(ownersData, args[1:1+ownersCount*32])
-	ownersWord := stygos.WordFromBigInt(new(big.Int).SetBytes(ownersData))
-	stygos.StorageStore(ownersKey, ownersWord)
-
-	// Initialize nonce
-	stygos.StorageStore(nonceKey, stygos.WordFromUint64(0))
-
 	return 0
 }
 
-// handleSubmitProposal submits a new proposal
-func handleSubmitProposal(args []byte) int32 {
-	if len(args) < 84 { // 32 (to) + 32 (value) + 1 (data_len) + 19 (min data)
-		return 1
+// handleInitialize sets the threshold and the initial owners, once
+func handleInitialize(args []byte) error {
+	if getThreshold() != 0 {
+		return ErrInitialized
 	}
-
-	// Check if caller is owner
-	caller := getCaller()
-	if !isOwner(caller) {
-		return 1
+	if len(args) < 64 || len(args)%32 != 0 {
+		return ErrInvalidInput
 	}
-
-	// Parse proposal data
-	to := stygos.Address{}
-	copy(to[:], args[:20])
-
-	value := stygos.Word{}
-	copy(value[:], args[20:52])
-
-	dataLen := int(args[52])
-	if len(args) < 53+dataLen {
-		return 1
+	threshold := uint64Arg(args, 0)
+	ecdsaCount := uint64Arg(args, 1)
+	keys := uint64(len(args)/32 - 2)
+	if ecdsaCount > keys {
+		return ErrInvalidInput
 	}
 
-	data := args[53 : 53+dataLen]
+	for i := uint64(0); i < keys; i++ {
+		var err error
+		if i < ecdsaCount {
+			err = addOwner(stygos.AddressFromWord(wordArg(args, 2+i)))
+		} else {
+			err = addSchnorrOwner(wordArg(args, 2+i))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return setThreshold(threshold)
+}
 
-	// Get next nonce
-	nonce := getNonce()
+// handleSubmitProposal stores a call proposed by an ECDSA owner and
+// returns its nonce
+func handleSubmitProposal(args []byte) error {
+	if len(args) < 64 {
+		return ErrInvalidInput
+	}
+	caller := stygos.GetMsgSender()
+	if !owners.Contains(caller) {
+		return ErrNotOwner
+	}
 
-	// Create proposal
 	proposal := Proposal{
-		To:       to,
-		Value:    &value,
-		Data:     data,
-		Executed: false,
+		To:    stygos.AddressFromWord(wordArg(args, 0)),
+		Value: wordArg(args, 1),
+		Data:  args[64:],
 	}
-
-	// Store proposal
-	proposalKey := getProposalKey(nonce)
-	storeProposal(proposalKey, proposal)
-
-	// Increment nonce
+	if proposal.To == (stygos.Address{}) {
+		return ErrInvalidInput
+	}
+	nonce := getNonce()
+	storeProposal(getProposalKey(nonce), proposal)
 	setNonce(nonce + 1)
 
-	// Emit event
-	emitProposalSubmitted(nonce, caller, to)
-
-	return 0
+	emitProposalSubmitted(nonce, caller, proposal.To)
+	return stygos.NewReturnBuilder(32).AppendUint64(nonce).Finish()
 }
 
-// handleApproveProposal approves a proposal with Schnorr signature
-func handleApproveProposal(args []byte) int32 {
-	if len(args) < 33 { // 32 (nonce) + 1 (sig_len)
-		return 1
+// handleApproveProposal records an owner's signature over the proposal
+// hash. Anyone may relay it.
+func handleApproveProposal(args []byte) error {
+	if len(args) < 32 {
+		return ErrInvalidInput
 	}
-
-	nonce := binary.BigEndian.Uint32(args[:4])
-
-	// Check if caller is owner
-	caller := getCaller()
-	if !isOwner(caller) {
-		return 1
-	}
-
-	// Get proposal
-	proposalKey := getProposalKey(uint64(nonce))
-	proposal, exists := getProposal(proposalKey)
+	nonce := uint64Arg(args, 0)
+	proposal, exists := getProposal(getProposalKey(nonce))
 	if !exists {
-		return 1
+		return ErrProposalNotFound
 	}
-
 	if proposal.Executed {
-		return 1
+		return ErrProposalExecuted
 	}
 
-	// Parse signature
-	sigLen := int(args[4])
-	if len(args) < 5+sigLen {
-		return 1
+	signer, err := recoverSigner(proposalDigest(&proposal, nonce), args[32:])
+	if err != nil {
+		return err
 	}
-
-	sig := args[5 : 5+sigLen]
-
-	// Verify signature
-	// In a real implementation, we would verify the Schnorr signature
-	// For now, we'll do a simple check
-	if len(sig) != 64 {
-		return 1
-	}
-
-	// Check if already approved
-	approvalKey := getApprovalKey(nonce, caller)
+	approvalKey := getApprovalKey(nonce, signer)
 	if hasApproval(approvalKey) {
-		return 1
+		return ErrAlreadyApproved
 	}
-
-	// Store approval
 	setApproval(approvalKey, true)
 
-	// Emit event
-	emitProposalApproved(nonce, caller)
-
-	return 0
+	emitProposalApproved(nonce, signer)
+	return nil
 }
 
-// handleExecuteProposal executes a proposal if it has enough approvals
-func handleExecuteProposal(args []byte) int32 {
-	if len(args) < 4 {
-		return 1
+// handleApproveBatch records the signatures of several ECDSA owners in
+// one call. The owners must be in ascending order, so each appears once,
+// and none may have approved the proposal already.
+func handleApproveBatch(args []byte) error {
+	if len(args) < 32 {
+		return ErrInvalidInput
+	}
+	nonce := uint64Arg(args, 0)
+	proposal, exists := getProposal(getProposalKey(nonce))
+	if !exists {
+		return ErrProposalNotFound
+	}
+	if proposal.Executed {
+		return ErrProposalExecuted
 	}
 
-	nonce := binary.BigEndian.Uint32(args[:4])
+	approvals, err := ecdsa.DecodeApprovals(args[32:])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if err := ecdsa.VerifyApprovals(proposalDigest(&proposal, nonce), approvals, 1, owners.Contains); err != nil {
+		if err == ecdsa.ErrUnknownSigner {
+			return ErrNotOwner
+		}
+		return ErrInvalidSignature
+	}
+	for _, a := range approvals {
+		signer := stygos.PadAddress(a.Signer)
+		approvalKey := getApprovalKey(nonce, signer)
+		if hasApproval(approvalKey) {
+			return ErrAlreadyApproved
+		}
+		setApproval(approvalKey, true)
+		emitProposalApproved(nonce, signer)
+	}
+	return nil
+}
 
-	// Get proposal
-	proposalKey := getProposalKey(uint64(nonce))
+// handleExecuteProposal performs the proposal's call once enough current
+// owners have approved it. A failed call reverts the execution.
+func handleExecuteProposal(args []byte) error {
+	if len(args) != 32 {
+		return ErrInvalidInput
+	}
+	nonce := uint64Arg(args, 0)
+	proposalKey := getProposalKey(nonce)
 	proposal, exists := getProposal(proposalKey)
 	if !exists {
-		return 1
+		return ErrProposalNotFound
 	}
-
 	if proposal.Executed {
-		return 1
+		return ErrProposalExecuted
 	}
-
-	// Count approvals
-	approvalCount := countApprovals(nonce)
-	threshold := getThreshold()
-
-	if approvalCount < threshold {
-		return 1
+	if countApprovals(nonce) < getThreshold() {
+		return ErrInsufficientApprovals
 	}
 
-	// Mark as executed
+	// Mark as executed before calling out, so the call cannot re-enter it
 	proposal.Executed = true
 	storeProposal(proposalKey, proposal)
 
-	// Emit event
+	result, err := stygos.Call(proposal.To, proposal.Value, proposal.Data)
+	if err != nil {
+		return err
+	}
 	emitProposalExecuted(nonce)
+	return stygos.SetReturnData(result)
+}
 
-	return 0
+// handleGetProposal returns to, value, executed and the approval count as
+// words, followed by the call data
+func handleGetProposal(args []byte) error {
+	if len(args) != 32 {
+		return ErrInvalidInput
+	}
+	nonce := uint64Arg(args, 0)
+	proposal, exists := getProposal(getProposalKey(nonce))
+	if !exists {
+		return ErrProposalNotFound
+	}
+
+	result := stygos.NewReturnBuilder(4*32 + len(proposal.Data))
+	result.AppendAddress(proposal.To).
+		AppendWord(proposal.Value).
+		AppendBool(proposal.Executed).
+		AppendUint64(countApprovals(nonce)).
+		AppendRaw(proposal.Data)
+	return result.Finish()
 }
 
-// handleGetProposal returns proposal data
-func handleGetProposal(args []byte) int32 {
-	if len(args) < 4 {
-		return 1
+// handleGetOwners returns the ECDSA owners, one 32-byte padded address each
+func handleGetOwners(args []byte) error {
+	list := owners.Values()
+	result := stygos.NewReturnBuilder(len(list) * 32)
+	for _, owner := range list {
+		result.AppendAddress(owner)
 	}
+	return result.Finish()
+}
 
-	nonce := binary.BigEndian.Uint32(args[:4])
-	proposalKey := getProposalKey(uint64(nonce))
-	proposal, exists := getProposal(proposalKey)
+// handleGetSchnorrOwners returns the Schnorr owner keys
+func handleGetSchnorrOwners(args []byte) error {
+	list := schnorrOwners.Values()
+	result := stygos.NewReturnBuilder(len(list) * 32)
+	for _, key := range list {
+		result.AppendWord(key)
+	}
+	return result.Finish()
+}
 
+// handleGetThreshold returns the threshold
+func handleGetThreshold(args []byte) error {
+	return stygos.NewReturnBuilder(32).AppendUint64(getThreshold()).Finish()
+}
+
+// handleGetProposalHash returns the EIP-712 digest owners sign to approve
+// a proposal
+func handleGetProposalHash(args []byte) error {
+	if len(args) != 32 {
+		return ErrInvalidInput
+	}
+	nonce := uint64Arg(args, 0)
+	proposal, exists := getProposal(getProposalKey(nonce))
 	if !exists {
-		return 1
+		return ErrProposalNotFound
 	}
+	digest := proposalDigest(&proposal, nonce)
+	return stygos.SetReturnData(digest[:])
+}
 
-	// Return proposal data
-	result := make([]byte, 20+32+1+len(proposal.Data)+1)
-	copy(result[:20], proposal.To[:])
-	copy(result[20:52], proposal.Value[:])
-	result[52] = byte(len(proposal.Data))
-	copy(result[53:53+len(proposal.Data)], proposal.Data)
-	if proposal.Executed {
-		result[53+len(proposal.Data)] = 1
-	} else {
-		result[53+len(proposal.Data)] = 0
-	}
+// Owner governance, callable only by the wallet through an executed
+// proposal
 
-	stygos.SetReturnData(result)
-	return 0
+func handleAddOwner(args []byte) error {
+	return governance(args, func(w stygos.Word) error {
+		return addOwner(stygos.AddressFromWord(w))
+	})
 }
 
-// handleGetOwners returns the list of owners
-func handleGetOwners(args []byte) int32 {
-	ownersWord := stygos.StorageLoad(ownersKey)
-	ownersData := stygos.BigIntFromWord(ownersWord).Bytes()
+func handleRemoveOwner(args []byte) error {
+	return governance(args, func(w stygos.Word) error {
+		if !owners.Remove(stygos.AddressFromWord(w)) {
+			return ErrNotOwner
+		}
+		return checkThreshold(getThreshold())
+	})
+}
 
-	stygos.SetReturnData(ownersData)
-	return 0
+func handleAddSchnorrOwner(args []byte) error {
+	return governance(args, addSchnorrOwner)
 }
 
-// handleGetThreshold returns the threshold
-func handleGetThreshold(args []byte) int32 {
-	thresholdWord := stygos.StorageLoad(thresholdKey)
-	threshold := stygos.Uint64FromWord(thresholdWord)
+func handleRemoveSchnorrOwner(args []byte) error {
+	return governance(args, func(w stygos.Word) error {
+		if !schnorrOwners.Remove(w) {
+			return ErrNotOwner
+		}
+		return checkThreshold(getThreshold())
+	})
+}
 
-	result := make([]byte, 8)
-	binary.BigEndian.PutUint64(result, threshold)
+func handleChangeThreshold(args []byte) error {
+	return governance(args, func(w stygos.Word) error {
+		if !stygos.U256FromWord(w).IsUint64() {
+			return ErrInvalidThreshold
+		}
+		return setThreshold(stygos.Uint64FromWord(w))
+	})
+}
 
-	stygos.SetReturnData(result)
-	return 0
+// governance checks that the wallet is calling itself and applies fn to
+// the single word argument
+func governance(args []byte, fn func(stygos.Word) error) error {
+	if stygos.GetMsgSender() != stygos.GetContractAddress() {
+		return ErrNotWallet
+	}
+	if len(args) != 32 {
+		return ErrInvalidInput
+	}
+	return fn(wordArg(args, 0))
 }
 
 // Helper functions
 
-func getCaller() stygos.Address {
-	// In a real implementation, this would get the caller address
-	// For now, return a mock address
-	return stygos.Address{}
+// recoverSigner returns the owner key that signed digest: the padded
+// address for a 65-byte ECDSA signature, or the x-only key for a 32-byte
+// key followed by a 64-byte Schnorr signature.
+func recoverSigner(digest stygos.Word, sig []byte) (stygos.Word, error) {
+	switch len(sig) {
+	case 65:
+		s, err := ecdsa.SignatureFromBytes(sig)
+		if err != nil {
+			return stygos.Word{}, ErrInvalidSignature
+		}
+		signer, err := ecdsa.Recover(digest, s)
+		if err != nil {
+			return stygos.Word{}, ErrInvalidSignature
+		}
+		if !owners.Contains(signer) {
+			return stygos.Word{}, ErrNotOwner
+		}
+		return stygos.PadAddress(signer), nil
+	case 96:
+		var key stygos.Word
+		copy(key[:], sig[:32])
+		if !schnorrOwners.Contains(key) {
+			return stygos.Word{}, ErrNotOwner
+		}
+		if !schnorr.Verify(digest[:], sig[32:], key[:]) {
+			return stygos.Word{}, ErrInvalidSignature
+		}
+		return key, nil
+	}
+	return stygos.Word{}, ErrInvalidSignature
+}
+
+// proposalDigest returns the EIP-712 digest of a proposal in this
+// wallet's domain, which includes the chain id and wallet address
+func proposalDigest(p *Proposal, nonce uint64) stygos.Word {
+	return eip712.Digest(eip712.NewDomain(domainName, domainVersion).Separator(), p.Hash(nonce))
 }
 
-func isOwner(addr stygos.Address) bool {
-	ownersWord := stygos.StorageLoad(ownersKey)
-	ownersData := stygos.BigIntFromWord(ownersWord).Bytes()
+func addOwner(owner stygos.Address) error {
+	if owner == (stygos.Address{}) || owner == stygos.GetContractAddress() {
+		return ErrInvalidOwner
+	}
+	if ownerCount() >= maxOwners || !owners.Add(owner) {
+		return ErrInvalidOwner
+	}
+	return nil
+}
 
-	// Check if address is in owners list
-	for i := 0; i < len(ownersData); i += 32 {
-		if i+20 <= len(ownersData) {
-			var ownerAddr stygos.Address
-			copy(ownerAddr[:], ownersData[i:i+20])
-			if ownerAddr == addr {
-				return true
-			}
-		}
+func addSchnorrOwner(key stygos.Word) error {
+	if _, err := schnorr.LiftX(stygos.BigIntFromWord(key)); err != nil {
+		return ErrInvalidOwner
+	}
+	if ownerCount() >= maxOwners || !schnorrOwners.Add(key) {
+		return ErrInvalidOwner
 	}
-	return false
+	return nil
+}
+
+func ownerCount() uint64 {
+	return owners.Length() + schnorrOwners.Length()
 }
 
 func getNonce() uint64 {
@@ -345,60 +504,54 @@ func getThreshold() uint64 {
 	return stygos.Uint64FromWord(thresholdWord)
 }
 
+func setThreshold(threshold uint64) error {
+	if err := checkThreshold(threshold); err != nil {
+		return err
+	}
+	stygos.StorageStore(thresholdKey, stygos.WordFromUint64(threshold))
+	return nil
+}
+
+// checkThreshold requires 1 <= threshold <= number of owners
+func checkThreshold(threshold uint64) error {
+	if threshold == 0 || threshold > ownerCount() {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
 func getProposalKey(nonce uint64) stygos.Word {
-	nonceBytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(nonceBytes, nonce)
-	return stygos.Keccak256(append(proposalPrefix[:], nonceBytes...))
+	n := stygos.WordFromUint64(nonce)
+	return storage.MapKey(proposalPrefix, n[:])
 }
 
-func getApprovalKey(nonce uint32, owner stygos.Address) stygos.Word {
-	nonceBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(nonceBytes, nonce)
-	return stygos.Keccak256(append(append(approvalPrefix[:], nonceBytes...), owner[:]...))
+func getApprovalKey(nonce uint64, signer stygos.Word) stygos.Word {
+	n := stygos.WordFromUint64(nonce)
+	return storage.MapKey(storage.MapKey(approvalPrefix, n[:]), signer[:])
 }
 
 func storeProposal(key stygos.Word, proposal Proposal) {
-	// Simplified storage - in practice, you'd serialize the proposal properly
-	data := make([]byte, 20+32+1+len(proposal.Data)+1)
-	copy(data[:20], proposal.To[:])
-	copy(data[20:52], proposal.Value[:])
-	data[52] = byte(len(proposal.Data))
-	copy(data[53:53+len(proposal.Data)], proposal.Data)
-	if proposal.Executed {
-		data[53+len(proposal.Data)] = 1
-	} else {
-		data[53+len(proposal.Data)] = 0
-	}
-
-	proposalWord := stygos.WordFromBigInt(new(big.Int).SetBytes(data))
-	stygos.StorageStore(key, proposalWord)
+	proposal.Store(key)
+	storage.StoreBytes(getProposalDataKey(key), proposal.Data)
 }
 
 func getProposal(key stygos.Word) (Proposal, bool) {
-	proposalWord := stygos.StorageLoad(key)
-	if proposalWord == (stygos.Word{}) {
-		return Proposal{}, false
-	}
-
-	data := stygos.BigIntFromWord(proposalWord).Bytes()
-	if len(data) < 53 {
-		return Proposal{}, false
-	}
-
 	var proposal Proposal
-	copy(proposal.To[:], data[:20])
-	copy(proposal.Value[:], data[20:52])
-	dataLen := int(data[52])
-	if len(data) < 53+dataLen+1 {
+	proposal.Load(key)
+	if proposal.MarshalWords() == ([ProposalPackedWords]stygos.Word{}) {
 		return Proposal{}, false
 	}
-	proposal.Data = make([]byte, dataLen)
-	copy(proposal.Data, data[53:53+dataLen])
-	proposal.Executed = data[53+dataLen] == 1
 
+	proposal.Data = storage.LoadBytes(getProposalDataKey(key))
 	return proposal, true
 }
 
+// getProposalDataKey returns the slot holding a proposal's call data, right
+// after the packed proposal words
+func getProposalDataKey(proposalKey stygos.Word) stygos.Word {
+	return storage.Offset(proposalKey, ProposalPackedWords)
+}
+
 func hasApproval(key stygos.Word) bool {
 	approvalWord := stygos.StorageLoad(key)
 	return approvalWord != (stygos.Word{})
@@ -412,50 +565,46 @@ func setApproval(key stygos.Word, approved bool) {
 	}
 }
 
-func countApprovals(nonce uint32) uint64 {
-	// Count how many owners have approved this proposal
-	ownersWord := stygos.StorageLoad(ownersKey)
-	ownersData := stygos.BigIntFromWord(ownersWord).Bytes()
-
+// countApprovals counts the current owners that approved a proposal, so
+// approvals by removed owners no longer count
+func countApprovals(nonce uint64) uint64 {
 	count := uint64(0)
-	for i := 0; i < len(ownersData); i += 32 {
-		if i+20 <= len(ownersData) {
-			var ownerAddr stygos.Address
-			copy(ownerAddr[:], ownersData[i:i+20])
-			approvalKey := getApprovalKey(nonce, ownerAddr)
-			if hasApproval(approvalKey) {
-				count++
-			}
+	for _, owner := range owners.Values() {
+		if hasApproval(getApprovalKey(nonce, stygos.PadAddress(owner))) {
+			count++
+		}
+	}
+	for _, key := range schnorrOwners.Values() {
+		if hasApproval(getApprovalKey(nonce, key)) {
+			count++
 		}
 	}
 	return count
 }
 
-// Event emission functions
-
-func emitProposalSubmitted(nonce uint64, proposer stygos.Address, to stygos.Address) {
-	eventData := make([]byte, 8+20+20)
-	binary.BigEndian.PutUint64(eventData[:8], nonce)
-	copy(eventData[8:28], proposer[:])
-	copy(eventData[28:48], to[:])
+func wordArg(args []byte, i uint64) stygos.Word {
+	var w stygos.Word
+	copy(w[:], args[32*i:32*i+32])
+	return w
+}
 
-	eventHash := stygos.Keccak256([]byte("ProposalSubmitted(uint64,address,address)"))
-	stygos.EmitEvent(eventData, eventHash)
+func uint64Arg(args []byte, i uint64) uint64 {
+	return stygos.Uint64FromWord(wordArg(args, i))
 }
 
-func emitProposalApproved(nonce uint32, approver stygos.Address) {
-	eventData := make([]byte, 4+20)
-	binary.BigEndian.PutUint32(eventData[:4], nonce)
-	copy(eventData[4:24], approver[:])
+// Event emission functions
 
-	eventHash := stygos.Keccak256([]byte("ProposalApproved(uint32,address)"))
-	stygos.EmitEvent(eventData, eventHash)
+func emitProposalSubmitted(nonce uint64, proposer stygos.Address, to stygos.Address) {
+	eventHash := stygos.Keccak256([]byte("ProposalSubmitted(uint256,address,address)"))
+	stygos.EmitEvent(nil, eventHash, stygos.WordFromUint64(nonce), stygos.PadAddress(proposer), stygos.PadAddress(to))
 }
 
-func emitProposalExecuted(nonce uint32) {
-	eventData := make([]byte, 4)
-	binary.BigEndian.PutUint32(eventData, nonce)
+func emitProposalApproved(nonce uint64, signer stygos.Word) {
+	eventHash := stygos.Keccak256([]byte("ProposalApproved(uint256,bytes32)"))
+	stygos.EmitEvent(nil, eventHash, stygos.WordFromUint64(nonce), signer)
+}
 
-	eventHash := stygos.Keccak256([]byte("ProposalExecuted(uint32)"))
-	stygos.EmitEvent(eventData, eventHash)
+func emitProposalExecuted(nonce uint64) {
+	eventHash := stygos.Keccak256([]byte("ProposalExecuted(uint256)"))
+	stygos.EmitEvent(nil, eventHash, stygos.WordFromUint64(nonce))
 }
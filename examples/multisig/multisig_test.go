@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/eip712"
+	"github.com/rafaelescrich/stygos/schnorr"
+)
+
+var (
+	wallet   = stygos.Address{0x3a}
+	relayer  = stygos.Address{0x12}
+	recorder = stygos.Address{0x7e}
+	aliceSK  = big.NewInt(0xa11ce)
+	bobSK    = big.NewInt(0xb0b)
+	carolSK  = big.NewInt(0xca401)
+	daveSK   = big.NewInt(0xda5e)
+)
+
+func cmd(c byte, args ...[]byte) []byte {
+	out := []byte{c}
+	for _, a := range args {
+		out = append(out, a...)
+	}
+	return out
+}
+
+func word(w stygos.Word) []byte { return w[:] }
+
+func num(n uint64) []byte { return word(stygos.WordFromUint64(n)) }
+
+func addr(a stygos.Address) []byte { return word(stygos.PadAddress(a)) }
+
+func schnorrKey(t *testing.T, d *big.Int) []byte {
+	t.Helper()
+	key, err := schnorr.PublicKey(d)
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	return key
+}
+
+// setup deploys a 2-of-3 wallet owned by alice and bob (ECDSA) and carol
+// (Schnorr), holding 1000 wei.
+func setup(t *testing.T) *stygos.MockRuntime {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = relayer
+	stygos.UseRuntime(mock)
+	ecdsa.InstallMockEcrecover(mock)
+	mock.Deploy(wallet, stygos.MockEntrypoint(entrypoint))
+	mock.SetBalance(wallet, big.NewInt(1000))
+
+	init := cmd(CMD_INITIALIZE, num(2), num(2),
+		addr(ecdsa.AddressOf(aliceSK)), addr(ecdsa.AddressOf(bobSK)), schnorrKey(t, carolSK))
+	if _, err := stygos.Call(wallet, stygos.Word{}, init); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return mock
+}
+
+// submit proposes a call as alice and returns its nonce.
+func submit(t *testing.T, mock *stygos.MockRuntime, to stygos.Address, value uint64, data []byte) uint64 {
+	t.Helper()
+	mock.Contract = ecdsa.AddressOf(aliceSK)
+	defer func() { mock.Contract = relayer }()
+	ret, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_SUBMIT_PROPOSAL, addr(to), num(value), data))
+	if err != nil || len(ret) != 32 {
+		t.Fatalf("submit failed: %x, %v", ret, err)
+	}
+	var w stygos.Word
+	copy(w[:], ret)
+	return stygos.Uint64FromWord(w)
+}
+
+// digest computes the EIP-712 hash of a proposal independently of the
+// contract.
+func digest(mock *stygos.MockRuntime, to stygos.Address, value uint64, data []byte, nonce uint64) stygos.Word {
+	domain := eip712.Domain{Name: "Multisig", Version: "1", ChainID: mock.Chain, VerifyingContract: wallet}
+	p := Proposal{To: to, Value: stygos.WordFromUint64(value), Data: data}
+	return eip712.Digest(domain.Separator(), p.Hash(nonce))
+}
+
+func signECDSA(t *testing.T, d *big.Int, hash stygos.Word) []byte {
+	t.Helper()
+	sig, err := ecdsa.Sign(d, hash)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	return sig.Bytes()
+}
+
+func signSchnorr(t *testing.T, d *big.Int, hash stygos.Word) []byte {
+	t.Helper()
+	sig, err := schnorr.Sign(d, hash[:], make([]byte, 32))
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	return append(schnorrKey(t, d), sig...)
+}
+
+func approve(nonce uint64, sig []byte) error {
+	_, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_APPROVE_PROPOSAL, num(nonce), sig))
+	return err
+}
+
+func execute(nonce uint64) ([]byte, error) {
+	return stygos.Call(wallet, stygos.Word{}, cmd(CMD_EXECUTE_PROPOSAL, num(nonce)))
+}
+
+func TestInitializeOwners(t *testing.T) {
+	mock := setup(t)
+
+	ret, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_OWNERS))
+	if err != nil || len(ret) != 2*32 {
+		t.Fatalf("get owners failed: %x, %v", ret, err)
+	}
+	for i, d := range []*big.Int{aliceSK, bobSK} {
+		var w stygos.Word
+		copy(w[:], ret[i*32:])
+		if stygos.AddressFromWord(w) != ecdsa.AddressOf(d) {
+			t.Errorf("owner %d = %x, want %x", i, stygos.AddressFromWord(w), ecdsa.AddressOf(d))
+		}
+	}
+	ret, err = stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_SCHNORR_OWNERS))
+	if err != nil || !bytes.Equal(ret, schnorrKey(t, carolSK)) {
+		t.Errorf("get schnorr owners failed: %x, %v", ret, err)
+	}
+	ret, err = stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_THRESHOLD))
+	if err != nil || !bytes.Equal(ret, num(2)) {
+		t.Errorf("get threshold failed: %x, %v", ret, err)
+	}
+
+	if _, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_INITIALIZE, num(1), num(1), addr(relayer))); err == nil {
+		t.Error("initialize failed. Expected a second call to revert")
+	}
+
+	// Invalid configurations
+	other := stygos.Address{0x3b}
+	mock.Deploy(other, stygos.MockEntrypoint(entrypoint))
+	tests := []struct {
+		name string
+		args []byte
+	}{
+		{"zero threshold", cmd(CMD_INITIALIZE, num(0), num(1), addr(relayer))},
+		{"threshold above owners", cmd(CMD_INITIALIZE, num(2), num(1), addr(relayer))},
+		{"duplicate owner", cmd(CMD_INITIALIZE, num(1), num(2), addr(relayer), addr(relayer))},
+		{"zero owner", cmd(CMD_INITIALIZE, num(1), num(1), addr(stygos.Address{}))},
+		{"key off the curve", cmd(CMD_INITIALIZE, num(1), num(0), num(5))},
+		{"count above keys", cmd(CMD_INITIALIZE, num(1), num(2), addr(relayer))},
+	}
+	for _, tt := range tests {
+		if _, err := stygos.Call(other, stygos.Word{}, tt.args); err == nil {
+			t.Errorf("initialize %s failed. Expected a revert", tt.name)
+		}
+	}
+}
+
+func TestProposalFlow(t *testing.T) {
+	mock := setup(t)
+	var received []byte
+	mock.Deploy(recorder, func(input []byte) ([]byte, error) {
+		received = input
+		return []byte("done"), nil
+	})
+
+	data := []byte("hello")
+	if _, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_SUBMIT_PROPOSAL, addr(recorder), num(300), data)); err == nil {
+		t.Error("submit failed. Expected a revert for a non-owner")
+	}
+	nonce := submit(t, mock, recorder, 300, data)
+	hash := digest(mock, recorder, 300, data, nonce)
+
+	ret, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_PROPOSAL_HASH, num(nonce)))
+	if err != nil || !bytes.Equal(ret, hash[:]) {
+		t.Errorf("get proposal hash failed. Expected %x, got %x, %v", hash, ret, err)
+	}
+
+	// Signatures by non-owners, over other hashes or in other domains fail
+	wrongChain := mock.Chain
+	mock.Chain++
+	foreign := digest(mock, recorder, 300, data, nonce)
+	mock.Chain = wrongChain
+	for name, sig := range map[string][]byte{
+		"non-owner":     signECDSA(t, daveSK, hash),
+		"other nonce":   signECDSA(t, aliceSK, digest(mock, recorder, 300, data, nonce+1)),
+		"other chain":   signECDSA(t, aliceSK, foreign),
+		"schnorr forge": append(schnorrKey(t, carolSK), signSchnorr(t, daveSK, hash)[32:]...),
+		"short":         {1, 2, 3},
+	} {
+		if err := approve(nonce, sig); err == nil {
+			t.Errorf("approve %s failed. Expected a revert", name)
+		}
+	}
+
+	if err := approve(nonce, signECDSA(t, aliceSK, hash)); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	if err := approve(nonce, signECDSA(t, aliceSK, hash)); err == nil {
+		t.Error("approve failed. Expected a duplicate approval to revert")
+	}
+	if _, err := execute(nonce); err == nil {
+		t.Error("execute failed. Expected a revert below the threshold")
+	}
+	if err := approve(nonce, signSchnorr(t, carolSK, hash)); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+
+	ret, err = stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_PROPOSAL, num(nonce)))
+	if err != nil || len(ret) != 4*32+len(data) || !bytes.Equal(ret[3*32:4*32], num(2)) {
+		t.Errorf("get proposal failed. Expected 2 approvals, got %x, %v", ret, err)
+	}
+
+	ret, err = execute(nonce)
+	if err != nil || string(ret) != "done" {
+		t.Fatalf("execute failed: %q, %v", ret, err)
+	}
+	if !bytes.Equal(received, data) || mock.BalanceOf(recorder).Int64() != 300 {
+		t.Errorf("execute failed. Expected the call with 300 wei, got %q, %v", received, mock.BalanceOf(recorder))
+	}
+	if _, err := execute(nonce); err == nil {
+		t.Error("execute failed. Expected a second execution to revert")
+	}
+	if err := approve(nonce, signECDSA(t, bobSK, hash)); err == nil {
+		t.Error("approve failed. Expected approving an executed proposal to revert")
+	}
+
+	// A call the wallet cannot fund reverts and leaves the proposal pending
+	nonce = submit(t, mock, recorder, 5000, nil)
+	hash = digest(mock, recorder, 5000, nil, nonce)
+	approve(nonce, signECDSA(t, aliceSK, hash))
+	approve(nonce, signECDSA(t, bobSK, hash))
+	if _, err := execute(nonce); err == nil {
+		t.Error("execute failed. Expected an unfunded call to revert")
+	}
+	mock.SetBalance(wallet, big.NewInt(5000))
+	if _, err := execute(nonce); err != nil {
+		t.Errorf("execute failed after funding: %v", err)
+	}
+}
+
+// A plain ETH transfer, with its digest and the owners' signatures made
+// outside this package with eth_signTypedData_v4's encoding
+func TestPlainTransferVector(t *testing.T) {
+	mock := setup(t)
+	mock.Chain = 1
+	mock.Deploy(recorder, func(input []byte) ([]byte, error) { return nil, nil })
+
+	nonce := submit(t, mock, recorder, 100, nil)
+	ret, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_PROPOSAL_HASH, num(nonce)))
+	if want := "9eb69f34901a94ea20797db65db38464b6d9e013205d2df435c70f3324ee1820"; nonce != 0 || err != nil || hex.EncodeToString(ret) != want {
+		t.Fatalf("get proposal hash failed. Expected nonce 0 and %s, got %d, %x, %v", want, nonce, ret, err)
+	}
+	for _, sig := range []string{
+		"7592aab5d43618dda13fba71e3993cd7517a712d3da49664c06ee1bd3d1f70af" +
+			"2f309f974eb2f38ad7b8e3ef46134ed192f71a5438a62fa922f63ec7891e86dc1c", // alice
+		"e5740e63bad28081ed7cf654dd6c19029ca03382fc05ab5f5dda81f2c55b845b" +
+			"5c205d3cec5a2852828ec0d7d64b6622caf1177c919d3e1b94618f5cf31e70c31c", // bob
+	} {
+		raw, _ := hex.DecodeString(sig)
+		if err := approve(nonce, raw); err != nil {
+			t.Fatalf("approve failed: %v", err)
+		}
+	}
+	if _, err := execute(nonce); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+	if got := mock.BalanceOf(recorder); got.Int64() != 100 {
+		t.Errorf("execute failed. Expected the recipient to receive 100, got %v", got)
+	}
+}
+
+func TestApproveBatch(t *testing.T) {
+	mock := setup(t)
+	mock.Deploy(recorder, func(input []byte) ([]byte, error) { return nil, nil })
+	nonce := submit(t, mock, recorder, 0, nil)
+	hash := digest(mock, recorder, 0, nil, nonce)
+
+	entry := func(d *big.Int) []byte {
+		a := ecdsa.AddressOf(d)
+		return append(a[:], signECDSA(t, d, hash)...)
+	}
+	first, second := entry(aliceSK), entry(bobSK)
+	if bytes.Compare(first[:20], second[:20]) > 0 {
+		first, second = second, first
+	}
+	batch := func(entries ...[]byte) error {
+		_, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_APPROVE_BATCH, append([][]byte{num(nonce)}, entries...)...))
+		return err
+	}
+
+	for name, entries := range map[string][][]byte{
+		"unsorted":  {second, first},
+		"duplicate": {first, first},
+		"non-owner": {entry(daveSK)},
+		"empty":     nil,
+		"truncated": {first[:84]},
+	} {
+		if err := batch(entries...); err == nil {
+			t.Errorf("approve batch %s failed. Expected a revert", name)
+		}
+	}
+	if _, err := execute(nonce); err == nil {
+		t.Error("execute failed. Expected a revert before any approval")
+	}
+
+	if err := batch(first, second); err != nil {
+		t.Fatalf("approve batch failed: %v", err)
+	}
+	if err := batch(first); err == nil {
+		t.Error("approve batch failed. Expected a repeated approval to revert")
+	}
+	if _, err := execute(nonce); err != nil {
+		t.Errorf("execute failed after a batch approval: %v", err)
+	}
+}
+
+// govern submits, approves by alice and bob, and executes a call the
+// wallet makes on itself.
+func govern(t *testing.T, mock *stygos.MockRuntime, data []byte) error {
+	t.Helper()
+	nonce := submit(t, mock, wallet, 0, data)
+	hash := digest(mock, wallet, 0, data, nonce)
+	for _, d := range []*big.Int{aliceSK, bobSK} {
+		if err := approve(nonce, signECDSA(t, d, hash)); err != nil {
+			t.Fatalf("approve failed: %v", err)
+		}
+	}
+	_, err := execute(nonce)
+	return err
+}
+
+func TestGovernance(t *testing.T) {
+	mock := setup(t)
+	dave := ecdsa.AddressOf(daveSK)
+
+	// Only the wallet may change its owners
+	mock.Contract = ecdsa.AddressOf(aliceSK)
+	if _, err := stygos.Call(wallet, stygos.Word{}, cmd(CMD_ADD_OWNER, addr(dave))); err == nil {
+		t.Error("add owner failed. Expected a revert for an owner calling directly")
+	}
+	mock.Contract = relayer
+
+	if err := govern(t, mock, cmd(CMD_ADD_OWNER, addr(dave))); err != nil {
+		t.Fatalf("add owner failed: %v", err)
+	}
+	if err := govern(t, mock, cmd(CMD_CHANGE_THRESHOLD, num(4))); err != nil {
+		t.Fatalf("change threshold failed: %v", err)
+	}
+	ret, _ := stygos.Call(wallet, stygos.Word{}, cmd(CMD_GET_THRESHOLD))
+	if !bytes.Equal(ret, num(4)) {
+		t.Errorf("change threshold failed. Expected 4, got %x", ret)
+	}
+
+	// With a threshold of 4, two approvals no longer suffice
+	if err := govern(t, mock, cmd(CMD_CHANGE_THRESHOLD, num(2))); err == nil {
+		t.Fatal("execute failed. Expected a revert below the new threshold")
+	}
+	nonce := getNonceOf(mock) - 1
+	hash := digest(mock, wallet, 0, cmd(CMD_CHANGE_THRESHOLD, num(2)), nonce)
+	approve(nonce, signSchnorr(t, carolSK, hash))
+	approve(nonce, signECDSA(t, daveSK, hash))
+	if _, err := execute(nonce); err != nil {
+		t.Fatalf("change threshold failed: %v", err)
+	}
+
+	// Removing owners cannot leave fewer owners than the threshold
+	if err := govern(t, mock, cmd(CMD_REMOVE_SCHNORR_OWNER, schnorrKey(t, carolSK))); err != nil {
+		t.Fatalf("remove schnorr owner failed: %v", err)
+	}
+	if err := govern(t, mock, cmd(CMD_REMOVE_OWNER, addr(dave))); err != nil {
+		t.Fatalf("remove owner failed: %v", err)
+	}
+	if err := govern(t, mock, cmd(CMD_REMOVE_OWNER, addr(ecdsa.AddressOf(bobSK)))); err == nil {
+		t.Error("remove owner failed. Expected a revert below the threshold")
+	}
+
+	// Removed owners' approvals no longer count
+	nonce = submit(t, mock, recorder, 0, nil)
+	hash = digest(mock, recorder, 0, nil, nonce)
+	if err := approve(nonce, signECDSA(t, daveSK, hash)); err == nil {
+		t.Error("approve failed. Expected a revert for a removed owner")
+	}
+}
+
+func getNonceOf(mock *stygos.MockRuntime) uint64 {
+	return stygos.Uint64FromWord(mock.StorageOf(wallet)[nonceKey])
+}
@@ -2,24 +2,23 @@ package main
 
 import (
 	"encoding/binary"
-	"math/big"
 
 	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/encoding/base64"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/erc2981"
+	"github.com/rafaelescrich/stygos/erc5192"
+	"github.com/rafaelescrich/stygos/metadata"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/strconvx"
+	"github.com/rafaelescrich/stygos/svg"
 )
 
 // Simple NFT contract implementation
 // Demonstrates NFT functionality using Stygos
 
-// Storage keys
-var (
-	nameKey        = stygos.Keccak256([]byte("name"))
-	symbolKey      = stygos.Keccak256([]byte("symbol"))
-	totalSupplyKey = stygos.Keccak256([]byte("totalSupply"))
-	ownerPrefix    = stygos.Keccak256([]byte("owner"))
-	balancePrefix  = stygos.Keccak256([]byte("balance"))
-	approvalPrefix = stygos.Keccak256([]byte("approval"))
-	metadataPrefix = stygos.Keccak256([]byte("metadata"))
-)
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go nameKey=name symbolKey=symbol totalSupplyKey=totalSupply ownerPrefix=owner balancePrefix=balance approvalPrefix=approval metadataPrefix=metadata royaltyKey=royalty locksKey=locks
 
 // Commands
 const (
@@ -33,8 +32,40 @@ const (
 	CMD_GET_APPROVAL  = 7
 	CMD_SET_METADATA  = 8
 	CMD_GET_METADATA  = 9
+	CMD_SET_ROYALTY   = 10
+	CMD_TOKEN_URI     = 11
+	CMD_MINT_LOCKED   = 12
 )
 
+// selTokenURI is the selector of ERC-721 tokenURI(uint256).
+var selTokenURI = stygos.Selector{0xc8, 0x7b, 0x56, 0xdd}
+
+// Royalties (ERC-2981), soulbound tokens (ERC-5192) and interface
+// detection (ERC-165)
+var (
+	interfaces = erc165.NewRegistry()
+	royalties  = erc2981.NewRoyalties(royaltyKey)
+	locks      = erc5192.NewLocks(locksKey)
+	abiRouter  = newABIRouter()
+)
+
+func newABIRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	interfaces.Mount(r)
+	royalties.Mount(r, interfaces)
+	locks.Mount(r, interfaces, exists)
+	r.HandleSelector(selTokenURI, handleTokenURIABI)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
 //export entrypoint
 func entrypoint() int32 {
 	callData, err := stygos.GetCallData()
@@ -42,6 +73,15 @@ func entrypoint() int32 {
 		return 1 // Invalid input
 	}
 
+	if isStandardCall(callData) {
+		result, err := abiRouter.Dispatch(callData)
+		if err != nil {
+			return 1
+		}
+		stygos.SetReturnData(result)
+		return 0
+	}
+
 	command := callData[0]
 	args := callData[1:]
 
@@ -50,6 +90,8 @@ func entrypoint() int32 {
 		return handleInitialize(args)
 	case CMD_MINT:
 		return handleMint(args)
+	case CMD_MINT_LOCKED:
+		return handleMintLocked(args)
 	case CMD_TRANSFER:
 		return handleTransfer(args)
 	case CMD_APPROVE:
@@ -66,12 +108,38 @@ func entrypoint() int32 {
 		return handleSetMetadata(args)
 	case CMD_GET_METADATA:
 		return handleGetMetadata(args)
+	case CMD_SET_ROYALTY:
+		return handleSetRoyalty(args)
+	case CMD_TOKEN_URI:
+		return handleTokenURI(args)
 	default:
 		return 1 // Unknown command
 	}
 }
 
-// handleInitialize initializes the NFT contract
+// isStandardCall reports whether callData is one of the ABI calls that
+// wallets and marketplaces make: supportsInterface(bytes4),
+// royaltyInfo(uint256,uint256), tokenURI(uint256) and locked(uint256). Commands are a single
+// byte followed by their arguments, so calldata is only treated as ABI when
+// both the selector and the exact length match.
+func isStandardCall(callData []byte) bool {
+	if len(callData) < 4 {
+		return false
+	}
+	var sel stygos.Selector
+	copy(sel[:], callData[:4])
+	switch sel {
+	case erc165.InterfaceID, selTokenURI, erc5192.InterfaceID:
+		return len(callData) == 4+32
+	case erc2981.InterfaceID:
+		return len(callData) == 4+64
+	}
+	return false
+}
+
+// handleInitialize initializes the NFT contract. The arguments may end with
+// a 20-byte receiver and a 2-byte fee in basis points to set the default
+// royalty of the collection.
 func handleInitialize(args []byte) int32 {
 	if len(args) < 2 {
 		return 1
@@ -88,15 +156,23 @@ func handleInitialize(args []byte) int32 {
 	symbol := args[2+nameLen : 2+nameLen+symbolLen]
 
 	// Store name and symbol
-	nameWord := stygos.WordFromBigInt(new(big.Int).SetBytes(name))
-	stygos.StorageStore(nameKey, nameWord)
-
-	symbolWord := stygos.WordFromBigInt(new(big.Int).SetBytes(symbol))
-	stygos.StorageStore(symbolKey, symbolWord)
+	storage.StoreBytes(nameKey, name)
+	storage.StoreBytes(symbolKey, symbol)
 
 	// Initialize total supply
 	stygos.StorageStore(totalSupplyKey, stygos.WordFromUint64(0))
 
+	// Optional default royalty
+	royalty := args[2+nameLen+symbolLen:]
+	if len(royalty) >= 22 {
+		var receiver stygos.Address
+		copy(receiver[:], royalty[:20])
+		feeBps := binary.BigEndian.Uint16(royalty[20:22])
+		if royalties.SetDefault(receiver, feeBps) != nil {
+			return 1
+		}
+	}
+
 	return 0
 }
 
@@ -108,27 +184,56 @@ func handleMint(args []byte) int32 {
 
 	var to stygos.Address
 	copy(to[:], args[:20])
+	_, ok := mint(to)
+	if !ok {
+		return 1
+	}
+	return 0
+}
+
+// handleMintLocked mints a soulbound NFT, which can be burned but never
+// transferred, and emits the ERC-5192 Locked event
+func handleMintLocked(args []byte) int32 {
+	if len(args) < 20 {
+		return 1
+	}
+
+	var to stygos.Address
+	copy(to[:], args[:20])
+	tokenId, ok := mint(to)
+	if !ok {
+		return 1
+	}
+	locks.Lock(stygos.NewU256(tokenId))
+	return 0
+}
+
+// mint mints the next NFT to to and returns its id
+func mint(to stygos.Address) (uint64, bool) {
 
 	// Get current total supply
 	totalSupply := stygos.Uint64FromWord(stygos.StorageLoad(totalSupplyKey))
-	tokenId := totalSupply + 1
+	tokenId, ok := stygos.SafeAddU64(totalSupply, 1)
+	if !ok {
+		return 0, false
+	}
+
+	// Update balance
+	if !addBalance(to) {
+		return 0, false
+	}
 
 	// Set owner
 	ownerKey := getOwnerKey(tokenId)
 	stygos.StorageStore(ownerKey, stygos.PadAddress(to))
 
-	// Update balance
-	balanceKey := getBalanceKey(to)
-	currentBalance := stygos.Uint64FromWord(stygos.StorageLoad(balanceKey))
-	stygos.StorageStore(balanceKey, stygos.WordFromUint64(currentBalance+1))
-
 	// Update total supply
 	stygos.StorageStore(totalSupplyKey, stygos.WordFromUint64(tokenId))
 
 	// Emit event
 	emitTransfer(stygos.Address{}, to, tokenId)
 
-	return 0
+	return tokenId, true
 }
 
 // handleTransfer transfers an NFT
@@ -151,17 +256,18 @@ func handleTransfer(args []byte) int32 {
 		return 1
 	}
 
+	// Soulbound tokens stay with their owner
+	if locks.CheckTransfer(currentOwner, to, stygos.NewU256(tokenId)) != nil {
+		return 1
+	}
+
 	// Update owner
 	stygos.StorageStore(ownerKey, stygos.PadAddress(to))
 
 	// Update balances
-	fromBalanceKey := getBalanceKey(currentOwner)
-	fromBalance := stygos.Uint64FromWord(stygos.StorageLoad(fromBalanceKey))
-	stygos.StorageStore(fromBalanceKey, stygos.WordFromUint64(fromBalance-1))
-
-	toBalanceKey := getBalanceKey(to)
-	toBalance := stygos.Uint64FromWord(stygos.StorageLoad(toBalanceKey))
-	stygos.StorageStore(toBalanceKey, stygos.WordFromUint64(toBalance+1))
+	if !subBalance(currentOwner) || !addBalance(to) {
+		return 1
+	}
 
 	// Clear approval
 	approvalKey := getApprovalKey(tokenId)
@@ -234,17 +340,18 @@ func handleTransferFrom(args []byte) int32 {
 		return 1
 	}
 
+	// Soulbound tokens stay with their owner
+	if locks.CheckTransfer(from, to, stygos.NewU256(tokenId)) != nil {
+		return 1
+	}
+
 	// Update owner
 	stygos.StorageStore(ownerKey, stygos.PadAddress(to))
 
 	// Update balances
-	fromBalanceKey := getBalanceKey(from)
-	fromBalance := stygos.Uint64FromWord(stygos.StorageLoad(fromBalanceKey))
-	stygos.StorageStore(fromBalanceKey, stygos.WordFromUint64(fromBalance-1))
-
-	toBalanceKey := getBalanceKey(to)
-	toBalance := stygos.Uint64FromWord(stygos.StorageLoad(toBalanceKey))
-	stygos.StorageStore(toBalanceKey, stygos.WordFromUint64(toBalance+1))
+	if !subBalance(from) || !addBalance(to) {
+		return 1
+	}
 
 	// Clear approval
 	stygos.StorageStore(approvalKey, stygos.WordFromUint64(0))
@@ -308,20 +415,21 @@ func handleGetApproval(args []byte) int32 {
 	return 0
 }
 
-// handleSetMetadata sets metadata for an NFT
+// handleSetMetadata sets the description of an NFT. The description is
+// stored in full, whatever its length, and shown by tokenURI.
 func handleSetMetadata(args []byte) int32 {
-	if len(args) < 9 {
+	if len(args) < 10 {
 		return 1
 	}
 
 	tokenId := binary.BigEndian.Uint64(args[:8])
-	metadataLen := int(args[8])
+	metadataLen := int(binary.BigEndian.Uint16(args[8:10]))
 
-	if len(args) < 9+metadataLen {
+	if len(args) < 10+metadataLen {
 		return 1
 	}
 
-	metadata := args[9 : 9+metadataLen]
+	description := args[10 : 10+metadataLen]
 
 	// Check ownership
 	ownerKey := getOwnerKey(tokenId)
@@ -333,9 +441,7 @@ func handleSetMetadata(args []byte) int32 {
 	}
 
 	// Store metadata
-	metadataKey := getMetadataKey(tokenId)
-	metadataWord := stygos.WordFromBigInt(new(big.Int).SetBytes(metadata))
-	stygos.StorageStore(metadataKey, metadataWord)
+	storage.StoreBytes(getMetadataKey(tokenId), description)
 
 	return 0
 }
@@ -347,20 +453,124 @@ func handleGetMetadata(args []byte) int32 {
 	}
 
 	tokenId := binary.BigEndian.Uint64(args[:8])
-	metadataKey := getMetadataKey(tokenId)
-	metadata := stygos.StorageLoad(metadataKey)
+	stygos.SetReturnData(storage.LoadBytes(getMetadataKey(tokenId)))
+	return 0
+}
 
-	metadataBytes := stygos.BigIntFromWord(metadata).Bytes()
-	stygos.SetReturnData(metadataBytes)
+// handleTokenURI returns the token URI of an NFT as raw bytes
+func handleTokenURI(args []byte) int32 {
+	if len(args) < 8 {
+		return 1
+	}
+
+	m, ok := tokenMetadata(binary.BigEndian.Uint64(args[:8]))
+	if !ok {
+		return 1
+	}
+	stygos.SetReturnData([]byte(m.TokenURI()))
+	return 0
+}
+
+// handleTokenURIABI serves ERC-721 tokenURI(uint256), returning an ABI
+// encoded string. The data URI is written straight into the return data.
+func handleTokenURIABI(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	var id stygos.Word
+	copy(id[:], args)
+	m, ok := tokenMetadata(stygos.Uint64FromWord(id))
+	if !ok {
+		return nil, stygos.ErrInvalidInput
+	}
+	doc := m.JSON()
+	uri := svg.NewResult(len(metadata.JSONPrefix) + base64.EncodedLen(len(doc)))
+	return uri.Raw(metadata.JSONPrefix).Base64(doc).Result()
+}
+
+// exists reports whether tokenID has been minted.
+func exists(tokenID stygos.U256) bool {
+	totalSupply := stygos.NewU256(stygos.Uint64FromWord(stygos.StorageLoad(totalSupplyKey)))
+	return !tokenID.IsZero() && !totalSupply.Lt(tokenID)
+}
+
+// tokenMetadata builds the fully on-chain metadata of a minted token, with
+// a generated SVG image.
+func tokenMetadata(tokenId uint64) (metadata.Metadata, bool) {
+	totalSupply := stygos.Uint64FromWord(stygos.StorageLoad(totalSupplyKey))
+	if tokenId == 0 || tokenId > totalSupply {
+		return metadata.Metadata{}, false
+	}
+	owner := stygos.AddressFromWord(stygos.StorageLoad(getOwnerKey(tokenId)))
+
+	id := strconvx.FormatUint(tokenId)
+	return metadata.Metadata{
+		Name:        string(storage.LoadBytes(nameKey)) + " #" + id,
+		Description: string(storage.LoadBytes(getMetadataKey(tokenId))),
+		Image:       metadata.ImageURI(renderImage(tokenId)),
+		Attributes: []metadata.Attribute{
+			metadata.Number("Token ID", tokenId),
+			metadata.Text("Owner", strconvx.EncodeHex(owner[:])),
+		},
+	}, true
+}
+
+// ring draws one of the concentric circles of a token image.
+var ring = svg.Compile(`<circle cx="50" cy="50" r="{}" fill="none" stroke="#{}" stroke-width="{}"/>`)
+
+// renderImage draws a token as rings colored from the hash of its id.
+func renderImage(tokenId uint64) []byte {
+	id := stygos.WordFromUint64(tokenId)
+	seed := stygos.Keccak256(id[:])
+
+	b := svg.NewBuilder(512)
+	b.Open("svg").Attr("xmlns", svg.Namespace).Attr("viewBox", "0 0 100 100").Close()
+	b.Open("rect").Attr("width", "100").Attr("height", "100").Attr("fill", "#"+hexColor(seed[0:3])).SelfClose()
+	for i := 0; i < 4; i++ {
+		radius := uint64(40 - 9*i)
+		width := svg.Fixed(int64(10+seed[3+i]%30), 1) // 1 to 3.9
+		ring.Execute(b, svg.Uint(radius), svg.Raw(hexColor(seed[8+3*i:11+3*i])), width)
+	}
+	b.Open("text").Attr("x", "50").Attr("y", "55").Attr("text-anchor", "middle").Attr("fill", "#fff").Close()
+	b.Raw("#").Uint(tokenId).End("text")
+	b.End("svg")
+	return b.Bytes()
+}
+
+// hexColor returns the hex digits of a three byte color.
+func hexColor(rgb []byte) string {
+	var buf [6]byte
+	return string(strconvx.AppendHex(buf[:0], rgb))
+}
+
+// handleSetRoyalty sets the royalty of a token, overriding the collection
+// default. Only the token owner may set it.
+func handleSetRoyalty(args []byte) int32 {
+	if len(args) < 30 {
+		return 1
+	}
+
+	tokenId := binary.BigEndian.Uint64(args[:8])
+	var receiver stygos.Address
+	copy(receiver[:], args[8:28])
+	feeBps := binary.BigEndian.Uint16(args[28:30])
+
+	// Check ownership
+	ownerKey := getOwnerKey(tokenId)
+	owner := stygos.AddressFromWord(stygos.StorageLoad(ownerKey))
+
+	caller := getCaller()
+	if owner != caller {
+		return 1
+	}
+
+	if royalties.SetToken(stygos.NewU256(tokenId), receiver, feeBps) != nil {
+		return 1
+	}
 	return 0
 }
 
 // Helper functions
 
 func getCaller() stygos.Address {
-	// In a real implementation, this would get the caller address
-	// For now, return a mock address
-	return stygos.Address{}
+	return stygos.GetMsgSender()
 }
 
 func getOwnerKey(tokenId uint64) stygos.Word {
@@ -373,6 +583,26 @@ func getBalanceKey(owner stygos.Address) stygos.Word {
 	return stygos.Keccak256(append(balancePrefix[:], owner[:]...))
 }
 
+// addBalance increments the token count of owner, failing on overflow
+func addBalance(owner stygos.Address) bool {
+	key := getBalanceKey(owner)
+	balance, ok := stygos.SafeAddU64(stygos.Uint64FromWord(stygos.StorageLoad(key)), 1)
+	if ok {
+		stygos.StorageStore(key, stygos.WordFromUint64(balance))
+	}
+	return ok
+}
+
+// subBalance decrements the token count of owner, failing if it holds none
+func subBalance(owner stygos.Address) bool {
+	key := getBalanceKey(owner)
+	balance, ok := stygos.SafeSubU64(stygos.Uint64FromWord(stygos.StorageLoad(key)), 1)
+	if ok {
+		stygos.StorageStore(key, stygos.WordFromUint64(balance))
+	}
+	return ok
+}
+
 func getApprovalKey(tokenId uint64) stygos.Word {
 	tokenIdBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(tokenIdBytes, tokenId)
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/encoding/base64"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/erc2981"
+	"github.com/rafaelescrich/stygos/erc5192"
+	"github.com/rafaelescrich/stygos/metadata"
+)
+
+func royaltyInfoCall(tokenId, salePrice uint64) []byte {
+	call := append([]byte{}, erc2981.InterfaceID[:]...)
+	id := stygos.WordFromUint64(tokenId)
+	price := stygos.WordFromUint64(salePrice)
+	call = append(call, id[:]...)
+	return append(call, price[:]...)
+}
+
+func TestRoyalties(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	// name "N", symbol "S", default royalty 5% to the artist
+	artist := stygos.Address{0xa1}
+	args := []byte{CMD_INITIALIZE, 1, 1, 'N', 'S'}
+	args = append(args, artist[:]...)
+	args = append(args, 0x01, 0xf4) // 500 bps
+	mock.Args = args
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("initialize returned %d", status)
+	}
+
+	// Mint token 1 to the caller
+	caller := getCaller()
+	mock.Args = append([]byte{CMD_MINT}, caller[:]...)
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("mint returned %d", status)
+	}
+
+	mock.Args = royaltyInfoCall(1, 1000)
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("royaltyInfo returned %d", status)
+	}
+	var recv, amount stygos.Word
+	copy(recv[:], mock.Result[:32])
+	copy(amount[:], mock.Result[32:])
+	if stygos.AddressFromWord(recv) != artist || stygos.Uint64FromWord(amount) != 50 {
+		t.Errorf("royaltyInfo = %x, %d, want %x, 50", stygos.AddressFromWord(recv), stygos.Uint64FromWord(amount), artist)
+	}
+
+	// The owner overrides the royalty of token 1 with 10% to a collaborator
+	collab := stygos.Address{0xc0}
+	args = make([]byte, 1+8+20+2)
+	args[0] = CMD_SET_ROYALTY
+	binary.BigEndian.PutUint64(args[1:9], 1)
+	copy(args[9:29], collab[:])
+	binary.BigEndian.PutUint16(args[29:], 1000)
+	mock.Args = args
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("set royalty returned %d", status)
+	}
+
+	mock.Args = royaltyInfoCall(1, 1000)
+	entrypoint()
+	copy(recv[:], mock.Result[:32])
+	copy(amount[:], mock.Result[32:])
+	if stygos.AddressFromWord(recv) != collab || stygos.Uint64FromWord(amount) != 100 {
+		t.Errorf("royaltyInfo after override = %x, %d, want %x, 100", stygos.AddressFromWord(recv), stygos.Uint64FromWord(amount), collab)
+	}
+}
+
+func TestSupportsInterface(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	for _, tt := range []struct {
+		id   stygos.Selector
+		want byte
+	}{
+		{erc165.InterfaceID, 1},
+		{erc2981.InterfaceID, 1},
+		{erc5192.InterfaceID, 1},
+		{stygos.Selector{0xde, 0xad, 0xbe, 0xef}, 0},
+	} {
+		call := append([]byte{}, erc165.InterfaceID[:]...)
+		call = append(call, make([]byte, 32)...)
+		copy(call[4:], tt.id[:])
+		mock.Args = call
+		if status := entrypoint(); status != 0 {
+			t.Fatalf("supportsInterface returned %d", status)
+		}
+		if mock.Result[31] != tt.want {
+			t.Errorf("supportsInterface(%x) = %d, want %d", tt.id, mock.Result[31], tt.want)
+		}
+	}
+}
+
+func TestTokenURI(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	if selTokenURI != stygos.SelectorOf("tokenURI(uint256)") {
+		t.Fatalf("tokenURI selector mismatch")
+	}
+
+	mock.Args = append([]byte{CMD_INITIALIZE, 7, 3}, "StygianSTY"...)
+	entrypoint()
+	caller := getCaller()
+	mock.Args = append([]byte{CMD_MINT}, caller[:]...)
+	entrypoint()
+
+	// A description longer than a storage word is kept in full
+	description := strings.Repeat("An on-chain token. ", 10)
+	args := make([]byte, 1+8+2)
+	args[0] = CMD_SET_METADATA
+	binary.BigEndian.PutUint64(args[1:9], 1)
+	binary.BigEndian.PutUint16(args[9:11], uint16(len(description)))
+	mock.Args = append(args, description...)
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("set metadata returned %d", status)
+	}
+
+	mock.Args = make([]byte, 9)
+	mock.Args[0] = CMD_GET_METADATA
+	binary.BigEndian.PutUint64(mock.Args[1:], 1)
+	entrypoint()
+	if string(mock.Result) != description {
+		t.Errorf("get metadata = %q, want %q", mock.Result, description)
+	}
+
+	// ABI tokenURI(uint256) returns a string holding a JSON data URI
+	call := append([]byte{}, selTokenURI[:]...)
+	id := stygos.WordFromUint64(1)
+	mock.Args = append(call, id[:]...)
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("tokenURI returned %d", status)
+	}
+	length := binary.BigEndian.Uint64(mock.Result[56:64])
+	uri := string(mock.Result[64 : 64+length])
+	if !strings.HasPrefix(uri, metadata.JSONPrefix) {
+		t.Fatalf("tokenURI = %q, want a JSON data URI", uri)
+	}
+	raw, err := base64.DecodeString(uri[len(metadata.JSONPrefix):])
+	if err != nil {
+		t.Fatalf("tokenURI is not base64: %v", err)
+	}
+	var doc struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Image       string `json:"image"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("tokenURI is not JSON: %v", err)
+	}
+	if doc.Name != "Stygian #1" || doc.Description != description {
+		t.Errorf("tokenURI metadata = %+v", doc)
+	}
+
+	// The image is a generated SVG
+	const imagePrefix = "data:image/svg+xml;base64,"
+	if !strings.HasPrefix(doc.Image, imagePrefix) {
+		t.Fatalf("tokenURI image = %q, want an SVG data URI", doc.Image)
+	}
+	image, err := base64.DecodeString(doc.Image[len(imagePrefix):])
+	if err != nil {
+		t.Fatalf("tokenURI image is not base64: %v", err)
+	}
+	var img struct {
+		XMLName xml.Name
+		Circles []struct {
+			Stroke string `xml:"stroke,attr"`
+		} `xml:"circle"`
+		Text string `xml:"text"`
+	}
+	if err := xml.Unmarshal(image, &img); err != nil {
+		t.Fatalf("tokenURI image is not XML: %v", err)
+	}
+	if img.XMLName.Local != "svg" || len(img.Circles) != 4 || img.Text != "#1" {
+		t.Errorf("tokenURI image = %s", image)
+	}
+
+	// Unminted tokens have no URI
+	id = stygos.WordFromUint64(2)
+	mock.Args = append(append([]byte{}, selTokenURI[:]...), id[:]...)
+	if status := entrypoint(); status == 0 {
+		t.Errorf("tokenURI of unminted token succeeded")
+	}
+}
+
+func TestTransferUnmintedToken(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	// The caller and the owner of an unminted token are both the zero
+	// address; its balance used to wrap around to 2^64-1
+	to := stygos.Address{0xb0}
+	args := append([]byte{CMD_TRANSFER}, to[:]...)
+	args = append(args, make([]byte, 20)...)
+	binary.BigEndian.PutUint64(args[21:29], 7)
+	mock.Args = args
+	if status := entrypoint(); status == 0 {
+		t.Error("transfer of an unminted token succeeded")
+	}
+}
+
+func TestSoulbound(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	alice, bob := stygos.Address{0xa1}, stygos.Address{0xb0}
+	mock.Args = append([]byte{CMD_INITIALIZE, 1, 1}, "NS"...)
+	entrypoint()
+	mock.Args = append([]byte{CMD_MINT}, alice[:]...)
+	entrypoint()
+	mock.Args = append([]byte{CMD_MINT_LOCKED}, alice[:]...)
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("mint locked returned %d", status)
+	}
+
+	for _, tt := range []struct {
+		token  uint64
+		status int32
+		locked byte
+	}{
+		{1, 0, 0},
+		{2, 0, 1},
+		{3, 1, 0}, // unminted
+	} {
+		id := stygos.WordFromUint64(tt.token)
+		mock.Args = append(append([]byte{}, erc5192.InterfaceID[:]...), id[:]...)
+		if status := entrypoint(); status != tt.status || (status == 0 && mock.Result[31] != tt.locked) {
+			t.Errorf("locked(%d) = %d, %x, want %d, %d", tt.token, status, mock.Result, tt.status, tt.locked)
+		}
+	}
+
+	// The locked token cannot move, directly or through an approval
+	mock.Sender = alice
+	transfer := func(tokenId uint64) int32 {
+		args := append([]byte{CMD_TRANSFER}, bob[:]...)
+		args = append(args, make([]byte, 20)...)
+		binary.BigEndian.PutUint64(args[21:29], tokenId)
+		mock.Args = args
+		return entrypoint()
+	}
+	if status := transfer(2); status == 0 {
+		t.Error("transfer of a soulbound token succeeded")
+	}
+	args := append([]byte{CMD_APPROVE}, bob[:]...)
+	mock.Args = append(args, 0, 0, 0, 0, 0, 0, 0, 2)
+	if status := entrypoint(); status != 0 {
+		t.Fatalf("approve returned %d", status)
+	}
+	mock.Sender = bob
+	args = append([]byte{CMD_TRANSFER_FROM}, alice[:]...)
+	args = append(args, bob[:]...)
+	mock.Args = append(args, make([]byte, 20)...)
+	binary.BigEndian.PutUint64(mock.Args[41:49], 2)
+	if status := entrypoint(); status == 0 {
+		t.Error("transferFrom of a soulbound token succeeded")
+	}
+	mock.Sender = alice
+	if status := transfer(1); status != 0 {
+		t.Errorf("transfer of a regular token returned %d", status)
+	}
+}
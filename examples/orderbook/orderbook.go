@@ -0,0 +1,359 @@
+// Command orderbook settles limit orders signed off-chain with
+// eth_signTypedData. Makers sign orders selling an amount of one ERC-20 for
+// an amount of another and approve the exchange on the token they sell;
+// the order book itself lives off-chain, and only fills touch the chain.
+//
+// A taker fills part or all of an order with fillOrder, paying the maker at
+// the order's price rounded in the maker's favour. A relayer settles two
+// crossing orders against each other with matchOrders, at the price of the
+// first. Each order records the amount of its sell token filled, so it can
+// be filled in pieces until it is exhausted. Makers cancel one order with
+// cancelOrder, or every order below a nonce with cancelUpTo.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/eip712"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go filledKey=filled minNonceKey=minNonce
+
+// ABI selectors
+var (
+	selFillOrder   = stygos.Selector{0xd1, 0x4e, 0x0e, 0x9e} // fillOrder((address,address,address,uint256,uint256,uint256,uint256),bytes,uint256)
+	selMatchOrders = stygos.Selector{0xae, 0xd4, 0x35, 0x33} // matchOrders((address,address,address,uint256,uint256,uint256,uint256),bytes,(address,address,address,uint256,uint256,uint256,uint256),bytes,uint256)
+	selCancelOrder = stygos.Selector{0xd4, 0xaa, 0x7e, 0xdb} // cancelOrder((address,address,address,uint256,uint256,uint256,uint256))
+	selCancelUpTo  = stygos.Selector{0x3c, 0x64, 0x19, 0x10} // cancelUpTo(uint256)
+	selFilled      = stygos.Selector{0x28, 0x8c, 0xdc, 0x91} // filled(bytes32)
+	selMinNonce    = stygos.Selector{0xaa, 0x99, 0xfa, 0x98} // minNonce(address)
+	selHashOrder   = stygos.Selector{0xc1, 0x51, 0x1d, 0x92} // hashOrder((address,address,address,uint256,uint256,uint256,uint256))
+)
+
+// Orderbook errors
+var (
+	ErrInvalidOrder     = errors.New("orderbook: zero amount in order")
+	ErrExpired          = errors.New("orderbook: order expired")
+	ErrCancelled        = errors.New("orderbook: order cancelled")
+	ErrInvalidSignature = errors.New("orderbook: signature is not the maker's")
+	ErrOverfill         = errors.New("orderbook: fill exceeds the remaining amount")
+	ErrZeroFill         = errors.New("orderbook: zero fill")
+	ErrNotMaker         = errors.New("orderbook: caller is not the maker")
+	ErrNonceTooLow      = errors.New("orderbook: nonce must increase")
+	ErrTokenMismatch    = errors.New("orderbook: orders do not trade the same pair")
+	ErrNoCross          = errors.New("orderbook: prices do not cross")
+	ErrOverflow         = errors.New("orderbook: amount overflow")
+)
+
+// orderType is the EIP-712 encoded type of Order.
+const orderType = "Order(address maker,address sellToken,address buyToken,uint256 sellAmount,uint256 buyAmount,uint256 nonce,uint256 expiry)"
+
+// orderWords is the number of ABI words of an encoded Order.
+const orderWords = 7
+
+// cancelled is the filled amount marking a cancelled order.
+var cancelled = stygos.U256{}.Not()
+
+// Order offers SellAmount of SellToken for BuyAmount of BuyToken, or any
+// part of it at the same price, until Expiry.
+type Order struct {
+	Maker      stygos.Address
+	SellToken  stygos.Address
+	BuyToken   stygos.Address
+	SellAmount stygos.U256
+	BuyAmount  stygos.U256
+	Nonce      stygos.U256
+	Expiry     uint64 // unix seconds
+}
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selFillOrder, handleFillOrder)
+	r.HandleSelector(selMatchOrders, handleMatchOrders)
+	r.HandleSelector(selCancelOrder, handleCancelOrder)
+	r.HandleSelector(selCancelUpTo, handleCancelUpTo)
+	r.HandleSelector(selFilled, handleFilled)
+	r.HandleSelector(selMinNonce, handleMinNonce)
+	r.HandleSelector(selHashOrder, handleHashOrder)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// Hash returns the EIP-712 digest the maker signs.
+func (o *Order) Hash() stygos.Word {
+	domain := eip712.NewDomain("Orderbook", "1")
+	return eip712.Digest(domain.Separator(), eip712.HashStruct(eip712.TypeHash(orderType),
+		stygos.PadAddress(o.Maker),
+		stygos.PadAddress(o.SellToken),
+		stygos.PadAddress(o.BuyToken),
+		o.SellAmount.Word(),
+		o.BuyAmount.Word(),
+		o.Nonce.Word(),
+		stygos.WordFromUint64(o.Expiry)))
+}
+
+// handleFillOrder sells the caller sellFill of the order's sell token and
+// returns the amount of the buy token it paid the maker.
+func handleFillOrder(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < (orderWords+2)*32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	o, err := decodeOrder(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signatureArg(args, orderWords)
+	if err != nil {
+		return nil, err
+	}
+	sellFill := stygos.U256FromWord(wordAt(args, orderWords+1))
+	hash, remaining, err := check(o, sig)
+	if err != nil {
+		return nil, err
+	}
+	if sellFill.IsZero() {
+		return nil, ErrZeroFill
+	}
+	if remaining.Lt(sellFill) {
+		return nil, ErrOverfill
+	}
+	buyFill, ok := stygos.MulDivUp(sellFill, o.BuyAmount, o.SellAmount)
+	if !ok {
+		return nil, ErrOverflow
+	}
+	addFill(hash, sellFill)
+
+	taker := stygos.GetMsgSender()
+	if err := token.SafeTransferFrom(token.NewERC20(o.BuyToken), taker, o.Maker, buyFill); err != nil {
+		return nil, err
+	}
+	if err := token.SafeTransferFrom(token.NewERC20(o.SellToken), o.Maker, taker, sellFill); err != nil {
+		return nil, err
+	}
+	return word(buyFill.Word()), nil
+}
+
+// handleMatchOrders settles a left order selling A for B against a right
+// order selling B for A. amount of A moves from the left maker to the right
+// maker, who pays the left order's price for it; the right order's price
+// must be at least as good for its maker. It returns the amount of B paid.
+func handleMatchOrders(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < (2*orderWords+3)*32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	left, err := decodeOrder(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	leftSig, err := signatureArg(args, orderWords)
+	if err != nil {
+		return nil, err
+	}
+	right, err := decodeOrder(args, orderWords+1)
+	if err != nil {
+		return nil, err
+	}
+	rightSig, err := signatureArg(args, 2*orderWords+1)
+	if err != nil {
+		return nil, err
+	}
+	amount := stygos.U256FromWord(wordAt(args, 2*orderWords+2))
+	if left.SellToken != right.BuyToken || left.BuyToken != right.SellToken {
+		return nil, ErrTokenMismatch
+	}
+	leftHash, leftRemaining, err := check(left, leftSig)
+	if err != nil {
+		return nil, err
+	}
+	rightHash, rightRemaining, err := check(right, rightSig)
+	if err != nil {
+		return nil, err
+	}
+	if amount.IsZero() {
+		return nil, ErrZeroFill
+	}
+	if leftRemaining.Lt(amount) {
+		return nil, ErrOverfill
+	}
+
+	// The right maker pays the left price, rounded up, and accepts at most
+	// its own price, rounded down: price B/A of right >= price B/A of left.
+	pay, ok := stygos.MulDivUp(amount, left.BuyAmount, left.SellAmount)
+	if !ok {
+		return nil, ErrOverflow
+	}
+	limit, ok := stygos.MulDiv(amount, right.SellAmount, right.BuyAmount)
+	if !ok || limit.Lt(pay) {
+		return nil, ErrNoCross
+	}
+	if rightRemaining.Lt(pay) {
+		return nil, ErrOverfill
+	}
+	addFill(leftHash, amount)
+	addFill(rightHash, pay)
+
+	if err := token.SafeTransferFrom(token.NewERC20(left.SellToken), left.Maker, right.Maker, amount); err != nil {
+		return nil, err
+	}
+	if err := token.SafeTransferFrom(token.NewERC20(right.SellToken), right.Maker, left.Maker, pay); err != nil {
+		return nil, err
+	}
+	return word(pay.Word()), nil
+}
+
+// handleCancelOrder cancels one order of the caller.
+func handleCancelOrder(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != orderWords*32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	o, err := decodeOrder(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	if o.Maker != stygos.GetMsgSender() {
+		return nil, ErrNotMaker
+	}
+	hash := o.Hash()
+	stygos.StorageStore(storage.MapKey(filledKey, hash[:]), cancelled.Word())
+	stygos.EmitEvent(nil, stygos.Keccak256([]byte("OrderCancelled(bytes32,address)")), hash, stygos.PadAddress(o.Maker))
+	return nil, nil
+}
+
+// handleCancelUpTo cancels every order of the caller with a lower nonce.
+func handleCancelUpTo(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	nonce := stygos.U256FromWord(wordAt(args, 0))
+	maker := stygos.GetMsgSender()
+	slot := storage.MapKey(minNonceKey, maker[:])
+	if !stygos.U256FromWord(stygos.StorageLoad(slot)).Lt(nonce) {
+		return nil, ErrNonceTooLow
+	}
+	stygos.StorageStore(slot, nonce.Word())
+	return nil, nil
+}
+
+// handleFilled returns the amount of the sell token filled for an order
+// hash, 2^256-1 once cancelled.
+func handleFilled(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	hash := wordAt(args, 0)
+	return word(stygos.StorageLoad(storage.MapKey(filledKey, hash[:]))), nil
+}
+
+// handleMinNonce returns the lowest valid nonce of a maker.
+func handleMinNonce(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	maker := stygos.AddressFromWord(wordAt(args, 0))
+	return word(stygos.StorageLoad(storage.MapKey(minNonceKey, maker[:]))), nil
+}
+
+// handleHashOrder returns the digest makers sign for an order.
+func handleHashOrder(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != orderWords*32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	o, err := decodeOrder(args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return word(o.Hash()), nil
+}
+
+// check verifies an order and its signature and returns its hash and the
+// amount of its sell token left to fill.
+func check(o *Order, sig ecdsa.Signature) (stygos.Word, stygos.U256, error) {
+	if o.SellAmount.IsZero() || o.BuyAmount.IsZero() {
+		return stygos.Word{}, stygos.U256{}, ErrInvalidOrder
+	}
+	if stygos.GetBlockTimestamp() >= o.Expiry {
+		return stygos.Word{}, stygos.U256{}, ErrExpired
+	}
+	minNonce := stygos.U256FromWord(stygos.StorageLoad(storage.MapKey(minNonceKey, o.Maker[:])))
+	if o.Nonce.Lt(minNonce) {
+		return stygos.Word{}, stygos.U256{}, ErrCancelled
+	}
+	hash := o.Hash()
+	filled := stygos.U256FromWord(stygos.StorageLoad(storage.MapKey(filledKey, hash[:])))
+	if filled == cancelled {
+		return stygos.Word{}, stygos.U256{}, ErrCancelled
+	}
+	if signer, err := ecdsa.Recover(hash, sig); err != nil || signer != o.Maker {
+		return stygos.Word{}, stygos.U256{}, ErrInvalidSignature
+	}
+	return hash, o.SellAmount.Sub(filled), nil
+}
+
+// addFill records amount more of an order as filled. The caller has
+// checked it against the remaining amount.
+func addFill(hash stygos.Word, amount stygos.U256) {
+	slot := storage.MapKey(filledKey, hash[:])
+	stygos.StorageStore(slot, stygos.U256FromWord(stygos.StorageLoad(slot)).Add(amount).Word())
+	stygos.EmitEvent(word(amount.Word()), stygos.Keccak256([]byte("OrderFilled(bytes32,uint256)")), hash)
+}
+
+// decodeOrder decodes the order whose words start at head word i.
+func decodeOrder(args []byte, i int) (*Order, error) {
+	expiry := stygos.U256FromWord(wordAt(args, i+6))
+	if !expiry.IsUint64() {
+		return nil, stygos.ErrInvalidInput
+	}
+	return &Order{
+		Maker:      stygos.AddressFromWord(wordAt(args, i)),
+		SellToken:  stygos.AddressFromWord(wordAt(args, i+1)),
+		BuyToken:   stygos.AddressFromWord(wordAt(args, i+2)),
+		SellAmount: stygos.U256FromWord(wordAt(args, i+3)),
+		BuyAmount:  stygos.U256FromWord(wordAt(args, i+4)),
+		Nonce:      stygos.U256FromWord(wordAt(args, i+5)),
+		Expiry:     expiry.Uint64(),
+	}, nil
+}
+
+// signatureArg decodes the 65-byte signature whose offset is head word i.
+func signatureArg(args []byte, i int) (ecdsa.Signature, error) {
+	offset := stygos.U256FromWord(wordAt(args, i))
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(args))-32 {
+		return ecdsa.Signature{}, stygos.ErrInvalidInput
+	}
+	start := offset.Uint64() + 32
+	length := stygos.U256FromWord(wordAt(args[start-32:], 0))
+	if !length.IsUint64() || length.Uint64() > uint64(len(args))-start {
+		return ecdsa.Signature{}, stygos.ErrInvalidInput
+	}
+	return ecdsa.SignatureFromBytes(args[start : start+length.Uint64()])
+}
+
+// wordAt returns head word i of args.
+func wordAt(args []byte, i int) stygos.Word {
+	var w stygos.Word
+	copy(w[:], args[32*i:])
+	return w
+}
+
+func word(w stygos.Word) []byte {
+	return w[:]
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/bytesutil"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	exchange = stygos.Address{0xe0}
+	tokenA   = stygos.Address{0x0a}
+	tokenB   = stygos.Address{0x0b}
+	taker    = stygos.Address{0x7a}
+
+	makerKey = big.NewInt(0x1111)
+	otherKey = big.NewInt(0x2222)
+)
+
+type env struct {
+	mock *stygos.MockRuntime
+	a, b *token.MockERC20
+}
+
+// setup deploys the exchange and two tokens, funds the makers and the
+// taker and approves the exchange for all of them.
+func setup() *env {
+	mock := stygos.NewMockRuntime()
+	mock.Time = 1000
+	stygos.UseRuntime(mock)
+	ecdsa.InstallMockEcrecover(mock)
+	mock.Deploy(exchange, stygos.MockEntrypoint(entrypoint))
+	e := &env{mock: mock, a: token.InstallMockERC20(mock, tokenA), b: token.InstallMockERC20(mock, tokenB)}
+	max := stygos.U256{}.Not()
+	for _, acc := range []stygos.Address{ecdsa.AddressOf(makerKey), ecdsa.AddressOf(otherKey), taker} {
+		e.a.Mint(acc, stygos.NewU256(1e6))
+		e.b.Mint(acc, stygos.NewU256(1e6))
+		e.a.Approve(acc, exchange, max)
+		e.b.Approve(acc, exchange, max)
+	}
+	mock.Contract = taker
+	return e
+}
+
+func order(key *big.Int, sell, buy stygos.Address, sellAmount, buyAmount, nonce uint64) Order {
+	return Order{
+		Maker:      ecdsa.AddressOf(key),
+		SellToken:  sell,
+		BuyToken:   buy,
+		SellAmount: stygos.NewU256(sellAmount),
+		BuyAmount:  stygos.NewU256(buyAmount),
+		Nonce:      stygos.NewU256(nonce),
+		Expiry:     2000,
+	}
+}
+
+func encodeOrder(o Order) []byte {
+	return encode(stygos.PadAddress(o.Maker), stygos.PadAddress(o.SellToken), stygos.PadAddress(o.BuyToken),
+		o.SellAmount.Word(), o.BuyAmount.Word(), o.Nonce.Word(), stygos.WordFromUint64(o.Expiry))
+}
+
+func encode(words ...stygos.Word) []byte {
+	var out []byte
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+func u(v uint64) stygos.Word {
+	return stygos.WordFromUint64(v)
+}
+
+// sign signs o through the exchange's hashOrder.
+func sign(t *testing.T, key *big.Int, o Order) []byte {
+	sig, err := ecdsa.Sign(key, hashOrder(t, o))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig.Bytes()
+}
+
+// sigTail returns the ABI tail of a signature.
+func sigTail(sig []byte) []byte {
+	return bytesutil.Concat(encode(u(uint64(len(sig)))), bytesutil.PadRight32(sig))
+}
+
+func fill(o Order, sig []byte, amount uint64) ([]byte, error) {
+	data := bytesutil.Concat(selFillOrder[:], encodeOrder(o), encode(u((orderWords+2)*32), u(amount)), sigTail(sig))
+	return stygos.Call(exchange, stygos.Word{}, data)
+}
+
+func match(left Order, leftSig []byte, right Order, rightSig []byte, amount uint64) ([]byte, error) {
+	head := uint64(2*orderWords+3) * 32
+	data := bytesutil.Concat(selMatchOrders[:],
+		encodeOrder(left), encode(u(head)),
+		encodeOrder(right), encode(u(head+uint64(len(sigTail(leftSig)))), u(amount)),
+		sigTail(leftSig), sigTail(rightSig))
+	return stygos.Call(exchange, stygos.Word{}, data)
+}
+
+func TestSelectors(t *testing.T) {
+	orderTuple := "(address,address,address,uint256,uint256,uint256,uint256)"
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selFillOrder, "fillOrder(" + orderTuple + ",bytes,uint256)"},
+		{selMatchOrders, "matchOrders(" + orderTuple + ",bytes," + orderTuple + ",bytes,uint256)"},
+		{selCancelOrder, "cancelOrder(" + orderTuple + ")"},
+		{selCancelUpTo, "cancelUpTo(uint256)"},
+		{selFilled, "filled(bytes32)"},
+		{selMinNonce, "minNonce(address)"},
+		{selHashOrder, "hashOrder(" + orderTuple + ")"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestPartialFills(t *testing.T) {
+	e := setup()
+	maker := ecdsa.AddressOf(makerKey)
+	// 300 A for 100 B: 3 A per B
+	o := order(makerKey, tokenA, tokenB, 300, 100, 1)
+	sig := sign(t, makerKey, o)
+
+	ret, err := fill(o, sig, 100)
+	if err != nil || stygos.Uint64FromWord(wordAt(ret, 0)) != 34 {
+		t.Fatalf("fillOrder failed. Expected to pay 34 B for 100 A, rounded up, got %x, %v", ret, err)
+	}
+	if _, err := fill(o, sig, 200); err != nil {
+		t.Fatalf("fillOrder failed: %v", err)
+	}
+	if e.a.Balances[taker] != stygos.NewU256(1e6+300) || e.b.Balances[maker] != stygos.NewU256(1e6+34+67) {
+		t.Errorf("fillOrder failed. Expected 300 A to the taker and 101 B to the maker, got %v and %v", e.a.Balances[taker], e.b.Balances[maker])
+	}
+	if _, err := fill(o, sig, 1); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected a filled order to revert, got %v", err)
+	}
+
+	// A fill above the remainder reverts and changes nothing
+	o.Nonce = stygos.NewU256(2)
+	sig = sign(t, makerKey, o)
+	if _, err := fill(o, sig, 301); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected an overfill to revert, got %v", err)
+	}
+	hash := hashOrder(t, o)
+	ret, _ = stygos.StaticCall(exchange, append(selFilled[:], hash[:]...))
+	if !stygos.U256FromWord(wordAt(ret, 0)).IsZero() {
+		t.Errorf("fillOrder failed. Expected nothing filled after a revert, got %x", ret)
+	}
+}
+
+func TestInvalidOrders(t *testing.T) {
+	e := setup()
+	o := order(makerKey, tokenA, tokenB, 100, 100, 1)
+	sig := sign(t, makerKey, o)
+
+	if _, err := fill(o, sign(t, otherKey, o), 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected another key's signature to revert, got %v", err)
+	}
+	tampered := o
+	tampered.BuyAmount = stygos.NewU256(1)
+	if _, err := fill(tampered, sig, 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected a changed order to revert, got %v", err)
+	}
+	e.mock.Time = o.Expiry
+	if _, err := fill(o, sig, 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected an expired order to revert, got %v", err)
+	}
+	e.mock.Time = 1000
+	if _, err := fill(o, sig, 10); err != nil {
+		t.Errorf("fillOrder failed: %v", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	mock := setup().mock
+	maker := ecdsa.AddressOf(makerKey)
+	o := order(makerKey, tokenA, tokenB, 100, 100, 5)
+	sig := sign(t, makerKey, o)
+
+	// Only the maker cancels
+	if _, err := stygos.Call(exchange, stygos.Word{}, append(selCancelOrder[:], encodeOrder(o)...)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("cancelOrder failed. Expected the taker to be refused, got %v", err)
+	}
+	mock.Contract = maker
+	if _, err := stygos.Call(exchange, stygos.Word{}, append(selCancelOrder[:], encodeOrder(o)...)); err != nil {
+		t.Fatalf("cancelOrder failed: %v", err)
+	}
+	mock.Contract = taker
+	if _, err := fill(o, sig, 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected a cancelled order to revert, got %v", err)
+	}
+
+	// cancelUpTo invalidates lower nonces only
+	low, high := order(makerKey, tokenA, tokenB, 100, 100, 9), order(makerKey, tokenA, tokenB, 100, 100, 10)
+	lowSig, highSig := sign(t, makerKey, low), sign(t, makerKey, high)
+	mock.Contract = maker
+	if _, err := stygos.Call(exchange, stygos.Word{}, append(selCancelUpTo[:], encode(u(10))...)); err != nil {
+		t.Fatalf("cancelUpTo failed: %v", err)
+	}
+	if _, err := stygos.Call(exchange, stygos.Word{}, append(selCancelUpTo[:], encode(u(10))...)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("cancelUpTo failed. Expected the same nonce to revert, got %v", err)
+	}
+	mock.Contract = taker
+	if _, err := fill(low, lowSig, 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("fillOrder failed. Expected nonce 9 to be cancelled, got %v", err)
+	}
+	if _, err := fill(high, highSig, 10); err != nil {
+		t.Errorf("fillOrder failed. Expected nonce 10 to stay valid, got %v", err)
+	}
+	addr := stygos.PadAddress(maker)
+	ret, err := stygos.StaticCall(exchange, append(selMinNonce[:], addr[:]...))
+	if err != nil || stygos.Uint64FromWord(wordAt(ret, 0)) != 10 {
+		t.Errorf("minNonce failed. Expected 10, got %x, %v", ret, err)
+	}
+}
+
+func TestMatchOrders(t *testing.T) {
+	e := setup()
+	alice, bob := ecdsa.AddressOf(makerKey), ecdsa.AddressOf(otherKey)
+	// alice sells 100 A at 2 B each; bob buys A paying up to 2.5 B each
+	left := order(makerKey, tokenA, tokenB, 100, 200, 1)
+	right := order(otherKey, tokenB, tokenA, 250, 100, 1)
+	leftSig, rightSig := sign(t, makerKey, left), sign(t, otherKey, right)
+
+	ret, err := match(left, leftSig, right, rightSig, 40)
+	if err != nil || stygos.Uint64FromWord(wordAt(ret, 0)) != 80 {
+		t.Fatalf("matchOrders failed. Expected bob to pay 80 B, got %x, %v", ret, err)
+	}
+	if e.a.Balances[bob] != stygos.NewU256(1e6+40) || e.b.Balances[alice] != stygos.NewU256(1e6+80) {
+		t.Errorf("matchOrders failed. Expected 40 A to bob and 80 B to alice, got %v and %v", e.a.Balances[bob], e.b.Balances[alice])
+	}
+	// bob's order has 170 B left: 85 A at alice's price
+	if _, err := match(left, leftSig, right, rightSig, 86); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("matchOrders failed. Expected an overfill of the right order to revert, got %v", err)
+	}
+	if _, err := match(left, leftSig, right, rightSig, 60); err != nil {
+		t.Errorf("matchOrders failed: %v", err)
+	}
+
+	// Prices that do not cross
+	cheap := order(otherKey, tokenB, tokenA, 150, 100, 2)
+	rich := order(makerKey, tokenA, tokenB, 100, 200, 2)
+	if _, err := match(rich, sign(t, makerKey, rich), cheap, sign(t, otherKey, cheap), 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("matchOrders failed. Expected orders that do not cross to revert, got %v", err)
+	}
+	// Orders on different pairs
+	if _, err := match(rich, sign(t, makerKey, rich), rich, sign(t, makerKey, rich), 10); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("matchOrders failed. Expected orders on the same side to revert, got %v", err)
+	}
+}
+
+// hashOrder returns the order hash computed by the exchange.
+func hashOrder(t *testing.T, o Order) stygos.Word {
+	ret, err := stygos.StaticCall(exchange, append(selHashOrder[:], encodeOrder(o)...))
+	if err != nil {
+		t.Fatalf("hashOrder failed: %v", err)
+	}
+	return wordAt(ret, 0)
+}
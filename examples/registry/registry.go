@@ -0,0 +1,292 @@
+// Command registry is a configuration registry mapping bytes32 keys to an
+// address or bytes, for protocol settings such as contract addresses and
+// parameters that other contracts and off-chain services look up.
+//
+// Writes are gated by access.AccessControl: accounts with WRITER_ROLE set
+// entries, and the default admin, the account that initialized the
+// registry, grants and revokes it. Every write adds a version to the key
+// instead of overwriting it, recording the writer and the time, so
+// readers can pin a version and an upgrade can be audited or undone by
+// writing back an earlier value.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/access"
+	"github.com/rafaelescrich/stygos/erc165"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go rolesKey=roles versionsKey=versions entriesKey=entries initializedKey=initialized
+
+// ABI selectors
+var (
+	selInitialize   = stygos.Selector{0x81, 0x29, 0xfc, 0x1c} // initialize()
+	selSetAddress   = stygos.Selector{0xca, 0x44, 0x6d, 0xd9} // setAddress(bytes32,address)
+	selSetBytes     = stygos.Selector{0x2e, 0x28, 0xd0, 0x84} // setBytes(bytes32,bytes)
+	selGetAddress   = stygos.Selector{0x21, 0xf8, 0xa7, 0x21} // getAddress(bytes32)
+	selGetBytes     = stygos.Selector{0xc0, 0x31, 0xa1, 0x80} // getBytes(bytes32)
+	selVersionOf    = stygos.Selector{0x58, 0x6e, 0x05, 0xa5} // versionOf(bytes32)
+	selGetAddressAt = stygos.Selector{0x68, 0x86, 0x95, 0x6f} // getAddressAt(bytes32,uint256)
+	selGetBytesAt   = stygos.Selector{0x35, 0x97, 0x15, 0x8b} // getBytesAt(bytes32,uint256)
+	selVersionInfo  = stygos.Selector{0xf9, 0x83, 0xba, 0x9c} // versionInfo(bytes32,uint256)
+)
+
+// entrySetTopic is the topic of EntrySet(bytes32,uint256,address).
+var entrySetTopic = stygos.Keccak256([]byte("EntrySet(bytes32,uint256,address)"))
+
+// Registry errors
+var (
+	ErrInitialized = errors.New("registry: already initialized")
+	ErrNoEntry     = errors.New("registry: no such entry or version")
+	ErrNotAddress  = errors.New("registry: entry is not an address")
+)
+
+// WriterRole may set entries.
+var WriterRole = access.Role("WRITER_ROLE")
+
+// Version describes one version of an entry, packed into storage by
+// version_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Version -o version_pack_gen.go
+type Version struct {
+	Writer    stygos.Address
+	Time      uint64 // unix seconds
+	IsAddress bool
+}
+
+var (
+	interfaces = erc165.NewRegistry()
+	roles      = access.NewAccessControl(rolesKey)
+	router     = newRouter()
+)
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	interfaces.Mount(r)
+	roles.Mount(r, interfaces)
+	r.HandleSelector(selInitialize, handleInitialize)
+	r.HandleSelector(selSetAddress, handleSetAddress)
+	r.HandleSelector(selSetBytes, handleSetBytes)
+	r.HandleSelector(selGetAddress, handleGetAddress)
+	r.HandleSelector(selGetBytes, handleGetBytes)
+	r.HandleSelector(selVersionOf, handleVersionOf)
+	r.HandleSelector(selGetAddressAt, handleGetAddressAt)
+	r.HandleSelector(selGetBytesAt, handleGetBytesAt)
+	r.HandleSelector(selVersionInfo, handleVersionInfo)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleInitialize makes the caller the default admin and a writer, once.
+func handleInitialize(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 0 {
+		return nil, stygos.ErrInvalidInput
+	}
+	if stygos.StorageLoad(initializedKey) != (stygos.Word{}) {
+		return nil, ErrInitialized
+	}
+	stygos.StorageStore(initializedKey, stygos.WordFromUint64(1))
+	caller := stygos.GetMsgSender()
+	roles.Grant(access.DefaultAdminRole, caller)
+	roles.Grant(WriterRole, caller)
+	return nil, nil
+}
+
+// handleSetAddress adds an address version to a key and returns its
+// number.
+func handleSetAddress(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 64 {
+		return nil, stygos.ErrInvalidInput
+	}
+	value := stygos.AddressFromWord(wordAt(args, 1))
+	return set(wordAt(args, 0), value[:], true)
+}
+
+// handleSetBytes adds a bytes version to a key and returns its number.
+func handleSetBytes(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) < 64 {
+		return nil, stygos.ErrInvalidInput
+	}
+	value, err := stygos.ArgBytes(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return set(wordAt(args, 0), value, false)
+}
+
+// set stores value as the next version of key for a writer.
+func set(key stygos.Word, value []byte, isAddress bool) ([]byte, error) {
+	writer := stygos.GetMsgSender()
+	if err := roles.CheckRole(WriterRole, writer); err != nil {
+		return nil, err
+	}
+	n := versionOf(key) + 1
+	stygos.StorageStore(storage.MapKey(versionsKey, key[:]), stygos.WordFromUint64(n))
+	slot := entrySlot(key, n)
+	v := Version{Writer: writer, Time: stygos.GetBlockTimestamp(), IsAddress: isAddress}
+	v.Store(slot)
+	storage.StoreBytes(storage.Offset(slot, VersionPackedWords), value)
+
+	version := stygos.WordFromUint64(n)
+	stygos.EmitEvent(nil, entrySetTopic, key, version, stygos.PadAddress(writer))
+	return version[:], nil
+}
+
+// handleGetAddress returns the latest version of an address entry.
+func handleGetAddress(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	key := wordAt(args, 0)
+	return getAddress(key, versionOf(key))
+}
+
+// handleGetAddressAt returns a version of an address entry.
+func handleGetAddressAt(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	key, n, err := versionArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return getAddress(key, n)
+}
+
+func getAddress(key stygos.Word, n uint64) ([]byte, error) {
+	v, value, err := load(key, n)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsAddress {
+		return nil, ErrNotAddress
+	}
+	var addr stygos.Address
+	copy(addr[:], value)
+	w := stygos.PadAddress(addr)
+	return w[:], nil
+}
+
+// handleGetBytes returns the latest version of an entry as bytes.
+func handleGetBytes(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	key := wordAt(args, 0)
+	return getBytes(key, versionOf(key))
+}
+
+// handleGetBytesAt returns a version of an entry as bytes.
+func handleGetBytesAt(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	key, n, err := versionArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return getBytes(key, n)
+}
+
+func getBytes(key stygos.Word, n uint64) ([]byte, error) {
+	_, value, err := load(key, n)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBytes(value), nil
+}
+
+// handleVersionOf returns the number of the latest version of a key, zero
+// if it was never set.
+func handleVersionOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	if len(args) != 32 {
+		return nil, stygos.ErrInvalidInput
+	}
+	n := stygos.WordFromUint64(versionOf(wordAt(args, 0)))
+	return n[:], nil
+}
+
+// handleVersionInfo returns the writer, the time and whether a version is
+// an address.
+func handleVersionInfo(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	key, n, err := versionArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	v, _, err := load(key, n)
+	if err != nil {
+		return nil, err
+	}
+	var isAddress stygos.Word
+	if v.IsAddress {
+		isAddress[31] = 1
+	}
+	return encode(stygos.PadAddress(v.Writer), stygos.WordFromUint64(v.Time), isAddress), nil
+}
+
+// load returns version n of key and its value.
+func load(key stygos.Word, n uint64) (Version, []byte, error) {
+	if n == 0 || n > versionOf(key) {
+		return Version{}, nil, ErrNoEntry
+	}
+	slot := entrySlot(key, n)
+	var v Version
+	v.Load(slot)
+	return v, storage.LoadBytes(storage.Offset(slot, VersionPackedWords)), nil
+}
+
+func versionOf(key stygos.Word) uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(storage.MapKey(versionsKey, key[:])))
+}
+
+// entrySlot returns the slot of version n of key: the packed Version,
+// followed by the value stored with storage.StoreBytes.
+func entrySlot(key stygos.Word, n uint64) stygos.Word {
+	version := stygos.WordFromUint64(n)
+	return storage.MapKey(storage.MapKey(entriesKey, key[:]), version[:])
+}
+
+// versionArgs decodes (bytes32 key, uint256 version).
+func versionArgs(args []byte) (stygos.Word, uint64, error) {
+	if len(args) != 64 {
+		return stygos.Word{}, 0, stygos.ErrInvalidInput
+	}
+	n := stygos.U256FromWord(wordAt(args, 1))
+	if !n.IsUint64() {
+		return stygos.Word{}, 0, ErrNoEntry
+	}
+	return wordAt(args, 0), n.Uint64(), nil
+}
+
+// encodeBytes returns the ABI encoding of a single bytes value.
+func encodeBytes(b []byte) []byte {
+	out := encode(stygos.WordFromUint64(32), stygos.WordFromUint64(uint64(len(b))))
+	out = append(out, b...)
+	return append(out, make([]byte, (32-len(b)%32)%32)...)
+}
+
+// wordAt returns head word i of args.
+func wordAt(args []byte, i int) stygos.Word {
+	var w stygos.Word
+	copy(w[:], args[32*i:])
+	return w
+}
+
+// encode concatenates words into ABI data.
+func encode(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
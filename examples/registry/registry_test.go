@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/access"
+	"github.com/rafaelescrich/stygos/erc165"
+)
+
+var (
+	registry = stygos.Address{0x5e}
+	admin    = stygos.Address{0xad}
+	writer   = stygos.Address{0xa1}
+	stranger = stygos.Address{0xb0}
+
+	oracleKey = stygos.Keccak256([]byte("ORACLE"))
+	paramsKey = stygos.Keccak256([]byte("PARAMS"))
+)
+
+// setup deploys the registry, initialized by admin.
+func setup(t *testing.T) *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Time = 1000
+	stygos.UseRuntime(mock)
+	mock.Deploy(registry, stygos.MockEntrypoint(entrypoint))
+	if _, err := send(mock, admin, selInitialize); err != nil {
+		t.Fatalf("initialize failed: %v", err)
+	}
+	return mock
+}
+
+// send calls the registry from an account with static words.
+func send(mock *stygos.MockRuntime, from stygos.Address, sel stygos.Selector, args ...stygos.Word) ([]byte, error) {
+	mock.Contract = from
+	return stygos.Call(registry, stygos.Word{}, append(sel[:], encode(args...)...))
+}
+
+// setBytesCall calls setBytes(key, value) from an account.
+func setBytesCall(mock *stygos.MockRuntime, from stygos.Address, key stygos.Word, value []byte) ([]byte, error) {
+	mock.Contract = from
+	data := append(selSetBytes[:], encode(key, stygos.WordFromUint64(64))...)
+	data = append(data, encodeBytes(value)[32:]...)
+	return stygos.Call(registry, stygos.Word{}, data)
+}
+
+// decodeBytes decodes an ABI encoded bytes return value.
+func decodeBytes(out []byte) []byte {
+	n := stygos.Uint64FromWord(wordAt(out, 1))
+	return out[64 : 64+n]
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selInitialize, "initialize()"},
+		{selSetAddress, "setAddress(bytes32,address)"},
+		{selSetBytes, "setBytes(bytes32,bytes)"},
+		{selGetAddress, "getAddress(bytes32)"},
+		{selGetBytes, "getBytes(bytes32)"},
+		{selVersionOf, "versionOf(bytes32)"},
+		{selGetAddressAt, "getAddressAt(bytes32,uint256)"},
+		{selGetBytesAt, "getBytesAt(bytes32,uint256)"},
+		{selVersionInfo, "versionInfo(bytes32,uint256)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestWriteAccess(t *testing.T) {
+	mock := setup(t)
+	if _, err := send(mock, stranger, selInitialize); err == nil {
+		t.Errorf("initialize failed. Expected a second call to revert")
+	}
+	if _, err := send(mock, writer, selSetAddress, oracleKey, stygos.PadAddress(stygos.Address{1})); err == nil {
+		t.Errorf("setAddress failed. Expected a revert without WRITER_ROLE")
+	}
+	if _, err := send(mock, stranger, stygos.SelectorOf("grantRole(bytes32,address)"), WriterRole, stygos.PadAddress(writer)); err == nil {
+		t.Errorf("grantRole failed. Expected a revert for a caller other than the admin")
+	}
+	if _, err := send(mock, admin, stygos.SelectorOf("grantRole(bytes32,address)"), WriterRole, stygos.PadAddress(writer)); err != nil {
+		t.Fatalf("grantRole failed: %v", err)
+	}
+	if _, err := send(mock, writer, selSetAddress, oracleKey, stygos.PadAddress(stygos.Address{1})); err != nil {
+		t.Errorf("setAddress failed: %v", err)
+	}
+
+	// The registry advertises IAccessControl
+	id := stygos.Word{}
+	copy(id[:], access.InterfaceID[:])
+	if out, err := send(mock, stranger, erc165.InterfaceID, id); err != nil || out[31] != 1 {
+		t.Errorf("supportsInterface failed. Expected IAccessControl, got %x, %v", out, err)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	mock := setup(t)
+	v1, v2 := stygos.Address{0x01}, stygos.Address{0x02}
+	if _, err := send(mock, admin, selGetAddress, oracleKey); err == nil {
+		t.Errorf("getAddress failed. Expected an unset key to revert")
+	}
+	send(mock, admin, selSetAddress, oracleKey, stygos.PadAddress(v1))
+	mock.Time = 2000
+	out, err := send(mock, admin, selSetAddress, oracleKey, stygos.PadAddress(v2))
+	if err != nil || stygos.Uint64FromWord(wordAt(out, 0)) != 2 {
+		t.Fatalf("setAddress failed. Expected version 2, got %x, %v", out, err)
+	}
+
+	if out, _ := send(mock, stranger, selGetAddress, oracleKey); stygos.AddressFromWord(wordAt(out, 0)) != v2 {
+		t.Errorf("getAddress failed. Expected the latest version %x, got %x", v2, out)
+	}
+	if out, _ := send(mock, stranger, selGetAddressAt, oracleKey, stygos.WordFromUint64(1)); stygos.AddressFromWord(wordAt(out, 0)) != v1 {
+		t.Errorf("getAddressAt failed. Expected version 1 %x, got %x", v1, out)
+	}
+	if _, err := send(mock, stranger, selGetAddressAt, oracleKey, stygos.WordFromUint64(3)); err == nil {
+		t.Errorf("getAddressAt failed. Expected a missing version to revert")
+	}
+	if out, _ := send(mock, stranger, selVersionOf, oracleKey); stygos.Uint64FromWord(wordAt(out, 0)) != 2 {
+		t.Errorf("versionOf failed. Expected 2, got %x", out)
+	}
+	out, err = send(mock, stranger, selVersionInfo, oracleKey, stygos.WordFromUint64(2))
+	if err != nil || stygos.AddressFromWord(wordAt(out, 0)) != admin || stygos.Uint64FromWord(wordAt(out, 1)) != 2000 || out[95] != 1 {
+		t.Errorf("versionInfo failed. Expected admin at 2000 for an address, got %x, %v", out, err)
+	}
+
+	// Bytes entries keep their versions too, of any length
+	long := bytes.Repeat([]byte("config"), 10)
+	setBytesCall(mock, admin, paramsKey, long)
+	setBytesCall(mock, admin, paramsKey, []byte("v2"))
+	if out, _ := send(mock, stranger, selGetBytes, paramsKey); string(decodeBytes(out)) != "v2" {
+		t.Errorf("getBytes failed. Expected v2, got %x", out)
+	}
+	if out, _ := send(mock, stranger, selGetBytesAt, paramsKey, stygos.WordFromUint64(1)); !bytes.Equal(decodeBytes(out), long) {
+		t.Errorf("getBytesAt failed. Expected the first value, got %x", out)
+	}
+	if _, err := send(mock, stranger, selGetAddress, paramsKey); err == nil {
+		t.Errorf("getAddress failed. Expected a bytes entry to revert")
+	}
+	if out, _ := send(mock, stranger, selGetBytes, oracleKey); !bytes.Equal(decodeBytes(out), v2[:]) {
+		t.Errorf("getBytes failed. Expected the 20 bytes of the address, got %x", out)
+	}
+}
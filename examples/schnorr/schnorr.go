@@ -1,62 +1,23 @@
 package main
 
 import (
-	"crypto/sha256"
-	"errors"
 	"math/big"
 
 	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/schnorr"
 )
 
-// secp256k1 constants
+// secp256k1 constants, from the schnorr package
 var (
-	// Field modulus p
-	P = new(big.Int).SetBytes([]byte{
-		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
-		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE, 0xFF, 0xFF, 0xFC, 0x2F,
-	})
-
-	// Curve order n
-	N = new(big.Int).SetBytes([]byte{
-		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE,
-		0xBA, 0xAE, 0xDC, 0xE6, 0xAF, 0x48, 0xA0, 0x3B, 0xBF, 0xD2, 0x5E, 0x8C, 0xD0, 0x36, 0x41, 0x41,
-	})
-
-	// Curve parameter b
-	B = big.NewInt(7)
-
-	// Generator point G
-	GX = new(big.Int).SetBytes([]byte{
-		0x79, 0xBE, 0x66, 0x7E, 0xF9, 0xDC, 0xBB, 0xAC, 0x55, 0xA0, 0x62, 0x95, 0xCE, 0x87, 0x0B, 0x07,
-		0x02, 0x9B, 0xFC, 0xDB, 0x2D, 0xCE, 0x28, 0xD9, 0x59, 0xF2, 0x81, 0x5B, 0x16, 0xF8, 0x17, 0x98,
-	})
-	GY = new(big.Int).SetBytes([]byte{
-		0x48, 0x3A, 0xDA, 0x77, 0x26, 0xA3, 0xC4, 0x65, 0x5D, 0xA4, 0xFB, 0xFC, 0x0E, 0x11, 0x08, 0xA8,
-		0xFD, 0x17, 0xB4, 0x48, 0xA6, 0x85, 0x54, 0x19, 0x9C, 0x47, 0xD0, 0x8F, 0xFB, 0x10, 0xD4, 0xB8,
-	})
-
-	// (p+1)/4 for square root in F_p
-	SQRT_EXP = func() *big.Int {
-		result := new(big.Int).Add(P, big.NewInt(1))
-		result.Rsh(result, 2)
-		return result
-	}()
-)
-
-// Error definitions
-var (
-	ErrInvalidSignatureLength = errors.New("invalid signature length")
-	ErrInvalidPubKeyLength    = errors.New("invalid public key length")
-	ErrLiftXFailed            = errors.New("lift x failed")
-	ErrScalarOutOfRange       = errors.New("scalar out of range")
-	ErrInfinityPoint          = errors.New("infinity point")
+	P  = schnorr.P
+	N  = schnorr.N
+	B  = schnorr.B
+	GX = schnorr.GX
+	GY = schnorr.GY
 )
 
 // Affine point representation
-type Affine struct {
-	X *big.Int
-	Y *big.Int
-}
+type Affine = schnorr.Point
 
 // Commands for the contract
 const (
@@ -68,6 +29,14 @@ const (
 	CMD_POINT_MUL      = 5
 )
 
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
 //export entrypoint
 func entrypoint() int32 {
 	callData, err := stygos.GetCallData()
@@ -243,274 +212,55 @@ func handlePointMul(args []byte) int32 {
 
 // verify verifies a standard BIP-340 signature
 func verify(msg, sig, pkX []byte) bool {
-	if len(sig) != 64 || len(pkX) != 32 {
-		return false
-	}
-
-	r := new(big.Int).SetBytes(sig[:32])
-	s := new(big.Int).SetBytes(sig[32:])
-
-	if r.Cmp(P) >= 0 || s.Cmp(N) >= 0 {
-		return false
-	}
-
-	pk, err := liftXEvenY(new(big.Int).SetBytes(pkX))
-	if err != nil {
-		return false
-	}
-
-	// e = H_tag(bytes32(r) || bytes(P) || m) mod n
-	e := challengeBIP340(r, pkX, msg)
-	e.Mod(e, N)
-
-	// Compute R = s*G - e*P
-	sG := mul(Affine{X: GX, Y: GY}, s)
-	eP := mul(pk, e)
-	negEP := Affine{X: eP.X, Y: new(big.Int).Sub(P, eP.Y)}
-	R := add(sG, negEP)
-
-	if isInfinity(R) {
-		return false
-	}
-
-	// Require even Y and x(R) == r
-	return R.Y.Bit(0) == 0 && R.X.Cmp(r) == 0
+	return schnorr.Verify(msg, sig, pkX)
 }
 
-// adaptorVerify verifies an adaptor signature
+// adaptorVerify verifies an adaptor pre-signature for adaptor point T
 func adaptorVerify(msg, sig, pkX []byte, T Affine) bool {
-	if len(sig) != 64 || len(pkX) != 32 {
-		return false
-	}
-
-	r := new(big.Int).SetBytes(sig[:32])
-	sPrime := new(big.Int).SetBytes(sig[32:])
-
-	if r.Cmp(P) >= 0 || sPrime.Cmp(N) >= 0 {
-		return false
-	}
-
-	pk, err := liftXEvenY(new(big.Int).SetBytes(pkX))
-	if err != nil {
-		return false
-	}
-
-	if !isOnCurve(T) {
-		return false
-	}
-
-	// R' = R + T
-	R, err := liftXEvenY(r)
-	if err != nil {
-		return false
-	}
-	Rp := add(R, T)
-
-	// Challenge uses bytes(R') || pk || m
-	e := challengeBIP340(Rp.X, pkX, msg)
-	e.Mod(e, N)
-
-	// Check s'·G == R + e·P
-	sG := mul(Affine{X: GX, Y: GY}, sPrime)
-	eP := mul(pk, e)
-	rhs := add(R, eP)
-
-	if isInfinity(sG) || isInfinity(rhs) {
-		return false
-	}
-
-	// Compute implied R* = s'G - eP
-	negEP := Affine{X: eP.X, Y: new(big.Int).Sub(P, eP.Y)}
-	Rstar := add(sG, negEP)
-	if isInfinity(Rstar) {
-		return false
-	}
-
-	return Rstar.Y.Bit(0) == 0 && Rstar.X.Cmp(r) == 0
+	return schnorr.VerifyAdaptor(msg, sig, pkX, T)
 }
 
-// extract extracts adaptor secret t = (s - s') mod n
+// extract extracts adaptor secret t = (s - s') mod n, or zero if the
+// signatures do not share a nonce
 func extract(sig, adaptorSig []byte) *big.Int {
-	if len(sig) != 64 || len(adaptorSig) != 64 {
-		return big.NewInt(0)
-	}
-
-	r1 := new(big.Int).SetBytes(sig[:32])
-	s := new(big.Int).SetBytes(sig[32:])
-	r2 := new(big.Int).SetBytes(adaptorSig[:32])
-	sPrime := new(big.Int).SetBytes(adaptorSig[32:])
-
-	if r1.Cmp(r2) != 0 {
+	t, err := schnorr.Extract(sig, adaptorSig)
+	if err != nil {
 		return big.NewInt(0)
 	}
-
-	// (s - s') mod n
-	if s.Cmp(sPrime) >= 0 {
-		return new(big.Int).Sub(s, sPrime)
-	}
-	return new(big.Int).Sub(N, new(big.Int).Sub(sPrime, s))
+	return t
 }
 
 // challengeBIP340 computes BIP-340 challenge hash
 func challengeBIP340(r *big.Int, pkX, msg []byte) *big.Int {
-	// Precompute tag hash
-	tagHash := sha256.Sum256([]byte("BIP0340/challenge"))
-
-	// Create tagged hash: SHA256(SHA256(tag)||SHA256(tag)||data)
-	rBytes := make([]byte, 32)
-	r.FillBytes(rBytes)
-
-	data := append(rBytes, pkX...)
-	data = append(data, msg...)
-
-	h := sha256.Sum256(append(append(tagHash[:], tagHash[:]...), data...))
-	return new(big.Int).SetBytes(h[:])
+	return schnorr.Challenge(r, pkX, msg)
 }
 
 // isOnCurve checks if a point is on the curve
 func isOnCurve(p Affine) bool {
-	if isInfinity(p) {
-		return true
-	}
-
-	yy := new(big.Int).Mul(p.Y, p.Y)
-	yy.Mod(yy, P)
-
-	xx := new(big.Int).Mul(p.X, p.X)
-	xx.Mod(xx, P)
-
-	xxx := new(big.Int).Mul(xx, p.X)
-	xxx.Mod(xxx, P)
-
-	rhs := new(big.Int).Add(xxx, B)
-	rhs.Mod(rhs, P)
-
-	return yy.Cmp(rhs) == 0
+	return schnorr.IsOnCurve(p)
 }
 
 // isInfinity checks if a point is at infinity
 func isInfinity(p Affine) bool {
-	return p.X.Cmp(big.NewInt(0)) == 0 && p.Y.Cmp(big.NewInt(0)) == 0
+	return p.IsInfinity()
 }
 
 // add adds two points
 func add(p1, p2 Affine) Affine {
-	if isInfinity(p1) {
-		return p2
-	}
-	if isInfinity(p2) {
-		return p1
-	}
-
-	if p1.X.Cmp(p2.X) == 0 {
-		sum := new(big.Int).Add(p1.Y, p2.Y)
-		sum.Mod(sum, P)
-		if p1.Y.Cmp(big.NewInt(0)) == 0 || sum.Cmp(big.NewInt(0)) == 0 {
-			return Affine{X: big.NewInt(0), Y: big.NewInt(0)}
-		}
-		return double(p1)
-	}
-
-	dx := new(big.Int).Sub(p2.X, p1.X)
-	dx.Mod(dx, P)
-
-	dy := new(big.Int).Sub(p2.Y, p1.Y)
-	dy.Mod(dy, P)
-
-	inv := new(big.Int).ModInverse(dx, P)
-	s := new(big.Int).Mul(dy, inv)
-	s.Mod(s, P)
-
-	s2 := new(big.Int).Mul(s, s)
-	s2.Mod(s2, P)
-
-	xr := new(big.Int).Sub(s2, new(big.Int).Add(p1.X, p2.X))
-	xr.Mod(xr, P)
-
-	yr := new(big.Int).Sub(p1.X, xr)
-	yr.Mul(yr, s)
-	yr.Sub(yr, p1.Y)
-	yr.Mod(yr, P)
-
-	return Affine{X: xr, Y: yr}
+	return schnorr.Add(p1, p2)
 }
 
 // double doubles a point
 func double(p Affine) Affine {
-	if isInfinity(p) || p.Y.Cmp(big.NewInt(0)) == 0 {
-		return Affine{X: big.NewInt(0), Y: big.NewInt(0)}
-	}
-
-	three := big.NewInt(3)
-	x2 := new(big.Int).Mul(p.X, p.X)
-	x2.Mod(x2, P)
-
-	s := new(big.Int).Mul(three, x2)
-	s.Mod(s, P)
-
-	twoY := new(big.Int).Mul(big.NewInt(2), p.Y)
-	twoY.Mod(twoY, P)
-
-	inv := new(big.Int).ModInverse(twoY, P)
-	s.Mul(s, inv)
-	s.Mod(s, P)
-
-	s2 := new(big.Int).Mul(s, s)
-	s2.Mod(s2, P)
-
-	xr := new(big.Int).Sub(s2, new(big.Int).Mul(big.NewInt(2), p.X))
-	xr.Mod(xr, P)
-
-	yr := new(big.Int).Sub(p.X, xr)
-	yr.Mul(yr, s)
-	yr.Sub(yr, p.Y)
-	yr.Mod(yr, P)
-
-	return Affine{X: xr, Y: yr}
+	return schnorr.Double(p)
 }
 
 // mul multiplies a point by a scalar
 func mul(p Affine, k *big.Int) Affine {
-	result := Affine{X: big.NewInt(0), Y: big.NewInt(0)}
-	addend := p
-
-	for k.Cmp(big.NewInt(0)) > 0 {
-		if k.Bit(0) == 1 {
-			result = add(result, addend)
-		}
-		addend = double(addend)
-		k.Rsh(k, 1)
-	}
-
-	return result
+	return schnorr.Mul(p, k)
 }
 
 // liftXEvenY lifts x-coordinate to even-Y point
 func liftXEvenY(x *big.Int) (Affine, error) {
-	if x.Cmp(P) >= 0 {
-		return Affine{}, ErrLiftXFailed
-	}
-
-	// y^2 = x^3 + 7 mod p
-	c := new(big.Int).Mul(x, x)
-	c.Mul(c, x)
-	c.Add(c, B)
-	c.Mod(c, P)
-
-	// y = c^((p+1)/4) mod p
-	y := new(big.Int).Exp(c, SQRT_EXP, P)
-
-	// Verify y^2 == c
-	y2 := new(big.Int).Mul(y, y)
-	y2.Mod(y2, P)
-	if y2.Cmp(c) != 0 {
-		return Affine{}, ErrLiftXFailed
-	}
-
-	// Enforce even Y
-	if y.Bit(0) == 1 {
-		y.Sub(P, y)
-	}
-
-	return Affine{X: x, Y: y}, nil
+	return schnorr.LiftX(x)
 }
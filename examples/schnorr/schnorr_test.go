@@ -230,7 +230,7 @@ func TestStygosIntegration(t *testing.T) {
 }
 
 // Example usage functions
-func ExampleVerify() {
+func Example_verify() {
 	msg := []byte("Hello, World!")
 	pkX := make([]byte, 32)
 	pkX[31] = 1 // Simple test key
@@ -242,7 +242,7 @@ func ExampleVerify() {
 	_ = valid // Use the result
 }
 
-func ExampleLiftX() {
+func Example_liftX() {
 	x := new(big.Int).SetBytes([]byte{
 		0x79, 0xBE, 0x66, 0x7E, 0xF9, 0xDC, 0xBB, 0xAC, 0x55, 0xA0, 0x62, 0x95, 0xCE, 0x87, 0x0B, 0x07,
 		0x02, 0x9B, 0xFC, 0xDB, 0x2D, 0xCE, 0x28, 0xD9, 0x59, 0xF2, 0x81, 0x5B, 0x16, 0xF8, 0x17, 0x98,
@@ -256,7 +256,7 @@ func ExampleLiftX() {
 	_ = point // Use the lifted point
 }
 
-func ExamplePointOperations() {
+func Example_pointOperations() {
 	g := Affine{X: GX, Y: GY}
 
 	// Double the generator point
@@ -2,23 +2,21 @@ package main
 
 import (
 	"encoding/binary"
-	"math/big"
 
 	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/governance"
 )
 
 // Voting contract implementation
-// Demonstrates governance and voting mechanisms using Stygos
+// Demonstrates governance and voting mechanisms using Stygos. Proposals and
+// tallies are kept by governance.Governor; a non-zero reveal period at
+// initialization switches the contract to commit-reveal voting. Voter
+// weights are voting units that count once delegated, to the holder itself
+// or to another voter, and proposals weigh the delegated votes at their
+// snapshot block through governance.Votes.
 
-// Storage keys
-var (
-	votingPeriodKey   = stygos.Keccak256([]byte("votingPeriod"))
-	quorumKey         = stygos.Keccak256([]byte("quorum"))
-	proposalCountKey  = stygos.Keccak256([]byte("proposalCount"))
-	proposalPrefix    = stygos.Keccak256([]byte("proposal"))
-	votePrefix        = stygos.Keccak256([]byte("vote"))
-	voterWeightPrefix = stygos.Keccak256([]byte("voterWeight"))
-)
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go governorKey=governor votesKey=votes voterWeightPrefix=voterWeight
 
 // Commands
 const (
@@ -29,34 +27,30 @@ const (
 	CMD_GET_PROPOSAL     = 4
 	CMD_GET_VOTE         = 5
 	CMD_SET_VOTER_WEIGHT = 6
+	CMD_COMMIT_VOTE      = 7
+	CMD_REVEAL_VOTE      = 8
+	CMD_DELEGATE         = 9
+	CMD_UNDELEGATE       = 10
 )
 
 // Vote types
 const (
-	VOTE_AGAINST = 0
-	VOTE_FOR     = 1
-	VOTE_ABSTAIN = 2
+	VOTE_AGAINST = uint8(governance.Against)
+	VOTE_FOR     = uint8(governance.For)
+	VOTE_ABSTAIN = uint8(governance.Abstain)
 )
 
-// Proposal status
-const (
-	STATUS_PENDING   = 0
-	STATUS_ACTIVE    = 1
-	STATUS_DEFEATED  = 2
-	STATUS_SUCCEEDED = 3
-	STATUS_EXECUTED  = 4
+var (
+	votes    = governance.NewVotes(votesKey, unitsOf)
+	governor = governance.NewGovernor(governorKey, votes.WeightAt)
 )
 
-// Proposal structure
-type Proposal struct {
-	Proposer     stygos.Address
-	StartBlock   uint64
-	EndBlock     uint64
-	ForVotes     uint64
-	AgainstVotes uint64
-	AbstainVotes uint64
-	Executed     bool
-	Description  []byte
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
 }
 
 //export entrypoint
@@ -84,29 +78,42 @@ func entrypoint() int32 {
 		return handleGetVote(args)
 	case CMD_SET_VOTER_WEIGHT:
 		return handleSetVoterWeight(args)
+	case CMD_COMMIT_VOTE:
+		return handleCommitVote(args)
+	case CMD_REVEAL_VOTE:
+		return handleRevealVote(args)
+	case CMD_DELEGATE:
+		return handleDelegate(args)
+	case CMD_UNDELEGATE:
+		return handleUndelegate()
 	default:
 		return 1 // Unknown command
 	}
 }
 
-// handleInitialize initializes the voting system
+// handleInitialize sets the voting period and quorum, and optionally a
+// reveal period that enables commit-reveal voting
 func handleInitialize(args []byte) int32 {
-	if len(args) < 8 {
+	if len(args) < 16 {
 		return 1
 	}
 
 	votingPeriod := binary.BigEndian.Uint64(args[:8])
 	quorum := binary.BigEndian.Uint64(args[8:16])
+	revealPeriod := uint64(0)
+	if len(args) >= 24 {
+		revealPeriod = binary.BigEndian.Uint64(args[16:24])
+	}
 
-	// Store configuration
-	stygos.StorageStore(votingPeriodKey, stygos.WordFromUint64(votingPeriod))
-	stygos.StorageStore(quorumKey, stygos.WordFromUint64(quorum))
-	stygos.StorageStore(proposalCountKey, stygos.WordFromUint64(0))
-
+	if governor.Initialize(votingPeriod, revealPeriod, stygos.NewU256(quorum)) != nil {
+		return 1
+	}
 	return 0
 }
 
-// handleCreateProposal creates a new proposal
+// handleCreateProposal creates a new proposal. The description may be
+// followed by the calls to make on execution: a count byte, then for each
+// call a target (20 bytes), a value (32), a data length (2) and the data.
 func handleCreateProposal(args []byte) int32 {
 	if len(args) < 1 {
 		return 1
@@ -118,41 +125,20 @@ func handleCreateProposal(args []byte) int32 {
 	}
 
 	description := args[1 : 1+descriptionLen]
-
-	// Get current block and voting period
-	currentBlock := stygos.GetBlockNumber()
-	votingPeriod := stygos.Uint64FromWord(stygos.StorageLoad(votingPeriodKey))
-
-	// Create proposal
-	proposal := Proposal{
-		Proposer:     getCaller(),
-		StartBlock:   currentBlock,
-		EndBlock:     currentBlock + votingPeriod,
-		ForVotes:     0,
-		AgainstVotes: 0,
-		AbstainVotes: 0,
-		Executed:     false,
-		Description:  description,
+	actions, ok := decodeActions(args[1+descriptionLen:])
+	if !ok {
+		return 1
+	}
+	proposalId, err := governor.Propose(actions, description)
+	if err != nil {
+		return 1
 	}
 
-	// Get next proposal ID
-	proposalCount := stygos.Uint64FromWord(stygos.StorageLoad(proposalCountKey))
-	proposalId := proposalCount + 1
-
-	// Store proposal
-	proposalKey := getProposalKey(proposalId)
-	storeProposal(proposalKey, proposal)
-
-	// Increment proposal count
-	stygos.StorageStore(proposalCountKey, stygos.WordFromUint64(proposalId))
-
-	// Emit event
-	emitProposalCreated(proposalId, proposal.Proposer, description)
-
+	emitProposalCreated(proposalId, stygos.GetMsgSender(), description)
 	return 0
 }
 
-// handleVote casts a vote on a proposal
+// handleVote casts a vote on a proposal in open voting
 func handleVote(args []byte) int32 {
 	if len(args) < 9 { // 8 (proposalId) + 1 (vote)
 		return 1
@@ -161,158 +147,114 @@ func handleVote(args []byte) int32 {
 	proposalId := binary.BigEndian.Uint64(args[:8])
 	voteType := args[8]
 
-	if voteType > VOTE_ABSTAIN {
+	weight, err := governor.CastVote(proposalId, governance.Support(voteType))
+	if err != nil {
 		return 1
 	}
 
-	// Get proposal
-	proposalKey := getProposalKey(proposalId)
-	proposal, exists := getProposal(proposalKey)
-	if !exists {
-		return 1
-	}
+	emitVoteCast(proposalId, stygos.GetMsgSender(), voteType, weight.Uint64())
+	return 0
+}
 
-	// Check if voting is active
-	currentBlock := stygos.GetBlockNumber()
-	if currentBlock < proposal.StartBlock || currentBlock > proposal.EndBlock {
+// handleCommitVote records a hashed vote in commit-reveal voting, see
+// governance.Commitment
+func handleCommitVote(args []byte) int32 {
+	if len(args) < 40 { // 8 (proposalId) + 32 (commitment)
 		return 1
 	}
 
-	// Check if already voted
-	voter := getCaller()
-	voteKey := getVoteKey(proposalId, voter)
-	if hasVote(voteKey) {
-		return 1
-	}
+	proposalId := binary.BigEndian.Uint64(args[:8])
+	var commitment stygos.Word
+	copy(commitment[:], args[8:40])
 
-	// Get voter weight
-	voterWeight := getVoterWeight(voter)
-	if voterWeight == 0 {
+	if governor.CommitVote(proposalId, commitment) != nil {
 		return 1
 	}
 
-	// Update proposal votes
-	switch voteType {
-	case VOTE_FOR:
-		proposal.ForVotes += voterWeight
-	case VOTE_AGAINST:
-		proposal.AgainstVotes += voterWeight
-	case VOTE_ABSTAIN:
-		proposal.AbstainVotes += voterWeight
-	}
-
-	// Store updated proposal
-	storeProposal(proposalKey, proposal)
-
-	// Store vote
-	setVote(voteKey, voteType, voterWeight)
-
-	// Emit event
-	emitVoteCast(proposalId, voter, voteType, voterWeight)
-
+	emitVoteCommitted(proposalId, stygos.GetMsgSender())
 	return 0
 }
 
-// handleExecuteProposal executes a successful proposal
-func handleExecuteProposal(args []byte) int32 {
-	if len(args) < 8 {
+// handleRevealVote reveals a committed vote once voting has ended
+func handleRevealVote(args []byte) int32 {
+	if len(args) < 41 { // 8 (proposalId) + 1 (vote) + 32 (salt)
 		return 1
 	}
 
 	proposalId := binary.BigEndian.Uint64(args[:8])
+	voteType := args[8]
+	var salt stygos.Word
+	copy(salt[:], args[9:41])
 
-	// Get proposal
-	proposalKey := getProposalKey(proposalId)
-	proposal, exists := getProposal(proposalKey)
-	if !exists {
-		return 1
-	}
-
-	if proposal.Executed {
-		return 1
-	}
-
-	// Check if voting period has ended
-	currentBlock := stygos.GetBlockNumber()
-	if currentBlock <= proposal.EndBlock {
+	weight, err := governor.RevealVote(proposalId, governance.Support(voteType), salt)
+	if err != nil {
 		return 1
 	}
 
-	// Check if proposal succeeded
-	totalVotes := proposal.ForVotes + proposal.AgainstVotes + proposal.AbstainVotes
-	quorum := stygos.Uint64FromWord(stygos.StorageLoad(quorumKey))
+	emitVoteCast(proposalId, stygos.GetMsgSender(), voteType, weight.Uint64())
+	return 0
+}
 
-	if totalVotes < quorum {
+// handleExecuteProposal executes a successful proposal, making its calls.
+// A failing call fails the command, which reverts them all.
+func handleExecuteProposal(args []byte) int32 {
+	if len(args) < 8 {
 		return 1
 	}
 
-	if proposal.ForVotes <= proposal.AgainstVotes {
+	proposalId := binary.BigEndian.Uint64(args[:8])
+	if governor.Execute(proposalId) != nil {
 		return 1
 	}
 
-	// Mark as executed
-	proposal.Executed = true
-	storeProposal(proposalKey, proposal)
-
-	// Emit event
 	emitProposalExecuted(proposalId)
-
 	return 0
 }
 
-// handleGetProposal returns proposal data
+// handleGetProposal returns proposal data: proposer, start, end and reveal
+// end blocks, the three tallies, the executed flag and the description
 func handleGetProposal(args []byte) int32 {
 	if len(args) < 8 {
 		return 1
 	}
 
 	proposalId := binary.BigEndian.Uint64(args[:8])
-	proposalKey := getProposalKey(proposalId)
-	proposal, exists := getProposal(proposalKey)
-
-	if !exists {
+	proposal, err := governor.Proposal(proposalId)
+	if err != nil {
 		return 1
 	}
-
-	// Return proposal data
-	result := make([]byte, 20+8+8+8+8+8+1+1+len(proposal.Description))
-	offset := 0
-
-	copy(result[offset:offset+20], proposal.Proposer[:])
-	offset += 20
-
-	binary.BigEndian.PutUint64(result[offset:offset+8], proposal.StartBlock)
-	offset += 8
-
-	binary.BigEndian.PutUint64(result[offset:offset+8], proposal.EndBlock)
-	offset += 8
-
-	binary.BigEndian.PutUint64(result[offset:offset+8], proposal.ForVotes)
-	offset += 8
-
-	binary.BigEndian.PutUint64(result[offset:offset+8], proposal.AgainstVotes)
-	offset += 8
-
-	binary.BigEndian.PutUint64(result[offset:offset+8], proposal.AbstainVotes)
-	offset += 8
+	description := governor.Description(proposalId)
+
+	result := make([]byte, 20+8*6+1+1+len(description))
+	offset := copy(result, proposal.Proposer[:])
+	for _, v := range []uint64{
+		proposal.Snapshot,
+		proposal.VoteEnd,
+		proposal.RevealEnd,
+		proposal.ForVotes.Uint64(),
+		proposal.AgainstVotes.Uint64(),
+		proposal.AbstainVotes.Uint64(),
+	} {
+		binary.BigEndian.PutUint64(result[offset:offset+8], v)
+		offset += 8
+	}
 
 	if proposal.Executed {
 		result[offset] = 1
-	} else {
-		result[offset] = 0
 	}
 	offset += 1
 
-	result[offset] = byte(len(proposal.Description))
+	result[offset] = byte(len(description))
 	offset += 1
 
-	copy(result[offset:offset+len(proposal.Description)], proposal.Description)
+	copy(result[offset:], description)
 
 	stygos.SetReturnData(result)
 	return 0
 }
 
-// handleGetVote returns vote data for a voter on a proposal
+// handleGetVote returns vote data for a voter on a proposal: whether the
+// vote was counted, its type and its weight
 func handleGetVote(args []byte) int32 {
 	if len(args) < 28 { // 8 (proposalId) + 20 (voter)
 		return 1
@@ -322,18 +264,21 @@ func handleGetVote(args []byte) int32 {
 	var voter stygos.Address
 	copy(voter[:], args[8:28])
 
-	voteKey := getVoteKey(proposalId, voter)
-	voteType, weight := getVote(voteKey)
+	receipt := governor.Receipt(proposalId, voter)
 
-	result := make([]byte, 2)
-	result[0] = voteType
-	result[1] = byte(weight)
+	result := make([]byte, 1+1+8)
+	if receipt.Voted {
+		result[0] = 1
+	}
+	result[1] = receipt.Support
+	binary.BigEndian.PutUint64(result[2:], receipt.Weight.Uint64())
 
 	stygos.SetReturnData(result)
 	return 0
 }
 
-// handleSetVoterWeight sets the voting weight for a voter
+// handleSetVoterWeight sets the voting units of a voter, moving the
+// difference in votes to or from its delegate
 func handleSetVoterWeight(args []byte) int32 {
 	if len(args) < 21 { // 20 (voter) + 1 (weight)
 		return 1
@@ -343,6 +288,18 @@ func handleSetVoterWeight(args []byte) int32 {
 	copy(voter[:], args[:20])
 	weight := uint8(args[20])
 
+	old := unitsOf(voter)
+	units := stygos.NewU256(uint64(weight))
+	var err error
+	if old.Lt(units) {
+		err = votes.TransferVotingUnits(stygos.Address{}, voter, units.Sub(old))
+	} else {
+		err = votes.TransferVotingUnits(voter, stygos.Address{}, old.Sub(units))
+	}
+	if err != nil {
+		return 1
+	}
+
 	voterWeightKey := getVoterWeightKey(voter)
 	stygos.StorageStore(voterWeightKey, stygos.WordFromUint64(uint64(weight)))
 
@@ -352,149 +309,66 @@ func handleSetVoterWeight(args []byte) int32 {
 	return 0
 }
 
-// Helper functions
-
-func getCaller() stygos.Address {
-	// In a real implementation, this would get the caller address
-	// For now, return a mock address
-	return stygos.Address{}
-}
-
-func getProposalKey(proposalId uint64) stygos.Word {
-	proposalIdBytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(proposalIdBytes, proposalId)
-	return stygos.Keccak256(append(proposalPrefix[:], proposalIdBytes...))
-}
-
-func getVoteKey(proposalId uint64, voter stygos.Address) stygos.Word {
-	proposalIdBytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(proposalIdBytes, proposalId)
-	return stygos.Keccak256(append(append(votePrefix[:], proposalIdBytes...), voter[:]...))
-}
-
-func getVoterWeightKey(voter stygos.Address) stygos.Word {
-	return stygos.Keccak256(append(voterWeightPrefix[:], voter[:]...))
-}
-
-func storeProposal(key stygos.Word, proposal Proposal) {
-	// Serialize proposal
-	data := make([]byte, 20+8+8+8+8+8+1+1+len(proposal.Description))
-	offset := 0
-
-	copy(data[offset:offset+20], proposal.Proposer[:])
-	offset += 20
-
-	binary.BigEndian.PutUint64(data[offset:offset+8], proposal.StartBlock)
-	offset += 8
-
-	binary.BigEndian.PutUint64(data[offset:offset+8], proposal.EndBlock)
-	offset += 8
-
-	binary.BigEndian.PutUint64(data[offset:offset+8], proposal.ForVotes)
-	offset += 8
-
-	binary.BigEndian.PutUint64(data[offset:offset+8], proposal.AgainstVotes)
-	offset += 8
-
-	binary.BigEndian.PutUint64(data[offset:offset+8], proposal.AbstainVotes)
-	offset += 8
-
-	if proposal.Executed {
-		data[offset] = 1
-	} else {
-		data[offset] = 0
-	}
-	offset += 1
-
-	data[offset] = byte(len(proposal.Description))
-	offset += 1
-
-	copy(data[offset:offset+len(proposal.Description)], proposal.Description)
-
-	proposalWord := stygos.WordFromBigInt(new(big.Int).SetBytes(data))
-	stygos.StorageStore(key, proposalWord)
-}
-
-func getProposal(key stygos.Word) (Proposal, bool) {
-	proposalWord := stygos.StorageLoad(key)
-	if proposalWord == (stygos.Word{}) {
-		return Proposal{}, false
-	}
-
-	data := stygos.BigIntFromWord(proposalWord).Bytes()
-	if len(data) < 53 {
-		return Proposal{}, false
+// handleDelegate delegates the caller's voting units to another voter, or
+// to the caller itself
+func handleDelegate(args []byte) int32 {
+	if len(args) < 20 {
+		return 1
 	}
 
-	var proposal Proposal
-	offset := 0
-
-	copy(proposal.Proposer[:], data[offset:offset+20])
-	offset += 20
-
-	proposal.StartBlock = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
-
-	proposal.EndBlock = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
-
-	proposal.ForVotes = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
-
-	proposal.AgainstVotes = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
-
-	proposal.AbstainVotes = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
+	var delegatee stygos.Address
+	copy(delegatee[:], args[:20])
 
-	proposal.Executed = data[offset] == 1
-	offset += 1
-
-	descriptionLen := int(data[offset])
-	offset += 1
-
-	if len(data) < offset+descriptionLen {
-		return Proposal{}, false
+	if votes.Delegate(delegatee) != nil {
+		return 1
 	}
-
-	proposal.Description = make([]byte, descriptionLen)
-	copy(proposal.Description, data[offset:offset+descriptionLen])
-
-	return proposal, true
+	return 0
 }
 
-func hasVote(key stygos.Word) bool {
-	voteWord := stygos.StorageLoad(key)
-	return voteWord != (stygos.Word{})
+// handleUndelegate withdraws the caller's voting units from its delegate
+func handleUndelegate() int32 {
+	if votes.Undelegate() != nil {
+		return 1
+	}
+	return 0
 }
 
-func setVote(key stygos.Word, voteType uint8, weight uint64) {
-	voteData := make([]byte, 2)
-	voteData[0] = voteType
-	voteData[1] = byte(weight)
-
-	voteWord := stygos.WordFromBigInt(new(big.Int).SetBytes(voteData))
-	stygos.StorageStore(key, voteWord)
-}
+// Helper functions
 
-func getVote(key stygos.Word) (uint8, uint64) {
-	voteWord := stygos.StorageLoad(key)
-	if voteWord == (stygos.Word{}) {
-		return 0, 0
+// decodeActions decodes the optional call list of CMD_CREATE_PROPOSAL
+func decodeActions(args []byte) ([]governance.Action, bool) {
+	if len(args) == 0 {
+		return nil, true
 	}
 
-	data := stygos.BigIntFromWord(voteWord).Bytes()
-	if len(data) < 2 {
-		return 0, 0
+	actions := make([]governance.Action, args[0])
+	offset := 1
+	for i := range actions {
+		if len(args) < offset+20+32+2 {
+			return nil, false
+		}
+		var value stygos.Word
+		copy(actions[i].Target[:], args[offset:offset+20])
+		copy(value[:], args[offset+20:offset+52])
+		actions[i].Value = stygos.U256FromWord(value)
+		dataLen := int(binary.BigEndian.Uint16(args[offset+52 : offset+54]))
+		offset += 54
+		if len(args) < offset+dataLen {
+			return nil, false
+		}
+		actions[i].Data = args[offset : offset+dataLen]
+		offset += dataLen
 	}
+	return actions, true
+}
 
-	return data[0], uint64(data[1])
+// unitsOf returns the voting units set by CMD_SET_VOTER_WEIGHT
+func unitsOf(voter stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(getVoterWeightKey(voter)))
 }
 
-func getVoterWeight(voter stygos.Address) uint64 {
-	voterWeightKey := getVoterWeightKey(voter)
-	voterWeightWord := stygos.StorageLoad(voterWeightKey)
-	return stygos.Uint64FromWord(voterWeightWord)
+func getVoterWeightKey(voter stygos.Address) stygos.Word {
+	return stygos.Keccak256(append(voterWeightPrefix[:], voter[:]...))
 }
 
 // Event emission functions
@@ -520,6 +394,15 @@ func emitVoteCast(proposalId uint64, voter stygos.Address, voteType uint8, weigh
 	stygos.EmitEvent(eventData, eventHash)
 }
 
+func emitVoteCommitted(proposalId uint64, voter stygos.Address) {
+	eventData := make([]byte, 8+20)
+	binary.BigEndian.PutUint64(eventData[:8], proposalId)
+	copy(eventData[8:28], voter[:])
+
+	eventHash := stygos.Keccak256([]byte("VoteCommitted(uint64,address)"))
+	stygos.EmitEvent(eventData, eventHash)
+}
+
 func emitProposalExecuted(proposalId uint64) {
 	eventData := make([]byte, 8)
 	binary.BigEndian.PutUint64(eventData, proposalId)
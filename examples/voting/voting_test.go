@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/governance"
+)
+
+var (
+	alice = stygos.Address{0xa1}
+	bob   = stygos.Address{0xb0}
+)
+
+func u64(n uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b
+}
+
+// run calls the entrypoint as sender with a command and its arguments.
+func run(mock *stygos.MockRuntime, sender stygos.Address, command byte, args ...[]byte) int32 {
+	mock.Sender = sender
+	mock.Args = []byte{command}
+	for _, a := range args {
+		mock.Args = append(mock.Args, a...)
+	}
+	return entrypoint()
+}
+
+// setup initializes a 10-block vote with a quorum of 300, alice weighing
+// 200 and bob 255, each delegating to themselves at block 99.
+func setup(t *testing.T, revealPeriod uint64) *stygos.MockRuntime {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.Block = 99
+	stygos.UseRuntime(mock)
+
+	if run(mock, alice, CMD_INITIALIZE, u64(10), u64(300), u64(revealPeriod)) != 0 {
+		t.Fatal("CMD_INITIALIZE failed")
+	}
+	run(mock, alice, CMD_SET_VOTER_WEIGHT, alice[:], []byte{200})
+	run(mock, alice, CMD_SET_VOTER_WEIGHT, bob[:], []byte{255})
+	run(mock, alice, CMD_DELEGATE, alice[:])
+	run(mock, bob, CMD_DELEGATE, bob[:])
+	mock.Block = 100
+	return mock
+}
+
+func TestOpenVoting(t *testing.T) {
+	mock := setup(t, 0)
+
+	// Descriptions longer than a word are kept whole
+	description := bytes.Repeat([]byte("raise the quorum "), 4)
+	if run(mock, alice, CMD_CREATE_PROPOSAL, []byte{byte(len(description))}, description) != 0 {
+		t.Fatal("CMD_CREATE_PROPOSAL failed")
+	}
+	if run(mock, alice, CMD_VOTE, u64(1), []byte{VOTE_FOR}) != 0 {
+		t.Fatal("CMD_VOTE failed")
+	}
+	if run(mock, alice, CMD_VOTE, u64(1), []byte{VOTE_AGAINST}) == 0 {
+		t.Error("CMD_VOTE failed. Expected a second vote to be rejected")
+	}
+	if run(mock, bob, CMD_COMMIT_VOTE, u64(1), make([]byte, 32)) == 0 {
+		t.Error("CMD_COMMIT_VOTE failed. Expected open voting to reject commitments")
+	}
+	run(mock, bob, CMD_VOTE, u64(1), []byte{VOTE_FOR})
+
+	run(mock, alice, CMD_GET_VOTE, u64(1), bob[:])
+	if want := append([]byte{1, VOTE_FOR}, u64(255)...); !bytes.Equal(mock.Result, want) {
+		t.Errorf("CMD_GET_VOTE failed. Expected %x, got %x", want, mock.Result)
+	}
+
+	if run(mock, alice, CMD_EXECUTE_PROPOSAL, u64(1)) == 0 {
+		t.Error("CMD_EXECUTE_PROPOSAL failed. Expected execution to wait for the vote to end")
+	}
+	mock.Block = 111
+	if run(mock, alice, CMD_EXECUTE_PROPOSAL, u64(1)) != 0 {
+		t.Fatal("CMD_EXECUTE_PROPOSAL failed")
+	}
+
+	run(mock, alice, CMD_GET_PROPOSAL, u64(1))
+	var want []byte
+	want = append(want, alice[:]...)
+	for _, v := range []uint64{99, 110, 110, 455, 0, 0} {
+		want = append(want, u64(v)...)
+	}
+	want = append(want, 1, byte(len(description)))
+	want = append(want, description...)
+	if !bytes.Equal(mock.Result, want) {
+		t.Errorf("CMD_GET_PROPOSAL failed. Expected %x, got %x", want, mock.Result)
+	}
+}
+
+func TestCommitRevealVoting(t *testing.T) {
+	mock := setup(t, 5)
+	run(mock, alice, CMD_CREATE_PROPOSAL, []byte{0})
+
+	aliceSalt, bobSalt := stygos.Word{0x01}, stygos.Word{0x02}
+	aliceCommit := governance.Commitment(1, alice, governance.For, aliceSalt)
+	bobCommit := governance.Commitment(1, bob, governance.For, bobSalt)
+
+	if run(mock, alice, CMD_VOTE, u64(1), []byte{VOTE_FOR}) == 0 {
+		t.Error("CMD_VOTE failed. Expected commit-reveal voting to reject open votes")
+	}
+	if run(mock, alice, CMD_COMMIT_VOTE, u64(1), aliceCommit[:]) != 0 {
+		t.Fatal("CMD_COMMIT_VOTE failed")
+	}
+	run(mock, bob, CMD_COMMIT_VOTE, u64(1), bobCommit[:])
+	if run(mock, alice, CMD_REVEAL_VOTE, u64(1), []byte{VOTE_FOR}, aliceSalt[:]) == 0 {
+		t.Error("CMD_REVEAL_VOTE failed. Expected reveals to wait for the vote to end")
+	}
+
+	mock.Block = 111
+	if run(mock, alice, CMD_REVEAL_VOTE, u64(1), []byte{VOTE_FOR}, aliceSalt[:]) != 0 {
+		t.Fatal("CMD_REVEAL_VOTE failed")
+	}
+
+	// bob does not reveal, so alice's 200 alone miss the quorum
+	mock.Block = 116
+	if run(mock, alice, CMD_EXECUTE_PROPOSAL, u64(1)) == 0 {
+		t.Error("CMD_EXECUTE_PROPOSAL failed. Expected execution to wait for the reveal period")
+	}
+	mock.Block = 117
+	if run(mock, alice, CMD_EXECUTE_PROPOSAL, u64(1)) == 0 {
+		t.Error("CMD_EXECUTE_PROPOSAL failed. Expected unrevealed votes not to count")
+	}
+}
+
+func TestDelegatedVoting(t *testing.T) {
+	mock := setup(t, 0)
+	if run(mock, bob, CMD_DELEGATE, alice[:]) != 0 {
+		t.Fatal("CMD_DELEGATE failed")
+	}
+	mock.Block = 101
+	run(mock, alice, CMD_CREATE_PROPOSAL, []byte{0})
+
+	// Delegation changes after the snapshot do not count
+	run(mock, bob, CMD_UNDELEGATE)
+	mock.Block = 102
+	if run(mock, bob, CMD_VOTE, u64(1), []byte{VOTE_AGAINST}) == 0 {
+		t.Error("CMD_VOTE failed. Expected bob's votes to be delegated")
+	}
+	run(mock, alice, CMD_VOTE, u64(1), []byte{VOTE_FOR})
+	run(mock, alice, CMD_GET_VOTE, u64(1), alice[:])
+	if want := append([]byte{1, VOTE_FOR}, u64(455)...); !bytes.Equal(mock.Result, want) {
+		t.Errorf("CMD_GET_VOTE failed. Expected %x, got %x", want, mock.Result)
+	}
+}
+
+func TestProposalActions(t *testing.T) {
+	mock := setup(t, 0)
+	mock.SetBalance(mock.Contract, big.NewInt(1000))
+	payee := stygos.Address{0x9a}
+	var received []byte
+	mock.Deploy(payee, func(input []byte) ([]byte, error) {
+		received = input
+		return nil, nil
+	})
+
+	// A grant of 250 wei to payee, with some calldata
+	value := stygos.WordFromUint64(250)
+	data := []byte("grant")
+	action := append(append(append([]byte{1}, payee[:]...), value[:]...), 0, byte(len(data)))
+	action = append(action, data...)
+	if run(mock, alice, CMD_CREATE_PROPOSAL, []byte{0}, action[:len(action)-1]) == 0 {
+		t.Error("CMD_CREATE_PROPOSAL failed. Expected truncated actions to be rejected")
+	}
+	if run(mock, alice, CMD_CREATE_PROPOSAL, []byte{0}, action) != 0 {
+		t.Fatal("CMD_CREATE_PROPOSAL failed")
+	}
+	run(mock, alice, CMD_VOTE, u64(1), []byte{VOTE_FOR})
+	run(mock, bob, CMD_VOTE, u64(1), []byte{VOTE_FOR})
+
+	mock.Block = 111
+	if run(mock, alice, CMD_EXECUTE_PROPOSAL, u64(1)) != 0 {
+		t.Fatal("CMD_EXECUTE_PROPOSAL failed")
+	}
+	if b := mock.BalanceOf(payee); b.Int64() != 250 || !bytes.Equal(received, data) {
+		t.Errorf("CMD_EXECUTE_PROPOSAL failed. Expected 250 wei and %q, got %d and %q", data, b.Int64(), received)
+	}
+}
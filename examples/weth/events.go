@@ -0,0 +1,32 @@
+package main
+
+import "github.com/rafaelescrich/stygos"
+
+// Events of WETH9, with its argument names. Emitters in events_gen.go.
+//go:generate stygos-gen events -type Transfer,Approval,Deposit,Withdrawal -o events_gen.go
+
+// Transfer is emitted when tokens move between accounts.
+type Transfer struct {
+	Src stygos.Address `stygos:"indexed"`
+	Dst stygos.Address `stygos:"indexed"`
+	Wad stygos.U256
+}
+
+// Approval is emitted when an owner sets a spender's allowance.
+type Approval struct {
+	Src stygos.Address `stygos:"indexed"`
+	Guy stygos.Address `stygos:"indexed"`
+	Wad stygos.U256
+}
+
+// Deposit is emitted when ETH is wrapped.
+type Deposit struct {
+	Dst stygos.Address `stygos:"indexed"`
+	Wad stygos.U256
+}
+
+// Withdrawal is emitted when tokens are unwrapped to ETH.
+type Withdrawal struct {
+	Src stygos.Address `stygos:"indexed"`
+	Wad stygos.U256
+}
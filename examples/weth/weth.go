@@ -0,0 +1,215 @@
+// Command weth is a WETH9-compatible wrapped native token: an ERC-20 minted
+// one for one against the ETH deposited and burned to withdraw it.
+//
+// ETH sent with deposit(), with empty calldata or with any calldata that
+// matches no function is deposited for the sender, as in WETH9. The total
+// supply is the contract's ETH balance. An allowance of 2^256-1 is never
+// decreased by transferFrom.
+package main
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Storage keys (precomputed keccak256 literals in slots_gen.go)
+//go:generate stygos-gen slots -o slots_gen.go balancesKey=balances allowancesKey=allowances
+
+// ABI selectors
+var (
+	selName         = stygos.Selector{0x06, 0xfd, 0xde, 0x03} // name()
+	selSymbol       = stygos.Selector{0x95, 0xd8, 0x9b, 0x41} // symbol()
+	selDecimals     = stygos.Selector{0x31, 0x3c, 0xe5, 0x67} // decimals()
+	selTotalSupply  = stygos.Selector{0x18, 0x16, 0x0d, 0xdd} // totalSupply()
+	selBalanceOf    = stygos.Selector{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	selAllowance    = stygos.Selector{0xdd, 0x62, 0xed, 0x3e} // allowance(address,address)
+	selApprove      = stygos.Selector{0x09, 0x5e, 0xa7, 0xb3} // approve(address,uint256)
+	selTransfer     = stygos.Selector{0xa9, 0x05, 0x9c, 0xbb} // transfer(address,uint256)
+	selTransferFrom = stygos.Selector{0x23, 0xb8, 0x72, 0xdd} // transferFrom(address,address,uint256)
+	selDeposit      = stygos.Selector{0xd0, 0xe3, 0x0d, 0xb0} // deposit()
+	selWithdraw     = stygos.Selector{0x2e, 0x1a, 0x7d, 0x4d} // withdraw(uint256)
+)
+
+// WETH errors
+var (
+	ErrInsufficientBalance   = errors.New("weth: amount exceeds balance")
+	ErrInsufficientAllowance = errors.New("weth: insufficient allowance")
+)
+
+const (
+	name     = "Wrapped Ether"
+	symbol   = "WETH"
+	decimals = 18
+)
+
+var router = newRouter()
+
+func newRouter() *stygos.Router {
+	r := stygos.NewRouter()
+	r.HandleSelector(selName, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encodeString(name), nil
+	})
+	r.HandleSelector(selSymbol, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encodeString(symbol), nil
+	})
+	r.HandleSelector(selDecimals, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.WordFromUint64(decimals)), nil
+	})
+	r.HandleSelector(selTotalSupply, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return encode(stygos.GetBalance(stygos.GetContractAddress()).Word()), nil
+	})
+	r.HandleSelector(selBalanceOf, handleBalanceOf)
+	r.HandleSelector(selAllowance, handleAllowance)
+	r.HandleSelector(selApprove, handleApprove)
+	r.HandleSelector(selTransfer, handleTransfer)
+	r.HandleSelector(selTransferFrom, handleTransferFrom)
+	r.HandleSelector(selDeposit, handleDeposit)
+	r.HandleSelector(selWithdraw, handleWithdraw)
+	// receive() and fallback() both deposit
+	r.Fallback(handleDeposit)
+	return r
+}
+
+// main is required by Go but not used directly by Stylus
+func main() {}
+
+// init registers entrypoint as the function Stylus runs for each call
+func init() {
+	stygos.SetEntrypoint(entrypoint)
+}
+
+//export entrypoint
+func entrypoint() int32 {
+	return router.Entrypoint()
+}
+
+// handleDeposit credits the sender with msg.value tokens.
+func handleDeposit(*stygos.Ctx, []byte) ([]byte, error) {
+	sender, wad := stygos.GetMsgSender(), stygos.U256FromBig(stygos.GetMsgValue())
+	// The balance cannot overflow: it is bounded by the ETH in existence.
+	setBalance(sender, balanceOf(sender).Add(wad))
+	EmitDeposit(sender, wad)
+	return nil, nil
+}
+
+// handleWithdraw burns wad tokens of the sender and sends it wad wei.
+func handleWithdraw(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	sender, wad := stygos.GetMsgSender(), stygos.U256FromWord(w[0])
+	balance := balanceOf(sender)
+	if balance.Lt(wad) {
+		return nil, ErrInsufficientBalance
+	}
+	// Burn before sending, so a reentrant call sees the new balance.
+	setBalance(sender, balance.Sub(wad))
+	EmitWithdrawal(sender, wad)
+	return nil, stygos.Transfer(sender, wad)
+}
+
+// handleBalanceOf returns the tokens held by an account.
+func handleBalanceOf(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return encode(balanceOf(stygos.AddressFromWord(w[0])).Word()), nil
+}
+
+// handleAllowance returns the tokens a spender may move for an owner.
+func handleAllowance(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	return encode(allowance(stygos.AddressFromWord(w[0]), stygos.AddressFromWord(w[1])).Word()), nil
+}
+
+// handleApprove sets the allowance of a spender over the sender's tokens.
+func handleApprove(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	owner, spender, wad := stygos.GetMsgSender(), stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1])
+	stygos.StorageStore(allowanceSlot(owner, spender), wad.Word())
+	EmitApproval(owner, spender, wad)
+	return encode(stygos.WordFromUint64(1)), nil
+}
+
+// handleTransfer moves tokens from the sender.
+func handleTransfer(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	return transferFrom(stygos.GetMsgSender(), stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1]))
+}
+
+// handleTransferFrom moves tokens from an owner, spending the sender's
+// allowance unless the sender is the owner.
+func handleTransferFrom(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+	w, err := stygos.ArgWords(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	return transferFrom(stygos.AddressFromWord(w[0]), stygos.AddressFromWord(w[1]), stygos.U256FromWord(w[2]))
+}
+
+func transferFrom(src, dst stygos.Address, wad stygos.U256) ([]byte, error) {
+	balance := balanceOf(src)
+	if balance.Lt(wad) {
+		return nil, ErrInsufficientBalance
+	}
+	if spender := stygos.GetMsgSender(); src != spender {
+		slot := allowanceSlot(src, spender)
+		allowed := stygos.U256FromWord(stygos.StorageLoad(slot))
+		if allowed != (stygos.U256{}).Not() {
+			if allowed.Lt(wad) {
+				return nil, ErrInsufficientAllowance
+			}
+			stygos.StorageStore(slot, allowed.Sub(wad).Word())
+		}
+	}
+	setBalance(src, balance.Sub(wad))
+	setBalance(dst, balanceOf(dst).Add(wad))
+	EmitTransfer(src, dst, wad)
+	return encode(stygos.WordFromUint64(1)), nil
+}
+
+func balanceOf(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(storage.MapKey(balancesKey, account[:])))
+}
+
+func setBalance(account stygos.Address, amount stygos.U256) {
+	stygos.StorageStore(storage.MapKey(balancesKey, account[:]), amount.Word())
+}
+
+func allowance(owner, spender stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(allowanceSlot(owner, spender)))
+}
+
+func allowanceSlot(owner, spender stygos.Address) stygos.Word {
+	return storage.MapKey(storage.MapKey(allowancesKey, owner[:]), spender[:])
+}
+
+// encode concatenates words into ABI return data.
+func encode(words ...stygos.Word) []byte {
+	out := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		out = append(out, w[:]...)
+	}
+	return out
+}
+
+// encodeString returns the ABI encoding of a single string of at most 32
+// bytes.
+func encodeString(s string) []byte {
+	var data stygos.Word
+	copy(data[:], s)
+	return encode(stygos.WordFromUint64(32), stygos.WordFromUint64(uint64(len(s))), data)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	contract = stygos.Address{0xe7}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+)
+
+// setup deploys the contract and makes alice the caller with 1000 wei.
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	mock.Deploy(contract, stygos.MockEntrypoint(entrypoint))
+	mock.Contract = alice
+	mock.SetBalance(alice, big.NewInt(1000))
+	return mock
+}
+
+func u(v uint64) stygos.U256 {
+	return stygos.NewU256(v)
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selName, "name()"},
+		{selSymbol, "symbol()"},
+		{selDecimals, "decimals()"},
+		{selTotalSupply, "totalSupply()"},
+		{selBalanceOf, "balanceOf(address)"},
+		{selAllowance, "allowance(address,address)"},
+		{selApprove, "approve(address,uint256)"},
+		{selTransfer, "transfer(address,uint256)"},
+		{selTransferFrom, "transferFrom(address,address,uint256)"},
+		{selDeposit, "deposit()"},
+		{selWithdraw, "withdraw(uint256)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestDepositWithdraw(t *testing.T) {
+	mock := setup()
+	weth := token.NewWETH(contract)
+
+	if err := weth.Deposit(u(300)); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+	// receive() and fallback() deposit too
+	if _, err := stygos.Call(contract, u(200).Word(), nil); err != nil {
+		t.Fatalf("receive failed: %v", err)
+	}
+	if _, err := stygos.Call(contract, u(100).Word(), []byte{0x12, 0x34, 0x56, 0x78}); err != nil {
+		t.Fatalf("fallback failed: %v", err)
+	}
+	if got, err := weth.BalanceOf(alice); err != nil || got != u(600) {
+		t.Errorf("deposit failed. Expected a balance of 600, got %v, %v", got, err)
+	}
+	if got, err := weth.TotalSupply(); err != nil || got != u(600) {
+		t.Errorf("totalSupply failed. Expected 600, got %v, %v", got, err)
+	}
+	if len(mock.Logs) != 3 {
+		t.Errorf("deposit failed. Expected 3 Deposit events, got %d", len(mock.Logs))
+	}
+
+	if err := weth.Withdraw(u(250)); err != nil {
+		t.Fatalf("withdraw failed: %v", err)
+	}
+	if got, _ := weth.BalanceOf(alice); got != u(350) {
+		t.Errorf("withdraw failed. Expected a balance of 350, got %v", got)
+	}
+	if mock.BalanceOf(alice).Int64() != 650 || mock.BalanceOf(contract).Int64() != 350 {
+		t.Errorf("withdraw failed. Expected 650 and 350 wei, got %s and %s", mock.BalanceOf(alice), mock.BalanceOf(contract))
+	}
+	if err := weth.Withdraw(u(351)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("withdraw failed. Expected a revert above the balance, got %v", err)
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	mock := setup()
+	weth := token.NewWETH(contract)
+	if err := weth.Deposit(u(100)); err != nil {
+		t.Fatalf("deposit failed: %v", err)
+	}
+
+	if err := weth.Transfer(bob, u(30)); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if err := weth.Transfer(bob, u(71)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("transfer failed. Expected a revert above the balance, got %v", err)
+	}
+
+	// bob spends a limited allowance, then an infinite one
+	if err := weth.Approve(bob, u(50)); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	mock.Contract = bob
+	if err := weth.TransferFrom(alice, bob, u(20)); err != nil {
+		t.Fatalf("transferFrom failed: %v", err)
+	}
+	if got, _ := weth.Allowance(alice, bob); got != u(30) {
+		t.Errorf("transferFrom failed. Expected an allowance of 30, got %v", got)
+	}
+	if err := weth.TransferFrom(alice, bob, u(31)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("transferFrom failed. Expected a revert above the allowance, got %v", err)
+	}
+	mock.Contract = alice
+	max := stygos.U256{}.Not()
+	if err := weth.Approve(bob, max); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	mock.Contract = bob
+	if err := weth.TransferFrom(alice, bob, u(50)); err != nil {
+		t.Fatalf("transferFrom failed: %v", err)
+	}
+	if got, _ := weth.Allowance(alice, bob); got != max {
+		t.Errorf("transferFrom failed. Expected an infinite allowance to stay, got %v", got)
+	}
+
+	if got, _ := weth.BalanceOf(bob); got != u(100) {
+		t.Errorf("transfers failed. Expected bob to hold 100, got %v", got)
+	}
+	if got, err := weth.Decimals(); err != nil || got != 18 {
+		t.Errorf("decimals failed. Expected 18, got %d, %v", got, err)
+	}
+	ret, err := stygos.StaticCall(contract, selSymbol[:])
+	if err != nil || len(ret) != 96 || string(ret[64:68]) != "WETH" || ret[63] != 4 {
+		t.Errorf("symbol failed. Expected WETH, got %x, %v", ret, err)
+	}
+}
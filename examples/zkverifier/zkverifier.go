@@ -0,0 +1,13 @@
+// Command zkverifier verifies Groth16 proofs on-chain through the BN254
+// precompiles. It is generated from a snarkjs verification key and keeps
+// the verifyProof function of the Solidity verifier snarkjs exports, so
+// provers and callers written for that contract work unchanged:
+//
+//	snarkjs zkey export verificationkey circuit.zkey verification_key.json
+//	go generate
+//
+// The key checked in here comes from a toy setup over two public inputs,
+// whose secrets the tests use to make proofs.
+package main
+
+//go:generate stygos-gen groth16 -vk verification_key.json -o verifier_gen.go -contract
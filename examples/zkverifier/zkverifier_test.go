@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/bn254"
+)
+
+var contract = stygos.Address{0x2c}
+
+// trapdoor holds the secrets of the toy setup behind verification_key.json.
+var trapdoor = &bn254.Groth16Trapdoor{
+	Alpha: bigInt("8130584791373891146219873712958"),
+	Beta:  bigInt("2765281903427018543619826410527"),
+	Gamma: bigInt("5918273640192837465019283746501"),
+	Delta: bigInt("7361829104758392017465829304756"),
+	IC: []*big.Int{
+		bigInt("4412093847561029384756102938475"),
+		bigInt("1928374650192837465019283746519"),
+		bigInt("6650192837465019283746501928377"),
+	},
+}
+
+func bigInt(s string) *big.Int {
+	v, _ := new(big.Int).SetString(s, 10)
+	return v
+}
+
+func setup() {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	bn254.InstallMockPrecompiles(mock)
+	mock.Deploy(contract, stygos.MockEntrypoint(entrypoint))
+}
+
+// verifyProof calls verifyProof with the proof and inputs ABI encoded as
+// a snarkjs verifier takes them.
+func verifyProof(proof bn254.Groth16Proof, inputs []stygos.Word) (bool, error) {
+	data := append(selVerifyProof[:], proof.A.Bytes()...)
+	data = append(data, proof.B.Bytes()...)
+	data = append(data, proof.C.Bytes()...)
+	for _, in := range inputs {
+		data = append(data, in[:]...)
+	}
+	ret, err := stygos.StaticCall(contract, data)
+	if err != nil {
+		return false, err
+	}
+	return len(ret) == 32 && ret[31] == 1, nil
+}
+
+func TestVerifyingKey(t *testing.T) {
+	if got := stygos.SelectorOf("verifyProof(uint256[2],uint256[2][2],uint256[2],uint256[2])"); got != selVerifyProof {
+		t.Errorf("Selector failed. Expected %x, got %x", got, selVerifyProof)
+	}
+	want := trapdoor.VerifyingKey()
+	if verifyingKey.Alpha != want.Alpha || verifyingKey.Beta != want.Beta ||
+		verifyingKey.Gamma != want.Gamma || verifyingKey.Delta != want.Delta ||
+		len(verifyingKey.IC) != len(want.IC) || verifyingKey.IC[2] != want.IC[2] {
+		t.Errorf("verifying key failed. Expected the key of the toy setup, got %+v", verifyingKey)
+	}
+}
+
+func TestVerifyProof(t *testing.T) {
+	setup()
+	inputs := []stygos.Word{stygos.WordFromUint64(33), stygos.WordFromUint64(3)}
+	proof := trapdoor.Prove(inputs, big.NewInt(987654321), big.NewInt(123456789))
+
+	if ok, err := verifyProof(proof, inputs); !ok || err != nil {
+		t.Errorf("verifyProof failed. Expected true, got %v, %v", ok, err)
+	}
+
+	other := []stygos.Word{stygos.WordFromUint64(33), stygos.WordFromUint64(11)}
+	if ok, err := verifyProof(proof, other); ok || err != nil {
+		t.Errorf("verifyProof with other inputs failed. Expected false, got %v, %v", ok, err)
+	}
+
+	// Invalid points and inputs return false, as in the Solidity verifier
+	offCurve := proof
+	offCurve.C.Y[31] ^= 1
+	if ok, err := verifyProof(offCurve, inputs); ok || err != nil {
+		t.Errorf("verifyProof off the curve failed. Expected false, got %v, %v", ok, err)
+	}
+	var aliased stygos.Word
+	new(big.Int).Add(bn254.R, big.NewInt(33)).FillBytes(aliased[:])
+	if ok, err := verifyProof(proof, []stygos.Word{aliased, inputs[1]}); ok || err != nil {
+		t.Errorf("verifyProof with an input over R failed. Expected false, got %v, %v", ok, err)
+	}
+
+	if _, err := verifyProof(proof, inputs[:1]); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("verifyProof with one input failed. Expected a revert, got %v", err)
+	}
+}
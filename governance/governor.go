@@ -0,0 +1,443 @@
+// Package governance implements on-chain proposals and weighted voting.
+//
+// A Governor takes proposals, counts votes For, Against and Abstain with
+// each voter's weight at the proposal's snapshot block, and lets a
+// proposal that reached quorum with more For than Against votes be
+// executed once, dispatching its actions. Voting is either open, with votes visible as they are
+// cast, or commit-reveal: voters submit hashed commitments while voting
+// is active and reveal them in a following reveal period, and only
+// revealed votes are tallied, so late voters cannot follow the running
+// result.
+package governance
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/strconvx"
+)
+
+// Governance errors
+var (
+	ErrInitialized        = errors.New("governance: already initialized")
+	ErrInvalidPeriod      = errors.New("governance: voting period must be positive")
+	ErrUnknownProposal    = errors.New("governance: unknown proposal")
+	ErrVotingClosed       = errors.New("governance: voting is not active")
+	ErrNotRevealing       = errors.New("governance: reveal period is not active")
+	ErrCommitReveal       = errors.New("governance: votes must be committed and revealed")
+	ErrOpenVoting         = errors.New("governance: commit-reveal is not enabled")
+	ErrInvalidSupport     = errors.New("governance: invalid vote type")
+	ErrAlreadyVoted       = errors.New("governance: already voted")
+	ErrNoWeight           = errors.New("governance: voter has no weight")
+	ErrNoCommitment       = errors.New("governance: no vote committed")
+	ErrCommitmentMismatch = errors.New("governance: reveal does not match commitment")
+	ErrNotSucceeded       = errors.New("governance: proposal has not succeeded")
+	ErrTooManyActions     = errors.New("governance: too many actions")
+)
+
+// MaxActions is the most actions a proposal can carry.
+const MaxActions = 16
+
+// Support is the kind of a vote.
+type Support uint8
+
+// Vote kinds, numbered as in OpenZeppelin's Governor
+const (
+	Against Support = iota
+	For
+	Abstain
+)
+
+// State is the stage of a proposal.
+type State uint8
+
+// Proposal states
+const (
+	Active    State = iota + 1 // open votes, or commitments in commit-reveal mode
+	Revealing                  // commit-reveal mode: commitments are being revealed
+	Defeated
+	Succeeded
+	Executed
+)
+
+// Config holds the voting rules, packed into storage by
+// governor_pack_gen.go. A non-zero RevealPeriod enables commit-reveal
+// voting.
+//
+//go:generate stygos-gen pack -type Config,Proposal,Receipt -o governor_pack_gen.go
+type Config struct {
+	VotingPeriod uint64 // blocks
+	RevealPeriod uint64 // blocks
+	Quorum       stygos.U256
+}
+
+// Proposal is the voting record of a proposal. Weights are taken at the
+// Snapshot block, the one before the proposal was created, so they are
+// final by the time anyone votes; votes (or commitments) are accepted after
+// Snapshot up to VoteEnd, and reveals after VoteEnd up to RevealEnd.
+type Proposal struct {
+	Proposer     stygos.Address
+	Snapshot     uint64
+	VoteEnd      uint64
+	RevealEnd    uint64
+	Executed     bool
+	ForVotes     stygos.U256
+	AgainstVotes stygos.U256
+	AbstainVotes stygos.U256
+}
+
+// Receipt is a voter's vote on a proposal. Commitment is set by
+// CommitVote; Voted, Support and Weight once the vote is cast or revealed.
+type Receipt struct {
+	Voted      bool
+	Support    uint8
+	Weight     stygos.U256
+	Commitment stygos.Word
+}
+
+// Action is a call a proposal makes when it is executed.
+type Action struct {
+	Target stygos.Address
+	Value  stygos.U256
+	Data   []byte
+}
+
+// ActionError reports the action of an executed proposal that failed.
+type ActionError struct {
+	Index int
+	Err   error
+}
+
+func (e *ActionError) Error() string {
+	return "governance: action " + strconvx.Itoa(e.Index) + " failed: " + e.Err.Error()
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// Weights returns the weight of voter at a snapshot block.
+type Weights func(voter stygos.Address, snapshot uint64) stygos.U256
+
+// Governor stores proposals and tallies votes.
+//
+// Storage layout relative to the base slot:
+//
+//	base     Config (ConfigPackedWords slots)
+//	base+2   proposal count
+//	base+3   MapKey(base+3, id) -> Proposal, then its description bytes
+//	base+4   MapKey(base+4, id || voter) -> Receipt
+//	base+5   MapKey(base+5, id) -> action count; MapKey(base+5, id || i) ->
+//	         action i as target, value and data (three slots)
+type Governor struct {
+	config    stygos.Word
+	count     stygos.Word
+	proposals stygos.Word
+	receipts  stygos.Word
+	actions   stygos.Word
+	weights   Weights
+}
+
+// NewGovernor returns the governor rooted at base, weighing votes with
+// weights.
+func NewGovernor(base stygos.Word, weights Weights) *Governor {
+	return &Governor{
+		config:    base,
+		count:     storage.Offset(base, 2),
+		proposals: storage.Offset(base, 3),
+		receipts:  storage.Offset(base, 4),
+		actions:   storage.Offset(base, 5),
+		weights:   weights,
+	}
+}
+
+// Initialize sets the voting period and quorum, in blocks and weight. A
+// non-zero revealPeriod selects commit-reveal voting.
+func (g *Governor) Initialize(votingPeriod, revealPeriod uint64, quorum stygos.U256) error {
+	if g.Config().VotingPeriod != 0 {
+		return ErrInitialized
+	}
+	if votingPeriod == 0 {
+		return ErrInvalidPeriod
+	}
+	c := Config{VotingPeriod: votingPeriod, RevealPeriod: revealPeriod, Quorum: quorum}
+	c.Store(g.config)
+	return nil
+}
+
+// Config returns the voting rules.
+func (g *Governor) Config() Config {
+	var c Config
+	c.Load(g.config)
+	return c
+}
+
+// CommitReveal reports whether votes are committed and revealed.
+func (g *Governor) CommitReveal() bool {
+	return g.Config().RevealPeriod != 0
+}
+
+// ProposalCount returns the number of proposals; ids run from 1.
+func (g *Governor) ProposalCount() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(g.count))
+}
+
+// Propose opens voting on a proposal by the caller to make the calls in
+// actions, and returns its id. A proposal without actions only records the
+// outcome of the vote.
+func (g *Governor) Propose(actions []Action, description []byte) (uint64, error) {
+	c := g.Config()
+	if c.VotingPeriod == 0 {
+		return 0, ErrInvalidPeriod
+	}
+	if len(actions) > MaxActions {
+		return 0, ErrTooManyActions
+	}
+	id := g.ProposalCount() + 1
+	now := stygos.GetBlockNumber()
+	p := Proposal{
+		Proposer:  stygos.GetMsgSender(),
+		Snapshot:  now - 1,
+		VoteEnd:   now + c.VotingPeriod,
+		RevealEnd: now + c.VotingPeriod + c.RevealPeriod,
+	}
+	slot := g.proposalSlot(id)
+	p.Store(slot)
+	storage.StoreBytes(storage.Offset(slot, ProposalPackedWords), description)
+	g.storeActions(id, actions)
+	stygos.StorageStore(g.count, stygos.WordFromUint64(id))
+	return id, nil
+}
+
+// Proposal returns the voting record of a proposal.
+func (g *Governor) Proposal(id uint64) (Proposal, error) {
+	var p Proposal
+	p.Load(g.proposalSlot(id))
+	if p.VoteEnd == 0 {
+		return p, ErrUnknownProposal
+	}
+	return p, nil
+}
+
+// Description returns the description of a proposal.
+func (g *Governor) Description(id uint64) []byte {
+	return storage.LoadBytes(storage.Offset(g.proposalSlot(id), ProposalPackedWords))
+}
+
+// Actions returns the calls a proposal makes when executed.
+func (g *Governor) Actions(id uint64) []Action {
+	idWord := stygos.WordFromUint64(id)
+	n := stygos.Uint64FromWord(stygos.StorageLoad(storage.MapKey(g.actions, idWord[:])))
+	actions := make([]Action, n)
+	for i := range actions {
+		slot := g.actionSlot(id, uint64(i))
+		actions[i] = Action{
+			Target: stygos.AddressFromWord(stygos.StorageLoad(slot)),
+			Value:  stygos.U256FromWord(stygos.StorageLoad(storage.Offset(slot, 1))),
+			Data:   storage.LoadBytes(storage.Offset(slot, 2)),
+		}
+	}
+	return actions
+}
+
+// State returns the stage of a proposal at the current block.
+func (g *Governor) State(id uint64) (State, error) {
+	p, err := g.Proposal(id)
+	if err != nil {
+		return 0, err
+	}
+	now := stygos.GetBlockNumber()
+	switch {
+	case p.Executed:
+		return Executed, nil
+	case now <= p.VoteEnd:
+		return Active, nil
+	case now <= p.RevealEnd:
+		return Revealing, nil
+	case g.succeeded(&p):
+		return Succeeded, nil
+	}
+	return Defeated, nil
+}
+
+// Receipt returns voter's vote on a proposal.
+func (g *Governor) Receipt(id uint64, voter stygos.Address) Receipt {
+	var r Receipt
+	r.Load(g.receiptSlot(id, voter))
+	return r
+}
+
+// CastVote records the caller's vote in open voting and returns its
+// weight.
+func (g *Governor) CastVote(id uint64, support Support) (stygos.U256, error) {
+	if g.CommitReveal() {
+		return stygos.U256{}, ErrCommitReveal
+	}
+	if support > Abstain {
+		return stygos.U256{}, ErrInvalidSupport
+	}
+	p, err := g.activeProposal(id)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	voter := stygos.GetMsgSender()
+	r := g.Receipt(id, voter)
+	if r.Voted {
+		return stygos.U256{}, ErrAlreadyVoted
+	}
+	return g.tally(id, &p, voter, &r, support)
+}
+
+// Commitment returns the hash a voter commits to in commit-reveal voting:
+// keccak256(id || voter || support || salt), with id as a uint256. The
+// salt must be secret and random, or the vote can be guessed.
+func Commitment(id uint64, voter stygos.Address, support Support, salt stygos.Word) stygos.Word {
+	idWord := stygos.WordFromUint64(id)
+	data := make([]byte, 0, 32+20+1+32)
+	data = append(data, idWord[:]...)
+	data = append(data, voter[:]...)
+	data = append(data, byte(support))
+	data = append(data, salt[:]...)
+	return stygos.Keccak256(data)
+}
+
+// CommitVote records the caller's vote commitment while voting is active.
+// A commitment can be replaced until voting ends.
+func (g *Governor) CommitVote(id uint64, commitment stygos.Word) error {
+	if !g.CommitReveal() {
+		return ErrOpenVoting
+	}
+	p, err := g.activeProposal(id)
+	if err != nil {
+		return err
+	}
+	voter := stygos.GetMsgSender()
+	if g.weights(voter, p.Snapshot).IsZero() {
+		return ErrNoWeight
+	}
+	r := g.Receipt(id, voter)
+	r.Commitment = commitment
+	r.Store(g.receiptSlot(id, voter))
+	return nil
+}
+
+// RevealVote opens the caller's commitment during the reveal period,
+// tallies the vote and returns its weight.
+func (g *Governor) RevealVote(id uint64, support Support, salt stygos.Word) (stygos.U256, error) {
+	if !g.CommitReveal() {
+		return stygos.U256{}, ErrOpenVoting
+	}
+	p, err := g.Proposal(id)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	now := stygos.GetBlockNumber()
+	if now <= p.VoteEnd || now > p.RevealEnd {
+		return stygos.U256{}, ErrNotRevealing
+	}
+	voter := stygos.GetMsgSender()
+	r := g.Receipt(id, voter)
+	if r.Voted {
+		return stygos.U256{}, ErrAlreadyVoted
+	}
+	if r.Commitment == (stygos.Word{}) {
+		return stygos.U256{}, ErrNoCommitment
+	}
+	if support > Abstain || Commitment(id, voter, support, salt) != r.Commitment {
+		return stygos.U256{}, ErrCommitmentMismatch
+	}
+	return g.tally(id, &p, voter, &r, support)
+}
+
+// Execute marks a succeeded proposal executed and makes its calls in
+// order, from the governing contract. A call that reverts stops execution
+// with an *ActionError; the contract must then revert, undoing the earlier
+// calls and the executed flag, so a proposal runs all of its actions or
+// none.
+func (g *Governor) Execute(id uint64) error {
+	state, err := g.State(id)
+	if err != nil {
+		return err
+	}
+	if state != Succeeded {
+		return ErrNotSucceeded
+	}
+	p, _ := g.Proposal(id)
+	p.Executed = true
+	p.Store(g.proposalSlot(id))
+
+	for i, a := range g.Actions(id) {
+		if _, err := stygos.Call(a.Target, a.Value.Word(), a.Data); err != nil {
+			return &ActionError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// activeProposal returns a proposal that is accepting votes or
+// commitments.
+func (g *Governor) activeProposal(id uint64) (Proposal, error) {
+	p, err := g.Proposal(id)
+	if err != nil {
+		return p, err
+	}
+	if now := stygos.GetBlockNumber(); now <= p.Snapshot || now > p.VoteEnd {
+		return p, ErrVotingClosed
+	}
+	return p, nil
+}
+
+// tally adds voter's weight to the proposal and records the receipt.
+func (g *Governor) tally(id uint64, p *Proposal, voter stygos.Address, r *Receipt, support Support) (stygos.U256, error) {
+	weight := g.weights(voter, p.Snapshot)
+	if weight.IsZero() {
+		return weight, ErrNoWeight
+	}
+	switch support {
+	case For:
+		p.ForVotes = p.ForVotes.Add(weight)
+	case Against:
+		p.AgainstVotes = p.AgainstVotes.Add(weight)
+	case Abstain:
+		p.AbstainVotes = p.AbstainVotes.Add(weight)
+	}
+	p.Store(g.proposalSlot(id))
+
+	r.Voted, r.Support, r.Weight = true, uint8(support), weight
+	r.Store(g.receiptSlot(id, voter))
+	return weight, nil
+}
+
+// succeeded reports whether the votes reached quorum, counting
+// abstentions, with more For than Against.
+func (g *Governor) succeeded(p *Proposal) bool {
+	total := p.ForVotes.Add(p.AgainstVotes).Add(p.AbstainVotes)
+	return !total.Lt(g.Config().Quorum) && p.AgainstVotes.Lt(p.ForVotes)
+}
+
+func (g *Governor) proposalSlot(id uint64) stygos.Word {
+	w := stygos.WordFromUint64(id)
+	return storage.MapKey(g.proposals, w[:])
+}
+
+func (g *Governor) storeActions(id uint64, actions []Action) {
+	idWord := stygos.WordFromUint64(id)
+	stygos.StorageStore(storage.MapKey(g.actions, idWord[:]), stygos.WordFromUint64(uint64(len(actions))))
+	for i, a := range actions {
+		slot := g.actionSlot(id, uint64(i))
+		stygos.StorageStore(slot, stygos.PadAddress(a.Target))
+		stygos.StorageStore(storage.Offset(slot, 1), a.Value.Word())
+		storage.StoreBytes(storage.Offset(slot, 2), a.Data)
+	}
+}
+
+func (g *Governor) actionSlot(id, i uint64) stygos.Word {
+	idWord, iWord := stygos.WordFromUint64(id), stygos.WordFromUint64(i)
+	return storage.MapKey(g.actions, append(idWord[:], iWord[:]...))
+}
+
+func (g *Governor) receiptSlot(id uint64, voter stygos.Address) stygos.Word {
+	w := stygos.WordFromUint64(id)
+	return storage.MapKey(g.receipts, append(w[:], voter[:]...))
+}
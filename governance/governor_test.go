@@ -0,0 +1,265 @@
+package governance
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+var (
+	alice = stygos.Address{0xa1}
+	bob   = stygos.Address{0xb0}
+	carol = stygos.Address{0xca}
+	eve   = stygos.Address{0xee}
+)
+
+// weights gives alice 1000, bob 400 and carol 300.
+func weights(voter stygos.Address, snapshot uint64) stygos.U256 {
+	return stygos.NewU256(map[stygos.Address]uint64{alice: 1000, bob: 400, carol: 300}[voter])
+}
+
+func setup(t *testing.T, revealPeriod uint64) (*stygos.MockRuntime, *Governor) {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.Block = 100
+	stygos.UseRuntime(mock)
+
+	g := NewGovernor(stygos.Word{0x90}, weights)
+	if err := g.Initialize(10, revealPeriod, stygos.NewU256(500)); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return mock, g
+}
+
+// as runs fn with sender as msg.sender.
+func as(mock *stygos.MockRuntime, sender stygos.Address, fn func() error) error {
+	mock.Sender = sender
+	defer func() { mock.Sender = stygos.Address{} }()
+	return fn()
+}
+
+func TestPropose(t *testing.T) {
+	mock, g := setup(t, 0)
+	if err := g.Initialize(1, 0, stygos.U256{}); err != ErrInitialized {
+		t.Errorf("Initialize failed. Expected ErrInitialized, got %v", err)
+	}
+
+	// Descriptions longer than a word are kept whole
+	description := bytes.Repeat([]byte("raise the quorum "), 4)
+	mock.Sender = alice
+	id, err := g.Propose(nil, description)
+	if err != nil || id != 1 {
+		t.Fatalf("Propose failed. Expected id 1, got %d, %v", id, err)
+	}
+	p, err := g.Proposal(id)
+	if err != nil || p.Proposer != alice || p.Snapshot != 99 || p.VoteEnd != 110 || p.RevealEnd != 110 {
+		t.Errorf("Proposal failed. Got %+v, %v", p, err)
+	}
+	if got := g.Description(id); !bytes.Equal(got, description) {
+		t.Errorf("Description failed. Expected %q, got %q", description, got)
+	}
+	if _, err := g.Proposal(2); err != ErrUnknownProposal {
+		t.Errorf("Proposal failed. Expected ErrUnknownProposal, got %v", err)
+	}
+}
+
+func TestOpenVoting(t *testing.T) {
+	mock, g := setup(t, 0)
+	id, _ := g.Propose(nil, nil)
+
+	tests := []struct {
+		voter   stygos.Address
+		support Support
+		err     error
+	}{
+		{alice, For, nil},
+		{alice, Against, ErrAlreadyVoted},
+		{bob, Against, nil},
+		{carol, Support(3), ErrInvalidSupport},
+		{carol, Abstain, nil},
+		{eve, For, ErrNoWeight},
+	}
+	for _, tt := range tests {
+		err := as(mock, tt.voter, func() error {
+			_, err := g.CastVote(id, tt.support)
+			return err
+		})
+		if err != tt.err {
+			t.Errorf("CastVote %x failed. Expected %v, got %v", tt.voter, tt.err, err)
+		}
+	}
+
+	// Weights above 255 and uint64 tallies are kept whole
+	p, _ := g.Proposal(id)
+	if p.ForVotes.Uint64() != 1000 || p.AgainstVotes.Uint64() != 400 || p.AbstainVotes.Uint64() != 300 {
+		t.Errorf("CastVote failed. Got tallies %d/%d/%d", p.ForVotes.Uint64(), p.AgainstVotes.Uint64(), p.AbstainVotes.Uint64())
+	}
+	if r := g.Receipt(id, bob); !r.Voted || Support(r.Support) != Against || r.Weight.Uint64() != 400 {
+		t.Errorf("Receipt failed. Got %+v", r)
+	}
+	if err := g.CommitVote(id, stygos.Word{1}); err != ErrOpenVoting {
+		t.Errorf("CommitVote failed. Expected ErrOpenVoting, got %v", err)
+	}
+
+	if err := g.Execute(id); err != ErrNotSucceeded {
+		t.Errorf("Execute failed. Expected ErrNotSucceeded while active, got %v", err)
+	}
+	mock.Block = 111
+	if err := as(mock, eve, func() error { _, err := g.CastVote(id, For); return err }); err != ErrVotingClosed {
+		t.Errorf("CastVote failed. Expected ErrVotingClosed, got %v", err)
+	}
+	if s, _ := g.State(id); s != Succeeded {
+		t.Errorf("State failed. Expected Succeeded, got %d", s)
+	}
+	if err := g.Execute(id); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if err := g.Execute(id); err != ErrNotSucceeded {
+		t.Errorf("Execute failed. Expected a second execution to fail, got %v", err)
+	}
+
+	// Below quorum
+	mock.Block = 200
+	id, _ = g.Propose(nil, nil)
+	as(mock, bob, func() error { _, err := g.CastVote(id, For); return err })
+	mock.Block = 211
+	if s, _ := g.State(id); s != Defeated {
+		t.Errorf("State failed. Expected Defeated below quorum, got %d", s)
+	}
+}
+
+func TestCommitReveal(t *testing.T) {
+	mock, g := setup(t, 5)
+	id, _ := g.Propose(nil, nil)
+	salts := map[stygos.Address]stygos.Word{alice: {0x01}, bob: {0x02}, carol: {0x03}}
+	votes := map[stygos.Address]Support{alice: Against, bob: For, carol: For}
+
+	if err := as(mock, alice, func() error { _, err := g.CastVote(id, For); return err }); err != ErrCommitReveal {
+		t.Errorf("CastVote failed. Expected ErrCommitReveal, got %v", err)
+	}
+	if err := as(mock, eve, func() error { return g.CommitVote(id, stygos.Word{1}) }); err != ErrNoWeight {
+		t.Errorf("CommitVote failed. Expected ErrNoWeight, got %v", err)
+	}
+	for voter, support := range votes {
+		c := Commitment(id, voter, support, salts[voter])
+		if err := as(mock, voter, func() error { return g.CommitVote(id, c) }); err != nil {
+			t.Fatalf("CommitVote failed: %v", err)
+		}
+	}
+
+	// Commitments reveal nothing and cannot be opened early
+	if p, _ := g.Proposal(id); !p.ForVotes.IsZero() || !p.AgainstVotes.IsZero() {
+		t.Error("CommitVote failed. Expected no tallies before the reveal")
+	}
+	reveal := func(voter stygos.Address, support Support, salt stygos.Word) error {
+		return as(mock, voter, func() error { _, err := g.RevealVote(id, support, salt); return err })
+	}
+	if err := reveal(alice, Against, salts[alice]); err != ErrNotRevealing {
+		t.Errorf("RevealVote failed. Expected ErrNotRevealing, got %v", err)
+	}
+
+	mock.Block = 111
+	if s, _ := g.State(id); s != Revealing {
+		t.Errorf("State failed. Expected Revealing, got %d", s)
+	}
+	if err := as(mock, alice, func() error { return g.CommitVote(id, stygos.Word{1}) }); err != ErrVotingClosed {
+		t.Errorf("CommitVote failed. Expected ErrVotingClosed, got %v", err)
+	}
+	if err := reveal(alice, For, salts[alice]); err != ErrCommitmentMismatch {
+		t.Errorf("RevealVote failed. Expected ErrCommitmentMismatch for a changed vote, got %v", err)
+	}
+	if err := reveal(eve, For, stygos.Word{}); err != ErrNoCommitment {
+		t.Errorf("RevealVote failed. Expected ErrNoCommitment, got %v", err)
+	}
+	if err := reveal(bob, For, salts[bob]); err != nil {
+		t.Fatalf("RevealVote failed: %v", err)
+	}
+	if err := reveal(bob, For, salts[bob]); err != ErrAlreadyVoted {
+		t.Errorf("RevealVote failed. Expected ErrAlreadyVoted, got %v", err)
+	}
+	if err := reveal(carol, For, salts[carol]); err != nil {
+		t.Fatalf("RevealVote failed: %v", err)
+	}
+
+	// Alice never reveals her Against vote, so only the revealed For votes
+	// count
+	mock.Block = 117
+	if err := reveal(alice, Against, salts[alice]); err != ErrNotRevealing {
+		t.Errorf("RevealVote failed. Expected ErrNotRevealing after the period, got %v", err)
+	}
+	p, _ := g.Proposal(id)
+	if p.ForVotes.Uint64() != 700 || !p.AgainstVotes.IsZero() {
+		t.Errorf("RevealVote failed. Expected 700 For and no Against, got %d/%d", p.ForVotes.Uint64(), p.AgainstVotes.Uint64())
+	}
+	if s, _ := g.State(id); s != Succeeded {
+		t.Errorf("State failed. Expected Succeeded, got %d", s)
+	}
+}
+
+func TestExecuteActions(t *testing.T) {
+	mock, g := setup(t, 0)
+	mock.SetBalance(mock.Contract, big.NewInt(100))
+	target, failing := stygos.Address{0x7a}, stygos.Address{0xfa}
+	var calls [][]byte
+	mock.Deploy(target, func(input []byte) ([]byte, error) {
+		calls = append(calls, input)
+		return nil, nil
+	})
+	mock.Deploy(failing, func([]byte) ([]byte, error) {
+		return nil, stygos.ErrRevert
+	})
+	pass := func(actions []Action) uint64 {
+		t.Helper()
+		mock.Block++
+		id, err := g.Propose(actions, nil)
+		if err != nil {
+			t.Fatalf("Propose failed: %v", err)
+		}
+		as(mock, alice, func() error { _, err := g.CastVote(id, For); return err })
+		mock.Block += 11
+		return id
+	}
+
+	if _, err := g.Propose(make([]Action, MaxActions+1), nil); err != ErrTooManyActions {
+		t.Errorf("Propose failed. Expected ErrTooManyActions, got %v", err)
+	}
+
+	// Calldata longer than a word is kept whole
+	long := bytes.Repeat([]byte{0xab}, 70)
+	actions := []Action{
+		{Target: target, Data: long},
+		{Target: target, Value: stygos.NewU256(40), Data: []byte{0x01}},
+	}
+	id := pass(actions)
+	got := g.Actions(id)
+	if len(got) != 2 || got[0].Target != target || !bytes.Equal(got[0].Data, long) || got[1].Value.Uint64() != 40 {
+		t.Errorf("Actions failed. Got %+v", got)
+	}
+	if err := g.Execute(id); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(calls) != 2 || !bytes.Equal(calls[0], long) || !bytes.Equal(calls[1], []byte{0x01}) {
+		t.Errorf("Execute failed. Expected both calls in order, got %x", calls)
+	}
+	if b := mock.BalanceOf(target); b.Int64() != 40 {
+		t.Errorf("Execute failed. Expected the value sent, got %d", b.Int64())
+	}
+
+	// A reverting action fails the execution
+	id = pass([]Action{{Target: target}, {Target: failing}})
+	err := g.Execute(id)
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) || actionErr.Index != 1 || !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Execute failed. Expected action 1 to fail, got %v", err)
+	}
+
+	// So does an action the treasury cannot pay for
+	id = pass([]Action{{Target: target, Value: stygos.NewU256(1000)}})
+	if err := g.Execute(id); !errors.As(err, &actionErr) || actionErr.Index != 0 {
+		t.Errorf("Execute failed. Expected action 0 to fail, got %v", err)
+	}
+}
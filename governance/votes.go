@@ -0,0 +1,244 @@
+package governance
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Votes errors
+var (
+	ErrFutureLookup      = errors.New("governance: block not yet mined")
+	ErrVotesOverflow     = errors.New("governance: votes exceed 208 bits")
+	ErrInsufficientVotes = errors.New("governance: delegate has too few votes")
+)
+
+// maxVotes is the largest vote count a checkpoint holds, 2^208 - 1.
+var maxVotes = stygos.U256{}.Not().Rsh(48)
+
+// Checkpoint is the vote count of an account from a block on.
+type Checkpoint struct {
+	Block uint64
+	Votes stygos.U256
+}
+
+// Units returns the voting units an account holds, usually its token
+// balance.
+type Units func(account stygos.Address) stygos.U256
+
+// Votes tracks delegated voting power with a history of checkpoints, like
+// OpenZeppelin's Votes. Holders delegate all their units to one delegate,
+// themselves included; units that were never delegated do not count. The
+// token contract reports every mint, burn and transfer with
+// TransferVotingUnits. Votes emits the DelegateChanged and
+// DelegateVotesChanged events of the OpenZeppelin ABI, so existing
+// governance tooling can follow delegations.
+//
+// Storage layout relative to the base slot:
+//
+//	base     MapKey(base, account) -> delegate
+//	base+1   MapKey(base+1, account) -> checkpoints (a WordArray)
+//	base+2   total supply checkpoints (a WordArray)
+//
+// A checkpoint is one word: the block in the top 6 bytes and the votes in
+// the low 26.
+type Votes struct {
+	delegates   stygos.Word
+	checkpoints stygos.Word
+	supply      storage.WordArray
+	units       Units
+}
+
+// NewVotes returns the votes rooted at base, reading holders' voting units
+// with units.
+func NewVotes(base stygos.Word, units Units) *Votes {
+	return &Votes{
+		delegates:   base,
+		checkpoints: storage.Offset(base, 1),
+		supply:      storage.NewWordArray(storage.Offset(base, 2)),
+		units:       units,
+	}
+}
+
+// Delegates returns the delegate of account, or the zero address.
+func (v *Votes) Delegates(account stygos.Address) stygos.Address {
+	return stygos.AddressFromWord(stygos.StorageLoad(storage.MapKey(v.delegates, account[:])))
+}
+
+// Delegate moves the caller's voting units to delegatee.
+func (v *Votes) Delegate(delegatee stygos.Address) error {
+	delegator := stygos.GetMsgSender()
+	old := v.Delegates(delegator)
+	stygos.StorageStore(storage.MapKey(v.delegates, delegator[:]), stygos.PadAddress(delegatee))
+	emitDelegateChanged(delegator, old, delegatee)
+	return v.moveVotes(old, delegatee, v.units(delegator))
+}
+
+// Undelegate withdraws the caller's voting units from their delegate.
+func (v *Votes) Undelegate() error {
+	return v.Delegate(stygos.Address{})
+}
+
+// TransferVotingUnits moves amount units from from to to between their
+// delegates. The zero address stands for mints and burns, which also
+// update the total supply.
+func (v *Votes) TransferVotingUnits(from, to stygos.Address, amount stygos.U256) error {
+	if from == (stygos.Address{}) {
+		if err := v.push(v.supply, v.latest(v.supply).Add(amount)); err != nil {
+			return err
+		}
+	}
+	if to == (stygos.Address{}) {
+		supply := v.latest(v.supply)
+		if supply.Lt(amount) {
+			return ErrInsufficientVotes
+		}
+		if err := v.push(v.supply, supply.Sub(amount)); err != nil {
+			return err
+		}
+	}
+	return v.moveVotes(v.Delegates(from), v.Delegates(to), amount)
+}
+
+// GetVotes returns the current votes of account.
+func (v *Votes) GetVotes(account stygos.Address) stygos.U256 {
+	return v.latest(v.accountCheckpoints(account))
+}
+
+// GetPastVotes returns the votes of account at the end of a past block.
+func (v *Votes) GetPastVotes(account stygos.Address, block uint64) (stygos.U256, error) {
+	if block >= stygos.GetBlockNumber() {
+		return stygos.U256{}, ErrFutureLookup
+	}
+	return lookup(v.accountCheckpoints(account), block), nil
+}
+
+// GetPastTotalSupply returns the voting units in existence at the end of a
+// past block, delegated or not.
+func (v *Votes) GetPastTotalSupply(block uint64) (stygos.U256, error) {
+	if block >= stygos.GetBlockNumber() {
+		return stygos.U256{}, ErrFutureLookup
+	}
+	return lookup(v.supply, block), nil
+}
+
+// NumCheckpoints returns the number of checkpoints of account.
+func (v *Votes) NumCheckpoints(account stygos.Address) uint64 {
+	return v.accountCheckpoints(account).Len()
+}
+
+// Checkpoints returns checkpoint i of account. It panics if i is out of
+// range.
+func (v *Votes) Checkpoints(account stygos.Address, i uint64) Checkpoint {
+	return decodeCheckpoint(v.accountCheckpoints(account).Get(i))
+}
+
+// WeightAt returns the votes of voter at a past snapshot block, or zero. It
+// is a Weights for NewGovernor.
+func (v *Votes) WeightAt(voter stygos.Address, snapshot uint64) stygos.U256 {
+	votes, err := v.GetPastVotes(voter, snapshot)
+	if err != nil {
+		return stygos.U256{}
+	}
+	return votes
+}
+
+// moveVotes moves amount votes from one delegate to another, skipping the
+// zero address.
+func (v *Votes) moveVotes(from, to stygos.Address, amount stygos.U256) error {
+	if from == to || amount.IsZero() {
+		return nil
+	}
+	if from != (stygos.Address{}) {
+		old := v.GetVotes(from)
+		if old.Lt(amount) {
+			return ErrInsufficientVotes
+		}
+		if err := v.push(v.accountCheckpoints(from), old.Sub(amount)); err != nil {
+			return err
+		}
+		emitDelegateVotesChanged(from, old, old.Sub(amount))
+	}
+	if to != (stygos.Address{}) {
+		old := v.GetVotes(to)
+		if err := v.push(v.accountCheckpoints(to), old.Add(amount)); err != nil {
+			return err
+		}
+		emitDelegateVotesChanged(to, old, old.Add(amount))
+	}
+	return nil
+}
+
+func (v *Votes) accountCheckpoints(account stygos.Address) storage.WordArray {
+	return storage.NewWordArray(storage.MapKey(v.checkpoints, account[:]))
+}
+
+func (v *Votes) latest(a storage.WordArray) stygos.U256 {
+	n := a.Len()
+	if n == 0 {
+		return stygos.U256{}
+	}
+	return decodeCheckpoint(a.Get(n - 1)).Votes
+}
+
+// push records votes from the current block on, replacing a checkpoint
+// written earlier in the same block.
+func (v *Votes) push(a storage.WordArray, votes stygos.U256) error {
+	if votes.Gt(maxVotes) {
+		return ErrVotesOverflow
+	}
+	c := Checkpoint{Block: stygos.GetBlockNumber(), Votes: votes}
+	if n := a.Len(); n > 0 && decodeCheckpoint(a.Get(n-1)).Block == c.Block {
+		a.Set(n-1, encodeCheckpoint(c))
+		return nil
+	}
+	a.Push(encodeCheckpoint(c))
+	return nil
+}
+
+// lookup returns the votes of the last checkpoint at or before block.
+func lookup(a storage.WordArray, block uint64) stygos.U256 {
+	lo, hi := uint64(0), a.Len()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if decodeCheckpoint(a.Get(mid)).Block > block {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo == 0 {
+		return stygos.U256{}
+	}
+	return decodeCheckpoint(a.Get(lo - 1)).Votes
+}
+
+func encodeCheckpoint(c Checkpoint) stygos.Word {
+	w := c.Votes.Word()
+	block := stygos.WordFromUint64(c.Block)
+	copy(w[:6], block[26:])
+	return w
+}
+
+func decodeCheckpoint(w stygos.Word) Checkpoint {
+	var block stygos.Word
+	copy(block[26:], w[:6])
+	for i := 0; i < 6; i++ {
+		w[i] = 0
+	}
+	return Checkpoint{Block: stygos.Uint64FromWord(block), Votes: stygos.U256FromWord(w)}
+}
+
+// Event emission functions, matching the OpenZeppelin Votes ABI
+
+func emitDelegateChanged(delegator, from, to stygos.Address) {
+	eventHash := stygos.Keccak256([]byte("DelegateChanged(address,address,address)"))
+	stygos.EmitEvent(nil, eventHash, stygos.PadAddress(delegator), stygos.PadAddress(from), stygos.PadAddress(to))
+}
+
+func emitDelegateVotesChanged(delegate stygos.Address, previous, votes stygos.U256) {
+	p, n := previous.Word(), votes.Word()
+	eventHash := stygos.Keccak256([]byte("DelegateVotesChanged(address,uint256,uint256)"))
+	stygos.EmitEvent(append(p[:], n[:]...), eventHash, stygos.PadAddress(delegate))
+}
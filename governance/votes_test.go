@@ -0,0 +1,165 @@
+package governance
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// OpenZeppelin's event topics
+const (
+	delegateChangedTopic      = "3134e8a2e6d97e929a7e54011ea5485d7d196dd5f0ba4d4ef95803e8e3fc257f"
+	delegateVotesChangedTopic = "dec2bacdd2f05b59de34da9b523dff8be42e5e38e818c82fdb0bae774387a724"
+)
+
+// token is a minimal token reporting its transfers to Votes.
+type token struct {
+	balances map[stygos.Address]uint64
+	votes    *Votes
+}
+
+func newToken() *token {
+	t := &token{balances: map[stygos.Address]uint64{}}
+	t.votes = NewVotes(stygos.Word{0x70}, func(a stygos.Address) stygos.U256 {
+		return stygos.NewU256(t.balances[a])
+	})
+	return t
+}
+
+func (t *token) transfer(from, to stygos.Address, amount uint64) error {
+	t.balances[from] -= amount
+	t.balances[to] += amount
+	return t.votes.TransferVotingUnits(from, to, stygos.NewU256(amount))
+}
+
+func countLogs(mock *stygos.MockRuntime, topic string) int {
+	n := 0
+	for _, l := range mock.Logs {
+		if bytes.Contains(l, []byte(topic)) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDelegation(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Block = 10
+	stygos.UseRuntime(mock)
+	tok := newToken()
+	v := tok.votes
+
+	// Units count once delegated
+	tok.transfer(stygos.Address{}, alice, 100)
+	if !v.GetVotes(alice).IsZero() {
+		t.Errorf("GetVotes failed. Expected undelegated units not to count, got %d", v.GetVotes(alice).Uint64())
+	}
+	if err := as(mock, alice, func() error { return v.Delegate(alice) }); err != nil {
+		t.Fatalf("Delegate failed: %v", err)
+	}
+	if got := v.GetVotes(alice).Uint64(); got != 100 {
+		t.Errorf("Delegate failed. Expected 100 votes, got %d", got)
+	}
+	if countLogs(mock, delegateChangedTopic) != 1 || countLogs(mock, delegateVotesChangedTopic) != 1 {
+		t.Errorf("Delegate failed. Expected DelegateChanged and DelegateVotesChanged, got %d logs", len(mock.Logs))
+	}
+
+	// Transfers follow the delegates
+	mock.Block = 20
+	as(mock, bob, func() error { return v.Delegate(carol) })
+	tok.transfer(alice, bob, 30)
+	if a, c := v.GetVotes(alice).Uint64(), v.GetVotes(carol).Uint64(); a != 70 || c != 30 {
+		t.Errorf("TransferVotingUnits failed. Expected 70 and 30 votes, got %d and %d", a, c)
+	}
+
+	// Several changes in a block share a checkpoint
+	mock.Block = 30
+	tok.transfer(alice, bob, 10)
+	tok.transfer(bob, alice, 5)
+	if n := v.NumCheckpoints(alice); n != 3 {
+		t.Errorf("NumCheckpoints failed. Expected 3, got %d", n)
+	}
+	if c := v.Checkpoints(alice, 2); c.Block != 30 || c.Votes.Uint64() != 65 {
+		t.Errorf("Checkpoints failed. Got %+v", c)
+	}
+
+	if err := as(mock, bob, v.Undelegate); err != nil {
+		t.Fatalf("Undelegate failed: %v", err)
+	}
+	if v.Delegates(bob) != (stygos.Address{}) || !v.GetVotes(carol).IsZero() {
+		t.Errorf("Undelegate failed. Expected carol's votes withdrawn, got %d", v.GetVotes(carol).Uint64())
+	}
+
+	tests := []struct {
+		account stygos.Address
+		block   uint64
+		want    uint64
+	}{
+		{alice, 9, 0},
+		{alice, 10, 100},
+		{alice, 19, 100},
+		{alice, 20, 70},
+		{alice, 29, 70},
+		{carol, 25, 30},
+		{bob, 25, 0},
+	}
+	mock.Block = 31
+	for _, tt := range tests {
+		got, err := v.GetPastVotes(tt.account, tt.block)
+		if err != nil || got.Uint64() != tt.want {
+			t.Errorf("GetPastVotes(%x, %d) failed. Expected %d, got %d, %v", tt.account, tt.block, tt.want, got.Uint64(), err)
+		}
+	}
+	if _, err := v.GetPastVotes(alice, 31); err != ErrFutureLookup {
+		t.Errorf("GetPastVotes failed. Expected ErrFutureLookup, got %v", err)
+	}
+
+	tok.transfer(alice, stygos.Address{}, 15)
+	mock.Block = 32
+	if s, _ := v.GetPastTotalSupply(30); s.Uint64() != 100 {
+		t.Errorf("GetPastTotalSupply failed. Expected 100, got %d", s.Uint64())
+	}
+	if s, _ := v.GetPastTotalSupply(31); s.Uint64() != 85 {
+		t.Errorf("GetPastTotalSupply failed. Expected 85 after the burn, got %d", s.Uint64())
+	}
+}
+
+func TestVotesOverflow(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	v := NewVotes(stygos.Word{0x71}, func(stygos.Address) stygos.U256 { return stygos.U256{} })
+	if err := v.TransferVotingUnits(stygos.Address{}, alice, maxVotes); err != nil {
+		t.Fatalf("TransferVotingUnits failed: %v", err)
+	}
+	if err := v.TransferVotingUnits(stygos.Address{}, alice, stygos.NewU256(1)); err != ErrVotesOverflow {
+		t.Errorf("TransferVotingUnits failed. Expected ErrVotesOverflow, got %v", err)
+	}
+}
+
+func TestGovernorWithVotes(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Block = 100
+	stygos.UseRuntime(mock)
+	tok := newToken()
+	tok.transfer(stygos.Address{}, alice, 600)
+	as(mock, alice, func() error { return tok.votes.Delegate(alice) })
+
+	mock.Block = 101
+	g := NewGovernor(stygos.Word{0x91}, tok.votes.WeightAt)
+	g.Initialize(10, 0, stygos.NewU256(500))
+	id, _ := g.Propose(nil, nil)
+
+	// Units moved after the snapshot do not vote twice
+	tok.transfer(alice, bob, 600)
+	as(mock, bob, func() error { return tok.votes.Delegate(bob) })
+	mock.Block = 102
+	mock.Sender = bob
+	if _, err := g.CastVote(id, For); err != ErrNoWeight {
+		t.Errorf("CastVote failed. Expected ErrNoWeight at the snapshot, got %v", err)
+	}
+	mock.Sender = alice
+	if w, err := g.CastVote(id, For); err != nil || w.Uint64() != 600 {
+		t.Errorf("CastVote failed. Expected 600 votes, got %d, %v", w.Uint64(), err)
+	}
+}
@@ -0,0 +1,116 @@
+package stygos
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// ErrInvalidChecksum is returned for a mixed-case address whose EIP-55
+// checksum does not match.
+var ErrInvalidChecksum = errors.New("invalid address checksum")
+
+// AddressFromHex parses an address of 40 hex digits, with or without a 0x
+// prefix. All-lowercase and all-uppercase addresses are accepted as is; a
+// mixed-case address must carry a valid EIP-55 checksum.
+func AddressFromHex(s string) (Address, error) {
+	var a Address
+	digits, err := decodeHex(a[:], s)
+	if err != nil {
+		return a, err
+	}
+	if isMixedCase(digits) && a.Hex()[2:] != digits {
+		return Address{}, ErrInvalidChecksum
+	}
+	return a, nil
+}
+
+// Hex returns the address in EIP-55 checksummed form, 0x-prefixed.
+func (a Address) Hex() string {
+	buf := make([]byte, 42)
+	copy(buf, "0x")
+	hex.Encode(buf[2:], a[:])
+	hash := Keccak256(buf[2:])
+	for i := 2; i < len(buf); i++ {
+		nibble := hash[(i-2)/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if buf[i] >= 'a' && nibble&0xf >= 8 {
+			buf[i] -= 'a' - 'A'
+		}
+	}
+	return string(buf)
+}
+
+// MarshalText implements encoding.TextMarshaler with the checksummed form.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see AddressFromHex.
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := AddressFromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// WordFromHex parses a word of 64 hex digits, with or without a 0x prefix.
+func WordFromHex(s string) (Word, error) {
+	var w Word
+	_, err := decodeHex(w[:], s)
+	return w, err
+}
+
+// Hex returns the word as 64 lowercase hex digits, 0x-prefixed.
+func (w Word) Hex() string {
+	buf := make([]byte, 66)
+	copy(buf, "0x")
+	hex.Encode(buf[2:], w[:])
+	return string(buf)
+}
+
+// MarshalText implements encoding.TextMarshaler with the Hex form.
+func (w Word) MarshalText() ([]byte, error) {
+	return []byte(w.Hex()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, see WordFromHex.
+func (w *Word) UnmarshalText(text []byte) error {
+	parsed, err := WordFromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*w = parsed
+	return nil
+}
+
+// decodeHex fills dst from s, which must hold exactly 2*len(dst) hex
+// digits after an optional 0x prefix, and returns the digits.
+func decodeHex(dst []byte, s string) (string, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	if len(s) != 2*len(dst) {
+		return s, ErrInvalidLength
+	}
+	if _, err := hex.Decode(dst, []byte(s)); err != nil {
+		return s, ErrInvalidInput
+	}
+	return s, nil
+}
+
+func isMixedCase(s string) bool {
+	lower, upper := false, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] >= 'a' && s[i] <= 'f':
+			lower = true
+		case s[i] >= 'A' && s[i] <= 'F':
+			upper = true
+		}
+	}
+	return lower && upper
+}
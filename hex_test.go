@@ -0,0 +1,86 @@
+package stygos
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAddressHex(t *testing.T) {
+	// EIP-55 test vectors
+	vectors := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+	for _, v := range vectors {
+		a, err := AddressFromHex(strings.ToLower(v))
+		if err != nil {
+			t.Fatalf("AddressFromHex(%s) failed: %v", v, err)
+		}
+		if got := a.Hex(); got != v {
+			t.Errorf("Hex failed. Expected %s, got %s", v, got)
+		}
+		if _, err := AddressFromHex(v); err != nil {
+			t.Errorf("AddressFromHex failed. Expected %s to be accepted, got %v", v, err)
+		}
+	}
+
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", nil},
+		{"0X5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", nil},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", ErrInvalidChecksum},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", ErrInvalidLength},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beazz", ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		if _, err := AddressFromHex(tt.in); err != tt.err {
+			t.Errorf("AddressFromHex(%s) failed. Expected %v, got %v", tt.in, tt.err, err)
+		}
+	}
+}
+
+func TestWordHex(t *testing.T) {
+	w := WordFromUint64(0xbeef)
+	want := "0x" + strings.Repeat("0", 60) + "beef"
+	if got := w.Hex(); got != want {
+		t.Errorf("Hex failed. Expected %s, got %s", want, got)
+	}
+	if got, err := WordFromHex(want[2:]); err != nil || got != w {
+		t.Errorf("WordFromHex failed. Expected %x, got %x, %v", w, got, err)
+	}
+	if _, err := WordFromHex("0xbeef"); err != ErrInvalidLength {
+		t.Errorf("WordFromHex failed. Expected ErrInvalidLength, got %v", err)
+	}
+}
+
+func TestHexText(t *testing.T) {
+	type fixture struct {
+		Owner Address
+		Salt  Word
+	}
+	owner, _ := AddressFromHex("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	in := fixture{Owner: owner, Salt: Word{0x01}}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"Owner":"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed","Salt":"0x01` + strings.Repeat("0", 62) + `"}`
+	if string(data) != want {
+		t.Errorf("MarshalText failed. Expected %s, got %s", want, data)
+	}
+
+	var out fixture
+	if err := json.Unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("UnmarshalText failed. Expected %+v, got %+v, %v", in, out, err)
+	}
+	bad := strings.Replace(string(data), "5aAeb", "5aaeB", 1)
+	if err := json.Unmarshal([]byte(bad), &out); err == nil {
+		t.Error("UnmarshalText failed. Expected a bad checksum to be rejected")
+	}
+}
@@ -0,0 +1,75 @@
+// Package history reads recent consensus-layer and block data from the
+// system contracts that keep it: the parent beacon block roots of EIP-4788
+// and the block hashes of EIP-2935.
+//
+// Both contracts take a single 32-byte big-endian key as calldata, with no
+// selector, and return a 32-byte word; they revert for keys outside the
+// range they hold. A chain that has not deployed a contract yet answers
+// with no data, which is reported as ErrUnavailable.
+package history
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// ErrUnavailable is returned when a system contract is not deployed on the
+// chain.
+var ErrUnavailable = errors.New("history: system contract not deployed")
+
+var (
+	// BeaconRootsAddress is the EIP-4788 contract, which keeps the parent
+	// beacon block root of recent blocks keyed by block timestamp.
+	BeaconRootsAddress = stygos.Address{
+		0x00, 0x0f, 0x3d, 0xf6, 0xd7, 0x32, 0x80, 0x7e, 0xf1, 0x31,
+		0x9f, 0xb7, 0xb8, 0xbb, 0x85, 0x22, 0xd0, 0xbe, 0xac, 0x02,
+	}
+
+	// HistoryStorageAddress is the EIP-2935 contract, which keeps the hashes
+	// of recent blocks keyed by block number.
+	HistoryStorageAddress = stygos.Address{
+		0x00, 0x00, 0xf9, 0x08, 0x27, 0xf1, 0xc5, 0x3a, 0x10, 0xcb,
+		0x7a, 0x02, 0x33, 0x5b, 0x17, 0x53, 0x20, 0x00, 0x29, 0x35,
+	}
+)
+
+// Ring buffer sizes of the contracts on Ethereum. Other chains may keep
+// more; the contracts revert for entries they no longer hold.
+const (
+	BeaconRootsBufferLength = 8191 // blocks of roots, about a day
+	HistoryServeWindow      = 8191 // block hashes
+)
+
+// BeaconRoot returns the parent beacon block root of the block with the
+// given timestamp. The root is that of the beacon block before the one
+// that carried the execution block. The contract is only filled on chains
+// whose blocks carry a parent beacon block root.
+func BeaconRoot(timestamp uint64) (stygos.Word, error) {
+	return read(BeaconRootsAddress, timestamp)
+}
+
+// BlockHash returns the hash of a block among the most recent ones,
+// further back than the 256 blocks of the BLOCKHASH opcode. The current
+// block and later ones are not available.
+func BlockHash(number uint64) (stygos.Word, error) {
+	return read(HistoryStorageAddress, number)
+}
+
+// read returns the word a system contract holds for key.
+func read(contract stygos.Address, key uint64) (stygos.Word, error) {
+	in := stygos.WordFromUint64(key)
+	ret, err := stygos.StaticCall(contract, in[:])
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	if len(ret) == 0 {
+		return stygos.Word{}, ErrUnavailable
+	}
+	if len(ret) != 32 {
+		return stygos.Word{}, stygos.ErrInvalidInput
+	}
+	var w stygos.Word
+	copy(w[:], ret)
+	return w, nil
+}
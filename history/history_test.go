@@ -0,0 +1,46 @@
+package history
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestBeaconRoot(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	if _, err := BeaconRoot(1700000000); err != ErrUnavailable {
+		t.Errorf("BeaconRoot failed. Expected ErrUnavailable before install, got %v", err)
+	}
+
+	h := InstallMock(mock)
+	h.BeaconRoots[1700000000] = stygos.Word{0xbe}
+	root, err := BeaconRoot(1700000000)
+	if err != nil || root != (stygos.Word{0xbe}) {
+		t.Errorf("BeaconRoot failed. Expected be00.., got %x, %v", root, err)
+	}
+	if _, err := BeaconRoot(1700000012); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("BeaconRoot failed. Expected a revert for an unknown timestamp, got %v", err)
+	}
+}
+
+func TestBlockHash(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	h := InstallMock(mock)
+	h.Mine(300)
+
+	// Further back than BLOCKHASH reaches, within the window
+	want := h.BlockHashes[1]
+	if got, err := BlockHash(1); err != nil || got != want || got == (stygos.Word{}) {
+		t.Errorf("BlockHash(1) failed. Expected %x, got %x, %v", want, got, err)
+	}
+	if _, err := BlockHash(h.BlockNumber); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("BlockHash(current) failed. Expected a revert, got %v", err)
+	}
+	h.Window = 256
+	if _, err := BlockHash(1); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("BlockHash(1) failed. Expected a revert outside the window, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+//go:build !tinygo
+
+package history
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Mock system contract reverts
+var (
+	ErrUnknownTimestamp   = errors.New("history: no beacon root for timestamp")
+	ErrInvalidBlockNumber = errors.New("history: block hash not available")
+)
+
+// MockHistory is the state served by the mock system contracts. Tests set
+// the roots and hashes they need, or Mine blocks with deterministic hashes.
+type MockHistory struct {
+	BeaconRoots map[uint64]stygos.Word // block timestamp -> parent beacon block root
+	BlockHashes map[uint64]stygos.Word
+	BlockNumber uint64 // the current block, whose hash is not available yet
+	Window      uint64 // number of block hashes served
+}
+
+// InstallMock deploys mock EIP-4788 and EIP-2935 contracts on rt and
+// returns their shared state.
+func InstallMock(rt *stygos.MockRuntime) *MockHistory {
+	m := &MockHistory{
+		BeaconRoots: make(map[uint64]stygos.Word),
+		BlockHashes: make(map[uint64]stygos.Word),
+		BlockNumber: 1,
+		Window:      HistoryServeWindow,
+	}
+	rt.Deploy(BeaconRootsAddress, m.beaconRoot)
+	rt.Deploy(HistoryStorageAddress, m.blockHash)
+	return m
+}
+
+// Mine advances the block number by n, giving each mined block a
+// deterministic hash derived from its number.
+func (m *MockHistory) Mine(n int) {
+	for i := 0; i < n; i++ {
+		number := stygos.WordFromUint64(m.BlockNumber)
+		m.BlockHashes[m.BlockNumber] = stygos.Keccak256(append([]byte("block"), number[:]...))
+		m.BlockNumber++
+	}
+}
+
+// key reads the calldata of the contracts, which revert on any other
+// length.
+func key(input []byte) (uint64, error) {
+	if len(input) != 32 {
+		return 0, stygos.ErrInvalidInput
+	}
+	var w stygos.Word
+	copy(w[:], input)
+	if !stygos.U256FromWord(w).IsUint64() {
+		return 0, stygos.ErrInvalidInput
+	}
+	return stygos.Uint64FromWord(w), nil
+}
+
+func (m *MockHistory) beaconRoot(input []byte) ([]byte, error) {
+	timestamp, err := key(input)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := m.BeaconRoots[timestamp]
+	if timestamp == 0 || !ok {
+		return nil, ErrUnknownTimestamp
+	}
+	return root[:], nil
+}
+
+func (m *MockHistory) blockHash(input []byte) ([]byte, error) {
+	n, err := key(input)
+	if err != nil {
+		return nil, err
+	}
+	if n >= m.BlockNumber || m.BlockNumber-n > m.Window {
+		return nil, ErrInvalidBlockNumber
+	}
+	h := m.BlockHashes[n]
+	return h[:], nil
+}
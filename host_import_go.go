@@ -6,9 +6,8 @@ package stygos
 // These stubs will be replaced by the mock implementations in host_mock.go when testing.
 
 // read_args stub implementation for regular Go testing
-func read_args(ptr *byte) uint32 {
+func read_args(ptr *byte) {
 	// This will be replaced by mock_read_args in runtime_mock.go
-	return 0
 }
 
 // write_result stub implementation for regular Go testing
@@ -32,12 +31,13 @@ func msg_value(value_ptr *byte) {
 }
 
 // block_number stub implementation for regular Go testing
-func block_number(value_ptr *byte) {
+func block_number() uint64 {
 	// This will be replaced by mock_block_number in runtime_mock.go
+	return 0
 }
 
 // emit_log stub implementation for regular Go testing
-func emit_log(ptr *byte, len uint32, topics_count uint32, topic1_ptr *byte, topic2_ptr *byte, topic3_ptr *byte, topic4_ptr *byte) {
+func emit_log(ptr *byte, len uint32, topics uint32) {
 	// This will be replaced by mock_emit_log in runtime_mock.go
 }
 
@@ -50,3 +50,54 @@ func native_keccak256(ptr *byte, len uint32, result_ptr *byte) {
 func memory_grow(pages uint32) {
 	// This will be replaced by mock_memory_grow in runtime_mock.go
 }
+
+// msg_sender stub implementation for regular Go testing
+func msg_sender(sender_ptr *byte) {
+	// This will be replaced by mock_msg_sender in runtime_mock.go
+}
+
+// contract_address stub implementation for regular Go testing
+func contract_address(address_ptr *byte) {
+	// This will be replaced by mock_contract_address in runtime_mock.go
+}
+
+// call_contract stub implementation for regular Go testing
+func call_contract(contract_ptr *byte, calldata_ptr *byte, calldata_len uint32, value_ptr *byte, gas uint64, return_data_len *uint32) uint8 {
+	// This will be replaced by mock_call_contract in runtime_mock.go
+	return 1
+}
+
+// static_call_contract stub implementation for regular Go testing
+func static_call_contract(contract_ptr *byte, calldata_ptr *byte, calldata_len uint32, gas uint64, return_data_len *uint32) uint8 {
+	// This will be replaced by mock_static_call_contract in runtime_mock.go
+	return 1
+}
+
+// read_return_data stub implementation for regular Go testing
+func read_return_data(dest_ptr *byte, offset uint32, size uint32) uint32 {
+	// This will be replaced by mock_read_return_data in runtime_mock.go
+	return 0
+}
+
+// block_timestamp stub implementation for regular Go testing
+func block_timestamp() uint64 {
+	// This will be replaced by mock_block_timestamp in runtime_mock.go
+	return 0
+}
+
+// account_balance stub implementation for regular Go testing
+func account_balance(address_ptr *byte, dest_ptr *byte) {
+	// This will be replaced by mock_account_balance in runtime_mock.go
+}
+
+// account_code_size stub implementation for regular Go testing
+func account_code_size(address_ptr *byte) uint32 {
+	// This will be replaced by mock_account_code_size in runtime_mock.go
+	return 0
+}
+
+// chainid stub implementation for regular Go testing
+func chainid() uint64 {
+	// This will be replaced by mock_chainid in runtime_mock.go
+	return 0
+}
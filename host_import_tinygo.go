@@ -4,30 +4,52 @@ package stygos
 
 // This file defines the low-level host functions provided by the Arbitrum Stylus environment.
 // These functions are imported from the host environment using //go:wasmimport directives.
+// Hostios that differ between ArbOS versions are in host_import_tinygo_arbos*.go.
 
-//go:wasmimport stylus read_args
-func read_args(ptr *byte) uint32
+//go:wasmimport vm_hooks read_args
+func read_args(ptr *byte)
 
-//go:wasmimport stylus write_result
+//go:wasmimport vm_hooks write_result
 func write_result(ptr *byte, len uint32)
 
-//go:wasmimport stylus storage_load_bytes32
+//go:wasmimport vm_hooks storage_load_bytes32
 func storage_load_bytes32(key_ptr *byte, value_ptr *byte)
 
-//go:wasmimport stylus storage_store_bytes32
-func storage_store_bytes32(key_ptr *byte, value_ptr *byte)
-
-//go:wasmimport stylus msg_value
+//go:wasmimport vm_hooks msg_value
 func msg_value(value_ptr *byte)
 
-//go:wasmimport stylus block_number
-func block_number(value_ptr *byte)
+//go:wasmimport vm_hooks block_number
+func block_number() uint64
 
-//go:wasmimport stylus emit_log
-func emit_log(ptr *byte, len uint32, topics_count uint32, topic1_ptr *byte, topic2_ptr *byte, topic3_ptr *byte, topic4_ptr *byte)
+//go:wasmimport vm_hooks emit_log
+func emit_log(ptr *byte, len uint32, topics uint32)
 
-//go:wasmimport stylus native_keccak256
+//go:wasmimport vm_hooks native_keccak256
 func native_keccak256(ptr *byte, len uint32, result_ptr *byte)
 
-//go:wasmimport vm_hooks memory_grow
-func memory_grow(pages uint32)
+//go:wasmimport vm_hooks msg_sender
+func msg_sender(sender_ptr *byte)
+
+//go:wasmimport vm_hooks contract_address
+func contract_address(address_ptr *byte)
+
+//go:wasmimport vm_hooks call_contract
+func call_contract(contract_ptr *byte, calldata_ptr *byte, calldata_len uint32, value_ptr *byte, gas uint64, return_data_len *uint32) uint8
+
+//go:wasmimport vm_hooks static_call_contract
+func static_call_contract(contract_ptr *byte, calldata_ptr *byte, calldata_len uint32, gas uint64, return_data_len *uint32) uint8
+
+//go:wasmimport vm_hooks read_return_data
+func read_return_data(dest_ptr *byte, offset uint32, size uint32) uint32
+
+//go:wasmimport vm_hooks block_timestamp
+func block_timestamp() uint64
+
+//go:wasmimport vm_hooks account_balance
+func account_balance(address_ptr *byte, dest_ptr *byte)
+
+//go:wasmimport vm_hooks account_code_size
+func account_code_size(address_ptr *byte) uint32
+
+//go:wasmimport vm_hooks chainid
+func chainid() uint64
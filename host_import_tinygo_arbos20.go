@@ -0,0 +1,16 @@
+//go:build tinygo && arbos20
+
+package stygos
+
+// Hostios of ArbOS 20, the Stylus testnets.
+
+//go:wasmimport vm_hooks storage_store_bytes32
+func storage_store_bytes32(key_ptr *byte, value_ptr *byte)
+
+//go:wasmimport vm_hooks memory_grow
+func memory_grow(pages uint32)
+
+// bindVersioned binds the host functions only some versions provide. ArbOS
+// 20 has no transient storage, so TransientLoad and TransientStore return
+// ErrUnsupported.
+func bindVersioned() {}
@@ -0,0 +1,37 @@
+//go:build tinygo && !arbos20
+
+package stygos
+
+// Hostios of ArbOS 30 and later.
+
+//go:wasmimport vm_hooks storage_cache_bytes32
+func storage_cache_bytes32(key_ptr *byte, value_ptr *byte)
+
+//go:wasmimport vm_hooks storage_flush_cache
+func storage_flush_cache(clear uint32)
+
+// storage_store_bytes32 writes a slot through the storage cache and
+// flushes it at once, so each store is persisted when it returns, as with
+// the storage_store_bytes32 hostio of ArbOS 20.
+func storage_store_bytes32(key_ptr *byte, value_ptr *byte) {
+	storage_cache_bytes32(key_ptr, value_ptr)
+	storage_flush_cache(0)
+}
+
+// memory_grow pays for pages the heap grows by; ArbOS 30 renamed the
+// hostio from memory_grow.
+//
+//go:wasmimport vm_hooks pay_for_memory_grow
+func memory_grow(pages uint32)
+
+//go:wasmimport vm_hooks transient_load_bytes32
+func transient_load_bytes32(key_ptr *byte, value_ptr *byte)
+
+//go:wasmimport vm_hooks transient_store_bytes32
+func transient_store_bytes32(key_ptr *byte, value_ptr *byte)
+
+// bindVersioned binds the host functions only some versions provide.
+func bindVersioned() {
+	TransientLoadBytes32 = transient_load_bytes32
+	TransientStoreBytes32 = transient_store_bytes32
+}
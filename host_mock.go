@@ -2,7 +2,7 @@ package stygos
 
 import (
 	"bytes"
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -20,7 +20,142 @@ type MockRuntime struct {
 	Result  []byte                // Mock execution result
 	Value   *big.Int              // Mock msg.value
 	Block   uint64                // Mock block number
+	Time    uint64                // Mock block timestamp
+	Chain   uint64                // Mock chain id
+	ArbOS   uint64                // ArbOS version, see TargetArbOS
+	Pages   uint32                // Wasm pages grown via memory_grow
 	mu      sync.Mutex            // Mutex for thread safety
+
+	Sender    Address                  // Mock msg.sender
+	Contract  Address                  // Address of the executing contract
+	Contracts map[Address]MockContract // Contracts reachable through calls
+	Balances  map[Address]*big.Int     // Account balances, moved by calls with value
+
+	accounts   map[Address]map[[32]byte][32]byte // Storage of contracts not executing
+	returnData []byte                            // Return data of the last call
+	transient  map[Address]map[Word]Word         // Transient storage, cleared by ResetGas
+	static     bool                              // Inside a static call
+
+	// Blobs of the transaction and the blob base fee, see GetBlobHash. A
+	// nil fee is unsupported, as on Arbitrum.
+	BlobHashes  []Word
+	BlobBaseFee *big.Int
+
+	// StorageHook, when set, is called with every key loaded or stored. It
+	// must not call back into host functions.
+	StorageHook func(key [32]byte, write bool)
+
+	// GasUsed is the gas of the host operations run since ResetGas, see
+	// mock_gas.go for the schedule.
+	GasUsed      uint64
+	warmSlots    map[mockSlot]bool
+	warmAccounts map[Address]bool
+
+	logSubs []*logSubscription // see SubscribeLogs
+
+	// Coverage, when set, records every call to a deployed contract.
+	Coverage *Coverage
+
+	preimages map[Word][]byte // see RecordPreimages
+	wordNames map[Word]string // see NameWord
+
+	// Ink metering, see SetInkTable
+	inkTable    *InkTable
+	inkUsed     uint64
+	inkProfiles []*InkProfile
+	inkStack    []*InkProfile // profiles of the calls in progress
+	inkRoot     *InkProfile   // profile of host operations outside calls
+}
+
+// MockContract is a contract deployed on a MockRuntime. It receives the
+// calldata and returns the return data, or an error to revert. An error
+// matching ErrOutOfInk fails the call as out of ink instead. While it runs
+// the runtime is switched to the callee: Storage is the callee's storage,
+// Sender is the caller and Contract is the callee.
+type MockContract func(input []byte) ([]byte, error)
+
+// MockEntrypoint wraps a contract entrypoint as a MockContract, so contracts
+// built with stygos can call each other in tests. A non-zero status reverts
+// with ErrRevert.
+func MockEntrypoint(entrypoint func() int32) MockContract {
+	return func(input []byte) ([]byte, error) {
+		status := entrypoint()
+		activeRuntime.mu.Lock()
+		out := activeRuntime.Result
+		activeRuntime.mu.Unlock()
+		if status != 0 {
+			return out, ErrRevert
+		}
+		return out, nil
+	}
+}
+
+// Deploy registers a mock contract at addr.
+func (m *MockRuntime) Deploy(addr Address, contract MockContract) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Contracts == nil {
+		m.Contracts = make(map[Address]MockContract)
+	}
+	m.Contracts[addr] = contract
+}
+
+// SetBalance sets the balance of an account in wei.
+func (m *MockRuntime) SetBalance(addr Address, wei *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Balances[addr] = new(big.Int).Set(wei)
+}
+
+// BalanceOf returns the balance of an account in wei.
+func (m *MockRuntime) BalanceOf(addr Address) *big.Int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.balanceOf(addr)
+}
+
+func (m *MockRuntime) balanceOf(addr Address) *big.Int {
+	if b, ok := m.Balances[addr]; ok {
+		return new(big.Int).Set(b)
+	}
+	return new(big.Int)
+}
+
+// moveBalance transfers wei between accounts, reporting false if from
+// cannot cover it.
+func (m *MockRuntime) moveBalance(from, to Address, wei *big.Int) bool {
+	if wei.Sign() == 0 {
+		return true
+	}
+	fromBalance := m.balanceOf(from)
+	if fromBalance.Cmp(wei) < 0 {
+		return false
+	}
+	m.Balances[from] = fromBalance.Sub(fromBalance, wei)
+	m.Balances[to] = m.balanceOf(to).Add(m.balanceOf(to), wei)
+	return true
+}
+
+// StorageOf returns the storage of the contract at addr.
+func (m *MockRuntime) StorageOf(addr Address) map[[32]byte][32]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.storageOf(addr)
+}
+
+func (m *MockRuntime) storageOf(addr Address) map[[32]byte][32]byte {
+	if addr == m.Contract {
+		return m.Storage
+	}
+	if m.accounts == nil {
+		m.accounts = make(map[Address]map[[32]byte][32]byte)
+	}
+	storage, ok := m.accounts[addr]
+	if !ok {
+		storage = make(map[[32]byte][32]byte)
+		m.accounts[addr] = storage
+	}
+	return storage
 }
 
 // activeRuntime holds the currently active runtime (either real host or mock).
@@ -34,13 +169,20 @@ func NewMockRuntime() *MockRuntime {
 		Storage: make(map[[32]byte][32]byte),
 		Logs:    make([][]byte, 0),
 		Value:   big.NewInt(0),
-		Block:   1, // Start block number at 1
+		Block:   1,      // Start block number at 1
+		Chain:   412346, // Arbitrum Nitro dev node
+		ArbOS:   buildArbOS,
+
+		Balances: make(map[Address]*big.Int),
 	}
 }
 
 // UseRuntime sets the provided MockRuntime as the active runtime for testing.
+// Each call to UseRuntime starts a fresh call context, like a new Stylus
+// instance, so memory growth accounting is reset.
 func UseRuntime(mock *MockRuntime) {
 	activeRuntime = mock
+	grownPages = 0
 }
 
 // --- Mock Implementations of Host Functions ---
@@ -48,7 +190,7 @@ func UseRuntime(mock *MockRuntime) {
 // Note: These functions mimic the behavior of the host imports for testing.
 // They interact with the MockRuntime state.
 
-func mock_read_args(ptr *byte) uint32 {
+func mock_read_args(ptr *byte) {
 	if activeRuntime == nil {
 		panic("mock runtime not initialized")
 	}
@@ -56,15 +198,24 @@ func mock_read_args(ptr *byte) uint32 {
 	defer activeRuntime.mu.Unlock()
 
 	argsLen := len(activeRuntime.Args)
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, argsLen, 0)
 	if argsLen == 0 {
-		return 0
+		return
 	}
 	// Unsafe pointer manipulation to copy data into the Wasm memory space (simulated)
 	// In a real Go test environment, we'd pass slices directly.
 	// This is a simplified representation.
 	buf := unsafeSlice(ptr, uint32(argsLen))
 	copy(buf, activeRuntime.Args)
-	return uint32(argsLen)
+}
+
+func mock_args_len() uint32 {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+	return uint32(len(activeRuntime.Args))
 }
 
 func mock_write_result(ptr *byte, length uint32) {
@@ -74,6 +225,7 @@ func mock_write_result(ptr *byte, length uint32) {
 	activeRuntime.mu.Lock()
 	defer activeRuntime.mu.Unlock()
 
+	activeRuntime.chargeInk(InkHostIO, 1, false, int(length), 0, 0)
 	buf := unsafeSlice(ptr, length)
 	activeRuntime.Result = make([]byte, length)
 	copy(activeRuntime.Result, buf)
@@ -87,6 +239,12 @@ func mock_storage_load_bytes32(keyPtr, valuePtr *byte) {
 	defer activeRuntime.mu.Unlock()
 
 	key := *(*[32]byte)(unsafe.Pointer(keyPtr))
+	if activeRuntime.StorageHook != nil {
+		activeRuntime.StorageHook(key, false)
+	}
+	gas := activeRuntime.GasUsed
+	activeRuntime.chargeSlot(key)
+	activeRuntime.chargeInk(InkStorage, 2, true, 32, 32, activeRuntime.GasUsed-gas)
 	value, exists := activeRuntime.Storage[key]
 	if exists {
 		valueBuf := unsafeSlice(valuePtr, 32)
@@ -107,10 +265,19 @@ func mock_storage_store_bytes32(keyPtr, valuePtr *byte) {
 	activeRuntime.mu.Lock()
 	defer activeRuntime.mu.Unlock()
 
+	if activeRuntime.static {
+		panic("storage write in static call")
+	}
 	key := *(*[32]byte)(unsafe.Pointer(keyPtr))
+	if activeRuntime.StorageHook != nil {
+		activeRuntime.StorageHook(key, true)
+	}
 	valueSlice := unsafeSlice(valuePtr, 32)
 	var value [32]byte
 	copy(value[:], valueSlice)
+	gas := activeRuntime.GasUsed
+	activeRuntime.chargeStore(key, value)
+	activeRuntime.chargeInk(InkStorage, 2, true, 64, 0, activeRuntime.GasUsed-gas)
 
 	// Check if value is zero, if so, delete from storage (EVM behavior)
 	isZero := true
@@ -128,6 +295,49 @@ func mock_storage_store_bytes32(keyPtr, valuePtr *byte) {
 	}
 }
 
+func mock_transient_load_bytes32(keyPtr, valuePtr *byte) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	key := *(*Word)(unsafe.Pointer(keyPtr))
+	activeRuntime.GasUsed += MockGasWarmAccess
+	activeRuntime.chargeInk(InkStorage, 2, true, 32, 32, MockGasWarmAccess)
+	value := activeRuntime.transient[activeRuntime.Contract][key]
+	copy(unsafeSlice(valuePtr, 32), value[:])
+}
+
+func mock_transient_store_bytes32(keyPtr, valuePtr *byte) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	if activeRuntime.static {
+		panic("transient storage write in static call")
+	}
+	key := *(*Word)(unsafe.Pointer(keyPtr))
+	value := *(*Word)(unsafe.Pointer(valuePtr))
+	activeRuntime.GasUsed += MockGasWarmAccess
+	activeRuntime.chargeInk(InkStorage, 2, true, 64, 0, MockGasWarmAccess)
+	if activeRuntime.transient == nil {
+		activeRuntime.transient = make(map[Address]map[Word]Word)
+	}
+	slots := activeRuntime.transient[activeRuntime.Contract]
+	if slots == nil {
+		slots = make(map[Word]Word)
+		activeRuntime.transient[activeRuntime.Contract] = slots
+	}
+	if value == (Word{}) {
+		delete(slots, key)
+	} else {
+		slots[key] = value
+	}
+}
+
 func mock_msg_value(valuePtr *byte) {
 	if activeRuntime == nil {
 		panic("mock runtime not initialized")
@@ -135,6 +345,7 @@ func mock_msg_value(valuePtr *byte) {
 	activeRuntime.mu.Lock()
 	defer activeRuntime.mu.Unlock()
 
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, 32, 0)
 	valueBuf := unsafeSlice(valuePtr, 32)
 	// Clear the buffer first
 	for i := range valueBuf {
@@ -143,41 +354,108 @@ func mock_msg_value(valuePtr *byte) {
 	activeRuntime.Value.FillBytes(valueBuf)
 }
 
-func mock_block_number(valuePtr *byte) {
+func mock_block_number() uint64 {
 	if activeRuntime == nil {
 		panic("mock runtime not initialized")
 	}
 	activeRuntime.mu.Lock()
 	defer activeRuntime.mu.Unlock()
 
-	valueBuf := unsafeSlice(valuePtr, 8)
-	binary.LittleEndian.PutUint64(valueBuf, activeRuntime.Block)
+	activeRuntime.chargeInk(InkHostIO, 0, false, 0, 0, 0)
+	return activeRuntime.Block
 }
 
-func mock_emit_log(ptr *byte, length uint32, topicsCount uint32, topic1Ptr, topic2Ptr, topic3Ptr, topic4Ptr *byte) {
+func mock_block_timestamp() uint64 {
 	if activeRuntime == nil {
 		panic("mock runtime not initialized")
 	}
 	activeRuntime.mu.Lock()
 	defer activeRuntime.mu.Unlock()
 
+	activeRuntime.chargeInk(InkHostIO, 0, false, 0, 0, 0)
+	return activeRuntime.Time
+}
+
+func mock_chainid() uint64 {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 0, false, 0, 0, 0)
+	return activeRuntime.Chain
+}
+
+func mock_blob_hash(index uint64, hashPtr *byte) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, 32, 0)
+	var h Word
+	if index < uint64(len(activeRuntime.BlobHashes)) {
+		h = activeRuntime.BlobHashes[index]
+	}
+	copy(unsafeSlice(hashPtr, 32), h[:])
+}
+
+func mock_blob_base_fee(feePtr *byte) bool {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, 32, 0)
+	if activeRuntime.BlobBaseFee == nil {
+		return false
+	}
+	activeRuntime.BlobBaseFee.FillBytes(unsafeSlice(feePtr, 32))
+	return true
+}
+
+func mock_emit_log(ptr *byte, length uint32, topicsCount uint32) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	if topicsCount > MaxTopics || length < 32*topicsCount {
+		panic("emit_log: the buffer does not hold the topics")
+	}
+	activeRuntime.mu.Lock()
+
+	var buf []byte
+	if length > 0 {
+		buf = unsafeSlice(ptr, length)
+	}
+	data := buf[32*topicsCount:]
+	gas := MockGasLog*uint64(1+topicsCount) + MockGasLogData*uint64(len(data))
+	activeRuntime.GasUsed += gas
+	activeRuntime.chargeInk(InkLog, 1, true, int(length), 0, gas)
+
 	logEntry := new(bytes.Buffer)
 	logEntry.Write([]byte(fmt.Sprintf("Topics: %d\n", topicsCount)))
+	log := Log{Address: activeRuntime.Contract}
 
-	topics := []*byte{topic1Ptr, topic2Ptr, topic3Ptr, topic4Ptr}
 	for i := uint32(0); i < topicsCount; i++ {
-		if topics[i] != nil {
-			topicData := unsafeSlice(topics[i], 32)
-			logEntry.Write([]byte(fmt.Sprintf("  Topic %d: %x\n", i+1, topicData)))
-		}
+		var topic Word
+		copy(topic[:], buf[32*i:])
+		logEntry.Write([]byte(fmt.Sprintf("  Topic %d: %x\n", i+1, topic)))
+		log.Topics = append(log.Topics, topic)
 	}
 
-	if length > 0 {
-		data := unsafeSlice(ptr, length)
+	if len(data) > 0 {
 		logEntry.Write([]byte(fmt.Sprintf("Data: %x\n", data)))
+		log.Data = append([]byte(nil), data...)
 	}
 
 	activeRuntime.Logs = append(activeRuntime.Logs, logEntry.Bytes())
+	subs := activeRuntime.logSubs
+	activeRuntime.mu.Unlock()
+
+	publishLog(subs, log)
 }
 
 func mock_native_keccak256(ptr *byte, length uint32, resultPtr *byte) {
@@ -190,19 +468,287 @@ func mock_native_keccak256(ptr *byte, length uint32, resultPtr *byte) {
 	}
 
 	// Compute real Keccak256 hash
+	var data []byte
 	if length > 0 {
-		data := unsafeSlice(ptr, length)
-		hash := sha3.NewLegacyKeccak256()
-		hash.Write(data)
-		hash.Sum(resultBuf[:0])
+		data = unsafeSlice(ptr, length)
+	}
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+	hash.Sum(resultBuf[:0])
+
+	if activeRuntime != nil {
+		activeRuntime.mu.Lock()
+		gas := MockGasKeccak + MockGasKeccakWord*uint64((length+31)/32)
+		activeRuntime.GasUsed += gas
+		activeRuntime.chargeInk(InkKeccak, 2, false, int(length), 32, gas)
+		if length > 0 {
+			var w Word
+			copy(w[:], resultBuf)
+			activeRuntime.recordPreimage(w, data)
+		}
+		activeRuntime.mu.Unlock()
 	}
 }
 
 func mock_memory_grow(pages uint32) {
-	// In a mock environment, memory growth is usually not explicitly simulated
-	// unless specific memory limit tests are needed.
-	// We can add logging here if required.
-	// fmt.Printf("Mock: memory_grow called with %d pages\n", pages)
+	// Memory is not actually simulated; the pages are only counted so tests
+	// can check how much growth a contract pays for.
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.Pages += pages
+}
+
+func mock_msg_sender(senderPtr *byte) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, 20, 0)
+	copy(unsafeSlice(senderPtr, 20), activeRuntime.Sender[:])
+}
+
+func mock_contract_address(addressPtr *byte) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, 20, 0)
+	copy(unsafeSlice(addressPtr, 20), activeRuntime.Contract[:])
+}
+
+func mock_call_contract(contractPtr, calldataPtr *byte, calldataLen uint32, valuePtr *byte, gas uint64, returnDataLen *uint32) uint8 {
+	var value Word
+	copy(value[:], unsafeSlice(valuePtr, 32))
+	return mockCall(contractPtr, calldataPtr, calldataLen, value, false, returnDataLen)
+}
+
+func mock_static_call_contract(contractPtr, calldataPtr *byte, calldataLen uint32, gas uint64, returnDataLen *uint32) uint8 {
+	return mockCall(contractPtr, calldataPtr, calldataLen, Word{}, true, returnDataLen)
+}
+
+// mockCall runs a call to a deployed mock contract in a new frame. Calls to
+// addresses without a contract succeed with no return data, as calls to
+// EOAs do. If the callee reverts or panics its storage changes are rolled
+// back. Gas is charged for the call itself, not limited.
+func mockCall(contractPtr, calldataPtr *byte, calldataLen uint32, value Word, static bool, returnDataLen *uint32) uint8 {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	rt := activeRuntime
+	rt.mu.Lock()
+
+	to := *(*Address)(unsafe.Pointer(contractPtr))
+	var input []byte
+	if calldataLen > 0 {
+		input = append(input, unsafeSlice(calldataPtr, calldataLen)...)
+	}
+
+	// Move the value first; an account that cannot cover it fails the call
+	wei := new(big.Int).SetBytes(value[:])
+	gas := rt.GasUsed
+	rt.chargeCall(to, wei.Sign() != 0)
+	rt.chargeInk(InkCall, 3, true, len(input)+20+32, 0, rt.GasUsed-gas)
+	if !rt.moveBalance(rt.Contract, to, wei) {
+		rt.returnData = nil
+		*returnDataLen = 0
+		rt.mu.Unlock()
+		return statusRevert
+	}
+
+	contract, ok := rt.Contracts[to]
+	if !ok {
+		rt.returnData = nil
+		*returnDataLen = 0
+		rt.mu.Unlock()
+		return 0
+	}
+
+	// Switch to the callee's frame
+	caller := mockFrame{rt.Storage, rt.Sender, rt.Contract, rt.Value, rt.Args, rt.Result, rt.static}
+	callee := rt.storageOf(to)
+	if rt.accounts == nil {
+		rt.accounts = make(map[Address]map[[32]byte][32]byte)
+	}
+	rt.accounts[rt.Contract] = rt.Storage
+	saved := rt.saveState()
+	rt.Storage = callee
+	rt.Sender = rt.Contract
+	rt.Contract = to
+	rt.Value = wei
+	rt.Args = input
+	rt.Result = nil
+	rt.static = static || caller.static
+	metered := rt.enterInk(to, input)
+	rt.mu.Unlock()
+
+	out, err := runMockContract(contract, input)
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if metered {
+		rt.exitInk()
+	}
+	if err != nil {
+		rt.restoreState(saved)
+		rt.moveBalance(to, caller.contract, wei)
+	}
+	rt.Storage = caller.storage
+	rt.Sender = caller.sender
+	rt.Contract = caller.contract
+	rt.Value = caller.value
+	rt.Args = caller.args
+	rt.Result = caller.result
+	rt.static = caller.static
+
+	rt.returnData = out
+	*returnDataLen = uint32(len(out))
+	if rt.Coverage != nil {
+		rt.Coverage.recordCall(to, input, out, err)
+	}
+	if errors.Is(err, ErrOutOfInk) {
+		return statusOutOfInk
+	}
+	if err != nil {
+		return statusRevert
+	}
+	return statusSuccess
+}
+
+// mockFrame is the per-call state of a MockRuntime saved across a call.
+type mockFrame struct {
+	storage  map[[32]byte][32]byte
+	sender   Address
+	contract Address
+	value    *big.Int
+	args     []byte
+	result   []byte
+	static   bool
+}
+
+// mockState is the state a reverting call rolls back: the storage of
+// every account, including that of successful sub-calls, balances,
+// transient storage and the logs emitted.
+type mockState struct {
+	storage   map[Address]map[[32]byte][32]byte
+	balances  map[Address]*big.Int
+	transient map[Address]map[Word]Word
+	logs      int
+}
+
+// saveState copies the state of every account before a call.
+func (m *MockRuntime) saveState() mockState {
+	s := mockState{
+		storage: make(map[Address]map[[32]byte][32]byte, len(m.accounts)),
+		logs:    len(m.Logs),
+	}
+	for addr, storage := range m.accounts {
+		saved := make(map[[32]byte][32]byte, len(storage))
+		for k, v := range storage {
+			saved[k] = v
+		}
+		s.storage[addr] = saved
+	}
+	if m.Balances != nil {
+		s.balances = make(map[Address]*big.Int, len(m.Balances))
+		for addr, b := range m.Balances {
+			s.balances[addr] = new(big.Int).Set(b)
+		}
+	}
+	if m.transient != nil {
+		s.transient = make(map[Address]map[Word]Word, len(m.transient))
+		for addr, slots := range m.transient {
+			saved := make(map[Word]Word, len(slots))
+			for k, v := range slots {
+				saved[k] = v
+			}
+			s.transient[addr] = saved
+		}
+	}
+	return s
+}
+
+// restoreState rolls the runtime back to s. Storage is restored in place,
+// since frames further up hold the same maps.
+func (m *MockRuntime) restoreState(s mockState) {
+	for addr, storage := range m.accounts {
+		for k := range storage {
+			delete(storage, k)
+		}
+		for k, v := range s.storage[addr] {
+			storage[k] = v
+		}
+	}
+	m.Balances = s.balances
+	m.transient = s.transient
+	m.Logs = m.Logs[:s.logs]
+}
+
+// runMockContract invokes contract, turning a panic into a revert.
+func runMockContract(contract MockContract, input []byte) (out []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, err = nil, fmt.Errorf("mock contract panicked: %v", r)
+		}
+	}()
+	return contract(input)
+}
+
+func mock_account_balance(addressPtr, destPtr *byte) {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 2, true, 20, 32, 0)
+	addr := *(*Address)(unsafe.Pointer(addressPtr))
+	dest := unsafeSlice(destPtr, 32)
+	for i := range dest {
+		dest[i] = 0
+	}
+	activeRuntime.balanceOf(addr).FillBytes(dest)
+}
+
+// mock_account_code_size reports 1 byte of code for addresses with a
+// deployed mock contract, which have no bytecode, and 0 for the others.
+func mock_account_code_size(addressPtr *byte) uint32 {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	activeRuntime.chargeInk(InkHostIO, 1, true, 20, 0, 0)
+	addr := *(*Address)(unsafe.Pointer(addressPtr))
+	if _, ok := activeRuntime.Contracts[addr]; ok {
+		return 1
+	}
+	return 0
+}
+
+func mock_read_return_data(destPtr *byte, offset, size uint32) uint32 {
+	if activeRuntime == nil {
+		panic("mock runtime not initialized")
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+
+	data := activeRuntime.returnData
+	if offset >= uint32(len(data)) {
+		activeRuntime.chargeInk(InkHostIO, 1, false, 0, 0, 0)
+		return 0
+	}
+	n := copy(unsafeSlice(destPtr, size), data[offset:])
+	activeRuntime.chargeInk(InkHostIO, 1, false, 0, n, 0)
+	return uint32(n)
 }
 
 // unsafeSlice creates a Go slice backed by the Wasm memory pointer and length.
@@ -0,0 +1,218 @@
+// Package htlc implements hashed timelock contracts for atomic swaps.
+//
+// A lock holds ETH or ERC-20 tokens for a recipient, who can claim them
+// before the timelock by revealing the 32-byte preimage of the hashlock;
+// after the timelock the sender can refund them. The hashlock is either
+// SHA-256, matching Bitcoin-style swaps (OP_SHA256 with a 32-byte
+// preimage), or Keccak-256. Claimed preimages stay readable so the
+// counterparty can complete the other leg of the swap.
+package htlc
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// HTLC errors
+var (
+	ErrUnknownLock      = errors.New("htlc: unknown lock")
+	ErrLockExists       = errors.New("htlc: lock already exists")
+	ErrZeroAmount       = errors.New("htlc: zero amount")
+	ErrValueMismatch    = errors.New("htlc: msg.value does not match amount")
+	ErrInvalidTimelock  = errors.New("htlc: timelock must be in the future")
+	ErrInvalidHashKind  = errors.New("htlc: invalid hash kind")
+	ErrInvalidRecipient = errors.New("htlc: invalid recipient")
+	ErrSettled          = errors.New("htlc: lock already claimed or refunded")
+	ErrWrongPreimage    = errors.New("htlc: preimage does not match hashlock")
+	ErrExpired          = errors.New("htlc: timelock passed")
+	ErrNotExpired       = errors.New("htlc: timelock not reached")
+	ErrNotSender        = errors.New("htlc: caller is not the sender")
+	ErrNotClaimed       = errors.New("htlc: lock not claimed")
+)
+
+// HashKind selects the hashlock function.
+type HashKind uint8
+
+// Hash kinds
+const (
+	SHA256 HashKind = iota + 1
+	Keccak256
+)
+
+// Hash returns the hashlock of preimage under kind.
+func Hash(kind HashKind, preimage stygos.Word) stygos.Word {
+	if kind == SHA256 {
+		return sha256.Sum256(preimage[:])
+	}
+	return stygos.Keccak256(preimage[:])
+}
+
+// Lock is a hashed timelock, packed into storage by lock_pack_gen.go. A
+// zero Token means ETH.
+//
+//go:generate stygos-gen pack -type Lock -o lock_pack_gen.go
+type Lock struct {
+	Sender    stygos.Address
+	Timelock  uint64 // unix seconds
+	Recipient stygos.Address
+	Kind      uint8 // HashKind
+	Claimed   bool
+	Refunded  bool
+	Token     stygos.Address
+	Amount    stygos.U256
+	Hashlock  stygos.Word
+	Preimage  stygos.Word // set on claim
+}
+
+// HTLC holds hashed timelocks keyed by id.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, id)   Lock (LockPackedWords slots)
+type HTLC struct {
+	base stygos.Word
+}
+
+// NewHTLC returns the locks rooted at base.
+func NewHTLC(base stygos.Word) *HTLC {
+	return &HTLC{base: base}
+}
+
+// ID returns the id of a lock: the keccak256 of its sender, recipient,
+// token, amount, hashlock and timelock, so the same terms cannot be locked
+// twice.
+func ID(sender, recipient, tokenAddr stygos.Address, amount stygos.U256, hashlock stygos.Word, timelock uint64) stygos.Word {
+	amt, tl := amount.Word(), stygos.WordFromUint64(timelock)
+	data := make([]byte, 0, 3*20+3*32)
+	data = append(data, sender[:]...)
+	data = append(data, recipient[:]...)
+	data = append(data, tokenAddr[:]...)
+	data = append(data, amt[:]...)
+	data = append(data, hashlock[:]...)
+	data = append(data, tl[:]...)
+	return stygos.Keccak256(data)
+}
+
+// Get returns the lock with the given id.
+func (h *HTLC) Get(id stygos.Word) (Lock, error) {
+	var l Lock
+	l.Load(h.slot(id))
+	if l.Sender == (stygos.Address{}) {
+		return l, ErrUnknownLock
+	}
+	return l, nil
+}
+
+// Create locks amount of tokenAddr for recipient and returns the lock id.
+// For ETH, tokenAddr is zero and amount must equal msg.value; tokens are
+// pulled from the caller, who approves the contract first.
+func (h *HTLC) Create(recipient, tokenAddr stygos.Address, amount stygos.U256, hashlock stygos.Word, kind HashKind, timelock uint64) (stygos.Word, error) {
+	if amount.IsZero() {
+		return stygos.Word{}, ErrZeroAmount
+	}
+	if kind != SHA256 && kind != Keccak256 {
+		return stygos.Word{}, ErrInvalidHashKind
+	}
+	if recipient == (stygos.Address{}) {
+		return stygos.Word{}, ErrInvalidRecipient
+	}
+	if timelock <= stygos.GetBlockTimestamp() {
+		return stygos.Word{}, ErrInvalidTimelock
+	}
+	sender := stygos.GetMsgSender()
+	id := ID(sender, recipient, tokenAddr, amount, hashlock, timelock)
+	if _, err := h.Get(id); err != ErrUnknownLock {
+		return stygos.Word{}, ErrLockExists
+	}
+
+	if tokenAddr == (stygos.Address{}) && stygos.U256FromBig(stygos.GetMsgValue()) != amount {
+		return stygos.Word{}, ErrValueMismatch
+	}
+
+	l := Lock{
+		Sender:    sender,
+		Timelock:  timelock,
+		Recipient: recipient,
+		Kind:      uint8(kind),
+		Token:     tokenAddr,
+		Amount:    amount,
+		Hashlock:  hashlock,
+	}
+	l.Store(h.slot(id))
+	if tokenAddr != (stygos.Address{}) {
+		if err := token.SafeTransferFrom(token.NewERC20(tokenAddr), sender, stygos.GetContractAddress(), amount); err != nil {
+			return stygos.Word{}, err
+		}
+	}
+	return id, nil
+}
+
+// Claim pays the recipient given the preimage of the hashlock, before the
+// timelock. Anyone may claim since the funds only go to the recipient.
+func (h *HTLC) Claim(id, preimage stygos.Word) error {
+	l, err := h.Get(id)
+	if err != nil {
+		return err
+	}
+	if l.Claimed || l.Refunded {
+		return ErrSettled
+	}
+	if stygos.GetBlockTimestamp() >= l.Timelock {
+		return ErrExpired
+	}
+	if Hash(HashKind(l.Kind), preimage) != l.Hashlock {
+		return ErrWrongPreimage
+	}
+	l.Claimed = true
+	l.Preimage = preimage
+	l.Store(h.slot(id))
+	return pay(l.Token, l.Recipient, l.Amount)
+}
+
+// Refund returns the funds to the sender, who must be the caller, once the
+// timelock has passed.
+func (h *HTLC) Refund(id stygos.Word) error {
+	l, err := h.Get(id)
+	if err != nil {
+		return err
+	}
+	if l.Claimed || l.Refunded {
+		return ErrSettled
+	}
+	if stygos.GetMsgSender() != l.Sender {
+		return ErrNotSender
+	}
+	if stygos.GetBlockTimestamp() < l.Timelock {
+		return ErrNotExpired
+	}
+	l.Refunded = true
+	l.Store(h.slot(id))
+	return pay(l.Token, l.Sender, l.Amount)
+}
+
+// Preimage returns the preimage revealed by a claim.
+func (h *HTLC) Preimage(id stygos.Word) (stygos.Word, error) {
+	l, err := h.Get(id)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	if !l.Claimed {
+		return stygos.Word{}, ErrNotClaimed
+	}
+	return l.Preimage, nil
+}
+
+func (h *HTLC) slot(id stygos.Word) stygos.Word {
+	return storage.MapKey(h.base, id[:])
+}
+
+func pay(tokenAddr, to stygos.Address, amount stygos.U256) error {
+	if tokenAddr == (stygos.Address{}) {
+		return stygos.Transfer(to, amount)
+	}
+	return token.SafeTransfer(token.NewERC20(tokenAddr), to, amount)
+}
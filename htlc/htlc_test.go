@@ -0,0 +1,125 @@
+package htlc
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	contract = stygos.Address{0xc0}
+	tokenAdr = stygos.Address{0x70}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+	preimage = stygos.Word{0x5e, 0xc2, 0xe7}
+)
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+	return mock
+}
+
+func TestHash(t *testing.T) {
+	setup()
+	if got, want := Hash(SHA256, preimage), stygos.Word(sha256.Sum256(preimage[:])); got != want {
+		t.Errorf("Hash(SHA256) failed. Expected %x, got %x", want, got)
+	}
+	if got, want := Hash(Keccak256, preimage), stygos.Keccak256(preimage[:]); got != want {
+		t.Errorf("Hash(Keccak256) failed. Expected %x, got %x", want, got)
+	}
+}
+
+func TestETHClaim(t *testing.T) {
+	mock := setup()
+	h := NewHTLC(stygos.Word{0x4c})
+	hashlock := Hash(SHA256, preimage)
+
+	mock.Sender = alice
+	mock.Value = big.NewInt(99)
+	if _, err := h.Create(bob, stygos.Address{}, stygos.NewU256(100), hashlock, SHA256, 2_000); err != ErrValueMismatch {
+		t.Errorf("Create failed. Expected ErrValueMismatch, got %v", err)
+	}
+	if _, err := h.Create(bob, stygos.Address{}, stygos.NewU256(99), hashlock, 3, 2_000); err != ErrInvalidHashKind {
+		t.Errorf("Create failed. Expected ErrInvalidHashKind, got %v", err)
+	}
+	if _, err := h.Create(bob, stygos.Address{}, stygos.NewU256(99), hashlock, SHA256, 1_000); err != ErrInvalidTimelock {
+		t.Errorf("Create failed. Expected ErrInvalidTimelock, got %v", err)
+	}
+	id, err := h.Create(bob, stygos.Address{}, stygos.NewU256(99), hashlock, SHA256, 2_000)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	mock.SetBalance(contract, mock.Value)
+	if _, err := h.Create(bob, stygos.Address{}, stygos.NewU256(99), hashlock, SHA256, 2_000); err != ErrLockExists {
+		t.Errorf("Create failed. Expected ErrLockExists, got %v", err)
+	}
+	mock.Value = big.NewInt(0)
+
+	if _, err := h.Preimage(id); err != ErrNotClaimed {
+		t.Errorf("Preimage failed. Expected ErrNotClaimed, got %v", err)
+	}
+	if err := h.Refund(id); err != ErrNotExpired {
+		t.Errorf("Refund failed. Expected ErrNotExpired, got %v", err)
+	}
+	if err := h.Claim(id, stygos.Word{0x01}); err != ErrWrongPreimage {
+		t.Errorf("Claim failed. Expected ErrWrongPreimage, got %v", err)
+	}
+	if err := h.Claim(id, preimage); err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if got := mock.BalanceOf(bob); got.Uint64() != 99 {
+		t.Errorf("Claim failed. Expected bob to receive 99, got %v", got)
+	}
+	if got, err := h.Preimage(id); err != nil || got != preimage {
+		t.Errorf("Preimage failed. Expected %x, got %x, %v", preimage, got, err)
+	}
+	if err := h.Claim(id, preimage); err != ErrSettled {
+		t.Errorf("Claim failed. Expected ErrSettled, got %v", err)
+	}
+}
+
+func TestTokenRefund(t *testing.T) {
+	mock := setup()
+	h := NewHTLC(stygos.Word{0x4d})
+	tok := token.InstallMockERC20(mock, tokenAdr)
+	tok.Mint(alice, stygos.NewU256(1000))
+	tok.Approve(alice, contract, stygos.NewU256(1000))
+	hashlock := Hash(Keccak256, preimage)
+
+	mock.Sender = alice
+	id, err := h.Create(bob, tokenAdr, stygos.NewU256(400), hashlock, Keccak256, 2_000)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if got := tok.Balances[contract]; got.Uint64() != 400 {
+		t.Errorf("Create failed. Expected the contract to hold 400, got %d", got.Uint64())
+	}
+	if id != ID(alice, bob, tokenAdr, stygos.NewU256(400), hashlock, 2_000) {
+		t.Errorf("Create failed. Expected the id to match ID")
+	}
+
+	mock.Time = 2_000
+	if err := h.Claim(id, preimage); err != ErrExpired {
+		t.Errorf("Claim failed. Expected ErrExpired, got %v", err)
+	}
+	mock.Sender = bob
+	if err := h.Refund(id); err != ErrNotSender {
+		t.Errorf("Refund failed. Expected ErrNotSender, got %v", err)
+	}
+	mock.Sender = alice
+	if err := h.Refund(id); err != nil {
+		t.Fatalf("Refund failed: %v", err)
+	}
+	if got := tok.Balances[alice]; got.Uint64() != 1000 {
+		t.Errorf("Refund failed. Expected alice to hold 1000, got %d", got.Uint64())
+	}
+	if err := h.Refund(id); err != ErrSettled {
+		t.Errorf("Refund failed. Expected ErrSettled, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package indexer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/eventlog"
+)
+
+// Decoding errors
+var (
+	ErrNotStruct     = errors.New("indexer: destination is not a pointer to a struct")
+	ErrTypeMismatch  = errors.New("indexer: argument does not fit the field")
+	ErrBadQuantity   = errors.New("indexer: malformed hex quantity")
+	ErrBadABIElement = errors.New("indexer: malformed ABI event")
+)
+
+// EventsFromABI returns the events of a JSON ABI, such as the one
+// stygos-gen client reads, by name. An overloaded event is keyed by its
+// signature instead, e.g. "Transfer(address,address,uint256)".
+func EventsFromABI(abiJSON []byte) (map[string]eventlog.Event, error) {
+	var entries []struct {
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Anonymous bool   `json:"anonymous"`
+		Inputs    []struct {
+			Name    string `json:"name"`
+			Type    string `json:"type"`
+			Indexed bool   `json:"indexed"`
+		} `json:"inputs"`
+	}
+	if err := json.Unmarshal(abiJSON, &entries); err != nil {
+		return nil, err
+	}
+	var events []eventlog.Event
+	count := make(map[string]int)
+	for _, entry := range entries {
+		if entry.Type != "event" {
+			continue
+		}
+		params := make([]string, len(entry.Inputs))
+		for i, in := range entry.Inputs {
+			p := in.Type
+			if in.Indexed {
+				p += " indexed"
+			}
+			if in.Name != "" {
+				p += " " + in.Name
+			}
+			params[i] = p
+		}
+		e, err := eventlog.ParseEvent(entry.Name + "(" + strings.Join(params, ", ") + ")")
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrBadABIElement, entry.Name, err)
+		}
+		e.Anonymous = entry.Anonymous
+		events = append(events, e)
+		count[e.Name]++
+	}
+	byName := make(map[string]eventlog.Event, len(events))
+	for _, e := range events {
+		if count[e.Name] > 1 {
+			byName[e.Signature()] = e
+		} else {
+			byName[e.Name] = e
+		}
+	}
+	return byName, nil
+}
+
+// Unmarshal stores the arguments of a decoded log in the struct dst
+// points to. An argument goes to the field tagged `indexer:"name"` or,
+// failing that, the field whose name matches it case-insensitively, with
+// any leading underscore dropped; arguments without a field are ignored.
+//
+// Integers fit *big.Int, big.Int, stygos.U256, stygos.Word and the sized
+// integer kinds when in range; addresses fit stygos.Address; bytesN and
+// bytes fit []byte and byte arrays of their length, bytes32 stygos.Word;
+// arrays fit slices of a fitting element. The hash standing for an indexed
+// dynamic argument fits stygos.Word.
+func Unmarshal(d eventlog.Decoded, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	v = v.Elem()
+	t := v.Type()
+	for _, a := range d.Args {
+		if a.Name == "" {
+			continue
+		}
+		name := strings.TrimLeft(a.Name, "_")
+		field := -1
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if tag, ok := f.Tag.Lookup("indexer"); ok {
+				if tag == a.Name {
+					field = i
+					break
+				}
+				continue
+			}
+			if field < 0 && strings.EqualFold(f.Name, name) {
+				field = i
+			}
+		}
+		if field < 0 {
+			continue
+		}
+		if err := assign(v.Field(field), a.Value); err != nil {
+			return fmt.Errorf("%w: %s %s into %s", err, a.Type, a.Name, t.Field(field).Type)
+		}
+	}
+	return nil
+}
+
+var (
+	typeBigInt  = reflect.TypeOf(big.Int{})
+	typeU256    = reflect.TypeOf(stygos.U256{})
+	typeWord    = reflect.TypeOf(stygos.Word{})
+	typeAddress = reflect.TypeOf(stygos.Address{})
+)
+
+// assign stores a decoded value in dst.
+func assign(dst reflect.Value, value any) error {
+	switch v := value.(type) {
+	case stygos.Address:
+		if dst.Type() == typeAddress {
+			dst.Set(reflect.ValueOf(v))
+			return nil
+		}
+	case stygos.Word:
+		if dst.Type() == typeWord {
+			dst.Set(reflect.ValueOf(v))
+			return nil
+		}
+	case bool:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(v)
+			return nil
+		}
+	case string:
+		if dst.Kind() == reflect.String {
+			dst.SetString(v)
+			return nil
+		}
+	case *big.Int:
+		return assignInt(dst, v)
+	case []byte:
+		switch {
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes(append([]byte(nil), v...))
+			return nil
+		case dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == len(v):
+			reflect.Copy(dst, reflect.ValueOf(v))
+			return nil
+		}
+	case []any:
+		if dst.Kind() == reflect.Slice {
+			s := reflect.MakeSlice(dst.Type(), len(v), len(v))
+			for i, elem := range v {
+				if err := assign(s.Index(i), elem); err != nil {
+					return err
+				}
+			}
+			dst.Set(s)
+			return nil
+		}
+	}
+	return ErrTypeMismatch
+}
+
+func assignInt(dst reflect.Value, v *big.Int) error {
+	switch {
+	case dst.Type() == reflect.PointerTo(typeBigInt):
+		dst.Set(reflect.ValueOf(new(big.Int).Set(v)))
+		return nil
+	case dst.Type() == typeBigInt:
+		dst.Set(reflect.ValueOf(new(big.Int).Set(v)).Elem())
+		return nil
+	case dst.Type() == typeU256 && v.Sign() >= 0:
+		dst.Set(reflect.ValueOf(stygos.U256FromBig(v)))
+		return nil
+	case dst.Type() == typeWord && v.Sign() >= 0:
+		dst.Set(reflect.ValueOf(stygos.WordFromBigInt(v)))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.IsUint64() && !dst.OverflowUint(v.Uint64()) {
+			dst.SetUint(v.Uint64())
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.IsInt64() && !dst.OverflowInt(v.Int64()) {
+			dst.SetInt(v.Int64())
+			return nil
+		}
+	}
+	return ErrTypeMismatch
+}
+
+// parseQuantity decodes a JSON-RPC hex quantity.
+func parseQuantity(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("%w: %q", ErrBadQuantity, s)
+	}
+	n, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrBadQuantity, s)
+	}
+	return n, nil
+}
+
+// parseData decodes JSON-RPC hex data.
+func parseData(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
@@ -0,0 +1,376 @@
+// Package indexer follows the events of stygos contracts from an
+// off-chain service. It subscribes to logs over a WebSocket JSON-RPC
+// connection, decodes them with eventlog, into Go structs if asked, and
+// hands them to callbacks, following chain reorganizations.
+//
+//	events, _ := indexer.EventsFromABI(abiJSON) // as read by stygos-gen client
+//	ix := indexer.New("ws://localhost:8548", token)
+//	indexer.On(ix, events["Transfer"], func(l indexer.Log, t Transfer) error {
+//		if l.Removed {
+//			return db.Undo(l.TxHash, l.Index)
+//		}
+//		return db.Apply(t)
+//	})
+//	err := ix.Run(ctx)
+//
+// Reorgs are handled in one of two ways. With Confirmations zero, logs are
+// delivered as soon as the node sends them, and a log that a reorg removes
+// is delivered again with Removed set, for the callback to undo. With
+// Confirmations n, logs are held until their block is n blocks deep and
+// logs removed before then are dropped unseen; only reorgs deeper than n
+// still deliver removals.
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/eventlog"
+)
+
+// Indexer errors
+var (
+	ErrAnonymous = errors.New("indexer: anonymous events cannot be routed by topic")
+	ErrNoEvents  = errors.New("indexer: no events to follow")
+)
+
+// reorgWindow is how many blocks back delivered logs are remembered, so a
+// reorg within it can deliver their removal.
+const reorgWindow = 256
+
+// Log is a log delivered to a callback, with its position on chain.
+type Log struct {
+	eventlog.Log
+	BlockNumber uint64
+	BlockHash   stygos.Word
+	TxHash      stygos.Word
+	Index       uint64 // position of the log in its block
+	Removed     bool   // a reorg removed this log after it was delivered
+}
+
+type logKey struct {
+	block stygos.Word
+	index uint64
+}
+
+func (l Log) key() logKey {
+	return logKey{l.BlockHash, l.Index}
+}
+
+// Indexer subscribes to the logs of a set of contracts and events.
+type Indexer struct {
+	URL           string           // ws:// or wss:// JSON-RPC endpoint
+	Addresses     []stygos.Address // contracts to follow, all if empty
+	FromBlock     uint64           // when non-zero, first deliver the logs since this block
+	Confirmations uint64           // blocks a log must be buried under before delivery
+
+	events   map[stygos.Word]eventlog.Event
+	handlers map[stygos.Word][]func(Log, eventlog.Decoded) error
+
+	head      uint64
+	pending   map[logKey]Log
+	delivered map[logKey]uint64 // block number of each delivered log
+}
+
+// New returns an indexer of the logs the contracts at addresses emit,
+// read from the node at url.
+func New(url string, addresses ...stygos.Address) *Indexer {
+	return &Indexer{
+		URL:       url,
+		Addresses: addresses,
+		events:    make(map[stygos.Word]eventlog.Event),
+		handlers:  make(map[stygos.Word][]func(Log, eventlog.Decoded) error),
+		pending:   make(map[logKey]Log),
+		delivered: make(map[logKey]uint64),
+	}
+}
+
+// Handle calls fn with every e log. An error from fn stops Run.
+func (ix *Indexer) Handle(e eventlog.Event, fn func(Log, eventlog.Decoded) error) error {
+	if e.Anonymous {
+		return ErrAnonymous
+	}
+	topic := e.Topic0()
+	ix.events[topic] = e
+	ix.handlers[topic] = append(ix.handlers[topic], fn)
+	return nil
+}
+
+// On calls fn with every e log decoded into a T, as Unmarshal does.
+func On[T any](ix *Indexer, e eventlog.Event, fn func(Log, T) error) error {
+	return ix.Handle(e, func(l Log, d eventlog.Decoded) error {
+		var v T
+		if err := Unmarshal(d, &v); err != nil {
+			return err
+		}
+		return fn(l, v)
+	})
+}
+
+// Run follows the logs until ctx is done or a callback fails. It returns
+// ctx.Err() once cancelled, and the connection error if the node goes
+// away; callers resuming after an error set FromBlock to the last block
+// they processed.
+func (ix *Indexer) Run(ctx context.Context) error {
+	if len(ix.events) == 0 {
+		return ErrNoEvents
+	}
+	ws, err := dialWS(ix.URL)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		ws.Close()
+	}()
+	c := newRPCConn(ws)
+	fail := func(err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+
+	// Subscribe before backfilling so no block falls in between
+	var logsSub, headsSub string
+	if err := c.call(&logsSub, "eth_subscribe", "logs", ix.filter()); err != nil {
+		return fail(err)
+	}
+	if ix.Confirmations > 0 {
+		if err := c.call(&headsSub, "eth_subscribe", "newHeads"); err != nil {
+			return fail(err)
+		}
+	}
+	var backfilled uint64
+	if ix.FromBlock > 0 || ix.Confirmations > 0 {
+		var head string
+		if err := c.call(&head, "eth_blockNumber"); err != nil {
+			return fail(err)
+		}
+		n, err := parseQuantity(head)
+		if err != nil {
+			return err
+		}
+		ix.head = n
+	}
+	if ix.FromBlock > 0 && ix.FromBlock <= ix.head {
+		f := ix.filter()
+		f["fromBlock"] = hexQuantity(ix.FromBlock)
+		f["toBlock"] = hexQuantity(ix.head)
+		var logs []rpcLog
+		if err := c.call(&logs, "eth_getLogs", f); err != nil {
+			return fail(err)
+		}
+		for _, rl := range logs {
+			l, err := rl.log()
+			if err != nil {
+				return err
+			}
+			if err := ix.receive(l); err != nil {
+				return err
+			}
+		}
+		backfilled = ix.head
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.notify:
+		}
+		notes, err := c.next()
+		if err != nil {
+			return fail(err)
+		}
+		for _, n := range notes {
+			switch n.Subscription {
+			case logsSub:
+				var rl rpcLog
+				if err := json.Unmarshal(n.Result, &rl); err != nil {
+					return err
+				}
+				l, err := rl.log()
+				if err != nil {
+					return err
+				}
+				if !l.Removed && l.BlockNumber <= backfilled {
+					continue
+				}
+				if err := ix.receive(l); err != nil {
+					return err
+				}
+			case headsSub:
+				var h struct {
+					Number string `json:"number"`
+				}
+				if err := json.Unmarshal(n.Result, &h); err != nil {
+					return err
+				}
+				number, err := parseQuantity(h.Number)
+				if err != nil {
+					return err
+				}
+				ix.head = number
+				if err := ix.flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// filter returns the eth_subscribe and eth_getLogs filter: the addresses
+// and any of the handled topics.
+func (ix *Indexer) filter() map[string]any {
+	topics := make([]string, 0, len(ix.events))
+	for t := range ix.events {
+		topics = append(topics, t.Hex())
+	}
+	sort.Strings(topics)
+	f := map[string]any{"topics": []any{topics}}
+	if len(ix.Addresses) > 0 {
+		addrs := make([]string, len(ix.Addresses))
+		for i, a := range ix.Addresses {
+			addrs[i] = strings.ToLower(a.Hex())
+		}
+		f["address"] = addrs
+	}
+	return f
+}
+
+// receive takes a log from the node, delivering it now or once confirmed.
+func (ix *Indexer) receive(l Log) error {
+	if l.Removed {
+		if _, ok := ix.pending[l.key()]; ok {
+			delete(ix.pending, l.key())
+			return nil
+		}
+		if _, ok := ix.delivered[l.key()]; ok {
+			delete(ix.delivered, l.key())
+			return ix.deliver(l)
+		}
+		return nil
+	}
+	if l.BlockNumber > ix.head {
+		ix.head = l.BlockNumber
+	}
+	if ix.Confirmations == 0 {
+		if err := ix.deliver(l); err != nil {
+			return err
+		}
+		ix.prune()
+		return nil
+	}
+	ix.pending[l.key()] = l
+	return ix.flush()
+}
+
+// flush delivers the pending logs that are Confirmations deep, in chain
+// order.
+func (ix *Indexer) flush() error {
+	var ready []Log
+	for k, l := range ix.pending {
+		if l.BlockNumber+ix.Confirmations <= ix.head {
+			ready = append(ready, l)
+			delete(ix.pending, k)
+		}
+	}
+	sort.Slice(ready, func(i, j int) bool {
+		if ready[i].BlockNumber != ready[j].BlockNumber {
+			return ready[i].BlockNumber < ready[j].BlockNumber
+		}
+		return ready[i].Index < ready[j].Index
+	})
+	for _, l := range ready {
+		if err := ix.deliver(l); err != nil {
+			return err
+		}
+	}
+	ix.prune()
+	return nil
+}
+
+func (ix *Indexer) deliver(l Log) error {
+	if len(l.Topics) == 0 {
+		return nil
+	}
+	e, ok := ix.events[l.Topics[0]]
+	if !ok {
+		return nil
+	}
+	d, err := e.Decode(l.Log)
+	if err != nil {
+		return err
+	}
+	if !l.Removed {
+		ix.delivered[l.key()] = l.BlockNumber
+	}
+	for _, fn := range ix.handlers[l.Topics[0]] {
+		if err := fn(l, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prune forgets delivered logs too deep to be reorged out.
+func (ix *Indexer) prune() {
+	if ix.head < reorgWindow {
+		return
+	}
+	for k, block := range ix.delivered {
+		if block < ix.head-reorgWindow {
+			delete(ix.delivered, k)
+		}
+	}
+}
+
+// rpcLog is a log as JSON-RPC returns it.
+type rpcLog struct {
+	Address         stygos.Address `json:"address"`
+	Topics          []stygos.Word  `json:"topics"`
+	Data            string         `json:"data"`
+	BlockNumber     string         `json:"blockNumber"`
+	BlockHash       stygos.Word    `json:"blockHash"`
+	TransactionHash stygos.Word    `json:"transactionHash"`
+	LogIndex        string         `json:"logIndex"`
+	Removed         bool           `json:"removed"`
+}
+
+func (rl rpcLog) log() (Log, error) {
+	data, err := parseData(rl.Data)
+	if err != nil {
+		return Log{}, err
+	}
+	block, err := parseQuantity(rl.BlockNumber)
+	if err != nil {
+		return Log{}, err
+	}
+	index, err := parseQuantity(rl.LogIndex)
+	if err != nil {
+		return Log{}, err
+	}
+	return Log{
+		Log:         eventlog.Log{Address: rl.Address, Topics: rl.Topics, Data: data},
+		BlockNumber: block,
+		BlockHash:   rl.BlockHash,
+		TxHash:      rl.TransactionHash,
+		Index:       index,
+		Removed:     rl.Removed,
+	}, nil
+}
+
+func hexQuantity(v uint64) string {
+	return "0x" + new(big.Int).SetUint64(v).Text(16)
+}
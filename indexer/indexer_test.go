@@ -0,0 +1,255 @@
+package indexer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/eventlog"
+)
+
+var transfer = eventlog.MustParseEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+
+type Transfer struct {
+	From  stygos.Address
+	To    stygos.Address
+	Value *big.Int
+}
+
+var (
+	token = stygos.Address{19: 0x70}
+	alice = stygos.Address{19: 0xa1}
+	bob   = stygos.Address{19: 0xb0}
+)
+
+// fakeNode answers the JSON-RPC calls the indexer makes over a WebSocket
+// and, once it has answered the method after, sends the notifications.
+type fakeNode struct {
+	head          uint64
+	logs          []string // eth_getLogs result
+	after         string
+	notifications []string
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, buf, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(buf, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		acceptKey(r.Header.Get("Sec-Websocket-Key")))
+	buf.Flush()
+	ws := &wsConn{conn: conn, r: bufio.NewReader(buf)}
+	defer ws.Close()
+
+	subs := 0
+	for {
+		data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		json.Unmarshal(data, &req)
+		var result string
+		switch req.Method {
+		case "eth_subscribe":
+			subs++
+			result = fmt.Sprintf(`"0x%d"`, subs)
+		case "eth_blockNumber":
+			result = fmt.Sprintf(`"%s"`, hexQuantity(n.head))
+		case "eth_getLogs":
+			result = "[" + strings.Join(n.logs, ",") + "]"
+		}
+		ws.WriteMessage([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, req.ID, result)))
+		if req.Method == n.after {
+			for _, msg := range n.notifications {
+				ws.WriteMessage([]byte(msg))
+			}
+		}
+	}
+}
+
+// transferLog returns the JSON of a Transfer log at block and index.
+func transferLog(block, index uint64, value uint64, removed bool) string {
+	from, to := stygos.PadAddress(alice), stygos.PadAddress(bob)
+	data := stygos.WordFromUint64(value)
+	return fmt.Sprintf(`{"address":"%s","topics":["%s","%s","%s"],"data":"0x%x","blockNumber":"%s","blockHash":"%s","transactionHash":"%s","logIndex":"%s","removed":%t}`,
+		token.Hex(), transfer.Topic0().Hex(), from.Hex(), to.Hex(), data[:],
+		hexQuantity(block), stygos.WordFromUint64(1000+block).Hex(), stygos.WordFromUint64(2000+block).Hex(), hexQuantity(index), removed)
+}
+
+func logNotification(log string) string {
+	return `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x1","result":` + log + `}}`
+}
+
+func headNotification(block uint64) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x2","result":{"number":"%s"}}}`, hexQuantity(block))
+}
+
+// run runs an indexer of Transfer logs against node until it has delivered
+// want logs or timed out.
+func run(t *testing.T, node *fakeNode, ix *Indexer, want int) []Log {
+	srv := httptest.NewServer(node)
+	defer srv.Close()
+	ix.URL = "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []Log
+	err := On(ix, transfer, func(l Log, tr Transfer) error {
+		if tr.From != alice || tr.To != bob || tr.Value.Uint64() != 10*l.BlockNumber {
+			t.Errorf("On failed. Expected a transfer of %d from alice to bob, got %+v", 10*l.BlockNumber, tr)
+		}
+		got = append(got, l)
+		if len(got) == want {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run failed. Expected context.Canceled after %d logs, got %v after %d", want, err, len(got))
+	}
+	return got
+}
+
+func TestRunBackfillAndReorg(t *testing.T) {
+	node := &fakeNode{
+		head:  2,
+		logs:  []string{transferLog(1, 0, 10, false), transferLog(2, 0, 20, false)},
+		after: "eth_getLogs",
+		notifications: []string{
+			logNotification(transferLog(2, 0, 20, false)), // already backfilled
+			logNotification(transferLog(3, 4, 30, false)),
+			logNotification(transferLog(3, 4, 30, true)),
+		},
+	}
+	ix := New("", token)
+	ix.FromBlock = 1
+	got := run(t, node, ix, 4)
+
+	want := []struct {
+		block, index uint64
+		removed      bool
+	}{{1, 0, false}, {2, 0, false}, {3, 4, false}, {3, 4, true}}
+	if len(got) != len(want) {
+		t.Fatalf("Run failed. Expected %d logs, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		l := got[i]
+		if l.BlockNumber != w.block || l.Index != w.index || l.Removed != w.removed || l.Address != token {
+			t.Errorf("Run failed. Expected log %d at block %d index %d removed %t, got %+v", i, w.block, w.index, w.removed, l)
+		}
+		if l.TxHash != stygos.WordFromUint64(2000+w.block) {
+			t.Errorf("Run failed. Expected tx hash of block %d, got %s", w.block, l.TxHash.Hex())
+		}
+	}
+}
+
+func TestRunConfirmations(t *testing.T) {
+	node := &fakeNode{
+		head:  10,
+		after: "eth_blockNumber",
+		notifications: []string{
+			logNotification(transferLog(10, 0, 100, false)),
+			logNotification(transferLog(10, 0, 100, true)), // reorged before confirmation
+			logNotification(transferLog(11, 1, 110, false)),
+			headNotification(12),
+			headNotification(13),
+		},
+	}
+	ix := New("", token)
+	ix.Confirmations = 2
+	got := run(t, node, ix, 1)
+	if len(got) != 1 || got[0].BlockNumber != 11 || got[0].Removed {
+		t.Errorf("Run failed. Expected only the log of block 11, got %+v", got)
+	}
+}
+
+func TestRunNoEvents(t *testing.T) {
+	if err := New("ws://localhost:1").Run(context.Background()); err != ErrNoEvents {
+		t.Errorf("Run failed. Expected ErrNoEvents, got %v", err)
+	}
+	anon := transfer
+	anon.Anonymous = true
+	if err := New("").Handle(anon, nil); err != ErrAnonymous {
+		t.Errorf("Handle failed. Expected ErrAnonymous, got %v", err)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	e := eventlog.MustParseEvent("Swap(address indexed _sender, uint256 amount, int8 tick, bytes4 tag, string note, uint32[] ids, bool ok)")
+	d := eventlog.Decoded{Event: e, Args: []eventlog.Arg{
+		{Param: e.Inputs[0], Value: alice},
+		{Param: e.Inputs[1], Value: big.NewInt(300)},
+		{Param: e.Inputs[2], Value: big.NewInt(-5)},
+		{Param: e.Inputs[3], Value: []byte{1, 2, 3, 4}},
+		{Param: e.Inputs[4], Value: "hi"},
+		{Param: e.Inputs[5], Value: []any{big.NewInt(7), big.NewInt(8)}},
+		{Param: e.Inputs[6], Value: true},
+	}}
+
+	var s struct {
+		Sender stygos.Address
+		Amount stygos.U256
+		Tick   int8
+		Tag    [4]byte
+		Text   string `indexer:"note"`
+		IDs    []uint32
+		OK     bool
+	}
+	if err := Unmarshal(d, &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Sender != alice || s.Amount.Uint64() != 300 || s.Tick != -5 || s.Tag != [4]byte{1, 2, 3, 4} ||
+		s.Text != "hi" || len(s.IDs) != 2 || s.IDs[1] != 8 || !s.OK {
+		t.Errorf("Unmarshal failed. Expected every argument stored, got %+v", s)
+	}
+
+	var small struct{ Amount uint8 }
+	if err := Unmarshal(d, &small); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Unmarshal failed. Expected ErrTypeMismatch for 300 into uint8, got %v", err)
+	}
+	if err := Unmarshal(d, s); err != ErrNotStruct {
+		t.Errorf("Unmarshal failed. Expected ErrNotStruct, got %v", err)
+	}
+}
+
+func TestEventsFromABI(t *testing.T) {
+	abi := `[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]},
+		{"type":"event","name":"Transfer","anonymous":false,"inputs":[
+			{"name":"from","type":"address","indexed":true},
+			{"name":"to","type":"address","indexed":true},
+			{"name":"value","type":"uint256","indexed":false}]},
+		{"type":"event","name":"Note","inputs":[{"name":"","type":"bytes","indexed":false}]},
+		{"type":"event","name":"Note","anonymous":true,"inputs":[]}
+	]`
+	events, err := EventsFromABI([]byte(abi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 {
+		t.Errorf("EventsFromABI failed. Expected 3 events, got %d", len(events))
+	}
+	if events["Transfer"].Topic0() != transfer.Topic0() || !events["Transfer"].Inputs[1].Indexed {
+		t.Errorf("EventsFromABI failed. Expected the Transfer event, got %+v", events["Transfer"])
+	}
+	if _, ok := events["Note(bytes)"]; !ok || !events["Note()"].Anonymous {
+		t.Errorf("EventsFromABI failed. Expected overloads keyed by signature, got %v", events)
+	}
+}
@@ -0,0 +1,373 @@
+package indexer
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// WebSocket opcodes
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xa
+)
+
+// wsGUID is appended to the handshake key to compute the accept header.
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxMessage bounds the size of a message read from the node.
+const maxMessage = 64 << 20
+
+var errFrame = errors.New("indexer: malformed websocket frame")
+
+// wsConn is a WebSocket connection carrying text messages, the part of
+// RFC 6455 a JSON-RPC client needs. Clients mask the frames they send and
+// servers do not.
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	mask bool
+
+	wmu sync.Mutex
+}
+
+// dialWS opens a WebSocket to a ws:// or wss:// URL.
+func dialWS(rawURL string) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "ws":
+			host = net.JoinHostPort(u.Hostname(), "80")
+		case "wss":
+			host = net.JoinHostPort(u.Hostname(), "443")
+		}
+	}
+
+	var conn net.Conn
+	switch u.Scheme {
+	case "ws":
+		conn, err = net.Dial("tcp", host)
+	case "wss":
+		conn, err = tls.Dial("tcp", host, &tls.Config{ServerName: u.Hostname()})
+	default:
+		return nil, fmt.Errorf("indexer: %q is not a ws:// or wss:// URL", rawURL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req := &http.Request{
+		Method: "GET",
+		URL:    u,
+		Host:   u.Host,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-Websocket-Key":     {key},
+			"Sec-Websocket-Version": {"13"},
+		},
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Sec-Websocket-Accept") != acceptKey(key) {
+		conn.Close()
+		return nil, fmt.Errorf("indexer: websocket handshake with %s failed: %s", u.Host, resp.Status)
+	}
+	return &wsConn{conn: conn, r: r, mask: true}, nil
+}
+
+// acceptKey returns the Sec-WebSocket-Accept value for a handshake key.
+func acceptKey(key string) string {
+	h := sha1.Sum([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// WriteMessage sends a text message in a single frame.
+func (c *wsConn) WriteMessage(msg []byte) error {
+	return c.writeFrame(opText, msg)
+}
+
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|op)
+	var maskBit byte
+	if c.mask {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126, byte(n>>8), byte(n))
+	default:
+		var ext [8]byte
+		binary.BigEndian.PutUint64(ext[:], uint64(n))
+		frame = append(append(frame, maskBit|127), ext[:]...)
+	}
+	if !c.mask {
+		frame = append(frame, payload...)
+	} else {
+		var key [4]byte
+		if _, err := rand.Read(key[:]); err != nil {
+			return err
+		}
+		frame = append(frame, key[:]...)
+		for i, b := range payload {
+			frame = append(frame, b^key[i%4])
+		}
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// ReadMessage returns the next text or binary message, answering pings
+// and joining fragments. A close frame ends the connection with io.EOF.
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case opPong:
+			continue
+		case opClose:
+			c.writeFrame(opClose, nil)
+			return nil, io.EOF
+		}
+		msg = append(msg, payload...)
+		if len(msg) > maxMessage {
+			return nil, errFrame
+		}
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.r, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, op = head[0]&0x80 != 0, head[0]&0x0f
+	masked := head[1]&0x80 != 0
+	n := uint64(head[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > maxMessage {
+		return false, 0, nil, errFrame
+	}
+	var key [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.r, key[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, n)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= key[i%4]
+		}
+	}
+	return fin, op, payload, nil
+}
+
+// Close closes the connection.
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
+
+// rpcConn is a JSON-RPC client over a WebSocket. Responses are matched to
+// requests by id; subscription notifications are queued for the reader.
+type rpcConn struct {
+	ws *wsConn
+
+	mu      sync.Mutex
+	id      int
+	pending map[int]chan rpcMessage
+	queue   []notification
+	err     error
+	notify  chan struct{} // signalled when the queue grows or reading stops
+}
+
+type rpcMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("indexer: rpc error %d: %s", e.Code, e.Message)
+}
+
+// notification is an eth_subscription message.
+type notification struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+func newRPCConn(ws *wsConn) *rpcConn {
+	c := &rpcConn{ws: ws, pending: make(map[int]chan rpcMessage), notify: make(chan struct{}, 1)}
+	go c.read()
+	return c
+}
+
+func (c *rpcConn) read() {
+	for {
+		data, err := c.ws.ReadMessage()
+		if err == nil {
+			var msg rpcMessage
+			if err = json.Unmarshal(data, &msg); err == nil {
+				c.dispatch(msg)
+				continue
+			}
+		}
+		c.mu.Lock()
+		c.err = err
+		for id, ch := range c.pending {
+			close(ch)
+			delete(c.pending, id)
+		}
+		c.mu.Unlock()
+		c.signal()
+		return
+	}
+}
+
+func (c *rpcConn) dispatch(msg rpcMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if msg.ID != nil {
+		if ch, ok := c.pending[*msg.ID]; ok {
+			delete(c.pending, *msg.ID)
+			ch <- msg
+		}
+		return
+	}
+	if msg.Method == "eth_subscription" {
+		var n notification
+		if json.Unmarshal(msg.Params, &n) == nil {
+			c.queue = append(c.queue, n)
+			c.signal()
+		}
+	}
+}
+
+func (c *rpcConn) signal() {
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// call sends a request and decodes its result into result.
+func (c *rpcConn) call(result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.id++
+	id := c.id
+	ch := make(chan rpcMessage, 1)
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	req, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	if err := c.ws.WriteMessage(req); err != nil {
+		return err
+	}
+	msg, ok := <-ch
+	if !ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.err
+	}
+	if msg.Error != nil {
+		return msg.Error
+	}
+	return json.Unmarshal(msg.Result, result)
+}
+
+// next returns the queued notifications, or the error that stopped the
+// connection once the queue is drained.
+func (c *rpcConn) next() ([]notification, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	q := c.queue
+	c.queue = nil
+	if len(q) == 0 && c.err != nil {
+		return nil, c.err
+	}
+	return q, nil
+}
@@ -0,0 +1,53 @@
+package stygos
+
+import "sort"
+
+// SDKVersion is the version of stygos reported by stygosInfo().
+const SDKVersion = "0.1.0"
+
+// InfoSelector is the selector of stygosInfo(), which every Router answers
+// unless a handler is registered for it:
+//
+//	function stygosInfo() returns (string sdkVersion, bytes32 abiHash, bytes32 layoutHash)
+//
+// Tooling calls it to detect which stygos version, ABI and storage layout a
+// deployed contract uses, for example to check that an upgrade keeps the
+// layout.
+var InfoSelector = Selector{0x4f, 0xe7, 0x6c, 0x37} // stygosInfo()
+
+// SetLayoutHash sets the storage layout hash reported by stygosInfo(),
+// usually the value `stygos-gen slots -layout` computes from the contract's
+// slots, or storage.Layout.Hash.
+func (r *Router) SetLayoutHash(h Word) {
+	r.layoutHash = h
+}
+
+// ABIHash returns keccak256 of the registered selectors, sorted and
+// concatenated. It changes whenever a function is added or removed.
+func (r *Router) ABIHash() Word {
+	sels := make([]Selector, 0, len(r.selectors))
+	for _, sel := range r.selectors {
+		if sel != InfoSelector {
+			sels = append(sels, sel)
+		}
+	}
+	sort.Slice(sels, func(i, j int) bool { return sels[i].Uint32() < sels[j].Uint32() })
+	buf := make([]byte, 0, 4*len(sels))
+	for _, sel := range sels {
+		buf = append(buf, sel[:]...)
+	}
+	return Keccak256(buf)
+}
+
+// info returns the ABI encoded result of stygosInfo().
+func (r *Router) info() []byte {
+	b := NewReturnBuilder(5 * 32)
+	b.AppendUint64(3 * 32)
+	b.AppendWord(r.ABIHash())
+	b.AppendWord(r.layoutHash)
+	b.AppendUint64(uint64(len(SDKVersion)))
+	var tail Word
+	copy(tail[:], SDKVersion)
+	b.AppendWord(tail)
+	return b.Bytes()
+}
@@ -0,0 +1,79 @@
+// Package auction implements English and Dutch auctions of ERC-721 tokens
+// paid in ETH.
+//
+// Listed tokens are escrowed by the auction contract, which must be approved
+// by the seller beforehand (approve or setApprovalForAll). Payments never
+// leave the contract during bidding or settlement: refunds to outbid
+// bidders and proceeds for sellers are credited to a payment.PullPayment,
+// and each account pulls its balance with WithdrawPayments. A bidder that
+// cannot receive ETH therefore cannot block an auction.
+package auction
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Auction errors
+var (
+	ErrUnknownAuction  = errors.New("auction: unknown auction")
+	ErrInvalidDuration = errors.New("auction: duration must be positive")
+	ErrInvalidPrice    = errors.New("auction: start price below end price")
+	ErrAuctionEnded    = errors.New("auction: auction has ended")
+	ErrAuctionActive   = errors.New("auction: auction has not ended")
+	ErrAlreadySettled  = errors.New("auction: auction already settled")
+	ErrBidTooLow       = errors.New("auction: bid below minimum")
+	ErrNotSeller       = errors.New("auction: caller is not the seller")
+	ErrHasBids         = errors.New("auction: auction has bids")
+	ErrNotEscrowed     = errors.New("auction: token was not escrowed")
+)
+
+// BpsDenominator is the denominator of basis point amounts.
+const BpsDenominator = 10000
+
+// ERC-721 selectors used for escrow and settlement
+var (
+	selTransferFrom = stygos.Selector{0x23, 0xb8, 0x72, 0xdd} // transferFrom(address,address,uint256)
+	selOwnerOf      = stygos.Selector{0x63, 0x52, 0x21, 0x1e} // ownerOf(uint256)
+)
+
+// transferToken moves an ERC-721 token with transferFrom.
+func transferToken(token, from, to stygos.Address, tokenID stygos.U256) error {
+	fromWord, toWord, id := stygos.PadAddress(from), stygos.PadAddress(to), tokenID.Word()
+	data := make([]byte, 0, 100)
+	data = append(data, selTransferFrom[:]...)
+	data = append(data, fromWord[:]...)
+	data = append(data, toWord[:]...)
+	data = append(data, id[:]...)
+	_, err := stygos.Call(token, stygos.Word{}, data)
+	return err
+}
+
+// escrowToken moves a token from seller into the auction contract. Calls
+// to accounts without code succeed, so ownership is confirmed afterwards.
+func escrowToken(token, seller stygos.Address, tokenID stygos.U256) error {
+	self := stygos.GetContractAddress()
+	if err := transferToken(token, seller, self, tokenID); err != nil {
+		return err
+	}
+	id := tokenID.Word()
+	ret, err := stygos.StaticCall(token, append(selOwnerOf[:], id[:]...))
+	if err != nil {
+		return err
+	}
+	var owner stygos.Word
+	if len(ret) < 32 {
+		return ErrNotEscrowed
+	}
+	copy(owner[:], ret)
+	if stygos.AddressFromWord(owner) != self {
+		return ErrNotEscrowed
+	}
+	return nil
+}
+
+// msgValue returns the ETH sent with the current call.
+func msgValue() stygos.U256 {
+	return stygos.U256FromBig(stygos.GetMsgValue())
+}
@@ -0,0 +1,264 @@
+package auction
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/payment"
+)
+
+var errNotAllowed = errors.New("not allowed")
+
+// mockNFT is a minimal ERC-721 reachable through mock calls.
+type mockNFT struct {
+	owners   map[stygos.U256]stygos.Address
+	operator map[stygos.Address]stygos.Address
+}
+
+func deployNFT(mock *stygos.MockRuntime, addr stygos.Address) *mockNFT {
+	nft := &mockNFT{
+		owners:   make(map[stygos.U256]stygos.Address),
+		operator: make(map[stygos.Address]stygos.Address),
+	}
+	router := stygos.NewRouter()
+	router.HandleSelector(selTransferFrom, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		var from, to, id stygos.Word
+		copy(from[:], args[0:32])
+		copy(to[:], args[32:64])
+		copy(id[:], args[64:96])
+		tokenID := stygos.U256FromWord(id)
+		owner := nft.owners[tokenID]
+		sender := stygos.GetMsgSender()
+		if owner != stygos.AddressFromWord(from) || (sender != owner && nft.operator[owner] != sender) {
+			return nil, errNotAllowed
+		}
+		nft.owners[tokenID] = stygos.AddressFromWord(to)
+		return nil, nil
+	})
+	router.HandleSelector(selOwnerOf, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		var id stygos.Word
+		copy(id[:], args)
+		owner := stygos.PadAddress(nft.owners[stygos.U256FromWord(id)])
+		return owner[:], nil
+	})
+	mock.Deploy(addr, router.Dispatch)
+	return nft
+}
+
+// setup returns a runtime for the auction contract with a token minted to
+// seller and the auction contract approved as operator.
+func setup() (*stygos.MockRuntime, *mockNFT) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = house
+	mock.Time = 1000
+	stygos.UseRuntime(mock)
+	nft := deployNFT(mock, token)
+	nft.owners[stygos.NewU256(1)] = seller
+	nft.operator[seller] = house
+	return mock, nft
+}
+
+var (
+	house  = stygos.Address{0xa0}
+	token  = stygos.Address{0x72}
+	seller = stygos.Address{0x5e}
+	alice  = stygos.Address{0xa1}
+	bob    = stygos.Address{0xb0}
+)
+
+// as makes the next call come from sender with wei attached, crediting the
+// wei to the auction contract as the call would.
+func as(mock *stygos.MockRuntime, sender stygos.Address, wei uint64) {
+	mock.Sender = sender
+	mock.Value = new(big.Int).SetUint64(wei)
+	mock.SetBalance(house, new(big.Int).Add(mock.BalanceOf(house), mock.Value))
+}
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	if sel := stygos.SelectorOf("transferFrom(address,address,uint256)"); sel != selTransferFrom {
+		t.Errorf("transferFrom selector failed. Expected %x, got %x", sel, selTransferFrom)
+	}
+	if sel := stygos.SelectorOf("ownerOf(uint256)"); sel != selOwnerOf {
+		t.Errorf("ownerOf selector failed. Expected %x, got %x", sel, selOwnerOf)
+	}
+}
+
+func TestEnglishAuction(t *testing.T) {
+	mock, nft := setup()
+	e := NewEnglish(stygos.Word{0xe1}, Config{MinIncrementBps: 500, ExtensionWindow: 300, Extension: 600})
+
+	as(mock, seller, 0)
+	id, err := e.Create(token, stygos.NewU256(1), stygos.NewU256(100), 3600)
+	if err != nil || id != 1 {
+		t.Fatalf("Create failed. Expected id 1, got %d, %v", id, err)
+	}
+	if nft.owners[stygos.NewU256(1)] != house {
+		t.Fatalf("Create failed to escrow the token")
+	}
+
+	// Reserve and minimum increments
+	as(mock, alice, 99)
+	if err := e.Bid(id); err != ErrBidTooLow {
+		t.Errorf("Bid failed. Expected ErrBidTooLow below reserve, got %v", err)
+	}
+	as(mock, alice, 100)
+	if err := e.Bid(id); err != nil {
+		t.Fatalf("Bid failed: %v", err)
+	}
+	if min, _ := e.MinimumBid(id); min.Uint64() != 105 {
+		t.Errorf("MinimumBid failed. Expected 105, got %d", min.Uint64())
+	}
+	as(mock, bob, 104)
+	if err := e.Bid(id); err != ErrBidTooLow {
+		t.Errorf("Bid failed. Expected ErrBidTooLow below increment, got %v", err)
+	}
+	as(mock, bob, 105)
+	if err := e.Bid(id); err != nil {
+		t.Fatalf("Bid failed: %v", err)
+	}
+	if owed := e.Payments(alice); owed.Uint64() != 100 {
+		t.Errorf("Payments failed. Expected outbid alice to be owed 100, got %d", owed.Uint64())
+	}
+
+	// Anti-sniping: a bid in the last 5 minutes extends to 10 minutes later
+	mock.Time = 1000 + 3600 - 60
+	as(mock, alice, 200)
+	if err := e.Bid(id); err != nil {
+		t.Fatalf("Bid failed: %v", err)
+	}
+	a, _ := e.Get(id)
+	if a.EndTime != mock.Time+600 {
+		t.Errorf("Anti-sniping failed. Expected end %d, got %d", mock.Time+600, a.EndTime)
+	}
+
+	// Settlement only after the extended end
+	mock.Time = 1000 + 3600
+	if err := e.Settle(id); err != ErrAuctionActive {
+		t.Errorf("Settle failed. Expected ErrAuctionActive, got %v", err)
+	}
+	mock.Time = a.EndTime
+	as(mock, bob, 1000)
+	if err := e.Bid(id); err != ErrAuctionEnded {
+		t.Errorf("Bid failed. Expected ErrAuctionEnded, got %v", err)
+	}
+	if err := e.Settle(id); err != nil {
+		t.Fatalf("Settle failed: %v", err)
+	}
+	if err := e.Settle(id); err != ErrAlreadySettled {
+		t.Errorf("Settle failed. Expected ErrAlreadySettled, got %v", err)
+	}
+	if owner := nft.owners[stygos.NewU256(1)]; owner != alice {
+		t.Errorf("Settle failed. Expected winner %x to own the token, got %x", alice, owner)
+	}
+	if owed := e.Payments(seller); owed.Uint64() != 200 {
+		t.Errorf("Settle failed. Expected seller to be owed 200, got %d", owed.Uint64())
+	}
+
+	// Withdraw pattern: alice was outbid once, bob once
+	as(mock, alice, 0)
+	if amount, err := e.WithdrawPayments(alice); err != nil || amount.Uint64() != 100 {
+		t.Errorf("WithdrawPayments failed. Expected 100, got %d, %v", amount.Uint64(), err)
+	}
+	if _, err := e.WithdrawPayments(alice); err != payment.ErrNothingToWithdraw {
+		t.Errorf("WithdrawPayments failed. Expected ErrNothingToWithdraw, got %v", err)
+	}
+	e.WithdrawPayments(bob)
+	e.WithdrawPayments(seller)
+
+	tests := []struct {
+		account stygos.Address
+		balance int64
+	}{
+		{alice, 100},
+		{bob, 105},
+		{seller, 200},
+	}
+	for _, tt := range tests {
+		if got := mock.BalanceOf(tt.account); got.Int64() != tt.balance {
+			t.Errorf("Balance of %x failed. Expected %d, got %v", tt.account, tt.balance, got)
+		}
+	}
+}
+
+func TestEnglishNoBids(t *testing.T) {
+	mock, nft := setup()
+	e := NewEnglish(stygos.Word{0xe2}, Config{})
+
+	as(mock, seller, 0)
+	id, _ := e.Create(token, stygos.NewU256(1), stygos.NewU256(1), 60)
+	as(mock, alice, 0)
+	if err := e.Cancel(id); err != ErrNotSeller {
+		t.Errorf("Cancel failed. Expected ErrNotSeller, got %v", err)
+	}
+
+	mock.Time += 60
+	if err := e.Settle(id); err != nil {
+		t.Fatalf("Settle failed: %v", err)
+	}
+	if owner := nft.owners[stygos.NewU256(1)]; owner != seller {
+		t.Errorf("Settle failed. Expected unsold token back with seller, got %x", owner)
+	}
+
+	// Tokens the seller cannot transfer are not listed
+	as(mock, alice, 0)
+	if _, err := e.Create(token, stygos.NewU256(1), stygos.NewU256(1), 60); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Create failed. Expected ErrRevert, got %v", err)
+	}
+	if _, err := e.Create(stygos.Address{0xee}, stygos.NewU256(1), stygos.NewU256(1), 60); err != ErrNotEscrowed {
+		t.Errorf("Create failed. Expected ErrNotEscrowed for an account without code, got %v", err)
+	}
+}
+
+func TestDutchAuction(t *testing.T) {
+	mock, nft := setup()
+	d := NewDutch(stygos.Word{0xd1})
+
+	as(mock, seller, 0)
+	if _, err := d.Create(token, stygos.NewU256(1), stygos.NewU256(10), stygos.NewU256(20), 100); err != ErrInvalidPrice {
+		t.Errorf("Create failed. Expected ErrInvalidPrice, got %v", err)
+	}
+	id, err := d.Create(token, stygos.NewU256(1), stygos.NewU256(1000), stygos.NewU256(200), 100)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	tests := []struct {
+		elapsed uint64
+		price   uint64
+	}{
+		{0, 1000},
+		{25, 800},
+		{99, 208},
+		{100, 200},
+		{5000, 200},
+	}
+	for _, tt := range tests {
+		mock.Time = 1000 + tt.elapsed
+		if price, _ := d.Price(id); price.Uint64() != tt.price {
+			t.Errorf("Price after %ds failed. Expected %d, got %d", tt.elapsed, tt.price, price.Uint64())
+		}
+	}
+
+	mock.Time = 1050
+	as(mock, alice, 599)
+	if err := d.Buy(id); err != ErrBidTooLow {
+		t.Errorf("Buy failed. Expected ErrBidTooLow, got %v", err)
+	}
+	as(mock, alice, 700)
+	if err := d.Buy(id); err != nil {
+		t.Fatalf("Buy failed: %v", err)
+	}
+	as(mock, bob, 700)
+	if err := d.Buy(id); err != ErrAuctionEnded {
+		t.Errorf("Buy failed. Expected ErrAuctionEnded, got %v", err)
+	}
+	if owner := nft.owners[stygos.NewU256(1)]; owner != alice {
+		t.Errorf("Buy failed. Expected buyer to own the token, got %x", owner)
+	}
+	if seller, refund := d.Payments(seller), d.Payments(alice); seller.Uint64() != 600 || refund.Uint64() != 100 {
+		t.Errorf("Buy failed. Expected seller owed 600 and refund 100, got %d and %d", seller.Uint64(), refund.Uint64())
+	}
+}
@@ -0,0 +1,150 @@
+package auction
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/payment"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Listing is the state of a Dutch auction, packed into storage by
+// auction_pack_gen.go.
+type Listing struct {
+	Seller     stygos.Address
+	StartTime  uint64 // unix seconds
+	Sold       bool
+	Token      stygos.Address
+	Duration   uint64 // seconds
+	TokenID    stygos.U256
+	StartPrice stygos.U256
+	EndPrice   stygos.U256
+}
+
+// Dutch runs descending-price auctions: the price falls linearly from the
+// start price to the end price over the duration, then stays at the end
+// price, and the first buyer to pay it wins the token.
+//
+// Storage layout relative to the base slot:
+//
+//	base                          listing count
+//	MapKey(Offset(base, 1), id)   Listing (ListingPackedWords slots)
+//	Offset(base, 2)               payment.PullPayment of refunds and proceeds
+type Dutch struct {
+	*payment.PullPayment
+	base stygos.Word
+}
+
+// NewDutch returns the Dutch auction house rooted at base.
+func NewDutch(base stygos.Word) *Dutch {
+	return &Dutch{PullPayment: payment.NewPullPayment(storage.Offset(base, 2)), base: base}
+}
+
+// Create escrows the caller's token and lists it starting now. Listing ids
+// start at 1.
+func (d *Dutch) Create(token stygos.Address, tokenID, startPrice, endPrice stygos.U256, duration uint64) (uint64, error) {
+	if duration == 0 {
+		return 0, ErrInvalidDuration
+	}
+	if startPrice.Lt(endPrice) {
+		return 0, ErrInvalidPrice
+	}
+	seller := stygos.GetMsgSender()
+	id := stygos.Uint64FromWord(stygos.StorageLoad(d.base)) + 1
+	stygos.StorageStore(d.base, stygos.WordFromUint64(id))
+	l := Listing{
+		Seller:     seller,
+		StartTime:  stygos.GetBlockTimestamp(),
+		Token:      token,
+		Duration:   duration,
+		TokenID:    tokenID,
+		StartPrice: startPrice,
+		EndPrice:   endPrice,
+	}
+	l.Store(d.slot(id))
+	if err := escrowToken(token, seller, tokenID); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Get returns the listing with the given id.
+func (d *Dutch) Get(id uint64) (Listing, error) {
+	var l Listing
+	l.Load(d.slot(id))
+	if l.Seller == (stygos.Address{}) {
+		return l, ErrUnknownAuction
+	}
+	return l, nil
+}
+
+// Price returns the current price of a listing.
+func (d *Dutch) Price(id uint64) (stygos.U256, error) {
+	l, err := d.Get(id)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	return l.price(stygos.GetBlockTimestamp()), nil
+}
+
+func (l *Listing) price(now uint64) stygos.U256 {
+	var elapsed uint64
+	if now > l.StartTime {
+		elapsed = now - l.StartTime
+	}
+	if elapsed >= l.Duration {
+		return l.EndPrice
+	}
+	// drop*elapsed/duration, split so it cannot overflow
+	drop := l.StartPrice.Sub(l.EndPrice)
+	duration, t := stygos.NewU256(l.Duration), stygos.NewU256(elapsed)
+	decay := drop.Div(duration).Mul(t).Add(drop.Mod(duration).Mul(t).Div(duration))
+	return l.StartPrice.Sub(decay)
+}
+
+// Buy pays the current price with the ETH sent and transfers the token to
+// the caller. The price is credited to the seller and any excess to the
+// buyer, both withdrawable.
+func (d *Dutch) Buy(id uint64) error {
+	l, err := d.Get(id)
+	if err != nil {
+		return err
+	}
+	if l.Sold {
+		return ErrAuctionEnded
+	}
+	price := l.price(stygos.GetBlockTimestamp())
+	value := msgValue()
+	if value.Lt(price) {
+		return ErrBidTooLow
+	}
+
+	l.Sold = true
+	l.Store(d.slot(id))
+
+	buyer := stygos.GetMsgSender()
+	d.Credit(l.Seller, price)
+	d.Credit(buyer, value.Sub(price))
+	return transferToken(l.Token, stygos.GetContractAddress(), buyer, l.TokenID)
+}
+
+// Cancel delists an unsold token and returns it to the seller. Only the
+// seller can cancel.
+func (d *Dutch) Cancel(id uint64) error {
+	l, err := d.Get(id)
+	if err != nil {
+		return err
+	}
+	if stygos.GetMsgSender() != l.Seller {
+		return ErrNotSeller
+	}
+	if l.Sold {
+		return ErrAuctionEnded
+	}
+	l.Sold = true
+	l.Store(d.slot(id))
+	return transferToken(l.Token, stygos.GetContractAddress(), l.Seller, l.TokenID)
+}
+
+func (d *Dutch) slot(id uint64) stygos.Word {
+	key := stygos.WordFromUint64(id)
+	return storage.MapKey(storage.Offset(d.base, 1), key[:])
+}
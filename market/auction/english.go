@@ -0,0 +1,195 @@
+package auction
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/payment"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Auction is the state of an English auction, packed into storage by
+// auction_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Auction,Listing -o auction_pack_gen.go
+type Auction struct {
+	Seller  stygos.Address
+	EndTime uint64 // unix seconds
+	Settled bool
+	Token   stygos.Address
+	TokenID stygos.U256
+	Reserve stygos.U256 // minimum first bid
+	Bidder  stygos.Address
+	Bid     stygos.U256
+}
+
+// Config holds the bidding rules of an English auction house.
+type Config struct {
+	// MinIncrementBps is the minimum raise over the highest bid, in basis
+	// points. Every bid raises by at least 1 wei.
+	MinIncrementBps uint64
+
+	// A bid placed less than ExtensionWindow seconds before the end moves
+	// the end to Extension seconds after the bid, so late bidders can
+	// always be answered. Zero disables the extension.
+	ExtensionWindow uint64
+	Extension       uint64
+}
+
+// English runs ascending-price auctions: the highest bid when time runs out
+// wins the token.
+//
+// Storage layout relative to the base slot:
+//
+//	base                          auction count
+//	MapKey(Offset(base, 1), id)   Auction (AuctionPackedWords slots)
+//	Offset(base, 2)               payment.PullPayment of refunds and proceeds
+type English struct {
+	*payment.PullPayment
+	base   stygos.Word
+	config Config
+}
+
+// NewEnglish returns the English auction house rooted at base.
+func NewEnglish(base stygos.Word, config Config) *English {
+	return &English{
+		PullPayment: payment.NewPullPayment(storage.Offset(base, 2)),
+		base:        base,
+		config:      config,
+	}
+}
+
+// Create escrows the caller's token and opens an auction for it ending
+// duration seconds from now. Auction ids start at 1.
+func (e *English) Create(token stygos.Address, tokenID, reserve stygos.U256, duration uint64) (uint64, error) {
+	if duration == 0 {
+		return 0, ErrInvalidDuration
+	}
+	seller := stygos.GetMsgSender()
+	id := stygos.Uint64FromWord(stygos.StorageLoad(e.base)) + 1
+	stygos.StorageStore(e.base, stygos.WordFromUint64(id))
+	a := Auction{
+		Seller:  seller,
+		EndTime: stygos.GetBlockTimestamp() + duration,
+		Token:   token,
+		TokenID: tokenID,
+		Reserve: reserve,
+	}
+	a.Store(e.slot(id))
+	if err := escrowToken(token, seller, tokenID); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Get returns the auction with the given id.
+func (e *English) Get(id uint64) (Auction, error) {
+	var a Auction
+	a.Load(e.slot(id))
+	if a.Seller == (stygos.Address{}) {
+		return a, ErrUnknownAuction
+	}
+	return a, nil
+}
+
+// MinimumBid returns the lowest bid the auction currently accepts.
+func (e *English) MinimumBid(id uint64) (stygos.U256, error) {
+	a, err := e.Get(id)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	return e.minimumBid(&a), nil
+}
+
+func (e *English) minimumBid(a *Auction) stygos.U256 {
+	if a.Bidder == (stygos.Address{}) {
+		return a.Reserve
+	}
+	// Split the bid so the increment cannot overflow for any bid
+	denom := stygos.NewU256(BpsDenominator)
+	bps := stygos.NewU256(e.config.MinIncrementBps)
+	increment := a.Bid.Div(denom).Mul(bps).Add(a.Bid.Mod(denom).Mul(bps).Div(denom))
+	if increment.IsZero() {
+		increment = stygos.NewU256(1)
+	}
+	return a.Bid.Add(increment)
+}
+
+// Bid places the ETH sent with the call as a bid. The previous highest bid
+// is credited to its bidder, who can withdraw it at any time.
+func (e *English) Bid(id uint64) error {
+	a, err := e.Get(id)
+	if err != nil {
+		return err
+	}
+	now := stygos.GetBlockTimestamp()
+	if a.Settled || now >= a.EndTime {
+		return ErrAuctionEnded
+	}
+	value := msgValue()
+	if value.Lt(e.minimumBid(&a)) {
+		return ErrBidTooLow
+	}
+
+	if a.Bidder != (stygos.Address{}) {
+		e.Credit(a.Bidder, a.Bid)
+	}
+	a.Bidder = stygos.GetMsgSender()
+	a.Bid = value
+	if a.EndTime-now < e.config.ExtensionWindow && now+e.config.Extension > a.EndTime {
+		a.EndTime = now + e.config.Extension
+	}
+	a.Store(e.slot(id))
+	return nil
+}
+
+// Cancel closes an auction without bids and returns the token to the
+// seller. Only the seller can cancel.
+func (e *English) Cancel(id uint64) error {
+	a, err := e.Get(id)
+	if err != nil {
+		return err
+	}
+	if stygos.GetMsgSender() != a.Seller {
+		return ErrNotSeller
+	}
+	if a.Settled {
+		return ErrAlreadySettled
+	}
+	if a.Bidder != (stygos.Address{}) {
+		return ErrHasBids
+	}
+	a.Settled = true
+	a.Store(e.slot(id))
+	return transferToken(a.Token, stygos.GetContractAddress(), a.Seller, a.TokenID)
+}
+
+// Settle closes an auction after its end time, transferring the token to
+// the winner and crediting the winning bid to the seller, or returning the
+// token to the seller if nobody bid. Anyone can settle.
+func (e *English) Settle(id uint64) error {
+	a, err := e.Get(id)
+	if err != nil {
+		return err
+	}
+	if a.Settled {
+		return ErrAlreadySettled
+	}
+	if stygos.GetBlockTimestamp() < a.EndTime {
+		return ErrAuctionActive
+	}
+
+	// Mark settled before the token call so it cannot settle twice
+	a.Settled = true
+	a.Store(e.slot(id))
+
+	self := stygos.GetContractAddress()
+	if a.Bidder == (stygos.Address{}) {
+		return transferToken(a.Token, self, a.Seller, a.TokenID)
+	}
+	e.Credit(a.Seller, a.Bid)
+	return transferToken(a.Token, self, a.Bidder, a.TokenID)
+}
+
+func (e *English) slot(id uint64) stygos.Word {
+	key := stygos.WordFromUint64(id)
+	return storage.MapKey(storage.Offset(e.base, 1), key[:])
+}
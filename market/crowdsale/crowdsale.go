@@ -0,0 +1,336 @@
+// Package crowdsale launches an ERC-20 token with a Dutch auction paid in
+// ETH, at a single clearing price for every buyer.
+//
+// The price per whole token falls linearly from a start price to a floor
+// over the sale. Buyers commit ETH at any time; the sale sells out as soon
+// as the ETH committed buys every token at the current price, and that
+// price becomes the clearing price. Otherwise it ends at its end time with
+// the floor, or the average committed price if higher, as clearing price.
+// Every buyer then receives commitment / clearing price tokens, so early
+// buyers pay no more than late ones.
+//
+// A sale that ends with less than its soft cap committed fails and buyers
+// take their ETH back with Refund. A successful sale is finalized by
+// anyone; tokens are minted to buyers with Claim through the ERC-20's
+// mint(address,uint256), which the sale contract must be allowed to call,
+// and the proceeds go to the beneficiary with WithdrawProceeds. Each buyer
+// may commit at most the per-address cap.
+package crowdsale
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+// Crowdsale errors
+var (
+	ErrInitialized       = errors.New("crowdsale: already initialized")
+	ErrNotInitialized    = errors.New("crowdsale: not initialized")
+	ErrInvalidConfig     = errors.New("crowdsale: invalid configuration")
+	ErrNotStarted        = errors.New("crowdsale: sale has not started")
+	ErrEnded             = errors.New("crowdsale: sale has ended")
+	ErrNotEnded          = errors.New("crowdsale: sale has not ended")
+	ErrZeroCommitment    = errors.New("crowdsale: no ETH sent")
+	ErrCapExceeded       = errors.New("crowdsale: commitment exceeds the per-address cap")
+	ErrFinalized         = errors.New("crowdsale: sale already finalized")
+	ErrNotFinalized      = errors.New("crowdsale: sale not finalized")
+	ErrSoftCapReached    = errors.New("crowdsale: soft cap reached, no refunds")
+	ErrSoftCapMissed     = errors.New("crowdsale: soft cap missed")
+	ErrNothingToClaim    = errors.New("crowdsale: nothing to claim")
+	ErrNothingToWithdraw = errors.New("crowdsale: nothing to withdraw")
+)
+
+// unit is one whole token, 10^18 base units: prices are in wei per unit.
+var unit = stygos.NewU256(1e18)
+
+// Config describes a sale.
+type Config struct {
+	Token         stygos.Address // ERC-20 minted to buyers
+	Beneficiary   stygos.Address // receives the proceeds
+	TotalTokens   stygos.U256    // tokens for sale, in base units
+	StartPrice    stygos.U256    // wei per whole token at StartTime
+	FloorPrice    stygos.U256    // wei per whole token from EndTime
+	SoftCap       stygos.U256    // wei to commit for the sale to succeed
+	MaxCommitment stygos.U256    // wei per address, no cap when zero
+	StartTime     uint64         // unix seconds
+	EndTime       uint64         // unix seconds
+}
+
+// State is a sale, packed into storage by state_pack_gen.go.
+//
+//go:generate stygos-gen pack -type State -o state_pack_gen.go
+type State struct {
+	Token       stygos.Address
+	StartTime   uint64 // unix seconds
+	Beneficiary stygos.Address
+	EndTime     uint64 // unix seconds
+	Finalized   bool
+	SoldOut     bool
+	Withdrawn   bool
+
+	TotalTokens    stygos.U256
+	StartPrice     stygos.U256
+	FloorPrice     stygos.U256
+	SoftCap        stygos.U256
+	MaxCommitment  stygos.U256
+	TotalCommitted stygos.U256
+	ClearingPrice  stygos.U256 // set when the sale sells out or is finalized
+}
+
+// Sale is a Dutch auction token sale.
+//
+// Storage layout relative to the base slot:
+//
+//	base ...                                       State (StatePackedWords slots)
+//	MapKey(Offset(base, StatePackedWords), acc)    ETH committed by acc, cleared on claim or refund
+type Sale struct {
+	base stygos.Word
+}
+
+// NewSale returns the sale rooted at base.
+func NewSale(base stygos.Word) *Sale {
+	return &Sale{base: base}
+}
+
+// Initialize configures the sale, once.
+func (s *Sale) Initialize(c Config) error {
+	st := s.load()
+	if st.Token != (stygos.Address{}) {
+		return ErrInitialized
+	}
+	if c.Token == (stygos.Address{}) || c.Beneficiary == (stygos.Address{}) || c.TotalTokens.IsZero() ||
+		c.FloorPrice.IsZero() || c.StartPrice.Lt(c.FloorPrice) || c.EndTime <= c.StartTime {
+		return ErrInvalidConfig
+	}
+	st = State{
+		Token:         c.Token,
+		StartTime:     c.StartTime,
+		Beneficiary:   c.Beneficiary,
+		EndTime:       c.EndTime,
+		TotalTokens:   c.TotalTokens,
+		StartPrice:    c.StartPrice,
+		FloorPrice:    c.FloorPrice,
+		SoftCap:       c.SoftCap,
+		MaxCommitment: c.MaxCommitment,
+	}
+	st.Store(s.base)
+	return nil
+}
+
+// State returns the sale state.
+func (s *Sale) State() State {
+	return s.load()
+}
+
+// CommitmentOf returns the ETH committed by account and not yet claimed or
+// refunded.
+func (s *Sale) CommitmentOf(account stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(s.commitmentSlot(account)))
+}
+
+// Price returns the auction price per whole token now.
+func (s *Sale) Price() stygos.U256 {
+	st := s.load()
+	return st.price(stygos.GetBlockTimestamp())
+}
+
+// ClearingPrice returns the price every buyer pays: fixed once the sale
+// sells out or is finalized, and otherwise the higher of the current price
+// and the average price of the ETH committed so far.
+func (s *Sale) ClearingPrice() stygos.U256 {
+	st := s.load()
+	return st.clearingPrice(stygos.GetBlockTimestamp())
+}
+
+// Commit commits the ETH sent by the caller. ETH beyond what sells out the
+// sale is refunded at once. It returns the amount committed.
+func (s *Sale) Commit() (stygos.U256, error) {
+	st, err := s.loadInitialized()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	now := stygos.GetBlockTimestamp()
+	if now < st.StartTime {
+		return stygos.U256{}, ErrNotStarted
+	}
+	if st.ended(now) {
+		return stygos.U256{}, ErrEnded
+	}
+	value := stygos.U256FromBig(stygos.GetMsgValue())
+	if value.IsZero() {
+		return stygos.U256{}, ErrZeroCommitment
+	}
+
+	target := st.target(now)
+	amount, excess := value, stygos.U256{}
+	if remaining := target.Sub(st.TotalCommitted); remaining.Lt(value) {
+		amount, excess = remaining, value.Sub(remaining)
+	}
+
+	buyer := stygos.GetMsgSender()
+	slot := s.commitmentSlot(buyer)
+	committed := stygos.U256FromWord(stygos.StorageLoad(slot)).Add(amount)
+	if !st.MaxCommitment.IsZero() && st.MaxCommitment.Lt(committed) {
+		return stygos.U256{}, ErrCapExceeded
+	}
+	stygos.StorageStore(slot, committed.Word())
+	st.TotalCommitted = st.TotalCommitted.Add(amount)
+	if st.TotalCommitted == target {
+		st.ClearingPrice = st.clearingPrice(now)
+		st.SoldOut = true
+	}
+	st.Store(s.base)
+
+	if !excess.IsZero() {
+		if err := stygos.Transfer(buyer, excess); err != nil {
+			return stygos.U256{}, err
+		}
+	}
+	return amount, nil
+}
+
+// Finalize fixes the clearing price once the sale has sold out or ended.
+// Anyone may call it. It fails if the soft cap was missed, in which case
+// buyers get refunds instead.
+func (s *Sale) Finalize() error {
+	st, err := s.loadInitialized()
+	if err != nil {
+		return err
+	}
+	if st.Finalized {
+		return ErrFinalized
+	}
+	now := stygos.GetBlockTimestamp()
+	if !st.ended(now) {
+		return ErrNotEnded
+	}
+	if st.TotalCommitted.Lt(st.SoftCap) {
+		return ErrSoftCapMissed
+	}
+	st.ClearingPrice = st.clearingPrice(now)
+	st.Finalized = true
+	st.Store(s.base)
+	return nil
+}
+
+// Claim mints the tokens bought by account at the clearing price. Anyone
+// may claim for a buyer after finalization. It returns the amount minted.
+func (s *Sale) Claim(account stygos.Address) (stygos.U256, error) {
+	st, err := s.loadInitialized()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if !st.Finalized {
+		return stygos.U256{}, ErrNotFinalized
+	}
+	slot := s.commitmentSlot(account)
+	committed := stygos.U256FromWord(stygos.StorageLoad(slot))
+	if committed.IsZero() {
+		return stygos.U256{}, ErrNothingToClaim
+	}
+	stygos.StorageStore(slot, stygos.Word{})
+	// Rounding down keeps the sum of the claims within TotalTokens
+	amount, _ := stygos.MulDiv(committed, unit, st.ClearingPrice)
+	return amount, token.NewERC20(st.Token).Mint(account, amount)
+}
+
+// Refund returns the caller's commitment once the sale has ended below its
+// soft cap.
+func (s *Sale) Refund() (stygos.U256, error) {
+	st, err := s.loadInitialized()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if !st.ended(stygos.GetBlockTimestamp()) {
+		return stygos.U256{}, ErrNotEnded
+	}
+	if !st.TotalCommitted.Lt(st.SoftCap) {
+		return stygos.U256{}, ErrSoftCapReached
+	}
+	buyer := stygos.GetMsgSender()
+	slot := s.commitmentSlot(buyer)
+	committed := stygos.U256FromWord(stygos.StorageLoad(slot))
+	if committed.IsZero() {
+		return stygos.U256{}, ErrNothingToWithdraw
+	}
+	stygos.StorageStore(slot, stygos.Word{})
+	return committed, stygos.Transfer(buyer, committed)
+}
+
+// WithdrawProceeds sends the ETH committed to the beneficiary after
+// finalization, once. Anyone may call it.
+func (s *Sale) WithdrawProceeds() (stygos.U256, error) {
+	st, err := s.loadInitialized()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if !st.Finalized {
+		return stygos.U256{}, ErrNotFinalized
+	}
+	if st.Withdrawn {
+		return stygos.U256{}, ErrNothingToWithdraw
+	}
+	st.Withdrawn = true
+	st.Store(s.base)
+	return st.TotalCommitted, stygos.Transfer(st.Beneficiary, st.TotalCommitted)
+}
+
+// price returns the auction price at now, falling linearly from the start
+// price to the floor.
+func (st *State) price(now uint64) stygos.U256 {
+	if now <= st.StartTime {
+		return st.StartPrice
+	}
+	duration, elapsed := st.EndTime-st.StartTime, now-st.StartTime
+	if elapsed >= duration {
+		return st.FloorPrice
+	}
+	decay, _ := stygos.MulDiv(st.StartPrice.Sub(st.FloorPrice), stygos.NewU256(elapsed), stygos.NewU256(duration))
+	return st.StartPrice.Sub(decay)
+}
+
+// target returns the ETH that buys every token at the price at now.
+func (st *State) target(now uint64) stygos.U256 {
+	target, _ := stygos.MulDivUp(st.price(now), st.TotalTokens, unit)
+	return target
+}
+
+// ended reports whether the sale is over at now: finalized, past its end,
+// or sold out by a commitment or by the price falling to the average
+// committed.
+func (st *State) ended(now uint64) bool {
+	return st.Finalized || st.SoldOut || now >= st.EndTime || !st.TotalCommitted.Lt(st.target(now))
+}
+
+func (st *State) clearingPrice(now uint64) stygos.U256 {
+	if st.SoldOut || st.Finalized {
+		return st.ClearingPrice
+	}
+	price := st.price(now)
+	average, _ := stygos.MulDivUp(st.TotalCommitted, unit, st.TotalTokens)
+	if price.Lt(average) {
+		return average
+	}
+	return price
+}
+
+func (s *Sale) load() State {
+	var st State
+	st.Load(s.base)
+	return st
+}
+
+func (s *Sale) loadInitialized() (State, error) {
+	st := s.load()
+	if st.Token == (stygos.Address{}) {
+		return st, ErrNotInitialized
+	}
+	return st, nil
+}
+
+func (s *Sale) commitmentSlot(account stygos.Address) stygos.Word {
+	return storage.MapKey(storage.Offset(s.base, StatePackedWords), account[:])
+}
@@ -0,0 +1,174 @@
+package crowdsale
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/token"
+)
+
+var (
+	saleAddr    = stygos.Address{0x5a}
+	tokenAddr   = stygos.Address{0x70}
+	beneficiary = stygos.Address{0xbe}
+	alice       = stygos.Address{0xa1}
+	bob         = stygos.Address{0xb0}
+	carol       = stygos.Address{0xc0}
+
+	base = stygos.Word{0x01}
+)
+
+// setup returns a sale of 1000 tokens from 1000 down to 100 wei each
+// between times 1000 and 2000, with the sale contract as minter.
+func setup(t *testing.T, softCap, maxCommitment uint64) (*stygos.MockRuntime, *Sale, *token.MockERC20) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = saleAddr
+	mock.Time = 1000
+	stygos.UseRuntime(mock)
+	tok := token.InstallMockERC20(mock, tokenAddr)
+	tok.Minter = saleAddr
+
+	s := NewSale(base)
+	err := s.Initialize(Config{
+		Token:         tokenAddr,
+		Beneficiary:   beneficiary,
+		TotalTokens:   stygos.NewU256(1000).Mul(unit),
+		StartPrice:    stygos.NewU256(1000),
+		FloorPrice:    stygos.NewU256(100),
+		SoftCap:       stygos.NewU256(softCap),
+		MaxCommitment: stygos.NewU256(maxCommitment),
+		StartTime:     1000,
+		EndTime:       2000,
+	})
+	if err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return mock, s, tok
+}
+
+// commit commits wei from sender, crediting it to the sale contract as the
+// call would.
+func commit(mock *stygos.MockRuntime, s *Sale, sender stygos.Address, wei uint64) (stygos.U256, error) {
+	mock.Sender = sender
+	mock.Value = new(big.Int).SetUint64(wei)
+	mock.SetBalance(saleAddr, new(big.Int).Add(mock.BalanceOf(saleAddr), mock.Value))
+	return s.Commit()
+}
+
+func TestInitialize(t *testing.T) {
+	_, s, _ := setup(t, 0, 0)
+	if err := s.Initialize(Config{}); err != ErrInitialized {
+		t.Errorf("Initialize failed. Expected ErrInitialized, got %v", err)
+	}
+	other := NewSale(stygos.Word{0x02})
+	if err := other.Initialize(Config{Token: tokenAddr, Beneficiary: beneficiary, TotalTokens: unit, StartPrice: stygos.NewU256(1), FloorPrice: stygos.NewU256(2), EndTime: 1}); err != ErrInvalidConfig {
+		t.Errorf("Initialize failed. Expected ErrInvalidConfig for a rising price, got %v", err)
+	}
+}
+
+func TestSellOut(t *testing.T) {
+	mock, s, tok := setup(t, 0, 0)
+	mock.Time = 900
+	if _, err := commit(mock, s, alice, 1); err != ErrNotStarted {
+		t.Errorf("Commit failed. Expected ErrNotStarted, got %v", err)
+	}
+
+	// Halfway the price is 550 wei and 550000 wei sells out
+	mock.Time = 1500
+	if got := s.Price(); got.Uint64() != 550 {
+		t.Errorf("Price failed. Expected 550, got %d", got.Uint64())
+	}
+	if _, err := commit(mock, s, alice, 200000); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	amount, err := commit(mock, s, bob, 400000)
+	if err != nil || amount.Uint64() != 350000 {
+		t.Fatalf("Commit failed. Expected 350000 accepted, got %d, %v", amount.Uint64(), err)
+	}
+	if mock.BalanceOf(bob).Int64() != 50000 {
+		t.Errorf("Commit failed. Expected 50000 wei refunded, got %s", mock.BalanceOf(bob))
+	}
+	if _, err := commit(mock, s, carol, 1); err != ErrEnded {
+		t.Errorf("Commit failed. Expected ErrEnded after selling out, got %v", err)
+	}
+	mock.SetBalance(saleAddr, big.NewInt(550000)) // the failed commit reverts
+
+	// The clearing price stays at 550 as the auction price falls
+	mock.Time = 1800
+	if got := s.ClearingPrice(); got.Uint64() != 550 {
+		t.Errorf("ClearingPrice failed. Expected 550, got %d", got.Uint64())
+	}
+	if _, err := s.Claim(alice); err != ErrNotFinalized {
+		t.Errorf("Claim failed. Expected ErrNotFinalized, got %v", err)
+	}
+	if err := s.Finalize(); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	for _, buyer := range []stygos.Address{alice, bob} {
+		if _, err := s.Claim(buyer); err != nil {
+			t.Fatalf("Claim failed: %v", err)
+		}
+	}
+	if _, err := s.Claim(alice); err != ErrNothingToClaim {
+		t.Errorf("Claim failed. Expected ErrNothingToClaim, got %v", err)
+	}
+	// 200000/550 and 350000/550 tokens, rounded down
+	wantAlice, _ := stygos.MulDiv(stygos.NewU256(200000), unit, stygos.NewU256(550))
+	if tok.Balances[alice] != wantAlice || tok.TotalSupply.Gt(stygos.NewU256(1000).Mul(unit)) {
+		t.Errorf("Claim failed. Expected %v tokens for alice within the supply, got %v of %v", wantAlice.Big(), tok.Balances[alice].Big(), tok.TotalSupply.Big())
+	}
+
+	if proceeds, err := s.WithdrawProceeds(); err != nil || proceeds.Uint64() != 550000 || mock.BalanceOf(beneficiary).Int64() != 550000 {
+		t.Errorf("WithdrawProceeds failed. Expected 550000 wei to the beneficiary, got %d, %v", proceeds.Uint64(), err)
+	}
+	if _, err := s.WithdrawProceeds(); err != ErrNothingToWithdraw {
+		t.Errorf("WithdrawProceeds failed. Expected ErrNothingToWithdraw, got %v", err)
+	}
+}
+
+func TestEndAtFloor(t *testing.T) {
+	mock, s, tok := setup(t, 50000, 100000)
+	mock.Time = 1200
+	if _, err := commit(mock, s, alice, 80000); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if _, err := commit(mock, s, alice, 20001); err != ErrCapExceeded {
+		t.Errorf("Commit failed. Expected ErrCapExceeded, got %v", err)
+	}
+	if err := s.Finalize(); err != ErrNotEnded {
+		t.Errorf("Finalize failed. Expected ErrNotEnded, got %v", err)
+	}
+	mock.Time = 2000
+	if _, err := s.Refund(); err != ErrSoftCapReached {
+		t.Errorf("Refund failed. Expected ErrSoftCapReached, got %v", err)
+	}
+	if err := s.Finalize(); err != nil {
+		t.Fatalf("Finalize failed: %v", err)
+	}
+	// 80000 wei at the floor of 100 buys 800 tokens
+	if amount, err := s.Claim(alice); err != nil || amount != stygos.NewU256(800).Mul(unit) || tok.Balances[alice] != amount {
+		t.Errorf("Claim failed. Expected 800 tokens, got %v, %v", amount.Big(), err)
+	}
+}
+
+func TestRefund(t *testing.T) {
+	mock, s, _ := setup(t, 100000, 0)
+	mock.Time = 1100
+	if _, err := commit(mock, s, alice, 60000); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if _, err := s.Refund(); err != ErrNotEnded {
+		t.Errorf("Refund failed. Expected ErrNotEnded, got %v", err)
+	}
+	mock.Time = 2500
+	if err := s.Finalize(); err != ErrSoftCapMissed {
+		t.Errorf("Finalize failed. Expected ErrSoftCapMissed, got %v", err)
+	}
+	if amount, err := s.Refund(); err != nil || amount.Uint64() != 60000 || mock.BalanceOf(alice).Int64() != 60000 {
+		t.Errorf("Refund failed. Expected 60000 wei back, got %d, %v", amount.Uint64(), err)
+	}
+	if _, err := s.Refund(); err != ErrNothingToWithdraw {
+		t.Errorf("Refund failed. Expected ErrNothingToWithdraw, got %v", err)
+	}
+}
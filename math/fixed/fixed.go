@@ -0,0 +1,218 @@
+// Package fixed implements decimal fixed-point arithmetic on stygos.U256.
+//
+// A WAD is a number scaled by 1e18 and a RAY one scaled by 1e27, as in
+// DeFi protocols: 1.5 is 1.5e18 as a WAD. Multiplications and divisions
+// take a 512-bit intermediate product and come in Down and Up variants, so
+// a contract can round in its own favor: down for what it pays out, up for
+// what it charges. Exp and Ln work on WADs and are accurate to a few units
+// in the last place; Sqrt rounds down.
+package fixed
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Fixed-point errors
+var (
+	ErrOverflow       = errors.New("fixed: overflow")
+	ErrDivisionByZero = errors.New("fixed: division by zero")
+	ErrUndefined      = errors.New("fixed: logarithm of zero")
+)
+
+var (
+	// WAD is 1.0 with 18 decimals.
+	WAD = stygos.NewU256(1e18)
+	// RAY is 1.0 with 27 decimals.
+	RAY = stygos.NewU256(1e9).Mul(stygos.NewU256(1e18))
+
+	// ln2Ray is ln(2) as a RAY.
+	ln2Ray = stygos.NewU256(693147180).Mul(stygos.NewU256(1e18)).Add(stygos.NewU256(559945309417232121))
+	// maxExpWad bounds the WADs whose exponential fits in 256 bits, e^135.
+	maxExpWad = stygos.NewU256(135).Mul(WAD)
+	// minExpWad bounds the negative WADs whose exponential rounds to a
+	// non-zero WAD, e^-42.
+	minExpWad = stygos.NewU256(42).Mul(WAD)
+)
+
+// MulDivDown returns floor(x * y / d).
+func MulDivDown(x, y, d stygos.U256) (stygos.U256, error) {
+	if d.IsZero() {
+		return stygos.U256{}, ErrDivisionByZero
+	}
+	z, ok := stygos.MulDiv(x, y, d)
+	if !ok {
+		return stygos.U256{}, ErrOverflow
+	}
+	return z, nil
+}
+
+// MulDivUp returns ceil(x * y / d).
+func MulDivUp(x, y, d stygos.U256) (stygos.U256, error) {
+	if d.IsZero() {
+		return stygos.U256{}, ErrDivisionByZero
+	}
+	z, ok := stygos.MulDivUp(x, y, d)
+	if !ok {
+		return stygos.U256{}, ErrOverflow
+	}
+	return z, nil
+}
+
+// MulWadDown returns x * y for WADs, rounded down.
+func MulWadDown(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivDown(x, y, WAD)
+}
+
+// MulWadUp returns x * y for WADs, rounded up.
+func MulWadUp(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivUp(x, y, WAD)
+}
+
+// DivWadDown returns x / y for WADs, rounded down.
+func DivWadDown(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivDown(x, WAD, y)
+}
+
+// DivWadUp returns x / y for WADs, rounded up.
+func DivWadUp(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivUp(x, WAD, y)
+}
+
+// MulRayDown returns x * y for RAYs, rounded down.
+func MulRayDown(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivDown(x, y, RAY)
+}
+
+// MulRayUp returns x * y for RAYs, rounded up.
+func MulRayUp(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivUp(x, y, RAY)
+}
+
+// DivRayDown returns x / y for RAYs, rounded down.
+func DivRayDown(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivDown(x, RAY, y)
+}
+
+// DivRayUp returns x / y for RAYs, rounded up.
+func DivRayUp(x, y stygos.U256) (stygos.U256, error) {
+	return MulDivUp(x, RAY, y)
+}
+
+// WadToRay converts a WAD to a RAY.
+func WadToRay(x stygos.U256) (stygos.U256, error) {
+	z, ok := x.CheckedMul(stygos.NewU256(1e9))
+	if !ok {
+		return stygos.U256{}, ErrOverflow
+	}
+	return z, nil
+}
+
+// RayToWad converts a RAY to a WAD, rounding down.
+func RayToWad(x stygos.U256) stygos.U256 {
+	return x.Div(stygos.NewU256(1e9))
+}
+
+// Sqrt returns floor(sqrt(x)).
+func Sqrt(x stygos.U256) stygos.U256 {
+	if x.IsZero() {
+		return x
+	}
+	// Newton's method from a power of two above the root, which then
+	// decreases monotonically to it
+	z := stygos.NewU256(1).Lsh(uint(x.BitLen()+1) / 2)
+	for {
+		next := z.Add(x.Div(z)).Rsh(1)
+		if !next.Lt(z) {
+			return z
+		}
+		z = next
+	}
+}
+
+// SqrtWad returns the square root of a WAD, rounded down.
+func SqrtWad(x stygos.U256) stygos.U256 {
+	if scaled, ok := x.CheckedMul(WAD); ok {
+		return Sqrt(scaled)
+	}
+	// Above 2^196 the root of x alone has 98 significant bits, far more
+	// than the 9 digits scaling it by 1e9 drops
+	return Sqrt(x).Mul(stygos.NewU256(1e9))
+}
+
+// ExpWad returns e^x for a WAD x, negated when neg is set. Exponents above
+// 135 overflow; results below 1e-18 round to zero.
+func ExpWad(x stygos.U256, neg bool) (stygos.U256, error) {
+	if neg {
+		if x.Gt(minExpWad) {
+			return stygos.U256{}, nil
+		}
+		e, err := ExpWad(x, false)
+		if err != nil {
+			return stygos.U256{}, err
+		}
+		return MulDivDown(WAD, WAD, e)
+	}
+	if x.Gt(maxExpWad) {
+		return stygos.U256{}, ErrOverflow
+	}
+
+	// e^x = 2^k * e^r with r = x - k*ln(2) in [0, ln(2)), the series for
+	// e^r evaluated in RAY precision
+	xRay, _ := WadToRay(x)
+	k := xRay.Div(ln2Ray)
+	r := xRay.Sub(k.Mul(ln2Ray))
+	sum, term := RAY, RAY
+	for i := uint64(1); !term.IsZero(); i++ {
+		term = term.Mul(r).Div(RAY.Mul(stygos.NewU256(i)))
+		sum = sum.Add(term)
+	}
+	result := RayToWad(sum)
+	if result.BitLen()+int(k.Uint64()) > 256 {
+		return stygos.U256{}, ErrOverflow
+	}
+	return result.Lsh(uint(k.Uint64())), nil
+}
+
+// LnWad returns the natural logarithm of a WAD x > 0 as a magnitude and a
+// sign, rounded toward zero.
+func LnWad(x stygos.U256) (ln stygos.U256, neg bool, err error) {
+	if x.IsZero() {
+		return stygos.U256{}, false, ErrUndefined
+	}
+
+	// x = 2^k * y with y in [1, 2), k negative below 1
+	k, below := 0, x.Lt(WAD)
+	y := x
+	if below {
+		for y.Lt(WAD) {
+			y = y.Lsh(1)
+			k++
+		}
+	} else {
+		k = x.Div(WAD).BitLen() - 1
+		y = x.Rsh(uint(k))
+	}
+
+	// ln(y) = 2*atanh(s) = 2*(s + s^3/3 + s^5/5 + ...) with
+	// s = (y-1)/(y+1) <= 1/3, in RAY precision
+	yRay, _ := WadToRay(y)
+	s := yRay.Sub(RAY).Mul(RAY).Div(yRay.Add(RAY))
+	s2 := s.Mul(s).Div(RAY)
+	sum, power := stygos.U256{}, s
+	for i := uint64(1); !power.IsZero(); i += 2 {
+		sum = sum.Add(power.Div(stygos.NewU256(i)))
+		power = power.Mul(s2).Div(RAY)
+	}
+	lnY := sum.Lsh(1)
+
+	kLn2 := ln2Ray.Mul(stygos.NewU256(uint64(k)))
+	if !below {
+		return RayToWad(kLn2.Add(lnY)), false, nil
+	}
+	if lnY.Gt(kLn2) {
+		return RayToWad(lnY.Sub(kLn2)), false, nil
+	}
+	return RayToWad(kLn2.Sub(lnY)), true, nil
+}
@@ -0,0 +1,143 @@
+package fixed
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func wad(f float64) stygos.U256 {
+	v, _ := new(big.Float).Mul(big.NewFloat(f), big.NewFloat(1e18)).Int(nil)
+	return stygos.U256FromBig(v)
+}
+
+func float(x stygos.U256) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(x.Big()), big.NewFloat(1e18)).Float64()
+	return f
+}
+
+// near reports whether got is within ulps of want.
+func near(got, want stygos.U256, ulps uint64) bool {
+	if got.Lt(want) {
+		got, want = want, got
+	}
+	return !got.Sub(want).Gt(stygos.NewU256(ulps))
+}
+
+func TestMulDiv(t *testing.T) {
+	third := wad(1).Div(stygos.NewU256(3))
+	tests := []struct {
+		name string
+		fn   func(x, y stygos.U256) (stygos.U256, error)
+		x, y stygos.U256
+		want stygos.U256
+	}{
+		{"MulWadDown", MulWadDown, wad(2.5), wad(0.5), wad(1.25)},
+		{"MulWadDown", MulWadDown, third, stygos.NewU256(2), stygos.NewU256(0)},
+		{"MulWadUp", MulWadUp, third, stygos.NewU256(2), stygos.NewU256(1)},
+		{"DivWadDown", DivWadDown, wad(1), wad(3), third},
+		{"DivWadUp", DivWadUp, wad(1), wad(3), third.Add(stygos.NewU256(1))},
+		{"MulRayDown", MulRayDown, RAY.Mul(stygos.NewU256(3)), RAY.Div(stygos.NewU256(2)), RAY.Mul(stygos.NewU256(3)).Div(stygos.NewU256(2))},
+		{"DivRayUp", DivRayUp, RAY, RAY.Mul(stygos.NewU256(3)), RAY.Div(stygos.NewU256(3)).Add(stygos.NewU256(1))},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn(tt.x, tt.y)
+		if err != nil || got != tt.want {
+			t.Errorf("%s(%v, %v) failed. Expected %v, got %v, %v", tt.name, tt.x.Big(), tt.y.Big(), tt.want.Big(), got.Big(), err)
+		}
+	}
+
+	// Products above 256 bits are fine while the result fits
+	big := stygos.NewU256(1).Lsh(200)
+	if got, err := MulWadDown(big, wad(3)); err != nil || got != big.Mul(stygos.NewU256(3)) {
+		t.Errorf("MulWadDown failed. Expected 3*2^200, got %v, %v", got.Big(), err)
+	}
+	if _, err := MulWadDown(stygos.U256{}.Not(), wad(2)); err != ErrOverflow {
+		t.Errorf("MulWadDown failed. Expected ErrOverflow, got %v", err)
+	}
+	if _, err := DivWadDown(wad(1), stygos.U256{}); err != ErrDivisionByZero {
+		t.Errorf("DivWadDown failed. Expected ErrDivisionByZero, got %v", err)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	allOnes := stygos.U256{}.Not()
+	tests := []struct {
+		x, want stygos.U256
+	}{
+		{stygos.NewU256(0), stygos.NewU256(0)},
+		{stygos.NewU256(1), stygos.NewU256(1)},
+		{stygos.NewU256(15), stygos.NewU256(3)},
+		{stygos.NewU256(16), stygos.NewU256(4)},
+		{allOnes, stygos.NewU256(1).Lsh(128).Sub(stygos.NewU256(1))},
+	}
+	for _, tt := range tests {
+		if got := Sqrt(tt.x); got != tt.want {
+			t.Errorf("Sqrt(%v) failed. Expected %v, got %v", tt.x.Big(), tt.want.Big(), got.Big())
+		}
+	}
+	if got := SqrtWad(wad(2)); got != stygos.NewU256(1414213562373095048) {
+		t.Errorf("SqrtWad(2) failed. Expected 1.414213562373095048, got %v", got.Big())
+	}
+	if got := SqrtWad(allOnes); !near(got, Sqrt(allOnes).Mul(stygos.NewU256(1e9)), 1e9) {
+		t.Errorf("SqrtWad failed on a large input, got %v", got.Big())
+	}
+}
+
+func TestExpWad(t *testing.T) {
+	if got, _ := ExpWad(stygos.U256{}, false); got != WAD {
+		t.Errorf("ExpWad(0) failed. Expected 1, got %v", got.Big())
+	}
+	if got, _ := ExpWad(WAD, false); !near(got, stygos.NewU256(2718281828459045235), 2) {
+		t.Errorf("ExpWad(1) failed. Expected e, got %v", got.Big())
+	}
+	for _, x := range []float64{0.001, 0.5, 3, 10.25, 41, 100, 135} {
+		for _, neg := range []bool{false, true} {
+			got, err := ExpWad(wad(x), neg)
+			want := math.Exp(x)
+			if neg {
+				want = math.Exp(-x)
+			}
+			if err != nil || math.Abs(float(got)-want) > want*1e-12+1e-18 {
+				t.Errorf("ExpWad(%v, %v) failed. Expected %v, got %v, %v", x, neg, want, float(got), err)
+			}
+		}
+	}
+	if _, err := ExpWad(wad(136), false); err != ErrOverflow {
+		t.Errorf("ExpWad(136) failed. Expected ErrOverflow, got %v", err)
+	}
+	if got, err := ExpWad(wad(50), true); err != nil || !got.IsZero() {
+		t.Errorf("ExpWad(-50) failed. Expected 0, got %v, %v", got.Big(), err)
+	}
+}
+
+func TestLnWad(t *testing.T) {
+	if got, _, _ := LnWad(WAD); !got.IsZero() {
+		t.Errorf("LnWad(1) failed. Expected 0, got %v", got.Big())
+	}
+	if got, neg, _ := LnWad(wad(2)); neg || !near(got, stygos.NewU256(693147180559945309), 2) {
+		t.Errorf("LnWad(2) failed. Expected ln 2, got %v", got.Big())
+	}
+	for _, x := range []float64{1e-18, 1e-9, 0.25, 0.999, 1.5, 2.718281828459045, 1000, 1e30} {
+		got, neg, err := LnWad(wad(x))
+		want := math.Log(x)
+		value := float(got)
+		if neg {
+			value = -value
+		}
+		if err != nil || math.Abs(value-want) > math.Abs(want)*1e-12+1e-15 {
+			t.Errorf("LnWad(%v) failed. Expected %v, got %v, %v", x, want, value, err)
+		}
+	}
+	if _, _, err := LnWad(stygos.U256{}); err != ErrUndefined {
+		t.Errorf("LnWad(0) failed. Expected ErrUndefined, got %v", err)
+	}
+
+	// Ln inverts Exp
+	e, _ := ExpWad(wad(7.5), false)
+	if got, _, _ := LnWad(e); !near(got, wad(7.5), 10) {
+		t.Errorf("LnWad(ExpWad(7.5)) failed. Expected 7.5, got %v", got.Big())
+	}
+}
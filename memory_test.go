@@ -0,0 +1,70 @@
+package stygos
+
+import "testing"
+
+func TestEnsureMemoryGrowsByDelta(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	// 100000 bytes need 2 pages
+	if err := EnsureMemory(100000); err != nil {
+		t.Fatalf("EnsureMemory failed: %v", err)
+	}
+	if mock.Pages != 2 {
+		t.Errorf("after first EnsureMemory: %d pages grown, want 2", mock.Pages)
+	}
+
+	// Already covered, nothing to grow
+	if err := EnsureMemory(65536); err != nil {
+		t.Fatalf("EnsureMemory failed: %v", err)
+	}
+	if mock.Pages != 2 {
+		t.Errorf("after covered EnsureMemory: %d pages grown, want 2", mock.Pages)
+	}
+
+	// 200000 bytes need 4 pages in total, so only 2 more
+	if err := EnsureMemory(200000); err != nil {
+		t.Fatalf("EnsureMemory failed: %v", err)
+	}
+	if mock.Pages != 4 {
+		t.Errorf("after second EnsureMemory: %d pages grown, want 4", mock.Pages)
+	}
+	if ReservedMemory() != 4*PageSize {
+		t.Errorf("ReservedMemory() = %d, want %d", ReservedMemory(), 4*PageSize)
+	}
+}
+
+func TestReserveMemory(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	if err := ReserveMemory(MaxMemoryPages*PageSize + 1); err != ErrMemoryLimit {
+		t.Errorf("ReserveMemory above the page limit = %v, want ErrMemoryLimit", err)
+	}
+	if mock.Pages != 0 {
+		t.Errorf("failed reservation grew %d pages", mock.Pages)
+	}
+
+	if err := ReserveMemory(1 << 20); err != nil {
+		t.Fatalf("ReserveMemory failed: %v", err)
+	}
+	if mock.Pages != 16 {
+		t.Errorf("ReserveMemory(1MiB) grew %d pages, want 16", mock.Pages)
+	}
+
+	// A return buffer inside the reservation does not grow memory again
+	b := NewReturnBuilder(4096)
+	b.AppendRaw(make([]byte, 512*1024))
+	if err := b.Finish(); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+	if mock.Pages != 16 {
+		t.Errorf("ReturnBuilder inside reservation grew memory to %d pages", mock.Pages)
+	}
+
+	// A fresh call context starts accounting from zero
+	UseRuntime(NewMockRuntime())
+	if ReservedMemory() != 0 {
+		t.Errorf("ReservedMemory() after UseRuntime = %d, want 0", ReservedMemory())
+	}
+}
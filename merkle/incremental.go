@@ -0,0 +1,245 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Incremental tree errors
+var (
+	ErrTreeFull     = errors.New("merkle: tree is full")
+	ErrInvalidProof = errors.New("merkle: invalid proof length")
+)
+
+// MaxDepth is the deepest incremental tree, with 2^32 leaves.
+const MaxDepth = 32
+
+// RootHistorySize is the number of recent roots an IncrementalTree
+// remembers, so a proof against a root stays valid while other leaves are
+// inserted.
+const RootHistorySize = 30
+
+// HashFunc returns the parent of two nodes. Unlike HashPair, the order of
+// the children matters in an incremental tree.
+type HashFunc func(left, right stygos.Word) stygos.Word
+
+// HashKeccak is keccak256(left || right).
+func HashKeccak(left, right stygos.Word) stygos.Word {
+	var buf [64]byte
+	copy(buf[:32], left[:])
+	copy(buf[32:], right[:])
+	return stygos.Keccak256(buf[:])
+}
+
+// HashSHA256 is sha256(left || right), the hash of the Eth2 deposit
+// contract's tree.
+func HashSHA256(left, right stygos.Word) stygos.Word {
+	var buf [64]byte
+	copy(buf[:32], left[:])
+	copy(buf[32:], right[:])
+	return sha256.Sum256(buf[:])
+}
+
+// IncrementalTree is an append-only Merkle tree of fixed depth, in the
+// style of the Eth2 deposit contract and Tornado Cash. Storage only keeps
+// the rightmost filled node of each level and the recent roots, so an
+// insert costs a few writes whatever the size; leaves not yet inserted
+// are zero words, and the roots of all-zero subtrees are computed once
+// per tree in memory.
+//
+// Storage layout relative to the base slot:
+//
+//	base                 number of leaves
+//	base+1               position of the latest root in the history
+//	base+2+i             rightmost filled node at level i, for i < depth
+//	base+2+depth+j       root history, j < RootHistorySize
+type IncrementalTree struct {
+	depth   int
+	hash    HashFunc
+	count   stygos.Word
+	latest  stygos.Word
+	branch  stygos.Word
+	history stygos.Word
+	zeros   []stygos.Word
+}
+
+// NewIncrementalTree returns the tree of the given depth rooted at base.
+// It panics unless 1 <= depth <= MaxDepth.
+func NewIncrementalTree(base stygos.Word, depth int, hash HashFunc) *IncrementalTree {
+	if depth < 1 || depth > MaxDepth {
+		panic("merkle: invalid incremental tree depth")
+	}
+	return &IncrementalTree{
+		depth:   depth,
+		hash:    hash,
+		count:   base,
+		latest:  storage.Offset(base, 1),
+		branch:  storage.Offset(base, 2),
+		history: storage.Offset(base, 2+uint64(depth)),
+	}
+}
+
+// Depth returns the depth of the tree.
+func (t *IncrementalTree) Depth() int {
+	return t.depth
+}
+
+// Count returns the number of leaves inserted.
+func (t *IncrementalTree) Count() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(t.count))
+}
+
+// Zero returns the root of an all-zero subtree of the given height:
+// the zero word at height 0.
+func (t *IncrementalTree) Zero(height int) stygos.Word {
+	if t.zeros == nil {
+		t.zeros = make([]stygos.Word, t.depth+1)
+		for i := 0; i < t.depth; i++ {
+			t.zeros[i+1] = t.hash(t.zeros[i], t.zeros[i])
+		}
+	}
+	return t.zeros[height]
+}
+
+// Insert appends leaf and returns its index and the new root.
+func (t *IncrementalTree) Insert(leaf stygos.Word) (uint64, stygos.Word, error) {
+	index := t.Count()
+	if index >= 1<<uint(t.depth) {
+		return 0, stygos.Word{}, ErrTreeFull
+	}
+	size := index + 1
+	stygos.StorageStore(t.count, stygos.WordFromUint64(size))
+
+	// The new leaf completes the subtrees below the lowest zero bit of
+	// size; their root becomes the filled node of that level
+	node := leaf
+	level := 0
+	for n := size; n&1 == 0; n >>= 1 {
+		node = t.hash(t.node(level), node)
+		level++
+	}
+	// The last leaf completes the whole tree
+	root := node
+	if level < t.depth {
+		stygos.StorageStore(storage.Offset(t.branch, uint64(level)), node)
+		root = t.computeRoot(size)
+	}
+
+	pos := (t.latestPosition() + 1) % RootHistorySize
+	if index == 0 {
+		pos = 0
+	}
+	stygos.StorageStore(storage.Offset(t.history, pos), root)
+	stygos.StorageStore(t.latest, stygos.WordFromUint64(pos))
+	return index, root, nil
+}
+
+// Root returns the current root.
+func (t *IncrementalTree) Root() stygos.Word {
+	if t.Count() == 0 {
+		return t.Zero(t.depth)
+	}
+	return stygos.StorageLoad(storage.Offset(t.history, t.latestPosition()))
+}
+
+// IsKnownRoot reports whether root is the current root or one of the
+// RootHistorySize-1 before it. The root of the empty tree is known until
+// the first insert.
+func (t *IncrementalTree) IsKnownRoot(root stygos.Word) bool {
+	count := t.Count()
+	if count == 0 {
+		return root == t.Zero(t.depth)
+	}
+	if root == (stygos.Word{}) {
+		return false
+	}
+	n := uint64(RootHistorySize)
+	if count < n {
+		n = count
+	}
+	for j := uint64(0); j < n; j++ {
+		if stygos.StorageLoad(storage.Offset(t.history, j)) == root {
+			return true
+		}
+	}
+	return false
+}
+
+// computeRoot returns the root of the first size leaves from the filled
+// nodes: where size has a one bit, the filled node of that level is the
+// left child, and elsewhere the node is a left child padded with zeros.
+func (t *IncrementalTree) computeRoot(size uint64) stygos.Word {
+	var node stygos.Word
+	for level := 0; level < t.depth; level++ {
+		if size>>uint(level)&1 == 1 {
+			node = t.hash(t.node(level), node)
+		} else {
+			node = t.hash(node, t.Zero(level))
+		}
+	}
+	return node
+}
+
+func (t *IncrementalTree) node(level int) stygos.Word {
+	return stygos.StorageLoad(storage.Offset(t.branch, uint64(level)))
+}
+
+func (t *IncrementalTree) latestPosition() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(t.latest))
+}
+
+// ProcessIndexedProof returns the root reached by hashing the leaf at
+// index up with the siblings in proof, the leaf's side at each level
+// given by the bits of index.
+func ProcessIndexedProof(hash HashFunc, leaf stygos.Word, index uint64, proof []stygos.Word) stygos.Word {
+	node := leaf
+	for i, sibling := range proof {
+		if index>>uint(i)&1 == 1 {
+			node = hash(sibling, node)
+		} else {
+			node = hash(node, sibling)
+		}
+	}
+	return node
+}
+
+// VerifyIndexedProof reports whether proof shows that leaf is at index in
+// the tree with root, whose depth is the length of the proof.
+func VerifyIndexedProof(hash HashFunc, root, leaf stygos.Word, index uint64, proof []stygos.Word) bool {
+	if len(proof) > MaxDepth || index>>uint(len(proof)) != 0 {
+		return false
+	}
+	return ProcessIndexedProof(hash, leaf, index, proof) == root
+}
+
+// IncrementalProof returns the proof of leaf index in an incremental tree
+// of the given depth holding leaves, for off-chain tools and tests.
+func IncrementalProof(hash HashFunc, depth int, leaves []stygos.Word, index uint64) ([]stygos.Word, error) {
+	if depth < 1 || depth > MaxDepth {
+		return nil, ErrInvalidProof
+	}
+	if uint64(len(leaves)) > 1<<uint(depth) {
+		return nil, ErrTreeFull
+	}
+	if index >= uint64(len(leaves)) {
+		return nil, ErrIndexOutOfTree
+	}
+	level := append([]stygos.Word(nil), leaves...)
+	var zero stygos.Word
+	proof := make([]stygos.Word, 0, depth)
+	for i := 0; i < depth; i++ {
+		if len(level)%2 == 1 {
+			level = append(level, zero)
+		}
+		proof = append(proof, level[index^1])
+		next := make([]stygos.Word, len(level)/2)
+		for j := range next {
+			next[j] = hash(level[2*j], level[2*j+1])
+		}
+		level, index, zero = next, index/2, hash(zero, zero)
+	}
+	return proof, nil
+}
@@ -0,0 +1,110 @@
+// Package merkle verifies Merkle proofs compatible with OpenZeppelin's
+// MerkleProof and StandardMerkleTree, for allowlists and airdrops that
+// commit to many entries with a single root.
+//
+// Pairs are hashed in sorted order, so a proof is only the list of
+// siblings from the leaf up, without left or right flags. Leaves are
+// double hashed with Leaf so that no inner node can pass for a leaf.
+//
+// Tree builds the root and the proofs off-chain, or in tests.
+//
+// IncrementalTree is an append-only tree in contract storage, with
+// positional rather than sorted hashing, for deposit queues and the
+// commitment trees of privacy protocols.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Merkle errors
+var (
+	ErrNoLeaves       = errors.New("merkle: tree has no leaves")
+	ErrIndexOutOfTree = errors.New("merkle: leaf index out of range")
+)
+
+// Leaf returns the leaf committing to data, the ABI encoding of the entry:
+// keccak256(keccak256(data)), as StandardMerkleTree hashes its values.
+func Leaf(data []byte) stygos.Word {
+	inner := stygos.Keccak256(data)
+	return stygos.Keccak256(inner[:])
+}
+
+// HashPair returns the parent of two nodes: the hash of the lower followed
+// by the higher.
+func HashPair(a, b stygos.Word) stygos.Word {
+	if bytes.Compare(b[:], a[:]) < 0 {
+		a, b = b, a
+	}
+	var buf [64]byte
+	copy(buf[:32], a[:])
+	copy(buf[32:], b[:])
+	return stygos.Keccak256(buf[:])
+}
+
+// ProcessProof returns the root reached by hashing leaf up with the
+// siblings in proof.
+func ProcessProof(proof []stygos.Word, leaf stygos.Word) stygos.Word {
+	node := leaf
+	for _, sibling := range proof {
+		node = HashPair(node, sibling)
+	}
+	return node
+}
+
+// Verify reports whether proof shows that leaf is in the tree with root.
+func Verify(proof []stygos.Word, root, leaf stygos.Word) bool {
+	return ProcessProof(proof, leaf) == root
+}
+
+// Tree is a Merkle tree over leaves in the order given. A node without a
+// sibling moves up a level unchanged, so trees of any size have proofs of
+// at most log2(n) rounded up siblings.
+type Tree struct {
+	levels [][]stygos.Word // levels[0] are the leaves, the last the root
+}
+
+// NewTree builds the tree over leaves, typically made with Leaf.
+func NewTree(leaves []stygos.Word) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+	level := append([]stygos.Word(nil), leaves...)
+	t := &Tree{levels: [][]stygos.Word{level}}
+	for len(level) > 1 {
+		next := make([]stygos.Word, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, HashPair(level[i], level[i+1]))
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t, nil
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() stygos.Word {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Proof returns the proof of leaf i.
+func (t *Tree) Proof(i int) ([]stygos.Word, error) {
+	if i < 0 || i >= len(t.levels[0]) {
+		return nil, ErrIndexOutOfTree
+	}
+	var proof []stygos.Word
+	for _, level := range t.levels[:len(t.levels)-1] {
+		if sibling := i ^ 1; sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		i /= 2
+	}
+	return proof, nil
+}
@@ -0,0 +1,126 @@
+package merkle
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func leaves(n int) []stygos.Word {
+	out := make([]stygos.Word, n)
+	for i := range out {
+		w := stygos.WordFromUint64(uint64(i))
+		out[i] = Leaf(w[:])
+	}
+	return out
+}
+
+func TestHashPair(t *testing.T) {
+	a, b := stygos.Word{0x01}, stygos.Word{0x02}
+	want := stygos.Keccak256(append(a[:], b[:]...))
+	if HashPair(a, b) != want || HashPair(b, a) != want {
+		t.Errorf("HashPair failed. Expected the sorted pair hash %x in both orders", want)
+	}
+	inner := stygos.Keccak256([]byte("entry"))
+	if Leaf([]byte("entry")) != stygos.Keccak256(inner[:]) {
+		t.Errorf("Leaf failed. Expected a double keccak256")
+	}
+}
+
+func TestTree(t *testing.T) {
+	if _, err := NewTree(nil); err != ErrNoLeaves {
+		t.Errorf("NewTree failed. Expected ErrNoLeaves, got %v", err)
+	}
+	for n := 1; n <= 9; n++ {
+		ls := leaves(n)
+		tree, err := NewTree(ls)
+		if err != nil {
+			t.Fatalf("NewTree(%d) failed: %v", n, err)
+		}
+		for i, leaf := range ls {
+			proof, err := tree.Proof(i)
+			if err != nil || !Verify(proof, tree.Root(), leaf) {
+				t.Errorf("Verify failed. Expected leaf %d of %d to verify, got %v", i, n, err)
+			}
+			if len(proof) > 0 && Verify(proof, tree.Root(), stygos.Word{0xff}) {
+				t.Errorf("Verify failed. Expected a foreign leaf to fail for leaf %d of %d", i, n)
+			}
+		}
+		if _, err := tree.Proof(n); err != ErrIndexOutOfTree {
+			t.Errorf("Proof failed. Expected ErrIndexOutOfTree, got %v", err)
+		}
+	}
+
+	// Four leaves: the root is the pair of the two pairs
+	ls := leaves(4)
+	tree, _ := NewTree(ls)
+	if want := HashPair(HashPair(ls[0], ls[1]), HashPair(ls[2], ls[3])); tree.Root() != want {
+		t.Errorf("Root failed. Expected %x, got %x", want, tree.Root())
+	}
+}
+
+func TestIncrementalTree(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	tree := NewIncrementalTree(stygos.Word{0x7e}, 5, HashKeccak)
+	empty := tree.Root()
+	if !tree.IsKnownRoot(empty) || empty != tree.Zero(5) {
+		t.Errorf("Root failed. Expected the empty root %x, got %x", tree.Zero(5), empty)
+	}
+
+	var inserted, roots []stygos.Word
+	for i, leaf := range leaves(RootHistorySize + 2) {
+		index, root, err := tree.Insert(leaf)
+		if err != nil || index != uint64(i) || root != tree.Root() {
+			t.Fatalf("Insert(%d) failed: index %d, root %x, %v", i, index, root, err)
+		}
+		inserted = append(inserted, leaf)
+		roots = append(roots, root)
+
+		// The root is that of the padded tree, and every leaf has a proof
+		for _, j := range []int{0, i / 2, i} {
+			proof, err := IncrementalProof(HashKeccak, 5, inserted, uint64(j))
+			if err != nil || !VerifyIndexedProof(HashKeccak, root, inserted[j], uint64(j), proof) {
+				t.Fatalf("VerifyIndexedProof(leaf %d of %d) failed: %v", j, i+1, err)
+			}
+		}
+	}
+	if tree.Count() != RootHistorySize+2 {
+		t.Errorf("Count failed. Expected %d, got %d", RootHistorySize+2, tree.Count())
+	}
+
+	// The last RootHistorySize roots are known, older ones are not
+	if !tree.IsKnownRoot(roots[len(roots)-1]) || !tree.IsKnownRoot(roots[2]) {
+		t.Error("IsKnownRoot failed. Expected recent roots to be known")
+	}
+	if tree.IsKnownRoot(roots[1]) || tree.IsKnownRoot(empty) || tree.IsKnownRoot(stygos.Word{}) {
+		t.Error("IsKnownRoot failed. Expected old and zero roots to be unknown")
+	}
+
+	proof, _ := IncrementalProof(HashKeccak, 5, inserted, 3)
+	if VerifyIndexedProof(HashKeccak, tree.Root(), inserted[3], 4, proof) || VerifyIndexedProof(HashKeccak, tree.Root(), inserted[3], 3+32, proof) {
+		t.Error("VerifyIndexedProof failed. Expected a wrong index to fail")
+	}
+
+	small := NewIncrementalTree(stygos.Word{0x7f}, 1, HashKeccak)
+	small.Insert(stygos.Word{1})
+	small.Insert(stygos.Word{2})
+	if _, _, err := small.Insert(stygos.Word{3}); err != ErrTreeFull {
+		t.Errorf("Insert failed. Expected ErrTreeFull, got %v", err)
+	}
+	if small.Root() != HashKeccak(stygos.Word{1}, stygos.Word{2}) {
+		t.Errorf("Root failed. Expected the hash of both leaves, got %x", small.Root())
+	}
+}
+
+// The Eth2 deposit contract's root mixes the count into a SHA-256 tree of
+// depth 32; empty, it is the root the contract was deployed with.
+func TestDepositTree(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	tree := NewIncrementalTree(stygos.Word{0xde}, 32, HashSHA256)
+	var count stygos.Word
+	root := HashSHA256(tree.Root(), count)
+	if hex.EncodeToString(root[:]) != "d70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e" {
+		t.Errorf("deposit root failed. Expected d70a2347..., got %x", root)
+	}
+}
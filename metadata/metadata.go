@@ -0,0 +1,142 @@
+// Package metadata builds ERC-721 and ERC-1155 token metadata on chain and
+// returns it as a data URI, so that tokenURI needs no off-chain server:
+//
+//	data:application/json;base64,eyJuYW1lIjoi...
+//
+// The JSON is written with the stygos encoding/json strings and strconvx
+// numbers rather than the standard encoding/json, which keeps reflection
+// out of TinyGo builds.
+package metadata
+
+import (
+	"github.com/rafaelescrich/stygos/encoding/base64"
+	"github.com/rafaelescrich/stygos/encoding/json"
+	"github.com/rafaelescrich/stygos/strconvx"
+)
+
+// JSONPrefix starts a base64 JSON data URI.
+const JSONPrefix = "data:application/json;base64,"
+
+// Attribute is an entry of the OpenSea-style "attributes" array.
+type Attribute struct {
+	TraitType   string
+	Value       string
+	Numeric     bool   // emit Value unquoted, e.g. for levels and stats
+	DisplayType string // optional: "number", "boost_percentage", "date", ...
+}
+
+// Text returns a string attribute.
+func Text(trait, value string) Attribute {
+	return Attribute{TraitType: trait, Value: value}
+}
+
+// Number returns a numeric attribute.
+func Number(trait string, value uint64) Attribute {
+	return Attribute{TraitType: trait, Value: strconvx.FormatUint(value), Numeric: true}
+}
+
+// Metadata is the JSON document returned by tokenURI. Empty fields are
+// omitted.
+type Metadata struct {
+	Name            string
+	Description     string
+	Image           string // URL or data URI, e.g. from ImageURI
+	ExternalURL     string
+	AnimationURL    string
+	BackgroundColor string // six hex digits without '#'
+	Attributes      []Attribute
+}
+
+// AppendJSON appends the JSON encoding of m to dst.
+func (m Metadata) AppendJSON(dst []byte) []byte {
+	dst = append(dst, '{')
+	first := true
+	field := func(name, value string) {
+		if value == "" {
+			return
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = json.AppendString(dst, name)
+		dst = append(dst, ':')
+		dst = json.AppendString(dst, value)
+	}
+
+	field("name", m.Name)
+	field("description", m.Description)
+	field("image", m.Image)
+	field("external_url", m.ExternalURL)
+	field("animation_url", m.AnimationURL)
+	field("background_color", m.BackgroundColor)
+
+	if len(m.Attributes) > 0 {
+		if !first {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, `"attributes":[`...)
+		for i, a := range m.Attributes {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = a.appendJSON(dst)
+		}
+		dst = append(dst, ']')
+	}
+	return append(dst, '}')
+}
+
+// JSON returns the JSON encoding of m.
+func (m Metadata) JSON() []byte {
+	return m.AppendJSON(nil)
+}
+
+// TokenURI returns m as a base64 JSON data URI.
+func (m Metadata) TokenURI() string {
+	return string(DataURI(JSONPrefix, m.JSON()))
+}
+
+// ImageURI returns an SVG image as a base64 data URI for the Image field.
+func ImageURI(svg []byte) string {
+	return string(DataURI("data:image/svg+xml;base64,", svg))
+}
+
+// DataURI returns prefix followed by the base64 encoding of data.
+func DataURI(prefix string, data []byte) []byte {
+	uri := make([]byte, 0, len(prefix)+base64.EncodedLen(len(data)))
+	uri = append(uri, prefix...)
+	return base64.AppendEncode(uri, data)
+}
+
+func (a Attribute) appendJSON(dst []byte) []byte {
+	dst = append(dst, '{')
+	if a.DisplayType != "" {
+		dst = append(dst, `"display_type":`...)
+		dst = json.AppendString(dst, a.DisplayType)
+		dst = append(dst, ',')
+	}
+	dst = append(dst, `"trait_type":`...)
+	dst = json.AppendString(dst, a.TraitType)
+	dst = append(dst, `,"value":`...)
+	if a.Numeric && isNumber(a.Value) {
+		dst = append(dst, a.Value...)
+	} else {
+		dst = json.AppendString(dst, a.Value)
+	}
+	return append(dst, '}')
+}
+
+// isNumber reports whether s is a plain unsigned decimal, so it can be
+// emitted unquoted without producing invalid JSON.
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package metadata
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos/encoding/base64"
+)
+
+func TestJSON(t *testing.T) {
+	m := Metadata{
+		Name:        "Stygian #1",
+		Description: "A \"quoted\"\nmulti-line\\description\x01",
+		Image:       ImageURI([]byte("<svg/>")),
+		Attributes: []Attribute{
+			Text("Background", "River"),
+			Number("Level", 5),
+			{TraitType: "Power", Value: "40", Numeric: true, DisplayType: "boost_percentage"},
+			{TraitType: "Bad", Value: "1e9", Numeric: true},
+		},
+	}
+
+	var decoded struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Image       string `json:"image"`
+		Attributes  []struct {
+			DisplayType string      `json:"display_type"`
+			TraitType   string      `json:"trait_type"`
+			Value       interface{} `json:"value"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(m.JSON(), &decoded); err != nil {
+		t.Fatalf("JSON produced invalid JSON: %v\n%s", err, m.JSON())
+	}
+
+	if decoded.Name != m.Name || decoded.Description != m.Description {
+		t.Errorf("JSON failed. Got name %q, description %q", decoded.Name, decoded.Description)
+	}
+	if decoded.Image != "data:image/svg+xml;base64,PHN2Zy8+" {
+		t.Errorf("ImageURI failed. Got %q", decoded.Image)
+	}
+	if len(decoded.Attributes) != 4 {
+		t.Fatalf("JSON failed. Expected 4 attributes, got %d", len(decoded.Attributes))
+	}
+	if v, ok := decoded.Attributes[1].Value.(float64); !ok || v != 5 {
+		t.Errorf("Number attribute failed. Got %#v", decoded.Attributes[1].Value)
+	}
+	if decoded.Attributes[2].DisplayType != "boost_percentage" {
+		t.Errorf("DisplayType failed. Got %q", decoded.Attributes[2].DisplayType)
+	}
+	if v, ok := decoded.Attributes[3].Value.(string); !ok || v != "1e9" {
+		t.Errorf("Non-decimal numeric attribute should be quoted. Got %#v", decoded.Attributes[3].Value)
+	}
+}
+
+func TestOmitsEmptyFields(t *testing.T) {
+	if got := string(Metadata{}.JSON()); got != "{}" {
+		t.Errorf("JSON failed. Expected {}, got %s", got)
+	}
+	if got := string(Metadata{Attributes: []Attribute{Text("a", "b")}}.JSON()); got != `{"attributes":[{"trait_type":"a","value":"b"}]}` {
+		t.Errorf("JSON failed. Got %s", got)
+	}
+}
+
+func TestTokenURI(t *testing.T) {
+	m := Metadata{Name: "Token", Description: strings.Repeat("long ", 40)}
+	uri := m.TokenURI()
+	if !strings.HasPrefix(uri, JSONPrefix) {
+		t.Fatalf("TokenURI failed. Got %q", uri)
+	}
+	raw, err := base64.DecodeString(uri[len(JSONPrefix):])
+	if err != nil || string(raw) != string(m.JSON()) {
+		t.Errorf("TokenURI failed to round trip: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package metatx implements ERC-2771 meta-transactions: a trusted forwarder
+// verifies a request signed by a user and calls the recipient contract
+// with the user's address appended to the calldata, so users act without
+// paying gas themselves.
+//
+// Recipients read the caller through a Context instead of
+// stygos.GetMsgSender. Forwarder is a minimal forwarder verifying EIP-712
+// signed requests with per-signer nonces.
+package metatx
+
+import "github.com/rafaelescrich/stygos"
+
+// Context resolves the original sender of calls relayed by a trusted
+// forwarder.
+type Context struct {
+	Forwarder stygos.Address
+}
+
+// NewContext returns a context trusting forwarder.
+func NewContext(forwarder stygos.Address) Context {
+	return Context{Forwarder: forwarder}
+}
+
+// IsTrustedForwarder reports whether addr is the trusted forwarder.
+func (c Context) IsTrustedForwarder(addr stygos.Address) bool {
+	return addr != (stygos.Address{}) && addr == c.Forwarder
+}
+
+// MsgSender returns the address appended to the calldata when called by
+// the trusted forwarder, and msg.sender otherwise.
+func (c Context) MsgSender() stygos.Address {
+	sender := stygos.GetMsgSender()
+	if !c.IsTrustedForwarder(sender) {
+		return sender
+	}
+	data, err := stygos.GetCallData()
+	if err != nil || len(data) < 20 {
+		return sender
+	}
+	var signer stygos.Address
+	copy(signer[:], data[len(data)-20:])
+	return signer
+}
+
+// MsgData returns the calldata without the address the trusted forwarder
+// appends.
+func (c Context) MsgData() ([]byte, error) {
+	data, err := stygos.GetCallData()
+	if err != nil {
+		return nil, err
+	}
+	if c.IsTrustedForwarder(stygos.GetMsgSender()) && len(data) >= 20 {
+		return data[:len(data)-20], nil
+	}
+	return data, nil
+}
+
+// Entrypoint is Router.Entrypoint for recipients: it dispatches MsgData so
+// that handlers see the same arguments whether called directly or through
+// the forwarder.
+func (c Context) Entrypoint(r *stygos.Router) int32 {
+	data, err := c.MsgData()
+	if err != nil {
+		return 1
+	}
+	result, err := r.Dispatch(data)
+	if err != nil {
+		return 1
+	}
+	if err := stygos.SetReturnData(result); err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package metatx
+
+import (
+	"errors"
+	"math"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/eip712"
+)
+
+// Forwarder errors
+var (
+	ErrInvalidSignature = errors.New("metatx: signature does not match request")
+	ErrInvalidNonce     = errors.New("metatx: invalid nonce")
+	ErrValueMismatch    = errors.New("metatx: msg.value does not match request value")
+)
+
+// ForwardRequestType is the EIP-712 type of a ForwardRequest, as in
+// OpenZeppelin's MinimalForwarder.
+const ForwardRequestType = "ForwardRequest(address from,address to,uint256 value,uint256 gas,uint256 nonce,bytes data)"
+
+// ForwardRequest is a call signed by From for the forwarder to make.
+type ForwardRequest struct {
+	From  stygos.Address
+	To    stygos.Address
+	Value stygos.U256
+	Gas   uint64 // 0 forwards all gas
+	Nonce stygos.U256
+	Data  []byte
+}
+
+// StructHash returns the EIP-712 struct hash of the request.
+func (r *ForwardRequest) StructHash() stygos.Word {
+	return eip712.HashStruct(eip712.TypeHash(ForwardRequestType),
+		stygos.PadAddress(r.From),
+		stygos.PadAddress(r.To),
+		r.Value.Word(),
+		stygos.WordFromUint64(r.Gas),
+		r.Nonce.Word(),
+		eip712.HashBytes(r.Data))
+}
+
+// Forwarder verifies and relays signed requests.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, signer)   next nonce (an eip712.ReplayGuard)
+type Forwarder struct {
+	guard   *eip712.ReplayGuard
+	name    string
+	version string
+}
+
+// NewForwarder returns the forwarder rooted at base, signing under the
+// EIP-712 domain name and version of the executing contract.
+func NewForwarder(base stygos.Word, name, version string) *Forwarder {
+	return &Forwarder{guard: eip712.NewReplayGuard(base), name: name, version: version}
+}
+
+// Nonce returns the nonce the next request from signer must use.
+func (f *Forwarder) Nonce(signer stygos.Address) stygos.U256 {
+	return f.guard.NextNonce(signer)
+}
+
+// Digest returns the EIP-712 digest From signs for req.
+func (f *Forwarder) Digest(req *ForwardRequest) stygos.Word {
+	return eip712.Digest(f.domain().Separator(), req.StructHash())
+}
+
+// Verify reports whether req is signed by req.From and carries its
+// current nonce.
+func (f *Forwarder) Verify(req *ForwardRequest, sig ecdsa.Signature) bool {
+	return f.verify(req, sig) == nil
+}
+
+// Execute verifies req, consumes its nonce and calls req.To with req.Data
+// followed by req.From. msg.value must equal req.Value. It returns the
+// return data of the call, or stygos.ErrRevert if it reverts;
+// reverting the forwarder in turn restores the nonce.
+func (f *Forwarder) Execute(req *ForwardRequest, sig ecdsa.Signature) ([]byte, error) {
+	if err := f.verify(req, sig); err != nil {
+		return nil, err
+	}
+	if stygos.U256FromBig(stygos.GetMsgValue()) != req.Value {
+		return nil, ErrValueMismatch
+	}
+	if err := f.guard.Consume(f.stamp(req)); err != nil {
+		return nil, ErrInvalidNonce
+	}
+
+	data := make([]byte, 0, len(req.Data)+20)
+	data = append(data, req.Data...)
+	data = append(data, req.From[:]...)
+	if req.Gas == 0 {
+		return stygos.Call(req.To, req.Value.Word(), data)
+	}
+	return stygos.CallGas(req.To, req.Value.Word(), data, req.Gas)
+}
+
+func (f *Forwarder) verify(req *ForwardRequest, sig ecdsa.Signature) error {
+	if err := f.guard.Check(f.stamp(req)); err != nil {
+		return ErrInvalidNonce
+	}
+	signer, err := ecdsa.Recover(f.Digest(req), sig)
+	if err != nil || signer != req.From {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (f *Forwarder) domain() eip712.Domain {
+	return eip712.NewDomain(f.name, f.version)
+}
+
+// stamp returns the replay fields of req. Forward requests do not expire,
+// and the domain binds them to this chain and forwarder.
+func (f *Forwarder) stamp(req *ForwardRequest) eip712.Stamp {
+	return f.domain().Stamp(req.From, req.Nonce, math.MaxUint64)
+}
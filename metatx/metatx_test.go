@@ -0,0 +1,152 @@
+package metatx
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+)
+
+var (
+	forwarder = stygos.Address{0xf0}
+	recipient = stygos.Address{0x7e}
+	relayer   = stygos.Address{0x12}
+	userKey   = big.NewInt(0x05e7)
+)
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = forwarder
+	stygos.UseRuntime(mock)
+	ecdsa.InstallMockEcrecover(mock)
+	return mock
+}
+
+// deployRecipient deploys a contract that records Context.MsgSender and
+// MsgData of each call.
+func deployRecipient(mock *stygos.MockRuntime, senders *[]stygos.Address, data *[][]byte) {
+	ctx := NewContext(forwarder)
+	mock.Deploy(recipient, func(input []byte) ([]byte, error) {
+		*senders = append(*senders, ctx.MsgSender())
+		d, _ := ctx.MsgData()
+		*data = append(*data, d)
+		return []byte("ok"), nil
+	})
+}
+
+func TestContext(t *testing.T) {
+	mock := setup()
+	ctx := NewContext(forwarder)
+	user := stygos.Address{0xaa}
+
+	mock.Sender = relayer
+	mock.Args = append([]byte{1, 2, 3}, user[:]...)
+	if got := ctx.MsgSender(); got != relayer {
+		t.Errorf("MsgSender failed. Expected the direct caller, got %x", got)
+	}
+	if got, _ := ctx.MsgData(); len(got) != 23 {
+		t.Errorf("MsgData failed. Expected the full calldata, got %d bytes", len(got))
+	}
+
+	mock.Sender = forwarder
+	if got := ctx.MsgSender(); got != user {
+		t.Errorf("MsgSender failed. Expected the appended address, got %x", got)
+	}
+	if got, _ := ctx.MsgData(); string(got) != "\x01\x02\x03" {
+		t.Errorf("MsgData failed. Expected the calldata without the suffix, got %x", got)
+	}
+	if NewContext(stygos.Address{}).IsTrustedForwarder(stygos.Address{}) {
+		t.Error("IsTrustedForwarder failed. Expected the zero address never to be trusted")
+	}
+}
+
+func TestForwarder(t *testing.T) {
+	mock := setup()
+	var senders []stygos.Address
+	var data [][]byte
+	deployRecipient(mock, &senders, &data)
+
+	f := NewForwarder(stygos.Word{0xf1}, "MinimalForwarder", "0.0.1")
+	user := ecdsa.AddressOf(userKey)
+	req := &ForwardRequest{From: user, To: recipient, Data: []byte("payload")}
+	sig, _ := ecdsa.Sign(userKey, f.Digest(req))
+
+	if !f.Verify(req, sig) {
+		t.Fatal("Verify failed. Expected the signed request to verify")
+	}
+	tampered := *req
+	tampered.Data = []byte("other")
+	if _, err := f.Execute(&tampered, sig); err != ErrInvalidSignature {
+		t.Errorf("Execute failed. Expected ErrInvalidSignature, got %v", err)
+	}
+
+	mock.Sender = relayer
+	ret, err := f.Execute(req, sig)
+	if err != nil || string(ret) != "ok" {
+		t.Fatalf("Execute failed. Expected ok, got %q, %v", ret, err)
+	}
+	if len(senders) != 1 || senders[0] != user {
+		t.Errorf("Execute failed. Expected the recipient to see %x, got %x", user, senders)
+	}
+	if string(data[0]) != "payload" {
+		t.Errorf("Execute failed. Expected the recipient data to be payload, got %q", data[0])
+	}
+	if got := f.Nonce(user); got.Uint64() != 1 {
+		t.Errorf("Nonce failed. Expected 1, got %d", got.Uint64())
+	}
+	if _, err := f.Execute(req, sig); err != ErrInvalidNonce {
+		t.Errorf("Execute failed. Expected ErrInvalidNonce on replay, got %v", err)
+	}
+
+	// Value is forwarded from the relayer's msg.value
+	req = &ForwardRequest{From: user, To: recipient, Value: stygos.NewU256(5), Nonce: stygos.NewU256(1)}
+	sig, _ = ecdsa.Sign(userKey, f.Digest(req))
+	if _, err := f.Execute(req, sig); err != ErrValueMismatch {
+		t.Errorf("Execute failed. Expected ErrValueMismatch, got %v", err)
+	}
+	mock.Value = big.NewInt(5)
+	mock.SetBalance(forwarder, big.NewInt(5))
+	if _, err := f.Execute(req, sig); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if got := mock.BalanceOf(recipient); got.Int64() != 5 {
+		t.Errorf("Execute failed. Expected the recipient to receive 5, got %v", got)
+	}
+}
+
+// A plain call with no data, signed outside this package with
+// eth_signTypedData_v4's encoding
+func TestForwardRequestVector(t *testing.T) {
+	mock := setup()
+	mock.Chain = 1
+	var senders []stygos.Address
+	var data [][]byte
+	deployRecipient(mock, &senders, &data)
+
+	f := NewForwarder(stygos.Word{0xf1}, "MinimalForwarder", "0.0.1")
+	user := ecdsa.AddressOf(userKey)
+	if got := hex.EncodeToString(user[:]); got != "5882eecf2a134f3f447ba3a4f1168b62f0c95d86" {
+		t.Fatalf("AddressOf failed. Expected 5882eecf2a134f3f447ba3a4f1168b62f0c95d86, got %s", got)
+	}
+	req := &ForwardRequest{From: user, To: recipient}
+	structHash, digest := req.StructHash(), f.Digest(req)
+	if got := hex.EncodeToString(structHash[:]); got != "65e44c903ad93399726afafbfe6f683487a89230a3bc4b514e2b0937f3ec4a01" {
+		t.Errorf("StructHash failed. Expected 65e44c903ad93399726afafbfe6f683487a89230a3bc4b514e2b0937f3ec4a01, got %s", got)
+	}
+	if got := hex.EncodeToString(digest[:]); got != "0c19a6e5021bb6da9f89207a4fcba943d48e0a5350f6fdba1b75c9259681e5ed" {
+		t.Errorf("Digest failed. Expected 0c19a6e5021bb6da9f89207a4fcba943d48e0a5350f6fdba1b75c9259681e5ed, got %s", got)
+	}
+
+	raw, _ := hex.DecodeString("d47644539acec3da5e3ecf5fe8863c628a9c97e8b71e9ea9167a6f4f83c03c32" +
+		"7b0e4b661dfae3e4d995912f76b05cc653ebc6edb966dfa4e860cccf1b42fa17" + "1b")
+	sig, _ := ecdsa.SignatureFromBytes(raw)
+	mock.Sender = relayer
+	if _, err := f.Execute(req, sig); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(senders) != 1 || senders[0] != user || len(data[0]) != 0 {
+		t.Errorf("Execute failed. Expected an empty call from %x, got %x, %x", user, senders, data)
+	}
+}
@@ -0,0 +1,96 @@
+package stygos
+
+import "errors"
+
+// ErrNonPayable is returned by NonPayable for calls carrying value.
+var ErrNonPayable = errors.New("function is not payable")
+
+// Middleware wraps the dispatch of calldata, selector included, to run code
+// before and after the handler or to refuse the call, as HTTP middleware
+// wraps a handler. The handler it wraps receives the call's context, with
+// its Selector set. Guards such as pausing or payability then live in one
+// place instead of at the top of every handler.
+type Middleware func(next Handler) Handler
+
+// Use adds middleware around every call the router dispatches, including
+// the fallback, stygosInfo() and each call of a multicall. The first
+// middleware added is the outermost: it runs first and sees the result
+// last.
+func (r *Router) Use(mw ...Middleware) {
+	r.middleware = append(r.middleware, mw...)
+	r.chain = nil
+}
+
+// handler returns dispatch wrapped in the router's middleware.
+func (r *Router) handler() Handler {
+	if r.chain == nil {
+		h := Handler(r.dispatch)
+		for i := len(r.middleware) - 1; i >= 0; i-- {
+			h = r.middleware[i](h)
+		}
+		r.chain = h
+	}
+	return r.chain
+}
+
+// Guard returns middleware that calls check with the call's context before
+// the handler and fails the call with its error, if any:
+//
+//	r.Use(stygos.Except(stygos.Guard(func(*stygos.Ctx) error {
+//		if breaker.Paused() {
+//			return ratelimit.ErrPaused
+//		}
+//		return nil
+//	}), "unpause()"))
+func Guard(check func(ctx *Ctx) error) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Ctx, callData []byte) ([]byte, error) {
+			if err := check(ctx); err != nil {
+				return nil, err
+			}
+			return next(ctx, callData)
+		}
+	}
+}
+
+// NonPayable returns middleware failing calls that carry msg.value with
+// ErrNonPayable, except calls to the payable functions with the given
+// canonical signatures.
+func NonPayable(payable ...string) Middleware {
+	return Except(Guard(func(ctx *Ctx) error {
+		if ctx.Payable() {
+			return ErrNonPayable
+		}
+		return nil
+	}), payable...)
+}
+
+// Only returns mw applied to the functions with the given canonical
+// signatures and skipped for every other call.
+func Only(mw Middleware, signatures ...string) Middleware {
+	return selectWhen(mw, signatures, true)
+}
+
+// Except returns mw applied to every call but those to the functions with
+// the given canonical signatures.
+func Except(mw Middleware, signatures ...string) Middleware {
+	return selectWhen(mw, signatures, false)
+}
+
+// selectWhen applies mw to calls whose selector is among signatures when
+// listed is true, and to the others when it is false.
+func selectWhen(mw Middleware, signatures []string, listed bool) Middleware {
+	selectors := make(map[Selector]bool, len(signatures))
+	for _, s := range signatures {
+		selectors[SelectorOf(s)] = true
+	}
+	return func(next Handler) Handler {
+		wrapped := mw(next)
+		return func(ctx *Ctx, callData []byte) ([]byte, error) {
+			if (len(callData) >= 4 && selectors[ctx.Selector]) == listed {
+				return wrapped(ctx, callData)
+			}
+			return next(ctx, callData)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package stygos
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	var trace []string
+	logging := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx *Ctx, callData []byte) ([]byte, error) {
+				trace = append(trace, name+" before")
+				out, err := next(ctx, callData)
+				trace = append(trace, name+" after")
+				return out, err
+			}
+		}
+	}
+	router := NewRouter()
+	router.Handle("ping()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		trace = append(trace, "ping")
+		return []byte("pong"), nil
+	})
+	router.Use(logging("outer"), logging("inner"))
+
+	sel := SelectorOf("ping()")
+	out, err := router.Dispatch(sel[:])
+	if err != nil || string(out) != "pong" {
+		t.Fatalf("Dispatch(ping) = %q, %v, want pong", out, err)
+	}
+	want := "outer before,inner before,ping,inner after,outer after"
+	if got := strings.Join(trace, ","); got != want {
+		t.Errorf("trace = %s, want %s", got, want)
+	}
+
+	// Middleware sees unknown selectors as well
+	trace = nil
+	if _, err := router.Dispatch([]byte{1, 2, 3, 4}); err != ErrUnknownSelector {
+		t.Errorf("Dispatch(unknown) = %v, want ErrUnknownSelector", err)
+	}
+	if len(trace) != 4 {
+		t.Errorf("trace = %v, want the middleware to run", trace)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	errPaused := errors.New("paused")
+	paused := false
+	router := NewRouter()
+	router.Handle("transfer(address,uint256)", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	router.Handle("unpause()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		paused = false
+		return nil, nil
+	})
+	router.HandleMulticall()
+	router.Use(Except(Guard(func(*Ctx) error {
+		if paused {
+			return errPaused
+		}
+		return nil
+	}), "unpause()", "multicall(bytes[])"))
+
+	transfer := SelectorOf("transfer(address,uint256)")
+	unpause := SelectorOf("unpause()")
+	if _, err := router.Dispatch(transfer[:]); err != nil {
+		t.Errorf("transfer failed: %v", err)
+	}
+	paused = true
+	if _, err := router.Dispatch(transfer[:]); err != errPaused {
+		t.Errorf("transfer while paused = %v, want errPaused", err)
+	}
+	// The calls of a batch are guarded one by one
+	if _, err := router.Dispatch(EncodeMulticall(transfer[:])); err != errPaused {
+		t.Errorf("multicall(transfer) while paused = %v, want errPaused", err)
+	}
+	if _, err := router.Dispatch(unpause[:]); err != nil {
+		t.Errorf("unpause failed: %v", err)
+	}
+	if _, err := router.Dispatch(transfer[:]); err != nil {
+		t.Errorf("transfer after unpause failed: %v", err)
+	}
+}
+
+func TestNonPayable(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	router := NewRouter()
+	router.Handle("deposit()", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	router.Handle("withdraw(uint256)", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	router.Use(NonPayable("deposit()"))
+
+	deposit := SelectorOf("deposit()")
+	withdraw := SelectorOf("withdraw(uint256)")
+	if _, err := router.Dispatch(withdraw[:]); err != nil {
+		t.Errorf("withdraw without value failed: %v", err)
+	}
+	mock.Value = big.NewInt(1)
+	if _, err := router.Dispatch(withdraw[:]); err != ErrNonPayable {
+		t.Errorf("withdraw with value = %v, want ErrNonPayable", err)
+	}
+	if _, err := router.Dispatch(deposit[:]); err != nil {
+		t.Errorf("deposit with value failed: %v", err)
+	}
+	if _, err := router.Dispatch(nil); err != ErrNonPayable {
+		t.Errorf("empty calldata with value = %v, want ErrNonPayable", err)
+	}
+}
+
+func TestOnly(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	errDenied := errors.New("denied")
+	router := NewRouter()
+	router.Handle("upgrade(address)", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	router.Handle("get()", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	router.Use(Only(Guard(func(*Ctx) error { return errDenied }), "upgrade(address)"))
+
+	upgrade := SelectorOf("upgrade(address)")
+	get := SelectorOf("get()")
+	if _, err := router.Dispatch(upgrade[:]); err != errDenied {
+		t.Errorf("upgrade = %v, want errDenied", err)
+	}
+	if _, err := router.Dispatch(get[:]); err != nil {
+		t.Errorf("get failed: %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package stygos
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Coverage records which functions of contracts tests exercise and which
+// ways they revert, so a report can point out untested entrypoints.
+//
+// Calls are recorded from three sources: a Router passed to Router, an
+// entrypoint wrapped with Entrypoint, and every call made through a
+// MockRuntime whose Coverage field is set. Contracts are identified by
+// name; mock calls use the name given to the callee with Name, or its
+// address.
+//
+//	cov := stygos.NewCoverage()
+//	cov.Commands("counter", "get", "increment", "decrement", "reset")
+//	entry := cov.Entrypoint("counter", entrypoint)
+//	// ... run the tests through entry ...
+//	cov.WriteReport(os.Stdout)
+type Coverage struct {
+	mu        sync.Mutex
+	contracts map[string]*ContractCoverage
+	names     map[Address]string
+}
+
+// ContractCoverage is the coverage of one contract.
+type ContractCoverage struct {
+	Name      string
+	Functions map[string]*FunctionCoverage // by function ID
+
+	commands bool // dispatches on the first calldata byte
+	routed   bool // recorded by its Router, not by its callers
+}
+
+// FunctionCoverage is the coverage of one function of a contract.
+type FunctionCoverage struct {
+	ID       string         // "0xa9059cbb" for a selector, "cmd 1" for a command
+	Name     string         // signature or command name, if declared
+	Declared bool           // declared with Selectors or Commands
+	Calls    int            // calls, including reverted ones
+	Reverts  map[string]int // reverted calls by reason
+}
+
+// NewCoverage returns an empty coverage recorder.
+func NewCoverage() *Coverage {
+	return &Coverage{
+		contracts: make(map[string]*ContractCoverage),
+		names:     make(map[Address]string),
+	}
+}
+
+// Selectors declares that contract dispatches on 4-byte selectors and
+// should cover the functions with the given signatures.
+func (c *Coverage) Selectors(contract string, signatures ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cc := c.contract(contract)
+	for _, sig := range signatures {
+		sel := SelectorOf(sig)
+		f := cc.function(selectorID(sel))
+		f.Name, f.Declared = sig, true
+	}
+}
+
+// Commands declares that contract dispatches on the first calldata byte,
+// command i being named commands[i], and should cover all of them. Empty
+// calldata counts as command 0.
+func (c *Coverage) Commands(contract string, commands ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cc := c.contract(contract)
+	cc.commands = true
+	for i, name := range commands {
+		f := cc.function(commandID(byte(i)))
+		f.Name, f.Declared = name, true
+	}
+}
+
+// Name names the contract deployed at addr for calls recorded through
+// MockRuntime.Coverage.
+func (c *Coverage) Name(addr Address, contract string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names[addr] = contract
+}
+
+// Router records every dispatch of r as contract, declaring the selectors
+// registered so far. Revert reasons are the errors of the handlers.
+func (c *Coverage) Router(contract string, r *Router) {
+	c.mu.Lock()
+	cc := c.contract(contract)
+	cc.routed = true
+	for _, sel := range r.selectors {
+		if sel != InfoSelector {
+			cc.function(selectorID(sel)).Declared = true
+		}
+	}
+	c.mu.Unlock()
+
+	r.Observe(func(callData []byte, err error) {
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		}
+		c.record(contract, callData, err != nil, reason)
+	})
+}
+
+// Entrypoint wraps a contract entrypoint so each invocation is recorded as
+// contract. A non-zero status is a revert, its reason the revert data.
+func (c *Coverage) Entrypoint(contract string, entrypoint func() int32) func() int32 {
+	return func() int32 {
+		callData := append([]byte(nil), activeRuntime.Args...)
+		activeRuntime.Result = nil
+		status := entrypoint()
+		c.record(contract, callData, status != 0, revertReason(activeRuntime.Result))
+		return status
+	}
+}
+
+// recordCall records a call through the mock runtime, unless the callee's
+// Router records it.
+func (c *Coverage) recordCall(to Address, input, out []byte, err error) {
+	c.mu.Lock()
+	contract, ok := c.names[to]
+	if !ok {
+		contract = fmt.Sprintf("%x", to)
+	}
+	routed := c.contracts[contract] != nil && c.contracts[contract].routed
+	c.mu.Unlock()
+	if routed {
+		return
+	}
+
+	reason := ""
+	if errors.Is(err, ErrRevert) {
+		reason = revertReason(out)
+	} else if err != nil {
+		reason = err.Error()
+	}
+	c.record(contract, input, err != nil, reason)
+}
+
+func (c *Coverage) record(contract string, callData []byte, reverted bool, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cc := c.contract(contract)
+	var id string
+	switch {
+	case cc.commands && len(callData) == 0:
+		id = commandID(0)
+	case cc.commands:
+		id = commandID(callData[0])
+	case len(callData) < 4:
+		id = "fallback"
+	default:
+		var sel Selector
+		copy(sel[:], callData)
+		id = selectorID(sel)
+	}
+	f := cc.function(id)
+	f.Calls++
+	if reverted {
+		f.Reverts[reason]++
+	}
+}
+
+// Contracts returns the coverage of every contract seen, sorted by name.
+func (c *Coverage) Contracts() []*ContractCoverage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]*ContractCoverage, 0, len(c.contracts))
+	for _, cc := range c.contracts {
+		out = append(out, cc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Uncovered returns the declared functions no test called, as
+// "contract.function".
+func (c *Coverage) Uncovered() []string {
+	var out []string
+	for _, cc := range c.Contracts() {
+		for _, f := range cc.sorted() {
+			if f.Declared && f.Calls == 0 {
+				out = append(out, cc.Name+"."+f.label())
+			}
+		}
+	}
+	return out
+}
+
+// WriteReport writes a text report listing, per contract, how often each
+// function was called and how it reverted, and the functions not called.
+func (c *Coverage) WriteReport(w io.Writer) error {
+	for _, cc := range c.Contracts() {
+		fns := cc.sorted()
+		covered, declared := 0, 0
+		for _, f := range fns {
+			if f.Declared {
+				declared++
+				if f.Calls > 0 {
+					covered++
+				}
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s: %d/%d functions covered\n", cc.Name, covered, declared); err != nil {
+			return err
+		}
+		for _, f := range fns {
+			var err error
+			switch {
+			case f.Calls == 0:
+				_, err = fmt.Fprintf(w, "  %-40s NOT CALLED\n", f.label())
+			case !f.Declared:
+				_, err = fmt.Fprintf(w, "  %-40s %d calls (undeclared)\n", f.label(), f.Calls)
+			default:
+				_, err = fmt.Fprintf(w, "  %-40s %d calls\n", f.label(), f.Calls)
+			}
+			if err != nil {
+				return err
+			}
+			reasons := make([]string, 0, len(f.Reverts))
+			for r := range f.Reverts {
+				reasons = append(reasons, r)
+			}
+			sort.Strings(reasons)
+			for _, r := range reasons {
+				if _, err := fmt.Fprintf(w, "    revert %q: %d\n", r, f.Reverts[r]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// contract returns the coverage of name, creating it. c.mu must be held.
+func (c *Coverage) contract(name string) *ContractCoverage {
+	cc, ok := c.contracts[name]
+	if !ok {
+		cc = &ContractCoverage{Name: name, Functions: make(map[string]*FunctionCoverage)}
+		c.contracts[name] = cc
+	}
+	return cc
+}
+
+func (cc *ContractCoverage) function(id string) *FunctionCoverage {
+	f, ok := cc.Functions[id]
+	if !ok {
+		f = &FunctionCoverage{ID: id, Reverts: make(map[string]int)}
+		cc.Functions[id] = f
+	}
+	return f
+}
+
+// sorted returns the functions of cc ordered by ID.
+func (cc *ContractCoverage) sorted() []*FunctionCoverage {
+	fns := make([]*FunctionCoverage, 0, len(cc.Functions))
+	for _, f := range cc.Functions {
+		fns = append(fns, f)
+	}
+	sort.Slice(fns, func(i, j int) bool { return fns[i].ID < fns[j].ID })
+	return fns
+}
+
+func (f *FunctionCoverage) label() string {
+	if f.Name == "" {
+		return f.ID
+	}
+	return f.Name + " (" + f.ID + ")"
+}
+
+func selectorID(sel Selector) string {
+	return "0x" + hex.EncodeToString(sel[:])
+}
+
+func commandID(cmd byte) string {
+	return fmt.Sprintf("cmd %d", cmd)
+}
+
+// revertReason names a revert by its data: the message of an Error(string)
+// revert, the selector of a custom error, or "no data".
+func revertReason(data []byte) string {
+	switch {
+	case len(data) == 0:
+		return "no data"
+	case len(data) >= 68 && data[0] == 0x08 && data[1] == 0xc3 && data[2] == 0x79 && data[3] == 0xa0:
+		var length Word
+		copy(length[:], data[36:68])
+		n := Uint64FromWord(length)
+		if uint64(len(data)-68) >= n {
+			return string(data[68 : 68+n])
+		}
+	}
+	if len(data) >= 4 {
+		return "error 0x" + hex.EncodeToString(data[:4])
+	}
+	return "0x" + hex.EncodeToString(data)
+}
@@ -0,0 +1,75 @@
+package stygos
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	cov := NewCoverage()
+
+	// A command contract reverting with Error(string) on command 2
+	cov.Commands("counter", "get", "increment", "reset")
+	entry := cov.Entrypoint("counter", func() int32 {
+		if len(mock.Args) > 0 && mock.Args[0] == 2 {
+			var reason Word
+			copy(reason[:], "not owner")
+			b := NewReturnBuilder(0)
+			b.AppendRaw([]byte{0x08, 0xc3, 0x79, 0xa0}).AppendUint64(32).AppendUint64(9).AppendWord(reason)
+			mock.Result = b.Bytes()
+			return 1
+		}
+		return 0
+	})
+	for _, args := range [][]byte{nil, {0}, {2}} {
+		mock.Args = args
+		entry()
+	}
+
+	// A router called through the mock
+	errPaused := errors.New("paused")
+	router := NewRouter()
+	router.Handle("transfer(address,uint256)", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, errPaused })
+	router.Handle("balanceOf(address)", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	token := Address{0x70}
+	cov.Router("token", router)
+	cov.Name(token, "token")
+	mock.Coverage = cov
+	mock.Deploy(token, router.Dispatch)
+	transfer := SelectorOf("transfer(address,uint256)")
+	Call(token, Word{}, transfer[:])
+	Call(token, Word{}, transfer[:])
+
+	uncovered := cov.Uncovered()
+	want := []string{
+		"counter.increment (cmd 1)",
+		"token.0x70a08231",
+	}
+	if strings.Join(uncovered, ",") != strings.Join(want, ",") {
+		t.Errorf("Uncovered() = %q, want %q", uncovered, want)
+	}
+
+	var report bytes.Buffer
+	if err := cov.WriteReport(&report); err != nil {
+		t.Fatalf("WriteReport failed: %v", err)
+	}
+	for _, line := range []string{
+		"counter: 2/3 functions covered",
+		"get (cmd 0)",
+		`revert "not owner": 1`,
+		"token: 1/2 functions covered",
+		`revert "paused": 2`,
+		"NOT CALLED",
+	} {
+		if !strings.Contains(report.String(), line) {
+			t.Errorf("report lacks %q:\n%s", line, report.String())
+		}
+	}
+	if f := cov.Contracts()[0].Functions["cmd 0"]; f.Calls != 2 {
+		t.Errorf("get calls = %d, want 2 counting empty calldata", f.Calls)
+	}
+}
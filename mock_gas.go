@@ -0,0 +1,97 @@
+package stygos
+
+// Gas costs charged by the mock runtime, modelled on the EVM schedule
+// after EIP-2929. Stylus meters WASM execution in ink, which the mock does
+// not, so GasUsed only covers host operations: the storage, log, hashing
+// and call costs that dominate contracts and scale with batch sizes.
+const (
+	MockGasColdSload    = 2100  // first access to a slot
+	MockGasWarmAccess   = 100   // later accesses to a slot or account
+	MockGasSstoreSet    = 20000 // zero to non-zero
+	MockGasSstoreReset  = 2900  // non-zero to another value
+	MockGasColdAccount  = 2600  // first call to an account
+	MockGasCallValue    = 9000  // call with value
+	MockGasLog          = 375   // per log, and per topic
+	MockGasLogData      = 8     // per byte of log data
+	MockGasKeccak       = 30    // per hash
+	MockGasKeccakWord   = 6     // per word hashed
+	MockGasCalldataZero = 4     // per zero calldata byte
+	MockGasCalldataByte = 16    // per non-zero calldata byte
+)
+
+// mockSlot is a storage slot of one contract.
+type mockSlot struct {
+	contract Address
+	key      [32]byte
+}
+
+// ResetGas starts metering a new transaction: GasUsed is cleared, every
+// slot and account is cold again and transient storage is empty.
+func (m *MockRuntime) ResetGas() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.GasUsed = 0
+	m.warmSlots = nil
+	m.warmAccounts = nil
+	m.transient = nil
+}
+
+// CalldataGas returns the intrinsic gas of calldata, which the sender pays
+// on top of execution.
+func CalldataGas(data []byte) uint64 {
+	var gas uint64
+	for _, b := range data {
+		if b == 0 {
+			gas += MockGasCalldataZero
+		} else {
+			gas += MockGasCalldataByte
+		}
+	}
+	return gas
+}
+
+// chargeSlot charges the access to key of the executing contract, cold the
+// first time. The caller holds the lock.
+func (m *MockRuntime) chargeSlot(key [32]byte) {
+	slot := mockSlot{m.Contract, key}
+	if m.warmSlots[slot] {
+		m.GasUsed += MockGasWarmAccess
+		return
+	}
+	if m.warmSlots == nil {
+		m.warmSlots = make(map[mockSlot]bool)
+	}
+	m.warmSlots[slot] = true
+	m.GasUsed += MockGasColdSload
+}
+
+// chargeStore charges writing value over the current value of key, besides
+// the access. The caller holds the lock.
+func (m *MockRuntime) chargeStore(key, value [32]byte) {
+	m.chargeSlot(key)
+	current := m.Storage[key]
+	switch {
+	case current == value:
+	case current == [32]byte{}:
+		m.GasUsed += MockGasSstoreSet
+	default:
+		m.GasUsed += MockGasSstoreReset
+	}
+}
+
+// chargeCall charges a call to addr, with value or not. The caller holds
+// the lock.
+func (m *MockRuntime) chargeCall(addr Address, withValue bool) {
+	if m.warmAccounts[addr] {
+		m.GasUsed += MockGasWarmAccess
+	} else {
+		if m.warmAccounts == nil {
+			m.warmAccounts = make(map[Address]bool)
+		}
+		m.warmAccounts[addr] = true
+		m.GasUsed += MockGasColdAccount
+	}
+	if withValue {
+		m.GasUsed += MockGasCallValue
+	}
+}
@@ -0,0 +1,45 @@
+package stygos
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMockGas(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	key := Word{0x01}
+
+	steps := []struct {
+		name string
+		run  func()
+		gas  uint64
+	}{
+		{"cold load", func() { StorageLoad(key) }, MockGasColdSload},
+		{"warm load", func() { StorageLoad(key) }, MockGasWarmAccess},
+		{"set", func() { StorageStore(key, Word{31: 1}) }, MockGasWarmAccess + MockGasSstoreSet},
+		{"reset", func() { StorageStore(key, Word{31: 2}) }, MockGasWarmAccess + MockGasSstoreReset},
+		{"no-op store", func() { StorageStore(key, Word{31: 2}) }, MockGasWarmAccess},
+		{"keccak", func() { Keccak256(make([]byte, 33)) }, MockGasKeccak + 2*MockGasKeccakWord},
+		{"log", func() { EmitEvent(make([]byte, 32), Word{}, Word{}) }, 3*MockGasLog + 32*MockGasLogData},
+		{"cold call", func() { Call(Address{0xee}, Word{}, nil) }, MockGasColdAccount},
+		{"warm call with value", func() { Call(Address{0xee}, NewU256(1).Word(), nil) }, MockGasWarmAccess + MockGasCallValue},
+	}
+	mock.SetBalance(Address{}, big.NewInt(1))
+	for _, s := range steps {
+		before := mock.GasUsed
+		s.run()
+		if got := mock.GasUsed - before; got != s.gas {
+			t.Errorf("GasUsed for %s failed. Expected %d, got %d", s.name, s.gas, got)
+		}
+	}
+
+	mock.ResetGas()
+	StorageLoad(key)
+	if mock.GasUsed != MockGasColdSload {
+		t.Errorf("ResetGas failed. Expected a cold load, got %d", mock.GasUsed)
+	}
+	if got := CalldataGas([]byte{0, 1, 0, 2}); got != 2*MockGasCalldataZero+2*MockGasCalldataByte {
+		t.Errorf("CalldataGas failed. Expected 40, got %d", got)
+	}
+}
@@ -0,0 +1,234 @@
+package stygos
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// InkTable prices host operations in ink, the unit Stylus meters WASM
+// execution in. Entering a hostio, passing pointers, crossing into the EVM
+// and copying memory across the boundary cost ink directly; the EVM work a
+// hostio does (storage access, logs, hashing, calls) costs its gas, charged
+// as ink at InkPerGas.
+type InkTable struct {
+	ArbOS     uint64 // ArbOS version the table models
+	InkPerGas uint64 // the ink price
+
+	HostIO  uint64 // entering any hostio
+	Pointer uint64 // per pointer argument
+	EVMAPI  uint64 // hostios that cross into the EVM: storage, logs, calls
+
+	ReadBase  uint64 // reading up to 32 bytes of WASM memory
+	ReadByte  uint64 // per byte read beyond 32
+	WriteBase uint64 // writing up to 32 bytes of WASM memory
+	WriteByte uint64 // per byte written beyond 32
+}
+
+// InkArbOS30 is the hostio schedule of Stylus as launched in ArbOS 30,
+// after nitro's user-host pricing.
+var InkArbOS30 = &InkTable{
+	ArbOS:     30,
+	InkPerGas: 10000,
+	HostIO:    8400,
+	Pointer:   5040,
+	EVMAPI:    59673,
+	ReadBase:  16381,
+	ReadByte:  55,
+	WriteBase: 5040,
+	WriteByte: 30,
+}
+
+// InkTables are the ink tables by ArbOS version. The hostio schedule has
+// not changed since ArbOS 30; a chain that repriced it is modelled by
+// copying a table and editing it.
+var InkTables = map[uint64]*InkTable{
+	30: InkArbOS30,
+	31: InkArbOS30,
+	32: InkArbOS30,
+}
+
+// Ink categories of an InkProfile
+const (
+	InkHostIO  = "hostio"  // hostio entry, pointers and EVM API crossings
+	InkMemory  = "memory"  // copying between WASM memory and the host
+	InkStorage = "storage" // storage access gas
+	InkKeccak  = "keccak"  // hashing gas
+	InkLog     = "log"     // log gas
+	InkCall    = "call"    // account access and value transfer gas of calls
+)
+
+// InkProfile is the ink spent by one call to a contract, by category. The
+// ink of the calls it makes is in their own profiles.
+type InkProfile struct {
+	Contract Address
+	Selector []byte // first 4 bytes of the calldata, or fewer
+	Depth    int    // 0 for calls made by the test
+	Ink      map[string]uint64
+}
+
+// Total returns the ink of all categories.
+func (p *InkProfile) Total() uint64 {
+	var total uint64
+	for _, ink := range p.Ink {
+		total += ink
+	}
+	return total
+}
+
+// SetInkTable makes the runtime meter ink with t, such as InkTables[30]
+// or a custom table. Every call made through the runtime gets an
+// InkProfile. Host operations run outside such a call, by the test itself
+// or an entrypoint it invokes directly, are profiled under a top-level
+// entry for the executing contract. A nil table stops metering.
+func (m *MockRuntime) SetInkTable(t *InkTable) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inkTable = t
+}
+
+// InkUsed returns the ink charged since the table was set or ResetInk.
+func (m *MockRuntime) InkUsed() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.inkUsed
+}
+
+// InkProfiles returns the profiles of the calls since the table was set or
+// ResetInk, in the order the calls started.
+func (m *MockRuntime) InkProfiles() []*InkProfile {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*InkProfile(nil), m.inkProfiles...)
+}
+
+// ResetInk clears the ink used and the profiles.
+func (m *MockRuntime) ResetInk() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inkUsed = 0
+	m.inkProfiles = nil
+	m.inkRoot = nil
+}
+
+// WriteInkProfile prints each call's ink by category, indented by depth,
+// with the total.
+func (m *MockRuntime) WriteInkProfile(w io.Writer) error {
+	profiles := m.InkProfiles()
+	var total uint64
+	for _, p := range profiles {
+		cats := make([]string, 0, len(p.Ink))
+		for c := range p.Ink {
+			cats = append(cats, c)
+		}
+		sort.Strings(cats)
+		indent := fmt.Sprintf("%*s", 2*p.Depth, "")
+		if _, err := fmt.Fprintf(w, "%s%s %x  %d ink\n", indent, p.Contract.Hex(), p.Selector, p.Total()); err != nil {
+			return err
+		}
+		for _, c := range cats {
+			if _, err := fmt.Fprintf(w, "%s  %-8s %12d\n", indent, c, p.Ink[c]); err != nil {
+				return err
+			}
+		}
+		total += p.Total()
+	}
+	_, err := fmt.Fprintf(w, "total %d ink (%d gas)\n", total, m.inkGas(total))
+	return err
+}
+
+// inkGas converts ink to gas at the table's price.
+func (m *MockRuntime) inkGas(ink uint64) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.inkTable == nil || m.inkTable.InkPerGas == 0 {
+		return 0
+	}
+	return ink / m.inkTable.InkPerGas
+}
+
+// chargeInk charges a hostio: its entry with ptrs pointer arguments and,
+// if evm, the EVM API crossing; read and written bytes of WASM memory; and
+// gas of EVM work under category. Nothing is charged without a table. The
+// caller holds the lock.
+func (m *MockRuntime) chargeInk(category string, ptrs int, evm bool, read, written int, gas uint64) {
+	t := m.inkTable
+	if t == nil {
+		return
+	}
+	p := m.inkProfile()
+	add := func(c string, ink uint64) {
+		if ink == 0 {
+			return
+		}
+		p.Ink[c] += ink
+		m.inkUsed += ink
+	}
+
+	entry := t.HostIO + uint64(ptrs)*t.Pointer
+	if evm {
+		entry += t.EVMAPI
+	}
+	add(InkHostIO, entry)
+
+	var memory uint64
+	if read > 0 {
+		memory += t.ReadBase + t.ReadByte*beyondWord(read)
+	}
+	if written > 0 {
+		memory += t.WriteBase + t.WriteByte*beyondWord(written)
+	}
+	add(InkMemory, memory)
+	add(category, gas*t.InkPerGas)
+}
+
+// inkProfile returns the profile charges go to: the innermost call's, or
+// the top-level one. The caller holds the lock.
+func (m *MockRuntime) inkProfile() *InkProfile {
+	if n := len(m.inkStack); n > 0 {
+		return m.inkStack[n-1]
+	}
+	if m.inkRoot == nil || m.inkRoot.Contract != m.Contract {
+		m.inkRoot = m.newInkProfile(m.Contract, m.Args, 0)
+	}
+	return m.inkRoot
+}
+
+// enterInk starts the profile of a call to contract and reports whether
+// it did, when metering. The caller holds the lock.
+func (m *MockRuntime) enterInk(contract Address, input []byte) bool {
+	if m.inkTable == nil {
+		return false
+	}
+	m.inkStack = append(m.inkStack, m.newInkProfile(contract, input, len(m.inkStack)))
+	return true
+}
+
+// exitInk ends the innermost call's profile. The caller holds the lock.
+func (m *MockRuntime) exitInk() {
+	if n := len(m.inkStack); n > 0 {
+		m.inkStack = m.inkStack[:n-1]
+	}
+}
+
+func (m *MockRuntime) newInkProfile(contract Address, input []byte, depth int) *InkProfile {
+	if len(input) > 4 {
+		input = input[:4]
+	}
+	p := &InkProfile{
+		Contract: contract,
+		Selector: append([]byte(nil), input...),
+		Depth:    depth,
+		Ink:      make(map[string]uint64),
+	}
+	m.inkProfiles = append(m.inkProfiles, p)
+	return p
+}
+
+// beyondWord returns how many of n bytes lie beyond the first 32.
+func beyondWord(n int) uint64 {
+	if n <= 32 {
+		return 0
+	}
+	return uint64(n - 32)
+}
@@ -0,0 +1,92 @@
+package stygos
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestInkProfile(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	inner, outer := Address{0x01}, Address{0x02}
+	mock.Deploy(inner, func(input []byte) ([]byte, error) {
+		StorageLoad(Word{1})
+		return nil, nil
+	})
+	mock.Deploy(outer, func(input []byte) ([]byte, error) {
+		Keccak256(make([]byte, 64))
+		EmitEvent(nil, Word{})
+		return Call(inner, Word{}, []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee})
+	})
+
+	// Nothing is metered without a table
+	Call(outer, Word{}, nil)
+	if mock.InkUsed() != 0 || len(mock.InkProfiles()) != 0 {
+		t.Fatalf("InkUsed = %d without a table, want 0", mock.InkUsed())
+	}
+
+	ink := InkTables[32]
+	mock.SetInkTable(ink)
+	mock.ResetGas()
+	if _, err := Call(outer, Word{}, []byte{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	// The test's own call is charged at the top level
+	profiles := mock.InkProfiles()
+	if len(profiles) != 3 {
+		t.Fatalf("len(InkProfiles()) = %d, want 3", len(profiles))
+	}
+	top, o, i := profiles[0], profiles[1], profiles[2]
+	if top.Contract != (Address{}) || top.Ink[InkCall] == 0 {
+		t.Errorf("top-level profile = %+v, want the test's call", top)
+	}
+	if o.Contract != outer || o.Depth != 0 || !bytes.Equal(o.Selector, []byte{1, 2, 3, 4}) {
+		t.Errorf("outer profile = %+v", o)
+	}
+	if i.Contract != inner || i.Depth != 1 || !bytes.Equal(i.Selector, []byte{0xaa, 0xbb, 0xcc, 0xdd}) {
+		t.Errorf("inner profile = %+v", i)
+	}
+
+	// The load's ink is its hostio, its memory and its gas
+	want := map[string]uint64{
+		InkHostIO:  ink.HostIO + 2*ink.Pointer + ink.EVMAPI,
+		InkMemory:  ink.ReadBase + ink.WriteBase,
+		InkStorage: MockGasColdSload * ink.InkPerGas,
+	}
+	for c, v := range want {
+		if i.Ink[c] != v {
+			t.Errorf("inner Ink[%s] = %d, want %d", c, i.Ink[c], v)
+		}
+	}
+	if len(i.Ink) != len(want) {
+		t.Errorf("inner Ink = %v, want only %v", i.Ink, want)
+	}
+	if got := o.Ink[InkKeccak]; got != (MockGasKeccak+2*MockGasKeccakWord)*ink.InkPerGas {
+		t.Errorf("outer Ink[keccak] = %d", got)
+	}
+	if o.Ink[InkLog] == 0 || o.Ink[InkCall] == 0 {
+		t.Errorf("outer Ink = %v, want log and call ink", o.Ink)
+	}
+	if total := top.Total() + o.Total() + i.Total(); mock.InkUsed() != total {
+		t.Errorf("InkUsed = %d, want the profiles' %d", mock.InkUsed(), total)
+	}
+
+	var out strings.Builder
+	if err := mock.WriteInkProfile(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{outer.Hex() + " 01020304", "  " + inner.Hex() + " aabbccdd", "storage", "total "} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("WriteInkProfile lacks %q:\n%s", s, out.String())
+		}
+	}
+
+	// Host operations outside calls are profiled at the top level
+	mock.ResetInk()
+	StorageLoad(Word{2})
+	StorageLoad(Word{3})
+	if p := mock.InkProfiles(); len(p) != 1 || p[0].Ink[InkStorage] != 2*MockGasColdSload*ink.InkPerGas {
+		t.Errorf("InkProfiles() = %v, want one top-level profile", p)
+	}
+}
@@ -0,0 +1,50 @@
+package stygos
+
+// Log is an event emitted on a MockRuntime, as delivered to SubscribeLogs.
+type Log struct {
+	Address Address // contract that emitted it
+	Topics  []Word
+	Data    []byte
+}
+
+// logSubscription is a channel registered with SubscribeLogs.
+type logSubscription struct {
+	ch chan<- Log
+}
+
+// SubscribeLogs delivers every log emitted from now on to ch, decoded and
+// tagged with the emitting contract, as the contract emits it. Invariant
+// checkers and simulation drivers can so react to events without polling
+// Logs, which keeps recording them too.
+//
+// Delivery is synchronous: the emitting contract waits until ch accepts the
+// log. Use a buffered channel when the test reads it from the same
+// goroutine, or receive from another one. Logs of calls that later revert
+// are delivered too, although they are dropped from Logs.
+//
+// The returned function cancels the subscription; it does not close ch.
+func (m *MockRuntime) SubscribeLogs(ch chan<- Log) (cancel func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sub := &logSubscription{ch}
+	m.logSubs = append(m.logSubs, sub)
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for i, s := range m.logSubs {
+			if s == sub {
+				m.logSubs = append(m.logSubs[:i:i], m.logSubs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// publishLog sends l to the subscribers registered when it was emitted. It
+// must be called without holding m.mu, so subscribers may use the runtime.
+func publishLog(subs []*logSubscription, l Log) {
+	for _, s := range subs {
+		s.ch <- l
+	}
+}
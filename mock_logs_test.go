@@ -0,0 +1,61 @@
+package stygos
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSubscribeLogs(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	token := Address{0x70}
+	transfer := Keccak256([]byte("Transfer(address,address,uint256)"))
+	mock.Deploy(token, func(input []byte) ([]byte, error) {
+		EmitEvent(input, transfer, PadAddress(GetMsgSender()))
+		return nil, nil
+	})
+
+	logs := make(chan Log, 4)
+	cancel := mock.SubscribeLogs(logs)
+	if _, err := Call(token, Word{}, []byte{1, 2}); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+	select {
+	case l := <-logs:
+		if l.Address != token || len(l.Topics) != 2 || l.Topics[0] != transfer || !bytes.Equal(l.Data, []byte{1, 2}) {
+			t.Errorf("log = %+v, want Transfer from the token", l)
+		}
+	default:
+		t.Fatalf("no log delivered")
+	}
+	if len(mock.Logs) != 1 {
+		t.Errorf("len(Logs) = %d, want the log recorded too", len(mock.Logs))
+	}
+
+	// An unbuffered channel drives a reader in another goroutine, which
+	// sees each log before the emitting call returns
+	seen := make(chan Log)
+	stop := mock.SubscribeLogs(seen)
+	done := make(chan int)
+	go func() {
+		n := 0
+		for range seen {
+			n++
+		}
+		done <- n
+	}()
+	for i := 0; i < 3; i++ {
+		Call(token, Word{}, nil)
+	}
+	stop()
+	close(seen)
+	if n := <-done; n != 3 {
+		t.Errorf("reader saw %d logs, want 3", n)
+	}
+
+	cancel()
+	Call(token, Word{}, nil)
+	if len(logs) != 3 {
+		t.Errorf("len(logs) = %d after cancel, want the 3 logs before it", len(logs))
+	}
+}
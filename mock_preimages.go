@@ -0,0 +1,150 @@
+package stygos
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxSlotOffset is the largest distance from a hash ExplainSlot attributes
+// to an array element or struct field at keccak(...) + n.
+const maxSlotOffset = 1 << 16
+
+// maxExplainDepth bounds the nesting of keccak(...) in an explanation.
+const maxExplainDepth = 8
+
+// RecordPreimages makes the runtime remember the input of every Keccak256
+// computed from now on, so ExplainSlot can tell how a storage key was
+// derived. Recording keeps every input, so it is off by default.
+func (m *MockRuntime) RecordPreimages() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.preimages == nil {
+		m.preimages = make(map[Word][]byte)
+	}
+}
+
+// NameWord makes ExplainSlot print name for w, such as the name of a
+// mapping prefix or a slot constant. A storage.Layout's entries give the
+// names of a contract's declared keys.
+func (m *MockRuntime) NameWord(w Word, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.wordNames == nil {
+		m.wordNames = make(map[Word]string)
+	}
+	m.wordNames[w] = name
+}
+
+// ExplainSlot returns how key was derived, from the preimages recorded
+// since RecordPreimages and the names given to NameWord:
+//
+//	keccak(balancePrefix ++ 0x00000000000000000000000000000000000000ab)
+//	keccak(keccak(allowancePrefix ++ 0x...) ++ 0x...)
+//	keccak(queuePrefix) + 3
+//
+// Words within a preimage are explained the same way; small numbers are
+// printed in decimal, addresses as 20 bytes and other data in hex. A key
+// the runtime knows nothing about is printed as a word.
+func (m *MockRuntime) ExplainSlot(key Word) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.explainWord(key, maxExplainDepth)
+}
+
+// explainWord explains w, nesting at most depth hashes. The caller holds
+// the lock.
+func (m *MockRuntime) explainWord(w Word, depth int) string {
+	if name, ok := m.wordNames[w]; ok {
+		return name
+	}
+	if depth > 0 {
+		if p, ok := m.preimages[w]; ok {
+			return m.explainPreimage(p, depth-1)
+		}
+		if base, n, ok := m.offsetOf(w); ok {
+			return m.explainPreimage(m.preimages[base], depth-1) + " + " + strconv.FormatUint(n, 10)
+		}
+	}
+
+	v := new(big.Int).SetBytes(w[:])
+	switch {
+	case v.BitLen() <= 32:
+		return v.String()
+	case isZero(w[:12]):
+		return "0x" + hex.EncodeToString(w[12:])
+	}
+	return w.Hex()
+}
+
+// explainPreimage renders keccak(p): printable text quoted, otherwise the
+// leading whole words explained and the remaining bytes in hex.
+func (m *MockRuntime) explainPreimage(p []byte, depth int) string {
+	if printable(p) {
+		return "keccak(" + strconv.Quote(string(p)) + ")"
+	}
+	var parts []string
+	for len(p) >= 32 {
+		var w Word
+		copy(w[:], p)
+		parts = append(parts, m.explainWord(w, depth))
+		p = p[32:]
+	}
+	if len(p) > 0 {
+		parts = append(parts, "0x"+hex.EncodeToString(p))
+	}
+	return "keccak(" + strings.Join(parts, " ++ ") + ")"
+}
+
+// offsetOf finds a recorded hash that w is at most maxSlotOffset above.
+// The caller holds the lock.
+func (m *MockRuntime) offsetOf(w Word) (Word, uint64, bool) {
+	key := new(big.Int).SetBytes(w[:])
+	var best Word
+	var bestOffset uint64 = maxSlotOffset + 1
+	for h := range m.preimages {
+		d := new(big.Int).Sub(key, new(big.Int).SetBytes(h[:]))
+		if d.Sign() <= 0 || d.BitLen() > 17 {
+			continue
+		}
+		if n := d.Uint64(); n < bestOffset {
+			best, bestOffset = h, n
+		}
+	}
+	return best, bestOffset, bestOffset <= maxSlotOffset
+}
+
+// recordPreimage remembers the input of a hash if recording. The caller
+// holds the lock.
+func (m *MockRuntime) recordPreimage(hash Word, data []byte) {
+	if m.preimages == nil {
+		return
+	}
+	if _, ok := m.preimages[hash]; !ok {
+		m.preimages[hash] = append([]byte(nil), data...)
+	}
+}
+
+// printable reports whether p is non-empty ASCII text, such as an event
+// signature or a namespace id.
+func printable(p []byte) bool {
+	if len(p) == 0 {
+		return false
+	}
+	for _, c := range p {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
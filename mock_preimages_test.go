@@ -0,0 +1,65 @@
+package stygos
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainSlot(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	// Nothing is recorded before RecordPreimages
+	before := Keccak256([]byte{1, 2, 3})
+	if got := mock.ExplainSlot(before); got != before.Hex() {
+		t.Errorf("ExplainSlot(unrecorded) = %s, want the word", got)
+	}
+
+	mock.RecordPreimages()
+	balancePrefix := Keccak256([]byte("balances"))
+	mock.NameWord(balancePrefix, "balancePrefix")
+	holder := Address{19: 0xab}
+	balance := Keccak256(append(balancePrefix[:], holder[:]...))
+	want := "keccak(balancePrefix ++ 0x00000000000000000000000000000000000000ab)"
+	if got := mock.ExplainSlot(balance); got != want {
+		t.Errorf("ExplainSlot(balance) = %s, want %s", got, want)
+	}
+
+	// Nested mappings, padded keys and offsets from a hash
+	spender := PadAddress(Address{0xcd, 19: 1})
+	allowance := Keccak256(append(balance[:], spender[:]...))
+	want = "keccak(" + want + " ++ 0xcd00000000000000000000000000000000000001)"
+	if got := mock.ExplainSlot(allowance); got != want {
+		t.Errorf("ExplainSlot(allowance) = %s, want %s", got, want)
+	}
+	slot := WordFromUint64(5)
+	data := Keccak256(slot[:])
+	if got := mock.ExplainSlot(data.Add(WordFromUint64(3))); got != "keccak(5) + 3" {
+		t.Errorf("ExplainSlot(element) = %s, want keccak(5) + 3", got)
+	}
+
+	// Text preimages are quoted, and hashes computed by a callee count too
+	mock.Deploy(Address{0x70}, func(input []byte) ([]byte, error) {
+		h := Keccak256([]byte("stygos.example"))
+		return h[:], nil
+	})
+	ret, err := Call(Address{0x70}, Word{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ns Word
+	copy(ns[:], ret)
+	if got := mock.ExplainSlot(ns); got != `keccak("stygos.example")` {
+		t.Errorf("ExplainSlot(namespace) = %s, want the quoted id", got)
+	}
+
+	if got := mock.ExplainSlot(WordFromUint64(7)); got != "7" {
+		t.Errorf("ExplainSlot(7) = %s, want 7", got)
+	}
+	if got := mock.ExplainSlot(balancePrefix); got != "balancePrefix" {
+		t.Errorf("ExplainSlot(prefix) = %s, want its name", got)
+	}
+	if got := mock.ExplainSlot(Keccak256([]byte{9})); !strings.HasPrefix(got, "keccak(0x09") {
+		t.Errorf("ExplainSlot(short) = %s, want the bytes in hex", got)
+	}
+}
@@ -0,0 +1,294 @@
+// Package mpt verifies Merkle-Patricia trie proofs, as returned by
+// eth_getProof, against a state or storage root.
+//
+// This lets a contract read the state of another chain (for example an L1
+// storage value on Arbitrum) trusting only a block's state root.
+package mpt
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/rlp"
+)
+
+// Proof errors
+var (
+	ErrEmptyProof   = errors.New("mpt: empty proof")
+	ErrBadNodeHash  = errors.New("mpt: node hash mismatch")
+	ErrBadNode      = errors.New("mpt: malformed node")
+	ErrIncomplete   = errors.New("mpt: proof ends before key is resolved")
+	ErrExtraNodes   = errors.New("mpt: unused proof nodes")
+	ErrBadAccount   = errors.New("mpt: malformed account")
+	ErrValueTooLong = errors.New("mpt: storage value exceeds 32 bytes")
+)
+
+// EmptyRoot is the root of an empty trie, keccak256(rlp("")).
+var EmptyRoot = stygos.Word{
+	0x56, 0xe8, 0x1f, 0x17, 0x1b, 0xcc, 0x55, 0xa6,
+	0xff, 0x83, 0x45, 0xe6, 0x92, 0xc0, 0xf8, 0x6e,
+	0x5b, 0x48, 0xe0, 0x1b, 0x99, 0x6c, 0xad, 0xc0,
+	0x01, 0x62, 0x2f, 0xb5, 0xe3, 0x63, 0xb4, 0x21,
+}
+
+// EmptyCodeHash is keccak256 of empty code, held by accounts without code.
+var EmptyCodeHash = stygos.Word{
+	0xc5, 0xd2, 0x46, 0x01, 0x86, 0xf7, 0x23, 0x3c,
+	0x92, 0x7e, 0x7d, 0xb2, 0xdc, 0xc7, 0x03, 0xc0,
+	0xe5, 0x00, 0xb6, 0x53, 0xca, 0x82, 0x27, 0x3b,
+	0x7b, 0xfa, 0xd8, 0x04, 0x5d, 0x85, 0xa4, 0x70,
+}
+
+// Account is the state of an account as stored in the state trie.
+type Account struct {
+	Nonce       uint64
+	Balance     stygos.U256
+	StorageRoot stygos.Word
+	CodeHash    stygos.Word
+}
+
+// VerifyProof walks proof from root along key and returns the value stored
+// there. A valid proof of absence returns a nil value and no error.
+//
+// The proof is the list of RLP encoded nodes from the root down, in the
+// order eth_getProof returns them. Key is the trie path, which for the
+// secure tries used by Ethereum is the keccak256 of the address or slot.
+func VerifyProof(root stygos.Word, key []byte, proof [][]byte) ([]byte, error) {
+	if len(proof) == 0 {
+		if root == EmptyRoot {
+			return nil, nil
+		}
+		return nil, ErrEmptyProof
+	}
+
+	path := toNibbles(key)
+	want := root
+	for i := 0; i < len(proof); i++ {
+		node := proof[i]
+		if stygos.Keccak256(node) != want {
+			return nil, ErrBadNodeHash
+		}
+
+		// Nodes shorter than 32 bytes are embedded in their parent rather
+		// than referenced by hash, so resolve them without consuming
+		// another proof element.
+		for {
+			val, next, embedded, rest, err := step(node, path)
+			if err != nil {
+				return nil, err
+			}
+			if next == nil {
+				if i != len(proof)-1 {
+					return nil, ErrExtraNodes
+				}
+				return val, nil
+			}
+			path = rest
+			if embedded {
+				node = next
+				continue
+			}
+			copy(want[:], next)
+			break
+		}
+	}
+	return nil, ErrIncomplete
+}
+
+// step descends one level into node following path. It returns either the
+// value found (next == nil) or the reference to the child node and the
+// remaining path. A missing child yields a nil value and nil next.
+func step(node, path []byte) (val, next []byte, embedded bool, rest []byte, err error) {
+	elems, err := rlp.ListElems(node)
+	if err != nil {
+		return nil, nil, false, nil, ErrBadNode
+	}
+
+	switch len(elems) {
+	case 17:
+		if len(path) == 0 {
+			val, err := rlp.Bytes(elems[16])
+			if err != nil {
+				return nil, nil, false, nil, ErrBadNode
+			}
+			return nilIfEmpty(val), nil, false, nil, nil
+		}
+		ref, embedded, err := childRef(elems[path[0]])
+		if err != nil || ref == nil {
+			return nil, nil, false, nil, err
+		}
+		return nil, ref, embedded, path[1:], nil
+
+	case 2:
+		enc, err := rlp.Bytes(elems[0])
+		if err != nil || len(enc) == 0 {
+			return nil, nil, false, nil, ErrBadNode
+		}
+		partial, leaf, err := decodeCompact(enc)
+		if err != nil {
+			return nil, nil, false, nil, err
+		}
+		if len(path) < len(partial) || !bytes.Equal(path[:len(partial)], partial) {
+			return nil, nil, false, nil, nil // key diverges: proof of absence
+		}
+		rest := path[len(partial):]
+		if leaf {
+			if len(rest) != 0 {
+				return nil, nil, false, nil, nil
+			}
+			val, err := rlp.Bytes(elems[1])
+			if err != nil {
+				return nil, nil, false, nil, ErrBadNode
+			}
+			return val, nil, false, nil, nil
+		}
+		ref, embedded, err := childRef(elems[1])
+		if err != nil {
+			return nil, nil, false, nil, err
+		}
+		if ref == nil {
+			return nil, nil, false, nil, ErrBadNode
+		}
+		return nil, ref, embedded, rest, nil
+	}
+	return nil, nil, false, nil, ErrBadNode
+}
+
+// childRef returns a child reference: a 32-byte hash, the raw encoding of
+// an embedded node (embedded is true), or nil for an empty slot.
+func childRef(elem []byte) (ref []byte, embedded bool, err error) {
+	k, content, _, err := rlp.Split(elem)
+	if err != nil {
+		return nil, false, ErrBadNode
+	}
+	switch {
+	case k == rlp.List:
+		return elem, true, nil
+	case len(content) == 0:
+		return nil, false, nil
+	case len(content) == 32:
+		return content, false, nil
+	}
+	return nil, false, ErrBadNode
+}
+
+// decodeCompact decodes a hex-prefix encoded path into nibbles and reports
+// whether it terminates a leaf.
+func decodeCompact(enc []byte) (nibbles []byte, leaf bool, err error) {
+	flag := enc[0] >> 4
+	if flag > 3 {
+		return nil, false, ErrBadNode
+	}
+	leaf = flag >= 2
+	all := toNibbles(enc)
+	if flag&1 == 1 {
+		return all[1:], leaf, nil
+	}
+	return all[2:], leaf, nil
+}
+
+// toNibbles splits each byte of b into its high and low nibble.
+func toNibbles(b []byte) []byte {
+	n := make([]byte, 2*len(b))
+	for i, c := range b {
+		n[2*i] = c >> 4
+		n[2*i+1] = c & 0x0f
+	}
+	return n
+}
+
+// rightAlign returns the big-endian integer b as a word.
+func rightAlign(b []byte) stygos.Word {
+	var w stygos.Word
+	copy(w[32-len(b):], b)
+	return w
+}
+
+func nilIfEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// VerifyAccountProof verifies an account proof against a state root. The
+// second result is false if the proof shows the account does not exist.
+func VerifyAccountProof(stateRoot stygos.Word, addr stygos.Address, proof [][]byte) (Account, bool, error) {
+	key := stygos.Keccak256(addr[:])
+	val, err := VerifyProof(stateRoot, key[:], proof)
+	if err != nil || val == nil {
+		return Account{}, false, err
+	}
+	acct, err := DecodeAccount(val)
+	if err != nil {
+		return Account{}, false, err
+	}
+	return acct, true, nil
+}
+
+// VerifyStorageProof verifies a storage proof against an account's storage
+// root and returns the slot value. Absent slots read as zero, matching
+// SLOAD.
+func VerifyStorageProof(storageRoot, slot stygos.Word, proof [][]byte) (stygos.Word, error) {
+	key := stygos.Keccak256(slot[:])
+	val, err := VerifyProof(storageRoot, key[:], proof)
+	if err != nil || val == nil {
+		return stygos.Word{}, err
+	}
+	content, err := rlp.Bytes(val)
+	if err != nil {
+		return stygos.Word{}, ErrBadNode
+	}
+	if len(content) > 32 {
+		return stygos.Word{}, ErrValueTooLong
+	}
+	return rightAlign(content), nil
+}
+
+// DecodeAccount decodes the RLP list [nonce, balance, storageRoot, codeHash].
+func DecodeAccount(enc []byte) (Account, error) {
+	elems, err := rlp.ListElems(enc)
+	if err != nil || len(elems) != 4 {
+		return Account{}, ErrBadAccount
+	}
+
+	var acct Account
+	if acct.Nonce, err = rlp.Uint64(elems[0]); err != nil {
+		return Account{}, ErrBadAccount
+	}
+	balance, err := rlp.Bytes(elems[1])
+	if err != nil || len(balance) > 32 {
+		return Account{}, ErrBadAccount
+	}
+	acct.Balance = stygos.U256FromWord(rightAlign(balance))
+
+	root, err := rlp.Bytes(elems[2])
+	if err != nil || len(root) != 32 {
+		return Account{}, ErrBadAccount
+	}
+	copy(acct.StorageRoot[:], root)
+
+	code, err := rlp.Bytes(elems[3])
+	if err != nil || len(code) != 32 {
+		return Account{}, ErrBadAccount
+	}
+	copy(acct.CodeHash[:], code)
+	return acct, nil
+}
+
+// EncodeAccount returns the RLP encoding of acct as stored in the state
+// trie.
+func EncodeAccount(acct Account) []byte {
+	balance := acct.Balance.Word()
+	i := 0
+	for i < 32 && balance[i] == 0 {
+		i++
+	}
+	return rlp.EncodeList(
+		rlp.AppendUint(nil, acct.Nonce),
+		rlp.EncodeString(balance[i:]),
+		rlp.EncodeString(acct.StorageRoot[:]),
+		rlp.EncodeString(acct.CodeHash[:]),
+	)
+}
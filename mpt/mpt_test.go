@@ -0,0 +1,259 @@
+package mpt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/rlp"
+)
+
+// entry is a key/value pair of a test trie, with the key as nibbles.
+type entry struct {
+	path []byte
+	val  []byte
+}
+
+// buildTrie encodes the trie holding entries below depth and appends the
+// hash-referenced nodes on the way to target to proof, root first.
+func buildTrie(entries []entry, depth int, target []byte, root bool, proof *[][]byte) []byte {
+	onPath := bytes.HasPrefix(target, entries[0].path[:depth])
+	idx := len(*proof)
+
+	var node []byte
+	if len(entries) == 1 {
+		node = rlp.EncodeList(
+			rlp.EncodeString(compact(entries[0].path[depth:], true)),
+			rlp.EncodeString(entries[0].val),
+		)
+	} else if n := commonPrefix(entries, depth); n > 0 {
+		child := buildTrie(entries, depth+n, target, false, proof)
+		node = rlp.EncodeList(
+			rlp.EncodeString(compact(entries[0].path[depth:depth+n], false)),
+			reference(child),
+		)
+	} else {
+		elems := make([][]byte, 17)
+		for nib := byte(0); nib < 16; nib++ {
+			var group []entry
+			for _, e := range entries {
+				if e.path[depth] == nib {
+					group = append(group, e)
+				}
+			}
+			elems[nib] = rlp.EncodeString(nil)
+			if len(group) > 0 {
+				elems[nib] = reference(buildTrie(group, depth+1, target, false, proof))
+			}
+		}
+		elems[16] = rlp.EncodeString(nil)
+		node = rlp.EncodeList(elems...)
+	}
+
+	if onPath && (root || len(node) >= 32) {
+		*proof = append((*proof)[:idx], append([][]byte{node}, (*proof)[idx:]...)...)
+	}
+	return node
+}
+
+func commonPrefix(entries []entry, depth int) int {
+	n := 0
+	for {
+		p := depth + n
+		if p >= len(entries[0].path) {
+			return n
+		}
+		for _, e := range entries[1:] {
+			if e.path[p] != entries[0].path[p] {
+				return n
+			}
+		}
+		n++
+	}
+}
+
+func reference(node []byte) []byte {
+	if len(node) < 32 {
+		return node
+	}
+	h := stygos.Keccak256(node)
+	return rlp.EncodeString(h[:])
+}
+
+func compact(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		nibbles = append([]byte{flag + 1}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+	out := make([]byte, len(nibbles)/2)
+	for i := range out {
+		out[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return out
+}
+
+// makeTrie returns the root of a trie over the given keys and a proof for
+// target.
+func makeTrie(keys, vals [][]byte, target []byte) (stygos.Word, [][]byte) {
+	entries := make([]entry, len(keys))
+	for i := range keys {
+		entries[i] = entry{toNibbles(keys[i]), vals[i]}
+	}
+	var proof [][]byte
+	root := buildTrie(entries, 0, toNibbles(target), true, &proof)
+	return stygos.Keccak256(root), proof
+}
+
+func TestEmptyConstants(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	if got := stygos.Keccak256(rlp.EncodeString(nil)); got != EmptyRoot {
+		t.Errorf("EmptyRoot failed. Expected %x, got %x", got, EmptyRoot)
+	}
+	if val, err := VerifyProof(EmptyRoot, []byte{1}, nil); err != nil || val != nil {
+		t.Errorf("VerifyProof failed for empty trie: %x, %v", val, err)
+	}
+}
+
+func TestVerifyProofShortKeys(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	// Short keys and values keep nodes under 32 bytes, so the proofs
+	// exercise embedded nodes as well as hashed ones.
+	keys := [][]byte{{0x12, 0x34}, {0x12, 0x35}, {0x56, 0x78}, {0x12, 0xf0}, {0xab, 0xcd}}
+	vals := [][]byte{[]byte("a"), []byte("b"), []byte("c"), bytes.Repeat([]byte("d"), 40), []byte("e")}
+
+	for i, key := range keys {
+		root, proof := makeTrie(keys, vals, key)
+		val, err := VerifyProof(root, key, proof)
+		if err != nil {
+			t.Errorf("VerifyProof failed for key %x: %v", key, err)
+			continue
+		}
+		if !bytes.Equal(val, vals[i]) {
+			t.Errorf("VerifyProof failed for key %x. Expected %q, got %q", key, vals[i], val)
+		}
+	}
+
+	for _, missing := range [][]byte{{0x12, 0x36}, {0x99, 0x99}, {0xab, 0xce}} {
+		root, proof := makeTrie(keys, vals, missing)
+		val, err := VerifyProof(root, missing, proof)
+		if err != nil || val != nil {
+			t.Errorf("VerifyProof failed for absent key %x. Expected nil, got %q, %v", missing, val, err)
+		}
+	}
+}
+
+func TestAccountAndStorageProof(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	// Storage trie of the account: slot -> rlp(value)
+	slots := []stygos.Word{stygos.WordFromUint64(0), stygos.WordFromUint64(1), stygos.WordFromUint64(7)}
+	values := []stygos.Word{stygos.WordFromUint64(42), stygos.WordFromUint64(0xdeadbeef), stygos.Keccak256([]byte("x"))}
+	var skeys, svals [][]byte
+	for i := range slots {
+		k := stygos.Keccak256(slots[i][:])
+		skeys = append(skeys, k[:])
+		v := values[i]
+		j := 0
+		for v[j] == 0 {
+			j++
+		}
+		svals = append(svals, rlp.EncodeString(v[j:]))
+	}
+	storageRoot, _ := makeTrie(skeys, svals, skeys[0])
+
+	// State trie: keccak(address) -> rlp(account)
+	target := stygos.Address{0xaa}
+	acct := Account{
+		Nonce:       3,
+		Balance:     stygos.NewU256(1e18),
+		StorageRoot: storageRoot,
+		CodeHash:    stygos.Keccak256([]byte("code")),
+	}
+	others := []stygos.Address{{0x01}, {0x02}, {0x03}}
+	var akeys, avals [][]byte
+	for _, a := range append(others, target) {
+		k := stygos.Keccak256(a[:])
+		akeys = append(akeys, k[:])
+		avals = append(avals, EncodeAccount(Account{StorageRoot: EmptyRoot, CodeHash: EmptyCodeHash}))
+	}
+	avals[len(avals)-1] = EncodeAccount(acct)
+	targetKey := stygos.Keccak256(target[:])
+	stateRoot, accountProof := makeTrie(akeys, avals, targetKey[:])
+
+	got, ok, err := VerifyAccountProof(stateRoot, target, accountProof)
+	if err != nil || !ok {
+		t.Fatalf("VerifyAccountProof failed: ok=%v err=%v", ok, err)
+	}
+	if got != acct {
+		t.Errorf("VerifyAccountProof failed. Expected %+v, got %+v", acct, got)
+	}
+
+	for i, slot := range slots {
+		_, proof := makeTrie(skeys, svals, skeys[i])
+		val, err := VerifyStorageProof(got.StorageRoot, slot, proof)
+		if err != nil {
+			t.Errorf("VerifyStorageProof failed for slot %d: %v", i, err)
+		}
+		if val != values[i] {
+			t.Errorf("VerifyStorageProof failed for slot %d. Expected %x, got %x", i, values[i], val)
+		}
+	}
+
+	// Unset slots read as zero
+	missing := stygos.WordFromUint64(99)
+	mk := stygos.Keccak256(missing[:])
+	_, proof := makeTrie(skeys, svals, mk[:])
+	if val, err := VerifyStorageProof(storageRoot, missing, proof); err != nil || val != (stygos.Word{}) {
+		t.Errorf("VerifyStorageProof failed for unset slot: %x, %v", val, err)
+	}
+
+	// Absent accounts report ok == false
+	absent := stygos.Address{0xbb}
+	ak := stygos.Keccak256(absent[:])
+	_, proof = makeTrie(akeys, avals, ak[:])
+	if _, ok, err := VerifyAccountProof(stateRoot, absent, proof); err != nil || ok {
+		t.Errorf("VerifyAccountProof failed for absent account: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestVerifyProofTampered(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	var keys, vals [][]byte
+	for i := byte(0); i < 8; i++ {
+		k := stygos.Keccak256([]byte{i})
+		keys = append(keys, k[:])
+		vals = append(vals, bytes.Repeat([]byte{i + 1}, 33))
+	}
+	root, proof := makeTrie(keys, vals, keys[3])
+
+	if _, err := VerifyProof(root, keys[3], nil); err != ErrEmptyProof {
+		t.Errorf("VerifyProof failed. Expected ErrEmptyProof, got %v", err)
+	}
+
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+	last := append([]byte(nil), proof[len(proof)-1]...)
+	last[len(last)-1] ^= 1
+	tampered[len(tampered)-1] = last
+	if _, err := VerifyProof(root, keys[3], tampered); err != ErrBadNodeHash {
+		t.Errorf("VerifyProof failed. Expected ErrBadNodeHash, got %v", err)
+	}
+
+	if _, err := VerifyProof(root, keys[3], proof[:len(proof)-1]); err != ErrIncomplete {
+		t.Errorf("VerifyProof failed. Expected ErrIncomplete, got %v", err)
+	}
+
+	wrongRoot := root
+	wrongRoot[0] ^= 1
+	if _, err := VerifyProof(wrongRoot, keys[3], proof); err != ErrBadNodeHash {
+		t.Errorf("VerifyProof failed. Expected ErrBadNodeHash for wrong root, got %v", err)
+	}
+}
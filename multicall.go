@@ -0,0 +1,133 @@
+package stygos
+
+import "errors"
+
+// ErrMalformedCalls is returned for multicall arguments that are not a
+// valid ABI encoded bytes[].
+var ErrMalformedCalls = errors.New("malformed multicall arguments")
+
+// selMulticall is the selector of multicall(bytes[]).
+var selMulticall = Selector{0xac, 0x96, 0x50, 0xd8}
+
+// MulticallSelf runs each of calls through dispatch, normally the
+// contract's own Router.DispatchCtx, with a copy of ctx each, and returns
+// their results. It stops at
+// the first call that fails and returns its error, which reverts the batch
+// as a whole when the entrypoint returns it, so callers can chain state
+// changes that must all happen, such as approve and deposit, in one
+// transaction.
+//
+// The calls run in the caller's frame: msg.sender is the batch's sender,
+// as with a delegatecall to self, and msg.value is the batch's value for
+// every call. A contract whose payable methods credit msg.value must not
+// expose them to MulticallSelf, or one payment would be counted once per
+// call.
+func MulticallSelf(ctx *Ctx, dispatch Handler, calls [][]byte) ([][]byte, error) {
+	results := make([][]byte, len(calls))
+	for i, call := range calls {
+		sub := *ctx
+		out, err := dispatch(&sub, call)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = out
+	}
+	return results, nil
+}
+
+// HandleMulticall registers multicall(bytes[] calls) returns (bytes[]),
+// which runs the calls through r with MulticallSelf.
+func (r *Router) HandleMulticall() {
+	r.HandleSelector(selMulticall, func(ctx *Ctx, args []byte) ([]byte, error) {
+		calls, err := decodeBytesArray(args)
+		if err != nil {
+			return nil, err
+		}
+		results, err := MulticallSelf(ctx, r.DispatchCtx, calls)
+		if err != nil {
+			return nil, err
+		}
+		return encodeBytesArray(results), nil
+	})
+}
+
+// EncodeMulticall returns the calldata of multicall(calls), for clients
+// and tests.
+func EncodeMulticall(calls ...[]byte) []byte {
+	return append(append([]byte(nil), selMulticall[:]...), encodeBytesArray(calls)...)
+}
+
+// DecodeMulticallResult decodes the bytes[] returned by multicall.
+func DecodeMulticallResult(ret []byte) ([][]byte, error) {
+	return decodeBytesArray(ret)
+}
+
+// encodeBytesArray ABI encodes a single bytes[] argument.
+func encodeBytesArray(items [][]byte) []byte {
+	size := 64 + 32*len(items)
+	for _, item := range items {
+		size += 32 + (len(item)+31)/32*32
+	}
+	out := make([]byte, 0, size)
+	out = appendWord(out, WordFromUint64(32))
+	out = appendWord(out, WordFromUint64(uint64(len(items))))
+	off := 32 * len(items)
+	for _, item := range items {
+		out = appendWord(out, WordFromUint64(uint64(off)))
+		off += 32 + (len(item)+31)/32*32
+	}
+	for _, item := range items {
+		out = appendWord(out, WordFromUint64(uint64(len(item))))
+		out = append(out, item...)
+		out = append(out, make([]byte, (len(item)+31)/32*32-len(item))...)
+	}
+	return out
+}
+
+// decodeBytesArray decodes a single ABI encoded bytes[] argument.
+func decodeBytesArray(args []byte) ([][]byte, error) {
+	start, ok := abiOffset(args, 0)
+	if !ok {
+		return nil, ErrMalformedCalls
+	}
+	array := args[start:]
+	n, ok := abiUint(array, 0)
+	if !ok || n > uint64(len(array))/32 {
+		return nil, ErrMalformedCalls
+	}
+	heads := array[32:]
+	items := make([][]byte, n)
+	for i := range items {
+		off, ok := abiOffset(heads, 32*uint64(i))
+		if !ok {
+			return nil, ErrMalformedCalls
+		}
+		length, ok := abiUint(heads, off)
+		if !ok || length > uint64(len(heads))-off-32 {
+			return nil, ErrMalformedCalls
+		}
+		items[i] = heads[off+32 : off+32+length]
+	}
+	return items, nil
+}
+
+// abiUint reads the word at off as an integer that fits in memory.
+func abiUint(data []byte, off uint64) (uint64, bool) {
+	if off > uint64(len(data)) || uint64(len(data))-off < 32 {
+		return 0, false
+	}
+	var w Word
+	copy(w[:], data[off:])
+	v := Uint64FromWord(w)
+	return v, w == WordFromUint64(v) && v <= uint64(len(data))
+}
+
+// abiOffset reads the word at off as an offset into data.
+func abiOffset(data []byte, off uint64) (uint64, bool) {
+	v, ok := abiUint(data, off)
+	return v, ok && uint64(len(data))-v >= 32
+}
+
+func appendWord(out []byte, w Word) []byte {
+	return append(out, w[:]...)
+}
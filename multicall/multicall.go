@@ -0,0 +1,446 @@
+// Package multicall batches calls through a Multicall3 contract, such as
+// the one deployed at Multicall3Address on most chains or the stygos port
+// in examples/multicall.
+//
+// A client reads many view functions in one round trip:
+//
+//	m := multicall.New(multicall.Multicall3Address, stygos.StaticCall)
+//	results, err := m.Aggregate3([]multicall.Call{
+//		{Target: tokenA, Data: balanceOfAlice},
+//		{Target: tokenB, Data: balanceOfAlice, AllowFailure: true},
+//	})
+//
+// In a contract the Caller is stygos.StaticCall; off-chain it wraps an
+// eth_call, such as script.Backend.Call. The encoders and decoders are
+// exported for contracts implementing the Multicall3 interface.
+package multicall
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Multicall errors
+var (
+	ErrBadReturn  = errors.New("multicall: malformed return data")
+	ErrBadCalls   = errors.New("multicall: malformed calls")
+	ErrCallFailed = errors.New("multicall: call failed")
+)
+
+// Multicall3Address is where Multicall3 is deployed, at the same address,
+// on Arbitrum One, Nova, Sepolia and most other chains.
+var Multicall3Address = stygos.Address{
+	0xca, 0x11, 0xbd, 0xe0, 0x59, 0x77, 0xb3, 0x63, 0x11, 0x67,
+	0x02, 0x88, 0x62, 0xbe, 0x2a, 0x17, 0x39, 0x76, 0xca, 0x11,
+}
+
+// Multicall3 selectors
+var (
+	selAggregate       = stygos.Selector{0x25, 0x2d, 0xba, 0x42} // aggregate((address,bytes)[])
+	selTryAggregate    = stygos.Selector{0xbc, 0xe3, 0x8b, 0xd7} // tryAggregate(bool,(address,bytes)[])
+	selAggregate3      = stygos.Selector{0x82, 0xad, 0x56, 0xcb} // aggregate3((address,bool,bytes)[])
+	selAggregate3Value = stygos.Selector{0x17, 0x4d, 0xea, 0x71} // aggregate3Value((address,bool,uint256,bytes)[])
+)
+
+// Call is a call in a batch. AllowFailure and Value are ignored by the
+// functions whose Multicall3 struct lacks them.
+type Call struct {
+	Target       stygos.Address
+	AllowFailure bool
+	Value        stygos.U256
+	Data         []byte
+}
+
+// Result is the outcome of a call in a batch.
+type Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Caller performs a read-only call, as stygos.StaticCall does.
+type Caller func(to stygos.Address, data []byte) ([]byte, error)
+
+// Multicall is a client for a Multicall3 contract.
+type Multicall struct {
+	addr stygos.Address
+	call Caller
+}
+
+// New returns a client for the Multicall3 contract at addr that calls it
+// through call.
+func New(addr stygos.Address, call Caller) Multicall {
+	return Multicall{addr: addr, call: call}
+}
+
+// Aggregate3 runs calls and returns their results. A call that fails
+// without AllowFailure fails the whole batch.
+func (m Multicall) Aggregate3(calls []Call) ([]Result, error) {
+	ret, err := m.call(m.addr, EncodeAggregate3(calls))
+	if err != nil {
+		return nil, err
+	}
+	results, err := DecodeResults(ret)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, ErrBadReturn
+	}
+	return results, nil
+}
+
+// Aggregate runs calls, all of which must succeed, and returns the block
+// number they ran at and their return data.
+func (m Multicall) Aggregate(calls []Call) (uint64, [][]byte, error) {
+	ret, err := m.call(m.addr, EncodeAggregate(calls))
+	if err != nil {
+		return 0, nil, err
+	}
+	block, data, err := DecodeAggregateResult(ret)
+	if err == nil && len(data) != len(calls) {
+		err = ErrBadReturn
+	}
+	return block, data, err
+}
+
+// --- Calldata ---
+
+// head lists the static fields of a Multicall3 call struct before its
+// calldata.
+type head struct {
+	allowFailure bool
+	value        bool
+}
+
+var (
+	plainCall  = head{}
+	call3      = head{allowFailure: true}
+	call3Value = head{allowFailure: true, value: true}
+)
+
+func (h head) tuples(calls []Call) []tuple {
+	items := make([]tuple, len(calls))
+	for i, c := range calls {
+		words := []stygos.Word{stygos.PadAddress(c.Target)}
+		if h.allowFailure {
+			words = append(words, boolWord(c.AllowFailure))
+		}
+		if h.value {
+			words = append(words, c.Value.Word())
+		}
+		items[i] = tuple{words, c.Data}
+	}
+	return items
+}
+
+func (h head) calls(items []tuple) ([]Call, error) {
+	calls := make([]Call, len(items))
+	for i, it := range items {
+		c := Call{Target: stygos.AddressFromWord(it.words[0]), Data: it.data}
+		n := 1
+		if h.allowFailure {
+			b, ok := wordBool(it.words[n])
+			if !ok {
+				return nil, ErrBadCalls
+			}
+			c.AllowFailure = b
+			n++
+		}
+		if h.value {
+			c.Value = stygos.U256FromWord(it.words[n])
+		}
+		calls[i] = c
+	}
+	return calls, nil
+}
+
+func (h head) size() int {
+	n := 1
+	if h.allowFailure {
+		n++
+	}
+	if h.value {
+		n++
+	}
+	return n
+}
+
+// EncodeAggregate returns the calldata of aggregate(calls).
+func EncodeAggregate(calls []Call) []byte {
+	return encodeCalls(selAggregate, nil, plainCall.tuples(calls))
+}
+
+// EncodeTryAggregate returns the calldata of tryAggregate(requireSuccess,
+// calls).
+func EncodeTryAggregate(requireSuccess bool, calls []Call) []byte {
+	return encodeCalls(selTryAggregate, []stygos.Word{boolWord(requireSuccess)}, plainCall.tuples(calls))
+}
+
+// EncodeAggregate3 returns the calldata of aggregate3(calls).
+func EncodeAggregate3(calls []Call) []byte {
+	return encodeCalls(selAggregate3, nil, call3.tuples(calls))
+}
+
+// EncodeAggregate3Value returns the calldata of aggregate3Value(calls).
+func EncodeAggregate3Value(calls []Call) []byte {
+	return encodeCalls(selAggregate3Value, nil, call3Value.tuples(calls))
+}
+
+// encodeCalls encodes the static arguments followed by the call array.
+func encodeCalls(sel stygos.Selector, static []stygos.Word, items []tuple) []byte {
+	out := append([]byte(nil), sel[:]...)
+	for _, w := range static {
+		out = appendWord(out, w)
+	}
+	out = appendWord(out, stygos.WordFromUint64(uint64(32*(len(static)+1))))
+	return appendArray(out, items)
+}
+
+// DecodeAggregate decodes the arguments of aggregate.
+func DecodeAggregate(args []byte) ([]Call, error) {
+	return decodeCalls(args, 0, plainCall)
+}
+
+// DecodeTryAggregate decodes the arguments of tryAggregate.
+func DecodeTryAggregate(args []byte) (requireSuccess bool, calls []Call, err error) {
+	w, ok := wordAt(args, 0)
+	if !ok {
+		return false, nil, ErrBadCalls
+	}
+	if requireSuccess, ok = wordBool(w); !ok {
+		return false, nil, ErrBadCalls
+	}
+	calls, err = decodeCalls(args, 32, plainCall)
+	return requireSuccess, calls, err
+}
+
+// DecodeAggregate3 decodes the arguments of aggregate3.
+func DecodeAggregate3(args []byte) ([]Call, error) {
+	return decodeCalls(args, 0, call3)
+}
+
+// DecodeAggregate3Value decodes the arguments of aggregate3Value.
+func DecodeAggregate3Value(args []byte) ([]Call, error) {
+	return decodeCalls(args, 0, call3Value)
+}
+
+// decodeCalls decodes the call array whose offset is at off in args.
+func decodeCalls(args []byte, off int, h head) ([]Call, error) {
+	start, ok := offsetAt(args, off)
+	if !ok {
+		return nil, ErrBadCalls
+	}
+	items, ok := decodeArray(args[start:], h.size())
+	if !ok {
+		return nil, ErrBadCalls
+	}
+	return h.calls(items)
+}
+
+// --- Return data ---
+
+// EncodeResults encodes the (bool,bytes)[] returned by tryAggregate,
+// aggregate3 and aggregate3Value.
+func EncodeResults(results []Result) []byte {
+	items := make([]tuple, len(results))
+	for i, r := range results {
+		items[i] = tuple{[]stygos.Word{boolWord(r.Success)}, r.ReturnData}
+	}
+	return appendArray(appendWord(nil, stygos.WordFromUint64(32)), items)
+}
+
+// DecodeResults decodes the (bool,bytes)[] returned by tryAggregate,
+// aggregate3 and aggregate3Value.
+func DecodeResults(ret []byte) ([]Result, error) {
+	start, ok := offsetAt(ret, 0)
+	if !ok {
+		return nil, ErrBadReturn
+	}
+	items, ok := decodeArray(ret[start:], 1)
+	if !ok {
+		return nil, ErrBadReturn
+	}
+	results := make([]Result, len(items))
+	for i, it := range items {
+		success, ok := wordBool(it.words[0])
+		if !ok {
+			return nil, ErrBadReturn
+		}
+		results[i] = Result{success, it.data}
+	}
+	return results, nil
+}
+
+// EncodeAggregateResult encodes the (uint256 blockNumber, bytes[]
+// returnData) returned by aggregate.
+func EncodeAggregateResult(block uint64, data [][]byte) []byte {
+	items := make([]tuple, len(data))
+	for i, d := range data {
+		items[i] = tuple{data: d}
+	}
+	out := appendWord(nil, stygos.WordFromUint64(block))
+	out = appendWord(out, stygos.WordFromUint64(64))
+	return appendArray(out, items)
+}
+
+// DecodeAggregateResult decodes the return data of aggregate.
+func DecodeAggregateResult(ret []byte) (uint64, [][]byte, error) {
+	w, ok := wordAt(ret, 0)
+	if !ok {
+		return 0, nil, ErrBadReturn
+	}
+	block := stygos.Uint64FromWord(w)
+	start, ok := offsetAt(ret, 32)
+	if !ok || w != stygos.WordFromUint64(block) {
+		return 0, nil, ErrBadReturn
+	}
+	items, ok := decodeArray(ret[start:], 0)
+	if !ok {
+		return 0, nil, ErrBadReturn
+	}
+	data := make([][]byte, len(items))
+	for i, it := range items {
+		data[i] = it.data
+	}
+	return block, data, nil
+}
+
+// --- ABI encoding ---
+
+// tuple is an element of a dynamic array: a struct of static words and a
+// trailing bytes field, or a bare bytes value when it has no words.
+type tuple struct {
+	words []stygos.Word
+	data  []byte
+}
+
+func (t tuple) size() int {
+	n := 32 + padded(len(t.data))
+	if len(t.words) > 0 {
+		n += 32 * (len(t.words) + 1)
+	}
+	return n
+}
+
+// appendArray appends the length, offsets and elements of an array.
+func appendArray(out []byte, items []tuple) []byte {
+	out = appendWord(out, stygos.WordFromUint64(uint64(len(items))))
+	off := 32 * len(items)
+	for _, it := range items {
+		out = appendWord(out, stygos.WordFromUint64(uint64(off)))
+		off += it.size()
+	}
+	for _, it := range items {
+		for _, w := range it.words {
+			out = appendWord(out, w)
+		}
+		if len(it.words) > 0 {
+			out = appendWord(out, stygos.WordFromUint64(uint64(32*(len(it.words)+1))))
+		}
+		out = appendWord(out, stygos.WordFromUint64(uint64(len(it.data))))
+		out = append(out, it.data...)
+		out = append(out, make([]byte, padded(len(it.data))-len(it.data))...)
+	}
+	return out
+}
+
+// decodeArray decodes an array of tuples of k words and bytes, starting at
+// its length.
+func decodeArray(data []byte, k int) ([]tuple, bool) {
+	n, ok := uintAt(data, 0)
+	if !ok || n > len(data)/32 {
+		return nil, false
+	}
+	heads := data[32:]
+	items := make([]tuple, n)
+	for i := range items {
+		off, ok := offsetAt(heads, 32*i)
+		if !ok {
+			return nil, false
+		}
+		if k == 0 {
+			if items[i].data, ok = bytesAt(heads, off); !ok {
+				return nil, false
+			}
+			continue
+		}
+		elem := heads[off:]
+		words := make([]stygos.Word, k)
+		for j := range words {
+			if words[j], ok = wordAt(elem, 32*j); !ok {
+				return nil, false
+			}
+		}
+		boff, ok := offsetAt(elem, 32*k)
+		if !ok {
+			return nil, false
+		}
+		b, ok := bytesAt(elem, boff)
+		if !ok {
+			return nil, false
+		}
+		items[i] = tuple{words, b}
+	}
+	return items, true
+}
+
+func wordAt(data []byte, off int) (stygos.Word, bool) {
+	var w stygos.Word
+	if off < 0 || off > len(data)-32 {
+		return w, false
+	}
+	copy(w[:], data[off:])
+	return w, true
+}
+
+// uintAt reads the word at off as a length or offset within data.
+func uintAt(data []byte, off int) (int, bool) {
+	w, ok := wordAt(data, off)
+	if !ok {
+		return 0, false
+	}
+	v := stygos.Uint64FromWord(w)
+	if w != stygos.WordFromUint64(v) || v > uint64(len(data)) {
+		return 0, false
+	}
+	return int(v), true
+}
+
+// offsetAt reads the word at off as the offset of a value in data.
+func offsetAt(data []byte, off int) (int, bool) {
+	v, ok := uintAt(data, off)
+	return v, ok && v <= len(data)-32
+}
+
+// bytesAt reads the length-prefixed bytes at off.
+func bytesAt(data []byte, off int) ([]byte, bool) {
+	n, ok := uintAt(data, off)
+	if !ok || n > len(data)-off-32 {
+		return nil, false
+	}
+	return data[off+32 : off+32+n], true
+}
+
+func boolWord(b bool) stygos.Word {
+	var w stygos.Word
+	if b {
+		w[31] = 1
+	}
+	return w
+}
+
+func wordBool(w stygos.Word) (bool, bool) {
+	if w != (stygos.Word{}) && w != boolWord(true) {
+		return false, false
+	}
+	return w[31] == 1, true
+}
+
+func appendWord(out []byte, w stygos.Word) []byte {
+	return append(out, w[:]...)
+}
+
+func padded(n int) int {
+	return (n + 31) / 32 * 32
+}
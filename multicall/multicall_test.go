@@ -0,0 +1,103 @@
+package multicall
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+var calls = []Call{
+	{Target: stygos.Address{19: 0x01}, AllowFailure: true, Value: stygos.NewU256(5), Data: []byte{0xde, 0xad}},
+	{Target: stygos.Address{19: 0x02}, Data: bytes.Repeat([]byte{0x11}, 40)},
+	{Target: stygos.Address{19: 0x03}},
+}
+
+func TestEncodeAggregate3(t *testing.T) {
+	// aggregate3([(0x…01, true, 0xdead)]) as encoded by solidity's abi.encodeCall
+	want := "82ad56cb" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"dead000000000000000000000000000000000000000000000000000000000000"
+	if got := hex.EncodeToString(EncodeAggregate3(calls[:1])); got != want {
+		t.Errorf("EncodeAggregate3 failed. Expected %s, got %s", want, got)
+	}
+}
+
+func TestCallsRoundTrip(t *testing.T) {
+	same := func(got []Call, allow, value bool) bool {
+		if len(got) != len(calls) {
+			return false
+		}
+		for i, c := range calls {
+			g := got[i]
+			if g.Target != c.Target || !bytes.Equal(g.Data, c.Data) ||
+				allow && g.AllowFailure != c.AllowFailure || value && g.Value != c.Value {
+				return false
+			}
+		}
+		return true
+	}
+
+	got, err := DecodeAggregate(EncodeAggregate(calls)[4:])
+	if err != nil || !same(got, false, false) {
+		t.Errorf("DecodeAggregate failed. Expected the calls back, got %+v, %v", got, err)
+	}
+	require, got, err := DecodeTryAggregate(EncodeTryAggregate(true, calls)[4:])
+	if err != nil || !require || !same(got, false, false) {
+		t.Errorf("DecodeTryAggregate failed. Expected the calls back, got %t, %+v, %v", require, got, err)
+	}
+	got, err = DecodeAggregate3(EncodeAggregate3(calls)[4:])
+	if err != nil || !same(got, true, false) {
+		t.Errorf("DecodeAggregate3 failed. Expected the calls back, got %+v, %v", got, err)
+	}
+	got, err = DecodeAggregate3Value(EncodeAggregate3Value(calls)[4:])
+	if err != nil || !same(got, true, true) {
+		t.Errorf("DecodeAggregate3Value failed. Expected the calls back, got %+v, %v", got, err)
+	}
+}
+
+func TestResultsRoundTrip(t *testing.T) {
+	results := []Result{{true, []byte("ok")}, {false, nil}}
+	got, err := DecodeResults(EncodeResults(results))
+	if err != nil || len(got) != 2 || !got[0].Success || string(got[0].ReturnData) != "ok" || got[1].Success {
+		t.Errorf("DecodeResults failed. Expected the results back, got %+v, %v", got, err)
+	}
+
+	block, data, err := DecodeAggregateResult(EncodeAggregateResult(9, [][]byte{{1}, {}}))
+	if err != nil || block != 9 || len(data) != 2 || !bytes.Equal(data[0], []byte{1}) || len(data[1]) != 0 {
+		t.Errorf("DecodeAggregateResult failed. Expected block 9 and the data back, got %d, %x, %v", block, data, err)
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	enc := EncodeAggregate3(calls)[4:]
+	for _, tt := range []struct {
+		name string
+		args []byte
+	}{
+		{"empty", nil},
+		{"truncated", enc[:len(enc)-1]},
+		{"huge length", append(append([]byte(nil), enc[:32]...), bytes.Repeat([]byte{0xff}, 32)...)},
+	} {
+		if _, err := DecodeAggregate3(tt.args); err != ErrBadCalls {
+			t.Errorf("DecodeAggregate3(%s) failed. Expected ErrBadCalls, got %v", tt.name, err)
+		}
+	}
+
+	// allowFailure must be a canonical bool
+	bad := append([]byte(nil), enc...)
+	bad[32+32+3*32+32+31] = 2
+	if _, err := DecodeAggregate3(bad); err != ErrBadCalls {
+		t.Errorf("DecodeAggregate3 failed. Expected ErrBadCalls for a bool of 2, got %v", err)
+	}
+	if _, err := DecodeResults([]byte{1}); err != ErrBadReturn {
+		t.Errorf("DecodeResults failed. Expected ErrBadReturn, got %v", err)
+	}
+}
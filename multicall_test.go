@@ -0,0 +1,75 @@
+package stygos
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestMulticallSelf(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+	mock.Sender = Address{0xa1}
+
+	errOdd := errors.New("odd value")
+	key := Word{0x01}
+	r := NewRouter()
+	r.Handle("set(uint256)", func(ctx *Ctx, args []byte) ([]byte, error) {
+		if args[31]%2 == 1 {
+			return nil, errOdd
+		}
+		var w Word
+		copy(w[:], args)
+		StorageStore(key, w)
+		return nil, nil
+	})
+	r.Handle("get()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		w := StorageLoad(key)
+		return w[:], nil
+	})
+	r.Handle("sender()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		w := PadAddress(GetMsgSender())
+		return w[:], nil
+	})
+	r.HandleMulticall()
+
+	set := func(v uint64) []byte {
+		sel := SelectorOf("set(uint256)")
+		w := WordFromUint64(v)
+		return append(sel[:], w[:]...)
+	}
+	get := SelectorOf("get()")
+	sender := SelectorOf("sender()")
+
+	ret, err := r.Dispatch(EncodeMulticall(set(4), get[:], sender[:]))
+	if err != nil {
+		t.Fatalf("multicall failed: %v", err)
+	}
+	results, err := DecodeMulticallResult(ret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	four, alice := WordFromUint64(4), PadAddress(mock.Sender)
+	if len(results) != 3 || len(results[0]) != 0 || !bytes.Equal(results[1], four[:]) || !bytes.Equal(results[2], alice[:]) {
+		t.Errorf("multicall failed. Expected [], 4 and the sender, got %x", results)
+	}
+
+	// The first failing call fails the batch
+	if _, err := r.Dispatch(EncodeMulticall(set(6), set(7))); err != errOdd {
+		t.Errorf("multicall failed. Expected the failing call's error, got %v", err)
+	}
+
+	// Empty batches and malformed arguments
+	ret, err = r.Dispatch(EncodeMulticall())
+	if results, _ := DecodeMulticallResult(ret); err != nil || len(results) != 0 {
+		t.Errorf("multicall failed. Expected no results for no calls, got %x, %v", ret, err)
+	}
+	bad := EncodeMulticall(set(4))
+	bad[4+63] = 0xff // array length
+	if _, err := r.Dispatch(bad); err != ErrMalformedCalls {
+		t.Errorf("multicall failed. Expected ErrMalformedCalls, got %v", err)
+	}
+	if _, err := r.Dispatch(EncodeMulticall(set(4))[:4+100]); err != ErrMalformedCalls {
+		t.Errorf("multicall failed. Expected ErrMalformedCalls for truncated calldata, got %v", err)
+	}
+}
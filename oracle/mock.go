@@ -0,0 +1,90 @@
+//go:build !tinygo
+
+package oracle
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// ErrNoRound is the revert of a MockFeed asked for a round it lacks.
+var ErrNoRound = errors.New("oracle: no data present")
+
+// MockFeed is an in-memory aggregator deployed on a stygos.MockRuntime.
+type MockFeed struct {
+	Decimals    uint8
+	Description string
+	Rounds      []RoundData // round i has RoundID i+1
+}
+
+// InstallMockFeed deploys a MockFeed at addr on rt and returns it.
+func InstallMockFeed(rt *stygos.MockRuntime, addr stygos.Address, decimals uint8, description string) *MockFeed {
+	m := &MockFeed{Decimals: decimals, Description: description}
+
+	r := stygos.NewRouter()
+	r.HandleSelector(selLatestRoundData, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(m.Rounds) == 0 {
+			return nil, ErrNoRound
+		}
+		return encodeRound(m.Rounds[len(m.Rounds)-1]), nil
+	})
+	r.HandleSelector(selGetRoundData, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		if len(args) < 32 {
+			return nil, ErrNoRound
+		}
+		id := stygos.Uint64FromWord(wordAt(args, 0))
+		if id == 0 || id > uint64(len(m.Rounds)) {
+			return nil, ErrNoRound
+		}
+		return encodeRound(m.Rounds[id-1]), nil
+	})
+	r.HandleSelector(selDecimals, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w := stygos.WordFromUint64(uint64(m.Decimals))
+		return w[:], nil
+	})
+	r.HandleSelector(selDescription, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		offset := stygos.WordFromUint64(32)
+		length := stygos.WordFromUint64(uint64(len(m.Description)))
+		out := append(offset[:], length[:]...)
+		out = append(out, m.Description...)
+		if pad := len(m.Description) % 32; pad != 0 {
+			out = append(out, make([]byte, 32-pad)...)
+		}
+		return out, nil
+	})
+	rt.Deploy(addr, r.Dispatch)
+	return m
+}
+
+// SetPrice publishes a new round with the given answer, started and
+// updated at timestamp.
+func (m *MockFeed) SetPrice(answer int64, timestamp uint64) {
+	id := stygos.NewU256(uint64(len(m.Rounds) + 1))
+	value := stygos.NewU256(uint64(answer))
+	if answer < 0 {
+		value = stygos.NewU256(uint64(-answer)).Not().Add(stygos.NewU256(1))
+	}
+	m.Rounds = append(m.Rounds, RoundData{
+		RoundID:         id,
+		Answer:          value,
+		StartedAt:       timestamp,
+		UpdatedAt:       timestamp,
+		AnsweredInRound: id,
+	})
+}
+
+func encodeRound(r RoundData) []byte {
+	ws := []stygos.Word{
+		r.RoundID.Word(),
+		r.Answer.Word(),
+		stygos.WordFromUint64(r.StartedAt),
+		stygos.WordFromUint64(r.UpdatedAt),
+		r.AnsweredInRound.Word(),
+	}
+	out := make([]byte, 0, 5*32)
+	for _, w := range ws {
+		out = append(out, w[:]...)
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+// Package oracle reads Chainlink-style price feeds implementing
+// AggregatorV3Interface, with the staleness and sanity checks a consumer
+// should apply before trusting a price.
+package oracle
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Oracle errors
+var (
+	ErrBadReturn       = errors.New("oracle: malformed return data")
+	ErrInvalidPrice    = errors.New("oracle: price is not positive")
+	ErrIncompleteRound = errors.New("oracle: round not complete")
+	ErrStalePrice      = errors.New("oracle: price is stale")
+	ErrSequencerDown   = errors.New("oracle: sequencer is down")
+	ErrGracePeriod     = errors.New("oracle: sequencer grace period not over")
+)
+
+// AggregatorV3Interface selectors
+var (
+	selLatestRoundData = stygos.Selector{0xfe, 0xaf, 0x96, 0x8c} // latestRoundData()
+	selGetRoundData    = stygos.Selector{0x9a, 0x6f, 0xc8, 0xf5} // getRoundData(uint80)
+	selDecimals        = stygos.Selector{0x31, 0x3c, 0xe5, 0x67} // decimals()
+	selDescription     = stygos.Selector{0x72, 0x84, 0xe4, 0x16} // description()
+)
+
+// RoundData is a round reported by a feed.
+type RoundData struct {
+	RoundID         stygos.U256
+	Answer          stygos.U256 // int256, two's complement
+	StartedAt       uint64
+	UpdatedAt       uint64
+	AnsweredInRound stygos.U256
+}
+
+// Negative reports whether the answer is below zero.
+func (r RoundData) Negative() bool {
+	return r.Answer[3]>>63 == 1
+}
+
+// Feed is a price feed at a fixed address.
+type Feed struct {
+	addr stygos.Address
+}
+
+// NewFeed returns a client for the aggregator (or its proxy) at addr.
+func NewFeed(addr stygos.Address) Feed {
+	return Feed{addr: addr}
+}
+
+// Address returns the address of the feed.
+func (f Feed) Address() stygos.Address {
+	return f.addr
+}
+
+// LatestRoundData returns the latest round of the feed.
+func (f Feed) LatestRoundData() (RoundData, error) {
+	return f.round(selLatestRoundData[:])
+}
+
+// GetRoundData returns a past round of the feed.
+func (f Feed) GetRoundData(roundID stygos.U256) (RoundData, error) {
+	id := roundID.Word()
+	return f.round(append(selGetRoundData[:], id[:]...))
+}
+
+func (f Feed) round(calldata []byte) (RoundData, error) {
+	ret, err := stygos.StaticCall(f.addr, calldata)
+	if err != nil {
+		return RoundData{}, err
+	}
+	if len(ret) < 5*32 {
+		return RoundData{}, ErrBadReturn
+	}
+	return RoundData{
+		RoundID:         stygos.U256FromWord(wordAt(ret, 0)),
+		Answer:          stygos.U256FromWord(wordAt(ret, 1)),
+		StartedAt:       stygos.Uint64FromWord(wordAt(ret, 2)),
+		UpdatedAt:       stygos.Uint64FromWord(wordAt(ret, 3)),
+		AnsweredInRound: stygos.U256FromWord(wordAt(ret, 4)),
+	}, nil
+}
+
+// Decimals returns the number of decimals of the feed's answers.
+func (f Feed) Decimals() (uint8, error) {
+	ret, err := stygos.StaticCall(f.addr, selDecimals[:])
+	if err != nil {
+		return 0, err
+	}
+	if len(ret) < 32 {
+		return 0, ErrBadReturn
+	}
+	return ret[31], nil
+}
+
+// Description returns the feed's description, such as "ETH / USD".
+func (f Feed) Description() (string, error) {
+	ret, err := stygos.StaticCall(f.addr, selDescription[:])
+	if err != nil {
+		return "", err
+	}
+	if len(ret) < 64 {
+		return "", ErrBadReturn
+	}
+	off := stygos.Uint64FromWord(wordAt(ret, 0))
+	if off > uint64(len(ret))-32 {
+		return "", ErrBadReturn
+	}
+	var length stygos.Word
+	copy(length[:], ret[off:])
+	n := stygos.Uint64FromWord(length)
+	if n > uint64(len(ret))-off-32 {
+		return "", ErrBadReturn
+	}
+	return string(ret[off+32 : off+32+n]), nil
+}
+
+// LatestPrice returns the latest answer after checking that it is positive,
+// that its round is complete and that it was updated at most maxAge seconds
+// before the current block.
+func (f Feed) LatestPrice(maxAge uint64) (stygos.U256, error) {
+	r, err := f.LatestRoundData()
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if r.Answer.IsZero() || r.Negative() {
+		return stygos.U256{}, ErrInvalidPrice
+	}
+	if r.UpdatedAt == 0 || r.AnsweredInRound.Lt(r.RoundID) {
+		return stygos.U256{}, ErrIncompleteRound
+	}
+	now := stygos.GetBlockTimestamp()
+	if r.UpdatedAt > now || now-r.UpdatedAt > maxAge {
+		return stygos.U256{}, ErrStalePrice
+	}
+	return r.Answer, nil
+}
+
+// CheckSequencer checks a Chainlink L2 sequencer uptime feed: an answer of
+// 0 means the sequencer is up and StartedAt is when it last changed status.
+// Prices are only trusted once the sequencer has been back up for
+// gracePeriod seconds, giving users time to react to prices that moved
+// while it was down.
+func CheckSequencer(uptimeFeed Feed, gracePeriod uint64) error {
+	r, err := uptimeFeed.LatestRoundData()
+	if err != nil {
+		return err
+	}
+	if !r.Answer.IsZero() {
+		return ErrSequencerDown
+	}
+	now := stygos.GetBlockTimestamp()
+	if r.StartedAt > now || now-r.StartedAt <= gracePeriod {
+		return ErrGracePeriod
+	}
+	return nil
+}
+
+// wordAt returns the i-th 32-byte word of data.
+func wordAt(data []byte, i int) stygos.Word {
+	var w stygos.Word
+	copy(w[:], data[32*i:])
+	return w
+}
@@ -0,0 +1,123 @@
+package oracle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selLatestRoundData, "latestRoundData()"},
+		{selGetRoundData, "getRoundData(uint80)"},
+		{selDecimals, "decimals()"},
+		{selDescription, "description()"},
+	}
+	for _, tt := range tests {
+		if want := stygos.SelectorOf(tt.signature); tt.sel != want {
+			t.Errorf("Selector of %s failed. Expected %x, got %x", tt.signature, want, tt.sel)
+		}
+	}
+}
+
+func TestFeed(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Time = 10000
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0xfe}
+	mockFeed := InstallMockFeed(mock, addr, 8, "ETH / USD")
+	mockFeed.SetPrice(2000e8, 9000)
+	mockFeed.SetPrice(2100e8, 9900)
+	feed := NewFeed(addr)
+
+	if d, err := feed.Decimals(); err != nil || d != 8 {
+		t.Errorf("Decimals failed. Expected 8, got %d, %v", d, err)
+	}
+	if desc, err := feed.Description(); err != nil || desc != "ETH / USD" {
+		t.Errorf("Description failed. Expected ETH / USD, got %q, %v", desc, err)
+	}
+
+	latest, err := feed.LatestRoundData()
+	if err != nil || latest.RoundID.Uint64() != 2 || latest.Answer.Uint64() != 2100e8 || latest.UpdatedAt != 9900 {
+		t.Errorf("LatestRoundData failed. Got %+v, %v", latest, err)
+	}
+	first, err := feed.GetRoundData(stygos.NewU256(1))
+	if err != nil || first.Answer.Uint64() != 2000e8 {
+		t.Errorf("GetRoundData failed. Got %+v, %v", first, err)
+	}
+	if _, err := feed.GetRoundData(stygos.NewU256(3)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("GetRoundData failed. Expected revert for unknown round, got %v", err)
+	}
+
+	if price, err := feed.LatestPrice(3600); err != nil || price.Uint64() != 2100e8 {
+		t.Errorf("LatestPrice failed. Got %v, %v", price, err)
+	}
+	if _, err := feed.LatestPrice(50); err != ErrStalePrice {
+		t.Errorf("LatestPrice failed. Expected ErrStalePrice, got %v", err)
+	}
+}
+
+func TestLatestPriceChecks(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Time = 500
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0xfe}
+	mockFeed := InstallMockFeed(mock, addr, 8, "")
+	feed := NewFeed(addr)
+
+	if _, err := feed.LatestPrice(60); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("LatestPrice failed. Expected revert without rounds, got %v", err)
+	}
+
+	mockFeed.SetPrice(-5, 500)
+	if _, err := feed.LatestPrice(60); err != ErrInvalidPrice {
+		t.Errorf("LatestPrice failed. Expected ErrInvalidPrice for negative answer, got %v", err)
+	}
+	if r, _ := feed.LatestRoundData(); !r.Negative() {
+		t.Errorf("Negative failed for answer -5")
+	}
+
+	mockFeed.SetPrice(100, 500)
+	mockFeed.Rounds[1].AnsweredInRound = stygos.NewU256(1)
+	if _, err := feed.LatestPrice(60); err != ErrIncompleteRound {
+		t.Errorf("LatestPrice failed. Expected ErrIncompleteRound, got %v", err)
+	}
+
+	mockFeed.SetPrice(100, 600)
+	if _, err := feed.LatestPrice(60); err != ErrStalePrice {
+		t.Errorf("LatestPrice failed. Expected ErrStalePrice for future update, got %v", err)
+	}
+}
+
+func TestCheckSequencer(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Time = 10000
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0x5e}
+	uptime := InstallMockFeed(mock, addr, 0, "L2 Sequencer Uptime Status Feed")
+	feed := NewFeed(addr)
+
+	uptime.SetPrice(1, 9000)
+	if err := CheckSequencer(feed, 3600); err != ErrSequencerDown {
+		t.Errorf("CheckSequencer failed. Expected ErrSequencerDown, got %v", err)
+	}
+
+	uptime.SetPrice(0, 9500)
+	if err := CheckSequencer(feed, 3600); err != ErrGracePeriod {
+		t.Errorf("CheckSequencer failed. Expected ErrGracePeriod, got %v", err)
+	}
+
+	mock.Time = 13200
+	if err := CheckSequencer(feed, 3600); err != nil {
+		t.Errorf("CheckSequencer failed: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package p256
+
+import "math/big"
+
+// point is an affine curve point, with the point at infinity as (0, 0),
+// which is not on the curve.
+type point struct {
+	x, y *big.Int
+}
+
+// Curve parameters of secp256r1: y² = x³ - 3x + b over F_p
+var (
+	p = hexInt("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff")
+	n = hexInt("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551")
+	b = hexInt("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b")
+	g = point{
+		x: hexInt("6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296"),
+		y: hexInt("4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5"),
+	}
+)
+
+func hexInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("p256: invalid constant")
+	}
+	return v
+}
+
+func infinity() point {
+	return point{x: new(big.Int), y: new(big.Int)}
+}
+
+func (a point) isInfinity() bool {
+	return a.x.Sign() == 0 && a.y.Sign() == 0
+}
+
+// onCurve reports whether a is a point of the curve other than infinity,
+// with coordinates below p.
+func onCurve(a point) bool {
+	if a.x.Cmp(p) >= 0 || a.y.Cmp(p) >= 0 || a.isInfinity() {
+		return false
+	}
+	yy := new(big.Int).Mul(a.y, a.y)
+	yy.Mod(yy, p)
+	rhs := new(big.Int).Mul(a.x, a.x)
+	rhs.Sub(rhs, big.NewInt(3))
+	rhs.Mul(rhs, a.x)
+	rhs.Add(rhs, b)
+	rhs.Mod(rhs, p)
+	return yy.Cmp(rhs) == 0
+}
+
+// add returns a + c.
+func add(a, c point) point {
+	if a.isInfinity() {
+		return c
+	}
+	if c.isInfinity() {
+		return a
+	}
+	if a.x.Cmp(c.x) == 0 {
+		if a.y.Cmp(c.y) == 0 {
+			return double(a)
+		}
+		return infinity()
+	}
+	dx := new(big.Int).Sub(c.x, a.x)
+	dx.ModInverse(dx.Mod(dx, p), p)
+	slope := new(big.Int).Sub(c.y, a.y)
+	slope.Mul(slope, dx)
+	slope.Mod(slope, p)
+	return chord(slope, a, c.x)
+}
+
+// double returns 2·a.
+func double(a point) point {
+	if a.isInfinity() || a.y.Sign() == 0 {
+		return infinity()
+	}
+	// slope = (3x² - 3) / 2y
+	slope := new(big.Int).Mul(a.x, a.x)
+	slope.Sub(slope, big.NewInt(1))
+	slope.Mul(slope, big.NewInt(3))
+	inv := new(big.Int).Lsh(a.y, 1)
+	inv.ModInverse(inv.Mod(inv, p), p)
+	slope.Mul(slope, inv)
+	slope.Mod(slope, p)
+	return chord(slope, a, a.x)
+}
+
+// chord returns the third point on the line through a with the given
+// slope, whose other intersection has x-coordinate x2, reflected.
+func chord(slope *big.Int, a point, x2 *big.Int) point {
+	x := new(big.Int).Mul(slope, slope)
+	x.Sub(x, a.x)
+	x.Sub(x, x2)
+	x.Mod(x, p)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, slope)
+	y.Sub(y, a.y)
+	y.Mod(y, p)
+	return point{x: x, y: y}
+}
+
+// mulAdd returns k1·a1 + k2·a2 with one shared chain of doublings.
+func mulAdd(k1 *big.Int, a1 point, k2 *big.Int, a2 point) point {
+	both := add(a1, a2)
+	bits := k1.BitLen()
+	if k2.BitLen() > bits {
+		bits = k2.BitLen()
+	}
+	result := infinity()
+	for i := bits - 1; i >= 0; i-- {
+		result = double(result)
+		switch k1.Bit(i)<<1 | k2.Bit(i) {
+		case 1:
+			result = add(result, a2)
+		case 2:
+			result = add(result, a1)
+		case 3:
+			result = add(result, both)
+		}
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+//go:build !tinygo
+
+package p256
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// InstallMockPrecompile deploys a Go implementation of the RIP-7212
+// precompile on rt. Like the precompile it returns no data for invalid
+// signatures and input of the wrong length.
+func InstallMockPrecompile(rt *stygos.MockRuntime) {
+	rt.Deploy(PrecompileAddress, func(input []byte) ([]byte, error) {
+		if len(input) != 160 {
+			return nil, nil
+		}
+		pub := ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(input[96:128]),
+			Y:     new(big.Int).SetBytes(input[128:160]),
+		}
+		r := new(big.Int).SetBytes(input[32:64])
+		s := new(big.Int).SetBytes(input[64:96])
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) || !ecdsa.Verify(&pub, input[:32], r, s) {
+			return nil, nil
+		}
+		one := stygos.WordFromUint64(1)
+		return one[:], nil
+	})
+}
+
+// PublicKeyOf returns the public key of priv in the form Verify takes.
+func PublicKeyOf(priv *ecdsa.PrivateKey) PublicKey {
+	var pub PublicKey
+	priv.X.FillBytes(pub.X[:])
+	priv.Y.FillBytes(pub.Y[:])
+	return pub
+}
+
+// Sign signs hash with priv.
+func Sign(priv *ecdsa.PrivateKey, hash stygos.Word) (Signature, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, priv, hash[:])
+	if err != nil {
+		return Signature{}, err
+	}
+	var sig Signature
+	r.FillBytes(sig.R[:])
+	s.FillBytes(sig.S[:])
+	return sig, nil
+}
@@ -0,0 +1,83 @@
+// Package p256 verifies secp256r1 (NIST P-256) ECDSA signatures, the
+// curve of passkeys and WebAuthn authenticators.
+//
+// Verify calls the RIP-7212 precompile on ArbOS versions that provide it
+// (stygos.CapP256Verify) and otherwise runs a pure implementation, which
+// gives the same results at a much higher ink cost. As in RIP-7212, high s
+// values are accepted: a passkey signature is only ever checked once
+// against its challenge, so malleability does not matter.
+package p256
+
+import (
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// PrecompileAddress is the RIP-7212 P256VERIFY precompile.
+var PrecompileAddress = stygos.Address{18: 0x01}
+
+// PublicKey is an uncompressed P-256 public key.
+type PublicKey struct {
+	X stygos.Word
+	Y stygos.Word
+}
+
+// Signature is a P-256 ECDSA signature.
+type Signature struct {
+	R stygos.Word
+	S stygos.Word
+}
+
+// Verify reports whether sig is a signature of hash by pub.
+func Verify(hash stygos.Word, sig Signature, pub PublicKey) bool {
+	if stygos.Supports(stygos.CapP256Verify) {
+		return verifyPrecompile(hash, sig, pub)
+	}
+	return VerifyPure(hash, sig, pub)
+}
+
+// verifyPrecompile calls P256VERIFY with hash || r || s || x || y. It
+// returns 1 as a word for a valid signature and no data otherwise.
+func verifyPrecompile(hash stygos.Word, sig Signature, pub PublicKey) bool {
+	input := make([]byte, 0, 160)
+	input = append(input, hash[:]...)
+	input = append(input, sig.R[:]...)
+	input = append(input, sig.S[:]...)
+	input = append(input, pub.X[:]...)
+	input = append(input, pub.Y[:]...)
+	ret, err := stygos.StaticCall(PrecompileAddress, input)
+	if err != nil || len(ret) != 32 {
+		return false
+	}
+	var w stygos.Word
+	copy(w[:], ret)
+	return w == stygos.WordFromUint64(1)
+}
+
+// VerifyPure reports whether sig is a signature of hash by pub without
+// the precompile.
+func VerifyPure(hash stygos.Word, sig Signature, pub PublicKey) bool {
+	r := new(big.Int).SetBytes(sig.R[:])
+	s := new(big.Int).SetBytes(sig.S[:])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+	q := point{x: new(big.Int).SetBytes(pub.X[:]), y: new(big.Int).SetBytes(pub.Y[:])}
+	if !onCurve(q) {
+		return false
+	}
+
+	// R = (e/s)·G + (r/s)·Q, and the signature is valid if x(R) = r mod n
+	e := new(big.Int).SetBytes(hash[:])
+	w := new(big.Int).ModInverse(s, n)
+	u1 := e.Mul(e, w)
+	u1.Mod(u1, n)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, n)
+	R := mulAdd(u1, g, u2, q)
+	if R.isInfinity() {
+		return false
+	}
+	return R.x.Mod(R.x, n).Cmp(r) == 0
+}
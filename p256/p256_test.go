@@ -0,0 +1,136 @@
+package p256
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func key(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv
+}
+
+func TestVerify(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	InstallMockPrecompile(mock)
+
+	priv := key(t)
+	pub := PublicKeyOf(priv)
+	hash := stygos.Keccak256([]byte("passkey"))
+	sig, err := Sign(priv, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// High s is as valid as low s, as in RIP-7212
+	s := new(big.Int).SetBytes(sig.S[:])
+	twin := sig
+	new(big.Int).Sub(n, s).FillBytes(twin.S[:])
+
+	other := PublicKeyOf(key(t))
+	offCurve := pub
+	offCurve.Y[31] ^= 1
+	zeroR := sig
+	zeroR.R = stygos.Word{}
+	overN := sig
+	n.FillBytes(overN.S[:])
+
+	tests := []struct {
+		name string
+		hash stygos.Word
+		sig  Signature
+		pub  PublicKey
+		want bool
+	}{
+		{"valid", hash, sig, pub, true},
+		{"high s", hash, twin, pub, true},
+		{"other hash", stygos.Word{1}, sig, pub, false},
+		{"other key", hash, sig, other, false},
+		{"off curve", hash, sig, offCurve, false},
+		{"zero r", hash, zeroR, pub, false},
+		{"s = n", hash, overN, pub, false},
+	}
+	for _, arbos := range []uint64{stygos.ArbOS20, stygos.ArbOS30} {
+		mock.ArbOS = arbos
+		for _, tt := range tests {
+			if got := Verify(tt.hash, tt.sig, tt.pub); got != tt.want {
+				t.Errorf("Verify %s on ArbOS %d failed. Expected %v, got %v", tt.name, arbos, tt.want, got)
+			}
+		}
+	}
+}
+
+// assertion builds the WebAuthn assertion a passkey would return for
+// challenge.
+func assertion(t *testing.T, priv *ecdsa.PrivateKey, challenge []byte, flags byte) *WebAuthnAuth {
+	t.Helper()
+	authData := make([]byte, 37)
+	authData[32] = flags
+	clientData := `{"type":"webauthn.get","challenge":"` + base64.RawURLEncoding.EncodeToString(challenge) +
+		`","origin":"https://wallet.example","crossOrigin":false}`
+	clientHash := sha256.Sum256([]byte(clientData))
+	hash := stygos.Word(sha256.Sum256(append(authData, clientHash[:]...)))
+	sig, err := Sign(priv, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &WebAuthnAuth{
+		AuthenticatorData: authData,
+		ClientDataJSON:    []byte(clientData),
+		ChallengeIndex:    strings.Index(clientData, `"challenge"`),
+		TypeIndex:         strings.Index(clientData, `"type"`),
+		Signature:         sig,
+	}
+}
+
+func TestVerifyWebAuthn(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.ArbOS = stygos.ArbOS30
+	stygos.UseRuntime(mock)
+	InstallMockPrecompile(mock)
+
+	priv := key(t)
+	pub := PublicKeyOf(priv)
+	challenge := stygos.Keccak256([]byte("userOp"))
+
+	auth := assertion(t, priv, challenge[:], FlagUserPresent|FlagUserVerified)
+	if !VerifyWebAuthn(challenge[:], true, auth, pub) {
+		t.Fatal("VerifyWebAuthn failed. Expected a valid assertion to verify")
+	}
+	if VerifyWebAuthn([]byte("other"), false, auth, pub) {
+		t.Error("VerifyWebAuthn failed. Expected another challenge to fail")
+	}
+
+	moved := *auth
+	moved.ChallengeIndex++
+	if VerifyWebAuthn(challenge[:], false, &moved, pub) {
+		t.Error("VerifyWebAuthn failed. Expected a wrong challenge index to fail")
+	}
+	moved = *auth
+	moved.TypeIndex = len(moved.ClientDataJSON)
+	if VerifyWebAuthn(challenge[:], false, &moved, pub) {
+		t.Error("VerifyWebAuthn failed. Expected a type index out of range to fail")
+	}
+
+	present := assertion(t, priv, challenge[:], FlagUserPresent)
+	if !VerifyWebAuthn(challenge[:], false, present, pub) || VerifyWebAuthn(challenge[:], true, present, pub) {
+		t.Error("VerifyWebAuthn failed. Expected user verification to be required only when asked")
+	}
+	absent := assertion(t, priv, challenge[:], 0)
+	if VerifyWebAuthn(challenge[:], false, absent, pub) {
+		t.Error("VerifyWebAuthn failed. Expected an assertion without user presence to fail")
+	}
+}
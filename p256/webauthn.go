@@ -0,0 +1,70 @@
+package p256
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Authenticator data flags
+const (
+	FlagUserPresent  = 0x01
+	FlagUserVerified = 0x04
+)
+
+// WebAuthnAuth is a WebAuthn assertion: what navigator.credentials.get
+// returns for a passkey, with the positions of the two clientDataJSON
+// fields the contract checks, so it does not have to parse JSON.
+type WebAuthnAuth struct {
+	AuthenticatorData []byte
+	ClientDataJSON    []byte
+	ChallengeIndex    int // offset of "challenge":"..." in ClientDataJSON
+	TypeIndex         int // offset of "type":"webauthn.get" in ClientDataJSON
+	Signature         Signature
+}
+
+var typeGet = []byte(`"type":"webauthn.get"`)
+
+// VerifyWebAuthn reports whether auth is an assertion by pub over
+// challenge, such as a user operation hash. It checks that the client
+// data is a webauthn.get carrying the base64url challenge, that the
+// authenticator saw the user present, and verified them if requireUV is
+// set, and that the signature covers
+// authenticatorData || sha256(clientDataJSON). As in other on-chain
+// verifiers, the origin and relying party id are left unchecked: the
+// challenge binds the assertion to this use.
+func VerifyWebAuthn(challenge []byte, requireUV bool, auth *WebAuthnAuth, pub PublicKey) bool {
+	data := auth.ClientDataJSON
+	if auth.TypeIndex < 0 || auth.TypeIndex > len(data)-len(typeGet) ||
+		!bytes.Equal(data[auth.TypeIndex:auth.TypeIndex+len(typeGet)], typeGet) {
+		return false
+	}
+
+	want := make([]byte, 0, 16+base64.RawURLEncoding.EncodedLen(len(challenge)))
+	want = append(want, `"challenge":"`...)
+	want = append(want, base64.RawURLEncoding.EncodeToString(challenge)...)
+	want = append(want, '"')
+	if auth.ChallengeIndex < 0 || auth.ChallengeIndex > len(data)-len(want) ||
+		!bytes.Equal(data[auth.ChallengeIndex:auth.ChallengeIndex+len(want)], want) {
+		return false
+	}
+
+	// The flags follow the 32-byte rpIdHash
+	if len(auth.AuthenticatorData) < 37 {
+		return false
+	}
+	flags := auth.AuthenticatorData[32]
+	if flags&FlagUserPresent == 0 || (requireUV && flags&FlagUserVerified == 0) {
+		return false
+	}
+
+	clientHash := sha256.Sum256(data)
+	signed := sha256.New()
+	signed.Write(auth.AuthenticatorData)
+	signed.Write(clientHash[:])
+	var hash stygos.Word
+	signed.Sum(hash[:0])
+	return Verify(hash, auth.Signature, pub)
+}
@@ -0,0 +1,121 @@
+// Package payment implements the withdrawal pattern: instead of sending
+// ETH during a state transition, such as refunding an outbid bidder, a
+// contract credits it to the payee, who withdraws it in a call of its own.
+// A payee that cannot receive ETH, or reenters on receipt, then only
+// affects its own withdrawal and never blocks the transition.
+//
+// PullPayment keeps the owed balances and serves the OpenZeppelin
+// PullPayment ABI, withdrawPayments(address) and payments(address), with
+// Mount.
+package payment
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// ErrNothingToWithdraw is returned when nothing is owed to the payee.
+var ErrNothingToWithdraw = errors.New("payment: nothing to withdraw")
+
+// PullPayment ABI selectors
+var (
+	selWithdrawPayments = stygos.Selector{0x31, 0xb3, 0xeb, 0x94} // withdrawPayments(address)
+	selPayments         = stygos.Selector{0xe2, 0x98, 0x2c, 0x21} // payments(address)
+)
+
+// Event signatures, as in OpenZeppelin's Escrow
+var (
+	DepositedTopic = stygos.Keccak256([]byte("Deposited(address,uint256)"))
+	WithdrawnTopic = stygos.Keccak256([]byte("Withdrawn(address,uint256)"))
+)
+
+// PullPayment holds the ETH a contract owes to payees. The ETH stays in
+// the contract's balance until withdrawn.
+//
+// Storage layout relative to the base slot:
+//
+//	base                  total owed
+//	MapKey(base, payee)   owed to payee
+type PullPayment struct {
+	base stygos.Word
+}
+
+// NewPullPayment returns the balances rooted at base.
+func NewPullPayment(base stygos.Word) *PullPayment {
+	return &PullPayment{base: base}
+}
+
+// Credit owes amount more to payee. The contract must hold the ETH.
+func (p *PullPayment) Credit(payee stygos.Address, amount stygos.U256) {
+	if amount.IsZero() {
+		return
+	}
+	slot := p.slot(payee)
+	stygos.StorageStore(slot, stygos.U256FromWord(stygos.StorageLoad(slot)).Add(amount).Word())
+	stygos.StorageStore(p.base, p.TotalOwed().Add(amount).Word())
+	data := amount.Word()
+	stygos.EmitEvent(data[:], DepositedTopic, stygos.PadAddress(payee))
+}
+
+// Payments returns the amount owed to payee.
+func (p *PullPayment) Payments(payee stygos.Address) stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(p.slot(payee)))
+}
+
+// TotalOwed returns the amount owed to all payees, the part of the
+// contract's balance that is not its own.
+func (p *PullPayment) TotalOwed() stygos.U256 {
+	return stygos.U256FromWord(stygos.StorageLoad(p.base))
+}
+
+// WithdrawPayments sends payee everything owed to it and returns the
+// amount. Anyone may call it since the ETH only goes to payee. The balance
+// is cleared before the transfer, so a reentrant call finds nothing.
+func (p *PullPayment) WithdrawPayments(payee stygos.Address) (stygos.U256, error) {
+	amount := p.Payments(payee)
+	if amount.IsZero() {
+		return amount, ErrNothingToWithdraw
+	}
+	stygos.StorageStore(p.slot(payee), stygos.Word{})
+	stygos.StorageStore(p.base, p.TotalOwed().Sub(amount).Word())
+	data := amount.Word()
+	stygos.EmitEvent(data[:], WithdrawnTopic, stygos.PadAddress(payee))
+	return amount, stygos.Transfer(payee, amount)
+}
+
+// Mount registers the withdrawPayments(address) and payments(address)
+// handlers on router.
+func (p *PullPayment) Mount(router *stygos.Router) {
+	router.HandleSelector(selWithdrawPayments, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		payee, err := payeeArg(args)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.WithdrawPayments(payee)
+		return nil, err
+	})
+	router.HandleSelector(selPayments, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		payee, err := payeeArg(args)
+		if err != nil {
+			return nil, err
+		}
+		owed := p.Payments(payee).Word()
+		return owed[:], nil
+	})
+}
+
+func (p *PullPayment) slot(payee stygos.Address) stygos.Word {
+	return storage.MapKey(p.base, payee[:])
+}
+
+// payeeArg decodes a single address argument.
+func payeeArg(args []byte) (stygos.Address, error) {
+	if len(args) != 32 {
+		return stygos.Address{}, stygos.ErrInvalidInput
+	}
+	var w stygos.Word
+	copy(w[:], args)
+	return stygos.AddressFromWord(w), nil
+}
@@ -0,0 +1,108 @@
+package payment
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+var (
+	contract = stygos.Address{0xc0}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+)
+
+func setup() *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = contract
+	mock.SetBalance(contract, big.NewInt(1000))
+	stygos.UseRuntime(mock)
+	return mock
+}
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selWithdrawPayments, "withdrawPayments(address)"},
+		{selPayments, "payments(address)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestPullPayment(t *testing.T) {
+	mock := setup()
+	p := NewPullPayment(stygos.Word{0x9a})
+
+	p.Credit(alice, stygos.NewU256(300))
+	p.Credit(alice, stygos.NewU256(200))
+	p.Credit(bob, stygos.NewU256(100))
+	p.Credit(bob, stygos.U256{})
+	if got := p.Payments(alice); got != stygos.NewU256(500) {
+		t.Errorf("Payments failed. Expected 500, got %s", got)
+	}
+	if got := p.TotalOwed(); got != stygos.NewU256(600) {
+		t.Errorf("TotalOwed failed. Expected 600, got %s", got)
+	}
+	if len(mock.Logs) != 3 || !strings.Contains(string(mock.Logs[0]), hex.EncodeToString(DepositedTopic[:])) {
+		t.Errorf("Credit failed. Expected 3 Deposited events, got %d", len(mock.Logs))
+	}
+	if got := mock.BalanceOf(alice).Int64(); got != 0 {
+		t.Errorf("Credit failed. Expected nothing sent to alice, got %d", got)
+	}
+
+	// Anyone may withdraw for alice, and only once
+	mock.Sender = bob
+	if amount, err := p.WithdrawPayments(alice); err != nil || amount != stygos.NewU256(500) {
+		t.Fatalf("WithdrawPayments failed. Expected 500, got %s, %v", amount, err)
+	}
+	if got := mock.BalanceOf(alice).Int64(); got != 500 {
+		t.Errorf("WithdrawPayments failed. Expected alice to get 500, got %d", got)
+	}
+	if got := p.TotalOwed(); got != stygos.NewU256(100) {
+		t.Errorf("WithdrawPayments failed. Expected 100 still owed, got %s", got)
+	}
+	if !strings.Contains(string(mock.Logs[3]), hex.EncodeToString(WithdrawnTopic[:])) {
+		t.Errorf("WithdrawPayments failed. Expected a Withdrawn event")
+	}
+	if _, err := p.WithdrawPayments(alice); err != ErrNothingToWithdraw {
+		t.Errorf("WithdrawPayments failed. Expected ErrNothingToWithdraw, got %v", err)
+	}
+}
+
+func TestMount(t *testing.T) {
+	mock := setup()
+	p := NewPullPayment(stygos.Word{0x9a})
+	p.Credit(alice, stygos.NewU256(250))
+
+	router := stygos.NewRouter()
+	p.Mount(router)
+	payee := stygos.PadAddress(alice)
+
+	out, err := router.Dispatch(append(selPayments[:], payee[:]...))
+	if err != nil || len(out) != 32 {
+		t.Fatalf("payments failed: %x, %v", out, err)
+	}
+	var owed stygos.Word
+	copy(owed[:], out)
+	if got := stygos.U256FromWord(owed); got != stygos.NewU256(250) {
+		t.Errorf("payments failed. Expected 250, got %s", got)
+	}
+	if _, err := router.Dispatch(append(selWithdrawPayments[:], payee[:]...)); err != nil {
+		t.Fatalf("withdrawPayments failed: %v", err)
+	}
+	if got := mock.BalanceOf(alice).Int64(); got != 250 {
+		t.Errorf("withdrawPayments failed. Expected alice to get 250, got %d", got)
+	}
+	if _, err := router.Dispatch(selWithdrawPayments[:]); err != stygos.ErrInvalidInput {
+		t.Errorf("withdrawPayments failed. Expected ErrInvalidInput without a payee, got %v", err)
+	}
+}
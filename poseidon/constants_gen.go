@@ -0,0 +1,1000 @@
+// Code generated by gen_constants.go. DO NOT EDIT.
+
+package poseidon
+
+// Round constants and MDS matrix for width 2, 56 partial rounds
+const (
+	c2 = "" +
+		"\x09\xc4\x6e\x9e\xc6\x8e\x9b\xd4\xfe\x1f\xaa\xba\x29\x4c\xba\x38\xa7\x1a\xa1\x77\x53\x4c\xdd\x1b\x6c\x7d\xc0\xdb\xd0\xab\xd7\xa7" +
+		"\x0c\x03\x56\x53\x08\x96\xee\xc4\x2a\x97\xed\x93\x7f\x31\x35\xcf\xc5\x14\x2b\x3a\xe4\x05\xb8\x34\x3c\x1d\x83\xff\xa6\x04\xcb\x81" +
+		"\x1e\x28\xa1\xd9\x35\x69\x8a\xd1\x14\x2e\x51\x18\x2b\xb5\x4c\xf4\xa0\x0e\xa5\xaa\xbd\x62\x68\xbd\x31\x7e\xa9\x77\xcc\x15\x4a\x30" +
+		"\x27\xaf\x2d\x83\x1a\x9d\x27\x48\x08\x09\x65\xdb\x30\xe2\x98\xe4\x0e\x57\x57\xc3\xe0\x08\xdb\x96\x4c\xf9\xe2\xb1\x2b\x91\x25\x1f" +
+		"\x1e\x6f\x11\xce\x60\xfc\x8f\x51\x3a\x6a\x3c\xfe\x16\xae\x17\x5a\x41\x29\x14\x62\xf2\x14\xcd\x08\x79\xaa\xf4\x35\x45\xb7\x4e\x03" +
+		"\x2a\x67\x38\x4d\x3b\xbd\x5e\x43\x85\x41\x81\x9c\xb6\x81\xf0\xbe\x04\x46\x2e\xd1\x4c\x36\x13\xd8\xf7\x19\x20\x62\x68\xd1\x42\xd3" +
+		"\x0b\x66\xfd\xf3\x56\x09\x3a\x61\x16\x09\xf8\xe1\x2f\xbf\xec\xf0\xb9\x85\xe3\x81\xf0\x25\x18\x89\x36\x40\x8f\x5d\x5c\x9f\x45\xd0" +
+		"\x01\x2e\xe3\xec\x1e\x78\xd4\x70\x83\x0c\x61\x09\x3c\x2a\xde\x37\x0b\x26\xc8\x3c\xc5\xce\xbe\xed\xda\xa6\x85\x2d\xbd\xb0\x9e\x21" +
+		"\x02\x52\xba\x5f\x67\x60\xbf\xbd\xfd\x88\xf6\x7f\x81\x75\xe3\xfd\x6c\xd1\xc4\x31\xb0\x99\xb6\xbb\x2d\x10\x8e\x7b\x44\x5b\xb1\xb9" +
+		"\x17\x94\x74\xcc\xec\xa5\xff\x67\x6c\x6b\xec\x3c\xef\x54\x29\x63\x54\x39\x1a\x89\x35\xff\x71\xd6\xef\x5a\xea\xad\x7c\xa9\x32\xf1" +
+		"\x2c\x24\x26\x13\x79\xa5\x1b\xfa\x92\x28\xff\x4a\x50\x3f\xd4\xed\x9c\x1f\x97\x4a\x26\x49\x69\xb3\x7e\x1a\x25\x89\xbb\xed\x2b\x91" +
+		"\x1c\xc1\xd7\xb6\x26\x92\xe6\x3e\xac\x2f\x28\x8b\xd0\x69\x5b\x43\xc2\xf6\x3f\x50\x01\xfc\x0f\xc5\x53\xe6\x6c\x05\x51\x80\x1b\x05" +
+		"\x25\x50\x59\x30\x1a\xad\xa9\x8b\xb2\xed\x55\xf8\x52\x97\x9e\x96\x00\x78\x4d\xbf\x17\xfb\xac\xd0\x5d\x9e\xff\x5f\xd9\xc9\x1b\x56" +
+		"\x28\x43\x7b\xe3\xac\x1c\xb2\xe4\x79\xe1\xf5\xc0\xec\xcd\x32\xb3\xae\xa2\x42\x34\x97\x0a\x81\x93\xb1\x1c\x29\xce\x7e\x59\xef\xd9" +
+		"\x28\x21\x6a\x44\x2f\x2e\x1f\x71\x1c\xa4\xfa\x6b\x53\x76\x6e\xb1\x18\x54\x8d\xa8\xfb\x4f\x78\xd4\x33\x87\x62\xc3\x7f\x5f\x20\x43" +
+		"\x2c\x1f\x47\xcd\x17\xfa\x5a\xdf\x1f\x39\xf4\xe7\x05\x6d\xd0\x3f\xee\xe1\xef\xce\x03\x09\x45\x81\x13\x1f\x23\x77\x32\x34\x82\xc9" +
+		"\x07\xab\xad\x02\xb7\xa5\xeb\xc4\x86\x32\xbc\xc9\x35\x6c\xeb\x7d\xd9\xda\xfc\xa2\x76\x63\x8a\x63\x64\x6b\x85\x66\xa6\x21\xaf\xc9" +
+		"\x02\x30\x26\x46\x01\xff\xdf\x29\x27\x5b\x33\xff\xaa\xb5\x1d\xfe\x94\x29\xf9\x08\x80\xa6\x9c\xd1\x37\xda\x0c\x4d\x15\xf9\x6c\x3c" +
+		"\x1b\xc9\x73\x05\x4e\x51\xd9\x05\xa0\xf1\x68\x65\x64\x97\xca\x40\xa8\x64\x41\x45\x57\xee\x28\x9e\x71\x7e\x5d\x66\x89\x9a\xa0\xa9" +
+		"\x2e\x1c\x22\xf9\x64\x43\x50\x08\x20\x6c\x31\x57\xe8\x63\x41\xed\xd2\x49\xaf\xf5\xc2\xd8\x42\x1f\x2a\x6b\x22\x28\x8f\x0a\x67\xfc" +
+		"\x12\x24\xf3\x8d\xf6\x7c\x53\x78\x12\x1c\x1d\x5f\x46\x1b\xbc\x50\x9e\x8e\xa1\x59\x8e\x46\xc9\xf7\xa7\x04\x52\xbc\x2b\xba\x86\xb8" +
+		"\x02\xe4\xe6\x9d\x8b\xa5\x9e\x51\x92\x80\xb4\xbd\x9e\xd0\x06\x8f\xd7\xbf\xe8\xcd\x9d\xfe\xda\x19\x69\xd2\x98\x91\x86\xcd\xe2\x0e" +
+		"\x1f\x1e\xcc\xc3\x4a\xab\xa0\x13\x7f\x5d\xf8\x1f\xc0\x4f\xf3\xee\x4f\x19\xee\x36\x4e\x65\x3f\x07\x6d\x47\xe9\x73\x5d\x98\x01\x8e" +
+		"\x16\x72\xad\x3d\x70\x9a\x35\x39\x74\x26\x6c\x30\x39\xa9\xa7\x31\x14\x24\x44\x80\x32\xcd\x18\x19\xea\xcb\x8a\x4d\x42\x84\xf5\x82" +
+		"\x28\x3e\x3f\xdc\x2c\x6e\x42\x0c\x56\xf4\x4a\xf5\x19\x2b\x4a\xe9\xcd\xa6\x96\x1f\x28\x4d\x24\x99\x1d\x2e\xd6\x02\xdf\x8c\x8f\xc7" +
+		"\x1c\x2a\x3d\x12\x0c\x55\x0e\xcf\xd0\xdb\x09\x57\x17\x0f\xa0\x13\x68\x37\x51\xf8\xfd\xff\x59\xd6\x61\x4f\xbd\x69\xff\x39\x4b\xcc" +
+		"\x21\x6f\x84\x87\x7a\xac\x61\x72\xf7\x89\x7a\x73\x23\x45\x6e\xfe\x14\x3a\x9a\x43\x77\x3e\xa6\xf2\x96\xcb\x6b\x81\x77\x65\x3f\xbd" +
+		"\x2c\x0d\x27\x2b\xec\xf2\xa7\x57\x64\xba\x7e\x8e\x3e\x28\xd1\x2b\xce\xaa\x47\xea\x61\xca\x59\xa4\x11\xa1\xf5\x15\x52\xf9\x47\x88" +
+		"\x16\xe3\x42\x99\x86\x5c\x0e\x28\x48\x4e\xe7\xa7\x4c\x45\x4e\x9f\x17\x0a\x54\x80\xab\xe0\x50\x8f\xcb\x4a\x6c\x3d\x89\x54\x6f\x43" +
+		"\x17\x5c\xeb\xa5\x99\xe9\x6f\x5b\x37\x5a\x23\x2a\x6f\xb9\xcc\x71\x77\x20\x47\x76\x58\x02\x29\x0f\x48\xcd\x93\x97\x55\x48\x8f\xc5" +
+		"\x0c\x75\x94\x44\x0d\xc4\x8c\x16\xfe\xad\x9e\x17\x58\xb0\x28\x06\x6a\xa4\x10\xbf\xbc\x35\x4f\x54\xd8\xc5\xff\xbb\x44\xa1\xee\x32" +
+		"\x1a\x3c\x29\xbc\x39\xf2\x1b\xb5\xc4\x66\xdb\x7d\x7e\xb6\xfd\x8f\x76\x0e\x20\x01\x3c\xcf\x91\x2c\x92\x47\x98\x82\xd9\x19\xfd\x8d" +
+		"\x0c\xcf\xdd\x90\x6f\x34\x26\xe5\xc0\x98\x6e\xa0\x49\xb2\x53\x40\x08\x55\xd3\x49\x07\x4f\x5a\x66\x95\xc8\xee\xab\xcd\x22\xe6\x8f" +
+		"\x14\xf6\xbc\x81\xd9\xf1\x86\xf6\x2b\xdb\x47\x5c\xe6\xc9\x41\x18\x66\xa7\xa8\xa3\xfd\x06\x5b\x3c\xe0\xe6\x99\xb6\x7d\xd9\xe7\x96" +
+		"\x09\x62\xb8\x27\x89\xfb\x3d\x12\x97\x02\xca\x70\xb2\xf6\xc5\xaa\xcc\x09\x98\x10\xc9\xc4\x95\xc8\x88\xed\xeb\x73\x86\xb9\x70\x52" +
+		"\x1a\x88\x0a\xf7\x07\x4d\x18\xb3\xbf\x20\xc7\x9d\xe2\x51\x27\xbc\x13\x28\x4a\xb0\x1e\xf0\x25\x75\xaf\xef\x0c\x8f\x6a\x31\xa8\x6d" +
+		"\x10\xcb\xa1\x84\x19\xa6\xa3\x32\xcd\x5e\x77\xf0\x21\x1c\x15\x4b\x20\xaf\x29\x24\xfc\x20\xff\x3f\x4c\x30\x12\xbb\x7a\xe9\x31\x1b" +
+		"\x05\x7e\x62\xa9\xa8\xf8\x9b\x3e\xbd\xc7\x6b\xa6\x3a\x9e\xac\xa8\xfa\x27\xb7\x31\x9c\xae\x34\x06\x75\x6a\x28\x49\xf3\x02\xf1\x0d" +
+		"\x28\x7c\x97\x1d\xe9\x1d\xc0\xab\xd4\x4a\xdf\x53\x84\xb4\x98\x8c\xb9\x61\x30\x3b\xbf\x65\xcf\xf5\xaf\xa0\x41\x3b\x44\x28\x0c\xee" +
+		"\x21\xdf\x33\x88\xaf\x16\x87\xbb\xb3\xbc\xa9\xda\x0c\xca\x90\x8f\x1e\x56\x2b\xc4\x6d\x4a\xba\x4e\x6f\x7f\x79\x60\xe3\x06\x89\x1d" +
+		"\x1b\xe5\xc8\x87\xd2\x5b\xce\x70\x3e\x25\xcc\x97\x4d\x09\x34\xcd\x78\x9d\xf8\xf7\x0b\x49\x8f\xd8\x3e\xff\x8b\x56\x0e\x16\x82\xb3" +
+		"\x26\x8d\xa3\x6f\x76\xe5\x68\xfb\x68\x11\x71\x75\xce\xa2\xcd\x0d\xd2\xcb\x5d\x42\xfd\xa5\xac\xea\x48\xd5\x9c\x27\x06\xa0\xd5\xc1" +
+		"\x0e\x17\xab\x09\x1f\x6e\xae\x50\xc6\x09\xbe\xaf\x55\x10\xec\xec\xc5\xd8\xbb\x74\x13\x5e\xbd\x05\xbd\x06\x46\x0c\xc2\x6a\x5e\xd6" +
+		"\x04\xd7\x27\xe7\x28\xff\xa0\xa6\x7a\xee\x53\x5a\xb0\x74\xa4\x30\x91\xef\x62\xd8\xcf\x83\xd2\x70\x04\x0f\x5c\xaa\x1f\x62\xaf\x40" +
+		"\x0d\xdb\xd7\xbf\x9c\x29\x34\x15\x81\xb5\x49\x76\x2b\xc0\x22\xed\x33\x70\x2a\xc1\x0f\x1b\xfd\x86\x2b\x15\x41\x7d\x7e\x39\xca\x6e" +
+		"\x27\x90\xeb\x33\x51\x62\x17\x52\x76\x81\x62\xe8\x29\x89\xc6\xc2\x34\xf5\xb0\xd1\xd3\xaf\x9b\x58\x8a\x29\xc4\x9c\x87\x89\x65\x4b" +
+		"\x1e\x45\x7c\x60\x1a\x63\xb7\x3e\x44\x71\x95\x01\x93\xd8\xa5\x70\x39\x5f\x3d\x9a\xb8\xb2\xfd\x09\x84\xb7\x64\x20\x61\x42\xf9\xe9" +
+		"\x21\xae\x64\x30\x1d\xca\x96\x25\x63\x8d\x6a\xb2\xbb\xe7\x13\x5f\xfa\x90\xec\xd0\xc4\x3f\xf9\x1f\xc4\xc6\x86\xfc\x46\xe0\x91\xb0" +
+		"\x03\x79\xf6\x3c\x8c\xe3\x46\x8d\x4d\xa2\x93\x16\x6f\x49\x49\x28\x85\x4b\xe9\xe3\x43\x2e\x09\x55\x58\x58\x53\x4e\xed\x8d\x35\x0b" +
+		"\x00\x2d\x56\x42\x03\x59\xd0\x26\x6a\x74\x4a\x08\x08\x09\xe0\x54\xca\x0e\x49\x21\xa4\x66\x86\xac\x8c\x9f\x58\xa3\x24\xc3\x50\x49" +
+		"\x12\x31\x58\xe5\x96\x5b\x5d\x9b\x1d\x68\xb3\xcd\x32\xe1\x0b\xbe\xda\x8d\x62\x45\x9e\x21\xf4\x09\x0f\xc2\xc5\xaf\x96\x35\x15\xa6" +
+		"\x0b\xe2\x9f\xc4\x08\x47\xa9\x41\x66\x1d\x14\xbb\xf6\xcb\xe0\x42\x0f\xbb\x2b\x6f\x52\x83\x6d\x4e\x60\xc8\x0e\xb4\x9c\xad\x9e\xc1" +
+		"\x1a\xc9\x69\x91\xde\xc2\xbb\x05\x57\x71\x61\x42\x01\x5a\x45\x3c\x36\xdb\x9d\x85\x9c\xad\x5f\x9a\x23\x38\x02\xf2\x4f\xdf\x4c\x1a" +
+		"\x15\x96\x44\x3f\x76\x3d\xbc\xc2\x5f\x49\x64\xfc\x61\xd2\x3b\x3e\x5e\x12\xc9\xfa\x97\xf1\x8a\x92\x51\xca\x33\x55\xbc\xb0\x62\x7e" +
+		"\x12\xe0\xbc\xd3\x65\x4b\xdf\xa7\x6b\x28\x61\xd4\xec\x3a\xea\xe0\xf1\x85\x7d\x9f\x17\xe7\x15\xae\xd6\xd0\x49\xea\xe3\xba\x32\x12" +
+		"\x0f\xc9\x2b\x4f\x1b\xbe\xa8\x2b\x9e\xa7\x3d\x4a\xf9\xaf\x2a\x50\xce\xab\xac\x7f\x37\x15\x4b\x19\x04\xe6\xc7\x6c\x7c\xf9\x64\xba" +
+		"\x1f\x9c\x0b\x16\x10\x44\x64\x42\xd6\xf2\xe5\x92\xa8\x01\x3f\x40\xb1\x4f\x7c\x77\x22\x23\x6f\x4f\x9c\x7e\x96\x52\x33\x87\x27\x62" +
+		"\x0e\xbd\x74\x24\x4a\xe7\x26\x75\xf8\xcd\xe0\x61\x57\xa7\x82\xf4\x05\x0d\x91\x4d\xa3\x8b\x4c\x05\x8d\x15\x9f\x64\x3d\xbb\xf4\xd3" +
+		"\x2c\xb7\xf0\xed\x39\xe1\x6e\x9f\x69\xa9\xfa\xfd\x4a\xb9\x51\xc0\x3b\x06\x71\xe9\x73\x46\xee\x39\x7a\x83\x98\x39\xdc\xcf\xc6\xd1" +
+		"\x1a\x9d\x6e\x2e\xcf\xf0\x22\xcc\x56\x05\x44\x3e\xe4\x1b\xab\x20\xce\x76\x1d\x05\x14\xce\x52\x66\x90\xc7\x2b\xca\x73\x52\xd9\xbf" +
+		"\x2a\x11\x54\x39\x60\x7f\x33\x5a\x5e\xa8\x3c\x3b\xc4\x4a\x93\x31\xd0\xc1\x33\x26\xa9\xa7\xba\x30\x87\xda\x18\x2d\x64\x8e\xc7\x2f" +
+		"\x23\xf9\xb6\x52\x9b\x5d\x04\x0d\x15\xb8\xfa\x7a\xee\x3e\x34\x10\xe7\x38\xb5\x63\x05\xcd\x44\xf2\x95\x35\xc1\x15\xc5\xa4\xc0\x60" +
+		"\x05\x87\x2c\x16\xdb\x0f\x72\xa2\x24\x9a\xc6\xba\x48\x4b\xb9\xc3\xa3\xce\x97\xc1\x6d\x58\xb6\x8b\x26\x0e\xb9\x39\xf0\xe6\xe8\xa7" +
+		"\x13\x00\xbd\xee\x08\xbb\x78\x24\xca\x20\xfb\x80\x11\x80\x75\xf4\x02\x19\xb6\x15\x1d\x55\xb5\xc5\x2b\x62\x4a\x7c\xde\xdd\xf6\xa7" +
+		"\x19\xb9\xb6\x3d\x2f\x10\x8e\x17\xe6\x38\x17\x86\x3a\x8f\x6c\x28\x8d\x7a\xd2\x99\x16\xd9\x8c\xb1\x07\x2e\x4e\x7b\x7d\x52\xb3\x76" +
+		"\x01\x5b\xee\x13\x57\xe3\xc0\x15\xb5\xbd\xa2\x37\x66\x85\x22\xf6\x13\xd1\xc8\x87\x26\xb5\xec\x42\x24\xa2\x01\x28\x48\x1b\x4f\x7f" +
+		"\x29\x53\x73\x6e\x94\xbb\x6b\x9f\x1b\x97\x07\xa4\xf1\x61\x5e\x4e\xfe\x1e\x1c\xe4\xba\xb2\x18\xcb\xea\x92\xc7\x85\xb1\x28\xff\xd1" +
+		"\x0b\x06\x93\x53\xba\x09\x16\x18\x86\x2f\x80\x61\x80\xc0\x38\x5f\x85\x1b\x98\xd3\x72\xb4\x5f\x54\x4c\xe7\x26\x6e\xd6\x60\x8d\xfc" +
+		"\x30\x4f\x74\xd4\x61\xcc\xc1\x31\x15\xe4\xe0\xbc\xfb\x93\x81\x7e\x55\xae\xb7\xeb\x93\x06\xb6\x4e\x4f\x58\x8a\xc9\x7d\x81\xf4\x29" +
+		"\x15\xbb\xf1\x46\xce\x9b\xca\x09\xe8\xa3\x3f\x5e\x77\xdf\xe4\xf5\xaa\xd2\xa1\x64\xa4\x61\x7a\x4c\xb8\xee\x54\x15\xcd\xe9\x13\xfc" +
+		"\x0a\xb4\xdf\xe0\xc2\x74\x2c\xde\x44\x90\x10\x31\x48\x79\x64\xed\x9b\x8f\x4b\x85\x04\x05\xc1\x0c\xa9\xff\x23\x85\x95\x72\xc8\xc6" +
+		"\x0e\x32\xdb\x32\x0a\x04\x4e\x31\x97\xf4\x5f\x76\x49\xa1\x96\x75\xef\x5e\xed\xfe\xa5\x46\xde\xa9\x25\x1d\xe3\x9f\x96\x39\x77\x9a" +
+		"\x0a\x17\x56\xaa\x1f\x37\x8c\xa4\xb2\x76\x35\xa7\x8b\x68\x88\xe6\x67\x97\x73\x3a\x82\x77\x48\x96\xa3\x07\x8e\xfa\x51\x6d\xa0\x16" +
+		"\x04\x4c\x4a\x33\xb1\x0f\x69\x34\x47\xfd\x17\x17\x7f\x95\x2e\xf8\x95\xe6\x1d\x32\x8f\x85\xef\xa9\x42\x54\xd6\xa2\xa2\x5d\x93\xef" +
+		"\x2e\xd3\x61\x1b\x72\x5b\x8a\x70\xbe\x65\x5b\x53\x7f\x66\xf7\x00\xfe\x08\x79\xd7\x9a\x49\x68\x91\xd3\x7b\x07\xb5\x46\x6c\x4b\x8b" +
+		"\x1f\x9b\xa4\xe8\xba\xb7\xce\x42\xc8\xec\xc3\xd7\x22\xaa\x2e\x0e\xad\xfd\xeb\x9c\xfd\xd3\x47\xb5\xd8\x33\x9e\xa7\x12\x08\x58\xaa" +
+		"\x1b\x23\x30\x43\x05\x2e\x8c\x28\x8f\x7e\xe9\x07\xa8\x4e\x51\x8a\xa3\x8e\x82\xac\x45\x02\x06\x6d\xb7\x40\x56\xf8\x65\xc5\xd3\xda" +
+		"\x24\x31\xe1\xcc\x16\x4b\xb8\xd0\x74\x03\x1a\xb7\x2b\xd5\x5b\x4c\x90\x20\x53\xbf\xc0\xf1\x4d\xb0\xca\x2f\x97\xb0\x20\x87\x59\x54" +
+		"\x08\x2f\x93\x4c\x91\xf5\xaa\xc3\x30\xcd\x69\x53\xa0\xa7\xdb\x45\xa1\x3e\x32\x20\x97\x58\x33\x19\xa7\x91\xf2\x73\x96\x58\x01\xfd" +
+		"\x2b\x9a\x0a\x22\x3e\x75\x38\xb0\xa3\x4b\xe0\x74\x31\x55\x42\xa3\xc7\x72\x45\xe2\xae\x7c\xbe\x99\x9a\xd6\xbb\x93\x0c\x48\x99\x7c" +
+		"\x0e\x1c\xd9\x1e\xdd\x2c\xfa\x2c\xce\xb8\x54\x83\xb8\x87\xa9\xbe\x81\x64\x16\x3e\x75\xa8\xa0\x0e\xb0\xb5\x89\xcc\x70\x21\x4e\x7d" +
+		"\x2e\x1e\xac\x0f\x2b\xfd\xfd\x63\xc9\x51\xf6\x14\x77\xe3\x69\x89\x99\x77\x4f\x19\x85\x4d\x00\xf5\x88\xd3\x24\x60\x1c\xeb\xe2\xf9" +
+		"\x0c\xbf\xa9\x5f\x37\xfb\x74\x06\x0c\x76\x15\x8e\x76\x9d\x6d\x15\x73\x45\x78\x4d\x8e\xfd\xb3\x3c\x23\xd7\x48\x11\x5b\x50\x0b\x83" +
+		"\x08\xf0\x5b\x3b\xe9\x23\xed\x44\xd6\x5a\xd4\x9d\x8a\x61\xe9\xa6\x76\xd9\x91\xe3\xa7\x75\x13\xd9\x98\x0c\x23\x2d\xfa\x4a\x4f\x84" +
+		"\x22\x71\x9e\x2a\x07\x0b\xcd\x08\x52\xbf\x8e\x21\x98\x4d\x04\x43\xe7\x28\x49\x25\xdc\x07\x58\xa3\x25\xa2\xdd\x51\x0c\x04\x7e\xf6" +
+		"\x04\x1f\x59\x6a\x9e\xe1\xcb\x2b\xc0\x60\xf7\xfc\xc3\xa1\xab\x4c\x7b\xdb\xf0\x36\x11\x99\x82\xc0\xf4\x1f\x62\xb2\xf2\x68\x30\xc0" +
+		"\x23\x3f\xd3\x5d\xe1\xbe\x52\x0a\x87\x62\x8e\xb0\x6f\x6b\x1d\x4c\x02\x1b\xe1\xc2\xd0\xdc\x46\x4a\x19\xfc\xdd\x09\x86\xb1\x0f\x89" +
+		"\x05\x24\xb4\x6d\x1a\xa8\x7a\x5e\x43\x25\xe0\xa4\x23\xeb\xc8\x10\xd3\x1e\x07\x8a\xa1\xb4\x70\x7e\xef\xcb\x45\x3c\x61\xc9\xc2\x67" +
+		"\x2c\x34\xf4\x24\xc8\x1e\x57\x16\xce\x47\xfc\xac\x89\x4b\x85\x82\x42\x27\xbb\x95\x4b\x0f\x31\x99\xcc\x44\x86\x23\x7c\x51\x52\x11" +
+		"\x0b\x5f\x2a\x4b\x63\x38\x78\x19\x20\x7e\xff\xc2\xb5\x54\x1f\xb7\x2d\xd2\x02\x5b\x54\x57\xcc\x97\xf3\x30\x10\x32\x7d\xe4\x91\x5e" +
+		"\x22\x20\x78\x56\x08\x2c\xcc\x54\xc5\xb7\x2f\xe4\x39\xd2\xcf\xd6\xc1\x74\x35\xd2\xf5\x7a\xf6\xce\xae\xfa\xc4\x1f\xe0\x5c\x65\x9f" +
+		"\x24\xd5\x7a\x8b\xf5\xda\x63\xfe\x4e\x24\x15\x9b\x7f\x89\x50\xb5\xcd\xfb\x21\x01\x94\xca\xf7\x9f\x27\x85\x40\x48\xce\x2c\x81\x71" +
+		"\x0a\xfa\xb1\x81\xfd\xd5\xe0\x58\x3b\x37\x1d\x75\xbd\x69\x3f\x98\x37\x4a\xd7\x09\x7b\xb0\x1a\x85\x73\x91\x9b\xb2\x3b\x79\x39\x6e" +
+		"\x2d\xba\x9b\x10\x8f\x20\x87\x72\x99\x8a\x52\xef\xac\x7c\xbd\x56\x76\xc0\x05\x71\x94\xc1\x6c\x0b\xf1\x62\x90\xd6\x2b\x11\x28\xee" +
+		"\x26\x34\x9b\x66\xed\xb8\xb1\x6f\x56\xf8\x81\xc7\x88\xf5\x3f\x83\xcb\xb8\x3d\xe0\xbd\x59\x2b\x25\x5a\xff\x13\xe6\xbc\xe4\x20\xb3" +
+		"\x25\xaf\x7c\xe0\xe5\xe1\x03\x57\x68\x5e\x95\xf9\x23\x39\x75\x3a\xd8\x1a\x56\xd2\x8e\xcc\x19\x3b\x23\x52\x88\xa3\xe6\xf1\x37\xdb" +
+		"\x25\xb4\xce\x7b\xd2\x29\x43\x90\xc0\x94\xd6\xa5\x5e\xdd\x68\xb9\x70\xee\xd7\xaa\xe8\x8b\x2b\xff\x1f\x7c\x01\x87\xfe\x35\x01\x1f" +
+		"\x22\xc5\x43\xf1\x0f\x6c\x89\xec\x38\x7e\x53\xf1\x90\x8a\x88\xe5\xde\x9c\xef\x28\xeb\xdf\x30\xb1\x8c\xb9\xd5\x4c\x1e\x02\xb6\x31" +
+		"\x02\x36\xf9\x3e\x77\x89\xc4\x72\x4f\xc7\x90\x8a\x9f\x19\x1e\x1e\x42\x5e\x90\x6a\x91\x9d\x7a\x34\xdf\x66\x8e\x74\x88\x2f\x87\xa9" +
+		"\x29\x35\x0b\x40\x11\x66\xca\x01\x0e\x7d\x27\xe3\x7d\x05\xda\x99\x65\x2b\xda\xe1\x14\xeb\x01\x65\x9c\xb4\x97\xaf\x98\x0c\x4b\x52" +
+		"\x0e\xed\x78\x7d\x65\x82\x0d\x3f\x6b\xd3\x1b\xba\xb5\x47\xf7\x5a\x65\xed\xb7\x5d\x84\x4e\xbb\x89\xee\x12\x60\x91\x66\x52\x36\x3f" +
+		"\x07\xcc\x11\x70\xf1\x3b\x46\xf2\x03\x6a\x75\x3f\x52\x0b\x32\x91\xfd\xcd\x0e\x99\xbd\x94\x29\x7d\x19\x06\xf6\x56\xf4\xde\x6f\xad" +
+		"\x22\xb9\x39\x23\x3b\x1d\x72\x05\xf4\x9b\xcf\x61\x3a\x3d\x30\xb1\x90\x87\x86\xd7\xf9\xf5\xd1\x0c\x20\x59\x43\x56\x89\xe8\xac\xea" +
+		"\x01\x45\x17\x62\xa0\xaa\xb8\x1c\x8a\xad\x1d\xc8\xbc\x33\xe8\x70\x74\x0f\x08\x3a\x5a\xa8\x54\x38\xad\xd6\x50\xac\xe6\x0a\xe5\xa6" +
+		"\x23\x50\x6b\xb5\xd8\x72\x7d\x44\x61\xfa\xbf\x10\x25\xd4\x6d\x1f\xe3\x2e\xaa\x61\xde\xc7\xda\x57\xe7\x04\xfe\xc0\x89\x2f\xce\x89" +
+		"\x2e\x48\x4c\x44\xe8\x38\xae\xa0\xba\xc0\x6a\xe3\xf7\x1b\xdd\x09\x2a\x37\x09\x53\x1e\x1e\xfe\xa9\x7f\x8b\xd6\x89\x07\x35\x55\x22" +
+		"\x0f\x4b\xc7\xd0\x7e\xba\xfd\x64\x37\x9e\x78\xc5\x0b\xd2\xe4\x2b\xaf\x4a\x59\x45\x45\xce\xdc\x25\x45\x41\x8d\xa2\x68\x35\xb5\x4c" +
+		"\x1f\x4d\x3c\x8f\x65\x83\xe9\xe5\xfa\x76\x63\x78\x62\xfa\xae\xe8\x51\x58\x23\x88\x72\x5d\xf4\x60\xe6\x20\x99\x6d\x50\xd8\xe7\x4e" +
+		"\x09\x35\x14\xe0\xc7\x07\x11\xf8\x26\x60\xd0\x7b\xe0\xe4\xa9\x88\xfa\xe0\x2a\xbc\x7b\x68\x1d\x91\x53\xeb\x9b\xcb\x48\xfe\x73\x89" +
+		"\x1a\xda\xb0\xc8\xe2\xb3\xba\xd3\x46\x69\x9a\x2b\x5f\x3b\xc0\x36\x43\xee\x83\xec\xe4\x72\x28\xf2\x4a\x58\xe0\xa3\x47\xe1\x53\xd8" +
+		"\x16\x72\xb1\x72\x60\x57\xd9\x9d\xd1\x47\x09\xeb\xb4\x74\x64\x1a\x37\x8c\x1b\x94\xb8\x07\x2b\xac\x1a\x22\xdb\xef\x9e\x80\xda\xd2" +
+		"\x1d\xfd\x53\xd4\x57\x6a\xf2\xe3\x8f\x44\xf5\x3f\xdc\xab\x46\x8c\xc5\xd8\xe2\xfa\xe0\xac\xc4\xee\x30\xd4\x7b\x23\x9b\x47\x9c\x14" +
+		"\x0c\x68\x88\xa1\x0b\x75\xb0\xf3\xa7\x0a\x36\x26\x3a\x37\xe1\x7f\xe6\xd7\x7d\x64\x0f\x6f\xc3\xde\xbc\x7f\x20\x77\x53\x20\x5c\x60" +
+		"\x1a\xdd\xb9\x33\xa6\x5b\xe7\x70\x92\xb3\x4a\x7e\x77\xd1\x2f\xe8\x61\x1a\x61\xe0\x0e\xe6\x84\x8b\x85\x09\x1e\xcc\xa9\xd1\xe5\x08" +
+		"\x00\xd7\x54\x0d\xcd\x26\x8a\x84\x5c\x10\xae\x18\xd1\xde\x93\x3c\xf6\x38\xff\x54\x25\xf0\xaf\xff\x79\x35\x62\x8e\x29\x9d\x17\x91" +
+		"\x14\x0c\x0e\x42\x68\x7e\x9e\xad\x01\xb2\x82\x7a\x56\x64\xca\x9c\x26\xfe\xdd\xe4\xac\xd9\x9d\xb1\xd3\x16\x93\x9d\x20\xb8\x2c\x0e" +
+		"\x2f\x0c\x3a\x11\x5d\x43\x17\xd1\x91\xba\x89\xb8\xd1\x3d\x18\x06\xc2\x0a\x0f\x9b\x24\xf8\xc5\xed\xc0\x91\xe2\xae\x56\x56\x59\x84" +
+		"\x0c\x4e\xe7\x78\xff\x7c\x14\x55\x30\x06\xed\x22\x0c\xf9\xc8\x10\x08\xa0\xcf\xf6\x70\xb2\x2b\x82\xd8\xc5\x38\xa1\xdc\x95\x8c\x61" +
+		"\x17\x04\xf2\x76\x6d\x46\xf8\x2c\x36\x93\xf0\x04\x40\xcc\xc3\x60\x94\x24\xed\x26\xc0\xac\xc6\x62\x27\xc3\xd7\x48\x5d\xe7\x4c\x69" +
+		"\x2f\x2d\x19\xcc\x3e\xa5\xd7\x8e\xa7\xa0\x2c\x1b\x51\xd2\x44\xab\xf0\x76\x9c\x9f\x85\x44\xe4\x02\x39\xb6\x6f\xe9\x00\x9c\x3c\xfa" +
+		"\x1a\xe0\x38\x53\xb7\x5f\xca\xba\x50\x53\xf1\x12\xe2\xa8\xe8\xdc\xdd\x7e\xe6\xcb\x9c\xfe\xd9\xc7\xd6\xc7\x66\xa8\x06\xfc\x66\x29" +
+		"\x09\x71\xaa\xbf\x79\x52\x41\xdf\x51\xd1\x31\xd0\xfa\x61\xaa\x5f\x35\x56\x92\x1b\x2d\x6f\x01\x4e\x4e\x41\xa8\x6d\xda\xf0\x56\xd5" +
+		"\x14\x08\xc3\x16\xe6\x01\x4e\x1a\x91\xd4\xcf\x6b\x6e\x0d\xe7\x3e\xda\x62\x4f\x83\x80\xdf\x1c\x87\x5f\x5c\x29\xf7\xbf\xe2\xf6\x46" +
+		"\x16\x67\xf3\xfe\x2e\xdb\xe8\x50\x24\x8a\xbe\x42\xb5\x43\x09\x3b\x6c\x89\xf1\xf7\x73\xef\x28\x53\x41\x69\x1f\x39\x82\x2e\xf5\xbd" +
+		"\x13\xbf\x7c\x5d\x0d\x2c\x43\x76\xa4\x8b\x0a\x03\x55\x7c\xdf\x91\x5b\x81\x71\x84\x09\xe5\xc1\x33\x42\x4c\x69\x57\x65\x00\xfe\x37" +
+		"\x07\x62\x0a\x6d\xfb\x0b\x6c\xec\x30\x16\xad\xf3\xd3\x53\x3c\x24\x02\x4b\x95\x34\x78\x56\xb7\x97\x19\xbc\x0b\xa7\x43\xa6\x2c\x2c" +
+		"\x15\x74\xc7\xef\x0c\x43\x54\x5f\x36\xa8\xca\x08\xbd\xbd\xd8\xb0\x75\xd2\x95\x9e\x2f\x32\x2b\x73\x16\x75\xde\x3e\x19\x82\xb4\xd0" +
+		"\x26\x9e\x4b\x5b\x7a\x2e\xb2\x1a\xfd\x56\x79\x70\xa7\x17\xce\xec\x5b\xd4\x18\x45\x71\xc2\x54\xfd\xc0\x6e\x03\xa7\xff\x83\x78\xf0"
+	m2 = "" +
+		"\x06\x6f\x6f\x85\xd6\xf6\x8a\x85\xec\x10\x34\x53\x51\xa2\x3a\x3a\xaf\x07\xf3\x8a\xf8\xc9\x52\xa7\xbc\xec\xa7\x0b\xd2\xaf\x7a\xd5" +
+		"\x2b\x9d\x4b\x41\x10\xc9\xae\x99\x77\x82\xe1\x50\x9b\x1d\x0f\xdb\x20\xa7\xc0\x2b\xbd\x8b\xea\x73\x05\x46\x2b\x9f\x81\x25\xb1\xe8" +
+		"\x0c\xc5\x7c\xdb\xb0\x85\x07\xd6\x2b\xf6\x7a\x44\x93\xcc\x26\x2f\xb6\xc0\x9d\x55\x70\x13\xff\xf1\xf5\x73\xf4\x31\x22\x1f\x8f\xf9" +
+		"\x12\x74\xe6\x49\xa3\x2e\xd3\x55\xa3\x1a\x6e\xd6\x97\x24\xe1\xad\xad\xe8\x57\xe8\x6e\xb5\xc3\xa1\x21\xbc\xd1\x47\x94\x32\x03\xc8"
+)
+
+// Round constants and MDS matrix for width 3, 57 partial rounds
+const (
+	c3 = "" +
+		"\x0e\xe9\xa5\x92\xba\x9a\x95\x18\xd0\x59\x86\xd6\x56\xf4\x0c\x21\x14\xc4\x99\x3c\x11\xbb\x29\x93\x8d\x21\xd4\x73\x04\xcd\x8e\x6e" +
+		"\x00\xf1\x44\x52\x35\xf2\x14\x8c\x59\x86\x58\x71\x69\xfc\x1b\xcd\x88\x7b\x08\xd4\xd0\x08\x68\xdf\x56\x96\xff\xf4\x09\x56\xe8\x64" +
+		"\x08\xdf\xf3\x48\x7e\x8a\xc9\x9e\x1f\x29\xa0\x58\xd0\xfa\x80\xb9\x30\xc7\x28\x73\x0b\x7a\xb3\x6c\xe8\x79\xf3\x89\x0e\xcf\x73\xf5" +
+		"\x2f\x27\xbe\x69\x0f\xda\xee\x46\xc3\xce\x28\xf7\x53\x2b\x13\xc8\x56\xc3\x53\x42\xc8\x4b\xda\x6e\x20\x96\x63\x10\xfa\xdc\x01\xd0" +
+		"\x2b\x2a\xe1\xac\xf6\x8b\x7b\x8d\x24\x16\xbe\xbf\x3d\x4f\x62\x34\xb7\x63\xfe\x04\xb8\x04\x3e\xe4\x8b\x83\x27\xbe\xbc\xa1\x6c\xf2" +
+		"\x03\x19\xd0\x62\x07\x2b\xef\x7e\xcc\xa5\xea\xc0\x6f\x97\xd4\xd5\x59\x52\xc1\x75\xab\x6b\x03\xea\xe6\x4b\x44\xc7\xdb\xf1\x1c\xfa" +
+		"\x28\x81\x3d\xca\xeb\xae\xaa\x82\x8a\x37\x6d\xf8\x7a\xf4\xa6\x3b\xc8\xb7\xbf\x27\xad\x49\xc6\x29\x8e\xf7\xb3\x87\xbf\x28\x52\x6d" +
+		"\x27\x27\x67\x3b\x2c\xcb\xc9\x03\xf1\x81\xbf\x38\xe1\xc1\xd4\x0d\x20\x33\x86\x52\x00\xc3\x52\xbc\x15\x09\x28\xad\xdd\xf9\xcb\x78" +
+		"\x23\x4e\xc4\x5c\xa2\x77\x27\xc2\xe7\x4a\xbd\x2b\x2a\x14\x94\xcd\x6e\xfb\xd4\x3e\x34\x05\x87\xd6\xb8\xfb\x9e\x31\xe6\x5c\xc6\x32" +
+		"\x15\xb5\x25\x34\x03\x1a\xe1\x8f\x7f\x86\x2c\xb2\xcf\x7c\xf7\x60\xab\x10\xa8\x15\x0a\x33\x7b\x1c\xcd\x99\xff\x6e\x87\x97\xd4\x28" +
+		"\x0d\xc8\xfa\xd6\xd9\xe4\xb3\x5f\x5e\xd9\xa3\xd1\x86\xb7\x9c\xe3\x8e\x0e\x8a\x8d\x1b\x58\xb1\x32\xd7\x01\xd4\xee\xcf\x68\xd1\xf6" +
+		"\x1b\xcd\x95\xff\xc2\x11\xfb\xca\x60\x0f\x70\x5f\xad\x3f\xb5\x67\xea\x4e\xb3\x78\xf6\x2e\x1f\xec\x97\x80\x55\x18\xa4\x7e\x4d\x9c" +
+		"\x10\x52\x0b\x0a\xb7\x21\xca\xdf\xe9\xef\xf8\x1b\x01\x6f\xc3\x4d\xc7\x6d\xa3\x6c\x25\x78\x93\x78\x17\xcb\x97\x8d\x06\x9d\xe5\x59" +
+		"\x1f\x6d\x48\x14\x9b\x8e\x7f\x7d\x9b\x25\x7d\x8e\xd5\xfb\xba\xf4\x29\x32\x49\x80\x75\xfe\xd0\xac\xe8\x8a\x9e\xb8\x1f\x56\x27\xf6" +
+		"\x1d\x96\x55\xf6\x52\x30\x90\x14\xd2\x9e\x00\xef\x35\xa2\x08\x9b\xff\xf8\xdc\x1c\x81\x6f\x0d\xc9\xca\x34\xbd\xb5\x46\x0c\x87\x05" +
+		"\x04\xdf\x5a\x56\xff\x95\xbc\xaf\xb0\x51\xf7\xb1\xcd\x43\xa9\x9b\xa7\x31\xff\x67\xe4\x70\x32\x05\x8f\xe3\xd4\x18\x56\x97\xcc\x7d" +
+		"\x06\x72\xd9\x95\xf8\xff\xf6\x40\x15\x1b\x3d\x29\x0c\xed\xaf\x14\x86\x90\xa1\x0a\x8c\x84\x24\xa7\xf6\xec\x28\x2b\x6e\x4b\xe8\x28" +
+		"\x09\x99\x52\xb4\x14\x88\x44\x54\xb2\x12\x00\xd7\xff\xaf\xdd\x5f\x0c\x9a\x9d\xcc\x06\xf2\x70\x8e\x9f\xc1\xd8\x20\x9b\x5c\x75\xb9" +
+		"\x05\x2c\xba\x22\x55\xdf\xd0\x0c\x7c\x48\x31\x43\xba\x8d\x46\x94\x48\xe4\x35\x86\xa9\xb4\xcd\x91\x83\xfd\x0e\x84\x3a\x6b\x9f\xa6" +
+		"\x0b\x8b\xad\xee\x69\x0a\xdb\x8e\xb0\xbd\x74\x71\x2b\x79\x99\xaf\x82\xde\x55\x70\x72\x51\xad\x77\x16\x07\x7c\xb9\x3c\x46\x4d\xdc" +
+		"\x11\x9b\x15\x90\xf1\x33\x07\xaf\x5a\x1e\xe6\x51\x02\x0c\x07\xc7\x49\xc1\x5d\x60\x68\x3a\x80\x50\xb9\x63\xd0\xa8\xe4\xb2\xbd\xd1" +
+		"\x03\x15\x0b\x7c\xd6\xd5\xd1\x7b\x25\x29\xd3\x6b\xe0\xf6\x7b\x83\x2c\x4a\xcf\xc8\x84\xef\x4e\xe5\xce\x15\xbe\x0b\xfb\x4a\x8d\x09" +
+		"\x2c\xc6\x18\x2c\x5e\x14\x54\x6e\x3c\xf1\x95\x1f\x17\x39\x12\x35\x53\x74\xef\xb8\x3d\x80\x89\x8a\xbe\x69\xcb\x31\x7c\x9e\xa5\x65" +
+		"\x00\x50\x32\x55\x1e\x63\x78\xc4\x50\xcf\xe1\x29\xa4\x04\xb3\x76\x42\x18\xca\xde\xda\xc1\x4e\x2b\x92\xd2\xcd\x73\x11\x1b\xf0\xf9" +
+		"\x23\x32\x37\xe3\x28\x9b\xaa\x34\xbb\x14\x7e\x97\x2e\xbc\xb9\x51\x64\x69\xc3\x99\xfc\xc0\x69\xfb\x88\xf9\xda\x2c\xc2\x82\x76\xb5" +
+		"\x05\xc8\xf4\xf4\xeb\xd4\xa6\xe3\xc9\x80\xd3\x16\x74\xbf\xbe\x63\x23\x03\x7f\x21\xb3\x4a\xe5\xa4\xe8\x0c\x2d\x4c\x24\xd6\x02\x80" +
+		"\x0a\x7b\x1d\xb1\x30\x42\xd3\x96\xba\x05\xd8\x18\xa3\x19\xf2\x52\x52\xbc\xf3\x5e\xf3\xae\xed\x91\xee\x1f\x09\xb2\x59\x0f\xc6\x5b" +
+		"\x2a\x73\xb7\x1f\x9b\x21\x0c\xf5\xb1\x42\x96\x57\x2c\x9d\x32\xdb\xf1\x56\xe2\xb0\x86\xff\x47\xdc\x5d\xf5\x42\x36\x5a\x40\x4e\xc0" +
+		"\x1a\xc9\xb0\x41\x7a\xbc\xc9\xa1\x93\x51\x07\xe9\xff\xc9\x1d\xc3\xec\x18\xf2\xc4\xdb\xe7\xf2\x29\x76\xa7\x60\xbb\x5c\x50\xc4\x60" +
+		"\x12\xc0\x33\x9a\xe0\x83\x74\x82\x3f\xab\xb0\x76\x70\x7e\xf4\x79\x26\x9f\x3e\x4d\x6c\xb1\x04\x34\x90\x15\xee\x04\x6d\xc9\x3f\xc0" +
+		"\x0b\x74\x75\xb1\x02\xa1\x65\xad\x7f\x5b\x18\xdb\x4e\x1e\x70\x4f\x52\x90\x0a\xa3\x25\x3b\xaa\xc6\x82\x46\x68\x2e\x56\xe9\xa2\x8e" +
+		"\x03\x7c\x28\x49\xe1\x91\xca\x3e\xdb\x1c\x5e\x49\xf6\xe8\xb8\x91\x7c\x84\x3e\x37\x93\x66\xf2\xea\x32\xab\x3a\xa8\x8d\x7f\x84\x48" +
+		"\x05\xa6\x81\x1f\x85\x56\xf0\x14\xe9\x26\x74\x66\x1e\x21\x7e\x9b\xd5\x20\x6c\x5c\x93\xa0\x7d\xc1\x45\xfd\xb1\x76\xa7\x16\x34\x6f" +
+		"\x29\xa7\x95\xe7\xd9\x80\x28\x94\x6e\x94\x7b\x75\xd5\x4e\x9f\x04\x40\x76\xe8\x7a\x7b\x28\x83\xb4\x7b\x67\x5e\xf5\xf3\x8b\xd6\x6e" +
+		"\x20\x43\x9a\x0c\x84\xb3\x22\xeb\x45\xa3\x85\x7a\xfc\x18\xf5\x82\x6e\x8c\x73\x82\xc8\xa1\x58\x5c\x50\x7b\xe1\x99\x98\x1f\xd2\x2f" +
+		"\x2e\x0b\xa8\xd9\x4d\x9e\xcf\x4a\x94\xec\x20\x50\xc7\x37\x1f\xf1\xbb\x50\xf2\x77\x99\xa8\x4b\x6d\x4a\x2a\x6f\x2a\x09\x82\xc8\x87" +
+		"\x14\x3f\xd1\x15\xce\x08\xfb\x27\xca\x38\xeb\x7c\xce\x82\x2b\x45\x17\x82\x2c\xd2\x10\x90\x48\xd2\xe6\xd0\xdd\xcc\xa1\x7d\x71\xc8" +
+		"\x0c\x64\xcb\xec\xb1\xc7\x34\xb8\x57\x96\x8d\xbb\xdc\xf8\x13\xcd\xf8\x61\x16\x59\x32\x3d\xbc\xbf\xc8\x43\x23\x62\x3b\xe9\xca\xf1" +
+		"\x02\x8a\x30\x58\x47\xc6\x83\xf6\x46\xfc\xa9\x25\xc1\x63\xff\x5a\xe7\x4f\x34\x8d\x62\xc2\xb6\x70\xf1\x42\x6c\xef\x94\x03\xda\x53" +
+		"\x2e\x4e\xf5\x10\xff\x0b\x6f\xda\x5f\xa9\x40\xab\x4c\x43\x80\xf2\x6a\x6b\xcb\x64\xd8\x94\x27\xb8\x24\xd6\x75\x5b\x5d\xb9\xe3\x0c" +
+		"\x00\x81\xc9\x5b\xc4\x33\x84\xe6\x63\xd7\x92\x70\xc9\x56\xce\x3b\x89\x25\xb4\xf6\xd0\x33\xb0\x78\xb9\x63\x84\xf5\x05\x79\x40\x0e" +
+		"\x2e\xd5\xf0\xc9\x1c\xbd\x97\x49\x18\x7e\x2f\xad\xe6\x87\xe0\x5e\xe2\x49\x1b\x34\x9c\x03\x9a\x0b\xba\x8a\x9f\x40\x23\xa0\xbb\x38" +
+		"\x30\x50\x99\x91\xf8\x8d\xa3\x50\x4b\xbf\x37\x4e\xd5\xaa\xe2\xf0\x34\x48\xa2\x2c\x76\x23\x4c\x8c\x99\x0f\x01\xf3\x3a\x73\x52\x06" +
+		"\x1c\x3f\x20\xfd\x55\x40\x9a\x53\x22\x1b\x7c\x4d\x49\xa3\x56\xb9\xf0\xa1\x11\x9f\xb2\x06\x7b\x41\xa7\x52\x90\x94\x42\x4e\xc6\xad" +
+		"\x10\xb4\xe7\xf3\xab\x5d\xf0\x03\x04\x95\x14\x45\x9b\x6e\x18\xee\xc4\x6b\xb2\x21\x3e\x8e\x13\x1e\x17\x08\x87\xb4\x7d\xdc\xb9\x6c" +
+		"\x2a\x19\x82\x97\x9c\x3f\xf7\xf4\x3d\xdd\x54\x3d\x89\x1c\x2a\xbd\xdd\x80\xf8\x04\xc0\x77\xd7\x75\x03\x9a\xa3\x50\x2e\x43\xad\xef" +
+		"\x1c\x74\xee\x64\xf1\x5e\x1d\xb6\xfe\xdd\xbe\xad\x56\xd6\xd5\x5d\xba\x43\x1e\xbc\x39\x6c\x9a\xf9\x5c\xad\x0f\x13\x15\xbd\x5c\x91" +
+		"\x07\x53\x3e\xc8\x50\xba\x7f\x98\xea\xb9\x30\x3c\xac\xe0\x1b\x4b\x9e\x4f\x2e\x8b\x82\x70\x8c\xfa\x9c\x2f\xe4\x5a\x0a\xe1\x46\xa0" +
+		"\x21\x57\x6b\x43\x8e\x50\x04\x49\xa1\x51\xe4\xee\xaf\x17\xb1\x54\x28\x5c\x68\xf4\x2d\x42\xc1\x80\x8a\x11\xab\xf3\x76\x4c\x07\x50" +
+		"\x2f\x17\xc0\x55\x9b\x8f\xe7\x96\x08\xad\x5c\xa1\x93\xd6\x2f\x10\xbc\xe8\x38\x4c\x81\x5f\x09\x06\x74\x3d\x69\x30\x83\x6d\x4a\x9e" +
+		"\x2d\x47\x7e\x38\x62\xd0\x77\x08\xa7\x9e\x8a\xae\x94\x61\x70\xbc\x97\x75\xa4\x20\x13\x18\x47\x4a\xe6\x65\xb0\xb1\xb7\xe2\x73\x0e" +
+		"\x16\x2f\x52\x43\x96\x70\x64\xc3\x90\xe0\x95\x57\x79\x84\xf2\x91\xaf\xba\x22\x66\xc3\x8f\x5a\xbc\xd8\x9b\xe0\xf5\xb2\x74\x7e\xab" +
+		"\x2b\x4c\xb2\x33\xed\xe9\xba\x48\x26\x4e\xcd\x2c\x8a\xe5\x0d\x1a\xd7\xa8\x59\x6a\x87\xf2\x9f\x8a\x77\x77\xa7\x00\x92\x39\x33\x11" +
+		"\x2c\x8f\xbc\xb2\xdd\x85\x73\xdc\x1d\xba\xf8\xf4\x62\x28\x54\x77\x6d\xb2\xee\xce\x6d\x85\xc4\xcf\x42\x54\xe7\xc3\x5e\x03\xb0\x7a" +
+		"\x1d\x6f\x34\x77\x25\xe4\x81\x6a\xf2\xff\x45\x3f\x0c\xd5\x6b\x19\x9e\x1b\x61\xe9\xf6\x01\xe9\xad\xe5\xe8\x8d\xb8\x70\x94\x9d\xa9" +
+		"\x20\x4b\x0c\x39\x7f\x4e\xbe\x71\xeb\xc2\xd8\xb3\xdf\x5b\x91\x3d\xf9\xe6\xac\x02\xb6\x8d\x31\x32\x4c\xd4\x9a\xf5\xc4\x56\x55\x29" +
+		"\x0c\x4c\xb9\xdc\x3c\x4f\xd8\x17\x4f\x11\x49\xb3\xc6\x3c\x3c\x2f\x9e\xcb\x82\x7c\xd7\xdc\x25\x53\x4f\xf8\xfb\x75\xbc\x79\xc5\x02" +
+		"\x17\x4a\xd6\x1a\x14\x48\xc8\x99\xa2\x54\x16\x47\x4f\x49\x30\x30\x1e\x5c\x49\x47\x52\x79\xe0\x63\x9a\x61\x6d\xdc\x45\xbc\x7b\x54" +
+		"\x1a\x96\x17\x7b\xcf\x4d\x8d\x89\xf7\x59\xdf\x4e\xc2\xf3\xcd\xe2\xea\xaa\x28\xc1\x77\xcc\x0f\xa1\x3a\x98\x16\xd4\x9a\x38\xd2\xef" +
+		"\x06\x6d\x04\xb2\x43\x31\xd7\x1c\xd0\xef\x80\x54\xbc\x60\xc4\xff\x05\x20\x2c\x12\x6a\x23\x3c\x1a\x82\x42\xac\xe3\x60\xb8\xa3\x0a" +
+		"\x2a\x4c\x4f\xc6\xec\x0b\x0c\xf5\x21\x95\x78\x28\x71\xc6\xdd\x3b\x38\x1c\xc6\x5f\x72\xe0\x2a\xd5\x27\x03\x7a\x62\xaa\x1b\xd8\x04" +
+		"\x13\xab\x2d\x13\x6c\xcf\x37\xd4\x47\xe9\xf2\xe1\x4a\x7c\xed\xc9\x5e\x72\x7f\x84\x46\xf6\xd9\xd7\xe5\x5a\xfc\x01\x21\x9f\xd6\x49" +
+		"\x11\x21\x55\x2f\xca\x26\x06\x16\x19\xd2\x4d\x84\x3d\xc8\x27\x69\xc1\xb0\x4f\xce\xc2\x6f\x55\x19\x4c\x2e\x3e\x86\x9a\xcc\x6a\x9a" +
+		"\x00\xef\x65\x33\x22\xb1\x3d\x6c\x88\x9b\xc8\x17\x15\xc3\x7d\x77\xa6\xcd\x26\x7d\x59\x5c\x4a\x89\x09\xa5\x54\x6c\x7c\x97\xcf\xf1" +
+		"\x0e\x25\x48\x3e\x45\xa6\x65\x20\x8b\x26\x1d\x8b\xa7\x40\x51\xe6\x40\x0c\x77\x6d\x65\x25\x95\xd9\x84\x5a\xca\x35\xd8\xa3\x97\xd3" +
+		"\x29\xf5\x36\xdc\xb9\xdd\x76\x82\x24\x52\x64\x65\x9e\x15\xd8\x8e\x39\x5a\xc3\xd4\xdd\xe9\x2d\x8c\x46\x44\x8d\xb9\x79\xee\xba\x89" +
+		"\x2a\x56\xef\x9f\x2c\x53\xfe\xba\xdf\xda\x33\x57\x5d\xbd\xbd\x88\x5a\x12\x4e\x27\x80\xbb\xea\x17\x0e\x45\x6b\xaa\xce\x0f\xa5\xbe" +
+		"\x1c\x83\x61\xc7\x8e\xb5\xcf\x5d\xec\xfb\x7a\x2d\x17\xb5\xc4\x09\xf2\xae\x29\x99\xa4\x67\x62\xe8\xee\x41\x62\x40\xa8\xcb\x9a\xf1" +
+		"\x15\x1a\xff\x5f\x38\xb2\x0a\x0f\xc0\x47\x30\x89\xaa\xf0\x20\x6b\x83\xe8\xe6\x8a\x76\x45\x07\xbf\xd3\xd0\xab\x4b\xe7\x43\x19\xc5" +
+		"\x04\xc6\x18\x7e\x41\xed\x88\x1d\xc1\xb2\x39\xc8\x8f\x7f\x9d\x43\xa9\xf5\x2f\xc8\xc8\xb6\xcd\xd1\xe7\x6e\x47\x61\x5b\x51\xf1\x00" +
+		"\x13\xb3\x7b\xd8\x0f\x4d\x27\xfb\x10\xd8\x43\x31\xf6\xfb\x6d\x53\x4b\x81\xc6\x1e\xd1\x57\x76\x44\x9e\x80\x1b\x7d\xdc\x9c\x29\x67" +
+		"\x01\xa5\xc5\x36\x27\x3c\x2d\x9d\xf5\x78\xbf\xbd\x32\xc1\x7b\x7a\x2c\xe3\x66\x4c\x2a\x52\x03\x2c\x93\x21\xce\xb1\xc4\xe8\xa8\xe4" +
+		"\x2a\xb3\x56\x18\x34\xca\x73\x83\x5a\xd0\x5f\x5d\x7a\xcb\x95\x0b\x4a\x9a\x2c\x66\x6b\x97\x26\xda\x83\x22\x39\x06\x5b\x7c\x3b\x02" +
+		"\x1d\x4d\x8e\xc2\x91\xe7\x20\xdb\x20\x0f\xe6\xd6\x86\xc0\xd6\x13\xac\xaf\x6a\xf4\xe9\x5d\x3b\xf6\x9f\x7e\xd5\x16\xa5\x97\xb6\x46" +
+		"\x04\x12\x94\xd2\xcc\x48\x4d\x22\x8f\x57\x84\xfe\x79\x19\xfd\x2b\xb9\x25\x35\x12\x40\xa0\x4b\x71\x15\x14\xc9\xc8\x0b\x65\xaf\x1d" +
+		"\x15\x4a\xc9\x8e\x01\x70\x8c\x61\x1c\x4f\xa7\x15\x99\x1f\x00\x48\x98\xf5\x79\x39\xd1\x26\xe3\x92\x04\x29\x71\xdd\x90\xe8\x1f\xc6" +
+		"\x0b\x33\x9d\x8a\xcc\xa7\xd4\xf8\x3e\xed\xd8\x40\x93\xae\xf5\x10\x50\xb3\x68\x4c\x88\xf8\xb0\xb0\x45\x24\x56\x3b\xc6\xea\x4d\xa4" +
+		"\x09\x55\xe4\x9e\x66\x10\xc9\x42\x54\xa4\xf8\x4c\xfb\xab\x34\x45\x98\xf0\xe7\x1e\xaf\xf4\xa7\xdd\x81\xed\x95\xb5\x08\x39\xc8\x2e" +
+		"\x06\x74\x6a\x61\x56\xeb\xa5\x44\x26\xb9\xe2\x22\x06\xf1\x5a\xbc\xa9\xa6\xf4\x1e\x6f\x53\x5c\x6f\x35\x25\x40\x1e\xa0\x65\x46\x26" +
+		"\x0f\x18\xf5\xa0\xec\xd1\x42\x3c\x49\x6f\x38\x20\xc5\x49\xc2\x78\x38\xe5\x79\x0e\x2b\xd0\xa1\x96\xac\x91\x7c\x7f\xf3\x20\x77\xfb" +
+		"\x04\xf6\xee\xca\x17\x51\xf7\x30\x8a\xc5\x9e\xff\x5b\xeb\x26\x1e\x4b\xb5\x63\x58\x3e\xde\x7b\xc9\x2a\x73\x82\x23\xd6\xf7\x6e\x13" +
+		"\x2b\x56\x97\x33\x64\xc4\xc4\xf5\xc1\xa3\xec\x4d\xa3\xcd\xce\x03\x88\x11\xeb\x11\x6f\xb3\xe4\x5b\xc1\x76\x8d\x26\xfc\x0b\x37\x58" +
+		"\x12\x37\x69\xdd\x49\xd5\xb0\x54\xdc\xd7\x6b\x89\x80\x4b\x1b\xcb\x8e\x13\x92\xb3\x85\x71\x6a\x5d\x83\xfe\xb6\x5d\x43\x7f\x29\xef" +
+		"\x21\x47\xb4\x24\xfc\x48\xc8\x0a\x88\xee\x52\xb9\x11\x69\xaa\xce\xa9\x89\xf6\x44\x64\x71\x15\x09\x94\x25\x7b\x2f\xb0\x1c\x63\xe9" +
+		"\x0f\xdc\x1f\x58\x54\x8b\x85\x70\x1a\x6c\x55\x05\xea\x33\x2a\x29\x64\x7e\x6f\x34\xad\x42\x43\xc2\xea\x54\xad\x89\x7c\xeb\xe5\x4d" +
+		"\x12\x37\x3a\x82\x51\xfe\xa0\x04\xdf\x68\xab\xcf\x0f\x77\x86\xd4\xbc\xef\xf2\x8c\x5d\xbb\xe0\xc3\x94\x4f\x68\x5c\xc0\xa0\xb1\xf2" +
+		"\x21\xe4\xf4\xea\x5f\x35\xf8\x5b\xad\x7e\xa5\x2f\xf7\x42\xc9\xe8\xa6\x42\x75\x6b\x6a\xf4\x42\x03\xdd\x8a\x1f\x35\xc1\xa9\x00\x35" +
+		"\x16\x24\x39\x16\xd6\x9d\x2c\xa3\xdf\xb4\x72\x22\x24\xd4\xc4\x62\xb5\x73\x66\x49\x2f\x45\xe9\x0d\x8a\x81\x93\x4f\x1b\xc3\xb1\x47" +
+		"\x1e\xfb\xe4\x6d\xd7\xa5\x78\xb4\xf6\x6f\x9a\xdb\xc8\x8b\x43\x78\xab\xc2\x15\x66\xe1\xa0\x45\x3c\xa1\x3a\x41\x59\xca\xc0\x4a\xc2" +
+		"\x07\xea\x5e\x85\x37\xcf\x5d\xd0\x88\x86\x02\x0e\x23\xa7\xf3\x87\xd4\x68\xd5\x52\x5b\xe6\x6f\x85\x3b\x67\x2c\xc9\x6a\x88\x96\x9a" +
+		"\x05\xa8\xc4\xf9\x96\x8b\x8a\xa3\xb7\xb4\x78\xa3\x0f\x9a\x5b\x63\x65\x0f\x19\xa7\x5e\x7c\xe1\x1c\xa9\xfe\x16\xc0\xb7\x6c\x00\xbc" +
+		"\x20\xf0\x57\x71\x2c\xc2\x16\x54\xfb\xfe\x59\xbd\x34\x5e\x8d\xac\x3f\x78\x18\xc7\x01\xb9\xc7\x88\x2d\x9d\x57\xb7\x2a\x32\xe8\x3f" +
+		"\x04\xa1\x2e\xde\xda\x9d\xfd\x68\x96\x72\xf8\xc6\x7f\xee\x31\x63\x6d\xcd\x8e\x88\xd0\x1d\x49\x01\x9b\xd9\x0b\x33\xeb\x33\xdb\x69" +
+		"\x27\xe8\x8d\x8c\x15\xf3\x7d\xce\xe4\x4f\x1e\x54\x25\xa5\x1d\xec\xbd\x13\x6c\xe5\x09\x1a\x67\x67\xe4\x9e\xc9\x54\x4c\xcd\x10\x1a" +
+		"\x2f\xee\xd1\x7b\x84\x28\x5e\xd9\xb8\xa5\xc8\xc5\xe9\x5a\x41\xf6\x6e\x09\x66\x19\xa7\x70\x32\x23\x17\x6c\x41\xee\x43\x3d\xe4\xd1" +
+		"\x1e\xd7\xcc\x76\xed\xf4\x5c\x7c\x40\x42\x41\x42\x0f\x72\x9c\xf3\x94\xe5\x94\x29\x11\x31\x2a\x0d\x69\x72\xb8\xbd\x53\xaf\xf2\xb8" +
+		"\x15\x74\x2e\x99\xb9\xbf\xa3\x23\x15\x7f\xf8\xc5\x86\xf5\x66\x0e\xac\x67\x83\x47\x61\x44\xcd\xca\xdf\x28\x74\xbe\x45\x46\x6b\x1a" +
+		"\x1a\xac\x28\x53\x87\xf6\x5e\x82\xc8\x95\xfc\x68\x87\xdd\xf4\x05\x77\x10\x74\x54\xc6\xec\x03\x17\x28\x4f\x03\x3f\x27\xd0\xc7\x85" +
+		"\x25\x85\x1c\x3c\x84\x5d\x47\x90\xf9\xdd\xad\xbd\xb6\x05\x73\x57\x83\x2e\x2e\x7a\x49\x77\x5f\x71\xec\x75\xa9\x65\x54\xd6\x7c\x77" +
+		"\x15\xa5\x82\x15\x65\xcc\x2e\xc2\xce\x78\x45\x7d\xb1\x97\xed\xf3\x53\xb7\xeb\xba\x2c\x55\x23\x37\x0d\xdc\xcc\x3d\x9f\x14\x6a\x67" +
+		"\x24\x11\xd5\x7a\x48\x13\xb9\x98\x0e\xfa\x7e\x31\xa1\xdb\x59\x66\xdc\xf6\x4f\x36\x04\x42\x77\x50\x2f\x15\x48\x5f\x28\xc7\x17\x27" +
+		"\x00\x2e\x6f\x8d\x65\x20\xcd\x47\x13\xe3\x35\xb8\xc0\xb6\xd2\xe6\x47\xe9\xa9\x8e\x12\xf4\xcd\x25\x58\x82\x8b\x5e\xf6\xcb\x4c\x9b" +
+		"\x2f\xf7\xbc\x8f\x43\x80\xcd\xe9\x97\xda\x00\xb6\x16\xb0\xfc\xd1\xaf\x8f\x0e\x91\xe2\xfe\x1e\xd7\x39\x88\x34\x60\x9e\x03\x15\xd2" +
+		"\x00\xb9\x83\x1b\x94\x85\x25\x59\x5e\xe0\x27\x24\x47\x1b\xcd\x18\x2e\x95\x21\xf6\xb7\xbb\x68\xf1\xe9\x3b\xe4\xfe\xbb\x0d\x3c\xbe" +
+		"\x0a\x2f\x53\x76\x8b\x8e\xbf\x6a\x86\x91\x3b\x0e\x57\xc0\x4e\x01\x1c\xa4\x08\x64\x8a\x47\x43\xa8\x7d\x77\xad\xbf\x0c\x9c\x35\x12" +
+		"\x00\x24\x81\x56\x14\x2f\xd0\x37\x3a\x47\x9f\x91\xff\x23\x9e\x96\x0f\x59\x9f\xf7\xe9\x4b\xe6\x9b\x7f\x2a\x29\x03\x05\xe1\x19\x8d" +
+		"\x17\x1d\x56\x20\xb8\x7b\xfb\x13\x28\xcf\x8c\x02\xab\x3f\x0c\x9a\x39\x71\x96\xaa\x6a\x54\x2c\x23\x50\xeb\x51\x2a\x2b\x2b\xcd\xa9" +
+		"\x17\x0a\x4f\x55\x53\x6f\x7d\xc9\x70\x08\x7c\x7c\x10\xd6\xfa\xd7\x60\xc9\x52\x17\x2d\xd5\x4d\xd9\x9d\x10\x45\xe4\xec\x34\xa8\x08" +
+		"\x29\xab\xa3\x3f\x79\x9f\xe6\x6c\x2e\xf3\x13\x4a\xea\x04\x33\x6e\xcc\x37\xe3\x8c\x1c\xd2\x11\xba\x48\x2e\xca\x17\xe2\xdb\xfa\xe1" +
+		"\x1e\x9b\xc1\x79\xa4\xfd\xd7\x58\xfd\xd1\xbb\x19\x45\x08\x8d\x47\xe7\x0d\x11\x4a\x03\xf6\xa0\xe8\xb5\xba\x65\x03\x69\xe6\x49\x73" +
+		"\x1d\xd2\x69\x79\x9b\x66\x0f\xad\x58\xf7\xf4\x89\x2d\xfb\x0b\x5a\xfe\xaa\xd8\x69\xa9\xc4\xb4\x4f\x9c\x9e\x1c\x43\xbd\xaf\x8f\x09" +
+		"\x22\xcd\xbc\x8b\x70\x11\x7a\xd1\x40\x11\x81\xd0\x2e\x15\x45\x9e\x7c\xcd\x42\x6f\xe8\x69\xc7\xc9\x5d\x1d\xd2\xcb\x0f\x24\xaf\x38" +
+		"\x0e\xf0\x42\xe4\x54\x77\x1c\x53\x3a\x9f\x57\xa5\x5c\x50\x3f\xce\xfd\x31\x50\xf5\x2e\xd9\x4a\x7c\xd5\xba\x93\xb9\xc7\xda\xce\xfd" +
+		"\x11\x60\x9e\x06\xad\x6c\x8f\xe2\xf2\x87\xf3\x03\x60\x37\xe8\x85\x13\x18\xe8\xb0\x8a\x03\x59\xa0\x3b\x30\x4f\xfc\xa6\x2e\x82\x84" +
+		"\x11\x66\xd9\xe5\x54\x61\x6d\xba\x9e\x75\x3e\xea\x42\x7c\x17\xb7\xfe\xcd\x58\xc0\x76\xdf\xe4\x27\x08\xb0\x8f\x5b\x78\x3a\xa9\xaf" +
+		"\x2d\xe5\x29\x89\x43\x1a\x85\x95\x93\x41\x30\x26\x35\x44\x13\xdb\x17\x7f\xbf\x4c\xd2\xac\x0b\x56\xf8\x55\xa8\x88\x35\x7e\xe4\x66" +
+		"\x30\x06\xeb\x4f\xfc\x7a\x85\x81\x9a\x6d\xa4\x92\xf3\xa8\xac\x1d\xf5\x1a\xee\x5b\x17\xb8\xe8\x9d\x74\xbf\x01\xcf\x5f\x71\xe9\xad" +
+		"\x2a\xf4\x1f\xbb\x61\xba\x8a\x80\xfd\xcf\x6f\xff\x9e\x3f\x6f\x42\x29\x93\xfe\x8f\x0a\x46\x39\xf9\x62\x34\x4c\x82\x25\x14\x50\x86" +
+		"\x11\x9e\x68\x4d\xe4\x76\x15\x5f\xe5\xa6\xb4\x1a\x8e\xbc\x85\xdb\x87\x18\xab\x27\x88\x9e\x85\xe7\x81\xb2\x14\xba\xce\x48\x27\xc3" +
+		"\x18\x35\xb7\x86\xe2\xe8\x92\x5e\x18\x8b\xea\x59\xae\x36\x35\x37\xb5\x12\x48\xc2\x38\x28\xf0\x47\xcf\xf7\x84\xb9\x7b\x3f\xd8\x00" +
+		"\x28\x20\x1a\x34\xc5\x94\xdf\xa3\x4d\x79\x49\x96\xc6\x43\x3a\x20\xd1\x52\xba\xc2\xa7\x90\x5c\x92\x6c\x40\xe2\x85\xab\x32\xee\xb6" +
+		"\x08\x3e\xfd\x7a\x27\xd1\x75\x10\x94\xe8\x0f\xef\xaf\x78\xb0\x00\x86\x4c\x82\xeb\x57\x11\x87\x72\x4a\x76\x1f\x88\xc2\x2c\xc4\xe7" +
+		"\x0b\x6f\x88\xa3\x57\x71\x99\x52\x61\x58\xe6\x1c\xee\xa2\x7b\xe8\x11\xc1\x6d\xf7\x77\x4d\xd8\x51\x9e\x07\x95\x64\xf6\x1f\xd1\x3b" +
+		"\x0e\xc8\x68\xe6\xd1\x5e\x51\xd9\x64\x4f\x66\xe1\xd6\x47\x1a\x94\x58\x95\x11\xca\x00\xd2\x9e\x10\x14\x39\x0e\x6e\xe4\x25\x4f\x5b" +
+		"\x2a\xf3\x3e\x3f\x86\x67\x71\x27\x1a\xc0\xc9\xb3\xed\x2e\x11\x42\xec\xd3\xe7\x4b\x93\x9c\xd4\x0d\x00\xd9\x37\xab\x84\xc9\x85\x91" +
+		"\x0b\x52\x02\x11\xf9\x04\xb5\xe7\xd0\x9b\x5d\x96\x1c\x6a\xce\x77\x34\x56\x8c\x54\x7d\xd6\x85\x8b\x36\x4c\xe5\xe4\x79\x51\xf1\x78" +
+		"\x0b\x2d\x72\x2d\x09\x19\xa1\xaa\xd8\xdb\x58\xf1\x00\x62\xa9\x2e\xa0\xc5\x6a\xc4\x27\x0e\x82\x2c\xca\x22\x86\x20\x18\x8a\x1d\x40" +
+		"\x1f\x79\x0d\x4d\x7f\x8c\xf0\x94\xd9\x80\xce\xb3\x7c\x24\x53\xe9\x57\xb5\x4a\x99\x91\xca\x38\xbb\xe0\x06\x1d\x1e\xd6\xe5\x62\xd4" +
+		"\x01\x71\xeb\x95\xdf\xbf\x7d\x1e\xae\xa9\x7c\xd3\x85\xf7\x80\x15\x08\x85\xc1\x62\x35\xa2\xa6\xa8\xda\x92\xce\xb0\x1e\x50\x42\x33" +
+		"\x0c\x2d\x0e\x3b\x5f\xd5\x75\x49\x32\x9b\xf6\x88\x5d\xa6\x6b\x9b\x79\x0b\x40\xde\xfd\x2c\x86\x50\x76\x23\x05\x38\x1b\x16\x88\x73" +
+		"\x11\x62\xfb\x28\x68\x9c\x27\x15\x4e\x5a\x82\x28\xb4\xe7\x2b\x37\x7c\xbc\xaf\xa5\x89\xe2\x83\xc3\x5d\x38\x03\x05\x44\x07\xa1\x8d" +
+		"\x2f\x14\x59\xb6\x5d\xee\x44\x1b\x64\xad\x38\x6a\x91\xe8\x31\x0f\x28\x2c\x5a\x92\xa8\x9e\x19\x92\x16\x23\xef\x82\x49\x71\x1b\xc0" +
+		"\x1e\x6f\xf3\x21\x6b\x68\x8c\x3d\x99\x6d\x74\x36\x7d\x5c\xd4\xc1\xbc\x48\x9d\x46\x75\x4e\xb7\x12\xc2\x43\xf7\x0d\x1b\x53\xcf\xbb" +
+		"\x01\xca\x8b\xe7\x38\x32\xb8\xd0\x68\x14\x87\xd2\x7d\x15\x78\x02\xd7\x41\xa6\xf3\x6c\xdc\x2a\x05\x76\x88\x1f\x93\x26\x47\x88\x75" +
+		"\x1f\x77\x35\x70\x6f\xfe\x9f\xc5\x86\xf9\x76\xd5\xbd\xf2\x23\xdc\x68\x02\x86\x08\x0b\x10\xce\xa0\x0b\x9b\x5d\xe3\x15\xf9\x65\x0e" +
+		"\x25\x22\xb6\x0f\x4e\xa3\x30\x76\x40\xa0\xc2\xdc\xe0\x41\xfb\xa9\x21\xac\x10\xa3\xd5\xf0\x96\xef\x47\x45\xca\x83\x82\x85\xf0\x19" +
+		"\x23\xf0\xbe\xe0\x01\xb1\x02\x9d\x52\x55\x07\x5d\xdc\x95\x7f\x83\x34\x18\xca\xd4\xf5\x2b\x6c\x3f\x8c\xe1\x6c\x23\x55\x72\x57\x5b" +
+		"\x2b\xc1\xae\x8b\x8d\xdb\xb8\x1f\xca\xac\x2d\x44\x55\x5e\xd5\x68\x5d\x14\x26\x33\xe9\xdf\x90\x5f\x66\xd9\x40\x10\x93\x08\x2d\x59" +
+		"\x0f\x94\x06\xb8\x29\x65\x64\xa3\x73\x04\x50\x7b\x8d\xba\x3e\xd1\x62\x37\x12\x73\xa0\x7b\x1f\xc9\x80\x11\xfc\xd6\xad\x72\x20\x5f" +
+		"\x23\x60\xa8\xeb\x0c\xc7\xde\xfa\x67\xb7\x29\x98\xde\x90\x71\x4e\x17\xe7\x5b\x17\x4a\x52\xee\x4a\xcb\x12\x6c\x8c\xd9\x95\xf0\xa8" +
+		"\x15\x87\x1a\x5c\xdd\xea\xd9\x76\x80\x4c\x80\x3c\xba\xef\x25\x5e\xb4\x81\x5a\x5e\x96\xdf\x8b\x00\x6d\xcb\xbc\x27\x67\xf8\x89\x48" +
+		"\x19\x3a\x56\x76\x69\x98\xee\x9e\x0a\x86\x52\xdd\x2f\x3b\x1d\xa0\x36\x2f\x4f\x54\xf7\x23\x79\x54\x4f\x95\x7c\xcd\xee\xfb\x42\x0f" +
+		"\x2a\x39\x4a\x43\x93\x4f\x86\x98\x2f\x9b\xe5\x6f\xf4\xfa\xb1\x70\x3b\x2e\x63\xc8\xad\x33\x48\x34\xe4\x30\x98\x05\xe7\x77\xae\x0f" +
+		"\x18\x59\x95\x4c\xfe\xb8\x69\x5f\x3e\x8b\x63\x5d\xcb\x34\x51\x92\x89\x2c\xd1\x12\x23\x44\x3b\xa7\xb4\x16\x6e\x88\x76\xc0\xd1\x42" +
+		"\x04\xe1\x18\x17\x63\x05\x0e\x58\x01\x34\x44\xdb\xcb\x99\xf1\x90\x2b\x11\xbc\x25\xd9\x0b\xbd\xca\x40\x8d\x38\x19\xf4\xfe\xd3\x2b" +
+		"\x0f\xdb\x25\x3d\xee\x83\x86\x9d\x40\xc3\x35\xea\x64\xde\x8c\x5b\xb1\x0e\xb8\x2d\xb0\x8b\x5e\x8b\x1f\x5e\x55\x52\xbf\xd0\x5f\x23" +
+		"\x05\x8c\xbe\x8a\x9a\x50\x27\xbd\xaa\x4e\xfb\x62\x3a\xde\xad\x62\x75\xf0\x86\x86\xf1\xc0\x89\x84\xa9\xd7\xc5\xba\xe9\xb4\xf1\xc0" +
+		"\x13\x82\xed\xce\x99\x71\xe1\x86\x49\x7e\xad\xb1\xae\xb1\xf5\x2b\x23\xb4\xb8\x3b\xef\x02\x3a\xb0\xd1\x52\x28\xb4\xcc\xec\xa5\x9a" +
+		"\x03\x46\x49\x90\xf0\x45\xc6\xee\x08\x19\xca\x51\xfd\x11\xb0\xbe\x7f\x61\xb8\xeb\x99\xf1\x4b\x77\xe1\xe6\x63\x46\x01\xd9\xe8\xb5" +
+		"\x23\xf7\xbf\xc8\x72\x0d\xc2\x96\xff\xf3\x3b\x41\xf9\x8f\xf8\x3c\x6f\xca\xb4\x60\x5d\xb2\xeb\x5a\xaa\x5b\xc1\x37\xae\xb7\x0a\x58" +
+		"\x0a\x59\xa1\x58\xe3\xee\xc2\x11\x7e\x6e\x94\xe7\xf0\xe9\xde\xcf\x18\xc3\xff\xd5\xe1\x53\x1a\x92\x19\x63\x61\x58\xbb\xaf\x62\xf2" +
+		"\x06\xec\x54\xc8\x03\x81\xc0\x52\xb5\x8b\xf2\x3b\x31\x2f\xfd\x3c\xe2\xc4\xeb\xa0\x65\x42\x0a\xf8\xf4\xc2\x3e\xd0\x07\x5f\xd0\x7b" +
+		"\x11\x88\x72\xdc\x83\x2e\x0e\xb5\x47\x6b\x56\x64\x8e\x86\x7e\xc8\xb0\x93\x40\xf7\xa7\xbc\xb1\xb4\x96\x2f\x0f\xf9\xed\x1f\x9d\x01" +
+		"\x13\xd6\x9f\xa1\x27\xd8\x34\x16\x5a\xd5\xc7\xcb\xa7\xad\x59\xed\x52\xe0\xb0\xf0\xe4\x2d\x7f\xea\x95\xe1\x90\x6b\x52\x09\x21\xb1" +
+		"\x16\x9a\x17\x7f\x63\xea\x68\x12\x70\xb1\xc6\x87\x7a\x73\xd2\x1b\xde\x14\x39\x42\xfb\x71\xdc\x55\xfd\x8a\x49\xf1\x9f\x10\xc7\x7b" +
+		"\x04\xef\x51\x59\x1c\x6e\xad\x97\xef\x42\xf2\x87\xad\xce\x40\xd9\x3a\xbe\xb0\x32\xb9\x22\xf6\x6f\xfb\x7e\x9a\x5a\x74\x50\x54\x4d" +
+		"\x25\x6e\x17\x5a\x1d\xc0\x79\x39\x0e\xcd\x7c\xa7\x03\xfb\x2e\x3b\x19\xec\x61\x80\x5d\x4f\x03\xce\xd5\xf4\x5e\xe6\xdd\x0f\x69\xec" +
+		"\x30\x10\x2d\x28\x63\x6a\xbd\x5f\xe5\xf2\xaf\x41\x2f\xf6\x00\x4f\x75\xcc\x36\x0d\x32\x05\xdd\x2d\xa0\x02\x81\x3d\x3e\x2c\xee\xb2" +
+		"\x10\x99\x8e\x42\xdf\xcd\x3b\xbf\x1c\x07\x14\xbc\x73\xeb\x1b\xf4\x04\x43\xa3\xfa\x99\xbe\xf4\xa3\x1f\xd3\x1b\xe1\x82\xfc\xc7\x92" +
+		"\x19\x3e\xdd\x8e\x9f\xcf\x3d\x76\x25\xfa\x7d\x24\xb5\x98\xa1\xd8\x9f\x33\x62\xea\xf4\xd5\x82\xef\xec\xad\x76\xf8\x79\xe3\x68\x60" +
+		"\x18\x16\x8a\xfd\x34\xf2\xd9\x15\xd0\x36\x8c\xe8\x0b\x7b\x33\x47\xd1\xc7\xa5\x61\xce\x61\x14\x25\xf2\x66\x4d\x7a\xa5\x1f\x0b\x5d" +
+		"\x29\x38\x3c\x01\xeb\xd3\xb6\xab\x0c\x01\x76\x56\xeb\xe6\x58\xb6\xa3\x28\xec\x77\xbc\x33\x62\x6e\x29\xe2\xe9\x5b\x33\xea\x61\x11" +
+		"\x10\x64\x6d\x2f\x26\x03\xde\x39\xa1\xf4\xae\x5e\x77\x71\xa6\x4a\x70\x2d\xb6\xe8\x6f\xb7\x6a\xb6\x00\xbf\x57\x3f\x90\x10\xc7\x11" +
+		"\x0b\xeb\x5e\x07\xd1\xb2\x71\x45\xf5\x75\xf1\x39\x5a\x55\xbf\x13\x2f\x90\xc2\x5b\x40\xda\x7b\x38\x64\xd0\x24\x2d\xcb\x11\x17\xfb" +
+		"\x16\xd6\x85\x25\x20\x78\xc1\x33\xdc\x0d\x3e\xca\xd6\x2b\x5c\x88\x30\xf9\x5b\xb2\xe5\x4b\x59\xab\xdf\xfb\xf0\x18\xd9\x6f\xa3\x36" +
+		"\x0a\x6a\xbd\x1d\x83\x39\x38\xf3\x3c\x74\x15\x4e\x04\x04\xb4\xb4\x0a\x55\x5b\xbb\xec\x21\xdd\xfa\xfd\x67\x2d\xd6\x20\x47\xf0\x1a" +
+		"\x1a\x67\x9f\x5d\x36\xeb\x7b\x5c\x8e\xa1\x2a\x4c\x2d\xed\xc8\xfe\xb1\x2d\xff\xee\xc4\x50\x31\x72\x70\xa6\xf1\x9b\x34\xcf\x18\x60" +
+		"\x09\x80\xfb\x23\x3b\xd4\x56\xc2\x39\x74\xd5\x0e\x0e\xbf\xde\x47\x26\xa4\x23\xea\xda\x4e\x8f\x6f\xfb\xc7\x59\x2e\x3f\x1b\x93\xd6" +
+		"\x16\x1b\x42\x23\x2e\x61\xb8\x4c\xbf\x18\x10\xaf\x93\xa3\x8f\xc0\xce\xce\x3d\x56\x28\xc9\x28\x20\x03\xeb\xac\xb5\xc3\x12\xc7\x2b" +
+		"\x0a\xda\x10\xa9\x0c\x7f\x05\x20\x95\x0f\x7d\x47\xa6\x0d\x5e\x6a\x49\x3f\x09\x78\x7f\x15\x64\xe5\xd0\x92\x03\xdb\x47\xde\x1a\x0b" +
+		"\x1a\x73\x0d\x37\x23\x10\xba\x82\x32\x03\x45\xa2\x9a\xc4\x23\x8e\xd3\xf0\x7a\x8a\x2b\x4e\x12\x1b\xb5\x0d\xdb\x9a\xf4\x07\xf4\x51" +
+		"\x2c\x81\x20\xf2\x68\xef\x05\x4f\x81\x70\x64\xc3\x69\xdd\xa7\xea\x90\x83\x77\xfe\xab\xa5\xc4\xdf\xfb\xda\x10\xef\x58\xe8\xc5\x56" +
+		"\x1c\x7c\x88\x24\xf7\x58\x75\x3f\xa5\x7c\x00\x78\x9c\x68\x42\x17\xb9\x30\xe9\x53\x13\xbc\xb7\x3e\x6e\x7b\x86\x49\xa4\x96\x8f\x70" +
+		"\x2c\xd9\xed\x31\xf5\xf8\x69\x1c\x8e\x39\xe4\x07\x7a\x74\xfa\xa0\xf4\x00\xad\x8b\x49\x1e\xb3\xf7\xb4\x7b\x27\xfa\x3f\xd1\xcf\x77" +
+		"\x23\xff\x4f\x9d\x46\x81\x34\x57\xcf\x60\xd9\x2f\x57\x61\x83\x99\xa5\xe0\x22\xac\x32\x1c\xa5\x50\x85\x4a\xe2\x39\x18\xa2\x2e\xea" +
+		"\x09\x94\x5a\x5d\x14\x7a\x4f\x66\xce\xec\xe6\x40\x5d\xdd\xd9\xd0\xaf\x5a\x2c\x51\x03\x52\x94\x07\xdf\xf1\xea\x58\xf1\x80\x42\x6d" +
+		"\x18\x8d\x9c\x52\x80\x25\xd4\xc2\xb6\x76\x60\xc6\xb7\x71\xb9\x0f\x7c\x7d\xa6\xea\xa2\x9d\x3f\x26\x8a\x6d\xd2\x23\xec\x6f\xc6\x30" +
+		"\x30\x50\xe3\x79\x96\x59\x6b\x7f\x81\xf6\x83\x11\x43\x1d\x87\x34\xdb\xa7\xd9\x26\xd3\x63\x35\x95\xe0\xc0\xd8\xdd\xf4\xf0\xf4\x7f" +
+		"\x15\xaf\x11\x69\x39\x68\x30\xa9\x16\x00\xca\x81\x02\xc3\x5c\x42\x6c\xea\xe5\x46\x1e\x3f\x95\xd8\x9d\x82\x95\x18\xd3\x0a\xfd\x78" +
+		"\x1d\xa6\xd0\x98\x85\x43\x2e\xa9\xa0\x6d\x9f\x37\xf8\x73\xd9\x85\xda\xe9\x33\xe3\x51\x46\x6b\x29\x04\x28\x4d\xa3\x32\x0d\x8a\xcc" +
+		"\x27\x96\xea\x90\xd2\x69\xaf\x29\xf5\xf8\xac\xf3\x39\x21\x12\x4e\x4e\x4f\xad\x3d\xbe\x65\x89\x45\xe5\x46\xee\x41\x1d\xda\xa9\xcb" +
+		"\x20\x2d\x7d\xd1\xda\x0f\x6b\x4b\x03\x25\xc8\xb3\x30\x77\x42\xf0\x1e\x15\x61\x2e\xc8\xe9\x30\x4a\x7c\xb0\x31\x9e\x01\xd3\x2d\x60" +
+		"\x09\x6d\x67\x90\xd0\x5b\xb7\x59\x15\x6a\x95\x2b\xa2\x63\xd6\x72\xa2\xd7\xf9\xc7\x88\xf4\xc8\x31\xa2\x9d\xac\xe4\xc0\xf8\xbe\x5f" +
+		"\x05\x4e\xfa\x1f\x65\xb0\xfc\xe2\x83\x80\x89\x65\x27\x5d\x87\x7b\x43\x8d\xa2\x3c\xe5\xb1\x3e\x19\x63\x79\x8c\xb1\x44\x7d\x25\xa4" +
+		"\x1b\x16\x2f\x83\xd9\x17\xe9\x3e\xdb\x33\x08\xc2\x98\x02\xde\xb9\xd8\xaa\x69\x01\x13\xb2\xe1\x48\x64\xcc\xf6\xe1\x8e\x41\x65\xf1" +
+		"\x21\xe5\x24\x1e\x12\x56\x4d\xd6\xfd\x9f\x1c\xdd\x2a\x0d\xe3\x9e\xed\xfe\xfc\x14\x66\xcc\x56\x8e\xc5\xce\xb7\x45\xa0\x50\x6e\xdc" +
+		"\x1c\xfb\x56\x62\xe8\xcf\x5a\xc9\x22\x6a\x80\xee\x17\xb3\x6a\xbe\xcb\x73\xab\x5f\x87\xe1\x61\x92\x7b\x43\x49\xe1\x0e\x4b\xdf\x08" +
+		"\x0f\x21\x17\x7e\x30\x2a\x77\x1b\xba\xe6\xd8\xd1\xec\xb3\x73\xb6\x2c\x99\xaf\x34\x62\x20\xac\x01\x29\xc5\x3f\x66\x6e\xb2\x41\x00" +
+		"\x16\x71\x52\x23\x74\x60\x69\x92\xaf\xfb\x0d\xd7\xf7\x1b\x12\xbe\xc4\x23\x6a\xed\xe6\x29\x05\x46\xbc\xef\x7e\x1f\x51\x5c\x23\x20" +
+		"\x0f\xa3\xec\x5b\x94\x88\x25\x9c\x2e\xb4\xcf\x24\x50\x1b\xfa\xd9\xbe\x2e\xc9\xe4\x2c\x5c\xc8\xcc\xd4\x19\xd2\xa6\x92\xca\xd8\x70" +
+		"\x19\x3c\x0e\x04\xe0\xbd\x29\x83\x57\xcb\x26\x6c\x15\x06\x08\x0e\xd3\x6e\xdc\xe8\x5c\x64\x8c\xc0\x85\xe8\xc5\x7b\x1a\xb5\x4b\xba" +
+		"\x10\x2a\xdf\x8e\xf7\x47\x35\xa2\x7e\x91\x28\x30\x6d\xcb\xc3\xc9\x9f\x6f\x72\x91\xcd\x40\x65\x78\xce\x14\xea\x2a\xda\xba\x68\xf8" +
+		"\x0f\xe0\xaf\x78\x58\xe4\x98\x59\xe2\xa5\x4d\x6f\x1a\xd9\x45\xb1\x31\x6a\xa2\x4b\xfb\xdd\x23\xae\x40\xa6\xd0\xcb\x70\xc3\xea\xb1" +
+		"\x21\x6f\x67\x17\xbb\xc7\xde\xdb\x08\x53\x6a\x22\x20\x84\x3f\x4e\x2d\xa5\xf1\xda\xa9\xeb\xde\xfd\xe8\xa5\xea\x73\x44\x79\x8d\x22" +
+		"\x1d\xa5\x5c\xc9\x00\xf0\xd2\x1f\x4a\x3e\x69\x43\x91\x91\x8a\x1b\x3c\x23\xb2\xac\x77\x3c\x6b\x3e\xf8\x8e\x2e\x42\x28\x32\x51\x61"
+	m3 = "" +
+		"\x10\x9b\x7f\x41\x1b\xa0\xe4\xc9\xb2\xb7\x0c\xaf\x5c\x36\xa7\xb1\x94\xbe\x7c\x11\xad\x24\x37\x8b\xfe\xdb\x68\x59\x2b\xa8\x11\x8b" +
+		"\x16\xed\x41\xe1\x3b\xb9\xc0\xc6\x6a\xe1\x19\x42\x4f\xdd\xbc\xbc\x93\x14\xdc\x9f\xdb\xde\xea\x55\xd6\xc6\x45\x43\xdc\x49\x03\xe0" +
+		"\x2b\x90\xbb\xa0\x0f\xca\x05\x89\xf6\x17\xe7\xdc\xbf\xe8\x2e\x0d\xf7\x06\xab\x64\x0c\xeb\x24\x7b\x79\x1a\x93\xb7\x4e\x36\x73\x6d" +
+		"\x29\x69\xf2\x7e\xed\x31\xa4\x80\xb9\xc3\x6c\x76\x43\x79\xdb\xca\x2c\xc8\xfd\xd1\x41\x5c\x3d\xde\xd6\x29\x40\xbc\xde\x0b\xd7\x71" +
+		"\x2e\x24\x19\xf9\xec\x02\xec\x39\x4c\x98\x71\xc8\x32\x96\x3d\xc1\xb8\x9d\x74\x3c\x8c\x7b\x96\x40\x29\xb2\x31\x16\x87\xb1\xfe\x23" +
+		"\x10\x10\x71\xf0\x03\x23\x79\xb6\x97\x31\x58\x76\x69\x0f\x05\x3d\x14\x8d\x4e\x10\x9f\x5f\xb0\x65\xc8\xaa\xcc\x55\xa0\xf8\x9b\xfa" +
+		"\x14\x30\x21\xec\x68\x6a\x3f\x33\x0d\x5f\x9e\x65\x46\x38\x06\x5c\xe6\xcd\x79\xe2\x8c\x5b\x37\x53\x32\x62\x44\xee\x65\xa1\xb1\xa7" +
+		"\x17\x6c\xc0\x29\x69\x5a\xd0\x25\x82\xa7\x0e\xff\x08\xa6\xfd\x99\xd0\x57\xe1\x2e\x58\xe7\xd7\xb6\xb1\x6c\xdf\xab\xc8\xee\x29\x11" +
+		"\x19\xa3\xfc\x0a\x56\x70\x2b\xf4\x17\xba\x7f\xee\x38\x02\x59\x3f\xa6\x44\x47\x03\x07\x04\x3f\x77\x73\x27\x9c\xd7\x1d\x25\xd5\xe0"
+)
+
+// Round constants and MDS matrix for width 4, 56 partial rounds
+const (
+	c4 = "" +
+		"\x19\xb8\x49\xf6\x94\x50\xb0\x68\x48\xda\x1d\x39\xbd\x5e\x4a\x43\x02\xbb\x86\x74\x4e\xdc\x26\x23\x8b\x08\x78\xe2\x69\xed\x23\xe5" +
+		"\x26\x5d\xdf\xe1\x27\xdd\x51\xbd\x72\x39\x34\x7b\x75\x8f\x0a\x13\x20\xeb\x2c\xc7\x45\x0a\xcc\x1d\xad\x47\xf8\x0c\x8d\xcf\x34\xd6" +
+		"\x19\x97\x50\xec\x47\x2f\x18\x09\xe0\xf6\x6a\x54\x5e\x1e\x51\x62\x41\x08\xac\x84\x50\x15\xc2\xaa\x3d\xfc\x36\xba\xb4\x97\xd8\xaa" +
+		"\x15\x7f\xf3\xfe\x65\xac\x72\x08\x11\x0f\x06\xa5\xf7\x43\x02\xb1\x4d\x74\x3e\xa2\x50\x67\xf0\xff\xd0\x32\xf7\x87\xc7\xf1\xcd\xf8" +
+		"\x2e\x49\xc4\x3c\x45\x69\xdd\x9c\x5f\xd3\x5a\xc4\x5f\xca\x33\xf1\x0b\x15\xc5\x90\x69\x2f\x8b\xee\xfe\x18\xf4\x89\x6a\xc9\x49\x02" +
+		"\x0e\x35\xfb\x89\x98\x18\x90\x52\x0d\x4a\xef\x2b\x6d\x65\x06\xc3\xcb\x2f\x0b\x69\x73\xc2\x4f\xa8\x27\x31\x34\x5f\xfa\x2d\x1f\x1e" +
+		"\x25\x1a\xd4\x7c\xb1\x5c\x4f\x11\x05\xf1\x09\xae\x5e\x94\x4f\x1b\xa9\xd9\xe7\x80\x6d\x66\x7f\xfe\xc6\xfe\x72\x30\x02\xe0\xb9\x96" +
+		"\x13\xda\x07\xdc\x64\xd4\x28\x36\x98\x73\xe9\x71\x60\x23\x46\x41\xf8\xbe\xb5\x6f\xdd\x05\xe5\xf3\x56\x3f\xa3\x9d\x9c\x22\xdf\x4e" +
+		"\x0c\x00\x9b\x84\xe6\x50\xe6\xd2\x3d\xc0\x0c\x7d\xcc\xef\x74\x83\xa5\x53\x93\x96\x89\xd3\x50\xcd\x46\xe7\xb8\x90\x55\xfd\x47\x38" +
+		"\x01\x1f\x16\xb1\xc6\x3a\x85\x4f\x01\x99\x2e\x39\x56\xf4\x2d\x8b\x04\xeb\x65\x0c\x6d\x53\x5e\xb0\x20\x3d\xec\x74\xbe\xfd\xca\x06" +
+		"\x0e\xd6\x9e\x5e\x38\x3a\x68\x8f\x20\x9d\x9a\x56\x1d\xaa\x79\x61\x2f\x3f\x78\xd0\x46\x7a\xd4\x54\x85\xdf\x07\x09\x3f\x36\x75\x49" +
+		"\x04\xdb\xa9\x4a\x7b\x0c\xe9\xe2\x21\xac\xad\x41\x47\x2b\x6b\xbe\x3a\xec\x50\x7f\x5e\xb3\xd3\x3f\x46\x36\x72\x26\x4c\x9f\x78\x9b" +
+		"\x0a\x3f\x26\x37\xd8\x40\xf3\xa1\x6e\xb0\x94\x27\x1c\x9d\x23\x7b\x60\x36\x75\x7d\x4b\xb5\x0b\xf7\xce\x73\x2f\xf1\xd4\xfa\x28\xe8" +
+		"\x25\x9a\x66\x6f\x12\x9e\xea\x19\x8f\x8a\x1c\x50\x2f\xdb\x38\xfa\x39\xb1\xf0\x75\x56\x95\x64\xb6\xe5\x4a\x48\x5d\x11\x82\x32\x3f" +
+		"\x28\xbf\x74\x59\xc9\xb2\xf4\xc6\xd8\xe7\xd0\x6a\x4e\xe3\xa4\x7f\x77\x45\xd4\x27\x10\x38\xe5\x15\x7a\x32\xfd\xf7\xed\xe0\xd6\xa1" +
+		"\x0a\x1c\xa9\x41\xf0\x57\x03\x75\x26\xea\x20\x0f\x48\x9b\xe8\xd4\xc3\x7c\x85\xbb\xcc\xe6\xa2\xae\xec\x91\xbd\x69\x41\x43\x24\x47" +
+		"\x0c\x6f\x8f\x95\x8b\xe0\xe9\x30\x53\xd7\xfd\x4f\xc5\x45\x12\x85\x55\x35\xed\x15\x39\xf0\x51\xdc\xb4\x3a\x26\xfd\x92\x63\x61\xcf" +
+		"\x12\x31\x06\xa9\x3c\xd1\x75\x78\xd4\x26\xe8\x12\x8a\xc9\xd9\x0a\xa9\xe8\xa0\x07\x08\xe2\x96\xe0\x84\xdd\x57\xe6\x9c\xaa\xf8\x11" +
+		"\x26\xe1\xba\x52\xad\x92\x85\xd9\x7d\xd3\xab\x52\xf8\xe8\x40\x08\x5e\x8f\xa8\x3f\xf1\xe8\xf1\x87\x7b\x07\x48\x67\xcd\x2d\xee\x75" +
+		"\x1c\xb5\x5c\xad\x7b\xd1\x33\xde\x18\xa6\x4c\x5c\x47\xb9\xc9\x7c\xbe\x4d\x8b\x7b\xf9\xe0\x95\x86\x44\x71\x53\x7e\x6a\x4a\xe2\xc5" +
+		"\x1d\xcd\x73\xe4\x6a\xcd\x8f\x8e\x0e\x2c\x7c\xe0\x4b\xde\x7f\x6d\x2a\x53\x04\x3d\x50\x60\xa4\x1c\x71\x43\xf0\x8e\x6e\x90\x55\xd0" +
+		"\x01\x10\x03\xe3\x2f\x6d\x9c\x66\xf5\x85\x2f\x05\x47\x4a\x4d\xef\x0c\xda\x29\x4a\x0e\xb4\xe9\xb9\xb1\x2b\x9b\xb4\x51\x2e\x55\x74" +
+		"\x2b\x1e\x80\x9a\xc1\xd1\x0a\xb2\x9a\xd5\xf2\x0d\x03\xa5\x7d\xfe\xba\xdf\xe5\x90\x3f\x58\xba\xfe\xd7\xc5\x08\xdd\x22\x87\xae\x8c" +
+		"\x25\x39\xde\x17\x85\xb7\x35\x99\x9f\xb4\xda\xc3\x5e\xe1\x7e\xd0\xef\x99\x5d\x05\xab\x2f\xc5\xfa\xea\xa6\x9a\xe8\x7b\xce\xc0\xa5" +
+		"\x0c\x24\x6c\x5a\x2e\xf8\xee\x01\x26\x49\x7f\x22\x2b\x3e\x0a\x0e\xf4\xe1\xc3\xd4\x1c\x86\xd4\x6e\x43\x98\x2c\xb1\x1d\x77\x95\x1d" +
+		"\x19\x20\x89\xc4\x97\x4f\x68\xe9\x54\x08\x14\x8f\x7c\x06\x32\xed\xbb\x09\xe6\xa6\xad\x1a\x1c\x2f\x3f\x03\x05\xf5\xd0\x3b\x52\x7b" +
+		"\x1e\xae\x0a\xd8\xab\x68\xb2\xf0\x6a\x0e\xe3\x6e\xeb\x0d\x0c\x05\x85\x29\x09\x7d\x91\x09\x6b\x75\x6d\x8f\xdc\x2f\xb5\xa6\x0d\x85" +
+		"\x17\x91\x90\xe5\xd0\xe2\x21\x79\xe4\x6f\x82\x82\x87\x2a\xbc\x88\xdb\x6e\x2f\xdc\x0d\xee\x99\xe6\x97\x68\xbd\x98\xc5\xd0\x6b\xfb" +
+		"\x29\xbb\x9e\x2c\x90\x76\x73\x25\x76\xe9\xa8\x1c\x7a\xc4\xb8\x32\x14\x52\x8f\x7d\xb0\x0f\x31\xbf\x6c\xaf\xe7\x94\xa9\xb3\xcd\x1c" +
+		"\x22\x5d\x39\x4e\x42\x20\x75\x99\x40\x3e\xfd\x0c\x24\x64\xa9\x0d\x52\x65\x26\x45\x88\x2a\xac\x35\xb1\x0e\x59\x0e\x6e\x69\x1e\x08" +
+		"\x06\x47\x60\x62\x3c\x25\xc8\xcf\x75\x3d\x23\x80\x55\xb4\x44\x53\x2b\xe1\x35\x57\x45\x1c\x08\x7d\xe0\x9e\xfd\x45\x4b\x23\xfd\x59" +
+		"\x10\xba\x3a\x0e\x01\xdf\x92\xe8\x7f\x30\x1c\x4b\x71\x6d\x8a\x39\x4d\x67\xf4\xbf\x42\xa7\x5c\x10\x92\x29\x10\xa7\x8f\x6b\x5b\x87" +
+		"\x0e\x07\x0b\xf5\x3f\x84\x51\xb2\x4f\x9c\x6e\x96\xb0\xc2\xa8\x01\xcb\x51\x1b\xc0\xc2\x42\xeb\x9d\x36\x1b\x77\x69\x3f\x21\x47\x1c" +
+		"\x1b\x94\xcd\x61\xb0\x51\xb0\x4d\xd3\x97\x55\xff\x93\x82\x1a\x73\xcc\xd6\xcb\x11\xd2\x49\x1d\x8a\xa7\xf9\x21\x01\x4d\xe2\x52\xfb" +
+		"\x1d\x7c\xb3\x9b\xaf\xb8\xc7\x44\xe1\x48\x78\x7a\x2e\x70\x23\x0f\x9d\x4e\x91\x7d\x57\x13\xbb\x05\x04\x87\xb5\xaa\x7d\x74\x07\x0b" +
+		"\x2e\xc9\x31\x89\xbd\x1a\xb4\xf6\x91\x17\xd0\xfe\x98\x0c\x80\xff\x87\x85\xc2\x96\x18\x29\xf7\x01\xbb\x74\xac\x1f\x30\x3b\x17\xdb" +
+		"\x2d\xb3\x66\xbf\xdd\x36\xd2\x77\xa6\x92\xbb\x82\x5b\x86\x27\x5b\xea\xc4\x04\xa1\x9a\xe0\x7a\x90\x82\xea\x46\xbd\x83\x51\x79\x26" +
+		"\x06\x21\x00\xeb\x48\x5d\xb0\x62\x69\x65\x5c\xf1\x86\xa6\x85\x32\x98\x52\x75\x42\x84\x50\x35\x9a\xdc\x99\xce\xc6\x96\x07\x11\xb8" +
+		"\x07\x61\xd3\x3c\x66\x61\x4a\xaa\x57\x0e\x7f\x1e\x82\x44\xca\x11\x20\x24\x3f\x92\xfa\x59\xe4\xf9\x00\xc5\x67\xbf\x41\xf5\xa5\x9b" +
+		"\x20\xfc\x41\x1a\x11\x4d\x13\x99\x2c\x27\x05\xaa\x03\x4e\x3f\x31\x5d\x78\x60\x8a\x0f\x7d\xe4\xcc\xf7\xa7\x2e\x49\x48\x55\xad\x0d" +
+		"\x25\xb5\xc0\x04\xa4\xbd\xfc\xb5\xad\xd9\xec\x4e\x9a\xb2\x19\xba\x10\x2c\x67\xe8\xb3\xef\xfb\x5f\xc3\xa3\x0f\x31\x72\x50\xbc\x5a" +
+		"\x23\xb1\x82\x2d\x27\x8e\xd6\x32\xa4\x94\xe5\x8f\x6d\xf6\xf5\xed\x03\x8b\x18\x6d\x84\x74\x15\x5a\xd8\x7e\x7d\xff\x62\xb3\x7f\x4b" +
+		"\x22\x73\x4b\x4c\x5c\x3f\x94\x93\x60\x6c\x4b\xa9\x01\x24\x99\xbf\x0f\x14\xd1\x3b\xfc\xfc\xcc\xaa\x16\x10\x2a\x29\xcc\x2f\x69\xe0" +
+		"\x26\xc0\xc8\xfe\x09\xeb\x30\xb7\xe2\x7a\x74\xdc\x33\x49\x23\x47\xe5\xbd\xff\x40\x9a\xa3\x61\x02\x54\x41\x3d\x3f\xad\x79\x5c\xe5" +
+		"\x07\x0d\xd0\xcc\xb6\xbd\x7b\xba\xe8\x8e\xac\x03\xfa\x1f\xbb\x26\x19\x6b\xe3\x08\x3a\x80\x98\x29\xbb\xd6\x26\xdf\x34\x8c\xca\xd9" +
+		"\x12\xb6\x59\x5b\xdb\x32\x9b\x6f\xb0\x43\xba\x78\xbb\x28\xc3\xbe\xc2\xc0\xa6\xde\x46\xd8\xc5\xad\x60\x67\xc4\xeb\xfd\x42\x50\xda" +
+		"\x24\x8d\x97\xd7\xf7\x62\x83\xd6\x3b\xec\x30\xe7\xa5\x87\x6c\x11\xc0\x6f\xca\x9b\x27\x5c\x67\x1c\x5e\x33\xd9\x5b\xb7\xe8\xd7\x29" +
+		"\x1a\x30\x6d\x43\x9d\x46\x3b\x08\x16\xfc\x6f\xd6\x4c\xc9\x39\x31\x8b\x45\xeb\x75\x9d\xdd\xe4\xaa\x10\x6d\x15\xd9\xbd\x9b\xaa\xaa" +
+		"\x28\xa8\xf8\x37\x2e\x3c\x38\xda\xce\xd7\xc0\x04\x21\xcb\x46\x21\xf4\xf1\xb5\x4d\xdc\x27\x82\x1b\x0d\x62\xd3\xd6\xec\x7c\x56\xcf" +
+		"\x00\x94\x97\x57\x17\xf9\xa8\xa8\xbb\x35\x15\x2f\x24\xd4\x32\x94\x07\x1c\xe3\x20\xc8\x29\xf3\x88\xbc\x85\x21\x83\xe1\xe2\xce\x7e" +
+		"\x04\xd5\xee\x4c\x3a\xa7\x8f\x7d\x80\xfd\xe6\x0d\x71\x64\x80\xd3\x59\x3f\x74\xd4\xf6\x53\xae\x83\xf4\x10\x32\x46\xdb\x2e\x8d\x65" +
+		"\x2a\x6c\xf5\xe9\xaa\x03\xd4\x33\x63\x49\xad\x6f\xb8\xed\x22\x69\xc7\xbe\xf5\x4b\x88\x22\xcc\x76\xd0\x84\x95\xc1\x2e\xfd\xe1\x87" +
+		"\x23\x04\xd3\x1e\xaa\xb9\x60\xba\x92\x74\xda\x43\xe1\x9d\xde\xb7\xf7\x92\x18\x08\x08\xfd\x6e\x43\xba\xae\x48\xd7\xef\xcb\xa3\xf3" +
+		"\x03\xfd\x9a\xc8\x65\xa4\xb2\xa6\xd5\xe7\x00\x97\x85\x81\x72\x49\xbf\xf0\x8a\x7e\x07\x26\xfc\xb4\xe1\xc1\x1d\x39\xd1\x99\xf0\xb0" +
+		"\x00\xb7\x25\x8d\xed\x52\xbb\xda\x22\x48\x40\x4d\x55\xee\x50\x44\x79\x8a\xfc\x3a\x20\x91\x93\x07\x3f\x79\x54\xd4\xd6\x3b\x0b\x64" +
+		"\x15\x9f\x81\xad\xa0\x77\x17\x99\xec\x38\xfc\xa2\xd4\xbf\x65\xeb\xb1\x3d\x3a\x74\xf3\x29\x8d\xb3\x62\x72\xc5\xca\x65\xe9\x2d\x9a" +
+		"\x1e\xf9\x0e\x67\x43\x7f\xbc\x85\x50\x23\x7a\x75\xbc\x28\xe3\xbb\x90\x00\x13\x0e\xa2\x5f\x0c\x54\x71\xe1\x44\xcf\x42\x64\x43\x1f" +
+		"\x1e\x65\xf8\x38\x51\x5e\x5f\xf0\x19\x6b\x49\xaa\x41\xa2\xd2\x56\x8d\xf7\x39\xbc\x17\x6b\x08\xec\x95\xa7\x9e\xd8\x29\x32\xe3\x0d" +
+		"\x2b\x1b\x04\x5d\xef\x3a\x16\x6c\xec\x6c\xe7\x68\xd0\x79\xba\x74\xb1\x8c\x84\x4e\x57\x0e\x1f\x82\x65\x75\xc1\x06\x8c\x94\xc3\x3f" +
+		"\x08\x32\xe5\x75\x3c\xeb\x0f\xf6\x40\x25\x43\xb1\x10\x92\x29\xc1\x65\xdc\x2d\x73\xbe\xf7\x15\xe3\xf1\xc6\xe0\x7c\x16\x8b\xb1\x73" +
+		"\x02\xf6\x14\xe9\xce\xdf\xb3\xdc\x6b\x76\x2a\xe0\xa3\x7d\x41\xba\xb1\xb8\x41\xc2\xe8\xb6\x45\x1b\xc5\xa8\xe3\xc3\x90\xb6\xad\x16" +
+		"\x0e\x24\x27\xd3\x8b\xd4\x6a\x60\xdd\x64\x0b\x8e\x36\x2c\xad\x96\x73\x70\xeb\xb7\x77\xbe\xdf\xf4\x0f\x6a\x0b\xe2\x7e\x7e\xd7\x05" +
+		"\x04\x93\x63\x0b\x7c\x67\x0b\x6d\xeb\x7c\x84\xd4\x14\xe7\xce\x79\x04\x9f\x0e\xc0\x98\xc3\xc7\xc5\x07\x68\xbb\xe2\x92\x14\xa5\x3a" +
+		"\x22\xea\xd1\x00\xe8\xe4\x82\x67\x4d\xec\xda\xb1\x70\x66\xc5\xa2\x6b\xb1\x51\x53\x55\xd5\x46\x1a\x3d\xc0\x6c\xc8\x53\x27\xce\xa9" +
+		"\x25\xb3\xe5\x6e\x65\x5b\x42\xcd\xaa\xe2\x62\x6e\xd2\x55\x4d\x48\x58\x3f\x1a\xe3\x56\x26\xd0\x4d\xe5\x08\x4e\x0b\x6d\x2a\x6f\x16" +
+		"\x1e\x32\x75\x2a\xda\x88\x36\xef\x58\x37\xa6\xcd\xe8\xff\x13\xdb\xb5\x99\xc3\x36\x34\x9e\x4c\x58\x4b\x4f\xdc\x0a\x0c\xf6\xf9\xd0" +
+		"\x2f\xa2\xa8\x71\xc1\x5a\x38\x7c\xc5\x0f\x68\xf6\xf3\xc3\x45\x5b\x23\xc0\x09\x95\xf0\x50\x78\xf6\x72\xa9\x86\x40\x74\xd4\x12\xe5" +
+		"\x2f\x56\x9b\x8a\x9a\x44\x24\xc9\x27\x8e\x1d\xb7\x31\x1e\x88\x9f\x54\xcc\xbf\x10\x66\x1b\xab\x7f\xcd\x18\xe7\xc7\xa7\xd8\x35\x05" +
+		"\x04\x4c\xb4\x55\x11\x0a\x8f\xdd\x53\x1a\xde\x53\x02\x34\xc5\x18\xa7\xdf\x93\xf7\x33\x2f\xfd\x21\x44\x16\x53\x74\xb2\x46\xb4\x3d" +
+		"\x22\x78\x08\xde\x93\x90\x6d\x5d\x42\x02\x46\x15\x7f\x2e\x42\xb1\x91\xfe\x8c\x90\xad\xfe\x11\x81\x78\xdd\xc7\x23\xa5\x31\x90\x25" +
+		"\x02\xfc\xca\x29\x34\xe0\x46\xbc\x62\x3a\xde\xad\x87\x35\x79\x86\x5d\x03\x78\x1a\xe0\x90\xad\x4a\x85\x79\xd2\xe7\xa6\x80\x03\x55" +
+		"\x0e\xf9\x15\xf0\xac\x12\x0b\x87\x6a\xbc\xcc\xeb\x34\x4a\x1d\x36\xba\xd3\xf3\xc5\xab\x91\xa8\xdd\xcb\xec\x2e\x06\x0d\x8b\xef\xac" +
+		"\x17\x97\x13\x0f\x4b\x7a\x3e\x17\x77\xeb\x75\x7b\xc6\xf2\x87\xf6\xab\x0f\xb8\x5f\x6b\xe6\x3b\x09\xf3\xb1\x6e\xf2\xb1\x40\x5d\x38" +
+		"\x0a\x76\x22\x5d\xc0\x41\x70\xae\x33\x06\xc8\x5a\xba\xb5\x9e\x60\x8c\x7f\x49\x7c\x20\x15\x6d\x4d\x36\xc6\x68\x55\x5d\xec\xc6\xe5" +
+		"\x1f\xff\xb9\xec\x19\x92\xd6\x6b\xa1\xe7\x7a\x7b\x93\x20\x9a\xf6\xf8\xfa\x76\xd4\x8a\xcb\x66\x47\x96\x17\x4b\x53\x26\xa3\x1a\x5c" +
+		"\x25\x72\x1c\x4f\xc1\x5a\x3f\x28\x53\xb5\x7c\x33\x8f\xa5\x38\xd8\x5f\x8f\xbb\xa6\xc6\xb9\xc6\x09\x06\x11\x88\x9b\x79\x7b\x9c\x5f" +
+		"\x0c\x81\x7f\xd4\x2d\x5f\x7a\x41\x21\x5e\x3d\x07\xba\x19\x72\x16\xad\xb4\xc3\x79\x07\x05\xda\x95\xeb\x63\xb9\x82\xbf\xca\xf7\x5a" +
+		"\x13\xab\xe3\xf5\x23\x99\x15\xd3\x9f\x7e\x13\xc2\xc2\x49\x70\xb6\xdf\x8c\xf8\x6c\xe0\x0a\x22\x00\x2b\xc1\x58\x66\xe5\x2b\x5a\x96" +
+		"\x21\x06\xfe\xea\x54\x62\x24\xea\x12\xef\x7f\x39\x98\x7a\x46\xc8\x5c\x1b\xc3\xdc\x29\xbd\xbd\x7a\x92\xcd\x60\xac\xb4\xd3\x91\xce" +
+		"\x21\xca\x85\x94\x68\xa7\x46\xb6\xaa\xa7\x94\x74\xa3\x7d\xab\x49\xf1\xca\x5a\x28\xc7\x48\xbc\x71\x57\xe1\xb3\x34\x5b\xb0\xf9\x59" +
+		"\x05\xcc\xd6\x25\x5c\x1e\x6f\x0c\x5c\xf1\xf0\xdf\x93\x41\x94\xc6\x29\x11\xd1\x4d\x03\x21\x66\x2a\x8f\x1a\x48\x99\x9e\x34\x18\x5b" +
+		"\x0f\x0e\x34\xa6\x4b\x70\xa6\x26\xe4\x64\xd8\x46\x67\x4c\x4c\x88\x16\xc4\xfb\x26\x7f\xe4\x4f\xe6\xea\x28\x67\x8c\xb0\x94\x90\xa4" +
+		"\x05\x58\x53\x1a\x4e\x25\x47\x0c\x61\x57\x79\x4c\xa3\x6d\x0e\x96\x47\xdb\xfc\xfe\x35\x0d\x64\x83\x8f\x5b\x1a\x8a\x2d\xe0\xd4\xbf" +
+		"\x09\xd3\xdc\xa9\x17\x3e\xd2\xfa\xce\xea\x12\x51\x57\x68\x3d\x18\x92\x4c\xad\xad\x3f\x65\x5a\x60\xb7\x2f\x58\x64\x96\x1f\x14\x55" +
+		"\x03\x28\xcb\xd5\x4e\x8c\x09\x13\x49\x3f\x86\x6e\xd0\x3d\x21\x8b\xf2\x3f\x92\xd6\x8a\xae\xc4\x86\x17\xd4\xc7\x22\xe5\xbd\x43\x35" +
+		"\x2b\xf0\x72\x16\xe2\xaf\xf0\xa2\x23\xa4\x87\xb1\xa7\x09\x4e\x07\xe7\x9e\x7b\xcc\x97\x98\xc6\x48\xee\x33\x47\xdd\x53\x29\xd3\x4b" +
+		"\x1d\xaf\x34\x5a\x58\x00\x6b\x73\x64\x99\xc5\x83\xcb\x76\xc3\x16\xd6\xf7\x8e\xd6\xa6\xdf\xfc\x82\x11\x1e\x11\xa6\x3f\xe4\x12\xdf" +
+		"\x17\x65\x63\x47\x24\x56\xaa\xa7\x46\xb6\x94\xc6\x0e\x18\x23\x61\x1e\xf3\x90\x39\xb2\xed\xc7\xff\x39\x1e\x6f\x22\x93\xd2\xc4\x04" +
+		"\x2e\xf1\xe0\xfa\xd9\xf0\x8e\x87\xa3\xbb\x5e\x47\xd7\xe3\x35\x38\xca\x96\x4d\x2b\x7d\x10\x83\xd4\xfb\x02\x25\x03\x5b\xd3\xf8\xdb" +
+		"\x22\x6c\x9b\x1a\xf9\x5b\xab\xcf\x17\xb2\xb1\xf5\x7c\x73\x10\x17\x9c\x18\x03\xde\xc5\xae\x8f\x0a\x17\x79\xed\x36\xc8\x17\xae\x2a" +
+		"\x14\xbc\xe3\x54\x9c\xc3\xdb\x74\x28\x12\x6b\x4c\x3a\x15\xae\x0f\xf8\x14\x8c\x89\xf1\x3f\xb3\x5d\x35\x73\x4e\xb5\xd4\xad\x0d\xef" +
+		"\x2d\xeb\xff\x15\x6e\x27\x6b\xb5\x74\x2c\x33\x73\xf2\x63\x5b\x48\xb8\xe9\x23\xd3\x01\xf3\x72\xf8\xe5\x50\xcf\xd4\x03\x42\x12\xc7" +
+		"\x2d\x40\x83\xcf\x5a\x87\xf5\xb6\xfc\x23\x95\xb2\x2e\x35\x6b\x64\x41\xaf\xe1\xb6\xb2\x9c\x47\xad\xd7\xd0\x43\x2d\x1d\x47\x60\xc7" +
+		"\x0c\x22\x5b\x7b\xcd\x04\xbf\x9c\x34\xb9\x11\x26\x2f\xdc\x9c\x1b\x91\xbf\x79\xa1\x0c\x01\x84\xd8\x9c\x31\x7c\x53\xd7\x16\x1c\x29" +
+		"\x03\x15\x21\x69\xd4\xf3\xd0\x6e\xc3\x3a\x79\xbf\xac\x91\xa0\x2c\x99\xaa\x02\x00\xdb\x66\xd5\xaa\x7b\x83\x52\x65\xf9\xc9\xc8\xf3" +
+		"\x0b\x61\x81\x1a\x92\x10\xbe\x78\xb0\x59\x74\x58\x74\x86\xd5\x8b\xdd\xc8\xf5\x1b\xfd\xfe\xbb\xb8\x7a\xfe\x8b\x7a\xa7\xd3\x19\x9c" +
+		"\x20\x3e\x00\x0c\xad\x29\x8d\xaa\xf7\xeb\xa6\xa5\xc5\x92\x18\x78\xb8\xae\x48\xac\xf7\x04\x8f\x16\x04\x6d\x63\x7a\x53\x3b\x6f\x78" +
+		"\x1a\x44\xbf\x09\x37\xc7\x22\xd1\x37\x66\x72\xb6\x9f\x6c\x96\x55\xba\x7e\xe3\x86\xfd\xa1\x11\x2c\x07\x57\x14\x3d\x1b\xfa\x91\x46" +
+		"\x03\x76\xb4\xfa\xe0\x8c\xb0\x3d\x35\x00\xaf\xec\x1a\x1f\x56\xac\xb8\xe0\xfd\xe7\x5a\x21\x06\xd7\x00\x2f\x59\xc5\x61\x1d\x4d\xaa" +
+		"\x00\x78\x0a\xf2\xca\x1c\xad\x64\x65\xa2\x17\x12\x50\xfd\xfc\x32\xd6\xfc\x24\x1d\x32\x14\x17\x7f\x3d\x55\x3e\xf3\x63\x18\x21\x85" +
+		"\x10\x77\x4d\x9a\xb8\x0c\x25\xbd\xeb\x80\x8b\xed\xfd\x72\xa8\xd9\xb7\x5d\xbe\x18\xd5\x22\x1c\x87\xe9\xd8\x57\x07\x9b\xdc\x31\xd5" +
+		"\x10\xdc\x6e\x9c\x00\x6e\xa3\x8b\x04\xb1\xe0\x3b\x4b\xd9\x49\x0c\x0d\x03\xf9\x89\x29\xca\x1d\x7f\xb5\x68\x21\xfd\x19\xd3\xb6\xe8" +
+		"\x00\x54\x4b\x83\x38\x79\x15\x18\xb2\xc7\x64\x5a\x50\x39\x27\x98\xb2\x1f\x75\xbb\x60\xe3\x59\x61\x70\x06\x7d\x00\x14\x1c\xac\x16" +
+		"\x22\x2c\x01\x17\x57\x18\x38\x6f\x2e\x2e\x82\xeb\x12\x27\x89\xe3\x52\xe1\x05\xa3\xb8\xfa\x85\x26\x13\xbc\x53\x44\x33\xee\x42\x8c" +
+		"\x28\x40\xd0\x45\xe9\xbc\x22\xb2\x59\xcf\xb8\x81\x1b\x1e\x0f\x45\xb7\x7f\x7b\xdb\x7f\x7e\x2b\x46\x15\x1a\x14\x30\xf6\x08\xe3\xc5" +
+		"\x06\x27\x52\xf8\x6e\xeb\xe1\x1a\x00\x9c\x93\x7e\x46\x8c\x33\x5b\x04\x55\x45\x74\xc2\x99\x01\x96\x50\x8e\x01\xfa\x58\x60\x18\x6b" +
+		"\x06\x04\x1b\xda\xc4\x82\x05\xac\x87\xad\xb8\x7c\x20\xa4\x78\xa7\x1c\x99\x50\xc1\x2a\x80\xbc\x0a\x55\xa8\xe8\x3e\xaa\xf0\x47\x46" +
+		"\x04\xa5\x33\xf2\x36\xc4\x22\xd1\xff\x90\x0a\x36\x89\x49\xb0\x02\x2c\x7a\x2a\xe0\x92\xf3\x08\xd8\x2b\x1d\xcb\xbf\x51\xf5\x00\x0d" +
+		"\x13\xe3\x1d\x7a\x67\x23\x2f\xd8\x11\xd6\xa9\x55\xb3\xd4\xf2\x5d\xfe\x06\x6d\x1e\x7d\xc3\x3d\xf0\x4b\xde\x50\xa2\xb2\xd0\x5b\x2a" +
+		"\x01\x1c\x26\x83\xae\x91\xeb\x4d\xfb\xc1\x3d\x63\x57\xe8\x59\x9a\x92\x79\xd1\x64\x8f\xf2\xc9\x5d\x2f\x79\x90\x5b\xb1\x39\x20\xf1" +
+		"\x0b\x0d\x21\x93\x46\xb8\x57\x45\x25\xb1\xa2\x70\xe0\xb4\xcb\xa5\xd5\x6c\x92\x8e\x3e\x2c\x2b\xd0\xa1\xec\xae\xd0\x15\xaa\xf6\xae" +
+		"\x14\xab\xde\xc8\xdb\x9c\x6d\xc9\x70\x29\x1e\xe6\x38\x69\x02\x09\xb6\x50\x80\x78\x1e\xf9\xfd\x13\xd8\x4c\x7a\x72\x6b\x5f\x13\x64" +
+		"\x1a\x0b\x70\xb4\xb2\x6f\xdc\x28\xfc\xd3\x2a\xa3\xd2\x66\x47\x88\x01\xeb\x12\x20\x2e\xf4\x7c\xed\x98\x8d\x03\x76\x61\x0b\xe1\x06" +
+		"\x27\x85\x43\x72\x1f\x96\xd1\x30\x7b\x69\x43\xf9\x80\x4e\x7f\xe5\x64\x01\xde\xb2\xef\x99\xc4\xd1\x27\x04\x88\x2e\x72\x78\xb6\x07" +
+		"\x16\xeb\x59\x49\x4a\x97\x76\xcf\x57\x86\x62\x14\xdb\xd1\x47\x3f\x3f\x07\x38\xa3\x25\x63\x8d\x8b\xa3\x65\x35\xe0\x11\xd5\x82\x59" +
+		"\x25\x67\xa6\x58\xa8\x1f\xfb\x44\x4f\x24\x00\x88\xfa\x55\x24\xc6\x9a\x9e\x53\xee\xab\x6b\x7f\x8c\x41\xc3\x47\x9d\xcf\x8c\x64\x4a" +
+		"\x29\xaa\x1d\x7c\x15\x1e\x9a\xd0\xa7\xab\x39\xf1\xab\xd9\xcf\x77\xab\x78\xe0\x21\x5a\x57\x15\xa6\xb8\x82\xad\xe8\x40\xbb\x13\xd8" +
+		"\x15\xc0\x91\x23\x3e\x60\xef\xe0\xd4\xbb\xfc\xe2\xb3\x64\x15\x00\x6a\x4f\x01\x7f\x9a\x85\x38\x8c\xe2\x06\xb9\x1f\x99\xf2\xc9\x84" +
+		"\x16\xbd\x7d\x22\xff\x85\x8e\x5e\x08\x82\xc2\xc9\x99\x55\x8d\x77\xe7\x67\x3a\xd5\xf1\x91\x5f\x9f\xeb\x67\x9a\x81\x15\xf0\x14\xcf" +
+		"\x02\xdb\x50\x48\x0a\x07\xbe\x0e\xb2\xc2\xe1\x3e\xd6\xef\x40\x74\xc0\x18\x2d\x9b\x66\x8b\x8e\x08\xff\xe6\x76\x92\x50\x04\x20\x25" +
+		"\x05\xe4\xa2\x20\xe6\xa3\xbc\x9f\x7b\x68\x06\xec\x9d\x6c\xdb\xa1\x86\x33\x0e\xf2\xbf\x7a\xdb\x4c\x13\xba\x86\x63\x43\xb7\x31\x19" +
+		"\x1d\xda\x05\xeb\xc3\x01\x70\xbc\x98\xcb\xf2\xa5\xee\x3b\x50\xe8\xb5\xf7\x0b\xc4\x24\xd3\x9f\xa4\x10\x4d\x37\xf1\xcb\xcf\x7a\x42" +
+		"\x01\x84\xbe\xf7\x21\x88\x81\x87\xf6\x45\xb6\xfe\xe3\x66\x7f\x3c\x91\xda\x21\x44\x14\xd8\x9b\xa5\xcd\x30\x1f\x22\xb0\xde\x89\x90" +
+		"\x14\x98\xa3\x07\xe6\x89\x00\x06\x5f\x5e\x82\x76\xf6\x2a\xef\x1c\x37\x41\x4b\x84\x49\x4e\x15\x77\xad\x1a\x6d\x64\x34\x1b\x78\xec" +
+		"\x25\xf4\x0f\x82\xb3\x1d\xac\xc4\xf4\x93\x98\x00\xb9\xd2\xc3\xea\xce\xf7\x37\xb8\xfa\xb1\xf8\x64\xfe\x33\x54\x8a\xd4\x6b\xd4\x9d" +
+		"\x09\xd3\x17\xcc\x67\x02\x51\x94\x3f\x6f\x58\x62\xa3\x0d\x2e\xa9\xe8\x30\x56\xce\x49\x07\xbf\xbb\xcb\x1f\xf3\x1c\xe5\xbb\x96\x50" +
+		"\x2f\x77\xd7\x77\x86\xd9\x79\xb2\x3b\xa4\xce\x4a\x4c\x1b\x3b\xd0\xa4\x11\x32\xcd\x46\x7a\x86\xab\x29\xb9\x13\xb6\xcf\x31\x49\xd0" +
+		"\x0f\x53\xda\xfd\x53\x5a\x9f\x44\x73\xdc\x26\x6b\x6f\xcc\xc6\x84\x1b\xbd\x33\x69\x63\xf2\x54\xc1\x52\xf8\x9e\x78\x5f\x72\x9b\xbf" +
+		"\x25\xc1\xfd\x72\xe2\x23\x04\x52\x65\xc3\xa0\x99\xe1\x75\x26\xfa\x0e\x69\x76\xe1\xc0\x0b\xaf\x16\xde\x96\xde\x85\xde\xef\x2f\xa2" +
+		"\x2a\x90\x2c\x89\x80\xc1\x7f\xaa\xe3\x68\xd3\x85\xd5\x2d\x16\xbe\x41\xaf\x95\xc8\x4e\xae\xa3\xcf\x89\x3e\x65\xd6\xce\x4a\x8f\x62" +
+		"\x1c\xe1\x58\x0a\x34\x52\xec\xf3\x02\x87\x8c\x89\x76\xb8\x2b\xe9\x66\x76\xdd\x11\x4d\x1d\xc8\xd2\x55\x27\x40\x57\x62\xf8\x35\x29" +
+		"\x24\xa6\x07\x3f\x91\xad\xdc\x33\xa4\x9a\x1f\xa3\x06\xdf\x00\x88\x01\xc5\xec\x56\x96\x09\x03\x4d\x2f\xc5\x0f\x7f\x0f\x4d\x00\x56" +
+		"\x25\xe5\x2d\xbd\x61\x24\x53\x0d\x9f\xc2\x7f\xe3\x06\xd7\x1d\x45\x83\xe0\x7c\xa5\x54\xb5\xd1\x57\x7f\x25\x6c\x68\xb0\xbe\x2b\x74" +
+		"\x23\xdf\xfa\xe3\xc4\x23\xfa\x7a\x93\x46\x8d\xbc\xcf\xb0\x29\x85\x59\x74\xbe\x4d\x0a\x7b\x29\x94\x67\x96\xe5\xb6\xcd\x70\xf1\x5d" +
+		"\x06\x34\x2d\xa3\x70\xcc\x0d\x8c\x49\xb7\x75\x94\xf6\xb0\x27\xc4\x80\x61\x5d\x50\xbe\x36\x24\x3a\x99\x59\x1b\xc9\x92\x4e\xd6\xf5" +
+		"\x27\x54\x11\x42\x81\x28\x65\x46\xb7\x5f\x09\xf1\x15\xfc\x75\x1b\x47\x78\x30\x3d\x04\x05\xc1\xb4\xcc\x7d\xf0\xd8\xe9\xf6\x39\x25" +
+		"\x15\xc1\x9e\x85\x34\xc5\xc1\xa8\x86\x2c\x2b\xc1\xd1\x19\xed\xde\xab\xf2\x14\x15\x38\x33\xd7\xbd\xb5\x9e\xe1\x97\xf8\x18\x7c\xf5" +
+		"\x26\x5f\xe0\x62\x76\x6d\x08\xfa\xb4\xc7\x8d\x0d\x9e\xf3\xca\xbe\x36\x6f\x3b\xe0\xa8\x21\x06\x16\x79\xb4\xb3\xd2\xd7\x7d\x5f\x3e" +
+		"\x13\xcc\xf6\x89\xd6\x7a\x3e\xc9\xf2\x2c\xb7\xcd\x0a\xc3\xa3\x27\xd3\x77\xac\x5c\xd0\x14\x6f\x04\x8d\xeb\xfd\x09\x8d\x3e\xc7\xbe" +
+		"\x17\x66\x2f\x74\x56\x78\x97\x39\xf8\x1c\xd3\x97\x48\x27\xa8\x87\xd9\x2a\x5e\x05\xbd\xf3\xfe\x6b\x9f\xbc\xcc\xa4\x52\x4a\xae\xbd" +
+		"\x21\xb2\x9c\x76\x32\x9b\x31\xc8\xef\x18\x63\x1e\x51\x5f\x7f\x2f\x82\xca\x6a\x5c\xca\x70\xce\xe4\xe8\x09\xfd\x62\x4b\xe7\xad\x5d" +
+		"\x18\x13\x74\x78\x38\x2a\xad\xba\x44\x1e\xb9\x7f\xe2\x79\x01\x98\x9c\x06\x73\x81\x65\x21\x53\x19\x93\x9e\xb1\x7b\x01\xfa\x97\x5c" +
+		"\x2b\xc0\x7e\xa2\xbf\xad\x68\xe8\xdc\x72\x4f\x5f\xef\x2b\x37\xc2\xd3\x4f\x76\x19\x35\xff\xd3\xb7\x39\xce\xec\x46\x68\xf3\x7e\x88" +
+		"\x2d\xdb\x2e\x37\x6f\x54\xd6\x4a\x56\x38\x40\x48\x0d\xf9\x93\xfe\xb4\x17\x32\x03\xc2\xbd\x94\xad\x0e\x60\x20\x77\xae\xf9\xa0\x3e" +
+		"\x27\x7e\xb5\x0f\x2b\xaa\x70\x61\x06\xb4\x1c\xb2\x4c\x60\x26\x09\xe8\xa2\x0f\x8d\x72\xf6\x13\x70\x8a\xdb\x25\x37\x35\x96\xc3\xf7" +
+		"\x0d\x4d\xe4\x7e\x1a\xba\x34\x26\x9d\x0c\x62\x09\x04\xf0\x1a\x56\xb3\x3f\xc4\xb4\x50\xc0\xdb\x50\xbb\x7f\x87\x73\x4c\x9a\x1f\xe5" +
+		"\x0b\x84\x42\xbf\xe9\xe4\xa1\xb4\x42\x86\x73\xb6\xbd\x3e\xea\x6f\x9f\x44\x56\x97\x05\x8f\x13\x4a\xae\x90\x8d\x02\x79\xa2\x9f\x0c" +
+		"\x11\xfe\x5b\x18\xfb\xbe\xa1\xa8\x6e\x06\x93\x0c\xb8\x9f\x7d\x4a\x26\xe1\x86\xa6\x59\x45\xe9\x65\x74\x24\x7f\xdd\xb7\x20\xf8\xf5" +
+		"\x22\x40\x26\xf6\xdf\xaf\x71\xe2\x4d\x25\xd8\xf6\xd9\xf9\x00\x21\xdf\x5b\x77\x4d\xca\xd4\xd8\x83\x17\x0e\x4a\xd8\x9c\x33\xa0\xd6" +
+		"\x0b\x2c\xa6\xa9\x99\xfe\x68\x87\xe0\x70\x4d\xad\x58\xd0\x34\x65\xa9\x6b\xc9\xe3\x7d\x10\x91\xf6\x1b\xc9\xf9\xc6\x2b\xbe\xb8\x24" +
+		"\x22\x1b\x63\xd6\x6f\x0b\x45\xf9\xd4\x0c\x54\x05\x3a\x28\xa0\x6b\x1d\x0a\x4c\xe4\x1d\x36\x47\x97\xa1\xa7\xe0\xc9\x65\x29\xf4\x21" +
+		"\x30\x18\x5c\x48\xb7\xb2\xf1\xd5\x3d\x41\x20\x80\x1b\x04\x7d\x08\x74\x93\xbc\xe6\x4d\x4d\x24\xae\xdc\xe2\xf4\x83\x6b\xb8\x4a\xd4" +
+		"\x23\xf5\xd3\x72\xa3\xf0\xe3\xcb\xa9\x89\xe2\x23\x05\x62\x27\xd3\x53\x33\x56\xf0\xfa\xa4\x8f\x27\xf8\x26\x73\x18\x63\x2a\x61\xf0" +
+		"\x27\x16\x68\x3b\x32\xc7\x55\xfd\x1b\xf8\x23\x5e\xa1\x62\xb1\xf3\x88\xe1\xe0\x09\x0d\x06\x16\x2e\x8e\x6d\xfb\xe4\x32\x8f\x3e\x3b" +
+		"\x09\x77\x54\x58\x36\x86\x6f\xa2\x04\xca\x1d\x85\x3e\xc0\x90\x9e\x3d\x14\x07\x70\xc8\x0a\xc6\x7d\xc9\x30\xc6\x97\x48\xd5\xd4\xbc" +
+		"\x14\x44\xe8\xf5\x92\xbd\xbf\xd8\x02\x5d\x91\xab\x49\x82\xdd\x42\x5f\x51\x68\x2d\x31\x47\x2b\x05\xe8\x1c\x43\xc0\xf9\x43\x4b\x31" +
+		"\x26\xe0\x4b\x65\xe9\xca\x82\x70\xbe\xb7\x4a\x1c\x5c\xb8\xfe\xe8\xbe\x3f\xfb\xfe\x58\x3f\x70\x12\xa0\x0f\x87\x4e\x77\x18\xfb\xe3" +
+		"\x22\xa5\xc2\xfa\x86\x0d\x11\xfe\x34\xee\x47\xa5\xcd\x9f\x86\x98\x00\xf4\x8f\x4f\xeb\xe2\x9a\xd6\xdf\x69\x81\x6f\xb1\xa9\x14\xd2" +
+		"\x17\x4b\x54\xd9\x90\x7d\x8f\x5c\x6a\xfd\x67\x2a\x73\x8f\x42\x73\x7e\xc3\x38\xf3\xa0\x96\x4c\x62\x9f\x74\x74\xdd\x44\xc5\xc8\xd7" +
+		"\x1d\xb1\xdb\x8a\xa4\x52\x83\xf3\x11\x68\xfa\x66\x69\x4c\xf2\x80\x8d\x21\x89\xb8\x7c\x8c\x81\x43\xd5\x6c\x87\x19\x07\xb3\x9b\x87" +
+		"\x15\x30\xbf\x0f\x46\x52\x7e\x88\x90\x30\xb8\xc7\xb7\xdf\xde\x12\x6f\x65\xfa\xf8\xcc\xe0\xab\x66\x38\x73\x41\xd8\x13\xd1\xbf\xd1" +
+		"\x0b\x73\xf6\x13\x99\x32\x29\xf5\x9f\x01\xc1\xce\xc8\x76\x0e\x99\x36\xea\xd9\xed\xc8\xf2\x81\x48\x89\x33\x0a\x2f\x2b\xad\xe4\x57" +
+		"\x29\xc2\x5a\x22\xfe\x21\x64\x60\x45\x52\xaa\xea\x37\x7f\x44\x8d\x58\x7a\xb9\x77\xfc\x82\x27\x78\x7b\xd2\xdc\x0f\x36\xbc\xf4\x1e" +
+		"\x2b\x30\xd5\x3e\xd1\x75\x9b\xfb\x85\x03\xda\x66\xc9\x2c\xf4\x07\x7a\xbe\x82\x79\x5d\xc2\x72\xb3\x77\xdf\x57\xd7\x7c\x87\x55\x26" +
+		"\x12\xf6\xd7\x03\xb5\x70\x2a\xab\x7b\x7b\x7e\x69\x35\x9d\x53\xa2\x75\x6c\x08\xc8\x5e\xde\x72\x27\xcf\x5f\x0a\x29\x16\x78\x7c\xd2" +
+		"\x25\x20\xe1\x83\x00\xaf\xda\x3f\x61\xa4\x0a\x0b\x88\x37\x29\x3a\x55\xad\x01\x07\x10\x28\xd4\x84\x1f\xfa\x9a\xc7\x06\x36\x41\x13" +
+		"\x1e\xc9\xda\xea\x86\x09\x71\xec\xdd\xa8\xed\x4f\x34\x6f\xa9\x67\xac\x9b\xc5\x92\x78\x27\x73\x93\xc6\x8f\x09\xfa\x03\xb8\xb9\x5f" +
+		"\x0a\x99\xb3\xe1\x78\xdb\x2e\x2e\x43\x2f\x5c\xd5\xbe\xf8\xfe\x44\x83\xbf\x5c\xbf\x70\xed\x40\x7c\x08\xaa\xe2\x4b\x83\x0a\xd7\x25" +
+		"\x07\xcd\xa9\xe6\x3d\xb6\xe3\x9f\x08\x6b\x89\xb6\x01\xc2\xbb\xe4\x07\xee\x0a\xba\xc3\xc8\x17\xa1\x31\x7a\xba\xd7\xc5\x77\x84\x92" +
+		"\x08\xc9\xc6\x5a\x4f\x95\x5e\x89\x52\xd5\x71\xb1\x91\xbb\x0a\xdb\x49\xbd\x82\x90\x96\x32\x03\xb3\x5d\x48\xaa\xb3\x8f\x8f\xc3\xa3" +
+		"\x27\x37\xf8\xce\x1d\x5a\x67\xb3\x49\x59\x0d\xdb\xfb\xd7\x09\xed\x9a\xf5\x4a\x2a\x3f\x27\x19\xd3\x38\x01\xc9\xc1\x7b\xdd\x9c\x9e" +
+		"\x10\x49\xa6\xc6\x5f\xf0\x19\xf0\xd2\x87\x70\x07\x27\x98\xe8\xb7\x90\x94\x32\xbd\x0c\x12\x98\x13\xa9\xf1\x79\xba\x62\x7f\x7d\x6a" +
+		"\x18\xb4\xfe\x96\x87\x32\xc4\x62\xc0\xea\x5a\x9b\xeb\x27\xce\xcb\xde\x88\x68\x94\x4f\xdf\x64\xee\x60\xa5\x12\x23\x61\xda\xed\xdb" +
+		"\x2f\xf2\xb6\xfd\x22\xdf\x49\xd2\x44\x0b\x2e\xae\xee\xfa\x8c\x02\xa6\xf4\x78\xcf\xcf\x11\xf1\xb2\xa4\xf7\x47\x34\x83\x88\x5d\x19" +
+		"\x2e\xc5\xf2\xf1\x92\x8f\xe9\x32\xe5\x6c\x78\x9b\x8f\x6b\xbc\xb3\xe8\xbe\x40\x57\xcb\xd8\xdb\xd1\x8a\x1b\x35\x2f\x5c\xef\x42\xff" +
+		"\x26\x5a\x5e\xcc\xd8\xb9\x29\x75\xe3\x3a\xd9\xf7\x5b\xf3\x42\x6d\x42\x4a\x4c\x6a\x77\x94\xee\x3f\x08\xc1\xd1\x00\x37\x8e\x54\x5e" +
+		"\x24\x05\xea\xa4\xc0\xbd\xe1\x12\x9d\x62\x42\xbb\x5a\xda\x0e\x68\x77\x8e\x65\x6c\xfc\xb3\x66\xbf\x20\x51\x7d\xa1\xdf\xd4\x27\x9c" +
+		"\x09\x4c\x97\xd8\xc1\x94\xc4\x2e\x88\x01\x80\x04\xcb\xbf\x2b\xc5\xfd\xb5\x19\x55\xd8\xb2\xd6\x6b\x76\xdd\x98\xa2\xdb\xf6\x04\x17" +
+		"\x2c\x30\xd5\xf3\x3b\xb3\x2c\x5c\x22\xb9\x97\x9a\x60\x5b\xf6\x4d\x50\x8b\x70\x52\x21\xe6\xa6\x86\x33\x0c\x96\x25\xc2\xaf\xe0\xb8" +
+		"\x01\xa7\x56\x66\xf6\x24\x1f\x68\x25\xd0\x1c\xc6\xdc\xb1\x62\x2d\x48\x86\xea\x58\x3e\x87\x29\x9e\x6a\xa2\xfc\x71\x6f\xdb\x6c\xf5" +
+		"\x0a\x32\x90\xe8\x39\x81\x13\xea\x4d\x12\xac\x09\x1e\x87\xbe\x7c\x6d\x35\x9a\xb9\xa6\x69\x79\xfc\xf4\x7b\xf2\xe8\x7d\x38\x2f\xcb" +
+		"\x15\x4a\xde\x9c\xa3\x6e\x26\x8d\xfe\xb3\x84\x61\x42\x5b\xb0\xd8\xc3\x12\x19\xd8\xfa\x0d\xfc\x75\xec\xd2\x1b\xf6\x9a\xa0\xcc\x74" +
+		"\x27\xaa\x8d\x3e\x25\x38\x0c\x0b\x1b\x17\x2d\x79\xc6\xf2\x2e\xee\x99\x23\x1e\xf5\xdc\x69\xd8\xdc\x13\xa4\xb5\x09\x5d\x02\x87\x72" +
+		"\x2c\xf4\x05\x1e\x6c\xab\x48\x30\x1a\x8b\x2e\x3b\xca\x60\x99\xd7\x56\xbb\xdf\x48\x5a\xfa\x1f\x54\x9d\x39\x5b\xbc\xbd\x80\x64\x61" +
+		"\x30\x1e\x70\xf7\x29\xf3\xc9\x4b\x1d\x3f\x51\x7d\xdf\xf9\xf2\x01\x51\x31\xfe\xab\x8a\xfa\x5e\xeb\xb0\x84\x3d\x7f\x84\xb2\x3e\x71" +
+		"\x29\x8b\xeb\x64\xf8\x12\xd2\x5d\x8b\x4d\x96\x20\x34\x7a\xb0\x23\x32\xdc\x4c\xef\x11\x3a\xe6\x0d\x17\xa8\xd7\xa4\xc9\x1f\x83\xbc" +
+		"\x1b\x36\x2e\x72\xa5\xf8\x47\xf8\x4d\x03\xfd\x29\x1c\x3c\x47\x1e\xd1\xc1\x4a\x15\xb2\x21\x68\x0a\xcf\x11\xa3\xf0\x2e\x46\xaa\x95" +
+		"\x0d\xc8\xa2\x14\x61\x10\xc0\xb3\x75\x43\x29\x02\x99\x92\x23\xd5\xaa\x1e\xf6\xe7\x8e\x1e\x5e\xbc\xbc\x1d\x9b\xa4\x1d\xc1\xc7\x37" +
+		"\x0a\x48\x66\x3b\x34\xce\x5e\x1c\x05\xdc\x93\x09\x2c\xb6\x97\x78\xcb\x21\x72\x9a\x72\xdd\xc0\x3a\x08\xaf\xa1\xeb\x92\x2f\xf2\x79" +
+		"\x0a\x87\x39\x1f\xb1\xcd\x8c\xdf\x60\x96\xb6\x4a\x82\xf9\xe9\x5f\x0f\xe4\x6f\x14\x3b\x70\x2d\x74\x54\x5b\xb3\x14\x88\x10\x98\xee" +
+		"\x1b\x5b\x29\x46\xf7\xc2\x89\x75\xf0\x51\x2f\xf8\xe6\xca\x36\x2f\x88\x26\xed\xd7\xea\x9c\x29\xf3\x82\xba\x8a\x2a\x08\x92\xfd\x5d" +
+		"\x01\x00\x1c\xf5\x12\xac\x24\x1d\x47\xeb\xe2\x23\x92\x19\xbc\x6a\x17\x3a\x8b\xbc\xb8\xa5\xb9\x87\xb4\xea\xc1\xf5\x33\x31\x5b\x6b" +
+		"\x2f\xd9\x77\xc7\x0f\x64\x5d\xb4\xf7\x04\xfa\x7d\x76\x93\xda\x72\x7a\xc0\x93\xd3\xfb\x5f\x5f\xeb\xc7\x2b\xeb\x17\xd8\x35\x8a\x32" +
+		"\x23\xc0\x03\x9a\x3f\xab\x4a\xd3\xc2\xd7\xcc\x68\x81\x64\xf3\x9e\x76\x1d\x53\x55\xc0\x54\x44\xd9\x9b\xe7\x63\xa9\x77\x93\xa9\xc4" +
+		"\x19\xd4\x3e\xe0\xc6\x08\x1c\x05\x2c\x9c\x0d\xf6\x16\x1e\xaa\xc1\xae\xc3\x56\xcf\x43\x58\x88\xe7\x9f\x27\xf2\x2f\xf0\x3f\xa2\x5d" +
+		"\x2d\x9b\x10\xc2\xf2\xe7\xac\x1a\xfd\xdc\xcf\xfd\x94\xa5\x63\x02\x8b\xf2\x9b\x64\x6d\x02\x08\x30\x91\x9f\x9d\x5c\xa1\xce\xfe\x59" +
+		"\x24\x57\xca\x6c\x2f\x2a\xa3\x0e\xc4\x7e\x4a\xff\x5a\x66\xf5\xce\x27\x99\x28\x3e\x16\x6f\xc8\x1c\xda\xe2\xf2\xb9\xf8\x3e\x42\x67" +
+		"\x0a\xbc\x39\x2f\xe8\x5e\xda\x85\x58\x20\x59\x24\x45\x09\x40\x22\x81\x1e\xe8\x67\x6e\xd6\xf0\xc3\x04\x4d\xfb\x54\xa7\xc1\x0b\x35" +
+		"\x19\xd2\xcc\x5c\xa5\x49\xd1\xd4\x0c\xeb\xcd\x37\xf3\xea\x54\xf3\x11\x61\xac\x39\x93\xac\xf3\x10\x1d\x2c\x2b\xc3\x0e\xac\x1e\xb0" +
+		"\x0f\x97\xae\x30\x33\xff\xa0\x16\x08\xaa\xfb\x26\xae\x13\xcd\x39\x3e\xe0\xe4\xec\x04\x1b\xa6\x44\xa3\xd3\xab\x54\x6e\x98\xc9\xc8" +
+		"\x16\xdb\xc7\x8f\xd2\x8b\x7f\xb8\x26\x0e\x40\x4c\xf1\xd4\x27\xa7\xfa\x15\x53\x7e\xa4\xe1\x68\xe8\x8a\x16\x64\x96\xe8\x8c\xfe\xca" +
+		"\x24\x0f\xaf\x28\xf1\x14\x99\xb9\x16\xf0\x85\xf7\x3b\xc4\xf2\x2e\xef\x83\x44\xe5\x76\xf8\xad\x3d\x18\x27\x82\x03\x66\xd5\xe0\x7b" +
+		"\x0a\x1b\xb0\x75\xaa\x37\xff\x0c\xfe\x6c\x85\x31\xe5\x5e\x17\x70\xea\xba\x80\x8c\x8f\xdb\x6d\xbf\x46\xf8\xca\xb5\x8d\x9e\xf1\xaf" +
+		"\x2e\x47\xe1\x5e\xa4\xa4\x7f\xf1\xa6\xa8\x53\xaa\xf3\xa6\x44\xca\x38\xd5\xb0\x85\xac\x10\x42\xfd\xc4\xa7\x05\xa7\xce\x08\x9f\x4d" +
+		"\x16\x6e\x5b\xf0\x73\x37\x83\x48\x86\x0c\xa4\xa9\xc0\x9d\x39\xe1\x67\x3a\xb0\x59\x93\x5f\x4d\xf3\x5f\xb1\x45\x28\x37\x57\x72\xb6" +
+		"\x18\xb4\x2d\x7f\xfd\xd2\xea\x4f\xaf\x23\x59\x02\xf0\x57\xa2\x74\x0c\xac\xcc\xd0\x27\x23\x30\x01\xed\x10\xf9\x65\x38\xf0\x91\x6f" +
+		"\x08\x9c\xb1\xb0\x32\x23\x8f\x5e\x49\x14\x78\x8e\x3e\x3c\x7e\xad\x4f\xc3\x68\x02\x0b\x3e\xd3\x82\x21\xde\xab\x10\x51\xc3\x77\x02" +
+		"\x24\x2a\xcd\x3e\xb3\xa2\xf7\x2b\xaf\x7c\x70\x76\xdd\x16\x5a\xdf\x89\xf9\x33\x9c\x7b\x97\x19\x21\xd9\xe7\x08\x63\x45\x1d\xd8\xd1" +
+		"\x17\x4f\xbb\x10\x4a\x4e\xe3\x02\xbf\x47\xf2\xbd\x82\xfc\xe8\x96\xea\xc9\xa0\x68\x28\x3f\x32\x64\x74\xaf\x86\x04\x57\x24\x5c\x3b" +
+		"\x17\x34\x0e\x71\xd9\x6f\x46\x6d\x61\xf3\x05\x8c\xe0\x92\xc6\x7d\x28\x91\xfb\x2b\xb3\x18\x61\x3f\x78\x0c\x27\x5f\xe1\x11\x6c\x6b" +
+		"\x1e\x8e\x40\xac\x85\x3b\x7d\x42\xf0\x0f\x2e\x38\x39\x82\xd0\x24\xf0\x98\xb9\xf8\xfd\x45\x59\x53\xa2\xfd\x38\x0c\x4d\xf7\xf6\xb2" +
+		"\x05\x29\x89\x8d\xc0\x64\x99\x07\xe1\xd4\xd5\xe2\x84\xb8\xd1\x07\x51\x98\xc5\x5c\xad\x66\xe8\xa9\xbf\x40\xf9\x29\x38\xe2\xe9\x61" +
+		"\x21\x62\x75\x4d\xb0\xba\xa0\x30\xbf\x7d\xe5\xbb\x79\x73\x64\xdc\xe8\xc7\x7a\xa0\x17\xee\x1d\x7b\xf6\x5f\x21\xc4\xd4\xe5\xdf\x8f" +
+		"\x12\xc7\x55\x36\x98\xc4\xbf\x6f\x3c\xeb\x25\x0a\xe0\x0c\x58\xc2\xa9\xf9\x29\x1e\xfb\xde\x4c\x84\x21\xbe\xf4\x47\x41\x75\x2e\xc6" +
+		"\x29\x26\x43\xe3\xba\x20\x26\xaf\xfc\xb8\xc5\x27\x93\x13\xbd\x51\xa7\x33\xc9\x33\x53\xe9\xd9\xc7\x9c\xb7\x23\x13\x65\x26\x50\x8e" +
+		"\x00\xcc\xf1\x3e\x0c\xb6\xf9\xd8\x1d\x52\x95\x1b\xea\x99\x0b\xd5\xb6\xc0\x7c\x5d\x98\xe6\x6f\xf7\x1d\xb6\xe7\x4d\x5b\x87\xd1\x58" +
+		"\x18\x5d\x1e\x20\xe2\x3b\x09\x17\xdd\x65\x41\x28\xcf\x2f\x3a\xaa\xb6\x72\x38\x73\xcb\x30\xfc\x22\xb0\xf8\x6c\x15\xab\x64\x5b\x4b" +
+		"\x14\xc6\x1c\x83\x6d\x55\xd3\xdf\x74\x2b\xdf\x11\xc6\x0e\xfa\x18\x67\x78\xe3\xde\x0f\x02\x4c\x0f\x13\xfe\x53\xf8\xd8\x76\x4e\x1f" +
+		"\x0f\x35\x68\x41\xb3\xf5\x56\xfc\xe5\xdb\xe4\x68\x04\x57\x69\x1c\x29\x19\xe2\xaf\x53\x00\x81\x84\xd0\x3e\xe1\x19\x5d\x72\x44\x9e" +
+		"\x1b\x8f\xd9\xff\x39\x71\x4e\x07\x5d\xf1\x24\xf8\x87\xbf\x40\xb3\x83\x14\x33\x74\xfd\x20\x80\xba\x0c\x0a\x6b\x6e\x8f\xa5\xb3\xe8" +
+		"\x0e\x86\xa8\xc2\x00\x9c\x14\x0c\xa3\xf8\x73\x92\x4e\x2a\xaa\x14\xfc\x3c\x8a\xe0\x4e\x9d\xf0\xb3\xe9\x10\x34\x18\x79\x6f\x60\x24" +
+		"\x2e\x6c\x5e\x89\x8f\x55\x47\x77\x0e\x54\x62\xad\x93\x2f\xcd\xd2\x37\x3f\xc4\x38\x20\xca\x2b\x16\xb0\x86\x14\x21\xe7\x91\x55\xc8" +
+		"\x05\xd7\x97\xf1\xab\x36\x47\x23\x7c\x14\xf9\xd1\xdf\x03\x2b\xc9\xff\x9f\xe1\xa0\xec\xd3\x77\x97\x2c\xe5\xfd\x5a\x0c\x01\x46\x04" +
+		"\x29\xa3\x11\x04\x63\xa5\xaa\xe7\x6c\x3d\x15\x28\x75\x98\x1d\x0c\x1d\xaf\x2d\xcd\x65\x51\x9e\xf5\xca\x89\x29\x85\x1d\xa8\xc0\x08" +
+		"\x29\x74\xda\x7b\xc0\x74\x32\x22\x73\xc3\xa4\xb9\x1c\x05\x35\x4c\xdc\x71\x64\x0a\x8b\xbd\x1f\x86\x4b\x73\x2f\x81\x63\x88\x33\x14" +
+		"\x1e\xd0\xfb\x06\x69\x9b\xa2\x49\xb2\xa3\x06\x21\xc0\x5e\xb1\x2c\xa2\x9c\xb9\x1a\xa0\x82\xc8\xbf\xcc\xe9\xc5\x22\x88\x9b\x47\xdc" +
+		"\x1c\x79\x3e\xf0\xdc\xc5\x11\x23\x65\x4f\xf2\x6d\x8d\x86\x3f\xee\xae\x29\xe8\xc5\x72\xec\xa9\x12\xd8\x0c\x8a\xe3\x6e\x40\xfe\x9b" +
+		"\x1e\x6a\xac\x1c\x6d\x3d\xd3\x15\x79\x56\x25\x7d\x3d\x23\x4e\xf1\x8c\x91\xe8\x25\x89\xa7\x81\x69\xfb\xb4\xa8\x77\x09\x77\xdc\x2f" +
+		"\x1a\x20\xad\xa7\x57\x62\x34\xee\xe6\x27\x3d\xd6\xfa\x98\xb2\x5e\xd0\x37\x74\x80\x80\xa4\x7d\x94\x8f\xcd\xa3\x32\x56\xfb\x6b\xf5" +
+		"\x19\x10\x33\xd6\xd8\x5c\xea\xa6\xfc\x7a\x9a\x23\xa6\xfd\x99\x96\x64\x2d\x77\x20\x45\xec\xe5\x13\x35\xd4\x93\x06\x72\x8a\xf9\x6c" +
+		"\x00\x6e\x59\x79\xda\x7e\x7e\xf5\x3a\x82\x5a\xa6\xfd\xdc\x3a\xbf\xc7\x6f\x20\x0b\x37\x40\xb8\xb2\x32\xef\x48\x1f\x5d\x06\x29\x7b" +
+		"\x0b\x0d\x7e\x69\xc6\x51\x91\x0b\xbe\xf3\xe6\x8d\x41\x7e\x9f\xa0\xfb\xd5\x7f\x59\x6c\x8f\x29\x83\x1e\xff\x8c\x01\x74\xcd\xb0\x6d" +
+		"\x25\xca\xf5\xb0\xc1\xb9\x3b\xc5\x16\x43\x5e\xc0\x84\xe2\xec\xd4\x4a\xc4\x6d\xbb\xb0\x33\xc5\x11\x2c\x4b\x20\xa2\x5c\x9c\xdf\x9d" +
+		"\x12\xc1\xea\x89\x2c\xc3\x1e\x0d\x9a\xf8\xb7\x96\xd9\x64\x58\x72\xf7\xf7\x74\x42\xd6\x2f\xd4\xc8\x08\x5b\x2f\x15\x0f\x72\x47\x2a" +
+		"\x16\xaf\x29\x69\x51\x57\xab\xa9\xb8\xbb\xe3\xaf\xeb\x24\x5f\xee\xe5\xa9\x29\xd9\xf9\x28\xb9\xb8\x1d\xe6\xda\xdc\x78\xc3\x2a\xae" +
+		"\x01\x36\xdf\x45\x7c\x80\x58\x8d\xd6\x87\xfb\x2f\x3b\xe1\x86\x91\x70\x5b\x87\xec\x5a\x4c\xfd\xc1\x68\xd3\x10\x84\x25\x6b\x67\xdc" +
+		"\x16\x39\xa2\x8c\x5b\x4c\x81\x16\x6a\xea\x98\x4f\xba\x6e\x71\x47\x9e\x07\xb1\xef\xbc\x74\x43\x4d\xb9\x5a\x28\x50\x60\xe7\xb0\x89" +
+		"\x03\xd6\x2f\xbf\x82\xfd\x1d\x43\x13\xf8\xe6\x50\xf5\x87\xec\x06\x81\x6c\x28\xb7\x00\xbd\xc5\x0f\x7e\x23\x2b\xd9\xb5\xca\x9b\x76" +
+		"\x11\xae\xeb\x52\x7d\xc8\xce\x44\xb4\xd1\x4a\xad\xdc\xa3\xcf\xe2\xf7\x7a\x1e\x40\xfc\x6d\xa9\x7c\x24\x98\x30\xde\x1e\xdf\xde\x54" +
+		"\x13\xf9\xb9\xa4\x12\x74\x12\x94\x79\xc5\xe6\x13\x8c\x6c\x8e\xe3\x6a\x67\x0e\x6b\xc6\x8c\x7a\x49\x64\x2b\x64\x58\x07\xbf\xc8\x24" +
+		"\x0e\x47\x72\xfa\x3d\x75\x17\x9d\xc8\x48\x4c\xd2\x6c\x7c\x1f\x63\x5d\xde\xee\xd7\xa9\x39\x44\x0c\x50\x6c\xae\x8b\x7e\xbc\xd1\x5b" +
+		"\x1b\x39\xa0\x0c\xbc\x81\xe4\x27\xde\x4b\xde\xc5\x8f\xeb\xe8\xd8\xb5\x97\x17\x52\x06\x7a\x61\x2b\x39\xfc\x46\xa6\x8c\x5d\x4d\xb4" +
+		"\x2b\xed\xb6\x6e\x1a\xd5\xa1\xd5\x71\xe1\x6e\x29\x53\xf4\x87\x31\xf6\x64\x63\xc2\xeb\x54\xa2\x45\x44\x4d\x1c\x0a\x3a\x25\x70\x7e" +
+		"\x2c\xf0\xa0\x9a\x55\xca\x93\xaf\x8a\xbd\x06\x8f\x06\xa7\x28\x7f\xb0\x8b\x19\x3b\x60\x85\x82\xa2\x73\x79\xce\x35\xda\x91\x5d\xec" +
+		"\x2d\x1b\xd7\x8f\xa9\x0e\x77\xaa\x88\x83\x0c\xab\xfe\xf2\xf8\xd2\x7d\x1a\x51\x20\x50\xba\x7d\xb0\x75\x3c\x8f\xb8\x63\xef\xb3\x87" +
+		"\x06\x56\x10\xc6\xf4\xf9\x24\x91\xf4\x23\xd3\x07\x1e\xb8\x35\x39\xf7\xc0\xd4\x9c\x13\x87\x06\x2e\x63\x0d\x7f\xd2\x83\xdc\x33\x94" +
+		"\x2d\x93\x3f\xf1\x92\x17\xa5\x54\x50\x13\xb1\x28\x73\x45\x2b\xeb\xcc\x5f\x99\x69\x03\x3f\x15\xec\x64\x2f\xb4\x64\xbd\x60\x73\x68" +
+		"\x1a\xa9\xd3\xfe\x4c\x64\x49\x10\xf7\x6b\x92\xb3\xe1\x3b\x30\xd5\x00\xda\xe5\x35\x4e\x79\x50\x8c\x3c\x49\xc8\xaa\x99\xe0\x25\x8b" +
+		"\x02\x7e\xf0\x48\x69\xe4\x82\xb1\xc7\x48\x63\x8c\x59\x11\x1c\x6b\x27\x09\x5f\xa7\x73\xe1\xac\xa0\x78\xce\xa1\xf1\xc8\x45\x0b\xdd" +
+		"\x2b\x7d\x52\x4c\x51\x72\xcb\xbb\x15\xdb\x4e\x00\x66\x8a\x8c\x44\x9f\x67\xa2\x60\x5d\x9e\xc0\x38\x02\xe3\xfa\x13\x6a\xd0\xb8\xfb" +
+		"\x0c\x7c\x38\x24\x43\xc6\xaa\x78\x7c\x87\x18\xd8\x67\x47\xc7\xf7\x46\x93\xae\x25\xb1\xe5\x5d\xf1\x3f\x7c\x3c\x1d\xd7\x35\xdb\x0f" +
+		"\x00\xb4\x56\x71\x86\xbc\x3f\x7c\x62\xa7\xb5\x6a\xcf\x4f\x76\x20\x7a\x1f\x43\xc2\xd3\x0d\x0f\xe4\xa6\x27\xdc\xdd\x9b\xd7\x90\x78" +
+		"\x1e\x41\xfc\x29\xb8\x25\x45\x4f\xe6\xd6\x17\x37\xfe\x08\xb4\x7f\xb0\x7f\xe7\x39\xe4\xc1\xe6\x1d\x03\x37\x49\x08\x83\xdb\x4f\xd5" +
+		"\x12\x50\x7c\xd5\x56\xb7\xbb\xcc\x72\xee\x6d\xaf\xc6\x16\x58\x44\x21\xe1\xaf\x87\x2d\x8c\x0e\x89\x00\x2a\xe8\xd3\xba\x06\x53\xb6" +
+		"\x13\xd4\x37\x08\x35\x53\x00\x6b\xce\xf3\x12\xe5\xe6\xf5\x2a\x5d\x97\xeb\x36\x61\x7e\xf3\x6f\xe4\xd7\x7d\x3e\x97\xf7\x1c\xb5\xdb" +
+		"\x16\x3e\xc7\x32\x51\xf8\x54\x43\x68\x72\x22\x48\x7d\xda\x9a\x65\x46\x7d\x90\xb2\x2f\x0b\x38\x66\x46\x86\x07\x7c\x6a\x44\x86\xd5"
+	m4 = "" +
+		"\x23\x6d\x13\x39\x3e\xf8\x5c\xc4\x8a\x35\x1d\xd7\x86\xdd\x7a\x1d\xe5\xe3\x99\x42\x29\x61\x27\xfd\x87\x94\x72\x23\xae\x51\x08\xad" +
+		"\x27\x76\x86\x49\x4f\x76\x44\xbb\xc4\xa9\xb1\x94\xe1\x07\x24\xeb\x96\x7f\x1d\xc5\x87\x18\xe5\x9e\x3c\xed\xc8\x21\xb2\xa7\xae\x19" +
+		"\x02\x3d\xb6\x87\x84\xe3\xf0\xcc\x0b\x85\x61\x88\x26\xa9\xb3\x50\x51\x29\xc1\x64\x79\x97\x3b\x0a\x84\xa4\x52\x9e\x66\xb0\x9c\x62" +
+		"\x1d\x35\x9d\x24\x5f\x28\x6c\x12\xd5\x0d\x66\x3b\xae\x73\x3f\x97\x8a\xf0\x8c\xdb\xd6\x30\x17\xc5\x7b\x3a\x75\x64\x6f\xf3\x82\xc1" +
+		"\x2a\x75\xa1\x71\x56\x3b\x80\x7d\xb5\x25\xbe\x25\x96\x99\xab\x28\xfe\x9b\xc7\xfb\x1f\x70\x94\x3f\xf0\x49\xbc\x97\x0e\x84\x1a\x0c" +
+		"\x08\x3a\xbf\xf5\xe1\x00\x51\xf0\x78\xe2\x82\x7d\x09\x2e\x1a\xe8\x08\xb4\xdd\x3e\x15\xcc\xc3\x70\x6f\x38\xce\x41\x57\xb6\x77\x0e" +
+		"\x1a\x5a\xd7\x1b\xbb\xec\xd8\xa9\x7d\xc4\x9c\xfd\xba\xe3\x03\xad\x24\xd5\xc4\x74\x1e\xab\x8b\x75\x68\xa9\xff\x82\x53\xa1\xeb\x6f" +
+		"\x0d\x74\x5f\xd0\x0d\xd1\x67\xfb\x86\x77\x21\x33\x64\x0f\x02\xce\x94\x50\x04\xa7\xbc\x2c\x59\xe8\x79\x0f\x72\x5c\x5d\x84\xf0\xaf" +
+		"\x20\x70\x67\x9e\x79\x87\x82\xef\x59\x2a\x52\xca\x9c\xef\x82\x0d\x49\x7a\xd2\xee\xcb\xaa\x7e\x42\xf3\x66\xb3\xe5\x21\xc4\xed\x42" +
+		"\x2e\x18\xc8\x57\x0d\x20\xbf\x5d\xf8\x00\x73\x9a\x53\xda\x75\xd9\x06\xec\xe3\x18\xcd\x22\x4a\xb6\xb3\xa2\xbe\x97\x9e\x2d\x7e\xab" +
+		"\x0f\xa8\x6f\x0f\x27\xe4\xd3\xdd\x7f\x33\x67\xce\x86\xf6\x84\xf1\xf2\xe4\x38\x6d\x3e\x5b\x9f\x38\xfa\x28\x3c\x6a\xa7\x23\xb6\x08" +
+		"\x03\xf3\xe6\xfa\xb7\x91\xf1\x66\x28\x16\x8e\x4b\x14\xdb\xae\xb6\x57\x03\x5e\xe3\xda\x6b\x2c\xa8\x3f\x0c\x24\x91\xe0\xb4\x03\xeb" +
+		"\x2f\x54\x5e\x57\x82\x02\xc9\x73\x24\x88\x54\x0e\x41\xf7\x83\xb6\x8f\xf0\x61\x3f\xd7\x93\x75\xf8\xba\x8b\x3d\x30\x95\x8e\x76\x77" +
+		"\x23\x81\x0b\xf8\x28\x77\xfc\x19\xbf\xf7\xee\xfe\xae\x3f\xaf\x4b\xb8\x10\x4c\x32\xba\x4c\xd7\x01\x59\x6a\x15\x62\x3d\x01\x47\x6e" +
+		"\x01\x4f\xcd\x5e\xb0\xbe\x6d\x5b\xee\xaf\xc4\x94\x40\x34\xcf\x32\x1c\x06\x8e\xf9\x30\xf1\x0b\xe2\x20\x7e\xd5\x8d\x2a\x34\xcd\xd6" +
+		"\x00\xc1\x5f\xc3\xa1\xd5\x73\x3d\xd8\x35\xea\xe0\x82\x3e\x37\x7f\x8b\xa4\xa8\xb6\x27\x62\x7c\xc2\xbb\x66\x1c\x25\xd2\x0f\xb5\x2a"
+)
+
+// Round constants and MDS matrix for width 5, 60 partial rounds
+const (
+	c5 = "" +
+		"\x0e\xb5\x44\xfe\xe2\x81\x5d\xda\x7f\x53\xe2\x9c\xca\xc9\x8e\xd7\xd8\x89\xbb\x4e\xbd\x47\xc3\x86\x4f\x3c\x2b\xd8\x1a\x6d\xa8\x91" +
+		"\x05\x54\xd7\x36\x31\x5b\x86\x62\xf0\x2f\xdb\xa7\xdd\x73\x7f\xbc\xa1\x97\xae\xb1\x2e\xa6\x47\x13\xba\x73\x3f\x28\x47\x51\x28\xcb" +
+		"\x2f\x83\xb9\xdf\x25\x9b\x2b\x68\xbc\xd7\x48\x05\x63\x07\xc3\x77\x54\x90\x7d\xf0\xc0\xfb\x00\x35\xf5\x08\x7c\x58\xd5\xe8\xc2\xd4" +
+		"\x2c\xa7\x0e\x2e\x8d\x7f\x39\xa1\x24\x47\xac\x83\x05\x24\x51\xb4\x61\xf1\x5f\x8b\x41\xa7\x5e\xf3\x19\x15\x20\x8f\x5a\xba\x96\x83" +
+		"\x1c\xb5\xf9\x31\x9b\xe6\xa4\x5e\x91\xb0\x4d\x72\x22\x27\x1c\x94\x99\x41\x96\xf1\x2e\xd2\x2c\x5d\x4e\xc7\x19\xcb\x83\xec\xfe\xa9" +
+		"\x2e\xb4\xf9\x9c\x69\xf9\x66\xeb\xf8\xa4\x21\x92\xde\x7f\xf6\x16\x21\xc7\xbb\x47\xb9\x37\x50\xc2\xb9\xea\x08\xd1\x84\x46\xc1\x22" +
+		"\x22\x4a\x28\xe5\xa3\x53\x85\xa7\xc5\x19\x81\x69\xe4\x05\xd9\xea\x0f\xc7\xda\x8b\x93\xee\x13\xb6\xd5\xf7\xd0\x99\xe2\x99\x52\x0e" +
+		"\x0f\x74\x11\xb4\x65\xe6\x00\xee\xd8\xaf\xdd\x6a\xfc\xa4\x9c\x30\x36\xf3\x3e\xcb\xd9\xa0\xf9\x78\x23\x79\x6b\x99\x3b\xbd\x82\xf7" +
+		"\x0f\x9d\x0d\x5a\xad\x2c\x95\x55\xa2\xbe\x71\x50\x39\x2d\x8d\x98\x19\xb2\x08\xae\x33\x70\xf9\x9a\x06\x26\xf9\xff\x5d\x90\xe4\xe3" +
+		"\x1e\x9a\x96\xdc\x82\x92\xbb\x59\x6f\x52\xa5\x95\x38\xd3\x29\x22\x97\x32\xb2\x52\x59\xcf\x74\x4b\x6a\x12\xd3\x07\x02\xd6\xfb\xa0" +
+		"\x08\x78\x05\x14\xcc\xd9\x03\x80\x88\x7d\x57\x8c\x45\x55\x5e\x59\x3c\xfe\x52\xea\xb4\xb9\x45\xc6\xc2\xcd\x4d\x52\x8f\xb3\xfe\x3c" +
+		"\x27\x24\x98\xfc\xed\x68\x6c\x7a\xc8\x14\x9f\xa3\xf7\x3e\xf8\xc2\xce\xd6\x47\x17\xe3\x55\x6d\x5a\x59\xf1\x19\xd6\x29\xcc\xb5\xfc" +
+		"\x01\xef\x8f\x9d\xd7\xc9\x3a\xac\x4b\x7c\xb8\x09\x30\xbd\x06\xeb\x45\xbd\x35\x0a\xff\x58\x5f\x10\xe3\xd0\xef\x8a\x78\x2e\xf7\xdf" +
+		"\x04\x5b\x9f\x59\xb6\x59\x5e\x61\x4d\xc0\x8f\x22\x2b\x46\x9b\x13\x8e\x88\x6e\x64\xbf\x3c\x40\xaa\x97\xea\x0a\xe7\x54\x93\x4d\x30" +
+		"\x0a\xc1\xe9\x1c\x57\xd9\xda\x91\x9f\xd6\xf5\x9d\x2a\x40\xff\x8e\xa3\xe4\x1e\x24\xe2\x47\xa3\x87\xad\xf2\x58\x42\x95\xd6\x1c\x66" +
+		"\x02\x8a\x16\x21\xa9\x40\x54\xb0\xc7\xf9\xa4\x21\x35\x3c\xd8\x9d\x0f\xd6\x70\x61\xae\xe9\x99\x79\xd1\x2e\x68\xf0\x4e\x62\xd1\x34" +
+		"\x26\xb4\x18\x02\xc0\x71\xea\x4c\x96\x32\x64\x7e\xd0\x59\x23\x6e\x50\xc1\x9c\x3f\xb3\xc9\x6d\x09\xd0\x2a\xae\x2a\x0d\xcd\x9d\xbc" +
+		"\x2f\xb5\xdd\xa8\x07\x2b\xb7\x2c\xba\xac\x2f\x63\xe4\x68\x21\x5e\x05\xc9\xde\x06\x75\x8d\xb6\xa9\x4a\xf3\x43\x84\xae\xdb\x46\x2b" +
+		"\x22\x12\xd3\xa0\xf5\xfc\xca\xf2\x44\xff\x35\x47\xfd\x82\x32\x49\xad\x8a\xb8\xba\x2a\x18\xd3\x83\xdd\x05\xc5\x6e\xe8\x94\xd8\x50" +
+		"\x1b\x04\x1a\xd5\xb2\xf0\x68\x42\x58\xe4\xdf\xae\xea\x09\xbe\x56\xa3\x27\x6f\xdb\x19\xf4\x4c\x01\x5c\xd0\xc7\xee\xd4\x65\xe2\xe3" +
+		"\x0a\x01\x77\x6b\xb2\x2f\x4b\x6b\x8e\xcc\xff\x33\xe7\x6f\xde\xd3\x14\x4f\xb7\xe3\xac\x14\xe8\x46\xa9\x1e\x64\xaf\xb1\x50\x0e\xff" +
+		"\x2b\x7b\x56\x74\xaa\xec\xc3\xcb\xf3\x4d\x3f\x27\x50\x66\xd5\x49\xa4\xf3\x3a\xe8\xc1\x5c\xf8\x27\xf7\x93\x64\x40\x81\x0a\xce\x43" +
+		"\x29\xd2\x99\xb8\x0c\xd4\x48\x9e\x4c\xf7\x57\x79\xed\x54\xb4\x8c\x60\xb0\x42\x25\x7b\x78\xfc\x00\x4c\x1b\x80\x33\x81\xa3\xbd\xfd" +
+		"\x1c\x46\x83\x1d\x9a\x74\x52\x93\x57\x64\x1c\x21\x9d\x72\x1a\x74\xa4\x27\x11\x00\x32\xb5\xe1\xdd\x19\xdd\xe3\x04\x24\xbe\x40\x1e" +
+		"\x06\xd7\x62\x6c\x95\x3c\xcb\x72\xf3\x71\x41\xdc\x34\xd5\x78\xe0\x36\x29\x6c\x06\x57\x67\x4f\x80\x73\x9a\xe1\xd8\x83\xe9\x12\x69" +
+		"\x28\xff\xdd\xc8\x6f\x18\xc1\x36\xc5\x40\x02\x74\x8e\x0c\x41\x0e\xdc\x5c\x44\x0a\x30\x22\xcd\x96\x0f\x10\x8c\x71\xcd\xa2\x93\x0c" +
+		"\x2e\x67\xf7\xee\x5e\x4a\xa2\x95\xf8\x5d\xee\xd0\x9e\x40\x0b\x17\xbe\x67\xf1\xb7\xed\x2a\xb6\xad\xb8\xec\x06\x19\xf6\xfb\xc5\xe9" +
+		"\x26\xce\x38\xfa\x63\x6c\x90\x63\x0e\x97\xf2\x51\x14\xa7\x9a\x2d\xca\x56\x85\x9e\xf7\x59\xe5\x3c\xe7\xab\xf2\x2c\x24\xe8\x0f\x27" +
+		"\x2e\x6e\x07\xc3\xc9\x5b\xf7\xc3\x4d\xd7\xa0\x1d\x00\xa7\xff\xec\x42\xcb\x3d\x16\xa1\xf7\x27\x21\xaf\xac\xb4\xc4\xcf\xd3\x5d\xb1" +
+		"\x2a\xa7\x4f\x75\x97\xf0\xc9\xf4\x5f\x91\xd7\x96\x1c\x3a\x54\xfb\x88\x90\xd2\x76\x61\x2e\x12\x46\x38\x4b\x14\x70\xda\x24\xd8\xcc" +
+		"\x28\x7d\x68\x1a\x46\xa2\xfa\xae\x2c\x7c\x09\x0f\x66\x8a\xb4\x5b\x8a\x71\x31\x3c\x15\x09\x18\x3e\x2e\xc0\xca\x63\x9b\x7f\x73\xfe" +
+		"\x21\x2b\xd1\x9d\xf8\x12\xea\xae\xf4\xa4\x06\x00\x52\x8f\x3d\x7d\xa5\xd3\x10\x6f\xf5\x65\xaa\x3b\x11\xe2\x9f\x33\x05\xe7\x3c\x04" +
+		"\x11\x54\xf7\xcf\x51\x91\x86\xbf\x1a\xaf\xb1\x4b\x35\x0e\xb8\x60\xf9\x7f\xd9\x74\x09\x26\xda\xb9\x38\x09\xc2\x84\x04\x71\x35\x04" +
+		"\x1d\xff\x63\x85\xcb\x31\xf1\xc2\x46\x37\x81\x0a\x4b\xd1\xb1\x6f\xbf\x51\x52\x90\x5b\xe3\x65\x83\xda\x74\x7e\x79\x66\x1f\xc2\x07" +
+		"\x0e\x44\x45\x82\xd2\x2b\x4e\x76\xc0\x81\xd3\x4c\x44\xc1\x8e\x42\x40\x11\xa3\x4d\x54\x76\x25\x28\x63\xea\x3c\x60\x6b\x55\x1e\x5c" +
+		"\x03\x23\xc9\xe4\x33\xba\x66\xc4\xab\xab\x66\x38\x32\x8f\x02\xf1\x81\x57\x73\xe9\xc2\x84\x63\x23\xff\x72\xd3\xaa\xb7\xe4\xef\xf8" +
+		"\x12\x74\x6b\xbd\x71\x79\x10\x59\x19\x3b\xba\x79\xcd\xec\x44\x8f\x25\xb8\xcf\x00\x27\x40\x11\x2d\xb7\x0f\x2c\x68\x76\xa9\xc2\x9d" +
+		"\x11\x73\xb7\xd1\x12\xc2\xa7\x98\xfd\x9b\x9d\x37\x51\x84\x2c\x75\xd4\x66\xc8\x37\xcf\x50\xd7\x3e\xfd\x04\x9e\xb4\x43\x8a\x22\x40" +
+		"\x13\xd5\x1c\x10\x90\xa1\xad\x48\x76\xd1\xe5\x55\xd7\xfe\xd1\x3d\xa8\xe5\x71\x3b\x25\x02\x6e\xbe\x5f\xdb\x48\x08\x70\x32\x43\xda" +
+		"\x00\x87\x4c\x13\x44\xa4\xad\x51\xff\x8d\xcb\x7c\xbd\x2d\x97\x43\xcb\x72\x74\x3f\x03\x94\xef\xe7\xf4\xa5\x8e\xbe\xb9\x56\xba\xa1" +
+		"\x22\xdf\x22\x13\x1a\xaa\xb8\x58\x65\xce\x23\x6b\x07\xf2\x44\xfa\x0e\xea\x48\xd3\x54\x6e\x97\xd6\xa3\x2a\x56\x20\x74\xfe\xf0\x8f" +
+		"\x0b\xf9\x64\xd2\xdb\xd2\x5b\x90\x87\x08\xb4\x37\xa4\x45\xfc\x3e\x98\x45\x24\xa5\x91\x01\xe6\xc1\x8b\xf5\xeb\x05\xa9\x19\xf1\x55" +
+		"\x09\xb1\x8d\x9b\x91\x7a\x55\xbc\xa3\x02\xbe\x1f\x7f\x18\x1e\x0e\x64\x0b\x9d\x73\xa9\xab\x29\x8c\x69\xb4\x35\xb5\xfc\x50\x2f\x32" +
+		"\x09\x4f\x55\x34\x44\x4f\xae\x36\xa4\xbf\xc1\xd5\xbf\x3d\xc0\x5b\xfb\xbb\xc7\x0a\x63\x65\x36\x6d\xd6\x74\x5a\x50\x67\x28\x9e\x43" +
+		"\x29\x99\xba\xb1\xa5\xf2\x52\x10\x51\x9f\xa6\x62\x2a\xf5\x3a\x15\xa3\xe2\x40\xc0\xda\x57\x01\xcb\x78\x4f\xdd\xc0\xdc\x23\xf0\x1f" +
+		"\x2f\x68\x98\xc0\x75\x81\xf6\x37\x1c\xa9\x4d\xb7\x37\x10\xe8\x80\x84\x30\x1b\xce\x8a\x93\xd1\x36\x69\x57\x5a\x11\xb0\x3a\x3d\x23" +
+		"\x07\x26\x8e\xaa\xba\x08\xbc\x19\xec\x16\xd7\xe1\x31\x8a\x47\x40\x56\x5d\xeb\x1e\x8e\x57\x42\xf8\x62\x17\x4b\x1a\x68\x66\xfc\xcb" +
+		"\x18\x62\x79\xb0\x03\x45\x4d\xb0\x13\x39\xff\x77\x11\x3b\xc9\xeb\x62\x60\x3e\x07\x8e\x1c\x66\x89\xa6\xc9\x58\x2c\x41\xa0\x52\x9f" +
+		"\x18\xa3\xf7\x36\x50\x91\x97\xd6\xe4\x91\x5b\xdd\x04\xd3\xe5\xdd\xb6\x7e\x2c\xc5\xde\x9a\x22\x75\x07\x68\xe5\x52\x47\x37\x17\x2c" +
+		"\x0a\x21\xfa\x19\x88\xcf\x38\xd8\x77\xcc\x1e\x2e\xd2\x4c\x80\x8c\x72\x5e\x2d\x4b\xcb\x2d\x3a\x00\x7b\x59\x87\xb8\x70\x85\x67\x1d" +
+		"\x15\xb2\x85\xcb\xe2\x6c\x46\x7f\x1f\xaf\x5e\xf6\xa6\x46\x25\x22\x83\x28\xc1\x84\xa2\xc4\x3b\xc0\x0b\x36\xa1\x35\xe7\x85\xfb\xa2" +
+		"\x16\x4b\x70\x62\xc4\x67\x1c\xf0\x8c\x08\xb8\xc3\xf9\x80\x6d\x56\x0b\x77\x75\xb7\xc9\x02\xf5\x78\x8c\xd2\x8d\xe3\xe7\x79\xf1\x61" +
+		"\x08\x90\xba\x08\x19\xac\x0a\x6f\x86\xd9\x86\x5f\xe7\xe5\x0e\xf3\x61\xc6\x1d\x3d\x43\xb6\xe6\x5d\x7a\x24\xf6\x51\x24\x9b\xaa\x70" +
+		"\x2f\xbe\xa4\xd6\x5d\x7e\xd4\x25\xa4\x27\x12\xe5\xa7\x21\xe4\xea\xa6\x27\xac\x5c\xb0\xeb\x87\x8c\xcc\x2e\xe0\xae\xd5\x43\xe9\x22" +
+		"\x04\x92\xbf\x38\x3c\x36\xfa\x55\x54\x03\x03\xa3\xb5\x36\xf8\x5e\x7b\x70\xa5\x8e\x85\x4a\xb9\xb9\x10\x3d\x7f\x5f\x37\x9a\xba\xaa" +
+		"\x05\xe9\x1f\xe9\x44\xe9\x44\x10\x4e\x20\x25\x1c\x56\x51\x42\xd6\x1d\x61\x85\xa9\xce\x85\x67\x5f\x6a\x96\x9d\x56\x29\x2d\xc2\x4e" +
+		"\x12\xfe\x5c\x20\x29\xe4\xb3\x38\x93\xd4\x63\xcb\x04\x1a\xca\xd0\x99\x5b\x96\x21\xe6\xe4\x9c\x3b\x7e\x38\x0a\x76\xe3\x6e\x6c\x1c" +
+		"\x02\x41\x54\xad\xf0\x25\x5d\x47\x95\x8f\x77\x23\x92\x14\x74\x13\x1f\x26\x29\xfa\xdc\x89\x49\x69\x06\xcd\x01\xdc\x6f\xa0\x78\x4e" +
+		"\x18\x82\x4a\x09\xe6\xaf\xaf\x4a\x36\xed\x24\x62\xa8\x6b\xd0\xba\xd7\x98\x81\x56\x44\xf2\xbb\xde\x88\x13\xc1\x34\x57\xa4\x55\x50" +
+		"\x0c\x8b\x48\x2d\xba\x0a\xd5\x1b\xe9\xf2\x55\xde\x0c\x3d\xbd\xdd\xdf\x84\xa6\x30\xaf\x68\xd5\x0b\xbb\x06\x98\x3e\x3d\x5d\x58\xa5" +
+		"\x17\x32\x5f\xd0\xab\x63\x58\x71\x36\x3e\x0a\x16\x67\xd3\xb6\x7c\x5a\x4f\xa6\x7f\xcd\x6a\xaf\x86\x44\x13\x92\x87\x8f\xdb\x05\xe6" +
+		"\x05\x0a\xe9\x5f\x6d\x2f\x15\x19\x12\x2f\x5a\xf6\x7b\x69\x0f\x31\xe5\x50\x77\x3f\xa8\xd1\x8b\xf7\x1c\xc6\xd0\xe9\x11\xfa\x40\x2e" +
+		"\x0f\x0d\x13\x9a\x0e\x81\xe9\x43\x03\x8c\xb2\x88\xd6\x26\x36\x76\x4b\xbb\x62\x95\xf0\x75\x69\x88\x57\x71\xec\x84\xed\xc5\x0c\x40" +
+		"\x1c\x0f\x86\x97\x79\x56\x89\xcd\xf7\x0f\xd2\xf2\xc0\xf9\x3d\x1a\x79\xb3\x9e\xbc\x7a\x1b\x1c\x54\x9d\xbb\xca\x7b\x8e\x74\x7c\xd6" +
+		"\x2b\xd0\xf9\x40\xad\x93\x6b\x79\x6d\x2b\xc2\xe0\x48\xbc\x97\x9e\x49\xbe\x23\xa4\xb1\x35\x98\xf9\xfe\x53\x6a\x16\xdc\x1d\x81\xe6" +
+		"\x27\xeb\x1b\xe2\x7c\x9c\x4e\x93\x47\x78\xc0\x9a\x00\x53\x33\x7f\xa0\x6e\xbb\x27\x5e\x09\x6d\x16\x7c\xe5\x4d\x1e\x96\xee\x62\xcb" +
+		"\x2e\x48\x89\xd8\x30\xa6\x7e\x5a\x8f\x96\xbd\xd3\x15\x5a\x7c\xa3\x28\x4f\xbd\x30\x7d\x1f\x71\xb0\xf1\x51\xbe\x62\x54\x8e\x2a\xea" +
+		"\x19\x3f\xe3\xdb\x0a\xb4\x7d\x3c\x5d\x2e\xc5\xe9\xc5\xbd\x99\x83\xc9\x89\x1f\x2c\xad\xc1\x65\xdb\x60\x64\xbb\xe6\xfc\xc1\xe3\x05" +
+		"\x2b\xf3\x08\x6e\x96\xc3\x6c\x7b\xce\x41\x59\x07\xad\x0c\x40\xed\x6e\x96\x61\xc0\x09\x67\x9e\x4e\x37\xcb\x13\x02\x7c\x83\xe5\x25" +
+		"\x12\xf1\x6e\x2d\xe6\xd4\xad\x46\xa9\x8c\xdb\x69\x7c\x6c\xad\x5d\xd5\xe7\xe4\x13\xf7\x41\xcc\xf2\x9f\xf2\xea\x48\x6e\x59\xbb\x28" +
+		"\x2a\x72\x14\x7d\x23\x01\x19\xf3\xa0\x26\x2e\x36\x53\xdd\xd1\x9f\x33\xf3\xd5\xd6\xec\x6c\x4b\xf0\xad\x91\x9b\x03\x43\xb9\x2d\x2f" +
+		"\x21\xbe\x0e\x2c\x4b\xfd\x64\xe5\x6d\xc4\x7f\x95\x78\x06\xdc\x5f\x0a\x2d\x9b\xcc\x26\x41\x2e\x29\x77\xdf\x79\xac\xc1\x0b\xa9\x74" +
+		"\x0e\x2d\x7e\x1d\xc9\x46\xd7\x0b\x27\x49\xa3\xb5\x43\x67\xb2\x5a\x71\xb8\x4f\xb9\x11\xaa\x57\xae\x13\x7f\xd4\xb6\xc2\x1b\x44\x4a" +
+		"\x26\x67\xf7\xfb\x5a\x4f\xa1\x24\x61\x70\xa7\x45\xd8\xa4\x18\x8c\xc3\x1a\xdb\x0e\xae\x33\x25\xdc\x9f\x3f\x07\xd4\xb9\x2b\x3e\x2e" +
+		"\x2c\xcc\x6f\x43\x1f\xb7\x40\x07\x30\xa7\x83\xb6\x60\x64\x69\x7a\x15\x50\xc1\x2b\x08\xdf\xeb\x72\x83\x0e\x10\x7d\xa7\x8e\x34\x05" +
+		"\x08\x88\x8a\x94\xfc\x5a\x2c\xa3\x4f\x02\x01\x46\x24\x20\x00\x1f\xae\x6d\xbe\xe9\xe8\xca\x0c\x24\x2e\xc5\x06\x21\xe3\x8e\x6e\x5d" +
+		"\x02\x97\x7b\x34\xee\xaa\x3c\xb6\xad\x40\xdd\x42\xc9\xb6\xfd\xd7\xa0\xd2\xfb\xe7\x53\xaf\x88\xb3\x6a\xcf\xcd\x3c\xcb\xc5\x3f\x2a" +
+		"\x12\x0c\xcc\xe1\x3d\x28\xb7\x5c\xfd\x6f\xb6\xc9\xea\x13\xa6\x48\xbf\xcf\xe0\xd7\xe6\xff\x8e\x96\x10\xb5\xe9\xf9\x71\xe1\x6b\x9a" +
+		"\x09\xfa\xd2\x26\x9c\x4a\x8e\x93\xc8\x1e\x1b\x97\x70\xea\x09\x8c\x92\x78\x7a\x45\x75\xb2\xbd\x73\xa0\xbf\x2a\xf3\x2f\x86\xff\x3c" +
+		"\x02\x60\x91\xfd\x3d\x4c\x44\xd5\x0a\x4b\x31\x0e\x4a\xc6\xf0\xfa\x0d\xeb\xdb\x70\x77\x5e\xeb\x8a\xf6\x30\xcf\xfb\x60\x09\x2d\x6f" +
+		"\x29\x40\x4a\xa2\xba\x56\x5b\x77\xbb\x7f\xba\x9d\xfb\x6f\xc3\x21\x25\x43\xcc\x56\xaf\xad\x6a\xfc\xb9\x04\xfd\x2b\xca\x89\x39\x94" +
+		"\x27\x49\x47\x5c\x39\x9a\xaf\x39\xd4\xe8\x7c\x25\x48\x69\x5b\x4e\xf1\xff\xd8\x65\x90\xe0\x82\x7d\xe7\x20\x13\x51\xb7\xc8\x83\xf9" +
+		"\x09\x8c\x84\x23\x22\x47\x9f\x72\x39\x91\x2b\x50\x42\x46\x85\xcb\xa2\xeb\xe2\xdc\x2e\x4d\xa7\x0a\xc7\x55\x7d\xab\x65\xff\xa2\x22" +
+		"\x18\xce\xf5\x81\x22\x2b\x64\x7e\x31\x23\x8e\x57\xfe\xad\x7d\x5c\x75\x8a\xce\x14\xc9\x3c\x4d\xa4\x01\x91\xd0\xc0\x53\xb5\x19\x36" +
+		"\x13\x17\x78\x39\xc6\x8a\x50\x80\xd4\xe7\x46\x74\x5e\x43\x71\x1d\x3c\xbc\x0c\xa4\xa1\x08\xf9\x8d\x63\xb2\xaa\x68\x16\x98\xde\x60" +
+		"\x02\x0c\xa6\x96\xf5\x31\xe4\x3e\xc0\x88\xf5\x6f\x4b\x74\x32\x56\x26\xcc\x4d\xf7\x12\xc0\xe5\xf0\xa9\x07\xd8\x8e\x5f\x0d\xef\xfd" +
+		"\x27\x23\x0e\xed\xe9\xcc\xcf\xc9\xfa\x80\x5a\x30\xfc\x54\x8d\xb6\x93\xd1\x37\x08\xc6\x46\x84\x1d\x16\xe0\x28\x38\x7c\x7a\xc0\x22" +
+		"\x01\x64\x59\x11\xc1\x19\x8b\x01\xd6\x4f\xde\x34\xa3\x42\xa1\x78\x64\x97\xc0\x59\x69\xa0\x15\x43\x90\x57\xd2\xfe\x75\xbb\x28\x1c" +
+		"\x2c\x32\x3f\xe1\x64\x81\xbf\x49\x6e\x43\x9c\x88\x34\x1c\xe2\x5f\x19\x89\x71\xe1\x44\x87\x05\x6c\xfd\xca\x4a\x45\x1a\x5d\x86\x43" +
+		"\x0f\xc0\x82\xdf\xe7\x07\x28\xe8\x45\x0b\xd2\x07\x4c\x3e\x22\xe1\xb0\x22\xc1\x24\xd3\xbf\xfe\x8b\x5a\xf8\x8a\xe6\xdb\x50\x85\xc8" +
+		"\x20\x52\xc1\x74\x80\x0d\xb2\x09\xd8\xcd\xca\x56\x8d\xcc\x25\xb3\xbe\x96\x42\x11\x6a\xc4\xc7\x7e\xfe\x8a\x48\x8b\x42\x35\x21\xee" +
+		"\x28\xe4\x20\xe1\x0d\xf2\xfb\xb5\xaf\x96\xd6\x21\xd5\x54\x23\x19\x0b\xe3\x51\xce\x81\x29\x06\x5a\x8d\xd9\xfd\x05\xb3\xec\xe9\xc0" +
+		"\x25\x69\x8c\xa5\xe2\x4a\x1b\x79\x9f\x78\x3c\x44\x62\xa2\x4d\xb6\x55\xd6\xae\x1b\xda\xcd\x1c\xb5\x49\xd6\xe0\xbc\x3a\xe5\x06\x9a" +
+		"\x16\x0a\x99\x81\xa5\xc8\x9a\x57\xcf\x8f\xfb\xfa\x57\xd5\x10\x49\xa2\x97\xb6\x10\x74\x42\x2a\xc1\x34\xd9\xb8\x57\xd6\x98\x4d\x35" +
+		"\x21\xc9\x1a\x39\xe1\x45\xc3\xbc\x34\xd9\xb6\x94\xb8\x43\xf3\xbf\x8b\x7c\xeb\xf5\x9d\xdb\xb0\xa0\x64\x64\x2b\x06\x99\x97\xf3\xd4" +
+		"\x1a\xc8\xd8\x0d\xcd\x5e\xe8\x76\xd2\xb0\x93\x45\xef\x11\x23\x45\xd6\xea\xa0\x29\xd9\x3f\x03\xb6\xd1\x09\x75\x46\x1e\x41\x73\x4c" +
+		"\x0a\xb3\xe6\xad\x0e\xcf\x8b\x8e\x7c\x16\x62\xa4\x17\x4c\x52\x22\x5d\x82\x28\x95\xe2\x75\x55\x44\xb8\xdb\xce\xa5\x65\x7c\xe0\x2c" +
+		"\x1c\x67\x51\x82\x51\x26\x20\xae\x27\xe3\xb0\xb9\x17\xb3\xa2\x1c\xa5\x2e\xf3\xef\x59\x09\xb4\xe1\xc5\xb2\x23\x7c\xbd\xab\x33\x77" +
+		"\x2c\xdb\xc9\x98\xdf\xd7\xaf\xfd\x3d\x94\x8d\x0c\x85\xba\xd2\xe2\xe3\x7a\x4a\x3e\x07\xa7\xd7\x5d\x0c\x8a\x90\x92\xac\x2b\xed\x45" +
+		"\x23\xb5\x84\xa5\x6e\x21\x17\xb0\x77\x4b\xf6\x7c\xc0\xde\xe3\x33\x24\x33\x73\x50\x30\x9d\xff\x83\x3e\x49\x1a\x13\x3b\xb6\x3b\x2e" +
+		"\x1e\x9e\x2b\x31\x0f\x60\xba\x9f\x8c\xb7\x30\x30\xa3\xc9\xd2\xa1\x0d\x13\x3b\xc6\xba\x4e\xc1\x15\x2f\x3d\x20\xde\x14\x65\xe9\xa5" +
+		"\x0e\x01\xe3\x65\xba\x5b\x30\x31\xab\xc3\xe7\x20\x14\x0a\xe7\x46\xc9\xab\x5d\xab\x98\x75\x20\xc4\x60\xbc\xd4\xf1\xfa\x5b\x22\xdb" +
+		"\x04\x08\x84\xcd\xcf\xc6\x4b\xfc\x7b\x71\x27\x34\x04\x98\xd5\xc4\x43\x38\x20\x11\xb6\x1c\x9a\x4b\x13\x87\xd8\x5b\xc1\x26\x4e\x68" +
+		"\x19\x0b\x1e\xe1\x20\x5e\xb9\x50\x0c\x74\xa3\x99\x8f\x2b\xea\x36\x35\x3f\x17\x24\xd6\x06\x7e\xd0\xa0\xa1\x7d\xe3\x11\xef\x96\x68" +
+		"\x16\x47\xc7\x2a\xec\x6c\x43\x88\xd0\x4f\x52\xfc\x23\xcd\x9c\x08\xc1\xdf\xcf\x65\xce\x61\xe1\x65\xfc\x28\xd1\xf8\x32\xbd\x3b\x2c" +
+		"\x24\x30\x00\x63\x46\xa0\x14\x5f\x79\x98\x80\xcc\x4c\x87\x36\x26\x9f\x54\x94\xd8\x9f\xb4\x8b\x02\x84\x2e\x59\x5b\x71\xe4\x54\x1d" +
+		"\x17\x7b\x9a\x08\x34\x39\x17\xe1\x36\x51\x07\xa3\xda\x3a\xe7\xf6\x9d\x85\x39\x02\xbb\x16\xba\xcb\x32\x21\x85\x02\x52\xb7\x57\xaf" +
+		"\x04\xa4\x20\xe6\x42\xb1\x1a\xe9\x4e\x58\x86\x2a\x68\xf5\xe3\x26\x09\xcd\x53\xd0\xae\x29\x42\x34\x39\xb1\x1d\x04\x66\x6d\xf4\xf8" +
+		"\x25\xd0\xe0\xf7\x39\xfb\x39\xfc\x10\x5a\x88\xfa\xb0\xaf\xd8\x10\xde\x24\x61\x85\x8e\x95\x6c\xcc\xcd\xfa\xbe\xdd\xb6\xa2\x5c\x8f" +
+		"\x04\x47\x6d\x91\xb7\xef\xf2\xfd\x85\x90\x5c\xbf\x58\x65\x1e\xdc\x32\x0c\xb1\x56\x10\xea\xed\x45\x2c\x4d\x4f\xfa\x0c\x74\x0a\x27" +
+		"\x10\x90\xc0\xb6\x8b\x3d\x7d\x7b\x8b\xc9\xca\x24\x19\xeb\x8d\xea\x1c\x28\xf6\xd5\xe1\x25\x0c\xb5\xe9\x78\x0f\xd9\xca\x28\x6f\xae" +
+		"\x25\x39\x3c\xe3\xb9\x25\x6d\x50\x44\x8a\x72\x5c\x5c\x7c\xd5\xad\x37\x6f\x2d\x43\x58\x55\xc1\x0e\xbf\x28\x99\xcb\x5c\x66\x17\xbe" +
+		"\x25\x93\x1c\x0c\x73\x71\xf4\xf1\xfc\x86\x2f\x30\x6e\x6e\x58\x30\xed\x82\x43\x88\xd6\xb9\x34\x26\x97\xd1\x44\xf0\xfa\xb4\x66\x30" +
+		"\x23\x96\xcb\x50\x17\x00\xbb\xe6\xc8\x2a\xad\x51\xb0\xfb\x79\xcf\x8a\x4d\x35\x31\x85\xd5\x80\x82\x03\xf7\x3f\x22\xaf\xbf\x62\xf6" +
+		"\x26\xa3\x63\x48\x33\x48\xb5\x89\x54\xea\x74\x8a\x71\x29\xa7\xb0\xa3\xdc\x90\x68\xc3\xcc\xa7\xb5\xb3\xf0\xce\x03\xb8\x72\x48\x84" +
+		"\x27\xca\x10\x7c\xa2\x04\xf2\xa1\x8d\x6f\x15\x35\xb9\x2c\x54\x78\xc9\x9b\x89\x33\x34\x21\x5f\x6b\xa7\xa0\xe5\xb4\x5f\xcd\x68\x97" +
+		"\x26\xda\x28\xfc\x09\x7e\xd7\x7c\xe4\x66\x2b\xde\x32\x6b\x2c\xce\xac\x15\xf7\x30\x11\x78\x58\x1d\x8d\x2d\x02\xb3\xb2\xd9\x10\x56" +
+		"\x05\x6a\xb3\x51\x69\x1d\x8b\xb3\x70\x3e\x30\x55\x07\x0a\xc9\xcc\x65\x57\x74\xc1\xbb\x35\xd5\x75\x72\x97\x1b\xa5\x6e\xe0\xcb\x89" +
+		"\x26\x38\xb5\x7f\x23\xb7\x54\xae\xc7\x6d\x10\x9a\x2f\x48\x1a\xa3\xc2\x25\x47\xa1\x1f\xfc\x50\x15\x2d\x72\x9a\xf6\x32\x37\x6a\x90" +
+		"\x30\x47\x54\xbb\x8c\x57\xd6\x07\x32\xf4\x92\xc2\x60\x51\x84\xfd\xc3\x3e\x46\xa5\x32\xbd\xec\x80\xea\x7b\xc5\x51\x9e\xde\x7c\xef" +
+		"\x00\xd1\x72\x7f\x84\x57\xee\x03\x51\x4f\x15\x5b\x58\x06\xcb\xf7\x48\xec\x68\x57\xfc\x55\x40\x10\x75\x2a\xc9\x3a\x9b\x76\x19\xac" +
+		"\x00\xee\x1f\x3c\x66\xfb\xc0\x5c\x43\xba\x29\x5a\x30\x3c\x72\xfa\xb5\xbc\xa8\x68\x05\xec\x94\x19\xc5\x88\xe5\x09\x47\x76\x1f\xa3" +
+		"\x0a\xfa\xfa\xdc\xf5\xb4\xdd\x4a\x4a\x76\xb5\xa1\xd8\x24\x15\xfd\x10\xa1\x9f\xbc\xfc\x59\x07\x8c\x61\xf9\x29\x7e\xb6\x75\xd9\x72" +
+		"\x0b\x24\x49\xf3\x97\x46\x08\x5e\x86\xce\x45\xe8\xee\xd1\x08\xee\x65\xa2\x34\x83\x5a\x0a\x6a\x5e\xa8\x99\x6d\x12\x4d\xd0\x4d\x0a" +
+		"\x20\x6b\x0c\xe2\xf1\xb2\xc5\xb7\xc9\xf3\x7b\x00\x45\x22\x70\x95\xf6\xc6\xf0\x71\xec\x3b\xdd\xa7\x6a\x7d\xdf\x48\x23\xdd\x5d\xd6" +
+		"\x0f\xeb\xa4\xfb\x87\x83\x4c\x7c\xb6\x96\xe6\x74\x33\x62\x8c\xd6\xca\xff\xc3\xa4\xef\x20\xfe\xa8\x52\xc7\xe1\x02\x94\x59\x40\x9c" +
+		"\x25\x4d\xbf\xac\x74\xc4\x9b\x0b\x89\x26\x75\x2e\x08\x4e\x02\x51\x3b\x06\xf1\x31\x5e\x6d\x70\xe1\x81\x73\xe9\x72\x33\x6e\x55\xd3" +
+		"\x0a\xdd\xb1\x37\x2c\xee\x4e\x16\x46\x55\x16\x8c\x36\x75\x59\xe1\x96\x06\xc5\xbd\x17\x91\x0a\xeb\x37\x71\x9e\xdf\xa0\xca\x87\x62" +
+		"\x26\xb2\x5b\x7e\x25\x7f\x3e\x97\xc7\x99\x02\x4f\xb0\x19\xf6\x5c\x6c\xa4\xd8\xd8\x1b\x1a\xe1\x62\x21\xa5\x89\xd6\x88\x31\xd7\x59" +
+		"\x09\x09\x95\xb7\x9a\xce\xc2\x40\x41\x3b\x8d\x4c\x65\x87\x87\xe5\xa4\x65\x7b\x9a\xb0\x0b\xdb\x5b\x19\x60\xb1\x05\x9e\x11\x3b\xa3" +
+		"\x08\xdb\xdc\x2e\x21\xef\x11\xf2\xc5\x72\x99\x68\x78\x43\xce\xa3\xeb\x0d\x8e\x40\xe9\x91\x31\xf4\x29\x74\x17\x8d\x44\xf7\x3b\x7b" +
+		"\x09\xe8\xab\xa6\x71\x48\x11\x97\x67\x9f\xaf\x75\x2a\x0f\x78\xe3\x42\xfe\x9c\x49\x15\x96\xab\x67\x58\xf1\x70\x93\x97\x85\x17\x9f" +
+		"\x1d\xeb\x05\x18\x0e\x83\x3e\x45\x65\x90\x52\xa7\xeb\xaf\x81\x6c\x7e\xfd\x12\xa7\xf9\xee\xc9\x4b\x7b\xc7\xc6\x83\xf1\x36\x3d\x5c" +
+		"\x19\xa7\x0e\xc6\xbd\xfc\x90\x98\xa9\x26\xef\xbc\xc0\x4a\xa9\xee\x24\x89\x97\xe8\xb2\xc2\x4a\xf3\x35\xfd\x65\x23\xe5\x25\x08\x79" +
+		"\x21\xd7\x73\x66\x0a\xda\xfb\x8a\x87\x99\x86\xf9\xaa\xb4\x89\x05\x66\x35\x3a\x37\x77\xd8\xa3\xf1\xeb\x93\xab\xe1\x0b\xbf\x1f\x64" +
+		"\x09\xf1\x89\x0f\x72\xe9\xdc\x71\x3e\x20\xba\x63\x7b\x89\xd5\xd3\x97\xa6\xb0\x1f\xcd\x66\x73\x47\xf6\xf4\x66\x17\x84\x1c\x39\x01" +
+		"\x05\xaf\x45\x93\x61\xeb\x45\x4d\x2a\x30\x0c\x61\xe4\x46\x99\x8d\x48\xfa\x1f\x89\x7b\xf2\x19\xd6\x08\xc2\x14\x5c\x33\xb1\x11\xc3" +
+		"\x0f\xa1\xa1\xd6\x82\x9f\x03\x45\x66\x4a\x66\xdc\x75\xa6\x57\x33\x5f\x33\x6f\x15\xf3\x40\x75\x6c\xfa\x12\xfc\x85\x0c\xc8\xb5\x13" +
+		"\x02\xe4\x7a\x35\xbc\xc0\xc3\xa0\xbd\xa0\xb1\xc0\x30\x7a\xd5\x43\xf4\x28\x0f\xcf\x87\xf6\x36\xf8\x53\x65\x5c\xf9\x7a\x62\x8b\xb0" +
+		"\x14\xf7\x73\xe9\x83\x4c\x6b\xde\xb8\xf9\x0e\x78\xbf\x4c\x24\xb7\x20\x34\x11\x46\x01\x12\x49\x10\x36\x62\x18\x95\x20\x4d\x0f\x12" +
+		"\x10\x2d\x98\xcf\x50\x2e\xd8\x43\x25\x5c\xf1\x9d\x29\xbc\x7d\x8e\x64\x2a\xbe\x7c\xfd\x63\x99\x92\xff\xb0\x91\x96\x2f\xc8\xf7\xcc" +
+		"\x04\x3d\xd5\xf4\xaa\x5a\x76\xdd\x4c\x47\xf6\xc6\x5d\xa7\xca\x23\x20\xd4\xc7\x3a\xd3\x29\x47\x38\xcb\xa6\x86\xa7\xe9\x13\x73\xc2" +
+		"\x21\x83\x38\x19\xc3\x33\x71\x94\xa6\xc0\xd2\x9a\x48\xd4\xf2\x67\x6f\x0e\x7c\x79\x74\x3a\x30\x6f\x4c\xfd\xb2\xb2\x6b\xd1\x1e\xfa" +
+		"\x0f\x28\x19\x25\xcf\x5e\xe6\x49\xb4\x74\xa6\x81\x9d\x11\x6c\xa3\xeb\x4e\xca\x24\x6c\x31\x1e\xca\xdc\x53\x26\x2a\x3c\xff\x2b\x53" +
+		"\x0d\x3e\x24\x77\xa7\xb1\x0b\xeb\x44\x70\x9c\x77\x46\xd6\x82\x4e\xdf\x62\x5d\xd6\x05\x04\xd5\xdc\x93\xce\x66\x2f\x15\xc2\x38\xd6" +
+		"\x2c\xd7\xf6\x41\xbe\xdb\xf6\x69\x56\xff\x8a\x01\xbe\x9c\xde\x35\xd8\x0f\x80\xab\x51\xe7\x3b\x49\xac\xbf\xc3\xef\xf5\xae\xfc\x44" +
+		"\x29\xe9\x5b\x49\x2b\xf2\xf9\x5f\x4d\x09\x38\x0f\x98\xb7\x4e\x38\x91\x49\xd2\x40\x45\x81\x1d\x7a\x86\xdd\x86\x13\x10\x46\x3c\xf8" +
+		"\x22\xda\x66\xbc\x62\xe8\xf0\x11\x26\x6e\xfc\xa8\x6a\x6c\x81\x0f\x9a\xe4\xc5\x1a\xf6\xff\xeb\x57\xf8\xb3\xc5\x0d\xf8\x3c\xc1\x3e" +
+		"\x0f\xe6\xd3\x0d\xe7\xa8\x2d\x16\x30\x23\x49\x17\x94\xf4\xac\xa3\x22\x0d\xb7\x9e\x81\x29\xdf\x36\x43\x07\x2d\x84\x19\x25\x55\x4a" +
+		"\x00\x50\xe8\x42\xa1\x29\x99\x09\x12\x3c\x46\xef\xf1\x85\xc2\x3a\xd3\x12\xd0\x3f\xef\x1a\xdf\xec\xc7\xe0\x7e\xcb\x29\x8f\xd6\x7f" +
+		"\x21\x30\xa3\xa7\xb3\x22\x12\x22\xbe\x34\xcc\x53\xa4\x2d\x77\x33\x66\x6f\x9d\xdf\x71\x4e\xd7\xc5\x88\x5c\xbb\xdb\x63\x10\x8c\x21" +
+		"\x2d\xf9\xee\x29\x4e\xdf\x99\xe3\xd8\xd5\x88\x3f\xe0\x56\x6c\x24\xaa\x66\x73\x1f\x34\xa9\x32\x80\xe1\xd3\x28\xe6\x7b\x33\xc9\xfa" +
+		"\x1b\xf7\xd6\xe4\x89\xad\x8c\x0c\xf2\x6e\xb6\x8c\xc2\x1f\xf5\x41\x58\x13\x23\x96\xdc\x25\x0a\xeb\xa4\xb6\xfc\x5f\xc3\x37\x27\x62" +
+		"\x0c\x60\x2f\xa1\x55\xbe\x95\x87\x61\xea\xf7\x39\x61\x7a\xb1\x36\xcf\x7b\x80\x77\x28\xbf\x7f\xe3\x5d\x47\x78\xd3\x11\x78\x0e\x54" +
+		"\x2e\x50\xe2\xc5\xb3\x6a\xa2\x05\x32\x40\x7d\x86\xb8\xd2\x2d\x7d\x51\x54\x08\x0a\x24\x97\x2f\xae\xb6\x3f\xaf\x01\x21\xed\x7f\x21" +
+		"\x17\xc2\x51\x09\x82\xa7\xb5\x82\x57\x10\xd6\x29\x0e\xc4\xf7\x82\xf6\x74\x99\x5e\xe8\x40\x9b\x42\xb4\x59\x12\x3b\x18\x03\x32\xe1" +
+		"\x0b\x0d\x52\xf0\x3c\x8a\xf7\x27\x68\x03\xec\xf2\x46\x5b\x88\x5b\x21\x33\x7b\x53\x8e\xab\xd2\xf6\xb2\xab\x25\x5f\x37\x6b\x42\xa8" +
+		"\x0f\x56\x33\xdf\x19\x72\xb9\x45\x59\x53\xd8\x8a\x63\xf8\x06\x47\xa9\xac\x77\xc6\xc0\xf8\x5d\x45\x61\x97\x2d\xd8\xfa\xb8\xbd\x14" +
+		"\x0e\xbf\x7a\xd2\x9c\xa1\x38\x04\xe1\x42\x2e\x93\x96\x81\x15\x51\x24\x78\x0f\xf4\x3e\x76\xe9\x29\x03\x54\x98\x13\x0a\x7f\x15\x72" +
+		"\x1a\xff\x13\xc8\x1b\xda\x47\xe8\x0b\x02\x96\x21\x73\xbb\xa3\x43\xe1\x8f\x94\xbe\xe2\x7c\x8a\x57\x66\x1b\x11\x03\xa7\x20\xff\xe2" +
+		"\x21\x04\x49\xdb\xf5\xcf\x30\x61\xda\x24\x65\xbe\x85\x50\x58\x62\xd3\xf3\x1d\xe1\xa3\xb5\x8f\xf3\x57\x13\xbe\x57\xef\xac\x6c\x07" +
+		"\x08\x82\x30\xc2\x79\x4e\x50\xc5\x7d\x75\xcd\x6d\x3c\x7b\x9d\xbe\x19\xd1\xe2\xf1\xd3\x00\x10\x44\xb9\x3a\xd1\xc3\xee\x62\x98\x17" +
+		"\x1c\x40\x8c\x25\x64\x90\xb0\xa1\xda\x08\xdc\x46\x41\x38\xdf\xc7\x8c\xce\x9a\x9e\x16\xc7\x70\x56\x17\xa4\xd6\xdb\xb2\x0e\x7e\x3a" +
+		"\x07\x45\x17\xe0\x81\xeb\x4c\x1f\x22\xd1\x77\x12\x00\xfb\x07\x65\x8f\x7c\x77\x65\x4d\x58\x44\x04\x90\xdd\x6f\x55\x7e\x9e\x39\x03" +
+		"\x02\xd0\x4e\x9c\x21\xdf\x1d\xbd\x88\x52\x4b\xdb\x20\x36\x91\xb4\xce\xe5\x53\x05\x59\xd6\xcf\x0f\xa0\x5a\xdf\x61\xe1\x2f\xdc\xbf" +
+		"\x2e\xb7\xa0\x11\xb8\xbc\xe9\x10\x82\xe1\x3e\xbd\x75\xde\x3b\x58\xeb\x9b\x46\x50\xda\xe9\xf1\x1a\xa8\x1d\xb3\x2c\xf1\xb6\x7b\x13" +
+		"\x2e\xfd\xa7\x7e\xd3\x5f\x4a\xf0\x29\x9f\x75\xd6\xe8\xa8\x49\xb5\x4d\x2a\xc6\xbf\x95\x36\x83\x04\xe6\x03\x0c\x18\xf0\xcf\x17\xb5" +
+		"\x09\x19\x9d\xca\xfd\x50\xce\x64\x2e\xdd\xbe\xda\x65\x20\x6d\x4f\x61\xa7\x3d\x10\x85\x2b\x81\x14\xc5\x1b\x24\x40\x19\x2a\xe0\x64" +
+		"\x26\x8c\x5c\xfc\x44\x6d\x39\x9c\x4d\xd3\x19\xdb\x66\x6a\x75\xb5\xcb\x65\x5d\x8c\x17\x97\xe9\xfa\x76\x18\x1c\xb4\x21\x6e\x15\x62" +
+		"\x23\x03\xa6\x52\xc9\x49\x07\x18\x26\xb0\xe9\xa3\x6c\x80\x57\x86\x97\xb4\x4e\x91\x2c\xce\x66\x87\x01\x28\x54\xed\xa1\x1a\x18\xdc" +
+		"\x27\xc5\x35\x63\xb1\x2a\x6e\xe2\xc3\xf0\x41\xf3\x1d\xc4\x59\x22\xbc\x53\x53\xeb\x11\x08\x68\xd2\x37\x07\x3f\x4e\xfb\x35\xfb\xdf" +
+		"\x12\x01\xa8\x7e\xaf\x4a\xe6\x18\xf0\x2b\xd8\x2d\x0a\x51\x09\x04\x99\x69\xb5\x24\x8c\xfe\x90\xf4\x2c\x27\x8f\x22\x61\x5d\x2b\x0e" +
+		"\x2c\x43\x16\x94\x39\xfc\xd6\x9e\xad\x82\x14\x99\x7b\xb0\x69\xbe\xca\xfc\xb1\xba\x2c\x51\xe5\x70\x6c\xb4\xb4\x3d\xab\x2a\x44\x3d" +
+		"\x06\x83\x59\x73\x15\x35\x90\x40\xea\x03\xc4\x5d\x69\x84\xc6\x89\x4f\x46\xcb\xb3\x6d\x70\x2e\x3c\x4f\xb9\x84\x7e\x63\x04\xd9\x44" +
+		"\x03\x54\x57\x06\x70\x6e\xab\x36\xaf\xb9\x3b\x12\x8f\xeb\xd1\x6f\xb0\x42\x5e\x15\x83\x14\x19\x7b\x77\x79\x5a\xd3\xa7\x98\xd1\x83" +
+		"\x1a\x33\xc2\x54\xec\x11\x76\x19\xd3\x5f\x1f\xc0\x51\xb3\x17\x28\x74\x0b\xed\x23\xa6\xa3\x78\x70\xed\xb3\x93\xb7\x1a\x0c\x0e\x6b" +
+		"\x1f\xfe\x69\x68\xa4\x47\x0c\xd5\x67\xb0\xc0\x02\x28\x1c\xaf\x99\x6e\x88\xf7\x1e\x75\x9b\x87\xe6\xf3\x38\xe5\x17\xf1\x69\x0c\x78" +
+		"\x0f\xd6\x6e\x03\xba\x88\x08\xff\xec\xb0\x59\xc8\x99\xfd\x80\xf4\x14\x0d\xdd\x5d\x2a\x5c\x44\x83\x10\x7f\x4e\x02\xe3\x55\xb3\x93" +
+		"\x26\x3a\xb6\x9f\x13\xb9\x66\xf8\x19\x73\x94\x55\x29\x06\xb1\x7e\x6c\x86\x17\xa7\xbd\xd5\xd7\x4a\x7b\xe3\x39\x6b\x7f\xe0\x13\xab" +
+		"\x16\xa4\x25\xe4\x7d\x11\x10\x62\x50\x54\xd5\xa1\x65\xde\x41\x3e\x3b\xd8\x7d\x5a\xa3\x95\x8f\xdd\x6e\xb7\xe0\x3e\x39\xba\x40\x46" +
+		"\x2d\xc5\x10\xa4\x71\x9e\xc1\x0c\xad\x75\x2f\x03\xc6\x73\xf0\xe2\x53\xcc\x31\xd1\x3e\x39\xe9\x09\xfc\xc5\xf7\x3a\xf9\x13\x8d\x9a" +
+		"\x24\xdf\x8e\x8d\x85\x6c\x5b\x5e\x1b\xd1\xca\xd2\x3d\x07\xdd\xa3\x42\x3c\x51\x79\x32\x9b\x7a\x82\xcb\x4a\xa7\x09\xa9\x45\x76\xe5" +
+		"\x2b\xcc\x94\xff\x4f\xc3\xc7\x6f\x3c\xd5\xc6\x89\x15\xa0\x42\xe8\x76\x28\x24\x9a\x01\xb0\x95\x61\xbd\xf2\x4a\x6c\xdc\xe5\x62\x0f" +
+		"\x07\x6c\x1e\x88\xdc\x54\x0c\x8d\x8d\xe5\x4e\x34\x3d\xf7\xc4\x29\xd3\x29\x5f\x52\xc3\x8c\xff\xe6\xb4\x8b\xe8\x68\x52\xda\x97\xdf" +
+		"\x09\xb5\xf2\x09\xa4\x51\xac\x43\x1c\x05\x1f\xb1\x2d\x9a\x5e\x4f\xe4\x0e\xe1\x60\x11\x20\x94\x7d\xa9\x90\xfb\x8e\x12\xcb\x46\xe1" +
+		"\x20\x5f\x17\xb0\xd8\x72\x9e\x2e\xaa\x88\xd6\xa4\x41\x35\xa6\xab\x64\xe9\x42\x4f\x55\xb0\xf1\xea\x06\x83\xaf\x75\xeb\x67\x7c\x07" +
+		"\x28\x1c\x5c\x68\x88\x36\xf6\xcf\x91\x26\x38\xc3\x8b\xe0\x46\xcd\x09\x16\x81\xf0\xa4\x17\x61\x72\x0c\xdd\x1e\xdf\x9f\x23\x70\x29" +
+		"\x1a\x05\x3e\x68\x78\xe9\x00\xf4\x5f\x4d\x67\x44\x8c\x47\x1c\xf3\x00\x9a\x44\xe7\xa0\x2e\xa5\x0e\x4a\xfa\x44\xf2\x59\x26\x21\xf5" +
+		"\x10\x0d\xc7\xd4\x26\xde\xbe\x30\x07\xfb\x7c\xea\xc8\x4e\x4f\x54\x68\xef\xcb\x89\x7e\x7b\xbe\xe9\x81\x74\x28\x39\xd5\x9e\x06\x4c" +
+		"\x17\x02\x26\x72\xa0\x16\xa9\x57\xbb\x87\xe2\xcf\xad\xc8\xb7\x5f\xb2\x89\x05\xbd\xb6\x2c\x82\xc8\x0b\x1c\xb3\x1b\x41\x1e\x49\xc8" +
+		"\x10\x86\xdb\x7e\x27\x60\xfc\x8b\x71\x05\x3a\x87\xeb\xe1\x51\x23\x9f\xb8\xb5\x47\x18\x2b\x17\x0d\xe0\xc2\x72\x03\xf9\x54\xf4\xd2" +
+		"\x15\x38\x4f\xe3\x9d\x73\xb6\x33\x02\x46\x0a\xe4\xc2\x94\x2f\xac\x2b\x41\xfb\x65\xa1\x85\x53\x6f\xb8\x5d\xd2\x4f\xd7\x58\x40\x64" +
+		"\x2e\xbb\x59\x9f\xe9\x13\x6d\x42\x4b\xf4\xab\xc5\x34\x2c\x6c\x74\x47\xb1\xa8\x53\x20\x5f\xcf\xb5\x51\x9e\x55\x13\x57\x70\x90\x08" +
+		"\x1b\x4b\x5e\x87\xcf\xb9\x26\x2c\xfe\xc3\xc0\xf0\x54\x2e\x4c\x5a\x4c\xf2\x78\x29\x2b\x4c\xe3\xee\xd9\x96\xfa\xc6\xf4\xd3\x72\x88" +
+		"\x24\x65\x05\x3a\xe5\x0b\x68\x85\x80\x1f\x3f\x82\xe3\x02\xca\xfb\xbb\x4a\x75\x81\xbb\x4f\xba\x60\xb6\x37\xfe\xbe\x65\x9e\x50\x57" +
+		"\x11\x4f\x32\xed\xcd\xea\x09\xcd\x09\x5c\x5b\xb5\xd3\x8f\x1b\x97\xda\x9f\x05\xe1\x8b\x37\x08\xbf\x6e\x0a\xb9\xd3\xd5\x48\x59\xef" +
+		"\x2b\xc7\x0d\xfe\xb2\xba\xab\x2f\x6b\x38\x7c\xd7\x7b\xe7\x79\xac\x2e\x5e\x55\x19\xf3\xd1\x81\x23\xee\x28\xd8\xc2\x54\x3c\x71\x48" +
+		"\x01\xc9\xbf\x7a\x20\x3c\xe2\x2b\x77\x5e\x3a\x61\xad\x7e\x77\xb6\xa7\x83\x48\xb9\xf6\xec\x68\xa4\x12\xe4\x9b\xfe\x32\xc0\x54\x15" +
+		"\x05\x14\xb0\xfe\x59\x09\xea\x88\x7b\xed\xb0\x29\x5f\xbb\xce\xc3\x55\xcf\xb5\x75\xff\x6a\x97\xcd\x9f\x4a\xd0\x0c\xcb\x57\xee\x9b" +
+		"\x26\x7c\x76\xec\x81\x93\x4c\xc8\x1a\x13\x2a\x8b\x05\x89\x10\xa1\x20\x92\x52\x0b\x12\xa2\x01\xaf\x03\xe3\x20\x2d\x7b\x6c\x1b\x7e" +
+		"\x29\x17\x0e\x33\x22\xb3\xd8\xd5\xc7\x8c\x84\xba\xbb\xb4\x70\xad\xf1\x62\x24\x93\xce\x83\xe9\x5c\xfb\x15\x1c\xf7\x57\xbd\xe5\xd6" +
+		"\x01\x9f\x6a\x81\x24\xb1\x9e\x33\xaf\x33\xe5\xd3\x87\x3f\x9c\x33\x5c\x6f\x09\xa4\x54\x86\xca\xb5\x36\xdd\x59\x6c\xa4\x1d\x95\x19" +
+		"\x19\x04\xaa\x4d\x69\x08\x54\x4a\x8b\x34\x8e\x9d\xb1\x98\x1c\x27\x00\x9e\xd8\xea\x17\x15\x18\xae\x54\x05\xd0\x36\x24\x2b\x60\xe9" +
+		"\x26\xf1\x78\x73\x94\x9b\xc6\x79\xf7\xf0\x43\x95\x66\x94\xe4\x22\xb3\xce\xe1\xde\x9d\xd6\xf6\x47\x3b\x93\x2a\x47\x64\x55\xff\x1a" +
+		"\x1a\xc6\x68\xf6\x12\xb8\x24\x3c\x19\x3b\x33\x72\x0b\x8a\xa5\x40\x40\xc4\x76\x03\x11\x97\x13\x1e\xbd\xca\xc9\xb1\x8b\xc4\x8f\x75" +
+		"\x09\x96\xd9\x61\xa7\x5c\x0d\x07\x19\x6d\xae\x45\xbf\x62\x47\x66\xcc\xfb\xf8\x55\x5b\xe9\x79\x6d\xa5\x2f\x81\x56\x8e\xf0\x66\x3d" +
+		"\x03\x0c\x97\xe1\xb8\xca\xd1\xd4\xfd\x50\xd1\xb4\x38\x3f\xbe\x66\x74\xd1\x71\xf9\x9c\x63\xfe\xbb\x54\x25\xb3\x95\xc2\x4f\xc8\x19" +
+		"\x06\xe3\xad\x6a\x46\x90\x0e\x2d\x39\x53\x37\x02\x55\xb6\x8f\x89\xb3\xe5\x23\xf1\xfe\x50\x26\x42\xee\x22\x6f\x2d\x8b\xd0\x84\x8f" +
+		"\x1d\x6b\x37\x55\x33\x1c\xd0\x21\x6b\x68\x80\xe4\x2f\x98\x80\xf5\x65\xcb\x94\xb0\xe0\x45\x51\x53\xa3\x29\x89\x05\x88\xcc\x91\x6e" +
+		"\x28\xe4\xdc\xba\x4b\x96\xf1\x2a\x59\xb0\x41\x53\x5e\x73\x0a\xc8\xc3\x51\x89\xdc\x0b\x85\xac\x03\x3d\xd3\x8c\x08\xba\xe5\x31\xf2" +
+		"\x08\xb6\x08\x60\x46\xa8\x35\x50\x8c\xcf\x48\x4f\x29\x74\xb6\xa6\xb0\x71\x2a\x47\x62\x60\x37\x6c\x7a\x3b\x3e\x4b\xc4\xa4\x7a\x14" +
+		"\x16\x2c\xd2\xca\x7f\xe3\xb5\xf1\x44\x4b\xce\xc9\x78\x12\x01\x9b\xb6\xfd\x85\xfb\xa6\xa0\x53\x6a\x89\x64\x3e\x15\xb9\xbb\x3b\x52" +
+		"\x28\xf1\xe0\x3b\xaa\xea\x9b\xbc\x05\xaf\x5b\x11\x93\x7e\x4f\x5c\xb5\xc9\xa9\xc1\x19\x20\x63\xd1\x99\x8c\x01\xc6\x4d\x48\x3a\x76" +
+		"\x1b\xdb\x06\x27\x78\xd7\xc1\x5d\xa3\x95\xaf\x27\x34\xc2\x5f\xaa\x01\x27\xd2\xaa\xb4\xaa\x71\x36\x60\x31\xa0\xbb\x67\x91\xce\x10" +
+		"\x23\x75\x83\x95\x02\xe0\x98\x90\xcb\x29\x14\xe8\x29\x62\x7e\x0e\x0f\xc9\x88\x70\xb2\x32\x4a\x8b\x50\x32\x9e\xbd\xd2\x47\x49\xcb" +
+		"\x1f\xa8\x66\x2f\xbc\xb6\x1f\xb3\xad\x7c\x55\x66\x8d\xc9\x42\x3a\x33\x2d\xc8\x7c\xfb\x2d\xf4\x56\xe9\x2d\x33\x61\x1e\xd7\xbb\x50" +
+		"\x1e\x4f\xad\x2d\xd6\xb0\xa6\xf1\xf8\x70\x7f\x72\x17\x16\xc8\xa4\x46\xe2\xfb\x2c\x47\xa5\x13\x8f\x3f\x7f\x97\x36\x07\x9d\x76\x94" +
+		"\x21\x12\x56\xd1\x6c\x72\x69\xfd\x6d\xf6\xf5\xfc\xdd\x1f\xa7\x88\xba\x3b\xd0\x50\x05\x9f\x53\xd2\x61\xb0\xf5\xf1\x37\x31\xff\xe7" +
+		"\x2e\x49\x08\x4b\x33\x6e\xce\xaa\x4f\x8e\x2a\x2e\x6a\xf0\x83\x18\xf4\x20\x60\xe5\x74\xdd\xa3\x41\xf4\xa1\x07\x9b\x12\xbc\xc5\xa5" +
+		"\x0c\xe1\x9f\x54\xcd\xc3\x9f\x7f\x3b\xf3\x51\x92\xac\x68\x08\x21\x1a\xec\xea\x08\xdf\xe1\x4c\xab\x75\x8d\x25\x89\x1f\xb0\x0b\xb9" +
+		"\x00\x11\xc5\xd5\x6c\x39\x0e\x89\x3c\xc3\x94\x22\x12\x61\xd8\x74\x8d\xc6\x04\x51\xe4\xae\x4e\x1c\x84\xa8\x46\x8b\xab\x2c\x14\xcb" +
+		"\x17\xd7\x9f\xf0\x6b\x63\xac\x2a\x8a\x9e\x05\xee\x6a\xf3\xdb\xb7\xca\x60\xe1\x7b\xfa\x39\xb4\x75\x14\xa8\xcd\x80\x51\x57\x9b\x4c" +
+		"\x19\xa7\xd3\xa4\x46\xcb\x53\x93\xdc\x74\x56\x00\x93\x59\x2b\x06\xb1\xa8\xb3\x5c\xd6\x41\x6a\x2e\xca\xb0\x01\x73\x63\x90\x15\xfa" +
+		"\x03\x0c\x00\xa0\x93\x3d\xcd\xba\x2a\x80\x8b\x2e\x1b\x92\x82\xf3\x31\xf0\x45\x96\xd8\x92\x8d\xa7\xaa\x6c\x3c\x97\x23\x70\x37\xa6" +
+		"\x16\xbc\xb4\x47\xce\x2d\x50\xf3\xae\x25\xad\x08\x06\x95\x38\x2e\x93\x5d\x2d\x00\x18\x4c\x4a\xcc\x93\x70\xbe\x8a\xab\x64\x13\x9c" +
+		"\x12\x34\x1b\x46\xb0\x15\x0a\xa2\x5e\xa4\xec\x87\x15\x31\x29\x97\xe6\x21\x24\xf3\x7c\xab\x7b\x6d\x39\x25\x5b\x7c\xd6\x6f\xeb\x1d" +
+		"\x0e\x86\xd1\x39\x17\xf4\x40\x50\xb7\x2a\x97\xb2\xbf\x61\x0c\x84\x00\x2f\xc2\x8e\x29\x6d\x10\x44\xdc\x89\x21\x2d\xb6\xa4\x9f\xf4" +
+		"\x08\xe6\xeb\x40\x89\xd3\x7d\x66\xd3\x57\xe0\x0b\x53\xd7\xf3\x0d\x10\x52\xa1\x81\xf8\xf2\xeb\x14\xd0\x59\x02\x5b\x11\x0c\x72\x62" +
+		"\x2e\xa1\x23\x85\x62\x45\xf6\xc8\x47\x38\xd1\x5d\xd1\x48\x1a\x0c\x04\x15\xcc\xb3\x51\xa1\xe0\xce\xe1\x0c\x48\xce\x97\xca\x7b\x18" +
+		"\x2d\xca\x72\xb2\xeb\xca\xb8\xc2\x34\x46\xe0\x03\x30\xb1\x63\x10\x41\x95\x78\x90\x25\x41\x3a\xbf\x66\x4d\xb0\xf9\xc8\x4d\xfa\x6f" +
+		"\x06\xff\x9e\xd5\x0d\x32\x7e\x84\x63\x32\x9f\x58\x5e\xc9\x24\xb3\xf2\xf6\xb4\x23\x5f\x03\x6f\xa4\xc6\x4a\x26\xcb\xd4\x2b\x6a\x6b" +
+		"\x24\x6a\x10\xb7\xe3\xe0\x08\x99\x47\xf7\xc9\xbd\xa3\xd5\x4d\xf8\xe2\xa6\x0e\x0c\xca\x84\xea\x2a\xc6\x30\xa4\x53\x5a\xfb\xf7\x30" +
+		"\x22\xa6\x35\x01\xc5\xf0\x4b\x90\x18\x71\x9e\xd9\x9d\x70\x0e\xe5\x2f\x84\x6a\x71\x5a\xe6\x7a\xd7\x5c\x96\xb3\x9d\x68\x8b\x66\x91" +
+		"\x2f\x4c\x50\x47\x7f\x7f\xd9\xc6\x71\x79\x9a\xc5\xd2\xe2\x24\xcd\xb9\x16\x4f\x58\x35\x1d\x8a\xa1\x40\xec\x07\xe5\x14\xfa\xe9\x37" +
+		"\x10\xff\xb7\xaa\xd1\xf5\x1c\x7d\x13\xb1\x7f\x4d\x87\x6d\x9a\x1e\x38\xf0\xba\x8a\x4a\x23\xd4\xb5\x0c\xda\x32\xca\xd8\x51\x56\x7e" +
+		"\x0e\x9c\xef\xdd\xc3\xc2\xd3\xbe\xa4\xd3\x97\x22\x53\x2d\x54\x20\x78\x40\x27\x35\x21\x87\xe7\xaf\x1a\x05\x69\x35\xc3\x58\x03\xae" +
+		"\x07\xaf\x84\xa4\xd3\x14\x1e\x7a\xc2\x33\x52\xe6\xdc\x6e\xa4\xaf\xa1\x65\x6f\x96\xa3\x3c\x89\x78\xa3\xe8\x3b\xdd\x4b\xa6\x2b\x41" +
+		"\x2d\x9e\x31\xa1\x0a\xeb\xc7\x61\xf8\xde\x00\xd1\x4b\x1e\x56\x6d\x1a\x39\x32\x3d\x6e\x89\xb6\x38\xe9\x40\xf3\xec\x8a\x22\xc3\xc5" +
+		"\x27\xf1\x9a\x65\x32\xe6\x6b\x53\x33\xdb\x1a\xfd\x59\x2f\x66\xf1\xd3\x60\x34\xb3\x14\xda\xd8\x44\x76\x56\x74\x7b\xe2\x7e\x64\xc7" +
+		"\x00\x58\xfa\x3c\x84\x54\xd6\x33\x54\xb2\x02\x4c\x3b\x4a\x57\x7a\x18\x0e\xd9\x9f\x8f\x31\x55\xcd\x7e\x4d\x61\x7d\x47\xd0\x7f\xfd" +
+		"\x04\x16\x27\xb6\x71\x5b\x78\x09\x67\x95\x7c\x08\x06\x99\x34\x3e\xb0\x41\x4a\x20\x5d\x3a\x17\x5d\x70\x89\x64\x95\x68\x16\xa5\xd5" +
+		"\x00\x6a\xc4\x9d\xd9\x25\x3e\xdc\x7f\x63\x2e\x57\xb9\x58\xcc\xec\xd9\x82\x01\x47\x1c\xf1\xf6\x65\x89\x88\x8f\x12\xb7\x27\xc5\x2d" +
+		"\x01\x31\xad\xff\xd8\xbd\x72\x54\xb1\xd8\xc3\x61\x6b\xbe\x33\x86\xec\x0c\x9c\x0d\x6d\x25\xa9\xa4\xec\x46\xa6\xbf\x18\x30\x13\x98" +
+		"\x1c\x4a\x6f\x52\xc9\xfc\xcf\x7a\x41\x38\xe4\x13\xef\x62\xa2\x83\x77\x97\x7a\xd7\xe2\x5e\x49\xa3\xcf\x03\x0e\x1c\xd8\xf9\xf5\xb6" +
+		"\x03\xf2\xa6\xbe\x51\xec\x67\x7f\x94\x65\x51\xb3\x86\x0e\xa4\x79\xfe\xe0\x48\xae\x20\x78\xae\xb7\xd1\xf7\x95\x8d\x2c\x26\x45\xf6" +
+		"\x2d\xa7\x70\xaa\xd2\xc2\xeb\x09\x39\x1a\x0c\xb7\x8e\xf3\xa9\x64\x8a\x13\x72\xd8\x54\x31\x19\x56\x4d\x73\x76\x39\x6b\x8d\xdc\x62" +
+		"\x15\x27\x84\x63\x66\x5f\x74\xcd\xdc\x18\x02\xfe\xbf\xab\x02\xce\xc9\xd4\x5f\xe8\x66\xc3\x59\xc7\x38\x06\x2a\xfb\x75\xd6\x4a\x03" +
+		"\x12\xfe\x27\x8a\xa3\x65\x44\xea\xc9\x73\x10\x27\x09\x05\x18\xd4\x34\xe3\x8e\xa9\x66\xa0\x8a\x6f\x8d\x58\x06\x38\xac\x54\xc7\x73" +
+		"\x14\x9b\x9c\x80\x21\x82\x55\x8a\x4c\x45\xd1\x19\xd3\xf4\xcc\x7f\xd8\x58\x76\x04\xca\x4f\x0d\x6e\x21\xb0\x6f\xf3\x0b\x6a\x23\xb6" +
+		"\x08\x12\xe7\xb4\xd8\x47\xbc\x85\x17\xd1\x93\x19\x77\x2f\x3c\x98\x55\xe0\x44\xfd\x60\xdb\xac\x9a\x0a\xdc\x49\x59\xb6\x91\xdf\xe4" +
+		"\x02\xed\x8d\x8d\xde\xaf\xe3\xd9\xd8\xdf\x7f\x28\xa0\xbf\xaa\x7f\x55\x58\x13\xc7\xe7\x50\x3a\xea\x2a\x66\x97\x37\x03\xa0\xc6\x1b" +
+		"\x0e\xbd\x07\x3b\xa0\x53\x7b\x51\x4d\xeb\x60\x29\xf9\x21\x02\x9e\x55\xe5\xe4\xd9\xa0\x3d\x6b\x6b\xa1\x30\x40\x38\x66\x2d\x4d\xb8" +
+		"\x15\xc7\x54\xd5\xb1\x4b\x2c\x42\x05\xc6\xba\x8d\x2c\xcd\x02\x82\x55\xb3\xe7\x92\xc6\xaf\xa0\x8b\x44\xee\x75\xb6\x2e\xff\x9f\x59" +
+		"\x16\x95\x15\xc8\x9a\xc5\x47\x9d\xb0\xed\x8f\xa6\xfa\x31\x1b\x39\x1c\xc1\x23\x52\x70\xf4\xcb\xc5\xc2\x9e\x7c\xbc\x30\xe8\x73\x2a" +
+		"\x25\x47\x9f\xbf\xb3\xa6\x8f\x98\x23\x88\xf2\x62\x10\x01\x10\x16\x08\xbd\xc2\x9f\x6f\xf0\x37\x69\x6d\x91\x61\xf5\xcd\x9a\x4f\xef" +
+		"\x14\x47\x5c\x4b\xd5\x20\x45\x1f\x3c\x85\x2c\xb0\x31\x1a\x57\x8c\xa7\xf8\xe6\xe9\x72\x18\x21\x96\xce\x09\x48\x6e\x94\xbe\x60\x71" +
+		"\x04\x5a\x69\x10\x66\xcc\x66\xbe\xc9\xba\xf2\x79\x88\x33\xa1\xdf\xd3\xa8\x47\x50\x2a\xec\x8d\x5f\x5c\x4e\x73\x36\x3d\x09\x77\x99" +
+		"\x26\x02\x9c\x0c\x26\x7c\x79\x9f\xb8\x33\xac\x8a\x11\xe3\xa3\xf0\x14\x7a\x8c\xa0\x37\x22\x1b\x90\x01\x3b\x8b\xcb\x37\xeb\xa6\x83" +
+		"\x16\x3f\xac\xb3\x4f\xf5\x72\xfb\xf7\xc9\x46\x96\x9c\x1c\x26\x08\x73\xce\x12\xa6\xa9\x4a\x3e\x45\xb8\x10\x1d\x5b\x94\x8d\x16\x41" +
+		"\x2c\x71\x4e\x96\xe1\x91\x3b\x35\x1d\x96\x93\x20\xcc\x69\xd5\xec\x13\xe0\x6a\x62\x75\xe5\x86\x88\xaf\x8e\xe0\x0c\x42\x40\xee\x28" +
+		"\x1c\x16\x61\xe2\xa7\xce\x74\xb7\x5a\xba\x84\x66\x5e\xcd\x2b\xf9\xdd\xd6\x26\x8f\x06\xde\xbf\xe2\xd5\x2b\x80\x4e\xff\x1d\x5f\xa6" +
+		"\x06\xa6\x9a\xe7\x95\xee\x9b\xfe\x5e\x5a\xf3\xe6\x61\x9a\x47\xd2\x66\x35\xb3\x4c\x2a\x08\x89\xfe\xa8\xc3\xc0\x68\xb7\xdc\x2c\x71" +
+		"\x11\x3d\x58\x53\x5d\x89\x21\x15\xc5\xd2\x8b\x4c\x19\xa3\x60\x93\x74\xdb\xdb\xad\xf5\x41\x95\xc7\x31\x41\x6c\x85\xd7\x31\xd4\x6a" +
+		"\x2a\xb8\x91\x02\xe2\xb8\xd5\xe6\x38\xff\x97\xd7\x61\xda\x60\x42\xe5\x34\xf1\xff\x47\xf7\x91\x7a\x2c\xa1\xa7\x40\x63\xb4\x61\x01" +
+		"\x03\xc1\x1c\xa7\x9e\x41\xfd\xfe\x96\x27\x30\xc4\x5e\x69\x95\x46\x34\x90\x31\x89\x3d\xa2\xb4\xfd\x39\x80\x4f\xd6\xa1\x5a\xd1\xb3" +
+		"\x27\x09\x6c\x67\x26\x21\x40\x38\x88\x01\x4d\xdb\xbb\xfc\x9d\xa1\xf7\xf6\x7b\x4d\x4c\xfe\x84\x6c\x6a\xdf\x04\x0f\xaa\xf2\x66\x9c" +
+		"\x2d\xe3\x2a\xd1\x54\x97\xae\xf4\xd5\x04\xd4\xde\xeb\x53\xb1\x3c\x66\xdb\x79\x0c\xe4\x86\x13\x0c\xaa\x9d\xc2\xb5\x7e\xf5\xbe\x0d" +
+		"\x0d\xc1\x08\xf2\xb0\xa2\x80\xd2\xfd\x5d\x34\x13\x10\x72\x2a\x2d\x28\xc7\x38\xdd\xda\xec\x9f\x3d\x25\x57\x54\x44\x8e\xef\xd0\x01" +
+		"\x18\x69\xf3\xb7\x63\xfe\x81\x64\xc9\x68\x58\xa1\xbb\x9e\xfa\xd5\xbc\xdc\x3e\xeb\xc4\x09\xbe\x7c\x7d\x34\xca\x50\x36\x5d\x83\x2f" +
+		"\x02\x2e\xd3\xa2\xd9\xff\x31\xcb\xf8\x25\x59\xfe\x6a\x91\x18\x43\xb6\x16\x94\x5e\x16\xa5\x68\xd4\x8c\x6d\x33\x76\x71\x29\x68\x2d" +
+		"\x21\x55\xd6\x00\x52\x10\x16\x9e\x39\x44\xed\x13\x65\xbd\x0e\x72\x92\xfc\xa1\xf2\x7c\x19\xc2\x66\x10\xc6\xae\xc0\x77\xd0\x26\xbc" +
+		"\x0d\xe1\xba\x7a\x56\x2a\x8f\x7a\xca\xe9\x32\x63\xf5\xf1\xb4\xbb\xec\x0c\x05\x56\xc9\x1a\xf3\xdb\x3e\xa5\x92\x8c\x8c\xae\xae\x85" +
+		"\x05\xdb\xb4\x40\x60\x24\xbe\xab\xcf\xce\x5b\xf4\x6e\xc7\xda\x38\x12\x6f\x74\x0b\xce\x8d\x63\x7b\x63\x51\xdf\xa7\xda\x90\x25\x63" +
+		"\x05\xd4\x14\x9b\xaa\xc4\x13\xbe\xd4\xd8\xdc\x8a\xd7\x78\xd3\x2c\x00\xe7\x89\xe3\xfc\xd7\x2d\xcc\xc9\x7e\x54\x27\xa3\x68\xfd\x5e" +
+		"\x01\xcd\xf8\xb4\x52\xd9\x7c\x2b\x9b\xe5\x04\x6e\x73\x97\xe7\x6f\xf0\xb6\x80\x2f\xa9\x41\xc7\x87\x92\x12\xe2\x21\x72\xc2\x7b\x2e" +
+		"\x1f\xc6\xa7\x18\x67\x02\x7f\x56\xaf\x80\x85\xff\x81\xad\xce\x33\xc4\xd7\xc5\x01\x5e\xce\xd8\xc7\x1b\x0a\x22\x27\x9d\x46\xc0\x7c" +
+		"\x10\x40\xbe\xf4\xc6\x42\xd0\x34\x5d\x4d\x59\xa5\xa7\xa3\xa4\x2b\xa9\xe1\x85\xb7\x53\x06\xd9\xc3\x56\x8e\x0f\xda\x96\xaa\xaf\xc2" +
+		"\x16\xb7\x9c\x3a\x6b\xf3\x16\xe0\xff\x2c\x91\xb2\x89\x33\x4a\x4d\x2b\x21\xe9\x56\x76\x43\x19\x18\xa8\x08\x14\x75\xab\x8f\xad\x0d" +
+		"\x20\xdf\xf1\xbc\x30\xf6\xdb\x6b\x43\x4b\x3a\x13\x87\xe3\xc8\xc6\xa3\x40\x70\xe5\x2b\x60\x1f\xc1\x3c\xbe\x1c\xdc\xd5\x9f\x47\x4e" +
+		"\x02\x12\xac\x2a\xb7\xa6\xea\xae\xc2\x54\x95\x50\x30\xa9\x70\xf8\x06\x2d\xd4\x17\x1a\x72\x6a\x8b\xdf\xb7\xfd\x85\x12\xae\x06\x0d" +
+		"\x2f\x29\x37\x74\x91\x47\x44\x42\x86\x9a\x10\x9c\x92\x15\x63\x7c\xb0\x2d\xc0\x31\x34\xf0\x04\x42\x13\xc8\x11\x9f\x69\x96\xae\x09" +
+		"\x09\x84\xca\x6a\x5f\x91\x85\xd5\x25\xec\x93\xc3\x3f\xea\x60\x32\x73\xbe\x9f\x38\x66\xaa\x28\x4c\x58\x37\xd9\xf3\x2d\x81\x4b\xfa" +
+		"\x0d\x08\x0a\x6b\x6b\x3b\x60\x70\x0d\x29\x9b\xd6\xfa\x81\x22\x0d\xe4\x91\x36\x1c\x8a\x6b\xd1\x9c\xeb\x0e\xe9\x29\x4b\x24\xf0\x28" +
+		"\x0e\x65\xcd\x99\xe8\x4b\x05\x2f\x67\x89\x53\x06\x38\xcb\x0a\xd8\x21\xac\xc8\x5b\x64\x00\x26\x4d\xce\x92\x9e\xd7\xc8\x5a\x45\x44" +
+		"\x2e\x20\x88\x75\xbc\x7a\xc1\x22\x48\x08\xf7\x2c\x71\x6c\xd0\x5e\xe3\x0e\x3d\x20\x38\x0f\xf6\xa6\x55\x97\x5d\xa1\x27\x36\x92\x0b" +
+		"\x29\x89\xf3\xae\x47\x7c\x2f\xd3\x76\xa0\xb0\xff\x3d\x7d\xfa\xc1\xae\x2e\x3b\x89\x4a\xfd\x29\xf6\x4a\x60\xd1\xaa\x85\x92\xba\xd5" +
+		"\x11\x36\x1c\xe5\x44\xe9\x41\x37\x92\x22\xd1\x01\xe6\xfa\xc0\xce\x91\x81\x06\xa4\x63\x29\x0a\x3e\x3a\x74\xc3\xce\xa7\x18\x94\x59" +
+		"\x1e\x8d\x01\x4b\x86\xcb\x5a\x7d\xa5\x39\xe1\x0c\x17\x3f\x6a\x75\xd1\x22\xa8\x22\xb8\xfb\x36\x6c\x34\xc8\xbd\x05\xa2\x06\x14\x38" +
+		"\x17\x3f\x65\xad\xec\x8d\xee\xe2\x7b\xa8\x12\xad\x29\x55\x8e\x23\xa0\xc2\x32\x41\x67\xef\x6c\x91\x21\x2e\xe2\xc2\x8e\xe9\x87\x33" +
+		"\x01\xc3\x6d\xaa\xf9\xf0\x1f\x1b\xaf\xee\x8b\xd0\xc7\x79\xac\x3e\x5d\xa5\xdf\x7a\xd4\x54\x99\xd0\x99\x1b\xd6\x95\x31\x0e\xdd\xd9" +
+		"\x13\x53\xac\xb0\x8c\x05\xad\xb4\xaa\x9a\xb1\xc4\x85\xbb\x85\xff\xf2\x77\xd1\xa3\xf2\xfc\x89\x94\x4a\x6f\x57\x41\xf3\x81\xe5\x62" +
+		"\x2e\x5a\xbd\x25\x37\x20\x7c\xad\x18\x60\xe7\x1e\xa1\x18\x8e\xe4\x00\x9d\x33\xde\xb4\xf9\x3a\xeb\x20\xf1\xc8\x7a\x3b\x06\x4d\x34" +
+		"\x19\x1d\x5c\x5e\xda\xef\x42\xd3\xd0\x2e\xed\xbb\x7a\xb8\x56\x25\x13\xde\xb4\xeb\x34\x91\x3a\x13\x42\x17\x26\xba\x8f\x69\x45\x5c" +
+		"\x11\xd7\xf8\xd1\xf2\x69\x26\x42\x82\xa2\x63\xfe\xa6\xd7\x59\x9d\x82\xa0\x4c\x74\xc1\x27\xde\x9d\xee\x79\x39\xdd\x2d\xcd\x08\x9e" +
+		"\x04\x21\x8f\xde\x36\x68\x29\xed\x90\xf7\x9a\xd5\xe6\x79\x97\x97\x34\x45\xcb\x4c\xd6\xbc\x6f\x95\x1b\xad\x08\x52\x86\xca\xc9\x71" +
+		"\x00\x70\x77\x2f\x7c\xf5\x24\x53\x04\x83\x97\xca\x5f\x47\xa2\x02\x02\x7b\x73\xb4\x89\x30\x1c\x32\x27\xb7\x1c\x73\x0d\x76\xd6\xdd" +
+		"\x03\x8a\x38\x9b\xae\xf5\xd9\xa7\xc8\x65\xb0\x65\x68\x7a\x1d\x9b\x67\x68\x1a\x98\xcd\x05\x16\x34\xc1\xdc\x04\xdb\xe3\xd2\xb8\x61" +
+		"\x09\xa5\xee\xfa\xb8\xb3\x6a\x80\xcd\xa4\x46\xb2\xb4\xb5\x9c\xcd\x0f\x39\xd0\x09\x66\xa5\x0b\xea\xf1\x98\x60\x78\x90\x15\xa6\xe5" +
+		"\x01\xb5\x88\x84\x8b\x8b\x47\xc8\xb9\x69\xc1\x45\x10\x9b\x4b\x58\x3d\x9e\xc9\x9e\xdf\xac\xb7\x48\x9d\x16\x21\x2c\x75\x84\xcd\x8c" +
+		"\x0b\x84\x6e\x4a\x39\x0e\x56\x0f\x6e\x1a\xf6\xdf\xc3\x34\x14\x19\x54\x5e\x5a\xbf\xa3\x23\xd8\x17\xfe\xd9\x1e\x30\xd4\x29\x54\xa6" +
+		"\x23\xa6\x67\x9c\x7d\x9a\xdb\x66\x0d\x43\xa0\x2d\xdb\x90\x00\x40\xeb\x15\x13\xbc\x39\x4f\xc4\xf9\x85\xca\xbf\xe8\x5c\xe7\x2f\xe3" +
+		"\x2e\x03\x74\xa6\x99\x19\x7e\x34\x3e\x5c\xaa\x35\xf1\x35\x1e\x9f\x4c\x34\x02\xfb\x7c\x85\xec\xcc\xf7\x2f\x31\xd6\xfe\x08\x92\x54" +
+		"\x07\x52\xcd\x89\x9e\x52\xdc\x4d\x7f\x7a\x08\xaf\x4c\xde\x3f\xf6\x4b\x8c\xc0\xb1\x17\x6b\xb9\xec\x37\xd4\x19\x13\xa7\xa2\x7b\x48" +
+		"\x06\x8f\x88\x13\x12\x72\x99\xda\xc3\x49\xa2\xb6\xd5\x73\x97\xa5\x02\x75\x14\x2b\x66\x4b\x80\x2c\x99\xe2\x87\x3d\xd7\xae\x55\xa7" +
+		"\x2b\xa7\x0a\x10\x23\x55\xd5\x49\x67\x75\x74\x16\x74\x34\xb3\xf9\x86\x87\x2d\x04\xa2\x95\xb5\xb8\xb3\x74\x33\x0f\x2d\xa2\x02\xb5" +
+		"\x2c\x46\x7a\xf8\x87\x48\xab\xf6\xa3\x34\xd1\xdf\x03\xb5\x52\x13\x09\xf9\x09\x9b\x82\x5d\xd2\x89\xb8\x60\x9e\x70\xa0\xb5\x08\x28" +
+		"\x05\xc5\xf2\x0b\xef\x1b\xd8\x27\x01\x00\x9a\x2b\x44\x8a\xe8\x81\xe3\xa5\x2c\x2d\x1a\x31\x95\x72\x96\xd2\x9e\x57\x63\xe8\xf4\x97" +
+		"\x0d\xc6\x38\x5f\xdc\x56\x7b\xe5\x84\x2a\x38\x1f\x60\x06\xe2\xc6\x0c\xd0\x83\xa2\xc6\x49\xd9\xf2\x3a\xc8\xc9\xfe\x61\xb7\x38\x71" +
+		"\x14\x2d\x39\x83\xf3\xdc\x7f\x7e\x19\xd4\x99\x11\xb8\x67\x0f\xa7\x03\x78\xd5\xb8\x41\x50\xd2\x5e\xd2\x55\xba\xa8\x11\x4b\x36\x9c" +
+		"\x29\xa0\x1e\xfb\x2f\x6a\xa8\x94\xfd\x7e\x6d\x98\xc9\x6a\x0f\xa0\xf3\x6f\x86\xa7\xa9\x9a\xa3\x5c\x00\xfa\x18\xc1\xb2\xdf\x67\xbf" +
+		"\x05\x25\xff\xee\x73\x7d\x60\x51\x38\xc4\xa5\x06\x66\x44\xec\x63\x0a\xb9\xe8\xaf\xc6\x45\x55\xb7\xd2\xa1\xaf\x04\xeb\x61\x3a\x76" +
+		"\x1e\x80\x7d\xca\x81\xd7\x95\x81\xf0\x76\x67\x7c\xa0\xe8\x22\x76\x7e\x16\x4f\x61\x49\x10\x26\x4e\xf1\x77\xcf\x42\x38\x30\x1d\xc8" +
+		"\x03\x85\xfb\x3f\x89\xc7\x4d\xc9\x93\x51\x08\x16\x47\x24\x74\xd3\x4c\x02\x23\xe0\xf7\x33\xa5\x2f\xdb\xa5\x60\x82\xdb\xd8\x75\x7c" +
+		"\x03\x76\x40\xdc\x1a\xfc\x01\x43\xe1\xa6\x29\x8e\x53\xca\xe5\x9f\xcf\xab\xd7\x01\x6f\xd6\xef\x1a\xf5\x58\xf3\x37\xba\xb0\xea\x01" +
+		"\x13\x41\x99\x9a\x1e\xd8\x69\x19\xf1\x2a\x6c\x52\x60\x82\x9e\xee\x5f\xd5\x6c\xf0\x31\xda\x80\x50\xb7\xe4\xc0\xde\x89\x60\x74\xb4" +
+		"\x06\x9e\xb0\x75\x86\x6b\x0a\xf3\x56\x90\x6d\x4b\xaf\xb1\x0a\xd7\x73\xaf\xd6\x42\xef\xdc\xc5\x65\x7b\x24\x4f\x65\xbe\xd8\xec\xe7" +
+		"\x17\x1c\x0b\x81\xe6\x21\x36\xe3\x95\xb3\x8e\x8e\x08\xb3\xe6\x46\xd2\x72\x61\x01\xd3\xaf\xaa\x02\xea\x19\x09\xa6\x19\x03\x36\x96" +
+		"\x2c\x81\x81\x4c\x94\x53\xf5\x1c\xb6\xeb\x55\xc3\x11\x75\x3e\x84\xcb\xbd\xcb\x39\xbf\xe6\x96\xf9\x55\x75\x10\x75\x02\xac\xce\xd8" +
+		"\x29\xd8\x43\xc0\x41\x5d\x35\xd9\xe3\xb3\x3f\xad\xcf\x27\x4b\x2a\xb0\x4b\x39\x03\x2a\xdc\xa9\x2c\xe3\x9b\x8a\x86\xa7\xc3\xa6\x04" +
+		"\x08\x5d\x6a\x10\x70\xf3\x51\x3d\x84\x36\xbc\xcd\xab\xb7\x87\x50\xd8\xe1\x5e\xa5\x94\x7f\x2c\xda\xa7\x66\x9c\xf3\xfa\xe7\x72\x8b" +
+		"\x11\x82\x03\x63\xed\x54\x1d\xaa\x10\xa4\x4b\xa6\x65\xbf\x30\x2c\xdb\xf1\xdd\x4e\x67\x06\xb0\x2c\x9e\x2a\x5c\xda\x41\x2f\xc3\x94" +
+		"\x20\x19\x35\xa5\x8f\x5c\x57\xfc\x02\xb6\x0d\x61\xa8\x37\x85\xbd\xdf\xd3\x15\x0e\x05\xf1\xdf\x5d\x10\x58\x40\xb7\x51\xa1\x63\x17" +
+		"\x0a\x8c\x28\x20\xc5\x69\x71\xaa\xe2\x7a\x95\x2a\xbd\x33\xa0\x3d\x46\x79\x4e\xed\xd6\x86\xcd\x8e\xcf\xed\x61\x0e\x87\xc0\x2e\x9a" +
+		"\x18\x06\x38\xff\x30\x1a\x64\xca\x04\xab\xd6\xd0\xbd\x75\x00\xb6\x65\x0b\x65\xff\x33\xe6\xbe\x1f\xd5\x0d\xbc\x16\x3a\x28\x18\x77" +
+		"\x09\x5c\x71\x62\x66\xf1\xde\x59\x04\x4f\x97\x11\x4a\x41\x58\xa3\xf8\x5c\xa8\xa9\x37\xcf\xbe\xc6\x3e\x9b\x32\x1a\x81\x2d\xd3\x6b" +
+		"\x17\xc3\x1e\xa0\x2f\xbc\x37\x83\x20\xd8\x6f\xfe\xd6\xc7\xca\x15\x83\xb6\x18\xc5\xc1\xa6\x87\x81\x8d\x40\x87\xa4\x97\xd7\x34\x90" +
+		"\x05\xb8\x6c\x4b\xb8\xef\x31\x8b\x6a\x72\x27\xe4\x19\x2d\x14\x9d\x3c\x17\xa9\x76\x4c\xcd\x66\x0d\xe4\xd5\x0a\x77\xf1\x92\xa9\x1b" +
+		"\x26\x5b\xc9\x5d\xf4\xa4\xc4\x87\x6f\xf7\x0d\x7e\xa2\xfd\xe2\xc7\xab\x15\xf4\xa6\xae\x0d\x23\x7c\xd6\xce\x74\xba\x98\x6c\x7a\x7b" +
+		"\x24\x75\x2b\x47\xbc\x6c\x6b\xc8\xd9\xbb\xe4\x8f\x5f\xef\x2f\x69\x08\x70\x17\x39\xc5\xf5\xb4\xb3\xd6\xc8\x86\xd4\x71\x5c\x79\x29" +
+		"\x14\x81\x4a\x1e\x0f\x49\x2a\x4e\xa0\xd8\x6e\x52\x7a\x96\x48\x21\x78\xd6\x24\xb9\x8d\xa9\x6e\xe5\xe5\x83\xb9\x32\x4d\x97\x4e\xfe" +
+		"\x10\xde\xf9\x31\x07\x3b\x64\x79\xbd\x60\x57\x73\x78\xf2\x93\x81\x99\x7c\x8e\x04\x1d\x3c\xfb\x3d\xc7\x52\x3b\xca\x90\x6f\x00\xbd" +
+		"\x14\xf7\xae\x77\x0b\xf7\xe9\x5f\x7f\x70\x6c\x0d\x8a\xb4\xed\x03\xfa\x0b\x88\x0d\x28\xc6\x9d\x03\x1b\x45\x92\xc9\x86\x10\x17\x5f" +
+		"\x1a\xef\x50\xa0\xce\xe7\x51\xb5\x9f\x92\x6a\xf4\x0e\x80\x35\xd1\x9d\xec\xc9\xd4\x28\xeb\xe4\xe7\x75\xc5\xcc\x9d\xce\x1c\xe5\x89" +
+		"\x04\x19\x35\x60\x71\x72\xf6\x8e\xba\x65\xca\x60\x06\x8d\xfe\x3b\x08\x6c\x2a\x2d\x57\xd0\x96\x02\x95\x12\x14\xb5\x7e\x73\xcf\x5a" +
+		"\x26\x86\x3e\x9d\xd2\x42\x55\xd1\x57\x3b\xd0\x83\x95\x9b\x85\x6c\x04\x93\xfb\xef\xe8\x3c\x81\x98\x37\xa1\x51\xd3\xbf\x45\x2c\xb8" +
+		"\x20\x36\xef\xb6\xf9\x83\x09\x65\xeb\x3d\x7a\x06\x8b\xd0\x87\xc9\xf5\xad\xf2\x51\xba\x62\x05\x2c\x65\x27\x38\xe6\x3f\xf8\xb3\xaf" +
+		"\x0c\x71\x2a\x97\x5b\x74\xdc\x9d\x76\x6b\x63\x9a\x02\x99\x69\xca\x30\xbe\x4f\x75\xa7\x53\xf8\x54\xb0\x0f\xa4\xf1\xb4\xf4\xee\x9b" +
+		"\x08\x01\x4d\xab\x3c\xd1\x66\x7e\x27\xaf\xc9\x9b\xfa\xc1\xe6\x80\x7a\xfd\xff\x64\x56\x49\x2c\xa3\x37\x57\x31\xd3\x87\x53\x96\x99" +
+		"\x19\x8d\x07\x19\x2d\xb4\xfa\xc2\xa8\x2a\x4a\x79\x83\x9d\x6a\x2b\x97\xc4\xdd\x4d\x37\xb4\xe8\xf3\xb5\x30\x09\xf7\x9b\x34\xe6\xa4" +
+		"\x29\xeb\x1d\xe4\x2a\x3a\xd3\x81\xb2\x3b\x41\x31\x42\x68\x97\xa3\x27\x09\xb2\x9d\x53\xbb\x94\x6d\xfd\x15\x78\x4d\x1f\x63\xe5\x72"
+	m5 = "" +
+		"\x25\x1e\x7f\xdf\x99\x59\x10\x80\x08\x0b\x0a\xf1\x33\xb9\xe4\x36\x9f\x22\xe5\x7a\xce\x3c\xd7\xf6\x4f\xc6\xfd\xbc\xf3\x8d\x7d\xa1" +
+		"\x25\xfb\x50\xb6\x5a\xcf\x4f\xb0\x47\xcb\xd3\xb1\xc1\x7d\x97\xc7\xfe\x26\xea\x9c\xa2\x38\xd6\xe3\x48\x55\x04\x86\xe9\x1c\x77\x65" +
+		"\x29\x3d\x61\x7d\x7d\xa7\x21\x02\x35\x5f\x39\xeb\xf6\x2f\x91\xb0\x6d\xeb\x53\x25\xf3\x67\xa4\x55\x6e\xa1\xe3\x1e\xd5\x76\x78\x33" +
+		"\x10\x4d\x02\x95\xab\x00\xc8\x5e\x96\x01\x11\xac\x25\xda\x47\x43\x66\x59\x9e\x57\x5a\x9b\x7e\xdf\x61\x45\xf1\x4b\xa6\xd3\xc1\xc4" +
+		"\x0a\xaa\x35\xe2\xc8\x4b\xaf\x11\x7d\xea\x3e\x33\x6c\xd9\x6a\x39\x79\x2b\x38\x13\x95\x4f\xe9\xbf\x3e\xd5\xb9\x0f\x2f\x69\xc9\x77" +
+		"\x2a\x70\xb9\xf1\xd4\xbb\xcc\xdb\xc0\x3e\x17\xc1\xd1\xdc\xdb\x02\x05\x29\x03\xdc\x66\x09\xea\x69\x69\xf6\x61\xb2\xeb\x74\xc8\x39" +
+		"\x28\x11\x54\x65\x1c\x92\x1e\x74\x63\x15\xa9\x93\x4f\x1b\x8a\x1b\xba\x9f\x92\xad\x8e\xf4\xb9\x79\x11\x5b\x8e\x2e\x99\x1c\xcd\x7a" +
+		"\x28\xc2\xbe\x2f\x82\x64\xf9\x5f\x0b\x53\xc7\x32\x13\x4e\xfa\x33\x8c\xcd\x8f\xdb\x9e\xe2\xb4\x5f\xb8\x6a\x89\x4f\x7d\xb3\x6c\x37" +
+		"\x21\x88\x80\x41\xe6\xfe\xbd\x54\x6d\x42\x7c\x89\x0b\x18\x83\xbb\x9b\x62\x6d\x8c\xb4\xdc\x18\xdc\xc4\xec\x8f\xa7\x5e\x53\x0a\x13" +
+		"\x14\xdd\xb5\xfa\xda\x01\x71\xdb\x80\x19\x5b\x95\x92\xd8\xcf\x2b\xe8\x10\x93\x0e\x3e\xa4\x57\x4a\x35\x0d\x65\xe2\xcb\xff\x49\x41" +
+		"\x2f\x69\xa7\x19\x8e\x1f\xbc\xc7\xde\xa4\x32\x65\x30\x6a\x37\xed\x55\xb9\x1b\xff\x65\x2a\xd6\x9a\xa4\xfa\x84\x78\x97\x0d\x40\x1d" +
+		"\x00\x1c\x1e\xdd\x62\x64\x5b\x73\xad\x93\x1a\xb8\x0e\x37\xbb\xb2\x67\xba\x31\x2b\x34\x14\x0e\x71\x6d\x6a\x37\x47\x59\x4d\x30\x52" +
+		"\x15\xb9\x8c\xe9\x3e\x47\xbc\x64\xce\x2f\x2c\x96\xc6\x96\x63\xc4\x39\xc4\x0c\x60\x30\x49\x46\x6f\xa7\xf9\xa4\xb2\x28\xbf\xc3\x2b" +
+		"\x12\xc7\xe2\xad\xfa\x52\x4e\x59\x58\xf6\x5b\xe2\xfb\xac\x80\x9f\xcb\xa8\x45\x8b\x28\xe4\x4d\x92\x65\x05\x1d\xe3\x31\x63\xcf\x9c" +
+		"\x2e\xfc\x2b\x90\xd6\x88\x13\x48\x49\x01\x82\x22\xe7\xb8\x92\x2e\xaf\x67\xce\x79\x81\x6e\xf4\x68\x53\x1e\xc2\xde\x53\xbb\xd1\x67" +
+		"\x0c\x3f\x05\x0a\x6b\xf5\xaf\x15\x19\x81\xe5\x5e\x3e\x1a\x29\xa1\x3c\x3f\xfa\x45\x50\xbd\x25\x14\xf1\xaf\xd6\xc5\xf7\x21\xf8\x30" +
+		"\x0d\xec\x54\xe6\xdb\xf7\x52\x05\xfa\x75\xba\x79\x92\xbd\x34\xf0\x8b\x2e\xfe\x2e\xcd\x42\x4a\x73\xed\xa7\x78\x43\x20\xa1\xa3\x6e" +
+		"\x1c\x48\x2a\x25\xa7\x29\xf5\xdf\x20\x22\x58\x15\x03\x4b\x19\x60\x98\x36\x4a\x11\xf4\xd9\x88\xfb\x7c\xc7\x5c\xf3\x2d\x81\x36\xfa" +
+		"\x26\x25\xce\x48\xa7\xb3\x9a\x42\x52\x73\x26\x24\xe4\xab\x94\x36\x08\x12\xac\x2f\xc9\xa1\x4a\x5f\xb8\xb6\x07\xae\x9f\xd8\x51\x4a" +
+		"\x07\xf0\x17\xa7\xeb\xd5\x6d\xd0\x86\xf7\xcd\x4f\xd7\x10\xc5\x09\xed\x7e\xf8\xe3\x00\xb9\xa8\xbb\x9f\xb9\xf2\x8a\xf7\x10\x25\x1f" +
+		"\x2a\x20\xe3\xa4\xa0\xe5\x7d\x92\xf9\x7c\x9d\x61\x86\xc6\xc3\xea\x7c\x5e\x55\xc2\x01\x46\x25\x9b\xe2\xf7\x8c\x2c\xcc\x2e\x35\x95" +
+		"\x10\x49\xf8\x21\x05\x66\xb5\x1f\xaa\xfb\x1e\x9a\x5d\x63\xc0\xee\x70\x16\x73\xae\xd8\x20\xd9\xc4\x40\x3b\x01\xfe\xb7\x27\xa5\x49" +
+		"\x02\xec\xac\x68\x7e\xf5\xb4\xb5\x68\x00\x2b\xd9\xd1\xb9\x6b\x4b\xef\x35\x7a\x69\xe3\xe8\x6b\x55\x61\xb9\x29\x9b\x82\xd6\x9c\x8e" +
+		"\x2d\x3a\x1a\xea\x2e\x6d\x44\x46\x68\x08\xf8\x8c\x9b\xa9\x03\xd3\xbd\xcb\x6b\x58\xba\x40\x44\x1e\xd4\xeb\xcf\x11\xbb\xe1\xe3\x7b" +
+		"\x14\x07\x4b\xb1\x4c\x98\x2c\x81\xc9\xad\x17\x1e\x4f\x35\xfe\x49\xb3\x9c\x4a\x7a\x72\xdb\xb6\xd9\xc9\x8d\x80\x3b\xfe\xd6\x5e\x64"
+)
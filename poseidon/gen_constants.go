@@ -0,0 +1,137 @@
+//go:build ignore
+
+// gen_constants writes constants_gen.go, the round constants and MDS
+// matrices of circomlib's Poseidon, derived as in the Poseidon reference
+// implementation: a Grain LFSR seeded with the parameters supplies the
+// constants, then the points of a Cauchy matrix. Run it with
+// go generate ./poseidon.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"math/big"
+	"os"
+)
+
+var modulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// Full and partial rounds of each width, as in circomlib
+const fullRounds = 8
+
+var partialRounds = map[int]int{2: 56, 3: 57, 4: 56, 5: 60}
+
+// grain is the LFSR of the reference implementation's
+// generate_parameters_grain script.
+type grain struct{ bits []int }
+
+func newGrain(t, rp int) *grain {
+	var s []int
+	push := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			s = append(s, (v>>i)&1)
+		}
+	}
+	push(1, 2)    // prime field
+	push(0, 4)    // x^alpha S-box
+	push(254, 12) // field size in bits
+	push(t, 12)
+	push(fullRounds, 10)
+	push(rp, 10)
+	for i := 0; i < 30; i++ {
+		s = append(s, 1)
+	}
+	g := &grain{s}
+	for i := 0; i < 160; i++ {
+		g.step()
+	}
+	return g
+}
+
+func (g *grain) step() int {
+	b := g.bits
+	bit := b[62] ^ b[51] ^ b[38] ^ b[23] ^ b[13] ^ b[0]
+	g.bits = append(b[1:], bit)
+	return bit
+}
+
+// next returns the next output bit: of each pair of bits, the second is
+// kept when the first is 1.
+func (g *grain) next() int {
+	for g.step() == 0 {
+		g.step()
+	}
+	return g.step()
+}
+
+func (g *grain) bits254() *big.Int {
+	v := new(big.Int)
+	for i := 0; i < 254; i++ {
+		v.Lsh(v, 1)
+		v.SetBit(v, 0, uint(g.next()))
+	}
+	return v
+}
+
+// field returns the next value below the modulus, rejecting the others.
+func (g *grain) field() *big.Int {
+	for {
+		if v := g.bits254(); v.Cmp(modulus) < 0 {
+			return v
+		}
+	}
+}
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_constants.go. DO NOT EDIT.\n\npackage poseidon\n")
+	for t := 2; t <= 5; t++ {
+		rp := partialRounds[t]
+		g := newGrain(t, rp)
+		var c []*big.Int
+		for i := 0; i < (fullRounds+rp)*t; i++ {
+			c = append(c, g.field())
+		}
+		xs := make([]*big.Int, 2*t)
+		for i := range xs {
+			xs[i] = new(big.Int).Mod(g.bits254(), modulus)
+		}
+		var m []*big.Int
+		for i := 0; i < t; i++ {
+			for j := 0; j < t; j++ {
+				e := new(big.Int).Add(xs[i], xs[t+j])
+				m = append(m, e.ModInverse(e.Mod(e, modulus), modulus))
+			}
+		}
+
+		fmt.Fprintf(&buf, "\n// Round constants and MDS matrix for width %d, %d partial rounds\n", t, rp)
+		fmt.Fprintf(&buf, "const (\n\tc%d = %s\n\tm%d = %s\n)\n", t, literal(c), t, literal(m))
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("constants_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// literal returns a string literal of the values as 32-byte big-endian
+// words, one per line.
+func literal(values []*big.Int) string {
+	var b bytes.Buffer
+	b.WriteString("\"\" +\n")
+	for i, v := range values {
+		b.WriteString("\t\t\"")
+		for _, x := range v.FillBytes(make([]byte, 32)) {
+			fmt.Fprintf(&b, "\\x%02x", x)
+		}
+		b.WriteString("\"")
+		if i < len(values)-1 {
+			b.WriteString(" +\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+// Package poseidon computes the Poseidon hash over the BN254 scalar field
+// with the parameters of circomlib, so contracts can check commitments,
+// nullifiers and Merkle roots produced by circom and halo2 circuits.
+//
+// Hash1 to Hash4 take 1 to 4 field elements, as 32-byte big-endian words
+// below FieldModulus. Each width has its own constants, about 6 to 12 KB
+// of program data, which are only linked into programs that call it.
+package poseidon
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+//go:generate go run gen_constants.go
+
+// FieldModulus is the order of the BN254 scalar field.
+var FieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// Poseidon errors
+var (
+	ErrNotInField = errors.New("poseidon: input not below the field modulus")
+	ErrInputs     = errors.New("poseidon: unsupported number of inputs")
+)
+
+// fullRounds is the number of rounds applying the S-box to every element,
+// half before and half after the partial rounds.
+const fullRounds = 8
+
+// Hash1 returns the Poseidon hash of one element.
+func Hash1(a stygos.Word) (stygos.Word, error) {
+	return permute(56, c2, m2, a)
+}
+
+// Hash2 returns the Poseidon hash of two elements, the hash of Merkle
+// tree nodes in circomlib.
+func Hash2(a, b stygos.Word) (stygos.Word, error) {
+	return permute(57, c3, m3, a, b)
+}
+
+// Hash3 returns the Poseidon hash of three elements.
+func Hash3(a, b, c stygos.Word) (stygos.Word, error) {
+	return permute(56, c4, m4, a, b, c)
+}
+
+// Hash4 returns the Poseidon hash of four elements.
+func Hash4(a, b, c, d stygos.Word) (stygos.Word, error) {
+	return permute(60, c5, m5, a, b, c, d)
+}
+
+// Hash returns the Poseidon hash of 1 to 4 elements. It links the
+// constants of every width; call HashN directly to keep only one.
+func Hash(inputs ...stygos.Word) (stygos.Word, error) {
+	switch len(inputs) {
+	case 1:
+		return Hash1(inputs[0])
+	case 2:
+		return Hash2(inputs[0], inputs[1])
+	case 3:
+		return Hash3(inputs[0], inputs[1], inputs[2])
+	case 4:
+		return Hash4(inputs[0], inputs[1], inputs[2], inputs[3])
+	}
+	return stygos.Word{}, ErrInputs
+}
+
+// permute runs the permutation on the state (0, inputs...) with the given
+// round constants and MDS matrix, and returns the first element. The
+// constants are 32-byte words, t per round, and the matrix t·t words by
+// rows.
+func permute(partialRounds int, constants, mds string, inputs ...stygos.Word) (stygos.Word, error) {
+	t := len(inputs) + 1
+	state := make([]*big.Int, t)
+	state[0] = new(big.Int)
+	for i, in := range inputs {
+		state[i+1] = new(big.Int).SetBytes(in[:])
+		if state[i+1].Cmp(FieldModulus) >= 0 {
+			return stygos.Word{}, ErrNotInField
+		}
+	}
+
+	m := make([]*big.Int, t*t)
+	for i := range m {
+		m[i] = element(mds, i)
+	}
+	next := make([]*big.Int, t)
+	for i := range next {
+		next[i] = new(big.Int)
+	}
+	sq, prod := new(big.Int), new(big.Int)
+
+	for r := 0; r < fullRounds+partialRounds; r++ {
+		full := r < fullRounds/2 || r >= fullRounds/2+partialRounds
+		for i, x := range state {
+			x.Add(x, element(constants, r*t+i))
+			if full || i == 0 {
+				// x^5
+				sq.Mul(x, x).Mod(sq, FieldModulus)
+				sq.Mul(sq, sq).Mod(sq, FieldModulus)
+				x.Mul(x, sq)
+			}
+			x.Mod(x, FieldModulus)
+		}
+		for i, y := range next {
+			y.SetInt64(0)
+			for j, x := range state {
+				y.Add(y, prod.Mul(m[i*t+j], x))
+			}
+			y.Mod(y, FieldModulus)
+		}
+		state, next = next, state
+	}
+
+	var out stygos.Word
+	state[0].FillBytes(out[:])
+	return out, nil
+}
+
+// element returns the i-th 32-byte word of table.
+func element(table string, i int) *big.Int {
+	return new(big.Int).SetBytes([]byte(table[32*i : 32*i+32]))
+}
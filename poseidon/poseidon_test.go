@@ -0,0 +1,59 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func num(s string) stygos.Word {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic(s)
+	}
+	var w stygos.Word
+	v.FillBytes(w[:])
+	return w
+}
+
+// Vectors from circomlibjs
+func TestHash(t *testing.T) {
+	one, two := stygos.WordFromUint64(1), stygos.WordFromUint64(2)
+	tests := []struct {
+		name   string
+		inputs []stygos.Word
+		want   string
+	}{
+		{"poseidon([1])", []stygos.Word{one}, "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+		{"poseidon([1, 2])", []stygos.Word{one, two}, "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+		{"poseidon([0, 0])", []stygos.Word{{}, {}}, "14744269619966411208579211824598458697587494354926760081771325075741142829156"},
+		{"poseidon([1, 2, 3, 4])", []stygos.Word{one, two, stygos.WordFromUint64(3), stygos.WordFromUint64(4)}, "18821383157269793795438455681495246036402687001665670618754263018637548127333"},
+	}
+	for _, tt := range tests {
+		if got, err := Hash(tt.inputs...); err != nil || got != num(tt.want) {
+			t.Errorf("%s failed. Expected %s, got %v, %v", tt.name, tt.want, stygos.BigIntFromWord(got), err)
+		}
+	}
+
+	three := stygos.WordFromUint64(3)
+	h3, err := Hash3(one, two, three)
+	if got, _ := Hash(one, two, three); err != nil || got != h3 || h3 == (stygos.Word{}) {
+		t.Errorf("Hash3 failed. Expected Hash to agree, got %x and %x, %v", h3, got, err)
+	}
+	if _, err := Hash(); err != ErrInputs {
+		t.Errorf("Hash failed. Expected ErrInputs, got %v", err)
+	}
+	var p stygos.Word
+	FieldModulus.FillBytes(p[:])
+	if _, err := Hash2(one, p); err != ErrNotInField {
+		t.Errorf("Hash2 failed. Expected ErrNotInField, got %v", err)
+	}
+}
+
+func BenchmarkHash2(b *testing.B) {
+	one, two := stygos.WordFromUint64(1), stygos.WordFromUint64(2)
+	for i := 0; i < b.N; i++ {
+		Hash2(one, two)
+	}
+}
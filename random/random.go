@@ -0,0 +1,276 @@
+// Package random provides randomness for contracts: an integration point for
+// verifiable sources such as VRF coordinators, and a built-in commit-reveal
+// scheme for when no such source is available.
+//
+// Nothing on chain is random by itself. Block hashes and timestamps are
+// known to, and partly chosen by, the sequencer, so they must never be used
+// alone. Commit-reveal combines a secret fixed before the block hash is
+// known with that hash, so neither the user nor the sequencer controls the
+// result on their own; a user can still refuse to reveal an unfavourable
+// outcome, which contracts must account for (e.g. by forfeiting a deposit).
+package random
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Randomness errors
+var (
+	ErrAlreadyCommitted = errors.New("random: commitment already pending")
+	ErrNoCommitment     = errors.New("random: no pending commitment")
+	ErrBadReveal        = errors.New("random: secret does not match commitment")
+	ErrRevealTooEarly   = errors.New("random: reveal block not reached")
+	ErrRevealExpired    = errors.New("random: reveal block hash no longer available")
+	ErrNotExpired       = errors.New("random: reveal has not expired")
+	ErrNotCoordinator   = errors.New("random: caller is not the coordinator")
+	ErrUnknownRequest   = errors.New("random: unknown or fulfilled request")
+	ErrNotFulfilled     = errors.New("random: request not fulfilled")
+)
+
+// Expand derives n independent words from a single random seed as
+// keccak256(seed ++ i).
+func Expand(seed stygos.Word, n int) []stygos.Word {
+	out := make([]stygos.Word, n)
+	var buf [64]byte
+	copy(buf[:32], seed[:])
+	for i := range out {
+		idx := stygos.WordFromUint64(uint64(i))
+		copy(buf[32:], idx[:])
+		out[i] = stygos.Keccak256(buf[:])
+	}
+	return out
+}
+
+// Uniform maps a random word to [0, n) without modulo bias, re-hashing on
+// the rare draws from the biased tail. n must be non-zero.
+func Uniform(r stygos.Word, n stygos.U256) stygos.U256 {
+	// Largest multiple of n representable in 256 bits
+	max := stygos.U256{}.Not()
+	limit := max.Sub(max.Mod(n).Add(stygos.NewU256(1)).Mod(n))
+	for {
+		v := stygos.U256FromWord(r)
+		if !v.Gt(limit) {
+			return v.Mod(n)
+		}
+		r = stygos.Keccak256(r[:])
+	}
+}
+
+// --- Commit-reveal ---
+
+// CommitReveal is a per-account commit-reveal randomness scheme.
+//
+// An account commits to keccak256(secret ++ account) (see CommitmentOf). At
+// least Delay L2 blocks later it reveals the secret and obtains
+// keccak256(secret ++ blockhash(commitBlock+Delay) ++ account). The reveal
+// must happen while that block hash is still available, i.e. within 256
+// blocks; after that the account calls Expire to commit again.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, account)     commitment
+//	MapKey(base, account)+1   commit block
+type CommitReveal struct {
+	base  stygos.Word
+	delay uint64
+}
+
+// NewCommitReveal returns the commit-reveal scheme rooted at base. delay is
+// the number of blocks between commit and reveal, at least 1.
+func NewCommitReveal(base stygos.Word, delay uint64) *CommitReveal {
+	if delay == 0 {
+		delay = 1
+	}
+	return &CommitReveal{base: base, delay: delay}
+}
+
+// CommitmentOf returns the commitment for secret by account. Binding the
+// account prevents others from copying a pending commitment.
+func CommitmentOf(secret stygos.Word, account stygos.Address) stygos.Word {
+	return stygos.Keccak256(append(secret[:], account[:]...))
+}
+
+// Commit records a commitment for the caller at the current L2 block.
+func (c *CommitReveal) Commit(commitment stygos.Word) error {
+	slot := c.slot(stygos.GetMsgSender())
+	if stygos.StorageLoad(slot) != (stygos.Word{}) {
+		return ErrAlreadyCommitted
+	}
+	block, err := arb.ArbBlockNumber()
+	if err != nil {
+		return err
+	}
+	stygos.StorageStore(slot, commitment)
+	stygos.StorageStore(storage.Offset(slot, 1), stygos.WordFromUint64(block))
+	return nil
+}
+
+// Pending returns the block at which account committed, if it has a
+// pending commitment.
+func (c *CommitReveal) Pending(account stygos.Address) (uint64, bool) {
+	slot := c.slot(account)
+	if stygos.StorageLoad(slot) == (stygos.Word{}) {
+		return 0, false
+	}
+	return stygos.Uint64FromWord(stygos.StorageLoad(storage.Offset(slot, 1))), true
+}
+
+// Reveal checks secret against the caller's commitment, clears it and
+// returns the random word. It fails with ErrRevealExpired once the block
+// hash is gone, leaving the commitment for Expire: the contract reverts on
+// the error, which would undo any clearing here.
+func (c *CommitReveal) Reveal(secret stygos.Word) (stygos.Word, error) {
+	sender := stygos.GetMsgSender()
+	slot := c.slot(sender)
+	commitment := stygos.StorageLoad(slot)
+	if commitment == (stygos.Word{}) {
+		return stygos.Word{}, ErrNoCommitment
+	}
+	if CommitmentOf(secret, sender) != commitment {
+		return stygos.Word{}, ErrBadReveal
+	}
+
+	hash, err := c.revealHash(slot)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	c.clear(slot)
+
+	var buf [84]byte
+	copy(buf[:32], secret[:])
+	copy(buf[32:64], hash[:])
+	copy(buf[64:], sender[:])
+	return stygos.Keccak256(buf[:]), nil
+}
+
+// Expire clears the caller's commitment once its reveal has expired, so
+// the account can commit again. It fails with ErrNotExpired while the
+// commitment can still be revealed. Contracts that take a deposit with the
+// commitment forfeit it here.
+func (c *CommitReveal) Expire() error {
+	slot := c.slot(stygos.GetMsgSender())
+	if stygos.StorageLoad(slot) == (stygos.Word{}) {
+		return ErrNoCommitment
+	}
+	switch _, err := c.revealHash(slot); err {
+	case ErrRevealExpired:
+		c.clear(slot)
+		return nil
+	case nil, ErrRevealTooEarly:
+		return ErrNotExpired
+	default:
+		return err
+	}
+}
+
+// revealHash returns the block hash mixed into the reveal of the
+// commitment at slot.
+func (c *CommitReveal) revealHash(slot stygos.Word) (stygos.Word, error) {
+	target := stygos.Uint64FromWord(stygos.StorageLoad(storage.Offset(slot, 1))) + c.delay
+	current, err := arb.ArbBlockNumber()
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	if current <= target {
+		return stygos.Word{}, ErrRevealTooEarly
+	}
+	hash := stygos.BlockHash(target)
+	if hash == (stygos.Word{}) {
+		return stygos.Word{}, ErrRevealExpired
+	}
+	return hash, nil
+}
+
+func (c *CommitReveal) clear(slot stygos.Word) {
+	stygos.StorageStore(slot, stygos.Word{})
+	stygos.StorageStore(storage.Offset(slot, 1), stygos.Word{})
+}
+
+func (c *CommitReveal) slot(account stygos.Address) stygos.Word {
+	return storage.MapKey(c.base, account[:])
+}
+
+// --- Verifiable sources ---
+
+// VRF tracks requests to an external verifiable randomness coordinator,
+// such as Chainlink VRF, and accepts its fulfillments.
+//
+// The contract builds the coordinator-specific request calldata and passes
+// it to Request; the coordinator later calls the contract back, which hands
+// the request id and random word to Fulfill.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, requestID)   status: 1 pending, 2 fulfilled
+//	MapKey(base, requestID)+1 random word
+type VRF struct {
+	base        stygos.Word
+	coordinator stygos.Address
+}
+
+// Request statuses
+const (
+	statusPending   = 1
+	statusFulfilled = 2
+)
+
+// NewVRF returns the request tracker rooted at base for coordinator.
+func NewVRF(base stygos.Word, coordinator stygos.Address) *VRF {
+	return &VRF{base: base, coordinator: coordinator}
+}
+
+// Coordinator returns the address allowed to fulfill requests.
+func (v *VRF) Coordinator() stygos.Address {
+	return v.coordinator
+}
+
+// Request calls the coordinator with calldata and records the request id it
+// returns (the first word of its return data) as pending. The id is only
+// known after the call: a coordinator fulfilling before it returns gets
+// ErrUnknownRequest.
+func (v *VRF) Request(calldata []byte) (stygos.Word, error) {
+	ret, err := stygos.Call(v.coordinator, stygos.Word{}, calldata)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	if len(ret) < 32 {
+		return stygos.Word{}, arb.ErrBadReturn
+	}
+	var id stygos.Word
+	copy(id[:], ret)
+	stygos.StorageStore(v.slot(id), stygos.WordFromUint64(statusPending))
+	return id, nil
+}
+
+// Fulfill stores the random word for a pending request. It must be called
+// from the coordinator's callback: the caller is checked to be the
+// coordinator.
+func (v *VRF) Fulfill(requestID, randomness stygos.Word) error {
+	if stygos.GetMsgSender() != v.coordinator {
+		return ErrNotCoordinator
+	}
+	slot := v.slot(requestID)
+	if stygos.Uint64FromWord(stygos.StorageLoad(slot)) != statusPending {
+		return ErrUnknownRequest
+	}
+	stygos.StorageStore(slot, stygos.WordFromUint64(statusFulfilled))
+	stygos.StorageStore(storage.Offset(slot, 1), randomness)
+	return nil
+}
+
+// Result returns the random word of a fulfilled request.
+func (v *VRF) Result(requestID stygos.Word) (stygos.Word, error) {
+	slot := v.slot(requestID)
+	if stygos.Uint64FromWord(stygos.StorageLoad(slot)) != statusFulfilled {
+		return stygos.Word{}, ErrNotFulfilled
+	}
+	return stygos.StorageLoad(storage.Offset(slot, 1)), nil
+}
+
+func (v *VRF) slot(requestID stygos.Word) stygos.Word {
+	return storage.MapKey(v.base, requestID[:])
+}
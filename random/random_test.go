@@ -0,0 +1,152 @@
+package random
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+)
+
+func TestCommitReveal(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Sender = stygos.Address{0xa1}
+	stygos.UseRuntime(mock)
+	arbos := arb.InstallMock(mock)
+	arbos.Mine(10)
+
+	cr := NewCommitReveal(stygos.Word{0x01}, 2)
+	secret := stygos.Word{0x5e, 0xc7}
+
+	if _, err := cr.Reveal(secret); err != ErrNoCommitment {
+		t.Errorf("Reveal failed. Expected ErrNoCommitment, got %v", err)
+	}
+	if err := cr.Commit(CommitmentOf(secret, mock.Sender)); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := cr.Commit(CommitmentOf(secret, mock.Sender)); err != ErrAlreadyCommitted {
+		t.Errorf("Commit failed. Expected ErrAlreadyCommitted, got %v", err)
+	}
+	if block, ok := cr.Pending(mock.Sender); !ok || block != 11 {
+		t.Errorf("Pending failed. Expected block 11, got %d, %v", block, ok)
+	}
+
+	arbos.Mine(2)
+	if _, err := cr.Reveal(secret); err != ErrRevealTooEarly {
+		t.Errorf("Reveal failed. Expected ErrRevealTooEarly, got %v", err)
+	}
+	arbos.Mine(1)
+	if _, err := cr.Reveal(stygos.Word{0xba, 0xd}); err != ErrBadReveal {
+		t.Errorf("Reveal failed. Expected ErrBadReveal, got %v", err)
+	}
+
+	r, err := cr.Reveal(secret)
+	if err != nil {
+		t.Fatalf("Reveal failed: %v", err)
+	}
+	hash := arbos.BlockHashes[13]
+	var buf []byte
+	buf = append(buf, secret[:]...)
+	buf = append(buf, hash[:]...)
+	buf = append(buf, mock.Sender[:]...)
+	if r != stygos.Keccak256(buf) {
+		t.Errorf("Reveal failed. Expected mix of secret and block 13 hash, got %x", r)
+	}
+	if _, ok := cr.Pending(mock.Sender); ok {
+		t.Errorf("Reveal failed to clear the commitment")
+	}
+}
+
+func TestCommitRevealExpired(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Sender = stygos.Address{0xa2}
+	stygos.UseRuntime(mock)
+	arbos := arb.InstallMock(mock)
+
+	cr := NewCommitReveal(stygos.Word{0x02}, 1)
+	secret := stygos.Word{0x77}
+	if err := cr.Commit(CommitmentOf(secret, mock.Sender)); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := cr.Expire(); err != ErrNotExpired {
+		t.Errorf("Expire failed. Expected ErrNotExpired, got %v", err)
+	}
+	arbos.Mine(300)
+	if _, err := cr.Reveal(secret); err != ErrRevealExpired {
+		t.Errorf("Reveal failed. Expected ErrRevealExpired, got %v", err)
+	}
+	// The contract reverts on the error, so the commitment must stay
+	if _, ok := cr.Pending(mock.Sender); !ok {
+		t.Errorf("Reveal failed. Expected the expired commitment to stay pending")
+	}
+	if err := cr.Expire(); err != nil {
+		t.Fatalf("Expire failed: %v", err)
+	}
+	if err := cr.Expire(); err != ErrNoCommitment {
+		t.Errorf("Expire failed. Expected ErrNoCommitment, got %v", err)
+	}
+	if err := cr.Commit(CommitmentOf(secret, mock.Sender)); err != nil {
+		t.Errorf("Commit after expiry failed: %v", err)
+	}
+}
+
+func TestVRF(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	stygos.UseRuntime(mock)
+
+	coordinator := stygos.Address{0xcc}
+	vrf := NewVRF(stygos.Word{0x03}, coordinator)
+	var next uint64 = 7
+	mock.Deploy(coordinator, func(input []byte) ([]byte, error) {
+		id := stygos.WordFromUint64(next)
+		next++
+		return id[:], nil
+	})
+
+	id, err := vrf.Request([]byte("requestRandomWords"))
+	if err != nil || stygos.Uint64FromWord(id) != 7 {
+		t.Fatalf("Request failed. Got %x, %v", id, err)
+	}
+	if _, err := vrf.Result(id); err != ErrNotFulfilled {
+		t.Errorf("Result failed. Expected ErrNotFulfilled, got %v", err)
+	}
+
+	if err := vrf.Fulfill(id, stygos.Word{0x42}); err != ErrNotCoordinator {
+		t.Errorf("Fulfill failed. Expected ErrNotCoordinator, got %v", err)
+	}
+	mock.Sender = coordinator
+	if err := vrf.Fulfill(stygos.Word{0x99}, stygos.Word{0x42}); err != ErrUnknownRequest {
+		t.Errorf("Fulfill failed. Expected ErrUnknownRequest, got %v", err)
+	}
+	if err := vrf.Fulfill(id, stygos.Word{0x42}); err != nil {
+		t.Fatalf("Fulfill failed: %v", err)
+	}
+	if err := vrf.Fulfill(id, stygos.Word{0x43}); err != ErrUnknownRequest {
+		t.Errorf("Fulfill failed. Expected a second fulfillment to be rejected, got %v", err)
+	}
+	if r, err := vrf.Result(id); err != nil || r != (stygos.Word{0x42}) {
+		t.Errorf("Result failed. Got %x, %v", r, err)
+	}
+}
+
+func TestExpandAndUniform(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	words := Expand(stygos.Word{1}, 3)
+	if len(words) != 3 || words[0] == words[1] || words[1] == words[2] {
+		t.Errorf("Expand failed. Got %x", words)
+	}
+
+	n := stygos.NewU256(6)
+	for _, w := range Expand(stygos.Word{2}, 50) {
+		if v := Uniform(w, n); !v.Lt(n) {
+			t.Errorf("Uniform failed. Expected value below 6, got %v", v)
+		}
+	}
+
+	// The maximum word lies in the biased tail for n = 6 and is re-hashed
+	max := stygos.U256{}.Not().Word()
+	if v, want := Uniform(max, n), Uniform(stygos.Keccak256(max[:]), n); v != want {
+		t.Errorf("Uniform failed for the maximum word. Expected %v, got %v", want, v)
+	}
+}
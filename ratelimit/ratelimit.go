@@ -0,0 +1,242 @@
+// Package ratelimit caps the volume that flows through a contract per
+// period, and trips a circuit breaker that pauses the flow when the cap is
+// hit.
+//
+// A RateLimit counts usage in fixed windows of Period seconds and rolls
+// over to a fresh window automatically on the first use after one ends.
+// Per-account limits are RateLimits rooted at storage.MapKey(base,
+// account). A CircuitBreaker wraps a RateLimit for flows such as bridge
+// withdrawals or vault redemptions: an amount that would exceed the cap
+// pauses the flow until an admin resumes it or a cooldown passes.
+package ratelimit
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Rate limit errors
+var (
+	ErrInvalidPeriod = errors.New("ratelimit: period must be positive")
+	ErrNotConfigured = errors.New("ratelimit: not configured")
+	ErrLimitExceeded = errors.New("ratelimit: limit exceeded")
+	ErrPaused        = errors.New("ratelimit: circuit breaker tripped")
+	ErrTripped       = errors.New("ratelimit: limit exceeded, circuit breaker tripped")
+)
+
+// Window is the state of a rate limit, packed into storage by
+// window_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Window -o window_pack_gen.go
+type Window struct {
+	Period uint64 // seconds
+	Start  uint64 // start of the current window
+	Limit  stygos.U256
+	Used   stygos.U256 // in the window starting at Start
+}
+
+// RateLimit caps the amount consumed per window.
+//
+// Storage layout relative to the base slot:
+//
+//	base   Window (WindowPackedWords slots)
+type RateLimit struct {
+	base stygos.Word
+}
+
+// NewRateLimit returns the rate limit rooted at base.
+func NewRateLimit(base stygos.Word) *RateLimit {
+	return &RateLimit{base: base}
+}
+
+// Configure sets the amount allowed per period. Usage in the current
+// window is kept.
+func (r *RateLimit) Configure(limit stygos.U256, period uint64) error {
+	if period == 0 {
+		return ErrInvalidPeriod
+	}
+	w := r.current()
+	if w.Period == 0 {
+		w.Start = stygos.GetBlockTimestamp()
+	}
+	w.Limit, w.Period = limit, period
+	w.Store(r.base)
+	return nil
+}
+
+// Limit returns the amount allowed per period.
+func (r *RateLimit) Limit() stygos.U256 {
+	return r.current().Limit
+}
+
+// Period returns the window length in seconds.
+func (r *RateLimit) Period() uint64 {
+	return r.current().Period
+}
+
+// Used returns the amount consumed in the current window.
+func (r *RateLimit) Used() stygos.U256 {
+	return r.current().Used
+}
+
+// Remaining returns the amount that can still be consumed in the current
+// window.
+func (r *RateLimit) Remaining() stygos.U256 {
+	w := r.current()
+	if w.Limit.Lt(w.Used) {
+		return stygos.U256{}
+	}
+	return w.Limit.Sub(w.Used)
+}
+
+// WindowEnd returns when the current window ends and the usage resets.
+func (r *RateLimit) WindowEnd() uint64 {
+	w := r.current()
+	return w.Start + w.Period
+}
+
+// Consume records amount against the current window, failing with
+// ErrLimitExceeded if it does not fit.
+func (r *RateLimit) Consume(amount stygos.U256) error {
+	w := r.current()
+	if w.Period == 0 {
+		return ErrNotConfigured
+	}
+	used := w.Used.Add(amount)
+	if used.Lt(w.Used) || w.Limit.Lt(used) {
+		return ErrLimitExceeded
+	}
+	w.Used = used
+	w.Store(r.base)
+	return nil
+}
+
+// Refund gives back amount consumed in the current window, e.g. when a
+// flow is cancelled or offset by an inflow.
+func (r *RateLimit) Refund(amount stygos.U256) {
+	w := r.current()
+	if w.Used.Lt(amount) {
+		w.Used = stygos.U256{}
+	} else {
+		w.Used = w.Used.Sub(amount)
+	}
+	w.Store(r.base)
+}
+
+// current loads the window, rolled over to the window containing now.
+// Windows stay aligned to the first one's start.
+func (r *RateLimit) current() Window {
+	var w Window
+	w.Load(r.base)
+	now := stygos.GetBlockTimestamp()
+	if w.Period != 0 && now >= w.Start+w.Period {
+		w.Start = now - (now-w.Start)%w.Period
+		w.Used = stygos.U256{}
+	}
+	return w
+}
+
+// Breaker is the state of a circuit breaker, packed into storage by
+// breaker_pack_gen.go.
+//
+//go:generate stygos-gen pack -type Breaker -o breaker_pack_gen.go
+type Breaker struct {
+	Tripped   bool
+	Manual    bool   // paused by Pause, ignoring the cooldown
+	TrippedAt uint64 // when it tripped
+	Cooldown  uint64 // seconds until it resumes by itself; 0 for never
+}
+
+// CircuitBreaker pauses a flow when it exceeds its rate limit.
+//
+// Storage layout relative to the base slot:
+//
+//	base                       RateLimit (WindowPackedWords slots)
+//	base+WindowPackedWords     Breaker (BreakerPackedWords slots)
+type CircuitBreaker struct {
+	limit *RateLimit
+	state stygos.Word
+}
+
+// NewCircuitBreaker returns the circuit breaker rooted at base.
+func NewCircuitBreaker(base stygos.Word) *CircuitBreaker {
+	return &CircuitBreaker{
+		limit: NewRateLimit(base),
+		state: storage.Offset(base, WindowPackedWords),
+	}
+}
+
+// Configure sets the rate limit and the cooldown after which a tripped
+// breaker resumes by itself (0 to wait for Resume).
+func (b *CircuitBreaker) Configure(limit stygos.U256, period, cooldown uint64) error {
+	if err := b.limit.Configure(limit, period); err != nil {
+		return err
+	}
+	s := b.load()
+	s.Cooldown = cooldown
+	s.Store(b.state)
+	return nil
+}
+
+// RateLimit returns the breaker's rate limit.
+func (b *CircuitBreaker) RateLimit() *RateLimit {
+	return b.limit
+}
+
+// Paused reports whether the breaker is tripped and the flow paused.
+func (b *CircuitBreaker) Paused() bool {
+	s := b.load()
+	if !s.Tripped {
+		return false
+	}
+	return s.Manual || s.Cooldown == 0 || stygos.GetBlockTimestamp() < s.TrippedAt+s.Cooldown
+}
+
+// Record lets amount through the breaker. It fails with ErrPaused while
+// the breaker is tripped. An amount that would exceed the rate limit trips
+// the breaker and fails with ErrTripped; the contract must then return
+// without reverting, or the trip is rolled back with the transaction:
+//
+//	if err := breaker.Record(amount); err == ratelimit.ErrTripped {
+//		return nil, nil // paused; nothing is paid out
+//	} else if err != nil {
+//		return nil, err
+//	}
+func (b *CircuitBreaker) Record(amount stygos.U256) error {
+	if b.Paused() {
+		return ErrPaused
+	}
+	err := b.limit.Consume(amount)
+	if err != ErrLimitExceeded {
+		return err
+	}
+	b.trip(false)
+	return ErrTripped
+}
+
+// Pause trips the breaker until Resume, e.g. from a guardian's emergency
+// call.
+func (b *CircuitBreaker) Pause() {
+	b.trip(true)
+}
+
+// Resume closes the breaker. The contract decides who may call it.
+func (b *CircuitBreaker) Resume() {
+	s := b.load()
+	s.Tripped, s.Manual, s.TrippedAt = false, false, 0
+	s.Store(b.state)
+}
+
+func (b *CircuitBreaker) trip(manual bool) {
+	s := b.load()
+	s.Tripped, s.Manual, s.TrippedAt = true, manual, stygos.GetBlockTimestamp()
+	s.Store(b.state)
+}
+
+func (b *CircuitBreaker) load() Breaker {
+	var s Breaker
+	s.Load(b.state)
+	return s
+}
@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+func setup(t *testing.T) *stygos.MockRuntime {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+	return mock
+}
+
+func TestRateLimit(t *testing.T) {
+	mock := setup(t)
+	r := NewRateLimit(stygos.Word{0x11})
+
+	if err := r.Consume(stygos.NewU256(1)); err != ErrNotConfigured {
+		t.Errorf("Consume failed. Expected ErrNotConfigured, got %v", err)
+	}
+	if err := r.Configure(stygos.NewU256(100), 0); err != ErrInvalidPeriod {
+		t.Errorf("Configure failed. Expected ErrInvalidPeriod, got %v", err)
+	}
+	if err := r.Configure(stygos.NewU256(100), 3_600); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	if err := r.Consume(stygos.NewU256(60)); err != nil {
+		t.Fatalf("Consume failed: %v", err)
+	}
+	if err := r.Consume(stygos.NewU256(41)); err != ErrLimitExceeded {
+		t.Errorf("Consume failed. Expected ErrLimitExceeded, got %v", err)
+	}
+	if err := r.Consume(stygos.NewU256(40)); err != nil {
+		t.Errorf("Consume failed. Expected the rest of the limit, got %v", err)
+	}
+	if got := r.Remaining(); !got.IsZero() {
+		t.Errorf("Remaining failed. Expected 0, got %d", got.Uint64())
+	}
+	r.Refund(stygos.NewU256(30))
+	if got := r.Used(); got.Uint64() != 70 {
+		t.Errorf("Refund failed. Expected 70 used, got %d", got.Uint64())
+	}
+
+	// Windows roll over, aligned to the first one
+	mock.Time = 1_000 + 2*3_600 + 5
+	if got := r.Used(); !got.IsZero() {
+		t.Errorf("Used failed. Expected a fresh window, got %d", got.Uint64())
+	}
+	if got := r.WindowEnd(); got != 1_000+3*3_600 {
+		t.Errorf("WindowEnd failed. Expected %d, got %d", 1_000+3*3_600, got)
+	}
+	if err := r.Consume(stygos.NewU256(100)); err != nil {
+		t.Errorf("Consume failed after roll-over: %v", err)
+	}
+
+	// Overflowing amounts are rejected
+	maxU256 := stygos.U256{}.Not()
+	if err := r.Configure(maxU256, 3_600); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+	if err := r.Consume(maxU256); err != ErrLimitExceeded {
+		t.Errorf("Consume failed. Expected ErrLimitExceeded on overflow, got %v", err)
+	}
+}
+
+func TestPerAccountLimits(t *testing.T) {
+	setup(t)
+	base := stygos.Word{0x22}
+	alice, bob := stygos.Address{0xa1}, stygos.Address{0xb0}
+	limitOf := func(a stygos.Address) *RateLimit {
+		return NewRateLimit(storage.MapKey(base, a[:]))
+	}
+	for _, a := range []stygos.Address{alice, bob} {
+		limitOf(a).Configure(stygos.NewU256(10), 60)
+	}
+	if err := limitOf(alice).Consume(stygos.NewU256(10)); err != nil {
+		t.Fatalf("Consume failed: %v", err)
+	}
+	if err := limitOf(bob).Consume(stygos.NewU256(10)); err != nil {
+		t.Errorf("Consume failed. Expected limits per account, got %v", err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	mock := setup(t)
+	b := NewCircuitBreaker(stygos.Word{0x33})
+	if err := b.Configure(stygos.NewU256(100), 3_600, 600); err != nil {
+		t.Fatalf("Configure failed: %v", err)
+	}
+
+	if err := b.Record(stygos.NewU256(80)); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := b.Record(stygos.NewU256(30)); err != ErrTripped {
+		t.Errorf("Record failed. Expected ErrTripped, got %v", err)
+	}
+	if !b.Paused() {
+		t.Error("Paused failed. Expected the breaker tripped")
+	}
+	if err := b.Record(stygos.NewU256(1)); err != ErrPaused {
+		t.Errorf("Record failed. Expected ErrPaused, got %v", err)
+	}
+	if got := b.RateLimit().Used(); got.Uint64() != 80 {
+		t.Errorf("Record failed. Expected the tripping amount not consumed, got %d", got.Uint64())
+	}
+
+	// The cooldown resumes the flow
+	mock.Time += 600
+	if b.Paused() {
+		t.Error("Paused failed. Expected the breaker to resume after the cooldown")
+	}
+	if err := b.Record(stygos.NewU256(20)); err != nil {
+		t.Errorf("Record failed after the cooldown: %v", err)
+	}
+
+	// Manual pauses ignore the cooldown
+	b.Pause()
+	mock.Time += 10_000
+	if err := b.Record(stygos.NewU256(1)); err != ErrPaused {
+		t.Errorf("Record failed. Expected ErrPaused, got %v", err)
+	}
+	b.Resume()
+	if err := b.Record(stygos.NewU256(1)); err != nil {
+		t.Errorf("Record failed after Resume: %v", err)
+	}
+}
+
+func TestCircuitBreakerWithoutCooldown(t *testing.T) {
+	mock := setup(t)
+	b := NewCircuitBreaker(stygos.Word{0x44})
+	b.Configure(stygos.NewU256(1), 60, 0)
+	if err := b.Record(stygos.NewU256(2)); err != ErrTripped {
+		t.Fatalf("Record failed. Expected ErrTripped, got %v", err)
+	}
+	mock.Time += 1_000_000
+	if !b.Paused() {
+		t.Error("Paused failed. Expected the breaker to wait for Resume")
+	}
+}
@@ -0,0 +1,249 @@
+// Package recovery implements guardian-based social recovery for wallets.
+//
+// A wallet names guardians and a threshold. When the owner key is lost, a
+// guardian proposes a new owner and the others approve it; once the
+// threshold is met a delay starts, during which the owner can still cancel.
+// After the delay anyone may execute the recovery, and the wallet installs
+// the new owner. The component only tracks guardians and the pending
+// request: the wallet contract decides who may configure and cancel, and
+// what executing a recovery changes.
+package recovery
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Recovery errors
+var (
+	ErrInitialized      = errors.New("recovery: already initialized")
+	ErrNotGuardian      = errors.New("recovery: caller is not a guardian")
+	ErrInvalidGuardian  = errors.New("recovery: invalid guardian")
+	ErrInvalidThreshold = errors.New("recovery: invalid threshold")
+	ErrInvalidOwner     = errors.New("recovery: invalid new owner")
+	ErrRecoveryPending  = errors.New("recovery: a recovery is pending")
+	ErrNoRecovery       = errors.New("recovery: no pending recovery")
+	ErrAlreadyApproved  = errors.New("recovery: already approved")
+	ErrNotReady         = errors.New("recovery: threshold or delay not reached")
+)
+
+// Request is a pending recovery, packed into storage by
+// request_pack_gen.go. ReadyAt is zero until the threshold is met, then
+// the time from which it can be executed.
+//
+//go:generate stygos-gen pack -type Request -o request_pack_gen.go
+type Request struct {
+	NewOwner stygos.Address
+	ReadyAt  uint64
+}
+
+// Recovery holds the guardians of a wallet and its pending recovery.
+//
+// Storage layout relative to the base slot:
+//
+//	base     guardians (an AddressSet, two slots)
+//	base+2   threshold
+//	base+3   delay in seconds
+//	base+4   round, incremented by each proposal
+//	base+5   approvals: MapKey(base+5, round || guardian) -> 1
+//	base+6   pending Request (RequestPackedWords slots)
+type Recovery struct {
+	guardians *storage.AddressSet
+	threshold stygos.Word
+	delay     stygos.Word
+	round     stygos.Word
+	approvals stygos.Word
+	pending   stygos.Word
+}
+
+// NewRecovery returns the recovery component rooted at base.
+func NewRecovery(base stygos.Word) *Recovery {
+	return &Recovery{
+		guardians: storage.NewAddressSet(base),
+		threshold: storage.Offset(base, 2),
+		delay:     storage.Offset(base, 3),
+		round:     storage.Offset(base, 4),
+		approvals: storage.Offset(base, 5),
+		pending:   storage.Offset(base, 6),
+	}
+}
+
+// Initialize sets the guardians, the number of approvals a recovery needs
+// and the delay before it can be executed.
+func (r *Recovery) Initialize(guardians []stygos.Address, threshold, delay uint64) error {
+	if r.Threshold() != 0 {
+		return ErrInitialized
+	}
+	for _, g := range guardians {
+		if err := r.AddGuardian(g); err != nil {
+			return err
+		}
+	}
+	r.SetDelay(delay)
+	return r.SetThreshold(threshold)
+}
+
+// Guardians returns the guardians.
+func (r *Recovery) Guardians() []stygos.Address {
+	return r.guardians.Values()
+}
+
+// IsGuardian reports whether addr is a guardian.
+func (r *Recovery) IsGuardian(addr stygos.Address) bool {
+	return r.guardians.Contains(addr)
+}
+
+// Threshold returns the number of guardian approvals a recovery needs.
+func (r *Recovery) Threshold() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(r.threshold))
+}
+
+// Delay returns the seconds between reaching the threshold and execution.
+func (r *Recovery) Delay() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(r.delay))
+}
+
+// AddGuardian adds a guardian. The zero address, the wallet itself and
+// existing guardians are rejected.
+func (r *Recovery) AddGuardian(g stygos.Address) error {
+	if g == (stygos.Address{}) || g == stygos.GetContractAddress() || !r.guardians.Add(g) {
+		return ErrInvalidGuardian
+	}
+	return nil
+}
+
+// RemoveGuardian removes a guardian, keeping the threshold reachable.
+func (r *Recovery) RemoveGuardian(g stygos.Address) error {
+	if r.guardians.Length() <= r.Threshold() && r.guardians.Contains(g) {
+		return ErrInvalidThreshold
+	}
+	if !r.guardians.Remove(g) {
+		return ErrNotGuardian
+	}
+	return nil
+}
+
+// SetThreshold sets the number of approvals, between 1 and the number of
+// guardians.
+func (r *Recovery) SetThreshold(threshold uint64) error {
+	if threshold == 0 || threshold > r.guardians.Length() {
+		return ErrInvalidThreshold
+	}
+	stygos.StorageStore(r.threshold, stygos.WordFromUint64(threshold))
+	return nil
+}
+
+// SetDelay sets the delay for recoveries that reach the threshold later.
+func (r *Recovery) SetDelay(delay uint64) {
+	stygos.StorageStore(r.delay, stygos.WordFromUint64(delay))
+}
+
+// Pending returns the pending recovery, if any.
+func (r *Recovery) Pending() (Request, bool) {
+	var req Request
+	req.Load(r.pending)
+	return req, req.NewOwner != (stygos.Address{})
+}
+
+// Round returns the number of recoveries proposed so far.
+func (r *Recovery) Round() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(r.round))
+}
+
+// HasApproved reports whether guardian approved the pending recovery.
+func (r *Recovery) HasApproved(guardian stygos.Address) bool {
+	return stygos.StorageLoad(r.approvalSlot(guardian)) != (stygos.Word{})
+}
+
+// Approvals counts the current guardians that approved the pending
+// recovery.
+func (r *Recovery) Approvals() uint64 {
+	if _, ok := r.Pending(); !ok {
+		return 0
+	}
+	count := uint64(0)
+	for _, g := range r.guardians.Values() {
+		if r.HasApproved(g) {
+			count++
+		}
+	}
+	return count
+}
+
+// Propose starts a recovery to newOwner, approved by the calling guardian.
+func (r *Recovery) Propose(newOwner stygos.Address) error {
+	if !r.IsGuardian(stygos.GetMsgSender()) {
+		return ErrNotGuardian
+	}
+	if newOwner == (stygos.Address{}) {
+		return ErrInvalidOwner
+	}
+	if _, ok := r.Pending(); ok {
+		return ErrRecoveryPending
+	}
+	stygos.StorageStore(r.round, stygos.WordFromUint64(r.Round()+1))
+	req := Request{NewOwner: newOwner}
+	req.Store(r.pending)
+	return r.Approve()
+}
+
+// Approve records the calling guardian's approval of the pending
+// recovery, starting the delay when the threshold is met.
+func (r *Recovery) Approve() error {
+	guardian := stygos.GetMsgSender()
+	if !r.IsGuardian(guardian) {
+		return ErrNotGuardian
+	}
+	req, ok := r.Pending()
+	if !ok {
+		return ErrNoRecovery
+	}
+	slot := r.approvalSlot(guardian)
+	if stygos.StorageLoad(slot) != (stygos.Word{}) {
+		return ErrAlreadyApproved
+	}
+	stygos.StorageStore(slot, stygos.WordFromUint64(1))
+
+	if req.ReadyAt == 0 && r.Approvals() >= r.Threshold() {
+		req.ReadyAt = stygos.GetBlockTimestamp() + r.Delay()
+		req.Store(r.pending)
+	}
+	return nil
+}
+
+// Cancel discards the pending recovery. The wallet calls it for its owner,
+// which is what the delay is for.
+func (r *Recovery) Cancel() error {
+	if _, ok := r.Pending(); !ok {
+		return ErrNoRecovery
+	}
+	var empty Request
+	empty.Store(r.pending)
+	return nil
+}
+
+// Execute completes the pending recovery once the delay has passed and
+// current guardians still meet the threshold, and returns the new owner
+// for the wallet to install.
+func (r *Recovery) Execute() (stygos.Address, error) {
+	req, ok := r.Pending()
+	if !ok {
+		return stygos.Address{}, ErrNoRecovery
+	}
+	if req.ReadyAt == 0 || stygos.GetBlockTimestamp() < req.ReadyAt || r.Approvals() < r.Threshold() {
+		return stygos.Address{}, ErrNotReady
+	}
+	var empty Request
+	empty.Store(r.pending)
+	return req.NewOwner, nil
+}
+
+// approvalSlot returns the slot of guardian's approval in the current
+// round. Cancelled and executed rounds keep their approvals, which no
+// longer count.
+func (r *Recovery) approvalSlot(guardian stygos.Address) stygos.Word {
+	round := stygos.WordFromUint64(r.Round())
+	return storage.MapKey(r.approvals, append(round[:], guardian[:]...))
+}
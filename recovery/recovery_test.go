@@ -0,0 +1,164 @@
+package recovery
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+var (
+	wallet   = stygos.Address{0xc0}
+	alice    = stygos.Address{0xa1}
+	bob      = stygos.Address{0xb0}
+	carol    = stygos.Address{0xca}
+	newOwner = stygos.Address{0x0e}
+)
+
+func setup(t *testing.T) (*stygos.MockRuntime, *Recovery) {
+	t.Helper()
+	mock := stygos.NewMockRuntime()
+	mock.Contract = wallet
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+
+	r := NewRecovery(stygos.Word{0x5e})
+	if err := r.Initialize([]stygos.Address{alice, bob, carol}, 2, 3_600); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	return mock, r
+}
+
+// as runs fn with sender as msg.sender.
+func as(mock *stygos.MockRuntime, sender stygos.Address, fn func() error) error {
+	mock.Sender = sender
+	defer func() { mock.Sender = stygos.Address{} }()
+	return fn()
+}
+
+func TestInitialize(t *testing.T) {
+	_, r := setup(t)
+	if r.Threshold() != 2 || r.Delay() != 3_600 || len(r.Guardians()) != 3 {
+		t.Errorf("Initialize failed. Got threshold %d, delay %d, %d guardians", r.Threshold(), r.Delay(), len(r.Guardians()))
+	}
+	if err := r.Initialize([]stygos.Address{alice}, 1, 0); err != ErrInitialized {
+		t.Errorf("Initialize failed. Expected ErrInitialized, got %v", err)
+	}
+
+	tests := []struct {
+		guardians []stygos.Address
+		threshold uint64
+		err       error
+	}{
+		{[]stygos.Address{alice}, 0, ErrInvalidThreshold},
+		{[]stygos.Address{alice}, 2, ErrInvalidThreshold},
+		{[]stygos.Address{alice, alice}, 1, ErrInvalidGuardian},
+		{[]stygos.Address{{}}, 1, ErrInvalidGuardian},
+		{[]stygos.Address{wallet}, 1, ErrInvalidGuardian},
+	}
+	for i, tt := range tests {
+		if err := NewRecovery(stygos.Word{0x60, byte(i)}).Initialize(tt.guardians, tt.threshold, 0); err != tt.err {
+			t.Errorf("Initialize %d failed. Expected %v, got %v", i, tt.err, err)
+		}
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	mock, r := setup(t)
+
+	if err := as(mock, newOwner, func() error { return r.Propose(newOwner) }); err != ErrNotGuardian {
+		t.Errorf("Propose failed. Expected ErrNotGuardian, got %v", err)
+	}
+	if err := as(mock, alice, func() error { return r.Propose(newOwner) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if err := as(mock, bob, func() error { return r.Propose(bob) }); err != ErrRecoveryPending {
+		t.Errorf("Propose failed. Expected ErrRecoveryPending, got %v", err)
+	}
+	if err := as(mock, alice, r.Approve); err != ErrAlreadyApproved {
+		t.Errorf("Approve failed. Expected ErrAlreadyApproved, got %v", err)
+	}
+	if _, err := r.Execute(); err != ErrNotReady {
+		t.Errorf("Execute failed. Expected ErrNotReady below the threshold, got %v", err)
+	}
+
+	mock.Time = 2_000
+	if err := as(mock, carol, r.Approve); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	req, ok := r.Pending()
+	if !ok || req.NewOwner != newOwner || req.ReadyAt != 5_600 || r.Approvals() != 2 {
+		t.Errorf("Pending failed. Got %+v, %v with %d approvals", req, ok, r.Approvals())
+	}
+
+	// The delay starts when the threshold is met
+	mock.Time = 5_599
+	if _, err := r.Execute(); err != ErrNotReady {
+		t.Errorf("Execute failed. Expected ErrNotReady during the delay, got %v", err)
+	}
+	mock.Time = 5_600
+	owner, err := r.Execute()
+	if err != nil || owner != newOwner {
+		t.Fatalf("Execute failed. Expected %x, got %x, %v", newOwner, owner, err)
+	}
+	if _, ok := r.Pending(); ok {
+		t.Error("Execute failed. Expected no pending recovery")
+	}
+	if _, err := r.Execute(); err != ErrNoRecovery {
+		t.Errorf("Execute failed. Expected ErrNoRecovery, got %v", err)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	mock, r := setup(t)
+	if err := r.Cancel(); err != ErrNoRecovery {
+		t.Errorf("Cancel failed. Expected ErrNoRecovery, got %v", err)
+	}
+
+	as(mock, alice, func() error { return r.Propose(newOwner) })
+	as(mock, bob, r.Approve)
+	if err := r.Cancel(); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if err := as(mock, carol, r.Approve); err != ErrNoRecovery {
+		t.Errorf("Approve failed. Expected ErrNoRecovery, got %v", err)
+	}
+
+	// Approvals do not carry over to the next round
+	if err := as(mock, carol, func() error { return r.Propose(carol) }); err != nil {
+		t.Fatalf("Propose failed: %v", err)
+	}
+	if r.Round() != 2 || r.HasApproved(alice) || r.Approvals() != 1 {
+		t.Errorf("Propose failed. Expected round 2 with one approval, got %d, %d", r.Round(), r.Approvals())
+	}
+}
+
+func TestGuardianChanges(t *testing.T) {
+	mock, r := setup(t)
+
+	if err := r.RemoveGuardian(newOwner); err != ErrNotGuardian {
+		t.Errorf("RemoveGuardian failed. Expected ErrNotGuardian, got %v", err)
+	}
+	if err := r.RemoveGuardian(carol); err != nil {
+		t.Fatalf("RemoveGuardian failed: %v", err)
+	}
+	if err := r.RemoveGuardian(bob); err != ErrInvalidThreshold {
+		t.Errorf("RemoveGuardian failed. Expected ErrInvalidThreshold, got %v", err)
+	}
+	if err := r.AddGuardian(alice); err != ErrInvalidGuardian {
+		t.Errorf("AddGuardian failed. Expected ErrInvalidGuardian, got %v", err)
+	}
+
+	// Approvals of removed guardians stop counting
+	as(mock, alice, func() error { return r.Propose(newOwner) })
+	as(mock, bob, r.Approve)
+	r.AddGuardian(carol)
+	r.RemoveGuardian(bob)
+	mock.Time += r.Delay()
+	if _, err := r.Execute(); err != ErrNotReady {
+		t.Errorf("Execute failed. Expected ErrNotReady after removing an approver, got %v", err)
+	}
+	as(mock, carol, r.Approve)
+	if _, err := r.Execute(); err != nil {
+		t.Errorf("Execute failed: %v", err)
+	}
+}
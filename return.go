@@ -0,0 +1,170 @@
+package stygos
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+// defaultReturnCapacity is the initial capacity of a ReturnBuilder created
+// without a size hint.
+const defaultReturnCapacity = 256
+
+// ReturnBuilder accumulates ABI-encoded return data in a growable buffer.
+//
+// The buffer doubles when it runs out of room, so appending n words costs
+// O(n) copies overall instead of re-allocating the whole payload on every
+// append. Before each growth the builder calls EnsureMemory so that the wasm
+// memory is extended up front rather than by the allocator mid-copy.
+//
+// Errors are sticky: once an append would exceed MaxCallDataSize the builder
+// stops growing and Finish reports ErrMemoryLimit.
+type ReturnBuilder struct {
+	buf []byte
+	err error
+}
+
+// ArrayMark records where a dynamic array started so its length can be
+// patched in once all elements have been appended.
+type ArrayMark struct {
+	lengthAt int
+}
+
+// NewReturnBuilder creates a builder able to hold sizeHint bytes without
+// growing. A sizeHint of 0 selects a small default.
+func NewReturnBuilder(sizeHint int) *ReturnBuilder {
+	if sizeHint <= 0 {
+		sizeHint = defaultReturnCapacity
+	}
+	b := &ReturnBuilder{}
+	if sizeHint > MaxCallDataSize {
+		b.err = ErrMemoryLimit
+		return b
+	}
+	if err := EnsureMemory(uint32(sizeHint)); err != nil {
+		b.err = err
+		return b
+	}
+	b.buf = make([]byte, 0, sizeHint)
+	return b
+}
+
+// reserve extends the buffer by n bytes and returns the new region, or nil
+// if the builder is in an error state.
+func (b *ReturnBuilder) reserve(n int) []byte {
+	if b.err != nil {
+		return nil
+	}
+	size := len(b.buf) + n
+	if size > MaxCallDataSize {
+		b.err = ErrMemoryLimit
+		return nil
+	}
+	if size > cap(b.buf) {
+		newCap := 2 * cap(b.buf)
+		if newCap < size {
+			newCap = size
+		}
+		if newCap > MaxCallDataSize {
+			newCap = MaxCallDataSize
+		}
+		if err := EnsureMemory(uint32(newCap)); err != nil {
+			b.err = err
+			return nil
+		}
+		grown := make([]byte, len(b.buf), newCap)
+		copy(grown, b.buf)
+		b.buf = grown
+	}
+	b.buf = b.buf[:size]
+	return b.buf[size-n:]
+}
+
+// AppendWord appends a raw 32-byte word.
+func (b *ReturnBuilder) AppendWord(w Word) *ReturnBuilder {
+	if dst := b.reserve(32); dst != nil {
+		copy(dst, w[:])
+	}
+	return b
+}
+
+// AppendUint64 appends v as a uint256 word.
+func (b *ReturnBuilder) AppendUint64(v uint64) *ReturnBuilder {
+	if dst := b.reserve(32); dst != nil {
+		for i := 0; i < 24; i++ {
+			dst[i] = 0
+		}
+		binary.BigEndian.PutUint64(dst[24:], v)
+	}
+	return b
+}
+
+// AppendBigInt appends v as a uint256 word. Values wider than 256 bits are
+// truncated like WordFromBigInt.
+func (b *ReturnBuilder) AppendBigInt(v *big.Int) *ReturnBuilder {
+	return b.AppendWord(WordFromBigInt(v))
+}
+
+// AppendAddress appends addr left-padded to a word.
+func (b *ReturnBuilder) AppendAddress(addr Address) *ReturnBuilder {
+	return b.AppendWord(PadAddress(addr))
+}
+
+// AppendBool appends v as a word holding 0 or 1.
+func (b *ReturnBuilder) AppendBool(v bool) *ReturnBuilder {
+	if v {
+		return b.AppendUint64(1)
+	}
+	return b.AppendUint64(0)
+}
+
+// AppendRaw appends data verbatim, without padding.
+func (b *ReturnBuilder) AppendRaw(data []byte) *ReturnBuilder {
+	if dst := b.reserve(len(data)); dst != nil {
+		copy(dst, data)
+	}
+	return b
+}
+
+// BeginArray starts a dynamic array (T[]) whose tail immediately follows the
+// current position. This matches the ABI layout when the array is the last
+// return value and no other dynamic value follows it, e.g. for
+// `returns (uint256 count, Proposal[] proposals)`. Elements are appended with
+// the regular Append methods and the array is closed with EndArray.
+func (b *ReturnBuilder) BeginArray() ArrayMark {
+	b.AppendUint64(uint64(len(b.buf) + 32))
+	mark := ArrayMark{lengthAt: len(b.buf)}
+	b.AppendUint64(0)
+	return mark
+}
+
+// EndArray patches the element count of the array started at mark.
+func (b *ReturnBuilder) EndArray(mark ArrayMark, count uint64) *ReturnBuilder {
+	if b.err != nil || mark.lengthAt+32 > len(b.buf) {
+		return b
+	}
+	binary.BigEndian.PutUint64(b.buf[mark.lengthAt+24:mark.lengthAt+32], count)
+	return b
+}
+
+// Len returns the number of bytes appended so far.
+func (b *ReturnBuilder) Len() int {
+	return len(b.buf)
+}
+
+// Bytes returns the encoded data. The slice aliases the builder's buffer.
+func (b *ReturnBuilder) Bytes() []byte {
+	return b.buf
+}
+
+// Err returns the first error encountered while appending.
+func (b *ReturnBuilder) Err() error {
+	return b.err
+}
+
+// Finish writes the accumulated data as the call's return data.
+func (b *ReturnBuilder) Finish() error {
+	if b.err != nil {
+		return b.err
+	}
+	return SetReturnData(b.buf)
+}
@@ -0,0 +1,90 @@
+package stygos
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestReturnBuilder(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	var owner Address
+	owner[19] = 0xaa
+
+	// Start tiny to force several growths
+	b := NewReturnBuilder(8)
+	b.AppendUint64(7).AppendAddress(owner).AppendBool(true).AppendBigInt(big.NewInt(1000))
+	if err := b.Finish(); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+
+	if len(mock.Result) != 4*32 {
+		t.Fatalf("result length = %d, want %d", len(mock.Result), 4*32)
+	}
+	if Uint64FromWord(wordAt(mock.Result, 0)) != 7 {
+		t.Errorf("word 0 = %x, want 7", mock.Result[:32])
+	}
+	if AddressFromWord(wordAt(mock.Result, 1)) != owner {
+		t.Errorf("word 1 = %x, want owner", mock.Result[32:64])
+	}
+	if Uint64FromWord(wordAt(mock.Result, 2)) != 1 {
+		t.Errorf("word 2 = %x, want 1", mock.Result[64:96])
+	}
+	if BigIntFromWord(wordAt(mock.Result, 3)).Int64() != 1000 {
+		t.Errorf("word 3 = %x, want 1000", mock.Result[96:128])
+	}
+}
+
+func TestReturnBuilderArray(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	// returns (uint256 total, uint256[] items)
+	b := NewReturnBuilder(0)
+	b.AppendUint64(3)
+	mark := b.BeginArray()
+	for i := uint64(1); i <= 3; i++ {
+		b.AppendUint64(i * 10)
+	}
+	b.EndArray(mark, 3)
+	if err := b.Finish(); err != nil {
+		t.Fatalf("Finish failed: %v", err)
+	}
+
+	want := []uint64{3, 64, 3, 10, 20, 30}
+	if len(mock.Result) != len(want)*32 {
+		t.Fatalf("result length = %d, want %d", len(mock.Result), len(want)*32)
+	}
+	for i, v := range want {
+		if got := Uint64FromWord(wordAt(mock.Result, i)); got != v {
+			t.Errorf("word %d = %d, want %d", i, got, v)
+		}
+	}
+}
+
+func TestReturnBuilderLimit(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	b := NewReturnBuilder(0)
+	b.AppendRaw(make([]byte, MaxCallDataSize))
+	if err := b.Err(); err != nil {
+		t.Fatalf("filling to the limit failed: %v", err)
+	}
+	b.AppendRaw([]byte{1})
+	if err := b.Finish(); err != ErrMemoryLimit {
+		t.Errorf("Finish past the limit = %v, want ErrMemoryLimit", err)
+	}
+	if !bytes.Equal(mock.Result, nil) {
+		t.Errorf("return data written despite error")
+	}
+}
+
+// wordAt returns the i-th 32-byte word of data.
+func wordAt(data []byte, i int) Word {
+	var w Word
+	copy(w[:], data[i*32:])
+	return w
+}
@@ -0,0 +1,228 @@
+// Package rlp implements the Recursive Length Prefix encoding used by
+// Ethereum for transactions, trie nodes and accounts.
+//
+// The decoder works on raw byte slices without reflection and returns
+// sub-slices of its input, so it does not allocate; this keeps it cheap
+// under TinyGo when verifying proofs on chain.
+package rlp
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Kind is the type of an RLP value.
+type Kind uint8
+
+const (
+	// Byte is a single byte below 0x80, encoded as itself.
+	Byte Kind = iota
+	// String is a byte string.
+	String
+	// List is a list of values.
+	List
+)
+
+// Decoding errors
+var (
+	ErrTruncated     = errors.New("rlp: value exceeds input")
+	ErrNonCanonical  = errors.New("rlp: non-canonical encoding")
+	ErrExpectedList  = errors.New("rlp: expected list")
+	ErrExpectedBytes = errors.New("rlp: expected string")
+	ErrTrailingData  = errors.New("rlp: trailing data")
+	ErrUintOverflow  = errors.New("rlp: integer too large")
+)
+
+// Split returns the kind and content of the first value in b and the bytes
+// following it.
+func Split(b []byte) (k Kind, content, rest []byte, err error) {
+	if len(b) == 0 {
+		return 0, nil, nil, ErrTruncated
+	}
+
+	prefix := b[0]
+	var offset, size uint64
+	switch {
+	case prefix < 0x80:
+		return Byte, b[:1], b[1:], nil
+	case prefix < 0xb8:
+		k, offset, size = String, 1, uint64(prefix-0x80)
+		if size == 1 && len(b) > 1 && b[1] < 0x80 {
+			return 0, nil, nil, ErrNonCanonical
+		}
+	case prefix < 0xc0:
+		k = String
+		offset, size, err = readLongSize(b, prefix-0xb7)
+	case prefix < 0xf8:
+		k, offset, size = List, 1, uint64(prefix-0xc0)
+	default:
+		k = List
+		offset, size, err = readLongSize(b, prefix-0xf7)
+	}
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if size > uint64(len(b))-offset {
+		return 0, nil, nil, ErrTruncated
+	}
+	return k, b[offset : offset+size], b[offset+size:], nil
+}
+
+// readLongSize decodes the big-endian length of a long string or list
+// stored in the n bytes after the prefix.
+func readLongSize(b []byte, n byte) (offset, size uint64, err error) {
+	if uint64(len(b)) < 1+uint64(n) {
+		return 0, 0, ErrTruncated
+	}
+	if b[1] == 0 {
+		return 0, 0, ErrNonCanonical
+	}
+	var buf [8]byte
+	copy(buf[8-n:], b[1:1+n])
+	size = binary.BigEndian.Uint64(buf[:])
+	if size < 56 {
+		return 0, 0, ErrNonCanonical
+	}
+	return 1 + uint64(n), size, nil
+}
+
+// SplitString splits the first value of b, which must be a string or byte.
+func SplitString(b []byte) (content, rest []byte, err error) {
+	k, content, rest, err := Split(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if k == List {
+		return nil, nil, ErrExpectedBytes
+	}
+	return content, rest, nil
+}
+
+// SplitList splits the first value of b, which must be a list, and returns
+// its encoded elements.
+func SplitList(b []byte) (content, rest []byte, err error) {
+	k, content, rest, err := Split(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if k != List {
+		return nil, nil, ErrExpectedList
+	}
+	return content, rest, nil
+}
+
+// ListElems decodes b as a single list and returns the raw encoding of each
+// element, so that nested values can be decoded or hashed as they are.
+func ListElems(b []byte) ([][]byte, error) {
+	content, rest, err := SplitList(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, ErrTrailingData
+	}
+
+	var elems [][]byte
+	for len(content) > 0 {
+		_, _, next, err := Split(content)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, content[:len(content)-len(next)])
+		content = next
+	}
+	return elems, nil
+}
+
+// Bytes decodes b as a single string and returns its content.
+func Bytes(b []byte) ([]byte, error) {
+	content, rest, err := SplitString(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, ErrTrailingData
+	}
+	return content, nil
+}
+
+// Uint64 decodes b as a single canonical unsigned integer.
+func Uint64(b []byte) (uint64, error) {
+	content, err := Bytes(b)
+	if err != nil {
+		return 0, err
+	}
+	if len(content) > 8 {
+		return 0, ErrUintOverflow
+	}
+	if len(content) > 0 && content[0] == 0 {
+		return 0, ErrNonCanonical
+	}
+	var buf [8]byte
+	copy(buf[8-len(content):], content)
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// --- Encoding ---
+
+// AppendString appends the encoding of the byte string s to dst.
+func AppendString(dst, s []byte) []byte {
+	if len(s) == 1 && s[0] < 0x80 {
+		return append(dst, s[0])
+	}
+	dst = appendHeader(dst, 0x80, uint64(len(s)))
+	return append(dst, s...)
+}
+
+// AppendUint appends the encoding of v as a minimal big-endian string.
+func AppendUint(dst []byte, v uint64) []byte {
+	if v == 0 {
+		return append(dst, 0x80)
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	i := 0
+	for buf[i] == 0 {
+		i++
+	}
+	return AppendString(dst, buf[i:])
+}
+
+// AppendList appends a list whose elements are already encoded.
+func AppendList(dst []byte, elems ...[]byte) []byte {
+	size := 0
+	for _, e := range elems {
+		size += len(e)
+	}
+	dst = appendHeader(dst, 0xc0, uint64(size))
+	for _, e := range elems {
+		dst = append(dst, e...)
+	}
+	return dst
+}
+
+// EncodeString returns the encoding of s.
+func EncodeString(s []byte) []byte {
+	return AppendString(nil, s)
+}
+
+// EncodeList returns the encoding of a list of already encoded elements.
+func EncodeList(elems ...[]byte) []byte {
+	return AppendList(nil, elems...)
+}
+
+// appendHeader appends the prefix for a string (base 0x80) or list (base
+// 0xc0) whose content is size bytes long.
+func appendHeader(dst []byte, base byte, size uint64) []byte {
+	if size < 56 {
+		return append(dst, base+byte(size))
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], size)
+	i := 0
+	for buf[i] == 0 {
+		i++
+	}
+	dst = append(dst, base+55+byte(8-i))
+	return append(dst, buf[i:]...)
+}
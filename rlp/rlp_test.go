@@ -0,0 +1,96 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	lorem := []byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit")
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"dog", EncodeString([]byte("dog")), "83646f67"},
+		{"empty string", EncodeString(nil), "80"},
+		{"single byte", EncodeString([]byte{0x0f}), "0f"},
+		{"byte 0x80", EncodeString([]byte{0x80}), "8180"},
+		{"zero", AppendUint(nil, 0), "80"},
+		{"15", AppendUint(nil, 15), "0f"},
+		{"1024", AppendUint(nil, 1024), "820400"},
+		{"empty list", EncodeList(), "c0"},
+		{"cat dog", EncodeList(EncodeString([]byte("cat")), EncodeString([]byte("dog"))), "c88363617483646f67"},
+		{"long string", EncodeString(lorem), "b838" + hex.EncodeToString(lorem)},
+	}
+
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.got); got != tt.want {
+			t.Errorf("%s failed. Expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	long := bytes.Repeat([]byte{0xab}, 300)
+	enc := EncodeList(
+		EncodeString([]byte("cat")),
+		AppendUint(nil, 1024),
+		EncodeString(long),
+		EncodeList(EncodeString(nil)),
+	)
+
+	elems, err := ListElems(enc)
+	if err != nil {
+		t.Fatalf("ListElems failed: %v", err)
+	}
+	if len(elems) != 4 {
+		t.Fatalf("ListElems failed. Expected 4 elements, got %d", len(elems))
+	}
+	if s, _ := Bytes(elems[0]); string(s) != "cat" {
+		t.Errorf("Bytes failed. Expected cat, got %q", s)
+	}
+	if v, _ := Uint64(elems[1]); v != 1024 {
+		t.Errorf("Uint64 failed. Expected 1024, got %d", v)
+	}
+	if s, _ := Bytes(elems[2]); !bytes.Equal(s, long) {
+		t.Errorf("Bytes failed for long string")
+	}
+	inner, err := ListElems(elems[3])
+	if err != nil || len(inner) != 1 {
+		t.Errorf("ListElems failed for nested list: %v", err)
+	}
+	if _, err := Bytes(elems[3]); err != ErrExpectedBytes {
+		t.Errorf("Bytes failed. Expected ErrExpectedBytes for a list, got %v", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"empty", "", ErrTruncated},
+		{"short string", "83646f", ErrTruncated},
+		{"single byte as string", "8105", ErrNonCanonical},
+		{"long size leading zero", "b90001", ErrNonCanonical},
+		{"long form for short", "b801ff", ErrNonCanonical},
+		{"trailing", "83646f67ff", ErrTrailingData},
+	}
+
+	for _, tt := range tests {
+		in, _ := hex.DecodeString(tt.in)
+		if _, err := Bytes(in); err != tt.err {
+			t.Errorf("%s failed. Expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+
+	if _, err := Uint64([]byte{0x82, 0x00, 0x01}); err != ErrNonCanonical {
+		t.Errorf("Uint64 failed. Expected ErrNonCanonical for leading zero, got %v", err)
+	}
+	if _, err := ListElems([]byte{0x80}); err != ErrExpectedList {
+		t.Errorf("ListElems failed. Expected ErrExpectedList, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package stygos
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Selector is the 4-byte function selector prefixed to ABI calldata.
+type Selector [4]byte
+
+// Handler processes the arguments following the selector and returns the
+// data to hand back to the caller. ctx describes the call.
+type Handler func(ctx *Ctx, args []byte) ([]byte, error)
+
+// Router errors
+var (
+	ErrUnknownSelector   = errors.New("unknown selector")
+	ErrDuplicateSelector = errors.New("duplicate selector")
+	ErrShortCallData     = errors.New("calldata shorter than a selector")
+)
+
+// SelectorOf returns the selector of a canonical function signature such as
+// "transfer(address,uint256)".
+func SelectorOf(signature string) Selector {
+	var sel Selector
+	hash := Keccak256([]byte(signature))
+	copy(sel[:], hash[:4])
+	return sel
+}
+
+// Uint32 returns the selector as a big-endian integer.
+func (s Selector) Uint32() uint32 {
+	return binary.BigEndian.Uint32(s[:])
+}
+
+// --- Jump table ---
+
+// jumpTableMul is the odd multiplier used by the jump table hash.
+const jumpTableMul = 0x9E3779B1
+
+// maxJumpTableSeeds bounds the search for a collision-free seed.
+const maxJumpTableSeeds = 1 << 16
+
+// JumpTable is a perfect hash from a fixed set of selectors to handler
+// indexes. A lookup is one multiply, one shift and one comparison regardless
+// of the number of methods, unlike the linear cost of a switch statement.
+//
+// Tables are normally emitted as literals by `stygos-gen dispatch` so that no
+// seed search happens on chain; BuildJumpTable is used by the generator and by
+// routers assembled at runtime.
+type JumpTable struct {
+	Seed      uint32     // xor-ed into the selector before hashing
+	Shift     uint8      // 32 - log2(len(Slots))
+	Slots     []uint8    // hash bucket -> handler index + 1, 0 when empty
+	Selectors []Selector // handler index -> selector, used to reject misses
+}
+
+// BuildJumpTable searches for a seed that maps every selector to its own
+// bucket. The table has at least twice as many buckets as selectors, so it
+// holds at most 255 selectors (the bucket entries are single bytes).
+func BuildJumpTable(selectors []Selector) (*JumpTable, error) {
+	if len(selectors) > 255 {
+		return nil, ErrInvalidInput
+	}
+	seen := make(map[Selector]bool, len(selectors))
+	for _, sel := range selectors {
+		if seen[sel] {
+			return nil, ErrDuplicateSelector
+		}
+		seen[sel] = true
+	}
+
+	bits := uint8(1)
+	for 1<<bits < 2*len(selectors) {
+		bits++
+	}
+
+	for ; bits <= 16; bits++ {
+		slots := make([]uint8, 1<<bits)
+		shift := 32 - bits
+		for seed := uint32(0); seed < maxJumpTableSeeds; seed++ {
+			if fillJumpTable(slots, selectors, seed, shift) {
+				return &JumpTable{
+					Seed:      seed,
+					Shift:     shift,
+					Slots:     slots,
+					Selectors: append([]Selector(nil), selectors...),
+				}, nil
+			}
+		}
+	}
+	return nil, errors.New("no perfect hash found for selectors")
+}
+
+// fillJumpTable tries to place every selector with the given seed, leaving
+// slots populated on success.
+func fillJumpTable(slots []uint8, selectors []Selector, seed uint32, shift uint8) bool {
+	for i := range slots {
+		slots[i] = 0
+	}
+	for i, sel := range selectors {
+		bucket := jumpTableHash(sel, seed, shift)
+		if slots[bucket] != 0 {
+			return false
+		}
+		slots[bucket] = uint8(i + 1)
+	}
+	return true
+}
+
+func jumpTableHash(sel Selector, seed uint32, shift uint8) uint32 {
+	return ((sel.Uint32() ^ seed) * jumpTableMul) >> shift
+}
+
+// Lookup returns the handler index registered for sel, or -1.
+func (t *JumpTable) Lookup(sel Selector) int {
+	if len(t.Slots) == 0 {
+		return -1
+	}
+	idx := int(t.Slots[jumpTableHash(sel, t.Seed, t.Shift)]) - 1
+	if idx < 0 || t.Selectors[idx] != sel {
+		return -1
+	}
+	return idx
+}
+
+// --- Router ---
+
+// Router dispatches ABI calldata to handlers by selector.
+type Router struct {
+	table     *JumpTable
+	selectors []Selector
+	handlers  []Handler
+	fallback  Handler
+	observe   func(callData []byte, err error)
+
+	middleware []Middleware // see Use
+	chain      Handler      // dispatch wrapped in middleware, built lazily
+
+	layoutHash Word // reported by stygosInfo(), see SetLayoutHash
+}
+
+// NewRouter creates an empty router. Handlers are registered with Handle and
+// the jump table is built on the first dispatch.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// NewTableRouter creates a router from a precomputed jump table, as emitted by
+// `stygos-gen dispatch`. handlers must be indexed like table.Selectors.
+func NewTableRouter(table *JumpTable, handlers []Handler) *Router {
+	return &Router{
+		table:     table,
+		selectors: table.Selectors,
+		handlers:  handlers,
+	}
+}
+
+// Handle registers h for the function with the given canonical signature.
+func (r *Router) Handle(signature string, h Handler) {
+	r.HandleSelector(SelectorOf(signature), h)
+}
+
+// HandleSelector registers h for a raw selector.
+func (r *Router) HandleSelector(sel Selector, h Handler) {
+	r.selectors = append(r.selectors, sel)
+	r.handlers = append(r.handlers, h)
+	r.table = nil
+}
+
+// Fallback registers a handler invoked with the full calldata when no
+// selector matches or the calldata is shorter than a selector.
+func (r *Router) Fallback(h Handler) {
+	r.fallback = h
+}
+
+// Observe registers fn to be called after every dispatch with the calldata
+// and the error returned, for tests and tracing such as Coverage.
+func (r *Router) Observe(fn func(callData []byte, err error)) {
+	r.observe = fn
+}
+
+// Dispatch routes calldata through the middleware to the matching handler,
+// with the context of the current call read from the host.
+func (r *Router) Dispatch(callData []byte) ([]byte, error) {
+	return r.DispatchCtx(NewCtx(), callData)
+}
+
+// DispatchCtx routes calldata like Dispatch with the given context, whose
+// Selector it sets. Tests use it to call handlers as any sender, with any
+// value, without setting up the host.
+func (r *Router) DispatchCtx(ctx *Ctx, callData []byte) ([]byte, error) {
+	ctx.Selector = Selector{}
+	if len(callData) >= 4 {
+		copy(ctx.Selector[:], callData)
+	}
+	if r.observe == nil {
+		return r.handler()(ctx, callData)
+	}
+	result, err := r.handler()(ctx, callData)
+	r.observe(callData, err)
+	return result, err
+}
+
+func (r *Router) dispatch(ctx *Ctx, callData []byte) ([]byte, error) {
+	if len(callData) < 4 {
+		if r.fallback != nil {
+			return r.fallback(ctx, callData)
+		}
+		return nil, ErrShortCallData
+	}
+
+	if r.table == nil {
+		table, err := BuildJumpTable(r.selectors)
+		if err != nil {
+			return nil, err
+		}
+		r.table = table
+	}
+
+	idx := r.table.Lookup(ctx.Selector)
+	if idx < 0 {
+		if ctx.Selector == InfoSelector {
+			return r.info(), nil
+		}
+		if r.fallback != nil {
+			return r.fallback(ctx, callData)
+		}
+		return nil, ErrUnknownSelector
+	}
+	return r.handlers[idx](ctx, callData[4:])
+}
+
+// Entrypoint reads the calldata, dispatches it and writes the result. It
+// returns the status code expected from a Stylus entrypoint: 0 on success
+// and 1 on failure. It writes the data of a RevertError as the revert data,
+// and passes on the revert data of a failed call a handler returns.
+func (r *Router) Entrypoint() int32 {
+	callData, err := GetCallData()
+	if err != nil {
+		return 1
+	}
+	result, err := r.Dispatch(callData)
+	if err != nil {
+		if data := revertData(err); data != nil {
+			SetReturnData(data)
+		}
+		return 1
+	}
+	if err := SetReturnData(result); err != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,220 @@
+package stygos
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestSelectorOf(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	// Well-known ERC20 selectors
+	tests := map[string]Selector{
+		"transfer(address,uint256)":             {0xa9, 0x05, 0x9c, 0xbb},
+		"balanceOf(address)":                    {0x70, 0xa0, 0x82, 0x31},
+		"transferFrom(address,address,uint256)": {0x23, 0xb8, 0x72, 0xdd},
+	}
+	for signature, want := range tests {
+		if got := SelectorOf(signature); got != want {
+			t.Errorf("SelectorOf(%q) = %x, want %x", signature, got, want)
+		}
+	}
+}
+
+func TestJumpTable(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	selectors := benchSelectors(40)
+	table, err := BuildJumpTable(selectors)
+	if err != nil {
+		t.Fatalf("BuildJumpTable failed: %v", err)
+	}
+	if len(table.Slots) < 2*len(selectors) {
+		t.Errorf("table has %d slots for %d selectors", len(table.Slots), len(selectors))
+	}
+
+	for i, sel := range selectors {
+		if got := table.Lookup(sel); got != i {
+			t.Errorf("Lookup(%x) = %d, want %d", sel, got, i)
+		}
+	}
+	if got := table.Lookup(SelectorOf("missing()")); got != -1 {
+		t.Errorf("Lookup(missing) = %d, want -1", got)
+	}
+
+	if _, err := BuildJumpTable([]Selector{{1, 2, 3, 4}, {1, 2, 3, 4}}); err != ErrDuplicateSelector {
+		t.Errorf("BuildJumpTable with duplicates: got %v, want ErrDuplicateSelector", err)
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	router := NewRouter()
+	router.Handle("echo(bytes)", func(ctx *Ctx, args []byte) ([]byte, error) {
+		return args, nil
+	})
+	router.Handle("fail()", func(ctx *Ctx, args []byte) ([]byte, error) {
+		return nil, ErrInvalidInput
+	})
+
+	echo := SelectorOf("echo(bytes)")
+	mock.Args = append(echo[:], 1, 2, 3)
+	if status := router.Entrypoint(); status != 0 {
+		t.Fatalf("Entrypoint() = %d, want 0", status)
+	}
+	if !bytes.Equal(mock.Result, []byte{1, 2, 3}) {
+		t.Errorf("echo result = %v, want [1 2 3]", mock.Result)
+	}
+
+	fail := SelectorOf("fail()")
+	mock.Args = fail[:]
+	if status := router.Entrypoint(); status != 1 {
+		t.Errorf("failing handler: Entrypoint() = %d, want 1", status)
+	}
+
+	if _, err := router.Dispatch([]byte{0xde, 0xad, 0xbe, 0xef}); err != ErrUnknownSelector {
+		t.Errorf("unknown selector: got %v, want ErrUnknownSelector", err)
+	}
+	if _, err := router.Dispatch([]byte{1}); err != ErrShortCallData {
+		t.Errorf("short calldata: got %v, want ErrShortCallData", err)
+	}
+
+	router.Fallback(func(ctx *Ctx, args []byte) ([]byte, error) {
+		return []byte("fallback"), nil
+	})
+	if result, err := router.Dispatch([]byte{0xde, 0xad, 0xbe, 0xef}); err != nil || string(result) != "fallback" {
+		t.Errorf("fallback: got %q, %v", result, err)
+	}
+}
+
+func TestStygosInfo(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	if InfoSelector != SelectorOf("stygosInfo()") {
+		t.Fatalf("InfoSelector = %x, want %x", InfoSelector, SelectorOf("stygosInfo()"))
+	}
+
+	router := NewRouter()
+	router.Handle("b()", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	router.Handle("a()", func(ctx *Ctx, args []byte) ([]byte, error) { return nil, nil })
+	layout := Keccak256([]byte("layout"))
+	router.SetLayoutHash(layout)
+
+	out, err := router.Dispatch(InfoSelector[:])
+	if err != nil || len(out) != 5*32 {
+		t.Fatalf("stygosInfo() = %x, %v, want 5 words", out, err)
+	}
+	a, b := SelectorOf("a()"), SelectorOf("b()")
+	first, second := a, b
+	if b.Uint32() < a.Uint32() {
+		first, second = b, a
+	}
+	abiHash := Keccak256(append(first[:], second[:]...))
+	if router.ABIHash() != abiHash || !bytes.Equal(out[32:64], abiHash[:]) {
+		t.Errorf("abiHash = %x, want %x", out[32:64], abiHash)
+	}
+	if !bytes.Equal(out[64:96], layout[:]) {
+		t.Errorf("layoutHash = %x, want %x", out[64:96], layout)
+	}
+	if out[31] != 96 || out[127] != byte(len(SDKVersion)) {
+		t.Errorf("sdkVersion head or length is wrong: %x", out)
+	}
+	if got := string(out[128 : 128+len(SDKVersion)]); got != SDKVersion {
+		t.Errorf("sdkVersion = %q, want %q", got, SDKVersion)
+	}
+
+	// A contract may answer stygosInfo() itself
+	router.HandleSelector(InfoSelector, func(ctx *Ctx, args []byte) ([]byte, error) { return []byte("own"), nil })
+	if out, _ := router.Dispatch(InfoSelector[:]); string(out) != "own" {
+		t.Errorf("registered stygosInfo() handler was not called, got %q", out)
+	}
+	if router.ABIHash() != abiHash {
+		t.Errorf("ABIHash changed with a stygosInfo() handler")
+	}
+}
+
+func TestTableRouter(t *testing.T) {
+	mock := NewMockRuntime()
+	UseRuntime(mock)
+
+	selectors := benchSelectors(3)
+	table, err := BuildJumpTable(selectors)
+	if err != nil {
+		t.Fatalf("BuildJumpTable failed: %v", err)
+	}
+
+	handlers := make([]Handler, len(selectors))
+	for i := range handlers {
+		i := i
+		handlers[i] = func(ctx *Ctx, args []byte) ([]byte, error) {
+			return []byte{byte(i)}, nil
+		}
+	}
+	router := NewTableRouter(table, handlers)
+
+	for i, sel := range selectors {
+		result, err := router.Dispatch(sel[:])
+		if err != nil || len(result) != 1 || result[0] != byte(i) {
+			t.Errorf("Dispatch(%x) = %v, %v, want [%d]", sel, result, err, i)
+		}
+	}
+}
+
+// benchSelectors returns n distinct selectors of synthetic methods.
+func benchSelectors(n int) []Selector {
+	selectors := make([]Selector, n)
+	for i := range selectors {
+		selectors[i] = SelectorOf(fmt.Sprintf("method%d(uint256)", i))
+	}
+	return selectors
+}
+
+// linearLookup mirrors the compiled form of a switch over selectors, which is
+// what contracts pay without a jump table.
+func linearLookup(selectors []Selector, sel Selector) int {
+	for i := range selectors {
+		if selectors[i] == sel {
+			return i
+		}
+	}
+	return -1
+}
+
+// BenchmarkDispatch compares jump table lookups against a linear scan for a
+// contract with 32 methods, looking up the last registered method (the worst
+// case for a switch). The mock does not meter ink, but lookup cost in Go is a
+// good proxy for the number of wasm instructions executed.
+func BenchmarkDispatch(b *testing.B) {
+	UseRuntime(NewMockRuntime())
+	selectors := benchSelectors(32)
+	table, err := BuildJumpTable(selectors)
+	if err != nil {
+		b.Fatalf("BuildJumpTable failed: %v", err)
+	}
+	target := selectors[len(selectors)-1]
+
+	b.Run("JumpTable", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if table.Lookup(target) < 0 {
+				b.Fatal("lookup failed")
+			}
+		}
+		// Code size is dominated by the table data: one byte per bucket plus
+		// four bytes per selector.
+		b.ReportMetric(float64(len(table.Slots)+4*len(table.Selectors)), "table-bytes")
+	})
+
+	b.Run("Linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if linearLookup(selectors, target) < 0 {
+				b.Fatal("lookup failed")
+			}
+		}
+	})
+}
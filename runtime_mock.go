@@ -14,5 +14,29 @@ func init() {
 	EmitLog = mock_emit_log
 	NativeKeccak256 = mock_native_keccak256
 	MemoryGrow = mock_memory_grow
+	MsgSender = mock_msg_sender
+	ContractAddress = mock_contract_address
+	CallContract = mock_call_contract
+	StaticCallContract = mock_static_call_contract
+	ReadReturnData = mock_read_return_data
+	BlockTimestamp = mock_block_timestamp
+	AccountBalance = mock_account_balance
+	AccountCodeSize = mock_account_code_size
+	ChainID = mock_chainid
+	TransientLoadBytes32 = mock_transient_load_bytes32
+	TransientStoreBytes32 = mock_transient_store_bytes32
+	BlobHash = mock_blob_hash
+	BlobBaseFee = mock_blob_base_fee
+	ArgsLen = mock_args_len
+}
+
+// hostArbOS returns the ArbOS version of the active runtime.
+func hostArbOS() uint64 {
+	if activeRuntime == nil {
+		return buildArbOS
+	}
+	activeRuntime.mu.Lock()
+	defer activeRuntime.mu.Unlock()
+	return activeRuntime.ArbOS
 }
 
@@ -0,0 +1,41 @@
+//go:build tinygo
+
+package stygos
+
+// This file wires the host functions to the Stylus hostios when building with tinygo.
+
+func init() {
+	ReadArgs = read_args
+	WriteResult = write_result
+	StorageLoadBytes32 = storage_load_bytes32
+	StorageStoreBytes32 = storage_store_bytes32
+	MsgValue = msg_value
+	BlockNumber = block_number
+	EmitLog = emit_log
+	NativeKeccak256 = native_keccak256
+	MemoryGrow = memory_grow
+	MsgSender = msg_sender
+	ContractAddress = contract_address
+	CallContract = call_contract
+	StaticCallContract = static_call_contract
+	ReadReturnData = read_return_data
+	BlockTimestamp = block_timestamp
+	AccountBalance = account_balance
+	AccountCodeSize = account_code_size
+	ChainID = chainid
+	ArgsLen = entrypointLen
+	bindVersioned()
+}
+
+// exportedEntrypoint is the function Stylus calls for each call, with the
+// calldata length; see SetEntrypoint.
+//
+//export user_entrypoint
+func exportedEntrypoint(argsLen uint32) int32 {
+	return userEntrypoint(argsLen)
+}
+
+// hostArbOS returns the ArbOS version the build targets.
+func hostArbOS() uint64 {
+	return buildArbOS
+}
@@ -0,0 +1,110 @@
+package stygos
+
+import "math/bits"
+
+// Checked arithmetic. Each function returns ok == false instead of wrapping
+// when the exact result does not fit, so balance updates can fail the call
+// rather than silently overflow.
+
+// SafeAddU64 returns a + b, or ok == false if the sum overflows.
+func SafeAddU64(a, b uint64) (uint64, bool) {
+	sum, carry := bits.Add64(a, b, 0)
+	return sum, carry == 0
+}
+
+// SafeSubU64 returns a - b, or ok == false if b > a.
+func SafeSubU64(a, b uint64) (uint64, bool) {
+	diff, borrow := bits.Sub64(a, b, 0)
+	return diff, borrow == 0
+}
+
+// SafeMulU64 returns a * b, or ok == false if the product overflows.
+func SafeMulU64(a, b uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	return lo, hi == 0
+}
+
+// CheckedAdd returns z + x, or ok == false if the sum exceeds 2^256 - 1.
+func (z U256) CheckedAdd(x U256) (U256, bool) {
+	sum := z.Add(x)
+	return sum, !sum.Lt(z)
+}
+
+// CheckedSub returns z - x, or ok == false if x > z.
+func (z U256) CheckedSub(x U256) (U256, bool) {
+	return z.Sub(x), !z.Lt(x)
+}
+
+// CheckedMul returns z * x, or ok == false if the product exceeds
+// 2^256 - 1.
+func (z U256) CheckedMul(x U256) (U256, bool) {
+	hi, lo := mul512(z, x)
+	return lo, hi.IsZero()
+}
+
+// MulDiv returns floor(x * y / d) computed with a 512-bit intermediate
+// product, so x * y may exceed 256 bits as long as the quotient does not.
+// ok is false if d is zero or the quotient overflows.
+func MulDiv(x, y, d U256) (U256, bool) {
+	q, _, ok := mulDivMod(x, y, d)
+	return q, ok
+}
+
+// MulDivUp is MulDiv rounding up: ceil(x * y / d).
+func MulDivUp(x, y, d U256) (U256, bool) {
+	q, r, ok := mulDivMod(x, y, d)
+	if !ok || r.IsZero() {
+		return q, ok
+	}
+	return q.CheckedAdd(NewU256(1))
+}
+
+// mulDivMod returns the quotient and remainder of x * y / d.
+func mulDivMod(x, y, d U256) (U256, U256, bool) {
+	if d.IsZero() {
+		return U256{}, U256{}, false
+	}
+	hi, lo := mul512(x, y)
+	if hi.IsZero() {
+		q, r := lo.divMod(d)
+		return q, r, true
+	}
+	if !hi.Lt(d) {
+		return U256{}, U256{}, false
+	}
+
+	// Binary long division of hi:lo by d. Starting from r = hi, which is
+	// what the high half leaves as it is below d, each low bit shifts in;
+	// r may briefly need 257 bits, tracked in top.
+	q, r := U256{}, hi
+	for i := 255; i >= 0; i-- {
+		top := r[3] >> 63
+		r = r.Lsh(1)
+		r[0] |= (lo[i/64] >> (uint(i) % 64)) & 1
+		if top != 0 || !r.Lt(d) {
+			r = r.Sub(d)
+			q[i/64] |= 1 << (uint(i) % 64)
+		}
+	}
+	return q, r, true
+}
+
+// mul512 returns the full product of z and x as high and low 256-bit
+// halves.
+func mul512(z, x U256) (hi, lo U256) {
+	var r [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			h, l := bits.Mul64(z[i], x[j])
+			l, c := bits.Add64(l, r[i+j], 0)
+			h += c
+			l, c = bits.Add64(l, carry, 0)
+			h += c
+			r[i+j] = l
+			carry = h
+		}
+		r[i+4] = carry
+	}
+	return U256{r[4], r[5], r[6], r[7]}, U256{r[0], r[1], r[2], r[3]}
+}
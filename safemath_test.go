@@ -0,0 +1,101 @@
+package stygos
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestSafeU64(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(a, b uint64) (uint64, bool)
+		a, b uint64
+		want uint64
+		ok   bool
+	}{
+		{"SafeAddU64", SafeAddU64, 1, 2, 3, true},
+		{"SafeAddU64", SafeAddU64, math.MaxUint64, 1, 0, false},
+		{"SafeSubU64", SafeSubU64, 3, 3, 0, true},
+		{"SafeSubU64", SafeSubU64, 0, 1, 0, false},
+		{"SafeMulU64", SafeMulU64, 1 << 32, 1<<32 - 1, 1<<64 - 1<<32, true},
+		{"SafeMulU64", SafeMulU64, 1 << 32, 1 << 32, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.fn(tt.a, tt.b)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s(%d, %d) failed. Expected %d, %v, got %d, %v", tt.name, tt.a, tt.b, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestCheckedU256(t *testing.T) {
+	allOnes := U256{}.Not()
+	one := NewU256(1)
+	if _, ok := allOnes.CheckedAdd(one); ok {
+		t.Error("CheckedAdd failed. Expected an overflow")
+	}
+	if got, ok := allOnes.Sub(one).CheckedAdd(one); !ok || got != allOnes {
+		t.Errorf("CheckedAdd failed. Expected 2^256-1, got %v, %v", got.Big(), ok)
+	}
+	if _, ok := one.CheckedSub(NewU256(2)); ok {
+		t.Error("CheckedSub failed. Expected an underflow")
+	}
+	if got, ok := NewU256(1).Lsh(128).CheckedMul(NewU256(1).Lsh(127)); !ok || got != NewU256(1).Lsh(255) {
+		t.Errorf("CheckedMul failed. Expected 2^255, got %v, %v", got.Big(), ok)
+	}
+	if _, ok := NewU256(1).Lsh(128).CheckedMul(NewU256(1).Lsh(128)); ok {
+		t.Error("CheckedMul failed. Expected an overflow")
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	allOnes := U256{}.Not()
+	tests := []struct {
+		x, y, d U256
+		ok      bool
+	}{
+		{NewU256(10), NewU256(20), NewU256(7), true},
+		{allOnes, allOnes, allOnes, true},
+		{allOnes, NewU256(3), NewU256(4), true},
+		{allOnes, allOnes.Sub(NewU256(5)), allOnes.Sub(NewU256(1)), true},
+		{NewU256(1).Lsh(200), NewU256(1).Lsh(100), NewU256(1).Lsh(60), true},
+		{allOnes, NewU256(2), NewU256(1), false},
+		{NewU256(1), NewU256(1), U256{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := MulDiv(tt.x, tt.y, tt.d)
+		if ok != tt.ok {
+			t.Errorf("MulDiv(%v, %v, %v) failed. Expected ok %v, got %v", tt.x.Big(), tt.y.Big(), tt.d.Big(), tt.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		want := new(big.Int).Mul(tt.x.Big(), tt.y.Big())
+		want.Div(want, tt.d.Big())
+		if got.Big().Cmp(want) != 0 {
+			t.Errorf("MulDiv(%v, %v, %v) failed. Expected %v, got %v", tt.x.Big(), tt.y.Big(), tt.d.Big(), want, got.Big())
+		}
+	}
+}
+
+func TestMulDivUp(t *testing.T) {
+	allOnes := U256{}.Not()
+	tests := []struct {
+		x, y, d, want U256
+		ok            bool
+	}{
+		{NewU256(10), NewU256(20), NewU256(7), NewU256(29), true},
+		{NewU256(10), NewU256(21), NewU256(7), NewU256(30), true},
+		{allOnes, NewU256(1), NewU256(1), allOnes, true},
+		{allOnes, NewU256(3), NewU256(3), allOnes, true},
+		{allOnes, allOnes, allOnes.Sub(NewU256(1)), U256{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := MulDivUp(tt.x, tt.y, tt.d)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("MulDivUp(%v, %v, %v) failed. Expected %v, %v, got %v, %v", tt.x.Big(), tt.y.Big(), tt.d.Big(), tt.want.Big(), tt.ok, got.Big(), ok)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package schnorr
+
+import "math/big"
+
+// Adaptor signatures let a signer hand out a pre-signature that becomes a
+// valid BIP-340 signature only when completed with the discrete logarithm t
+// of an adaptor point T = t·G, and anyone holding both the pre-signature
+// and the completed signature learns t.
+//
+// A pre-signature is bytes(R') || bytes(s') where R' = R + T is the final
+// nonce (with even y) and s' = k + e·d for the signer's nonce k with
+// R = k·G. The completed signature is bytes(R') || bytes(s' + t).
+
+// VerifyAdaptor checks that preSig is a pre-signature of msg by pubX for
+// adaptor point T: that completing it with t = log(T) yields a valid
+// signature.
+func VerifyAdaptor(msg, preSig, pubX []byte, T Point) bool {
+	if len(preSig) != 64 || len(pubX) != 32 {
+		return false
+	}
+	if T.IsInfinity() || !IsOnCurve(T) {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(preSig[:32])
+	sPrime := new(big.Int).SetBytes(preSig[32:])
+	if r.Cmp(P) >= 0 || sPrime.Cmp(N) >= 0 {
+		return false
+	}
+
+	// R = s'·G - e·P must satisfy R + T = R' with even y
+	R, ok := nonceFor(sPrime, r, pubX, msg)
+	if !ok {
+		return false
+	}
+	Rp := Add(R, T)
+	if Rp.IsInfinity() {
+		return false
+	}
+	return Rp.Y.Bit(0) == 0 && Rp.X.Cmp(r) == 0
+}
+
+// Adapt completes a pre-signature with the adaptor secret t.
+func Adapt(preSig []byte, t *big.Int) ([]byte, error) {
+	if len(preSig) != 64 {
+		return nil, ErrInvalidSignatureLength
+	}
+	if t.Sign() <= 0 || t.Cmp(N) >= 0 {
+		return nil, ErrScalarOutOfRange
+	}
+	s := new(big.Int).SetBytes(preSig[32:])
+	s.Add(s, t)
+	s.Mod(s, N)
+
+	sig := make([]byte, 64)
+	copy(sig, preSig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
+
+// Extract recovers the adaptor secret t = (s - s') mod n from a completed
+// signature and its pre-signature.
+func Extract(sig, preSig []byte) (*big.Int, error) {
+	if len(sig) != 64 || len(preSig) != 64 {
+		return nil, ErrInvalidSignatureLength
+	}
+	for i := 0; i < 32; i++ {
+		if sig[i] != preSig[i] {
+			return nil, ErrNonceMismatch
+		}
+	}
+
+	t := new(big.Int).SetBytes(sig[32:])
+	t.Sub(t, new(big.Int).SetBytes(preSig[32:]))
+	t.Mod(t, N)
+	return t, nil
+}
@@ -0,0 +1,60 @@
+//go:build ignore
+
+// gen_gtable writes gtable_gen.go, the comb table used by MulG in gtable
+// builds. Run it with go generate -tags gtable ./schnorr.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"math/big"
+	"os"
+
+	"github.com/rafaelescrich/stygos/schnorr"
+)
+
+const (
+	teeth   = 6
+	spacing = (256 + teeth - 1) / teeth
+)
+
+func main() {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_gtable.go. DO NOT EDIT.\n\n")
+	buf.WriteString("//go:build gtable\n\npackage schnorr\n\n")
+	fmt.Fprintf(&buf, "// gTable holds, for m = 1..%d, the sum of 2^(%d·i)·G over the bits i\n", 1<<teeth-1, spacing)
+	buf.WriteString("// set in m, each as the 64 bytes x || y.\n")
+	buf.WriteString("const gTable = \"\" +\n")
+
+	var bases [teeth]schnorr.Point
+	for i := range bases {
+		bases[i] = schnorr.Mul(schnorr.G(), new(big.Int).Lsh(big.NewInt(1), uint(i*spacing)))
+	}
+	for m := 1; m < 1<<teeth; m++ {
+		p := schnorr.Infinity()
+		for i := range bases {
+			if m>>i&1 == 1 {
+				p = schnorr.Add(p, bases[i])
+			}
+		}
+		buf.WriteString("\t\"")
+		for _, b := range p.Bytes() {
+			fmt.Fprintf(&buf, "\\x%02x", b)
+		}
+		if m == 1<<teeth-1 {
+			buf.WriteString("\"\n")
+		} else {
+			buf.WriteString("\" +\n")
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("gtable_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by gen_gtable.go. DO NOT EDIT.
+
+//go:build gtable
+
+package schnorr
+
+// gTable holds, for m = 1..63, the sum of 2^(43·i)·G over the bits i
+// set in m, each as the 64 bytes x || y.
+const gTable = "" +
+	"\x79\xbe\x66\x7e\xf9\xdc\xbb\xac\x55\xa0\x62\x95\xce\x87\x0b\x07\x02\x9b\xfc\xdb\x2d\xce\x28\xd9\x59\xf2\x81\x5b\x16\xf8\x17\x98\x48\x3a\xda\x77\x26\xa3\xc4\x65\x5d\xa4\xfb\xfc\x0e\x11\x08\xa8\xfd\x17\xb4\x48\xa6\x85\x54\x19\x9c\x47\xd0\x8f\xfb\x10\xd4\xb8" +
+	"\xa2\xb7\xb3\x62\x9f\x7b\xd2\x53\xb7\xd2\x82\xb5\xc2\x1d\xa0\x14\x46\xb4\x82\x1d\xc6\x5e\x76\x51\x60\x48\xb0\x60\x43\xff\x83\x59\x69\x30\x38\x94\x16\x95\x12\x2d\x57\xa9\x37\xa3\xf7\x1e\x29\xc9\x10\xd1\x08\x35\x04\x6f\x38\x35\xa2\x39\x7f\xec\xfe\x86\xfe\xc2" +
+	"\x65\x76\xd5\x54\x8b\x4d\x88\xd4\x8c\xd0\xb2\xd6\x18\xe4\xa8\xad\x86\x96\x06\x38\x34\x7f\x3a\x26\xbe\x27\xd0\x57\xb1\x0f\xd3\x04\xb4\x81\xe6\x3e\x3a\x1e\x8c\x39\x4b\xa2\x82\xbd\x74\x71\xa2\xcd\xde\x91\xc8\xff\x19\xdc\xa5\x3c\x32\x14\xfb\xf6\x74\xb3\x5a\x7e" +
+	"\xd6\x78\x85\x90\x73\x1f\xea\x19\x83\x92\x11\x9d\x7a\xdb\xb4\x1f\xf5\x94\x8a\x78\x04\xc8\x5b\x17\x47\x67\x06\xe4\xdf\xbf\xa4\xdc\x28\xea\xa8\xc8\x9d\x50\x63\xc4\x94\x0e\xf5\xc6\xd2\x1c\x13\xaa\x62\x06\xf1\xc4\xdd\xc9\xa0\x7c\xca\x7b\xcd\x6b\xbd\x3b\x54\x06" +
+	"\x29\xc4\x7e\xab\x89\x1e\x3c\xc0\x52\x56\x5e\x80\x93\x39\xae\x07\x25\xe2\x1c\x36\x81\xb3\xf4\xaa\x3e\x73\xfc\xc8\xf7\x86\x61\x96\x4e\x0d\x94\xb7\x88\xb8\x3d\xf0\xd5\x5a\x8d\xec\x6a\xca\x3e\xf4\x3e\x49\x81\x5b\x2f\xf1\x0f\xdf\x3d\x9d\x8a\xa9\x26\xac\x3d\xcd" +
+	"\xf7\x3c\x12\x85\x1a\xd3\x19\x62\x17\x0a\x37\xe4\xcf\x58\xf7\xe0\x30\x9f\xef\xfb\x58\x44\xa0\x4c\xea\x37\x50\x08\xed\xec\xc8\x47\x50\x6b\x9e\x27\x59\xc6\xb1\x14\x8c\x3a\x8a\x7d\x3e\x0d\x25\x81\x99\xed\xbe\xdf\x17\xb6\x86\x4f\x2c\xf7\x14\xdb\x4b\x5d\x70\xe2" +
+	"\xce\xa2\xe1\x72\x63\xd3\x26\xc5\x5d\x53\xc3\x26\x29\xaa\x5f\x4b\xa6\x47\xb5\xb0\x65\xde\xd4\x30\x8f\x6f\xf9\xc4\x2b\x7f\xe6\xb1\x31\x68\x5d\xb5\x9b\x97\xd9\x6f\x88\x4e\x42\xab\xc2\x51\xb9\xe4\x2c\x15\x7f\xa2\x99\xc5\x47\xa7\x7e\x51\x11\xe5\xb3\xcf\x7b\xd1" +
+	"\x4d\x49\xae\xfd\x78\x4e\x81\x58\xfc\xaf\xeb\xe7\x7f\xd9\xaf\x59\xd8\x98\x58\xad\xe7\x62\x7e\xae\xe6\x84\x7d\xf8\x4c\xf2\x70\x76\xcd\x32\xfc\x59\xa1\x0d\xd1\x35\xe7\x23\xf2\x10\x35\x9c\xa6\xf0\x6e\x0f\x2d\x1a\x7d\xf4\xd8\x46\x6b\x90\xb6\x62\x03\xaa\x78\x1e" +
+	"\x2c\x0e\x45\x87\xcd\x01\x23\xc6\x50\x5c\x7c\xe5\xbd\x26\x23\xd6\xea\x8b\x0f\xa8\x27\x0f\x23\xbf\x04\x2f\x79\x89\xce\x27\x9a\x45\xa0\x2f\x61\x27\x07\xd4\x27\x62\xf4\x5b\xaa\x5c\x94\x68\x01\xeb\xc8\x81\xdb\xf3\xc5\x34\x8e\xbe\xaa\x54\x91\xed\x79\x85\x8d\xa8" +
+	"\xdc\xb9\x7f\xc1\x3c\xd4\xa9\x52\xca\x8f\x1b\x6a\x99\xe9\x2f\x76\x83\x44\xfc\x81\xd2\x53\xe9\xa6\x00\x35\xaf\x53\x7f\x56\xf8\x27\x2e\xfb\xd1\x69\xff\x5d\x73\x7b\x0a\x19\x05\x12\x12\xc0\x1d\x14\x42\x44\x3f\x4b\x40\x8c\x61\x30\x16\x0a\x4b\x4e\x87\xb6\x7c\x3d" +
+	"\x25\x43\xe5\xf8\x1c\xe7\xd8\x33\x5a\x95\x76\x98\x57\xe5\x5d\x8a\x4d\x1e\xbb\x05\xa5\x85\x0c\x70\x35\x55\x69\xba\x16\xf4\x1f\x0a\x9a\xf0\x05\x33\x17\x3c\x88\x1f\xc2\x3e\xb5\x66\x57\x36\x34\xad\xef\x0e\x40\x31\x2f\xbf\xc3\xdd\x50\xe9\x13\xa0\x05\x96\x23\x8c" +
+	"\xe5\xe0\x8b\x13\xbb\x68\xbe\x24\xd2\x42\x95\x17\xc6\x1c\xa3\x7f\x76\x67\x1c\x46\x72\x3d\xf5\xa8\xe0\xb3\xa8\x43\x74\xb4\x59\x60\xea\xe0\x0d\x38\x39\x23\x29\xa9\xe2\xec\x20\x9e\x19\xa7\x6c\x68\xf1\x50\xb8\xe7\xaa\xba\xcf\xf0\x1c\xaf\x63\x9c\x69\x90\xcf\xc6" +
+	"\x3d\x56\xce\x04\x7d\x0c\x0b\xac\xb7\x08\x13\xe4\x39\xba\x6e\xa9\x37\xb8\xd8\x70\xd3\x3d\xc8\x67\xf4\xac\x2e\x21\x78\xe4\xe9\xda\x42\x50\x98\x97\x2c\xb1\x16\xd6\x8a\xb4\xd9\xbb\x79\xd9\x28\xab\x0b\x5b\x18\x92\x0b\xbf\x0e\xfa\x1a\x72\x05\xc7\x6e\x00\x5f\x31" +
+	"\x40\x2d\x95\x0e\x14\xfe\x9b\xf7\xb5\x4f\x07\xc0\xa7\x04\xd6\xd1\xa0\xec\x82\x85\x00\x4c\x17\xba\x8c\x30\x94\x1c\xcc\x2a\x56\xd6\x92\xea\xe9\x8f\xa0\x4e\x06\x7c\x72\xbb\xc0\xef\x07\x12\x28\x52\xbe\x32\x98\xe1\xb7\xa0\x3a\xc1\x78\x29\x6e\xc7\xff\xd3\x7a\x94" +
+	"\x8f\xd8\x5d\xd8\x21\x92\xf3\x80\x25\xd8\xa1\xa1\x79\x09\xd6\x6d\xbd\xa9\x41\x62\x32\xb1\xf4\x91\xbd\x77\x61\x66\xfa\xcf\xba\x20\x90\xe0\x05\x91\xaf\xb2\xff\xf6\x14\x4c\xb3\x4f\xcb\x3f\xb9\xe9\xca\x56\xc7\x19\x7b\x5b\x9a\xb6\x0b\xf5\x97\x3b\x12\x75\xd6\x8d" +
+	"\x77\x78\xa7\x8c\x28\xde\xc3\xe3\x0a\x05\xfe\x96\x29\xde\x8c\x38\xbb\x30\xd1\xf5\xcf\x9a\x3a\x20\x8f\x76\x38\x89\xbe\x58\xad\x71\x34\x62\x6d\x9a\xb5\xa5\xb2\x2f\xf7\x09\x8e\x12\xf2\xff\x58\x00\x87\xb3\x84\x11\xff\x24\xac\x56\x3b\x51\x3f\xc1\xfd\x9f\x43\xac" +
+	"\x0d\x20\x75\x80\xa8\x2f\x66\x07\xfd\xb0\xa5\xa0\xb3\x8e\x94\x7e\x9c\x02\xce\xdd\x3d\x03\x12\x97\x92\xb0\x72\xdd\x48\xed\x13\x67\x7f\x6f\x57\x8e\x9f\x2e\x5a\xe6\x24\x9d\x10\x5e\x78\x06\xa8\x21\x4b\xf8\xe9\xd4\x73\xd7\x04\x5f\x97\x60\x73\x26\xf6\x93\xd2\x8e" +
+	"\x42\xa4\x63\x4a\xf4\xba\xaf\x3f\x02\xce\xad\x82\x33\xdd\x83\x0d\x55\x7e\x6c\x70\x59\xaf\x20\xf2\xe1\xc7\x4a\xca\xb1\x5c\xb0\xa8\xb2\x39\x26\x4b\x81\x1e\x89\xb0\x8c\xdc\x23\xa3\xa3\x9f\x43\xce\xf4\xfa\x5d\x59\x63\x8f\xc0\xa9\xb2\xf7\xcc\xf5\xe0\xda\x51\x3c" +
+	"\xc1\x96\x4e\x0e\xfa\x8c\x13\xa7\xed\x1e\xd0\x50\x8f\x72\x26\xb5\x0f\x7f\x67\x87\x98\x0a\xde\x7a\xb3\x0f\x19\x51\x48\xe8\x24\x95\x9b\xc3\x05\x16\x01\xc2\x1f\xfe\x9b\x01\x9b\xbf\x3b\x8e\x22\x4c\x74\xd4\xe3\x62\x5e\xe3\x5b\x01\x24\x8b\x05\x7c\xdd\xab\x5f\x2c" +
+	"\x7c\x63\x6c\xdc\x59\x37\x31\x63\xa4\xe1\x40\x9d\x98\x1f\xf1\x63\xd1\x14\x89\x5e\xe3\xe6\x4e\x20\xaa\xa0\x28\x55\x1d\x66\xe2\x42\x72\x74\xa8\xe2\x95\x2c\xc2\x72\x3b\xe6\xc1\xef\xfd\x6a\x1c\x16\x77\x20\x62\xde\xe9\xc4\x11\xdc\x22\xe7\x13\x0e\xbd\xa8\x6b\xe3" +
+	"\xc7\xab\xfc\x5c\xfa\x35\xca\x7b\xce\x13\x4b\xef\x23\xcd\x51\x3e\x6f\xac\xbc\x2b\x3f\xad\xa3\x81\xf9\x66\x85\x26\x1b\x2a\xea\x68\x87\x58\xb7\xf1\x38\xf7\x55\xd9\xcf\xc5\xfb\xa0\x29\xa3\xcc\xc5\xbc\x85\xb7\x30\xd1\x9d\x0e\xb0\xa1\xb5\xab\xd1\x92\x65\x8c\x1c" +
+	"\x02\x78\x10\x7b\x51\x38\xc6\x1f\x2e\xc4\xad\xac\xbd\x49\x69\x35\x8e\x30\xca\x87\x55\x33\x3c\x65\x6e\xb5\x2d\xd9\xeb\x77\x76\x97\xb5\xfe\x26\x0e\x28\x02\x82\xa7\xb4\xe6\x26\x80\x09\x27\xf9\x9f\xd4\x50\xe0\x64\x90\x7f\x17\xba\x80\x9b\xd7\x35\x00\xfc\x31\xa9" +
+	"\x40\xe9\x4e\x24\x2c\x4e\x3f\x25\x2a\x0b\x0c\x46\x46\x61\x48\x91\xf0\xd9\xb6\x55\xf7\x64\x37\x45\x30\x66\x36\x48\x95\x7b\x0a\x0d\x16\x9e\xe3\x13\xab\x74\x5c\x23\xec\xe0\x8e\x1d\xbd\x3e\x84\xdf\x6d\x73\x1d\x6f\xe5\xa1\xd6\x6c\x8d\x58\xf6\xf5\xa6\x0e\x3e\x05" +
+	"\x5e\x67\xd6\x97\x3d\x95\x8a\x99\xc0\xe4\xfb\xdc\xb1\x5f\x58\xe4\x41\x13\x3d\x51\x44\x03\x86\x3c\x40\x05\xde\xf4\x15\x54\x48\x67\x1d\x22\xc1\x49\xa6\x13\x01\xe9\xe0\x43\xd1\x44\xd4\x84\x3b\xa9\x29\x2d\xff\x5f\x82\x70\x37\x92\x41\x0a\x4e\x8e\xde\x26\xe2\xcf" +
+	"\xf1\xa5\xea\xe5\x45\x7b\x84\xdf\xf5\xeb\x48\x7f\x3d\x7e\xb3\xc1\x64\x88\x53\x62\xf2\xeb\x49\xa9\x87\xfa\x81\xc7\x35\xd6\x36\x71\x0a\xeb\xc9\x38\xcb\x8c\xb5\xb4\x9a\x89\x40\xfe\xa2\x2c\x81\x91\xa3\x94\xce\x9c\x1b\x62\xaf\xc2\x1f\x66\x4b\x95\xaf\x57\xdc\xa7" +
+	"\xfa\x7e\xa7\x71\x76\x01\xba\x84\x2b\xd5\x34\x50\xac\x6e\x28\xc8\xe4\x57\x3e\x3a\xac\x64\x72\x03\xda\x17\x3e\x1e\xbb\x8c\x82\x98\xdb\x11\xb8\x10\xc6\x20\xfd\x3e\xd7\x1a\xf8\x88\xeb\x2b\x23\xae\x43\x2b\xed\x96\x06\x3f\xa8\x9b\xfd\x9d\x76\x78\xd1\xf4\x27\x0c" +
+	"\x5c\x10\x48\xa5\x32\xc2\x82\x7d\xd3\xae\x5d\x6d\x83\xc0\xc3\x7e\xe7\xb7\xf8\x6f\x20\xdd\x1a\x21\x76\x20\x5b\x8f\x01\x53\xa2\x30\x68\x59\xfc\x33\x26\x80\xc8\x91\xbf\x24\x69\xc7\x0f\x3e\x2a\xd0\x91\xff\xb6\x41\x98\xa8\xb3\xad\x2c\xf3\xd4\xd1\xbc\x73\xa5\x33" +
+	"\xd4\x3e\x05\xcd\xdb\xd1\xbc\x55\x30\x64\x6a\x48\x7a\xf2\x04\x04\x6e\x48\x00\x0d\x1e\xc2\x17\xe7\xe1\x9a\x13\xe9\x34\x08\x7a\x25\x0a\xee\x00\x25\x58\x08\x92\x17\xd0\xb7\xb2\x42\x24\x83\xc1\x9f\x67\xf6\x6a\x71\x13\x20\xdc\x1c\x70\xfe\xfa\xb9\x86\xe4\x39\xbc" +
+	"\x0a\x34\xbb\x13\xfe\xce\xe2\xe7\x06\x04\xb8\x08\xa7\x53\xc1\x90\x94\x6f\x23\x62\xca\x26\x7c\x4a\xdd\xc3\xc4\x19\x71\x0f\x10\x26\x1d\x69\x73\x2c\xe0\x8a\x90\x3c\x0c\x1e\xaf\x02\xc1\x5f\x0f\x55\xd9\x41\x1c\xfe\x0e\xe1\x75\x58\xbc\x66\x05\x51\x83\x7b\x45\x96" +
+	"\xdd\xb6\x18\xeb\xa6\xe0\x9a\xb5\x38\x7e\x34\xd2\xf9\x7c\xf5\x85\x1a\xd0\xc6\x0f\x3b\x5f\xc1\x20\x18\xdc\x08\xe5\xe9\x54\xd4\x99\x74\x59\xf3\x0c\x6d\x22\x19\x78\x8c\x20\x95\xc6\x71\x92\xdb\x95\x54\xab\xb2\x9e\xd7\x70\x81\x2b\xeb\x60\x97\x3f\x0a\xcb\x5d\xd3" +
+	"\x0e\x7a\xc3\x9f\x1d\x06\xf3\xfe\xad\x70\xfb\xa2\xc0\xca\xbb\x2b\x87\x58\xbf\x9a\xe7\x27\x1f\xac\x4b\x21\x5f\xcf\x48\x50\x6a\x70\x25\x71\x78\x99\x94\xc7\xa2\x8d\x2d\x0a\xc5\xea\xed\xcd\x78\x92\x93\x46\x47\x41\x76\x3c\x7a\x81\x14\x55\xfa\x0e\x10\x0a\xe7\xa9" +
+	"\x71\x75\x40\x7f\x1b\x58\xf0\x10\xd4\xcd\xa4\xc6\x25\x11\xe5\x9d\xb7\xed\xcf\x28\xf5\x47\x6d\x99\x5c\xf3\x99\x44\xb2\x6b\x64\xf1\x43\xb4\x55\x43\x44\xe3\xd5\x50\xf3\x6d\x34\x01\x13\x4c\xc8\x6e\xb0\x1f\xe8\xb7\x74\x47\x1d\x2a\x42\x6e\x7e\xfa\xb2\x42\x34\xd5" +
+	"\x1c\xc8\x17\xb6\xb0\xcc\xd4\x8e\x2d\xd2\x8f\xd1\x55\x3a\xea\xda\x32\x97\xf9\xbd\x53\xca\x91\x41\xae\xf3\xdd\xcc\x70\x09\x52\xef\x1e\x3e\x58\xc7\xda\x85\xc2\x9c\xe4\x44\x28\x3c\x75\x03\x3d\x5a\xcb\xe3\x09\xdd\x78\x3d\x6a\x22\x26\xb1\xdd\x83\x12\x7f\x53\x8e" +
+	"\x60\xca\xc1\x4d\x21\x7d\xdb\x87\xfa\x81\x9d\x53\x61\x35\xa6\x2f\xb3\x66\x48\x10\xb8\xe1\x6d\xc1\x58\x84\x15\x9b\xd7\x72\x11\x15\xc2\xfe\xb9\x35\x4e\x25\xe4\x44\x45\x5e\xe5\xd2\x69\x76\xf1\xd0\x5d\x33\x0d\x63\xd2\x0b\xca\xd2\x4b\x5e\x34\x71\xfb\x69\xe4\x82" +
+	"\xef\xa5\x3f\x42\x7e\x0d\xae\x3e\x14\x48\xbe\xf4\xe6\x41\xcb\x81\x33\x9b\x12\x7a\x60\x2a\x2a\x3d\x53\xd4\x85\x00\x95\x9b\xac\xad\x6f\x5b\xba\xe1\xa2\xbb\x65\xc7\x07\xa2\x7e\x70\xdd\x94\x9d\xf7\x25\xd7\xc8\x47\x89\x1f\x9e\x25\xcf\xa2\xa1\x5e\xca\x6a\xfd\x2a" +
+	"\xe1\x0e\x89\xb8\x7a\x56\xa1\x6d\x1c\xc9\x69\x33\xbe\x39\xc4\xd5\xac\xe6\x7d\xb5\xd0\x3e\xb4\xfb\x7c\xa3\xde\xea\x3d\xcb\xe5\xdd\x8e\x4c\xd1\x9d\x44\x98\xcb\x19\xae\x56\xfa\x13\x65\x1b\x1e\x7a\xe8\x31\x9a\xc5\xe1\x46\x6a\x33\xb9\x9d\x50\x43\x3d\x18\x06\xcd" +
+	"\x80\xf1\x18\xa6\xc7\x29\xf9\x53\x3c\x55\x49\x18\xea\x13\x44\xf7\x98\xbf\xf2\x1d\x56\x4b\x36\x19\x4f\x08\x51\x99\x12\x2f\x0f\x71\x43\xc9\x40\x8c\x77\x56\xaf\x48\x92\xaf\x03\x2f\x9d\xde\x7f\xed\x2b\x66\x24\xa1\x04\xb6\x56\x3d\x26\x20\x7c\x60\x1f\x1a\x9c\xa2" +
+	"\x3f\xa3\xa9\x59\x1f\xa8\x8c\x85\xe1\x07\x44\xcc\x1a\x03\xd2\x71\xd0\x79\x84\xed\x74\xf4\xfb\xe9\xe4\x3f\xd4\x14\x76\xa4\x59\x6c\x7d\x47\xda\x22\xbc\x60\xf1\x21\xb1\xeb\x18\xb2\x3a\x78\x8f\x67\xeb\x89\xfc\xa4\x30\x88\x39\x54\x0d\x42\xf7\x16\x4a\x7b\x42\xa2" +
+	"\x38\xc5\x89\x7a\x97\x89\x51\x0c\x44\xf2\x84\x7c\x11\x79\x53\xb2\x67\x02\x05\xa7\x76\x87\x90\x0e\x54\x08\xc2\x04\x5f\xf7\x81\xed\x94\x78\x58\xd5\x6d\xfb\x3c\x09\x1b\x4d\x31\x64\x23\xca\x73\x11\xff\xeb\x48\x26\x1c\xae\xfd\x1b\x9f\xe3\x87\xc9\xfd\x6f\x39\x68" +
+	"\x6e\x7f\x11\xc8\x09\x80\xe5\xf2\x0b\xd8\xe3\xae\xd5\x16\x20\xbc\xa9\xde\xe8\x62\x6b\x53\xbe\xb2\xcf\xb4\xa0\x87\x0c\xc1\xb9\xed\x14\x98\xb6\xf1\x3b\x8a\xca\x26\xde\xd3\xa6\x15\xb3\x55\xf0\xd8\xd0\x5f\x9a\xe5\x7c\x8e\x24\xb9\x28\xc8\xa2\x05\x07\xee\x8b\x3e" +
+	"\x2f\x92\x48\x5f\xd2\x6e\xae\xf4\x9b\xc6\x1b\x74\xd0\x4b\x9c\x13\xe4\x5a\xf2\x03\x39\xe4\x71\x48\xe6\xa6\xd1\x43\xfb\xad\xaf\x91\x20\x07\x0b\x88\xf3\x82\x4c\xa8\x1a\x17\x62\x33\x7f\xc6\xf4\xba\x12\x6b\x5c\xad\x4a\x76\x99\xfa\x0b\x6a\x37\x95\x19\x2d\x89\x26" +
+	"\x19\x7a\xc9\x2f\x79\xb9\x28\xbf\xb9\x06\xd7\x8d\x7a\x39\x84\x7d\xf6\xde\x05\xe9\x4f\x27\x9b\xbd\xb7\x9b\x5e\xcf\xdf\xbb\x68\xa2\xac\x2b\x3f\xfe\xbd\xfb\xa5\xdc\xdf\x13\x6f\xf1\x80\xf7\xfb\x94\x66\xda\x35\x3b\xf2\x09\x6e\x06\x6b\x38\x62\x7a\x08\x91\x2f\x0e" +
+	"\x75\xb0\xfd\x5a\x90\xd0\xdc\x18\x1d\x20\x24\xc2\xbc\x6b\xc5\x41\xd2\xcb\xaa\xbb\x77\x6e\xaf\xc2\x5c\x8e\x2b\x6c\x99\xb8\xa0\xba\x3c\xf9\xa4\x4b\x58\xbf\x26\x58\xe5\x9d\x73\x4c\xfc\xf1\xf4\x34\xfb\xb2\xe1\xeb\x40\x31\xd2\xf6\xc0\x9e\xf1\x8e\x60\x9c\xe2\xec" +
+	"\x27\xb2\xb3\xa4\xe0\xa2\x95\x11\x09\x28\x4c\xf6\xae\x51\xbf\x84\xae\x04\x08\x81\x3e\x85\x65\xb4\x7f\xa4\x20\x90\xee\xbf\x6b\xb7\x80\x0e\x23\xb4\x69\x42\x80\x0c\xae\x64\xb6\x6d\xe3\xbc\xdb\x3a\x7a\xbe\x3d\xb7\x1b\x21\x9c\x9b\xc8\x8b\x67\xe5\x13\x97\xec\x0a" +
+	"\x80\x2d\xdd\xc6\x45\x77\x16\xdc\xa7\x90\x9e\x91\xed\xf6\xaa\x05\x5c\x2c\x98\xdb\x8e\x32\xd0\x4a\x2c\xd5\x9c\x9d\xae\xb0\x02\xa6\xd4\x6b\x7e\x27\xdb\x84\x8e\x62\xe4\x42\x4f\xea\xd9\x1b\xe4\xa0\x99\x20\xe0\x8a\xc7\xfd\x6c\x58\xc1\xbb\x3a\xeb\x20\xa3\x4d\x02" +
+	"\x03\x17\x9e\x31\x4d\x2d\xf8\xfa\x09\xb8\x9e\x98\x7e\xf7\x71\xb4\x4e\x43\x90\xf2\x49\x9a\x8c\x7d\xfb\xc5\xe2\xc6\x3c\x1d\xbe\x36\xfa\x46\x1b\x20\xb7\x3f\x95\xcb\x0f\xbe\x63\x32\x43\x93\x50\x12\x22\x76\x67\x6f\x01\xcb\xb1\x5e\x71\x87\x1a\xbe\x48\xb5\xad\x2b" +
+	"\x7a\xa6\x16\x48\x69\x38\x67\xe2\x85\xa6\xd0\x79\xbc\xcd\xef\xb3\xc3\x82\xa7\x7a\x3b\x97\xb6\xe6\x24\xcd\xcc\x14\xc9\xd3\x69\x95\x21\x5e\xa7\x06\x56\xc3\xb7\xd6\x6b\x1d\x7a\xee\x99\x99\x1d\x1c\x97\x15\xb2\x43\x0c\x21\x0b\x89\x6f\xa3\x3d\xc1\xad\x4e\x9e\x90" +
+	"\x91\xb4\x4a\xdb\x4e\xa4\xc4\x64\x20\xfa\xeb\xc1\x1d\x66\x02\x17\x27\x17\xbd\x73\x53\x68\xc6\xd6\xbe\x35\xd0\x78\x5a\xe6\x0b\xa3\xf9\xa2\xa6\x8c\xc5\x8a\x47\x74\xfa\x56\x43\xd7\x79\xdb\x16\x49\x64\x86\x4f\x81\xcb\xac\xdb\x25\xff\x02\x17\xdd\x5e\xb7\xd7\x1b" +
+	"\x97\xcf\x99\x0e\x77\xe6\x5b\xb1\x8e\x13\x96\x89\x12\x70\xc1\xdd\x17\x25\x52\x2c\x39\xa2\xa2\xe7\x31\x5b\x0d\x5d\x5d\x76\x03\x3d\x0c\x6f\x12\x6a\xf3\x9b\xf3\xb1\x66\xa8\x94\x3c\x6e\xb4\x02\x4e\xa4\x27\xe2\x4a\x0a\x79\xcd\x92\xab\x15\x0e\x3c\x64\xd3\x40\x89" +
+	"\x10\xb2\x10\x46\x65\x55\x18\x26\xe6\xe6\x0b\x49\x49\x71\x1f\x57\x1c\x79\x6c\x26\xae\xb1\xed\x2f\x24\x92\x27\x3c\x8a\xa5\xcd\x34\xf5\x49\x8b\x37\x09\x2d\x9b\xe4\xb1\x4f\x59\xa2\x7f\x0a\x41\xdc\x6f\x5b\x70\x0c\x0f\xc8\xd9\x39\xaf\x42\xa1\x54\x0c\x68\x06\x13" +
+	"\x6f\xca\x2f\xe6\xf5\xcc\xe5\x8d\xdd\xbd\x90\x23\x2b\xec\x10\x59\x0c\xe3\x3a\xc1\x47\x61\x27\xb0\x19\x75\x6a\x7c\x14\x99\x35\x0d\x81\xbf\x22\x64\x7b\x2d\xad\xf7\xd1\xf6\x1b\x64\xf7\x9b\xb6\x2b\x90\x3c\xc8\x5a\x3a\x3b\x24\xb1\xe0\xf0\xf8\x3a\x01\xe0\xf1\x9f" +
+	"\x17\xb8\x32\xe4\x45\x71\x38\x6b\xd1\xa6\xbb\xbe\x14\x76\x16\x33\x75\x19\x14\x57\x2d\xca\x79\xef\x28\xc7\x9f\x0a\xac\xe7\x57\xfe\x5a\x7a\xb5\x36\x44\xc5\xfc\xc9\x4e\x66\x75\xc1\xc7\x3c\x3d\x28\x92\xcf\x24\x6a\xac\x39\x71\xa5\xf0\xa6\xcf\x26\xcb\x5b\x05\x97" +
+	"\x60\xd4\x5e\xfb\x2b\x4e\x91\x44\x57\xf7\x6e\x5c\xe9\x62\xd9\x2c\x1a\xa5\x84\xbf\xf5\xc5\x54\x9b\x40\xb9\x08\x60\x60\x7e\x5b\xa7\xca\xad\x90\xbe\x8b\xa9\x08\x6c\x26\xee\x09\x61\xe9\xa1\x34\x6e\x24\x8e\x3d\xad\x0f\xae\x5b\x6c\xac\x84\xaf\x0e\x04\x17\xe3\xd3" +
+	"\x71\xc4\x41\xd7\xe3\xb3\x2a\x5b\xf7\x14\xd3\xfa\x27\x6e\xf5\x3c\x59\x55\x85\x98\x4a\xb8\xa5\xf7\x8a\xd7\xd3\x99\x40\x4f\x42\x3d\xf6\x42\xd5\xaf\xe3\x09\x89\x6c\x51\x40\xb9\x81\x66\xbb\x2c\xda\x16\x4e\xb4\xd7\xc6\x2b\xcb\x6d\x49\x5b\xd4\xc1\x07\x38\x8e\xb8" +
+	"\xb0\xfb\x44\x6d\x18\xbd\xae\xa0\x6c\x5f\x6d\xe2\x78\xef\xf8\x49\x86\x1b\x55\x97\x82\x52\xed\x26\xa1\xa6\xb0\xce\x40\xae\xee\xc1\x44\x57\x3f\x4f\xcb\x57\x07\x54\x4a\x02\x45\x3e\x73\x48\x23\xc2\x61\x4f\x65\x8c\xa9\x4f\x9a\x62\xdd\x4c\x2e\x4e\xcc\x52\xcb\x4b" +
+	"\x2b\x5a\x34\x87\xe6\xd8\xf9\xa2\xfb\x8a\xf8\x2a\xe4\x12\x16\x30\xfd\x9a\x06\xec\xae\x0e\x53\x7a\x08\x47\xb9\x7e\x9d\x2b\x66\xf6\x5b\xea\x4f\x2e\x8e\x0f\x32\x81\x53\x60\x26\x50\xfa\xac\xa6\x27\x55\xc3\xd0\x37\xb8\xa9\x4c\xb3\x8b\xb9\x4c\x3a\x07\xfd\x38\x8f" +
+	"\xad\xc6\x13\xff\x05\xc8\xf5\xf6\x39\xaf\x12\x71\x45\xe2\xa7\x4b\x91\xa2\xd0\xaa\xb2\xc8\x22\xff\x79\x2e\xa9\x2c\x71\x6e\x7b\x6c\xc9\x7f\x9a\x92\xf2\x22\x80\xe9\xb5\x25\x4c\x04\xe3\x87\x03\xc1\x31\xb7\xa7\xcc\x71\xb4\xd7\xa7\xe9\xd9\x79\x3e\xfb\x00\xcb\xf4" +
+	"\xe0\xf4\xe2\x3f\xd0\x3c\x30\xce\x2d\x46\x4f\x09\xa6\x94\x03\xa8\x9e\xd7\xeb\xa3\x93\xb1\x87\x60\xbc\xee\x76\xe7\x6f\x67\xa7\xfa\xf4\x28\x1b\xf2\x36\x8a\x21\xbe\xf7\xaa\x6c\x27\x56\x1b\x50\xeb\xba\xf5\x13\x15\x6d\x65\x0a\x84\x43\x93\x85\x77\x92\xcd\x77\x6f" +
+	"\x37\xac\x7f\x14\x06\x7b\xef\x7f\x93\xeb\xa2\x02\x40\x22\x30\x94\x78\xdf\x12\x8a\x8d\x59\xb9\x0b\x88\x6e\x0c\x32\x93\x60\x52\x59\x28\x8e\x32\x1f\x57\xc1\xc6\xbd\x58\xba\x35\x33\xb5\xec\xb4\xc4\x5f\x45\x5f\x8f\xa7\x6e\x9b\x01\x83\xbb\xbb\x5d\xda\x29\xe7\x4a" +
+	"\x2b\x3f\xef\xc1\xca\xf7\xa4\xcc\x7f\xc5\xf4\xd0\x87\x2c\x14\x6a\x23\x32\xbd\xb7\xa3\x33\xfe\xec\x1a\x6d\x4c\xb9\x59\xf5\x46\x95\x92\xe6\x32\x3d\x24\x60\x2f\xd5\x28\xb6\x42\x97\x59\x90\x92\x28\xea\xf8\x37\xac\xf8\x97\x8b\x40\x15\xd0\x4f\x75\x70\x9f\xaf\x11" +
+	"\x9e\x7d\xee\x38\x3b\x89\x5a\x3e\xc9\xb5\x99\x47\xd5\xd6\xa2\x6e\x7a\xa0\x4f\xf6\x0a\x74\x85\x75\x86\x71\xb7\x90\x95\xda\x59\xe5\x49\x83\x1b\xfa\x78\x07\x22\xa4\xdd\x1a\xb3\xc3\x0a\xc7\xcc\x2f\x35\x4a\x19\x21\x02\x6c\xa8\x4f\x3e\x0f\xba\x1a\x53\xee\x48\x5b" +
+	"\x1c\x1e\x62\x83\x90\xc7\x5a\xb2\xfa\xad\x30\x77\x31\xe3\x6c\x60\x7b\xc9\xf6\xbe\x59\xd0\xbd\x87\xf8\xd1\x9d\x14\x3c\xd7\x9f\x44\xa7\xf4\xd2\x42\x9c\x57\xb3\x16\x52\x3b\x17\x91\x4d\x39\x11\x33\x1e\x7b\xb2\x0b\x27\x96\x9b\x07\xbb\xa3\xba\x1e\x37\x14\xff\x9f" +
+	"\x25\x89\x8a\xd2\x57\x0f\xba\x39\x14\x28\x93\xfd\xba\x91\xbf\x45\x5a\xe9\x1d\x7f\xfb\x3b\x1b\x98\x93\xcc\xad\x49\x1f\x53\x0f\xee\x21\x88\xb6\xd4\xb0\x26\x76\x81\xc9\xd4\xaa\xd1\xf2\x82\x03\xdb\x8a\x89\xe3\x4c\xc7\xc5\x4c\x52\x0b\xaa\x59\x82\x1b\x71\x80\xe3"
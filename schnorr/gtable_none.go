@@ -0,0 +1,15 @@
+//go:build !gtable
+
+package schnorr
+
+import "math/big"
+
+// precomputed reports whether MulG uses the generator table.
+const precomputed = false
+
+// MulG returns k·G. Build with -tags gtable to use precomputed multiples
+// of G, which makes it about four times faster at the cost of 4 KB of
+// table data in the program.
+func MulG(k *big.Int) Point {
+	return Mul(G(), k)
+}
@@ -0,0 +1,305 @@
+// Package schnorr implements BIP-340 Schnorr signatures over secp256k1 and
+// adaptor signatures built on them.
+//
+// Public keys are 32-byte x-only keys and signatures are 64 bytes
+// (bytes(R) || bytes(s)), as in BIP-340. Points are affine with the point
+// at infinity represented as (0, 0).
+package schnorr
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// secp256k1 constants
+var (
+	// Field modulus p
+	P = new(big.Int).SetBytes([]byte{
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE, 0xFF, 0xFF, 0xFC, 0x2F,
+	})
+
+	// Curve order n
+	N = new(big.Int).SetBytes([]byte{
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE,
+		0xBA, 0xAE, 0xDC, 0xE6, 0xAF, 0x48, 0xA0, 0x3B, 0xBF, 0xD2, 0x5E, 0x8C, 0xD0, 0x36, 0x41, 0x41,
+	})
+
+	// Curve parameter b
+	B = big.NewInt(7)
+
+	// Generator point G
+	GX = new(big.Int).SetBytes([]byte{
+		0x79, 0xBE, 0x66, 0x7E, 0xF9, 0xDC, 0xBB, 0xAC, 0x55, 0xA0, 0x62, 0x95, 0xCE, 0x87, 0x0B, 0x07,
+		0x02, 0x9B, 0xFC, 0xDB, 0x2D, 0xCE, 0x28, 0xD9, 0x59, 0xF2, 0x81, 0x5B, 0x16, 0xF8, 0x17, 0x98,
+	})
+	GY = new(big.Int).SetBytes([]byte{
+		0x48, 0x3A, 0xDA, 0x77, 0x26, 0xA3, 0xC4, 0x65, 0x5D, 0xA4, 0xFB, 0xFC, 0x0E, 0x11, 0x08, 0xA8,
+		0xFD, 0x17, 0xB4, 0x48, 0xA6, 0x85, 0x54, 0x19, 0x9C, 0x47, 0xD0, 0x8F, 0xFB, 0x10, 0xD4, 0xB8,
+	})
+
+	// (p+1)/4 for square root in F_p
+	sqrtExp = func() *big.Int {
+		result := new(big.Int).Add(P, big.NewInt(1))
+		result.Rsh(result, 2)
+		return result
+	}()
+)
+
+// Error definitions
+var (
+	ErrInvalidSignatureLength = errors.New("schnorr: invalid signature length")
+	ErrInvalidPubKeyLength    = errors.New("schnorr: invalid public key length")
+	ErrLiftXFailed            = errors.New("schnorr: lift x failed")
+	ErrScalarOutOfRange       = errors.New("schnorr: scalar out of range")
+	ErrInfinityPoint          = errors.New("schnorr: infinity point")
+	ErrNonceMismatch          = errors.New("schnorr: signature and pre-signature nonces differ")
+)
+
+// Point is an affine curve point.
+type Point struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// G returns the generator point.
+func G() Point {
+	return Point{X: GX, Y: GY}
+}
+
+// Infinity returns the point at infinity.
+func Infinity() Point {
+	return Point{X: big.NewInt(0), Y: big.NewInt(0)}
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p Point) IsInfinity() bool {
+	return p.X.Sign() == 0 && p.Y.Sign() == 0
+}
+
+// Bytes returns the 64-byte encoding x || y.
+func (p Point) Bytes() []byte {
+	out := make([]byte, 64)
+	p.X.FillBytes(out[:32])
+	p.Y.FillBytes(out[32:])
+	return out
+}
+
+// PointFromBytes decodes x || y. The point is not checked to be on the
+// curve.
+func PointFromBytes(b []byte) Point {
+	return Point{X: new(big.Int).SetBytes(b[:32]), Y: new(big.Int).SetBytes(b[32:64])}
+}
+
+// IsOnCurve checks if a point is on the curve
+func IsOnCurve(p Point) bool {
+	if p.IsInfinity() {
+		return true
+	}
+	if p.X.Cmp(P) >= 0 || p.Y.Cmp(P) >= 0 {
+		return false
+	}
+
+	yy := new(big.Int).Mul(p.Y, p.Y)
+	yy.Mod(yy, P)
+
+	rhs := new(big.Int).Mul(p.X, p.X)
+	rhs.Mul(rhs, p.X)
+	rhs.Add(rhs, B)
+	rhs.Mod(rhs, P)
+
+	return yy.Cmp(rhs) == 0
+}
+
+// Neg returns -p.
+func Neg(p Point) Point {
+	if p.IsInfinity() {
+		return p
+	}
+	return Point{X: p.X, Y: new(big.Int).Sub(P, p.Y)}
+}
+
+// Add adds two points
+func Add(p1, p2 Point) Point {
+	if p1.IsInfinity() {
+		return p2
+	}
+	if p2.IsInfinity() {
+		return p1
+	}
+
+	if p1.X.Cmp(p2.X) == 0 {
+		sum := new(big.Int).Add(p1.Y, p2.Y)
+		sum.Mod(sum, P)
+		if p1.Y.Sign() == 0 || sum.Sign() == 0 {
+			return Infinity()
+		}
+		return Double(p1)
+	}
+
+	dx := new(big.Int).Sub(p2.X, p1.X)
+	dx.Mod(dx, P)
+
+	dy := new(big.Int).Sub(p2.Y, p1.Y)
+	dy.Mod(dy, P)
+
+	inv := new(big.Int).ModInverse(dx, P)
+	s := new(big.Int).Mul(dy, inv)
+	s.Mod(s, P)
+
+	s2 := new(big.Int).Mul(s, s)
+	s2.Mod(s2, P)
+
+	xr := new(big.Int).Sub(s2, new(big.Int).Add(p1.X, p2.X))
+	xr.Mod(xr, P)
+
+	yr := new(big.Int).Sub(p1.X, xr)
+	yr.Mul(yr, s)
+	yr.Sub(yr, p1.Y)
+	yr.Mod(yr, P)
+
+	return Point{X: xr, Y: yr}
+}
+
+// Double doubles a point
+func Double(p Point) Point {
+	if p.IsInfinity() || p.Y.Sign() == 0 {
+		return Infinity()
+	}
+
+	x2 := new(big.Int).Mul(p.X, p.X)
+	x2.Mod(x2, P)
+
+	s := new(big.Int).Mul(big.NewInt(3), x2)
+	s.Mod(s, P)
+
+	twoY := new(big.Int).Lsh(p.Y, 1)
+	twoY.Mod(twoY, P)
+
+	inv := new(big.Int).ModInverse(twoY, P)
+	s.Mul(s, inv)
+	s.Mod(s, P)
+
+	s2 := new(big.Int).Mul(s, s)
+	s2.Mod(s2, P)
+
+	xr := new(big.Int).Sub(s2, new(big.Int).Lsh(p.X, 1))
+	xr.Mod(xr, P)
+
+	yr := new(big.Int).Sub(p.X, xr)
+	yr.Mul(yr, s)
+	yr.Sub(yr, p.Y)
+	yr.Mod(yr, P)
+
+	return Point{X: xr, Y: yr}
+}
+
+// Mul multiplies a point by a scalar. k is not modified.
+func Mul(p Point, k *big.Int) Point {
+	result := Infinity()
+	addend := p
+
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = Add(result, addend)
+		}
+		addend = Double(addend)
+	}
+
+	return result
+}
+
+// LiftX returns the point with x-coordinate x and an even y-coordinate.
+func LiftX(x *big.Int) (Point, error) {
+	if x.Cmp(P) >= 0 {
+		return Point{}, ErrLiftXFailed
+	}
+
+	// y^2 = x^3 + 7 mod p
+	c := new(big.Int).Mul(x, x)
+	c.Mul(c, x)
+	c.Add(c, B)
+	c.Mod(c, P)
+
+	// y = c^((p+1)/4) mod p
+	y := new(big.Int).Exp(c, sqrtExp, P)
+
+	// Verify y^2 == c
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, P)
+	if y2.Cmp(c) != 0 {
+		return Point{}, ErrLiftXFailed
+	}
+
+	// Enforce even Y
+	if y.Bit(0) == 1 {
+		y.Sub(P, y)
+	}
+
+	return Point{X: x, Y: y}, nil
+}
+
+// TaggedHash returns SHA256(SHA256(tag) || SHA256(tag) || data), the
+// BIP-340 tagged hash.
+func TaggedHash(tag string, data ...[]byte) [32]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	var out [32]byte
+	h.Sum(out[:0])
+	return out
+}
+
+// Challenge computes the BIP-340 challenge hash of r, the public key and the
+// message, not reduced modulo n.
+func Challenge(r *big.Int, pubX, msg []byte) *big.Int {
+	rBytes := make([]byte, 32)
+	r.FillBytes(rBytes)
+	h := TaggedHash("BIP0340/challenge", rBytes, pubX, msg)
+	return new(big.Int).SetBytes(h[:])
+}
+
+// Verify verifies a BIP-340 signature of msg by the x-only public key pubX.
+func Verify(msg, sig, pubX []byte) bool {
+	if len(sig) != 64 || len(pubX) != 32 {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(P) >= 0 || s.Cmp(N) >= 0 {
+		return false
+	}
+
+	R, ok := nonceFor(s, r, pubX, msg)
+	if !ok {
+		return false
+	}
+
+	// Require even Y and x(R) == r
+	return R.Y.Bit(0) == 0 && R.X.Cmp(r) == 0
+}
+
+// nonceFor computes s·G - e·P, the nonce point a signature with scalar s
+// and challenge nonce r commits to.
+func nonceFor(s, r *big.Int, pubX, msg []byte) (Point, bool) {
+	pk, err := LiftX(new(big.Int).SetBytes(pubX))
+	if err != nil {
+		return Point{}, false
+	}
+
+	// e = H_tag(bytes32(r) || bytes(P) || m) mod n
+	e := Challenge(r, pubX, msg)
+	e.Mod(e, N)
+
+	R := Add(MulG(s), Neg(Mul(pk, e)))
+	if R.IsInfinity() {
+		return Point{}, false
+	}
+	return R, true
+}
@@ -0,0 +1,211 @@
+package schnorr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// BIP-340 test vectors 0 and 1
+func TestSignVectors(t *testing.T) {
+	tests := []struct {
+		key, pub, aux, msg, sig string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000003",
+			"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		},
+		{
+			"B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+			"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		},
+	}
+	for i, tt := range tests {
+		d := new(big.Int).SetBytes(mustHex(tt.key))
+		pub, _ := PublicKey(d)
+		if !bytes.Equal(pub, mustHex(tt.pub)) {
+			t.Errorf("PublicKey %d failed. Expected %s, got %X", i, tt.pub, pub)
+		}
+		msg, want := mustHex(tt.msg), mustHex(tt.sig)
+		sig, err := Sign(d, msg, mustHex(tt.aux))
+		if err != nil || !bytes.Equal(sig, want) {
+			t.Errorf("Sign %d failed. Expected %s, got %X, %v", i, tt.sig, sig, err)
+		}
+		if !Verify(msg, want, pub) {
+			t.Errorf("Verify %d failed. Expected the vector to verify", i)
+		}
+		want[63] ^= 1
+		if Verify(msg, want, pub) {
+			t.Errorf("Verify %d failed. Expected a tampered signature to fail", i)
+		}
+	}
+}
+
+func TestAdaptor(t *testing.T) {
+	d := big.NewInt(0x5eed)
+	secret := big.NewInt(0xadd)
+	T := Mul(G(), secret)
+	pub, _ := PublicKey(d)
+	msg := []byte("swap 1 BTC for 20 ETH")
+
+	pre, err := AdaptorSign(d, msg, make([]byte, 32), T)
+	if err != nil {
+		t.Fatalf("AdaptorSign failed: %v", err)
+	}
+	if !VerifyAdaptor(msg, pre, pub, T) {
+		t.Fatal("VerifyAdaptor failed. Expected the pre-signature to verify")
+	}
+	if Verify(msg, pre, pub) {
+		t.Error("Verify failed. Expected the pre-signature not to be a valid signature")
+	}
+	if VerifyAdaptor(msg, pre, pub, G()) {
+		t.Error("VerifyAdaptor failed. Expected a different adaptor point to fail")
+	}
+	if VerifyAdaptor([]byte("other"), pre, pub, T) {
+		t.Error("VerifyAdaptor failed. Expected a different message to fail")
+	}
+
+	sig, _ := Adapt(pre, secret)
+	if !Verify(msg, sig, pub) {
+		t.Fatal("Verify failed. Expected the adapted signature to verify")
+	}
+	got, err := Extract(sig, pre)
+	if err != nil || got.Cmp(secret) != 0 {
+		t.Errorf("Extract failed. Expected %v, got %v, %v", secret, got, err)
+	}
+
+	other, _ := Sign(d, msg, make([]byte, 32))
+	if _, err := Extract(other, pre); err != ErrNonceMismatch {
+		t.Errorf("Extract failed. Expected ErrNonceMismatch, got %v", err)
+	}
+}
+
+func TestMulDoesNotModifyScalar(t *testing.T) {
+	k := big.NewInt(12345)
+	Mul(G(), k)
+	if k.Int64() != 12345 {
+		t.Errorf("Mul failed. Expected k unchanged, got %v", k)
+	}
+	if !Mul(G(), N).IsInfinity() {
+		t.Error("Mul failed. Expected n·G to be the point at infinity")
+	}
+}
+
+func TestMulG(t *testing.T) {
+	scalars := []*big.Int{big.NewInt(1), big.NewInt(15), big.NewInt(16), new(big.Int).Sub(N, big.NewInt(1)), new(big.Int).Add(N, big.NewInt(2))}
+	for i := 0; i < 8; i++ {
+		h := TaggedHash("test", []byte{byte(i)})
+		scalars = append(scalars, new(big.Int).SetBytes(h[:]))
+	}
+	for _, k := range scalars {
+		want := Mul(G(), k)
+		if got := MulG(k); got.X.Cmp(want.X) != 0 || got.Y.Cmp(want.Y) != 0 {
+			t.Errorf("MulG(%x) failed. Expected %x, got %x", k, want.Bytes(), got.Bytes())
+		}
+	}
+	if !MulG(new(big.Int)).IsInfinity() || !MulG(N).IsInfinity() {
+		t.Error("MulG failed. Expected 0·G and n·G to be the point at infinity")
+	}
+}
+
+// BenchmarkMulG measures k·G; compare with -tags gtable.
+func BenchmarkMulG(b *testing.B) {
+	h := TaggedHash("bench")
+	k := new(big.Int).SetBytes(h[:])
+	for i := 0; i < b.N; i++ {
+		MulG(k)
+	}
+}
+
+// batch signs n messages with distinct keys.
+func batch(t testing.TB, n int) []BatchEntry {
+	entries := make([]BatchEntry, n)
+	for i := range entries {
+		d := big.NewInt(int64(1000 + i))
+		pub, err := PublicKey(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := bytes.Repeat([]byte{byte(i)}, 32)
+		sig, err := Sign(d, msg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[i] = BatchEntry{PubX: pub, Msg: msg, Sig: sig}
+	}
+	return entries
+}
+
+func TestVerifyBatch(t *testing.T) {
+	entries := batch(t, 5)
+	if !VerifyBatch(entries) || !VerifyBatch(entries[:1]) || !VerifyBatch(nil) {
+		t.Fatal("VerifyBatch failed. Expected valid signatures to verify")
+	}
+
+	// Corrupting any one signature fails the whole batch
+	for i := range entries {
+		bad := append([]BatchEntry(nil), entries...)
+		bad[i].Msg = []byte("other")
+		if VerifyBatch(bad) {
+			t.Errorf("VerifyBatch failed. Expected entry %d with another message to fail", i)
+		}
+	}
+
+	// Two invalid signatures whose errors cancel in an unweighted sum
+	bad := append([]BatchEntry(nil), entries[:2]...)
+	s0 := new(big.Int).SetBytes(bad[0].Sig[32:])
+	s1 := new(big.Int).SetBytes(bad[1].Sig[32:])
+	s0.Add(s0, big.NewInt(1))
+	s1.Sub(s1, big.NewInt(1))
+	bad[0].Sig = append(bad[0].Sig[:32:32], s0.FillBytes(make([]byte, 32))...)
+	bad[1].Sig = append(bad[1].Sig[:32:32], s1.FillBytes(make([]byte, 32))...)
+	if VerifyBatch(bad) {
+		t.Error("VerifyBatch failed. Expected offsetting invalid signatures to fail")
+	}
+
+	short := append([]BatchEntry(nil), entries...)
+	short[2].Sig = short[2].Sig[:63]
+	if VerifyBatch(short) {
+		t.Error("VerifyBatch failed. Expected a short signature to fail")
+	}
+}
+
+// BenchmarkVerify and BenchmarkVerifyBatch compare verifying 16
+// signatures one by one and as a batch. Verification is pure computation,
+// so the ratio of their times is roughly the ratio of the ink they use.
+func BenchmarkVerify(b *testing.B) {
+	entries := batch(b, 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, e := range entries {
+			if !Verify(e.Msg, e.Sig, e.PubX) {
+				b.Fatal("Verify failed")
+			}
+		}
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	entries := batch(b, 16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !VerifyBatch(entries) {
+			b.Fatal("VerifyBatch failed")
+		}
+	}
+}
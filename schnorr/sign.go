@@ -0,0 +1,105 @@
+package schnorr
+
+import "math/big"
+
+// Signing happens off-chain: contracts only verify. These helpers exist for
+// tests, scripts and tooling that produce the signatures contracts check,
+// and are not constant time.
+
+// PublicKey returns the 32-byte x-only public key of the secret key d.
+func PublicKey(d *big.Int) ([]byte, error) {
+	if d.Sign() <= 0 || d.Cmp(N) >= 0 {
+		return nil, ErrScalarOutOfRange
+	}
+	pub := make([]byte, 32)
+	MulG(d).X.FillBytes(pub)
+	return pub, nil
+}
+
+// Sign produces a BIP-340 signature of msg with the secret key d and
+// auxiliary randomness aux (32 bytes).
+func Sign(d *big.Int, msg, aux []byte) ([]byte, error) {
+	d, pub, err := signingKey(d)
+	if err != nil {
+		return nil, err
+	}
+
+	// t = bytes(d) xor hash_aux(a)
+	auxHash := TaggedHash("BIP0340/aux", aux)
+	t := make([]byte, 32)
+	d.FillBytes(t)
+	for i := range t {
+		t[i] ^= auxHash[i]
+	}
+
+	rand := TaggedHash("BIP0340/nonce", t, pub, msg)
+	k := new(big.Int).SetBytes(rand[:])
+	k.Mod(k, N)
+	if k.Sign() == 0 {
+		return nil, ErrScalarOutOfRange
+	}
+	R := MulG(k)
+	if R.Y.Bit(0) == 1 {
+		k.Sub(N, k)
+	}
+	return finish(R.X, k, d, pub, msg), nil
+}
+
+// AdaptorSign produces a pre-signature of msg with the secret key d for the
+// adaptor point T. Adapt with t = log(T) completes it.
+func AdaptorSign(d *big.Int, msg, aux []byte, T Point) ([]byte, error) {
+	if T.IsInfinity() || !IsOnCurve(T) {
+		return nil, ErrInfinityPoint
+	}
+	d, pub, err := signingKey(d)
+	if err != nil {
+		return nil, err
+	}
+
+	// The nonce cannot simply be negated as in Sign since R' = R + T must
+	// have even y, so retry with a counter until it does.
+	seed := make([]byte, 32)
+	d.FillBytes(seed)
+	for ctr := byte(0); ; ctr++ {
+		rand := TaggedHash("BIP0340/nonce", seed, pub, msg, aux, T.Bytes(), []byte{ctr})
+		k := new(big.Int).SetBytes(rand[:])
+		k.Mod(k, N)
+		if k.Sign() == 0 {
+			continue
+		}
+		Rp := Add(MulG(k), T)
+		if Rp.IsInfinity() || Rp.Y.Bit(0) == 1 {
+			continue
+		}
+		return finish(Rp.X, k, d, pub, msg), nil
+	}
+}
+
+// signingKey returns d negated if needed so that d·G has even y, and the
+// x-only public key.
+func signingKey(d *big.Int) (*big.Int, []byte, error) {
+	if d.Sign() <= 0 || d.Cmp(N) >= 0 {
+		return nil, nil, ErrScalarOutOfRange
+	}
+	pk := MulG(d)
+	if pk.Y.Bit(0) == 1 {
+		d = new(big.Int).Sub(N, d)
+	}
+	pub := make([]byte, 32)
+	pk.X.FillBytes(pub)
+	return d, pub, nil
+}
+
+// finish returns bytes(r) || bytes(k + e·d mod n).
+func finish(r, k, d *big.Int, pub, msg []byte) []byte {
+	e := Challenge(r, pub, msg)
+	e.Mod(e, N)
+	s := new(big.Int).Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, N)
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig
+}
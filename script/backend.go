@@ -0,0 +1,150 @@
+package script
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/rlp"
+)
+
+// Backend is the chain a script runs on: MockBackend for tests, RPCBackend
+// for a dev node or a live chain.
+type Backend interface {
+	// Sender returns the account transactions are sent from.
+	Sender() stygos.Address
+
+	// ChainID returns the id of the chain, recorded in the journal.
+	ChainID() (uint64, error)
+
+	// Balance returns the balance of an account in wei.
+	Balance(addr stygos.Address) (*big.Int, error)
+
+	// Call runs tx from the sender without broadcasting it and returns its
+	// return data.
+	Call(tx Tx) ([]byte, error)
+
+	// ActivationFee estimates the data fee of activating the compressed
+	// program code.
+	ActivationFee(code []byte) (*big.Int, error)
+
+	// StorageAt returns the storage slot key of contract.
+	StorageAt(contract stygos.Address, key stygos.Word) (stygos.Word, error)
+
+	// Code returns the code deployed at addr: for a Stylus program, its
+	// compressed wasm behind the Stylus prefix.
+	Code(addr stygos.Address) ([]byte, error)
+
+	// Send signs tx, passes the transaction hash and its signed encoding
+	// to sent before broadcasting it, and waits for the receipt. An error
+	// from sent cancels the broadcast.
+	Send(tx Tx, sent func(hash stygos.Word, raw []byte) error) (Receipt, error)
+
+	// Resume waits for a transaction sent before, broadcasting raw again
+	// if the node does not know it.
+	Resume(hash stygos.Word, raw []byte) (Receipt, error)
+}
+
+// Tx is a transaction to send or call.
+type Tx struct {
+	To    *stygos.Address // nil to deploy Data as init code
+	Data  []byte
+	Value *big.Int // nil for none
+}
+
+// Receipt is the outcome of a mined transaction.
+type Receipt struct {
+	TxHash   stygos.Word
+	Status   bool           // false if the transaction reverted
+	Contract stygos.Address // deployed contract, zero for calls
+	Block    uint64
+	GasUsed  uint64
+}
+
+// RPCError is an error returned by a JSON-RPC node, such as a revert.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("script: rpc error %d: %s", e.Code, e.Message)
+}
+
+// RevertData returns the revert data of an execution error, or nil.
+func (e *RPCError) RevertData() []byte {
+	s, ok := e.Data.(string)
+	if !ok || len(s) < 2 || s[:2] != "0x" {
+		return nil
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// stylusPrefix marks a contract's code as a Stylus program: the 0xEFF000
+// discriminant followed by the brotli dictionary id, 0 for none.
+var stylusPrefix = []byte{0xef, 0xf0, 0x00, 0x00}
+
+// deployPreludeSize is the length of the init code before the program.
+const deployPreludeSize = 43
+
+// deployPrelude is the init code cargo stylus sends, after a PUSH32 of the
+// program size: copy the program that follows into memory and return it.
+var deployPrelude = []byte{
+	0x80,                    // DUP1
+	0x60, deployPreludeSize, // PUSH1 offset
+	0x60, 0x00, // PUSH1 0
+	0x39,       // CODECOPY
+	0x60, 0x00, // PUSH1 0
+	0xf3, // RETURN
+	0x00, // version
+}
+
+// DeployCode returns the init code deploying a Stylus program from its
+// brotli-compressed wasm.
+func DeployCode(compressed []byte) []byte {
+	size := stygos.WordFromUint64(uint64(len(stylusPrefix) + len(compressed)))
+	code := make([]byte, 0, deployPreludeSize+len(stylusPrefix)+len(compressed))
+	code = append(code, 0x7f) // PUSH32
+	code = append(code, size[:]...)
+	code = append(code, deployPrelude...)
+	code = append(code, stylusPrefix...)
+	return append(code, compressed...)
+}
+
+// programOf returns the compressed wasm deployed by init code built by
+// DeployCode, or false.
+func programOf(initCode []byte) ([]byte, bool) {
+	if len(initCode) < deployPreludeSize+len(stylusPrefix) || initCode[0] != 0x7f {
+		return nil, false
+	}
+	if !bytes.Equal(initCode[33:deployPreludeSize], deployPrelude) ||
+		!bytes.Equal(initCode[deployPreludeSize:deployPreludeSize+len(stylusPrefix)], stylusPrefix) {
+		return nil, false
+	}
+	return initCode[deployPreludeSize+len(stylusPrefix):], true
+}
+
+// DeployedProgram returns the brotli-compressed wasm of a Stylus program
+// from the code deployed for it, or false if code is not a Stylus program.
+func DeployedProgram(code []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(code, stylusPrefix) {
+		return nil, false
+	}
+	return code[len(stylusPrefix):], true
+}
+
+// CreateAddress returns the address of the contract deployed by from with
+// the given nonce, keccak256(rlp([from, nonce]))[12:].
+func CreateAddress(from stygos.Address, nonce uint64) stygos.Address {
+	h := stygos.Keccak256(rlp.EncodeList(rlp.EncodeString(from[:]), rlp.AppendUint(nil, nonce)))
+	var addr stygos.Address
+	copy(addr[:], h[12:])
+	return addr
+}
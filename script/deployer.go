@@ -0,0 +1,194 @@
+package script
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// StylusDeployerAddress is the StylusDeployer contract of Arbitrum One,
+// Nova and Sepolia, deployed at the same address on each. It deploys,
+// activates and initializes a program in a single transaction.
+var StylusDeployerAddress = stygos.Address{
+	0xce, 0xcb, 0xa2, 0xf1, 0xdc, 0x23, 0x4f, 0x70, 0xdd, 0x89,
+	0xf2, 0x04, 0x10, 0x29, 0x80, 0x7f, 0x8d, 0x03, 0xa9, 0x90,
+}
+
+// StylusDeployer selectors and errors
+var (
+	selDeploy                 = stygos.Selector{0xa9, 0xa8, 0xe4, 0xe9} // deploy(bytes,bytes,uint256,bytes32)
+	errContractInitialization = []byte{0x88, 0xd8, 0xf5, 0x7d}          // ContractInitializationError(address,bytes)
+	errContractDeployment     = []byte{0x79, 0x4c, 0x92, 0xce}          // ContractDeploymentError(bytes)
+)
+
+// Deployment errors
+var (
+	ErrInitFailed   = errors.New("script: initializer reverted, nothing was deployed")
+	ErrDeployFailed = errors.New("script: deployment failed, nothing was deployed")
+)
+
+// InitOptions configure DeployAndInit.
+type InitOptions struct {
+	Value *big.Int    // wei passed to the initializer, nil for none
+	Salt  stygos.Word // CREATE2 salt, zero to derive it from the step name
+}
+
+// DeployAndInit deploys a program, activates it and calls it with
+// initData in one transaction through the StylusDeployer, as the step
+// name. Unlike Deploy followed by Initialize, nobody can call the program
+// between its deployment and its initialization, so an initializer that
+// sets the owner cannot be front-run. code is the brotli-compressed wasm.
+//
+// The initializer runs with the StylusDeployer as msg.sender: it must take
+// the owner and any other account as arguments rather than use the caller.
+//
+// The transaction is first run with eth_call. If the initializer reverts
+// there or on chain, the whole deployment is rolled back and the error,
+// wrapping ErrInitFailed or ErrDeployFailed, reports the revert data.
+func (s *Script) DeployAndInit(name string, code, initData []byte, opts InitOptions) (stygos.Address, error) {
+	deployer := s.Deployer
+	if deployer == (stygos.Address{}) {
+		deployer = StylusDeployerAddress
+	}
+	salt := opts.Salt
+	if salt == (stygos.Word{}) {
+		salt = stygos.Keccak256([]byte(name))
+	}
+	initValue := new(big.Int)
+	if opts.Value != nil {
+		initValue.Set(opts.Value)
+	}
+
+	// The deployer salts CREATE2 with the init data, so the address is
+	// only reachable with these arguments
+	bytecode := DeployCode(code)
+	addr := Create2Address(deployer, stygos.Keccak256(append(salt[:], initData...)), stygos.Keccak256(bytecode))
+	data := encodeDeploy(bytecode, initData, initValue, salt)
+
+	if s.Journal != nil && s.Journal.Entry(name) != nil {
+		_, err := s.Send(name, Tx{})
+		return addr, err
+	}
+
+	fee, err := s.Backend.ActivationFee(code)
+	if err != nil {
+		return stygos.Address{}, err
+	}
+	fee.Mul(fee, big.NewInt(100+activationBump))
+	fee.Div(fee, big.NewInt(100))
+	tx := Tx{To: &deployer, Data: data, Value: fee.Add(fee, initValue)}
+
+	ret, err := s.Backend.Call(tx)
+	if err != nil {
+		return stygos.Address{}, deployError(name, err)
+	}
+	if len(ret) < 32 || !bytes.Equal(ret[12:32], addr[:]) {
+		return stygos.Address{}, fmt.Errorf("%w: %s: deployer returned %x, want %s", ErrDeployFailed, name, ret, addr.Hex())
+	}
+
+	if _, err := s.Send(name, tx); err != nil {
+		if errors.Is(err, ErrReverted) {
+			return stygos.Address{}, fmt.Errorf("%w: %s: the deployment reverted on chain after succeeding in eth_call", ErrDeployFailed, name)
+		}
+		return stygos.Address{}, err
+	}
+	s.logf("%s: deployed and initialized at %s\n", name, addr.Hex())
+	return addr, nil
+}
+
+// deployError describes the revert of a StylusDeployer call.
+func deployError(name string, err error) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		if errors.Is(err, stygos.ErrRevert) {
+			return fmt.Errorf("%w: %s", ErrDeployFailed, name)
+		}
+		return err
+	}
+	data := rpcErr.RevertData()
+	switch {
+	case bytes.HasPrefix(data, errContractInitialization):
+		return fmt.Errorf("%w: %s: init reverted with %x", ErrInitFailed, name, revertReason(data, 1))
+	case bytes.HasPrefix(data, errContractDeployment):
+		return fmt.Errorf("%w: %s: CREATE2 failed, the salt may be taken", ErrDeployFailed, name)
+	}
+	return fmt.Errorf("%w: %s: %s %x", ErrDeployFailed, name, rpcErr.Message, data)
+}
+
+// revertReason returns the dynamic bytes argument at index i of an error's
+// ABI encoding, or the whole data if it is malformed.
+func revertReason(data []byte, i int) []byte {
+	args := data[4:]
+	b, ok := bytesArg(args, i)
+	if !ok {
+		return data
+	}
+	return b
+}
+
+// encodeDeploy encodes deploy(bytecode, initData, initValue, salt).
+func encodeDeploy(bytecode, initData []byte, initValue *big.Int, salt stygos.Word) []byte {
+	tail1 := 4 * 32
+	tail2 := tail1 + 32 + padded(len(bytecode))
+	data := make([]byte, 0, 4+tail2+32+padded(len(initData)))
+	data = append(data, selDeploy[:]...)
+	for _, w := range []stygos.Word{
+		stygos.WordFromUint64(uint64(tail1)),
+		stygos.WordFromUint64(uint64(tail2)),
+		stygos.WordFromBigInt(initValue),
+		salt,
+	} {
+		data = append(data, w[:]...)
+	}
+	data = appendBytesArg(data, bytecode)
+	return appendBytesArg(data, initData)
+}
+
+// appendBytesArg appends the length and right-padded contents of b.
+func appendBytesArg(data, b []byte) []byte {
+	length := stygos.WordFromUint64(uint64(len(b)))
+	data = append(data, length[:]...)
+	data = append(data, b...)
+	return append(data, make([]byte, padded(len(b))-len(b))...)
+}
+
+// bytesArg decodes the dynamic bytes argument at index i of ABI encoded
+// arguments.
+func bytesArg(args []byte, i int) ([]byte, bool) {
+	if len(args) < 32*(i+1) {
+		return nil, false
+	}
+	var w stygos.Word
+	copy(w[:], args[32*i:])
+	off := stygos.Uint64FromWord(w)
+	if w != stygos.WordFromUint64(off) || off > uint64(len(args)) || uint64(len(args))-off < 32 {
+		return nil, false
+	}
+	copy(w[:], args[off:])
+	n := stygos.Uint64FromWord(w)
+	if w != stygos.WordFromUint64(n) || n > uint64(len(args))-off-32 {
+		return nil, false
+	}
+	return args[off+32 : off+32+n], true
+}
+
+func padded(n int) int {
+	return (n + 31) / 32 * 32
+}
+
+// Create2Address returns the address of a contract deployed by deployer
+// with CREATE2, keccak256(0xff ++ deployer ++ salt ++ initCodeHash)[12:].
+func Create2Address(deployer stygos.Address, salt, initCodeHash stygos.Word) stygos.Address {
+	buf := make([]byte, 0, 85)
+	buf = append(buf, 0xff)
+	buf = append(buf, deployer[:]...)
+	buf = append(buf, salt[:]...)
+	buf = append(buf, initCodeHash[:]...)
+	h := stygos.Keccak256(buf)
+	var addr stygos.Address
+	copy(addr[:], h[12:])
+	return addr
+}
@@ -0,0 +1,146 @@
+package script
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// ErrJournalChain is returned when a journal was written on another chain.
+var ErrJournalChain = errors.New("script: journal belongs to another chain")
+
+// Journal records the transactions of a script's steps in a JSON file, so
+// running the script again resumes it instead of broadcasting twice. The
+// file is rewritten after every change.
+type Journal struct {
+	path    string
+	ChainID uint64   `json:"chainId"`
+	Steps   []*Entry `json:"steps"`
+}
+
+// Entry is the journaled state of a step. An entry is written when its
+// transaction is signed, before it is broadcast, and marked done with the
+// receipt once mined.
+type Entry struct {
+	Step     string         `json:"step"`
+	TxHash   stygos.Word    `json:"txHash"`
+	Raw      []byte         `json:"-"`
+	RawHex   string         `json:"raw,omitempty"`
+	Done     bool           `json:"done"`
+	Contract stygos.Address `json:"contract,omitempty"`
+	Block    uint64         `json:"block,omitempty"`
+	GasUsed  uint64         `json:"gasUsed,omitempty"`
+}
+
+// Receipt returns the receipt of a done step.
+func (e *Entry) Receipt() Receipt {
+	return Receipt{TxHash: e.TxHash, Status: e.Done, Contract: e.Contract, Block: e.Block, GasUsed: e.GasUsed}
+}
+
+// OpenJournal reads the journal at path, or starts an empty one if the
+// file does not exist. It fails with ErrJournalChain if the journal was
+// written on a chain other than chainID.
+func OpenJournal(path string, chainID uint64) (*Journal, error) {
+	j := &Journal{path: path, ChainID: chainID}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, err
+	}
+	if j.ChainID != chainID {
+		return nil, ErrJournalChain
+	}
+	for _, e := range j.Steps {
+		if e.Raw, err = hex.DecodeString(strings.TrimPrefix(e.RawHex, "0x")); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// Entry returns the entry of step, or nil.
+func (j *Journal) Entry(step string) *Entry {
+	for _, e := range j.Steps {
+		if e.Step == step {
+			return e
+		}
+	}
+	return nil
+}
+
+// Sent records that the transaction of step was signed and is about to be
+// broadcast.
+func (j *Journal) Sent(step string, hash stygos.Word, raw []byte) error {
+	e := j.Entry(step)
+	if e == nil {
+		e = &Entry{Step: step}
+		j.Steps = append(j.Steps, e)
+	}
+	*e = Entry{Step: step, TxHash: hash, Raw: raw}
+	if raw != nil {
+		e.RawHex = "0x" + hex.EncodeToString(raw)
+	}
+	return j.save()
+}
+
+// Mined records the receipt of step, marking it done.
+func (j *Journal) Mined(step string, r Receipt) error {
+	e := j.Entry(step)
+	if e == nil {
+		e = &Entry{Step: step}
+		j.Steps = append(j.Steps, e)
+	}
+	e.TxHash, e.Done = r.TxHash, true
+	e.Contract, e.Block, e.GasUsed = r.Contract, r.Block, r.GasUsed
+	e.Raw, e.RawHex = nil, ""
+	return j.save()
+}
+
+// Remove forgets step, so it runs again.
+func (j *Journal) Remove(step string) error {
+	for i, e := range j.Steps {
+		if e.Step == step {
+			j.Steps = append(j.Steps[:i], j.Steps[i+1:]...)
+			return j.save()
+		}
+	}
+	return nil
+}
+
+// save writes the journal to a temporary file and renames it over the
+// journal, so a crash leaves the old or the new journal but never half.
+func (j *Journal) save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
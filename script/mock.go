@@ -0,0 +1,298 @@
+//go:build !tinygo
+
+package script
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+)
+
+// Mock backend errors
+var (
+	ErrUnknownProgram = errors.New("script: no mock contract registered for the deployed code")
+	ErrUnknownTx      = errors.New("script: unknown transaction")
+)
+
+// MockBackend runs scripts on a stygos.MockRuntime. Programs cannot run
+// from their wasm on the mock, so each is registered with the MockContract
+// that stands in for it; deploying the registered code deploys that
+// contract at the CREATE address. ArbWasm is mocked to activate any
+// deployed program for free.
+type MockBackend struct {
+	Runtime *stygos.MockRuntime
+	From    stygos.Address
+
+	nonce    uint64
+	programs map[stygos.Word]stygos.MockContract // by hash of the compressed code
+	deployed map[stygos.Address]bool
+	code     map[stygos.Address][]byte // deployed code, Stylus prefix included
+	receipts map[stygos.Word]Receipt
+}
+
+// NewMockBackend returns a backend sending from from on rt.
+func NewMockBackend(rt *stygos.MockRuntime, from stygos.Address) *MockBackend {
+	b := &MockBackend{
+		Runtime:  rt,
+		From:     from,
+		programs: make(map[stygos.Word]stygos.MockContract),
+		deployed: make(map[stygos.Address]bool),
+		code:     make(map[stygos.Address][]byte),
+		receipts: make(map[stygos.Word]Receipt),
+	}
+	rt.Deploy(arb.ArbWasmAddress, b.arbWasm)
+	rt.Deploy(StylusDeployerAddress, b.stylusDeployer)
+	return b
+}
+
+// Register makes deploying the compressed wasm code deploy contract.
+func (b *MockBackend) Register(code []byte, contract stygos.MockContract) {
+	b.programs[stygos.Keccak256(code)] = contract
+}
+
+// Sender returns the account transactions are sent from.
+func (b *MockBackend) Sender() stygos.Address {
+	return b.From
+}
+
+// ChainID returns the chain id of the runtime.
+func (b *MockBackend) ChainID() (uint64, error) {
+	return b.Runtime.Chain, nil
+}
+
+// Balance returns the balance of addr on the runtime.
+func (b *MockBackend) Balance(addr stygos.Address) (*big.Int, error) {
+	return b.Runtime.BalanceOf(addr), nil
+}
+
+// Call runs tx from the sender like eth_call: state changes, moved value
+// and logs are rolled back afterwards. A revert is returned as an
+// *RPCError carrying the revert data, as a node returns it.
+func (b *MockBackend) Call(tx Tx) ([]byte, error) {
+	if tx.To == nil {
+		return nil, ErrUnknownProgram
+	}
+	var value stygos.Word
+	if tx.Value != nil {
+		value = stygos.WordFromBigInt(tx.Value)
+	}
+	defer b.snapshot()()
+
+	var out []byte
+	var err error
+	b.asSender(func() {
+		out, err = stygos.Call(*tx.To, value, tx.Data)
+	})
+	if errors.Is(err, stygos.ErrRevert) {
+		return nil, &RPCError{Code: 3, Message: "execution reverted", Data: "0x" + hex.EncodeToString(out)}
+	}
+	return out, err
+}
+
+// ActivationFee returns zero: the mock ArbWasm activates for free.
+func (b *MockBackend) ActivationFee(code []byte) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+// StorageAt returns the storage slot key of contract.
+func (b *MockBackend) StorageAt(contract stygos.Address, key stygos.Word) (stygos.Word, error) {
+	return b.Runtime.StorageOf(contract)[key], nil
+}
+
+// Code returns the code deployed at addr through this backend, the
+// compressed program behind the Stylus prefix, or nil.
+func (b *MockBackend) Code(addr stygos.Address) ([]byte, error) {
+	return b.code[addr], nil
+}
+
+// Send runs tx in a block of its own. Reverted calls return a receipt with
+// Status false, their changes rolled back by the runtime.
+func (b *MockBackend) Send(tx Tx, sent func(hash stygos.Word, raw []byte) error) (Receipt, error) {
+	var nonce [8]byte
+	binary.BigEndian.PutUint64(nonce[:], b.nonce)
+	hash := stygos.Keccak256(append(append([]byte("mock tx"), b.From[:]...), nonce[:]...))
+
+	var contract stygos.MockContract
+	var program []byte
+	if tx.To == nil {
+		var ok bool
+		program, ok = programOf(tx.Data)
+		if !ok {
+			return Receipt{}, ErrUnknownProgram
+		}
+		if contract, ok = b.programs[stygos.Keccak256(program)]; !ok {
+			return Receipt{}, ErrUnknownProgram
+		}
+	}
+	if err := sent(hash, nil); err != nil {
+		return Receipt{}, err
+	}
+
+	rt := b.Runtime
+	rt.Block++
+	r := Receipt{TxHash: hash, Status: true, Block: rt.Block}
+	if tx.To == nil {
+		r.Contract = CreateAddress(b.From, b.nonce)
+		rt.Deploy(r.Contract, contract)
+		b.deployed[r.Contract] = true
+		b.code[r.Contract] = append(append([]byte(nil), stylusPrefix...), program...)
+	} else {
+		var value stygos.Word
+		if tx.Value != nil {
+			value = stygos.WordFromBigInt(tx.Value)
+		}
+		var err error
+		b.asSender(func() {
+			_, err = stygos.Call(*tx.To, value, tx.Data)
+		})
+		r.Status = err == nil
+	}
+	b.nonce++
+	b.receipts[hash] = r
+	return r, nil
+}
+
+// Resume returns the receipt of a transaction sent on this backend.
+func (b *MockBackend) Resume(hash stygos.Word, raw []byte) (Receipt, error) {
+	r, ok := b.receipts[hash]
+	if !ok {
+		return Receipt{}, ErrUnknownTx
+	}
+	return r, nil
+}
+
+// asSender runs f with the runtime executing as the sender's account, so
+// calls made by f come from the sender.
+func (b *MockBackend) asSender(f func()) {
+	rt := b.Runtime
+	stygos.UseRuntime(rt)
+	contract, storage, sender := rt.Contract, rt.Storage, rt.Sender
+	rt.Storage = rt.StorageOf(b.From)
+	rt.Contract, rt.Sender = b.From, b.From
+	defer func() {
+		rt.Contract, rt.Storage, rt.Sender = contract, storage, sender
+	}()
+	f()
+}
+
+// snapshot saves the state a call can change and returns a function
+// restoring it. Storage maps are restored in place, as the runtime may
+// hold them.
+func (b *MockBackend) snapshot() func() {
+	rt := b.Runtime
+	contracts := make(map[stygos.Address]stygos.MockContract, len(rt.Contracts))
+	storage := make(map[stygos.Address]map[[32]byte][32]byte, len(rt.Contracts)+1)
+	save := func(addr stygos.Address) {
+		saved := make(map[[32]byte][32]byte)
+		for k, v := range rt.StorageOf(addr) {
+			saved[k] = v
+		}
+		storage[addr] = saved
+	}
+	for addr, c := range rt.Contracts {
+		contracts[addr] = c
+		save(addr)
+	}
+	save(b.From)
+	balances := make(map[stygos.Address]*big.Int, len(rt.Balances))
+	for addr, wei := range rt.Balances {
+		balances[addr] = new(big.Int).Set(wei)
+	}
+	deployed := make(map[stygos.Address]bool, len(b.deployed))
+	for addr := range b.deployed {
+		deployed[addr] = true
+	}
+	logs := len(rt.Logs)
+
+	return func() {
+		for addr := range rt.Contracts {
+			if _, ok := contracts[addr]; !ok {
+				storage[addr] = nil
+			}
+		}
+		for addr, saved := range storage {
+			live := rt.StorageOf(addr)
+			for k := range live {
+				delete(live, k)
+			}
+			for k, v := range saved {
+				live[k] = v
+			}
+		}
+		rt.Contracts, rt.Balances, b.deployed = contracts, balances, deployed
+		rt.Logs = rt.Logs[:logs]
+	}
+}
+
+// arbWasm is the mock ArbWasm precompile. activateProgram returns version
+// 1 and no data fee for deployed programs and reverts for other addresses.
+func (b *MockBackend) arbWasm(input []byte) ([]byte, error) {
+	if len(input) != 36 || string(input[:4]) != string(selActivateProgram[:]) {
+		return nil, stygos.ErrRevert
+	}
+	var w stygos.Word
+	copy(w[:], input[4:])
+	if !b.deployed[stygos.AddressFromWord(w)] {
+		return nil, stygos.ErrRevert
+	}
+	version := stygos.WordFromUint64(1)
+	return append(version[:], make([]byte, 32)...), nil
+}
+
+// stylusDeployer is the mock StylusDeployer: it deploys the registered
+// contract of the bytecode at its CREATE2 address, activates it and calls
+// it with the init data, reverting everything if the call reverts.
+func (b *MockBackend) stylusDeployer(input []byte) ([]byte, error) {
+	if len(input) < 4+128 || string(input[:4]) != string(selDeploy[:]) {
+		return nil, stygos.ErrRevert
+	}
+	args := input[4:]
+	bytecode, ok1 := bytesArg(args, 0)
+	initData, ok2 := bytesArg(args, 1)
+	if !ok1 || !ok2 {
+		return nil, stygos.ErrRevert
+	}
+	var initValue, salt stygos.Word
+	copy(initValue[:], args[64:96])
+	copy(salt[:], args[96:128])
+
+	rt := b.Runtime
+	addr := Create2Address(StylusDeployerAddress, stygos.Keccak256(append(salt[:], initData...)), stygos.Keccak256(bytecode))
+	program, ok := programOf(bytecode)
+	if ok {
+		_, ok = b.programs[stygos.Keccak256(program)]
+	}
+	if _, taken := rt.Contracts[addr]; !ok || taken {
+		offset := stygos.WordFromUint64(32)
+		out := append(append([]byte(nil), errContractDeployment...), offset[:]...)
+		return appendBytesArg(out, bytecode), stygos.ErrRevert
+	}
+	rt.Deploy(addr, b.programs[stygos.Keccak256(program)])
+	b.deployed[addr] = true
+	b.code[addr] = append(append([]byte(nil), stylusPrefix...), program...)
+
+	if len(initData) > 0 {
+		if ret, err := stygos.Call(addr, initValue, initData); err != nil {
+			delete(rt.Contracts, addr)
+			delete(b.deployed, addr)
+			delete(b.code, addr)
+			head, offset := stygos.PadAddress(addr), stygos.WordFromUint64(64)
+			out := append(append([]byte(nil), errContractInitialization...), head[:]...)
+			return appendBytesArg(append(out, offset[:]...), ret), stygos.ErrRevert
+		}
+	}
+
+	// Refund what the activation did not use
+	excess := new(big.Int).Sub(stygos.GetMsgValue(), stygos.BigIntFromWord(initValue))
+	if excess.Sign() > 0 {
+		if _, err := stygos.Call(stygos.GetMsgSender(), stygos.WordFromBigInt(excess), nil); err != nil {
+			return nil, err
+		}
+	}
+	w := stygos.PadAddress(addr)
+	return w[:], nil
+}
@@ -0,0 +1,390 @@
+//go:build !tinygo
+
+package script
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/rlp"
+	"github.com/rafaelescrich/stygos/schnorr"
+)
+
+// RPC backend errors
+var (
+	ErrInvalidKey = errors.New("script: invalid private key")
+	ErrTimeout    = errors.New("script: timed out waiting for the receipt")
+)
+
+// Gas defaults of the RPC backend
+const (
+	gasBump             = 20 // percent added to eth_estimateGas
+	defaultPollInterval = time.Second
+	defaultTimeout      = 5 * time.Minute
+)
+
+// RPCBackend sends EIP-1559 transactions through a node's JSON-RPC API:
+// a nitro dev node (http://localhost:8547), a testnet or mainnet. It
+// signs with a private key held in memory.
+type RPCBackend struct {
+	URL          string
+	Client       *http.Client
+	PollInterval time.Duration // between receipt polls
+	Timeout      time.Duration // for a transaction to be mined
+
+	key     *big.Int
+	from    stygos.Address
+	chainID uint64
+	id      int
+}
+
+// ParseKey parses a hex private key, with or without 0x.
+func ParseKey(s string) (*big.Int, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil || len(b) != 32 {
+		return nil, ErrInvalidKey
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// Dial returns a backend sending from the account of key through the node
+// at url. With a nil key the backend is read-only: it calls and reads
+// state, and Send fails with ErrInvalidKey.
+func Dial(url string, key *big.Int) (*RPCBackend, error) {
+	b := &RPCBackend{
+		URL:          url,
+		Client:       http.DefaultClient,
+		PollInterval: defaultPollInterval,
+		Timeout:      defaultTimeout,
+		key:          key,
+	}
+	if key != nil {
+		if key.Sign() <= 0 || key.Cmp(schnorr.N) >= 0 {
+			return nil, ErrInvalidKey
+		}
+		b.from = ecdsa.AddressOf(key)
+	}
+	var id string
+	if err := b.call(&id, "eth_chainId"); err != nil {
+		return nil, err
+	}
+	chainID, err := parseQuantity(id)
+	if err != nil {
+		return nil, err
+	}
+	b.chainID = chainID.Uint64()
+	return b, nil
+}
+
+// Sender returns the address of the key.
+func (b *RPCBackend) Sender() stygos.Address {
+	return b.from
+}
+
+// ChainID returns the chain id reported by the node when dialing.
+func (b *RPCBackend) ChainID() (uint64, error) {
+	return b.chainID, nil
+}
+
+// Balance returns the latest balance of addr.
+func (b *RPCBackend) Balance(addr stygos.Address) (*big.Int, error) {
+	var s string
+	if err := b.call(&s, "eth_getBalance", addr.Hex(), "latest"); err != nil {
+		return nil, err
+	}
+	return parseQuantity(s)
+}
+
+// Call runs tx with eth_call on the latest block. Reverts are returned as
+// *RPCError.
+func (b *RPCBackend) Call(tx Tx) ([]byte, error) {
+	var s string
+	if err := b.call(&s, "eth_call", b.callArgs(tx), "latest"); err != nil {
+		return nil, err
+	}
+	return parseData(s)
+}
+
+// ActivationFee estimates the data fee of activating code by calling
+// ArbWasm.activateProgram on a scratch address overridden to hold the
+// program, from the sender with an overridden balance, as cargo stylus
+// does before deploying.
+func (b *RPCBackend) ActivationFee(code []byte) (*big.Int, error) {
+	program := "0x" + hex.EncodeToString(stylusPrefix) + hex.EncodeToString(code)
+	scratch := stygos.AddressFromWord(stygos.Keccak256([]byte("stygos activation estimate")))
+	data := make([]byte, 0, 36)
+	data = append(data, selActivateProgram[:]...)
+	word := stygos.PadAddress(scratch)
+	data = append(data, word[:]...)
+
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	args := b.callArgs(Tx{To: &arb.ArbWasmAddress, Data: data, Value: ether})
+	overrides := map[string]any{
+		scratch.Hex(): map[string]string{"code": program},
+		b.from.Hex():  map[string]string{"balance": "0x" + new(big.Int).Mul(ether, big.NewInt(1000)).Text(16)},
+	}
+	var s string
+	if err := b.call(&s, "eth_call", args, "latest", overrides); err != nil {
+		return nil, err
+	}
+	ret, err := parseData(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) < 64 {
+		return nil, arb.ErrBadReturn
+	}
+	return new(big.Int).SetBytes(ret[32:64]), nil
+}
+
+// StorageAt returns the latest value of the storage slot key of contract.
+func (b *RPCBackend) StorageAt(contract stygos.Address, key stygos.Word) (stygos.Word, error) {
+	var s string
+	if err := b.call(&s, "eth_getStorageAt", contract.Hex(), key.Hex(), "latest"); err != nil {
+		return stygos.Word{}, err
+	}
+	return stygos.WordFromHex(s)
+}
+
+// Code returns the latest code at addr.
+func (b *RPCBackend) Code(addr stygos.Address) ([]byte, error) {
+	var s string
+	if err := b.call(&s, "eth_getCode", addr.Hex(), "latest"); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+// Send estimates gas and fees, signs tx with the next pending nonce,
+// passes it to sent, broadcasts it and waits for the receipt.
+func (b *RPCBackend) Send(tx Tx, sent func(hash stygos.Word, raw []byte) error) (Receipt, error) {
+	if b.key == nil {
+		return Receipt{}, ErrInvalidKey
+	}
+	var nonceHex, tipHex, gasHex string
+	var head struct {
+		BaseFee string `json:"baseFeePerGas"`
+	}
+	if err := b.call(&nonceHex, "eth_getTransactionCount", b.from.Hex(), "pending"); err != nil {
+		return Receipt{}, err
+	}
+	if err := b.call(&tipHex, "eth_maxPriorityFeePerGas"); err != nil {
+		return Receipt{}, err
+	}
+	if err := b.call(&head, "eth_getBlockByNumber", "latest", false); err != nil {
+		return Receipt{}, err
+	}
+	if err := b.call(&gasHex, "eth_estimateGas", b.callArgs(tx)); err != nil {
+		return Receipt{}, err
+	}
+
+	nonce, err := parseQuantity(nonceHex)
+	if err != nil {
+		return Receipt{}, err
+	}
+	tip, err := parseQuantity(tipHex)
+	if err != nil {
+		return Receipt{}, err
+	}
+	baseFee, err := parseQuantity(head.BaseFee)
+	if err != nil {
+		return Receipt{}, err
+	}
+	gas, err := parseQuantity(gasHex)
+	if err != nil {
+		return Receipt{}, err
+	}
+
+	// Room for the base fee to double before the transaction is mined
+	feeCap := new(big.Int).Lsh(baseFee, 1)
+	feeCap.Add(feeCap, tip)
+	gasLimit := gas.Uint64() * (100 + gasBump) / 100
+
+	hash, raw, err := signTx(b.key, b.chainID, nonce.Uint64(), tip, feeCap, gasLimit, tx)
+	if err != nil {
+		return Receipt{}, err
+	}
+	if err := sent(hash, raw); err != nil {
+		return Receipt{}, err
+	}
+	if err := b.call(nil, "eth_sendRawTransaction", "0x"+hex.EncodeToString(raw)); err != nil {
+		return Receipt{}, err
+	}
+	return b.wait(hash)
+}
+
+// Resume waits for a transaction sent before. If the node has no receipt
+// it broadcasts raw again; a node that already has the transaction in its
+// pool reports so, which is not an error.
+func (b *RPCBackend) Resume(hash stygos.Word, raw []byte) (Receipt, error) {
+	r, ok, err := b.receipt(hash)
+	if err != nil || ok {
+		return r, err
+	}
+	if len(raw) > 0 {
+		err := b.call(nil, "eth_sendRawTransaction", "0x"+hex.EncodeToString(raw))
+		var rpcErr *RPCError
+		if err != nil && !(errors.As(err, &rpcErr) && strings.Contains(rpcErr.Message, "already known")) {
+			return Receipt{}, err
+		}
+	}
+	return b.wait(hash)
+}
+
+func (b *RPCBackend) wait(hash stygos.Word) (Receipt, error) {
+	deadline := time.Now().Add(b.Timeout)
+	for {
+		r, ok, err := b.receipt(hash)
+		if err != nil || ok {
+			return r, err
+		}
+		if time.Now().After(deadline) {
+			return Receipt{}, ErrTimeout
+		}
+		time.Sleep(b.PollInterval)
+	}
+}
+
+func (b *RPCBackend) receipt(hash stygos.Word) (Receipt, bool, error) {
+	var res *struct {
+		Status          string          `json:"status"`
+		ContractAddress *stygos.Address `json:"contractAddress"`
+		BlockNumber     string          `json:"blockNumber"`
+		GasUsed         string          `json:"gasUsed"`
+	}
+	if err := b.call(&res, "eth_getTransactionReceipt", hash.Hex()); err != nil || res == nil {
+		return Receipt{}, false, err
+	}
+	r := Receipt{TxHash: hash, Status: res.Status == "0x1"}
+	if res.ContractAddress != nil {
+		r.Contract = *res.ContractAddress
+	}
+	block, err := parseQuantity(res.BlockNumber)
+	if err != nil {
+		return Receipt{}, false, err
+	}
+	gasUsed, err := parseQuantity(res.GasUsed)
+	if err != nil {
+		return Receipt{}, false, err
+	}
+	r.Block, r.GasUsed = block.Uint64(), gasUsed.Uint64()
+	return r, true, nil
+}
+
+// callArgs returns the transaction object of eth_call and eth_estimateGas.
+func (b *RPCBackend) callArgs(tx Tx) map[string]string {
+	args := map[string]string{
+		"from": b.from.Hex(),
+		"data": "0x" + hex.EncodeToString(tx.Data),
+	}
+	if tx.To != nil {
+		args["to"] = tx.To.Hex()
+	}
+	if tx.Value != nil {
+		args["value"] = "0x" + tx.Value.Text(16)
+	}
+	return args
+}
+
+// call sends a JSON-RPC request and decodes its result into result, which
+// may be nil.
+func (b *RPCBackend) call(result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	b.id++
+	req, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      b.id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := b.Client.Post(b.URL, "application/json", bytes.NewReader(req))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  *RPCError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("script: %s: %s: %v", method, resp.Status, err)
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(res.Result, result)
+}
+
+// signTx signs an EIP-1559 transaction and returns its hash and encoding,
+// 0x02 || rlp([chainId, nonce, tip, feeCap, gas, to, value, data,
+// accessList, yParity, r, s]).
+func signTx(key *big.Int, chainID, nonce uint64, tip, feeCap *big.Int, gas uint64, tx Tx) (stygos.Word, []byte, error) {
+	var to []byte
+	if tx.To != nil {
+		to = tx.To[:]
+	}
+	value := new(big.Int)
+	if tx.Value != nil {
+		value = tx.Value
+	}
+	fields := [][]byte{
+		rlp.AppendUint(nil, chainID),
+		rlp.AppendUint(nil, nonce),
+		rlp.EncodeString(tip.Bytes()),
+		rlp.EncodeString(feeCap.Bytes()),
+		rlp.AppendUint(nil, gas),
+		rlp.EncodeString(to),
+		rlp.EncodeString(value.Bytes()),
+		rlp.EncodeString(tx.Data),
+		rlp.EncodeList(), // access list
+	}
+	sigHash := stygos.Keccak256(append([]byte{0x02}, rlp.EncodeList(fields...)...))
+	sig, err := ecdsa.Sign(key, sigHash)
+	if err != nil {
+		return stygos.Word{}, nil, err
+	}
+
+	fields = append(fields,
+		rlp.AppendUint(nil, uint64(sig.V-27)),
+		rlp.EncodeString(new(big.Int).SetBytes(sig.R[:]).Bytes()),
+		rlp.EncodeString(new(big.Int).SetBytes(sig.S[:]).Bytes()),
+	)
+	raw := append([]byte{0x02}, rlp.EncodeList(fields...)...)
+	return stygos.Keccak256(raw), raw, nil
+}
+
+// parseQuantity parses a JSON-RPC hex quantity.
+func parseQuantity(s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("script: invalid quantity %q", s)
+	}
+	return v, nil
+}
+
+// parseData parses JSON-RPC hex data.
+func parseData(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("script: invalid data %q", s)
+	}
+	return hex.DecodeString(s[2:])
+}
@@ -0,0 +1,234 @@
+// Package script runs Go deployment scripts for stygos contracts: deploy
+// and activate a program, initialize it with calldata, check its storage
+// and hand over ownership. It is host-side tooling, not contract code.
+//
+// A script is written once against a Backend and runs unchanged on the mock
+// runtime, a local nitro dev node or a live chain:
+//
+//	key, _ := script.ParseKey(os.Getenv("PRIVATE_KEY"))
+//	backend, err := script.Dial("http://localhost:8547", key)
+//	...
+//	s, err := script.New(backend, "deploy-412346.json")
+//	...
+//	counter, err := s.Deploy("counter", compressedWasm)
+//	err = s.Initialize("counter/init", counter, []byte{CMD_INITIALIZE})
+//	err = s.VerifyStorage(counter, slot, want)
+//	err = s.TransferOwnership("counter/owner", counter, multisig)
+//
+// Every step that broadcasts a transaction has a name, and the Journal
+// records its progress in a JSON file: the signed transaction before it is
+// sent, then its receipt. Running the script again skips the steps already
+// mined, waits for the ones sent but not yet mined, and sends the rest, so a
+// deployment interrupted halfway is finished by running it again. Reads
+// such as VerifyStorage always run.
+package script
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+)
+
+// Script errors
+var (
+	ErrReverted        = errors.New("script: transaction reverted")
+	ErrStorageMismatch = errors.New("script: unexpected storage value")
+	ErrOwnerMismatch   = errors.New("script: ownership was not transferred")
+	ErrNoContract      = errors.New("script: deployment created no contract")
+)
+
+// ArbWasm and Ownable selectors, and the ArbWasm error for active code
+var (
+	selActivateProgram   = stygos.Selector{0x58, 0xc7, 0x80, 0xc2} // activateProgram(address)
+	errProgramUpToDate   = []byte{0xcc, 0x94, 0x4b, 0xf2}          // ProgramUpToDate()
+	selTransferOwnership = stygos.Selector{0xf2, 0xfd, 0xe3, 0x8b} // transferOwnership(address)
+	selOwner             = stygos.Selector{0x8d, 0xa5, 0xcb, 0x5b} // owner()
+)
+
+// activationBump is the percentage added to the estimated activation data
+// fee, as cargo stylus does; ArbWasm refunds the excess.
+const activationBump = 20
+
+// Script runs deployment steps on a backend, journaling the transactions.
+type Script struct {
+	Backend Backend
+	Journal *Journal  // nil to run without journaling
+	Log     io.Writer // progress messages, nil for none
+
+	// Deployer is the StylusDeployer used by DeployAndInit, zero for
+	// StylusDeployerAddress.
+	Deployer stygos.Address
+}
+
+// New returns a script running on b and journaling to the file at path,
+// which is created if it does not exist. An empty path disables
+// journaling.
+func New(b Backend, path string) (*Script, error) {
+	s := &Script{Backend: b}
+	if path == "" {
+		return s, nil
+	}
+	chainID, err := b.ChainID()
+	if err != nil {
+		return nil, err
+	}
+	if s.Journal, err = OpenJournal(path, chainID); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Deploy deploys a Stylus program and activates it, as the steps name and
+// name+"/activate". code is the brotli-compressed wasm, as cargo stylus
+// deploys it. Activation is skipped when a program with the same code is
+// already active.
+func (s *Script) Deploy(name string, code []byte) (stygos.Address, error) {
+	r, err := s.Send(name, Tx{Data: DeployCode(code)})
+	if err != nil {
+		return stygos.Address{}, err
+	}
+	if r.Contract == (stygos.Address{}) {
+		return stygos.Address{}, ErrNoContract
+	}
+	if err := s.activate(name+"/activate", r.Contract, code); err != nil {
+		return stygos.Address{}, err
+	}
+	return r.Contract, nil
+}
+
+func (s *Script) activate(name string, program stygos.Address, code []byte) error {
+	data := make([]byte, 0, 36)
+	data = append(data, selActivateProgram[:]...)
+	word := stygos.PadAddress(program)
+	data = append(data, word[:]...)
+
+	// A journaled step resumes with the transaction already signed
+	if s.Journal != nil && s.Journal.Entry(name) != nil {
+		_, err := s.Send(name, Tx{})
+		return err
+	}
+
+	fee, err := s.Backend.ActivationFee(code)
+	if err != nil {
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) && bytes.HasPrefix(rpcErr.RevertData(), errProgramUpToDate) {
+			s.logf("%s: program already active\n", name)
+			return nil
+		}
+		return err
+	}
+	fee.Mul(fee, big.NewInt(100+activationBump))
+	fee.Div(fee, big.NewInt(100))
+	_, err = s.Send(name, Tx{To: &arb.ArbWasmAddress, Data: data, Value: fee})
+	return err
+}
+
+// Initialize sends calldata to an initializer of contract as the step name.
+func (s *Script) Initialize(name string, contract stygos.Address, calldata []byte) error {
+	_, err := s.Send(name, Tx{To: &contract, Data: calldata})
+	return err
+}
+
+// TransferOwnership calls transferOwnership(newOwner) on an Ownable
+// contract as the step name, then checks that owner() returns newOwner.
+func (s *Script) TransferOwnership(name string, contract, newOwner stygos.Address) error {
+	data := make([]byte, 0, 36)
+	data = append(data, selTransferOwnership[:]...)
+	word := stygos.PadAddress(newOwner)
+	data = append(data, word[:]...)
+	if _, err := s.Send(name, Tx{To: &contract, Data: data}); err != nil {
+		return err
+	}
+
+	ret, err := s.Backend.Call(Tx{To: &contract, Data: selOwner[:]})
+	if err != nil {
+		return err
+	}
+	if len(ret) < 32 || !bytes.Equal(ret[12:32], newOwner[:]) {
+		return fmt.Errorf("%w: owner() of %s is not %s", ErrOwnerMismatch, contract.Hex(), newOwner.Hex())
+	}
+	s.logf("%s: owner is %s\n", name, newOwner.Hex())
+	return nil
+}
+
+// VerifyStorage checks that the storage slot key of contract holds want.
+func (s *Script) VerifyStorage(contract stygos.Address, key, want stygos.Word) error {
+	got, err := s.Backend.StorageAt(contract, key)
+	if err != nil {
+		return err
+	}
+	if got != want {
+		return fmt.Errorf("%w: slot %s of %s holds %s, want %s", ErrStorageMismatch, key.Hex(), contract.Hex(), got.Hex(), want.Hex())
+	}
+	return nil
+}
+
+// Call runs a read-only call on the backend.
+func (s *Script) Call(to stygos.Address, data []byte) ([]byte, error) {
+	return s.Backend.Call(Tx{To: &to, Data: data})
+}
+
+// Send broadcasts tx as the step name and waits for it to be mined. If the
+// journal shows the step mined it returns the journaled receipt without
+// sending; if it shows the step sent, it waits for that transaction
+// instead of sending another. A reverted transaction is removed from the
+// journal, so the step runs again next time, and fails with ErrReverted.
+func (s *Script) Send(name string, tx Tx) (Receipt, error) {
+	var e *Entry
+	if s.Journal != nil {
+		e = s.Journal.Entry(name)
+	}
+
+	var r Receipt
+	var err error
+	switch {
+	case e != nil && e.Done:
+		s.logf("%s: done in tx %s, skipping\n", name, e.TxHash.Hex())
+		return e.Receipt(), nil
+	case e != nil:
+		s.logf("%s: waiting for tx %s\n", name, e.TxHash.Hex())
+		r, err = s.Backend.Resume(e.TxHash, e.Raw)
+	default:
+		r, err = s.Backend.Send(tx, func(hash stygos.Word, raw []byte) error {
+			s.logf("%s: sending tx %s\n", name, hash.Hex())
+			if s.Journal == nil {
+				return nil
+			}
+			return s.Journal.Sent(name, hash, raw)
+		})
+	}
+	if err != nil {
+		return Receipt{}, err
+	}
+
+	if !r.Status {
+		if s.Journal != nil {
+			if err := s.Journal.Remove(name); err != nil {
+				return Receipt{}, err
+			}
+		}
+		return r, fmt.Errorf("%w: step %s, tx %s", ErrReverted, name, r.TxHash.Hex())
+	}
+	if s.Journal != nil {
+		if err := s.Journal.Mined(name, r); err != nil {
+			return Receipt{}, err
+		}
+	}
+	if r.Contract != (stygos.Address{}) {
+		s.logf("%s: deployed at %s in block %d\n", name, r.Contract.Hex(), r.Block)
+	} else {
+		s.logf("%s: mined in block %d\n", name, r.Block)
+	}
+	return r, nil
+}
+
+func (s *Script) logf(format string, args ...any) {
+	if s.Log != nil {
+		fmt.Fprintf(s.Log, format, args...)
+	}
+}
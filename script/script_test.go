@@ -0,0 +1,422 @@
+package script
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+	"github.com/rafaelescrich/stygos/rlp"
+)
+
+var (
+	selInitialize      = []byte{0xfe, 0x4b, 0x84, 0xdf} // initialize(uint256)
+	selInitializeOwner = []byte{0xda, 0x35, 0xa2, 0x6f} // initialize(uint256,address)
+	deployer           = stygos.Address{19: 0xd0}
+	newOwner           = stygos.Address{19: 0x0e}
+	program            = []byte("compressed counter wasm")
+)
+
+// ownableCounter stores a non-zero value set once by initialize(uint256),
+// whose caller becomes the owner, or by initialize(uint256,address).
+func ownableCounter(input []byte) ([]byte, error) {
+	valueSlot, ownerSlot := stygos.Word{}, stygos.Word{31: 1}
+	switch {
+	case len(input) == 68 && string(input[:4]) == string(selInitializeOwner):
+		var v, owner stygos.Word
+		copy(v[:], input[4:])
+		copy(owner[:], input[36:])
+		if v.IsZero() || !stygos.StorageLoad(ownerSlot).IsZero() {
+			return []byte("zero value"), stygos.ErrRevert
+		}
+		stygos.StorageStore(valueSlot, v)
+		stygos.StorageStore(ownerSlot, owner)
+		return nil, nil
+	case len(input) == 36 && string(input[:4]) == string(selInitialize):
+		if !stygos.StorageLoad(ownerSlot).IsZero() {
+			return nil, stygos.ErrRevert
+		}
+		var v stygos.Word
+		copy(v[:], input[4:])
+		stygos.StorageStore(valueSlot, v)
+		stygos.StorageStore(ownerSlot, stygos.PadAddress(stygos.GetMsgSender()))
+		return nil, nil
+	case len(input) == 36 && string(input[:4]) == string(selTransferOwnership[:]):
+		if stygos.AddressFromWord(stygos.StorageLoad(ownerSlot)) != stygos.GetMsgSender() {
+			return nil, stygos.ErrRevert
+		}
+		var w stygos.Word
+		copy(w[:], input[4:])
+		stygos.StorageStore(ownerSlot, w)
+		return nil, nil
+	case len(input) == 4 && string(input) == string(selOwner[:]):
+		w := stygos.StorageLoad(ownerSlot)
+		return w[:], nil
+	}
+	return nil, stygos.ErrRevert
+}
+
+func initCalldata(v uint64) []byte {
+	w := stygos.WordFromUint64(v)
+	return append(append([]byte(nil), selInitialize...), w[:]...)
+}
+
+func newBackend() *MockBackend {
+	b := NewMockBackend(stygos.NewMockRuntime(), deployer)
+	b.Register(program, ownableCounter)
+	return b
+}
+
+// deploy is the script under test.
+func deploy(s *Script) (stygos.Address, error) {
+	counter, err := s.Deploy("counter", program)
+	if err != nil {
+		return stygos.Address{}, err
+	}
+	if err := s.Initialize("counter/init", counter, initCalldata(42)); err != nil {
+		return stygos.Address{}, err
+	}
+	if err := s.VerifyStorage(counter, stygos.Word{}, stygos.WordFromUint64(42)); err != nil {
+		return stygos.Address{}, err
+	}
+	return counter, s.TransferOwnership("counter/owner", counter, newOwner)
+}
+
+func TestScriptMock(t *testing.T) {
+	b := newBackend()
+	path := filepath.Join(t.TempDir(), "deploy.json")
+	s, err := New(b, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	counter, err := deploy(s)
+	if err != nil {
+		t.Fatalf("Deploy script failed: %v", err)
+	}
+	if counter != CreateAddress(deployer, 0) {
+		t.Errorf("Deploy failed. Expected %s, got %s", CreateAddress(deployer, 0).Hex(), counter.Hex())
+	}
+	if got := stygos.Word(b.Runtime.StorageOf(counter)[stygos.Word{31: 1}]); got != stygos.PadAddress(newOwner) {
+		t.Errorf("TransferOwnership failed. Expected owner %s, got %s", newOwner.Hex(), got.Hex())
+	}
+	if b.nonce != 4 {
+		t.Errorf("Deploy script failed. Expected 4 transactions, got %d", b.nonce)
+	}
+	if code, _ := b.Code(counter); !bytes.Equal(code, append(append([]byte(nil), stylusPrefix...), program...)) {
+		t.Errorf("Code failed. Expected the program behind the Stylus prefix, got %x", code)
+	}
+	for _, step := range []string{"counter", "counter/activate", "counter/init", "counter/owner"} {
+		if e := s.Journal.Entry(step); e == nil || !e.Done {
+			t.Errorf("Journal failed. Expected step %s done, got %+v", step, e)
+		}
+	}
+
+	// Running again from the journal sends nothing
+	s, err = New(b, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := deploy(s)
+	if err != nil || again != counter {
+		t.Errorf("Rerun failed. Expected %s, got %s, %v", counter.Hex(), again.Hex(), err)
+	}
+	if b.nonce != 4 {
+		t.Errorf("Rerun failed. Expected no transactions, got %d", b.nonce-4)
+	}
+
+	// Storage checks fail with the slot
+	if err := s.VerifyStorage(counter, stygos.Word{}, stygos.WordFromUint64(7)); !errors.Is(err, ErrStorageMismatch) {
+		t.Errorf("VerifyStorage failed. Expected ErrStorageMismatch, got %v", err)
+	}
+
+	// A journal of another chain is refused
+	b.Runtime.Chain = 42161
+	if _, err := New(b, path); err != ErrJournalChain {
+		t.Errorf("New failed. Expected ErrJournalChain, got %v", err)
+	}
+}
+
+func TestScriptRevert(t *testing.T) {
+	b := newBackend()
+	s, err := New(b, filepath.Join(t.TempDir(), "deploy.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := deploy(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Initializing twice reverts and leaves no journal entry
+	err = s.Initialize("counter/init-again", counter, initCalldata(1))
+	if !errors.Is(err, ErrReverted) {
+		t.Errorf("Initialize failed. Expected ErrReverted, got %v", err)
+	}
+	if e := s.Journal.Entry("counter/init-again"); e != nil {
+		t.Errorf("Initialize failed. Expected no journal entry, got %+v", e)
+	}
+
+	// The deployer no longer owns the counter
+	err = s.TransferOwnership("counter/owner-again", counter, deployer)
+	if !errors.Is(err, ErrReverted) {
+		t.Errorf("TransferOwnership failed. Expected ErrReverted, got %v", err)
+	}
+
+	// Unregistered code cannot be deployed on the mock
+	if _, err := s.Deploy("other", []byte("other wasm")); err != ErrUnknownProgram {
+		t.Errorf("Deploy failed. Expected ErrUnknownProgram, got %v", err)
+	}
+}
+
+// lostBackend loses the connection after broadcasting.
+type lostBackend struct {
+	*MockBackend
+}
+
+func (b lostBackend) Send(tx Tx, sent func(hash stygos.Word, raw []byte) error) (Receipt, error) {
+	if _, err := b.MockBackend.Send(tx, sent); err != nil {
+		return Receipt{}, err
+	}
+	return Receipt{}, errors.New("connection lost")
+}
+
+func TestScriptResume(t *testing.T) {
+	b := newBackend()
+	path := filepath.Join(t.TempDir(), "deploy.json")
+	s, err := New(lostBackend{b}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Deploy("counter", program); err == nil {
+		t.Fatal("Deploy failed. Expected the lost connection error")
+	}
+	if e := s.Journal.Entry("counter"); e == nil || e.Done {
+		t.Fatalf("Journal failed. Expected the step sent but not done, got %+v", e)
+	}
+
+	// The rerun waits for the sent transaction instead of deploying again
+	s, err = New(b, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, err := deploy(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counter != CreateAddress(deployer, 0) || b.nonce != 4 {
+		t.Errorf("Resume failed. Expected %s after 4 transactions, got %s after %d", CreateAddress(deployer, 0).Hex(), counter.Hex(), b.nonce)
+	}
+}
+
+func TestDeployAndInit(t *testing.T) {
+	b := newBackend()
+	path := filepath.Join(t.TempDir(), "deploy.json")
+	s, err := New(b, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := stygos.PadAddress(newOwner)
+	initData := append(initCalldata(42), owner[:]...)
+	copy(initData, selInitializeOwner)
+
+	counter, err := s.DeployAndInit("counter", program, initData, InitOptions{})
+	if err != nil {
+		t.Fatalf("DeployAndInit failed: %v", err)
+	}
+	salt := stygos.Keccak256([]byte("counter"))
+	want := Create2Address(StylusDeployerAddress, stygos.Keccak256(append(salt[:], initData...)), stygos.Keccak256(DeployCode(program)))
+	if counter != want || b.nonce != 1 {
+		t.Errorf("DeployAndInit failed. Expected %s in 1 transaction, got %s in %d", want.Hex(), counter.Hex(), b.nonce)
+	}
+	if err := s.VerifyStorage(counter, stygos.Word{31: 1}, owner); err != nil {
+		t.Errorf("DeployAndInit failed. Expected the owner set: %v", err)
+	}
+
+	// Rerunning sends nothing
+	s, _ = New(b, path)
+	if again, err := s.DeployAndInit("counter", program, initData, InitOptions{}); err != nil || again != counter || b.nonce != 1 {
+		t.Errorf("Rerun failed. Expected %s without transactions, got %s, %v after %d", counter.Hex(), again.Hex(), err, b.nonce)
+	}
+
+	// A reverting initializer deploys nothing and sends nothing
+	bad := append(initCalldata(0), owner[:]...)
+	copy(bad, selInitializeOwner)
+	_, err = s.DeployAndInit("counter/bad", program, bad, InitOptions{})
+	if !errors.Is(err, ErrInitFailed) || b.nonce != 1 {
+		t.Errorf("DeployAndInit failed. Expected ErrInitFailed without transactions, got %v after %d", err, b.nonce)
+	}
+	salt = stygos.Keccak256([]byte("counter/bad"))
+	badAddr := Create2Address(StylusDeployerAddress, stygos.Keccak256(append(salt[:], bad...)), stygos.Keccak256(DeployCode(program)))
+	if _, ok := b.Runtime.Contracts[badAddr]; ok {
+		t.Error("DeployAndInit failed. Expected the preflight to leave nothing deployed")
+	}
+
+	// The same salt and init data cannot deploy twice
+	_, err = s.DeployAndInit("counter/again", program, initData, InitOptions{Salt: stygos.Keccak256([]byte("counter"))})
+	if !errors.Is(err, ErrDeployFailed) {
+		t.Errorf("DeployAndInit failed. Expected ErrDeployFailed, got %v", err)
+	}
+}
+
+func TestDeployCode(t *testing.T) {
+	code := DeployCode(program)
+	if len(code) != 43+4+len(program) || code[0] != 0x7f {
+		t.Fatalf("DeployCode failed. Got %x", code)
+	}
+	if size := stygos.Uint64FromWord(*(*stygos.Word)(code[1:33])); size != uint64(4+len(program)) {
+		t.Errorf("DeployCode failed. Expected size %d, got %d", 4+len(program), size)
+	}
+	got, ok := programOf(code)
+	if !ok || string(got) != string(program) {
+		t.Errorf("programOf failed. Expected %q, got %q, %v", program, got, ok)
+	}
+	if _, ok := programOf([]byte{0x60, 0x80}); ok {
+		t.Error("programOf failed. Expected EVM init code to be rejected")
+	}
+}
+
+func TestCreateAddress(t *testing.T) {
+	from, _ := stygos.AddressFromHex("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	tests := []struct {
+		nonce uint64
+		want  string
+	}{
+		{0, "0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"},
+		{1, "0x343c43a37d37dff08ae8c4a11544c718abb4fcf8"},
+	}
+	for _, tt := range tests {
+		want, _ := stygos.AddressFromHex(tt.want)
+		if got := CreateAddress(from, tt.nonce); got != want {
+			t.Errorf("CreateAddress(%d) failed. Expected %s, got %s", tt.nonce, want.Hex(), got.Hex())
+		}
+	}
+}
+
+func TestSignTx(t *testing.T) {
+	key := big.NewInt(0xc0ffee)
+	to := stygos.Address{19: 0x11}
+	tx := Tx{To: &to, Data: []byte{1, 2, 3}, Value: big.NewInt(1000)}
+	hash, raw, err := signTx(key, 412346, 7, big.NewInt(0), big.NewInt(200000000), 50000, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw[0] != 0x02 || hash != stygos.Keccak256(raw) {
+		t.Fatalf("signTx failed. Expected a type 2 transaction hashed as keccak256(raw)")
+	}
+
+	// Recover the sender from the signing hash
+	fields, err := rlp.ListElems(raw[1:])
+	if err != nil || len(fields) != 12 {
+		t.Fatalf("signTx failed. Expected 12 fields, got %d, %v", len(fields), err)
+	}
+	unsigned := append([]byte{0x02}, rlp.EncodeList(fields[:9]...)...)
+	parity, _ := rlp.Uint64(fields[9])
+	r, _ := rlp.Bytes(fields[10])
+	s, _ := rlp.Bytes(fields[11])
+	var sig ecdsa.Signature
+	sig.V = 27 + uint8(parity)
+	copy(sig.R[32-len(r):], r)
+	copy(sig.S[32-len(s):], s)
+	signer, err := ecdsa.RecoverAddress(stygos.Keccak256(unsigned), sig)
+	if err != nil || signer != ecdsa.AddressOf(key) {
+		t.Errorf("signTx failed. Expected signer %s, got %s, %v", ecdsa.AddressOf(key).Hex(), signer.Hex(), err)
+	}
+}
+
+func TestRPCBackend(t *testing.T) {
+	var sent []string
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var call struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(req.Body).Decode(&call)
+		var result any
+		switch call.Method {
+		case "eth_chainId":
+			result = "0x64aba"
+		case "eth_getTransactionCount":
+			result = "0x3"
+		case "eth_maxPriorityFeePerGas":
+			result = "0x0"
+		case "eth_getBlockByNumber":
+			result = map[string]string{"baseFeePerGas": "0x5f5e100"}
+		case "eth_estimateGas":
+			result = "0x5208"
+		case "eth_sendRawTransaction":
+			var raw string
+			json.Unmarshal(call.Params[0], &raw)
+			sent = append(sent, raw)
+			result = "0x"
+		case "eth_getTransactionReceipt":
+			if len(sent) == 0 {
+				result = nil
+				break
+			}
+			result = map[string]any{"status": "0x1", "contractAddress": nil, "blockNumber": "0x10", "gasUsed": "0x5208"}
+		case "eth_getStorageAt":
+			result = stygos.WordFromUint64(42).Hex()
+		case "eth_getCode":
+			result = "0xeff00000c0de"
+		default:
+			json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "error": map[string]any{"code": 3, "message": "execution reverted", "data": "0xcc944bf2"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": call.ID, "result": result})
+	}))
+	defer node.Close()
+
+	key := big.NewInt(0xc0ffee)
+	b, err := Dial(node.URL, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := b.ChainID(); id != 412346 || b.Sender() != ecdsa.AddressOf(key) {
+		t.Errorf("Dial failed. Expected chain 412346 and the key's address, got %d, %s", id, b.Sender().Hex())
+	}
+
+	to := stygos.Address{19: 0x11}
+	var journaled stygos.Word
+	r, err := b.Send(Tx{To: &to, Data: []byte{1}}, func(hash stygos.Word, raw []byte) error {
+		journaled = hash
+		return nil
+	})
+	if err != nil || !r.Status || r.Block != 16 || r.TxHash != journaled || len(sent) != 1 {
+		t.Errorf("Send failed. Got %+v, %v after %d broadcasts", r, err, len(sent))
+	}
+
+	got, err := b.StorageAt(to, stygos.Word{})
+	if err != nil || got != stygos.WordFromUint64(42) {
+		t.Errorf("StorageAt failed. Expected 42, got %s, %v", got.Hex(), err)
+	}
+
+	_, err = b.Call(Tx{To: &to})
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || string(rpcErr.RevertData()) != string(errProgramUpToDate) {
+		t.Errorf("Call failed. Expected the revert data, got %v", err)
+	}
+
+	code, err := b.Code(to)
+	if program, ok := DeployedProgram(code); err != nil || !ok || string(program) != "\xc0\xde" {
+		t.Errorf("Code failed. Expected a Stylus program, got %x, %v", code, err)
+	}
+
+	if _, err := Dial(node.URL, new(big.Int)); err != ErrInvalidKey {
+		t.Errorf("Dial failed. Expected ErrInvalidKey, got %v", err)
+	}
+	readOnly, err := Dial(node.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readOnly.Send(Tx{To: &to}, func(stygos.Word, []byte) error { return nil }); err != ErrInvalidKey {
+		t.Errorf("Send failed. Expected ErrInvalidKey without a key, got %v", err)
+	}
+}
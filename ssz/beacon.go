@@ -0,0 +1,72 @@
+package ssz
+
+import (
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/history"
+)
+
+// Generalized indices of the fields of a BeaconBlockHeader, whose five
+// fields make a tree of depth 3.
+const (
+	HeaderSlotIndex          = 8
+	HeaderProposerIndexIndex = 9
+	HeaderParentRootIndex    = 10
+	HeaderStateRootIndex     = 11
+	HeaderBodyRootIndex      = 12
+)
+
+// BeaconBlockHeader is the consensus-layer block header, whose root is
+// the beacon block root.
+type BeaconBlockHeader struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    stygos.Word
+	StateRoot     stygos.Word
+	BodyRoot      stygos.Word
+}
+
+// HashTreeRoot returns the root of the header.
+func (h *BeaconBlockHeader) HashTreeRoot() stygos.Word {
+	return ContainerRoot(Uint64(h.Slot), Uint64(h.ProposerIndex), h.ParentRoot, h.StateRoot, h.BodyRoot)
+}
+
+// Validator is an entry of the beacon state's validator registry.
+type Validator struct {
+	Pubkey                     [48]byte
+	WithdrawalCredentials      stygos.Word
+	EffectiveBalance           uint64 // in gwei
+	Slashed                    bool
+	ActivationEligibilityEpoch uint64
+	ActivationEpoch            uint64
+	ExitEpoch                  uint64
+	WithdrawableEpoch          uint64
+}
+
+// HashTreeRoot returns the root of the validator.
+func (v *Validator) HashTreeRoot() stygos.Word {
+	return ContainerRoot(
+		ByteVectorRoot(v.Pubkey[:]),
+		v.WithdrawalCredentials,
+		Uint64(v.EffectiveBalance),
+		Bool(v.Slashed),
+		Uint64(v.ActivationEligibilityEpoch),
+		Uint64(v.ActivationEpoch),
+		Uint64(v.ExitEpoch),
+		Uint64(v.WithdrawableEpoch),
+	)
+}
+
+// VerifyBeaconProof checks that branch proves leaf at generalized index
+// gindex under the parent beacon block root of the block with the given
+// timestamp, read with history.BeaconRoot. It returns ErrInvalidProof if
+// it does not.
+func VerifyBeaconProof(timestamp uint64, leaf stygos.Word, branch []stygos.Word, gindex uint64) error {
+	root, err := history.BeaconRoot(timestamp)
+	if err != nil {
+		return err
+	}
+	if !VerifyProof(root, leaf, branch, gindex) {
+		return ErrInvalidProof
+	}
+	return nil
+}
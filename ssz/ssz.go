@@ -0,0 +1,184 @@
+// Package ssz computes SimpleSerialize hash tree roots and verifies Merkle
+// proofs against them, so contracts can check consensus-layer data such as
+// validator balances and withdrawal credentials for staking and
+// restaking.
+//
+// Values are split into 32-byte chunks, packed little-endian for basic
+// types, and merkleized with SHA-256 over a tree padded with zero
+// subtrees to the type's chunk limit. Lists mix their length into the
+// root. A proof is the branch of sibling roots from a leaf up to the root,
+// and the leaf's position is its generalized index: 1 for the root, 2i
+// and 2i+1 for the children of node i.
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// SSZ errors
+var (
+	ErrTooLong      = errors.New("ssz: more chunks than the limit")
+	ErrInvalidProof = errors.New("ssz: invalid proof")
+)
+
+// zeroHashes caches the roots of all-zero subtrees by depth, filled on
+// first use so that programs pay for the hashing only when they merkleize.
+var zeroHashes []stygos.Word
+
+// ZeroHash returns the root of a subtree of 2^depth zero chunks.
+func ZeroHash(depth int) stygos.Word {
+	if len(zeroHashes) == 0 {
+		zeroHashes = append(zeroHashes, stygos.Word{})
+	}
+	for len(zeroHashes) <= depth {
+		last := zeroHashes[len(zeroHashes)-1]
+		zeroHashes = append(zeroHashes, Hash(last, last))
+	}
+	return zeroHashes[depth]
+}
+
+// Hash returns the root of two sibling nodes, sha256(a || b).
+func Hash(a, b stygos.Word) stygos.Word {
+	var buf [64]byte
+	copy(buf[:32], a[:])
+	copy(buf[32:], b[:])
+	return sha256.Sum256(buf[:])
+}
+
+// Merkleize returns the root of chunks in a tree padded with zero chunks
+// to limit leaves, rounded up to a power of two. The chunks are
+// overwritten.
+func Merkleize(chunks []stygos.Word, limit uint64) (stygos.Word, error) {
+	if uint64(len(chunks)) > limit {
+		return stygos.Word{}, ErrTooLong
+	}
+	depth := 0
+	for uint64(1)<<depth < limit {
+		depth++
+	}
+	if len(chunks) == 0 {
+		return ZeroHash(depth), nil
+	}
+	layer := chunks
+	for d := 0; d < depth; d++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, ZeroHash(d))
+		}
+		next := layer[:len(layer)/2]
+		for i := range next {
+			next[i] = Hash(layer[2*i], layer[2*i+1])
+		}
+		layer = next
+	}
+	return layer[0], nil
+}
+
+// MixInLength returns the root of a list from the root of its contents.
+func MixInLength(root stygos.Word, length uint64) stygos.Word {
+	var n stygos.Word
+	binary.LittleEndian.PutUint64(n[:], length)
+	return Hash(root, n)
+}
+
+// ContainerRoot returns the root of a container from the roots of its
+// fields, in order.
+func ContainerRoot(fields ...stygos.Word) stygos.Word {
+	root, _ := Merkleize(append([]stygos.Word(nil), fields...), uint64(len(fields)))
+	return root
+}
+
+// Uint64 returns the chunk of a uint64 field, little-endian.
+func Uint64(v uint64) stygos.Word {
+	var w stygos.Word
+	binary.LittleEndian.PutUint64(w[:], v)
+	return w
+}
+
+// Bool returns the chunk of a boolean field.
+func Bool(v bool) stygos.Word {
+	var w stygos.Word
+	if v {
+		w[0] = 1
+	}
+	return w
+}
+
+// PackUint64s packs a vector or list of uint64s into chunks, four per
+// chunk.
+func PackUint64s(vs []uint64) []stygos.Word {
+	chunks := make([]stygos.Word, (len(vs)+3)/4)
+	for i, v := range vs {
+		binary.LittleEndian.PutUint64(chunks[i/4][i%4*8:], v)
+	}
+	return chunks
+}
+
+// PackBytes splits b into chunks, zero-padding the last.
+func PackBytes(b []byte) []stygos.Word {
+	chunks := make([]stygos.Word, (len(b)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], b[i*32:])
+	}
+	return chunks
+}
+
+// ByteVectorRoot returns the root of a fixed-length byte vector, such as
+// a 48-byte BLS public key.
+func ByteVectorRoot(b []byte) stygos.Word {
+	chunks := PackBytes(b)
+	root, _ := Merkleize(chunks, uint64(len(chunks)))
+	return root
+}
+
+// ByteListRoot returns the root of a byte list of at most maxLen bytes.
+func ByteListRoot(b []byte, maxLen uint64) (stygos.Word, error) {
+	root, err := Merkleize(PackBytes(b), (maxLen+31)/32)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	return MixInLength(root, uint64(len(b))), nil
+}
+
+// ListRoot returns the root of a list of composite elements, given their
+// roots, of at most limit elements.
+func ListRoot(roots []stygos.Word, limit uint64) (stygos.Word, error) {
+	root, err := Merkleize(append([]stygos.Word(nil), roots...), limit)
+	if err != nil {
+		return stygos.Word{}, err
+	}
+	return MixInLength(root, uint64(len(roots))), nil
+}
+
+// GeneralizedIndex returns the generalized index of leaf index of a tree
+// of the given depth.
+func GeneralizedIndex(depth int, index uint64) uint64 {
+	return 1<<uint(depth) | index
+}
+
+// ProcessProof returns the root reached from leaf at generalized index
+// gindex through branch, the siblings from the leaf up.
+func ProcessProof(leaf stygos.Word, branch []stygos.Word, gindex uint64) stygos.Word {
+	node := leaf
+	for i, sibling := range branch {
+		if gindex>>uint(i)&1 == 1 {
+			node = Hash(sibling, node)
+		} else {
+			node = Hash(node, sibling)
+		}
+	}
+	return node
+}
+
+// VerifyProof reports whether branch proves leaf at generalized index
+// gindex under root. The branch must be exactly as long as the leaf is
+// deep.
+func VerifyProof(root, leaf stygos.Word, branch []stygos.Word, gindex uint64) bool {
+	if gindex == 0 || gindex>>uint(len(branch)) != 1 {
+		return false
+	}
+	return ProcessProof(leaf, branch, gindex) == root
+}
@@ -0,0 +1,128 @@
+package ssz
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/history"
+)
+
+// naiveRoot merkleizes a full tree of leaves, a power of two of them.
+func naiveRoot(leaves []stygos.Word) stygos.Word {
+	for len(leaves) > 1 {
+		var next []stygos.Word
+		for i := 0; i < len(leaves); i += 2 {
+			next = append(next, sha256.Sum256(append(leaves[i][:], leaves[i+1][:]...)))
+		}
+		leaves = next
+	}
+	return leaves[0]
+}
+
+func chunks(n int) []stygos.Word {
+	out := make([]stygos.Word, n)
+	for i := range out {
+		out[i] = stygos.WordFromUint64(uint64(i + 1))
+	}
+	return out
+}
+
+func TestMerkleize(t *testing.T) {
+	want := "f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b"
+	if got := ZeroHash(1); hex.EncodeToString(got[:]) != want {
+		t.Errorf("ZeroHash(1) failed. Expected %s, got %x", want, got)
+	}
+
+	for _, tt := range []struct {
+		n     int
+		limit uint64
+	}{{0, 4}, {1, 1}, {3, 4}, {5, 8}, {5, 16}, {7, 7}} {
+		padded := make([]stygos.Word, 1)
+		for uint64(len(padded)) < tt.limit {
+			padded = append(padded, padded...)
+		}
+		copy(padded, chunks(tt.n))
+		got, err := Merkleize(chunks(tt.n), tt.limit)
+		if err != nil || got != naiveRoot(padded) {
+			t.Errorf("Merkleize(%d, %d) failed. Expected %x, got %x, %v", tt.n, tt.limit, naiveRoot(padded), got, err)
+		}
+	}
+	if _, err := Merkleize(chunks(5), 4); err != ErrTooLong {
+		t.Errorf("Merkleize failed. Expected ErrTooLong, got %v", err)
+	}
+}
+
+func TestListRoots(t *testing.T) {
+	// Five uint64s pack into 2 chunks; a limit of 16 uint64s makes 4 leaves
+	root, err := Merkleize(PackUint64s([]uint64{1, 2, 3, 4, 5}), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var first, second stygos.Word
+	first[0], first[8], first[16], first[24], second[0] = 1, 2, 3, 4, 5
+	if root != naiveRoot([]stygos.Word{first, second, {}, {}}) {
+		t.Errorf("PackUint64s failed. Expected little-endian packing, got root %x", root)
+	}
+
+	got, err := ByteListRoot([]byte("abc"), 64)
+	var chunk stygos.Word
+	copy(chunk[:], "abc")
+	if want := MixInLength(naiveRoot([]stygos.Word{chunk, {}}), 3); err != nil || got != want {
+		t.Errorf("ByteListRoot failed. Expected %x, got %x, %v", want, got, err)
+	}
+	if _, err := ListRoot(chunks(3), 2); err != ErrTooLong {
+		t.Errorf("ListRoot failed. Expected ErrTooLong, got %v", err)
+	}
+}
+
+func TestBeaconProof(t *testing.T) {
+	v := Validator{
+		WithdrawalCredentials: stygos.Word{0: 0x01, 31: 0xaa},
+		EffectiveBalance:      32e9,
+		ExitEpoch:             ^uint64(0),
+		WithdrawableEpoch:     ^uint64(0),
+	}
+	v.Pubkey[0] = 0xb0
+	var pubkey [2]stygos.Word
+	copy(pubkey[0][:], v.Pubkey[:])
+	fields := []stygos.Word{naiveRoot(pubkey[:]), v.WithdrawalCredentials, Uint64(32e9), {}, {}, {}, Uint64(^uint64(0)), Uint64(^uint64(0))}
+	if got := v.HashTreeRoot(); got != naiveRoot(fields) {
+		t.Errorf("Validator.HashTreeRoot failed. Expected %x, got %x", naiveRoot(fields), got)
+	}
+
+	h := BeaconBlockHeader{Slot: 7, ProposerIndex: 3, StateRoot: v.HashTreeRoot(), BodyRoot: stygos.Word{1}}
+	leaves := []stygos.Word{Uint64(7), Uint64(3), {}, h.StateRoot, h.BodyRoot, {}, {}, {}}
+	root := h.HashTreeRoot()
+	if root != naiveRoot(leaves) {
+		t.Fatalf("BeaconBlockHeader.HashTreeRoot failed. Expected %x, got %x", naiveRoot(leaves), root)
+	}
+
+	// The state root is leaf 3: its sibling is the parent root, then the
+	// subtree of slot and proposer, then the right half
+	branch := []stygos.Word{{}, Hash(Uint64(7), Uint64(3)), naiveRoot(leaves[4:])}
+	if !VerifyProof(root, h.StateRoot, branch, HeaderStateRootIndex) {
+		t.Error("VerifyProof failed. Expected the state root proof to verify")
+	}
+	if VerifyProof(root, h.StateRoot, branch, HeaderParentRootIndex) {
+		t.Error("VerifyProof failed. Expected a proof at the wrong index to fail")
+	}
+	if VerifyProof(root, h.StateRoot, branch[:2], 5) || VerifyProof(root, h.StateRoot, branch, GeneralizedIndex(4, 3)) {
+		t.Error("VerifyProof failed. Expected a branch of the wrong depth to fail")
+	}
+
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	history.InstallMock(mock).BeaconRoots[1700000000] = root
+	if err := VerifyBeaconProof(1700000000, h.StateRoot, branch, HeaderStateRootIndex); err != nil {
+		t.Errorf("VerifyBeaconProof failed: %v", err)
+	}
+	if err := VerifyBeaconProof(1700000000, h.BodyRoot, branch, HeaderStateRootIndex); err != ErrInvalidProof {
+		t.Errorf("VerifyBeaconProof failed. Expected ErrInvalidProof, got %v", err)
+	}
+	if err := VerifyBeaconProof(1700000012, h.StateRoot, branch, HeaderStateRootIndex); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("VerifyBeaconProof failed. Expected a revert for an unknown timestamp, got %v", err)
+	}
+}
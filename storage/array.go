@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// WordArray is a dynamic array of words laid out like a Solidity dynamic
+// array: the length lives at the base slot and element i at
+// keccak256(base) + i.
+type WordArray struct {
+	base stygos.Word
+	data stygos.Word
+}
+
+// NewWordArray returns the array rooted at base.
+func NewWordArray(base stygos.Word) WordArray {
+	return WordArray{base: base, data: stygos.Keccak256(base[:])}
+}
+
+// Len returns the number of elements.
+func (a WordArray) Len() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(a.base))
+}
+
+// Get returns element i. It panics if i is out of range, mirroring a slice
+// index; contracts should check Len first.
+func (a WordArray) Get(i uint64) stygos.Word {
+	if i >= a.Len() {
+		panic("storage: array index out of range")
+	}
+	return stygos.StorageLoad(Offset(a.data, i))
+}
+
+// Set overwrites element i. It panics if i is out of range.
+func (a WordArray) Set(i uint64, w stygos.Word) {
+	if i >= a.Len() {
+		panic("storage: array index out of range")
+	}
+	stygos.StorageStore(Offset(a.data, i), w)
+}
+
+// Push appends w and returns its index.
+func (a WordArray) Push(w stygos.Word) uint64 {
+	n := a.Len()
+	stygos.StorageStore(Offset(a.data, n), w)
+	stygos.StorageStore(a.base, stygos.WordFromUint64(n+1))
+	return n
+}
+
+// Pop removes and returns the last element. ok is false if the array is empty.
+func (a WordArray) Pop() (w stygos.Word, ok bool) {
+	n := a.Len()
+	if n == 0 {
+		return w, false
+	}
+	slot := Offset(a.data, n-1)
+	w = stygos.StorageLoad(slot)
+	stygos.StorageStore(slot, stygos.Word{})
+	stygos.StorageStore(a.base, stygos.WordFromUint64(n-1))
+	return w, true
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Bitmap is a set of boolean flags packed 256 to a storage slot, in the
+// spirit of OpenZeppelin's BitMaps. Setting a flag in a slot that already
+// holds one costs a non-zero to non-zero write instead of a fresh slot, so
+// dense indexes such as airdrop claims or unordered nonces are far cheaper
+// than one slot per flag.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, i/256)   flags i/256*256 through i/256*256+255, flag i at
+//	                      bit i%256 of the big-endian word
+type Bitmap struct {
+	base stygos.Word
+}
+
+// NewBitmap returns the bitmap rooted at base.
+func NewBitmap(base stygos.Word) *Bitmap {
+	return &Bitmap{base: base}
+}
+
+// Get reports whether flag i is set.
+func (m *Bitmap) Get(i uint64) bool {
+	w := m.Bucket(i / 256)
+	return w[31-i%256/8]&(1<<(i%8)) != 0
+}
+
+// Set sets flag i.
+func (m *Bitmap) Set(i uint64) {
+	m.SetTo(i, true)
+}
+
+// Clear clears flag i.
+func (m *Bitmap) Clear(i uint64) {
+	m.SetTo(i, false)
+}
+
+// SetTo sets flag i to v.
+func (m *Bitmap) SetTo(i uint64, v bool) {
+	m.swap(i, v)
+}
+
+// TrySet sets flag i and reports whether it was clear, with a single load:
+// the check-and-mark of a claim.
+func (m *Bitmap) TrySet(i uint64) bool {
+	return !m.swap(i, true)
+}
+
+// Bucket returns the word holding flags pos*256 through pos*256+255.
+func (m *Bitmap) Bucket(pos uint64) stygos.Word {
+	return stygos.StorageLoad(m.key(pos))
+}
+
+// SetBucket overwrites the word holding flags pos*256 through
+// pos*256+255, e.g. to invalidate a range of unordered nonces at once.
+func (m *Bitmap) SetBucket(pos uint64, w stygos.Word) {
+	stygos.StorageStore(m.key(pos), w)
+}
+
+// FindFirstUnset returns the lowest clear flag at or after from, loading
+// one slot per 256 flags scanned. ok is false if every flag from there up
+// to 2^64-1 is set.
+func (m *Bitmap) FindFirstUnset(from uint64) (i uint64, ok bool) {
+	for pos := from / 256; ; pos++ {
+		w := m.Bucket(pos)
+		start := uint64(0)
+		if pos == from/256 {
+			start = from % 256
+		}
+		// Scan the limbs of the word from the least significant up
+		for limb := start / 64; limb < 4; limb++ {
+			free := ^binary.BigEndian.Uint64(w[24-limb*8:])
+			if limb == start/64 {
+				free &= ^uint64(0) << (start % 64)
+			}
+			if free != 0 {
+				return pos*256 + limb*64 + uint64(bits.TrailingZeros64(free)), true
+			}
+		}
+		if pos == ^uint64(0)/256 {
+			return 0, false
+		}
+	}
+}
+
+// swap sets flag i to v and returns its previous value, skipping the
+// store when nothing changes.
+func (m *Bitmap) swap(i uint64, v bool) bool {
+	key := m.key(i / 256)
+	w := stygos.StorageLoad(key)
+	b, mask := &w[31-i%256/8], byte(1<<(i%8))
+	old := *b&mask != 0
+	if old == v {
+		return old
+	}
+	*b ^= mask
+	stygos.StorageStore(key, w)
+	return old
+}
+
+func (m *Bitmap) key(pos uint64) stygos.Word {
+	p := stygos.WordFromUint64(pos)
+	return MapKey(m.base, p[:])
+}
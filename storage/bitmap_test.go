@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestBitmap(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	m := NewBitmap(ConstSlot("claimed"))
+
+	for _, i := range []uint64{0, 7, 8, 255, 256, 1000} {
+		if m.Get(i) {
+			t.Errorf("Get(%d) failed. Expected a fresh flag to be clear", i)
+		}
+		m.Set(i)
+		if !m.Get(i) {
+			t.Errorf("Get(%d) failed. Expected the flag to be set", i)
+		}
+	}
+	if len(mock.Storage) != 3 {
+		t.Errorf("Set failed. Expected 3 slots for flags in buckets 0, 1 and 3, got %d", len(mock.Storage))
+	}
+
+	// Flags sit at their bit of the big-endian word, as in OpenZeppelin
+	w := m.Bucket(0)
+	if w[31] != 0x81 || w[30] != 0x01 || w[0] != 0x80 {
+		t.Errorf("Bucket failed. Expected bits 0, 7, 8 and 255, got %x", w)
+	}
+
+	m.Clear(7)
+	m.SetTo(8, false)
+	if m.Get(7) || m.Get(8) || !m.Get(0) {
+		t.Error("Clear failed. Expected flags 7 and 8 cleared and 0 kept")
+	}
+	m.Clear(1000)
+	if len(mock.Storage) != 2 {
+		t.Errorf("Clear failed. Expected the emptied bucket to free its slot, got %d slots", len(mock.Storage))
+	}
+
+	if !m.TrySet(42) || m.TrySet(42) {
+		t.Error("TrySet failed. Expected success exactly once")
+	}
+
+	var all stygos.Word
+	for i := range all {
+		all[i] = 0xff
+	}
+	m.SetBucket(5, all)
+	if !m.Get(5*256) || !m.Get(5*256+255) {
+		t.Error("SetBucket failed. Expected every flag of the bucket set")
+	}
+}
+
+func TestBitmapFindFirstUnset(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	m := NewBitmap(ConstSlot("nonces"))
+
+	for i := uint64(0); i < 70; i++ {
+		m.Set(i)
+	}
+	m.Set(71)
+	tests := []struct {
+		from, want uint64
+	}{
+		{0, 70},
+		{70, 70},
+		{71, 72},
+		{300, 300},
+	}
+	for _, tt := range tests {
+		if got, ok := m.FindFirstUnset(tt.from); !ok || got != tt.want {
+			t.Errorf("FindFirstUnset(%d) failed. Expected %d, got %d, %v", tt.from, tt.want, got, ok)
+		}
+	}
+
+	// Full buckets are skipped
+	var all stygos.Word
+	for i := range all {
+		all[i] = 0xff
+	}
+	m.SetBucket(1, all)
+	m.SetBucket(2, all)
+	if got, ok := m.FindFirstUnset(256); !ok || got != 768 {
+		t.Errorf("FindFirstUnset failed. Expected 768 past full buckets, got %d, %v", got, ok)
+	}
+
+	last := ^uint64(0) / 256
+	m.SetBucket(last, all)
+	if _, ok := m.FindFirstUnset(last * 256); ok {
+		t.Error("FindFirstUnset failed. Expected none left at the end of the index space")
+	}
+}
+
+// gasMeter estimates the storage gas of a workload under EIP-2929 and
+// EIP-2200 pricing, treating each step as its own transaction: the first
+// access to a slot is cold, a clean zero to non-zero write costs 20000 and
+// any other change 2900. Refunds and everything but storage are ignored.
+type gasMeter struct {
+	warm map[[32]byte]bool
+	gas  uint64
+}
+
+func meter(b *testing.B) *gasMeter {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	g := &gasMeter{warm: map[[32]byte]bool{}}
+
+	load, store := stygos.StorageLoadBytes32, stygos.StorageStoreBytes32
+	stygos.StorageLoadBytes32 = func(key, value *byte) {
+		g.access(keyAt(key))
+		load(key, value)
+	}
+	stygos.StorageStoreBytes32 = func(key, value *byte) {
+		k := keyAt(key)
+		g.access(k)
+		old, v := mock.Storage[k], keyAt(value)
+		switch {
+		case old == v:
+		case old == [32]byte{}:
+			g.gas += 20000
+		default:
+			g.gas += 2900
+		}
+		store(key, value)
+	}
+	b.Cleanup(func() {
+		stygos.StorageLoadBytes32, stygos.StorageStoreBytes32 = load, store
+	})
+	return g
+}
+
+// tx starts a new transaction, cooling every slot.
+func (g *gasMeter) tx() {
+	g.warm = map[[32]byte]bool{}
+}
+
+func (g *gasMeter) access(key [32]byte) {
+	if g.warm[key] {
+		g.gas += 100
+		return
+	}
+	g.warm[key] = true
+	g.gas += 2100
+}
+
+func (g *gasMeter) report(b *testing.B) {
+	b.ReportMetric(float64(g.gas)/float64(b.N), "gas/op")
+}
+
+func keyAt(p *byte) [32]byte {
+	var w stygos.Word
+	copy(w[:], unsafe.Slice(p, 32))
+	return w
+}
+
+// BenchmarkBitmapClaim claims consecutive indexes, one per transaction,
+// as an airdrop does.
+func BenchmarkBitmapClaim(b *testing.B) {
+	g := meter(b)
+	m := NewBitmap(ConstSlot("claimed"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.tx()
+		m.TrySet(uint64(i))
+	}
+	g.report(b)
+}
+
+// BenchmarkSlotPerFlagClaim is BenchmarkBitmapClaim with a whole slot per
+// flag, as a mapping(uint256 => bool).
+func BenchmarkSlotPerFlagClaim(b *testing.B) {
+	g := meter(b)
+	base := ConstSlot("claimed")
+	one := stygos.WordFromUint64(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.tx()
+		n := stygos.WordFromUint64(uint64(i))
+		key := MapKey(base, n[:])
+		if stygos.StorageLoad(key).IsZero() {
+			stygos.StorageStore(key, one)
+		}
+	}
+	g.report(b)
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// StoreBytes stores a byte string of any length at slot. The length is kept
+// at slot and the data in consecutive slots from keccak256(slot), like a
+// long Solidity bytes value. Slots left over from a longer previous value are
+// cleared.
+func StoreBytes(slot stygos.Word, data []byte) {
+	oldWords := wordsFor(LoadBytesLen(slot))
+	newWords := wordsFor(uint64(len(data)))
+	base := stygos.Keccak256(slot[:])
+
+	for i := uint64(0); i < newWords; i++ {
+		var w stygos.Word
+		copy(w[:], data[i*32:])
+		stygos.StorageStore(Offset(base, i), w)
+	}
+	for i := newWords; i < oldWords; i++ {
+		stygos.StorageStore(Offset(base, i), stygos.Word{})
+	}
+	stygos.StorageStore(slot, stygos.WordFromUint64(uint64(len(data))))
+}
+
+// LoadBytes loads a byte string stored with StoreBytes.
+func LoadBytes(slot stygos.Word) []byte {
+	n := LoadBytesLen(slot)
+	if n == 0 {
+		return nil
+	}
+	base := stygos.Keccak256(slot[:])
+	data := make([]byte, wordsFor(n)*32)
+	for i := uint64(0); i < wordsFor(n); i++ {
+		w := stygos.StorageLoad(Offset(base, i))
+		copy(data[i*32:], w[:])
+	}
+	return data[:n]
+}
+
+// LoadBytesLen returns the length of the byte string stored at slot.
+func LoadBytesLen(slot stygos.Word) uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(slot))
+}
+
+// wordsFor returns the number of 32-byte words needed for n bytes.
+func wordsFor(n uint64) uint64 {
+	return (n + 31) / 32
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestStoreBytes(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	slot := ConstSlot("description")
+	long := bytes.Repeat([]byte("stygos "), 20) // 140 bytes, 5 words
+
+	StoreBytes(slot, long)
+	if got := LoadBytes(slot); !bytes.Equal(got, long) {
+		t.Errorf("LoadBytes = %q, want %q", got, long)
+	}
+	if LoadBytesLen(slot) != uint64(len(long)) {
+		t.Errorf("LoadBytesLen = %d, want %d", LoadBytesLen(slot), len(long))
+	}
+
+	// Shrinking clears the slots that are no longer used
+	StoreBytes(slot, []byte("short"))
+	if got := LoadBytes(slot); string(got) != "short" {
+		t.Errorf("LoadBytes = %q, want short", got)
+	}
+	if len(mock.Storage) != 2 {
+		t.Errorf("%d slots used after shrinking, want 2", len(mock.Storage))
+	}
+
+	StoreBytes(slot, nil)
+	if got := LoadBytes(slot); got != nil || len(mock.Storage) != 0 {
+		t.Errorf("empty value left %q and %d slots", got, len(mock.Storage))
+	}
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"math/big"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// WordCodec converts values of type T to and from a single storage word. The
+// typed containers take codecs for their keys and values, which also lets Go
+// infer the container's type parameters:
+//
+//	balances := storage.NewIterableMap(base, storage.Addresses, storage.Uint64s)
+type WordCodec[T any] struct {
+	Encode func(T) stygos.Word
+	Decode func(stygos.Word) T
+}
+
+// Built-in codecs
+var (
+	// Words stores words as they are.
+	Words = WordCodec[stygos.Word]{
+		Encode: func(w stygos.Word) stygos.Word { return w },
+		Decode: func(w stygos.Word) stygos.Word { return w },
+	}
+
+	// Addresses stores addresses left-padded, like the EVM.
+	Addresses = WordCodec[stygos.Address]{
+		Encode: stygos.PadAddress,
+		Decode: stygos.AddressFromWord,
+	}
+
+	// Uint64s stores integers big-endian in the low bytes of the word.
+	Uint64s = WordCodec[uint64]{
+		Encode: stygos.WordFromUint64,
+		Decode: stygos.Uint64FromWord,
+	}
+
+	// Bools stores 1 for true and 0 for false.
+	Bools = WordCodec[bool]{
+		Encode: func(b bool) stygos.Word {
+			var w stygos.Word
+			if b {
+				w[31] = 1
+			}
+			return w
+		},
+		Decode: func(w stygos.Word) bool { return w != stygos.Word{} },
+	}
+
+	// U256s stores 256-bit integers big-endian.
+	U256s = WordCodec[stygos.U256]{
+		Encode: stygos.U256.Word,
+		Decode: stygos.U256FromWord,
+	}
+
+	// BigInts stores unsigned integers of up to 256 bits.
+	BigInts = WordCodec[*big.Int]{
+		Encode: stygos.WordFromBigInt,
+		Decode: stygos.BigIntFromWord,
+	}
+)
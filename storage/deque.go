@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"encoding/binary"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Deque is a double-ended queue over contract storage, useful for order
+// books, pending-withdrawal queues and timelock queues.
+//
+// The base slot packs the head and tail indexes; element i lives at
+// keccak256(base) + i. Indexes wrap modulo 2^64, so pushing to the front of
+// an empty deque simply moves the head below zero.
+type Deque[T any] struct {
+	base  stygos.Word
+	data  stygos.Word
+	codec WordCodec[T]
+}
+
+// NewDeque returns the deque rooted at base.
+func NewDeque[T any](base stygos.Word, codec WordCodec[T]) *Deque[T] {
+	return &Deque[T]{base: base, data: stygos.Keccak256(base[:]), codec: codec}
+}
+
+// Len returns the number of elements.
+func (d *Deque[T]) Len() uint64 {
+	head, tail := loadBounds(d.base)
+	return tail - head
+}
+
+// PushBack appends v at the back.
+func (d *Deque[T]) PushBack(v T) {
+	head, tail := loadBounds(d.base)
+	stygos.StorageStore(Offset(d.data, tail), d.codec.Encode(v))
+	storeBounds(d.base, head, tail+1)
+}
+
+// PushFront inserts v at the front.
+func (d *Deque[T]) PushFront(v T) {
+	head, tail := loadBounds(d.base)
+	head--
+	stygos.StorageStore(Offset(d.data, head), d.codec.Encode(v))
+	storeBounds(d.base, head, tail)
+}
+
+// PopFront removes and returns the front element. ok is false if the deque
+// is empty.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	head, tail := loadBounds(d.base)
+	if head == tail {
+		return v, false
+	}
+	v = d.take(head)
+	storeBounds(d.base, head+1, tail)
+	return v, true
+}
+
+// PopBack removes and returns the back element. ok is false if the deque is
+// empty.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	head, tail := loadBounds(d.base)
+	if head == tail {
+		return v, false
+	}
+	v = d.take(tail - 1)
+	storeBounds(d.base, head, tail-1)
+	return v, true
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (v T, ok bool) {
+	head, tail := loadBounds(d.base)
+	if head == tail {
+		return v, false
+	}
+	return d.codec.Decode(stygos.StorageLoad(Offset(d.data, head))), true
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (v T, ok bool) {
+	head, tail := loadBounds(d.base)
+	if head == tail {
+		return v, false
+	}
+	return d.codec.Decode(stygos.StorageLoad(Offset(d.data, tail-1))), true
+}
+
+// At returns the i-th element counting from the front. It panics if
+// i >= Len().
+func (d *Deque[T]) At(i uint64) T {
+	head, tail := loadBounds(d.base)
+	if i >= tail-head {
+		panic("storage: deque index out of range")
+	}
+	return d.codec.Decode(stygos.StorageLoad(Offset(d.data, head+i)))
+}
+
+// take loads the element at index and clears its slot.
+func (d *Deque[T]) take(index uint64) T {
+	slot := Offset(d.data, index)
+	v := d.codec.Decode(stygos.StorageLoad(slot))
+	stygos.StorageStore(slot, stygos.Word{})
+	return v
+}
+
+// RingBuffer keeps the last Cap() values pushed to it, overwriting the
+// oldest once full. It suits bounded histories such as price observations.
+//
+// The base slot packs the index of the oldest element and the element
+// count; element i lives at keccak256(base) + (i mod capacity).
+type RingBuffer[T any] struct {
+	base     stygos.Word
+	data     stygos.Word
+	capacity uint64
+	codec    WordCodec[T]
+}
+
+// NewRingBuffer returns the ring buffer of the given capacity rooted at
+// base. The capacity is part of the layout and must not change between
+// upgrades. It panics if capacity is zero.
+func NewRingBuffer[T any](base stygos.Word, capacity uint64, codec WordCodec[T]) *RingBuffer[T] {
+	if capacity == 0 {
+		panic("storage: ring buffer capacity must be positive")
+	}
+	return &RingBuffer[T]{base: base, data: stygos.Keccak256(base[:]), capacity: capacity, codec: codec}
+}
+
+// Len returns the number of stored values.
+func (r *RingBuffer[T]) Len() uint64 {
+	_, count := loadBounds(r.base)
+	return count
+}
+
+// Cap returns the capacity.
+func (r *RingBuffer[T]) Cap() uint64 {
+	return r.capacity
+}
+
+// Push appends v. When the buffer is full the oldest value is overwritten
+// and returned with evicted set to true.
+func (r *RingBuffer[T]) Push(v T) (old T, evicted bool) {
+	start, count := loadBounds(r.base)
+	slot := Offset(r.data, (start+count)%r.capacity)
+	if count == r.capacity {
+		old = r.codec.Decode(stygos.StorageLoad(slot))
+		evicted = true
+		start = (start + 1) % r.capacity
+	} else {
+		count++
+	}
+	stygos.StorageStore(slot, r.codec.Encode(v))
+	storeBounds(r.base, start, count)
+	return old, evicted
+}
+
+// Shift removes and returns the oldest value.
+func (r *RingBuffer[T]) Shift() (v T, ok bool) {
+	start, count := loadBounds(r.base)
+	if count == 0 {
+		return v, false
+	}
+	slot := Offset(r.data, start)
+	v = r.codec.Decode(stygos.StorageLoad(slot))
+	stygos.StorageStore(slot, stygos.Word{})
+	storeBounds(r.base, (start+1)%r.capacity, count-1)
+	return v, true
+}
+
+// At returns the i-th value, 0 being the oldest. It panics if i >= Len().
+func (r *RingBuffer[T]) At(i uint64) T {
+	start, count := loadBounds(r.base)
+	if i >= count {
+		panic("storage: ring buffer index out of range")
+	}
+	return r.codec.Decode(stygos.StorageLoad(Offset(r.data, (start+i)%r.capacity)))
+}
+
+// Latest returns the most recently pushed value.
+func (r *RingBuffer[T]) Latest() (v T, ok bool) {
+	start, count := loadBounds(r.base)
+	if count == 0 {
+		return v, false
+	}
+	return r.codec.Decode(stygos.StorageLoad(Offset(r.data, (start+count-1)%r.capacity))), true
+}
+
+// loadBounds reads two uint64 values packed in the low 16 bytes of a slot.
+func loadBounds(slot stygos.Word) (uint64, uint64) {
+	w := stygos.StorageLoad(slot)
+	return binary.BigEndian.Uint64(w[16:24]), binary.BigEndian.Uint64(w[24:32])
+}
+
+// storeBounds packs two uint64 values into the low 16 bytes of a slot.
+func storeBounds(slot stygos.Word, a, b uint64) {
+	var w stygos.Word
+	binary.BigEndian.PutUint64(w[16:24], a)
+	binary.BigEndian.PutUint64(w[24:32], b)
+	stygos.StorageStore(slot, w)
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestDeque(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	q := NewDeque(ConstSlot("queue"), Uint64s)
+	if _, ok := q.PopFront(); ok {
+		t.Errorf("PopFront on empty deque succeeded")
+	}
+
+	// Build 1 2 3 4 by pushing on both ends; 2 and 1 wrap below index zero
+	q.PushBack(3)
+	q.PushBack(4)
+	q.PushFront(2)
+	q.PushFront(1)
+	if q.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", q.Len())
+	}
+	for i := uint64(0); i < 4; i++ {
+		if got := q.At(i); got != i+1 {
+			t.Errorf("At(%d) = %d, want %d", i, got, i+1)
+		}
+	}
+	if v, _ := q.Front(); v != 1 {
+		t.Errorf("Front() = %d, want 1", v)
+	}
+	if v, _ := q.Back(); v != 4 {
+		t.Errorf("Back() = %d, want 4", v)
+	}
+
+	if v, ok := q.PopFront(); !ok || v != 1 {
+		t.Errorf("PopFront() = %d, %v, want 1", v, ok)
+	}
+	if v, ok := q.PopBack(); !ok || v != 4 {
+		t.Errorf("PopBack() = %d, %v, want 4", v, ok)
+	}
+	q.PopBack()
+	q.PopBack()
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d after popping everything", q.Len())
+	}
+	if len(mock.Storage) != 1 {
+		// Only the packed bounds slot remains
+		t.Errorf("%d slots left in storage, want 1", len(mock.Storage))
+	}
+}
+
+func TestRingBuffer(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	r := NewRingBuffer(ConstSlot("prices"), 3, Uint64s)
+	for v := uint64(1); v <= 3; v++ {
+		if _, evicted := r.Push(v); evicted {
+			t.Errorf("Push(%d) evicted before the buffer was full", v)
+		}
+	}
+	if old, evicted := r.Push(4); !evicted || old != 1 {
+		t.Errorf("Push(4) = %d, %v, want eviction of 1", old, evicted)
+	}
+	if r.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", r.Len())
+	}
+	for i, want := range []uint64{2, 3, 4} {
+		if got := r.At(uint64(i)); got != want {
+			t.Errorf("At(%d) = %d, want %d", i, got, want)
+		}
+	}
+	if v, _ := r.Latest(); v != 4 {
+		t.Errorf("Latest() = %d, want 4", v)
+	}
+	if v, ok := r.Shift(); !ok || v != 2 {
+		t.Errorf("Shift() = %d, %v, want 2", v, ok)
+	}
+	r.Push(5)
+	if got := r.At(2); got != 5 || r.Len() != 3 {
+		t.Errorf("after Shift and Push: At(2) = %d, Len() = %d", got, r.Len())
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// IterableMap is a storage mapping that also keeps an index of its keys, so
+// contracts can enumerate entries on chain (holders, owners, voters).
+//
+// Storage layout relative to the base slot:
+//
+//	base     key index (a WordArray of encoded keys)
+//	base+1   prefix of the value mapping: MapKey(base+1, key) -> value
+//	base+2   prefix of the position mapping: MapKey(base+2, key) -> index+1
+//
+// Set, Get and Delete are O(1); Delete moves the last key into the freed
+// position, so iteration order is not stable across deletions.
+type IterableMap[K comparable, V any] struct {
+	keys      WordArray
+	values    stygos.Word
+	positions stygos.Word
+	keyCodec  WordCodec[K]
+	valCodec  WordCodec[V]
+}
+
+// NewIterableMap returns the map rooted at base. base and the two following
+// slots must not be used by anything else.
+func NewIterableMap[K comparable, V any](base stygos.Word, keys WordCodec[K], values WordCodec[V]) *IterableMap[K, V] {
+	return &IterableMap[K, V]{
+		keys:      NewWordArray(base),
+		values:    Offset(base, 1),
+		positions: Offset(base, 2),
+		keyCodec:  keys,
+		valCodec:  values,
+	}
+}
+
+// Len returns the number of entries.
+func (m *IterableMap[K, V]) Len() uint64 {
+	return m.keys.Len()
+}
+
+// Contains reports whether k is present.
+func (m *IterableMap[K, V]) Contains(k K) bool {
+	return m.position(m.keyCodec.Encode(k)) != 0
+}
+
+// Get returns the value stored for k and whether k is present.
+func (m *IterableMap[K, V]) Get(k K) (V, bool) {
+	kw := m.keyCodec.Encode(k)
+	if m.position(kw) == 0 {
+		var zero V
+		return zero, false
+	}
+	return m.valCodec.Decode(stygos.StorageLoad(MapKey(m.values, kw[:]))), true
+}
+
+// Set stores v for k, adding k to the index if needed.
+func (m *IterableMap[K, V]) Set(k K, v V) {
+	kw := m.keyCodec.Encode(k)
+	if m.position(kw) == 0 {
+		idx := m.keys.Push(kw)
+		stygos.StorageStore(MapKey(m.positions, kw[:]), stygos.WordFromUint64(idx+1))
+	}
+	stygos.StorageStore(MapKey(m.values, kw[:]), m.valCodec.Encode(v))
+}
+
+// Delete removes k and reports whether it was present.
+func (m *IterableMap[K, V]) Delete(k K) bool {
+	kw := m.keyCodec.Encode(k)
+	pos := m.position(kw)
+	if pos == 0 {
+		return false
+	}
+
+	// Move the last key into the freed position
+	last := m.keys.Len() - 1
+	if pos-1 != last {
+		moved := m.keys.Get(last)
+		m.keys.Set(pos-1, moved)
+		stygos.StorageStore(MapKey(m.positions, moved[:]), stygos.WordFromUint64(pos))
+	}
+	m.keys.Pop()
+
+	stygos.StorageStore(MapKey(m.positions, kw[:]), stygos.Word{})
+	stygos.StorageStore(MapKey(m.values, kw[:]), stygos.Word{})
+	return true
+}
+
+// KeyAt returns the i-th key of the index. It panics if i >= Len().
+func (m *IterableMap[K, V]) KeyAt(i uint64) K {
+	return m.keyCodec.Decode(m.keys.Get(i))
+}
+
+// At returns the i-th entry of the index. It panics if i >= Len().
+func (m *IterableMap[K, V]) At(i uint64) (K, V) {
+	kw := m.keys.Get(i)
+	return m.keyCodec.Decode(kw), m.valCodec.Decode(stygos.StorageLoad(MapKey(m.values, kw[:])))
+}
+
+// Range calls fn for every entry until fn returns false. fn must not modify
+// the map.
+func (m *IterableMap[K, V]) Range(fn func(k K, v V) bool) {
+	n := m.Len()
+	for i := uint64(0); i < n; i++ {
+		if !fn(m.At(i)) {
+			return
+		}
+	}
+}
+
+// position returns the 1-based index of an encoded key, or 0 if absent.
+func (m *IterableMap[K, V]) position(kw stygos.Word) uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(MapKey(m.positions, kw[:])))
+}
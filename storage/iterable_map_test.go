@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestIterableMap(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	holders := NewIterableMap(ConstSlot("holders"), Addresses, Uint64s)
+
+	addrs := make([]stygos.Address, 4)
+	for i := range addrs {
+		addrs[i][19] = byte(i + 1)
+		holders.Set(addrs[i], uint64(100*(i+1)))
+	}
+	if holders.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", holders.Len())
+	}
+
+	// Overwriting keeps a single index entry
+	holders.Set(addrs[1], 250)
+	if holders.Len() != 4 {
+		t.Errorf("Len() after overwrite = %d, want 4", holders.Len())
+	}
+	if v, ok := holders.Get(addrs[1]); !ok || v != 250 {
+		t.Errorf("Get(addrs[1]) = %d, %v, want 250, true", v, ok)
+	}
+
+	// Delete from the middle moves the last key into its place
+	if !holders.Delete(addrs[0]) {
+		t.Fatalf("Delete(addrs[0]) = false")
+	}
+	if holders.Delete(addrs[0]) {
+		t.Errorf("second Delete(addrs[0]) = true")
+	}
+	if holders.Contains(addrs[0]) {
+		t.Errorf("deleted key still present")
+	}
+	if holders.KeyAt(0) != addrs[3] {
+		t.Errorf("KeyAt(0) = %x, want last key %x", holders.KeyAt(0), addrs[3])
+	}
+
+	sum := uint64(0)
+	holders.Range(func(k stygos.Address, v uint64) bool {
+		sum += v
+		return true
+	})
+	if sum != 250+300+400 {
+		t.Errorf("sum over Range = %d, want %d", sum, 250+300+400)
+	}
+
+	// Remaining keys are still reachable through their new positions
+	for _, addr := range addrs[1:] {
+		holders.Delete(addr)
+	}
+	if holders.Len() != 0 {
+		t.Errorf("Len() after deleting all = %d, want 0", holders.Len())
+	}
+	if len(mock.Storage) != 0 {
+		t.Errorf("%d slots left in storage after deleting all entries", len(mock.Storage))
+	}
+}
+
+func TestWordArray(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	arr := NewWordArray(ConstSlot("array"))
+	if _, ok := arr.Pop(); ok {
+		t.Errorf("Pop() on empty array succeeded")
+	}
+	for i := uint64(0); i < 3; i++ {
+		if idx := arr.Push(stygos.WordFromUint64(i + 10)); idx != i {
+			t.Errorf("Push returned index %d, want %d", idx, i)
+		}
+	}
+	arr.Set(1, stygos.WordFromUint64(99))
+	if got := stygos.Uint64FromWord(arr.Get(1)); got != 99 {
+		t.Errorf("Get(1) = %d, want 99", got)
+	}
+	if w, ok := arr.Pop(); !ok || stygos.Uint64FromWord(w) != 12 {
+		t.Errorf("Pop() = %x, %v, want 12", w, ok)
+	}
+	if arr.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", arr.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Get out of range did not panic")
+		}
+	}()
+	arr.Get(2)
+}
@@ -0,0 +1,291 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Kind describes how a declared key is used in storage.
+type Kind uint8
+
+const (
+	// KindSlot is a single storage slot.
+	KindSlot Kind = iota
+	// KindMapping is a prefix whose key space is keccak256(prefix ++ key).
+	KindMapping
+	// KindArray is a run of consecutive slots starting at the key.
+	KindArray
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindSlot:
+		return "slot"
+	case KindMapping:
+		return "mapping"
+	case KindArray:
+		return "array"
+	default:
+		return fmt.Sprintf("kind(%d)", uint8(k))
+	}
+}
+
+// Entry is a single declaration in a storage layout.
+type Entry struct {
+	Component string      // Library or module owning the key, e.g. "erc20"
+	Name      string      // Human readable name, e.g. "balance"
+	Kind      Kind        // How the key is used
+	Key       stygos.Word // Slot, mapping prefix or first array slot
+	Length    uint64      // Number of slots for KindArray
+}
+
+// String returns a short description of the entry.
+func (e Entry) String() string {
+	return fmt.Sprintf("%s.%s (%s %x)", e.Component, e.Name, e.Kind, e.Key[:4])
+}
+
+// Collision reports two entries whose key spaces overlap.
+type Collision struct {
+	A, B Entry
+}
+
+// String describes the collision.
+func (c Collision) String() string {
+	return c.A.String() + " overlaps " + c.B.String()
+}
+
+// CollisionError is returned by Layout.Check when key spaces overlap.
+type CollisionError struct {
+	Collisions []Collision
+}
+
+// Error lists every collision.
+func (e *CollisionError) Error() string {
+	parts := make([]string, len(e.Collisions))
+	for i, c := range e.Collisions {
+		parts[i] = c.String()
+	}
+	return "storage layout collision: " + strings.Join(parts, "; ")
+}
+
+// Layout is a registry of the storage keys declared by the components of a
+// contract. It is used to detect components that accidentally share key
+// spaces, such as a token and a governance module both deriving their
+// balances from keccak256("balance").
+type Layout struct {
+	entries  []Entry
+	derived  map[stygos.Word]stygos.Word // mapping key -> prefix, see MapKey
+	observed []Collision                 // collisions seen at runtime, see Watch
+}
+
+// NewLayout creates an empty layout.
+func NewLayout() *Layout {
+	return &Layout{derived: make(map[stygos.Word]stygos.Word)}
+}
+
+// DefaultLayout collects the declarations made with Declare, DeclareMapping
+// and DeclareArray. Under TinyGo nothing is recorded, so declaring keys costs
+// nothing on chain.
+var DefaultLayout = NewLayout()
+
+// Declare records a single slot owned by component and returns key, so it
+// can be used directly in a variable declaration:
+//
+//	var totalSupplyKey = storage.Declare("erc20", "totalSupply", storage.ConstSlot("totalSupply"))
+func Declare(component, name string, key stygos.Word) stygos.Word {
+	if layoutEnabled {
+		DefaultLayout.Add(Entry{Component: component, Name: name, Kind: KindSlot, Key: key})
+	}
+	return key
+}
+
+// DeclareMapping records a mapping prefix owned by component and returns it.
+func DeclareMapping(component, name string, prefix stygos.Word) stygos.Word {
+	if layoutEnabled {
+		DefaultLayout.Add(Entry{Component: component, Name: name, Kind: KindMapping, Key: prefix})
+	}
+	return prefix
+}
+
+// DeclareArray records length consecutive slots starting at base and
+// returns base.
+func DeclareArray(component, name string, base stygos.Word, length uint64) stygos.Word {
+	if layoutEnabled {
+		DefaultLayout.Add(Entry{Component: component, Name: name, Kind: KindArray, Key: base, Length: length})
+	}
+	return base
+}
+
+// MapKey derives the key of an element of a mapping as keccak256(prefix ++ key),
+// the scheme used throughout the examples. Keys derived this way can be
+// attributed to their mapping by a watched layout.
+func MapKey(prefix stygos.Word, key []byte) stygos.Word {
+	buf := make([]byte, 32+len(key))
+	copy(buf, prefix[:])
+	copy(buf[32:], key)
+	slot := stygos.Keccak256(buf)
+	if layoutEnabled {
+		DefaultLayout.derived[slot] = prefix
+	}
+	return slot
+}
+
+// Add records an entry.
+func (l *Layout) Add(e Entry) {
+	l.entries = append(l.entries, e)
+}
+
+// Entries returns the recorded entries in declaration order.
+func (l *Layout) Entries() []Entry {
+	return l.entries
+}
+
+// Hash returns the LayoutHash of the declared keys, for
+// stygos.Router.SetLayoutHash.
+func (l *Layout) Hash() stygos.Word {
+	keys := make([]stygos.Word, len(l.entries))
+	for i, e := range l.entries {
+		keys[i] = e.Key
+	}
+	return LayoutHash(keys...)
+}
+
+// LayoutHash identifies a storage layout by its root keys: keccak256 of the
+// distinct keys, sorted and concatenated. Renaming a key keeps the hash;
+// adding, removing or moving one changes it. `stygos-gen slots -layout`
+// computes the same hash at generation time.
+func LayoutHash(keys ...stygos.Word) stygos.Word {
+	sorted := append([]stygos.Word(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i][:], sorted[j][:]) < 0 })
+	buf := make([]byte, 0, 32*len(sorted))
+	for i, k := range sorted {
+		if i > 0 && k == sorted[i-1] {
+			continue
+		}
+		buf = append(buf, k[:]...)
+	}
+	return stygos.Keccak256(buf)
+}
+
+// Check reports every pair of entries from the layout whose key spaces
+// overlap, plus any collision observed at runtime while the layout was
+// watching a mock. It returns nil or a *CollisionError.
+//
+// Contracts typically run it from a test so that composing modules with
+// clashing keys fails the build:
+//
+//	func TestStorageLayout(t *testing.T) {
+//		if err := storage.DefaultLayout.Check(); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+func (l *Layout) Check() error {
+	var collisions []Collision
+	for i := 0; i < len(l.entries); i++ {
+		for j := i + 1; j < len(l.entries); j++ {
+			if overlaps(l.entries[i], l.entries[j]) {
+				collisions = append(collisions, Collision{A: l.entries[i], B: l.entries[j]})
+			}
+		}
+	}
+	for _, c := range l.observed {
+		if !containsCollision(collisions, c) {
+			collisions = append(collisions, c)
+		}
+	}
+	if len(collisions) == 0 {
+		return nil
+	}
+	return &CollisionError{Collisions: collisions}
+}
+
+// Watch installs a storage hook on mock that attributes every accessed key to
+// the entries owning it. Keys owned by entries of different components are
+// recorded as collisions and reported by Check.
+func (l *Layout) Watch(mock *stygos.MockRuntime) {
+	mock.StorageHook = func(key [32]byte, write bool) {
+		owners := l.owners(key)
+		for i := 1; i < len(owners); i++ {
+			if owners[i].Component != owners[0].Component {
+				l.observe(Collision{A: owners[0], B: owners[i]})
+			}
+		}
+	}
+}
+
+// observe records c unless the same pair was already recorded.
+func (l *Layout) observe(c Collision) {
+	if !containsCollision(l.observed, c) {
+		l.observed = append(l.observed, c)
+	}
+}
+
+// Observed returns the collisions seen at runtime while watching a mock.
+func (l *Layout) Observed() []Collision {
+	return l.observed
+}
+
+// containsCollision reports whether list already holds the pair in c.
+func containsCollision(list []Collision, c Collision) bool {
+	for _, seen := range list {
+		if seen == c || (seen.A == c.B && seen.B == c.A) {
+			return true
+		}
+	}
+	return false
+}
+
+// owners returns the entries whose key space contains key.
+func (l *Layout) owners(key stygos.Word) []Entry {
+	prefix, derived := l.derived[key]
+	if !derived && l != DefaultLayout {
+		prefix, derived = DefaultLayout.derived[key]
+	}
+
+	var owners []Entry
+	for _, e := range l.entries {
+		switch e.Kind {
+		case KindSlot:
+			if e.Key == key {
+				owners = append(owners, e)
+			}
+		case KindMapping:
+			if derived && e.Key == prefix {
+				owners = append(owners, e)
+			}
+		case KindArray:
+			if inArray(e, key) {
+				owners = append(owners, e)
+			}
+		}
+	}
+	return owners
+}
+
+// overlaps reports whether two entries share any key.
+func overlaps(a, b Entry) bool {
+	if a.Kind == KindArray && b.Kind == KindArray {
+		return inArray(a, b.Key) || inArray(b, a.Key)
+	}
+	if a.Kind == KindArray {
+		return inArray(a, b.Key)
+	}
+	if b.Kind == KindArray {
+		return inArray(b, a.Key)
+	}
+	// Slots and mapping prefixes clash when they reuse the same key: two
+	// mappings then share every element, and a slot reusing a prefix means
+	// two modules derived their keys from the same name.
+	return a.Key == b.Key
+}
+
+// inArray reports whether key falls inside the slots of an array entry,
+// which wrap modulo 2^256 like Offset.
+func inArray(e Entry, key stygos.Word) bool {
+	return key.Sub(e.Key).Cmp(stygos.WordFromUint64(e.Length)) < 0
+}
@@ -0,0 +1,39 @@
+//go:build !tinygo
+
+package storage
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// layoutEnabled turns on layout recording for regular Go builds, where the
+// layout is checked by tests and the mock runtime.
+const layoutEnabled = true
+
+// manifestEntry is the JSON form of an Entry.
+type manifestEntry struct {
+	Component string `json:"component"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Key       string `json:"key"`
+	Length    uint64 `json:"length,omitempty"`
+}
+
+// WriteManifest writes the layout as a JSON manifest listing every declared
+// key, so it can be reviewed or diffed between releases.
+func (l *Layout) WriteManifest(w io.Writer) error {
+	entries := make([]manifestEntry, len(l.entries))
+	for i, e := range l.entries {
+		entries[i] = manifestEntry{
+			Component: e.Component,
+			Name:      e.Name,
+			Kind:      e.Kind.String(),
+			Key:       e.Key.Hex(),
+			Length:    e.Length,
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestLayoutCheck(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	l := NewLayout()
+	l.Add(Entry{Component: "erc20", Name: "balance", Kind: KindMapping, Key: ConstSlot("balance")})
+	l.Add(Entry{Component: "erc20", Name: "totalSupply", Kind: KindSlot, Key: ConstSlot("totalSupply")})
+	l.Add(Entry{Component: "governance", Name: "proposals", Kind: KindArray, Key: stygos.WordFromUint64(100), Length: 10})
+	if err := l.Check(); err != nil {
+		t.Fatalf("disjoint layout reported %v", err)
+	}
+
+	// A second module deriving its balances from the same name
+	l.Add(Entry{Component: "staking", Name: "balance", Kind: KindMapping, Key: ConstSlot("balance")})
+	// A slot inside the proposals array
+	l.Add(Entry{Component: "auth", Name: "owner", Kind: KindSlot, Key: stygos.WordFromUint64(105)})
+	// An array overlapping the proposals array
+	l.Add(Entry{Component: "queue", Name: "items", Kind: KindArray, Key: stygos.WordFromUint64(95), Length: 6})
+
+	err := l.Check()
+	var collisionErr *CollisionError
+	if !errors.As(err, &collisionErr) {
+		t.Fatalf("Check() = %v, want *CollisionError", err)
+	}
+	if len(collisionErr.Collisions) != 3 {
+		t.Errorf("got %d collisions, want 3: %v", len(collisionErr.Collisions), err)
+	}
+}
+
+func TestLayoutWatch(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	l := NewLayout()
+	tokenBalances := ConstSlot("balance")
+	l.Add(Entry{Component: "token", Name: "balances", Kind: KindMapping, Key: tokenBalances})
+	l.Add(Entry{Component: "votes", Name: "weights", Kind: KindMapping, Key: tokenBalances})
+	l.Watch(mock)
+
+	var holder stygos.Address
+	holder[0] = 1
+	stygos.StorageStore(MapKey(tokenBalances, holder[:]), stygos.WordFromUint64(5))
+
+	if observed := l.Observed(); len(observed) != 1 || observed[0].A.Component != "token" {
+		t.Fatalf("Observed() = %v, want the token/votes collision", observed)
+	}
+
+	// The observed collision is the same pair the static check reports, so
+	// it is not reported twice.
+	var collisionErr *CollisionError
+	if err := l.Check(); !errors.As(err, &collisionErr) || len(collisionErr.Collisions) != 1 {
+		t.Errorf("Check() = %v, want a single collision", err)
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	l := NewLayout()
+	l.Add(Entry{Component: "erc20", Name: "allowance", Kind: KindMapping, Key: ConstSlot("allowance")})
+
+	var buf bytes.Buffer
+	if err := l.WriteManifest(&buf); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["kind"] != "mapping" || entries[0]["component"] != "erc20" {
+		t.Errorf("unexpected manifest %s", buf.String())
+	}
+}
+
+func TestLayoutHash(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	a, b := ConstSlot("balance"), ConstSlot("totalSupply")
+	h := LayoutHash(a, b)
+	if LayoutHash(b, a) != h || LayoutHash(a, b, a) != h {
+		t.Errorf("LayoutHash depends on key order or duplicates")
+	}
+	if LayoutHash(a) == h || LayoutHash(a, ConstSlot("supply")) == h {
+		t.Errorf("LayoutHash did not change with the keys")
+	}
+
+	l := NewLayout()
+	l.Add(Entry{Component: "erc20", Name: "totalSupply", Kind: KindSlot, Key: b})
+	l.Add(Entry{Component: "erc20", Name: "balance", Kind: KindMapping, Key: a})
+	if got := l.Hash(); got != h {
+		t.Errorf("Hash() = %x, want %x", got, h)
+	}
+}
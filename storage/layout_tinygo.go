@@ -0,0 +1,7 @@
+//go:build tinygo
+
+package storage
+
+// layoutEnabled turns off layout recording in contracts, where declarations
+// only return their key.
+const layoutEnabled = false
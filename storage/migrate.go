@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Migration errors
+var (
+	ErrSchemaTooNew = errors.New("storage: stored schema is newer than the implementation")
+)
+
+// initializedTopic is the topic of OpenZeppelin's Initialized(uint64),
+// emitted for each version reached.
+var initializedTopic = stygos.Keccak256([]byte("Initialized(uint64)"))
+
+// Migration is a step of a Migrator, bringing storage to Version from the
+// version of the step before it.
+type Migration struct {
+	Version uint64
+	Run     func() error
+}
+
+// Migrator upgrades the storage layout of a contract when a new
+// implementation is first called. A slot holds the schema version; Run
+// applies the steps above it in order, recording the version after each,
+// so every step runs once per contract and calling Run again is a single
+// storage load.
+//
+// Call Run at the top of the entrypoint, before dispatching:
+//
+//	var migrator = storage.NewMigrator(schemaKey,
+//		storage.Migration{Version: 1, Run: initialize},
+//		storage.Migration{Version: 2, Run: splitBalances},
+//	)
+//
+//	func entrypoint() int32 {
+//		if _, err := migrator.Run(); err != nil {
+//			return 1
+//		}
+//		return router.Entrypoint()
+//	}
+//
+// A failing step returns its error and the caller reverts, which rolls
+// back the steps before it too. The first call after an upgrade writes
+// storage, so it must not be a static call; upgrade scripts usually make
+// it themselves.
+type Migrator struct {
+	slot  stygos.Word
+	steps []Migration
+}
+
+// NewMigrator returns a migrator keeping the schema version at slot. Step
+// versions must increase strictly from 1 or more.
+func NewMigrator(slot stygos.Word, steps ...Migration) *Migrator {
+	var last uint64
+	for _, s := range steps {
+		if s.Version <= last {
+			panic("storage: migration versions must increase")
+		}
+		last = s.Version
+	}
+	return &Migrator{slot: slot, steps: steps}
+}
+
+// Version returns the schema version in storage, 0 before any step ran.
+func (m *Migrator) Version() uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(m.slot))
+}
+
+// Latest returns the schema version of the implementation, the version of
+// its last step.
+func (m *Migrator) Latest() uint64 {
+	if len(m.steps) == 0 {
+		return 0
+	}
+	return m.steps[len(m.steps)-1].Version
+}
+
+// Pending reports whether steps remain to run.
+func (m *Migrator) Pending() bool {
+	return m.Version() < m.Latest()
+}
+
+// Run applies the steps above the stored version in order and returns how
+// many ran. Each completed step records its version and emits
+// Initialized(uint64), as OpenZeppelin's reinitializers do. It returns
+// ErrSchemaTooNew if storage was migrated by a later implementation, as
+// after a rollback to an older one.
+func (m *Migrator) Run() (int, error) {
+	current := m.Version()
+	latest := m.Latest()
+	if current == latest {
+		return 0, nil
+	}
+	if current > latest {
+		return 0, ErrSchemaTooNew
+	}
+	ran := 0
+	for _, s := range m.steps {
+		if s.Version <= current {
+			continue
+		}
+		if err := s.Run(); err != nil {
+			return ran, err
+		}
+		version := stygos.WordFromUint64(s.Version)
+		stygos.StorageStore(m.slot, version)
+		stygos.EmitEvent(version[:], initializedTopic)
+		ran++
+	}
+	return ran, nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// The token below keeps balances in a plain mapping in v1. v2 moves them,
+// with the owner, into an ERC-7201 namespace and records when each
+// balance last changed.
+var (
+	schemaSlot   = ConstSlot("schema")
+	v1Owner      = ConstSlot("owner")
+	v1Balances   = ConstSlot("balances")
+	v1Holders    = NewWordArray(ConstSlot("holders"))
+	v2Namespace  = Namespace("example.token.v2")
+	v2Owner      = Offset(v2Namespace, 0)
+	v2Accounts   = Offset(v2Namespace, 1)
+	accountCodec = ABICodec[account]{
+		Words: 2,
+		Encode: func(a account) []stygos.Word {
+			return []stygos.Word{stygos.WordFromUint64(a.Balance), stygos.WordFromUint64(a.Updated)}
+		},
+		Decode: func(w []stygos.Word) (account, error) {
+			return account{Balance: stygos.Uint64FromWord(w[0]), Updated: stygos.Uint64FromWord(w[1])}, nil
+		},
+	}
+)
+
+type account struct {
+	Balance, Updated uint64
+}
+
+// initializeOwner is step 1 of both implementations.
+func initializeOwner() error {
+	stygos.StorageStore(v1Owner, stygos.PadAddress(stygos.GetMsgSender()))
+	return nil
+}
+
+// tokenV1 credits deposits (command 0) and returns balances (command 1).
+func tokenV1(migrator *Migrator) stygos.MockContract {
+	return func(input []byte) ([]byte, error) {
+		if _, err := migrator.Run(); err != nil {
+			return nil, err
+		}
+		sender := stygos.GetMsgSender()
+		slot := MapKey(v1Balances, sender[:])
+		switch input[0] {
+		case 0:
+			balance := stygos.Uint64FromWord(stygos.StorageLoad(slot))
+			if balance == 0 {
+				v1Holders.Push(stygos.PadAddress(sender))
+			}
+			stygos.StorageStore(slot, stygos.WordFromUint64(balance+binary.BigEndian.Uint64(input[1:])))
+			return nil, nil
+		default:
+			w := stygos.StorageLoad(slot)
+			return w[:], nil
+		}
+	}
+}
+
+// tokenV2 reads balances from the v2 layout.
+func tokenV2(migrator *Migrator) stygos.MockContract {
+	accounts := NewMapping(v2Accounts, Addresses, Codec[account](accountCodec))
+	return func(input []byte) ([]byte, error) {
+		if _, err := migrator.Run(); err != nil {
+			return nil, err
+		}
+		a, err := accounts.Get(stygos.GetMsgSender())
+		if err != nil {
+			return nil, err
+		}
+		w := stygos.WordFromUint64(a.Balance)
+		return w[:], nil
+	}
+}
+
+// moveToNamespace is step 2: it moves the owner and the balances into the
+// namespace and clears the v1 slots.
+func moveToNamespace() error {
+	stygos.StorageStore(v2Owner, stygos.StorageLoad(v1Owner))
+	stygos.StorageStore(v1Owner, stygos.Word{})
+	accounts := NewMapping(v2Accounts, Addresses, Codec[account](accountCodec))
+	now := stygos.GetBlockTimestamp()
+	for {
+		holder, ok := v1Holders.Pop()
+		if !ok {
+			return nil
+		}
+		addr := stygos.AddressFromWord(holder)
+		slot := MapKey(v1Balances, addr[:])
+		balance := stygos.Uint64FromWord(stygos.StorageLoad(slot))
+		if err := accounts.Set(addr, account{Balance: balance, Updated: now}); err != nil {
+			return err
+		}
+		stygos.StorageStore(slot, stygos.Word{})
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	mock.Time = 500
+	tokenAddr, alice, bob := stygos.Address{0x70}, stygos.Address{0xa1}, stygos.Address{0xb0}
+	call := func(from stygos.Address, data ...byte) ([]byte, error) {
+		mock.Contract = from
+		return stygos.Call(tokenAddr, stygos.Word{}, data)
+	}
+	// inToken runs fn in the token's storage
+	inToken := func(fn func()) {
+		mock.Contract = stygos.Address{}
+		saved := mock.Storage
+		mock.Storage = mock.StorageOf(tokenAddr)
+		mock.Contract = tokenAddr
+		fn()
+		mock.Contract = stygos.Address{}
+		mock.Storage = saved
+	}
+	deposit := func(from stygos.Address, amount uint64) {
+		data := make([]byte, 9)
+		binary.BigEndian.PutUint64(data[1:], amount)
+		if _, err := call(from, data...); err != nil {
+			t.Fatalf("deposit failed: %v", err)
+		}
+	}
+
+	v1 := NewMigrator(schemaSlot, Migration{Version: 1, Run: initializeOwner})
+	mock.Deploy(tokenAddr, tokenV1(v1))
+	deposit(alice, 30)
+	deposit(bob, 5)
+	deposit(alice, 12)
+	inToken(func() {
+		if v1.Version() != 1 || v1.Pending() {
+			t.Fatalf("Version() = %d, want 1 after the first call", v1.Version())
+		}
+	})
+
+	// Upgrade: the first call to v2 runs step 2 only
+	ran := 0
+	v2 := NewMigrator(schemaSlot,
+		Migration{Version: 1, Run: func() error { ran++; return initializeOwner() }},
+		Migration{Version: 2, Run: func() error { ran += 10; return moveToNamespace() }},
+	)
+	mock.Deploy(tokenAddr, tokenV2(v2))
+	logs, version2 := len(mock.Logs), stygos.WordFromUint64(2)
+	out, err := call(alice)
+	if err != nil || len(out) != 32 || out[31] != 42 {
+		t.Fatalf("balanceOf(alice) after upgrade = %x, %v, want 42", out, err)
+	}
+	if ran != 10 {
+		t.Errorf("migration steps ran = %d, want step 2 once", ran)
+	}
+	if len(mock.Logs) != logs+1 || !strings.HasSuffix(string(mock.Logs[logs]), hex.EncodeToString(version2[:])+"\n") {
+		t.Errorf("Run emitted %d logs, want Initialized(2)", len(mock.Logs)-logs)
+	}
+	if out, _ := call(bob); out[31] != 5 {
+		t.Errorf("balanceOf(bob) after upgrade = %x, want 5", out)
+	}
+	if ran != 10 {
+		t.Errorf("migration steps ran again on the second call")
+	}
+
+	inToken(func() {
+		if v2.Version() != 2 || v2.Pending() {
+			t.Errorf("Version() = %d, want 2", v2.Version())
+		}
+		if stygos.StorageLoad(v1Owner) != (stygos.Word{}) || stygos.AddressFromWord(stygos.StorageLoad(v2Owner)) != alice {
+			t.Errorf("owner was not moved to the namespace")
+		}
+		if stygos.StorageLoad(MapKey(v1Balances, alice[:])) != (stygos.Word{}) || v1Holders.Len() != 0 {
+			t.Errorf("v1 balances were not cleared")
+		}
+		if a, _ := NewMapping(v2Accounts, Addresses, Codec[account](accountCodec)).Get(alice); a.Updated != 500 {
+			t.Errorf("account = %+v, want updated at 500", a)
+		}
+	})
+
+	// Rolling back to v1 is refused rather than reading a layout it does
+	// not know
+	mock.Deploy(tokenAddr, tokenV1(v1))
+	if _, err := call(alice, 1); err == nil {
+		t.Errorf("call to v1 after the v2 migration succeeded")
+	}
+	inToken(func() {
+		if _, err := v1.Run(); err != ErrSchemaTooNew {
+			t.Errorf("Run() = %v, want %v", err, ErrSchemaTooNew)
+		}
+	})
+}
+
+func TestMigratorOrder(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewMigrator with decreasing versions did not panic")
+		}
+	}()
+	NewMigrator(schemaSlot, Migration{Version: 2}, Migration{Version: 1})
+}
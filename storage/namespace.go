@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// Namespace returns the ERC-7201 base slot for a namespace id:
+//
+//	keccak256(abi.encode(uint256(keccak256(id)) - 1)) & ~bytes32(uint256(0xff))
+//
+// Fields of a namespaced struct live at consecutive slots from the base, see
+// Offset. Following the convention keeps the layout of an upgradeable
+// contract stable and compatible with Solidity contracts using
+// @custom:storage-location erc7201:<id>.
+func Namespace(id string) stygos.Word {
+	inner := stygos.Keccak256([]byte(id))
+
+	// uint256(keccak256(id)) - 1, borrowing across bytes
+	for i := 31; i >= 0; i-- {
+		inner[i]--
+		if inner[i] != 0xff {
+			break
+		}
+	}
+
+	base := stygos.Keccak256(inner[:])
+	base[31] = 0
+	return base
+}
+
+// DeclareNamespace records the field slots of an ERC-7201 namespace in the
+// default layout and returns its base slot.
+func DeclareNamespace(component, id string, fields uint64) stygos.Word {
+	return DeclareArray(component, id, Namespace(id), fields)
+}
+
+// Offset returns the slot n positions after base, wrapping modulo 2^256.
+func Offset(base stygos.Word, n uint64) stygos.Word {
+	return base.Add(stygos.WordFromUint64(n))
+}
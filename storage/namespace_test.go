@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestNamespace(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	// Reference value from the ERC-7201 specification
+	want := "183a6125c38840424c4a85fa12bab2ab606c4b6d0e7cc73c0c06ba5300eab500"
+	got := Namespace("example.main")
+	if hex.EncodeToString(got[:]) != want {
+		t.Errorf("Namespace(example.main) = %x, want %s", got, want)
+	}
+	if got[31] != 0 {
+		t.Errorf("base slot is not aligned to 256 slots: %x", got)
+	}
+	if Namespace("erc20.main") == Namespace("erc20.v2") {
+		t.Errorf("distinct namespaces derived the same base")
+	}
+}
+
+func TestOffset(t *testing.T) {
+	base := stygos.WordFromUint64(0xff)
+	if got := Offset(base, 1); stygos.Uint64FromWord(got) != 0x100 {
+		t.Errorf("Offset(0xff, 1) = %x, want 0x100", got)
+	}
+	if got := Offset(base, 0); got != base {
+		t.Errorf("Offset(base, 0) = %x, want base", got)
+	}
+
+	var max stygos.Word
+	for i := range max {
+		max[i] = 0xff
+	}
+	if got := Offset(max, 2); stygos.Uint64FromWord(got) != 1 || got[0] != 0 {
+		t.Errorf("Offset(max, 2) = %x, want 1 (wrapped)", got)
+	}
+
+	// Large offsets carry across several bytes
+	if got := Offset(stygos.WordFromUint64(1<<40-1), 1<<40+1); stygos.Uint64FromWord(got) != 1<<41 {
+		t.Errorf("Offset carry = %x, want 2^41", got)
+	}
+}
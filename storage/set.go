@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// Set is an enumerable set of values over contract storage, in the spirit of
+// OpenZeppelin's EnumerableSet.
+//
+// Storage layout relative to the base slot:
+//
+//	base     member index (a WordArray of encoded values)
+//	base+1   prefix of the position mapping: MapKey(base+1, value) -> index+1
+//
+// Add, Remove and Contains are O(1). Remove moves the last member into the
+// freed position, so the order returned by At is not stable across removals.
+type Set[T comparable] struct {
+	members   WordArray
+	positions stygos.Word
+	codec     WordCodec[T]
+}
+
+// AddressSet is a set of addresses, e.g. multisig owners.
+type AddressSet = Set[stygos.Address]
+
+// U256Set is a set of 256-bit integers, e.g. proposal ids.
+type U256Set = Set[stygos.U256]
+
+// NewSet returns the set rooted at base. base and the following slot must
+// not be used by anything else.
+func NewSet[T comparable](base stygos.Word, codec WordCodec[T]) *Set[T] {
+	return &Set[T]{
+		members:   NewWordArray(base),
+		positions: Offset(base, 1),
+		codec:     codec,
+	}
+}
+
+// NewAddressSet returns the address set rooted at base.
+func NewAddressSet(base stygos.Word) *AddressSet {
+	return NewSet(base, Addresses)
+}
+
+// NewU256Set returns the U256 set rooted at base.
+func NewU256Set(base stygos.Word) *U256Set {
+	return NewSet(base, U256s)
+}
+
+// Add inserts v and reports whether it was not already present.
+func (s *Set[T]) Add(v T) bool {
+	w := s.codec.Encode(v)
+	if s.position(w) != 0 {
+		return false
+	}
+	idx := s.members.Push(w)
+	stygos.StorageStore(MapKey(s.positions, w[:]), stygos.WordFromUint64(idx+1))
+	return true
+}
+
+// Remove deletes v and reports whether it was present.
+func (s *Set[T]) Remove(v T) bool {
+	w := s.codec.Encode(v)
+	pos := s.position(w)
+	if pos == 0 {
+		return false
+	}
+
+	last := s.members.Len() - 1
+	if pos-1 != last {
+		moved := s.members.Get(last)
+		s.members.Set(pos-1, moved)
+		stygos.StorageStore(MapKey(s.positions, moved[:]), stygos.WordFromUint64(pos))
+	}
+	s.members.Pop()
+	stygos.StorageStore(MapKey(s.positions, w[:]), stygos.Word{})
+	return true
+}
+
+// Contains reports whether v is a member.
+func (s *Set[T]) Contains(v T) bool {
+	w := s.codec.Encode(v)
+	return s.position(w) != 0
+}
+
+// Length returns the number of members.
+func (s *Set[T]) Length() uint64 {
+	return s.members.Len()
+}
+
+// At returns the i-th member. It panics if i >= Length().
+func (s *Set[T]) At(i uint64) T {
+	return s.codec.Decode(s.members.Get(i))
+}
+
+// Values returns all members. Reading every slot is expensive on chain; it is
+// intended for view calls on small sets.
+func (s *Set[T]) Values() []T {
+	n := s.members.Len()
+	values := make([]T, n)
+	for i := uint64(0); i < n; i++ {
+		values[i] = s.At(i)
+	}
+	return values
+}
+
+// position returns the 1-based index of an encoded member, or 0 if absent.
+func (s *Set[T]) position(w stygos.Word) uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(MapKey(s.positions, w[:])))
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestAddressSet(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	owners := NewAddressSet(ConstSlot("owners"))
+	var a, b, c stygos.Address
+	a[19], b[19], c[19] = 1, 2, 3
+
+	for _, addr := range []stygos.Address{a, b, c} {
+		if !owners.Add(addr) {
+			t.Errorf("Add(%x) = false for new member", addr)
+		}
+	}
+	if owners.Add(b) {
+		t.Errorf("Add(b) = true for existing member")
+	}
+	if owners.Length() != 3 {
+		t.Fatalf("Length() = %d, want 3", owners.Length())
+	}
+
+	if !owners.Remove(a) || owners.Remove(a) {
+		t.Errorf("Remove(a) should succeed exactly once")
+	}
+	if owners.Contains(a) || !owners.Contains(b) || !owners.Contains(c) {
+		t.Errorf("membership wrong after Remove: %v", owners.Values())
+	}
+	if owners.At(0) != c {
+		t.Errorf("At(0) = %x, want last member moved into place", owners.At(0))
+	}
+
+	owners.Remove(b)
+	owners.Remove(c)
+	if owners.Length() != 0 || len(mock.Storage) != 0 {
+		t.Errorf("set not empty after removing all: length %d, %d slots", owners.Length(), len(mock.Storage))
+	}
+}
+
+func TestU256Set(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	ids := NewU256Set(ConstSlot("proposals"))
+	big := stygos.NewU256(1).Lsh(200)
+	ids.Add(stygos.NewU256(7))
+	ids.Add(big)
+
+	if !ids.Contains(big) || ids.Contains(stygos.NewU256(8)) {
+		t.Errorf("Contains mismatch")
+	}
+	values := ids.Values()
+	if len(values) != 2 || values[0] != stygos.NewU256(7) || values[1] != big {
+		t.Errorf("Values() = %v", values)
+	}
+}
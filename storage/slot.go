@@ -0,0 +1,19 @@
+// Package storage provides slot derivation helpers and typed containers built
+// on top of the raw 32-byte storage host functions exposed by stygos.
+package storage
+
+import (
+	"github.com/rafaelescrich/stygos"
+)
+
+// ConstSlot returns the storage slot derived from a constant name, which is
+// keccak256(name).
+//
+// ConstSlot hashes at runtime. Contracts that want to keep the hash off the hot
+// path should declare their slots with `stygos-gen slots`, which emits the same
+// keys as precomputed Word literals:
+//
+//	//go:generate stygos-gen slots -o slots_gen.go balancePrefix=balance
+func ConstSlot(name string) stygos.Word {
+	return stygos.Keccak256([]byte(name))
+}
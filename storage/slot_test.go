@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestConstSlot(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	want := stygos.Keccak256([]byte("balance"))
+	if got := ConstSlot("balance"); got != want {
+		t.Errorf("ConstSlot(balance) = %x, want %x", got, want)
+	}
+	if ConstSlot("balance") == ConstSlot("allowance") {
+		t.Errorf("distinct names derived the same slot")
+	}
+}
@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Codec errors
+var (
+	ErrCodecSize      = errors.New("storage: encoded value has the wrong number of words")
+	ErrUnknownVersion = errors.New("storage: value stored in an unknown version")
+)
+
+// Codec stores values of type T, such as structs, from a slot on. Unlike a
+// WordCodec it may use any number of slots; containers such as Mapping take
+// one to choose how their values are encoded.
+//
+// The built-in codecs are:
+//
+//	Word(c)         one slot, through a WordCodec
+//	Packed[T]()     a stygos-gen pack struct, in PackedWords slots
+//	ABICodec        one slot per ABI word, as Solidity lays out a struct
+//	BinaryCodec     any byte encoding, stored with StoreBytes
+//	Versioned       any of these behind a version number, so the encoding
+//	                can change across upgrades
+type Codec[T any] interface {
+	Store(slot stygos.Word, v T) error
+	Load(slot stygos.Word) (T, error)
+}
+
+// wordCodec adapts a WordCodec.
+type wordCodec[T any] struct {
+	c WordCodec[T]
+}
+
+// Word returns a Codec storing values in the single slot given.
+func Word[T any](c WordCodec[T]) Codec[T] {
+	return wordCodec[T]{c}
+}
+
+func (w wordCodec[T]) Store(slot stygos.Word, v T) error {
+	stygos.StorageStore(slot, w.c.Encode(v))
+	return nil
+}
+
+func (w wordCodec[T]) Load(slot stygos.Word) (T, error) {
+	return w.c.Decode(stygos.StorageLoad(slot)), nil
+}
+
+// packable is implemented by pointers to structs generated by stygos-gen
+// pack.
+type packable[T any] interface {
+	*T
+	Store(base stygos.Word)
+	Load(base stygos.Word)
+}
+
+// PackedCodec stores structs with the Store and Load methods generated by
+// stygos-gen pack. Build one with Packed.
+type PackedCodec[T any, PT packable[T]] struct{}
+
+// Packed returns the codec of a stygos-gen pack struct:
+//
+//	positions := storage.NewMapping(base, storage.Addresses, storage.Packed[Position]())
+func Packed[T any, PT packable[T]]() PackedCodec[T, PT] {
+	return PackedCodec[T, PT]{}
+}
+
+// Store writes v with its generated Store method.
+func (PackedCodec[T, PT]) Store(slot stygos.Word, v T) error {
+	PT(&v).Store(slot)
+	return nil
+}
+
+// Load reads a value with its generated Load method.
+func (PackedCodec[T, PT]) Load(slot stygos.Word) (T, error) {
+	var v T
+	PT(&v).Load(slot)
+	return v, nil
+}
+
+// ABICodec stores a static tuple as its ABI words, one per slot from the
+// given slot on. This is the layout Solidity gives a struct whose members
+// each fill a slot, and the encoding a getter returns, so a stored value
+// can be returned as is.
+type ABICodec[T any] struct {
+	Words  uint64
+	Encode func(T) []stygos.Word
+	Decode func([]stygos.Word) (T, error)
+}
+
+// Store writes the Words words of v. It returns ErrCodecSize if Encode
+// returns another number of words.
+func (c ABICodec[T]) Store(slot stygos.Word, v T) error {
+	words := c.Encode(v)
+	if uint64(len(words)) != c.Words {
+		return ErrCodecSize
+	}
+	for i, w := range words {
+		stygos.StorageStore(Offset(slot, uint64(i)), w)
+	}
+	return nil
+}
+
+// Load reads and decodes Words words.
+func (c ABICodec[T]) Load(slot stygos.Word) (T, error) {
+	return c.Decode(c.LoadWords(slot))
+}
+
+// LoadWords reads the stored words without decoding them, for example to
+// return them from a getter.
+func (c ABICodec[T]) LoadWords(slot stygos.Word) []stygos.Word {
+	words := make([]stygos.Word, c.Words)
+	for i := range words {
+		words[i] = stygos.StorageLoad(Offset(slot, uint64(i)))
+	}
+	return words
+}
+
+// BinaryCodec stores values in any byte encoding with StoreBytes, the
+// length at the slot and the data from keccak256(slot). It suits values of
+// varying size, such as structs with strings.
+type BinaryCodec[T any] struct {
+	Marshal   func(T) []byte
+	Unmarshal func([]byte) (T, error)
+}
+
+// Store writes the encoding of v.
+func (c BinaryCodec[T]) Store(slot stygos.Word, v T) error {
+	StoreBytes(slot, c.Marshal(v))
+	return nil
+}
+
+// Load reads and decodes a value.
+func (c BinaryCodec[T]) Load(slot stygos.Word) (T, error) {
+	return c.Unmarshal(LoadBytes(slot))
+}
+
+// Versioned is an envelope letting the encoding of a value evolve across
+// contract upgrades. The slot holds the version the value was written in
+// and the value follows from the next slot, in the codec of that version.
+//
+// Store always writes the current version. Load decodes the current
+// version directly and older ones through the upgrades registered with
+// Upgrade, so an upgraded implementation reads values written by the
+// previous one and rewrites them in the new encoding when it next stores
+// them. Slots of an older encoding beyond the new one are not cleared.
+type Versioned[T any] struct {
+	version  uint64
+	codec    Codec[T]
+	upgrades map[uint64]func(slot stygos.Word) (T, error)
+}
+
+// NewVersioned returns an envelope writing values with codec as version,
+// which must not be zero: an unwritten slot reads as version 0.
+func NewVersioned[T any](version uint64, codec Codec[T]) *Versioned[T] {
+	if version == 0 {
+		panic("storage: version 0 is reserved for unwritten values")
+	}
+	return &Versioned[T]{version: version, codec: codec, upgrades: map[uint64]func(stygos.Word) (T, error){}}
+}
+
+// Upgrade registers how to read values written in an older version: with
+// codec, converted by upgrade.
+func Upgrade[Old, T any](v *Versioned[T], version uint64, codec Codec[Old], upgrade func(Old) T) {
+	v.upgrades[version] = func(slot stygos.Word) (T, error) {
+		old, err := codec.Load(slot)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return upgrade(old), nil
+	}
+}
+
+// Version returns the version Store writes.
+func (v *Versioned[T]) Version() uint64 {
+	return v.version
+}
+
+// StoredVersion returns the version of the value at slot, 0 if none was
+// stored.
+func (v *Versioned[T]) StoredVersion(slot stygos.Word) uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(slot))
+}
+
+// Store writes x in the current version.
+func (v *Versioned[T]) Store(slot stygos.Word, x T) error {
+	if err := v.codec.Store(Offset(slot, 1), x); err != nil {
+		return err
+	}
+	stygos.StorageStore(slot, stygos.WordFromUint64(v.version))
+	return nil
+}
+
+// Load reads the value at slot, upgrading it from an older version if
+// needed. It returns the zero value for an unwritten slot and
+// ErrUnknownVersion for a version without a registered upgrade.
+func (v *Versioned[T]) Load(slot stygos.Word) (T, error) {
+	switch version := v.StoredVersion(slot); version {
+	case 0:
+		var zero T
+		return zero, nil
+	case v.version:
+		return v.codec.Load(Offset(slot, 1))
+	default:
+		upgrade, ok := v.upgrades[version]
+		if !ok {
+			var zero T
+			return zero, ErrUnknownVersion
+		}
+		return upgrade(Offset(slot, 1))
+	}
+}
+
+// Migrate rewrites the value at slot in the current version and reports
+// whether it was stored in an older one.
+func (v *Versioned[T]) Migrate(slot stygos.Word) (bool, error) {
+	version := v.StoredVersion(slot)
+	if version == 0 || version == v.version {
+		return false, nil
+	}
+	x, err := v.Load(slot)
+	if err != nil {
+		return false, err
+	}
+	return true, v.Store(slot, x)
+}
+
+// Mapping maps keys to values of any codec, such as structs.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, key)    value, in as many slots from there as the codec uses
+type Mapping[K any, V any] struct {
+	base   stygos.Word
+	keys   WordCodec[K]
+	values Codec[V]
+}
+
+// NewMapping returns the mapping rooted at base.
+func NewMapping[K any, V any](base stygos.Word, keys WordCodec[K], values Codec[V]) *Mapping[K, V] {
+	return &Mapping[K, V]{base: base, keys: keys, values: values}
+}
+
+// Get returns the value stored for k, the codec's reading of empty slots
+// if none was.
+func (m *Mapping[K, V]) Get(k K) (V, error) {
+	return m.values.Load(m.Slot(k))
+}
+
+// Set stores v for k.
+func (m *Mapping[K, V]) Set(k K, v V) error {
+	return m.values.Store(m.Slot(k), v)
+}
+
+// Slot returns the first slot of the value of k.
+func (m *Mapping[K, V]) Slot(k K) stygos.Word {
+	kw := m.keys.Encode(k)
+	return MapKey(m.base, kw[:])
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// point stands in for a stygos-gen pack struct, packing two uint64s in one
+// word.
+type point struct {
+	X, Y uint64
+}
+
+func (p *point) Store(base stygos.Word) {
+	var w stygos.Word
+	binary.BigEndian.PutUint64(w[16:24], p.X)
+	binary.BigEndian.PutUint64(w[24:32], p.Y)
+	stygos.StorageStore(base, w)
+}
+
+func (p *point) Load(base stygos.Word) {
+	w := stygos.StorageLoad(base)
+	p.X = binary.BigEndian.Uint64(w[16:24])
+	p.Y = binary.BigEndian.Uint64(w[24:32])
+}
+
+// profileV1 is the first layout of a stored struct; profileV2 adds a field
+// and moves to a binary encoding.
+type profileV1 struct {
+	Owner stygos.Address
+	Score uint64
+}
+
+type profileV2 struct {
+	Owner stygos.Address
+	Score uint64
+	Name  string
+}
+
+var profileV1ABI = ABICodec[profileV1]{
+	Words: 2,
+	Encode: func(p profileV1) []stygos.Word {
+		return []stygos.Word{stygos.PadAddress(p.Owner), stygos.WordFromUint64(p.Score)}
+	},
+	Decode: func(w []stygos.Word) (profileV1, error) {
+		return profileV1{Owner: stygos.AddressFromWord(w[0]), Score: stygos.Uint64FromWord(w[1])}, nil
+	},
+}
+
+var profileV2Binary = BinaryCodec[profileV2]{
+	Marshal: func(p profileV2) []byte {
+		out := make([]byte, 28, 28+len(p.Name))
+		copy(out, p.Owner[:])
+		binary.BigEndian.PutUint64(out[20:], p.Score)
+		return append(out, p.Name...)
+	},
+	Unmarshal: func(b []byte) (profileV2, error) {
+		var p profileV2
+		if len(b) < 28 {
+			return p, errors.New("short profile")
+		}
+		copy(p.Owner[:], b)
+		p.Score = binary.BigEndian.Uint64(b[20:28])
+		p.Name = string(b[28:])
+		return p, nil
+	},
+}
+
+func TestCodecs(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	slot := ConstSlot("value")
+
+	words := Word(Uint64s)
+	if err := words.Store(slot, 42); err != nil {
+		t.Fatalf("Word Store failed: %v", err)
+	}
+	if v, _ := words.Load(slot); v != 42 {
+		t.Errorf("Word Load = %d, want 42", v)
+	}
+
+	packed := Packed[point]()
+	packed.Store(slot, point{X: 3, Y: 4})
+	if p, _ := packed.Load(slot); p != (point{3, 4}) {
+		t.Errorf("Packed Load = %+v, want {3 4}", p)
+	}
+	if stygos.StorageLoad(Offset(slot, 1)) != (stygos.Word{}) {
+		t.Errorf("Packed Store wrote past its word")
+	}
+
+	v1 := profileV1{Owner: stygos.Address{0xaa}, Score: 7}
+	if err := profileV1ABI.Store(slot, v1); err != nil {
+		t.Fatalf("ABICodec Store failed: %v", err)
+	}
+	if got, _ := profileV1ABI.Load(slot); got != v1 {
+		t.Errorf("ABICodec Load = %+v, want %+v", got, v1)
+	}
+	if w := profileV1ABI.LoadWords(slot); stygos.Uint64FromWord(w[1]) != 7 {
+		t.Errorf("LoadWords = %x, want the ABI words", w)
+	}
+	short := profileV1ABI
+	short.Words = 3
+	if err := short.Store(slot, v1); err != ErrCodecSize {
+		t.Errorf("ABICodec Store = %v, want %v", err, ErrCodecSize)
+	}
+
+	v2 := profileV2{Owner: stygos.Address{0xbb}, Score: 9, Name: "a name longer than one storage word"}
+	profileV2Binary.Store(slot, v2)
+	if got, err := profileV2Binary.Load(slot); err != nil || got != v2 {
+		t.Errorf("BinaryCodec Load = %+v, %v, want %+v", got, err, v2)
+	}
+}
+
+func TestVersioned(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+	owner := stygos.Address{0xaa}
+
+	// The first implementation stores profiles as ABI words
+	old := NewVersioned(1, Codec[profileV1](profileV1ABI))
+	profiles := NewMapping(ConstSlot("profiles"), Addresses, Codec[profileV1](old))
+	if err := profiles.Set(owner, profileV1{Owner: owner, Score: 7}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// The upgrade adds a name and reads v1 values through an upgrade
+	current := NewVersioned(2, Codec[profileV2](profileV2Binary))
+	Upgrade(current, 1, Codec[profileV1](profileV1ABI), func(p profileV1) profileV2 {
+		return profileV2{Owner: p.Owner, Score: p.Score, Name: "unnamed"}
+	})
+	upgraded := NewMapping(ConstSlot("profiles"), Addresses, Codec[profileV2](current))
+	slot := upgraded.Slot(owner)
+	if current.StoredVersion(slot) != 1 {
+		t.Fatalf("StoredVersion = %d, want 1", current.StoredVersion(slot))
+	}
+	p, err := upgraded.Get(owner)
+	if err != nil || p != (profileV2{Owner: owner, Score: 7, Name: "unnamed"}) {
+		t.Errorf("Get = %+v, %v, want the upgraded v1 profile", p, err)
+	}
+
+	migrated, err := current.Migrate(slot)
+	if err != nil || !migrated || current.StoredVersion(slot) != 2 {
+		t.Fatalf("Migrate = %v, %v, version %d, want true, nil, 2", migrated, err, current.StoredVersion(slot))
+	}
+	if again, _ := current.Migrate(slot); again {
+		t.Errorf("second Migrate = true")
+	}
+	if p, _ := upgraded.Get(owner); p.Name != "unnamed" || p.Score != 7 {
+		t.Errorf("Get after Migrate = %+v", p)
+	}
+
+	// Unwritten values are zero; the old implementation cannot read v2
+	if p, err := upgraded.Get(stygos.Address{0x01}); err != nil || p != (profileV2{}) {
+		t.Errorf("Get(unset) = %+v, %v, want zero", p, err)
+	}
+	if _, err := profiles.Get(owner); err != ErrUnknownVersion {
+		t.Errorf("v1 Get of a v2 value = %v, want %v", err, ErrUnknownVersion)
+	}
+}
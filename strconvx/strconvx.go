@@ -0,0 +1,249 @@
+// Package strconvx converts numbers and bytes to and from decimal and hex
+// text for contracts built with TinyGo.
+//
+// fmt pulls reflection and most of the runtime's type information into a
+// wasm binary, and strconv brings float formatting and its tables along
+// with the integer functions. The functions here cover what contracts
+// need, integers and hex, and have Append forms writing into a caller's
+// buffer so that a number costs no allocation of its own.
+package strconvx
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Strconvx errors
+var (
+	ErrSyntax = errors.New("strconvx: invalid syntax")
+	ErrRange  = errors.New("strconvx: value out of range")
+)
+
+const hexDigits = "0123456789abcdef"
+
+// FormatUint returns v in decimal.
+func FormatUint(v uint64) string {
+	var buf [20]byte
+	return string(AppendUint(buf[:0], v))
+}
+
+// AppendUint appends v in decimal to dst.
+func AppendUint(dst []byte, v uint64) []byte {
+	var digits [20]byte
+	i := len(digits)
+	for {
+		i--
+		digits[i] = byte('0' + v%10)
+		v /= 10
+		if v == 0 {
+			break
+		}
+	}
+	return append(dst, digits[i:]...)
+}
+
+// FormatInt returns v in decimal.
+func FormatInt(v int64) string {
+	var buf [20]byte
+	return string(AppendInt(buf[:0], v))
+}
+
+// AppendInt appends v in decimal to dst.
+func AppendInt(dst []byte, v int64) []byte {
+	if v < 0 {
+		// -v overflows for the minimum, but its uint64 conversion is right
+		return AppendUint(append(dst, '-'), uint64(-v))
+	}
+	return AppendUint(dst, uint64(v))
+}
+
+// Itoa returns v in decimal.
+func Itoa(v int) string {
+	return FormatInt(int64(v))
+}
+
+// FormatU256 returns v in decimal. It is v.String().
+func FormatU256(v stygos.U256) string {
+	return v.String()
+}
+
+// AppendU256 appends v in decimal to dst.
+func AppendU256(dst []byte, v stygos.U256) []byte {
+	return v.Append(dst)
+}
+
+// ParseUint parses an unsigned decimal. It returns ErrSyntax for empty
+// input or a character other than a digit, and ErrRange if the value
+// does not fit in 64 bits.
+func ParseUint(s string) (uint64, error) {
+	if s == "" {
+		return 0, ErrSyntax
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, ErrSyntax
+		}
+		if v > (1<<64-1)/10 {
+			return 0, ErrRange
+		}
+		next := v*10 + uint64(c-'0')
+		if next < v {
+			return 0, ErrRange
+		}
+		v = next
+	}
+	return v, nil
+}
+
+// ParseInt parses a decimal with an optional sign.
+func ParseInt(s string) (int64, error) {
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	u, err := ParseUint(s)
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		if u > 1<<63 {
+			return 0, ErrRange
+		}
+		return -int64(u), nil
+	}
+	if u > 1<<63-1 {
+		return 0, ErrRange
+	}
+	return int64(u), nil
+}
+
+// Atoi parses a decimal int.
+func Atoi(s string) (int, error) {
+	v, err := ParseInt(s)
+	if err != nil {
+		return 0, err
+	}
+	if int64(int(v)) != v {
+		return 0, ErrRange
+	}
+	return int(v), nil
+}
+
+// ParseU256 parses an unsigned decimal up to 2^256-1, or a hex number
+// with a 0x prefix.
+func ParseU256(s string) (stygos.U256, error) {
+	if has0x(s) {
+		return parseHexU256(s[2:])
+	}
+	if s == "" {
+		return stygos.U256{}, ErrSyntax
+	}
+	ten := stygos.NewU256(10)
+	var v stygos.U256
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return stygos.U256{}, ErrSyntax
+		}
+		next := v.Mul(ten).Add(stygos.NewU256(uint64(c - '0')))
+		// Multiplication wraps; dividing back detects it
+		if next.Div(ten) != v {
+			return stygos.U256{}, ErrRange
+		}
+		v = next
+	}
+	return v, nil
+}
+
+func parseHexU256(s string) (stygos.U256, error) {
+	if s == "" {
+		return stygos.U256{}, ErrSyntax
+	}
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	if len(s) > 64 {
+		return stygos.U256{}, ErrRange
+	}
+	var v stygos.U256
+	for i := 0; i < len(s); i++ {
+		d, ok := fromHexDigit(s[i])
+		if !ok {
+			return stygos.U256{}, ErrSyntax
+		}
+		v = v.Lsh(4)
+		v[0] |= uint64(d)
+	}
+	return v, nil
+}
+
+// EncodeHex returns src as lowercase hex digits with a 0x prefix.
+func EncodeHex(src []byte) string {
+	return string(AppendHex(append(make([]byte, 0, 2+2*len(src)), "0x"...), src))
+}
+
+// AppendHex appends src as lowercase hex digits, without a prefix, to dst.
+func AppendHex(dst, src []byte) []byte {
+	for _, b := range src {
+		dst = append(dst, hexDigits[b>>4], hexDigits[b&0xf])
+	}
+	return dst
+}
+
+// AppendHexUint appends v as lowercase hex digits without leading zeros
+// or a prefix, as in JSON-RPC quantities after "0x".
+func AppendHexUint(dst []byte, v uint64) []byte {
+	var digits [16]byte
+	i := len(digits)
+	for {
+		i--
+		digits[i] = hexDigits[v&0xf]
+		v >>= 4
+		if v == 0 {
+			break
+		}
+	}
+	return append(dst, digits[i:]...)
+}
+
+// DecodeHex decodes hex digits of either case, with or without a 0x
+// prefix. It returns ErrSyntax for an odd number of digits or a
+// character other than a hex digit.
+func DecodeHex(s string) ([]byte, error) {
+	if has0x(s) {
+		s = s[2:]
+	}
+	if len(s)%2 != 0 {
+		return nil, ErrSyntax
+	}
+	out := make([]byte, len(s)/2)
+	for i := range out {
+		hi, ok1 := fromHexDigit(s[2*i])
+		lo, ok2 := fromHexDigit(s[2*i+1])
+		if !ok1 || !ok2 {
+			return nil, ErrSyntax
+		}
+		out[i] = hi<<4 | lo
+	}
+	return out, nil
+}
+
+func has0x(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+func fromHexDigit(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,126 @@
+package strconvx
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestFormat(t *testing.T) {
+	for _, v := range []uint64{0, 7, 10, 1<<32 + 1, math.MaxUint64} {
+		if got := FormatUint(v); got != strconv.FormatUint(v, 10) {
+			t.Errorf("FormatUint failed. Expected %d, got %s", v, got)
+		}
+	}
+	for _, v := range []int64{0, -1, 42, math.MinInt64, math.MaxInt64} {
+		if got := FormatInt(v); got != strconv.FormatInt(v, 10) {
+			t.Errorf("FormatInt failed. Expected %d, got %s", v, got)
+		}
+	}
+	if got := Itoa(-12); got != "-12" {
+		t.Errorf("Itoa failed. Expected -12, got %s", got)
+	}
+	max := stygos.NewU256(0).Not()
+	if got := FormatU256(max); got != max.Big().String() {
+		t.Errorf("FormatU256 failed. Expected %s, got %s", max.Big(), got)
+	}
+	if got := string(AppendU256([]byte("wei="), stygos.NewU256(5))); got != "wei=5" {
+		t.Errorf("AppendU256 failed. Expected wei=5, got %s", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s    string
+		want uint64
+		err  error
+	}{
+		{"0", 0, nil},
+		{"18446744073709551615", math.MaxUint64, nil},
+		{"18446744073709551616", 0, ErrRange},
+		{"184467440737095516150", 0, ErrRange},
+		{"", 0, ErrSyntax},
+		{"12a", 0, ErrSyntax},
+		{"-1", 0, ErrSyntax},
+	}
+	for _, tt := range tests {
+		if got, err := ParseUint(tt.s); got != tt.want || err != tt.err {
+			t.Errorf("ParseUint(%q) failed. Expected %d, %v, got %d, %v", tt.s, tt.want, tt.err, got, err)
+		}
+	}
+
+	signed := []struct {
+		s    string
+		want int64
+		err  error
+	}{
+		{"-9223372036854775808", math.MinInt64, nil},
+		{"+9223372036854775807", math.MaxInt64, nil},
+		{"9223372036854775808", 0, ErrRange},
+		{"-", 0, ErrSyntax},
+	}
+	for _, tt := range signed {
+		if got, err := ParseInt(tt.s); got != tt.want || err != tt.err {
+			t.Errorf("ParseInt(%q) failed. Expected %d, %v, got %d, %v", tt.s, tt.want, tt.err, got, err)
+		}
+	}
+	if n, err := Atoi("-300"); n != -300 || err != nil {
+		t.Errorf("Atoi failed. Expected -300, got %d, %v", n, err)
+	}
+}
+
+func TestParseU256(t *testing.T) {
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	tests := []struct {
+		s    string
+		want *big.Int
+		err  error
+	}{
+		{"0", big.NewInt(0), nil},
+		{"1000000000000000000", big.NewInt(1e18), nil},
+		{max.String(), max, nil},
+		{"0x" + strings.Repeat("f", 64), max, nil},
+		{"0x00" + strings.Repeat("f", 64), max, nil},
+		{"0XABC", big.NewInt(0xabc), nil},
+		{new(big.Int).Add(max, big.NewInt(1)).String(), nil, ErrRange},
+		{"0x1" + strings.Repeat("0", 64), nil, ErrRange},
+		{"0x", nil, ErrSyntax},
+		{"1e18", nil, ErrSyntax},
+	}
+	for _, tt := range tests {
+		got, err := ParseU256(tt.s)
+		if err != tt.err || (tt.err == nil && got.Big().Cmp(tt.want) != 0) {
+			t.Errorf("ParseU256(%q) failed. Expected %v, %v, got %s, %v", tt.s, tt.want, tt.err, got, err)
+		}
+	}
+}
+
+func TestHex(t *testing.T) {
+	data := []byte{0x00, 0xab, 0xcd, 0xef, 0x12}
+	if got := EncodeHex(data); got != "0x00abcdef12" {
+		t.Errorf("EncodeHex failed. Expected 0x00abcdef12, got %s", got)
+	}
+	if got := EncodeHex(nil); got != "0x" {
+		t.Errorf("EncodeHex failed. Expected 0x, got %s", got)
+	}
+	for _, s := range []string{"0x00abcdef12", "00ABCDEF12", "0X00AbCdEf12"} {
+		if got, err := DecodeHex(s); err != nil || !bytes.Equal(got, data) {
+			t.Errorf("DecodeHex(%q) failed. Expected %x, got %x, %v", s, data, got, err)
+		}
+	}
+	for _, s := range []string{"0xabc", "zz", "0x0g"} {
+		if _, err := DecodeHex(s); err != ErrSyntax {
+			t.Errorf("DecodeHex(%q) failed. Expected %v, got %v", s, ErrSyntax, err)
+		}
+	}
+	for _, v := range []uint64{0, 0x1f, math.MaxUint64} {
+		if got := string(AppendHexUint(nil, v)); got != strconv.FormatUint(v, 16) {
+			t.Errorf("AppendHexUint failed. Expected %x, got %s", v, got)
+		}
+	}
+}
@@ -25,24 +25,80 @@ const (
 
 // Function pointers for host functions
 var (
-	ReadArgs            func(ptr *byte) uint32
+	ReadArgs            func(ptr *byte)
 	WriteResult         func(ptr *byte, len uint32)
 	StorageLoadBytes32  func(key_ptr *byte, value_ptr *byte)
 	StorageStoreBytes32 func(key_ptr *byte, value_ptr *byte)
 	MsgValue            func(value_ptr *byte)
-	BlockNumber         func(value_ptr *byte)
-	EmitLog             func(ptr *byte, len uint32, topics_count uint32, topic1_ptr *byte, topic2_ptr *byte, topic3_ptr *byte, topic4_ptr *byte)
+	BlockNumber         func() uint64
+	EmitLog             func(ptr *byte, len uint32, topics uint32) // the topics, then the data
 	NativeKeccak256     func(ptr *byte, len uint32, result_ptr *byte)
 	MemoryGrow          func(pages uint32)
+	MsgSender           func(sender_ptr *byte)
+	ContractAddress     func(address_ptr *byte)
+	CallContract        func(contract_ptr *byte, calldata_ptr *byte, calldata_len uint32, value_ptr *byte, gas uint64, return_data_len *uint32) uint8
+	StaticCallContract  func(contract_ptr *byte, calldata_ptr *byte, calldata_len uint32, gas uint64, return_data_len *uint32) uint8
+	ReadReturnData      func(dest_ptr *byte, offset uint32, size uint32) uint32
+	BlockTimestamp      func() uint64
+	AccountBalance      func(address_ptr *byte, dest_ptr *byte)
+	AccountCodeSize     func(address_ptr *byte) uint32
+	ChainID             func() uint64
+
+	// Bound from ArbOS 30 on, nil before; see TransientLoad
+	TransientLoadBytes32  func(key_ptr *byte, value_ptr *byte)
+	TransientStoreBytes32 func(key_ptr *byte, value_ptr *byte)
+
+	// No Stylus hostio, bound by the mock only; see GetBlobHash
+	BlobHash    func(index uint64, hash_ptr *byte)
+	BlobBaseFee func(fee_ptr *byte) bool
+
+	// No Stylus hostio: the calldata length Stylus passes to
+	// user_entrypoint, which read_args cannot report; see SetEntrypoint
+	ArgsLen func() uint32
 )
 
+// entrypointArgsLen is the calldata length of the current call, recorded
+// by UserEntrypoint.
+var entrypointArgsLen uint32
+
+// contractEntrypoint is the entrypoint SetEntrypoint registered.
+var contractEntrypoint func() int32
+
+// SetEntrypoint registers the contract's entrypoint. On TinyGo builds the
+// SDK exports user_entrypoint, which Stylus calls for each call, and runs
+// it through UserEntrypoint. Contracts register from init:
+//
+//	func init() { stygos.SetEntrypoint(entrypoint) }
+func SetEntrypoint(entrypoint func() int32) {
+	contractEntrypoint = entrypoint
+}
+
+// UserEntrypoint runs entrypoint for a call with argsLen bytes of calldata.
+// Stylus passes that length to user_entrypoint, and GetCallData needs it
+// to size the buffer read_args fills.
+func UserEntrypoint(argsLen uint32, entrypoint func() int32) int32 {
+	entrypointArgsLen = argsLen
+	return entrypoint()
+}
+
+// userEntrypoint is user_entrypoint: it runs the registered entrypoint,
+// and fails the call when there is none.
+func userEntrypoint(argsLen uint32) int32 {
+	if contractEntrypoint == nil {
+		return 1
+	}
+	return UserEntrypoint(argsLen, contractEntrypoint)
+}
+
+func entrypointLen() uint32 {
+	return entrypointArgsLen
+}
+
 // --- High-level API wrappers ---
 
 // GetCallData returns the input data for the current call
 func GetCallData() ([]byte, error) {
-	// First call with nil to get the length
-	var dummyByte byte
-	length := ReadArgs(&dummyByte)
+	length := ArgsLen()
 	if length == 0 {
 		return []byte{}, nil
 	}
@@ -91,18 +147,18 @@ func GetMsgValue() *big.Int {
 
 // GetBlockNumber returns the current block number
 func GetBlockNumber() uint64 {
-	var blockNum [8]byte
-	BlockNumber(&blockNum[0])
-	return binary.LittleEndian.Uint64(blockNum[:])
+	return BlockNumber()
 }
 
 // Keccak256 computes the Keccak256 hash of the input data
 func Keccak256(data []byte) Word {
 	var result Word
-	if len(data) == 0 {
-		return result
+	// The host reads no input bytes for an empty input, so any pointer does
+	ptr := &result[0]
+	if len(data) > 0 {
+		ptr = &data[0]
 	}
-	NativeKeccak256(&data[0], uint32(len(data)), &result[0])
+	NativeKeccak256(ptr, uint32(len(data)), &result[0])
 	return result
 }
 
@@ -112,24 +168,21 @@ func EmitEvent(data []byte, topics ...Word) error {
 		return ErrInvalidInput
 	}
 
-	var topicPtrs [4]*byte
-	topicsCount := uint32(len(topics))
-
-	for i := uint32(0); i < topicsCount; i++ {
-		topicPtrs[i] = &topics[i][0]
+	if len(data) > MaxCallDataSize {
+		return ErrMemoryLimit
 	}
 
-	var dataPtr *byte
-	dataLen := uint32(0)
-	if len(data) > 0 {
-		if len(data) > MaxCallDataSize {
-			return ErrMemoryLimit
-		}
-		dataPtr = &data[0]
-		dataLen = uint32(len(data))
+	// emit_log takes one buffer: the topics, then the data
+	buf := make([]byte, 0, 32*len(topics)+len(data))
+	for _, topic := range topics {
+		buf = append(buf, topic[:]...)
 	}
-
-	EmitLog(dataPtr, dataLen, topicsCount, topicPtrs[0], topicPtrs[1], topicPtrs[2], topicPtrs[3])
+	buf = append(buf, data...)
+	var ptr *byte
+	if len(buf) > 0 {
+		ptr = &buf[0]
+	}
+	EmitLog(ptr, uint32(len(buf)), uint32(len(topics)))
 	return nil
 }
 
@@ -180,27 +233,64 @@ func BigIntFromWord(word Word) *big.Int {
 
 // --- Memory management helpers ---
 
+// Memory constants
+const (
+	PageSize       uint32 = 65536 // Wasm page size (64KiB)
+	MaxMemoryPages uint32 = 128   // Default Stylus page limit (8MiB)
+)
+
+// grownPages counts the pages requested through GrowMemory during the current
+// call. Every Stylus call runs in a fresh instance, so it starts at zero.
+var grownPages uint32
+
 // GrowMemory requests additional memory from the host
 // Each page is 64KiB (65536 bytes)
 func GrowMemory(additionalPages uint32) error {
 	if additionalPages == 0 {
 		return nil
 	}
+	if additionalPages > MaxMemoryPages-grownPages {
+		return ErrMemoryLimit
+	}
 	MemoryGrow(additionalPages)
+	grownPages += additionalPages
 	return nil
 }
 
 // EnsureMemory ensures that enough memory is available
-// It grows memory if needed to accommodate the requested size
+// It grows memory by the difference between the pages needed for sizeBytes
+// and the pages already grown, so repeated calls never pay twice for the
+// same memory.
 func EnsureMemory(sizeBytes uint32) error {
-	if sizeBytes == 0 {
+	pagesNeeded := pagesFor(sizeBytes)
+	if pagesNeeded <= grownPages {
 		return nil
 	}
-	// Calculate how many 64KiB pages are needed
-	const pageSize uint32 = 65536
-	pagesNeeded := (sizeBytes + pageSize - 1) / pageSize
-	if pagesNeeded > 0 {
-		return GrowMemory(pagesNeeded)
+	return GrowMemory(pagesNeeded - grownPages)
+}
+
+// ReserveMemory grows memory once, at entry, to cover the expected peak usage
+// of the call.
+//
+// TinyGo's allocator extends the heap page by page as it runs out of room,
+// each extension being a separate memory_grow. Reserving the expected size up
+// front replaces those with a single growth; later EnsureMemory calls (for
+// example from ReturnBuilder) within the reservation are free. Reserving does
+// not hand memory to the allocator directly, it only makes sure the pages are
+// paid for and present when the heap expands into them.
+func ReserveMemory(sizeBytes uint32) error {
+	if pagesFor(sizeBytes) > MaxMemoryPages {
+		return ErrMemoryLimit
 	}
-	return nil
+	return EnsureMemory(sizeBytes)
+}
+
+// ReservedMemory returns the number of bytes grown so far during this call.
+func ReservedMemory() uint32 {
+	return grownPages * PageSize
+}
+
+// pagesFor returns the number of 64KiB pages needed to hold sizeBytes.
+func pagesFor(sizeBytes uint32) uint32 {
+	return uint32((uint64(sizeBytes) + uint64(PageSize) - 1) / uint64(PageSize))
 }
@@ -2,6 +2,8 @@ package stygos
 
 import (
 	"bytes"
+	"encoding/hex"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -50,6 +52,12 @@ func TestKeccak256(t *testing.T) {
 	if !bytes.Equal(hash[:], expected) {
 		t.Errorf("Keccak256 implementation not working as expected")
 	}
+
+	// The empty input hashes like any other, to keccak256("")
+	empty := Keccak256(nil)
+	if want := "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"; hex.EncodeToString(empty[:]) != want {
+		t.Errorf("Keccak256 of the empty input failed. Expected %s, got %x", want, empty)
+	}
 }
 
 func TestEmitEvent(t *testing.T) {
@@ -67,7 +75,13 @@ func TestEmitEvent(t *testing.T) {
 
 	// Verify
 	if len(mock.Logs) != 1 {
-		t.Errorf("Expected 1 log entry, got %d", len(mock.Logs))
+		t.Fatalf("Expected 1 log entry, got %d", len(mock.Logs))
+	}
+
+	// emit_log reads the topics from the front of the buffer, then the data
+	want := fmt.Sprintf("Topics: 2\n  Topic 1: %x\n  Topic 2: %x\nData: %x\n", topic1, topic2, data)
+	if string(mock.Logs[0]) != want {
+		t.Errorf("EmitEvent failed. Expected %q, got %q", want, mock.Logs[0])
 	}
 }
 
@@ -121,6 +135,31 @@ func TestGetCallData(t *testing.T) {
 	}
 }
 
+func TestUserEntrypoint(t *testing.T) {
+	var seen uint32
+	status := UserEntrypoint(68, func() int32 {
+		seen = entrypointLen()
+		return 1
+	})
+	if status != 1 || seen != 68 {
+		t.Errorf("UserEntrypoint failed. Expected status 1 and length 68, got %d and %d", status, seen)
+	}
+
+	// user_entrypoint fails without a registered entrypoint
+	SetEntrypoint(nil)
+	if status := userEntrypoint(4); status != 1 {
+		t.Errorf("userEntrypoint failed. Expected status 1 without an entrypoint, got %d", status)
+	}
+	SetEntrypoint(func() int32 {
+		seen = entrypointLen()
+		return 0
+	})
+	defer SetEntrypoint(nil)
+	if status := userEntrypoint(36); status != 0 || seen != 36 {
+		t.Errorf("userEntrypoint failed. Expected status 0 and length 36, got %d and %d", status, seen)
+	}
+}
+
 func TestSetReturnData(t *testing.T) {
 	// Setup mock runtime
 	mock := NewMockRuntime()
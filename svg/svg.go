@@ -0,0 +1,336 @@
+// Package svg assembles SVG images and JSON documents on chain for
+// generative NFTs.
+//
+// A Builder appends markup, escaped text and numbers, formatted with
+// strconvx, to a single growable buffer without fmt or reflection, which
+// keeps TinyGo builds small and avoids the intermediate strings of
+// concatenation:
+//
+//	b := svg.NewBuilder(1024)
+//	b.Open("svg").Attr("xmlns", svg.Namespace).Attr("viewBox", "0 0 100 100").Close()
+//	b.Open("circle").AttrUint("cx", 50).AttrUint("cy", 50).AttrUint("r", r).Attr("fill", color).SelfClose()
+//	b.End("svg")
+//
+// Large outputs are returned from a handler without copying: NewResult
+// reserves the ABI head of a string return value in front of the text, and
+// Result patches in the length and padding and returns the buffer as the
+// return data.
+package svg
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/encoding/base64"
+	"github.com/rafaelescrich/stygos/encoding/json"
+	"github.com/rafaelescrich/stygos/strconvx"
+)
+
+// Namespace is the value of the xmlns attribute of the root svg element.
+const Namespace = "http://www.w3.org/2000/svg"
+
+// defaultCapacity is the initial capacity of a Builder created without a
+// size hint.
+const defaultCapacity = 256
+
+// SVG errors
+var (
+	ErrNotResult    = errors.New("svg: builder was not created with NewResult")
+	ErrTemplateArgs = errors.New("svg: wrong number of template arguments")
+)
+
+// Builder appends text to a buffer.
+//
+// The buffer doubles when it runs out of room and calls
+// stygos.EnsureMemory before each growth, like stygos.ReturnBuilder. Errors
+// are sticky: once the text would exceed stygos.MaxCallDataSize the builder
+// stops appending and Err, Result report stygos.ErrMemoryLimit.
+type Builder struct {
+	buf   []byte
+	start int // length of the reserved ABI head, 0 for plain text
+	err   error
+}
+
+// NewBuilder returns a builder able to hold sizeHint bytes without
+// growing. A sizeHint of 0 selects a small default.
+func NewBuilder(sizeHint int) *Builder {
+	b := &Builder{}
+	b.init(sizeHint)
+	return b
+}
+
+// NewResult returns a builder whose text is returned as an ABI string by
+// Result, able to hold sizeHint bytes of text without growing.
+func NewResult(sizeHint int) *Builder {
+	b := &Builder{start: 64}
+	b.init(64 + sizeHint + 31)
+	b.buf = b.buf[:64]
+	return b
+}
+
+func (b *Builder) init(capacity int) {
+	if capacity <= 0 {
+		capacity = defaultCapacity
+	}
+	if capacity > stygos.MaxCallDataSize {
+		capacity = stygos.MaxCallDataSize
+	}
+	if err := stygos.EnsureMemory(uint32(capacity)); err != nil {
+		b.err = err
+		return
+	}
+	b.buf = make([]byte, 0, capacity)
+}
+
+// grow makes room for n more bytes and reports whether it succeeded.
+func (b *Builder) grow(n int) bool {
+	if b.err != nil {
+		return false
+	}
+	size := len(b.buf) + n
+	if size > stygos.MaxCallDataSize {
+		b.err = stygos.ErrMemoryLimit
+		return false
+	}
+	if size <= cap(b.buf) {
+		return true
+	}
+	newCap := 2 * cap(b.buf)
+	if newCap < size {
+		newCap = size
+	}
+	if newCap > stygos.MaxCallDataSize {
+		newCap = stygos.MaxCallDataSize
+	}
+	if err := stygos.EnsureMemory(uint32(newCap)); err != nil {
+		b.err = err
+		return false
+	}
+	grown := make([]byte, len(b.buf), newCap)
+	copy(grown, b.buf)
+	b.buf = grown
+	return true
+}
+
+// Raw appends s verbatim.
+func (b *Builder) Raw(s string) *Builder {
+	if b.grow(len(s)) {
+		b.buf = append(b.buf, s...)
+	}
+	return b
+}
+
+// RawBytes appends p verbatim.
+func (b *Builder) RawBytes(p []byte) *Builder {
+	if b.grow(len(p)) {
+		b.buf = append(b.buf, p...)
+	}
+	return b
+}
+
+// Text appends s escaped for XML text and attribute values.
+func (b *Builder) Text(s string) *Builder {
+	if b.grow(len(s)) {
+		b.buf = AppendEscaped(b.buf, s)
+	}
+	return b
+}
+
+// JSONString appends s as a quoted JSON string.
+func (b *Builder) JSONString(s string) *Builder {
+	if b.grow(len(s) + 2) {
+		b.buf = AppendJSONString(b.buf, s)
+	}
+	return b
+}
+
+// Uint appends v in decimal.
+func (b *Builder) Uint(v uint64) *Builder {
+	if b.grow(20) {
+		b.buf = strconvx.AppendUint(b.buf, v)
+	}
+	return b
+}
+
+// Int appends v in decimal.
+func (b *Builder) Int(v int64) *Builder {
+	if b.grow(20) {
+		b.buf = strconvx.AppendInt(b.buf, v)
+	}
+	return b
+}
+
+// U256 appends v in decimal.
+func (b *Builder) U256(v stygos.U256) *Builder {
+	if b.grow(78) {
+		b.buf = v.Append(b.buf)
+	}
+	return b
+}
+
+// Fixed appends v/10^decimals in decimal, without trailing zeros.
+func (b *Builder) Fixed(v int64, decimals int) *Builder {
+	if b.grow(22 + decimals) {
+		b.buf = AppendFixed(b.buf, v, decimals)
+	}
+	return b
+}
+
+// Base64 appends the standard base64 encoding of p, for nested data URIs.
+func (b *Builder) Base64(p []byte) *Builder {
+	if b.grow(base64.EncodedLen(len(p))) {
+		b.buf = base64.AppendEncode(b.buf, p)
+	}
+	return b
+}
+
+// Open starts an element, "<tag", for attributes to follow.
+func (b *Builder) Open(tag string) *Builder {
+	return b.Raw("<").Raw(tag)
+}
+
+// Attr appends ` name="value"` with value escaped.
+func (b *Builder) Attr(name, value string) *Builder {
+	return b.Raw(" ").Raw(name).Raw(`="`).Text(value).Raw(`"`)
+}
+
+// AttrUint appends ` name="v"`.
+func (b *Builder) AttrUint(name string, v uint64) *Builder {
+	return b.Raw(" ").Raw(name).Raw(`="`).Uint(v).Raw(`"`)
+}
+
+// AttrInt appends ` name="v"`.
+func (b *Builder) AttrInt(name string, v int64) *Builder {
+	return b.Raw(" ").Raw(name).Raw(`="`).Int(v).Raw(`"`)
+}
+
+// Close ends the start tag of an element with content.
+func (b *Builder) Close() *Builder {
+	return b.Raw(">")
+}
+
+// SelfClose ends an element without content.
+func (b *Builder) SelfClose() *Builder {
+	return b.Raw("/>")
+}
+
+// End appends the end tag "</tag>".
+func (b *Builder) End(tag string) *Builder {
+	return b.Raw("</").Raw(tag).Raw(">")
+}
+
+// Len returns the length of the text.
+func (b *Builder) Len() int {
+	return len(b.buf) - b.start
+}
+
+// Bytes returns the text. The slice aliases the builder's buffer.
+func (b *Builder) Bytes() []byte {
+	if len(b.buf) < b.start {
+		return nil
+	}
+	return b.buf[b.start:]
+}
+
+// String returns a copy of the text.
+func (b *Builder) String() string {
+	return string(b.Bytes())
+}
+
+// Err returns the first error encountered while appending.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// Result completes the ABI string return value of a builder created with
+// NewResult and returns it, for a handler to return as is. The builder
+// must not be used afterwards.
+func (b *Builder) Result() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.start == 0 {
+		return nil, ErrNotResult
+	}
+	n := b.Len()
+	pad := (32 - n%32) % 32
+	if !b.grow(pad) {
+		return nil, b.err
+	}
+	b.buf = append(b.buf, make([]byte, pad)...)
+	offset := stygos.WordFromUint64(32)
+	length := stygos.WordFromUint64(uint64(n))
+	copy(b.buf[:32], offset[:])
+	copy(b.buf[32:64], length[:])
+	return b.buf, nil
+}
+
+// AppendEscaped appends s to dst with &, <, >, " and ' replaced by
+// entities.
+func AppendEscaped(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '&':
+			dst = append(dst, "&amp;"...)
+		case '<':
+			dst = append(dst, "&lt;"...)
+		case '>':
+			dst = append(dst, "&gt;"...)
+		case '"':
+			dst = append(dst, "&quot;"...)
+		case '\'':
+			dst = append(dst, "&#39;"...)
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// AppendJSONString appends s to dst as a quoted JSON string, see
+// json.AppendString.
+func AppendJSONString(dst []byte, s string) []byte {
+	return json.AppendString(dst, s)
+}
+
+// AppendFixed appends v/10^decimals to dst in decimal, without trailing
+// zeros in the fraction, so that AppendFixed(dst, 1250, 2) appends "12.5".
+func AppendFixed(dst []byte, v int64, decimals int) []byte {
+	if v < 0 {
+		dst = append(dst, '-')
+	}
+	u := uint64(v)
+	if v < 0 {
+		u = uint64(-v)
+	}
+	if decimals <= 0 {
+		return strconvx.AppendUint(dst, u)
+	}
+	if decimals > 19 {
+		decimals = 19
+	}
+	scale := uint64(1)
+	for i := 0; i < decimals; i++ {
+		scale *= 10
+	}
+	dst = strconvx.AppendUint(dst, u/scale)
+	frac := u % scale
+	if frac == 0 {
+		return dst
+	}
+	for frac%10 == 0 {
+		frac /= 10
+		decimals--
+	}
+	return appendPadded(append(dst, '.'), frac, decimals)
+}
+
+// appendPadded appends v left-padded with zeros to width digits.
+func appendPadded(dst []byte, v uint64, width int) []byte {
+	var digits [20]byte
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = byte('0' + v%10)
+		v /= 10
+	}
+	return append(dst, digits[:width]...)
+}
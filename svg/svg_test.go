@@ -0,0 +1,145 @@
+package svg
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+func TestNumbers(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	max256 := stygos.NewU256(0).Not()
+	tests := []struct {
+		got  []byte
+		want string
+	}{
+		{NewBuilder(0).Uint(math.MaxUint64).Bytes(), "18446744073709551615"},
+		{NewBuilder(0).Int(math.MinInt64).Bytes(), "-9223372036854775808"},
+		{NewBuilder(0).U256(max256).Bytes(), max256.String()},
+		{NewBuilder(0).Fixed(-1250, 2).Bytes(), "-12.5"},
+		{AppendFixed(nil, 1250, 2), "12.5"},
+		{AppendFixed(nil, 1200, 2), "12"},
+		{AppendFixed(nil, -5, 3), "-0.005"},
+		{AppendFixed(nil, 42, 0), "42"},
+	}
+	for _, tt := range tests {
+		if string(tt.got) != tt.want {
+			t.Errorf("Formatting failed. Expected %s, got %s", tt.want, tt.got)
+		}
+	}
+}
+
+func TestElements(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	b := NewBuilder(0)
+	b.Open("svg").Attr("xmlns", Namespace).Attr("viewBox", "0 0 100 100").Close()
+	b.Open("circle").AttrUint("cx", 50).AttrInt("cy", -5).Attr("fill", `"red" & <blue>`).SelfClose()
+	b.Open("text").Close().Text("Tom & Jerry's <tale>").End("text")
+	b.End("svg")
+	if b.Err() != nil {
+		t.Fatalf("Builder failed: %v", b.Err())
+	}
+
+	want := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100">` +
+		`<circle cx="50" cy="-5" fill="&quot;red&quot; &amp; &lt;blue&gt;"/>` +
+		`<text>Tom &amp; Jerry&#39;s &lt;tale&gt;</text></svg>`
+	if b.String() != want {
+		t.Errorf("Builder failed. Expected %s, got %s", want, b.String())
+	}
+	var doc struct {
+		Circle struct {
+			Fill string `xml:"fill,attr"`
+		} `xml:"circle"`
+		Text string `xml:"text"`
+	}
+	if err := xml.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("Builder failed. Expected valid XML, got %v", err)
+	}
+	if doc.Circle.Fill != `"red" & <blue>` || doc.Text != "Tom & Jerry's <tale>" {
+		t.Errorf("Escaping failed. Got fill %q and text %q", doc.Circle.Fill, doc.Text)
+	}
+}
+
+func TestJSONString(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	s := "quote \" slash \\ line\n tab\t bell\x07"
+	b := NewBuilder(0).Raw(`{"name":`).JSONString(s).Raw(`,"id":`).Uint(7).Raw("}")
+	var doc struct {
+		Name string `json:"name"`
+		ID   int    `json:"id"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &doc); err != nil {
+		t.Fatalf("JSONString failed. Expected valid JSON, got %v: %s", err, b.Bytes())
+	}
+	if doc.Name != s || doc.ID != 7 {
+		t.Errorf("JSONString failed. Expected %q and 7, got %+v", s, doc)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	cell := Compile(`<rect x="{}" y="{}" opacity="{}" fill="{}"/>{}`)
+	if cell.NumArgs() != 5 {
+		t.Fatalf("Compile failed. Expected 5 placeholders, got %d", cell.NumArgs())
+	}
+	b := NewBuilder(0)
+	cell.Execute(b, Uint(10), Int(-20), Fixed(75, 2), Str("a&b"), Raw("<g/>"))
+	want := `<rect x="10" y="-20" opacity="0.75" fill="a&amp;b"/><g/>`
+	if b.String() != want || b.Err() != nil {
+		t.Errorf("Execute failed. Expected %s, got %s, %v", want, b.String(), b.Err())
+	}
+
+	cell.Execute(b, Uint(1))
+	if b.Err() != ErrTemplateArgs {
+		t.Errorf("Execute failed. Expected %v, got %v", ErrTemplateArgs, b.Err())
+	}
+	if plain := Compile("no placeholders"); plain.NumArgs() != 0 {
+		t.Errorf("Compile failed. Expected no placeholders, got %d", plain.NumArgs())
+	}
+}
+
+func TestResult(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	// Start tiny to force several growths
+	b := NewResult(1)
+	for i := uint64(0); i < 1000; i++ {
+		b.Open("rect").AttrUint("x", i).SelfClose()
+	}
+	text := b.String()
+	out, err := b.Result()
+	if err != nil {
+		t.Fatalf("Result failed: %v", err)
+	}
+	if len(out)%32 != 0 || binary.BigEndian.Uint64(out[24:32]) != 32 {
+		t.Fatalf("Result failed. Expected a padded ABI string, got %d bytes", len(out))
+	}
+	n := binary.BigEndian.Uint64(out[56:64])
+	if n != uint64(len(text)) || string(out[64:64+n]) != text {
+		t.Errorf("Result failed. Expected %d bytes of text, got %d", len(text), n)
+	}
+	if mock.Pages == 0 {
+		t.Errorf("Result failed. Expected memory to be grown for the buffer")
+	}
+
+	if _, err := NewBuilder(0).Raw("x").Result(); err != ErrNotResult {
+		t.Errorf("Result failed. Expected %v, got %v", ErrNotResult, err)
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+	b := NewBuilder(0).Raw(strings.Repeat("x", stygos.MaxCallDataSize)).Raw("y")
+	if b.Err() != stygos.ErrMemoryLimit || b.Len() != stygos.MaxCallDataSize {
+		t.Errorf("Builder failed. Expected %v after %d bytes, got %v after %d", stygos.ErrMemoryLimit, stygos.MaxCallDataSize, b.Err(), b.Len())
+	}
+	if _, err := b.Result(); err != stygos.ErrMemoryLimit {
+		t.Errorf("Result failed. Expected %v, got %v", stygos.ErrMemoryLimit, err)
+	}
+}
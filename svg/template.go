@@ -0,0 +1,119 @@
+package svg
+
+import "github.com/rafaelescrich/stygos"
+
+// Template is markup with {} placeholders, filled in order by Execute.
+// Compile templates once, in package variables, and execute them per
+// token:
+//
+//	var cell = svg.Compile(`<rect x="{}" y="{}" width="10" height="10" fill="{}"/>`)
+//
+//	cell.Execute(b, svg.Uint(x), svg.Uint(y), svg.Str(color))
+type Template struct {
+	parts []string
+}
+
+// Compile splits text at its {} placeholders.
+func Compile(text string) Template {
+	var t Template
+	start := 0
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] == '{' && text[i+1] == '}' {
+			t.parts = append(t.parts, text[start:i])
+			start = i + 2
+			i++
+		}
+	}
+	t.parts = append(t.parts, text[start:])
+	return t
+}
+
+// NumArgs returns the number of placeholders.
+func (t Template) NumArgs() int {
+	return len(t.parts) - 1
+}
+
+// Execute appends the template with its placeholders replaced by args to
+// b. It sets ErrTemplateArgs on b unless there is exactly one argument per
+// placeholder.
+func (t Template) Execute(b *Builder, args ...Value) {
+	if len(args) != t.NumArgs() {
+		if b.err == nil {
+			b.err = ErrTemplateArgs
+		}
+		return
+	}
+	for i, part := range t.parts {
+		b.Raw(part)
+		if i < len(args) {
+			args[i].append(b)
+		}
+	}
+}
+
+// Value is an argument of Template.Execute.
+type Value struct {
+	kind  valueKind
+	s     string
+	n     uint64
+	u256  stygos.U256
+	scale int
+}
+
+type valueKind uint8
+
+const (
+	kindText valueKind = iota
+	kindRaw
+	kindUint
+	kindInt
+	kindU256
+	kindFixed
+)
+
+// Str returns a string argument, escaped for XML.
+func Str(s string) Value {
+	return Value{kind: kindText, s: s}
+}
+
+// Raw returns a string argument inserted verbatim, e.g. nested markup.
+func Raw(s string) Value {
+	return Value{kind: kindRaw, s: s}
+}
+
+// Uint returns a decimal argument.
+func Uint(v uint64) Value {
+	return Value{kind: kindUint, n: v}
+}
+
+// Int returns a signed decimal argument.
+func Int(v int64) Value {
+	return Value{kind: kindInt, n: uint64(v)}
+}
+
+// U256 returns a 256-bit decimal argument.
+func U256(v stygos.U256) Value {
+	return Value{kind: kindU256, u256: v}
+}
+
+// Fixed returns v/10^decimals as a decimal argument, see AppendFixed.
+func Fixed(v int64, decimals int) Value {
+	return Value{kind: kindFixed, n: uint64(v), scale: decimals}
+}
+
+func (v Value) append(b *Builder) {
+	switch v.kind {
+	case kindText:
+		b.Text(v.s)
+	case kindRaw:
+		b.Raw(v.s)
+	case kindUint:
+		b.Uint(v.n)
+	case kindInt:
+		b.Int(int64(v.n))
+	case kindU256:
+		b.U256(v.u256)
+	case kindFixed:
+		b.Fixed(int64(v.n), v.scale)
+	}
+}
@@ -0,0 +1,150 @@
+// Package token provides clients for calling token contracts from stygos
+// contracts.
+//
+// Each method encodes the ABI call, performs it through stygos.Call or
+// stygos.StaticCall and decodes the result. In tests, InstallMockERC20
+// deploys an in-memory token on a stygos.MockRuntime.
+package token
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// Token errors
+var (
+	ErrBadReturn      = errors.New("token: malformed return data")
+	ErrTransferFailed = errors.New("token: transfer returned false")
+	ErrApproveFailed  = errors.New("token: approve returned false")
+)
+
+// ERC-20 selectors
+var (
+	selBalanceOf    = stygos.Selector{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	selTotalSupply  = stygos.Selector{0x18, 0x16, 0x0d, 0xdd} // totalSupply()
+	selAllowance    = stygos.Selector{0xdd, 0x62, 0xed, 0x3e} // allowance(address,address)
+	selDecimals     = stygos.Selector{0x31, 0x3c, 0xe5, 0x67} // decimals()
+	selTransfer     = stygos.Selector{0xa9, 0x05, 0x9c, 0xbb} // transfer(address,uint256)
+	selTransferFrom = stygos.Selector{0x23, 0xb8, 0x72, 0xdd} // transferFrom(address,address,uint256)
+	selApprove      = stygos.Selector{0x09, 0x5e, 0xa7, 0xb3} // approve(address,uint256)
+
+	selIncreaseAllowance = stygos.Selector{0x39, 0x50, 0x93, 0x51} // increaseAllowance(address,uint256)
+	selDecreaseAllowance = stygos.Selector{0xa4, 0x57, 0xc2, 0xd7} // decreaseAllowance(address,uint256)
+	selMint              = stygos.Selector{0x40, 0xc1, 0x0f, 0x19} // mint(address,uint256)
+)
+
+// ERC20 is a client for an ERC-20 token contract.
+type ERC20 struct {
+	addr stygos.Address
+}
+
+// NewERC20 returns a client for the token at addr.
+func NewERC20(addr stygos.Address) ERC20 {
+	return ERC20{addr: addr}
+}
+
+// Address returns the token contract address.
+func (t ERC20) Address() stygos.Address {
+	return t.addr
+}
+
+// BalanceOf returns the token balance of account.
+func (t ERC20) BalanceOf(account stygos.Address) (stygos.U256, error) {
+	return t.staticU256(encodeCall(selBalanceOf, stygos.PadAddress(account)))
+}
+
+// TotalSupply returns the token supply.
+func (t ERC20) TotalSupply() (stygos.U256, error) {
+	return t.staticU256(encodeCall(selTotalSupply))
+}
+
+// Allowance returns the amount spender may transfer from owner.
+func (t ERC20) Allowance(owner, spender stygos.Address) (stygos.U256, error) {
+	return t.staticU256(encodeCall(selAllowance, stygos.PadAddress(owner), stygos.PadAddress(spender)))
+}
+
+// Decimals returns the number of decimals of the token.
+func (t ERC20) Decimals() (uint8, error) {
+	v, err := t.staticU256(encodeCall(selDecimals))
+	return uint8(v.Uint64()), err
+}
+
+// Transfer sends amount tokens from the calling contract to to.
+func (t ERC20) Transfer(to stygos.Address, amount stygos.U256) error {
+	return t.callBool(encodeCall(selTransfer, stygos.PadAddress(to), amount.Word()), ErrTransferFailed)
+}
+
+// TransferFrom moves amount tokens from from to to using the calling
+// contract's allowance.
+func (t ERC20) TransferFrom(from, to stygos.Address, amount stygos.U256) error {
+	data := encodeCall(selTransferFrom, stygos.PadAddress(from), stygos.PadAddress(to), amount.Word())
+	return t.callBool(data, ErrTransferFailed)
+}
+
+// Approve lets spender transfer up to amount of the calling contract's
+// tokens.
+func (t ERC20) Approve(spender stygos.Address, amount stygos.U256) error {
+	return t.callBool(encodeCall(selApprove, stygos.PadAddress(spender), amount.Word()), ErrApproveFailed)
+}
+
+// IncreaseAllowance raises the calling contract's allowance for spender by
+// amount. Unlike Approve it cannot race with a transferFrom of the old
+// allowance, but only tokens implementing the OpenZeppelin extension
+// support it.
+func (t ERC20) IncreaseAllowance(spender stygos.Address, amount stygos.U256) error {
+	return t.callBool(encodeCall(selIncreaseAllowance, stygos.PadAddress(spender), amount.Word()), ErrApproveFailed)
+}
+
+// DecreaseAllowance lowers the calling contract's allowance for spender by
+// amount. The token reverts if the spender has already used more.
+func (t ERC20) DecreaseAllowance(spender stygos.Address, amount stygos.U256) error {
+	return t.callBool(encodeCall(selDecreaseAllowance, stygos.PadAddress(spender), amount.Word()), ErrApproveFailed)
+}
+
+// Mint creates amount tokens for to, on tokens with a
+// mint(address,uint256) function restricted to a minter role that the
+// calling contract holds. Its return data is ignored.
+func (t ERC20) Mint(to stygos.Address, amount stygos.U256) error {
+	_, err := stygos.Call(t.addr, stygos.Word{}, encodeCall(selMint, stygos.PadAddress(to), amount.Word()))
+	return err
+}
+
+// callBool performs a call that must return true. Tokens that return
+// nothing are rejected.
+func (t ERC20) callBool(data []byte, falseErr error) error {
+	ret, err := stygos.Call(t.addr, stygos.Word{}, data)
+	if err != nil {
+		return err
+	}
+	if len(ret) < 32 {
+		return ErrBadReturn
+	}
+	if ret[31] != 1 {
+		return falseErr
+	}
+	return nil
+}
+
+func (t ERC20) staticU256(data []byte) (stygos.U256, error) {
+	ret, err := stygos.StaticCall(t.addr, data)
+	if err != nil {
+		return stygos.U256{}, err
+	}
+	if len(ret) < 32 {
+		return stygos.U256{}, ErrBadReturn
+	}
+	var w stygos.Word
+	copy(w[:], ret)
+	return stygos.U256FromWord(w), nil
+}
+
+// encodeCall returns selector ++ args, each argument a 32-byte word.
+func encodeCall(sel stygos.Selector, args ...stygos.Word) []byte {
+	data := make([]byte, 4, 4+32*len(args))
+	copy(data, sel[:])
+	for _, arg := range args {
+		data = append(data, arg[:]...)
+	}
+	return data
+}
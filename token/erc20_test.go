@@ -0,0 +1,292 @@
+package token
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+)
+
+func TestSelectors(t *testing.T) {
+	stygos.UseRuntime(stygos.NewMockRuntime())
+
+	tests := []struct {
+		sel       stygos.Selector
+		signature string
+	}{
+		{selBalanceOf, "balanceOf(address)"},
+		{selTotalSupply, "totalSupply()"},
+		{selAllowance, "allowance(address,address)"},
+		{selDecimals, "decimals()"},
+		{selTransfer, "transfer(address,uint256)"},
+		{selTransferFrom, "transferFrom(address,address,uint256)"},
+		{selApprove, "approve(address,uint256)"},
+		{selIncreaseAllowance, "increaseAllowance(address,uint256)"},
+		{selDecreaseAllowance, "decreaseAllowance(address,uint256)"},
+		{selMint, "mint(address,uint256)"},
+		{selPermitTransferFrom, "permitTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes)"},
+		{selPermit2TransferFrom, "transferFrom(address,address,uint160,address)"},
+		{selDeposit, "deposit()"},
+		{selWithdraw, "withdraw(uint256)"},
+	}
+	for _, tt := range tests {
+		if got := stygos.SelectorOf(tt.signature); got != tt.sel {
+			t.Errorf("Selector for %s failed. Expected %x, got %x", tt.signature, got, tt.sel)
+		}
+	}
+}
+
+func TestERC20(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0x70}
+	mockToken := InstallMockERC20(mock, addr)
+	mockToken.Mint(mock.Contract, stygos.NewU256(1000))
+	tok := NewERC20(addr)
+
+	alice, bob := stygos.Address{0xa1}, stygos.Address{0xb0}
+	if err := tok.Transfer(alice, stygos.NewU256(300)); err != nil {
+		t.Fatalf("Transfer failed: %v", err)
+	}
+	if err := tok.Transfer(alice, stygos.NewU256(701)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Transfer failed. Expected ErrRevert beyond balance, got %v", err)
+	}
+
+	// Spending alice's tokens needs her allowance
+	if err := tok.TransferFrom(alice, bob, stygos.NewU256(100)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("TransferFrom failed. Expected ErrRevert without allowance, got %v", err)
+	}
+	mockToken.Approve(alice, mock.Contract, stygos.NewU256(150))
+	if err := tok.TransferFrom(alice, bob, stygos.NewU256(100)); err != nil {
+		t.Fatalf("TransferFrom failed: %v", err)
+	}
+	if allowance, _ := tok.Allowance(alice, mock.Contract); allowance.Uint64() != 50 {
+		t.Errorf("Allowance failed. Expected 50, got %d", allowance.Uint64())
+	}
+
+	if err := tok.Approve(bob, stygos.NewU256(42)); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	if got := mockToken.Allowance(mock.Contract, bob); got.Uint64() != 42 {
+		t.Errorf("Approve failed. Expected 42, got %d", got.Uint64())
+	}
+
+	tests := []struct {
+		account stygos.Address
+		balance uint64
+	}{
+		{mock.Contract, 700},
+		{alice, 200},
+		{bob, 100},
+	}
+	for _, tt := range tests {
+		if got, err := tok.BalanceOf(tt.account); err != nil || got.Uint64() != tt.balance {
+			t.Errorf("BalanceOf(%x) failed. Expected %d, got %d, %v", tt.account, tt.balance, got.Uint64(), err)
+		}
+	}
+	if supply, _ := tok.TotalSupply(); supply.Uint64() != 1000 {
+		t.Errorf("TotalSupply failed. Expected 1000, got %d", supply.Uint64())
+	}
+	if decimals, _ := tok.Decimals(); decimals != 18 {
+		t.Errorf("Decimals failed. Expected 18, got %d", decimals)
+	}
+}
+
+func TestERC20RejectsMissingReturn(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0x71}
+	mock.Deploy(addr, func(input []byte) ([]byte, error) { return nil, nil })
+	if err := NewERC20(addr).Transfer(stygos.Address{0x01}, stygos.NewU256(1)); err != ErrBadReturn {
+		t.Errorf("Transfer failed. Expected ErrBadReturn, got %v", err)
+	}
+
+	falseWord := stygos.WordFromUint64(0)
+	mock.Deploy(addr, func(input []byte) ([]byte, error) { return falseWord[:], nil })
+	if err := NewERC20(addr).Transfer(stygos.Address{0x01}, stygos.NewU256(1)); err != ErrTransferFailed {
+		t.Errorf("Transfer failed. Expected ErrTransferFailed, got %v", err)
+	}
+}
+
+func TestAllowanceChanges(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0x70}
+	mockToken := InstallMockERC20(mock, addr)
+	tok := NewERC20(addr)
+	spender := stygos.Address{0x5e}
+
+	if err := tok.IncreaseAllowance(spender, stygos.NewU256(70)); err != nil {
+		t.Fatalf("IncreaseAllowance failed: %v", err)
+	}
+	if err := tok.DecreaseAllowance(spender, stygos.NewU256(30)); err != nil {
+		t.Fatalf("DecreaseAllowance failed: %v", err)
+	}
+	if got := mockToken.Allowance(mock.Contract, spender); got.Uint64() != 40 {
+		t.Errorf("DecreaseAllowance failed. Expected 40, got %d", got.Uint64())
+	}
+	if err := tok.DecreaseAllowance(spender, stygos.NewU256(41)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("DecreaseAllowance failed. Expected ErrRevert below zero, got %v", err)
+	}
+}
+
+func TestMint(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0x70}
+	mockToken := InstallMockERC20(mock, addr)
+	tok := NewERC20(addr)
+	to := stygos.Address{0x7e}
+
+	if err := tok.Mint(to, stygos.NewU256(5)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("Mint failed. Expected ErrRevert without the minter role, got %v", err)
+	}
+	mockToken.Minter = mock.Contract
+	if err := tok.Mint(to, stygos.NewU256(5)); err != nil {
+		t.Fatalf("Mint failed: %v", err)
+	}
+	if mockToken.Balances[to].Uint64() != 5 || mockToken.TotalSupply.Uint64() != 5 {
+		t.Errorf("Mint failed. Expected a balance and supply of 5, got %d and %d", mockToken.Balances[to].Uint64(), mockToken.TotalSupply.Uint64())
+	}
+}
+
+func TestPermit2(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	mock.Time = 1_000
+	stygos.UseRuntime(mock)
+
+	addr := stygos.Address{0x70}
+	mockToken := InstallMockERC20(mock, addr)
+	InstallMockPermit2(mock, Permit2Address)
+	p := NewPermit2(Permit2Address)
+
+	key := big.NewInt(0x0a11ce)
+	owner, bob := ecdsa.AddressOf(key), stygos.Address{0xb0}
+	mockToken.Mint(owner, stygos.NewU256(1000))
+	mockToken.Approve(owner, Permit2Address, stygos.NewU256(1000))
+
+	sign := func(permit PermitTransferFrom, spender stygos.Address) []byte {
+		sig, err := ecdsa.Sign(key, permit.Digest(spender, mock.Chain, Permit2Address))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig.Bytes()
+	}
+	permit := PermitTransferFrom{Token: addr, Amount: stygos.NewU256(500), Nonce: stygos.NewU256(7), Deadline: 2_000}
+	sig := sign(permit, mock.Contract)
+
+	if err := p.PermitTransferFrom(permit, bob, stygos.NewU256(501), owner, sig); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("PermitTransferFrom failed. Expected a revert beyond the permitted amount, got %v", err)
+	}
+	if err := p.PermitTransferFrom(permit, bob, stygos.NewU256(400), owner, sig); err != nil {
+		t.Fatalf("PermitTransferFrom failed: %v", err)
+	}
+	if got := mockToken.Balances[bob]; got.Uint64() != 400 {
+		t.Errorf("PermitTransferFrom failed. Expected bob to hold 400, got %d", got.Uint64())
+	}
+	if err := p.PermitTransferFrom(permit, bob, stygos.NewU256(100), owner, sig); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("PermitTransferFrom failed. Expected a replayed nonce to revert, got %v", err)
+	}
+
+	// The signature binds the spender and the deadline
+	permit.Nonce = stygos.NewU256(8)
+	if err := p.PermitTransferFrom(permit, bob, stygos.NewU256(1), owner, sign(permit, stygos.Address{0xee})); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("PermitTransferFrom failed. Expected a permit for another spender to revert, got %v", err)
+	}
+	mock.Time = 2_001
+	if err := p.PermitTransferFrom(permit, bob, stygos.NewU256(1), owner, sign(permit, mock.Contract)); !errors.Is(err, stygos.ErrRevert) {
+		t.Errorf("PermitTransferFrom failed. Expected an expired permit to revert, got %v", err)
+	}
+
+	var huge stygos.U256
+	huge[2] = 1 << 32
+	if err := p.TransferFrom(owner, bob, huge, addr); err != ErrAmountTooLarge {
+		t.Errorf("TransferFrom failed. Expected ErrAmountTooLarge above uint160, got %v", err)
+	}
+}
+
+func TestSafeWrappers(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = stygos.Address{0xc0}
+	stygos.UseRuntime(mock)
+	to := stygos.Address{0xb0}
+
+	// A USDT-like token: no return values, and approve only from or to zero
+	usdt := stygos.Address{0x75}
+	var moved uint64
+	allowance := map[stygos.Address]stygos.Word{}
+	mock.Deploy(usdt, func(input []byte) ([]byte, error) {
+		var sel stygos.Selector
+		copy(sel[:], input)
+		w, err := argWords(input[4:], (len(input)-4)/32)
+		if err != nil {
+			return nil, err
+		}
+		switch sel {
+		case selTransfer, selTransferFrom:
+			moved += stygos.Uint64FromWord(w[len(w)-1])
+		case selApprove:
+			spender := stygos.AddressFromWord(w[0])
+			if allowance[spender] != (stygos.Word{}) && w[1] != (stygos.Word{}) {
+				return nil, ErrBadCalldata
+			}
+			allowance[spender] = w[1]
+		}
+		return nil, nil
+	})
+	tok := NewERC20(usdt)
+	if err := tok.Transfer(to, stygos.NewU256(5)); err != ErrBadReturn {
+		t.Errorf("Transfer failed. Expected ErrBadReturn without a return value, got %v", err)
+	}
+	if err := SafeTransfer(tok, to, stygos.NewU256(5)); err != nil {
+		t.Errorf("SafeTransfer failed: %v", err)
+	}
+	if err := SafeTransferFrom(tok, stygos.Address{0xa1}, to, stygos.NewU256(5)); err != nil {
+		t.Errorf("SafeTransferFrom failed: %v", err)
+	}
+	// Transfer moved its 5 tokens too, but could not tell
+	if moved != 15 {
+		t.Errorf("SafeTransfer failed. Expected 15 tokens moved, got %d", moved)
+	}
+	for _, amount := range []uint64{10, 20} {
+		if err := SafeApprove(tok, to, stygos.NewU256(amount)); err != nil {
+			t.Errorf("SafeApprove(%d) failed: %v", amount, err)
+		}
+	}
+	if got := stygos.Uint64FromWord(allowance[to]); got != 20 {
+		t.Errorf("SafeApprove failed. Expected the allowance to be reset and set to 20, got %d", got)
+	}
+
+	// False and malformed returns still fail
+	tests := []struct {
+		ret  []byte
+		want error
+	}{
+		{make([]byte, 32), ErrTransferFailed},
+		{append(make([]byte, 31), 2), ErrBadReturn},
+		{[]byte{1}, ErrBadReturn},
+	}
+	odd := stygos.Address{0x0d}
+	for _, tt := range tests {
+		ret := tt.ret
+		mock.Deploy(odd, func([]byte) ([]byte, error) { return ret, nil })
+		if err := SafeTransfer(NewERC20(odd), to, stygos.NewU256(1)); err != tt.want {
+			t.Errorf("SafeTransfer failed. Expected %v for return data %x, got %v", tt.want, tt.ret, err)
+		}
+	}
+
+	// An address without code would swallow the transfer
+	if err := SafeTransfer(NewERC20(stygos.Address{0xe0}), to, stygos.NewU256(1)); err != ErrNoCode {
+		t.Errorf("SafeTransfer failed. Expected ErrNoCode for an EOA, got %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+//go:build !tinygo
+
+package token
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/ecdsa"
+)
+
+// MockERC20 reverts
+var (
+	ErrInsufficientBalance   = errors.New("token: transfer amount exceeds balance")
+	ErrInsufficientAllowance = errors.New("token: insufficient allowance")
+	ErrBadCalldata           = errors.New("token: malformed calldata")
+	ErrAllowanceOverflow     = errors.New("token: allowance overflow")
+	ErrAllowanceBelowZero    = errors.New("token: decreased allowance below zero")
+	ErrNotMinter             = errors.New("token: caller is not the minter")
+)
+
+// MockERC20 is an in-memory ERC-20 token deployed on a stygos.MockRuntime.
+type MockERC20 struct {
+	Decimals    uint8
+	TotalSupply stygos.U256
+	Balances    map[stygos.Address]stygos.U256
+	Allowances  map[stygos.Address]map[stygos.Address]stygos.U256
+	Minter      stygos.Address // may call mint(address,uint256), nobody when zero
+}
+
+// InstallMockERC20 deploys a MockERC20 with 18 decimals at addr on rt and
+// returns it.
+func InstallMockERC20(rt *stygos.MockRuntime, addr stygos.Address) *MockERC20 {
+	m := &MockERC20{
+		Decimals:   18,
+		Balances:   make(map[stygos.Address]stygos.U256),
+		Allowances: make(map[stygos.Address]map[stygos.Address]stygos.U256),
+	}
+
+	r := stygos.NewRouter()
+	r.HandleSelector(selBalanceOf, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return wordResult(m.Balances[stygos.AddressFromWord(w[0])].Word()), nil
+	})
+	r.HandleSelector(selTotalSupply, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return wordResult(m.TotalSupply.Word()), nil
+	})
+	r.HandleSelector(selAllowance, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return wordResult(m.Allowance(stygos.AddressFromWord(w[0]), stygos.AddressFromWord(w[1])).Word()), nil
+	})
+	r.HandleSelector(selDecimals, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		return wordResult(stygos.WordFromUint64(uint64(m.Decimals))), nil
+	})
+	r.HandleSelector(selTransfer, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.move(stygos.GetMsgSender(), stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1])); err != nil {
+			return nil, err
+		}
+		return wordResult(stygos.WordFromUint64(1)), nil
+	})
+	r.HandleSelector(selTransferFrom, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 3)
+		if err != nil {
+			return nil, err
+		}
+		from, amount := stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[2])
+		spender := stygos.GetMsgSender()
+		allowance := m.Allowance(from, spender)
+		if allowance.Lt(amount) {
+			return nil, ErrInsufficientAllowance
+		}
+		if err := m.move(from, stygos.AddressFromWord(w[1]), amount); err != nil {
+			return nil, err
+		}
+		m.Approve(from, spender, allowance.Sub(amount))
+		return wordResult(stygos.WordFromUint64(1)), nil
+	})
+	r.HandleSelector(selApprove, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		m.Approve(stygos.GetMsgSender(), stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1]))
+		return wordResult(stygos.WordFromUint64(1)), nil
+	})
+	r.HandleSelector(selIncreaseAllowance, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		owner, spender := stygos.GetMsgSender(), stygos.AddressFromWord(w[0])
+		allowance, ok := m.Allowance(owner, spender).CheckedAdd(stygos.U256FromWord(w[1]))
+		if !ok {
+			return nil, ErrAllowanceOverflow
+		}
+		m.Approve(owner, spender, allowance)
+		return wordResult(stygos.WordFromUint64(1)), nil
+	})
+	r.HandleSelector(selDecreaseAllowance, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		owner, spender := stygos.GetMsgSender(), stygos.AddressFromWord(w[0])
+		allowance, ok := m.Allowance(owner, spender).CheckedSub(stygos.U256FromWord(w[1]))
+		if !ok {
+			return nil, ErrAllowanceBelowZero
+		}
+		m.Approve(owner, spender, allowance)
+		return wordResult(stygos.WordFromUint64(1)), nil
+	})
+	r.HandleSelector(selMint, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		if m.Minter == (stygos.Address{}) || stygos.GetMsgSender() != m.Minter {
+			return nil, ErrNotMinter
+		}
+		m.Mint(stygos.AddressFromWord(w[0]), stygos.U256FromWord(w[1]))
+		return nil, nil
+	})
+	rt.Deploy(addr, r.Dispatch)
+	return m
+}
+
+// Mint creates amount tokens for to.
+func (m *MockERC20) Mint(to stygos.Address, amount stygos.U256) {
+	m.Balances[to] = m.Balances[to].Add(amount)
+	m.TotalSupply = m.TotalSupply.Add(amount)
+}
+
+// Allowance returns the amount spender may transfer from owner.
+func (m *MockERC20) Allowance(owner, spender stygos.Address) stygos.U256 {
+	return m.Allowances[owner][spender]
+}
+
+// Approve sets the amount spender may transfer from owner.
+func (m *MockERC20) Approve(owner, spender stygos.Address, amount stygos.U256) {
+	if m.Allowances[owner] == nil {
+		m.Allowances[owner] = make(map[stygos.Address]stygos.U256)
+	}
+	m.Allowances[owner][spender] = amount
+}
+
+func (m *MockERC20) move(from, to stygos.Address, amount stygos.U256) error {
+	if m.Balances[from].Lt(amount) {
+		return ErrInsufficientBalance
+	}
+	m.Balances[from] = m.Balances[from].Sub(amount)
+	m.Balances[to] = m.Balances[to].Add(amount)
+	return nil
+}
+
+// MockPermit2 reverts
+var (
+	ErrSignatureExpired = errors.New("token: permit2 signature expired")
+	ErrInvalidNonce     = errors.New("token: permit2 nonce already used")
+	ErrInvalidAmount    = errors.New("token: permit2 requested amount too high")
+	ErrInvalidSigner    = errors.New("token: permit2 signature is not the owner's")
+)
+
+// MockPermit2 is an in-memory Permit2 deployed on a stygos.MockRuntime. It
+// implements permitTransferFrom, checking signatures in Go, and moves
+// tokens with transferFrom on the token, which the owner must have
+// approved Permit2 on.
+type MockPermit2 struct {
+	Nonces map[stygos.Address]map[stygos.U256]bool // used nonces by owner
+}
+
+// InstallMockPermit2 deploys a MockPermit2 at addr on rt and returns it.
+func InstallMockPermit2(rt *stygos.MockRuntime, addr stygos.Address) *MockPermit2 {
+	m := &MockPermit2{Nonces: make(map[stygos.Address]map[stygos.U256]bool)}
+
+	r := stygos.NewRouter()
+	r.HandleSelector(selPermitTransferFrom, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		w, err := argWords(args, 9)
+		if err != nil {
+			return nil, err
+		}
+		permit := PermitTransferFrom{
+			Token:    stygos.AddressFromWord(w[0]),
+			Amount:   stygos.U256FromWord(w[1]),
+			Nonce:    stygos.U256FromWord(w[2]),
+			Deadline: stygos.Uint64FromWord(w[3]),
+		}
+		to, requested, owner := stygos.AddressFromWord(w[4]), stygos.U256FromWord(w[5]), stygos.AddressFromWord(w[6])
+		if n := stygos.Uint64FromWord(w[8]); w[7] != stygos.WordFromUint64(8*32) || uint64(len(args)) < 9*32+n {
+			return nil, ErrBadCalldata
+		}
+		sig, err := ecdsa.SignatureFromBytes(args[9*32 : 9*32+stygos.Uint64FromWord(w[8])])
+		if err != nil {
+			return nil, ErrInvalidSigner
+		}
+
+		if stygos.GetBlockTimestamp() > permit.Deadline {
+			return nil, ErrSignatureExpired
+		}
+		if permit.Amount.Lt(requested) {
+			return nil, ErrInvalidAmount
+		}
+		if m.Nonces[owner][permit.Nonce] {
+			return nil, ErrInvalidNonce
+		}
+		digest := permit.Digest(stygos.GetMsgSender(), stygos.GetChainID(), stygos.GetContractAddress())
+		if signer, err := ecdsa.RecoverAddress(digest, sig); err != nil || signer != owner {
+			return nil, ErrInvalidSigner
+		}
+		if err := NewERC20(permit.Token).TransferFrom(owner, to, requested); err != nil {
+			return nil, err
+		}
+		if m.Nonces[owner] == nil {
+			m.Nonces[owner] = make(map[stygos.U256]bool)
+		}
+		m.Nonces[owner][permit.Nonce] = true
+		return nil, nil
+	})
+	rt.Deploy(addr, r.Dispatch)
+	return m
+}
+
+func argWords(args []byte, n int) ([]stygos.Word, error) {
+	if len(args) < 32*n {
+		return nil, ErrBadCalldata
+	}
+	out := make([]stygos.Word, n)
+	for i := range out {
+		copy(out[i][:], args[32*i:])
+	}
+	return out, nil
+}
+
+func wordResult(w stygos.Word) []byte {
+	return w[:]
+}
@@ -0,0 +1,103 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/eip712"
+)
+
+// ErrAmountTooLarge is returned for Permit2 allowance transfers of more
+// than a uint160 holds.
+var ErrAmountTooLarge = errors.New("token: amount exceeds uint160")
+
+// Permit2Address is the canonical Permit2 deployment, at the same address
+// on every chain.
+var Permit2Address = stygos.Address{
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x22, 0xd4, 0x73, 0x03, 0x0f,
+	0x11, 0x6d, 0xde, 0xe9, 0xf6, 0xb4, 0x3a, 0xc7, 0x8b, 0xa3,
+}
+
+// Permit2 selectors
+var (
+	selPermitTransferFrom  = stygos.Selector{0x30, 0xf2, 0x8b, 0x7a} // permitTransferFrom(((address,uint256),uint256,uint256),(address,uint256),address,bytes)
+	selPermit2TransferFrom = stygos.Selector{0x36, 0xc7, 0x85, 0x16} // transferFrom(address,address,uint160,address)
+)
+
+// Permit2 EIP-712 types
+const (
+	permit2DomainType      = "EIP712Domain(string name,uint256 chainId,address verifyingContract)"
+	tokenPermissionsType   = "TokenPermissions(address token,uint256 amount)"
+	permitTransferFromType = "PermitTransferFrom(TokenPermissions permitted,address spender,uint256 nonce,uint256 deadline)" + tokenPermissionsType
+)
+
+// PermitTransferFrom is a Permit2 signature transfer: the owner signs it
+// off-chain for one spender, the contract that then calls Permit2, which
+// may transfer up to Amount once before the deadline.
+type PermitTransferFrom struct {
+	Token    stygos.Address
+	Amount   stygos.U256
+	Nonce    stygos.U256 // unordered; any unused nonce of the owner works
+	Deadline uint64      // unix seconds
+}
+
+// Digest returns the EIP-712 digest the owner signs to let spender use the
+// permit, with Permit2 at permit2 on chain chainID.
+func (p PermitTransferFrom) Digest(spender stygos.Address, chainID uint64, permit2 stygos.Address) stygos.Word {
+	domain := eip712.HashStruct(eip712.TypeHash(permit2DomainType),
+		eip712.HashString("Permit2"),
+		stygos.WordFromUint64(chainID),
+		stygos.PadAddress(permit2))
+	permitted := eip712.HashStruct(eip712.TypeHash(tokenPermissionsType), stygos.PadAddress(p.Token), p.Amount.Word())
+	return eip712.Digest(domain, eip712.HashStruct(eip712.TypeHash(permitTransferFromType),
+		permitted,
+		stygos.PadAddress(spender),
+		p.Nonce.Word(),
+		stygos.WordFromUint64(p.Deadline)))
+}
+
+// Permit2 is a client for the Permit2 contract. Owners approve Permit2
+// once per token; contracts then move their tokens with a signature per
+// transfer, or within an allowance kept by Permit2, instead of an ERC-20
+// approve per spender with its front-running race.
+type Permit2 struct {
+	addr stygos.Address
+}
+
+// NewPermit2 returns a client for Permit2 at addr, normally
+// Permit2Address.
+func NewPermit2(addr stygos.Address) Permit2 {
+	return Permit2{addr: addr}
+}
+
+// PermitTransferFrom transfers requested tokens, at most permit.Amount,
+// from owner to to with the owner's signature of the permit for the calling
+// contract. Permit2 reverts if the signature, nonce or deadline is invalid,
+// which makes the nonce single-use across every spender.
+func (p Permit2) PermitTransferFrom(permit PermitTransferFrom, to stygos.Address, requested stygos.U256, owner stygos.Address, signature []byte) error {
+	data := encodeCall(selPermitTransferFrom,
+		stygos.PadAddress(permit.Token),
+		permit.Amount.Word(),
+		permit.Nonce.Word(),
+		stygos.WordFromUint64(permit.Deadline),
+		stygos.PadAddress(to),
+		requested.Word(),
+		stygos.PadAddress(owner),
+		stygos.WordFromUint64(8*32),
+		stygos.WordFromUint64(uint64(len(signature))))
+	data = append(data, signature...)
+	data = append(data, make([]byte, (len(signature)+31)/32*32-len(signature))...)
+	_, err := stygos.Call(p.addr, stygos.Word{}, data)
+	return err
+}
+
+// TransferFrom moves amount of token from from to to within the allowance
+// from granted the calling contract through Permit2's AllowanceTransfer.
+func (p Permit2) TransferFrom(from, to stygos.Address, amount stygos.U256, token stygos.Address) error {
+	if amount[3] != 0 || amount[2]>>32 != 0 {
+		return ErrAmountTooLarge
+	}
+	data := encodeCall(selPermit2TransferFrom, stygos.PadAddress(from), stygos.PadAddress(to), amount.Word(), stygos.PadAddress(token))
+	_, err := stygos.Call(p.addr, stygos.Word{}, data)
+	return err
+}
@@ -0,0 +1,69 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// ErrNoCode is returned by the safe wrappers when the token address has
+// no code: a call to it would succeed without moving anything.
+var ErrNoCode = errors.New("token: no contract at the token address")
+
+// SafeTransfer sends amount tokens from the calling contract to to. Unlike
+// ERC20.Transfer it accepts tokens that return nothing, such as USDT, as
+// long as the call does not revert and the token has code. It fails on a
+// false return, malformed return data or a revert.
+func SafeTransfer(t ERC20, to stygos.Address, amount stygos.U256) error {
+	return t.callOptionalBool(encodeCall(selTransfer, stygos.PadAddress(to), amount.Word()), ErrTransferFailed)
+}
+
+// SafeTransferFrom moves amount tokens from from to to using the calling
+// contract's allowance, accepting tokens that return nothing as
+// SafeTransfer does.
+func SafeTransferFrom(t ERC20, from, to stygos.Address, amount stygos.U256) error {
+	data := encodeCall(selTransferFrom, stygos.PadAddress(from), stygos.PadAddress(to), amount.Word())
+	return t.callOptionalBool(data, ErrTransferFailed)
+}
+
+// SafeApprove sets the calling contract's allowance for spender to amount,
+// accepting tokens that return nothing. Tokens such as USDT refuse to
+// change an allowance that is not zero; when the approval fails,
+// SafeApprove resets it to zero and approves again.
+func SafeApprove(t ERC20, spender stygos.Address, amount stygos.U256) error {
+	approve := encodeCall(selApprove, stygos.PadAddress(spender), amount.Word())
+	err := t.callOptionalBool(approve, ErrApproveFailed)
+	if err != ErrApproveFailed && !errors.Is(err, stygos.ErrRevert) {
+		return err
+	}
+	if err := t.callOptionalBool(encodeCall(selApprove, stygos.PadAddress(spender), stygos.Word{}), ErrApproveFailed); err != nil {
+		return err
+	}
+	return t.callOptionalBool(approve, ErrApproveFailed)
+}
+
+// callOptionalBool performs a call that must return true or nothing.
+func (t ERC20) callOptionalBool(data []byte, falseErr error) error {
+	ret, err := stygos.Call(t.addr, stygos.Word{}, data)
+	if err != nil {
+		return err
+	}
+	if len(ret) == 0 {
+		if stygos.GetCodeSize(t.addr) == 0 {
+			return ErrNoCode
+		}
+		return nil
+	}
+	if len(ret) < 32 {
+		return ErrBadReturn
+	}
+	var w stygos.Word
+	copy(w[:], ret)
+	switch w {
+	case stygos.WordFromUint64(1):
+		return nil
+	case stygos.Word{}:
+		return falseErr
+	}
+	return ErrBadReturn
+}
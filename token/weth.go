@@ -0,0 +1,36 @@
+package token
+
+import "github.com/rafaelescrich/stygos"
+
+// WETH selectors
+var (
+	selDeposit  = stygos.Selector{0xd0, 0xe3, 0x0d, 0xb0} // deposit()
+	selWithdraw = stygos.Selector{0x2e, 0x1a, 0x7d, 0x4d} // withdraw(uint256)
+)
+
+// WETH is a client for a WETH9-style wrapped native token. It is an ERC-20
+// whose tokens are minted one for one against the ETH deposited and burned
+// to withdraw it, so contracts can handle ETH with the same code as any
+// other token.
+type WETH struct {
+	ERC20
+}
+
+// NewWETH returns a client for the wrapped native token at addr.
+func NewWETH(addr stygos.Address) WETH {
+	return WETH{ERC20: NewERC20(addr)}
+}
+
+// Deposit wraps wei of the calling contract's ETH, crediting it with the
+// same amount of tokens.
+func (w WETH) Deposit(wei stygos.U256) error {
+	_, err := stygos.Call(w.addr, wei.Word(), selDeposit[:])
+	return err
+}
+
+// Withdraw burns amount of the calling contract's tokens and sends it the
+// ETH back, running its code: the contract must accept plain transfers.
+func (w WETH) Withdraw(amount stygos.U256) error {
+	_, err := stygos.Call(w.addr, stygos.Word{}, encodeCall(selWithdraw, amount.Word()))
+	return err
+}
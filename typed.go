@@ -0,0 +1,411 @@
+package stygos
+
+import "strings"
+
+// ABIType is a Go type typed handlers decode arguments into and encode
+// results from:
+//
+//	address    Address
+//	bytes32    Word
+//	uintN      U256, or uint8 to uint64 when N fits
+//	bool       bool
+//	bytes      []byte
+//	string     string
+type ABIType interface {
+	Address | Word | U256 | bool | uint8 | uint16 | uint32 | uint64 | []byte | string
+}
+
+// TypedHandler is a handler with typed arguments, built from a function by
+// Func0 to Func4, or Func0R to Func4R for functions returning a value, and
+// registered with Router.HandleTyped.
+type TypedHandler struct {
+	params []any // zero value of each argument, to check the signature
+	result any   // zero value of the result, nil for none
+	call   func(ctx *Ctx, d *argDecoder) (any, error)
+}
+
+// HandleTyped registers h for the function with the given canonical
+// signature. The arguments are decoded, and checked to be in range of
+// their ABI types, before h runs, and its result is ABI encoded:
+//
+//	r.HandleTyped("transfer(address,uint256)", stygos.Func2(func(ctx *stygos.Ctx, to stygos.Address, amount stygos.U256) error {
+//		...
+//	}))
+//
+// It panics if the signature's parameters do not match the function's, so
+// a mismatch fails when the router is built rather than on chain.
+func (r *Router) HandleTyped(signature string, h TypedHandler) {
+	types, ok := signatureParams(signature)
+	if !ok || len(types) != len(h.params) {
+		panic("stygos: HandleTyped: " + signature + " does not take " + string(rune('0'+len(h.params))) + " arguments")
+	}
+	for i, t := range types {
+		if !abiAccepts(h.params[i], t) {
+			panic("stygos: HandleTyped: argument " + string(rune('0'+i)) + " of " + signature + " cannot be decoded as " + goTypeName(h.params[i]))
+		}
+	}
+	r.Handle(signature, func(ctx *Ctx, args []byte) ([]byte, error) {
+		d := &argDecoder{args: args, types: types}
+		result, err := h.call(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		if h.result == nil {
+			return nil, nil
+		}
+		return encodeResult(result), nil
+	})
+}
+
+// Func0 adapts a function without arguments for HandleTyped.
+func Func0(fn func(ctx *Ctx) error) TypedHandler {
+	return TypedHandler{call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		return nil, fn(ctx)
+	}}
+}
+
+// Func1 adapts a function of one argument for HandleTyped.
+func Func1[A ABIType](fn func(ctx *Ctx, a A) error) TypedHandler {
+	var a A
+	return TypedHandler{params: []any{a}, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a := decodeArg[A](d, 0)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return nil, fn(ctx, a)
+	}}
+}
+
+// Func2 adapts a function of two arguments for HandleTyped.
+func Func2[A, B ABIType](fn func(ctx *Ctx, a A, b B) error) TypedHandler {
+	var a A
+	var b B
+	return TypedHandler{params: []any{a, b}, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a, b := decodeArg[A](d, 0), decodeArg[B](d, 1)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return nil, fn(ctx, a, b)
+	}}
+}
+
+// Func3 adapts a function of three arguments for HandleTyped.
+func Func3[A, B, C ABIType](fn func(ctx *Ctx, a A, b B, c C) error) TypedHandler {
+	var a A
+	var b B
+	var c C
+	return TypedHandler{params: []any{a, b, c}, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a, b, c := decodeArg[A](d, 0), decodeArg[B](d, 1), decodeArg[C](d, 2)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return nil, fn(ctx, a, b, c)
+	}}
+}
+
+// Func4 adapts a function of four arguments for HandleTyped.
+func Func4[A, B, C, D ABIType](fn func(ctx *Ctx, a A, b B, c C, d D) error) TypedHandler {
+	var a A
+	var b B
+	var c C
+	var e D
+	return TypedHandler{params: []any{a, b, c, e}, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a, b, c, e := decodeArg[A](d, 0), decodeArg[B](d, 1), decodeArg[C](d, 2), decodeArg[D](d, 3)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return nil, fn(ctx, a, b, c, e)
+	}}
+}
+
+// Func0R adapts a function without arguments returning a value.
+func Func0R[R ABIType](fn func(ctx *Ctx) (R, error)) TypedHandler {
+	var r R
+	return TypedHandler{result: r, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		return fn(ctx)
+	}}
+}
+
+// Func1R adapts a function of one argument returning a value.
+func Func1R[A, R ABIType](fn func(ctx *Ctx, a A) (R, error)) TypedHandler {
+	var a A
+	var r R
+	return TypedHandler{params: []any{a}, result: r, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a := decodeArg[A](d, 0)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return fn(ctx, a)
+	}}
+}
+
+// Func2R adapts a function of two arguments returning a value.
+func Func2R[A, B, R ABIType](fn func(ctx *Ctx, a A, b B) (R, error)) TypedHandler {
+	var a A
+	var b B
+	var r R
+	return TypedHandler{params: []any{a, b}, result: r, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a, b := decodeArg[A](d, 0), decodeArg[B](d, 1)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return fn(ctx, a, b)
+	}}
+}
+
+// Func3R adapts a function of three arguments returning a value.
+func Func3R[A, B, C, R ABIType](fn func(ctx *Ctx, a A, b B, c C) (R, error)) TypedHandler {
+	var a A
+	var b B
+	var c C
+	var r R
+	return TypedHandler{params: []any{a, b, c}, result: r, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a, b, c := decodeArg[A](d, 0), decodeArg[B](d, 1), decodeArg[C](d, 2)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return fn(ctx, a, b, c)
+	}}
+}
+
+// Func4R adapts a function of four arguments returning a value.
+func Func4R[A, B, C, D, R ABIType](fn func(ctx *Ctx, a A, b B, c C, d D) (R, error)) TypedHandler {
+	var a A
+	var b B
+	var c C
+	var e D
+	var r R
+	return TypedHandler{params: []any{a, b, c, e}, result: r, call: func(ctx *Ctx, d *argDecoder) (any, error) {
+		a, b, c, e := decodeArg[A](d, 0), decodeArg[B](d, 1), decodeArg[C](d, 2), decodeArg[D](d, 3)
+		if d.err != nil {
+			return nil, d.err
+		}
+		return fn(ctx, a, b, c, e)
+	}}
+}
+
+// argDecoder decodes the ABI encoded arguments of a call, keeping the
+// first error.
+type argDecoder struct {
+	args  []byte
+	types []string // ABI type of each argument
+	err   error
+}
+
+// decodeArg decodes argument i as a T.
+func decodeArg[T ABIType](d *argDecoder, i int) T {
+	var v T
+	if d.err == nil {
+		d.decode(any(&v), i)
+	}
+	return v
+}
+
+// decode decodes argument i into p, a pointer to an ABIType, failing with
+// ErrInvalidInput on short calldata and values out of range of the ABI type.
+func (d *argDecoder) decode(p any, i int) {
+	w, ok := d.word(uint64(32 * i))
+	if !ok {
+		d.err = ErrInvalidInput
+		return
+	}
+	typ := d.types[i]
+	switch p := p.(type) {
+	case *Address:
+		if !isZero(w[:12]) {
+			d.err = ErrInvalidInput
+		}
+		*p = AddressFromWord(w)
+	case *Word:
+		*p = w
+	case *U256:
+		d.checkBits(w, typ)
+		*p = U256FromWord(w)
+	case *bool:
+		if w != (Word{}) && w != WordFromUint64(1) {
+			d.err = ErrInvalidInput
+		}
+		*p = w[31] == 1
+	case *uint8:
+		d.checkBits(w, typ)
+		*p = uint8(Uint64FromWord(w))
+	case *uint16:
+		d.checkBits(w, typ)
+		*p = uint16(Uint64FromWord(w))
+	case *uint32:
+		d.checkBits(w, typ)
+		*p = uint32(Uint64FromWord(w))
+	case *uint64:
+		d.checkBits(w, typ)
+		*p = Uint64FromWord(w)
+	case *[]byte:
+		*p = d.dynamic(w)
+	case *string:
+		*p = string(d.dynamic(w))
+	}
+}
+
+// word returns the word at off.
+func (d *argDecoder) word(off uint64) (Word, bool) {
+	var w Word
+	if off > uint64(len(d.args)) || uint64(len(d.args))-off < 32 {
+		return w, false
+	}
+	copy(w[:], d.args[off:])
+	return w, true
+}
+
+// checkBits fails unless w fits in the bits of the uintN typ.
+func (d *argDecoder) checkBits(w Word, typ string) {
+	bits, _ := uintBits(typ)
+	for i := 0; i < 32-bits/8; i++ {
+		if w[i] != 0 {
+			d.err = ErrInvalidInput
+			return
+		}
+	}
+}
+
+// dynamic returns the bytes or string at the offset head.
+func (d *argDecoder) dynamic(head Word) []byte {
+	off := Uint64FromWord(head)
+	if head != WordFromUint64(off) {
+		d.err = ErrInvalidInput
+		return nil
+	}
+	n, ok := abiUint(d.args, off)
+	if !ok || n > uint64(len(d.args))-off-32 {
+		d.err = ErrInvalidInput
+		return nil
+	}
+	return d.args[off+32 : off+32+n]
+}
+
+// encodeResult ABI encodes a single return value.
+func encodeResult(v any) []byte {
+	switch v := v.(type) {
+	case Address:
+		w := PadAddress(v)
+		return w[:]
+	case Word:
+		return v[:]
+	case U256:
+		w := v.Word()
+		return w[:]
+	case bool:
+		var w Word
+		if v {
+			w[31] = 1
+		}
+		return w[:]
+	case uint8:
+		return appendWord(nil, WordFromUint64(uint64(v)))
+	case uint16:
+		return appendWord(nil, WordFromUint64(uint64(v)))
+	case uint32:
+		return appendWord(nil, WordFromUint64(uint64(v)))
+	case uint64:
+		return appendWord(nil, WordFromUint64(v))
+	case []byte:
+		return encodeDynamic(v)
+	case string:
+		return encodeDynamic([]byte(v))
+	}
+	return nil
+}
+
+// encodeDynamic encodes bytes as the only return value: offset, length
+// and the data padded to words.
+func encodeDynamic(b []byte) []byte {
+	out := make([]byte, 0, 64+(len(b)+31)/32*32)
+	out = appendWord(out, WordFromUint64(32))
+	out = appendWord(out, WordFromUint64(uint64(len(b))))
+	out = append(out, b...)
+	return append(out, make([]byte, (len(b)+31)/32*32-len(b))...)
+}
+
+// signatureParams returns the parameter types of a canonical signature
+// without tuples.
+func signatureParams(signature string) ([]string, bool) {
+	open := strings.IndexByte(signature, '(')
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return nil, false
+	}
+	params := signature[open+1 : len(signature)-1]
+	if params == "" {
+		return nil, true
+	}
+	if strings.ContainsAny(params, "() ") {
+		return nil, false
+	}
+	return strings.Split(params, ","), true
+}
+
+// abiAccepts reports whether the Go value v decodes the ABI type typ.
+func abiAccepts(v any, typ string) bool {
+	bits, isUint := uintBits(typ)
+	switch v.(type) {
+	case Address:
+		return typ == "address"
+	case Word:
+		return typ == "bytes32"
+	case U256:
+		return isUint
+	case bool:
+		return typ == "bool"
+	case uint8:
+		return isUint && bits <= 8
+	case uint16:
+		return isUint && bits <= 16
+	case uint32:
+		return isUint && bits <= 32
+	case uint64:
+		return isUint && bits <= 64
+	case []byte:
+		return typ == "bytes"
+	case string:
+		return typ == "string"
+	}
+	return false
+}
+
+// uintBits returns the width of a uintN type.
+func uintBits(typ string) (int, bool) {
+	if !strings.HasPrefix(typ, "uint") || len(typ) == 4 || len(typ) > 7 {
+		return 0, false
+	}
+	bits := 0
+	for _, c := range typ[4:] {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		bits = bits*10 + int(c-'0')
+	}
+	return bits, bits > 0 && bits <= 256 && bits%8 == 0
+}
+
+func goTypeName(v any) string {
+	switch v.(type) {
+	case Address:
+		return "Address"
+	case Word:
+		return "Word"
+	case U256:
+		return "U256"
+	case bool:
+		return "bool"
+	case uint8:
+		return "uint8"
+	case uint16:
+		return "uint16"
+	case uint32:
+		return "uint32"
+	case uint64:
+		return "uint64"
+	case []byte:
+		return "[]byte"
+	case string:
+		return "string"
+	}
+	return "an unsupported type"
+}
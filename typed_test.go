@@ -0,0 +1,118 @@
+package stygos
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHandleTyped(t *testing.T) {
+	var gotTo Address
+	var gotAmount U256
+	balances := map[Address]uint64{{0xb0}: 12}
+	router := NewRouter()
+	router.HandleTyped("transfer(address,uint256)", Func2(func(ctx *Ctx, to Address, amount U256) error {
+		gotTo, gotAmount = to, amount
+		return nil
+	}))
+	router.HandleTyped("balanceOf(address)", Func1R(func(ctx *Ctx, owner Address) (uint64, error) {
+		return balances[owner], nil
+	}))
+	router.HandleTyped("name()", Func0R(func(ctx *Ctx) (string, error) {
+		return "Stygian", nil
+	}))
+	router.HandleTyped("echo(bytes,uint8,bool)", Func3R(func(ctx *Ctx, data []byte, n uint8, flag bool) ([]byte, error) {
+		if flag {
+			return append(data, n), nil
+		}
+		return data, nil
+	}))
+
+	to := Address{19: 0x42}
+	amount := U256FromWord(WordFromUint64(1000))
+	call := abiCall("transfer(address,uint256)", PadAddress(to), amount.Word())
+	if _, err := router.DispatchCtx(&Ctx{}, call); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if gotTo != to || gotAmount != amount {
+		t.Errorf("transfer(%x, %v), want (%x, %v)", gotTo, gotAmount, to, amount)
+	}
+
+	out, err := router.DispatchCtx(&Ctx{}, abiCall("balanceOf(address)", PadAddress(Address{0xb0})))
+	if err != nil || !bytes.Equal(out, appendWord(nil, WordFromUint64(12))) {
+		t.Errorf("balanceOf = %x, %v, want 12", out, err)
+	}
+
+	out, err = router.DispatchCtx(&Ctx{}, abiCall("name()"))
+	if err != nil || !bytes.Equal(out, encodeDynamic([]byte("Stygian"))) {
+		t.Errorf("name = %x, %v, want Stygian", out, err)
+	}
+
+	// Dynamic arguments are read through their offsets
+	data := []byte("hello")
+	var tail Word
+	copy(tail[:], data)
+	call = abiCall("echo(bytes,uint8,bool)", WordFromUint64(96), WordFromUint64(7), WordFromUint64(1), WordFromUint64(5), tail)
+	out, err = router.DispatchCtx(&Ctx{}, call)
+	if err != nil || !bytes.Equal(out, encodeDynamic([]byte("hello\x07"))) {
+		t.Errorf("echo = %x, %v, want hello 7", out, err)
+	}
+}
+
+func TestHandleTypedRejects(t *testing.T) {
+	router := NewRouter()
+	router.HandleTyped("set(uint8,bool,address)", Func3(func(ctx *Ctx, n uint8, flag bool, a Address) error { return nil }))
+	router.HandleTyped("data(bytes)", Func1(func(ctx *Ctx, data []byte) error { return nil }))
+
+	valid := []Word{WordFromUint64(255), WordFromUint64(1), PadAddress(Address{1})}
+	if _, err := router.DispatchCtx(&Ctx{}, abiCall("set(uint8,bool,address)", valid...)); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	tests := map[string][]byte{
+		"short":          abiCall("set(uint8,bool,address)", valid[:2]...),
+		"uint8 overflow": abiCall("set(uint8,bool,address)", WordFromUint64(256), valid[1], valid[2]),
+		"bool not 0/1":   abiCall("set(uint8,bool,address)", valid[0], WordFromUint64(2), valid[2]),
+		"dirty address":  abiCall("set(uint8,bool,address)", valid[0], valid[1], Word{0: 1, 31: 1}),
+		"bad offset":     abiCall("data(bytes)", WordFromUint64(64)),
+		"long length":    abiCall("data(bytes)", WordFromUint64(32), WordFromUint64(33), Word{}),
+	}
+	for name, call := range tests {
+		if _, err := router.DispatchCtx(&Ctx{}, call); err != ErrInvalidInput {
+			t.Errorf("%s: got %v, want ErrInvalidInput", name, err)
+		}
+	}
+}
+
+func TestHandleTypedSignatureMismatch(t *testing.T) {
+	tests := []struct {
+		signature string
+		h         TypedHandler
+		want      string
+	}{
+		{"f(address)", Func2(func(*Ctx, Address, U256) error { return nil }), "does not take 2 arguments"},
+		{"f(uint256)", Func1(func(*Ctx, uint64) error { return nil }), "cannot be decoded as uint64"},
+		{"f(bytes32)", Func1(func(*Ctx, Address) error { return nil }), "cannot be decoded as Address"},
+		{"f((uint256,bool))", Func1(func(*Ctx, U256) error { return nil }), "does not take 1 arguments"},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if r, _ := recover().(string); !strings.Contains(r, tt.want) {
+					t.Errorf("HandleTyped(%s) panicked with %q, want %q", tt.signature, r, tt.want)
+				}
+			}()
+			NewRouter().HandleTyped(tt.signature, tt.h)
+		}()
+	}
+}
+
+// abiCall returns the calldata of signature with the given head and tail
+// words.
+func abiCall(signature string, words ...Word) []byte {
+	sel := SelectorOf(signature)
+	out := append([]byte(nil), sel[:]...)
+	for _, w := range words {
+		out = appendWord(out, w)
+	}
+	return out
+}
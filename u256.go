@@ -0,0 +1,279 @@
+package stygos
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// U256 is an unsigned 256-bit integer stored as four 64-bit limbs, least
+// significant first. Arithmetic wraps modulo 2^256 like the EVM and never
+// allocates, which makes it much cheaper than big.Int under TinyGo.
+type U256 [4]uint64
+
+// NewU256 returns v as a U256.
+func NewU256(v uint64) U256 {
+	return U256{v, 0, 0, 0}
+}
+
+// U256FromWord interprets a big-endian word as a U256.
+func U256FromWord(w Word) U256 {
+	return U256{
+		binary.BigEndian.Uint64(w[24:32]),
+		binary.BigEndian.Uint64(w[16:24]),
+		binary.BigEndian.Uint64(w[8:16]),
+		binary.BigEndian.Uint64(w[0:8]),
+	}
+}
+
+// U256FromBig converts a non-negative big.Int, truncating values wider than
+// 256 bits like WordFromBigInt.
+func U256FromBig(v *big.Int) U256 {
+	return U256FromWord(WordFromBigInt(v))
+}
+
+// Word returns the big-endian word representation of z.
+func (z U256) Word() Word {
+	var w Word
+	binary.BigEndian.PutUint64(w[0:8], z[3])
+	binary.BigEndian.PutUint64(w[8:16], z[2])
+	binary.BigEndian.PutUint64(w[16:24], z[1])
+	binary.BigEndian.PutUint64(w[24:32], z[0])
+	return w
+}
+
+// Big returns z as a big.Int.
+func (z U256) Big() *big.Int {
+	w := z.Word()
+	return new(big.Int).SetBytes(w[:])
+}
+
+// Uint64 returns the low 64 bits of z.
+func (z U256) Uint64() uint64 {
+	return z[0]
+}
+
+// IsUint64 reports whether z fits in a uint64.
+func (z U256) IsUint64() bool {
+	return z[1]|z[2]|z[3] == 0
+}
+
+// IsZero reports whether z is zero.
+func (z U256) IsZero() bool {
+	return z[0]|z[1]|z[2]|z[3] == 0
+}
+
+// Cmp returns -1, 0 or +1 depending on whether z is less than, equal to or
+// greater than x.
+func (z U256) Cmp(x U256) int {
+	for i := 3; i >= 0; i-- {
+		if z[i] < x[i] {
+			return -1
+		}
+		if z[i] > x[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Lt reports whether z < x.
+func (z U256) Lt(x U256) bool {
+	return z.Cmp(x) < 0
+}
+
+// Gt reports whether z > x.
+func (z U256) Gt(x U256) bool {
+	return z.Cmp(x) > 0
+}
+
+// Add returns z + x modulo 2^256.
+func (z U256) Add(x U256) U256 {
+	var r U256
+	var carry uint64
+	r[0], carry = bits.Add64(z[0], x[0], 0)
+	r[1], carry = bits.Add64(z[1], x[1], carry)
+	r[2], carry = bits.Add64(z[2], x[2], carry)
+	r[3], _ = bits.Add64(z[3], x[3], carry)
+	return r
+}
+
+// Sub returns z - x modulo 2^256.
+func (z U256) Sub(x U256) U256 {
+	var r U256
+	var borrow uint64
+	r[0], borrow = bits.Sub64(z[0], x[0], 0)
+	r[1], borrow = bits.Sub64(z[1], x[1], borrow)
+	r[2], borrow = bits.Sub64(z[2], x[2], borrow)
+	r[3], _ = bits.Sub64(z[3], x[3], borrow)
+	return r
+}
+
+// Mul returns z * x modulo 2^256.
+func (z U256) Mul(x U256) U256 {
+	var r U256
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; i+j < 4; j++ {
+			hi, lo := bits.Mul64(z[i], x[j])
+			lo, c := bits.Add64(lo, r[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r[i+j] = lo
+			carry = hi
+		}
+	}
+	return r
+}
+
+// Div returns z / x, or zero when x is zero (the EVM convention).
+func (z U256) Div(x U256) U256 {
+	q, _ := z.divMod(x)
+	return q
+}
+
+// Mod returns z % x, or zero when x is zero (the EVM convention).
+func (z U256) Mod(x U256) U256 {
+	_, r := z.divMod(x)
+	return r
+}
+
+// divMod implements schoolbook binary long division. It is only used for
+// operands that do not fit in 64 bits, which are rare in contract code.
+func (z U256) divMod(x U256) (U256, U256) {
+	if x.IsZero() || z.Lt(x) {
+		if x.IsZero() {
+			return U256{}, U256{}
+		}
+		return U256{}, z
+	}
+	if z.IsUint64() && x.IsUint64() {
+		return NewU256(z[0] / x[0]), NewU256(z[0] % x[0])
+	}
+
+	var q, r U256
+	for i := z.BitLen() - 1; i >= 0; i-- {
+		r = r.Lsh(1)
+		r[0] |= (z[i/64] >> (uint(i) % 64)) & 1
+		if !r.Lt(x) {
+			r = r.Sub(x)
+			q[i/64] |= 1 << (uint(i) % 64)
+		}
+	}
+	return q, r
+}
+
+// BitLen returns the number of bits needed to represent z.
+func (z U256) BitLen() int {
+	for i := 3; i >= 0; i-- {
+		if z[i] != 0 {
+			return i*64 + bits.Len64(z[i])
+		}
+	}
+	return 0
+}
+
+// Lsh returns z << n.
+func (z U256) Lsh(n uint) U256 {
+	if n >= 256 {
+		return U256{}
+	}
+	var r U256
+	limbs, shift := int(n/64), n%64
+	for i := 3; i >= limbs; i-- {
+		r[i] = z[i-limbs] << shift
+		if shift != 0 && i-limbs-1 >= 0 {
+			r[i] |= z[i-limbs-1] >> (64 - shift)
+		}
+	}
+	return r
+}
+
+// Rsh returns z >> n.
+func (z U256) Rsh(n uint) U256 {
+	if n >= 256 {
+		return U256{}
+	}
+	var r U256
+	limbs, shift := int(n/64), n%64
+	for i := 0; i+limbs < 4; i++ {
+		r[i] = z[i+limbs] >> shift
+		if shift != 0 && i+limbs+1 < 4 {
+			r[i] |= z[i+limbs+1] << (64 - shift)
+		}
+	}
+	return r
+}
+
+// And returns z & x.
+func (z U256) And(x U256) U256 {
+	return U256{z[0] & x[0], z[1] & x[1], z[2] & x[2], z[3] & x[3]}
+}
+
+// Or returns z | x.
+func (z U256) Or(x U256) U256 {
+	return U256{z[0] | x[0], z[1] | x[1], z[2] | x[2], z[3] | x[3]}
+}
+
+// Xor returns z ^ x.
+func (z U256) Xor(x U256) U256 {
+	return U256{z[0] ^ x[0], z[1] ^ x[1], z[2] ^ x[2], z[3] ^ x[3]}
+}
+
+// Not returns ^z.
+func (z U256) Not() U256 {
+	return U256{^z[0], ^z[1], ^z[2], ^z[3]}
+}
+
+// Append appends the decimal representation of z to dst, like
+// big.Int.Append with base 10 but without allocating.
+func (z U256) Append(dst []byte) []byte {
+	if z.IsUint64() {
+		return appendUint64(dst, z[0])
+	}
+	// Peel off base 10^19 chunks, the largest power of ten in a limb,
+	// least significant first
+	const chunk = 10000000000000000000
+	var chunks [5]uint64
+	n := 0
+	for !z.IsZero() {
+		var rem uint64
+		for i := 3; i >= 0; i-- {
+			z[i], rem = bits.Div64(rem, z[i], chunk)
+		}
+		chunks[n] = rem
+		n++
+	}
+	dst = appendUint64(dst, chunks[n-1])
+	for i := n - 2; i >= 0; i-- {
+		var digits [19]byte
+		v := chunks[i]
+		for j := len(digits) - 1; j >= 0; j-- {
+			digits[j] = byte('0' + v%10)
+			v /= 10
+		}
+		dst = append(dst, digits[:]...)
+	}
+	return dst
+}
+
+// String returns the decimal representation of z.
+func (z U256) String() string {
+	var buf [78]byte
+	return string(z.Append(buf[:0]))
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	var digits [20]byte
+	i := len(digits)
+	for {
+		i--
+		digits[i] = byte('0' + v%10)
+		v /= 10
+		if v == 0 {
+			break
+		}
+	}
+	return append(dst, digits[i:]...)
+}
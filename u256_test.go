@@ -0,0 +1,97 @@
+package stygos
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// randU256 returns a random value with a random bit length, so both small
+// and full-width operands are exercised.
+func randU256(rng *rand.Rand) U256 {
+	z := U256{rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64()}
+	return z.Rsh(uint(rng.Intn(256)))
+}
+
+func TestU256Arithmetic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	mod := new(big.Int).Lsh(big.NewInt(1), 256)
+
+	for i := 0; i < 2000; i++ {
+		x, y := randU256(rng), randU256(rng)
+		bx, by := x.Big(), y.Big()
+
+		check := func(op string, got U256, want *big.Int) {
+			want.Mod(want, mod)
+			if got.Big().Cmp(want) != 0 {
+				t.Fatalf("%x %s %x = %x, want %x", bx, op, by, got.Big(), want)
+			}
+		}
+
+		check("+", x.Add(y), new(big.Int).Add(bx, by))
+		check("-", x.Sub(y), new(big.Int).Sub(bx, by))
+		check("*", x.Mul(y), new(big.Int).Mul(bx, by))
+		if !y.IsZero() {
+			check("/", x.Div(y), new(big.Int).Div(bx, by))
+			check("%", x.Mod(y), new(big.Int).Mod(bx, by))
+		}
+
+		n := uint(rng.Intn(300))
+		check("<<", x.Lsh(n), new(big.Int).Lsh(bx, n))
+		check(">>", x.Rsh(n), new(big.Int).Rsh(bx, n))
+		check("&", x.And(y), new(big.Int).And(bx, by))
+		check("|", x.Or(y), new(big.Int).Or(bx, by))
+		check("^", x.Xor(y), new(big.Int).Xor(bx, by))
+
+		if x.Cmp(y) != bx.Cmp(by) {
+			t.Fatalf("Cmp(%x, %x) = %d, want %d", bx, by, x.Cmp(y), bx.Cmp(by))
+		}
+		if x.BitLen() != bx.BitLen() {
+			t.Fatalf("BitLen(%x) = %d, want %d", bx, x.BitLen(), bx.BitLen())
+		}
+	}
+}
+
+func TestU256Conversions(t *testing.T) {
+	v := new(big.Int).Lsh(big.NewInt(0x1234), 200)
+	z := U256FromBig(v)
+	if z.Big().Cmp(v) != 0 {
+		t.Errorf("U256FromBig round trip = %x, want %x", z.Big(), v)
+	}
+	if U256FromWord(z.Word()) != z {
+		t.Errorf("Word round trip failed for %x", v)
+	}
+	if w := NewU256(42).Word(); Uint64FromWord(w) != 42 {
+		t.Errorf("NewU256(42).Word() = %x", w)
+	}
+	if NewU256(1).Div(U256{}) != (U256{}) || NewU256(1).Mod(U256{}) != (U256{}) {
+		t.Errorf("division by zero does not yield zero")
+	}
+	if !NewU256(7).IsUint64() || z.IsUint64() {
+		t.Errorf("IsUint64 mismatch")
+	}
+	if NewU256(0).Not().Add(NewU256(1)) != (U256{}) {
+		t.Errorf("max + 1 does not wrap to zero")
+	}
+}
+
+func TestU256String(t *testing.T) {
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	tests := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(42),
+		new(big.Int).SetUint64(1<<64 - 1),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(19), nil),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(38), nil),
+		new(big.Int).Lsh(big.NewInt(0x1234), 200),
+		max,
+	}
+	for _, v := range tests {
+		if got := U256FromBig(v).String(); got != v.String() {
+			t.Errorf("String = %s, want %s", got, v)
+		}
+	}
+	if got := string(NewU256(7).Append([]byte("x="))); got != "x=7" {
+		t.Errorf("Append = %q, want %q", got, "x=7")
+	}
+}
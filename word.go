@@ -0,0 +1,67 @@
+package stygos
+
+// Word arithmetic treats a word as a big-endian unsigned 256-bit integer,
+// like the EVM, and wraps modulo 2^256. The methods go through U256 and
+// never allocate.
+
+// Add returns w + x.
+func (w Word) Add(x Word) Word {
+	return U256FromWord(w).Add(U256FromWord(x)).Word()
+}
+
+// Sub returns w - x.
+func (w Word) Sub(x Word) Word {
+	return U256FromWord(w).Sub(U256FromWord(x)).Word()
+}
+
+// And returns the bitwise w & x.
+func (w Word) And(x Word) Word {
+	for i := range w {
+		w[i] &= x[i]
+	}
+	return w
+}
+
+// Or returns the bitwise w | x.
+func (w Word) Or(x Word) Word {
+	for i := range w {
+		w[i] |= x[i]
+	}
+	return w
+}
+
+// Xor returns the bitwise w ^ x.
+func (w Word) Xor(x Word) Word {
+	for i := range w {
+		w[i] ^= x[i]
+	}
+	return w
+}
+
+// Lsh returns w << n.
+func (w Word) Lsh(n uint) Word {
+	return U256FromWord(w).Lsh(n).Word()
+}
+
+// Rsh returns w >> n.
+func (w Word) Rsh(n uint) Word {
+	return U256FromWord(w).Rsh(n).Word()
+}
+
+// IsZero reports whether every byte of w is zero.
+func (w Word) IsZero() bool {
+	return w == Word{}
+}
+
+// Cmp compares w and x as unsigned integers and returns -1, 0 or +1.
+func (w Word) Cmp(x Word) int {
+	for i := range w {
+		switch {
+		case w[i] < x[i]:
+			return -1
+		case w[i] > x[i]:
+			return 1
+		}
+	}
+	return 0
+}
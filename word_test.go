@@ -0,0 +1,57 @@
+package stygos
+
+import "testing"
+
+func TestWordArithmetic(t *testing.T) {
+	one := WordFromUint64(1)
+	allOnes := Word{}.Sub(one)
+	for i, b := range allOnes {
+		if b != 0xff {
+			t.Fatalf("Sub failed. Expected 0 - 1 to wrap to all ones, byte %d is %x", i, b)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		got, want Word
+	}{
+		{"Add", WordFromUint64(0xff).Add(one), WordFromUint64(0x100)},
+		{"Add wraps", allOnes.Add(one), Word{}},
+		{"Sub", WordFromUint64(0x100).Sub(one), WordFromUint64(0xff)},
+		{"And", WordFromUint64(0b1100).And(WordFromUint64(0b1010)), WordFromUint64(0b1000)},
+		{"Or", WordFromUint64(0b1100).Or(WordFromUint64(0b1010)), WordFromUint64(0b1110)},
+		{"Xor", WordFromUint64(0b1100).Xor(WordFromUint64(0b1010)), WordFromUint64(0b0110)},
+		{"Lsh", one.Lsh(255), Word{0x80}},
+		{"Lsh out", one.Lsh(256), Word{}},
+		{"Rsh", Word{0x80}.Rsh(255), one},
+		{"Rsh across limbs", Word{0x12, 0x34}.Rsh(240), WordFromUint64(0x1234)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s failed. Expected %x, got %x", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestWordCmp(t *testing.T) {
+	tests := []struct {
+		a, b Word
+		want int
+	}{
+		{Word{}, Word{}, 0},
+		{WordFromUint64(1), WordFromUint64(2), -1},
+		{Word{0x01}, WordFromUint64(^uint64(0)), 1},
+		{WordFromUint64(7), WordFromUint64(7), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Cmp(tt.b); got != tt.want {
+			t.Errorf("Cmp(%x, %x) failed. Expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+		if got := U256FromWord(tt.a).Cmp(U256FromWord(tt.b)); got != tt.want {
+			t.Errorf("Cmp(%x, %x) failed. Expected U256.Cmp to agree, got %d", tt.a, tt.b, got)
+		}
+	}
+	if !(Word{}).IsZero() || WordFromUint64(1).IsZero() {
+		t.Error("IsZero failed")
+	}
+}
@@ -0,0 +1,206 @@
+package xchain
+
+import (
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+)
+
+// ErrFeeTooLow is returned by ArbitrumToChild when the fee does not cover
+// the gas of the auto-redeem.
+var ErrFeeTooLow = errors.New("xchain: fee does not cover the retryable gas")
+
+// Selectors of deliveries and bridge calls
+var (
+	ReceiveSelector   = stygos.Selector{0xf9, 0x53, 0xce, 0xc7} // receiveMessage(bytes)
+	HyperlaneSelector = stygos.Selector{0x56, 0xd5, 0xd4, 0x75} // handle(uint32,bytes32,bytes)
+
+	selSendMessage = stygos.Selector{0x82, 0x64, 0x6a, 0x58} // sendMessage(bytes)
+	selDispatch    = stygos.Selector{0xfa, 0x31, 0xde, 0x01} // dispatch(uint32,bytes32,bytes)
+)
+
+// Delivery returns the calldata that delivers msg to its receiver,
+// receiveMessage(bytes) with the encoded message. Contracts route
+// ReceiveSelector to a handler calling Endpoint.Receive.
+func Delivery(msg *Message) []byte {
+	return bytesCall(ReceiveSelector, msg.Encode())
+}
+
+// Outbox is a Transport calling sendMessage(bytes) with the encoded
+// message on a bridge contract, which relays it to the destination inbox.
+type Outbox stygos.Address
+
+// Send implements Transport.
+func (o Outbox) Send(msg *Message, fee stygos.U256) error {
+	_, err := stygos.Call(stygos.Address(o), fee.Word(), bytesCall(selSendMessage, msg.Encode()))
+	return err
+}
+
+// Inbox is an Authenticator trusting a bridge contract that delivers
+// messages by calling receiveMessage(bytes) itself, after verifying them
+// on its own.
+type Inbox stygos.Address
+
+// Authenticate implements Authenticator.
+func (i Inbox) Authenticate(args []byte) (Message, error) {
+	if stygos.GetMsgSender() != stygos.Address(i) {
+		return Message{}, ErrUnauthenticated
+	}
+	return decodeDelivery(args)
+}
+
+// ArbitrumToParent is a Transport sending messages from an Arbitrum chain
+// to its parent chain through ArbSys.sendTxToL1. Once the assertion is
+// confirmed, anyone executes the message on the parent chain's Outbox,
+// which calls the receiver with the Delivery calldata.
+type ArbitrumToParent struct{}
+
+// Send implements Transport.
+func (ArbitrumToParent) Send(msg *Message, fee stygos.U256) error {
+	_, err := arb.SendTxToL1(msg.Receiver, fee, Delivery(msg))
+	return err
+}
+
+// ArbitrumToChild is a Transport sending messages to a child chain, such
+// as an Orbit chain settling on this one, as retryable tickets through
+// its delayed inbox. The fee pays GasLimit*MaxFeePerGas for the
+// auto-redeem, and the rest is the submission cost; unused fees go to
+// RefundAddress.
+type ArbitrumToChild struct {
+	Inbox         stygos.Address
+	GasLimit      uint64
+	MaxFeePerGas  stygos.U256
+	RefundAddress stygos.Address
+}
+
+// Send implements Transport.
+func (a ArbitrumToChild) Send(msg *Message, fee stygos.U256) error {
+	gas := stygos.NewU256(a.GasLimit).Mul(a.MaxFeePerGas)
+	if fee.Lt(gas) {
+		return ErrFeeTooLow
+	}
+	_, err := arb.CreateRetryableTicket(a.Inbox, arb.RetryableTicket{
+		To:                     msg.Receiver,
+		MaxSubmissionCost:      fee.Sub(gas),
+		ExcessFeeRefundAddress: a.RefundAddress,
+		CallValueRefundAddress: a.RefundAddress,
+		GasLimit:               a.GasLimit,
+		MaxFeePerGas:           a.MaxFeePerGas,
+		Data:                   Delivery(msg),
+	})
+	return err
+}
+
+// ArbitrumFromParent is an Authenticator for messages sent by
+// ArbitrumToChild: retryable tickets execute with the aliased address of
+// the parent chain contract as the caller, which must be the message's
+// sender. ParentChain is the chain id of the parent.
+type ArbitrumFromParent struct {
+	ParentChain uint64
+}
+
+// Authenticate implements Authenticator.
+func (a ArbitrumFromParent) Authenticate(args []byte) (Message, error) {
+	msg, err := decodeDelivery(args)
+	if err != nil {
+		return Message{}, err
+	}
+	if msg.SourceChain != a.ParentChain || arb.RequireL1Sender(msg.Sender) != nil {
+		return Message{}, ErrUnauthenticated
+	}
+	return msg, nil
+}
+
+// Hyperlane is a Transport dispatching messages through a Hyperlane
+// Mailbox, with chain ids as Hyperlane domains, as for most EVM chains.
+// The fee must cover the Mailbox's quoteDispatch.
+type Hyperlane stygos.Address
+
+// Send implements Transport.
+func (h Hyperlane) Send(msg *Message, fee stygos.U256) error {
+	data := bytesCall(selDispatch, msg.Encode(), stygos.WordFromUint64(msg.DestChain), stygos.PadAddress(msg.Receiver))
+	_, err := stygos.Call(stygos.Address(h), fee.Word(), data)
+	return err
+}
+
+// HyperlaneMailbox is an Authenticator for deliveries by a Hyperlane
+// Mailbox, which calls handle(uint32,bytes32,bytes) with the origin
+// domain and sender it verified; contracts route HyperlaneSelector to
+// Endpoint.Receive. The message must agree with both.
+type HyperlaneMailbox stygos.Address
+
+// Authenticate implements Authenticator.
+func (h HyperlaneMailbox) Authenticate(args []byte) (Message, error) {
+	if stygos.GetMsgSender() != stygos.Address(h) || len(args) < 64 {
+		return Message{}, ErrUnauthenticated
+	}
+	var origin, sender stygos.Word
+	copy(origin[:], args[:32])
+	copy(sender[:], args[32:64])
+	body, err := decodeBytes(args, 2)
+	if err != nil {
+		return Message{}, err
+	}
+	msg, err := DecodeMessage(body)
+	if err != nil {
+		return Message{}, err
+	}
+	if origin != stygos.WordFromUint64(msg.SourceChain) || sender != stygos.PadAddress(msg.Sender) {
+		return Message{}, ErrUnauthenticated
+	}
+	return msg, nil
+}
+
+// decodeDelivery decodes the arguments of receiveMessage(bytes).
+func decodeDelivery(args []byte) (Message, error) {
+	body, err := decodeBytes(args, 0)
+	if err != nil {
+		return Message{}, err
+	}
+	return DecodeMessage(body)
+}
+
+// bytesCall returns the calldata of sel with the static head words
+// followed by one bytes argument b.
+func bytesCall(sel stygos.Selector, b []byte, head ...stygos.Word) []byte {
+	data := append([]byte(nil), sel[:]...)
+	for _, w := range head {
+		data = append(data, w[:]...)
+	}
+	offset := stygos.WordFromUint64(uint64(32 * (len(head) + 1)))
+	length := stygos.WordFromUint64(uint64(len(b)))
+	data = append(data, offset[:]...)
+	data = append(data, length[:]...)
+	data = append(data, b...)
+	if pad := len(b) % 32; pad != 0 {
+		data = append(data, make([]byte, 32-pad)...)
+	}
+	return data
+}
+
+// decodeBytes returns the dynamic bytes argument whose offset is in head
+// word i of args.
+func decodeBytes(args []byte, i int) ([]byte, error) {
+	if len(args) < 32*(i+1) {
+		return nil, ErrBadMessage
+	}
+	var w stygos.Word
+	copy(w[:], args[32*i:])
+	if !stygos.U256FromWord(w).IsUint64() {
+		return nil, ErrBadMessage
+	}
+	offset := stygos.Uint64FromWord(w)
+	if offset > uint64(len(args)) || uint64(len(args))-offset < 32 {
+		return nil, ErrBadMessage
+	}
+	copy(w[:], args[offset:])
+	if !stygos.U256FromWord(w).IsUint64() {
+		return nil, ErrBadMessage
+	}
+	n := stygos.Uint64FromWord(w)
+	if n > uint64(len(args))-offset-32 {
+		return nil, ErrBadMessage
+	}
+	return args[offset+32 : offset+32+n], nil
+}
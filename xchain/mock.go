@@ -0,0 +1,77 @@
+//go:build !tinygo
+
+package xchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rafaelescrich/stygos"
+)
+
+// MockBridge connects mock runtimes standing for different chains, keyed
+// by their Chain ids. It is deployed at the same address on each of them,
+// where it serves sendMessage(bytes) for the Outbox transport and queues
+// the messages until Relay delivers them as the caller expected by Inbox.
+type MockBridge struct {
+	Address stygos.Address
+	Pending []Message // sent and not yet delivered, in order
+
+	chains map[uint64]*stygos.MockRuntime
+}
+
+// NewMockBridge returns a bridge at addr with no chains.
+func NewMockBridge(addr stygos.Address) *MockBridge {
+	return &MockBridge{Address: addr, chains: make(map[uint64]*stygos.MockRuntime)}
+}
+
+// AddChain deploys the bridge on rt, the chain with id rt.Chain.
+func (b *MockBridge) AddChain(rt *stygos.MockRuntime) {
+	chain := rt.Chain
+	b.chains[chain] = rt
+	rt.Deploy(b.Address, func(input []byte) ([]byte, error) {
+		if len(input) < 4 || !bytes.Equal(input[:4], selSendMessage[:]) {
+			return nil, stygos.ErrInvalidInput
+		}
+		msg, err := decodeDelivery(input[4:])
+		if err != nil {
+			return nil, err
+		}
+		if msg.SourceChain != chain || msg.Sender != stygos.GetMsgSender() {
+			return nil, ErrUnauthenticated
+		}
+		b.Pending = append(b.Pending, msg)
+		return nil, nil
+	})
+}
+
+// Relay delivers the pending messages in order, stopping at the first
+// delivery that reverts, which stays pending.
+func (b *MockBridge) Relay() error {
+	for len(b.Pending) > 0 {
+		if err := b.Deliver(b.Pending[0]); err != nil {
+			return err
+		}
+		b.Pending = b.Pending[1:]
+	}
+	return nil
+}
+
+// Deliver calls the receiver of msg on its destination chain with the
+// Delivery calldata, as the bridge, whether or not msg was sent. Tests
+// use it to replay or forge messages. The runtime's Contract must be an
+// account other than the receiver, as when tests drive deployed
+// contracts. Deliver leaves the destination runtime in use, so tests
+// select their runtime again afterwards.
+func (b *MockBridge) Deliver(msg Message) error {
+	rt, ok := b.chains[msg.DestChain]
+	if !ok {
+		return fmt.Errorf("xchain: mock bridge has no chain %d", msg.DestChain)
+	}
+	stygos.UseRuntime(rt)
+	caller := rt.Contract
+	rt.Contract = b.Address
+	defer func() { rt.Contract = caller }()
+	_, err := stygos.Call(msg.Receiver, stygos.Word{}, Delivery(&msg))
+	return err
+}
@@ -0,0 +1,199 @@
+// Package xchain lets contracts exchange messages with their peers on
+// other chains without depending on one bridge.
+//
+// An Endpoint numbers outgoing messages per destination chain and hands
+// them to a Transport, and accepts incoming ones through an
+// Authenticator, which checks that the bridge vouches for the source
+// chain and sender. The endpoint then only accepts messages from the
+// peer registered for that chain, each once. Transports and
+// authenticators exist for a generic outbox and inbox, for Arbitrum's
+// native messaging and for Hyperlane; MockBridge connects mock runtimes
+// standing for different chains in tests.
+package xchain
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/storage"
+)
+
+// Cross-chain errors
+var (
+	ErrBadMessage       = errors.New("xchain: malformed message")
+	ErrUnknownPeer      = errors.New("xchain: no peer for chain")
+	ErrUntrustedSender  = errors.New("xchain: sender is not the peer of its chain")
+	ErrWrongDestination = errors.New("xchain: message is for another chain or contract")
+	ErrReplayed         = errors.New("xchain: message already received")
+	ErrUnauthenticated  = errors.New("xchain: delivery not vouched for by the bridge")
+)
+
+// Event signatures
+var (
+	MessageSentTopic     = stygos.Keccak256([]byte("MessageSent(bytes32,uint64,uint64)"))
+	MessageReceivedTopic = stygos.Keccak256([]byte("MessageReceived(bytes32,uint64,uint64)"))
+)
+
+// HeaderLength is the length of an encoded message without its payload.
+const HeaderLength = 8 + 20 + 8 + 20 + 8
+
+// Message is a message between contracts on two chains.
+type Message struct {
+	SourceChain uint64
+	Sender      stygos.Address // contract on the source chain
+	DestChain   uint64
+	Receiver    stygos.Address // contract on the destination chain
+	Nonce       uint64         // per source contract and destination chain
+	Payload     []byte
+}
+
+// Encode returns the message packed as its fields in order, with the
+// chain ids and nonce as 8-byte big-endian integers.
+func (m *Message) Encode() []byte {
+	out := make([]byte, HeaderLength, HeaderLength+len(m.Payload))
+	binary.BigEndian.PutUint64(out, m.SourceChain)
+	copy(out[8:], m.Sender[:])
+	binary.BigEndian.PutUint64(out[28:], m.DestChain)
+	copy(out[36:], m.Receiver[:])
+	binary.BigEndian.PutUint64(out[56:], m.Nonce)
+	return append(out, m.Payload...)
+}
+
+// ID returns the keccak256 of the encoded message.
+func (m *Message) ID() stygos.Word {
+	return stygos.Keccak256(m.Encode())
+}
+
+// DecodeMessage parses an encoded message.
+func DecodeMessage(b []byte) (Message, error) {
+	var m Message
+	if len(b) < HeaderLength {
+		return m, ErrBadMessage
+	}
+	m.SourceChain = binary.BigEndian.Uint64(b)
+	copy(m.Sender[:], b[8:28])
+	m.DestChain = binary.BigEndian.Uint64(b[28:])
+	copy(m.Receiver[:], b[36:56])
+	m.Nonce = binary.BigEndian.Uint64(b[56:])
+	m.Payload = append([]byte(nil), b[HeaderLength:]...)
+	return m, nil
+}
+
+// Transport hands messages to a bridge. fee is the value sent along to
+// pay the bridge, and must be covered by the contract's balance.
+type Transport interface {
+	Send(msg *Message, fee stygos.U256) error
+}
+
+// Authenticator is the source-chain authentication hook. It returns the
+// message carried by the calldata arguments of a delivery, made by the
+// current caller, after checking that the bridge vouches for its source
+// chain and sender.
+type Authenticator interface {
+	Authenticate(args []byte) (Message, error)
+}
+
+// Endpoint sends and receives a contract's cross-chain messages.
+//
+// Storage layout relative to the base slot:
+//
+//	MapKey(base, chain)              peer contract on chain
+//	MapKey(Offset(base, 1), chain)   next nonce of messages to chain
+//	MapKey(Offset(base, 2), id)      1 if message id was received
+type Endpoint struct {
+	peers     stygos.Word
+	nonces    stygos.Word
+	received  stygos.Word
+	transport Transport
+	auth      Authenticator
+}
+
+// NewEndpoint returns the endpoint rooted at base, sending with transport
+// and receiving through auth.
+func NewEndpoint(base stygos.Word, transport Transport, auth Authenticator) *Endpoint {
+	return &Endpoint{
+		peers:     base,
+		nonces:    storage.Offset(base, 1),
+		received:  storage.Offset(base, 2),
+		transport: transport,
+		auth:      auth,
+	}
+}
+
+// SetPeer sets the contract on chain that messages are sent to and
+// accepted from; the zero address removes it. Contracts guard it with
+// their own access control.
+func (e *Endpoint) SetPeer(chain uint64, peer stygos.Address) {
+	stygos.StorageStore(e.chainKey(e.peers, chain), stygos.PadAddress(peer))
+}
+
+// Peer returns the peer on chain, or the zero address.
+func (e *Endpoint) Peer(chain uint64) stygos.Address {
+	return stygos.AddressFromWord(stygos.StorageLoad(e.chainKey(e.peers, chain)))
+}
+
+// NextNonce returns the nonce of the next message to chain.
+func (e *Endpoint) NextNonce(chain uint64) uint64 {
+	return stygos.Uint64FromWord(stygos.StorageLoad(e.chainKey(e.nonces, chain)))
+}
+
+// Send sends payload to the peer on destChain, paying the bridge fee, and
+// returns the message.
+func (e *Endpoint) Send(destChain uint64, payload []byte, fee stygos.U256) (Message, error) {
+	peer := e.Peer(destChain)
+	if peer == (stygos.Address{}) {
+		return Message{}, ErrUnknownPeer
+	}
+	nonce := e.NextNonce(destChain)
+	stygos.StorageStore(e.chainKey(e.nonces, destChain), stygos.WordFromUint64(nonce+1))
+	msg := Message{
+		SourceChain: stygos.GetChainID(),
+		Sender:      stygos.GetContractAddress(),
+		DestChain:   destChain,
+		Receiver:    peer,
+		Nonce:       nonce,
+		Payload:     payload,
+	}
+	if err := e.transport.Send(&msg, fee); err != nil {
+		return Message{}, err
+	}
+	n := stygos.WordFromUint64(nonce)
+	stygos.EmitEvent(n[:], MessageSentTopic, msg.ID(), stygos.WordFromUint64(destChain))
+	return msg, nil
+}
+
+// Receive authenticates the delivery in the calldata arguments args and
+// returns its message for the contract to act on. It fails unless the
+// message comes from the peer of its source chain, is addressed to this
+// contract on this chain, and was not received before.
+func (e *Endpoint) Receive(args []byte) (Message, error) {
+	msg, err := e.auth.Authenticate(args)
+	if err != nil {
+		return Message{}, err
+	}
+	if msg.DestChain != stygos.GetChainID() || msg.Receiver != stygos.GetContractAddress() {
+		return Message{}, ErrWrongDestination
+	}
+	if peer := e.Peer(msg.SourceChain); peer == (stygos.Address{}) || msg.Sender != peer {
+		return Message{}, ErrUntrustedSender
+	}
+	id := msg.ID()
+	if e.Received(id) {
+		return Message{}, ErrReplayed
+	}
+	stygos.StorageStore(storage.MapKey(e.received, id[:]), stygos.WordFromUint64(1))
+	n := stygos.WordFromUint64(msg.Nonce)
+	stygos.EmitEvent(n[:], MessageReceivedTopic, id, stygos.WordFromUint64(msg.SourceChain))
+	return msg, nil
+}
+
+// Received reports whether the message id was received.
+func (e *Endpoint) Received(id stygos.Word) bool {
+	return !stygos.StorageLoad(storage.MapKey(e.received, id[:])).IsZero()
+}
+
+func (e *Endpoint) chainKey(base stygos.Word, chain uint64) stygos.Word {
+	c := stygos.WordFromUint64(chain)
+	return storage.MapKey(base, c[:])
+}
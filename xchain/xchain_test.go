@@ -0,0 +1,298 @@
+package xchain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/rafaelescrich/stygos"
+	"github.com/rafaelescrich/stygos/arb"
+)
+
+var (
+	bridge   = stygos.Address{0xb7}
+	appA     = stygos.Address{0xa0} // on chain 1
+	appB     = stygos.Address{0xb0} // on chain 2
+	user     = stygos.Address{0x05}
+	lastSlot = stygos.Word{0x01}
+)
+
+// app is a contract pinging its peers over the mock bridge and storing
+// the last payload received.
+var app = func() *stygos.Router {
+	e := NewEndpoint(stygos.Word{0xe0}, Outbox(bridge), Inbox(bridge))
+	r := stygos.NewRouter()
+	r.Handle("setPeer(uint64,address)", func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		var chain, peer stygos.Word
+		copy(chain[:], args)
+		copy(peer[:], args[32:])
+		e.SetPeer(stygos.Uint64FromWord(chain), stygos.AddressFromWord(peer))
+		return nil, nil
+	})
+	r.Handle("ping(uint64,bytes32)", func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		var chain stygos.Word
+		copy(chain[:], args)
+		_, err := e.Send(stygos.Uint64FromWord(chain), args[32:64], stygos.U256FromBig(stygos.GetMsgValue()))
+		return nil, err
+	})
+	r.HandleSelector(ReceiveSelector, func(ctx *stygos.Ctx, args []byte) ([]byte, error) {
+		msg, err := e.Receive(args)
+		if err != nil {
+			return nil, err
+		}
+		var w stygos.Word
+		copy(w[:], msg.Payload)
+		stygos.StorageStore(lastSlot, w)
+		return nil, nil
+	})
+	return r
+}()
+
+// call calls sig on to with value and static args.
+func call(t *testing.T, to stygos.Address, value int64, sig string, args ...stygos.Word) error {
+	t.Helper()
+	sel := stygos.SelectorOf(sig)
+	data := sel[:]
+	for _, a := range args {
+		data = append(data, a[:]...)
+	}
+	_, err := stygos.Call(to, stygos.WordFromUint64(uint64(value)), data)
+	return err
+}
+
+func newChain(b *MockBridge, chain uint64, addr stygos.Address) *stygos.MockRuntime {
+	mock := stygos.NewMockRuntime()
+	mock.Chain = chain
+	mock.Contract = user
+	mock.Deploy(addr, stygos.MockEntrypoint(app.Entrypoint))
+	b.AddChain(mock)
+	return mock
+}
+
+func TestMessageEncoding(t *testing.T) {
+	msg := Message{SourceChain: 1, Sender: appA, DestChain: 2, Receiver: appB, Nonce: 7, Payload: []byte("hello")}
+	enc := msg.Encode()
+	if len(enc) != HeaderLength+5 {
+		t.Fatalf("Encode failed. Expected %d bytes, got %d", HeaderLength+5, len(enc))
+	}
+	got, err := DecodeMessage(enc)
+	if err != nil || got.ID() != msg.ID() || !bytes.Equal(got.Payload, msg.Payload) {
+		t.Errorf("DecodeMessage failed. Expected %+v, got %+v, %v", msg, got, err)
+	}
+	if _, err := DecodeMessage(enc[:HeaderLength-1]); err != ErrBadMessage {
+		t.Errorf("DecodeMessage failed. Expected ErrBadMessage, got %v", err)
+	}
+	if _, err := decodeDelivery(Delivery(&msg)[4:40]); err != ErrBadMessage {
+		t.Errorf("decodeDelivery failed. Expected ErrBadMessage, got %v", err)
+	}
+
+	for sig, sel := range map[string]stygos.Selector{
+		"receiveMessage(bytes)":          ReceiveSelector,
+		"handle(uint32,bytes32,bytes)":   HyperlaneSelector,
+		"sendMessage(bytes)":             selSendMessage,
+		"dispatch(uint32,bytes32,bytes)": selDispatch,
+	} {
+		if got := stygos.SelectorOf(sig); got != sel {
+			t.Errorf("SelectorOf(%s) = %x, want %x", sig, got, sel)
+		}
+	}
+}
+
+func TestMockBridge(t *testing.T) {
+	b := NewMockBridge(bridge)
+	chainA := newChain(b, 1, appA)
+	chainB := newChain(b, 2, appB)
+
+	stygos.UseRuntime(chainA)
+	if err := call(t, appA, 0, "setPeer(uint64,address)", stygos.WordFromUint64(2), stygos.PadAddress(appB)); err != nil {
+		t.Fatalf("setPeer failed: %v", err)
+	}
+	stygos.UseRuntime(chainB)
+	if err := call(t, appB, 0, "setPeer(uint64,address)", stygos.WordFromUint64(1), stygos.PadAddress(appA)); err != nil {
+		t.Fatalf("setPeer failed: %v", err)
+	}
+
+	stygos.UseRuntime(chainA)
+	chainA.SetBalance(user, big.NewInt(10))
+	payload := stygos.Word{0xca, 0xfe}
+	if err := call(t, appA, 5, "ping(uint64,bytes32)", stygos.WordFromUint64(2), payload); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if err := call(t, appA, 0, "ping(uint64,bytes32)", stygos.WordFromUint64(3), payload); err == nil {
+		t.Errorf("ping failed. Expected a revert for a chain without peer")
+	}
+	if len(b.Pending) != 1 || b.Pending[0].Nonce != 0 || b.Pending[0].Receiver != appB {
+		t.Fatalf("ping failed. Expected one message to appB, got %+v", b.Pending)
+	}
+	if got := chainA.BalanceOf(bridge).Int64(); got != 5 {
+		t.Errorf("ping failed. Expected the bridge to get the fee, got %d", got)
+	}
+	sent := b.Pending[0]
+
+	if err := b.Relay(); err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if got := chainB.StorageOf(appB)[lastSlot]; got != payload {
+		t.Errorf("Relay failed. Expected the payload at appB, got %x", got)
+	}
+	logs := chainB.Logs
+	if len(logs) != 1 || !strings.Contains(string(logs[0]), hex.EncodeToString(MessageReceivedTopic[:])) {
+		t.Errorf("Relay failed. Expected a MessageReceived event, got %d logs", len(logs))
+	}
+
+	// Replays and forgeries revert
+	if err := b.Deliver(sent); err == nil {
+		t.Errorf("Deliver failed. Expected a replay to revert")
+	}
+	forged := sent
+	forged.Sender = user
+	if err := b.Deliver(forged); err == nil {
+		t.Errorf("Deliver failed. Expected a message from another sender to revert")
+	}
+	if err := b.Deliver(Message{DestChain: 9}); err == nil {
+		t.Errorf("Deliver failed. Expected an error for an unknown chain")
+	}
+
+	// Contracts cannot send messages in another contract's name
+	stygos.UseRuntime(chainA)
+	if _, err := stygos.Call(bridge, stygos.Word{}, bytesCall(selSendMessage, sent.Encode())); err == nil {
+		t.Errorf("sendMessage failed. Expected a revert for a forged sender")
+	}
+}
+
+func TestReceive(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = appB
+	mock.Chain = 2
+	stygos.UseRuntime(mock)
+	e := NewEndpoint(stygos.Word{0xe0}, Outbox(bridge), Inbox(bridge))
+	e.SetPeer(1, appA)
+	if e.Peer(1) != appA {
+		t.Fatalf("Peer failed. Expected appA, got %x", e.Peer(1))
+	}
+
+	msg := Message{SourceChain: 1, Sender: appA, DestChain: 2, Receiver: appB, Payload: []byte("hi")}
+	args := func(m Message) []byte { return Delivery(&m)[4:] }
+
+	mock.Sender = user
+	if _, err := e.Receive(args(msg)); err != ErrUnauthenticated {
+		t.Errorf("Receive failed. Expected ErrUnauthenticated, got %v", err)
+	}
+	mock.Sender = bridge
+	got, err := e.Receive(args(msg))
+	if err != nil || string(got.Payload) != "hi" || !e.Received(msg.ID()) {
+		t.Fatalf("Receive failed. Expected the message, got %+v, %v", got, err)
+	}
+	if _, err := e.Receive(args(msg)); err != ErrReplayed {
+		t.Errorf("Receive failed. Expected ErrReplayed, got %v", err)
+	}
+
+	for name, tt := range map[string]struct {
+		change func(m *Message)
+		want   error
+	}{
+		"other chain":    {func(m *Message) { m.DestChain = 3 }, ErrWrongDestination},
+		"other receiver": {func(m *Message) { m.Receiver = appA }, ErrWrongDestination},
+		"other sender":   {func(m *Message) { m.Sender = user }, ErrUntrustedSender},
+		"no peer":        {func(m *Message) { m.SourceChain = 5 }, ErrUntrustedSender},
+	} {
+		m := msg
+		m.Nonce = 1
+		tt.change(&m)
+		if _, err := e.Receive(args(m)); err != tt.want {
+			t.Errorf("Receive(%s) failed. Expected %v, got %v", name, tt.want, err)
+		}
+	}
+
+	if _, err := e.Send(4, nil, stygos.U256{}); err != ErrUnknownPeer {
+		t.Errorf("Send failed. Expected ErrUnknownPeer, got %v", err)
+	}
+}
+
+func TestHyperlane(t *testing.T) {
+	mailbox := stygos.Address{0x4b}
+	mock := stygos.NewMockRuntime()
+	mock.Contract = appA
+	mock.Chain = 1
+	stygos.UseRuntime(mock)
+	var dispatched []byte
+	mock.Deploy(mailbox, func(input []byte) ([]byte, error) {
+		dispatched = input
+		return nil, nil
+	})
+
+	e := NewEndpoint(stygos.Word{0xe0}, Hyperlane(mailbox), HyperlaneMailbox(mailbox))
+	e.SetPeer(2, appB)
+	msg, err := e.Send(2, []byte("hi"), stygos.U256{})
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if len(dispatched) < 4 || !bytes.Equal(dispatched[:4], selDispatch[:]) {
+		t.Fatalf("Send failed. Expected a dispatch call, got %x", dispatched)
+	}
+	var domain, recipient stygos.Word
+	copy(domain[:], dispatched[4:])
+	copy(recipient[:], dispatched[36:])
+	if domain != stygos.WordFromUint64(2) || recipient != stygos.PadAddress(appB) {
+		t.Errorf("Send failed. Expected domain 2 and appB, got %x, %x", domain, recipient)
+	}
+
+	// The mailbox delivers handle(origin, sender, body) on chain 2
+	mock.Contract = appB
+	mock.Chain = 2
+	mock.Sender = mailbox
+	e.SetPeer(1, appA)
+	handle := bytesCall(HyperlaneSelector, msg.Encode(), stygos.WordFromUint64(1), stygos.PadAddress(appA))
+	if got, err := e.Receive(handle[4:]); err != nil || string(got.Payload) != "hi" {
+		t.Errorf("Receive failed. Expected the message, got %+v, %v", got, err)
+	}
+	msg.Nonce = 1
+	handle = bytesCall(HyperlaneSelector, msg.Encode(), stygos.WordFromUint64(1), stygos.PadAddress(user))
+	if _, err := e.Receive(handle[4:]); err != ErrUnauthenticated {
+		t.Errorf("Receive failed. Expected ErrUnauthenticated for a mismatched sender, got %v", err)
+	}
+}
+
+func TestArbitrum(t *testing.T) {
+	mock := stygos.NewMockRuntime()
+	mock.Contract = appA
+	mock.Chain = 42161
+	stygos.UseRuntime(mock)
+	arbos := arb.InstallMock(mock)
+	msg := Message{SourceChain: 42161, Sender: appA, DestChain: 1, Receiver: appB, Payload: []byte("up")}
+
+	if err := (ArbitrumToParent{}).Send(&msg, stygos.U256{}); err != nil {
+		t.Fatalf("ArbitrumToParent failed: %v", err)
+	}
+	if len(arbos.Messages) != 1 || arbos.Messages[0].Destination != appB || !bytes.Equal(arbos.Messages[0].Data, Delivery(&msg)) {
+		t.Errorf("ArbitrumToParent failed. Expected a delivery to appB, got %+v", arbos.Messages)
+	}
+
+	inboxAddr := stygos.Address{0x1b}
+	inbox := arb.InstallMockInbox(mock, inboxAddr)
+	mock.SetBalance(appA, big.NewInt(1_000))
+	child := ArbitrumToChild{Inbox: inboxAddr, GasLimit: 100, MaxFeePerGas: stygos.NewU256(2), RefundAddress: user}
+	if err := child.Send(&msg, stygos.NewU256(199)); err != ErrFeeTooLow {
+		t.Errorf("ArbitrumToChild failed. Expected ErrFeeTooLow, got %v", err)
+	}
+	if err := child.Send(&msg, stygos.NewU256(250)); err != nil {
+		t.Fatalf("ArbitrumToChild failed: %v", err)
+	}
+	if len(inbox.Tickets) != 1 || inbox.Tickets[0].To != appB || inbox.Tickets[0].MaxSubmissionCost != stygos.NewU256(50) {
+		t.Errorf("ArbitrumToChild failed. Expected a ticket to appB, got %+v", inbox.Tickets)
+	}
+
+	// The ticket executes on the child chain with the aliased sender
+	msg.SourceChain, msg.DestChain = 1, 42161
+	auth := ArbitrumFromParent{ParentChain: 1}
+	mock.Sender = arb.ApplyL1ToL2Alias(appA)
+	if _, err := auth.Authenticate(Delivery(&msg)[4:]); err != nil {
+		t.Errorf("ArbitrumFromParent failed: %v", err)
+	}
+	mock.Sender = appA
+	if _, err := auth.Authenticate(Delivery(&msg)[4:]); err != ErrUnauthenticated {
+		t.Errorf("ArbitrumFromParent failed. Expected ErrUnauthenticated, got %v", err)
+	}
+}